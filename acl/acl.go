@@ -520,6 +520,53 @@ func (a *ACL) IsManagement() bool {
 	return a.management
 }
 
+// AllowOperation checks if an operation is allowed on a resource, where the
+// resource is named after the policy stanza granting access to it. For the
+// namespace resource the operation is a namespace capability checked against
+// the given namespace; for the other resources it is the policy level
+// required, "read" or "write" ("read" or "list" for plugins). An error is
+// returned if the resource or operation is invalid.
+func (a *ACL) AllowOperation(resource, op, ns string) (bool, error) {
+	switch resource {
+	case ResourceNamespace:
+		if op == NamespaceCapabilityDeny || !isNamespaceCapabilityValid(op) {
+			return false, fmt.Errorf("invalid namespace capability %q", op)
+		}
+		return a.AllowNamespaceOperation(ns, op), nil
+	case ResourcePlugin:
+		switch op {
+		case PolicyRead:
+			return a.AllowPluginRead(), nil
+		case PolicyList:
+			return a.AllowPluginList(), nil
+		}
+		return false, fmt.Errorf("invalid operation %q for resource %q", op, resource)
+	}
+
+	var read, write func() bool
+	switch resource {
+	case ResourceAgent:
+		read, write = a.AllowAgentRead, a.AllowAgentWrite
+	case ResourceNode:
+		read, write = a.AllowNodeRead, a.AllowNodeWrite
+	case ResourceOperator:
+		read, write = a.AllowOperatorRead, a.AllowOperatorWrite
+	case ResourceQuota:
+		read, write = a.AllowQuotaRead, a.AllowQuotaWrite
+	default:
+		return false, fmt.Errorf("invalid resource %q", resource)
+	}
+
+	switch op {
+	case PolicyRead:
+		return read(), nil
+	case PolicyWrite:
+		return write(), nil
+	default:
+		return false, fmt.Errorf("invalid operation %q for resource %q", op, resource)
+	}
+}
+
 // NamespaceValidator returns a func that wraps ACL.AllowNamespaceOperation in
 // a list of operations. Returns true (allowed) if acls are disabled or if
 // *any* capabilities match.
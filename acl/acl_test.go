@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCapabilitySet(t *testing.T) {
@@ -274,6 +275,63 @@ func TestAllowNamespace(t *testing.T) {
 	}
 }
 
+func TestAllowOperation(t *testing.T) {
+	ci.Parallel(t)
+
+	policy, err := Parse(`
+namespace "default" { policy = "read" }
+namespace "ci" { capabilities = ["submit-job"] }
+node { policy = "read" }
+operator { policy = "write" }
+plugin { policy = "list" }
+`)
+	require.NoError(t, err)
+	acl, err := NewACL(false, []*Policy{policy})
+	require.NoError(t, err)
+
+	tests := []struct {
+		Resource  string
+		Operation string
+		Namespace string
+		Allow     bool
+		Err       string
+	}{
+		{Resource: "namespace", Operation: "read-job", Namespace: "default", Allow: true},
+		{Resource: "namespace", Operation: "submit-job", Namespace: "default", Allow: false},
+		{Resource: "namespace", Operation: "submit-job", Namespace: "ci", Allow: true},
+		{Resource: "namespace", Operation: "read-job", Namespace: "other", Allow: false},
+		{Resource: "namespace", Operation: "deny", Namespace: "default", Err: "invalid namespace capability"},
+		{Resource: "namespace", Operation: "fly", Namespace: "default", Err: "invalid namespace capability"},
+		{Resource: "node", Operation: "read", Allow: true},
+		{Resource: "node", Operation: "write", Allow: false},
+		{Resource: "operator", Operation: "write", Allow: true},
+		{Resource: "agent", Operation: "read", Allow: false},
+		{Resource: "quota", Operation: "read", Allow: false},
+		{Resource: "quota", Operation: "list", Err: "invalid operation"},
+		{Resource: "plugin", Operation: "list", Allow: true},
+		{Resource: "plugin", Operation: "read", Allow: false},
+		{Resource: "plugin", Operation: "write", Err: "invalid operation"},
+		{Resource: "volume", Operation: "read", Err: "invalid resource"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Resource+"/"+tc.Operation+"/"+tc.Namespace, func(t *testing.T) {
+			allowed, err := acl.AllowOperation(tc.Resource, tc.Operation, tc.Namespace)
+			if tc.Err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.Err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.Allow, allowed)
+
+			allowed, err = ManagementACL.AllowOperation(tc.Resource, tc.Operation, tc.Namespace)
+			require.NoError(t, err)
+			require.True(t, allowed)
+		})
+	}
+}
+
 func TestWildcardNamespaceMatching(t *testing.T) {
 	ci.Parallel(t)
 
@@ -38,6 +38,9 @@ const (
 	NamespaceCapabilitySentinelOverride     = "sentinel-override"
 	NamespaceCapabilityCSIRegisterPlugin    = "csi-register-plugin"
 	NamespaceCapabilityCSIWriteVolume       = "csi-write-volume"
+	NamespaceCapabilityCSICreateVolume      = "csi-create-volume"
+	NamespaceCapabilityCSIRegisterVolume    = "csi-register-volume"
+	NamespaceCapabilityCSIDeleteVolume      = "csi-delete-volume"
 	NamespaceCapabilityCSIReadVolume        = "csi-read-volume"
 	NamespaceCapabilityCSIListVolume        = "csi-list-volume"
 	NamespaceCapabilityCSIMountVolume       = "csi-mount-volume"
@@ -52,6 +55,17 @@ var (
 	validNamespace = regexp.MustCompile("^[a-zA-Z0-9-*]{1,128}$")
 )
 
+const (
+	// The following are the resources a policy grants access to, named after
+	// their policy stanza.
+	ResourceNamespace = "namespace"
+	ResourceAgent     = "agent"
+	ResourceNode      = "node"
+	ResourceOperator  = "operator"
+	ResourceQuota     = "quota"
+	ResourcePlugin    = "plugin"
+)
+
 const (
 	// The following are the fine-grained capabilities that can be granted for a volume set.
 	// The Policy stanza is a short hand for granting several of these. When capabilities are
@@ -152,6 +166,7 @@ func isNamespaceCapabilityValid(cap string) bool {
 		NamespaceCapabilityReadFS, NamespaceCapabilityAllocLifecycle,
 		NamespaceCapabilityAllocExec, NamespaceCapabilityAllocNodeExec,
 		NamespaceCapabilityCSIReadVolume, NamespaceCapabilityCSIWriteVolume, NamespaceCapabilityCSIListVolume, NamespaceCapabilityCSIMountVolume, NamespaceCapabilityCSIRegisterPlugin,
+		NamespaceCapabilityCSICreateVolume, NamespaceCapabilityCSIRegisterVolume, NamespaceCapabilityCSIDeleteVolume,
 		NamespaceCapabilityListScalingPolicies, NamespaceCapabilityReadScalingPolicy, NamespaceCapabilityReadJobScaling, NamespaceCapabilityScaleJob:
 		return true
 	// Separate the enterprise-only capabilities
@@ -189,6 +204,9 @@ func expandNamespacePolicy(policy string) []string {
 		NamespaceCapabilityAllocLifecycle,
 		NamespaceCapabilityCSIMountVolume,
 		NamespaceCapabilityCSIWriteVolume,
+		NamespaceCapabilityCSICreateVolume,
+		NamespaceCapabilityCSIRegisterVolume,
+		NamespaceCapabilityCSIDeleteVolume,
 		NamespaceCapabilitySubmitRecommendation,
 	}...)
 
@@ -112,6 +112,9 @@ func TestParse(t *testing.T) {
 							NamespaceCapabilityAllocLifecycle,
 							NamespaceCapabilityCSIMountVolume,
 							NamespaceCapabilityCSIWriteVolume,
+							NamespaceCapabilityCSICreateVolume,
+							NamespaceCapabilityCSIRegisterVolume,
+							NamespaceCapabilityCSIDeleteVolume,
 							NamespaceCapabilitySubmitRecommendation,
 						},
 					},
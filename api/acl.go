@@ -62,6 +62,33 @@ func (a *ACLPolicies) Info(policyName string, q *QueryOptions) (*ACLPolicy, *Que
 	return &resp, wm, nil
 }
 
+// Check is used to check whether a token is allowed to perform an operation
+// without performing it. If the request has no SecretID, the token making the
+// request is checked.
+func (a *ACLPolicies) Check(req *ACLCheckRequest, q *QueryOptions) (*ACLCheckResponse, *QueryMeta, error) {
+	if req == nil || req.Resource == "" || req.Operation == "" {
+		return nil, nil, fmt.Errorf("missing resource or operation")
+	}
+
+	// The namespace of the request takes precedence over the one of the
+	// query options
+	if req.Namespace != "" {
+		qo := QueryOptions{}
+		if q != nil {
+			qo = *q
+		}
+		qo.Namespace = req.Namespace
+		q = &qo
+	}
+
+	var resp ACLCheckResponse
+	qm, err := a.client.putQuery("/v1/acl/check", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // ACLTokens is used to query the ACL token endpoints.
 type ACLTokens struct {
 	client *Client
@@ -192,6 +219,32 @@ type ACLPolicyListStub struct {
 	ModifyIndex uint64
 }
 
+// ACLCheckRequest is used to check whether a token is allowed to perform an
+// operation on a resource.
+type ACLCheckRequest struct {
+	// SecretID is the token to check. If empty, the token making the request
+	// is checked. Checking another token requires a management token.
+	SecretID string
+
+	// Resource is the resource to check, named after its policy stanza:
+	// "namespace", "agent", "node", "operator", "quota" or "plugin".
+	Resource string
+
+	// Operation is the capability to check for the namespace resource, such
+	// as "submit-job", or the policy level for the other resources, "read"
+	// or "write" ("read" or "list" for plugins).
+	Operation string
+
+	// Namespace is the namespace of namespace operations. If empty, the
+	// namespace of the query options is used.
+	Namespace string
+}
+
+// ACLCheckResponse is used to return whether an operation is allowed.
+type ACLCheckResponse struct {
+	Allowed bool
+}
+
 // ACLPolicy is used to represent an ACL policy
 type ACLPolicy struct {
 	Name        string
@@ -212,6 +265,19 @@ type ACLToken struct {
 	CreateTime  time.Time
 	CreateIndex uint64
 	ModifyIndex uint64
+
+	// ExpiringPolicies are policies granted to the token until their
+	// expiration time.
+	ExpiringPolicies []*ACLTokenExpiringPolicy
+}
+
+// ACLTokenExpiringPolicy links a policy to a token for a bounded amount of
+// time. Either ExpirationTime or ExpirationTTL must be set when creating or
+// updating a token; the servers convert ExpirationTTL into ExpirationTime.
+type ACLTokenExpiringPolicy struct {
+	Name           string
+	ExpirationTime time.Time
+	ExpirationTTL  time.Duration
 }
 
 type ACLTokenListStub struct {
@@ -223,6 +289,8 @@ type ACLTokenListStub struct {
 	CreateTime  time.Time
 	CreateIndex uint64
 	ModifyIndex uint64
+
+	ExpiringPolicies []*ACLTokenExpiringPolicy
 }
 
 type OneTimeToken struct {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/nomad/api/internal/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestACLPolicies_ListUpsert(t *testing.T) {
@@ -110,6 +111,47 @@ func TestACLPolicies_Info(t *testing.T) {
 	assert.Equal(t, policy.Name, out.Name)
 }
 
+func TestACLPolicies_Check(t *testing.T) {
+	testutil.Parallel(t)
+	c, s, _ := makeACLClient(t, nil, nil)
+	defer s.Stop()
+	ap := c.ACLPolicies()
+
+	policy := &ACLPolicy{
+		Name:  "ci",
+		Rules: `namespace "default" { capabilities = ["submit-job"] }`,
+	}
+	_, err := ap.Upsert(policy, nil)
+	require.NoError(t, err)
+
+	token, _, err := c.ACLTokens().Create(&ACLToken{
+		Type:     "client",
+		Policies: []string{policy.Name},
+	}, nil)
+	require.NoError(t, err)
+
+	out, qm, err := ap.Check(&ACLCheckRequest{
+		SecretID:  token.SecretID,
+		Resource:  "namespace",
+		Operation: "submit-job",
+		Namespace: "default",
+	}, nil)
+	require.NoError(t, err)
+	assertQueryMeta(t, qm)
+	require.True(t, out.Allowed)
+
+	out, _, err = ap.Check(&ACLCheckRequest{
+		SecretID:  token.SecretID,
+		Resource:  "node",
+		Operation: "read",
+	}, nil)
+	require.NoError(t, err)
+	require.False(t, out.Allowed)
+
+	_, _, err = ap.Check(&ACLCheckRequest{Resource: "node"}, nil)
+	require.Error(t, err)
+}
+
 func TestACLTokens_List(t *testing.T) {
 	testutil.Parallel(t)
 	c, s, _ := makeACLClient(t, nil, nil)
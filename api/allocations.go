@@ -39,13 +39,19 @@ func (c *Client) Allocations() *Allocations {
 }
 
 // List returns a list of all of the allocations.
+//
+// Allocations of all the namespaces the token can read are returned if the
+// namespace is set to "*". Paginated results are returned in the order of the
+// server so that they are consistent with the NextToken.
 func (a *Allocations) List(q *QueryOptions) ([]*AllocationListStub, *QueryMeta, error) {
 	var resp []*AllocationListStub
 	qm, err := a.client.query("/v1/allocations", &resp, q)
 	if err != nil {
 		return nil, nil, err
 	}
-	sort.Sort(AllocIndexSort(resp))
+	if !q.isPaginated() {
+		sort.Sort(AllocIndexSort(resp))
+	}
 	return resp, qm, nil
 }
 
@@ -81,6 +87,21 @@ func (a *Allocations) Exec(ctx context.Context,
 	stdin io.Reader, stdout, stderr io.Writer,
 	terminalSizeCh <-chan TerminalSize, q *QueryOptions) (exitCode int, err error) {
 
+	return a.ExecOpts(ctx, alloc, task, tty, command, stdin, stdout, stderr, terminalSizeCh, nil, q)
+}
+
+// ExecOpts is used to execute a command inside a running task, like Exec, with
+// the keepalive, idle timeout and event settings of opts. A nil opts uses the
+// defaults of Exec.
+func (a *Allocations) ExecOpts(ctx context.Context,
+	alloc *Allocation, task string, tty bool, command []string,
+	stdin io.Reader, stdout, stderr io.Writer,
+	terminalSizeCh <-chan TerminalSize, opts *ExecOptions, q *QueryOptions) (exitCode int, err error) {
+
+	if opts == nil {
+		opts = &ExecOptions{}
+	}
+
 	s := &execSession{
 		client:  a.client,
 		alloc:   alloc,
@@ -93,6 +114,7 @@ func (a *Allocations) Exec(ctx context.Context,
 		stderr: stderr,
 
 		terminalSizeCh: terminalSizeCh,
+		opts:           opts,
 		q:              q,
 	}
 
@@ -177,6 +199,7 @@ type Allocation struct {
 	RescheduleTracker     *RescheduleTracker
 	PreemptedAllocations  []string
 	PreemptedByAllocation string
+	HostVolumeSources     map[string]string
 	CreateIndex           uint64
 	ModifyIndex           uint64
 	AllocModifyIndex      uint64
@@ -300,6 +323,10 @@ type AllocDeploymentStatus struct {
 	Healthy     *bool
 	Timestamp   time.Time
 	Canary      bool
+	Staged      bool
+	Manual      bool
+	Reason      string
+	SetBy       string
 	ModifyIndex uint64
 }
 
@@ -411,6 +438,10 @@ type GenericResponse struct {
 // RescheduleTracker encapsulates previous reschedule events
 type RescheduleTracker struct {
 	Events []*RescheduleEvent
+
+	// LifetimeStart is the time the first allocation of the reschedule chain
+	// was created.
+	LifetimeStart int64
 }
 
 // RescheduleEvent is used to keep track of previous attempts at rescheduling an allocation
@@ -465,6 +496,52 @@ type ExecStreamingInput struct {
 	TTYSize *TerminalSize             `json:"tty_size,omitempty"`
 }
 
+// ExecOptions are the optional settings of an exec session.
+type ExecOptions struct {
+	// KeepaliveInterval is the interval between the websocket pings sent to
+	// keep the session alive. Defaults to 10 seconds.
+	KeepaliveInterval time.Duration
+
+	// IdleTimeout is the duration after which the session fails if nothing,
+	// including replies to keepalive pings, was received from the agent.
+	// Zero disables the timeout.
+	IdleTimeout time.Duration
+
+	// Events, if set, receives an ExecEvent for each terminal resize sent, and
+	// for the error or exit that ends the session. Events are dropped if the
+	// channel is not ready to receive, so it should be buffered.
+	Events chan<- ExecEvent
+}
+
+// ExecEventType is the type of an ExecEvent.
+type ExecEventType string
+
+const (
+	// ExecEventResize is sent when a terminal resize was sent to the task.
+	ExecEventResize ExecEventType = "resize"
+
+	// ExecEventError is sent when the session ends with an error.
+	ExecEventError ExecEventType = "error"
+
+	// ExecEventExit is sent when the command exits.
+	ExecEventExit ExecEventType = "exit"
+)
+
+// ExecEvent is an event of an exec session. Only the fields matching the
+// event's Type are set.
+type ExecEvent struct {
+	Type ExecEventType
+
+	// TTYSize is the terminal size sent, for resize events.
+	TTYSize *TerminalSize
+
+	// Error is the error that ended the session, for error events.
+	Error error
+
+	// ExitCode is the exit code of the command, for exit events.
+	ExitCode int
+}
+
 // ExecStreamingExitResults captures the exit code of just completed nomad exec command
 type ExecStreamingExitResult struct {
 	ExitCode int `json:"exit_code"`
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"strconv"
 	"sync"
 	"time"
@@ -32,10 +33,49 @@ type execSession struct {
 
 	terminalSizeCh <-chan TerminalSize
 
-	q *QueryOptions
+	opts *ExecOptions
+	q    *QueryOptions
+}
+
+// keepaliveInterval returns the interval between keepalive pings.
+func (s *execSession) keepaliveInterval() time.Duration {
+	if s.opts != nil && s.opts.KeepaliveInterval > 0 {
+		return s.opts.KeepaliveInterval
+	}
+	return heartbeatInterval
+}
+
+// idleTimeout returns the idle timeout of the session, or zero if disabled.
+func (s *execSession) idleTimeout() time.Duration {
+	if s.opts == nil {
+		return 0
+	}
+	return s.opts.IdleTimeout
+}
+
+// emit sends the event to the session's events channel, if any, without
+// blocking.
+func (s *execSession) emit(ev ExecEvent) {
+	if s.opts == nil || s.opts.Events == nil {
+		return
+	}
+	select {
+	case s.opts.Events <- ev:
+	default:
+	}
 }
 
 func (s *execSession) run(ctx context.Context) (exitCode int, err error) {
+	exitCode, err = s.runSession(ctx)
+	if err != nil {
+		s.emit(ExecEvent{Type: ExecEventError, Error: err})
+	} else {
+		s.emit(ExecEvent{Type: ExecEventExit, ExitCode: exitCode})
+	}
+	return exitCode, err
+}
+
+func (s *execSession) runSession(ctx context.Context) (exitCode int, err error) {
 	ctx, cancelFn := context.WithCancel(ctx)
 	defer cancelFn()
 
@@ -176,18 +216,21 @@ func (s *execSession) startTransmit(ctx context.Context, conn *websocket.Conn) <
 				}
 				resizeInput.TTYSize = &size
 				send(&resizeInput)
+				s.emit(ExecEvent{Type: ExecEventResize, TTYSize: &size})
 			}
 
 		}
 	}()
 
-	// send a heartbeat every 10 seconds
+	// send a heartbeat and a websocket ping every keepalive interval; the
+	// pong replies reset the idle timeout
 	go func() {
-		t := time.NewTimer(heartbeatInterval)
+		interval := s.keepaliveInterval()
+		t := time.NewTimer(interval)
 		defer t.Stop()
 
 		for {
-			t.Reset(heartbeatInterval)
+			t.Reset(interval)
 
 			select {
 			case <-ctx.Done():
@@ -195,6 +238,7 @@ func (s *execSession) startTransmit(ctx context.Context, conn *websocket.Conn) <
 			case <-t.C:
 				// heartbeat message
 				send(&execStreamingInputHeartbeat)
+				conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(interval))
 			}
 		}
 	}()
@@ -206,6 +250,20 @@ func (s *execSession) startReceiving(ctx context.Context, conn *websocket.Conn)
 	exitCodeCh := make(chan int, 1)
 	errCh := make(chan error, 1)
 
+	// extend the read deadline whenever anything is received, including
+	// pongs, so the session fails once idle for longer than the timeout
+	idleTimeout := s.idleTimeout()
+	resetDeadline := func() {
+		if idleTimeout > 0 {
+			conn.SetReadDeadline(time.Now().Add(idleTimeout))
+		}
+	}
+	resetDeadline()
+	conn.SetPongHandler(func(string) error {
+		resetDeadline()
+		return nil
+	})
+
 	go func() {
 		for ctx.Err() == nil {
 
@@ -215,10 +273,14 @@ func (s *execSession) startReceiving(ctx context.Context, conn *websocket.Conn)
 			if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
 				errCh <- fmt.Errorf("websocket closed before receiving exit code: %w", err)
 				return
+			} else if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				errCh <- fmt.Errorf("exec session idle for longer than %v", idleTimeout)
+				return
 			} else if err != nil {
 				errCh <- err
 				return
 			}
+			resetDeadline()
 
 			switch {
 			case frame.Stdout != nil:
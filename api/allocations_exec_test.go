@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/nomad/api/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// testExecConn starts a websocket server running handler and returns a client
+// connection to it.
+func testExecConn(t *testing.T, handler func(conn *websocket.Conn)) *websocket.Conn {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handler(conn)
+	}))
+	t.Cleanup(srv.Close)
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestExecSession_IdleTimeout(t *testing.T) {
+	testutil.Parallel(t)
+
+	// the server never replies, not even to pings
+	block := make(chan struct{})
+	defer close(block)
+	conn := testExecConn(t, func(conn *websocket.Conn) {
+		conn.SetPingHandler(func(string) error { return nil })
+		<-block
+	})
+
+	s := &execSession{opts: &ExecOptions{IdleTimeout: 50 * time.Millisecond}}
+	_, errCh := s.startReceiving(context.Background(), conn)
+
+	select {
+	case err := <-errCh:
+		require.EqualError(t, err, "exec session idle for longer than 50ms")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for idle timeout")
+	}
+}
+
+func TestExecSession_Keepalive(t *testing.T) {
+	testutil.Parallel(t)
+
+	// the server answers pings, but sends nothing else until the exit
+	exitCh := make(chan struct{})
+	conn := testExecConn(t, func(conn *websocket.Conn) {
+		go func() {
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+		<-exitCh
+		conn.WriteJSON(&ExecStreamingOutput{
+			Exited: true,
+			Result: &ExecStreamingExitResult{ExitCode: 3},
+		})
+	})
+
+	events := make(chan ExecEvent, 4)
+	s := &execSession{
+		stdin: strings.NewReader(""),
+		opts: &ExecOptions{
+			KeepaliveInterval: 10 * time.Millisecond,
+			IdleTimeout:       100 * time.Millisecond,
+			Events:            events,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.startTransmit(ctx, conn)
+	exitCodeCh, errCh := s.startReceiving(ctx, conn)
+
+	// the session outlives the idle timeout while pings are answered
+	time.AfterFunc(500*time.Millisecond, func() { close(exitCh) })
+
+	select {
+	case code := <-exitCodeCh:
+		require.Equal(t, 3, code)
+	case err := <-errCh:
+		t.Fatalf("unexpected error: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for exit")
+	}
+}
+
+func TestExecSession_Events(t *testing.T) {
+	testutil.Parallel(t)
+
+	events := make(chan ExecEvent, 1)
+	s := &execSession{opts: &ExecOptions{Events: events}}
+
+	s.emit(ExecEvent{Type: ExecEventExit, ExitCode: 1})
+	require.Equal(t, ExecEvent{Type: ExecEventExit, ExitCode: 1}, <-events)
+
+	// events are dropped rather than blocking the session
+	s.emit(ExecEvent{Type: ExecEventExit})
+	s.emit(ExecEvent{Type: ExecEventExit})
+	require.Len(t, events, 1)
+
+	// sessions without an events channel don't emit
+	(&execSession{}).emit(ExecEvent{Type: ExecEventExit})
+}
@@ -48,6 +48,11 @@ type QueryOptions struct {
 	// a read. This allows for lower latency and higher throughput
 	AllowStale bool
 
+	// MaxStale bounds the staleness of reads when AllowStale is set. Servers
+	// that haven't been in contact with the leader within the bound forward
+	// the read to the leader. Defaults to the server's max_stale setting.
+	MaxStale time.Duration
+
 	// WaitIndex is used to enable a blocking query. Waits
 	// until the timeout or the next index is reached
 	WaitIndex uint64
@@ -87,6 +92,14 @@ type QueryOptions struct {
 	// Currently only supported by specific endpoints.
 	Reverse bool
 
+	// FromIndex and ToIndex bound the create indexes of the listed results,
+	// with zero values leaving the window open.
+	//
+	// Currently only supported by the deployment and evaluation listings of
+	// jobs.
+	FromIndex uint64
+	ToIndex   uint64
+
 	// ctx is an optional context pass through to the underlying HTTP
 	// request layer. Use Context() and WithContext() to manage this.
 	ctx context.Context
@@ -125,6 +138,10 @@ type QueryMeta struct {
 	// server servicing the request
 	LastContact time.Duration
 
+	// Number of log entries the server servicing the request
+	// knows the leader has committed but hasn't applied yet
+	IndexLag uint64
+
 	// Is there a known leader
 	KnownLeader bool
 
@@ -587,6 +604,9 @@ func (r *request) setQueryOptions(q *QueryOptions) {
 	if q.AllowStale {
 		r.params.Set("stale", "")
 	}
+	if q.MaxStale != 0 {
+		r.header.Set("X-Nomad-Max-Stale", q.MaxStale.String())
+	}
 	if q.WaitIndex != 0 {
 		r.params.Set("index", strconv.FormatUint(q.WaitIndex, 10))
 	}
@@ -608,6 +628,12 @@ func (r *request) setQueryOptions(q *QueryOptions) {
 	if q.Reverse {
 		r.params.Set("reverse", "true")
 	}
+	if q.FromIndex != 0 {
+		r.params.Set("from_index", strconv.FormatUint(q.FromIndex, 10))
+	}
+	if q.ToIndex != 0 {
+		r.params.Set("to_index", strconv.FormatUint(q.ToIndex, 10))
+	}
 	for k, v := range q.Params {
 		r.params.Set(k, v)
 	}
@@ -1014,6 +1040,14 @@ func parseQueryMeta(resp *http.Response, q *QueryMeta) error {
 	q.LastContact = time.Duration(last) * time.Millisecond
 	q.NextToken = header.Get("X-Nomad-NextToken")
 
+	// Parse the X-Nomad-IndexLag, which older servers don't set
+	if lag := header.Get("X-Nomad-IndexLag"); lag != "" {
+		q.IndexLag, err = strconv.ParseUint(lag, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Failed to parse X-Nomad-IndexLag: %v", err)
+		}
+	}
+
 	// Parse the X-Nomad-KnownLeader
 	switch header.Get("X-Nomad-KnownLeader") {
 	case "true":
@@ -1103,6 +1137,11 @@ func (o *QueryOptions) WithContext(ctx context.Context) *QueryOptions {
 	return o2
 }
 
+// isPaginated returns true if the query requests a page of results.
+func (o *QueryOptions) isPaginated() bool {
+	return o != nil && (o.PerPage > 0 || o.NextToken != "")
+}
+
 // Context returns the context used for canceling HTTP requests related to this write
 func (o *WriteOptions) Context() context.Context {
 	if o != nil && o.ctx != nil {
@@ -177,10 +177,13 @@ func TestSetQueryOptions(t *testing.T) {
 		Region:     "foo",
 		Namespace:  "bar",
 		AllowStale: true,
+		MaxStale:   5 * time.Second,
 		WaitIndex:  1000,
 		WaitTime:   100 * time.Second,
 		AuthToken:  "foobar",
 		Reverse:    true,
+		FromIndex:  100,
+		ToIndex:    200,
 	}
 	r.setQueryOptions(q)
 
@@ -199,6 +202,11 @@ func TestSetQueryOptions(t *testing.T) {
 	try("index", "1000")
 	try("wait", "100000ms")
 	try("reverse", "true")
+	try("from_index", "100")
+	try("to_index", "200")
+
+	// Check the staleness bound is set
+	require.Equal(t, "5s", r.header.Get("X-Nomad-Max-Stale"))
 }
 
 func TestQueryOptionsContext(t *testing.T) {
@@ -311,6 +319,7 @@ func TestParseQueryMeta(t *testing.T) {
 	}
 	resp.Header.Set("X-Nomad-Index", "12345")
 	resp.Header.Set("X-Nomad-LastContact", "80")
+	resp.Header.Set("X-Nomad-IndexLag", "3")
 	resp.Header.Set("X-Nomad-KnownLeader", "true")
 
 	qm := &QueryMeta{}
@@ -324,6 +333,9 @@ func TestParseQueryMeta(t *testing.T) {
 	if qm.LastContact != 80*time.Millisecond {
 		t.Fatalf("Bad: %v", qm)
 	}
+	if qm.IndexLag != 3 {
+		t.Fatalf("Bad: %v", qm)
+	}
 	if !qm.KnownLeader {
 		t.Fatalf("Bad: %v", qm)
 	}
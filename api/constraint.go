@@ -11,6 +11,7 @@ const (
 	ConstraintSetContainsAny    = "set_contains_any"
 	ConstraintAttributeIsSet    = "is_set"
 	ConstraintAttributeIsNotSet = "is_not_set"
+	ConstraintTimeWindow        = "time_window"
 )
 
 // Constraint is used to serialize a job placement constraint.
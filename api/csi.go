@@ -146,6 +146,9 @@ func (v *CSIVolumes) ListSnapshotsOpts(req *CSISnapshotListRequest) (*CSISnapsho
 	if req.PluginID != "" {
 		qp.Set("plugin_id", req.PluginID)
 	}
+	if req.VolumeID != "" {
+		qp.Set("volume_id", req.VolumeID)
+	}
 	if req.NextToken != "" {
 		qp.Set("next_token", req.NextToken)
 	}
@@ -466,7 +469,12 @@ type CSISnapshotCreateResponse struct {
 // fields
 type CSISnapshotListRequest struct {
 	PluginID string
-	Secrets  CSISecrets
+
+	// VolumeID filters the snapshots by their source volume. It can be either
+	// the ID of a volume registered with Nomad or the storage provider's ID.
+	VolumeID string
+
+	Secrets CSISecrets
 	QueryOptions
 }
 
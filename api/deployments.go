@@ -54,9 +54,16 @@ func (d *Deployments) Allocations(deploymentID string, q *QueryOptions) ([]*Allo
 
 // Fail is used to fail the given deployment.
 func (d *Deployments) Fail(deploymentID string, q *WriteOptions) (*DeploymentUpdateResponse, *WriteMeta, error) {
+	return d.FailWithReason(deploymentID, "", q)
+}
+
+// FailWithReason is used to fail the given deployment, recording the reason
+// for failing it on the deployment.
+func (d *Deployments) FailWithReason(deploymentID, reason string, q *WriteOptions) (*DeploymentUpdateResponse, *WriteMeta, error) {
 	var resp DeploymentUpdateResponse
 	req := &DeploymentFailRequest{
 		DeploymentID: deploymentID,
+		Reason:       reason,
 	}
 	wm, err := d.client.write("/v1/deployment/fail/"+deploymentID, req, &resp, q)
 	if err != nil {
@@ -107,6 +114,25 @@ func (d *Deployments) PromoteGroups(deploymentID string, groups []string, q *Wri
 	return &resp, wm, nil
 }
 
+// PromotePercent is used to promote canaries in the passed groups, or all
+// groups if none are passed, while limiting the rollout to the given
+// percentage of each group's allocations. Promoting again with a higher
+// percentage continues the rollout.
+func (d *Deployments) PromotePercent(deploymentID string, groups []string, percent int, q *WriteOptions) (*DeploymentUpdateResponse, *WriteMeta, error) {
+	var resp DeploymentUpdateResponse
+	req := &DeploymentPromoteRequest{
+		DeploymentID: deploymentID,
+		All:          len(groups) == 0,
+		Groups:       groups,
+		Percent:      percent,
+	}
+	wm, err := d.client.write("/v1/deployment/promote/"+deploymentID, req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
 // Unblock is used to unblock the given deployment.
 func (d *Deployments) Unblock(deploymentID string, q *WriteOptions) (*DeploymentUpdateResponse, *WriteMeta, error) {
 	var resp DeploymentUpdateResponse
@@ -123,12 +149,50 @@ func (d *Deployments) Unblock(deploymentID string, q *WriteOptions) (*Deployment
 // SetAllocHealth is used to set allocation health for allocs that are part of
 // the given deployment
 func (d *Deployments) SetAllocHealth(deploymentID string, healthy, unhealthy []string, q *WriteOptions) (*DeploymentUpdateResponse, *WriteMeta, error) {
-	var resp DeploymentUpdateResponse
-	req := &DeploymentAllocHealthRequest{
-		DeploymentID:           deploymentID,
+	opts := &SetAllocHealthOptions{
 		HealthyAllocationIDs:   healthy,
 		UnhealthyAllocationIDs: unhealthy,
 	}
+	return d.SetAllocHealthOpts(deploymentID, opts, q)
+}
+
+// SetAllocHealthOptions is used to select the allocations whose health is set
+// and to record the reasons for setting it.
+type SetAllocHealthOptions struct {
+	// HealthyAllocationIDs and UnhealthyAllocationIDs are the allocations to
+	// mark healthy or unhealthy.
+	HealthyAllocationIDs   []string
+	UnhealthyAllocationIDs []string
+
+	// HealthyTaskGroups and UnhealthyTaskGroups mark all the non-terminal
+	// allocations of these task groups healthy or unhealthy.
+	HealthyTaskGroups   []string
+	UnhealthyTaskGroups []string
+
+	// Reason is recorded for the allocations without a reason in Reasons.
+	Reason string
+
+	// Reasons is the reason recorded for each allocation, keyed by
+	// allocation ID.
+	Reasons map[string]string
+}
+
+// SetAllocHealthOpts is used to set allocation health for allocs that are part
+// of the given deployment, selected by ID or by task group, recording the
+// reasons for setting it.
+func (d *Deployments) SetAllocHealthOpts(deploymentID string, opts *SetAllocHealthOptions, q *WriteOptions) (*DeploymentUpdateResponse, *WriteMeta, error) {
+	var resp DeploymentUpdateResponse
+	req := &DeploymentAllocHealthRequest{
+		DeploymentID: deploymentID,
+	}
+	if opts != nil {
+		req.HealthyAllocationIDs = opts.HealthyAllocationIDs
+		req.UnhealthyAllocationIDs = opts.UnhealthyAllocationIDs
+		req.HealthyTaskGroups = opts.HealthyTaskGroups
+		req.UnhealthyTaskGroups = opts.UnhealthyTaskGroups
+		req.Reason = opts.Reason
+		req.Reasons = opts.Reasons
+	}
 	wm, err := d.client.write("/v1/deployment/allocation-health/"+deploymentID, req, &resp, q)
 	if err != nil {
 		return nil, nil, err
@@ -177,6 +241,10 @@ type Deployment struct {
 	// status.
 	StatusDescription string
 
+	// StatusReason is the reason given by the operator who set the status of
+	// the deployment, such as when failing it.
+	StatusReason string
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -188,6 +256,7 @@ type DeploymentState struct {
 	ProgressDeadline  time.Duration
 	RequireProgressBy time.Time
 	Promoted          bool
+	PromotedPercent   int
 	DesiredCanaries   int
 	DesiredTotal      int
 	PlacedAllocs      int
@@ -234,6 +303,18 @@ type DeploymentAllocHealthRequest struct {
 	// Any unhealthy allocations fail the deployment
 	UnhealthyAllocationIDs []string
 
+	// Marks the non-terminal allocations of these task groups as healthy
+	// or unhealthy.
+	HealthyTaskGroups   []string
+	UnhealthyTaskGroups []string
+
+	// Reason is recorded for the allocations without a reason in Reasons.
+	Reason string
+
+	// Reasons is the reason recorded for each allocation, keyed by
+	// allocation ID.
+	Reasons map[string]string
+
 	WriteRequest
 }
 
@@ -247,6 +328,10 @@ type DeploymentPromoteRequest struct {
 	// Groups is used to set the promotion status per task group
 	Groups []string
 
+	// Percent is the percentage of each promoted group's allocations the
+	// deployment may roll out to. Zero promotes the groups fully.
+	Percent int
+
 	WriteRequest
 }
 
@@ -270,6 +355,10 @@ type DeploymentSpecificRequest struct {
 // DeploymentFailRequest is used to fail a particular deployment
 type DeploymentFailRequest struct {
 	DeploymentID string
+
+	// Reason is an optional explanation of why the deployment is failed.
+	Reason string
+
 	WriteRequest
 }
 
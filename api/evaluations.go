@@ -68,6 +68,7 @@ type Evaluation struct {
 	StatusDescription    string
 	Wait                 time.Duration
 	WaitUntil            time.Time
+	BlockedUntil         time.Time
 	NextEval             string
 	PreviousEval         string
 	BlockedEval          string
@@ -78,6 +79,7 @@ type Evaluation struct {
 	QuotaLimitReached    string
 	AnnotatePlan         bool
 	QueuedAllocations    map[string]int
+	Trace                *EvalTrace
 	SnapshotIndex        uint64
 	CreateIndex          uint64
 	ModifyIndex          uint64
@@ -85,6 +87,25 @@ type Evaluation struct {
 	ModifyTime           int64
 }
 
+// EvalTrace is the timeline of scheduler decisions recorded while processing
+// an evaluation when eval tracing is enabled in the scheduler configuration.
+type EvalTrace struct {
+	Events    []*EvalTraceEvent
+	Truncated bool
+}
+
+// EvalTraceEvent is a single scheduler decision recorded in an EvalTrace.
+type EvalTraceEvent struct {
+	Time      int64
+	Type      string
+	TaskGroup string
+	NodeID    string
+	NodeName  string
+	Reason    string
+	Score     float64
+	Scores    map[string]float64
+}
+
 // EvaluationStub is used to serialize parts of an evaluation returned in the
 // RelatedEvals field of an Evaluation.
 type EvaluationStub struct {
@@ -99,6 +120,7 @@ type EvaluationStub struct {
 	Status            string
 	StatusDescription string
 	WaitUntil         time.Time
+	BlockedUntil      time.Time
 	NextEval          string
 	PreviousEval      string
 	BlockedEval       string
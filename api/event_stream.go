@@ -16,6 +16,8 @@ const (
 	TopicAllocation Topic = "Allocation"
 	TopicJob        Topic = "Job"
 	TopicNode       Topic = "Node"
+	TopicCSIVolume  Topic = "CSIVolume"
+	TopicGC         Topic = "GC"
 	TopicAll        Topic = "*"
 )
 
@@ -91,12 +93,23 @@ func (e *Event) Node() (*Node, error) {
 	return out.Node, nil
 }
 
+// CSIVolume returns a CSIVolume struct from a given event payload. If the
+// Event Topic is CSIVolume this will return a valid CSIVolume.
+func (e *Event) CSIVolume() (*CSIVolume, error) {
+	out, err := e.decodePayload()
+	if err != nil {
+		return nil, err
+	}
+	return out.Volume, nil
+}
+
 type eventPayload struct {
 	Allocation *Allocation `mapstructure:"Allocation"`
 	Deployment *Deployment `mapstructure:"Deployment"`
 	Evaluation *Evaluation `mapstructure:"Evaluation"`
 	Job        *Job        `mapstructure:"Job"`
 	Node       *Node       `mapstructure:"Node"`
+	Volume     *CSIVolume  `mapstructure:"Volume"`
 }
 
 func (e *Event) decodePayload() (*eventPayload, error) {
@@ -134,9 +147,44 @@ func (c *Client) EventStream() *EventStream {
 	return &EventStream{client: c}
 }
 
+// EventFilter restricts the events of a subscription to those of the given
+// jobs, task groups and namespaces. The events are filtered by the server.
+type EventFilter struct {
+	// JobIDs are the exact IDs of the jobs to receive events for.
+	JobIDs []string
+
+	// TaskGroups are the names of the task groups to receive events for.
+	// Evaluations don't belong to a task group and never match.
+	TaskGroups []string
+
+	// Namespaces are the namespaces to receive events for, overriding the
+	// namespace of the query options. They may contain glob wildcards.
+	Namespaces []string
+}
+
 // Stream establishes a new subscription to Nomad's event stream and streams
 // results back to the returned channel.
 func (e *EventStream) Stream(ctx context.Context, topics map[Topic][]string, index uint64, q *QueryOptions) (<-chan *Events, error) {
+	return e.StreamWithFilter(ctx, topics, index, nil, q)
+}
+
+// StreamWithFilter establishes a new subscription to Nomad's event stream
+// that only receives the events matching the filter, and streams results back
+// to the returned channel.
+func (e *EventStream) StreamWithFilter(ctx context.Context, topics map[Topic][]string, index uint64, filter *EventFilter, q *QueryOptions) (<-chan *Events, error) {
+	return e.stream(ctx, topics, index, "", filter, q)
+}
+
+// StreamFromCursor establishes a new subscription to Nomad's event stream
+// that resumes after the index of the cursor of the durable consumer, and
+// streams results back to the returned channel. The consumer is responsible
+// for updating its cursor with UpsertCursor as it processes the events. The
+// filter is optional.
+func (e *EventStream) StreamFromCursor(ctx context.Context, topics map[Topic][]string, consumer string, filter *EventFilter, q *QueryOptions) (<-chan *Events, error) {
+	return e.stream(ctx, topics, 0, consumer, filter, q)
+}
+
+func (e *EventStream) stream(ctx context.Context, topics map[Topic][]string, index uint64, consumer string, filter *EventFilter, q *QueryOptions) (<-chan *Events, error) {
 	r, err := e.client.newRequest("GET", "/v1/event/stream")
 	if err != nil {
 		return nil, err
@@ -148,6 +196,10 @@ func (e *EventStream) Stream(ctx context.Context, topics map[Topic][]string, ind
 	q.Params["index"] = strconv.FormatUint(index, 10)
 	r.setQueryOptions(q)
 
+	if consumer != "" {
+		r.params.Set("consumer", consumer)
+	}
+
 	// Build topic query params
 	for topic, keys := range topics {
 		for _, k := range keys {
@@ -155,6 +207,21 @@ func (e *EventStream) Stream(ctx context.Context, topics map[Topic][]string, ind
 		}
 	}
 
+	if filter != nil {
+		for _, id := range filter.JobIDs {
+			r.params.Add("job_id", id)
+		}
+		for _, tg := range filter.TaskGroups {
+			r.params.Add("task_group", tg)
+		}
+		if len(filter.Namespaces) > 0 {
+			r.params.Del("namespace")
+			for _, ns := range filter.Namespaces {
+				r.params.Add("namespace", ns)
+			}
+		}
+	}
+
 	_, resp, err := requireOK(e.client.doRequest(r))
 
 	if err != nil {
@@ -190,3 +257,71 @@ func (e *EventStream) Stream(ctx context.Context, topics map[Topic][]string, ind
 
 	return eventsCh, nil
 }
+
+// EventCursor is the position of a durable consumer of the event stream,
+// along with how far it lags behind the events published by the server.
+type EventCursor struct {
+	// Name is the name of the consumer.
+	Name string
+
+	// Index is the index of the last event processed by the consumer.
+	Index uint64
+
+	// LatestIndex is the index of the latest events published by the server.
+	LatestIndex uint64
+
+	// Lag is the number of raft indexes between the cursor and the latest
+	// events.
+	Lag uint64
+
+	// Expired is set when events published after the cursor may no longer
+	// be in the event buffer of the server, so the stream can't be resumed
+	// from the cursor.
+	Expired bool
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Cursors is used to list the cursors of the durable consumers of the event
+// stream.
+func (e *EventStream) Cursors(q *QueryOptions) ([]*EventCursor, *QueryMeta, error) {
+	var resp []*EventCursor
+	qm, err := e.client.query("/v1/event/cursors", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// Cursor is used to read the cursor of a durable consumer of the event
+// stream.
+func (e *EventStream) Cursor(consumer string, q *QueryOptions) (*EventCursor, *QueryMeta, error) {
+	var resp EventCursor
+	qm, err := e.client.query("/v1/event/cursor/"+consumer, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// UpsertCursor is used to create or update the cursor of a durable consumer
+// of the event stream to the index of the last event it processed.
+func (e *EventStream) UpsertCursor(consumer string, index uint64, q *WriteOptions) (*WriteMeta, error) {
+	cursor := &EventCursor{Name: consumer, Index: index}
+	wm, err := e.client.write("/v1/event/cursor/"+consumer, cursor, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// DeleteCursor is used to delete the cursor of a durable consumer of the
+// event stream.
+func (e *EventStream) DeleteCursor(consumer string, q *WriteOptions) (*WriteMeta, error) {
+	wm, err := e.client.delete("/v1/event/cursor/"+consumer, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
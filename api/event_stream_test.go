@@ -49,6 +49,103 @@ func TestEvent_Stream(t *testing.T) {
 	}
 }
 
+func TestEvent_StreamWithFilter(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	// register two jobs to generate events
+	jobs := c.Jobs()
+	job := testJob()
+	_, _, err := jobs.Register(job, nil)
+	require.NoError(t, err)
+	other := testJob()
+	other.ID = stringToPtr("other")
+	other.Name = stringToPtr("other")
+	_, _, err = jobs.Register(other, nil)
+	require.NoError(t, err)
+
+	// build event stream request
+	events := c.EventStream()
+	topics := map[Topic][]string{
+		TopicJob: {"*"},
+	}
+	filter := &EventFilter{
+		JobIDs:     []string{"other"},
+		Namespaces: []string{"def*"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streamCh, err := events.StreamWithFilter(ctx, topics, 0, filter, nil)
+	require.NoError(t, err)
+
+	select {
+	case event := <-streamCh:
+		require.NoError(t, event.Err)
+		require.Len(t, event.Events, 1)
+		require.Equal(t, "other", event.Events[0].Key)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "failed waiting for event stream event")
+	}
+}
+
+func TestEvent_StreamFromCursor(t *testing.T) {
+	testutil.Parallel(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	// register a job and save the cursor after its registration
+	jobs := c.Jobs()
+	resp, _, err := jobs.Register(testJob(), nil)
+	require.NoError(t, err)
+
+	events := c.EventStream()
+	_, err = events.UpsertCursor("consumer", resp.JobModifyIndex, nil)
+	require.NoError(t, err)
+
+	cursor, _, err := events.Cursor("consumer", nil)
+	require.NoError(t, err)
+	require.Equal(t, resp.JobModifyIndex, cursor.Index)
+
+	cursors, _, err := events.Cursors(nil)
+	require.NoError(t, err)
+	require.Len(t, cursors, 1)
+
+	// register another job, which is the only one streamed
+	other := testJob()
+	other.ID = stringToPtr("other")
+	other.Name = stringToPtr("other")
+	_, _, err = jobs.Register(other, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	topics := map[Topic][]string{
+		TopicJob: {"*"},
+	}
+	streamCh, err := events.StreamFromCursor(ctx, topics, "consumer", nil, nil)
+	require.NoError(t, err)
+
+	select {
+	case event := <-streamCh:
+		require.NoError(t, event.Err)
+		require.Greater(t, event.Index, resp.JobModifyIndex)
+		require.Equal(t, "other", event.Events[0].Key)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "failed waiting for event stream event")
+	}
+
+	_, err = events.DeleteCursor("consumer", nil)
+	require.NoError(t, err)
+	_, _, err = events.Cursor("consumer", nil)
+	require.Error(t, err)
+}
+
 func TestEvent_Stream_Err_InvalidQueryParam(t *testing.T) {
 	testutil.Parallel(t)
 
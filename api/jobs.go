@@ -92,6 +92,15 @@ type RegisterOptions struct {
 	PolicyOverride bool
 	PreserveCounts bool
 	EvalPriority   int
+
+	// IdempotencyToken makes retries of the registration return the result
+	// of the original registration rather than registering the job again.
+	IdempotencyToken string
+
+	// Async enqueues the registration on the leader. The response only holds
+	// the SubmissionID, which is passed to Submission to poll for the result
+	// of the registration.
+	Async bool
 }
 
 // Register is used to register a new job. It returns the ID
@@ -110,10 +119,14 @@ func (j *Jobs) EnforceRegister(job *Job, modifyIndex uint64, q *WriteOptions) (*
 // returns the ID of the evaluation, along with any errors encountered.
 func (j *Jobs) RegisterOpts(job *Job, opts *RegisterOptions, q *WriteOptions) (*JobRegisterResponse, *WriteMeta, error) {
 	// Format the request
+	path := "/v1/jobs"
 	req := &JobRegisterRequest{
 		Job: job,
 	}
 	if opts != nil {
+		if opts.Async {
+			path += "?async=true"
+		}
 		if opts.EnforceIndex {
 			req.EnforceIndex = true
 			req.JobModifyIndex = opts.ModifyIndex
@@ -121,10 +134,47 @@ func (j *Jobs) RegisterOpts(job *Job, opts *RegisterOptions, q *WriteOptions) (*
 		req.PolicyOverride = opts.PolicyOverride
 		req.PreserveCounts = opts.PreserveCounts
 		req.EvalPriority = opts.EvalPriority
+
+		if opts.IdempotencyToken != "" {
+			var wo WriteOptions
+			if q != nil {
+				wo = *q
+			}
+			wo.IdempotencyToken = opts.IdempotencyToken
+			q = &wo
+		}
 	}
 
 	var resp JobRegisterResponse
-	wm, err := j.client.write("/v1/jobs", req, &resp, q)
+	wm, err := j.client.write(path, req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// Submission is used to read the status of an asynchronous job registration
+// by the ID of its submission.
+func (j *Jobs) Submission(submissionID string, q *QueryOptions) (*JobRegisterSubmission, *QueryMeta, error) {
+	var resp JobRegisterSubmission
+	qm, err := j.client.query("/v1/jobs/submission/"+submissionID, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// RegisterBundle is used to register a set of jobs atomically: either all the
+// jobs are registered or none are. The jobs must all be in the same namespace
+// and region. It returns the ID of the bundle and the ID of the evaluation
+// created for each job.
+func (j *Jobs) RegisterBundle(jobs []*Job, q *WriteOptions) (*JobBundleRegisterResponse, *WriteMeta, error) {
+	req := &JobBundleRegisterRequest{
+		Jobs: jobs,
+	}
+
+	var resp JobBundleRegisterResponse
+	wm, err := j.client.write("/v1/jobs/bundle", req, &resp, q)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -132,13 +182,19 @@ func (j *Jobs) RegisterOpts(job *Job, opts *RegisterOptions, q *WriteOptions) (*
 }
 
 // List is used to list all of the existing jobs.
+//
+// Jobs of all the namespaces the token can list are returned if the namespace
+// is set to "*". Paginated results are returned in the order of the server so
+// that they are consistent with the NextToken.
 func (j *Jobs) List(q *QueryOptions) ([]*JobListStub, *QueryMeta, error) {
 	var resp []*JobListStub
 	qm, err := j.client.query("/v1/jobs", &resp, q)
 	if err != nil {
 		return nil, qm, err
 	}
-	sort.Sort(JobIDSort(resp))
+	if !q.isPaginated() {
+		sort.Sort(JobIDSort(resp))
+	}
 	return resp, qm, nil
 }
 
@@ -185,6 +241,52 @@ func (j *Jobs) Scale(jobID, group string, count *int, message string, error bool
 	return &resp, qm, nil
 }
 
+// ScaleAt is used to change the count of a task group at the given time. The
+// scaling event is recorded when the change is applied.
+func (j *Jobs) ScaleAt(jobID, group string, count int, scheduleAt time.Time, message string,
+	meta map[string]interface{}, q *WriteOptions) (*JobRegisterResponse, *WriteMeta, error) {
+
+	req := &ScalingRequest{
+		Count: int64ToPtr(int64(count)),
+		Target: map[string]string{
+			"Job":   jobID,
+			"Group": group,
+		},
+		Message:    message,
+		Meta:       meta,
+		ScheduleAt: &scheduleAt,
+	}
+	var resp JobRegisterResponse
+	qm, err := j.client.write(fmt.Sprintf("/v1/job/%s/scale", url.PathEscape(jobID)), req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// ScaleResources is used to vertically scale the resources of the tasks of a
+// task group, keyed by task name.
+func (j *Jobs) ScaleResources(jobID, group string, resources map[string]*ScalingResources, message string, error bool,
+	meta map[string]interface{}, q *WriteOptions) (*JobRegisterResponse, *WriteMeta, error) {
+
+	req := &ScalingRequest{
+		Resources: resources,
+		Target: map[string]string{
+			"Job":   jobID,
+			"Group": group,
+		},
+		Error:   error,
+		Message: message,
+		Meta:    meta,
+	}
+	var resp JobRegisterResponse
+	qm, err := j.client.write(fmt.Sprintf("/v1/job/%s/scale", url.PathEscape(jobID)), req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // ScaleStatus is used to retrieve information about a particular
 // job given its unique ID.
 func (j *Jobs) ScaleStatus(jobID string, q *QueryOptions) (*JobScaleStatusResponse, *QueryMeta, error) {
@@ -207,6 +309,18 @@ func (j *Jobs) Versions(jobID string, diffs bool, q *QueryOptions) ([]*Job, []*J
 	return resp.Versions, resp.Diffs, qm, nil
 }
 
+// DiffVersions is used to retrieve the diff between two arbitrary versions of
+// a job, from the older fromVersion to toVersion.
+func (j *Jobs) DiffVersions(jobID string, fromVersion, toVersion uint64, q *QueryOptions) (*JobDiff, *QueryMeta, error) {
+	var resp JobVersionsDiffResponse
+	qm, err := j.client.query(fmt.Sprintf("/v1/job/%s/versions/diff?from=%d&to=%d",
+		url.PathEscape(jobID), fromVersion, toVersion), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Diff, qm, nil
+}
+
 // Allocations is used to return the allocs for a given job ID.
 func (j *Jobs) Allocations(jobID string, allAllocs bool, q *QueryOptions) ([]*AllocationListStub, *QueryMeta, error) {
 	var resp []*AllocationListStub
@@ -228,7 +342,9 @@ func (j *Jobs) Allocations(jobID string, allAllocs bool, q *QueryOptions) ([]*Al
 }
 
 // Deployments is used to query the deployments associated with the given job
-// ID.
+// ID, sorted from newest to oldest. Paginated results are returned in the
+// order of the server, oldest first unless reversed, so that they are
+// consistent with the NextToken.
 func (j *Jobs) Deployments(jobID string, all bool, q *QueryOptions) ([]*Deployment, *QueryMeta, error) {
 	var resp []*Deployment
 	u, err := url.Parse("/v1/job/" + url.PathEscape(jobID) + "/deployments")
@@ -243,7 +359,9 @@ func (j *Jobs) Deployments(jobID string, all bool, q *QueryOptions) ([]*Deployme
 	if err != nil {
 		return nil, nil, err
 	}
-	sort.Sort(DeploymentIndexSort(resp))
+	if !q.isPaginated() {
+		sort.Sort(DeploymentIndexSort(resp))
+	}
 	return resp, qm, nil
 }
 
@@ -259,14 +377,30 @@ func (j *Jobs) LatestDeployment(jobID string, q *QueryOptions) (*Deployment, *Qu
 }
 
 // Evaluations is used to query the evaluations associated with the given job
-// ID.
+// ID, sorted from newest to oldest. Paginated results are returned in the
+// order of the server, oldest first unless reversed, so that they are
+// consistent with the NextToken.
 func (j *Jobs) Evaluations(jobID string, q *QueryOptions) ([]*Evaluation, *QueryMeta, error) {
 	var resp []*Evaluation
 	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/evaluations", &resp, q)
 	if err != nil {
 		return nil, nil, err
 	}
-	sort.Sort(EvalIndexSort(resp))
+	if !q.isPaginated() {
+		sort.Sort(EvalIndexSort(resp))
+	}
+	return resp, qm, nil
+}
+
+// PlanResults is used to query the most recent plan apply results of a job,
+// sorted from newest to oldest. They record which nodes the scheduler's plans
+// were applied to and which nodes were rejected, and why.
+func (j *Jobs) PlanResults(jobID string, q *QueryOptions) ([]*PlanApplyResult, *QueryMeta, error) {
+	var resp []*PlanApplyResult
+	qm, err := j.client.query("/v1/job/"+url.PathEscape(jobID)+"/plan-results", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
 	return resp, qm, nil
 }
 
@@ -459,6 +593,81 @@ func (j *Jobs) Stable(jobID string, version uint64, stable bool,
 	return &resp, wm, nil
 }
 
+// Move is used to move a job to a different namespace, to rename it, or
+// both. The job is moved from the namespace of the write options. An empty
+// newNamespace or newJobID keeps the current namespace or ID of the job.
+func (j *Jobs) Move(jobID, newNamespace, newJobID string,
+	q *WriteOptions) (*JobMoveResponse, *WriteMeta, error) {
+
+	opts := &MoveOptions{
+		NewNamespace: newNamespace,
+		NewJobID:     newJobID,
+	}
+	return j.MoveOpts(jobID, opts, q)
+}
+
+// MoveOptions is used to set the destination of a job move and the tokens the
+// moved job is admitted to the new namespace with.
+type MoveOptions struct {
+	// NewNamespace and NewJobID are the namespace and ID of the moved job. An
+	// empty value keeps the current namespace or ID of the job.
+	NewNamespace string
+	NewJobID     string
+
+	// VaultToken and ConsulToken are checked against the Vault policies and
+	// the Consul usages of the job, as when it's registered.
+	VaultToken  string
+	ConsulToken string
+}
+
+// MoveOpts is used to move a job to a different namespace, to rename it, or
+// both, passing the tokens the job is admitted to the new namespace with.
+func (j *Jobs) MoveOpts(jobID string, opts *MoveOptions,
+	q *WriteOptions) (*JobMoveResponse, *WriteMeta, error) {
+
+	var resp JobMoveResponse
+	req := &JobMoveRequest{
+		JobID: jobID,
+	}
+	if opts != nil {
+		req.NewNamespace = opts.NewNamespace
+		req.NewJobID = opts.NewJobID
+		req.VaultToken = opts.VaultToken
+		req.ConsulToken = opts.ConsulToken
+	}
+	wm, err := j.client.write("/v1/job/"+url.PathEscape(jobID)+"/move", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// TagVersion is used to tag a job version. Tagged versions are not garbage
+// collected and can be reverted to by name. Tag names must be unique among the
+// versions of the job.
+func (j *Jobs) TagVersion(jobID string, version uint64, name, description string,
+	q *WriteOptions) (*WriteMeta, error) {
+
+	req := &JobVersionTagRequest{
+		JobID:      jobID,
+		JobVersion: version,
+		Tag: &JobVersionTag{
+			Name:        name,
+			Description: description,
+		},
+	}
+	return j.client.write("/v1/job/"+url.PathEscape(jobID)+"/versions/tag", req, nil, q)
+}
+
+// UntagVersion is used to remove the tag of a job version.
+func (j *Jobs) UntagVersion(jobID string, version uint64, q *WriteOptions) (*WriteMeta, error) {
+	req := &JobVersionTagRequest{
+		JobID:      jobID,
+		JobVersion: version,
+	}
+	return j.client.write("/v1/job/"+url.PathEscape(jobID)+"/versions/tag", req, nil, q)
+}
+
 // periodicForceResponse is used to deserialize a force response
 type periodicForceResponse struct {
 	EvalID string
@@ -475,6 +684,9 @@ type UpdateStrategy struct {
 	Canary           *int           `mapstructure:"canary" hcl:"canary,optional"`
 	AutoRevert       *bool          `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
 	AutoPromote      *bool          `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	SLOQuery         *string        `mapstructure:"slo_query" hcl:"slo_query,optional"`
+	SLOInterval      *time.Duration `mapstructure:"slo_interval" hcl:"slo_interval,optional"`
+	BlueGreen        *bool          `mapstructure:"blue_green" hcl:"blue_green,optional"`
 }
 
 // DefaultUpdateStrategy provides a baseline that can be used to upgrade
@@ -536,6 +748,18 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 		copy.AutoPromote = boolToPtr(*u.AutoPromote)
 	}
 
+	if u.SLOQuery != nil {
+		copy.SLOQuery = stringToPtr(*u.SLOQuery)
+	}
+
+	if u.SLOInterval != nil {
+		copy.SLOInterval = timeToPtr(*u.SLOInterval)
+	}
+
+	if u.BlueGreen != nil {
+		copy.BlueGreen = boolToPtr(*u.BlueGreen)
+	}
+
 	return copy
 }
 
@@ -579,6 +803,18 @@ func (u *UpdateStrategy) Merge(o *UpdateStrategy) {
 	if o.AutoPromote != nil {
 		u.AutoPromote = boolToPtr(*o.AutoPromote)
 	}
+
+	if o.SLOQuery != nil {
+		u.SLOQuery = stringToPtr(*o.SLOQuery)
+	}
+
+	if o.SLOInterval != nil {
+		u.SLOInterval = timeToPtr(*o.SLOInterval)
+	}
+
+	if o.BlueGreen != nil {
+		u.BlueGreen = boolToPtr(*o.BlueGreen)
+	}
 }
 
 func (u *UpdateStrategy) Canonicalize() {
@@ -663,6 +899,14 @@ func (u *UpdateStrategy) Empty() bool {
 		return false
 	}
 
+	if u.SLOQuery != nil && *u.SLOQuery != "" {
+		return false
+	}
+
+	if u.BlueGreen != nil && *u.BlueGreen {
+		return false
+	}
+
 	return true
 }
 
@@ -821,6 +1065,7 @@ type Job struct {
 	Priority         *int                    `hcl:"priority,optional"`
 	AllAtOnce        *bool                   `mapstructure:"all_at_once" hcl:"all_at_once,optional"`
 	Datacenters      []string                `hcl:"datacenters,optional"`
+	DependsOn        []string                `mapstructure:"depends_on" hcl:"depends_on,optional"`
 	Constraints      []*Constraint           `hcl:"constraint,block"`
 	Affinities       []*Affinity             `hcl:"affinity,block"`
 	TaskGroups       []*TaskGroup            `hcl:"group,block"`
@@ -835,12 +1080,18 @@ type Job struct {
 	ConsulToken      *string                 `mapstructure:"consul_token" hcl:"consul_token,optional"`
 	VaultToken       *string                 `mapstructure:"vault_token" hcl:"vault_token,optional"`
 
+	// VaultClusterTokens are the Vault tokens of the named Vault clusters the
+	// tasks request policies from, indexed by the name of the cluster. They
+	// can't be set in job files.
+	VaultClusterTokens map[string]string
+
 	/* Fields set by server, not sourced from job config file */
 
 	Stop                     *bool
 	ParentID                 *string
 	Dispatched               bool
 	DispatchIdempotencyToken *string
+	BundleID                 *string
 	Payload                  []byte
 	ConsulNamespace          *string `mapstructure:"consul_namespace"`
 	VaultNamespace           *string `mapstructure:"vault_namespace"`
@@ -848,6 +1099,7 @@ type Job struct {
 	Status                   *string
 	StatusDescription        *string
 	Stable                   *bool
+	VersionTag               *JobVersionTag
 	Version                  *uint64
 	SubmitTime               *int64
 	CreateIndex              *uint64
@@ -1205,6 +1457,30 @@ type JobRegisterRequest struct {
 	WriteRequest
 }
 
+// JobBundleRegisterRequest is used to register a set of jobs atomically.
+type JobBundleRegisterRequest struct {
+	BundleID       string `json:",omitempty"`
+	Jobs           []*Job
+	PolicyOverride bool `json:",omitempty"`
+
+	WriteRequest
+}
+
+// JobBundleRegisterResponse is used to respond to a bundle registration.
+type JobBundleRegisterResponse struct {
+	BundleID       string
+	JobModifyIndex uint64
+
+	// JobEvals maps the ID of each job of the bundle to its created
+	// evaluation
+	JobEvals map[string]string
+
+	// Warnings contains any warnings about the given jobs.
+	Warnings string
+
+	QueryMeta
+}
+
 // JobRegisterResponse is used to respond to a job registration
 type JobRegisterResponse struct {
 	EvalID          string
@@ -1215,9 +1491,42 @@ type JobRegisterResponse struct {
 	// deprecation warnings.
 	Warnings string
 
+	// SubmissionID is the ID of the submission of an asynchronous
+	// registration.
+	SubmissionID string
+
 	QueryMeta
 }
 
+const (
+	JobRegisterSubmissionStatusPending  = "pending"
+	JobRegisterSubmissionStatusRunning  = "running"
+	JobRegisterSubmissionStatusComplete = "complete"
+	JobRegisterSubmissionStatusFailed   = "failed"
+)
+
+// JobRegisterSubmission is the status of an asynchronous job registration.
+type JobRegisterSubmission struct {
+	ID        string
+	Namespace string
+	JobID     string
+
+	// Status is the status of the registration, one of pending, running,
+	// complete or failed.
+	Status string
+
+	// Error is the error the registration failed with.
+	Error string
+
+	// The result of a complete registration.
+	EvalID         string
+	JobModifyIndex uint64
+	Warnings       string
+
+	SubmitTime int64
+	ModifyTime int64
+}
+
 // JobDeregisterResponse is used to respond to a job deregistration
 type JobDeregisterResponse struct {
 	EvalID          string
@@ -1286,8 +1595,21 @@ type ObjectDiff struct {
 }
 
 type PlanAnnotations struct {
-	DesiredTGUpdates map[string]*DesiredUpdates
-	PreemptedAllocs  []*AllocationListStub
+	DesiredTGUpdates     map[string]*DesiredUpdates
+	PreemptedAllocs      []*AllocationListStub
+	PreemptingTaskGroups map[string]string
+	PlacementScores      []*PlacementScore
+}
+
+// PlacementScore is the score of the node selected for a placement, broken
+// down by the built-in scorers and scoring plugins that ranked it.
+type PlacementScore struct {
+	AllocName  string
+	TaskGroup  string
+	NodeID     string
+	NodeName   string
+	FinalScore float64
+	Scores     map[string]float64
 }
 
 type DesiredUpdates struct {
@@ -1299,6 +1621,7 @@ type DesiredUpdates struct {
 	DestructiveUpdate uint64
 	Canary            uint64
 	Preemptions       uint64
+	Displaced         uint64
 }
 
 type JobDispatchRequest struct {
@@ -1322,6 +1645,29 @@ type JobVersionsResponse struct {
 	QueryMeta
 }
 
+// JobVersionsDiffResponse is used for a job versions diff request
+type JobVersionsDiffResponse struct {
+	Diff *JobDiff
+	QueryMeta
+}
+
+// PlanApplyResult records which nodes the plan applier accepted and rejected
+// when applying the plan of an evaluation.
+type PlanApplyResult struct {
+	EvalID        string
+	AcceptedNodes []string
+	RejectedNodes []*PlanRejectedNode
+	RefreshIndex  uint64
+	CreateTime    int64
+	CreateIndex   uint64
+}
+
+// PlanRejectedNode is a node rejected by the plan applier.
+type PlanRejectedNode struct {
+	NodeID string
+	Reason string
+}
+
 // JobStabilityRequest is used to marked a job as stable.
 type JobStabilityRequest struct {
 	// Job to set the stability on
@@ -1339,6 +1685,41 @@ type JobStabilityResponse struct {
 	WriteMeta
 }
 
+// JobMoveRequest is used to move a job to a different namespace or to rename
+// it.
+type JobMoveRequest struct {
+	JobID        string
+	NewNamespace string
+	NewJobID     string
+	VaultToken   string `json:",omitempty"`
+	ConsulToken  string `json:",omitempty"`
+	WriteRequest
+}
+
+// JobMoveResponse is the response when moving a job.
+type JobMoveResponse struct {
+	EvalID          string
+	EvalCreateIndex uint64
+	JobModifyIndex  uint64
+	WriteMeta
+}
+
+// JobVersionTag is a name given to a version of a job.
+type JobVersionTag struct {
+	Name        string
+	Description string
+	TaggedTime  int64
+}
+
+// JobVersionTagRequest is used to tag a job version, or to remove the tag of
+// a job version when Tag is nil.
+type JobVersionTagRequest struct {
+	JobID      string
+	JobVersion uint64
+	Tag        *JobVersionTag
+	WriteRequest
+}
+
 // JobEvaluateRequest is used when we just need to re-evaluate a target job
 type JobEvaluateRequest struct {
 	JobID       string
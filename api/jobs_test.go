@@ -1,6 +1,7 @@
 package api
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 	"strings"
@@ -342,6 +343,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Delay:         timeToPtr(30 * time.Second),
 							MaxDelay:      timeToPtr(1 * time.Hour),
 							Unlimited:     boolToPtr(true),
+							Jitter:        float64ToPtr(0),
+							MaxLifetime:   timeToPtr(0),
 						},
 						Consul: &Consul{
 							Namespace: "",
@@ -426,6 +429,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Delay:         timeToPtr(5 * time.Second),
 							MaxDelay:      timeToPtr(0),
 							Unlimited:     boolToPtr(false),
+							Jitter:        float64ToPtr(0),
+							MaxLifetime:   timeToPtr(0),
 						},
 						Consul: &Consul{
 							Namespace: "",
@@ -515,6 +520,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Delay:         timeToPtr(30 * time.Second),
 							MaxDelay:      timeToPtr(1 * time.Hour),
 							Unlimited:     boolToPtr(true),
+							Jitter:        float64ToPtr(0),
+							MaxLifetime:   timeToPtr(0),
 						},
 						Consul: &Consul{
 							Namespace: "",
@@ -682,6 +689,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Delay:         timeToPtr(30 * time.Second),
 							MaxDelay:      timeToPtr(1 * time.Hour),
 							Unlimited:     boolToPtr(true),
+							Jitter:        float64ToPtr(0),
+							MaxLifetime:   timeToPtr(0),
 						},
 						EphemeralDisk: &EphemeralDisk{
 							Sticky:  boolToPtr(false),
@@ -768,6 +777,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 										RightDelim:   stringToPtr("}}"),
 										Envvars:      boolToPtr(false),
 										VaultGrace:   timeToPtr(0),
+										VaultCluster: stringToPtr(""),
 									},
 									{
 										SourcePath:   stringToPtr(""),
@@ -781,6 +791,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 										RightDelim:   stringToPtr("}}"),
 										Envvars:      boolToPtr(true),
 										VaultGrace:   timeToPtr(0),
+										VaultCluster: stringToPtr(""),
 									},
 								},
 							},
@@ -942,6 +953,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Delay:         timeToPtr(30 * time.Second),
 							MaxDelay:      timeToPtr(1 * time.Hour),
 							Unlimited:     boolToPtr(true),
+							Jitter:        float64ToPtr(0),
+							MaxLifetime:   timeToPtr(0),
 						},
 						Consul: &Consul{
 							Namespace: "",
@@ -989,6 +1002,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Delay:         timeToPtr(30 * time.Second),
 							MaxDelay:      timeToPtr(1 * time.Hour),
 							Unlimited:     boolToPtr(true),
+							Jitter:        float64ToPtr(0),
+							MaxLifetime:   timeToPtr(0),
 						},
 						Consul: &Consul{
 							Namespace: "",
@@ -1118,6 +1133,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Delay:         timeToPtr(30 * time.Second),
 							MaxDelay:      timeToPtr(1 * time.Hour),
 							Unlimited:     boolToPtr(true),
+							Jitter:        float64ToPtr(0),
+							MaxLifetime:   timeToPtr(0),
 						},
 						Consul: &Consul{
 							Namespace: "",
@@ -1170,6 +1187,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Delay:         timeToPtr(30 * time.Second),
 							MaxDelay:      timeToPtr(1 * time.Hour),
 							Unlimited:     boolToPtr(true),
+							Jitter:        float64ToPtr(0),
+							MaxLifetime:   timeToPtr(0),
 						},
 						Consul: &Consul{
 							Namespace: "",
@@ -1486,6 +1505,147 @@ func TestJobs_Versions(t *testing.T) {
 	}
 }
 
+func TestJobs_DiffVersions(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	jobs := c.Jobs()
+
+	// Register three versions of the job
+	job := testJob()
+	for _, priority := range []int{50, 60, 70} {
+		job.Priority = intToPtr(priority)
+		_, wm, err := jobs.Register(job, nil)
+		require.NoError(t, err)
+		assertWriteMeta(t, wm)
+	}
+
+	// Diff the first and last versions
+	diff, qm, err := jobs.DiffVersions(*job.ID, 0, 2, nil)
+	require.NoError(t, err)
+	assertQueryMeta(t, qm)
+	require.Equal(t, "Edited", diff.Type)
+	require.Contains(t, diff.Fields, &FieldDiff{
+		Type: "Edited",
+		Name: "Priority",
+		Old:  "50",
+		New:  "70",
+	})
+
+	// Diffing unknown versions returns an error
+	_, _, err = jobs.DiffVersions(*job.ID, 0, 5, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not found")
+}
+
+func TestJobs_TagVersion(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	jobs := c.Jobs()
+
+	job := testJob()
+	_, _, err := jobs.Register(job, nil)
+	require.NoError(t, err)
+
+	// Tag the version
+	wm, err := jobs.TagVersion(*job.ID, 0, "golden", "known good", nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, wm)
+
+	out, _, err := jobs.Info(*job.ID, nil)
+	require.NoError(t, err)
+	require.NotNil(t, out.VersionTag)
+	require.Equal(t, "golden", out.VersionTag.Name)
+	require.Equal(t, "known good", out.VersionTag.Description)
+
+	// Remove the tag
+	wm, err = jobs.UntagVersion(*job.ID, 0, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, wm)
+
+	out, _, err = jobs.Info(*job.ID, nil)
+	require.NoError(t, err)
+	require.Nil(t, out.VersionTag)
+}
+
+func TestJobs_Move(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	jobs := c.Jobs()
+
+	job := testJob()
+	resp, _, err := jobs.Register(job, nil)
+	require.NoError(t, err)
+
+	// Wait for the registration evaluation to be processed, since jobs with
+	// pending evaluations can't be moved
+	testutil.WaitForResult(func() (bool, error) {
+		eval, _, err := c.Evaluations().Info(resp.EvalID, nil)
+		if err != nil {
+			return false, err
+		}
+		if eval.Status == "pending" {
+			return false, fmt.Errorf("evaluation %q is pending", eval.ID)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %s", err)
+	})
+
+	// Rename the job
+	moveResp, wm, err := jobs.Move(*job.ID, "", "renamed", nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, wm)
+	require.NotEmpty(t, moveResp.EvalID)
+
+	out, _, err := jobs.Info("renamed", nil)
+	require.NoError(t, err)
+	require.Equal(t, "renamed", *out.ID)
+
+	_, _, err = jobs.Info(*job.ID, nil)
+	require.Error(t, err)
+}
+
+func TestJobs_RegisterBundle(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	jobs := c.Jobs()
+
+	job1 := testJob()
+	job2 := testJob()
+	job2.ID = stringToPtr("job2")
+	job2.Name = stringToPtr("job2")
+
+	resp, wm, err := jobs.RegisterBundle([]*Job{job1, job2}, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, wm)
+	require.NotEmpty(t, resp.BundleID)
+	require.Len(t, resp.JobEvals, 2)
+
+	for _, id := range []string{*job1.ID, *job2.ID} {
+		out, _, err := jobs.Info(id, nil)
+		require.NoError(t, err)
+		require.Equal(t, resp.BundleID, *out.BundleID)
+		require.Equal(t, resp.JobModifyIndex, *out.JobModifyIndex)
+	}
+
+	// An invalid job fails the whole bundle
+	job3 := testJob()
+	job3.ID = stringToPtr("job3")
+	job3.Name = stringToPtr("job3")
+	invalid := testJob()
+	invalid.ID = stringToPtr("invalid")
+	invalid.TaskGroups = nil
+	_, _, err = jobs.RegisterBundle([]*Job{job3, invalid}, nil)
+	require.Error(t, err)
+
+	_, _, err = jobs.Info(*job3.ID, nil)
+	require.Error(t, err)
+}
+
 func TestJobs_PrefixList(t *testing.T) {
 	testutil.Parallel(t)
 	c, s := makeClient(t, nil, nil)
@@ -1558,6 +1718,56 @@ func TestJobs_List(t *testing.T) {
 	}
 }
 
+func TestJobs_List_AllNamespaces(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	jobs := c.Jobs()
+
+	ns := testNamespace()
+	_, err := c.Namespaces().Register(ns, nil)
+	require.NoError(t, err)
+
+	// Register a job in each namespace
+	for i, namespace := range []string{"default", ns.Name} {
+		job := testJob()
+		job.ID = stringToPtr(fmt.Sprintf("job%d", i))
+		job.Namespace = stringToPtr(namespace)
+		_, _, err := jobs.Register(job, nil)
+		require.NoError(t, err)
+	}
+
+	// The wildcard lists the jobs of all namespaces
+	results, _, err := jobs.List(&QueryOptions{Namespace: "*"})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	// Filters apply across namespaces
+	results, _, err = jobs.List(&QueryOptions{
+		Namespace: "*",
+		Filter:    fmt.Sprintf(`Namespace == %q`, ns.Name),
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "job1", results[0].ID)
+	require.Equal(t, ns.Name, results[0].Namespace)
+
+	// Pages can be iterated across namespaces
+	q := &QueryOptions{Namespace: "*", PerPage: 1}
+	var ids []string
+	for {
+		results, qm, err := jobs.List(q)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		ids = append(ids, results[0].ID)
+		if qm.NextToken == "" {
+			break
+		}
+		q.NextToken = qm.NextToken
+	}
+	require.ElementsMatch(t, []string{"job0", "job1"}, ids)
+}
+
 func TestJobs_Allocations(t *testing.T) {
 	testutil.Parallel(t)
 	c, s := makeClient(t, nil, nil)
@@ -37,6 +37,16 @@ func (n *Namespaces) PrefixList(prefix string, q *QueryOptions) ([]*Namespace, *
 	return n.List(q)
 }
 
+// Usage is used to query the resources allocated to each namespace.
+func (n *Namespaces) Usage(q *QueryOptions) (*NamespaceUsageResponse, *QueryMeta, error) {
+	var resp NamespaceUsageResponse
+	qm, err := n.client.query("/v1/namespaces/usage", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // Info is used to query a single namespace by its name.
 func (n *Namespaces) Info(name string, q *QueryOptions) (*Namespace, *QueryMeta, error) {
 	var resp Namespace
@@ -71,6 +81,7 @@ type Namespace struct {
 	Description  string
 	Quota        string
 	Capabilities *NamespaceCapabilities `hcl:"capabilities,block"`
+	JobLimits    *NamespaceJobLimits    `hcl:"job_limits,block"`
 	Meta         map[string]string
 	CreateIndex  uint64
 	ModifyIndex  uint64
@@ -81,6 +92,15 @@ type NamespaceCapabilities struct {
 	DisabledTaskDrivers []string `hcl:"disabled_task_drivers"`
 }
 
+// NamespaceJobLimits bounds the size of the jobs registered in a namespace. A
+// limit of zero is unlimited.
+type NamespaceJobLimits struct {
+	MaxJobSizeBytes  int `hcl:"max_job_size_bytes"`
+	MaxGroups        int `hcl:"max_groups"`
+	MaxTasksPerGroup int `hcl:"max_tasks_per_group"`
+	MaxGroupCount    int `hcl:"max_group_count"`
+}
+
 // NamespaceIndexSort is a wrapper to sort Namespaces by CreateIndex. We
 // reverse the test so that we get the highest index first.
 type NamespaceIndexSort []*Namespace
@@ -96,3 +116,26 @@ func (n NamespaceIndexSort) Less(i, j int) bool {
 func (n NamespaceIndexSort) Swap(i, j int) {
 	n[i], n[j] = n[j], n[i]
 }
+
+// NamespaceUsageResponse is used to serialize the resources allocated to each
+// namespace.
+type NamespaceUsageResponse struct {
+	Namespaces []*NamespaceUsage
+
+	// ClusterCPU and ClusterMemoryMB are the resources of the ready nodes in
+	// the cluster that can be allocated. They are only set if the token used
+	// is allowed to read nodes.
+	ClusterCPU      int64
+	ClusterMemoryMB int64
+
+	QueryMeta
+}
+
+// NamespaceUsage is the resources allocated to the non-terminal allocations of
+// a namespace.
+type NamespaceUsage struct {
+	Namespace   string
+	Allocations int
+	CPU         int64
+	MemoryMB    int64
+}
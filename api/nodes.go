@@ -474,6 +474,75 @@ func (n *Nodes) GC(nodeID string, q *QueryOptions) error {
 	return err
 }
 
+// Meta returns the static and dynamic metadata of a node.
+func (n *Nodes) Meta(nodeID string, q *QueryOptions) (*NodeMetaResponse, error) {
+	var resp NodeMetaResponse
+	path := fmt.Sprintf("/v1/client/metadata?node_id=%s", nodeID)
+	if _, err := n.client.query(path, &resp, q); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// MetaWatch sends the metadata of a node on the returned channel, first its
+// current metadata and then each time it changes. Set the WaitIndex of the
+// query options to skip the metadata that was already seen. The watch stops
+// when the context is canceled, or after sending an error on the error
+// channel. Both channels are closed when the watch stops.
+func (n *Nodes) MetaWatch(ctx context.Context, nodeID string, q *QueryOptions) (<-chan *NodeMetaResponse, <-chan error) {
+	metaCh := make(chan *NodeMetaResponse, 1)
+	errCh := make(chan error, 1)
+
+	var opts QueryOptions
+	if q != nil {
+		opts = *q
+	}
+
+	go func() {
+		defer close(metaCh)
+		defer close(errCh)
+
+		for {
+			resp, err := n.Meta(nodeID, opts.WithContext(ctx))
+			if err != nil {
+				if ctx.Err() == nil {
+					errCh <- err
+				}
+				return
+			}
+
+			// The request timed out without a change. An index lower than
+			// the wait index means the client restarted.
+			if resp.Index == opts.WaitIndex {
+				continue
+			}
+			opts.WaitIndex = resp.Index
+
+			select {
+			case metaCh <- resp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return metaCh, errCh
+}
+
+// ApplyMeta sets or unsets dynamic metadata on a node. A nil value unsets the
+// key. The resulting metadata of the node is returned.
+func (n *Nodes) ApplyMeta(nodeID string, meta map[string]*string, q *QueryOptions) (*NodeMetaResponse, error) {
+	req := &NodeMetaApplyRequest{
+		NodeID: nodeID,
+		Meta:   meta,
+	}
+	var resp NodeMetaResponse
+	if _, err := n.client.putQuery("/v1/client/metadata", req, &resp, q); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // TODO Add tests
 func (n *Nodes) GcAlloc(allocID string, q *QueryOptions) error {
 	path := fmt.Sprintf("/v1/client/allocation/%s/gc", allocID)
@@ -493,6 +562,30 @@ func (n *Nodes) Purge(nodeID string, q *QueryOptions) (*NodePurgeResponse, *Quer
 	return &resp, qm, nil
 }
 
+// NodeMetaApplyRequest is used to set or unset dynamic node metadata.
+type NodeMetaApplyRequest struct {
+	NodeID string
+	Meta   map[string]*string
+}
+
+// NodeMetaResponse is used to deserialize the metadata of a node.
+type NodeMetaResponse struct {
+	// Meta is the merged metadata used for scheduling.
+	Meta map[string]string
+
+	// Dynamic is the metadata set at runtime. Nil values unset keys from
+	// the static metadata.
+	Dynamic map[string]*string
+
+	// Static is the metadata set in the client configuration.
+	Static map[string]string
+
+	// Index is incremented each time the metadata of the node changes and
+	// can be used as the WaitIndex of a blocking query. It is reset when the
+	// client restarts.
+	Index uint64
+}
+
 // NodePurgeResponse is used to deserialize a Purge response.
 type NodePurgeResponse struct {
 	EvalIDs         []string
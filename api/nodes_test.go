@@ -660,6 +660,67 @@ func TestNodes_Purge(t *testing.T) {
 	require.Greater(meta.LastIndex, uint64(0))
 }
 
+func TestNodes_MetaWatch(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, func(c *testutil.TestServerConfig) {
+		c.DevMode = true
+	})
+	defer s.Stop()
+	nodes := c.Nodes()
+
+	// Get the node ID
+	var nodeID string
+	testutil.WaitForResult(func() (bool, error) {
+		out, _, err := nodes.List(nil)
+		if err != nil {
+			return false, err
+		}
+		if n := len(out); n != 1 {
+			return false, fmt.Errorf("expected 1 node, got: %d", n)
+		}
+		nodeID = out[0].ID
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %s", err)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	metaCh, errCh := nodes.MetaWatch(ctx, nodeID, &QueryOptions{WaitTime: 5 * time.Second})
+
+	// The current metadata is sent first
+	var initial *NodeMetaResponse
+	select {
+	case initial = <-metaCh:
+	case err := <-errCh:
+		t.Fatalf("err: %v", err)
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for metadata")
+	}
+	require.NotContains(t, initial.Meta, "zone")
+
+	// Changes are sent as they happen
+	zone := "z1"
+	_, err := nodes.ApplyMeta(nodeID, map[string]*string{"zone": &zone}, nil)
+	require.NoError(t, err)
+
+	select {
+	case resp := <-metaCh:
+		require.Equal(t, "z1", resp.Meta["zone"])
+		require.Greater(t, resp.Index, initial.Index)
+	case err := <-errCh:
+		t.Fatalf("err: %v", err)
+	case <-ctx.Done():
+		t.Fatalf("timed out waiting for metadata change")
+	}
+
+	// Canceling the context closes the channels
+	cancel()
+	_, ok := <-metaCh
+	require.False(t, ok)
+}
+
 func TestNodeStatValueFormatting(t *testing.T) {
 	testutil.Parallel(t)
 
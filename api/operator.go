@@ -134,6 +134,10 @@ type SchedulerConfiguration struct {
 	// management ACL token
 	RejectJobRegistration bool
 
+	// EvalTracingEnabled makes the scheduler record a timeline of its
+	// decisions on each evaluation it processes
+	EvalTracingEnabled bool
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -147,6 +151,87 @@ type SchedulerConfigurationResponse struct {
 	QueryMeta
 }
 
+// SchedulerQueuesResponse is the response object that wraps the queue depth of
+// each namespace in the eval broker
+type SchedulerQueuesResponse struct {
+	// Namespaces is the queue depth of each namespace with outstanding
+	// evaluations
+	Namespaces map[string]*SchedulerNamespaceQueue
+
+	QueryMeta
+}
+
+// SchedulerNamespaceQueue is the queue depth of a namespace in the eval broker
+type SchedulerNamespaceQueue struct {
+	// Ready is the number of evaluations waiting to be dequeued
+	Ready int
+
+	// Unacked is the number of evaluations being processed by schedulers
+	Unacked int
+}
+
+// SchedulerFragmentationResponse is the response object that wraps the
+// fragmentation report of the free capacity of the nodes in each datacenter
+type SchedulerFragmentationResponse struct {
+	// Shapes are the most common shapes of the running service and batch
+	// allocations, which the free capacity of the nodes is measured against
+	Shapes []*SchedulerAllocShape
+
+	// Datacenters is the fragmentation report of each datacenter with nodes
+	// eligible for scheduling
+	Datacenters map[string]*SchedulerDatacenterFragmentation
+
+	QueryMeta
+}
+
+// SchedulerAllocShape is the resources requested by an allocation
+type SchedulerAllocShape struct {
+	CPU      int64
+	MemoryMB int64
+
+	// Count is the number of running allocations with this shape
+	Count int
+}
+
+// SchedulerDatacenterFragmentation is the fragmentation report of the nodes
+// of a datacenter
+type SchedulerDatacenterFragmentation struct {
+	// Nodes is the number of nodes eligible for scheduling
+	Nodes int
+
+	// FreeCPU and FreeMemoryMB are the unallocated resources of the nodes
+	FreeCPU      int64
+	FreeMemoryMB int64
+
+	// StrandedCPU and StrandedMemoryMB are the unallocated resources of the
+	// nodes that can't fit an allocation of any of the common shapes
+	StrandedCPU      int64
+	StrandedMemoryMB int64
+
+	// Placements is the number of additional allocations of each shape, in
+	// the order of the response Shapes, that fit in the datacenter
+	Placements []int
+
+	// Migrations are the candidate migrations that would free up whole
+	// nodes. They are only returned when requested.
+	Migrations []*SchedulerMigration
+}
+
+// SchedulerMigration is a set of allocations that could be moved off a node
+// to free it up entirely
+type SchedulerMigration struct {
+	NodeID string
+	Allocs []*SchedulerMigrationAlloc
+}
+
+// SchedulerMigrationAlloc is an allocation to move to another node
+type SchedulerMigrationAlloc struct {
+	ID           string
+	Namespace    string
+	JobID        string
+	TargetNodeID string
+}
+
 // SchedulerSetConfigurationResponse is the response object used
 // when updating scheduler configuration
 type SchedulerSetConfigurationResponse struct {
@@ -185,6 +270,33 @@ func (op *Operator) SchedulerGetConfiguration(q *QueryOptions) (*SchedulerConfig
 	return &resp, qm, nil
 }
 
+// SchedulerGetQueues is used to query the queue depth of each namespace in the
+// eval broker.
+func (op *Operator) SchedulerGetQueues(q *QueryOptions) (*SchedulerQueuesResponse, *QueryMeta, error) {
+	var resp SchedulerQueuesResponse
+	qm, err := op.c.query("/v1/operator/scheduler/queues", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// SchedulerGetFragmentation is used to query the fragmentation report of the
+// free capacity of the nodes in each datacenter. If suggest is set, candidate
+// migrations that would free up whole nodes are included.
+func (op *Operator) SchedulerGetFragmentation(suggest bool, q *QueryOptions) (*SchedulerFragmentationResponse, *QueryMeta, error) {
+	var resp SchedulerFragmentationResponse
+	path := "/v1/operator/scheduler/fragmentation"
+	if suggest {
+		path += "?suggest=true"
+	}
+	qm, err := op.c.query(path, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // SchedulerSetConfiguration is used to set the current Scheduler configuration.
 func (op *Operator) SchedulerSetConfiguration(conf *SchedulerConfiguration, q *WriteOptions) (*SchedulerSetConfigurationResponse, *WriteMeta, error) {
 	var out SchedulerSetConfigurationResponse
@@ -208,6 +320,59 @@ func (op *Operator) SchedulerCASConfiguration(conf *SchedulerConfiguration, q *W
 	return &out, wm, nil
 }
 
+// WorkloadIdentityKey is a key generated by the servers to sign workload
+// identities, without its private key material.
+type WorkloadIdentityKey struct {
+	// KeyID is the ID of the key in the key set published by the servers.
+	KeyID string
+
+	// Algorithm is the JSON Web Signature algorithm of the key.
+	Algorithm string
+
+	// State is "next" for the key published ahead of signing identities,
+	// "active" for the key signing identities, and "retired" for keys that
+	// are still published until the identities they signed expired.
+	State string
+
+	CreateTime   time.Time
+	ActivateTime time.Time
+	RetireTime   time.Time
+	CreateIndex  uint64
+	ModifyIndex  uint64
+}
+
+// WorkloadIdentityKeyRotateResponse is the response object of a rotation of
+// the keys signing workload identities.
+type WorkloadIdentityKeyRotateResponse struct {
+	// KeyID is the ID of the key signing identities after the rotation.
+	KeyID string
+
+	WriteMeta
+}
+
+// WorkloadIdentityKeys is used to list the keys signing workload identities,
+// such that third parties can pin the keys they trust ahead of rotations.
+func (op *Operator) WorkloadIdentityKeys(q *QueryOptions) ([]*WorkloadIdentityKey, *QueryMeta, error) {
+	var resp []*WorkloadIdentityKey
+	qm, err := op.c.query("/v1/operator/workload-identity/keys", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// RotateWorkloadIdentityKey is used to rotate the keys signing workload
+// identities ahead of the rotation interval. The next key, which is already
+// published, starts signing identities, and the active key is retired.
+func (op *Operator) RotateWorkloadIdentityKey(q *WriteOptions) (*WorkloadIdentityKeyRotateResponse, *WriteMeta, error) {
+	var out WorkloadIdentityKeyRotateResponse
+	wm, err := op.c.write("/v1/operator/workload-identity/rotate", nil, &out, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &out, wm, nil
+}
+
 // Snapshot is used to capture a snapshot state of a running cluster.
 // The returned reader that must be consumed fully
 func (op *Operator) Snapshot(q *QueryOptions) (io.ReadCloser, error) {
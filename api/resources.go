@@ -15,6 +15,16 @@ type Resources struct {
 	Networks    []*NetworkResource `hcl:"network,block"`
 	Devices     []*RequestedDevice `hcl:"device,block"`
 
+	// DiskIOPS and DiskBandwidthMB limit the read and write operations per
+	// second and the read and write MB per second of the task on the disk
+	// holding its allocation directory.
+	DiskIOPS        *int `mapstructure:"disk_iops" hcl:"disk_iops,optional"`
+	DiskBandwidthMB *int `mapstructure:"disk_bandwidth" hcl:"disk_bandwidth,optional"`
+
+	// NUMA configures the binding of the memory of the task to the NUMA
+	// nodes of its reserved cores.
+	NUMA *NUMAResource `hcl:"numa,block"`
+
 	// COMPAT(0.10)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
 	// 0.10 and is only being kept to allow any references to be removed before
@@ -47,6 +57,22 @@ func (r *Resources) Canonicalize() {
 	for _, d := range r.Devices {
 		d.Canonicalize()
 	}
+	if r.NUMA != nil {
+		r.NUMA.Canonicalize()
+	}
+}
+
+// NUMAResource configures the binding of the memory of a task to the NUMA
+// nodes of its reserved cores.
+type NUMAResource struct {
+	// Affinity is "none", "prefer" or "require".
+	Affinity *string `hcl:"affinity,optional"`
+}
+
+func (n *NUMAResource) Canonicalize() {
+	if n.Affinity == nil {
+		n.Affinity = stringToPtr("none")
+	}
 }
 
 // DefaultResources is a small resources object that contains the
@@ -97,10 +123,11 @@ func (r *Resources) Merge(other *Resources) {
 }
 
 type Port struct {
-	Label       string `hcl:",label"`
-	Value       int    `mapstructure:"static" hcl:"static,optional"`
-	To          int    `mapstructure:"to" hcl:"to,optional"`
-	HostNetwork string `mapstructure:"host_network" hcl:"host_network,optional"`
+	Label        string   `hcl:",label"`
+	Value        int      `mapstructure:"static" hcl:"static,optional"`
+	To           int      `mapstructure:"to" hcl:"to,optional"`
+	HostNetwork  string   `mapstructure:"host_network" hcl:"host_network,optional"`
+	HostNetworks []string `mapstructure:"host_networks" hcl:"host_networks,optional"`
 }
 
 type DNSConfig struct {
@@ -109,17 +136,27 @@ type DNSConfig struct {
 	Options  []string `mapstructure:"options" hcl:"options,optional"`
 }
 
+// NetworkFirewall is the egress traffic of a bridge network allowed through
+// the firewall enforced by clients with bridge_network_firewall enabled.
+type NetworkFirewall struct {
+	AllowClientAPI bool     `mapstructure:"allow_client_api" hcl:"allow_client_api,optional"`
+	AllowMetadata  bool     `mapstructure:"allow_metadata" hcl:"allow_metadata,optional"`
+	AllowCIDRs     []string `mapstructure:"allow_cidrs" hcl:"allow_cidrs,optional"`
+}
+
 // NetworkResource is used to describe required network
 // resources of a given task.
 type NetworkResource struct {
-	Mode          string     `hcl:"mode,optional"`
-	Device        string     `hcl:"device,optional"`
-	CIDR          string     `hcl:"cidr,optional"`
-	IP            string     `hcl:"ip,optional"`
-	DNS           *DNSConfig `hcl:"dns,block"`
-	ReservedPorts []Port     `hcl:"reserved_ports,block"`
-	DynamicPorts  []Port     `hcl:"port,block"`
-	Hostname      string     `hcl:"hostname,optional"`
+	Mode          string           `hcl:"mode,optional"`
+	Device        string           `hcl:"device,optional"`
+	CIDR          string           `hcl:"cidr,optional"`
+	IP            string           `hcl:"ip,optional"`
+	DNS           *DNSConfig       `hcl:"dns,block"`
+	ReservedPorts []Port           `hcl:"reserved_ports,block"`
+	DynamicPorts  []Port           `hcl:"port,block"`
+	Hostname      string           `hcl:"hostname,optional"`
+	Bandwidth     *int             `hcl:"bandwidth,optional"`
+	Firewall      *NetworkFirewall `hcl:"firewall,block"`
 
 	// COMPAT(0.13)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
@@ -1,8 +1,21 @@
 package api
 
+import (
+	"fmt"
+	"time"
+)
+
 const (
 	// ScalingPolicyTypeHorizontal indicates a policy that does horizontal scaling.
 	ScalingPolicyTypeHorizontal = "horizontal"
+
+	// ScalingPolicyTypeVerticalCPU indicates a policy that scales the CPU
+	// resources of a task.
+	ScalingPolicyTypeVerticalCPU = "vertical_cpu"
+
+	// ScalingPolicyTypeVerticalMem indicates a policy that scales the memory
+	// resources of a task.
+	ScalingPolicyTypeVerticalMem = "vertical_mem"
 )
 
 // Scaling is used to query scaling-related API endpoints
@@ -24,6 +37,18 @@ func (s *Scaling) ListPolicies(q *QueryOptions) ([]*ScalingPolicyListStub, *Quer
 	return resp, qm, nil
 }
 
+// ListScheduledActions returns the scheduled changes to the count of task
+// groups that haven't been applied yet. Set the "job" parameter of the query
+// options to only list the actions of a single job.
+func (s *Scaling) ListScheduledActions(q *QueryOptions) ([]*ScheduledScalingAction, *QueryMeta, error) {
+	var resp []*ScheduledScalingAction
+	qm, err := s.client.query("/v1/scaling/actions", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 func (s *Scaling) GetPolicy(id string, q *QueryOptions) (*ScalingPolicy, *QueryMeta, error) {
 	var policy ScalingPolicy
 	qm, err := s.client.query("/v1/scaling/policy/"+id, &policy, q)
@@ -33,6 +58,47 @@ func (s *Scaling) GetPolicy(id string, q *QueryOptions) (*ScalingPolicy, *QueryM
 	return &policy, qm, nil
 }
 
+// UpsertPolicy creates or updates a standalone scaling policy, which is managed
+// independently of the specification of the job it targets. The policy is
+// updated if its ID is set or if a standalone policy of the same type already
+// targets the same group or task. It returns the ID of the policy.
+func (s *Scaling) UpsertPolicy(policy *ScalingPolicy, q *WriteOptions) (string, *WriteMeta, error) {
+	if policy == nil {
+		return "", nil, fmt.Errorf("missing policy")
+	}
+
+	endpoint := "/v1/scaling/policies"
+	if policy.ID != "" {
+		endpoint = "/v1/scaling/policy/" + policy.ID
+	}
+
+	var resp scalingPolicyUpsertResponse
+	wm, err := s.client.write(endpoint, policy, &resp, q)
+	if err != nil {
+		return "", nil, err
+	}
+	return resp.ID, wm, nil
+}
+
+// DeletePolicy deletes a standalone scaling policy. Policies defined in the
+// specification of a job can only be removed from it.
+func (s *Scaling) DeletePolicy(id string, q *WriteOptions) (*WriteMeta, error) {
+	if id == "" {
+		return nil, fmt.Errorf("missing policy ID")
+	}
+	wm, err := s.client.delete("/v1/scaling/policy/"+id, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// scalingPolicyUpsertResponse is the response of the scaling policy upsert
+// endpoints.
+type scalingPolicyUpsertResponse struct {
+	ID string
+}
+
 func (p *ScalingPolicy) Canonicalize(taskGroupCount int) {
 	if p.Enabled == nil {
 		p.Enabled = boolToPtr(true)
@@ -48,14 +114,17 @@ func (p *ScalingPolicy) Canonicalize(taskGroupCount int) {
 
 // ScalingRequest is the payload for a generic scaling action
 type ScalingRequest struct {
-	Count   *int64
-	Target  map[string]string
-	Message string
-	Error   bool
-	Meta    map[string]interface{}
+	Count     *int64
+	Resources map[string]*ScalingResources
+	Target    map[string]string
+	Message   string
+	Error     bool
+	Meta      map[string]interface{}
 	WriteRequest
 	// this is effectively a job update, so we need the ability to override policy.
 	PolicyOverride bool
+	// ScheduleAt defers the count change until the given time.
+	ScheduleAt *time.Time `json:",omitempty"`
 }
 
 // ScalingPolicy is the user-specified API object for an autoscaling policy
@@ -68,11 +137,12 @@ type ScalingPolicy struct {
 	Enabled *bool                  `hcl:"enabled,optional"`
 	Type    string                 `hcl:"type,optional"`
 
-	/* fields set by server */
+	/* fields set by server, or by the user for standalone policies */
 
 	ID          string
 	Namespace   string
 	Target      map[string]string
+	Standalone  bool
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -82,6 +152,7 @@ type ScalingPolicy struct {
 type ScalingPolicyListStub struct {
 	ID          string
 	Enabled     bool
+	Standalone  bool
 	Type        string
 	Target      map[string]string
 	CreateIndex uint64
@@ -108,12 +179,36 @@ type TaskGroupScaleStatus struct {
 }
 
 type ScalingEvent struct {
-	Count         *int64
-	PreviousCount int64
-	Error         bool
-	Message       string
-	Meta          map[string]interface{}
-	EvalID        *string
-	Time          uint64
-	CreateIndex   uint64
+	Count             *int64
+	PreviousCount     int64
+	Resources         map[string]*ScalingResources
+	PreviousResources map[string]*ScalingResources
+	Error             bool
+	Message           string
+	Meta              map[string]interface{}
+	EvalID            *string
+	Time              uint64
+	CreateIndex       uint64
+}
+
+// ScalingResources are the resources of a task requested by a vertical
+// scaling action. Zero values leave the task's resource unchanged.
+type ScalingResources struct {
+	CPU      int
+	MemoryMB int
+}
+
+// ScheduledScalingAction is a change to the count of a task group that is
+// applied at ScheduleAt, as requested with Jobs.ScaleAt.
+type ScheduledScalingAction struct {
+	ID          string
+	Namespace   string
+	JobID       string
+	TaskGroup   string
+	Count       int64
+	Message     string
+	Meta        map[string]interface{}
+	ScheduleAt  time.Time
+	CreateIndex uint64
+	ModifyIndex uint64
 }
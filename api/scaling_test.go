@@ -123,3 +123,58 @@ func TestScalingPolicies_GetPolicy(t *testing.T) {
 	require.Equal(policy.Max, resp.Max)
 	require.Equal(ScalingPolicyTypeHorizontal, resp.Type)
 }
+
+func TestScalingPolicies_UpsertDeletePolicy(t *testing.T) {
+	testutil.Parallel(t)
+	require := require.New(t)
+
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	scaling := c.Scaling()
+
+	// Register a job without scaling policy
+	job := testJob()
+	_, _, err := c.Jobs().Register(job, nil)
+	require.NoError(err)
+
+	// Create a standalone policy for its group
+	policy := &ScalingPolicy{
+		Max: int64ToPtr(5),
+		Target: map[string]string{
+			"Job":   *job.ID,
+			"Group": *job.TaskGroups[0].Name,
+		},
+		Policy: map[string]interface{}{
+			"key": "value",
+		},
+	}
+	id, wm, err := scaling.UpsertPolicy(policy, nil)
+	require.NoError(err)
+	require.NotEmpty(id)
+	assertWriteMeta(t, wm)
+
+	resp, _, err := scaling.GetPolicy(id, nil)
+	require.NoError(err)
+	require.True(resp.Standalone)
+	require.Equal(int64(5), *resp.Max)
+	require.Equal(policy.Policy, resp.Policy)
+
+	// Update it
+	policy.ID = id
+	policy.Max = int64ToPtr(10)
+	_, _, err = scaling.UpsertPolicy(policy, nil)
+	require.NoError(err)
+
+	resp, _, err = scaling.GetPolicy(id, nil)
+	require.NoError(err)
+	require.Equal(int64(10), *resp.Max)
+
+	// Delete it
+	wm, err = scaling.DeletePolicy(id, nil)
+	require.NoError(err)
+	assertWriteMeta(t, wm)
+
+	_, _, err = scaling.GetPolicy(id, nil)
+	require.Error(err)
+	require.Contains(err.Error(), "404")
+}
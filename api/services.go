@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -92,11 +93,17 @@ type ServiceCheck struct {
 	CheckRestart           *CheckRestart       `mapstructure:"check_restart" hcl:"check_restart,block"`
 	GRPCService            string              `mapstructure:"grpc_service" hcl:"grpc_service,optional"`
 	GRPCUseTLS             bool                `mapstructure:"grpc_use_tls" hcl:"grpc_use_tls,optional"`
+	TLSServerName          string              `mapstructure:"tls_server_name" hcl:"tls_server_name,optional"`
+	TLSCACert              string              `mapstructure:"tls_ca_cert" hcl:"tls_ca_cert,optional"`
+	TLSClientCert          string              `mapstructure:"tls_client_cert" hcl:"tls_client_cert,optional"`
+	TLSClientKey           string              `mapstructure:"tls_client_key" hcl:"tls_client_key,optional"`
 	TaskName               string              `mapstructure:"task" hcl:"task,optional"`
 	SuccessBeforePassing   int                 `mapstructure:"success_before_passing" hcl:"success_before_passing,optional"`
 	FailuresBeforeCritical int                 `mapstructure:"failures_before_critical" hcl:"failures_before_critical,optional"`
 	Body                   string              `hcl:"body,optional"`
 	OnUpdate               string              `mapstructure:"on_update" hcl:"on_update,optional"`
+	Namespace              string              `hcl:"namespace,optional"`
+	Partition              string              `hcl:"partition,optional"`
 }
 
 // Service represents a Consul service definition.
@@ -116,6 +123,31 @@ type Service struct {
 	CanaryMeta        map[string]string `hcl:"canary_meta,block"`
 	TaskName          string            `mapstructure:"task" hcl:"task,optional"`
 	OnUpdate          string            `mapstructure:"on_update" hcl:"on_update,optional"`
+	Weights           *ServiceWeights   `hcl:"weights,block"`
+	Namespace         string            `hcl:"namespace,optional"`
+	Partition         string            `hcl:"partition,optional"`
+}
+
+// ServiceWeights are the weights of the service in Consul DNS SRV responses
+// when the service is passing or warning.
+type ServiceWeights struct {
+	Passing *int `hcl:"passing,optional"`
+	Warning *int `hcl:"warning,optional"`
+}
+
+// Canonicalize sets the weights to Consul's default of 1 when unset. A weight
+// explicitly set to 0 is kept, as Consul accepts a warning weight of 0 to
+// leave warning instances out of DNS responses.
+func (w *ServiceWeights) Canonicalize() {
+	if w == nil {
+		return
+	}
+	if w.Passing == nil {
+		w.Passing = intToPtr(1)
+	}
+	if w.Warning == nil {
+		w.Warning = intToPtr(1)
+	}
 }
 
 const (
@@ -146,6 +178,7 @@ func (s *Service) Canonicalize(t *Task, tg *TaskGroup, job *Job) {
 	}
 
 	s.Connect.Canonicalize()
+	s.Weights.Canonicalize()
 
 	// Canonicalize CheckRestart on Checks and merge Service.CheckRestart
 	// into each check.
@@ -174,6 +207,7 @@ type ConsulConnect struct {
 	Gateway        *ConsulGateway        `hcl:"gateway,block"`
 	SidecarService *ConsulSidecarService `mapstructure:"sidecar_service" hcl:"sidecar_service,block"`
 	SidecarTask    *SidecarTask          `mapstructure:"sidecar_task" hcl:"sidecar_task,block"`
+	Certs          *ConsulConnectCerts   `hcl:"certs,block"`
 }
 
 func (cc *ConsulConnect) Canonicalize() {
@@ -182,8 +216,33 @@ func (cc *ConsulConnect) Canonicalize() {
 	}
 
 	cc.SidecarService.Canonicalize()
+	cc.SidecarTask.scaleResources(cc.SidecarService)
 	cc.SidecarTask.Canonicalize()
 	cc.Gateway.Canonicalize()
+	cc.Certs.Canonicalize()
+}
+
+// ConsulConnectCerts configures how a Connect native task is notified when
+// the certificates Nomad delivers into its secrets directory change.
+type ConsulConnectCerts struct {
+	ChangeMode   string `mapstructure:"change_mode" hcl:"change_mode,optional"`
+	ChangeSignal string `mapstructure:"change_signal" hcl:"change_signal,optional"`
+}
+
+func (c *ConsulConnectCerts) Canonicalize() {
+	if c == nil {
+		return
+	}
+
+	if c.ChangeMode == "" {
+		c.ChangeMode = "noop"
+	}
+
+	if c.ChangeMode == "signal" && c.ChangeSignal == "" {
+		c.ChangeSignal = "SIGHUP"
+	}
+
+	c.ChangeSignal = strings.ToUpper(c.ChangeSignal)
 }
 
 // ConsulSidecarService represents a Consul Connect SidecarService jobspec
@@ -221,6 +280,51 @@ type SidecarTask struct {
 	LogConfig     *LogConfig             `mapstructure:"logs" hcl:"logs,block"`
 	ShutdownDelay *time.Duration         `mapstructure:"shutdown_delay" hcl:"shutdown_delay,optional"`
 	KillSignal    string                 `mapstructure:"kill_signal" hcl:"kill_signal,optional"`
+
+	// ScaleResources sizes the default CPU and memory of the sidecar task by
+	// the number of upstreams and expose paths of the sidecar service.
+	ScaleResources bool `mapstructure:"scale_resources" hcl:"scale_resources,optional"`
+}
+
+const (
+	// sidecarScaledBaseCPU and sidecarScaledBaseMemoryMB are the resources of
+	// a sidecar task with ScaleResources set and no upstreams or expose paths,
+	// matching the defaults of the sidecar task injected by Nomad.
+	sidecarScaledBaseCPU      = 250
+	sidecarScaledBaseMemoryMB = 128
+
+	// sidecarScaledListenerCPU and sidecarScaledListenerMemoryMB are the
+	// resources added to a sidecar task with ScaleResources set for each
+	// upstream and expose path.
+	sidecarScaledListenerCPU      = 25
+	sidecarScaledListenerMemoryMB = 8
+)
+
+// scaleResources sets the CPU and memory of the sidecar task which are not
+// set explicitly, if ScaleResources is set. It must be called before
+// Canonicalize, which sets them to the defaults.
+func (st *SidecarTask) scaleResources(css *ConsulSidecarService) {
+	if st == nil || !st.ScaleResources {
+		return
+	}
+
+	listeners := 0
+	if css != nil && css.Proxy != nil {
+		listeners += len(css.Proxy.Upstreams)
+		if css.Proxy.ExposeConfig != nil {
+			listeners += len(css.Proxy.ExposeConfig.Path)
+		}
+	}
+
+	if st.Resources == nil {
+		st.Resources = new(Resources)
+	}
+	if st.Resources.CPU == nil && st.Resources.Cores == nil {
+		st.Resources.CPU = intToPtr(sidecarScaledBaseCPU + listeners*sidecarScaledListenerCPU)
+	}
+	if st.Resources.MemoryMB == nil {
+		st.Resources.MemoryMB = intToPtr(sidecarScaledBaseMemoryMB + listeners*sidecarScaledListenerMemoryMB)
+	}
 }
 
 func (st *SidecarTask) Canonicalize() {
@@ -388,6 +492,9 @@ type ConsulGateway struct {
 
 	// Mesh indicates the Consul service should be a Mesh Gateway.
 	Mesh *ConsulMeshConfigEntry `hcl:"mesh,block"`
+
+	// APIGateway represents the Consul Configuration Entry for an API Gateway.
+	APIGateway *ConsulAPIGatewayConfigEntry `mapstructure:"api_gateway" hcl:"api_gateway,block"`
 }
 
 func (g *ConsulGateway) Canonicalize() {
@@ -397,6 +504,7 @@ func (g *ConsulGateway) Canonicalize() {
 	g.Proxy.Canonicalize()
 	g.Ingress.Canonicalize()
 	g.Terminating.Canonicalize()
+	g.APIGateway.Canonicalize()
 }
 
 func (g *ConsulGateway) Copy() *ConsulGateway {
@@ -408,6 +516,7 @@ func (g *ConsulGateway) Copy() *ConsulGateway {
 		Proxy:       g.Proxy.Copy(),
 		Ingress:     g.Ingress.Copy(),
 		Terminating: g.Terminating.Copy(),
+		APIGateway:  g.APIGateway.Copy(),
 	}
 }
 
@@ -721,3 +830,137 @@ func (e *ConsulMeshConfigEntry) Copy() *ConsulMeshConfigEntry {
 	}
 	return new(ConsulMeshConfigEntry)
 }
+
+// ConsulAPIGatewayTLSConfig is used to configure TLS for a listener on a
+// Consul API Gateway.
+type ConsulAPIGatewayTLSConfig struct {
+	// Certificates are the names of the Consul certificate Configuration
+	// Entries used by the listener.
+	Certificates  []string `hcl:"certificates,optional"`
+	TLSMinVersion string   `mapstructure:"tls_min_version" hcl:"tls_min_version,optional"`
+	TLSMaxVersion string   `mapstructure:"tls_max_version" hcl:"tls_max_version,optional"`
+	CipherSuites  []string `mapstructure:"cipher_suites" hcl:"cipher_suites,optional"`
+}
+
+func (c *ConsulAPIGatewayTLSConfig) Canonicalize() {
+	if c == nil {
+		return
+	}
+
+	if len(c.Certificates) == 0 {
+		c.Certificates = nil
+	}
+
+	if len(c.CipherSuites) == 0 {
+		c.CipherSuites = nil
+	}
+}
+
+func (c *ConsulAPIGatewayTLSConfig) Copy() *ConsulAPIGatewayTLSConfig {
+	if c == nil {
+		return nil
+	}
+
+	var certificates []string = nil
+	if n := len(c.Certificates); n > 0 {
+		certificates = make([]string, n)
+		copy(certificates, c.Certificates)
+	}
+
+	var cipherSuites []string = nil
+	if n := len(c.CipherSuites); n > 0 {
+		cipherSuites = make([]string, n)
+		copy(cipherSuites, c.CipherSuites)
+	}
+
+	return &ConsulAPIGatewayTLSConfig{
+		Certificates:  certificates,
+		TLSMinVersion: c.TLSMinVersion,
+		TLSMaxVersion: c.TLSMaxVersion,
+		CipherSuites:  cipherSuites,
+	}
+}
+
+const (
+	defaultAPIGatewayListenerProtocol = "tcp"
+)
+
+// ConsulAPIGatewayListener is used to configure a listener on a Consul API
+// Gateway.
+type ConsulAPIGatewayListener struct {
+	Name     string                     `hcl:"name,optional"`
+	Hostname string                     `hcl:"hostname,optional"`
+	Port     int                        `hcl:"port,optional"`
+	Protocol string                     `hcl:"protocol,optional"`
+	TLS      *ConsulAPIGatewayTLSConfig `hcl:"tls,block"`
+}
+
+func (l *ConsulAPIGatewayListener) Canonicalize() {
+	if l == nil {
+		return
+	}
+
+	if l.Protocol == "" {
+		// same as default from consul
+		l.Protocol = defaultAPIGatewayListenerProtocol
+	}
+
+	l.TLS.Canonicalize()
+}
+
+func (l *ConsulAPIGatewayListener) Copy() *ConsulAPIGatewayListener {
+	if l == nil {
+		return nil
+	}
+
+	return &ConsulAPIGatewayListener{
+		Name:     l.Name,
+		Hostname: l.Hostname,
+		Port:     l.Port,
+		Protocol: l.Protocol,
+		TLS:      l.TLS.Copy(),
+	}
+}
+
+// ConsulAPIGatewayConfigEntry represents the Consul Configuration Entry type
+// for an API Gateway.
+//
+// https://www.consul.io/docs/connect/config-entries/api-gateway
+type ConsulAPIGatewayConfigEntry struct {
+	// Namespace is not yet supported.
+	// Namespace string
+
+	Listeners []*ConsulAPIGatewayListener `hcl:"listener,block"`
+}
+
+func (e *ConsulAPIGatewayConfigEntry) Canonicalize() {
+	if e == nil {
+		return
+	}
+
+	if len(e.Listeners) == 0 {
+		e.Listeners = nil
+	}
+
+	for _, listener := range e.Listeners {
+		listener.Canonicalize()
+	}
+}
+
+func (e *ConsulAPIGatewayConfigEntry) Copy() *ConsulAPIGatewayConfigEntry {
+	if e == nil {
+		return nil
+	}
+
+	var listeners []*ConsulAPIGatewayListener = nil
+	if n := len(e.Listeners); n > 0 {
+		listeners = make([]*ConsulAPIGatewayListener, n)
+		for i := 0; i < n; i++ {
+			listeners[i] = e.Listeners[i].Copy()
+		}
+	}
+
+	return &ConsulAPIGatewayConfigEntry{
+		Listeners: listeners,
+	}
+}
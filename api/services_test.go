@@ -24,6 +24,32 @@ func TestService_Canonicalize(t *testing.T) {
 	require.Equal(t, OnUpdateRequireHealthy, s.OnUpdate)
 }
 
+func TestService_Weights_Canonicalize(t *testing.T) {
+	testutil.Parallel(t)
+
+	j := &Job{Name: stringToPtr("job")}
+	tg := &TaskGroup{Name: stringToPtr("group")}
+	task := &Task{Name: "task"}
+
+	t.Run("unset", func(t *testing.T) {
+		s := &Service{}
+		s.Canonicalize(task, tg, j)
+		require.Nil(t, s.Weights)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		s := &Service{Weights: &ServiceWeights{Passing: intToPtr(10)}}
+		s.Canonicalize(task, tg, j)
+		require.Equal(t, &ServiceWeights{Passing: intToPtr(10), Warning: intToPtr(1)}, s.Weights)
+	})
+
+	t.Run("warning zero", func(t *testing.T) {
+		s := &Service{Weights: &ServiceWeights{Passing: intToPtr(10), Warning: intToPtr(0)}}
+		s.Canonicalize(task, tg, j)
+		require.Equal(t, &ServiceWeights{Passing: intToPtr(10), Warning: intToPtr(0)}, s.Weights)
+	})
+}
+
 func TestServiceCheck_Canonicalize(t *testing.T) {
 	testutil.Parallel(t)
 
@@ -352,6 +378,54 @@ func TestService_Connect_SidecarTask_Canonicalize(t *testing.T) {
 	})
 }
 
+func TestService_Connect_SidecarTask_ScaleResources(t *testing.T) {
+	testutil.Parallel(t)
+
+	css := &ConsulSidecarService{
+		Proxy: &ConsulProxy{
+			Upstreams: []*ConsulUpstream{
+				{DestinationName: "db", LocalBindPort: 9000},
+				{DestinationName: "cache", LocalBindPort: 9001},
+			},
+			ExposeConfig: &ConsulExposeConfig{
+				Path: []*ConsulExposePath{{Path: "/health"}},
+			},
+		},
+	}
+
+	t.Run("scaled by listeners", func(t *testing.T) {
+		cc := &ConsulConnect{
+			SidecarService: css,
+			SidecarTask:    &SidecarTask{ScaleResources: true},
+		}
+		cc.Canonicalize()
+		require.Equal(t, 325, *cc.SidecarTask.Resources.CPU)
+		require.Equal(t, 152, *cc.SidecarTask.Resources.MemoryMB)
+	})
+
+	t.Run("explicit resources kept", func(t *testing.T) {
+		cc := &ConsulConnect{
+			SidecarService: css,
+			SidecarTask: &SidecarTask{
+				ScaleResources: true,
+				Resources:      &Resources{CPU: intToPtr(500)},
+			},
+		}
+		cc.Canonicalize()
+		require.Equal(t, 500, *cc.SidecarTask.Resources.CPU)
+		require.Equal(t, 152, *cc.SidecarTask.Resources.MemoryMB)
+	})
+
+	t.Run("not scaled", func(t *testing.T) {
+		cc := &ConsulConnect{
+			SidecarService: css,
+			SidecarTask:    new(SidecarTask),
+		}
+		cc.Canonicalize()
+		require.Equal(t, DefaultResources(), cc.SidecarTask.Resources)
+	})
+}
+
 func TestService_ConsulGateway_Canonicalize(t *testing.T) {
 	testutil.Parallel(t)
 
@@ -560,6 +634,73 @@ func TestService_ConsulTerminatingConfigEntry_Copy(t *testing.T) {
 	})
 }
 
+func TestService_ConsulAPIGatewayConfigEntry_Canonicalize(t *testing.T) {
+	testutil.Parallel(t)
+
+	t.Run("nil", func(t *testing.T) {
+		c := (*ConsulAPIGatewayConfigEntry)(nil)
+		c.Canonicalize()
+		require.Nil(t, c)
+	})
+
+	t.Run("empty listeners", func(t *testing.T) {
+		c := &ConsulAPIGatewayConfigEntry{
+			Listeners: []*ConsulAPIGatewayListener{},
+		}
+		c.Canonicalize()
+		require.Nil(t, c.Listeners)
+	})
+
+	t.Run("listener defaults", func(t *testing.T) {
+		c := &ConsulAPIGatewayConfigEntry{
+			Listeners: []*ConsulAPIGatewayListener{{
+				Name: "listener1",
+				Port: 8080,
+				TLS: &ConsulAPIGatewayTLSConfig{
+					Certificates: []string{"cert"},
+					CipherSuites: []string{},
+				},
+			}},
+		}
+		c.Canonicalize()
+		require.Equal(t, "tcp", c.Listeners[0].Protocol)
+		require.Nil(t, c.Listeners[0].TLS.CipherSuites)
+	})
+}
+
+func TestService_ConsulAPIGatewayConfigEntry_Copy(t *testing.T) {
+	testutil.Parallel(t)
+
+	t.Run("nil", func(t *testing.T) {
+		result := (*ConsulAPIGatewayConfigEntry)(nil).Copy()
+		require.Nil(t, result)
+	})
+
+	entry := &ConsulAPIGatewayConfigEntry{
+		Listeners: []*ConsulAPIGatewayListener{{
+			Name:     "listener1",
+			Hostname: "*.example.com",
+			Port:     8443,
+			Protocol: "http",
+			TLS: &ConsulAPIGatewayTLSConfig{
+				Certificates:  []string{"cert1", "cert2"},
+				TLSMinVersion: "TLSv1_2",
+				TLSMaxVersion: "TLSv1_3",
+				CipherSuites:  []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+			},
+		}, {
+			Name:     "listener2",
+			Port:     9090,
+			Protocol: "tcp",
+		}},
+	}
+
+	t.Run("complete", func(t *testing.T) {
+		result := entry.Copy()
+		require.Equal(t, entry, result)
+	})
+}
+
 func TestService_ConsulMeshConfigEntry_Canonicalize(t *testing.T) {
 	testutil.Parallel(t)
 
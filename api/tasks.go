@@ -109,6 +109,14 @@ type ReschedulePolicy struct {
 
 	// Unlimited allows rescheduling attempts until they succeed
 	Unlimited *bool `mapstructure:"unlimited" hcl:"unlimited,optional"`
+
+	// Jitter is the fraction of the delay, between 0 and 1, that may be
+	// randomly added to it.
+	Jitter *float64 `mapstructure:"jitter" hcl:"jitter,optional"`
+
+	// MaxLifetime is the duration, from the creation of the first allocation
+	// of a reschedule chain, after which rescheduling stops.
+	MaxLifetime *time.Duration `mapstructure:"max_lifetime" hcl:"max_lifetime,optional"`
 }
 
 func (r *ReschedulePolicy) Merge(rp *ReschedulePolicy) {
@@ -133,6 +141,12 @@ func (r *ReschedulePolicy) Merge(rp *ReschedulePolicy) {
 	if rp.Unlimited != nil {
 		r.Unlimited = rp.Unlimited
 	}
+	if rp.Jitter != nil {
+		r.Jitter = rp.Jitter
+	}
+	if rp.MaxLifetime != nil {
+		r.MaxLifetime = rp.MaxLifetime
+	}
 }
 
 func (r *ReschedulePolicy) Canonicalize(jobType string) {
@@ -155,6 +169,12 @@ func (r *ReschedulePolicy) Canonicalize(jobType string) {
 	if r.Unlimited == nil {
 		r.Unlimited = dp.Unlimited
 	}
+	if r.Jitter == nil {
+		r.Jitter = dp.Jitter
+	}
+	if r.MaxLifetime == nil {
+		r.MaxLifetime = dp.MaxLifetime
+	}
 }
 
 // Affinity is used to serialize task group affinities
@@ -191,6 +211,8 @@ func NewDefaultReschedulePolicy(jobType string) *ReschedulePolicy {
 			DelayFunction: stringToPtr("exponential"),
 			MaxDelay:      timeToPtr(1 * time.Hour),
 			Unlimited:     boolToPtr(true),
+			Jitter:        float64ToPtr(0),
+			MaxLifetime:   timeToPtr(0),
 
 			Attempts: intToPtr(0),
 			Interval: timeToPtr(0),
@@ -204,8 +226,10 @@ func NewDefaultReschedulePolicy(jobType string) *ReschedulePolicy {
 			Delay:         timeToPtr(5 * time.Second),
 			DelayFunction: stringToPtr("constant"),
 
-			MaxDelay:  timeToPtr(0),
-			Unlimited: boolToPtr(false),
+			MaxDelay:    timeToPtr(0),
+			Unlimited:   boolToPtr(false),
+			Jitter:      float64ToPtr(0),
+			MaxLifetime: timeToPtr(0),
 		}
 
 	case "system":
@@ -216,6 +240,8 @@ func NewDefaultReschedulePolicy(jobType string) *ReschedulePolicy {
 			DelayFunction: stringToPtr(""),
 			MaxDelay:      timeToPtr(0),
 			Unlimited:     boolToPtr(false),
+			Jitter:        float64ToPtr(0),
+			MaxLifetime:   timeToPtr(0),
 		}
 
 	default:
@@ -229,6 +255,8 @@ func NewDefaultReschedulePolicy(jobType string) *ReschedulePolicy {
 			DelayFunction: stringToPtr(""),
 			MaxDelay:      timeToPtr(0),
 			Unlimited:     boolToPtr(false),
+			Jitter:        float64ToPtr(0),
+			MaxLifetime:   timeToPtr(0),
 		}
 	}
 	return dp
@@ -377,15 +405,16 @@ func (m *MigrateStrategy) Copy() *MigrateStrategy {
 
 // VolumeRequest is a representation of a storage volume that a TaskGroup wishes to use.
 type VolumeRequest struct {
-	Name           string           `hcl:"name,label"`
-	Type           string           `hcl:"type,optional"`
-	Source         string           `hcl:"source,optional"`
-	ReadOnly       bool             `hcl:"read_only,optional"`
-	AccessMode     string           `hcl:"access_mode,optional"`
-	AttachmentMode string           `hcl:"attachment_mode,optional"`
-	MountOptions   *CSIMountOptions `hcl:"mount_options,block"`
-	PerAlloc       bool             `hcl:"per_alloc,optional"`
-	ExtraKeysHCL   []string         `hcl1:",unusedKeys,optional" json:"-"`
+	Name               string           `hcl:"name,label"`
+	Type               string           `hcl:"type,optional"`
+	Source             string           `hcl:"source,optional"`
+	AlternativeSources []string         `hcl:"alternative_sources,optional"`
+	ReadOnly           bool             `hcl:"read_only,optional"`
+	AccessMode         string           `hcl:"access_mode,optional"`
+	AttachmentMode     string           `hcl:"attachment_mode,optional"`
+	MountOptions       *CSIMountOptions `hcl:"mount_options,block"`
+	PerAlloc           bool             `hcl:"per_alloc,optional"`
+	ExtraKeysHCL       []string         `hcl1:",unusedKeys,optional" json:"-"`
 }
 
 const (
@@ -394,6 +423,12 @@ const (
 	VolumeMountPropagationBidirectional = "bidirectional"
 )
 
+const (
+	VolumeMountOnRecoverRestartTask = "restart_task"
+	VolumeMountOnRecoverSignal      = "signal"
+	VolumeMountOnRecoverNoop        = "noop"
+)
+
 // VolumeMount represents the relationship between a destination path in a task
 // and the task group volume that should be mounted there.
 type VolumeMount struct {
@@ -401,6 +436,7 @@ type VolumeMount struct {
 	Destination     *string `hcl:"destination,optional"`
 	ReadOnly        *bool   `mapstructure:"read_only" hcl:"read_only,optional"`
 	PropagationMode *string `mapstructure:"propagation_mode" hcl:"propagation_mode,optional"`
+	OnRecover       *string `mapstructure:"on_recover" hcl:"on_recover,optional"`
 }
 
 func (vm *VolumeMount) Canonicalize() {
@@ -410,6 +446,9 @@ func (vm *VolumeMount) Canonicalize() {
 	if vm.ReadOnly == nil {
 		vm.ReadOnly = boolToPtr(false)
 	}
+	if vm.OnRecover == nil {
+		vm.OnRecover = stringToPtr(VolumeMountOnRecoverNoop)
+	}
 }
 
 // TaskGroup is the unit of scheduling.
@@ -433,6 +472,7 @@ type TaskGroup struct {
 	StopAfterClientDisconnect *time.Duration            `mapstructure:"stop_after_client_disconnect" hcl:"stop_after_client_disconnect,optional"`
 	Scaling                   *ScalingPolicy            `hcl:"scaling,block"`
 	Consul                    *Consul                   `hcl:"consul,block"`
+	Priority                  *int                      `hcl:"priority,optional"`
 }
 
 // NewTaskGroup creates a new TaskGroup.
@@ -638,23 +678,41 @@ func (l *LogConfig) Canonicalize() {
 
 // DispatchPayloadConfig configures how a task gets its input from a job dispatch
 type DispatchPayloadConfig struct {
-	File string `hcl:"file,optional"`
+	File  string `hcl:"file,optional"`
+	Stdin bool   `hcl:"stdin,optional"`
 }
 
 const (
 	TaskLifecycleHookPrestart  = "prestart"
 	TaskLifecycleHookPoststart = "poststart"
+	TaskLifecycleHookPrestop   = "prestop"
 	TaskLifecycleHookPoststop  = "poststop"
 )
 
 type TaskLifecycle struct {
-	Hook    string `mapstructure:"hook" hcl:"hook,optional"`
-	Sidecar bool   `mapstructure:"sidecar" hcl:"sidecar,optional"`
+	Hook      string `mapstructure:"hook" hcl:"hook,optional"`
+	Sidecar   bool   `mapstructure:"sidecar" hcl:"sidecar,optional"`
+	OnFailure bool   `mapstructure:"on_failure" hcl:"on_failure,optional"`
+}
+
+// TaskRuntimeEnv opts a task into the environment variables that size common
+// runtimes to the resources of the task.
+type TaskRuntimeEnv struct {
+	GOMAXPROCS      bool `mapstructure:"gomaxprocs" hcl:"gomaxprocs,optional"`
+	JavaHeapPercent int  `mapstructure:"java_heap_percent" hcl:"java_heap_percent,optional"`
 }
 
 // Determine if lifecycle has user-input values
 func (l *TaskLifecycle) Empty() bool {
-	return l == nil || (l.Hook == "")
+	return l == nil || (l.Hook == "" && !l.OnFailure)
+}
+
+// Canonicalize defaults the hook of tasks that only run on failure to
+// poststop.
+func (l *TaskLifecycle) Canonicalize() {
+	if l.Hook == "" && l.OnFailure {
+		l.Hook = TaskLifecycleHookPoststop
+	}
 }
 
 // Task is a single process in a task group.
@@ -663,6 +721,7 @@ type Task struct {
 	Driver          string                 `hcl:"driver,optional"`
 	User            string                 `hcl:"user,optional"`
 	Lifecycle       *TaskLifecycle         `hcl:"lifecycle,block"`
+	RuntimeEnv      *TaskRuntimeEnv        `mapstructure:"runtime_env" hcl:"runtime_env,block"`
 	Config          map[string]interface{} `hcl:"config,block"`
 	Constraints     []*Constraint          `hcl:"constraint,block"`
 	Affinities      []*Affinity            `hcl:"affinity,block"`
@@ -675,6 +734,7 @@ type Task struct {
 	LogConfig       *LogConfig             `mapstructure:"logs" hcl:"logs,block"`
 	Artifacts       []*TaskArtifact        `hcl:"artifact,block"`
 	Vault           *Vault                 `hcl:"vault,block"`
+	Identities      []*WorkloadIdentity    `hcl:"identity,block"`
 	Templates       []*Template            `hcl:"template,block"`
 	DispatchPayload *DispatchPayloadConfig `hcl:"dispatch_payload,block"`
 	VolumeMounts    []*VolumeMount         `hcl:"volume_mount,block"`
@@ -703,6 +763,9 @@ func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
 	for _, artifact := range t.Artifacts {
 		artifact.Canonicalize()
 	}
+	for _, w := range t.Identities {
+		w.Canonicalize()
+	}
 	if t.Vault != nil {
 		t.Vault.Canonicalize()
 	}
@@ -720,6 +783,8 @@ func (t *Task) Canonicalize(tg *TaskGroup, job *Job) {
 	}
 	if t.Lifecycle.Empty() {
 		t.Lifecycle = nil
+	} else {
+		t.Lifecycle.Canonicalize()
 	}
 	if t.CSIPluginConfig != nil {
 		t.CSIPluginConfig.Canonicalize()
@@ -790,11 +855,40 @@ func (wc *WaitConfig) Copy() *WaitConfig {
 	return nwc
 }
 
+// ChangeScript is the script executed inside the task when a template with
+// change mode script is re-rendered.
+type ChangeScript struct {
+	Command         *string        `mapstructure:"command" hcl:"command"`
+	Args            []string       `mapstructure:"args" hcl:"args,optional"`
+	Timeout         *time.Duration `mapstructure:"timeout" hcl:"timeout,optional"`
+	FailOnError     *bool          `mapstructure:"fail_on_error" hcl:"fail_on_error,optional"`
+	PassChangedKeys *bool          `mapstructure:"pass_changed_keys" hcl:"pass_changed_keys,optional"`
+}
+
+func (cs *ChangeScript) Canonicalize() {
+	if cs.Command == nil {
+		cs.Command = stringToPtr("")
+	}
+	if cs.Args == nil {
+		cs.Args = []string{}
+	}
+	if cs.Timeout == nil {
+		cs.Timeout = timeToPtr(5 * time.Second)
+	}
+	if cs.FailOnError == nil {
+		cs.FailOnError = boolToPtr(false)
+	}
+	if cs.PassChangedKeys == nil {
+		cs.PassChangedKeys = boolToPtr(false)
+	}
+}
+
 type Template struct {
 	SourcePath   *string        `mapstructure:"source" hcl:"source,optional"`
 	DestPath     *string        `mapstructure:"destination" hcl:"destination,optional"`
 	EmbeddedTmpl *string        `mapstructure:"data" hcl:"data,optional"`
 	ChangeMode   *string        `mapstructure:"change_mode" hcl:"change_mode,optional"`
+	ChangeScript *ChangeScript  `mapstructure:"change_script" hcl:"change_script,block"`
 	ChangeSignal *string        `mapstructure:"change_signal" hcl:"change_signal,optional"`
 	Splay        *time.Duration `mapstructure:"splay" hcl:"splay,optional"`
 	Perms        *string        `mapstructure:"perms" hcl:"perms,optional"`
@@ -802,6 +896,7 @@ type Template struct {
 	RightDelim   *string        `mapstructure:"right_delimiter" hcl:"right_delimiter,optional"`
 	Envvars      *bool          `mapstructure:"env" hcl:"env,optional"`
 	VaultGrace   *time.Duration `mapstructure:"vault_grace" hcl:"vault_grace,optional"`
+	VaultCluster *string        `mapstructure:"vault_cluster" hcl:"vault_cluster,optional"`
 	Wait         *WaitConfig    `mapstructure:"wait" hcl:"wait,block"`
 }
 
@@ -828,6 +923,9 @@ func (tmpl *Template) Canonicalize() {
 		sig := *tmpl.ChangeSignal
 		tmpl.ChangeSignal = stringToPtr(strings.ToUpper(sig))
 	}
+	if tmpl.ChangeScript != nil {
+		tmpl.ChangeScript.Canonicalize()
+	}
 	if tmpl.Splay == nil {
 		tmpl.Splay = timeToPtr(5 * time.Second)
 	}
@@ -848,6 +946,9 @@ func (tmpl *Template) Canonicalize() {
 	if tmpl.VaultGrace == nil {
 		tmpl.VaultGrace = timeToPtr(0)
 	}
+	if tmpl.VaultCluster == nil {
+		tmpl.VaultCluster = stringToPtr("")
+	}
 }
 
 type Vault struct {
@@ -856,6 +957,26 @@ type Vault struct {
 	Env          *bool    `hcl:"env,optional"`
 	ChangeMode   *string  `mapstructure:"change_mode" hcl:"change_mode,optional"`
 	ChangeSignal *string  `mapstructure:"change_signal" hcl:"change_signal,optional"`
+
+	Clusters []*VaultCluster `hcl:"cluster,block"`
+}
+
+// VaultCluster is the set of policies a task needs access to on a named Vault
+// cluster configured on the agents.
+type VaultCluster struct {
+	Name      string   `hcl:",label"`
+	Policies  []string `hcl:"policies,optional"`
+	Namespace *string  `mapstructure:"namespace" hcl:"namespace,optional"`
+	Env       *bool    `hcl:"env,optional"`
+}
+
+func (c *VaultCluster) Canonicalize() {
+	if c.Env == nil {
+		c.Env = boolToPtr(true)
+	}
+	if c.Namespace == nil {
+		c.Namespace = stringToPtr("")
+	}
 }
 
 func (v *Vault) Canonicalize() {
@@ -871,6 +992,32 @@ func (v *Vault) Canonicalize() {
 	if v.ChangeSignal == nil {
 		v.ChangeSignal = stringToPtr("SIGHUP")
 	}
+	for _, c := range v.Clusters {
+		c.Canonicalize()
+	}
+}
+
+// WorkloadIdentity is a named JSON Web Token signed by the servers that a task
+// can present to third parties, such as the OIDC federation of cloud
+// providers.
+type WorkloadIdentity struct {
+	Name     string         `hcl:",label"`
+	Audience []string       `mapstructure:"aud" hcl:"aud,optional"`
+	TTL      *time.Duration `mapstructure:"ttl" hcl:"ttl,optional"`
+	Env      *bool          `hcl:"env,optional"`
+	File     *bool          `hcl:"file,optional"`
+}
+
+func (w *WorkloadIdentity) Canonicalize() {
+	if w.TTL == nil {
+		w.TTL = timeToPtr(time.Hour)
+	}
+	if w.Env == nil {
+		w.Env = boolToPtr(false)
+	}
+	if w.File == nil {
+		w.File = boolToPtr(true)
+	}
 }
 
 // NewTask creates and initializes a new Task.
@@ -935,6 +1082,10 @@ type TaskState struct {
 	FinishedAt  time.Time
 	Events      []*TaskEvent
 
+	// DriverStatus is the most recent message reported by the task driver
+	// while the task is pending, such as image pull progress.
+	DriverStatus string
+
 	// Experimental -  TaskHandle is based on drivers.TaskHandle and used
 	// by remote task drivers to migrate task handles between allocations.
 	TaskHandle *TaskHandle
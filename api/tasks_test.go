@@ -270,7 +270,7 @@ func TestTask_Require(t *testing.T) {
 			{
 				CIDR:          "0.0.0.0/0",
 				MBits:         intToPtr(100),
-				ReservedPorts: []Port{{"", 80, 0, ""}, {"", 443, 0, ""}},
+				ReservedPorts: []Port{{Value: 80}, {Value: 443}},
 			},
 		},
 	}
@@ -375,6 +375,8 @@ func TestTask_VolumeMount(t *testing.T) {
 	vm.Canonicalize()
 	require.NotNil(t, vm.PropagationMode)
 	require.Equal(t, *vm.PropagationMode, "private")
+	require.NotNil(t, vm.OnRecover)
+	require.Equal(t, *vm.OnRecover, "noop")
 }
 
 func TestTask_Canonicalize_TaskLifecycle(t *testing.T) {
@@ -391,6 +393,16 @@ func TestTask_Canonicalize_TaskLifecycle(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			name: "on failure without hook",
+			task: &Task{
+				Lifecycle: &TaskLifecycle{OnFailure: true},
+			},
+			expected: &TaskLifecycle{
+				Hook:      TaskLifecycleHookPoststop,
+				OnFailure: true,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -834,6 +846,8 @@ func Test_NewDefaultReschedulePolicy(t *testing.T) {
 				DelayFunction: stringToPtr("exponential"),
 				MaxDelay:      timeToPtr(1 * time.Hour),
 				Unlimited:     boolToPtr(true),
+				Jitter:        float64ToPtr(0),
+				MaxLifetime:   timeToPtr(0),
 			},
 		},
 		{
@@ -846,6 +860,8 @@ func Test_NewDefaultReschedulePolicy(t *testing.T) {
 				DelayFunction: stringToPtr("constant"),
 				MaxDelay:      timeToPtr(0),
 				Unlimited:     boolToPtr(false),
+				Jitter:        float64ToPtr(0),
+				MaxLifetime:   timeToPtr(0),
 			},
 		},
 		{
@@ -858,6 +874,8 @@ func Test_NewDefaultReschedulePolicy(t *testing.T) {
 				DelayFunction: stringToPtr(""),
 				MaxDelay:      timeToPtr(0),
 				Unlimited:     boolToPtr(false),
+				Jitter:        float64ToPtr(0),
+				MaxLifetime:   timeToPtr(0),
 			},
 		},
 		{
@@ -870,6 +888,8 @@ func Test_NewDefaultReschedulePolicy(t *testing.T) {
 				DelayFunction: stringToPtr(""),
 				MaxDelay:      timeToPtr(0),
 				Unlimited:     boolToPtr(false),
+				Jitter:        float64ToPtr(0),
+				MaxLifetime:   timeToPtr(0),
 			},
 		},
 	}
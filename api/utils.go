@@ -41,6 +41,11 @@ func timeToPtr(t time.Duration) *time.Duration {
 	return &t
 }
 
+// float64ToPtr returns the pointer to a float64
+func float64ToPtr(f float64) *float64 {
+	return &f
+}
+
 // formatFloat converts the floating-point number f to a string,
 // after rounding it to the passed unit.
 //
@@ -100,8 +100,9 @@ func (c *Client) resolveTokenAndACL(secretID string) (*acl.ACL, *structs.ACLToke
 		return acl.ManagementACL, token, nil
 	}
 
-	// Resolve the policies
-	policies, err := c.resolvePolicies(token.SecretID, token.Policies)
+	// Resolve the policies, skipping expiring policies whose expiration time
+	// has passed since the token was cached
+	policies, err := c.resolvePolicies(token.SecretID, token.ActivePolicies(time.Now()))
 	if err != nil {
 		return nil, nil, err
 	}
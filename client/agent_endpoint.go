@@ -112,13 +112,19 @@ func (a *Agent) monitor(conn io.ReadWriteCloser) {
 		return
 	}
 
+	overrides, err := monitor.ParseLevelOverrides(args.LogLevelOverrides)
+	if err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(400), encoder)
+		return
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	monitor := monitor.New(512, a.c.logger, &log.LoggerOptions{
+	monitor := monitor.NewWithOverrides(512, a.c.logger, &log.LoggerOptions{
 		JSONFormat: args.LogJSON,
 		Level:      logLevel,
-	})
+	}, overrides)
 
 	frames := make(chan *sframer.StreamFrame, streamFramesBuffer)
 	errCh := make(chan error)
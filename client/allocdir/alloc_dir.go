@@ -91,6 +91,10 @@ type AllocDir struct {
 	// built is true if Build has successfully run
 	built bool
 
+	// quotaProjectID is the project ID of the disk quota set by
+	// SetDiskQuota, if any.
+	quotaProjectID uint32
+
 	mu sync.RWMutex
 
 	logger hclog.Logger
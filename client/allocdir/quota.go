@@ -0,0 +1,134 @@
+package allocdir
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// projectIDBase is the first of the filesystem project IDs used for the
+	// disk quotas of alloc dirs. Operators shouldn't assign project IDs above
+	// it to other directories of the filesystem holding the alloc dirs.
+	projectIDBase = 0x40000000
+)
+
+var (
+	// ErrDiskQuotaUnsupported is returned when disk quotas can't be enforced
+	// on the alloc dir, either because its filesystem doesn't have project
+	// quotas enabled or because the client isn't allowed to manage them.
+	ErrDiskQuotaUnsupported = errors.New("project quotas are not supported")
+
+	// projectIDLock serializes choosing the project ID of an alloc dir and
+	// assigning it, so that two alloc dirs can't choose the same one.
+	projectIDLock sync.Mutex
+)
+
+// projectID returns the filesystem project ID preferred to account for the
+// disk space of the alloc dir. It's derived from the alloc ID, so collisions
+// with the project IDs of other alloc dirs are possible and are resolved by
+// chooseProjectID.
+func (d *AllocDir) projectID() uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(filepath.Base(d.AllocDir)))
+	return projectIDBase | (h.Sum32() &^ projectIDBase)
+}
+
+// chooseProjectID returns the project ID of the alloc dir: the project ID it
+// was assigned before the client restarted, if any, otherwise the first
+// project ID from projectID that no other alloc dir is assigned. It must be
+// called with projectIDLock held.
+func (d *AllocDir) chooseProjectID() (uint32, error) {
+	current, err := dirProjectID(d.AllocDir)
+	if err != nil {
+		return 0, err
+	}
+	if current >= projectIDBase {
+		return current, nil
+	}
+
+	entries, err := os.ReadDir(d.clientAllocDir)
+	if err != nil {
+		return 0, err
+	}
+	inUse := make(map[uint32]struct{}, len(entries))
+	for _, entry := range entries {
+		path := filepath.Join(d.clientAllocDir, entry.Name())
+		if !entry.IsDir() || path == d.AllocDir {
+			continue
+		}
+		id, err := dirProjectID(path)
+		if err != nil {
+			// The alloc dir may have been destroyed concurrently
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return 0, err
+		}
+		inUse[id] = struct{}{}
+	}
+
+	id := d.projectID()
+	for i := 0; i < len(inUse)+1; i++ {
+		if _, ok := inUse[id]; !ok {
+			return id, nil
+		}
+		id = projectIDBase | ((id + 1) &^ projectIDBase)
+	}
+	return 0, fmt.Errorf("no project ID available for alloc dir")
+}
+
+// diskQuotaProjectID returns the project ID the quota of the alloc dir was set
+// for: the one chosen by SetDiskQuota, otherwise the one assigned to the alloc
+// dir before the client restarted, falling back to projectID once the alloc
+// dir is destroyed.
+func (d *AllocDir) diskQuotaProjectID() uint32 {
+	d.mu.RLock()
+	id := d.quotaProjectID
+	d.mu.RUnlock()
+	if id != 0 {
+		return id
+	}
+
+	if id, err := dirProjectID(d.AllocDir); err == nil && id >= projectIDBase {
+		return id
+	}
+	return d.projectID()
+}
+
+// SetDiskQuota limits the disk space used by the files of the alloc dir to
+// sizeMB. The limit is enforced by the filesystem with a project quota, so
+// writes that would exceed it fail. ErrDiskQuotaUnsupported is returned if
+// project quotas aren't available.
+func (d *AllocDir) SetDiskQuota(sizeMB int) error {
+	projectIDLock.Lock()
+	defer projectIDLock.Unlock()
+
+	id, err := d.chooseProjectID()
+	if err != nil {
+		return err
+	}
+	if err := setProjectQuota(d.clientAllocDir, d.AllocDir, id, uint64(sizeMB)*1024*1024); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.quotaProjectID = id
+	d.mu.Unlock()
+	return nil
+}
+
+// DiskQuotaUsage returns the disk space in bytes used by the files of the
+// alloc dir, as accounted by the quota set with SetDiskQuota.
+func (d *AllocDir) DiskQuotaUsage() (uint64, error) {
+	return projectQuotaUsage(d.clientAllocDir, d.diskQuotaProjectID())
+}
+
+// RemoveDiskQuota removes the limit set with SetDiskQuota. It's safe to call
+// after the alloc dir has been destroyed.
+func (d *AllocDir) RemoveDiskQuota() error {
+	return removeProjectQuota(d.clientAllocDir, d.diskQuotaProjectID())
+}
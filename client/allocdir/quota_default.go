@@ -0,0 +1,24 @@
+//go:build !linux
+// +build !linux
+
+package allocdir
+
+// setProjectQuota is not supported on this platform.
+func setProjectQuota(mountDir, dir string, projectID uint32, limit uint64) error {
+	return ErrDiskQuotaUnsupported
+}
+
+// projectQuotaUsage is not supported on this platform.
+func projectQuotaUsage(mountDir string, projectID uint32) (uint64, error) {
+	return 0, ErrDiskQuotaUnsupported
+}
+
+// removeProjectQuota is not supported on this platform.
+func removeProjectQuota(mountDir string, projectID uint32) error {
+	return ErrDiskQuotaUnsupported
+}
+
+// dirProjectID is not supported on this platform.
+func dirProjectID(dir string) (uint32, error) {
+	return 0, ErrDiskQuotaUnsupported
+}
@@ -0,0 +1,282 @@
+package allocdir
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// The project quota ioctls, flags and quotactl commands aren't defined by the
+// version of x/sys/unix used by Nomad. The ioctl numbers use the generic
+// encoding shared by the x86 and arm architectures, other architectures
+// report project quotas as unsupported.
+const (
+	// fsIocFsGetXattr and fsIocFsSetXattr are FS_IOC_FSGETXATTR and
+	// FS_IOC_FSSETXATTR, which read and write the project ID of a file.
+	fsIocFsGetXattr = 0x801c581f
+	fsIocFsSetXattr = 0x401c5820
+
+	// fsXflagProjInherit is FS_XFLAG_PROJINHERIT, which makes the files
+	// created in a directory inherit its project ID.
+	fsXflagProjInherit = 0x200
+
+	// qGetQuota and qSetQuota are the Q_GETQUOTA and Q_SETQUOTA quotactl
+	// commands, applied to project quotas.
+	qGetQuota = 0x800007
+	qSetQuota = 0x800008
+	prjQuota  = 2
+
+	// qifBlimits is QIF_BLIMITS, which marks the block limits of a quota as
+	// valid.
+	qifBlimits = 1
+
+	// quotaBlockSize is the size of the blocks quota limits are expressed in.
+	quotaBlockSize = 1024
+)
+
+// fsxattr is the struct fsxattr used by the FS_IOC_FSGETXATTR and
+// FS_IOC_FSSETXATTR ioctls.
+type fsxattr struct {
+	xflags     uint32
+	extsize    uint32
+	nextents   uint32
+	projid     uint32
+	cowextsize uint32
+	pad        [8]byte
+}
+
+// dqblk is the struct if_dqblk used by quotactl.
+type dqblk struct {
+	bhardlimit uint64
+	bsoftlimit uint64
+	curspace   uint64
+	ihardlimit uint64
+	isoftlimit uint64
+	curinodes  uint64
+	btime      uint64
+	itime      uint64
+	valid      uint32
+}
+
+// setProjectQuota limits the disk space used by the files of dir, which is
+// on the same filesystem as mountDir, to limit bytes. The files of dir are
+// assigned the project ID and new files inherit it.
+func setProjectQuota(mountDir, dir string, projectID uint32, limit uint64) error {
+	device, err := quotaDevice(mountDir)
+	if err != nil {
+		return err
+	}
+
+	blocks := (limit + quotaBlockSize - 1) / quotaBlockSize
+	quota := dqblk{
+		bhardlimit: blocks,
+		bsoftlimit: blocks,
+		valid:      qifBlimits,
+	}
+	if err := quotactl(qSetQuota, device, projectID, unsafe.Pointer(&quota)); err != nil {
+		return quotaError("failed to set project quota", err)
+	}
+
+	// Skip assigning the project to the files if it was already done before
+	// the client restarted, which is tracked by the project of dir being set
+	// last.
+	attr, err := getFsxattr(dir)
+	if err != nil {
+		return quotaError("failed to get project of alloc dir", err)
+	}
+	if attr.projid == projectID && attr.xflags&fsXflagProjInherit != 0 {
+		return nil
+	}
+
+	var st unix.Stat_t
+	if err := unix.Stat(dir, &st); err != nil {
+		return err
+	}
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir || !(entry.IsDir() || entry.Type().IsRegular()) {
+			return nil
+		}
+
+		// Skip the mounts in the alloc dir, such as the secrets dirs
+		if entry.IsDir() {
+			var entrySt unix.Stat_t
+			if err := unix.Lstat(path, &entrySt); err != nil {
+				return err
+			}
+			if entrySt.Dev != st.Dev {
+				return filepath.SkipDir
+			}
+		}
+		return setProject(path, projectID, entry.IsDir())
+	})
+	if err != nil {
+		return quotaError("failed to set project of alloc dir", err)
+	}
+	if err := setProject(dir, projectID, true); err != nil {
+		return quotaError("failed to set project of alloc dir", err)
+	}
+	return nil
+}
+
+// dirProjectID returns the project ID the files created in dir inherit, or
+// zero if they don't inherit one.
+func dirProjectID(dir string) (uint32, error) {
+	attr, err := getFsxattr(dir)
+	if err != nil {
+		return 0, quotaError("failed to get project of dir", err)
+	}
+	if attr.xflags&fsXflagProjInherit == 0 {
+		return 0, nil
+	}
+	return attr.projid, nil
+}
+
+// projectQuotaUsage returns the disk space in bytes accounted to the project
+// on the filesystem of mountDir.
+func projectQuotaUsage(mountDir string, projectID uint32) (uint64, error) {
+	device, err := quotaDevice(mountDir)
+	if err != nil {
+		return 0, err
+	}
+
+	var quota dqblk
+	if err := quotactl(qGetQuota, device, projectID, unsafe.Pointer(&quota)); err != nil {
+		return 0, quotaError("failed to get project quota", err)
+	}
+	return quota.curspace, nil
+}
+
+// removeProjectQuota removes the limits of the project on the filesystem of
+// mountDir.
+func removeProjectQuota(mountDir string, projectID uint32) error {
+	device, err := quotaDevice(mountDir)
+	if err != nil {
+		return err
+	}
+
+	quota := dqblk{valid: qifBlimits}
+	if err := quotactl(qSetQuota, device, projectID, unsafe.Pointer(&quota)); err != nil {
+		return quotaError("failed to remove project quota", err)
+	}
+	return nil
+}
+
+// quotaDevice returns the block device of the filesystem holding path, which
+// must be an ext4 or xfs filesystem to support project quotas.
+func quotaDevice(path string) (string, error) {
+	var st unix.Stat_t
+	if err := unix.Stat(path, &st); err != nil {
+		return "", err
+	}
+	dev := fmt.Sprintf("%d:%d", unix.Major(st.Dev), unix.Minor(st.Dev))
+
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	// The fields of mountinfo are described in proc(5), the optional fields
+	// are terminated by a single hyphen and followed by the filesystem type
+	// and the mount source.
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[2] != dev {
+			continue
+		}
+		for i, field := range fields {
+			if field != "-" || len(fields) < i+3 {
+				continue
+			}
+			fsType, source := fields[i+1], fields[i+2]
+			if fsType != "ext4" && fsType != "xfs" {
+				return "", fmt.Errorf("%w: %s filesystem", ErrDiskQuotaUnsupported, fsType)
+			}
+			return source, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%w: no mount found for %s", ErrDiskQuotaUnsupported, path)
+}
+
+func quotactl(cmd int, device string, projectID uint32, addr unsafe.Pointer) error {
+	p, err := unix.BytePtrFromString(device)
+	if err != nil {
+		return err
+	}
+	_, _, errno := unix.Syscall6(unix.SYS_QUOTACTL, uintptr(cmd<<8|prjQuota),
+		uintptr(unsafe.Pointer(p)), uintptr(projectID), uintptr(addr), 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func getFsxattr(path string) (*fsxattr, error) {
+	fd, err := unix.Open(path, unix.O_RDONLY|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer unix.Close(fd)
+
+	var attr fsxattr
+	if err := fsxattrIoctl(fd, fsIocFsGetXattr, &attr); err != nil {
+		return nil, err
+	}
+	return &attr, nil
+}
+
+// setProject assigns the project ID to the file at path. Directories are
+// flagged so their new files inherit it.
+func setProject(path string, projectID uint32, dir bool) error {
+	fd, err := unix.Open(path, unix.O_RDONLY|unix.O_NOFOLLOW|unix.O_CLOEXEC|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	var attr fsxattr
+	if err := fsxattrIoctl(fd, fsIocFsGetXattr, &attr); err != nil {
+		return err
+	}
+	attr.projid = projectID
+	if dir {
+		attr.xflags |= fsXflagProjInherit
+	}
+	return fsxattrIoctl(fd, fsIocFsSetXattr, &attr)
+}
+
+func fsxattrIoctl(fd int, req uintptr, attr *fsxattr) error {
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), req, uintptr(unsafe.Pointer(attr))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// quotaError wraps errors caused by the filesystem not supporting project
+// quotas, or by the client not being allowed to manage them, with
+// ErrDiskQuotaUnsupported.
+func quotaError(msg string, err error) error {
+	var errno unix.Errno
+	if errors.As(err, &errno) {
+		switch errno {
+		case unix.ENOSYS, unix.ENOTTY, unix.EOPNOTSUPP, unix.EPERM,
+			unix.ESRCH, unix.ENOTBLK, unix.EINVAL:
+			return fmt.Errorf("%w: %s: %v", ErrDiskQuotaUnsupported, msg, err)
+		}
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
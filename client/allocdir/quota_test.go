@@ -0,0 +1,92 @@
+package allocdir
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocDir_ProjectID(t *testing.T) {
+	ci.Parallel(t)
+
+	allocID := uuid.Generate()
+	d1 := NewAllocDir(testlog.HCLogger(t), t.TempDir(), allocID)
+	d2 := NewAllocDir(testlog.HCLogger(t), t.TempDir(), allocID)
+	other := NewAllocDir(testlog.HCLogger(t), t.TempDir(), uuid.Generate())
+
+	// The project ID only depends on the alloc ID so it's the same after the
+	// client restarts
+	require.Equal(t, d1.projectID(), d2.projectID())
+	require.NotEqual(t, d1.projectID(), other.projectID())
+	require.GreaterOrEqual(t, d1.projectID(), uint32(projectIDBase))
+}
+
+func TestAllocDir_DiskQuota(t *testing.T) {
+	ci.Parallel(t)
+
+	d := NewAllocDir(testlog.HCLogger(t), t.TempDir(), uuid.Generate())
+	require.NoError(t, d.Build())
+	defer d.Destroy()
+
+	err := d.SetDiskQuota(1)
+	if errors.Is(err, ErrDiskQuotaUnsupported) {
+		t.Skipf("project quotas are not supported: %v", err)
+	}
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, d.RemoveDiskQuota())
+	}()
+
+	// Writing more than the quota fails
+	f, err := os.Create(filepath.Join(d.SharedDir, "data", "file"))
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = f.Write(make([]byte, 2*1024*1024))
+	require.Error(t, err)
+
+	used, err := d.DiskQuotaUsage()
+	require.NoError(t, err)
+	require.NotZero(t, used)
+}
+
+func TestAllocDir_DiskQuota_ProjectIDCollision(t *testing.T) {
+	ci.Parallel(t)
+
+	clientAllocDir := t.TempDir()
+	d1 := NewAllocDir(testlog.HCLogger(t), clientAllocDir, uuid.Generate())
+	require.NoError(t, d1.Build())
+	defer d1.Destroy()
+	d2 := NewAllocDir(testlog.HCLogger(t), clientAllocDir, uuid.Generate())
+	require.NoError(t, d2.Build())
+	defer d2.Destroy()
+
+	// Assign the preferred project ID of d2 to d1 as if their alloc IDs
+	// hashed to the same project ID
+	err := setProjectQuota(clientAllocDir, d1.AllocDir, d2.projectID(), 1024*1024)
+	if errors.Is(err, ErrDiskQuotaUnsupported) {
+		t.Skipf("project quotas are not supported: %v", err)
+	}
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, d1.RemoveDiskQuota())
+	}()
+
+	// d2 must not share the project ID, and its quota must not be accounted
+	// against d1
+	require.NoError(t, d2.SetDiskQuota(1))
+	defer func() {
+		require.NoError(t, d2.RemoveDiskQuota())
+	}()
+	require.NotEqual(t, d2.projectID(), d2.diskQuotaProjectID())
+	require.Equal(t, d2.projectID(), d1.diskQuotaProjectID())
+
+	// The project ID is kept after the client restarts
+	restored := NewAllocDir(testlog.HCLogger(t), clientAllocDir, filepath.Base(d2.AllocDir))
+	require.Equal(t, d2.diskQuotaProjectID(), restored.diskQuotaProjectID())
+}
@@ -277,10 +277,11 @@ func (t *Tracker) watchTaskEvents() {
 		// Detect if the alloc is unhealthy or if all tasks have started yet
 		latestStartTime := time.Time{}
 		for taskName, state := range alloc.TaskStates {
-			// If the task is a poststop task we do not want to evaluate it
-			// since it will remain pending until the main task has finished
-			// or exited.
-			if t.lifecycleTasks[taskName] == structs.TaskLifecycleHookPoststop {
+			// If the task is a prestop or poststop task we do not want to
+			// evaluate it since it will remain pending until the allocation
+			// is stopped or the main task has finished or exited.
+			if hook := t.lifecycleTasks[taskName]; hook == structs.TaskLifecycleHookPrestop ||
+				hook == structs.TaskLifecycleHookPoststop {
 				continue
 			}
 
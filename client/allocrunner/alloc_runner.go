@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	"github.com/hashicorp/nomad/client/allocrunner/state"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/getter"
 	"github.com/hashicorp/nomad/client/allocwatcher"
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/consul"
@@ -22,6 +23,7 @@ import (
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	"github.com/hashicorp/nomad/client/pluginmanager/taskhookmanager"
 	cstate "github.com/hashicorp/nomad/client/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/vaultclient"
@@ -76,6 +78,12 @@ type allocRunner struct {
 	// vaultClient is the used to manage Vault tokens
 	vaultClient vaultclient.VaultClient
 
+	// vaultClusters are the clients of the named Vault clusters
+	vaultClusters map[string]vaultclient.VaultClient
+
+	// identitySigner is used to request the workload identities of tasks
+	identitySigner cinterfaces.IdentitySigner
+
 	// waitCh is closed when the Run loop has exited
 	waitCh chan struct{}
 
@@ -164,6 +172,12 @@ type allocRunner struct {
 	// event handlers
 	driverManager drivermanager.Manager
 
+	// taskHookManager is responsible for dispensing task hook plugins
+	taskHookManager taskhookmanager.Manager
+
+	// artifactCache is the cache of artifacts passed to the task runners
+	artifactCache *getter.Cache
+
 	// serversContactedCh is passed to TaskRunners so they can detect when
 	// servers have been contacted for the first time in case of a failed
 	// restore.
@@ -200,6 +214,8 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 		consulProxiesClient:      config.ConsulProxies,
 		sidsClient:               config.ConsulSI,
 		vaultClient:              config.Vault,
+		vaultClusters:            config.VaultClusters,
+		identitySigner:           config.IdentitySigner,
 		tasks:                    make(map[string]*taskrunner.TaskRunner, len(tg.Tasks)),
 		waitCh:                   make(chan struct{}),
 		destroyCh:                make(chan struct{}),
@@ -218,6 +234,8 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 		cpusetManager:            config.CpusetManager,
 		devicemanager:            config.DeviceManager,
 		driverManager:            config.DriverManager,
+		taskHookManager:          config.TaskHookManager,
+		artifactCache:            config.ArtifactCache,
 		serversContactedCh:       config.ServersContactedCh,
 		rpcClient:                config.RPCClient,
 	}
@@ -266,10 +284,14 @@ func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
 			ConsulProxies:        ar.consulProxiesClient,
 			ConsulSI:             ar.sidsClient,
 			Vault:                ar.vaultClient,
+			VaultClusters:        ar.vaultClusters,
+			IdentitySigner:       ar.identitySigner,
 			DeviceStatsReporter:  ar.deviceStatsReporter,
 			CSIManager:           ar.csiManager,
 			DeviceManager:        ar.devicemanager,
 			DriverManager:        ar.driverManager,
+			TaskHookManager:      ar.taskHookManager,
+			ArtifactCache:        ar.artifactCache,
 			ServersContactedCh:   ar.serversContactedCh,
 			StartConditionMetCtx: ar.taskHookCoordinator.startConditionForTask(task),
 			ShutdownDelayCtx:     ar.shutdownDelayCtx,
@@ -385,6 +407,22 @@ func (ar *allocRunner) runTasks() {
 	// Signal poststop tasks to proceed to main runtime
 	ar.taskHookCoordinator.StartPoststopTasks()
 
+	// Poststop tasks that only run on failure are skipped unless a main task
+	// failed
+	if ar.mainTasksFailed() {
+		ar.taskHookCoordinator.StartPoststopFailureTasks()
+	} else {
+		for name, tr := range ar.tasks {
+			if lc := tr.Task().Lifecycle; tr.IsPoststopTask() && lc.OnFailure {
+				taskEvent := structs.NewTaskEvent(structs.TaskKilling).
+					SetKillReason("main tasks didn't fail")
+				if err := tr.Kill(context.TODO(), taskEvent); err != nil && err != taskrunner.ErrTaskNotRunning {
+					ar.logger.Warn("error skipping poststop task", "error", err, "task_name", name)
+				}
+			}
+		}
+	}
+
 	// Wait for poststop tasks to finish before proceeding
 	for _, task := range ar.tasks {
 		if task.IsPoststopTask() {
@@ -393,6 +431,16 @@ func (ar *allocRunner) runTasks() {
 	}
 }
 
+// mainTasksFailed returns whether any of the main tasks failed.
+func (ar *allocRunner) mainTasksFailed() bool {
+	for _, tr := range ar.tasks {
+		if tr.Task().Lifecycle == nil && tr.TaskState().Failed {
+			return true
+		}
+	}
+	return false
+}
+
 // Alloc returns the current allocation being run by this runner as sent by the
 // server. This view of the allocation does not have updated task states.
 func (ar *allocRunner) Alloc() *structs.Allocation {
@@ -591,9 +639,10 @@ func (ar *allocRunner) handleTaskStateUpdates() {
 	}
 }
 
-// killTasks kills all task runners, leader (if there is one) first. Errors are
-// logged except taskrunner.ErrTaskNotRunning which is ignored. Task states
-// after Kill has been called are returned.
+// killTasks kills all task runners, leader (if there is one) first, after
+// running the prestop tasks. Errors are logged except
+// taskrunner.ErrTaskNotRunning which is ignored. Task states after Kill has
+// been called are returned.
 func (ar *allocRunner) killTasks() map[string]*structs.TaskState {
 	var mu sync.Mutex
 	states := make(map[string]*structs.TaskState, len(ar.tasks))
@@ -601,6 +650,9 @@ func (ar *allocRunner) killTasks() map[string]*structs.TaskState {
 	// run alloc prekill hooks
 	ar.preKillHooks()
 
+	// run prestop tasks before any other task is killed
+	ar.runPrestopTasks()
+
 	// Kill leader first, synchronously
 	for name, tr := range ar.tasks {
 		if !tr.IsLeader() {
@@ -648,6 +700,49 @@ func (ar *allocRunner) killTasks() map[string]*structs.TaskState {
 	return states
 }
 
+// runPrestopTasks starts the prestop tasks and waits for them to finish, for
+// at most their kill timeout, if any main task is still running. Prestop
+// tasks still running afterwards are killed along with the other tasks.
+func (ar *allocRunner) runPrestopTasks() {
+	var prestop, main []*taskrunner.TaskRunner
+	for _, tr := range ar.tasks {
+		if tr.IsPrestopTask() {
+			prestop = append(prestop, tr)
+		} else if tr.Task().Lifecycle == nil && tr.TaskState().State == structs.TaskStateRunning {
+			main = append(main, tr)
+		}
+	}
+	if len(prestop) == 0 || len(main) == 0 {
+		return
+	}
+
+	var timeout time.Duration
+	for _, tr := range prestop {
+		if kt := tr.Task().KillTimeout; kt > timeout {
+			timeout = kt
+		}
+	}
+
+	for _, tr := range main {
+		tr.EmitEvent(structs.NewTaskEvent(structs.TaskRunningPrestop))
+	}
+	ar.logger.Debug("running prestop tasks", "timeout", timeout)
+	ar.taskHookCoordinator.StartPrestopTasks()
+
+	timer, stop := helper.NewSafeTimer(timeout)
+	defer stop()
+	for _, tr := range prestop {
+		select {
+		case <-tr.WaitCh():
+		case <-timer.C:
+			ar.logger.Warn("prestop tasks didn't finish before their kill timeout")
+			return
+		case <-ar.shutdownCh:
+			return
+		}
+	}
+}
+
 // clientAlloc takes in the task states and returns an Allocation populated
 // with Client specific fields
 func (ar *allocRunner) clientAlloc(taskStates map[string]*structs.TaskState) *structs.Allocation {
@@ -1128,6 +1223,20 @@ func (ar *allocRunner) LatestAllocStats(taskFilter string) (*cstructs.AllocResou
 	return astat, nil
 }
 
+// EmitTaskEvent emits the event to all the tasks of the allocation.
+func (ar *allocRunner) EmitTaskEvent(event *structs.TaskEvent) {
+	for _, tr := range ar.tasks {
+		tr.EmitEvent(event)
+	}
+}
+
+// EmitTaskEventFor emits an event to a single task of the allocation.
+func (ar *allocRunner) EmitTaskEventFor(taskName string, event *structs.TaskEvent) {
+	if tr, ok := ar.tasks[taskName]; ok {
+		tr.EmitEvent(event)
+	}
+}
+
 func (ar *allocRunner) GetTaskEventHandler(taskName string) drivermanager.EventHandler {
 	if tr, ok := ar.tasks[taskName]; ok {
 		return func(ev *drivers.TaskEvent) {
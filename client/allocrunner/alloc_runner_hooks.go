@@ -133,6 +133,12 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 		return fmt.Errorf("failed to initialize network configurator: %v", err)
 	}
 
+	// build the networks of tasks with their own network namespace
+	tns, err := newTaskNetworks(hookLogger, ar.Alloc(), ar.driverManager, config)
+	if err != nil {
+		return fmt.Errorf("failed to configure task networks: %v", err)
+	}
+
 	// Create a new taskenv.Builder which is used and mutated by networkHook.
 	envBuilder := taskenv.NewBuilder(
 		config.Node, ar.Alloc(), nil, config.Region).SetAllocDir(ar.allocDir.AllocDir)
@@ -146,11 +152,11 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 	alloc := ar.Alloc()
 	ar.runnerHooks = []interfaces.RunnerHook{
 		newAllocDirHook(hookLogger, ar.allocDir),
-		newCgroupHook(ar.Alloc(), ar.cpusetManager),
+		newCgroupHook(ar.Alloc(), ar.cpusetManager, ar),
 		newUpstreamAllocsHook(hookLogger, ar.prevAllocWatcher),
 		newDiskMigrationHook(hookLogger, ar.prevAllocMigrator, ar.allocDir),
 		newAllocHealthWatcherHook(hookLogger, alloc, hs, ar.Listener(), ar.consulClient),
-		newNetworkHook(hookLogger, ns, alloc, nm, nc, ar, builtTaskEnv),
+		newNetworkHook(hookLogger, ns, alloc, nm, nc, ar, builtTaskEnv, tns),
 		newGroupServiceHook(groupServiceHookConfig{
 			alloc:               alloc,
 			consul:              ar.consulClient,
@@ -166,6 +172,12 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 		newCSIHook(alloc, hookLogger, ar.csiManager, ar.rpcClient, ar, hrs, ar.clientConfig.Node.SecretID),
 	}
 
+	// Enforce the ephemeral disk size once the previous allocation's data has
+	// been migrated, as files can't be moved across project quotas.
+	if config.EphemeralDiskEnforcement == clientconfig.EphemeralDiskEnforcementHard {
+		ar.runnerHooks = append(ar.runnerHooks, newDiskQuotaHook(hookLogger, alloc, ar.allocDir, ar))
+	}
+
 	return nil
 }
 
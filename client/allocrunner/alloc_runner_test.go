@@ -480,6 +480,207 @@ func TestAllocRunner_Lifecycle_Poststop(t *testing.T) {
 
 }
 
+func TestAllocRunner_Lifecycle_PoststopOnFailure(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name     string
+		exitCode int
+		ran      bool
+	}{
+		{name: "main task failed", exitCode: 1, ran: true},
+		{name: "main task succeeded", exitCode: 0, ran: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			alloc := mock.LifecycleAlloc()
+			tr := alloc.AllocatedResources.Tasks[alloc.Job.TaskGroups[0].Tasks[0].Name]
+			alloc.Job.TaskGroups[0].RestartPolicy.Attempts = 0
+
+			mainTask := alloc.Job.TaskGroups[0].Tasks[0]
+			mainTask.RestartPolicy = alloc.Job.TaskGroups[0].RestartPolicy
+			mainTask.Config["run_for"] = "100ms"
+			mainTask.Config["exit_code"] = tc.exitCode
+
+			poststopTask := alloc.Job.TaskGroups[0].Tasks[1]
+			poststopTask.Name = "diagnostics"
+			poststopTask.Lifecycle.Hook = structs.TaskLifecycleHookPoststop
+			poststopTask.Lifecycle.OnFailure = true
+			poststopTask.Config["run_for"] = "100ms"
+
+			alloc.Job.TaskGroups[0].Tasks = []*structs.Task{mainTask, poststopTask}
+			alloc.AllocatedResources.Tasks = map[string]*structs.AllocatedTaskResources{
+				mainTask.Name:     tr,
+				poststopTask.Name: tr,
+			}
+
+			conf, cleanup := testAllocRunnerConfig(t, alloc)
+			defer cleanup()
+			ar, err := NewAllocRunner(conf)
+			require.NoError(t, err)
+			defer destroy(ar)
+			go ar.Run()
+
+			select {
+			case <-ar.WaitCh():
+			case <-time.After(10 * time.Second):
+				t.Fatalf("timed out waiting for alloc to finish")
+			}
+
+			states := ar.AllocState().TaskStates
+			require.Equal(t, structs.TaskStateDead, states[poststopTask.Name].State)
+			require.Equal(t, tc.ran, !states[poststopTask.Name].StartedAt.IsZero())
+		})
+	}
+}
+
+func TestAllocRunner_Lifecycle_Prestop(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.LifecycleAlloc()
+	tr := alloc.AllocatedResources.Tasks[alloc.Job.TaskGroups[0].Tasks[0].Name]
+
+	alloc.Job.Type = structs.JobTypeService
+	mainTask := alloc.Job.TaskGroups[0].Tasks[0]
+	mainTask.Config["run_for"] = "100s"
+
+	prestopTask := alloc.Job.TaskGroups[0].Tasks[1]
+	prestopTask.Name = "deregister"
+	prestopTask.Lifecycle.Hook = structs.TaskLifecycleHookPrestop
+	prestopTask.Config["run_for"] = "500ms"
+	prestopTask.KillTimeout = 10 * time.Second
+
+	alloc.Job.TaskGroups[0].Tasks = []*structs.Task{mainTask, prestopTask}
+	alloc.AllocatedResources.Tasks = map[string]*structs.AllocatedTaskResources{
+		mainTask.Name:    tr,
+		prestopTask.Name: tr,
+	}
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+	ar, err := NewAllocRunner(conf)
+	require.NoError(t, err)
+	defer destroy(ar)
+	go ar.Run()
+
+	upd := conf.StateUpdater.(*MockStateUpdater)
+
+	// Wait for main task to be running while the prestop task is pending
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last == nil {
+			return false, fmt.Errorf("No updates")
+		}
+
+		if last.ClientStatus != structs.AllocClientStatusRunning {
+			return false, fmt.Errorf("expected alloc to be running not %s", last.ClientStatus)
+		}
+
+		if s := last.TaskStates[mainTask.Name].State; s != structs.TaskStateRunning {
+			return false, fmt.Errorf("expected main task to be running not %s", s)
+		}
+
+		if s := last.TaskStates[prestopTask.Name].State; s != structs.TaskStatePending {
+			return false, fmt.Errorf("expected prestop task to be pending not %s", s)
+		}
+
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("error waiting for initial state:\n%v", err)
+	})
+
+	// Tell the alloc to stop
+	stopAlloc := alloc.Copy()
+	stopAlloc.DesiredStatus = structs.AllocDesiredStatusStop
+	ar.Update(stopAlloc)
+
+	// Wait for both tasks to be dead
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+
+		for _, name := range []string{mainTask.Name, prestopTask.Name} {
+			if s := last.TaskStates[name].State; s != structs.TaskStateDead {
+				return false, fmt.Errorf("expected task %s to be dead not %s", name, s)
+			}
+		}
+
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("error waiting for tasks to stop:\n%v", err)
+	})
+
+	// The prestop task ran to completion before the main task was killed
+	last := upd.Last()
+	prestopState := last.TaskStates[prestopTask.Name]
+	mainState := last.TaskStates[mainTask.Name]
+	require.True(t, prestopState.Successful())
+	require.False(t, prestopState.StartedAt.IsZero())
+
+	var prestopEvent, killingEvent *structs.TaskEvent
+	for _, ev := range mainState.Events {
+		switch ev.Type {
+		case structs.TaskRunningPrestop:
+			prestopEvent = ev
+		case structs.TaskKilling:
+			killingEvent = ev
+		}
+	}
+	require.NotNil(t, prestopEvent)
+	require.NotNil(t, killingEvent)
+	require.LessOrEqual(t, prestopState.FinishedAt.UnixNano(), killingEvent.Time)
+}
+
+func TestAllocRunner_Lifecycle_Prestop_NotStopped(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.LifecycleAlloc()
+	tr := alloc.AllocatedResources.Tasks[alloc.Job.TaskGroups[0].Tasks[0].Name]
+
+	alloc.Job.Type = structs.JobTypeBatch
+	mainTask := alloc.Job.TaskGroups[0].Tasks[0]
+	mainTask.Config["run_for"] = "100ms"
+
+	prestopTask := alloc.Job.TaskGroups[0].Tasks[1]
+	prestopTask.Name = "deregister"
+	prestopTask.Lifecycle.Hook = structs.TaskLifecycleHookPrestop
+	prestopTask.Config["run_for"] = "100ms"
+
+	alloc.Job.TaskGroups[0].Tasks = []*structs.Task{mainTask, prestopTask}
+	alloc.AllocatedResources.Tasks = map[string]*structs.AllocatedTaskResources{
+		mainTask.Name:    tr,
+		prestopTask.Name: tr,
+	}
+
+	conf, cleanup := testAllocRunnerConfig(t, alloc)
+	defer cleanup()
+	ar, err := NewAllocRunner(conf)
+	require.NoError(t, err)
+	defer destroy(ar)
+	go ar.Run()
+
+	upd := conf.StateUpdater.(*MockStateUpdater)
+
+	// The alloc completes once the main task exits, without running the
+	// prestop task
+	testutil.WaitForResult(func() (bool, error) {
+		last := upd.Last()
+		if last == nil {
+			return false, fmt.Errorf("No updates")
+		}
+
+		if last.ClientStatus != structs.AllocClientStatusComplete {
+			return false, fmt.Errorf("expected alloc to be complete not %s", last.ClientStatus)
+		}
+
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("error waiting for alloc to complete:\n%v", err)
+	})
+
+	require.True(t, upd.Last().TaskStates[prestopTask.Name].StartedAt.IsZero())
+}
+
 func TestAllocRunner_TaskGroup_ShutdownDelay(t *testing.T) {
 	ci.Parallel(t)
 
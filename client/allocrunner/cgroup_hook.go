@@ -1,20 +1,29 @@
 package allocrunner
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
-func newCgroupHook(alloc *structs.Allocation, man cgutil.CpusetManager) *cgroupHook {
+// singleTaskEventEmitter emits an event to a single task of an allocation.
+type singleTaskEventEmitter interface {
+	EmitTaskEventFor(taskName string, event *structs.TaskEvent)
+}
+
+func newCgroupHook(alloc *structs.Allocation, man cgutil.CpusetManager, emitter singleTaskEventEmitter) *cgroupHook {
 	return &cgroupHook{
 		alloc:         alloc,
 		cpusetManager: man,
+		emitter:       emitter,
 	}
 }
 
 type cgroupHook struct {
 	alloc         *structs.Allocation
 	cpusetManager cgutil.CpusetManager
+	emitter       singleTaskEventEmitter
 }
 
 func (c *cgroupHook) Name() string {
@@ -23,9 +32,30 @@ func (c *cgroupHook) Name() string {
 
 func (c *cgroupHook) Prerun() error {
 	c.cpusetManager.AddAlloc(c.alloc)
+	c.emitCrossNUMAEvents()
 	return nil
 }
 
+// emitCrossNUMAEvents emits an event to the tasks whose memory is bound to
+// several NUMA nodes, as their reserved cores span these nodes and accessing
+// the memory of another node is slower.
+func (c *cgroupHook) emitCrossNUMAEvents() {
+	tg := c.alloc.Job.LookupTaskGroup(c.alloc.TaskGroup)
+	if tg == nil {
+		return
+	}
+
+	for _, task := range tg.Tasks {
+		nodes := c.cpusetManager.MemoryNodesFor(c.alloc.ID, task.Name)
+		if nodes.Size() < 2 {
+			continue
+		}
+		c.emitter.EmitTaskEventFor(task.Name, structs.NewTaskEvent(structs.TaskNUMACrossNode).
+			SetMessage(fmt.Sprintf("Reserved cores span NUMA nodes %s, memory accesses across nodes are slower", nodes)).
+			SetNUMANodes(nodes.String()))
+	}
+}
+
 func (c *cgroupHook) Postrun() error {
 	c.cpusetManager.RemoveAlloc(c.alloc.ID)
 	return nil
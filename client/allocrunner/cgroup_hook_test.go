@@ -0,0 +1,64 @@
+package allocrunner
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
+	"github.com/hashicorp/nomad/lib/cpuset"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// numaCpusetManager is a cpuset manager binding the memory of tasks to fixed
+// NUMA nodes.
+type numaCpusetManager struct {
+	cgutil.CpusetManager
+	memoryNodes map[string]cpuset.CPUSet
+}
+
+func (m *numaCpusetManager) MemoryNodesFor(allocID, task string) cpuset.CPUSet {
+	if nodes, ok := m.memoryNodes[task]; ok {
+		return nodes
+	}
+	return cpuset.New()
+}
+
+// recordingEmitter records the events emitted to each task.
+type recordingEmitter struct {
+	events map[string][]*structs.TaskEvent
+}
+
+func (e *recordingEmitter) EmitTaskEventFor(taskName string, event *structs.TaskEvent) {
+	e.events[taskName] = append(e.events[taskName], event)
+}
+
+func TestCgroupHook_CrossNUMAEvents(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	local := tg.Tasks[0].Copy()
+	local.Name = "local"
+	tg.Tasks = append(tg.Tasks, local)
+
+	man := &numaCpusetManager{
+		CpusetManager: cgutil.NoopCpusetManager(),
+		memoryNodes: map[string]cpuset.CPUSet{
+			tg.Tasks[0].Name: cpuset.New(0, 1),
+			local.Name:       cpuset.New(1),
+		},
+	}
+	emitter := &recordingEmitter{events: map[string][]*structs.TaskEvent{}}
+
+	hook := newCgroupHook(alloc, man, emitter)
+	require.NoError(t, hook.Prerun())
+
+	require.Len(t, emitter.events, 1)
+	events := emitter.events[tg.Tasks[0].Name]
+	require.Len(t, events, 1)
+	require.Equal(t, structs.TaskNUMACrossNode, events[0].Type)
+	require.Equal(t, "0-1", events[0].Details["numa_nodes"])
+	require.Contains(t, events[0].Message, "span NUMA nodes 0-1")
+}
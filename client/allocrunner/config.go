@@ -2,6 +2,7 @@ package allocrunner
 
 import (
 	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/getter"
 	"github.com/hashicorp/nomad/client/allocwatcher"
 	clientconfig "github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/consul"
@@ -11,6 +12,7 @@ import (
 	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	"github.com/hashicorp/nomad/client/pluginmanager/taskhookmanager"
 	cstate "github.com/hashicorp/nomad/client/state"
 	"github.com/hashicorp/nomad/client/vaultclient"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -43,6 +45,13 @@ type Config struct {
 	// Vault is the Vault client to use to retrieve Vault tokens
 	Vault vaultclient.VaultClient
 
+	// VaultClusters are the clients of the named Vault clusters, indexed by
+	// name
+	VaultClusters map[string]vaultclient.VaultClient
+
+	// IdentitySigner is used to request the workload identities of tasks
+	IdentitySigner interfaces.IdentitySigner
+
 	// StateUpdater is used to emit updated task state
 	StateUpdater interfaces.AllocStateHandler
 
@@ -70,6 +79,13 @@ type Config struct {
 	// DriverManager handles dispensing of driver plugins
 	DriverManager drivermanager.Manager
 
+	// TaskHookManager handles dispensing of task hook plugins
+	TaskHookManager taskhookmanager.Manager
+
+	// ArtifactCache is the cache of artifacts shared by the allocations of the
+	// client, or nil if artifacts aren't cached
+	ArtifactCache *getter.Cache
+
 	// CpusetManager configures the cpuset cgroup if supported by the platform
 	CpusetManager cgutil.CpusetManager
 
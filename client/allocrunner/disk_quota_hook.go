@@ -0,0 +1,143 @@
+package allocrunner
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// diskQuotaCheckInterval is the interval at which the disk space used by
+	// the allocation is compared to its quota.
+	diskQuotaCheckInterval = 10 * time.Second
+)
+
+// taskEventEmitter emits an event to all the tasks of an allocation.
+type taskEventEmitter interface {
+	EmitTaskEvent(event *structs.TaskEvent)
+}
+
+// diskQuotaHook enforces the ephemeral disk size of an allocation with a
+// filesystem project quota on its alloc dir, and emits a task event when the
+// allocation reaches it.
+type diskQuotaHook struct {
+	alloc    *structs.Allocation
+	allocDir *allocdir.AllocDir
+	emitter  taskEventEmitter
+	logger   log.Logger
+
+	// checkInterval is the interval at which the quota usage is checked,
+	// which is only changed by tests.
+	checkInterval time.Duration
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+func newDiskQuotaHook(logger log.Logger, alloc *structs.Allocation, allocDir *allocdir.AllocDir, emitter taskEventEmitter) *diskQuotaHook {
+	h := &diskQuotaHook{
+		alloc:         alloc,
+		allocDir:      allocDir,
+		emitter:       emitter,
+		checkInterval: diskQuotaCheckInterval,
+		stopCh:        make(chan struct{}),
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+func (h *diskQuotaHook) Name() string {
+	return "disk_quota"
+}
+
+func (h *diskQuotaHook) Prerun() error {
+	sizeMB := h.sizeMB()
+	if sizeMB == 0 {
+		return nil
+	}
+
+	err := h.allocDir.SetDiskQuota(sizeMB)
+	if errors.Is(err, allocdir.ErrDiskQuotaUnsupported) {
+		h.logger.Warn("ephemeral disk size can't be enforced on the alloc dir", "error", err)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to set disk quota: %v", err)
+	}
+
+	go h.watch(uint64(sizeMB) * 1024 * 1024)
+	return nil
+}
+
+// sizeMB returns the ephemeral disk size of the allocation.
+func (h *diskQuotaHook) sizeMB() int {
+	if h.alloc.AllocatedResources != nil {
+		return int(h.alloc.AllocatedResources.Shared.DiskMB)
+	}
+	if h.alloc.Resources != nil {
+		return h.alloc.Resources.DiskMB
+	}
+	return 0
+}
+
+// watch emits a task event each time the allocation reaches its quota of
+// limit bytes.
+func (h *diskQuotaHook) watch(limit uint64) {
+	ticker := time.NewTicker(h.checkInterval)
+	defer ticker.Stop()
+
+	exceeded := false
+	for {
+		select {
+		case <-h.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		used, err := h.allocDir.DiskQuotaUsage()
+		if err != nil {
+			h.logger.Warn("failed to get disk quota usage", "error", err)
+			continue
+		}
+
+		if used < limit {
+			exceeded = false
+			continue
+		}
+		if !exceeded {
+			exceeded = true
+			h.logger.Warn("allocation reached its disk quota", "used", used, "limit", limit)
+			h.emitter.EmitTaskEvent(structs.NewTaskEvent(structs.TaskDiskExceeded).
+				SetMessage(fmt.Sprintf("Allocation reached its ephemeral disk size of %d MB, writes are failing", limit/1024/1024)))
+		}
+	}
+}
+
+func (h *diskQuotaHook) stop() {
+	h.stopOnce.Do(func() {
+		close(h.stopCh)
+	})
+}
+
+func (h *diskQuotaHook) Postrun() error {
+	h.stop()
+	return nil
+}
+
+func (h *diskQuotaHook) Destroy() error {
+	h.stop()
+
+	err := h.allocDir.RemoveDiskQuota()
+	if err != nil && !errors.Is(err, allocdir.ErrDiskQuotaUnsupported) {
+		return err
+	}
+	return nil
+}
+
+func (h *diskQuotaHook) Shutdown() {
+	h.stop()
+}
@@ -0,0 +1,85 @@
+package allocrunner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// statically assert disk quota hook implements the expected interfaces
+var _ interfaces.RunnerPrerunHook = (*diskQuotaHook)(nil)
+var _ interfaces.RunnerPostrunHook = (*diskQuotaHook)(nil)
+var _ interfaces.RunnerDestroyHook = (*diskQuotaHook)(nil)
+var _ interfaces.ShutdownHook = (*diskQuotaHook)(nil)
+
+// mockTaskEventEmitter implements taskEventEmitter and stores the events
+type mockTaskEventEmitter struct {
+	lock   sync.Mutex
+	events []*structs.TaskEvent
+}
+
+func (m *mockTaskEventEmitter) EmitTaskEvent(event *structs.TaskEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.events = append(m.events, event)
+}
+
+func (m *mockTaskEventEmitter) Events() []*structs.TaskEvent {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.events
+}
+
+func TestDiskQuotaHook(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	alloc := mock.Alloc()
+	alloc.AllocatedResources.Shared.DiskMB = 1
+
+	allocDir := allocdir.NewAllocDir(logger, t.TempDir(), alloc.ID)
+	require.NoError(t, allocDir.Build())
+	defer allocDir.Destroy()
+
+	emitter := &mockTaskEventEmitter{}
+	h := newDiskQuotaHook(logger, alloc, allocDir, emitter)
+	h.checkInterval = 10 * time.Millisecond
+
+	// The hook doesn't fail the allocation if project quotas aren't
+	// supported by the filesystem
+	require.NoError(t, h.Prerun())
+	defer func() {
+		require.NoError(t, h.Postrun())
+		require.NoError(t, h.Destroy())
+	}()
+
+	if _, err := allocDir.DiskQuotaUsage(); errors.Is(err, allocdir.ErrDiskQuotaUnsupported) {
+		t.Skipf("project quotas are not supported: %v", err)
+	}
+
+	// Filling the ephemeral disk emits a task event
+	f, err := os.Create(filepath.Join(allocDir.SharedDir, "data", "file"))
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = f.Write(make([]byte, 2*1024*1024))
+	require.Error(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		return len(emitter.Events()) == 1, nil
+	}, func(err error) {
+		t.Fatalf("expected a task event")
+	})
+	require.Equal(t, structs.TaskDiskExceeded, emitter.Events()[0].Type)
+}
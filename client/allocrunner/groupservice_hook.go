@@ -38,6 +38,7 @@ type groupServiceHook struct {
 
 	// The following fields may be updated
 	canary         bool
+	blueGreenTag   string
 	services       []*structs.Service
 	networks       structs.Networks
 	ports          structs.AllocatedPorts
@@ -90,6 +91,7 @@ func newGroupServiceHook(cfg groupServiceHookConfig) *groupServiceHook {
 	if cfg.alloc.DeploymentStatus != nil {
 		h.canary = cfg.alloc.DeploymentStatus.Canary
 	}
+	h.blueGreenTag = cfg.alloc.BlueGreenTag()
 
 	return h
 }
@@ -145,6 +147,7 @@ func (h *groupServiceHook) Update(req *interfaces.RunnerUpdateRequest) error {
 	h.networks = networks
 	h.services = tg.Services
 	h.canary = canary
+	h.blueGreenTag = req.Alloc.BlueGreenTag()
 	h.delay = shutdown
 	h.taskEnvBuilder.UpdateTask(req.Alloc, nil)
 
@@ -220,6 +223,7 @@ func (h *groupServiceHook) deregister() {
 func (h *groupServiceHook) getWorkloadServices() *agentconsul.WorkloadServices {
 	// Interpolate with the task's environment
 	interpolatedServices := taskenv.InterpolateServices(h.taskEnvBuilder.Build(), h.services)
+	agentconsul.AddBlueGreenTag(interpolatedServices, h.blueGreenTag)
 
 	var netStatus *structs.AllocNetworkStatus
 	if h.networkStatusGetter != nil {
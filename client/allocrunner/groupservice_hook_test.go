@@ -211,6 +211,53 @@ func TestGroupServiceHook_getWorkloadServices(t *testing.T) {
 	require.Len(t, services.Services, 1)
 }
 
+// TestGroupServiceHook_BlueGreenTags asserts the services of blue/green
+// deployments are registered with the staged tag while the allocation is a
+// canary or staged, and with the live tag otherwise.
+func TestGroupServiceHook_BlueGreenTags(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Networks = []*structs.NetworkResource{}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	tg.Update = &structs.UpdateStrategy{Canary: 1, BlueGreen: true}
+	tg.Services = []*structs.Service{
+		{
+			Name:      "web",
+			PortLabel: "9999",
+			Tags:      []string{"http"},
+		},
+	}
+	alloc.DeploymentStatus = &structs.AllocDeploymentStatus{Canary: true}
+	logger := testlog.HCLogger(t)
+
+	consulClient := consul.NewMockConsulServiceClient(t, logger)
+
+	h := newGroupServiceHook(groupServiceHookConfig{
+		alloc:          alloc,
+		consul:         consulClient,
+		restarter:      agentconsul.NoopRestarter(),
+		taskEnvBuilder: taskenv.NewBuilder(mock.Node(), alloc, nil, alloc.Job.Region),
+		logger:         logger,
+	})
+	require.Equal(t, []string{"http", structs.BlueGreenStagedTag}, h.getWorkloadServices().Services[0].Tags)
+
+	// Promote the canary
+	alloc = alloc.Copy()
+	alloc.DeploymentStatus.Canary = false
+	require.NoError(t, h.Update(&interfaces.RunnerUpdateRequest{Alloc: alloc}))
+	require.Equal(t, []string{"http", structs.BlueGreenLiveTag}, h.getWorkloadServices().Services[0].Tags)
+
+	// Stage the allocation once replaced by a newer version
+	alloc = alloc.Copy()
+	alloc.DeploymentStatus.Staged = true
+	require.NoError(t, h.Update(&interfaces.RunnerUpdateRequest{Alloc: alloc}))
+	require.Equal(t, []string{"http", structs.BlueGreenStagedTag}, h.getWorkloadServices().Services[0].Tags)
+
+	// The job's services are left unmodified
+	require.Equal(t, []string{"http"}, tg.Services[0].Tags)
+}
+
 // TestGroupServiceHook_Update08Alloc asserts that adding group services to a previously
 // 0.8 alloc works.
 //
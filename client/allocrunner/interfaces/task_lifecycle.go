@@ -56,6 +56,10 @@ type TaskPrestartRequest struct {
 	// Vault token may optionally be set if a Vault token is available
 	VaultToken string
 
+	// VaultClusterTokens are the Vault tokens of the named Vault clusters
+	// available so far, indexed by the name of the cluster
+	VaultClusterTokens map[string]string
+
 	// TaskDir contains the task's directory tree on the host
 	TaskDir *allocdir.TaskDir
 
@@ -153,6 +157,10 @@ type TaskExitedHook interface {
 type TaskUpdateRequest struct {
 	VaultToken string
 
+	// VaultClusterTokens are the Vault tokens of the named Vault clusters,
+	// indexed by the name of the cluster
+	VaultClusterTokens map[string]string
+
 	// Alloc is the current version of the allocation (may have been
 	// updated since the hook was created)
 	Alloc *structs.Allocation
@@ -175,12 +183,17 @@ type TaskUpdateHook interface {
 }
 
 type TaskStopRequest struct {
-	// ExistingState is previously set hook data and should only be
-	// read. Stop hooks cannot alter state.
+	// ExistingState is previously set hook data. It should only be read;
+	// stop hooks alter state by setting State in the response.
 	ExistingState map[string]string
 }
 
-type TaskStopResponse struct{}
+type TaskStopResponse struct {
+	// State allows the hook to emit data to be passed in the next time it is
+	// run, such as when a dead task is restored after the client restarts.
+	// If nil, the existing state is kept.
+	State map[string]string
+}
 
 type TaskStopHook interface {
 	TaskHook
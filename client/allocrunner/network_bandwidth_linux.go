@@ -0,0 +1,91 @@
+package allocrunner
+
+import (
+	"fmt"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+)
+
+// setBandwidthLimit limits the egress and ingress bandwidth of the interface
+// ifName of the network namespace at nsPath to mbits MBits/s. Egress traffic
+// is shaped on the interface itself, and ingress traffic on its veth peer in
+// the host network namespace, as traffic can only be shaped when it's sent.
+func setBandwidthLimit(nsPath, ifName string, mbits int) error {
+	rate := uint64(mbits) * 1000 * 1000
+
+	return ns.WithNetNSPath(nsPath, func(hostNS ns.NetNS) error {
+		link, err := netlink.LinkByName(ifName)
+		if err != nil {
+			return fmt.Errorf("failed to find interface %s: %v", ifName, err)
+		}
+		if err := shapeLink(link, rate); err != nil {
+			return fmt.Errorf("failed to limit egress bandwidth of %s: %v", ifName, err)
+		}
+
+		// The peer of a veth interface is reported as its parent.
+		peerIndex := link.Attrs().ParentIndex
+		if _, ok := link.(*netlink.Veth); !ok || peerIndex == 0 {
+			return fmt.Errorf("failed to limit ingress bandwidth of %s: interface isn't a veth", ifName)
+		}
+		return hostNS.Do(func(ns.NetNS) error {
+			peer, err := netlink.LinkByIndex(peerIndex)
+			if err != nil {
+				return fmt.Errorf("failed to find veth peer of %s: %v", ifName, err)
+			}
+			if err := shapeLink(peer, rate); err != nil {
+				return fmt.Errorf("failed to limit ingress bandwidth of %s: %v", ifName, err)
+			}
+			return nil
+		})
+	})
+}
+
+// shapeLink replaces the root qdisc of the link with an HTB qdisc sending all
+// the traffic through a single class limited to rate bits/s.
+func shapeLink(link netlink.Link, rate uint64) error {
+	qdisc := netlink.NewHtb(netlink.QdiscAttrs{
+		LinkIndex: link.Attrs().Index,
+		Handle:    netlink.MakeHandle(1, 0),
+		Parent:    netlink.HANDLE_ROOT,
+	})
+	qdisc.Defcls = 1
+
+	// HTB qdiscs can't be changed once created, so only the class is
+	// replaced if the link is already shaped.
+	shaped, err := hasRootQdisc(link, qdisc)
+	if err != nil {
+		return err
+	}
+	if !shaped {
+		if err := netlink.QdiscReplace(qdisc); err != nil {
+			return err
+		}
+	}
+
+	class := netlink.NewHtbClass(netlink.ClassAttrs{
+		LinkIndex: link.Attrs().Index,
+		Handle:    netlink.MakeHandle(1, 1),
+		Parent:    qdisc.Handle,
+	}, netlink.HtbClassAttrs{
+		Rate: rate,
+		Ceil: rate,
+	})
+	return netlink.ClassReplace(class)
+}
+
+// hasRootQdisc returns whether the root qdisc of the link is of the same type
+// and has the same handle as qdisc.
+func hasRootQdisc(link netlink.Link, qdisc netlink.Qdisc) (bool, error) {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return false, err
+	}
+	for _, q := range qdiscs {
+		attrs := q.Attrs()
+		if attrs.Parent == netlink.HANDLE_ROOT {
+			return q.Type() == qdisc.Type() && attrs.Handle == qdisc.Attrs().Handle, nil
+		}
+	}
+	return false, nil
+}
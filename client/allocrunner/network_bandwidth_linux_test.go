@@ -0,0 +1,86 @@
+package allocrunner
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/nsutil"
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+)
+
+func TestSetBandwidthLimit(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	netNS, err := nsutil.NewNS("nomad-test-" + uuid.Short())
+	require.NoError(t, err)
+	defer func() {
+		netNS.Close()
+		nsutil.UnmountNS(netNS.Path())
+	}()
+
+	// Create a veth pair whose peer is moved to the network namespace, as
+	// the bridge CNI plugin does.
+	hostName := "nt" + uuid.Short()
+	veth := &netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: hostName},
+		PeerName:  "np" + uuid.Short(),
+	}
+	require.NoError(t, netlink.LinkAdd(veth))
+	defer netlink.LinkDel(veth)
+
+	peer, err := netlink.LinkByName(veth.PeerName)
+	require.NoError(t, err)
+	require.NoError(t, netlink.LinkSetNsFd(peer, int(netNS.Fd())))
+	require.NoError(t, netNS.Do(func(ns.NetNS) error {
+		return netlink.LinkSetName(peer, "eth0")
+	}))
+
+	require.NoError(t, setBandwidthLimit(netNS.Path(), "eth0", 10))
+
+	requireRate := func(link netlink.Link, rate uint64) {
+		classes, err := netlink.ClassList(link, netlink.MakeHandle(1, 0))
+		require.NoError(t, err)
+		require.Len(t, classes, 1)
+		class, ok := classes[0].(*netlink.HtbClass)
+		require.True(t, ok, fmt.Sprintf("%T isn't an HTB class", classes[0]))
+		require.Equal(t, rate, class.Rate)
+	}
+
+	hostLink, err := netlink.LinkByName(hostName)
+	require.NoError(t, err)
+	// 10 MBits/s is 1250000 bytes/s
+	requireRate(hostLink, 1250000)
+
+	require.NoError(t, netNS.Do(func(ns.NetNS) error {
+		link, err := netlink.LinkByName("eth0")
+		require.NoError(t, err)
+		requireRate(link, 1250000)
+		return nil
+	}))
+
+	// Setting the limit again replaces it
+	require.NoError(t, setBandwidthLimit(netNS.Path(), "eth0", 20))
+	requireRate(hostLink, 2500000)
+}
+
+func TestSetBandwidthLimit_MissingInterface(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	netNS, err := nsutil.NewNS("nomad-test-" + uuid.Short())
+	require.NoError(t, err)
+	defer func() {
+		netNS.Close()
+		nsutil.UnmountNS(netNS.Path())
+	}()
+
+	err = setBandwidthLimit(netNS.Path(), "eth0", 10)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to find interface eth0")
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+// +build !linux
+
+package allocrunner
+
+import "errors"
+
+// setBandwidthLimit is not supported on this platform.
+func setBandwidthLimit(nsPath, ifName string, mbits int) error {
+	return errors.New("network bandwidth limits are only supported on Linux")
+}
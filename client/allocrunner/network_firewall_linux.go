@@ -0,0 +1,196 @@
+package allocrunner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// firewallChainPrefix is the prefix of the iptables chain holding the
+	// firewall rules of an allocation
+	firewallChainPrefix = "NOMAD-FW-"
+
+	// cloudMetadataCIDR is the address of the cloud metadata endpoint
+	cloudMetadataCIDR = "169.254.169.254/32"
+)
+
+// firewallParentChains are the filter chains jumping to the firewall chain of
+// an allocation: INPUT for the traffic to the client itself and FORWARD for
+// the traffic leaving the bridge.
+var firewallParentChains = []string{"INPUT", "FORWARD"}
+
+// bridgeNetworkFirewall blocks the allocations in bridge network mode from
+// reaching the client HTTP API, the cloud metadata endpoint and the denied
+// CIDRs, unless the network firewall of their task group allows it.
+type bridgeNetworkFirewall struct {
+	apiPort   int
+	denyCIDRs []string
+}
+
+// newBridgeNetworkFirewall returns the firewall for the client HTTP API
+// advertised at httpAddr.
+func newBridgeNetworkFirewall(httpAddr string, denyCIDRs []string) (*bridgeNetworkFirewall, error) {
+	_, port, err := net.SplitHostPort(httpAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client HTTP address %q: %v", httpAddr, err)
+	}
+	apiPort, err := strconv.Atoi(port)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client HTTP port %q: %v", port, err)
+	}
+
+	return &bridgeNetworkFirewall{
+		apiPort:   apiPort,
+		denyCIDRs: denyCIDRs,
+	}, nil
+}
+
+// firewallChainName returns the name of the iptables chain of the alloc. The
+// name is derived from the alloc ID so it can be found again on teardown,
+// including after a client restart.
+func firewallChainName(allocID string) string {
+	sum := sha256.Sum256([]byte(allocID))
+	return firewallChainPrefix + hex.EncodeToString(sum[:])[:16]
+}
+
+// groupFirewall returns the network firewall of the alloc's task group, nil
+// if it has none. It also applies to the tasks with a network namespace of
+// their own, as their networks can't set a firewall.
+func groupFirewall(alloc *structs.Allocation) *structs.NetworkFirewall {
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || len(tg.Networks) == 0 {
+		return nil
+	}
+	return tg.Networks[0].Firewall
+}
+
+// rules returns the rules of the firewall chain of the alloc. The rules
+// return to the parent chain for allowed traffic and reject denied traffic,
+// anything else is left to the rest of the parent chain.
+func (f *bridgeNetworkFirewall) rules(alloc *structs.Allocation) [][]string {
+	firewall := groupFirewall(alloc)
+
+	rules := [][]string{
+		{"-m", "conntrack", "--ctstate", "ESTABLISHED,RELATED", "-j", "RETURN"},
+	}
+	if firewall != nil {
+		for _, cidr := range firewall.AllowCIDRs {
+			rules = append(rules, []string{"-d", cidr, "-j", "RETURN"})
+		}
+	}
+	if firewall == nil || !firewall.AllowMetadata {
+		rules = append(rules, rejectRule("-d", cloudMetadataCIDR))
+	}
+	// The tasks of the group share the network namespace, so the client API
+	// is only opened to all of them at once and the job must opt in even if
+	// its tasks have workload identities to call it with
+	if firewall == nil || !firewall.AllowClientAPI {
+		rules = append(rules, rejectRule("-p", "tcp", "--dport", strconv.Itoa(f.apiPort),
+			"-m", "addrtype", "--dst-type", "LOCAL"))
+	}
+	for _, cidr := range f.denyCIDRs {
+		rules = append(rules, rejectRule("-d", cidr))
+	}
+	return rules
+}
+
+func rejectRule(match ...string) []string {
+	return append(match, "-j", "REJECT")
+}
+
+// Setup creates the firewall chain of the alloc with the given address and
+// makes the parent chains jump to it for the traffic of the alloc.
+func (f *bridgeNetworkFirewall) Setup(alloc *structs.Allocation, address string) error {
+	if address == "" {
+		return fmt.Errorf("alloc has no address")
+	}
+
+	ipt, err := iptables.New()
+	if err != nil {
+		return err
+	}
+
+	chain := firewallChainName(alloc.ID)
+	if err := ipt.ClearChain("filter", chain); err != nil {
+		return fmt.Errorf("failed to create iptables chain %s: %v", chain, err)
+	}
+	for _, rule := range f.rules(alloc) {
+		if err := ipt.Append("filter", chain, rule...); err != nil {
+			return fmt.Errorf("failed to add rule to iptables chain %s: %v", chain, err)
+		}
+	}
+
+	jump := []string{"-s", address + "/32", "-j", chain}
+	for _, parent := range firewallParentChains {
+		exists, err := ipt.Exists("filter", parent, jump...)
+		if err != nil {
+			return fmt.Errorf("failed to check iptables chain %s: %v", parent, err)
+		}
+		if exists {
+			continue
+		}
+		if err := ipt.Insert("filter", parent, 1, jump...); err != nil {
+			return fmt.Errorf("failed to add rule to iptables chain %s: %v", parent, err)
+		}
+	}
+	return nil
+}
+
+// Teardown removes the firewall chain of the alloc and the jumps to it. It is
+// a noop if the chain doesn't exist.
+func (f *bridgeNetworkFirewall) Teardown(alloc *structs.Allocation) error {
+	ipt, err := iptables.New()
+	if err != nil {
+		return err
+	}
+
+	chain := firewallChainName(alloc.ID)
+	exists, err := ipt.ChainExists("filter", chain)
+	if err != nil {
+		return fmt.Errorf("failed to list iptables chains: %v", err)
+	}
+	if !exists {
+		return nil
+	}
+
+	for _, parent := range firewallParentChains {
+		rules, err := ipt.List("filter", parent)
+		if err != nil {
+			return fmt.Errorf("failed to list iptables chain %s: %v", parent, err)
+		}
+		for _, rule := range rules {
+			spec, ok := jumpRuleSpec(rule, parent, chain)
+			if !ok {
+				continue
+			}
+			if err := ipt.Delete("filter", parent, spec...); err != nil {
+				return fmt.Errorf("failed to delete rule from iptables chain %s: %v", parent, err)
+			}
+		}
+	}
+
+	return ipt.ClearAndDeleteChain("filter", chain)
+}
+
+// jumpRuleSpec returns the spec of a rule listed in the parent chain if the
+// rule jumps to the given chain.
+func jumpRuleSpec(rule, parent, chain string) ([]string, bool) {
+	fields := strings.Fields(rule)
+	if len(fields) < 2 || fields[0] != "-A" || fields[1] != parent {
+		return nil, false
+	}
+	spec := fields[2:]
+	for i := 0; i < len(spec)-1; i++ {
+		if spec[i] == "-j" && spec[i+1] == chain {
+			return spec, true
+		}
+	}
+	return nil, false
+}
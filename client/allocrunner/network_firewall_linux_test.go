@@ -0,0 +1,109 @@
+package allocrunner
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBridgeNetworkFirewall(t *testing.T) {
+	ci.Parallel(t)
+
+	f, err := newBridgeNetworkFirewall("10.0.0.1:4646", []string{"192.168.0.0/16"})
+	require.NoError(t, err)
+	require.Equal(t, 4646, f.apiPort)
+	require.Equal(t, []string{"192.168.0.0/16"}, f.denyCIDRs)
+
+	_, err = newBridgeNetworkFirewall("10.0.0.1", nil)
+	require.Error(t, err)
+
+	_, err = newBridgeNetworkFirewall("10.0.0.1:http", nil)
+	require.Error(t, err)
+}
+
+func TestBridgeNetworkFirewall_Rules(t *testing.T) {
+	ci.Parallel(t)
+
+	f := &bridgeNetworkFirewall{
+		apiPort:   4646,
+		denyCIDRs: []string{"192.168.0.0/16"},
+	}
+
+	established := []string{"-m", "conntrack", "--ctstate", "ESTABLISHED,RELATED", "-j", "RETURN"}
+	metadata := []string{"-d", "169.254.169.254/32", "-j", "REJECT"}
+	api := []string{"-p", "tcp", "--dport", "4646", "-m", "addrtype", "--dst-type", "LOCAL", "-j", "REJECT"}
+	denied := []string{"-d", "192.168.0.0/16", "-j", "REJECT"}
+
+	cases := []struct {
+		name       string
+		firewall   *structs.NetworkFirewall
+		identities []*structs.WorkloadIdentity
+		expected   [][]string
+	}{
+		{
+			name:     "default",
+			expected: [][]string{established, metadata, api, denied},
+		},
+		{
+			name: "allow all",
+			firewall: &structs.NetworkFirewall{
+				AllowClientAPI: true,
+				AllowMetadata:  true,
+				AllowCIDRs:     []string{"192.168.1.0/24"},
+			},
+			expected: [][]string{
+				established,
+				{"-d", "192.168.1.0/24", "-j", "RETURN"},
+				denied,
+			},
+		},
+		{
+			name:       "workload identity",
+			identities: []*structs.WorkloadIdentity{{Name: "default"}},
+			expected:   [][]string{established, metadata, api, denied},
+		},
+		{
+			name:       "workload identity with client api allowed",
+			firewall:   &structs.NetworkFirewall{AllowClientAPI: true},
+			identities: []*structs.WorkloadIdentity{{Name: "default"}},
+			expected:   [][]string{established, metadata, denied},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			alloc := mock.Alloc()
+			tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+			tg.Networks = []*structs.NetworkResource{{Mode: "bridge", Firewall: tc.firewall}}
+			tg.Tasks[0].Identities = tc.identities
+
+			require.Equal(t, tc.expected, f.rules(alloc))
+		})
+	}
+}
+
+func TestFirewallChainName(t *testing.T) {
+	ci.Parallel(t)
+
+	name := firewallChainName("7cf4a0bc-0c2b-4ffa-8f0c-8a1a7b6b8f4e")
+	require.Len(t, name, len(firewallChainPrefix)+16)
+	require.Equal(t, name, firewallChainName("7cf4a0bc-0c2b-4ffa-8f0c-8a1a7b6b8f4e"))
+	require.NotEqual(t, name, firewallChainName("c5a5b7d4-8d5e-4c2a-9f0b-4f0e3b2b1a6d"))
+}
+
+func TestJumpRuleSpec(t *testing.T) {
+	ci.Parallel(t)
+
+	spec, ok := jumpRuleSpec("-A INPUT -s 172.26.64.2/32 -j NOMAD-FW-0123456789abcdef", "INPUT", "NOMAD-FW-0123456789abcdef")
+	require.True(t, ok)
+	require.Equal(t, []string{"-s", "172.26.64.2/32", "-j", "NOMAD-FW-0123456789abcdef"}, spec)
+
+	_, ok = jumpRuleSpec("-A INPUT -s 172.26.64.3/32 -j NOMAD-FW-fedcba9876543210", "INPUT", "NOMAD-FW-0123456789abcdef")
+	require.False(t, ok)
+
+	_, ok = jumpRuleSpec("-P INPUT ACCEPT", "INPUT", "NOMAD-FW-0123456789abcdef")
+	require.False(t, ok)
+}
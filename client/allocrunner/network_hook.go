@@ -3,8 +3,10 @@ package allocrunner
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	hclog "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
@@ -23,10 +25,16 @@ const (
 	// container and the task group network include a user supplied hostname
 	// parameter.
 	dockerNetSpecHostnameKey = "docker_sandbox_hostname"
+
+	// defaultBandwidthInterface is the interface of the network namespace
+	// whose bandwidth is limited when the network configurator doesn't report
+	// the interface it configured.
+	defaultBandwidthInterface = "eth0"
 )
 
 type networkIsolationSetter interface {
 	SetNetworkIsolation(*drivers.NetworkIsolationSpec)
+	SetTaskNetworkIsolation(string, *drivers.NetworkIsolationSpec)
 }
 
 // allocNetworkIsolationSetter is a shim to allow the alloc network hook to
@@ -42,6 +50,14 @@ func (a *allocNetworkIsolationSetter) SetNetworkIsolation(n *drivers.NetworkIsol
 	}
 }
 
+// SetTaskNetworkIsolation sets the network isolation configuration of a
+// single task, overriding the alloc's.
+func (a *allocNetworkIsolationSetter) SetTaskNetworkIsolation(task string, n *drivers.NetworkIsolationSpec) {
+	if tr, ok := a.ar.tasks[task]; ok {
+		tr.SetNetworkIsolation(n)
+	}
+}
+
 type networkStatusSetter interface {
 	SetNetworkStatus(*structs.AllocNetworkStatus)
 }
@@ -75,6 +91,10 @@ type networkHook struct {
 	// taskEnv is used to perform interpolation within the network blocks.
 	taskEnv *taskenv.TaskEnv
 
+	// taskNetworks are the networks of the tasks which have their own network
+	// namespace rather than joining the alloc's, keyed by task name
+	taskNetworks map[string]*taskNetwork
+
 	logger hclog.Logger
 }
 
@@ -85,6 +105,7 @@ func newNetworkHook(logger hclog.Logger,
 	netConfigurator NetworkConfigurator,
 	networkStatusSetter networkStatusSetter,
 	taskEnv *taskenv.TaskEnv,
+	taskNetworks map[string]*taskNetwork,
 ) *networkHook {
 	return &networkHook{
 		isolationSetter:     ns,
@@ -93,6 +114,7 @@ func newNetworkHook(logger hclog.Logger,
 		manager:             netManager,
 		networkConfigurator: netConfigurator,
 		taskEnv:             taskEnv,
+		taskNetworks:        taskNetworks,
 		logger:              logger,
 	}
 }
@@ -102,6 +124,15 @@ func (h *networkHook) Name() string {
 }
 
 func (h *networkHook) Prerun() error {
+	if err := h.prerunAlloc(); err != nil {
+		return err
+	}
+	return h.prerunTasks()
+}
+
+// prerunAlloc creates and configures the network namespace shared by the
+// alloc's tasks
+func (h *networkHook) prerunAlloc() error {
 	tg := h.alloc.Job.LookupTaskGroup(h.alloc.TaskGroup)
 	if len(tg.Networks) == 0 || tg.Networks[0].Mode == "host" || tg.Networks[0].Mode == "" {
 		return nil
@@ -145,6 +176,10 @@ func (h *networkHook) Prerun() error {
 			return fmt.Errorf("failed to configure networking for alloc: %v", err)
 		}
 
+		if err := limitBandwidth(interpolatedNetworks[0], spec, status); err != nil {
+			return fmt.Errorf("failed to limit network bandwidth for alloc: %v", err)
+		}
+
 		// If the driver set the sandbox hostname label, then we will use that
 		// to set the HostsConfig.Hostname. Otherwise, identify the sandbox
 		// container ID which will have been used to set the network namespace
@@ -174,13 +209,95 @@ func (h *networkHook) Prerun() error {
 	return nil
 }
 
+// prerunTasks creates and configures the network namespaces of the tasks
+// which have their own, and sets them on those tasks in place of the alloc's
+func (h *networkHook) prerunTasks() error {
+	tg := h.alloc.Job.LookupTaskGroup(h.alloc.TaskGroup)
+	for _, name := range h.taskNetworkNames() {
+		tn := h.taskNetworks[name]
+		id := taskNetworkID(h.alloc.ID, name)
+
+		spec, created, err := tn.manager.CreateNetwork(id, &drivers.NetworkCreateRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to create network for task %q: %v", name, err)
+		}
+		if spec == nil {
+			continue
+		}
+
+		spec.Mode = drivers.NetIsolationModeTask
+		tn.spec = spec
+		h.isolationSetter.SetTaskNetworkIsolation(name, spec)
+
+		if created {
+			status, err := tn.configurator.Setup(context.TODO(), taskNetworkAlloc(h.alloc, name), spec)
+			if err != nil {
+				return fmt.Errorf("failed to configure networking for task %q: %v", name, err)
+			}
+			if task := tg.LookupTask(name); task != nil {
+				if err := limitBandwidth(task.Resources.Networks[0], spec, status); err != nil {
+					return fmt.Errorf("failed to limit network bandwidth for task %q: %v", name, err)
+				}
+			}
+			if status != nil {
+				h.logger.Debug("configured task network", "task", name, "address", status.Address)
+			}
+		}
+	}
+	return nil
+}
+
+// limitBandwidth enforces the bandwidth limit of the network, if any, on the
+// interface configured in the network namespace described by spec.
+func limitBandwidth(net *structs.NetworkResource, spec *drivers.NetworkIsolationSpec, status *structs.AllocNetworkStatus) error {
+	if net.Bandwidth == 0 {
+		return nil
+	}
+
+	ifName := defaultBandwidthInterface
+	if status != nil && status.InterfaceName != "" {
+		ifName = status.InterfaceName
+	}
+	return setBandwidthLimit(spec.Path, ifName, net.Bandwidth)
+}
+
+// taskNetworkNames returns the names of the tasks with their own network
+// namespace in a stable order
+func (h *networkHook) taskNetworkNames() []string {
+	names := make([]string, 0, len(h.taskNetworks))
+	for name := range h.taskNetworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (h *networkHook) Postrun() error {
+	var mErr multierror.Error
+	for _, name := range h.taskNetworkNames() {
+		tn := h.taskNetworks[name]
+		if tn.spec == nil {
+			continue
+		}
+
+		id := taskNetworkID(h.alloc.ID, name)
+		if err := tn.configurator.Teardown(context.TODO(), taskNetworkAlloc(h.alloc, name), tn.spec); err != nil {
+			h.logger.Error("failed to cleanup network for task, resources may have leaked", "alloc", h.alloc.ID, "task", name, "error", err)
+		}
+		if err := tn.manager.DestroyNetwork(id, tn.spec); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("failed to destroy network for task %q: %v", name, err))
+		}
+	}
+
 	if h.spec == nil {
-		return nil
+		return mErr.ErrorOrNil()
 	}
 
 	if err := h.networkConfigurator.Teardown(context.TODO(), h.alloc, h.spec); err != nil {
 		h.logger.Error("failed to cleanup network for allocation, resources may have leaked", "alloc", h.alloc.ID, "error", err)
 	}
-	return h.manager.DestroyNetwork(h.alloc.ID, h.spec)
+	if err := h.manager.DestroyNetwork(h.alloc.ID, h.spec); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	return mErr.ErrorOrNil()
 }
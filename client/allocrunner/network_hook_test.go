@@ -1,6 +1,7 @@
 package allocrunner
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/nomad/ci"
@@ -22,6 +23,7 @@ type mockNetworkIsolationSetter struct {
 	t            *testing.T
 	expectedSpec *drivers.NetworkIsolationSpec
 	called       bool
+	taskSpecs    map[string]*drivers.NetworkIsolationSpec
 }
 
 func (m *mockNetworkIsolationSetter) SetNetworkIsolation(spec *drivers.NetworkIsolationSpec) {
@@ -29,6 +31,13 @@ func (m *mockNetworkIsolationSetter) SetNetworkIsolation(spec *drivers.NetworkIs
 	require.Exactly(m.t, m.expectedSpec, spec)
 }
 
+func (m *mockNetworkIsolationSetter) SetTaskNetworkIsolation(task string, spec *drivers.NetworkIsolationSpec) {
+	if m.taskSpecs == nil {
+		m.taskSpecs = make(map[string]*drivers.NetworkIsolationSpec)
+	}
+	m.taskSpecs[task] = spec
+}
+
 type mockNetworkStatusSetter struct {
 	t              *testing.T
 	expectedStatus *structs.AllocNetworkStatus
@@ -86,7 +95,7 @@ func TestNetworkHook_Prerun_Postrun(t *testing.T) {
 	envBuilder := taskenv.NewBuilder(mock.Node(), alloc, nil, alloc.Job.Region)
 
 	logger := testlog.HCLogger(t)
-	hook := newNetworkHook(logger, setter, alloc, nm, &hostNetworkConfigurator{}, statusSetter, envBuilder.Build())
+	hook := newNetworkHook(logger, setter, alloc, nm, &hostNetworkConfigurator{}, statusSetter, envBuilder.Build(), nil)
 	require.NoError(hook.Prerun())
 	require.True(setter.called)
 	require.False(destroyCalled)
@@ -97,10 +106,87 @@ func TestNetworkHook_Prerun_Postrun(t *testing.T) {
 	setter.called = false
 	destroyCalled = false
 	alloc.Job.TaskGroups[0].Networks[0].Mode = "host"
-	hook = newNetworkHook(logger, setter, alloc, nm, &hostNetworkConfigurator{}, statusSetter, envBuilder.Build())
+	hook = newNetworkHook(logger, setter, alloc, nm, &hostNetworkConfigurator{}, statusSetter, envBuilder.Build(), nil)
 	require.NoError(hook.Prerun())
 	require.False(setter.called)
 	require.False(destroyCalled)
 	require.NoError(hook.Postrun())
 	require.False(destroyCalled)
 }
+
+type mockNetworkConfigurator struct {
+	setupAllocs    []*structs.Allocation
+	teardownAllocs []*structs.Allocation
+}
+
+func (m *mockNetworkConfigurator) Setup(_ context.Context, alloc *structs.Allocation, _ *drivers.NetworkIsolationSpec) (*structs.AllocNetworkStatus, error) {
+	m.setupAllocs = append(m.setupAllocs, alloc)
+	return &structs.AllocNetworkStatus{Address: "172.26.64.2"}, nil
+}
+
+func (m *mockNetworkConfigurator) Teardown(_ context.Context, alloc *structs.Allocation, _ *drivers.NetworkIsolationSpec) error {
+	m.teardownAllocs = append(m.teardownAllocs, alloc)
+	return nil
+}
+
+// Test that the prerun and postrun hooks create, configure and destroy the
+// network namespace of a task with its own network, even when the alloc is in
+// host network mode
+func TestNetworkHook_Prerun_Postrun_TaskNetwork(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Resources.Networks = []*structs.NetworkResource{{Mode: "bridge"}}
+	taskID := alloc.ID + "-" + task.Name
+
+	spec := &drivers.NetworkIsolationSpec{
+		Mode:   drivers.NetIsolationModeGroup,
+		Path:   "/var/run/netns/" + taskID,
+		Labels: map[string]string{},
+	}
+
+	destroyCalled := false
+	nm := &testutils.MockDriver{
+		MockNetworkManager: testutils.MockNetworkManager{
+			CreateNetworkF: func(id string, req *drivers.NetworkCreateRequest) (*drivers.NetworkIsolationSpec, bool, error) {
+				require.Equal(t, taskID, id)
+				return spec, true, nil
+			},
+			DestroyNetworkF: func(id string, netSpec *drivers.NetworkIsolationSpec) error {
+				destroyCalled = true
+				require.Equal(t, taskID, id)
+				require.Exactly(t, spec, netSpec)
+				return nil
+			},
+		},
+	}
+	nc := &mockNetworkConfigurator{}
+	taskNetworks := map[string]*taskNetwork{
+		task.Name: {manager: nm, configurator: nc},
+	}
+
+	setter := &mockNetworkIsolationSetter{t: t}
+	statusSetter := &mockNetworkStatusSetter{t: t}
+	envBuilder := taskenv.NewBuilder(mock.Node(), alloc, nil, alloc.Job.Region)
+
+	logger := testlog.HCLogger(t)
+	hook := newNetworkHook(logger, setter, alloc, nil, nil, statusSetter, envBuilder.Build(), taskNetworks)
+	require.NoError(t, hook.Prerun())
+
+	// the alloc has no network, the task has its own
+	require.False(t, setter.called)
+	require.Exactly(t, spec, setter.taskSpecs[task.Name])
+	require.Equal(t, drivers.NetIsolationModeTask, spec.Mode)
+
+	// the task network is configured with the task's ports
+	require.Len(t, nc.setupAllocs, 1)
+	require.Equal(t, taskID, nc.setupAllocs[0].ID)
+	require.Equal(t, alloc.AllocatedResources.Tasks[task.Name].Networks,
+		nc.setupAllocs[0].AllocatedResources.Shared.Networks)
+
+	require.NoError(t, hook.Postrun())
+	require.True(t, destroyCalled)
+	require.Len(t, nc.teardownAllocs, 1)
+	require.Equal(t, taskID, nc.teardownAllocs[0].ID)
+}
@@ -165,7 +165,12 @@ func newNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, config
 		return &hostNetworkConfigurator{}, nil
 	}
 
-	netMode := strings.ToLower(tg.Networks[0].Mode)
+	return newModeNetworkConfigurator(log, strings.ToLower(tg.Networks[0].Mode), config)
+}
+
+// newModeNetworkConfigurator returns the NetworkConfigurator for the network
+// mode.
+func newModeNetworkConfigurator(log hclog.Logger, netMode string, config *clientconfig.Config) (NetworkConfigurator, error) {
 	ignorePortMappingHostIP := config.BindWildcardDefaultHostNetwork
 	if len(config.HostNetworks) > 0 {
 		ignorePortMappingHostIP = false
@@ -177,6 +182,12 @@ func newNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, config
 		if err != nil {
 			return nil, err
 		}
+		if config.BridgeNetworkFirewall {
+			c.firewall, err = newBridgeNetworkFirewall(config.Node.HTTPAddr, config.BridgeNetworkFirewallDenyCIDRs)
+			if err != nil {
+				return nil, err
+			}
+		}
 		return &synchronizedNetworkConfigurator{c}, nil
 	case strings.HasPrefix(netMode, "cni/"):
 		c, err := newCNINetworkConfigurator(log, config.CNIPath, config.CNIInterfacePrefix, config.CNIConfigDir, netMode[4:], ignorePortMappingHostIP)
@@ -188,3 +199,49 @@ func newNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, config
 		return &hostNetworkConfigurator{}, nil
 	}
 }
+
+// newTaskNetworks returns the networks of the alloc's tasks which have their
+// own network namespace rather than joining the alloc's. A task has its own
+// network namespace if its network mode is bridge or CNI and its driver
+// supports task network isolation without creating the network itself.
+func newTaskNetworks(log hclog.Logger, alloc *structs.Allocation, driverManager drivermanager.Manager, config *clientconfig.Config) (map[string]*taskNetwork, error) {
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+
+	var taskNetworks map[string]*taskNetwork
+	for _, task := range tg.Tasks {
+		netMode := taskNetworkMode(task)
+		if netMode != "bridge" && !strings.HasPrefix(netMode, "cni/") {
+			continue
+		}
+
+		driver, err := driverManager.Dispense(task.Driver)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dispense driver %s: %v", task.Driver, err)
+		}
+
+		caps, err := driver.Capabilities()
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve capabilities for driver %s: %v",
+				task.Driver, err)
+		}
+
+		if !caps.HasNetIsolationMode(drivers.NetIsolationModeTask) || caps.MustInitiateNetwork {
+			continue
+		}
+
+		nc, err := newModeNetworkConfigurator(log, netMode, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize network configurator for task %s: %v", task.Name, err)
+		}
+
+		if taskNetworks == nil {
+			taskNetworks = make(map[string]*taskNetwork)
+		}
+		taskNetworks[task.Name] = &taskNetwork{
+			manager:      &defaultNetworkManager{},
+			configurator: nc,
+		}
+	}
+
+	return taskNetworks, nil
+}
@@ -4,7 +4,10 @@ import (
 	"testing"
 
 	"github.com/hashicorp/nomad/ci"
+	clientconfig "github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/pluginmanager"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/hashicorp/nomad/plugins/drivers/testutils"
@@ -35,6 +38,15 @@ var mockDrivers = map[string]drivers.DriverPlugin{
 			}, nil
 		},
 	},
+	"task1": &testutils.MockDriver{
+		CapabilitiesF: func() (*drivers.Capabilities, error) {
+			return &drivers.Capabilities{
+				NetIsolationModes: []drivers.NetIsolationMode{
+					drivers.NetIsolationModeHost, drivers.NetIsolationModeGroup,
+					drivers.NetIsolationModeTask},
+			}, nil
+		},
+	},
 	"mustinit1": &testutils.MockDriver{
 		CapabilitiesF: func() (*drivers.Capabilities, error) {
 			return &drivers.Capabilities{
@@ -277,3 +289,47 @@ func TestNewNetworkManager(t *testing.T) {
 	}
 
 }
+
+func TestNewTaskNetworks_Firewall(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	tg := alloc.Job.TaskGroups[0]
+	tg.Networks = nil
+	tg.Tasks = []*structs.Task{
+		{
+			Name:   "isolated",
+			Driver: "task1",
+			Resources: &structs.Resources{
+				Networks: []*structs.NetworkResource{{Mode: "bridge"}},
+			},
+		},
+		{
+			// drivers without task network isolation join the alloc's
+			Name:   "shared",
+			Driver: "group1",
+			Resources: &structs.Resources{
+				Networks: []*structs.NetworkResource{{Mode: "bridge"}},
+			},
+		},
+	}
+
+	config := &clientconfig.Config{
+		Node:                  &structs.Node{HTTPAddr: "10.0.0.1:4646"},
+		BridgeNetworkFirewall: true,
+	}
+	tns, err := newTaskNetworks(testlog.HCLogger(t), alloc, &mockDriverManager{}, config)
+	require.NoError(t, err)
+	require.Len(t, tns, 1)
+	require.Contains(t, tns, "isolated")
+
+	// The network of the task is firewalled like the group's would be
+	synced, ok := tns["isolated"].configurator.(*synchronizedNetworkConfigurator)
+	require.True(t, ok)
+	bridge, ok := synced.nc.(*bridgeNetworkConfigurator)
+	require.True(t, ok)
+	require.NotNil(t, bridge.firewall)
+
+	api := []string{"-p", "tcp", "--dport", "4646", "-m", "addrtype", "--dst-type", "LOCAL", "-j", "REJECT"}
+	require.Contains(t, bridge.firewall.rules(taskNetworkAlloc(alloc, "isolated")), api)
+}
@@ -19,3 +19,7 @@ func newNetworkManager(alloc *structs.Allocation, driverManager drivermanager.Ma
 func newNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, config *clientconfig.Config) (NetworkConfigurator, error) {
 	return &hostNetworkConfigurator{}, nil
 }
+
+func newTaskNetworks(log hclog.Logger, alloc *structs.Allocation, driverManager drivermanager.Manager, config *clientconfig.Config) (map[string]*taskNetwork, error) {
+	return nil, nil
+}
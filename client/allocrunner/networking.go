@@ -2,6 +2,7 @@ package allocrunner
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -15,6 +16,51 @@ type NetworkConfigurator interface {
 	Teardown(context.Context, *structs.Allocation, *drivers.NetworkIsolationSpec) error
 }
 
+// taskNetwork is the network namespace of a task which has its own network
+// rather than joining the network of its alloc
+type taskNetwork struct {
+	// manager creates the task's network namespace
+	manager drivers.DriverNetworkManager
+
+	// configurator configures the interfaces, routes, etc of the task's
+	// network namespace once created
+	configurator NetworkConfigurator
+
+	// spec describes the task's network namespace once created
+	spec *drivers.NetworkIsolationSpec
+}
+
+// taskNetworkID returns the ID naming the network namespace of a task and
+// identifying it to CNI plugins.
+func taskNetworkID(allocID, task string) string {
+	return allocID + "-" + task
+}
+
+// taskNetworkMode returns the network mode of the task's own network, or an
+// empty string if the task has no network of its own.
+func taskNetworkMode(task *structs.Task) string {
+	if task.Resources == nil || len(task.Resources.Networks) == 0 {
+		return ""
+	}
+	return strings.ToLower(task.Resources.Networks[0].Mode)
+}
+
+// taskNetworkAlloc returns a copy of the alloc to configure the network of a
+// task with. Its ID is the ID of the task's network and its shared networks
+// are the task's, so that port mappings are built from the task's ports.
+func taskNetworkAlloc(alloc *structs.Allocation, task string) *structs.Allocation {
+	ta := new(structs.Allocation)
+	*ta = *alloc
+	ta.ID = taskNetworkID(alloc.ID, task)
+	ta.AllocatedResources = &structs.AllocatedResources{}
+	if alloc.AllocatedResources != nil {
+		if tr, ok := alloc.AllocatedResources.Tasks[task]; ok {
+			ta.AllocatedResources.Shared.Networks = tr.Networks
+		}
+	}
+	return ta
+}
+
 // hostNetworkConfigurator is a noop implementation of a NetworkConfigurator for
 // when the alloc join's a client host's network namespace and thus does not
 // require further configuration
@@ -6,6 +6,7 @@ import (
 
 	"github.com/coreos/go-iptables/iptables"
 	hclog "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 )
@@ -36,6 +37,9 @@ type bridgeNetworkConfigurator struct {
 	allocSubnet string
 	bridgeName  string
 
+	// firewall restricts the egress traffic of the allocs, nil if disabled
+	firewall *bridgeNetworkFirewall
+
 	logger hclog.Logger
 }
 
@@ -126,12 +130,31 @@ func (b *bridgeNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Al
 		return nil, fmt.Errorf("failed to initialize table forwarding rules: %v", err)
 	}
 
-	return b.cni.Setup(ctx, alloc, spec)
+	status, err := b.cni.Setup(ctx, alloc, spec)
+	if err != nil || b.firewall == nil {
+		return status, err
+	}
+
+	if err := b.firewall.Setup(alloc, status.Address); err != nil {
+		return nil, fmt.Errorf("failed to setup network firewall: %v", err)
+	}
+	return status, nil
 }
 
 // Teardown calls the CNI plugins with the delete action
 func (b *bridgeNetworkConfigurator) Teardown(ctx context.Context, alloc *structs.Allocation, spec *drivers.NetworkIsolationSpec) error {
-	return b.cni.Teardown(ctx, alloc, spec)
+	// The network namespace is torn down even if the firewall rules can't be
+	// removed so its IP address isn't leaked
+	var mErr *multierror.Error
+	if b.firewall != nil {
+		if err := b.firewall.Teardown(alloc); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("failed to teardown network firewall: %v", err))
+		}
+	}
+	if err := b.cni.Teardown(ctx, alloc, spec); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+	return mErr.ErrorOrNil()
 }
 
 func buildNomadBridgeNetConfig(bridgeName, subnet string) []byte {
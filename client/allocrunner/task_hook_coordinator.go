@@ -24,9 +24,16 @@ type taskHookCoordinator struct {
 
 	poststartTaskCtx       context.Context
 	poststartTaskCtxCancel func()
+	prestopTaskCtx         context.Context
+	prestopTaskCtxCancel   context.CancelFunc
 	poststopTaskCtx        context.Context
 	poststopTaskCtxCancel  context.CancelFunc
 
+	// poststop tasks that only run on failure are gated separately, as
+	// whether they run is only known once the main tasks are dead
+	poststopFailureTaskCtx       context.Context
+	poststopFailureTaskCtxCancel context.CancelFunc
+
 	prestartSidecar   map[string]struct{}
 	prestartEphemeral map[string]struct{}
 	mainTasksRunning  map[string]struct{} // poststop: main tasks running -> finished
@@ -39,7 +46,9 @@ func newTaskHookCoordinator(logger hclog.Logger, tasks []*structs.Task) *taskHoo
 
 	mainTaskCtx, mainCancelFn := context.WithCancel(context.Background())
 	poststartTaskCtx, poststartCancelFn := context.WithCancel(context.Background())
+	prestopTaskCtx, prestopTaskCancelFn := context.WithCancel(context.Background())
 	poststopTaskCtx, poststopTaskCancelFn := context.WithCancel(context.Background())
+	poststopFailureTaskCtx, poststopFailureTaskCancelFn := context.WithCancel(context.Background())
 
 	c := &taskHookCoordinator{
 		logger:                 logger,
@@ -52,8 +61,13 @@ func newTaskHookCoordinator(logger hclog.Logger, tasks []*structs.Task) *taskHoo
 		mainTasksPending:       map[string]struct{}{},
 		poststartTaskCtx:       poststartTaskCtx,
 		poststartTaskCtxCancel: poststartCancelFn,
+		prestopTaskCtx:         prestopTaskCtx,
+		prestopTaskCtxCancel:   prestopTaskCancelFn,
 		poststopTaskCtx:        poststopTaskCtx,
 		poststopTaskCtxCancel:  poststopTaskCancelFn,
+
+		poststopFailureTaskCtx:       poststopFailureTaskCtx,
+		poststopFailureTaskCtxCancel: poststopFailureTaskCancelFn,
 	}
 	c.setTasks(tasks)
 	return c
@@ -77,6 +91,8 @@ func (c *taskHookCoordinator) setTasks(tasks []*structs.Task) {
 			}
 		case structs.TaskLifecycleHookPoststart:
 			// Poststart hooks don't need to be tracked.
+		case structs.TaskLifecycleHookPrestop:
+			// Prestop hooks are started when the allocation is stopped.
 		case structs.TaskLifecycleHookPoststop:
 			// Poststop hooks don't need to be tracked.
 		default:
@@ -112,7 +128,12 @@ func (c *taskHookCoordinator) startConditionForTask(task *structs.Task) <-chan s
 		return c.closedCh
 	case structs.TaskLifecycleHookPoststart:
 		return c.poststartTaskCtx.Done()
+	case structs.TaskLifecycleHookPrestop:
+		return c.prestopTaskCtx.Done()
 	case structs.TaskLifecycleHookPoststop:
+		if task.Lifecycle.OnFailure {
+			return c.poststopFailureTaskCtx.Done()
+		}
 		return c.poststopTaskCtx.Done()
 	default:
 		// it should never have a lifecycle stanza w/o a hook, so report an error but allow the task to start normally
@@ -176,11 +197,20 @@ func (c *taskHookCoordinator) StartPoststopTasks() {
 	c.poststopTaskCtxCancel()
 }
 
-// hasNonSidecarTasks returns false if all the passed tasks are sidecar tasks
+func (c *taskHookCoordinator) StartPoststopFailureTasks() {
+	c.poststopFailureTaskCtxCancel()
+}
+
+func (c *taskHookCoordinator) StartPrestopTasks() {
+	c.prestopTaskCtxCancel()
+}
+
+// hasNonSidecarTasks returns false if all the passed tasks are sidecar or
+// prestop tasks, which don't keep the allocation running on their own
 func hasNonSidecarTasks(tasks []*taskrunner.TaskRunner) bool {
 	for _, tr := range tasks {
 		lc := tr.Task().Lifecycle
-		if lc == nil || !lc.Sidecar {
+		if lc == nil || (!lc.Sidecar && lc.Hook != structs.TaskLifecycleHookPrestop) {
 			return true
 		}
 	}
@@ -188,11 +218,12 @@ func hasNonSidecarTasks(tasks []*taskrunner.TaskRunner) bool {
 	return false
 }
 
-// hasSidecarTasks returns true if all the passed tasks are sidecar tasks
+// hasSidecarTasks returns true if any of the passed tasks are sidecar or
+// prestop tasks
 func hasSidecarTasks(tasks map[string]*taskrunner.TaskRunner) bool {
 	for _, tr := range tasks {
 		lc := tr.Task().Lifecycle
-		if lc != nil && lc.Sidecar {
+		if lc != nil && (lc.Sidecar || lc.Hook == structs.TaskLifecycleHookPrestop) {
 			return true
 		}
 	}
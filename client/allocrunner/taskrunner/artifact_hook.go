@@ -14,12 +14,14 @@ import (
 // artifactHook downloads artifacts for a task.
 type artifactHook struct {
 	eventEmitter ti.EventEmitter
+	getterConfig *getter.Config
 	logger       log.Logger
 }
 
-func newArtifactHook(e ti.EventEmitter, logger log.Logger) *artifactHook {
+func newArtifactHook(e ti.EventEmitter, getterConfig *getter.Config, logger log.Logger) *artifactHook {
 	h := &artifactHook{
 		eventEmitter: e,
+		getterConfig: getterConfig,
 	}
 	h.logger = logger.Named(h.Name())
 	return h
@@ -52,7 +54,7 @@ func (h *artifactHook) Prestart(ctx context.Context, req *interfaces.TaskPrestar
 
 		h.logger.Debug("downloading artifact", "artifact", artifact.GetterSource)
 		//XXX add ctx to GetArtifact to allow cancelling long downloads
-		if err := getter.GetArtifact(req.TaskEnv, artifact); err != nil {
+		if err := getter.GetArtifact(req.TaskEnv, artifact, h.getterConfig); err != nil {
 
 			wrapped := structs.NewRecoverableError(
 				fmt.Errorf("failed to download artifact %q: %v", artifact.GetterSource, err),
@@ -0,0 +1,310 @@
+package taskrunner
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/consul-template/signals"
+	consulapi "github.com/hashicorp/consul/api"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	connectNativeCertsHookName = "connect_native_certs"
+
+	// connectCARootsFile, connectLeafCertFile and connectLeafKeyFile are the
+	// names of the files the Connect CA roots, leaf certificate and leaf
+	// private key are written to in the task's secrets directory.
+	connectCARootsFile  = "connect_ca_roots.pem"
+	connectLeafCertFile = "connect_leaf_cert.pem"
+	connectLeafKeyFile  = "connect_leaf_key.pem"
+
+	// connectCertsFilePerms is the level of file permissions granted on the
+	// certificate files in the secrets directory for the task
+	connectCertsFilePerms = 0440
+
+	// connectCertsWaitTime is the maximum time a blocking query for a new
+	// leaf certificate waits for a change.
+	connectCertsWaitTime = 5 * time.Minute
+)
+
+// connectCertsAPI is the subset of the Consul Agent API used to fetch the
+// Connect certificates of a native service.
+type connectCertsAPI interface {
+	ConnectCALeaf(service string, q *consulapi.QueryOptions) (*consulapi.LeafCert, *consulapi.QueryMeta, error)
+	ConnectCARoots(q *consulapi.QueryOptions) (*consulapi.CARootList, *consulapi.QueryMeta, error)
+}
+
+type connectNativeCertsHookConfig struct {
+	alloc           *structs.Allocation
+	task            *structs.Task
+	consul          *config.ConsulConfig
+	consulNamespace string
+	lifecycle       ti.TaskLifecycle
+	logger          hclog.Logger
+}
+
+// connectNativeCertsHook delivers the Connect leaf certificate and CA roots
+// of a Connect native service into the secrets directory of its task, and
+// keeps them up to date as Consul rotates them. The task is notified of
+// rotations according to the certs change mode of the service.
+type connectNativeCertsHook struct {
+	// service is the name of the Connect native service
+	service string
+
+	// namespace is the Consul namespace of the service
+	namespace string
+
+	// certs is the certs block of the service, which may be nil
+	certs *structs.ConsulConnectCerts
+
+	// newClient creates the Consul client used to fetch certificates using
+	// the given ACL token, which may be empty.
+	newClient func(token string) (connectCertsAPI, error)
+
+	lifecycle ti.TaskLifecycle
+
+	// firstRun is true until Prestart has started watching for changes
+	firstRun bool
+
+	// ctx and cancel are used to stop watching for changes
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	logger hclog.Logger
+}
+
+func newConnectNativeCertsHook(c *connectNativeCertsHookConfig) *connectNativeCertsHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	h := &connectNativeCertsHook{
+		service:   c.task.Kind.Value(),
+		namespace: c.consulNamespace,
+		lifecycle: c.lifecycle,
+		firstRun:  true,
+		ctx:       ctx,
+		cancel:    cancel,
+		logger:    c.logger.Named(connectNativeCertsHookName),
+	}
+
+	if tg := c.alloc.Job.LookupTaskGroup(c.alloc.TaskGroup); tg != nil {
+		for _, service := range append(tg.Services, c.task.Services...) {
+			if service.Name == h.service && service.Connect.IsNative() {
+				h.namespace = service.ConsulNamespace(c.consulNamespace)
+				h.certs = service.Connect.Certs
+				break
+			}
+		}
+	}
+
+	h.newClient = func(token string) (connectCertsAPI, error) {
+		return newConnectCertsClient(c.consul, token)
+	}
+	return h
+}
+
+// newConnectCertsClient creates a Consul Agent API client from the client's
+// Consul configuration, authenticated with token if it is set.
+func newConnectCertsClient(consul *config.ConsulConfig, token string) (connectCertsAPI, error) {
+	cfg, err := consul.ApiConfig()
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		cfg.Token = token
+	}
+
+	// Blocking queries are bounded by their wait time rather than the Consul
+	// client timeout, which is intended for short requests.
+	if cfg.HttpClient != nil {
+		cfg.HttpClient.Timeout = 0
+	}
+
+	client, err := consulapi.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client.Agent(), nil
+}
+
+func (connectNativeCertsHook) Name() string {
+	return connectNativeCertsHookName
+}
+
+func (h *connectNativeCertsHook) Prestart(
+	ctx context.Context,
+	request *interfaces.TaskPrestartRequest,
+	response *interfaces.TaskPrestartResponse) error {
+
+	// The certificates are kept up to date for the lifetime of the task, so
+	// only the first run needs to start watching them. We do not use the
+	// PrestartDone value because we want to resume watching on restoration.
+	if !h.firstRun {
+		return nil
+	}
+
+	// Use the Service Identity token of the task if Consul ACLs are enabled
+	token, err := ioutil.ReadFile(filepath.Join(request.TaskDir.SecretsDir, sidsTokenFile))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to load SI token")
+	}
+
+	client, err := h.newClient(string(token))
+	if err != nil {
+		return errors.Wrap(err, "failed to create Consul client")
+	}
+
+	index, err := h.update(ctx, client, request.TaskDir.SecretsDir, 0)
+	if err != nil {
+		h.logger.Error("failed to fetch Connect certificates", "error", err)
+		return structs.NewRecoverableError(err, true)
+	}
+
+	h.firstRun = false
+	go h.run(client, request.TaskDir.SecretsDir, index)
+	return nil
+}
+
+func (h *connectNativeCertsHook) Stop(context.Context, *interfaces.TaskStopRequest, *interfaces.TaskStopResponse) error {
+	h.cancel()
+	return nil
+}
+
+func (h *connectNativeCertsHook) Shutdown() {
+	h.cancel()
+}
+
+// run watches for changes to the leaf certificate of the service, writes the
+// new certificates and notifies the task, until the hook is stopped.
+func (h *connectNativeCertsHook) run(client connectCertsAPI, dir string, index uint64) {
+	attempt := 0
+	for {
+		newIndex, err := h.update(h.ctx, client, dir, index)
+		if h.ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			attempt++
+			h.logger.Warn("failed to fetch Connect certificates, retrying", "error", err, "attempt", attempt)
+			if !backoff(h.ctx, attempt) {
+				return
+			}
+			continue
+		}
+		attempt = 0
+
+		if newIndex != index {
+			index = newIndex
+			h.certificatesChanged()
+		}
+	}
+}
+
+// update blocks until the leaf certificate of the service changes from index,
+// then writes it and the current CA roots to dir. It returns the index of the
+// written certificate, which is unchanged if the query timed out.
+func (h *connectNativeCertsHook) update(ctx context.Context, client connectCertsAPI, dir string, index uint64) (uint64, error) {
+	q := &consulapi.QueryOptions{
+		Namespace: h.namespace,
+		WaitIndex: index,
+		WaitTime:  connectCertsWaitTime,
+	}
+
+	leaf, meta, err := client.ConnectCALeaf(h.service, q.WithContext(ctx))
+	if err != nil {
+		return index, errors.Wrap(err, "failed to fetch leaf certificate")
+	}
+	if meta.LastIndex == index {
+		return index, nil
+	}
+
+	roots, _, err := client.ConnectCARoots((&consulapi.QueryOptions{Namespace: h.namespace}).WithContext(ctx))
+	if err != nil {
+		return index, errors.Wrap(err, "failed to fetch CA roots")
+	}
+
+	var rootsPEM strings.Builder
+	for _, root := range roots.Roots {
+		rootsPEM.WriteString(strings.TrimSpace(root.RootCertPEM))
+		rootsPEM.WriteString("\n")
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{connectCARootsFile, rootsPEM.String()},
+		{connectLeafCertFile, leaf.CertPEM},
+		{connectLeafKeyFile, leaf.PrivateKeyPEM},
+	}
+	for _, f := range files {
+		if err := writeConnectCertFile(dir, f.name, f.content); err != nil {
+			return index, err
+		}
+	}
+
+	h.logger.Debug("wrote Connect certificates", "serial", leaf.SerialNumber, "valid_before", leaf.ValidBefore)
+	return meta.LastIndex, nil
+}
+
+// writeConnectCertFile atomically replaces the named file in dir so the task
+// never reads a partially written certificate.
+func writeConnectCertFile(dir, name, content string) error {
+	tmp, err := ioutil.TempFile(dir, name+".tmp")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create secrets/%s", name)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "failed to write secrets/%s", name)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write secrets/%s", name)
+	}
+	if err := os.Chmod(tmp.Name(), connectCertsFilePerms); err != nil {
+		return errors.Wrapf(err, "failed to set permissions of secrets/%s", name)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, name)); err != nil {
+		return errors.Wrapf(err, "failed to write secrets/%s", name)
+	}
+	return nil
+}
+
+// certificatesChanged applies the certs change mode of the service after the
+// certificates have been rotated.
+func (h *connectNativeCertsHook) certificatesChanged() {
+	const msg = "Consul Connect: certificates rotated"
+
+	if h.certs == nil {
+		return
+	}
+
+	switch h.certs.ChangeMode {
+	case structs.ConnectCertsChangeModeSignal:
+		s, err := signals.Parse(h.certs.ChangeSignal)
+		if err != nil {
+			h.logger.Error("failed to parse signal", "error", err)
+			return
+		}
+
+		event := structs.NewTaskEvent(structs.TaskSignaling).SetTaskSignal(s).SetDisplayMessage(msg)
+		if err := h.lifecycle.Signal(event, h.certs.ChangeSignal); err != nil {
+			h.logger.Error("failed to send signal", "error", err)
+		}
+	case structs.ConnectCertsChangeModeRestart:
+		const noFailure = false
+		h.lifecycle.Restart(h.ctx,
+			structs.NewTaskEvent(structs.TaskRestartSignal).SetDisplayMessage(msg), noFailure)
+	}
+}
@@ -0,0 +1,136 @@
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/stretchr/testify/require"
+)
+
+// mockConnectCertsAPI serves a leaf certificate that is rotated by sending
+// to rotateCh.
+type mockConnectCertsAPI struct {
+	lock     sync.Mutex
+	index    uint64
+	service  string
+	rotateCh chan struct{}
+}
+
+func (m *mockConnectCertsAPI) ConnectCALeaf(service string, q *consulapi.QueryOptions) (*consulapi.LeafCert, *consulapi.QueryMeta, error) {
+	m.lock.Lock()
+	m.service = service
+	index := m.index
+	m.lock.Unlock()
+
+	if q.WaitIndex == index {
+		select {
+		case <-m.rotateCh:
+			m.lock.Lock()
+			m.index++
+			index = m.index
+			m.lock.Unlock()
+		case <-q.Context().Done():
+			return nil, nil, q.Context().Err()
+		}
+	}
+
+	leaf := &consulapi.LeafCert{
+		SerialNumber:  "serial",
+		CertPEM:       fmt.Sprintf("cert-%d", index),
+		PrivateKeyPEM: fmt.Sprintf("key-%d", index),
+	}
+	return leaf, &consulapi.QueryMeta{LastIndex: index}, nil
+}
+
+func (m *mockConnectCertsAPI) ConnectCARoots(*consulapi.QueryOptions) (*consulapi.CARootList, *consulapi.QueryMeta, error) {
+	return &consulapi.CARootList{
+		Roots: []*consulapi.CARoot{{RootCertPEM: "root-a\n"}, {RootCertPEM: "root-b"}},
+	}, &consulapi.QueryMeta{}, nil
+}
+
+// mockCertsLifecycle records the signals sent to the task.
+type mockCertsLifecycle struct {
+	signalCh chan string
+}
+
+func (m *mockCertsLifecycle) Restart(context.Context, *structs.TaskEvent, bool) error { return nil }
+func (m *mockCertsLifecycle) Kill(context.Context, *structs.TaskEvent) error          { return nil }
+func (m *mockCertsLifecycle) IsRunning() bool                                         { return true }
+func (m *mockCertsLifecycle) Signal(_ *structs.TaskEvent, s string) error {
+	m.signalCh <- s
+	return nil
+}
+
+func TestConnectNativeCertsHook_Prestart(t *testing.T) {
+	ci.Parallel(t)
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.ConnectNativeAlloc("bridge")
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	tg.Services[0].Connect.Certs = &structs.ConsulConnectCerts{
+		ChangeMode:   structs.ConnectCertsChangeModeSignal,
+		ChangeSignal: "SIGHUP",
+	}
+	task := tg.Tasks[0]
+	task.Kind = structs.NewTaskKind(structs.ConnectNativePrefix, tg.Services[0].Name)
+
+	allocDir, cleanup := allocdir.TestAllocDir(t, logger, "ConnectNativeCerts", alloc.ID)
+	defer cleanup()
+
+	lifecycle := &mockCertsLifecycle{signalCh: make(chan string, 1)}
+	h := newConnectNativeCertsHook(&connectNativeCertsHookConfig{
+		alloc:     alloc,
+		task:      task,
+		consul:    &config.ConsulConfig{},
+		lifecycle: lifecycle,
+		logger:    logger,
+	})
+	api := &mockConnectCertsAPI{index: 1, rotateCh: make(chan struct{})}
+	h.newClient = func(string) (connectCertsAPI, error) { return api, nil }
+	defer h.Shutdown()
+
+	request := &interfaces.TaskPrestartRequest{
+		Task:    task,
+		TaskDir: allocDir.NewTaskDir(task.Name),
+	}
+	require.NoError(t, request.TaskDir.Build(false, nil))
+
+	require.NoError(t, h.Prestart(context.Background(), request, new(interfaces.TaskPrestartResponse)))
+
+	readFile := func(name string) string {
+		b, err := ioutil.ReadFile(filepath.Join(request.TaskDir.SecretsDir, name))
+		require.NoError(t, err)
+		return string(b)
+	}
+	require.Equal(t, "root-a\nroot-b\n", readFile(connectCARootsFile))
+	require.Equal(t, "cert-1", readFile(connectLeafCertFile))
+	require.Equal(t, "key-1", readFile(connectLeafKeyFile))
+
+	api.lock.Lock()
+	require.Equal(t, tg.Services[0].Name, api.service)
+	api.lock.Unlock()
+
+	// rotating the leaf certificate rewrites it and signals the task
+	api.rotateCh <- struct{}{}
+	select {
+	case s := <-lifecycle.signalCh:
+		require.Equal(t, "SIGHUP", s)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for signal")
+	}
+	require.Equal(t, "cert-2", readFile(connectLeafCertFile))
+	require.Equal(t, "key-2", readFile(connectLeafKeyFile))
+}
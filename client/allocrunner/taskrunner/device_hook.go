@@ -5,8 +5,11 @@ import (
 	"fmt"
 
 	log "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	"github.com/hashicorp/nomad/client/devicemanager"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/device"
 	"github.com/hashicorp/nomad/plugins/drivers"
 )
@@ -14,17 +17,27 @@ import (
 const (
 	// HookNameDevices is the name of the devices hook
 	HookNameDevices = "devices"
+
+	// deviceHookResetKey is the key of the hook state recording that the
+	// devices of the task were reset
+	deviceHookResetKey = "reset"
 )
 
-// deviceHook is used to retrieve device mounting information.
+// deviceHook is used to retrieve device mounting information and to reset
+// the devices once the task has exited.
 type deviceHook struct {
 	logger log.Logger
 	dm     devicemanager.Manager
+
+	// resources are the resources allocated to the task, holding the devices
+	// to reset when the task stops.
+	resources *structs.AllocatedTaskResources
 }
 
-func newDeviceHook(dm devicemanager.Manager, logger log.Logger) *deviceHook {
+func newDeviceHook(dm devicemanager.Manager, resources *structs.AllocatedTaskResources, logger log.Logger) *deviceHook {
 	h := &deviceHook{
-		dm: dm,
+		dm:        dm,
+		resources: resources,
 	}
 	h.logger = logger.Named(h.Name())
 	return h
@@ -77,6 +90,37 @@ func (h *deviceHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartR
 	return nil
 }
 
+// Stop resets the devices of the task so they are clean before being reserved
+// for another allocation. Devices which fail to reset are marked unhealthy by
+// the device manager. The devices are reset exactly once, so restoring the
+// dead task after the client restarts doesn't reset them again, possibly
+// while they are already reserved for another allocation.
+func (h *deviceHook) Stop(ctx context.Context, req *interfaces.TaskStopRequest, resp *interfaces.TaskStopResponse) error {
+	if h.resources == nil || len(h.resources.Devices) == 0 {
+		return nil
+	}
+	if req.ExistingState[deviceHookResetKey] == "true" {
+		return nil
+	}
+
+	var mErr multierror.Error
+	for _, d := range h.resources.Devices {
+		if err := h.dm.Reset(d); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("failed to reset device %s: %v", d.ID(), err))
+		}
+	}
+
+	// Record the reset even if it failed, as failed devices are marked
+	// unhealthy instead of being reset again
+	resp.State = helper.CopyMapStringString(req.ExistingState)
+	if resp.State == nil {
+		resp.State = make(map[string]string, 1)
+	}
+	resp.State[deviceHookResetKey] = "true"
+
+	return mErr.ErrorOrNil()
+}
+
 func convertMount(in *device.Mount) *drivers.MountConfig {
 	return &drivers.MountConfig{
 		TaskPath: in.TaskPath,
@@ -21,7 +21,7 @@ func TestDeviceHook_CorrectDevice(t *testing.T) {
 
 	dm := devicemanager.NoopMockManager()
 	l := testlog.HCLogger(t)
-	h := newDeviceHook(dm, l)
+	h := newDeviceHook(dm, nil, l)
 
 	reqDev := &structs.AllocatedDeviceResource{
 		Vendor:    "foo",
@@ -103,7 +103,7 @@ func TestDeviceHook_IncorrectDevice(t *testing.T) {
 
 	dm := devicemanager.NoopMockManager()
 	l := testlog.HCLogger(t)
-	h := newDeviceHook(dm, l)
+	h := newDeviceHook(dm, nil, l)
 
 	reqDev := &structs.AllocatedDeviceResource{
 		Vendor:    "foo",
@@ -130,3 +130,58 @@ func TestDeviceHook_IncorrectDevice(t *testing.T) {
 	err := h.Prestart(context.Background(), req, &resp)
 	require.Error(err)
 }
+
+func TestDeviceHook_Stop(t *testing.T) {
+	ci.Parallel(t)
+
+	dm := devicemanager.NoopMockManager()
+	l := testlog.HCLogger(t)
+
+	devs := []*structs.AllocatedDeviceResource{
+		{
+			Vendor:    "foo",
+			Type:      "bar",
+			Name:      "baz",
+			DeviceIDs: []string{"123"},
+		},
+		{
+			Vendor:    "foo",
+			Type:      "bar",
+			Name:      "qux",
+			DeviceIDs: []string{"456"},
+		},
+	}
+	h := newDeviceHook(dm, &structs.AllocatedTaskResources{Devices: devs}, l)
+
+	// Every device is reset, even if resetting one of them fails
+	var reset []string
+	dm.ResetF = func(d *structs.AllocatedDeviceResource) error {
+		reset = append(reset, d.DeviceIDs...)
+		if d.Name == "baz" {
+			return fmt.Errorf("device busy")
+		}
+		return nil
+	}
+
+	var resp interfaces.TaskStopResponse
+	err := h.Stop(context.Background(), &interfaces.TaskStopRequest{}, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to reset device foo/bar/baz")
+	require.Contains(t, err.Error(), "device busy")
+	require.Equal(t, []string{"123", "456"}, reset)
+	require.Equal(t, map[string]string{deviceHookResetKey: "true"}, resp.State)
+
+	// Devices are not reset again when a dead task is restored
+	reset = nil
+	req := &interfaces.TaskStopRequest{ExistingState: resp.State}
+	resp = interfaces.TaskStopResponse{}
+	require.NoError(t, h.Stop(context.Background(), req, &resp))
+	require.Empty(t, reset)
+	require.Nil(t, resp.State)
+
+	// Tasks without devices have nothing to reset
+	h = newDeviceHook(dm, &structs.AllocatedTaskResources{}, l)
+	reset = nil
+	require.NoError(t, h.Stop(context.Background(), &interfaces.TaskStopRequest{}, &interfaces.TaskStopResponse{}))
+	require.Empty(t, reset)
+}
@@ -2,26 +2,45 @@ package taskrunner
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/golang/snappy"
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/lib/fifo"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
 )
 
-// dispatchHook writes a dispatch payload to the task dir
+// dispatchHook writes a dispatch payload to the task dir, or streams it to
+// the task's stdin
 type dispatchHook struct {
 	payload []byte
 
+	// stdinFifo is the path of the fifo the payload is streamed through if
+	// the task reads it from stdin
+	stdinFifo string
+
+	// caps are the capabilities of the task driver
+	caps *drivers.Capabilities
+
+	// stopStdin stops streaming the payload to the task's stdin
+	stopStdin     context.CancelFunc
+	stopStdinLock sync.Mutex
+
 	logger hclog.Logger
 }
 
-func newDispatchHook(alloc *structs.Allocation, logger hclog.Logger) *dispatchHook {
+func newDispatchHook(alloc *structs.Allocation, stdinFifo string, caps *drivers.Capabilities, logger hclog.Logger) *dispatchHook {
 	h := &dispatchHook{
-		payload: alloc.Job.Payload,
+		payload:   alloc.Job.Payload,
+		stdinFifo: stdinFifo,
+		caps:      caps,
 	}
 	h.logger = logger.Named(h.Name())
 	return h
@@ -34,6 +53,12 @@ func (*dispatchHook) Name() string {
 }
 
 func (h *dispatchHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
+	if req.Task.DispatchPayload != nil && req.Task.DispatchPayload.Stdin {
+		// The payload is streamed again every time the task starts, so the
+		// hook is never done
+		return h.prestartStdin()
+	}
+
 	if len(h.payload) == 0 || req.Task.DispatchPayload == nil || req.Task.DispatchPayload.File == "" {
 		// No dispatch payload
 		resp.Done = true
@@ -56,6 +81,105 @@ func (h *dispatchHook) Prestart(ctx context.Context, req *interfaces.TaskPrestar
 	return nil
 }
 
+// prestartStdin creates the fifo the task's stdin is read from and starts
+// streaming the payload to it.
+func (h *dispatchHook) prestartStdin() error {
+	if h.stdinFifo == "" {
+		return structs.NewRecoverableError(
+			errors.New("reading the dispatch payload from stdin is not supported on this client"), false)
+	}
+	if h.caps == nil || !h.caps.Stdin {
+		return structs.NewRecoverableError(
+			errors.New("task driver does not support reading the dispatch payload from stdin"), false)
+	}
+
+	// Without a payload the fifo is closed as soon as the task opens it, so
+	// the task reads EOF
+	var decoded []byte
+	if len(h.payload) != 0 {
+		var err error
+		decoded, err = snappy.Decode(nil, h.payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Stop streaming to the previous instance of the task, if any
+	h.stop()
+
+	if err := os.Remove(h.stdinFifo); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stdin fifo: %v", err)
+	}
+	if _, err := fifo.CreateAndRead(h.stdinFifo); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.stopStdinLock.Lock()
+	h.stopStdin = cancel
+	h.stopStdinLock.Unlock()
+
+	go h.streamPayload(ctx, decoded)
+	return nil
+}
+
+// streamPayload writes the payload to the stdin fifo once the task driver
+// opens it, and closes the fifo so that the task reads EOF.
+func (h *dispatchHook) streamPayload(ctx context.Context, payload []byte) {
+	opened := make(chan struct{})
+	go func() {
+		select {
+		case <-opened:
+		case <-ctx.Done():
+			// Opening the fifo for reading unblocks the writer if the task
+			// never started
+			if r, err := fifo.OpenReader(h.stdinFifo); err == nil {
+				r.Close()
+			}
+		}
+	}()
+
+	w, err := fifo.OpenWriter(h.stdinFifo)
+	close(opened)
+	if err != nil {
+		h.logger.Error("failed to open stdin fifo", "error", err)
+		return
+	}
+	defer w.Close()
+
+	if ctx.Err() != nil {
+		return
+	}
+	if _, err := w.Write(payload); err != nil {
+		h.logger.Warn("failed to stream dispatch payload to stdin", "error", err)
+		return
+	}
+
+	h.logger.Trace("dispatch payload streamed to stdin", "bytes", len(payload))
+}
+
+// Stop stops streaming the payload and removes the stdin fifo.
+func (h *dispatchHook) Stop(ctx context.Context, req *interfaces.TaskStopRequest, resp *interfaces.TaskStopResponse) error {
+	if h.stdinFifo == "" {
+		return nil
+	}
+
+	h.stop()
+	if err := os.Remove(h.stdinFifo); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stdin fifo: %v", err)
+	}
+	return nil
+}
+
+func (h *dispatchHook) stop() {
+	h.stopStdinLock.Lock()
+	defer h.stopStdinLock.Unlock()
+	if h.stopStdin != nil {
+		h.stopStdin()
+		h.stopStdin = nil
+	}
+}
+
 // writeDispatchPayload writes the payload to the given file or returns an
 // error.
 func writeDispatchPayload(base, filename string, payload []byte) error {
@@ -3,21 +3,26 @@ package taskrunner
 import (
 	"context"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/golang/snappy"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/lib/fifo"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/stretchr/testify/require"
 )
 
 // Statically assert the stats hook implements the expected interfaces
 var _ interfaces.TaskPrestartHook = (*dispatchHook)(nil)
+var _ interfaces.TaskStopHook = (*dispatchHook)(nil)
 
 // TestTaskRunner_DispatchHook_NoPayload asserts that the hook is a noop and is
 // marked as done if there is no dispatch payload.
@@ -37,7 +42,7 @@ func TestTaskRunner_DispatchHook_NoPayload(t *testing.T) {
 	taskDir := allocDir.NewTaskDir(task.Name)
 	require.NoError(taskDir.Build(false, nil))
 
-	h := newDispatchHook(alloc, logger)
+	h := newDispatchHook(alloc, "", nil, logger)
 
 	req := interfaces.TaskPrestartRequest{
 		Task:    task,
@@ -83,7 +88,7 @@ func TestTaskRunner_DispatchHook_Ok(t *testing.T) {
 	taskDir := allocDir.NewTaskDir(task.Name)
 	require.NoError(taskDir.Build(false, nil))
 
-	h := newDispatchHook(alloc, logger)
+	h := newDispatchHook(alloc, "", nil, logger)
 
 	req := interfaces.TaskPrestartRequest{
 		Task:    task,
@@ -128,7 +133,7 @@ func TestTaskRunner_DispatchHook_Error(t *testing.T) {
 	taskDir := allocDir.NewTaskDir(task.Name)
 	require.NoError(taskDir.Build(false, nil))
 
-	h := newDispatchHook(alloc, logger)
+	h := newDispatchHook(alloc, "", nil, logger)
 
 	req := interfaces.TaskPrestartRequest{
 		Task:    task,
@@ -145,3 +150,112 @@ func TestTaskRunner_DispatchHook_Error(t *testing.T) {
 	require.NoError(err)
 	require.Empty(files)
 }
+
+// TestTaskRunner_DispatchHook_Stdin asserts that dispatch payloads are
+// streamed through the stdin fifo every time the task starts.
+func TestTaskRunner_DispatchHook_Stdin(t *testing.T) {
+	ci.Parallel(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("streaming the payload to stdin is not supported on Windows")
+	}
+
+	require := require.New(t)
+	ctx := context.Background()
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.BatchAlloc()
+	alloc.Job.ParameterizedJob = &structs.ParameterizedJobConfig{
+		Payload: structs.DispatchPayloadRequired,
+	}
+	expected := []byte("hello world")
+	alloc.Job.Payload = snappy.Encode(nil, expected)
+
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.DispatchPayload = &structs.DispatchPayloadConfig{
+		Stdin: true,
+	}
+
+	stdinFifo := filepath.Join(t.TempDir(), "stdin.fifo")
+	h := newDispatchHook(alloc, stdinFifo, &drivers.Capabilities{Stdin: true}, logger)
+	req := interfaces.TaskPrestartRequest{Task: task}
+
+	for i := 0; i < 2; i++ {
+		// The hook is run again when the task restarts
+		resp := interfaces.TaskPrestartResponse{}
+		require.NoError(h.Prestart(ctx, &req, &resp))
+		require.False(resp.Done)
+
+		r, err := fifo.OpenReader(stdinFifo)
+		require.NoError(err)
+		result, err := ioutil.ReadAll(r)
+		require.NoError(err)
+		require.NoError(r.Close())
+		require.Equal(expected, result)
+	}
+
+	// Stopping the hook unblocks a payload that was never read and removes
+	// the fifo
+	resp := interfaces.TaskPrestartResponse{}
+	require.NoError(h.Prestart(ctx, &req, &resp))
+	require.NoError(h.Stop(ctx, &interfaces.TaskStopRequest{}, &interfaces.TaskStopResponse{}))
+	_, err := os.Stat(stdinFifo)
+	require.True(os.IsNotExist(err))
+}
+
+// TestTaskRunner_DispatchHook_Stdin_NoPayload asserts that tasks dispatched
+// without an optional payload read an empty stdin.
+func TestTaskRunner_DispatchHook_Stdin_NoPayload(t *testing.T) {
+	ci.Parallel(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("streaming the payload to stdin is not supported on Windows")
+	}
+
+	require := require.New(t)
+	ctx := context.Background()
+
+	alloc := mock.BatchAlloc()
+	alloc.Job.ParameterizedJob = &structs.ParameterizedJobConfig{
+		Payload: structs.DispatchPayloadOptional,
+	}
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.DispatchPayload = &structs.DispatchPayloadConfig{
+		Stdin: true,
+	}
+
+	stdinFifo := filepath.Join(t.TempDir(), "stdin.fifo")
+	h := newDispatchHook(alloc, stdinFifo, &drivers.Capabilities{Stdin: true}, testlog.HCLogger(t))
+	req := interfaces.TaskPrestartRequest{Task: task}
+	resp := interfaces.TaskPrestartResponse{}
+	require.NoError(h.Prestart(ctx, &req, &resp))
+
+	r, err := fifo.OpenReader(stdinFifo)
+	require.NoError(err)
+	result, err := ioutil.ReadAll(r)
+	require.NoError(err)
+	require.NoError(r.Close())
+	require.Empty(result)
+
+	require.NoError(h.Stop(ctx, &interfaces.TaskStopRequest{}, &interfaces.TaskStopResponse{}))
+}
+
+// TestTaskRunner_DispatchHook_Stdin_Unsupported asserts that tasks fail to
+// start if their driver can't stream the payload to stdin.
+func TestTaskRunner_DispatchHook_Stdin_Unsupported(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	alloc.Job.Payload = snappy.Encode(nil, []byte("hello world"))
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.DispatchPayload = &structs.DispatchPayloadConfig{
+		Stdin: true,
+	}
+
+	stdinFifo := filepath.Join(t.TempDir(), "stdin.fifo")
+	h := newDispatchHook(alloc, stdinFifo, &drivers.Capabilities{}, testlog.HCLogger(t))
+	req := interfaces.TaskPrestartRequest{Task: task}
+	resp := interfaces.TaskPrestartResponse{}
+
+	err := h.Prestart(context.Background(), &req, &resp)
+	require.EqualError(t, err, "task driver does not support reading the dispatch payload from stdin")
+	require.False(t, structs.IsRecoverable(err))
+}
@@ -179,6 +179,8 @@ func isConnectKind(kind string) bool {
 		return true
 	case structs.ConnectMeshPrefix:
 		return true
+	case structs.ConnectAPIGatewayPrefix:
+		return true
 	default:
 		return false
 	}
@@ -466,7 +468,7 @@ func (h *envoyBootstrapHook) newEnvoyBootstrapArgs(
 	grpcAddr, envoyAdminBind, envoyReadyBind, siToken, filepath string,
 ) envoyBootstrapArgs {
 
-	namespace := h.getConsulNamespace()
+	namespace := service.ConsulNamespace(h.getConsulNamespace())
 	proxyID := h.proxyServiceID(group, service)
 
 	var gateway string
@@ -479,10 +481,12 @@ func (h *envoyBootstrapHook) newEnvoyBootstrapArgs(
 		gateway = "terminating"
 	case service.Connect.IsMesh():
 		gateway = "mesh"
+	case service.Connect.IsAPIGateway():
+		gateway = "api"
 	}
 
 	h.logger.Info("bootstrapping envoy",
-		"namespace", namespace, "proxy_id", proxyID, "service", service.Name,
+		"namespace", namespace, "partition", service.Partition, "proxy_id", proxyID, "service", service.Name,
 		"gateway", gateway, "bootstrap_file", filepath, "grpc_addr", grpcAddr,
 		"admin_bind", envoyAdminBind, "ready_bind", envoyReadyBind,
 	)
@@ -496,6 +500,7 @@ func (h *envoyBootstrapHook) newEnvoyBootstrapArgs(
 		gateway:        gateway,
 		proxyID:        proxyID,
 		namespace:      namespace,
+		partition:      service.Partition,
 	}
 }
 
@@ -511,6 +516,7 @@ type envoyBootstrapArgs struct {
 	gateway        string // gateways only
 	proxyID        string // gateways and sidecars
 	namespace      string
+	partition      string
 }
 
 // args returns the CLI arguments consul needs in the correct order, with the
@@ -551,6 +557,10 @@ func (e envoyBootstrapArgs) args() []string {
 		arguments = append(arguments, "-namespace", v)
 	}
 
+	if v := e.partition; v != "" {
+		arguments = append(arguments, "-partition", v)
+	}
+
 	return arguments
 }
 
@@ -573,6 +583,9 @@ func (e envoyBootstrapArgs) env(env []string) []string {
 	if v := e.namespace; v != "" {
 		env = append(env, fmt.Sprintf("%s=%s", "CONSUL_NAMESPACE", v))
 	}
+	if v := e.partition; v != "" {
+		env = append(env, fmt.Sprintf("%s=%s", "CONSUL_PARTITION", v))
+	}
 	return env
 }
 
@@ -225,6 +225,29 @@ func TestEnvoyBootstrapHook_envoyBootstrapArgs(t *testing.T) {
 			"-gateway", "my-mesh-gateway",
 		}, result)
 	})
+
+	t.Run("namespace and partition", func(t *testing.T) {
+		ebArgs := envoyBootstrapArgs{
+			proxyID:        "s1-sidecar-proxy",
+			grpcAddr:       "1.1.1.1",
+			consulConfig:   consulPlainConfig,
+			envoyAdminBind: "127.0.0.2:19000",
+			envoyReadyBind: "127.0.0.1:19100",
+			namespace:      "team-a",
+			partition:      "tenant-a",
+		}
+		result := ebArgs.args()
+		require.Equal(t, []string{"connect", "envoy",
+			"-grpc-addr", "1.1.1.1",
+			"-http-addr", "2.2.2.2",
+			"-admin-bind", "127.0.0.2:19000",
+			"-address", "127.0.0.1:19100",
+			"-proxy-id", "s1-sidecar-proxy",
+			"-bootstrap",
+			"-namespace", "team-a",
+			"-partition", "tenant-a",
+		}, result)
+	})
 }
 
 func TestEnvoyBootstrapHook_envoyBootstrapEnv(t *testing.T) {
@@ -256,6 +279,21 @@ func TestEnvoyBootstrapHook_envoyBootstrapEnv(t *testing.T) {
 			envoyAdminBind: "localhost:3333",
 		}.env(environment))
 	})
+
+	t.Run("namespace and partition", func(t *testing.T) {
+		require.Equal(t, []string{
+			"foo=bar", "baz=1",
+			"CONSUL_NAMESPACE=team-a",
+			"CONSUL_PARTITION=tenant-a",
+		}, envoyBootstrapArgs{
+			proxyID:        "s1-sidecar-proxy",
+			grpcAddr:       "1.1.1.1",
+			consulConfig:   consulPlainConfig,
+			envoyAdminBind: "localhost:3333",
+			namespace:      "team-a",
+			partition:      "tenant-a",
+		}.env(environment))
+	})
 }
 
 // envoyConfig is used to unmarshal an envoy bootstrap configuration file, so that
@@ -850,6 +888,7 @@ func TestTaskRunner_EnvoyBootstrapHook_isConnectKind(t *testing.T) {
 	require.True(t, isConnectKind(structs.ConnectIngressPrefix))
 	require.True(t, isConnectKind(structs.ConnectTerminatingPrefix))
 	require.True(t, isConnectKind(structs.ConnectMeshPrefix))
+	require.True(t, isConnectKind(structs.ConnectAPIGatewayPrefix))
 	require.False(t, isConnectKind(""))
 	require.False(t, isConnectKind("something"))
 }
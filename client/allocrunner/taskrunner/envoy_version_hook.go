@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-version"
 	ifs "github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/consul"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/helper/envoy"
@@ -22,22 +23,25 @@ const (
 type envoyVersionHookConfig struct {
 	alloc         *structs.Allocation
 	proxiesClient consul.SupportedProxiesAPI
+	envoyVersions []*config.EnvoyVersionConfig
 	logger        hclog.Logger
 }
 
-func newEnvoyVersionHookConfig(alloc *structs.Allocation, proxiesClient consul.SupportedProxiesAPI, logger hclog.Logger) *envoyVersionHookConfig {
+func newEnvoyVersionHookConfig(alloc *structs.Allocation, proxiesClient consul.SupportedProxiesAPI, envoyVersions []*config.EnvoyVersionConfig, logger hclog.Logger) *envoyVersionHookConfig {
 	return &envoyVersionHookConfig{
 		alloc:         alloc,
 		logger:        logger,
 		proxiesClient: proxiesClient,
+		envoyVersions: envoyVersions,
 	}
 }
 
 // envoyVersionHook is used to determine and set the Docker image used for Consul
 // Connect sidecar proxy tasks. It will query Consul for a set of preferred Envoy
-// versions if the task image is unset or references ${NOMAD_envoy_version}. Nomad
-// will fallback the image to the previous default Envoy v1.11.2 if Consul is too old
-// to support the supported proxies API.
+// versions if the task image is unset or references ${NOMAD_envoy_version}, unless
+// the compatibility matrix of the client selects a version for the version of
+// Consul on the node. Nomad will fallback the image to the previous default Envoy
+// v1.11.2 if Consul is too old to support the supported proxies API.
 type envoyVersionHook struct {
 	// alloc is the allocation with the envoy task being rewritten.
 	alloc *structs.Allocation
@@ -46,6 +50,10 @@ type envoyVersionHook struct {
 	// from Consul about the versions of Envoy it supports.
 	proxiesClient consul.SupportedProxiesAPI
 
+	// envoyVersions is the compatibility matrix of Consul and Envoy versions
+	// configured on the client.
+	envoyVersions []*config.EnvoyVersionConfig
+
 	// logger is used to log things.
 	logger hclog.Logger
 }
@@ -54,6 +62,7 @@ func newEnvoyVersionHook(c *envoyVersionHookConfig) *envoyVersionHook {
 	return &envoyVersionHook{
 		alloc:         c.alloc,
 		proxiesClient: c.proxiesClient,
+		envoyVersions: c.envoyVersions,
 		logger:        c.logger.Named(envoyVersionHookName),
 	}
 }
@@ -77,6 +86,16 @@ func (h *envoyVersionHook) Prestart(_ context.Context, request *ifs.TaskPrestart
 		return nil
 	}
 
+	// The compatibility matrix of the client takes precedence over the
+	// versions supported by Consul.
+	if v := h.matrixVersion(request.TaskEnv); v != "" {
+		image := strings.ReplaceAll(h.taskImage(request.Task.Config), envoy.VersionVar, v)
+		h.logger.Trace("setting task envoy image from version matrix", "image", image)
+		request.Task.Config["image"] = image
+		response.Done = true
+		return nil
+	}
+
 	// We either need to acquire Consul's preferred Envoy version or fallback
 	// to the legacy default. Query Consul and use the (possibly empty) result.
 	proxies, err := h.proxiesClient.Proxies()
@@ -162,6 +181,38 @@ func (h *envoyVersionHook) needsVersion(config map[string]interface{}) bool {
 	return strings.Contains(image, envoy.VersionVar)
 }
 
+// matrixVersion returns the Envoy version selected by the compatibility matrix
+// for the version of Consul fingerprinted on the node, or an empty string if no
+// entry matches.
+func (h *envoyVersionHook) matrixVersion(env *taskenv.TaskEnv) string {
+	if len(h.envoyVersions) == 0 || env == nil {
+		return ""
+	}
+
+	raw, ok := env.NodeAttrs["attr.consul.version"]
+	if !ok {
+		return ""
+	}
+	consulVersion, err := version.NewVersion(raw)
+	if err != nil {
+		h.logger.Debug("failed to parse consul version", "version", raw, "error", err)
+		return ""
+	}
+
+	for _, e := range h.envoyVersions {
+		if !e.Matches(consulVersion) {
+			continue
+		}
+		v, err := semver(e.Envoy)
+		if err != nil {
+			h.logger.Warn("invalid envoy version in matrix", "version", e.Envoy, "error", err)
+			return ""
+		}
+		return v
+	}
+	return ""
+}
+
 // tweakImage determines the best Envoy version to use. If supported is nil or empty
 // Nomad will fallback to the legacy envoy image used before Nomad v1.0.
 func (h *envoyVersionHook) tweakImage(configured string, supported map[string][]string) (string, error) {
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocdir"
 	ifs "github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/command/agent/consul"
 	"github.com/hashicorp/nomad/helper/envoy"
@@ -241,7 +242,7 @@ func TestTaskRunner_EnvoyVersionHook_Prestart_standard(t *testing.T) {
 	}
 
 	// Run envoy_version hook
-	h := newEnvoyVersionHook(newEnvoyVersionHookConfig(alloc, spAPI, logger))
+	h := newEnvoyVersionHook(newEnvoyVersionHookConfig(alloc, spAPI, nil, logger))
 
 	// Create a prestart request
 	request := &ifs.TaskPrestartRequest{
@@ -264,6 +265,59 @@ func TestTaskRunner_EnvoyVersionHook_Prestart_standard(t *testing.T) {
 	require.Equal(t, "envoyproxy/envoy:v1.15.0", request.Task.Config["image"])
 }
 
+func TestTaskRunner_EnvoyVersionHook_Prestart_matrix(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+
+	// Setup an Allocation
+	alloc := mock.ConnectAlloc()
+	alloc.Job.TaskGroups[0].Tasks[0] = mock.ConnectSidecarTask()
+	allocDir, cleanupDir := allocdir.TestAllocDir(t, logger, "EnvoyVersionHook", alloc.ID)
+	defer cleanupDir()
+
+	// Setup a mock for Consul API
+	spAPI := consul.MockSupportedProxiesAPI{
+		Value: map[string][]string{
+			"envoy": {"1.15.0", "1.14.4"},
+		},
+		Error: nil,
+	}
+
+	envoyVersions := []*config.EnvoyVersionConfig{
+		{Envoy: "1.22.2", Consul: ">= 1.12.0, < 1.13.0"},
+		{Envoy: "1.20.4", Consul: "< 1.12.0"},
+	}
+
+	// Run envoy_version hook
+	h := newEnvoyVersionHook(newEnvoyVersionHookConfig(alloc, spAPI, envoyVersions, logger))
+
+	run := func(consulVersion string) string {
+		task := alloc.Job.TaskGroups[0].Tasks[0].Copy()
+		request := &ifs.TaskPrestartRequest{
+			Task:    task,
+			TaskDir: allocDir.NewTaskDir(task.Name),
+			TaskEnv: taskenv.NewTaskEnv(nil, nil, nil, map[string]string{
+				"meta.connect.sidecar_image": envoy.ImageFormat,
+				"attr.consul.version":        consulVersion,
+			}, "", ""),
+		}
+		require.NoError(t, request.TaskDir.Build(false, nil))
+
+		var response ifs.TaskPrestartResponse
+		require.NoError(t, h.Prestart(context.Background(), request, &response))
+		require.True(t, response.Done)
+		return task.Config["image"].(string)
+	}
+
+	// The first matching entry of the matrix is used
+	require.Equal(t, "envoyproxy/envoy:v1.22.2", run("1.12.3"))
+	require.Equal(t, "envoyproxy/envoy:v1.20.4", run("1.11.0"))
+
+	// Versions of Consul outside the matrix use the versions Consul supports
+	require.Equal(t, "envoyproxy/envoy:v1.15.0", run("1.13.1"))
+}
+
 func TestTaskRunner_EnvoyVersionHook_Prestart_custom(t *testing.T) {
 	ci.Parallel(t)
 
@@ -285,7 +339,7 @@ func TestTaskRunner_EnvoyVersionHook_Prestart_custom(t *testing.T) {
 	}
 
 	// Run envoy_version hook
-	h := newEnvoyVersionHook(newEnvoyVersionHookConfig(alloc, spAPI, logger))
+	h := newEnvoyVersionHook(newEnvoyVersionHookConfig(alloc, spAPI, nil, logger))
 
 	// Create a prestart request
 	request := &ifs.TaskPrestartRequest{
@@ -332,7 +386,7 @@ func TestTaskRunner_EnvoyVersionHook_Prestart_skip(t *testing.T) {
 	}
 
 	// Run envoy_version hook
-	h := newEnvoyVersionHook(newEnvoyVersionHookConfig(alloc, spAPI, logger))
+	h := newEnvoyVersionHook(newEnvoyVersionHookConfig(alloc, spAPI, nil, logger))
 
 	// Create a prestart request
 	request := &ifs.TaskPrestartRequest{
@@ -373,7 +427,7 @@ func TestTaskRunner_EnvoyVersionHook_Prestart_fallback(t *testing.T) {
 	}
 
 	// Run envoy_version hook
-	h := newEnvoyVersionHook(newEnvoyVersionHookConfig(alloc, spAPI, logger))
+	h := newEnvoyVersionHook(newEnvoyVersionHookConfig(alloc, spAPI, nil, logger))
 
 	// Create a prestart request
 	request := &ifs.TaskPrestartRequest{
@@ -414,7 +468,7 @@ func TestTaskRunner_EnvoyVersionHook_Prestart_error(t *testing.T) {
 	}
 
 	// Run envoy_version hook
-	h := newEnvoyVersionHook(newEnvoyVersionHookConfig(alloc, spAPI, logger))
+	h := newEnvoyVersionHook(newEnvoyVersionHookConfig(alloc, spAPI, nil, logger))
 
 	// Create a prestart request
 	request := &ifs.TaskPrestartRequest{
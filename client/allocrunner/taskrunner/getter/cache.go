@@ -0,0 +1,325 @@
+package getter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	gg "github.com/hashicorp/go-getter"
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// cacheTmpPrefix is the prefix of the directories artifacts are
+	// downloaded into before being added to the cache.
+	cacheTmpPrefix = ".tmp-"
+
+	// cacheArtifact is the name of the artifact in the directory of its
+	// cache entry.
+	cacheArtifact = "artifact"
+)
+
+// Cache is a content-addressed cache of the artifacts verified by a checksum,
+// shared by all the allocations of the client. Cached artifacts are copied
+// into the task directories instead of being downloaded again. They are never
+// linked, as a task writing to a file sharing its content with the cache
+// would change the artifact handed to every other task. The least recently
+// used artifacts are removed once the cache grows above its size.
+type Cache struct {
+	dir      string
+	maxBytes int64
+	logger   log.Logger
+
+	l       sync.Mutex
+	entries map[string]*cacheEntry
+	size    int64
+}
+
+type cacheEntry struct {
+	size     int64
+	lastUsed time.Time
+}
+
+// NewCache returns a cache of at most maxBytes in dir, which holds the
+// artifacts cached before the client restarted.
+func NewCache(dir string, maxBytes int64, logger log.Logger) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create artifact cache dir: %v", err)
+	}
+
+	c := &Cache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		logger:   logger.Named("artifact_cache"),
+		entries:  make(map[string]*cacheEntry),
+	}
+
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact cache dir: %v", err)
+	}
+	for _, dirEntry := range dirEntries {
+		entryPath := filepath.Join(dir, dirEntry.Name())
+
+		// Remove the downloads interrupted by the client stopping
+		if !dirEntry.IsDir() || strings.HasPrefix(dirEntry.Name(), cacheTmpPrefix) {
+			os.RemoveAll(entryPath)
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			return nil, err
+		}
+		size, err := treeSize(entryPath)
+		if err != nil {
+			return nil, err
+		}
+		c.entries[dirEntry.Name()] = &cacheEntry{size: size, lastUsed: info.ModTime()}
+		c.size += size
+	}
+
+	c.l.Lock()
+	c.evictLocked("")
+	c.l.Unlock()
+	return c, nil
+}
+
+// cacheKey returns the key of the artifact at the interpolated source URL src
+// in the cache, or false if the artifact isn't verified by a checksum and
+// can't be cached. Besides the checksum, the key covers the options
+// determining the files written by the download, so the same artifact
+// downloaded from different hosts shares an entry.
+func cacheKey(mode, src string) (string, bool) {
+	source, subdir := gg.SourceDirSubdir(src)
+	u, err := url.Parse(source)
+	if err != nil {
+		return "", false
+	}
+	q := u.Query()
+
+	checksum := strings.ToLower(q.Get("checksum"))
+	idx := strings.Index(checksum, ":")
+	if idx == -1 {
+		return "", false
+	}
+	switch checksum[:idx] {
+	case "md5", "sha1", "sha256", "sha512":
+	default:
+		// Checksum files may change without the source changing
+		return "", false
+	}
+
+	if mode == "" {
+		mode = structs.GetterModeAny
+	}
+
+	// The name of the downloaded file comes from the path of the source
+	name := path.Base(u.Path)
+
+	h := sha256.New()
+	for _, part := range []string{checksum, mode, q.Get("archive"), name, subdir} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+// Get copies the artifact with the key into dest, calling download to add it
+// to the cache first if it isn't cached. download must write the artifact to
+// the path it is given as it would write it to dest.
+func (c *Cache) Get(key, dest, mode string, download func(dst string) error) error {
+	c.l.Lock()
+	_, cached := c.entries[key]
+	c.l.Unlock()
+
+	if cached {
+		metrics.IncrCounter([]string{"client", "artifact_cache", "hit"}, 1)
+	} else {
+		metrics.IncrCounter([]string{"client", "artifact_cache", "miss"}, 1)
+		if err := c.add(key, download); err != nil {
+			return err
+		}
+	}
+
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return fmt.Errorf("artifact was removed from the cache while being added")
+	}
+	now := time.Now()
+	entry.lastUsed = now
+	entryPath := filepath.Join(c.dir, key)
+	if err := os.Chtimes(entryPath, now, now); err != nil {
+		c.logger.Warn("failed to update access time of cached artifact", "key", key, "error", err)
+	}
+
+	if err := copyTree(filepath.Join(entryPath, cacheArtifact), dest, mode); err != nil {
+		return fmt.Errorf("failed to copy cached artifact: %v", err)
+	}
+
+	c.evictLocked(key)
+	return nil
+}
+
+// add downloads the artifact with the key into the cache. The artifact is
+// downloaded into a temporary directory, which is only renamed to its entry
+// once complete.
+func (c *Cache) add(key string, download func(dst string) error) error {
+	tmp, err := os.MkdirTemp(c.dir, cacheTmpPrefix)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := download(filepath.Join(tmp, cacheArtifact)); err != nil {
+		return err
+	}
+
+	size, err := treeSize(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to add artifact to the cache: %v", err)
+	}
+
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	// The artifact may have been added by another task meanwhile
+	if _, ok := c.entries[key]; ok {
+		return nil
+	}
+	if err := os.Rename(tmp, filepath.Join(c.dir, key)); err != nil {
+		return fmt.Errorf("failed to add artifact to the cache: %v", err)
+	}
+	c.entries[key] = &cacheEntry{size: size, lastUsed: time.Now()}
+	c.size += size
+	return nil
+}
+
+// evictLocked removes the least recently used artifacts, other than the one
+// with the key to keep, until the cache is below its size. The copies of the
+// removed artifacts in task directories are unaffected.
+func (c *Cache) evictLocked(keep string) {
+	for c.size > c.maxBytes {
+		var oldest string
+		for key, entry := range c.entries {
+			if key == keep {
+				continue
+			}
+			if oldest == "" || entry.lastUsed.Before(c.entries[oldest].lastUsed) {
+				oldest = key
+			}
+		}
+		if oldest == "" {
+			break
+		}
+
+		if err := os.RemoveAll(filepath.Join(c.dir, oldest)); err != nil {
+			c.logger.Warn("failed to remove cached artifact", "key", oldest, "error", err)
+			break
+		}
+		c.logger.Debug("removed cached artifact", "key", oldest, "size", c.entries[oldest].size)
+		c.size -= c.entries[oldest].size
+		delete(c.entries, oldest)
+	}
+	metrics.SetGauge([]string{"client", "artifact_cache", "size_bytes"}, float32(c.size))
+}
+
+// copyTree copies the cached artifact at src to dest. In file mode src is a
+// single file, otherwise the files of src are copied into the directory dest,
+// replacing the existing ones.
+func copyTree(src, dest, mode string) error {
+	if mode == structs.GetterModeFile {
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		return copyFile(src, dest)
+	}
+
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+
+		switch {
+		case entry.IsDir():
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			return os.MkdirAll(target, info.Mode().Perm())
+		case entry.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if err := os.RemoveAll(target); err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			return copyFile(path, target)
+		}
+	})
+}
+
+// copyFile copies the file at src to dest, replacing it.
+func copyFile(src, dest string) error {
+	if err := os.RemoveAll(dest); err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_EXCL|os.O_WRONLY, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// treeSize returns the size of the regular files under dir.
+func treeSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
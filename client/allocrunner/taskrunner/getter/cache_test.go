@@ -0,0 +1,133 @@
+package getter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// testDownload returns a download writing content to a file in the artifact
+// directory, and a pointer to the number of times it was called.
+func testDownload(content string) (func(dst string) error, *int) {
+	calls := 0
+	return func(dst string) error {
+		calls++
+		if err := os.MkdirAll(dst, 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dst, "model.bin"), []byte(content), 0644)
+	}, &calls
+}
+
+func TestCacheKey(t *testing.T) {
+	ci.Parallel(t)
+
+	const sum = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	key, ok := cacheKey("", "https://a.example.com/model.tar.gz?checksum="+sum)
+	require.True(t, ok)
+
+	// The same artifact from another host shares the entry
+	other, ok := cacheKey(structs.GetterModeAny, "https://b.example.com/mirror/model.tar.gz?checksum="+sum)
+	require.True(t, ok)
+	require.Equal(t, key, other)
+
+	// Options changing the downloaded files change the key
+	other, ok = cacheKey("", "https://a.example.com/model.tar.gz?archive=false&checksum="+sum)
+	require.True(t, ok)
+	require.NotEqual(t, key, other)
+
+	other, ok = cacheKey(structs.GetterModeFile, "https://a.example.com/model.tar.gz?checksum="+sum)
+	require.True(t, ok)
+	require.NotEqual(t, key, other)
+
+	// Artifacts without a checksum or verified by a checksum file aren't
+	// cached
+	_, ok = cacheKey("", "https://a.example.com/model.tar.gz")
+	require.False(t, ok)
+	_, ok = cacheKey("", "https://a.example.com/model.tar.gz?checksum=file:https://a.example.com/SHA256SUMS")
+	require.False(t, ok)
+}
+
+func TestCache_Get(t *testing.T) {
+	ci.Parallel(t)
+
+	cache, err := NewCache(t.TempDir(), 1024, testlog.HCLogger(t))
+	require.NoError(t, err)
+	download, calls := testDownload("weights")
+
+	dest1 := filepath.Join(t.TempDir(), "local")
+	require.NoError(t, cache.Get("key", dest1, "", download))
+	dest2 := filepath.Join(t.TempDir(), "local")
+	require.NoError(t, cache.Get("key", dest2, "", download))
+	require.Equal(t, 1, *calls)
+
+	for _, dest := range []string{dest1, dest2} {
+		content, err := os.ReadFile(filepath.Join(dest, "model.bin"))
+		require.NoError(t, err)
+		require.Equal(t, "weights", string(content))
+	}
+
+	// The files are copies, so tasks writing to them don't change the
+	// artifact handed to other tasks
+	info1, err := os.Stat(filepath.Join(dest1, "model.bin"))
+	require.NoError(t, err)
+	info2, err := os.Stat(filepath.Join(dest2, "model.bin"))
+	require.NoError(t, err)
+	require.False(t, os.SameFile(info1, info2))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dest1, "model.bin"), []byte("poisoned"), 0644))
+	dest3 := filepath.Join(t.TempDir(), "local")
+	require.NoError(t, cache.Get("key", dest3, "", download))
+	content, err := os.ReadFile(filepath.Join(dest3, "model.bin"))
+	require.NoError(t, err)
+	require.Equal(t, "weights", string(content))
+}
+
+func TestCache_Evict(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	cache, err := NewCache(dir, 10, testlog.HCLogger(t))
+	require.NoError(t, err)
+
+	download1, _ := testDownload("123456")
+	require.NoError(t, cache.Get("first", filepath.Join(t.TempDir(), "local"), "", download1))
+	download2, _ := testDownload("abcdef")
+	dest := filepath.Join(t.TempDir(), "local")
+	require.NoError(t, cache.Get("second", dest, "", download2))
+
+	// The least recently used artifact is removed, without affecting copies
+	require.NoDirExists(t, filepath.Join(dir, "first"))
+	require.DirExists(t, filepath.Join(dir, "second"))
+	require.FileExists(t, filepath.Join(dest, "model.bin"))
+
+	// An artifact larger than the cache is still copied
+	download3, _ := testDownload("larger than the cache")
+	require.NoError(t, cache.Get("third", filepath.Join(t.TempDir(), "local"), "", download3))
+	require.NoDirExists(t, filepath.Join(dir, "second"))
+}
+
+func TestNewCache_Restore(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	cache, err := NewCache(dir, 1024, testlog.HCLogger(t))
+	require.NoError(t, err)
+	download, calls := testDownload("weights")
+	require.NoError(t, cache.Get("key", filepath.Join(t.TempDir(), "local"), "", download))
+
+	// Interrupted downloads are removed on restore
+	require.NoError(t, os.Mkdir(filepath.Join(dir, cacheTmpPrefix+"123"), 0700))
+
+	cache, err = NewCache(dir, 1024, testlog.HCLogger(t))
+	require.NoError(t, err)
+	require.NoDirExists(t, filepath.Join(dir, cacheTmpPrefix+"123"))
+	require.NoError(t, cache.Get("key", filepath.Join(t.TempDir(), "local"), "", download))
+	require.Equal(t, 1, *calls)
+}
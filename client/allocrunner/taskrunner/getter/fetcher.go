@@ -0,0 +1,271 @@
+package getter
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// fetcherOutputLimit is the number of bytes of the output of a fetcher
+	// kept to report its failures.
+	fetcherOutputLimit = 4096
+
+	// fetcherEnvPrefix is the prefix of the environment variables describing
+	// the artifact to the fetcher.
+	fetcherEnvPrefix = "NOMAD_ARTIFACT_"
+)
+
+// fetcherFor returns the fetcher registered for the scheme of the source URL,
+// or nil if the artifact is fetched by go-getter.
+func fetcherFor(src string, fetchers map[string]*config.ArtifactFetcherConfig) *config.ArtifactFetcherConfig {
+	if len(fetchers) == 0 {
+		return nil
+	}
+
+	u, err := url.Parse(src)
+	if err != nil || u.Scheme == "" {
+		return nil
+	}
+	for _, fetcher := range fetchers {
+		if helper.SliceStringContains(fetcher.Schemes, u.Scheme) {
+			return fetcher
+		}
+	}
+	return nil
+}
+
+// fetch downloads the artifact at src into dest with the fetcher. The fetcher
+// runs as its user with a minimal environment and downloads into a staging
+// directory, whose content must stay within the directory and match the
+// checksum of the artifact before being moved to dest.
+func fetch(fetcher *config.ArtifactFetcherConfig, src string, headers http.Header, mode, dest string) error {
+	u, err := url.Parse(src)
+	if err != nil {
+		return err
+	}
+
+	// The checksum is verified by Nomad rather than passed to the fetcher
+	q := u.Query()
+	checksum := q.Get("checksum")
+	q.Del("checksum")
+	u.RawQuery = q.Encode()
+
+	parent := filepath.Dir(dest)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return err
+	}
+	staging, err := os.MkdirTemp(parent, ".nomad-artifact-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+
+	timeout := fetcher.Timeout
+	if timeout == 0 {
+		timeout = config.DefaultArtifactFetcherTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	output := &limitedBuffer{limit: fetcherOutputLimit}
+	cmd := exec.CommandContext(ctx, fetcher.Command, fetcher.Args...)
+	cmd.Dir = staging
+	cmd.Env = fetcherEnv(fetcher, u.String(), headers, mode, staging)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	reclaim, err := setFetcherUser(cmd, fetcher.User, staging)
+	if err != nil {
+		return fmt.Errorf("artifact fetcher %q: %v", fetcher.Name, err)
+	}
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+		return fmt.Errorf("artifact fetcher %q failed: %v: %s", fetcher.Name, err, strings.TrimSpace(output.String()))
+	}
+
+	if err := reclaim(); err != nil {
+		return fmt.Errorf("artifact fetcher %q: %v", fetcher.Name, err)
+	}
+	if err := checkStaged(staging); err != nil {
+		return fmt.Errorf("artifact fetcher %q: %v", fetcher.Name, err)
+	}
+	if checksum != "" {
+		if err := verifyChecksum(staging, checksum); err != nil {
+			return err
+		}
+	}
+	return moveStaged(staging, dest, mode)
+}
+
+// fetcherEnv returns the environment of the fetcher. Only the PATH of the
+// client is passed, so the fetcher can't read the credentials of the client.
+func fetcherEnv(fetcher *config.ArtifactFetcherConfig, src string, headers http.Header, mode, staging string) []string {
+	env := []string{"PATH=" + os.Getenv("PATH")}
+	for k, v := range fetcher.Env {
+		env = append(env, k+"="+v)
+	}
+
+	if mode == "" {
+		mode = structs.GetterModeAny
+	}
+	env = append(env,
+		fetcherEnvPrefix+"SOURCE="+src,
+		fetcherEnvPrefix+"DESTINATION="+staging,
+		fetcherEnvPrefix+"MODE="+mode,
+	)
+	for k := range headers {
+		name := strings.ToUpper(strings.ReplaceAll(k, "-", "_"))
+		env = append(env, fetcherEnvPrefix+"HEADER_"+name+"="+headers.Get(k))
+	}
+	return env
+}
+
+// checkStaged returns an error if the staging directory holds files other
+// than regular files, directories and symlinks, or symlinks pointing outside
+// of it.
+func checkStaged(staging string) error {
+	return filepath.WalkDir(staging, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case entry.IsDir(), entry.Type().IsRegular():
+			return nil
+		case entry.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if filepath.IsAbs(target) || helper.PathEscapesSandbox(staging, filepath.Join(filepath.Dir(path), target)) {
+				return fmt.Errorf("symlink %q escapes the artifact", entry.Name())
+			}
+			return nil
+		default:
+			return fmt.Errorf("%q is not a regular file", entry.Name())
+		}
+	})
+}
+
+// stagedFile returns the path of the single regular file in the staging
+// directory.
+func stagedFile(staging string) (string, error) {
+	entries, err := os.ReadDir(staging)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) != 1 || !entries[0].Type().IsRegular() {
+		return "", fmt.Errorf("expected the artifact to be a single file, got %d entries", len(entries))
+	}
+	return filepath.Join(staging, entries[0].Name()), nil
+}
+
+// verifyChecksum verifies the file fetched into the staging directory against
+// the checksum, in the "type:value" format of go-getter.
+func verifyChecksum(staging, checksum string) error {
+	idx := strings.Index(checksum, ":")
+	if idx == -1 {
+		return fmt.Errorf("invalid checksum %q: expected type:value", checksum)
+	}
+	checksumType, expected := checksum[:idx], checksum[idx+1:]
+
+	var h hash.Hash
+	switch checksumType {
+	case "md5":
+		h = md5.New()
+	case "sha1":
+		h = sha1.New()
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf("unsupported checksum type %q", checksumType)
+	}
+
+	path, err := stagedFile(staging)
+	if err != nil {
+		return fmt.Errorf("failed to verify checksum: %v", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+
+	if actual := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("checksums did not match: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// moveStaged moves the fetched artifact to dest. In file mode the staging
+// directory must hold a single file, which is moved to dest. Otherwise its
+// entries are moved into dest, replacing the existing ones.
+func moveStaged(staging, dest, mode string) error {
+	if mode == structs.GetterModeFile {
+		path, err := stagedFile(staging)
+		if err != nil {
+			return err
+		}
+		return os.Rename(path, dest)
+	}
+
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(staging)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		target := filepath.Join(dest, entry.Name())
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+		if err := os.Rename(filepath.Join(staging, entry.Name()), target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// limitedBuffer keeps the first bytes written to it, up to its limit.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		if len(p) > room {
+			b.Buffer.Write(p[:room])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
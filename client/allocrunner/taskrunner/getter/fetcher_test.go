@@ -0,0 +1,133 @@
+package getter
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// testFetcherScript fetches artifacts depending on the host of their source.
+const testFetcherScript = `#!/bin/sh
+case "$NOMAD_ARTIFACT_SOURCE" in
+corp://ok/*) printf hello > "$NOMAD_ARTIFACT_DESTINATION/hello.txt" ;;
+corp://env/*) env > "$NOMAD_ARTIFACT_DESTINATION/env.txt" ;;
+corp://escape/*) ln -s /etc/passwd "$NOMAD_ARTIFACT_DESTINATION/passwd" ;;
+*) echo "no access to $NOMAD_ARTIFACT_SOURCE" >&2; exit 1 ;;
+esac
+`
+
+func testFetchers(t *testing.T) map[string]*config.ArtifactFetcherConfig {
+	if runtime.GOOS == "windows" {
+		t.Skip("test fetcher is a shell script")
+	}
+
+	command := filepath.Join(t.TempDir(), "fetch.sh")
+	require.NoError(t, os.WriteFile(command, []byte(testFetcherScript), 0755))
+
+	// The fetcher runs as the current user, since the test directories are
+	// only accessible to it
+	current, err := user.Current()
+	require.NoError(t, err)
+
+	fetcher := &config.ArtifactFetcherConfig{
+		Name:    "corp",
+		Command: command,
+		Env:     map[string]string{"CORP_REGION": "eu"},
+		User:    current.Username,
+	}
+	fetcher.Canonicalize()
+	return map[string]*config.ArtifactFetcherConfig{fetcher.Name: fetcher}
+}
+
+func TestGetArtifact_Fetcher(t *testing.T) {
+	ci.Parallel(t)
+
+	fetchers := testFetchers(t)
+	taskDir := t.TempDir()
+
+	artifact := &structs.TaskArtifact{
+		GetterSource: "corp://ok/hello",
+		GetterOptions: map[string]string{
+			"checksum": "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+		RelativeDest: "local/",
+	}
+	require.NoError(t, GetArtifact(noopTaskEnv(taskDir), artifact, &Config{Fetchers: fetchers}))
+
+	b, err := os.ReadFile(filepath.Join(taskDir, "local", "hello.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(b))
+
+	// The staging directory is removed
+	staged, err := filepath.Glob(filepath.Join(taskDir, ".nomad-artifact-*"))
+	require.NoError(t, err)
+	require.Empty(t, staged)
+
+	// Single files are moved to the destination in file mode
+	artifact.GetterMode = structs.GetterModeFile
+	artifact.RelativeDest = "local/greeting"
+	require.NoError(t, GetArtifact(noopTaskEnv(taskDir), artifact, &Config{Fetchers: fetchers}))
+	b, err = os.ReadFile(filepath.Join(taskDir, "local", "greeting"))
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(b))
+
+	// Checksums are verified by Nomad
+	artifact.GetterOptions["checksum"] = "md5:00000000000000000000000000000000"
+	err = GetArtifact(noopTaskEnv(taskDir), artifact, &Config{Fetchers: fetchers})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "checksums did not match")
+}
+
+func TestGetArtifact_Fetcher_Env(t *testing.T) {
+	ci.Parallel(t)
+
+	fetchers := testFetchers(t)
+	taskDir := t.TempDir()
+	os.Setenv("NOMAD_TEST_FETCHER_SECRET", "secret")
+	defer os.Unsetenv("NOMAD_TEST_FETCHER_SECRET")
+
+	artifact := &structs.TaskArtifact{
+		GetterSource:  "corp://env/config",
+		GetterOptions: map[string]string{"version": "2"},
+		GetterHeaders: map[string]string{"X-Corp-Token": "token"},
+		RelativeDest:  "local/",
+	}
+	require.NoError(t, GetArtifact(noopTaskEnv(taskDir), artifact, &Config{Fetchers: fetchers}))
+
+	b, err := os.ReadFile(filepath.Join(taskDir, "local", "env.txt"))
+	require.NoError(t, err)
+	env := string(b)
+	require.Contains(t, env, "CORP_REGION=eu\n")
+	require.Contains(t, env, "NOMAD_ARTIFACT_SOURCE=corp://env/config?version=2\n")
+	require.Contains(t, env, "NOMAD_ARTIFACT_MODE=any\n")
+	require.Contains(t, env, "NOMAD_ARTIFACT_HEADER_X_CORP_TOKEN=token\n")
+	require.NotContains(t, env, "NOMAD_TEST_FETCHER_SECRET")
+}
+
+func TestGetArtifact_Fetcher_Errors(t *testing.T) {
+	ci.Parallel(t)
+
+	fetchers := testFetchers(t)
+	taskDir := t.TempDir()
+
+	artifact := &structs.TaskArtifact{
+		GetterSource: "corp://escape/passwd",
+		RelativeDest: "local/",
+	}
+	err := GetArtifact(noopTaskEnv(taskDir), artifact, &Config{Fetchers: fetchers})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `symlink "passwd" escapes the artifact`)
+	require.NoFileExists(t, filepath.Join(taskDir, "local", "passwd"))
+
+	artifact.GetterSource = "corp://denied/secret"
+	err = GetArtifact(noopTaskEnv(taskDir), artifact, &Config{Fetchers: fetchers})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no access to corp://denied/secret")
+}
@@ -0,0 +1,85 @@
+//go:build !windows
+// +build !windows
+
+package getter
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"syscall"
+)
+
+// setFetcherUser sets the command to run as the user of the fetcher when the
+// client runs as root, and gives the user ownership of the staging directory.
+// The returned function takes back ownership of the fetched files once the
+// command exits.
+func setFetcherUser(cmd *exec.Cmd, username, staging string) (func() error, error) {
+	noop := func() error { return nil }
+	if os.Geteuid() != 0 {
+		return noop, nil
+	}
+
+	u, err := user.Lookup(username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to identify user %q: %v", username, err)
+	}
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert uid of user %q: %v", username, err)
+	}
+	gid, err := strconv.ParseUint(u.Gid, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert gid of user %q: %v", username, err)
+	}
+	if uid == 0 {
+		return noop, nil
+	}
+
+	if err := os.Chown(staging, int(uid), int(gid)); err != nil {
+		return nil, err
+	}
+
+	// Supplementary groups are dropped since Groups is empty
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Credential: &syscall.Credential{
+			Uid: uint32(uid),
+			Gid: uint32(gid),
+		},
+	}
+	return func() error {
+		return reclaimStaged(staging, uint32(uid))
+	}, nil
+}
+
+// reclaimStaged gives the files of the staging directory owned by the user of
+// the fetcher back to the client, so that they can't be modified by other
+// processes of the user once moved to the task. Hard links are rejected since
+// they may point to files outside of the staging directory.
+func reclaimStaged(staging string, uid uint32) error {
+	return filepath.WalkDir(staging, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		stat, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			return nil
+		}
+		if info.Mode().IsRegular() && stat.Nlink > 1 {
+			return fmt.Errorf("%q is a hard link", entry.Name())
+		}
+		if stat.Uid != uid {
+			return nil
+		}
+		return os.Lchown(path, os.Geteuid(), os.Getegid())
+	})
+}
@@ -0,0 +1,75 @@
+//go:build !windows
+// +build !windows
+
+package getter
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetArtifact_Fetcher_User(t *testing.T) {
+	ci.Parallel(t)
+	if syscall.Geteuid() != 0 {
+		t.Skip("Must be root to run test")
+	}
+
+	nobody, err := user.Lookup(config.DefaultArtifactFetcherUser)
+	if err != nil {
+		t.Skipf("no %q user: %v", config.DefaultArtifactFetcherUser, err)
+	}
+
+	// The fetcher must be able to run the command and reach the staging
+	// directory
+	dir, err := os.MkdirTemp("", "nomad-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, os.Chmod(dir, 0755))
+
+	command := filepath.Join(dir, "fetch.sh")
+	script := `#!/bin/sh
+id -u > "$NOMAD_ARTIFACT_DESTINATION/uid.txt"
+`
+	require.NoError(t, os.WriteFile(command, []byte(script), 0755))
+
+	fetcher := &config.ArtifactFetcherConfig{
+		Name:    "corp",
+		Command: command,
+	}
+	fetcher.Canonicalize()
+	fetchers := map[string]*config.ArtifactFetcherConfig{fetcher.Name: fetcher}
+
+	taskDir := filepath.Join(dir, "task")
+	require.NoError(t, os.Mkdir(taskDir, 0755))
+	artifact := &structs.TaskArtifact{
+		GetterSource: "corp://ok/uid",
+		RelativeDest: "local/",
+	}
+	require.NoError(t, GetArtifact(noopTaskEnv(taskDir), artifact, &Config{Fetchers: fetchers}))
+
+	// The fetcher ran as nobody
+	path := filepath.Join(taskDir, "local", "uid.txt")
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, nobody.Uid, strings.TrimSpace(string(b)))
+
+	// The fetched file was given back to the client
+	info, err := os.Lstat(path)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), info.Sys().(*syscall.Stat_t).Uid)
+
+	// Unknown users fail the download
+	fetcher.User = "nomad-no-such-user"
+	err = GetArtifact(noopTaskEnv(taskDir), artifact, &Config{Fetchers: fetchers})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to identify user")
+}
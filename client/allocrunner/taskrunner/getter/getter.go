@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/go-cleanhttp"
 	gg "github.com/hashicorp/go-getter"
 
+	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -110,8 +111,32 @@ func getHeaders(env EnvReplacer, m map[string]string) http.Header {
 	return headers
 }
 
-// GetArtifact downloads an artifact into the specified task directory.
-func GetArtifact(taskEnv EnvReplacer, artifact *structs.TaskArtifact) error {
+// Config configures how artifacts are downloaded by the client.
+type Config struct {
+	// Fetchers are the external commands fetching the artifacts whose source
+	// uses their schemes.
+	Fetchers map[string]*config.ArtifactFetcherConfig
+
+	// Registries is the authentication to the registries the images of
+	// oci:// artifacts are pulled from, by host.
+	Registries map[string]*config.OCIRegistryConfig
+
+	// Cache is the cache artifacts verified by a checksum are copied from,
+	// or nil if artifacts aren't cached.
+	Cache *Cache
+}
+
+// GetArtifact downloads an artifact into the specified task directory. OCI
+// images are pulled by Nomad, and other artifacts are downloaded by the
+// fetcher registered for the scheme of their source if any, or by go-getter.
+// Artifacts verified by a checksum are copied from the cache if enabled, and
+// only downloaded if they aren't cached. conf may be nil to download
+// artifacts with go-getter only.
+func GetArtifact(taskEnv EnvReplacer, artifact *structs.TaskArtifact, conf *Config) error {
+	if conf == nil {
+		conf = &Config{}
+	}
+
 	ggURL, err := getGetterUrl(taskEnv, artifact)
 	if err != nil {
 		return newGetError(artifact.GetterSource, err, false)
@@ -135,7 +160,22 @@ func GetArtifact(taskEnv EnvReplacer, artifact *structs.TaskArtifact) error {
 	}
 
 	headers := getHeaders(taskEnv, artifact.GetterHeaders)
-	if err := getClient(ggURL, headers, mode, dest).Get(); err != nil {
+	download := func(dst string) error {
+		if isOCI(ggURL) {
+			return pullImage(ggURL, conf.Registries, artifact.GetterMode, dst)
+		}
+		if fetcher := fetcherFor(ggURL, conf.Fetchers); fetcher != nil {
+			return fetch(fetcher, ggURL, headers, artifact.GetterMode, dst)
+		}
+		return getClient(ggURL, headers, mode, dst).Get()
+	}
+
+	if key, ok := cacheKey(artifact.GetterMode, ggURL); ok && conf.Cache != nil {
+		err = conf.Cache.Get(key, dest, artifact.GetterMode, download)
+	} else {
+		err = download(dest)
+	}
+	if err != nil {
 		return newGetError(ggURL, err, true)
 	}
 
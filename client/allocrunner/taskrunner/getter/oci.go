@@ -0,0 +1,645 @@
+package getter
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// ociScheme is the URL scheme of the OCI images pulled as artifacts.
+	ociScheme = "oci"
+
+	// ociDefaultTag is the tag pulled when the reference has none.
+	ociDefaultTag = "latest"
+
+	// dockerHubHost is the host of Docker Hub in image references, and
+	// dockerHubRegistry the host of its registry API.
+	dockerHubHost     = "docker.io"
+	dockerHubRegistry = "registry-1.docker.io"
+
+	// ociManifestLimit is the maximum size of the manifests read.
+	ociManifestLimit = 4 << 20
+
+	// maxSymlinks is the number of symlinks followed when resolving a path
+	// within an image before giving up.
+	maxSymlinks = 255
+
+	// whiteoutPrefix marks the files removed from the lower layers, and
+	// whiteoutOpaque the directories whose lower content is removed.
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+)
+
+const (
+	mediaTypeOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// ociManifestTypes are the manifest media types accepted from registries.
+var ociManifestTypes = []string{
+	mediaTypeOCIManifest,
+	mediaTypeOCIIndex,
+	mediaTypeDockerManifest,
+	mediaTypeDockerManifestList,
+}
+
+// ociDescriptor describes a manifest or blob of an image.
+type ociDescriptor struct {
+	MediaType string       `json:"mediaType"`
+	Digest    string       `json:"digest"`
+	Size      int64        `json:"size"`
+	Platform  *ociPlatform `json:"platform,omitempty"`
+}
+
+type ociPlatform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+}
+
+// ociManifest holds the fields of image manifests and indexes used to pull
+// images.
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Manifests []ociDescriptor `json:"manifests"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// ociReference is a reference to an image, parsed from an oci:// source.
+type ociReference struct {
+	// host is the registry host as written in the reference, which the
+	// registry configuration is looked up by.
+	host string
+
+	// registry is the host of the registry API.
+	registry string
+
+	repository string
+
+	// reference is the tag or digest of the image.
+	reference string
+}
+
+// isOCI returns true if the source is an OCI image.
+func isOCI(src string) bool {
+	return strings.HasPrefix(src, ociScheme+"://")
+}
+
+// parseOCIReference parses a source in the oci://host/repository[:tag] or
+// oci://host/repository@digest format.
+func parseOCIReference(src string) (*ociReference, error) {
+	u, err := url.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("image reference %q has no registry host", src)
+	}
+
+	ref := &ociReference{
+		host:     u.Host,
+		registry: u.Host,
+	}
+	repository := strings.TrimPrefix(u.Path, "/")
+	if idx := strings.Index(repository, "@"); idx != -1 {
+		repository, ref.reference = repository[:idx], repository[idx+1:]
+	} else if idx := strings.LastIndex(repository, ":"); idx > strings.LastIndex(repository, "/") {
+		repository, ref.reference = repository[:idx], repository[idx+1:]
+	} else {
+		ref.reference = ociDefaultTag
+	}
+	if repository == "" || ref.reference == "" {
+		return nil, fmt.Errorf("invalid image reference %q", src)
+	}
+
+	// Official images of Docker Hub are in the library namespace
+	if ref.host == dockerHubHost {
+		ref.registry = dockerHubRegistry
+		if !strings.Contains(repository, "/") {
+			repository = "library/" + repository
+		}
+	}
+	ref.repository = repository
+	return ref, nil
+}
+
+// pullImage pulls the OCI image at src and unpacks its layers into dest,
+// which holds the root filesystem of the image once done. The manifest
+// matching the platform of the client is pulled from multi-platform images.
+func pullImage(src string, registries map[string]*config.OCIRegistryConfig, mode, dest string) error {
+	if mode == structs.GetterModeFile {
+		return fmt.Errorf("OCI images can only be unpacked into a directory")
+	}
+
+	ref, err := parseOCIReference(src)
+	if err != nil {
+		return err
+	}
+	c := &ociClient{
+		ref:      ref,
+		registry: registries[ref.host],
+		client:   httpClient,
+	}
+
+	manifest, err := c.manifest(ref.reference)
+	if err != nil {
+		return err
+	}
+	if manifest.MediaType == mediaTypeOCIIndex || manifest.MediaType == mediaTypeDockerManifestList {
+		desc, err := selectPlatform(manifest.Manifests)
+		if err != nil {
+			return err
+		}
+		if manifest, err = c.manifest(desc.Digest); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+	for _, layer := range manifest.Layers {
+		if err := c.unpackLayer(layer, dest); err != nil {
+			return fmt.Errorf("failed to unpack layer %s: %v", layer.Digest, err)
+		}
+	}
+	return nil
+}
+
+// selectPlatform returns the manifest of an index matching the platform of
+// the client.
+func selectPlatform(manifests []ociDescriptor) (*ociDescriptor, error) {
+	for _, desc := range manifests {
+		if desc.Platform != nil && desc.Platform.OS == runtime.GOOS && desc.Platform.Architecture == runtime.GOARCH {
+			return &desc, nil
+		}
+	}
+	return nil, fmt.Errorf("image has no manifest for platform %s/%s", runtime.GOOS, runtime.GOARCH)
+}
+
+// ociClient pulls the manifests and blobs of an image from its registry,
+// following the distribution specification.
+type ociClient struct {
+	ref      *ociReference
+	registry *config.OCIRegistryConfig
+	client   *http.Client
+
+	// authorization is the Authorization header of the requests once the
+	// registry challenged the client.
+	authorization string
+}
+
+// manifest returns the manifest with the tag or digest, whose digest is
+// verified if pulled by digest.
+func (c *ociClient) manifest(reference string) (*ociManifest, error) {
+	resp, err := c.get("manifests/"+reference, strings.Join(ociManifestTypes, ", "))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, ociManifestLimit))
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(reference, ":") {
+		if err := verifyDigest(reference, body); err != nil {
+			return nil, fmt.Errorf("manifest %s: %v", reference, err)
+		}
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %v", err)
+	}
+	if manifest.MediaType == "" {
+		manifest.MediaType = strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+	}
+	return &manifest, nil
+}
+
+// unpackLayer pulls the layer and extracts it into dest, verifying its
+// digest.
+func (c *ociClient) unpackLayer(layer ociDescriptor, dest string) error {
+	h, err := digestHash(layer.Digest)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.get("blobs/"+layer.Digest, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var r io.Reader = io.TeeReader(resp.Body, h)
+	switch {
+	case strings.HasSuffix(layer.MediaType, "gzip"):
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	case strings.HasSuffix(layer.MediaType, "tar"):
+	default:
+		return fmt.Errorf("unsupported layer media type %q", layer.MediaType)
+	}
+
+	if err := extractLayer(tar.NewReader(r), dest); err != nil {
+		return err
+	}
+
+	// Read the rest of the blob, such as the padding of the archive
+	if _, err := io.Copy(h, resp.Body); err != nil {
+		return err
+	}
+	return checkDigest(layer.Digest, h)
+}
+
+// get requests the path under the repository of the image in the registry
+// API, authenticating once challenged by the registry.
+func (c *ociClient) get(p, accept string) (*http.Response, error) {
+	scheme := "https"
+	if c.registry != nil && c.registry.Insecure {
+		scheme = "http"
+	}
+	u := fmt.Sprintf("%s://%s/v2/%s/%s", scheme, c.ref.registry, c.ref.repository, p)
+
+	do := func() (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if c.authorization != "" {
+			req.Header.Set("Authorization", c.authorization)
+		}
+		return c.client.Do(req)
+	}
+
+	resp, err := do()
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.authorization == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := c.authenticate(challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = do(); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to pull %s: %s", u, resp.Status)
+	}
+	return resp, nil
+}
+
+// authenticate sets the authorization of the requests answering the
+// challenge of the registry, with the credentials of the registry if
+// configured or anonymously.
+func (c *ociClient) authenticate(challenge string) error {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.registry == nil || c.registry.Username == "" {
+			return fmt.Errorf("registry %s requires credentials", c.ref.host)
+		}
+		req := &http.Request{Header: make(http.Header)}
+		req.SetBasicAuth(c.registry.Username, c.registry.Password)
+		c.authorization = req.Header.Get("Authorization")
+		return nil
+	case "bearer":
+	default:
+		return fmt.Errorf("registry %s requested unsupported authentication %q", c.ref.host, scheme)
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return fmt.Errorf("registry %s returned an invalid token realm %q", c.ref.host, params["realm"])
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", c.ref.repository)
+	}
+
+	var req *http.Request
+	if c.registry != nil && c.registry.Token != "" {
+		// Identity tokens are exchanged with the OAuth2 refresh token flow
+		form := url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {c.registry.Token},
+			"service":       {params["service"]},
+			"scope":         {scope},
+			"client_id":     {"nomad"},
+		}
+		req, err = http.NewRequest(http.MethodPost, realm.String(), strings.NewReader(form.Encode()))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		q := realm.Query()
+		if service := params["service"]; service != "" {
+			q.Set("service", service)
+		}
+		q.Set("scope", scope)
+		realm.RawQuery = q.Encode()
+		req, err = http.NewRequest(http.MethodGet, realm.String(), nil)
+		if err != nil {
+			return err
+		}
+		if c.registry != nil && c.registry.Username != "" {
+			req.SetBasicAuth(c.registry.Username, c.registry.Password)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request registry token: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to request registry token: %s", resp.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, ociManifestLimit)).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode registry token: %v", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return fmt.Errorf("registry %s returned an empty token", c.ref.host)
+	}
+	c.authorization = "Bearer " + token.Token
+	return nil
+}
+
+// parseChallenge parses the scheme and parameters of a WWW-Authenticate
+// header, such as: Bearer realm="https://auth.example.com/token",service="x"
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := make(map[string]string)
+	scheme, rest := strings.TrimSpace(challenge), ""
+	if idx := strings.Index(scheme, " "); idx != -1 {
+		scheme, rest = scheme[:idx], scheme[idx+1:]
+	}
+	for {
+		rest = strings.TrimLeft(rest, " ,")
+		idx := strings.Index(rest, "=")
+		if idx == -1 {
+			return scheme, params
+		}
+		key := strings.ToLower(strings.TrimSpace(rest[:idx]))
+		rest = rest[idx+1:]
+
+		end := strings.Index(rest, ",")
+		if strings.HasPrefix(rest, `"`) {
+			rest = rest[1:]
+			end = strings.Index(rest, `"`)
+		}
+		if end == -1 {
+			params[key], rest = rest, ""
+		} else {
+			params[key], rest = rest[:end], rest[end+1:]
+		}
+	}
+}
+
+// digestHash returns the hash computing digests of the algorithm of the
+// digest.
+func digestHash(digest string) (hash.Hash, error) {
+	switch strings.SplitN(digest, ":", 2)[0] {
+	case "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported digest %q", digest)
+	}
+}
+
+// verifyDigest returns an error if the content doesn't match the digest.
+func verifyDigest(digest string, content []byte) error {
+	h, err := digestHash(digest)
+	if err != nil {
+		return err
+	}
+	h.Write(content)
+	return checkDigest(digest, h)
+}
+
+// checkDigest returns an error if the hash doesn't match the digest.
+func checkDigest(digest string, h hash.Hash) error {
+	expected := digest[strings.Index(digest, ":")+1:]
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != expected {
+		return fmt.Errorf("digest did not match: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// extractLayer extracts the layer into the root filesystem at root, applying
+// its whiteouts to the content of the lower layers. Paths are resolved within
+// root, so the symlinks of the image can't be followed outside of it. Device
+// files are skipped, and ownership is only kept when running as root.
+func extractLayer(tr *tar.Reader, root string) error {
+	// The entries of the layer, which opaque whiteouts don't remove
+	extracted := make(map[string]struct{})
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimPrefix(path.Clean("/"+hdr.Name), "/")
+		if name == "" {
+			continue
+		}
+		dir, base := path.Split(name)
+		parent, err := resolveInRoot(root, dir)
+		if err != nil {
+			return err
+		}
+
+		// Apply whiteouts to the lower layers
+		if base == whiteoutOpaque {
+			if err := removeLowerEntries(parent, extracted); err != nil {
+				return err
+			}
+			continue
+		}
+		if strings.HasPrefix(base, whiteoutPrefix) {
+			if err := os.RemoveAll(filepath.Join(parent, strings.TrimPrefix(base, whiteoutPrefix))); err != nil {
+				return err
+			}
+			continue
+		}
+
+		target := filepath.Join(parent, base)
+		extracted[target] = struct{}{}
+		if hdr.Typeflag != tar.TypeDir {
+			if err := os.RemoveAll(target); err != nil {
+				return err
+			}
+		}
+
+		mode := hdr.FileInfo().Mode()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if info, err := os.Lstat(target); err == nil && !info.IsDir() {
+				if err := os.Remove(target); err != nil {
+					return err
+				}
+			}
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err := os.MkdirAll(parent, 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.MkdirAll(parent, 0755); err != nil {
+				return err
+			}
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			linkDir, linkBase := path.Split(strings.TrimPrefix(path.Clean("/"+hdr.Linkname), "/"))
+			linkParent, err := resolveInRoot(root, linkDir)
+			if err != nil {
+				return err
+			}
+			if err := os.Link(filepath.Join(linkParent, linkBase), target); err != nil {
+				return err
+			}
+			continue
+		default:
+			// Device files and FIFOs can't be created in task directories
+			continue
+		}
+
+		if os.Geteuid() == 0 {
+			if err := os.Lchown(target, hdr.Uid, hdr.Gid); err != nil {
+				return err
+			}
+		}
+		if hdr.Typeflag != tar.TypeSymlink {
+			if err := os.Chmod(target, mode&(os.ModePerm|os.ModeSetuid|os.ModeSetgid|os.ModeSticky)); err != nil {
+				return err
+			}
+			if err := os.Chtimes(target, hdr.ModTime, hdr.ModTime); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// removeLowerEntries removes the entries of the directory not extracted from
+// the current layer.
+func removeLowerEntries(dir string, extracted map[string]struct{}) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		p := filepath.Join(dir, entry.Name())
+		if _, ok := extracted[p]; ok {
+			continue
+		}
+		if err := os.RemoveAll(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveInRoot returns the host path of the directory at the slash
+// separated path p of the root filesystem at root, following its symlinks as
+// if root was the root of the filesystem.
+func resolveInRoot(root, p string) (string, error) {
+	var resolved []string
+	remaining := strings.Split(p, "/")
+	for links := 0; len(remaining) > 0; {
+		component := remaining[0]
+		remaining = remaining[1:]
+
+		switch component {
+		case "", ".":
+			continue
+		case "..":
+			if len(resolved) > 0 {
+				resolved = resolved[:len(resolved)-1]
+			}
+			continue
+		}
+
+		current := filepath.Join(append([]string{root}, append(resolved, component)...)...)
+		info, err := os.Lstat(current)
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			// Missing directories are created when extracting their entries
+			resolved = append(resolved, component)
+			continue
+		}
+
+		links++
+		if links > maxSymlinks {
+			return "", fmt.Errorf("too many symlinks resolving %q", p)
+		}
+		target, err := os.Readlink(current)
+		if err != nil {
+			return "", err
+		}
+		if path.IsAbs(target) {
+			resolved = nil
+		}
+		remaining = append(strings.Split(target, "/"), remaining...)
+	}
+	return filepath.Join(append([]string{root}, resolved...)...), nil
+}
@@ -0,0 +1,293 @@
+package getter
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// testLayerEntry is an entry of a test image layer.
+type testLayerEntry struct {
+	name     string
+	typeflag byte
+	content  string
+	linkname string
+}
+
+func testLayer(t *testing.T, compress bool, entries ...testLayerEntry) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &tar.Header{
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Linkname: e.linkname,
+			Mode:     0644,
+			Size:     int64(len(e.content)),
+		}
+		if e.typeflag == tar.TypeDir {
+			hdr.Mode = 0755
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(e.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	if !compress {
+		return buf.Bytes()
+	}
+	var gzBuf bytes.Buffer
+	gz := gzip.NewWriter(&gzBuf)
+	_, err := gz.Write(buf.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return gzBuf.Bytes()
+}
+
+func testDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// testRegistry is a registry serving a multi-platform image at org/app:1.0,
+// requiring a token obtained with the nomad:hunter2 credentials.
+type testRegistry struct {
+	*httptest.Server
+	blobs map[string][]byte
+
+	// indexDigest is the digest of the image index
+	indexDigest string
+}
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	r := &testRegistry{blobs: make(map[string][]byte)}
+
+	add := func(content []byte) ociDescriptor {
+		d := testDigest(content)
+		r.blobs[d] = content
+		return ociDescriptor{Digest: d, Size: int64(len(content))}
+	}
+
+	base := add(testLayer(t, true,
+		testLayerEntry{name: "etc/", typeflag: tar.TypeDir},
+		testLayerEntry{name: "etc/os-release", typeflag: tar.TypeReg, content: "base"},
+		testLayerEntry{name: "usr/lib/", typeflag: tar.TypeDir},
+		testLayerEntry{name: "lib", typeflag: tar.TypeSymlink, linkname: "/usr/lib"},
+		testLayerEntry{name: "escape", typeflag: tar.TypeSymlink, linkname: "../../../../outside"},
+		testLayerEntry{name: "var/cache/old", typeflag: tar.TypeReg, content: "old"},
+	))
+	base.MediaType = "application/vnd.oci.image.layer.v1.tar+gzip"
+	top := add(testLayer(t, false,
+		testLayerEntry{name: "etc/.wh.os-release", typeflag: tar.TypeReg},
+		testLayerEntry{name: "var/cache/.wh..wh..opq", typeflag: tar.TypeReg},
+		testLayerEntry{name: "var/cache/new", typeflag: tar.TypeReg, content: "new"},
+		testLayerEntry{name: "lib/libc.so", typeflag: tar.TypeReg, content: "libc"},
+		testLayerEntry{name: "escape/pwned", typeflag: tar.TypeReg, content: "pwned"},
+		testLayerEntry{name: "libc-link", typeflag: tar.TypeLink, linkname: "lib/libc.so"},
+	))
+	top.MediaType = "application/vnd.oci.image.layer.v1.tar"
+
+	manifest, err := json.Marshal(ociManifest{
+		MediaType: mediaTypeOCIManifest,
+		Layers:    []ociDescriptor{base, top},
+	})
+	require.NoError(t, err)
+	platform := add(manifest)
+	platform.MediaType = mediaTypeOCIManifest
+	platform.Platform = &ociPlatform{OS: runtime.GOOS, Architecture: runtime.GOARCH}
+
+	index, err := json.Marshal(ociManifest{
+		MediaType: mediaTypeOCIIndex,
+		Manifests: []ociDescriptor{
+			{MediaType: mediaTypeOCIManifest, Digest: "sha256:0000", Platform: &ociPlatform{OS: "plan9", Architecture: "arm"}},
+			platform,
+		},
+	})
+	require.NoError(t, err)
+	r.indexDigest = add(index).Digest
+
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/token" {
+			if user, pass, ok := req.BasicAuth(); !ok || user != "nomad" || pass != "hunter2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if req.URL.Query().Get("scope") != "repository:org/app:pull" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"token": "t0k"})
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Bearer t0k" {
+			w.Header().Set("WWW-Authenticate",
+				fmt.Sprintf(`Bearer realm="%s/token",service="test"`, r.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch p := strings.TrimPrefix(req.URL.Path, "/v2/org/app/"); {
+		case p == "manifests/1.0":
+			w.Header().Set("Content-Type", mediaTypeOCIIndex)
+			w.Write(r.blobs[r.indexDigest])
+		case strings.HasPrefix(p, "manifests/"), strings.HasPrefix(p, "blobs/"):
+			content, ok := r.blobs[p[strings.Index(p, "/")+1:]]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(content)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *testRegistry) config() *Config {
+	u, _ := url.Parse(r.URL)
+	return &Config{
+		Registries: map[string]*config.OCIRegistryConfig{
+			u.Host: {Host: u.Host, Username: "nomad", Password: "hunter2", Insecure: true},
+		},
+	}
+}
+
+func (r *testRegistry) source(reference string) string {
+	return "oci://" + strings.TrimPrefix(r.URL, "http://") + "/org/app" + reference
+}
+
+func TestGetArtifact_OCI(t *testing.T) {
+	ci.Parallel(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("image layers use symlinks")
+	}
+
+	registry := newTestRegistry(t)
+	taskDir := t.TempDir()
+	artifact := &structs.TaskArtifact{
+		GetterSource: registry.source(":1.0"),
+		RelativeDest: "local/rootfs",
+	}
+	require.NoError(t, GetArtifact(noopTaskEnv(taskDir), artifact, registry.config()))
+
+	rootfs := filepath.Join(taskDir, "local", "rootfs")
+	read := func(p string) string {
+		content, err := os.ReadFile(filepath.Join(rootfs, p))
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	// Whiteouts remove the files of the lower layer
+	require.NoFileExists(t, filepath.Join(rootfs, "etc", "os-release"))
+	require.DirExists(t, filepath.Join(rootfs, "etc"))
+	require.NoFileExists(t, filepath.Join(rootfs, "var", "cache", "old"))
+	require.Equal(t, "new", read("var/cache/new"))
+
+	// Symlinks are followed within the image
+	require.Equal(t, "libc", read("usr/lib/libc.so"))
+	require.Equal(t, "libc", read("libc-link"))
+	require.Equal(t, "pwned", read("outside/pwned"))
+	require.NoFileExists(t, filepath.Join(taskDir, "outside", "pwned"))
+	require.NoDirExists(t, filepath.Join(filepath.Dir(taskDir), "outside"))
+}
+
+func TestGetArtifact_OCI_Errors(t *testing.T) {
+	ci.Parallel(t)
+
+	registry := newTestRegistry(t)
+	taskDir := t.TempDir()
+
+	// Images pulled by digest are verified
+	const bogus = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	registry.blobs[bogus] = registry.blobs[registry.indexDigest]
+	artifact := &structs.TaskArtifact{
+		GetterSource: registry.source("@" + bogus),
+		RelativeDest: "local/rootfs",
+	}
+	err := GetArtifact(noopTaskEnv(taskDir), artifact, registry.config())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "digest did not match")
+
+	artifact.GetterSource = registry.source("@" + registry.indexDigest)
+	artifact.GetterMode = structs.GetterModeFile
+	err = GetArtifact(noopTaskEnv(taskDir), artifact, registry.config())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "OCI images can only be unpacked into a directory")
+
+	// Pulling requires the credentials of the registry
+	artifact.GetterMode = ""
+	conf := registry.config()
+	for _, r := range conf.Registries {
+		r.Password = "wrong"
+	}
+	err = GetArtifact(noopTaskEnv(taskDir), artifact, conf)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to request registry token: 401 Unauthorized")
+}
+
+func TestParseOCIReference(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		src      string
+		expected *ociReference
+	}{
+		{
+			src:      "oci://ghcr.io/org/app:1.0",
+			expected: &ociReference{host: "ghcr.io", registry: "ghcr.io", repository: "org/app", reference: "1.0"},
+		},
+		{
+			src:      "oci://localhost:5000/app",
+			expected: &ociReference{host: "localhost:5000", registry: "localhost:5000", repository: "app", reference: "latest"},
+		},
+		{
+			src:      "oci://docker.io/alpine@sha256:abcd",
+			expected: &ociReference{host: "docker.io", registry: "registry-1.docker.io", repository: "library/alpine", reference: "sha256:abcd"},
+		},
+	}
+	for _, c := range cases {
+		ref, err := parseOCIReference(c.src)
+		require.NoError(t, err, c.src)
+		require.Equal(t, c.expected, ref, c.src)
+	}
+
+	_, err := parseOCIReference("oci:///app")
+	require.Error(t, err)
+}
+
+func TestParseChallenge(t *testing.T) {
+	ci.Parallel(t)
+
+	scheme, params := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:org/app:pull"`)
+	require.Equal(t, "Bearer", scheme)
+	require.Equal(t, map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:org/app:pull",
+	}, params)
+
+	scheme, params = parseChallenge(`Basic realm=registry`)
+	require.Equal(t, "Basic", scheme)
+	require.Equal(t, map[string]string{"realm": "registry"}, params)
+}
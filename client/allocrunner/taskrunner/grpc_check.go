@@ -0,0 +1,392 @@
+package taskrunner
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// grpcCheckReconnectBaseline is the initial delay before reopening a
+	// failed Watch stream. The delay doubles on every consecutive failure
+	// up to the check interval.
+	grpcCheckReconnectBaseline = 1 * time.Second
+)
+
+var (
+	// errGRPCWatchUnimplemented is returned by watchStream when the
+	// service does not implement the streaming Watch method.
+	errGRPCWatchUnimplemented = errors.New("gRPC health watch not implemented")
+
+	// errGRPCCheckReconnect is returned by watchStream when the stream
+	// was closed because the certificates on disk changed.
+	errGRPCCheckReconnect = errors.New("gRPC health watch reconnecting")
+)
+
+// grpcCheckExec implements interfaces.ScriptExecutor for gRPC health checks
+// that present a client certificate. Consul agents cannot run these checks,
+// so they are run by the client as tasklets and reported as TTL checks.
+//
+// The health of the service is followed with the streaming Watch method,
+// which is opened on the first Exec and reopened whenever it fails or the
+// certificates change. Each Exec reports the last status received. Services
+// that do not implement Watch are polled with Check instead.
+type grpcCheckExec struct {
+	addr       string
+	service    string
+	serverName string
+	skipVerify bool
+	caCert     string
+	clientCert string
+	clientKey  string
+	interval   time.Duration
+	timeout    time.Duration
+
+	// reconnectCh is signaled by Exec when the certificates on disk are
+	// newer than the ones used by the open stream
+	reconnectCh chan struct{}
+
+	// l guards the fields below, which are updated by the watch goroutine
+	l             sync.Mutex
+	cancel        context.CancelFunc
+	unimplemented bool
+	certModTime   time.Time
+	result        *grpcCheckResult
+	updateCh      chan struct{} // closed and replaced on every result
+}
+
+// grpcCheckResult is the last status received from the Watch stream, or
+// the error that closed it.
+type grpcCheckResult struct {
+	status healthpb.HealthCheckResponse_ServingStatus
+	err    error
+}
+
+// newGRPCCheckExec returns a grpcCheckExec for the check, with its address
+// and certificate paths interpolated in the task environment. The
+// certificate paths are resolved on the client and may not escape the task
+// or allocation directories.
+func newGRPCCheckExec(check *structs.ServiceCheck, portLabel string, taskEnv *taskenv.TaskEnv) (*grpcCheckExec, error) {
+	if check.PortLabel != "" {
+		portLabel = check.PortLabel
+	}
+	addr, ok := taskEnv.EnvMap[taskenv.AddrPrefix+portLabel]
+	if !ok {
+		return nil, fmt.Errorf("unable to find address for port %q", portLabel)
+	}
+
+	e := &grpcCheckExec{
+		addr:        addr,
+		service:     check.GRPCService,
+		serverName:  check.TLSServerName,
+		skipVerify:  check.TLSSkipVerify,
+		interval:    check.Interval,
+		timeout:     check.Timeout,
+		reconnectCh: make(chan struct{}, 1),
+		updateCh:    make(chan struct{}),
+	}
+
+	for _, p := range []struct {
+		raw string
+		out *string
+	}{
+		{check.TLSCACert, &e.caCert},
+		{check.TLSClientCert, &e.clientCert},
+		{check.TLSClientKey, &e.clientKey},
+	} {
+		if p.raw == "" {
+			continue
+		}
+		path, escapes := taskEnv.ClientPath(p.raw, false)
+		if escapes {
+			return nil, fmt.Errorf("path %q escapes the allocation directory", p.raw)
+		}
+		*p.out = path
+	}
+	return e, nil
+}
+
+// tlsConfig loads the certificates from disk on every connection so that
+// certificates rotated by a template are picked up without a restart.
+func (e *grpcCheckExec) tlsConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(e.clientCert, e.clientKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %v", err)
+	}
+
+	conf := &tls.Config{
+		Certificates:       []tls.Certificate{cert},
+		ServerName:         e.serverName,
+		InsecureSkipVerify: e.skipVerify,
+	}
+
+	if e.caCert != "" {
+		pem, err := ioutil.ReadFile(e.caCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse CA certificate %q", e.caCert)
+		}
+		conf.RootCAs = pool
+	}
+	return conf, nil
+}
+
+// certsModTime returns the latest modification time of the certificates, or
+// the zero time if any of them cannot be read.
+func (e *grpcCheckExec) certsModTime() time.Time {
+	var latest time.Time
+	for _, path := range []string{e.caCert, e.clientCert, e.clientKey} {
+		if path == "" {
+			continue
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}
+		}
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+	return latest
+}
+
+// Exec reports the last status received from the gRPC health service. The
+// command and arguments are unused. A SERVING status results in exit code 0,
+// any other status in exit code 2, which the check callback reports as
+// passing and critical respectively. The first Exec opens the Watch stream
+// and waits up to the timeout for its first status.
+func (e *grpcCheckExec) Exec(timeout time.Duration, _ string, _ []string) ([]byte, int, error) {
+	deadline := time.Now().Add(timeout)
+
+	e.l.Lock()
+	if e.unimplemented {
+		e.l.Unlock()
+		return e.check(timeout)
+	}
+	if e.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		e.cancel = cancel
+		go e.watch(ctx)
+	} else if !e.certModTime.IsZero() && e.certsModTime().After(e.certModTime) {
+		select {
+		case e.reconnectCh <- struct{}{}:
+		default:
+		}
+	}
+	result, updateCh := e.result, e.updateCh
+	e.l.Unlock()
+
+	if result == nil {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case <-updateCh:
+		case <-timer.C:
+			return nil, 0, context.DeadlineExceeded
+		}
+
+		e.l.Lock()
+		result = e.result
+		unimplemented := e.unimplemented
+		e.l.Unlock()
+		if unimplemented {
+			return e.check(time.Until(deadline))
+		}
+		if result == nil {
+			return nil, 0, context.DeadlineExceeded
+		}
+	}
+
+	if result.err != nil {
+		return nil, 0, result.err
+	}
+	return e.output(result.status)
+}
+
+// Stop closes the Watch stream. It is called when the tasklet running the
+// check exits.
+func (e *grpcCheckExec) Stop() {
+	e.l.Lock()
+	defer e.l.Unlock()
+	if e.cancel != nil {
+		e.cancel()
+	}
+}
+
+// setResult records the result of the Watch stream and wakes up an Exec
+// waiting for the first one.
+func (e *grpcCheckExec) setResult(result *grpcCheckResult) {
+	e.l.Lock()
+	defer e.l.Unlock()
+	e.result = result
+	close(e.updateCh)
+	e.updateCh = make(chan struct{})
+}
+
+// watch keeps a Watch stream open until the context is canceled, reopening
+// it with a backoff when it fails. If the service does not implement Watch
+// the check falls back to Check on every Exec.
+func (e *grpcCheckExec) watch(ctx context.Context) {
+	backoff := grpcCheckReconnectBaseline
+	limit := e.interval
+	if limit < backoff {
+		limit = backoff
+	}
+
+	for {
+		received, err := e.watchStream(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		switch err {
+		case errGRPCCheckReconnect:
+			backoff = grpcCheckReconnectBaseline
+			continue
+		case errGRPCWatchUnimplemented:
+			e.l.Lock()
+			e.unimplemented = true
+			close(e.updateCh)
+			e.updateCh = make(chan struct{})
+			e.l.Unlock()
+			return
+		}
+
+		e.setResult(&grpcCheckResult{err: err})
+		if received {
+			backoff = grpcCheckReconnectBaseline
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if backoff > limit {
+			backoff = limit
+		}
+	}
+}
+
+// watchStream opens a Watch stream and records every status it receives
+// until the stream fails. It returns whether any status was received.
+func (e *grpcCheckExec) watchStream(ctx context.Context) (bool, error) {
+	modTime := e.certsModTime()
+	conf, err := e.tlsConfig()
+	if err != nil {
+		return false, err
+	}
+
+	e.l.Lock()
+	e.certModTime = modTime
+	e.l.Unlock()
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Drain any stale signal, then close the stream when the certificates
+	// change so they are reloaded
+	select {
+	case <-e.reconnectCh:
+	default:
+	}
+	go func() {
+		select {
+		case <-e.reconnectCh:
+			cancel()
+		case <-streamCtx.Done():
+		}
+	}()
+
+	dialCtx, dialCancel := context.WithTimeout(streamCtx, e.timeout)
+	conn, err := grpc.DialContext(dialCtx, e.addr,
+		grpc.WithTransportCredentials(credentials.NewTLS(conf)),
+		grpc.WithBlock(),
+	)
+	dialCancel()
+	if err != nil {
+		if ctx.Err() == nil && streamCtx.Err() != nil {
+			return false, errGRPCCheckReconnect
+		}
+		return false, fmt.Errorf("failed to connect to %s: %v", e.addr, err)
+	}
+	defer conn.Close()
+
+	stream, err := healthpb.NewHealthClient(conn).Watch(streamCtx,
+		&healthpb.HealthCheckRequest{Service: e.service})
+	received := false
+	for err == nil {
+		var resp *healthpb.HealthCheckResponse
+		resp, err = stream.Recv()
+		if err == nil {
+			received = true
+			e.setResult(&grpcCheckResult{status: resp.GetStatus()})
+		}
+	}
+
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		return received, errGRPCWatchUnimplemented
+	case ctx.Err() == nil && streamCtx.Err() != nil:
+		return received, errGRPCCheckReconnect
+	}
+	return received, fmt.Errorf("gRPC health watch failed: %v", err)
+}
+
+// check runs a single gRPC health check with Check, for services that do
+// not implement Watch.
+func (e *grpcCheckExec) check(timeout time.Duration) ([]byte, int, error) {
+	conf, err := e.tlsConfig()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, e.addr,
+		grpc.WithTransportCredentials(credentials.NewTLS(conf)),
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to connect to %s: %v", e.addr, err)
+	}
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx,
+		&healthpb.HealthCheckRequest{Service: e.service})
+	if err != nil {
+		return nil, 0, fmt.Errorf("gRPC health check failed: %v", err)
+	}
+	return e.output(resp.GetStatus())
+}
+
+// output formats a health status as the check output and exit code.
+func (e *grpcCheckExec) output(s healthpb.HealthCheckResponse_ServingStatus) ([]byte, int, error) {
+	output := []byte(fmt.Sprintf("gRPC check %s/%s: %s", e.addr, e.service, s))
+	if s != healthpb.HealthCheckResponse_SERVING {
+		return output, 2, nil
+	}
+	return output, 0, nil
+}
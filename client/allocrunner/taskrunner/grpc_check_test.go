@@ -0,0 +1,166 @@
+package taskrunner
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestGRPCCheckExec_New(t *testing.T) {
+	ci.Parallel(t)
+
+	env := taskenv.NewTaskEnv(
+		map[string]string{
+			"NOMAD_ADDR_grpc":   "10.0.0.1:9090",
+			"NOMAD_SECRETS_DIR": "/secrets",
+		},
+		map[string]string{
+			"NOMAD_ADDR_grpc":   "10.0.0.1:9090",
+			"NOMAD_SECRETS_DIR": "/alloc/web/secrets",
+		},
+		nil, nil, "/alloc/web", "/alloc/alloc")
+
+	check := &structs.ServiceCheck{
+		Name:          "check",
+		Type:          structs.ServiceCheckGRPC,
+		GRPCService:   "foo.Bar",
+		GRPCUseTLS:    true,
+		TLSServerName: "api.example.com",
+		TLSClientCert: "${NOMAD_SECRETS_DIR}/client.pem",
+		TLSClientKey:  "${NOMAD_SECRETS_DIR}/client-key.pem",
+		Interval:      time.Second,
+		Timeout:       time.Second,
+	}
+
+	exec, err := newGRPCCheckExec(check, "grpc", env)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1:9090", exec.addr)
+	require.Equal(t, "foo.Bar", exec.service)
+	require.Equal(t, "api.example.com", exec.serverName)
+	require.Equal(t, "/alloc/web/secrets/client.pem", exec.clientCert)
+	require.Equal(t, "/alloc/web/secrets/client-key.pem", exec.clientKey)
+	require.Empty(t, exec.caCert)
+
+	// Unknown port labels
+	_, err = newGRPCCheckExec(check, "http", env)
+	require.EqualError(t, err, `unable to find address for port "http"`)
+
+	// Paths may not escape the allocation
+	check.TLSCACert = "/etc/ssl/ca.pem"
+	_, err = newGRPCCheckExec(check, "grpc", env)
+	require.EqualError(t, err, `path "/etc/ssl/ca.pem" escapes the allocation directory`)
+
+	// Certificates are loaded on every check
+	check.TLSCACert = ""
+	exec, err = newGRPCCheckExec(check, "grpc", env)
+	require.NoError(t, err)
+	_, _, err = exec.Exec(time.Second, "", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to load client certificate")
+}
+
+// checkOnlyHealthServer implements Check but not Watch
+type checkOnlyHealthServer struct {
+	healthpb.UnimplementedHealthServer
+}
+
+func (checkOnlyHealthServer) Check(context.Context, *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// testGRPCHealthServer starts a TLS gRPC server with the health service and
+// returns its address.
+func testGRPCHealthServer(t *testing.T, health healthpb.HealthServer) string {
+	cert, err := tls.LoadX509KeyPair(
+		"../../../helper/tlsutil/testdata/global-server.pem",
+		"../../../helper/tlsutil/testdata/global-server-key.pem")
+	require.NoError(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+	})))
+	healthpb.RegisterHealthServer(srv, health)
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+
+	return ln.Addr().String()
+}
+
+func testGRPCCheckExec(addr string) *grpcCheckExec {
+	return &grpcCheckExec{
+		addr:        addr,
+		service:     "foo.Bar",
+		skipVerify:  true,
+		clientCert:  "../../../helper/tlsutil/testdata/global-client.pem",
+		clientKey:   "../../../helper/tlsutil/testdata/global-client-key.pem",
+		interval:    time.Second,
+		timeout:     time.Second,
+		reconnectCh: make(chan struct{}, 1),
+		updateCh:    make(chan struct{}),
+	}
+}
+
+func TestGRPCCheckExec_Watch(t *testing.T) {
+	ci.Parallel(t)
+
+	health := grpchealth.NewServer()
+	health.SetServingStatus("foo.Bar", healthpb.HealthCheckResponse_SERVING)
+	exec := testGRPCCheckExec(testGRPCHealthServer(t, health))
+	defer exec.Stop()
+
+	output, code, err := exec.Exec(5*time.Second, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, code)
+	require.Contains(t, string(output), "SERVING")
+
+	// Status changes are streamed without polling the service
+	health.SetServingStatus("foo.Bar", healthpb.HealthCheckResponse_NOT_SERVING)
+	testutil.WaitForResult(func() (bool, error) {
+		_, code, err := exec.Exec(time.Second, "", nil)
+		if err != nil {
+			return false, err
+		}
+		return code == 2, fmt.Errorf("expected exit code 2, got %d", code)
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	exec.l.Lock()
+	require.False(t, exec.unimplemented)
+	exec.l.Unlock()
+}
+
+func TestGRPCCheckExec_WatchUnimplemented(t *testing.T) {
+	ci.Parallel(t)
+
+	exec := testGRPCCheckExec(testGRPCHealthServer(t, checkOnlyHealthServer{}))
+	defer exec.Stop()
+
+	// Services without Watch are polled with Check
+	for i := 0; i < 2; i++ {
+		output, code, err := exec.Exec(5*time.Second, "", nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, code)
+		require.Contains(t, string(output), "SERVING")
+	}
+
+	exec.l.Lock()
+	require.True(t, exec.unimplemented)
+	exec.l.Unlock()
+}
@@ -0,0 +1,228 @@
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// identityRetryBaseline is the baseline time for exponential backoff
+	// when rotating a workload identity fails.
+	identityRetryBaseline = 5 * time.Second
+
+	// identityRetryLimit is the limit of the exponential backoff when
+	// rotating a workload identity fails.
+	identityRetryLimit = 3 * time.Minute
+
+	// identityFilePerms is the level of file permissions granted on the
+	// files of workload identities in the secrets directory of the task.
+	identityFilePerms = 0444
+)
+
+type identityHookConfig struct {
+	alloc  *structs.Allocation
+	task   *structs.Task
+	signer cinterfaces.IdentitySigner
+	events ti.EventEmitter
+	logger log.Logger
+}
+
+// identityHook requests the signed workload identities of a task, delivers
+// them as files in the secrets directory and environment variables, and
+// rotates the files before the identities expire.
+type identityHook struct {
+	alloc  *structs.Allocation
+	task   *structs.Task
+	signer cinterfaces.IdentitySigner
+	events ti.EventEmitter
+	logger log.Logger
+
+	// retryBaseline and retryLimit bound the backoff of failed rotations.
+	retryBaseline time.Duration
+	retryLimit    time.Duration
+
+	// cancel stops the rotation of the identities. It is called by Exited,
+	// Stop and Shutdown.
+	cancel context.CancelFunc
+
+	mu sync.Mutex
+}
+
+func newIdentityHook(config *identityHookConfig) *identityHook {
+	h := &identityHook{
+		alloc:         config.alloc,
+		task:          config.task,
+		signer:        config.signer,
+		events:        config.events,
+		retryBaseline: identityRetryBaseline,
+		retryLimit:    identityRetryLimit,
+	}
+	h.logger = config.logger.Named(h.Name())
+	return h
+}
+
+func (*identityHook) Name() string {
+	return "workload_identity"
+}
+
+func (h *identityHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Identities are signed again on every start, so stop rotating the
+	// identities of a previous run.
+	if h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+
+	names := make([]string, 0, len(h.task.Identities))
+	for _, identity := range h.task.Identities {
+		names = append(names, identity.Name)
+	}
+
+	signed, err := h.signer.SignIdentities(h.alloc, h.task.Name, names)
+	if err != nil {
+		return fmt.Errorf("failed to sign workload identities: %v", err)
+	}
+
+	secretsDir := req.TaskDir.SecretsDir
+	resp.Env = make(map[string]string)
+	var rotated []*structs.WorkloadIdentity
+	for _, identity := range h.task.Identities {
+		s, ok := signed[identity.Name]
+		if !ok {
+			return fmt.Errorf("workload identity %q was not signed", identity.Name)
+		}
+		if identity.Env {
+			resp.Env[identity.EnvVar()] = s.JWT
+		}
+		if identity.File {
+			if err := writeIdentity(secretsDir, identity, s); err != nil {
+				return err
+			}
+			rotated = append(rotated, identity)
+		}
+	}
+
+	// Only identities delivered as files are rotated, since the environment
+	// of a running task can't be changed.
+	if len(rotated) != 0 {
+		rotateCtx, cancel := context.WithCancel(context.Background())
+		h.cancel = cancel
+		for _, identity := range rotated {
+			go h.rotate(rotateCtx, secretsDir, identity, signed[identity.Name].Expiration)
+		}
+	}
+
+	return nil
+}
+
+// rotate signs the identity again after two thirds of the lifetime of the
+// current identity elapsed, until the context is cancelled.
+func (h *identityHook) rotate(ctx context.Context, secretsDir string, identity *structs.WorkloadIdentity, expiration time.Time) {
+	retry := 0
+	for {
+		wait := time.Until(expiration) * 2 / 3
+		if retry > 0 {
+			wait = h.retryBaseline << (retry - 1)
+			if wait > h.retryLimit || wait <= 0 {
+				wait = h.retryLimit
+			}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		signed, err := h.signer.SignIdentities(h.alloc, h.task.Name, []string{identity.Name})
+		if err == nil && signed[identity.Name] == nil {
+			err = fmt.Errorf("workload identity %q was not signed", identity.Name)
+		}
+		if err == nil {
+			err = writeIdentity(secretsDir, identity, signed[identity.Name])
+		}
+		if err != nil {
+			h.logger.Warn("failed to rotate workload identity", "identity", identity.Name, "expiration", expiration, "error", err)
+			if retry == 0 {
+				h.events.EmitEvent(structs.NewTaskEvent(structs.TaskIdentityRotationFailed).
+					SetMessage(fmt.Sprintf("Failed to rotate identity %q: %v", identity.Name, err)))
+			}
+			retry++
+			continue
+		}
+
+		retry = 0
+		expiration = signed[identity.Name].Expiration
+		h.logger.Debug("rotated workload identity", "identity", identity.Name, "expiration", expiration)
+		h.events.EmitEvent(structs.NewTaskEvent(structs.TaskIdentityRotated).
+			SetMessage(fmt.Sprintf("Identity %q rotated, expires at %s", identity.Name, expiration.UTC().Format(time.RFC3339))))
+	}
+}
+
+// writeIdentity atomically replaces the file of the identity in the secrets
+// directory, such that tasks never read a partially written identity.
+func writeIdentity(secretsDir string, identity *structs.WorkloadIdentity, signed *structs.SignedWorkloadIdentity) error {
+	path := filepath.Join(secretsDir, identity.FileName())
+	tmp, err := ioutil.TempFile(secretsDir, identity.FileName()+".tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write workload identity %q: %v", identity.Name, err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := tmp.WriteString(signed.JWT); err != nil {
+		return fmt.Errorf("failed to write workload identity %q: %v", identity.Name, err)
+	}
+	if err := tmp.Chmod(identityFilePerms); err != nil {
+		return fmt.Errorf("failed to write workload identity %q: %v", identity.Name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write workload identity %q: %v", identity.Name, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write workload identity %q: %v", identity.Name, err)
+	}
+	return nil
+}
+
+func (h *identityHook) stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cancel == nil {
+		return
+	}
+
+	h.cancel()
+	h.cancel = nil
+}
+
+func (h *identityHook) Exited(context.Context, *interfaces.TaskExitedRequest, *interfaces.TaskExitedResponse) error {
+	h.stop()
+	return nil
+}
+
+func (h *identityHook) Stop(context.Context, *interfaces.TaskStopRequest, *interfaces.TaskStopResponse) error {
+	h.stop()
+	return nil
+}
+
+func (h *identityHook) Shutdown() {
+	h.stop()
+}
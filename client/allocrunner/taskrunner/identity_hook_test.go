@@ -0,0 +1,173 @@
+package taskrunner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// Statically assert the identity hook implements the expected interfaces
+var _ interfaces.TaskPrestartHook = (*identityHook)(nil)
+var _ interfaces.TaskExitedHook = (*identityHook)(nil)
+var _ interfaces.TaskStopHook = (*identityHook)(nil)
+var _ interfaces.ShutdownHook = (*identityHook)(nil)
+
+// mockIdentitySigner signs identities that expire after ttl, numbering the
+// signed tokens, and fails while err is set.
+type mockIdentitySigner struct {
+	ttl time.Duration
+
+	mu    sync.Mutex
+	count int
+	err   error
+}
+
+func (m *mockIdentitySigner) SignIdentities(_ *structs.Allocation, task string, identities []string) (map[string]*structs.SignedWorkloadIdentity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	signed := make(map[string]*structs.SignedWorkloadIdentity, len(identities))
+	for _, name := range identities {
+		m.count++
+		signed[name] = &structs.SignedWorkloadIdentity{
+			Name:       name,
+			JWT:        fmt.Sprintf("%s.%s.%d", task, name, m.count),
+			Expiration: time.Now().Add(m.ttl),
+		}
+	}
+	return signed, nil
+}
+
+func (m *mockIdentitySigner) setErr(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+// mockIdentityEvents records the events of the task.
+type mockIdentityEvents struct {
+	eventCh chan *structs.TaskEvent
+}
+
+func (m *mockIdentityEvents) EmitEvent(event *structs.TaskEvent) {
+	m.eventCh <- event
+}
+
+func testIdentityHook(t *testing.T, signer *mockIdentitySigner) (*identityHook, *mockIdentityEvents, *interfaces.TaskPrestartRequest) {
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Identities = []*structs.WorkloadIdentity{
+		{Name: "aws", Audience: []string{"sts.amazonaws.com"}, TTL: time.Hour, File: true},
+		{Name: "gcp", Audience: []string{"gcp"}, TTL: time.Hour, Env: true},
+	}
+
+	events := &mockIdentityEvents{eventCh: make(chan *structs.TaskEvent, 10)}
+	h := newIdentityHook(&identityHookConfig{
+		alloc:  alloc,
+		task:   task,
+		signer: signer,
+		events: events,
+		logger: testlog.HCLogger(t),
+	})
+	h.retryBaseline = 10 * time.Millisecond
+	h.retryLimit = 10 * time.Millisecond
+
+	req := &interfaces.TaskPrestartRequest{
+		Task:    task,
+		TaskDir: &allocdir.TaskDir{SecretsDir: t.TempDir()},
+	}
+	return h, events, req
+}
+
+func TestIdentityHook_Prestart(t *testing.T) {
+	ci.Parallel(t)
+
+	signer := &mockIdentitySigner{ttl: time.Hour}
+	h, _, req := testIdentityHook(t, signer)
+	defer h.Shutdown()
+
+	var resp interfaces.TaskPrestartResponse
+	require.NoError(t, h.Prestart(context.Background(), req, &resp))
+
+	// Identities are delivered as files or environment variables
+	raw, err := ioutil.ReadFile(filepath.Join(req.TaskDir.SecretsDir, "nomad_aws.jwt"))
+	require.NoError(t, err)
+	require.Equal(t, "web.aws.1", string(raw))
+	require.NoFileExists(t, filepath.Join(req.TaskDir.SecretsDir, "nomad_gcp.jwt"))
+	require.Equal(t, map[string]string{"NOMAD_TOKEN_gcp": "web.gcp.2"}, resp.Env)
+
+	// Signing failures fail the prestart
+	signer.setErr(errors.New("no leader"))
+	err = h.Prestart(context.Background(), req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no leader")
+}
+
+func TestIdentityHook_Rotate(t *testing.T) {
+	ci.Parallel(t)
+
+	signer := &mockIdentitySigner{ttl: 150 * time.Millisecond}
+	h, events, req := testIdentityHook(t, signer)
+	defer h.Shutdown()
+
+	var resp interfaces.TaskPrestartResponse
+	require.NoError(t, h.Prestart(context.Background(), req, &resp))
+
+	// The file is rotated before the identity expires
+	select {
+	case event := <-events.eventCh:
+		require.Equal(t, structs.TaskIdentityRotated, event.Type)
+		require.Contains(t, event.Message, `Identity "aws" rotated`)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the identity to rotate")
+	}
+	raw, err := ioutil.ReadFile(filepath.Join(req.TaskDir.SecretsDir, "nomad_aws.jwt"))
+	require.NoError(t, err)
+	require.NotEqual(t, "web.aws.1", string(raw))
+
+	// Failed rotations are reported and retried
+	signer.setErr(errors.New("no leader"))
+	select {
+	case event := <-events.eventCh:
+		require.Equal(t, structs.TaskIdentityRotationFailed, event.Type)
+		require.Contains(t, event.Message, "no leader")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the rotation to fail")
+	}
+	signer.setErr(nil)
+	select {
+	case event := <-events.eventCh:
+		require.Equal(t, structs.TaskIdentityRotated, event.Type)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the rotation to be retried")
+	}
+
+	// Identities are not rotated once the task exited
+	require.NoError(t, h.Exited(context.Background(), nil, nil))
+	time.Sleep(50 * time.Millisecond)
+	for len(events.eventCh) > 0 {
+		<-events.eventCh
+	}
+	select {
+	case event := <-events.eventCh:
+		t.Fatalf("unexpected event after the task exited: %v", event.Type)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
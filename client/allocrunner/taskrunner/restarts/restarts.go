@@ -38,8 +38,8 @@ func NewRestartTracker(policy *structs.RestartPolicy, jobType string, tlc *struc
 		onSuccess = tlc.Sidecar
 	}
 
-	// Poststop should never be restarted on success
-	if tlc != nil && tlc.Hook == structs.TaskLifecycleHookPoststop {
+	// Prestop and poststop should never be restarted on success
+	if tlc != nil && (tlc.Hook == structs.TaskLifecycleHookPrestop || tlc.Hook == structs.TaskLifecycleHookPoststop) {
 		onSuccess = false
 	}
 
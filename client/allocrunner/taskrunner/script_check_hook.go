@@ -98,8 +98,9 @@ func (h *scriptCheckHook) Poststart(ctx context.Context, req *interfaces.TaskPos
 	defer h.mu.Unlock()
 
 	if req.DriverExec == nil {
+		// gRPC checks with client certificates are still run by the
+		// client, but script checks will be skipped
 		h.logger.Debug("driver doesn't support script checks")
-		return nil
 	}
 	h.driverExec = req.DriverExec
 	h.taskEnv = req.TaskEnv
@@ -179,16 +180,18 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 	interpolatedTaskServices := taskenv.InterpolateServices(h.taskEnv, h.task.Services)
 	for _, service := range interpolatedTaskServices {
 		for _, check := range service.Checks {
-			if check.Type != structs.ServiceCheckScript {
+			if check.Type != structs.ServiceCheckScript && !check.RequiresClientTLS() {
 				continue
 			}
 			serviceID := agentconsul.MakeAllocServiceID(
 				h.alloc.ID, h.task.Name, service)
 			sc := newScriptCheck(&scriptCheckConfig{
-				consulNamespace: h.consulNamespace,
+				consulNamespace: service.ConsulNamespace(h.consulNamespace),
+				consulPartition: service.Partition,
 				allocID:         h.alloc.ID,
 				taskName:        h.task.Name,
 				check:           check,
+				portLabel:       service.PortLabel,
 				serviceID:       serviceID,
 				ttlUpdater:      h.consul,
 				driverExec:      h.driverExec,
@@ -215,7 +218,7 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 	interpolatedGroupServices := taskenv.InterpolateServices(h.taskEnv, tg.Services)
 	for _, service := range interpolatedGroupServices {
 		for _, check := range service.Checks {
-			if check.Type != structs.ServiceCheckScript {
+			if check.Type != structs.ServiceCheckScript && !check.RequiresClientTLS() {
 				continue
 			}
 			if !h.associated(h.task.Name, service.TaskName, check.TaskName) {
@@ -225,10 +228,12 @@ func (h *scriptCheckHook) newScriptChecks() map[string]*scriptCheck {
 			serviceID := agentconsul.MakeAllocServiceID(
 				h.alloc.ID, groupTaskName, service)
 			sc := newScriptCheck(&scriptCheckConfig{
-				consulNamespace: h.consulNamespace,
+				consulNamespace: service.ConsulNamespace(h.consulNamespace),
+				consulPartition: service.Partition,
 				allocID:         h.alloc.ID,
 				taskName:        groupTaskName,
 				check:           check,
+				portLabel:       service.PortLabel,
 				serviceID:       serviceID,
 				ttlUpdater:      h.consul,
 				driverExec:      h.driverExec,
@@ -262,7 +267,7 @@ func (*scriptCheckHook) associated(task, serviceTask, checkTask string) bool {
 // TTLUpdater is the subset of consul agent functionality needed by script
 // checks to heartbeat
 type TTLUpdater interface {
-	UpdateTTL(id, namespace, output, status string) error
+	UpdateTTL(id, namespace, partition, output, status string) error
 }
 
 // scriptCheck runs script checks via a interfaces.ScriptExecutor and updates the
@@ -270,6 +275,7 @@ type TTLUpdater interface {
 type scriptCheck struct {
 	id              string
 	consulNamespace string
+	consulPartition string
 	ttlUpdater      TTLUpdater
 	check           *structs.ServiceCheck
 	lastCheckOk     bool // true if the last check was ok; otherwise false
@@ -282,7 +288,9 @@ type scriptCheckConfig struct {
 	taskName        string
 	serviceID       string
 	consulNamespace string
+	consulPartition string
 	check           *structs.ServiceCheck
+	portLabel       string
 	ttlUpdater      TTLUpdater
 	driverExec      tinterfaces.ScriptExecutor
 	taskEnv         *taskenv.TaskEnv
@@ -299,7 +307,23 @@ func newScriptCheck(config *scriptCheckConfig) *scriptCheck {
 
 	// Guard against not having a valid taskEnv. This can be the case if the
 	// PreKilling or Exited hook is run before Poststart.
-	if config.taskEnv == nil || config.driverExec == nil {
+	if config.taskEnv == nil {
+		return nil
+	}
+
+	// gRPC checks with client certificates are run by the client rather
+	// than in the task's driver
+	exec := config.driverExec
+	if config.check.RequiresClientTLS() {
+		grpcExec, err := newGRPCCheckExec(config.check, config.portLabel, config.taskEnv)
+		if err != nil {
+			config.logger.Error("failed to configure gRPC check",
+				"check", config.check.Name, "error", err)
+			return nil
+		}
+		exec = grpcExec
+	}
+	if exec == nil {
 		return nil
 	}
 
@@ -315,7 +339,7 @@ func newScriptCheck(config *scriptCheckConfig) *scriptCheck {
 	sc.Args = config.taskEnv.ParseAndReplace(config.check.Args)
 	sc.Interval = config.check.Interval
 	sc.Timeout = config.check.Timeout
-	sc.exec = config.driverExec
+	sc.exec = exec
 	sc.callback = newScriptCheckCallback(sc)
 	sc.logger = config.logger
 	sc.shutdownCh = config.shutdownCh
@@ -332,6 +356,7 @@ func newScriptCheck(config *scriptCheckConfig) *scriptCheck {
 		sc.id = agentconsul.MakeCheckID(config.serviceID, sc.check)
 	}
 	sc.consulNamespace = config.consulNamespace
+	sc.consulPartition = config.consulPartition
 	return sc
 }
 
@@ -401,7 +426,7 @@ const (
 // service registration and the first check.
 func (sc *scriptCheck) updateTTL(ctx context.Context, msg, state string) error {
 	for attempts := 0; ; attempts++ {
-		err := sc.ttlUpdater.UpdateTTL(sc.id, sc.consulNamespace, msg, state)
+		err := sc.ttlUpdater.UpdateTTL(sc.id, sc.consulNamespace, sc.consulPartition, msg, state)
 		if err == nil {
 			return nil
 		}
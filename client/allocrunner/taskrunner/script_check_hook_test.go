@@ -46,7 +46,7 @@ type fakeHeartbeater struct {
 	heartbeats chan heartbeat
 }
 
-func (f *fakeHeartbeater) UpdateTTL(checkID, namespace, output, status string) error {
+func (f *fakeHeartbeater) UpdateTTL(checkID, namespace, partition, output, status string) error {
 	f.heartbeats <- heartbeat{checkID: checkID, output: output, status: status}
 	return nil
 }
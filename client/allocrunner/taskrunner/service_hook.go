@@ -42,13 +42,14 @@ type serviceHook struct {
 	logger          log.Logger
 
 	// The following fields may be updated
-	driverExec tinterfaces.ScriptExecutor
-	driverNet  *drivers.DriverNetwork
-	canary     bool
-	services   []*structs.Service
-	networks   structs.Networks
-	ports      structs.AllocatedPorts
-	taskEnv    *taskenv.TaskEnv
+	driverExec   tinterfaces.ScriptExecutor
+	driverNet    *drivers.DriverNetwork
+	canary       bool
+	blueGreenTag string
+	services     []*structs.Service
+	networks     structs.Networks
+	ports        structs.AllocatedPorts
+	taskEnv      *taskenv.TaskEnv
 
 	// initialRegistrations tracks if Poststart has completed, initializing
 	// fields required in other lifecycle funcs
@@ -81,6 +82,7 @@ func newServiceHook(c serviceHookConfig) *serviceHook {
 	if c.alloc.DeploymentStatus != nil && c.alloc.DeploymentStatus.Canary {
 		h.canary = true
 	}
+	h.blueGreenTag = c.alloc.BlueGreenTag()
 
 	h.logger = c.logger.Named(h.Name())
 	return h
@@ -154,6 +156,7 @@ func (h *serviceHook) updateHookFields(req *interfaces.TaskUpdateRequest) error
 	h.services = task.Services
 	h.networks = networks
 	h.canary = canary
+	h.blueGreenTag = req.Alloc.BlueGreenTag()
 	h.ports = req.Alloc.AllocatedResources.Shared.Ports
 
 	return nil
@@ -196,6 +199,7 @@ func (h *serviceHook) Stop(ctx context.Context, req *interfaces.TaskStopRequest,
 func (h *serviceHook) getWorkloadServices() *agentconsul.WorkloadServices {
 	// Interpolate with the task's environment
 	interpolatedServices := taskenv.InterpolateServices(h.taskEnv, h.services)
+	agentconsul.AddBlueGreenTag(interpolatedServices, h.blueGreenTag)
 
 	// Create task services struct with request's driver metadata
 	return &agentconsul.WorkloadServices{
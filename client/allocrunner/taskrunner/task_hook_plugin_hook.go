@@ -0,0 +1,125 @@
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	"github.com/hashicorp/nomad/client/pluginmanager/taskhookmanager"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/taskhook"
+)
+
+type taskHookPluginHookConfig struct {
+	plugin  string
+	manager taskhookmanager.Manager
+	alloc   *structs.Allocation
+	task    *structs.Task
+	taskDir *allocdir.TaskDir
+	events  ti.EventEmitter
+	logger  log.Logger
+}
+
+// taskHookPluginHook calls the hooks of a task hook plugin around the
+// lifecycle of the task. Failures of the prestart hook of the plugin fail the
+// start of the task, while failures of the other hooks are only reported.
+type taskHookPluginHook struct {
+	plugin  string
+	manager taskhookmanager.Manager
+	events  ti.EventEmitter
+	logger  log.Logger
+
+	// task is the context of the task passed to the plugin. Its environment
+	// is updated on every start of the task.
+	task   *taskhook.TaskContext
+	taskMu sync.Mutex
+}
+
+func newTaskHookPluginHook(config *taskHookPluginHookConfig) *taskHookPluginHook {
+	h := &taskHookPluginHook{
+		plugin:  config.plugin,
+		manager: config.manager,
+		events:  config.events,
+		task: &taskhook.TaskContext{
+			AllocID:   config.alloc.ID,
+			Namespace: config.alloc.Namespace,
+			JobID:     config.alloc.JobID,
+			TaskGroup: config.alloc.TaskGroup,
+			TaskName:  config.task.Name,
+			Driver:    config.task.Driver,
+			AllocDir:  config.taskDir.AllocDir,
+			TaskDir:   config.taskDir.Dir,
+		},
+	}
+	h.logger = config.logger.Named(h.Name())
+	return h
+}
+
+func (h *taskHookPluginHook) Name() string {
+	return "task_hook_" + h.plugin
+}
+
+// taskContext returns the context of the task, updating its environment if
+// one is given.
+func (h *taskHookPluginHook) taskContext(env map[string]string) *taskhook.TaskContext {
+	h.taskMu.Lock()
+	defer h.taskMu.Unlock()
+
+	if env != nil {
+		task := *h.task
+		task.Env = env
+		h.task = &task
+	}
+	return h.task
+}
+
+func (h *taskHookPluginHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
+	plugin, err := h.manager.Dispense(h.plugin)
+	if err != nil {
+		return structs.NewRecoverableError(err, true)
+	}
+
+	presp, err := plugin.Prestart(ctx, h.taskContext(req.TaskEnv.Map()))
+	if err != nil {
+		return structs.NewRecoverableError(fmt.Errorf("task hook plugin %q failed: %v", h.plugin, err), true)
+	}
+
+	if presp != nil {
+		resp.Env = presp.Env
+	}
+	return nil
+}
+
+func (h *taskHookPluginHook) Poststart(ctx context.Context, req *interfaces.TaskPoststartRequest, _ *interfaces.TaskPoststartResponse) error {
+	var env map[string]string
+	if req.TaskEnv != nil {
+		env = req.TaskEnv.Map()
+	}
+	h.call(ctx, "poststart", h.taskContext(env), taskhook.TaskHookPlugin.Poststart)
+	return nil
+}
+
+func (h *taskHookPluginHook) PreKilling(ctx context.Context, _ *interfaces.TaskPreKillRequest, _ *interfaces.TaskPreKillResponse) error {
+	h.call(ctx, "prestop", h.taskContext(nil), taskhook.TaskHookPlugin.Prestop)
+	return nil
+}
+
+// call calls a hook of the plugin, reporting failures as task events since
+// the task is already running.
+func (h *taskHookPluginHook) call(ctx context.Context, hook string, task *taskhook.TaskContext,
+	fn func(taskhook.TaskHookPlugin, context.Context, *taskhook.TaskContext) error) {
+
+	plugin, err := h.manager.Dispense(h.plugin)
+	if err == nil {
+		err = fn(plugin, ctx, task)
+	}
+	if err != nil {
+		h.logger.Warn("task hook plugin failed", "hook", hook, "error", err)
+		h.events.EmitEvent(structs.NewTaskEvent(structs.TaskHookFailed).
+			SetMessage(fmt.Sprintf("%s hook of task hook plugin %q failed: %v", hook, h.plugin, err)))
+	}
+}
@@ -0,0 +1,139 @@
+package taskrunner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/taskhook"
+	"github.com/stretchr/testify/require"
+)
+
+// Statically assert the task hook plugin hook implements the expected
+// interfaces
+var _ interfaces.TaskPrestartHook = (*taskHookPluginHook)(nil)
+var _ interfaces.TaskPoststartHook = (*taskHookPluginHook)(nil)
+var _ interfaces.TaskPreKillHook = (*taskHookPluginHook)(nil)
+
+// mockTaskHookManager dispenses the given task hook plugins.
+type mockTaskHookManager struct {
+	plugins map[string]taskhook.TaskHookPlugin
+}
+
+func (m *mockTaskHookManager) Run()               {}
+func (m *mockTaskHookManager) Shutdown()          {}
+func (m *mockTaskHookManager) PluginType() string { return base.PluginTypeTaskHook }
+
+func (m *mockTaskHookManager) Plugins() []string {
+	var plugins []string
+	for name := range m.plugins {
+		plugins = append(plugins, name)
+	}
+	return plugins
+}
+
+func (m *mockTaskHookManager) Dispense(name string) (taskhook.TaskHookPlugin, error) {
+	p, ok := m.plugins[name]
+	if !ok {
+		return nil, errors.New("no matching plugin")
+	}
+	return p, nil
+}
+
+func testTaskHookPluginHook(t *testing.T, plugin *taskhook.MockTaskHookPlugin) (*taskHookPluginHook, *mockEmitter, *structs.Allocation) {
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	events := &mockEmitter{}
+	h := newTaskHookPluginHook(&taskHookPluginHookConfig{
+		plugin: "billing",
+		manager: &mockTaskHookManager{
+			plugins: map[string]taskhook.TaskHookPlugin{"billing": plugin},
+		},
+		alloc:   alloc,
+		task:    task,
+		taskDir: &allocdir.TaskDir{AllocDir: "/alloc", Dir: "/alloc/web"},
+		events:  events,
+		logger:  testlog.HCLogger(t),
+	})
+	return h, events, alloc
+}
+
+func TestTaskHookPluginHook_Hooks(t *testing.T) {
+	ci.Parallel(t)
+
+	var called []*taskhook.TaskContext
+	record := func(_ context.Context, task *taskhook.TaskContext) error {
+		called = append(called, task)
+		return nil
+	}
+	h, events, alloc := testTaskHookPluginHook(t, &taskhook.MockTaskHookPlugin{
+		PrestartF: func(ctx context.Context, task *taskhook.TaskContext) (*taskhook.PrestartResponse, error) {
+			return &taskhook.PrestartResponse{Env: map[string]string{"BILLING_ID": "42"}}, record(ctx, task)
+		},
+		PoststartF: record,
+		PrestopF:   record,
+	})
+
+	env := taskenv.NewBuilder(mock.Node(), alloc, alloc.Job.TaskGroups[0].Tasks[0], "global").Build()
+	req := &interfaces.TaskPrestartRequest{TaskEnv: env}
+	var resp interfaces.TaskPrestartResponse
+	require.NoError(t, h.Prestart(context.Background(), req, &resp))
+	require.Equal(t, map[string]string{"BILLING_ID": "42"}, resp.Env)
+
+	require.NoError(t, h.Poststart(context.Background(), &interfaces.TaskPoststartRequest{TaskEnv: env}, nil))
+	require.NoError(t, h.PreKilling(context.Background(), nil, nil))
+	require.Empty(t, events.events)
+
+	require.Len(t, called, 3)
+	for _, task := range called {
+		require.Equal(t, alloc.ID, task.AllocID)
+		require.Equal(t, alloc.Namespace, task.Namespace)
+		require.Equal(t, alloc.JobID, task.JobID)
+		require.Equal(t, alloc.TaskGroup, task.TaskGroup)
+		require.Equal(t, "web", task.TaskName)
+		require.Equal(t, "exec", task.Driver)
+		require.Equal(t, "/alloc", task.AllocDir)
+		require.Equal(t, "/alloc/web", task.TaskDir)
+		require.Equal(t, alloc.ID, task.Env["NOMAD_ALLOC_ID"])
+	}
+}
+
+// TestTaskHookPluginHook_Failures asserts that failures of the prestart hook
+// of a plugin fail the task with a recoverable error, while failures of the
+// other hooks are only reported as task events.
+func TestTaskHookPluginHook_Failures(t *testing.T) {
+	ci.Parallel(t)
+
+	failing := func(context.Context, *taskhook.TaskContext) error {
+		return errors.New("bookkeeping unavailable")
+	}
+	h, events, _ := testTaskHookPluginHook(t, &taskhook.MockTaskHookPlugin{
+		PrestartF: func(ctx context.Context, task *taskhook.TaskContext) (*taskhook.PrestartResponse, error) {
+			return nil, failing(ctx, task)
+		},
+		PoststartF: failing,
+		PrestopF:   failing,
+	})
+
+	req := &interfaces.TaskPrestartRequest{TaskEnv: taskenv.NewEmptyTaskEnv()}
+	err := h.Prestart(context.Background(), req, &interfaces.TaskPrestartResponse{})
+	require.Error(t, err)
+	require.True(t, structs.IsRecoverable(err))
+	require.Contains(t, err.Error(), "bookkeeping unavailable")
+
+	require.NoError(t, h.Poststart(context.Background(), &interfaces.TaskPoststartRequest{}, nil))
+	require.NoError(t, h.PreKilling(context.Background(), nil, nil))
+	require.Len(t, events.events, 2)
+	for _, event := range events.events {
+		require.Equal(t, structs.TaskHookFailed, event.Type)
+		require.Contains(t, event.Message, "bookkeeping unavailable")
+	}
+}
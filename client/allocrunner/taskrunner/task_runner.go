@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/hcl/v2/hcldec"
 	"github.com/hashicorp/nomad/client/allocdir"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/getter"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/restarts"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
 	"github.com/hashicorp/nomad/client/config"
@@ -25,6 +26,7 @@ import (
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	"github.com/hashicorp/nomad/client/pluginmanager/taskhookmanager"
 	cstate "github.com/hashicorp/nomad/client/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/taskenv"
@@ -180,11 +182,23 @@ type TaskRunner struct {
 	// vaultClient is the client to use to derive and renew Vault tokens
 	vaultClient vaultclient.VaultClient
 
+	// vaultClusters are the clients to use to derive and renew the Vault
+	// tokens of the named Vault clusters
+	vaultClusters map[string]vaultclient.VaultClient
+
+	// identitySigner is used to request the workload identities of the task
+	identitySigner cinterfaces.IdentitySigner
+
 	// vaultToken is the current Vault token. It should be accessed with the
 	// getter.
 	vaultToken     string
 	vaultTokenLock sync.Mutex
 
+	// vaultClusterTokens are the current Vault tokens of the named Vault
+	// clusters. They should be accessed with the getter and are guarded by
+	// vaultTokenLock.
+	vaultClusterTokens map[string]string
+
 	// baseLabels are used when emitting tagged metrics. All task runner metrics
 	// will have these tags, and optionally more.
 	baseLabels []metrics.Label
@@ -193,6 +207,10 @@ type TaskRunner struct {
 	// to be passed to the driver for task logging
 	logmonHookConfig *logmonHookConfig
 
+	// stdinFifo is the path of the fifo the task's stdin is read from, if the
+	// task reads its dispatch payload from stdin
+	stdinFifo string
+
 	// resourceUsage is written via UpdateStats and read via
 	// LatestResourceUsage. May be nil at all times.
 	resourceUsage     *cstructs.TaskResourceUsage
@@ -215,6 +233,13 @@ type TaskRunner struct {
 	// handlers
 	driverManager drivermanager.Manager
 
+	// taskHookManager is used to dispense task hook plugins
+	taskHookManager taskhookmanager.Manager
+
+	// artifactCache is the cache artifacts are copied from, or nil if
+	// artifacts aren't cached
+	artifactCache *getter.Cache
+
 	// dynamicRegistry is where dynamic plugins should be registered.
 	dynamicRegistry dynamicplugins.Registry
 
@@ -263,6 +288,13 @@ type Config struct {
 	// Vault is the client to use to derive and renew Vault tokens
 	Vault vaultclient.VaultClient
 
+	// VaultClusters are the clients to use to derive and renew the Vault
+	// tokens of the named Vault clusters
+	VaultClusters map[string]vaultclient.VaultClient
+
+	// IdentitySigner is used to request the workload identities of the task
+	IdentitySigner cinterfaces.IdentitySigner
+
 	// StateDB is used to store and restore state.
 	StateDB cstate.StateDB
 
@@ -286,6 +318,13 @@ type Config struct {
 	// handlers
 	DriverManager drivermanager.Manager
 
+	// TaskHookManager is used to dispense task hook plugins
+	TaskHookManager taskhookmanager.Manager
+
+	// ArtifactCache is the cache of artifacts shared by the allocations of the
+	// client, or nil if artifacts aren't cached
+	ArtifactCache *getter.Cache
+
 	// ServersContactedCh is closed when the first GetClientAllocs call to
 	// servers succeeds and allocs are synced.
 	ServersContactedCh chan struct{}
@@ -336,6 +375,8 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		consulProxiesClient:    config.ConsulProxies,
 		siClient:               config.ConsulSI,
 		vaultClient:            config.Vault,
+		vaultClusters:          config.VaultClusters,
+		identitySigner:         config.IdentitySigner,
 		state:                  tstate,
 		localState:             state.NewLocalState(),
 		stateDB:                config.StateDB,
@@ -351,6 +392,8 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		cpusetCgroupPathGetter: config.CpusetCgroupPathGetter,
 		devicemanager:          config.DeviceManager,
 		driverManager:          config.DriverManager,
+		taskHookManager:        config.TaskHookManager,
+		artifactCache:          config.ArtifactCache,
 		maxEvents:              defaultMaxEvents,
 		serversContactedCh:     config.ServersContactedCh,
 		startConditionMetCtx:   config.StartConditionMetCtx,
@@ -662,7 +705,8 @@ func (tr *TaskRunner) shouldShutdown() bool {
 		return true
 	}
 
-	if !tr.IsPoststopTask() && alloc.ServerTerminalStatus() {
+	// Prestop and poststop tasks run once the allocation is stopped
+	if !tr.IsPrestopTask() && !tr.IsPoststopTask() && alloc.ServerTerminalStatus() {
 		return true
 	}
 
@@ -813,6 +857,11 @@ func (tr *TaskRunner) runDriver() error {
 		return encodeErr
 	}
 
+	if err := tr.setNamespaceTaskUser(taskConfig); err != nil {
+		tr.EmitEvent(structs.NewTaskEvent(structs.TaskFailedValidation).SetValidationError(err))
+		return err
+	}
+
 	// If there's already a task handle (eg from a Restore) there's nothing
 	// to do except update state.
 	if tr.getDriverHandle() != nil {
@@ -1048,6 +1097,12 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		cpusetCpus[i] = fmt.Sprintf("%d", v)
 	}
 
+	var diskIOPS, diskBandwidth int64
+	if task.Resources != nil {
+		diskIOPS = int64(task.Resources.DiskIOPS)
+		diskBandwidth = int64(task.Resources.DiskBandwidthMB) * 1024 * 1024
+	}
+
 	return &drivers.TaskConfig{
 		ID:            fmt.Sprintf("%s/%s/%s", alloc.ID, task.Name, invocationid),
 		Name:          task.Name,
@@ -1060,10 +1115,12 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		Resources: &drivers.Resources{
 			NomadResources: taskResources,
 			LinuxResources: &drivers.LinuxResources{
-				MemoryLimitBytes: memoryLimit * 1024 * 1024,
-				CPUShares:        taskResources.Cpu.CpuShares,
-				CpusetCpus:       strings.Join(cpusetCpus, ","),
-				PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Cpu.CpuShares),
+				MemoryLimitBytes:   memoryLimit * 1024 * 1024,
+				CPUShares:          taskResources.Cpu.CpuShares,
+				CpusetCpus:         strings.Join(cpusetCpus, ","),
+				PercentTicks:       float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Cpu.CpuShares),
+				DiskIOPS:           diskIOPS,
+				DiskBandwidthBytes: diskBandwidth,
 			},
 			Ports: &ports,
 		},
@@ -1075,12 +1132,40 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		AllocDir:         tr.taskDir.AllocDir,
 		StdoutPath:       tr.logmonHookConfig.stdoutFifo,
 		StderrPath:       tr.logmonHookConfig.stderrFifo,
+		StdinPath:        tr.stdinFifo,
 		AllocID:          tr.allocID,
 		NetworkIsolation: tr.networkIsolationSpec,
 		DNS:              dns,
 	}
 }
 
+// namespaceIDRangeDrivers are the task drivers whose tasks run as a host uid
+// and gid of the id range of the task's namespace, if configured.
+var namespaceIDRangeDrivers = map[string]struct{}{
+	"exec":   {},
+	"docker": {},
+}
+
+// setNamespaceTaskUser overrides the user of the task with the host uid and
+// gid of the id range of the namespace of the allocation, if any. This
+// replaces the USER of docker images.
+func (tr *TaskRunner) setNamespaceTaskUser(taskConfig *drivers.TaskConfig) error {
+	if _, ok := namespaceIDRangeDrivers[tr.Task().Driver]; !ok {
+		return nil
+	}
+	idRange, ok := tr.clientConfig.NamespaceIDRanges[tr.Alloc().Namespace]
+	if !ok {
+		return nil
+	}
+
+	user, err := idRange.TaskUser(taskConfig.User)
+	if err != nil {
+		return err
+	}
+	taskConfig.User = user
+	return nil
+}
+
 // Restore task runner state. Called by AllocRunner.Restore after NewTaskRunner
 // but before Run so no locks need to be acquired.
 func (tr *TaskRunner) Restore() error {
@@ -1204,6 +1289,11 @@ func (tr *TaskRunner) updateStateImpl(state string) error {
 	taskState := tr.state
 	taskState.State = state
 
+	// Driver status messages only describe a pending task
+	if state != structs.TaskStatePending {
+		taskState.DriverStatus = ""
+	}
+
 	// Handle the state transition.
 	switch state {
 	case structs.TaskStateRunning:
@@ -1287,6 +1377,11 @@ func (tr *TaskRunner) appendEvent(event *structs.TaskEvent) error {
 		tr.state.LastRestart = time.Unix(0, event.Time)
 	}
 
+	// Surface what the driver is doing while the task is pending
+	if event.Type == structs.TaskDriverMessage && tr.state.State == structs.TaskStatePending {
+		tr.state.DriverStatus = event.DisplayMessage
+	}
+
 	// Append event to slice
 	appendTaskEvent(tr.state, event, tr.maxEvents)
 
@@ -2,6 +2,7 @@ package taskrunner
 
 import (
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -28,6 +29,11 @@ func (tr *TaskRunner) IsLeader() bool {
 	return tr.taskLeader
 }
 
+// IsPrestopTask returns true if this task is a prestop task in its task group.
+func (tr *TaskRunner) IsPrestopTask() bool {
+	return tr.Task().Lifecycle != nil && tr.Task().Lifecycle.Hook == structs.TaskLifecycleHookPrestop
+}
+
 // IsPoststopTask returns true if this task is a poststop task in its task group.
 func (tr *TaskRunner) IsPoststopTask() bool {
 	return tr.Task().Lifecycle != nil && tr.Task().Lifecycle.Hook == structs.TaskLifecycleHookPoststop
@@ -71,6 +77,41 @@ func (tr *TaskRunner) setVaultToken(token string) {
 	tr.envBuilder.SetVaultToken(token, ns, tr.task.Vault.Env)
 }
 
+// getVaultClusterTokens returns a copy of the Vault tokens of the named Vault
+// clusters.
+func (tr *TaskRunner) getVaultClusterTokens() map[string]string {
+	tr.vaultTokenLock.Lock()
+	defer tr.vaultTokenLock.Unlock()
+	return helper.CopyMapStringString(tr.vaultClusterTokens)
+}
+
+// setVaultClusterToken updates the Vault token of the named Vault cluster on
+// the task runner as well as in the task's environment.
+func (tr *TaskRunner) setVaultClusterToken(cluster, token string) {
+	c := tr.task.Vault.Cluster(cluster)
+	if c == nil {
+		return
+	}
+
+	tr.vaultTokenLock.Lock()
+	defer tr.vaultTokenLock.Unlock()
+
+	// Update the Vault token on the runner
+	if tr.vaultClusterTokens == nil {
+		tr.vaultClusterTokens = make(map[string]string)
+	}
+	tr.vaultClusterTokens[cluster] = token
+
+	ns := ""
+	if conf := tr.clientConfig.VaultConfig.Cluster(cluster); conf != nil {
+		ns = conf.Namespace
+	}
+	if c.Namespace != "" {
+		ns = c.Namespace
+	}
+	tr.envBuilder.SetVaultClusterToken(cluster, token, ns, c.Env)
+}
+
 // getDriverHandle returns a driver handle.
 func (tr *TaskRunner) getDriverHandle() *DriverHandle {
 	tr.handleLock.Lock()
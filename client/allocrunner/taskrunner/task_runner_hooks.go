@@ -3,12 +3,15 @@ package taskrunner
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"runtime"
 	"sync"
 	"time"
 
 	"github.com/LK4D4/joincontext"
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/getter"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
@@ -52,6 +55,13 @@ func (tr *TaskRunner) initHooks() {
 
 	tr.logmonHookConfig = newLogMonHookConfig(task.Name, tr.taskDir.LogDir)
 
+	// On Windows fifos are named pipes served by their reader, which can't be
+	// created ahead of the task driver, so the dispatch payload can only be
+	// streamed to stdin on other platforms
+	if task.DispatchPayload != nil && task.DispatchPayload.Stdin && runtime.GOOS != "windows" {
+		tr.stdinFifo = filepath.Join(tr.taskDir.LogDir, fmt.Sprintf(".%s.stdin.fifo", task.Name))
+	}
+
 	// Add the hook resources
 	tr.hookResources = &hookResources{}
 
@@ -62,11 +72,15 @@ func (tr *TaskRunner) initHooks() {
 		newValidateHook(tr.clientConfig, hookLogger),
 		newTaskDirHook(tr, hookLogger),
 		newLogMonHook(tr, hookLogger),
-		newDispatchHook(alloc, hookLogger),
+		newDispatchHook(alloc, tr.stdinFifo, tr.driverCapabilities, hookLogger),
 		newVolumeHook(tr, hookLogger),
-		newArtifactHook(tr, hookLogger),
+		newArtifactHook(tr, &getter.Config{
+			Fetchers:   tr.clientConfig.ArtifactFetchers,
+			Registries: tr.clientConfig.OCIRegistries,
+			Cache:      tr.artifactCache,
+		}, hookLogger),
 		newStatsHook(tr, tr.clientConfig.StatsCollectionInterval, hookLogger),
-		newDeviceHook(tr.devicemanager, hookLogger),
+		newDeviceHook(tr.devicemanager, tr.taskResources, hookLogger),
 	}
 
 	// If the task has a CSI stanza, add the hook.
@@ -82,6 +96,30 @@ func (tr *TaskRunner) initHooks() {
 			}))
 	}
 
+	// If the task has volume mounts with an on_recover action, add the hook
+	if hasVolumeRecoverActions(task) {
+		tr.runnerHooks = append(tr.runnerHooks, newVolumeRecoverHook(&volumeRecoverHookConfig{
+			alloc:       alloc,
+			task:        task,
+			hostVolumes: tr.clientConfig.Node.HostVolumes,
+			csiMounts:   tr.allocHookResources.GetCSIMounts,
+			lifecycle:   tr,
+			events:      tr,
+			logger:      hookLogger,
+		}))
+	}
+
+	// If the task has workload identities, add the hook
+	if len(task.Identities) != 0 {
+		tr.runnerHooks = append(tr.runnerHooks, newIdentityHook(&identityHookConfig{
+			alloc:  alloc,
+			task:   task,
+			signer: tr.identitySigner,
+			events: tr,
+			logger: hookLogger,
+		}))
+	}
+
 	// If Vault is enabled, add the hook
 	if task.Vault != nil {
 		tr.runnerHooks = append(tr.runnerHooks, newVaultHook(&vaultHookConfig{
@@ -94,6 +132,20 @@ func (tr *TaskRunner) initHooks() {
 			alloc:       tr.Alloc(),
 			task:        tr.taskName,
 		}))
+
+		for _, cluster := range task.Vault.Clusters {
+			tr.runnerHooks = append(tr.runnerHooks, newVaultHook(&vaultHookConfig{
+				vaultStanza: task.Vault,
+				cluster:     cluster.Name,
+				client:      tr.vaultClusters[cluster.Name],
+				events:      tr,
+				lifecycle:   tr,
+				updater:     tr,
+				logger:      hookLogger,
+				alloc:       tr.Alloc(),
+				task:        tr.taskName,
+			}))
+		}
 	}
 
 	// Get the consul namespace for the TG of the allocation
@@ -108,6 +160,7 @@ func (tr *TaskRunner) initHooks() {
 			templates:       task.Templates,
 			clientConfig:    tr.clientConfig,
 			envBuilder:      tr.envBuilder,
+			alloc:           alloc,
 			consulNamespace: consulNamespace,
 		}))
 	}
@@ -140,13 +193,21 @@ func (tr *TaskRunner) initHooks() {
 
 		if task.UsesConnectSidecar() {
 			tr.runnerHooks = append(tr.runnerHooks,
-				newEnvoyVersionHook(newEnvoyVersionHookConfig(alloc, tr.consulProxiesClient, hookLogger)),
+				newEnvoyVersionHook(newEnvoyVersionHookConfig(alloc, tr.consulProxiesClient, tr.clientConfig.EnvoyVersions, hookLogger)),
 				newEnvoyBootstrapHook(newEnvoyBootstrapHookConfig(alloc, tr.clientConfig.ConsulConfig, consulNamespace, hookLogger)),
 			)
 		} else if task.Kind.IsConnectNative() {
-			tr.runnerHooks = append(tr.runnerHooks, newConnectNativeHook(
-				newConnectNativeHookConfig(alloc, tr.clientConfig.ConsulConfig, hookLogger),
-			))
+			tr.runnerHooks = append(tr.runnerHooks,
+				newConnectNativeHook(newConnectNativeHookConfig(alloc, tr.clientConfig.ConsulConfig, hookLogger)),
+				newConnectNativeCertsHook(&connectNativeCertsHookConfig{
+					alloc:           alloc,
+					task:            task,
+					consul:          tr.clientConfig.ConsulConfig,
+					consulNamespace: consulNamespace,
+					lifecycle:       tr,
+					logger:          hookLogger,
+				}),
+			)
 		}
 	}
 
@@ -165,6 +226,23 @@ func (tr *TaskRunner) initHooks() {
 	if tr.driverCapabilities.RemoteTasks {
 		tr.runnerHooks = append(tr.runnerHooks, newRemoteTaskHook(tr, hookLogger))
 	}
+
+	// Add a hook for every task hook plugin of the client, last such that
+	// the plugins receive the environment of the task built by the other
+	// hooks.
+	if tr.taskHookManager != nil {
+		for _, plugin := range tr.taskHookManager.Plugins() {
+			tr.runnerHooks = append(tr.runnerHooks, newTaskHookPluginHook(&taskHookPluginHookConfig{
+				plugin:  plugin,
+				manager: tr.taskHookManager,
+				alloc:   alloc,
+				task:    task,
+				taskDir: tr.taskDir,
+				events:  tr,
+				logger:  hookLogger,
+			}))
+		}
+	}
 }
 
 func (tr *TaskRunner) emitHookError(err error, hookName string) {
@@ -238,6 +316,7 @@ func (tr *TaskRunner) prestart() error {
 		}
 
 		req.VaultToken = tr.getVaultToken()
+		req.VaultClusterTokens = tr.getVaultClusterTokens()
 
 		// Time the prestart hook
 		var start time.Time
@@ -435,8 +514,25 @@ func (tr *TaskRunner) stop() error {
 			merr.Errors = append(merr.Errors, fmt.Errorf("stop hook %q failed: %v", name, err))
 		}
 
-		// Stop hooks cannot alter state and must be idempotent, so
-		// unlike prestart there's no state to persist here.
+		// Store the hook state, so hooks don't repeat their work when a
+		// dead task is restored
+		if resp.State != nil {
+			hookState := origHookState.Copy()
+			if hookState == nil {
+				hookState = &state.HookState{}
+			}
+			hookState.Data = resp.State
+
+			if !hookState.Equal(origHookState) {
+				tr.stateLock.Lock()
+				tr.localState.Hooks[name] = hookState
+				tr.stateLock.Unlock()
+
+				if err := tr.persistLocalState(); err != nil {
+					merr.Errors = append(merr.Errors, fmt.Errorf("failed to persist state of stop hook %q: %v", name, err))
+				}
+			}
+		}
 
 		if tr.logger.IsTrace() {
 			end := time.Now()
@@ -474,9 +570,10 @@ func (tr *TaskRunner) updateHooks() {
 
 		// Build the request
 		req := interfaces.TaskUpdateRequest{
-			VaultToken: tr.getVaultToken(),
-			Alloc:      alloc,
-			TaskEnv:    tr.envBuilder.Build(),
+			VaultToken:         tr.getVaultToken(),
+			VaultClusterTokens: tr.getVaultClusterTokens(),
+			Alloc:              alloc,
+			TaskEnv:            tr.envBuilder.Build(),
 		}
 
 		// Time the update hook
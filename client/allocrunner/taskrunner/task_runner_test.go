@@ -207,6 +207,48 @@ func TestTaskRunner_BuildTaskConfig_CPU_Memory(t *testing.T) {
 	}
 }
 
+// TestTaskRunner_SetNamespaceTaskUser asserts exec and docker tasks run as the
+// host ids of the id range configured for the namespace of the allocation.
+func TestTaskRunner_SetNamespaceTaskUser(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name      string
+		driver    string
+		namespace string
+		user      string
+		expected  string
+	}{
+		{name: "docker", driver: "docker", namespace: "tenant-a", user: "33", expected: "100033:100033"},
+		{name: "exec default user", driver: "exec", namespace: "tenant-a", expected: "100000:100000"},
+		{name: "other driver", driver: "raw_exec", namespace: "tenant-a", user: "33", expected: "33"},
+		{name: "other namespace", driver: "exec", namespace: "tenant-b", user: "33", expected: "33"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			alloc := mock.BatchAlloc()
+			alloc.Namespace = tc.namespace
+			task := alloc.Job.TaskGroups[0].Tasks[0]
+
+			conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name)
+			defer cleanup()
+			conf.ClientConfig.NamespaceIDRanges = map[string]*config.NamespaceIDRangeConfig{
+				"tenant-a": {Namespace: "tenant-a", UID: 100000, GID: 100000, Size: 1000},
+			}
+
+			tr, err := NewTaskRunner(conf)
+			require.NoError(t, err)
+			tr.task.Driver = tc.driver
+
+			tc2 := tr.buildTaskConfig()
+			tc2.User = tc.user
+			require.NoError(t, tr.setNamespaceTaskUser(tc2))
+			require.Equal(t, tc.expected, tc2.User)
+		})
+	}
+}
+
 // TestTaskRunner_Stop_ExitCode asserts that the exit code is captured on a task, even if it's stopped
 func TestTaskRunner_Stop_ExitCode(t *testing.T) {
 	ctestutil.ExecCompatible(t)
@@ -853,6 +895,58 @@ func TestTaskRunner_Restore_HookEnv(t *testing.T) {
 	require.Equal("1", env["mock_hook"])
 }
 
+// mockStopHook is a test hook that records in its state that it stopped, and
+// doesn't stop again once it did.
+type mockStopHook struct {
+	called int
+}
+
+func (*mockStopHook) Name() string {
+	return "mock_stop_hook"
+}
+
+func (h *mockStopHook) Stop(ctx context.Context, req *interfaces.TaskStopRequest, resp *interfaces.TaskStopResponse) error {
+	if req.ExistingState["stopped"] == "true" {
+		return nil
+	}
+	h.called++
+
+	resp.State = map[string]string{"stopped": "true"}
+	return nil
+}
+
+// TestTaskRunner_Restore_StopHookState asserts that the state set by stop
+// hooks is persisted and passed to the hooks of restored task runners.
+func TestTaskRunner_Restore_StopHookState(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name)
+	conf.StateDB = cstate.NewMemDB(conf.Logger) // "persist" state between task runners
+	defer cleanup()
+
+	tr, err := NewTaskRunner(conf)
+	require.NoError(err)
+
+	mockHook := &mockStopHook{}
+	tr.runnerHooks = []interfaces.TaskHook{mockHook}
+
+	require.NoError(tr.stop())
+	require.NoError(tr.stop())
+	require.Equal(1, mockHook.called)
+
+	// Restored task runners don't stop the hook again
+	newTR, err := NewTaskRunner(conf)
+	require.NoError(err)
+	require.NoError(newTR.Restore())
+	newTR.runnerHooks = []interfaces.TaskHook{mockHook}
+
+	require.NoError(newTR.stop())
+	require.Equal(1, mockHook.called)
+}
+
 // This test asserts that we can recover from an "external" plugin exiting by
 // retrieving a new instance of the driver and recovering the task.
 func TestTaskRunner_RecoverFromDriverExiting(t *testing.T) {
@@ -1649,6 +1743,64 @@ func TestTaskRunner_DeriveToken_Retry(t *testing.T) {
 	})
 }
 
+// TestTaskRunner_DeriveToken_Clusters asserts the tokens of the named Vault
+// clusters are derived from their clients and written next to the token of
+// the default cluster.
+func TestTaskRunner_DeriveToken_Clusters(t *testing.T) {
+	ci.Parallel(t)
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Vault = &structs.Vault{
+		Policies: []string{"default"},
+		Env:      true,
+		Clusters: []*structs.VaultCluster{
+			{Name: "team-a", Policies: []string{"team"}, Env: true},
+		},
+	}
+
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name)
+	defer cleanup()
+
+	vaultClient := conf.Vault.(*vaultclient.MockVaultClient)
+	vaultClient.DeriveTokenFn = func(*structs.Allocation, []string) (map[string]string, error) {
+		return map[string]string{task.Name: "1234"}, nil
+	}
+	teamClient := vaultclient.NewMockVaultClient()
+	teamClient.DeriveTokenFn = func(*structs.Allocation, []string) (map[string]string, error) {
+		return map[string]string{task.Name: "5678"}, nil
+	}
+	conf.VaultClusters = map[string]vaultclient.VaultClient{"team-a": teamClient}
+
+	tr, err := NewTaskRunner(conf)
+	require.NoError(t, err)
+	defer tr.Kill(context.Background(), structs.NewTaskEvent("cleanup"))
+	go tr.Run()
+
+	select {
+	case <-tr.WaitCh():
+	case <-time.After(time.Duration(testutil.TestMultiplier()*15) * time.Second):
+		require.Fail(t, "timed out waiting for task runner to exit")
+	}
+
+	state := tr.TaskState()
+	require.Equal(t, structs.TaskStateDead, state.State)
+	require.False(t, state.Failed)
+
+	// Check that the tokens are on disk
+	data, err := ioutil.ReadFile(filepath.Join(conf.TaskDir.SecretsDir, vaultTokenFile))
+	require.NoError(t, err)
+	require.Equal(t, "1234", string(data))
+
+	data, err = ioutil.ReadFile(filepath.Join(conf.TaskDir.SecretsDir, vaultTokenFile+"_team-a"))
+	require.NoError(t, err)
+	require.Equal(t, "5678", string(data))
+
+	// Check that the tokens are in the environment
+	env := tr.envBuilder.Build().Map()
+	require.Equal(t, "1234", env["VAULT_TOKEN"])
+	require.Equal(t, "5678", env["VAULT_TOKEN_TEAM_A"])
+}
+
 // TestTaskRunner_DeriveToken_Unrecoverable asserts that an unrecoverable error
 // from deriving a vault token will fail a task.
 func TestTaskRunner_DeriveToken_Unrecoverable(t *testing.T) {
@@ -2543,3 +2695,29 @@ func TestTaskRunner_BaseLabels(t *testing.T) {
 	require.Equal(alloc.ID, labels["alloc_id"])
 	require.Equal(alloc.Namespace, labels["namespace"])
 }
+
+// TestTaskRunner_DriverStatus asserts driver messages are surfaced in the task
+// state while the task is pending and cleared once it starts.
+func TestTaskRunner_DriverStatus(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+
+	config, cleanup := testTaskRunnerConfig(t, alloc, task.Name)
+	defer cleanup()
+
+	tr, err := NewTaskRunner(config)
+	require.NoError(t, err)
+
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskDriverMessage).
+		SetDriverMessage("Docker image pull progress: Pulled 1/2 layers"))
+	require.Equal(t, "Docker image pull progress: Pulled 1/2 layers", tr.TaskState().DriverStatus)
+
+	tr.UpdateState(structs.TaskStateRunning, structs.NewTaskEvent(structs.TaskStarted))
+	require.Empty(t, tr.TaskState().DriverStatus)
+
+	// messages from a running task are only recorded as events
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskDriverMessage).SetDriverMessage("hello"))
+	require.Empty(t, tr.TaskState().DriverStatus)
+}
@@ -74,6 +74,13 @@ type tasklet struct {
 	shutdownCh <-chan struct{}
 }
 
+// stoppableExec is implemented by executors that hold resources between
+// runs, such as gRPC checks keeping a health stream open. Stop is called
+// once the tasklet exits.
+type stoppableExec interface {
+	Stop()
+}
+
 // taskletHandle is returned by tasklet.run by cancelling a tasklet and
 // waiting for it to shutdown.
 type taskletHandle struct {
@@ -104,6 +111,9 @@ func (t *tasklet) run() *taskletHandle {
 
 	go func() {
 		defer close(exitCh)
+		if stopper, ok := t.exec.(stoppableExec); ok {
+			defer stopper.Stop()
+		}
 		timer := time.NewTimer(0)
 		defer timer.Stop()
 		for {
@@ -0,0 +1,216 @@
+package template
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// consulTemplatePluginFunc is the function of consul-template running an
+// external command, which the calls to plugin functions are rewritten to.
+const consulTemplatePluginFunc = "plugin"
+
+// pluginCaller is the allocation whose templates call plugin functions. It is
+// passed to the commands in the environment, so they can authorize the call.
+type pluginCaller struct {
+	Namespace   string
+	JobID       string
+	ParentJobID string
+	AllocID     string
+}
+
+// newPluginCaller returns the caller of the plugin functions called by the
+// templates of the allocation. It is read from the allocation rather than the
+// task environment, which the env block of the task can override.
+func newPluginCaller(alloc *structs.Allocation) *pluginCaller {
+	caller := &pluginCaller{
+		Namespace: alloc.Namespace,
+		JobID:     alloc.JobID,
+		AllocID:   alloc.ID,
+	}
+	if alloc.Job != nil {
+		caller.ParentJobID = alloc.Job.ParentID
+	}
+	return caller
+}
+
+// env returns the environment variables passed to the commands.
+func (c *pluginCaller) env() []string {
+	return []string{
+		taskenv.Namespace + "=" + c.Namespace,
+		taskenv.JobID + "=" + c.JobID,
+		taskenv.AllocID + "=" + c.AllocID,
+	}
+}
+
+// rewritePluginFunctions rewrites the calls to the plugin functions of the
+// client in the contents of a template into calls to the plugin function of
+// consul-template running their command. It returns false if the template
+// doesn't call any plugin function and is unchanged.
+//
+// The plugin function of consul-template runs commands with the environment
+// of the client, so the commands are run through env to set the variables of
+// the caller. Templates calling a function that isn't allowed for the job of
+// the caller are rejected.
+//
+// The plugin function of consul-template is usually denied, as it would let
+// templates run any command on the client, and is only allowed for templates
+// calling plugin functions. The templates calling it directly are rejected
+// unless denylist allows it.
+func rewritePluginFunctions(contents, leftDelim, rightDelim string, functions []*config.TemplatePluginFunctionConfig, denylist []string, caller *pluginCaller) (string, bool, error) {
+	if len(functions) == 0 {
+		return contents, false, nil
+	}
+	byName := make(map[string]*config.TemplatePluginFunctionConfig, len(functions))
+	for _, f := range functions {
+		byName[f.Name] = f
+	}
+
+	tree := parse.New("template")
+	tree.Mode = parse.SkipFuncCheck
+	trees := make(map[string]*parse.Tree)
+	if _, err := tree.Parse(contents, leftDelim, rightDelim, trees); err != nil {
+		return "", false, fmt.Errorf("failed to parse template: %v", err)
+	}
+
+	var calls []*parse.IdentifierNode
+	for _, t := range trees {
+		walkIdentifiers(t.Root, func(n *parse.IdentifierNode) {
+			calls = append(calls, n)
+		})
+	}
+
+	// Rewrite the calls from the end of the template so the positions of the
+	// ones before remain valid.
+	sort.Slice(calls, func(i, j int) bool { return calls[i].Pos > calls[j].Pos })
+	rewritten := false
+	envCommand := ""
+	for _, call := range calls {
+		if call.Ident == consulTemplatePluginFunc && helper.SliceStringContains(denylist, consulTemplatePluginFunc) {
+			return "", false, fmt.Errorf("template calls the denied function %q", consulTemplatePluginFunc)
+		}
+
+		f, ok := byName[call.Ident]
+		if !ok {
+			continue
+		}
+		if helper.SliceStringContains(denylist, f.Name) {
+			return "", false, fmt.Errorf("template calls the denied function %q", f.Name)
+		}
+		if !f.Allows(caller.Namespace, caller.JobID, caller.ParentJobID) {
+			return "", false, fmt.Errorf("template calls the function %q not allowed for job %q in namespace %q",
+				f.Name, caller.JobID, caller.Namespace)
+		}
+
+		if envCommand == "" {
+			var err error
+			if envCommand, err = exec.LookPath("env"); err != nil {
+				return "", false, fmt.Errorf("failed to find env command to run plugin functions: %v", err)
+			}
+		}
+
+		parts := []string{consulTemplatePluginFunc, strconv.Quote(envCommand)}
+		for _, arg := range append(caller.env(), f.Command) {
+			parts = append(parts, strconv.Quote(arg))
+		}
+		for _, arg := range f.Args {
+			parts = append(parts, strconv.Quote(arg))
+		}
+		pos := int(call.Pos)
+		contents = contents[:pos] + strings.Join(parts, " ") + contents[pos+len(call.Ident):]
+		rewritten = true
+	}
+	return contents, rewritten, nil
+}
+
+// walkIdentifiers calls fn with the function identifiers under the node.
+func walkIdentifiers(node parse.Node, fn func(*parse.IdentifierNode)) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walkIdentifiers(child, fn)
+		}
+	case *parse.ActionNode:
+		walkIdentifiers(n.Pipe, fn)
+	case *parse.IfNode:
+		walkBranch(&n.BranchNode, fn)
+	case *parse.RangeNode:
+		walkBranch(&n.BranchNode, fn)
+	case *parse.WithNode:
+		walkBranch(&n.BranchNode, fn)
+	case *parse.TemplateNode:
+		walkIdentifiers(n.Pipe, fn)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			walkIdentifiers(cmd, fn)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			walkIdentifiers(arg, fn)
+		}
+	case *parse.ChainNode:
+		walkIdentifiers(n.Node, fn)
+	case *parse.IdentifierNode:
+		fn(n)
+	}
+}
+
+func walkBranch(n *parse.BranchNode, fn func(*parse.IdentifierNode)) {
+	walkIdentifiers(n.Pipe, fn)
+	walkIdentifiers(n.List, fn)
+	walkIdentifiers(n.ElseList, fn)
+}
+
+// templatePluginFunctions returns the contents of the template with the calls
+// to plugin functions rewritten, and the functions denied to it. The contents
+// of templates reading the source at src are only returned if rewritten. The
+// functions are called by the job of the allocation.
+func templatePluginFunctions(cc *config.ClientTemplateConfig, tmpl *structs.Template, src string, alloc *structs.Allocation) (string, []string, error) {
+	if len(cc.PluginFunctions) == 0 {
+		return tmpl.EmbeddedTmpl, cc.FunctionDenylist, nil
+	}
+	if alloc == nil {
+		return "", nil, fmt.Errorf("missing allocation to call plugin functions")
+	}
+
+	contents := tmpl.EmbeddedTmpl
+	if src != "" {
+		raw, err := os.ReadFile(src)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read template source: %v", err)
+		}
+		contents = string(raw)
+	}
+
+	rewritten, ok, err := rewritePluginFunctions(contents, tmpl.LeftDelim, tmpl.RightDelim,
+		cc.PluginFunctions, cc.FunctionDenylist, newPluginCaller(alloc))
+	if err != nil {
+		return "", nil, err
+	}
+	if !ok {
+		return tmpl.EmbeddedTmpl, cc.FunctionDenylist, nil
+	}
+
+	denylist := make([]string, 0, len(cc.FunctionDenylist))
+	for _, fn := range cc.FunctionDenylist {
+		if fn != consulTemplatePluginFunc {
+			denylist = append(denylist, fn)
+		}
+	}
+	return rewritten, denylist, nil
+}
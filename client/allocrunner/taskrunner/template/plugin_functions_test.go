@@ -0,0 +1,93 @@
+package template
+
+import (
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewritePluginFunctions(t *testing.T) {
+	ci.Parallel(t)
+
+	functions := []*config.TemplatePluginFunctionConfig{
+		{Name: "externalSecret", Command: "/usr/bin/external-secret", Args: []string{"--role", "web"}},
+		{Name: "token", Command: "/usr/bin/token"},
+		{Name: "prodToken", Command: "/usr/bin/token", Namespaces: []string{"prod"}},
+	}
+	denylist := []string{"plugin"}
+
+	// The commands are run through env, with the caller in their environment
+	caller := &pluginCaller{Namespace: "default", JobID: "web", AllocID: "0b1f8d6e"}
+	envCommand, err := exec.LookPath("env")
+	require.NoError(t, err)
+	plugin := fmt.Sprintf(`plugin %q "NOMAD_NAMESPACE=default" "NOMAD_JOB_ID=web" "NOMAD_ALLOC_ID=0b1f8d6e"`, envCommand)
+
+	cases := []struct {
+		name       string
+		contents   string
+		left       string
+		right      string
+		expected   string
+		unchanged  bool
+		errMessage string
+	}{
+		{
+			name:      "no plugin functions",
+			contents:  `{{ key "app/config" }} {{ "externalSecret" }}`,
+			unchanged: true,
+		},
+		{
+			name:     "calls",
+			contents: `a={{ externalSecret "db" }} b={{ "x" | token }}{{ if true }}{{ (token) | toUpper }}{{ end }}`,
+			expected: `a={{ ` + plugin + ` "/usr/bin/external-secret" "--role" "web" "db" }} b={{ "x" | ` + plugin + ` "/usr/bin/token" }}{{ if true }}{{ (` + plugin + ` "/usr/bin/token") | toUpper }}{{ end }}`,
+		},
+		{
+			name:     "defined templates and delimiters",
+			contents: `[[ define "t" ]][[ token ]][[ end ]]{{ token }}[[ template "t" ]]`,
+			left:     "[[",
+			right:    "]]",
+			expected: `[[ define "t" ]][[ ` + plugin + ` "/usr/bin/token" ]][[ end ]]{{ token }}[[ template "t" ]]`,
+		},
+		{
+			name:       "denied plugin",
+			contents:   `{{ token }}{{ plugin "/bin/sh" "-c" "id" }}`,
+			errMessage: `template calls the denied function "plugin"`,
+		},
+		{
+			name:       "function not allowed for the job",
+			contents:   `{{ prodToken }}`,
+			errMessage: `template calls the function "prodToken" not allowed for job "web" in namespace "default"`,
+		},
+		{
+			name:       "invalid template",
+			contents:   `{{ token `,
+			errMessage: "failed to parse template",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			out, rewritten, err := rewritePluginFunctions(c.contents, c.left, c.right, functions, denylist, caller)
+			if c.errMessage != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, !c.unchanged, rewritten)
+			if c.unchanged {
+				require.Equal(t, c.contents, out)
+			} else {
+				require.Equal(t, c.expected, out)
+			}
+		})
+	}
+
+	// Functions of the denylist can't be called
+	_, _, err = rewritePluginFunctions(`{{ token }}`, "", "", functions, []string{"token"}, caller)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `template calls the denied function "token"`)
+}
@@ -64,6 +64,16 @@ type TaskTemplateManager struct {
 	// shutdown marks whether the manager has been shutdown
 	shutdown     bool
 	shutdownLock sync.Mutex
+
+	// handle executes the change scripts of templates inside the task. It is
+	// nil until the task has started.
+	handle     interfaces.ScriptExecutor
+	handleLock sync.Mutex
+
+	// templateEnv is the last loaded environment of each env template with
+	// change mode script, used to find the variables that changed when the
+	// template is re-rendered.
+	templateEnv map[*structs.Template]map[string]string
 }
 
 // TaskTemplateManagerConfig is used to configure an instance of the
@@ -94,12 +104,21 @@ type TaskTemplateManagerConfig struct {
 	// VaultNamespace is the Vault namespace for the task
 	VaultNamespace string
 
+	// VaultCluster is the name of the Vault cluster the templates read from,
+	// or empty for the default cluster. VaultToken and VaultNamespace are
+	// the token and namespace of the task on that cluster.
+	VaultCluster string
+
 	// TaskDir is the task's directory
 	TaskDir string
 
 	// EnvBuilder is the environment variable builder for the task.
 	EnvBuilder *taskenv.Builder
 
+	// Alloc is the allocation of the task. Its job is the caller of the
+	// plugin functions of the templates.
+	Alloc *structs.Allocation
+
 	// MaxTemplateEventRate is the maximum rate at which we should emit events.
 	MaxTemplateEventRate time.Duration
 }
@@ -134,8 +153,9 @@ func NewTaskTemplateManager(config *TaskTemplateManagerConfig) (*TaskTemplateMan
 	}
 
 	tm := &TaskTemplateManager{
-		config:     config,
-		shutdownCh: make(chan struct{}),
+		config:      config,
+		shutdownCh:  make(chan struct{}),
+		templateEnv: make(map[*structs.Template]map[string]string),
 	}
 
 	// Parse the signals that we need
@@ -186,6 +206,22 @@ func (tm *TaskTemplateManager) Stop() {
 	}
 }
 
+// SetDriverHandle sets the executor used to run the change scripts of
+// templates inside the task once it has started.
+func (tm *TaskTemplateManager) SetDriverHandle(executor interfaces.ScriptExecutor) {
+	tm.handleLock.Lock()
+	defer tm.handleLock.Unlock()
+	tm.handle = executor
+}
+
+// driverHandle returns the executor of change scripts, or nil if the task
+// hasn't started.
+func (tm *TaskTemplateManager) driverHandle() interfaces.ScriptExecutor {
+	tm.handleLock.Lock()
+	defer tm.handleLock.Unlock()
+	return tm.handle
+}
+
 // run is the long lived loop that handles errors and templates being rendered
 func (tm *TaskTemplateManager) run() {
 	// Runner is nil if there are no templates
@@ -217,7 +253,17 @@ func (tm *TaskTemplateManager) run() {
 				SetDisplayMessage(fmt.Sprintf("Template failed to read environment variables: %v", err)))
 		return
 	}
-	tm.config.EnvBuilder.SetTemplateEnv(envMap)
+	tm.setTemplateEnv(envMap)
+
+	// Record the environment of the env templates with change scripts, so
+	// the variables changed by later renders can be passed to the scripts
+	if _, err := tm.changedEnvKeys(); err != nil {
+		tm.config.Lifecycle.Kill(context.Background(),
+			structs.NewTaskEvent(structs.TaskKilling).
+				SetFailsTask().
+				SetDisplayMessage(fmt.Sprintf("Template failed to read environment variables: %v", err)))
+		return
+	}
 
 	// Unblock the task
 	close(tm.config.UnblockCh)
@@ -386,6 +432,7 @@ func (tm *TaskTemplateManager) onTemplateRendered(handledRenders map[string]time
 
 	var handling []string
 	signals := make(map[string]struct{})
+	scripts := make(map[*structs.Template][]string)
 	restart := false
 	var splay time.Duration
 
@@ -422,7 +469,7 @@ func (tm *TaskTemplateManager) onTemplateRendered(handledRenders map[string]time
 					SetDisplayMessage(fmt.Sprintf("Template failed to read environment variables: %v", err)))
 			return
 		}
-		tm.config.EnvBuilder.SetTemplateEnv(envMap)
+		tm.setTemplateEnv(envMap)
 
 		for _, tmpl := range tmpls {
 			switch tmpl.ChangeMode {
@@ -430,6 +477,9 @@ func (tm *TaskTemplateManager) onTemplateRendered(handledRenders map[string]time
 				signals[tmpl.ChangeSignal] = struct{}{}
 			case structs.TemplateChangeModeRestart:
 				restart = true
+			case structs.TemplateChangeModeScript:
+				// The keys of env templates are the changed variables
+				scripts[tmpl] = []string{tmpl.DestPath}
 			case structs.TemplateChangeModeNoop:
 				continue
 			}
@@ -442,7 +492,24 @@ func (tm *TaskTemplateManager) onTemplateRendered(handledRenders map[string]time
 		handling = append(handling, id)
 	}
 
-	if restart || len(signals) != 0 {
+	// Find the variables changed by the env templates with change scripts
+	if len(scripts) != 0 {
+		changedEnv, err := tm.changedEnvKeys()
+		if err != nil {
+			tm.config.Lifecycle.Kill(context.Background(),
+				structs.NewTaskEvent(structs.TaskKilling).
+					SetFailsTask().
+					SetDisplayMessage(fmt.Sprintf("Template failed to read environment variables: %v", err)))
+			return
+		}
+		for tmpl := range scripts {
+			if tmpl.Envvars {
+				scripts[tmpl] = changedEnv[tmpl]
+			}
+		}
+	}
+
+	if restart || len(signals) != 0 || len(scripts) != 0 {
 		if splay != 0 {
 			ns := splay.Nanoseconds()
 			offset := rand.Int63n(ns)
@@ -484,10 +551,97 @@ func (tm *TaskTemplateManager) onTemplateRendered(handledRenders map[string]time
 					structs.NewTaskEvent(structs.TaskKilling).
 						SetFailsTask().
 						SetDisplayMessage(fmt.Sprintf("Template failed to send signals %v: %v", flat, err)))
+				return
 			}
 		}
+
+		// Run the change scripts, unless the task was restarted
+		if !restart && len(scripts) != 0 {
+			var wg sync.WaitGroup
+			for tmpl, keys := range scripts {
+				wg.Add(1)
+				go tm.processScript(tmpl.ChangeScript, keys, &wg)
+			}
+			wg.Wait()
+		}
+	}
+
+}
+
+// processScript runs the change script of a re-rendered template inside the
+// task, appending the changed keys to its arguments if requested. A failure
+// fails the task if the script requires it, and only emits an event
+// otherwise.
+func (tm *TaskTemplateManager) processScript(script *structs.ChangeScript, keys []string, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	args := script.Args
+	if script.PassChangedKeys {
+		args = append(helper.CopySliceString(script.Args), keys...)
+	}
+
+	var err error
+	if handle := tm.driverHandle(); handle == nil {
+		err = fmt.Errorf("task driver handle is not available")
+	} else {
+		var exitCode int
+		_, exitCode, err = handle.Exec(script.Timeout, script.Command, args)
+		if err == nil && exitCode != 0 {
+			err = fmt.Errorf("exited with code %d", exitCode)
+		}
+	}
+
+	if err == nil {
+		tm.config.Events.EmitEvent(structs.NewTaskEvent(consulTemplateSourceName).
+			SetDisplayMessage(fmt.Sprintf("Template ran change script %q", script.Command)))
+		return
+	}
+
+	msg := fmt.Sprintf("Template failed to run change script %q: %v", script.Command, err)
+	if script.FailOnError {
+		tm.config.Lifecycle.Kill(context.Background(),
+			structs.NewTaskEvent(structs.TaskKilling).
+				SetFailsTask().
+				SetDisplayMessage(msg))
+		return
 	}
+	tm.config.Events.EmitEvent(structs.NewTaskEvent(structs.TaskHookFailed).SetDisplayMessage(msg))
+}
+
+// changedEnvKeys reloads the environment of the env templates with change
+// mode script and returns the names of the variables each template changed,
+// added or removed since it was last loaded.
+func (tm *TaskTemplateManager) changedEnvKeys() (map[*structs.Template][]string, error) {
+	changed := make(map[*structs.Template][]string)
+	taskEnv := tm.config.EnvBuilder.Build()
+	for _, tmpl := range tm.config.Templates {
+		if !tmpl.Envvars || tmpl.ChangeMode != structs.TemplateChangeModeScript {
+			continue
+		}
+
+		vars, err := loadTemplateEnvFile(tmpl, taskEnv)
+		if err != nil {
+			return nil, err
+		}
 
+		old := tm.templateEnv[tmpl]
+		var keys []string
+		for k, v := range vars {
+			if oldV, ok := old[k]; !ok || oldV != v {
+				keys = append(keys, k)
+			}
+		}
+		for k := range old {
+			if _, ok := vars[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		changed[tmpl] = keys
+		tm.templateEnv[tmpl] = vars
+	}
+	return changed, nil
 }
 
 // allTemplatesNoop returns whether all the managed templates have change mode noop.
@@ -588,13 +742,22 @@ func parseTemplateConfigs(config *TaskTemplateManagerConfig) (map[*ctconf.Templa
 			}
 		}
 
+		contents, denylist, err := templatePluginFunctions(config.ClientConfig.TemplateConfig, tmpl, src, config.Alloc)
+		if err != nil {
+			return nil, err
+		}
+		if contents != tmpl.EmbeddedTmpl {
+			// The rewritten contents of the source replace it
+			src = ""
+		}
+
 		ct := ctconf.DefaultTemplateConfig()
 		ct.Source = &src
 		ct.Destination = &dest
-		ct.Contents = &tmpl.EmbeddedTmpl
+		ct.Contents = &contents
 		ct.LeftDelim = &tmpl.LeftDelim
 		ct.RightDelim = &tmpl.RightDelim
-		ct.FunctionDenylist = config.ClientConfig.TemplateConfig.FunctionDenylist
+		ct.FunctionDenylist = denylist
 		if sandboxEnabled {
 			ct.SandboxPath = &config.TaskDir
 		}
@@ -752,35 +915,45 @@ func newRunnerConfig(config *TaskTemplateManagerConfig,
 		conf.Consul.Namespace = &config.ConsulNamespace
 	}
 
-	// Set up the Vault config
-	// Always set these to ensure nothing is picked up from the environment
+	// Set up the Vault config, of the named Vault cluster if the templates
+	// read from one. Always set these to ensure nothing is picked up from the
+	// environment
+	vc := cc.VaultConfig
+	if config.VaultCluster != "" {
+		vc = nil
+		if cc.VaultConfig != nil {
+			if cluster := cc.VaultConfig.Cluster(config.VaultCluster); cluster != nil {
+				vc = cluster.Config()
+			}
+		}
+	}
 	emptyStr := ""
 	conf.Vault.RenewToken = helper.BoolToPtr(false)
 	conf.Vault.Token = &emptyStr
-	if cc.VaultConfig != nil && cc.VaultConfig.IsEnabled() {
-		conf.Vault.Address = &cc.VaultConfig.Addr
+	if vc != nil && vc.IsEnabled() {
+		conf.Vault.Address = &vc.Addr
 		conf.Vault.Token = &config.VaultToken
 
 		// Set the Vault Namespace. Passed in Task config has
 		// highest precedence.
-		if config.ClientConfig.VaultConfig.Namespace != "" {
-			conf.Vault.Namespace = &config.ClientConfig.VaultConfig.Namespace
+		if vc.Namespace != "" {
+			conf.Vault.Namespace = &vc.Namespace
 		}
 		if config.VaultNamespace != "" {
 			conf.Vault.Namespace = &config.VaultNamespace
 		}
 
-		if strings.HasPrefix(cc.VaultConfig.Addr, "https") || cc.VaultConfig.TLSCertFile != "" {
-			skipVerify := cc.VaultConfig.TLSSkipVerify != nil && *cc.VaultConfig.TLSSkipVerify
+		if strings.HasPrefix(vc.Addr, "https") || vc.TLSCertFile != "" {
+			skipVerify := vc.TLSSkipVerify != nil && *vc.TLSSkipVerify
 			verify := !skipVerify
 			conf.Vault.SSL = &ctconf.SSLConfig{
 				Enabled:    helper.BoolToPtr(true),
 				Verify:     &verify,
-				Cert:       &cc.VaultConfig.TLSCertFile,
-				Key:        &cc.VaultConfig.TLSKeyFile,
-				CaCert:     &cc.VaultConfig.TLSCaFile,
-				CaPath:     &cc.VaultConfig.TLSCaPath,
-				ServerName: &cc.VaultConfig.TLSServerName,
+				Cert:       &vc.TLSCertFile,
+				Key:        &vc.TLSKeyFile,
+				CaCert:     &vc.TLSCaFile,
+				CaPath:     &vc.TLSCaPath,
+				ServerName: &vc.TLSServerName,
 			}
 		} else {
 			conf.Vault.SSL = &ctconf.SSLConfig{
@@ -811,6 +984,17 @@ func newRunnerConfig(config *TaskTemplateManagerConfig,
 	return conf, nil
 }
 
+// setTemplateEnv sets the environment variables of the templates in the task
+// environment, separately from those of the templates reading from other
+// Vault clusters.
+func (tm *TaskTemplateManager) setTemplateEnv(envMap map[string]string) {
+	if tm.config.VaultCluster != "" {
+		tm.config.EnvBuilder.SetVaultClusterTemplateEnv(tm.config.VaultCluster, envMap)
+		return
+	}
+	tm.config.EnvBuilder.SetTemplateEnv(envMap)
+}
+
 // loadTemplateEnv loads task environment variables from all templates.
 func loadTemplateEnv(tmpls []*structs.Template, taskEnv *taskenv.TaskEnv) (map[string]string, error) {
 	all := make(map[string]string, 50)
@@ -819,18 +1003,9 @@ func loadTemplateEnv(tmpls []*structs.Template, taskEnv *taskenv.TaskEnv) (map[s
 			continue
 		}
 
-		// we checked escape before we rendered the file
-		dest, _ := taskEnv.ClientPath(t.DestPath, true)
-		f, err := os.Open(dest)
+		vars, err := loadTemplateEnvFile(t, taskEnv)
 		if err != nil {
-			return nil, fmt.Errorf("error opening env template: %v", err)
-		}
-		defer f.Close()
-
-		// Parse environment fil
-		vars, err := envparse.Parse(f)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing env template %q: %v", dest, err)
+			return nil, err
 		}
 		for k, v := range vars {
 			all[k] = v
@@ -838,3 +1013,21 @@ func loadTemplateEnv(tmpls []*structs.Template, taskEnv *taskenv.TaskEnv) (map[s
 	}
 	return all, nil
 }
+
+// loadTemplateEnvFile loads the environment variables of an env template.
+func loadTemplateEnvFile(t *structs.Template, taskEnv *taskenv.TaskEnv) (map[string]string, error) {
+	// we checked escape before we rendered the file
+	dest, _ := taskEnv.ClientPath(t.DestPath, true)
+	f, err := os.Open(dest)
+	if err != nil {
+		return nil, fmt.Errorf("error opening env template: %v", err)
+	}
+	defer f.Close()
+
+	// Parse environment fil
+	vars, err := envparse.Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing env template %q: %v", dest, err)
+	}
+	return vars, nil
+}
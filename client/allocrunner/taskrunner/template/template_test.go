@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
@@ -125,6 +126,7 @@ type testHarness struct {
 	mockHooks  *MockTaskHooks
 	templates  []*structs.Template
 	envBuilder *taskenv.Builder
+	alloc      *structs.Allocation
 	node       *structs.Node
 	config     *config.Config
 	vaultToken string
@@ -156,6 +158,7 @@ func newTestHarness(t *testing.T, templates []*structs.Template, consul, vault b
 	a := mock.Alloc()
 	task := a.Job.TaskGroups[0].Tasks[0]
 	task.Name = TestTaskName
+	harness.alloc = a
 	harness.envBuilder = taskenv.NewBuilder(harness.node, a, task, region)
 
 	// Make a tempdir
@@ -204,6 +207,7 @@ func (h *testHarness) startWithErr() error {
 		VaultToken:           h.vaultToken,
 		TaskDir:              h.taskDir,
 		EnvBuilder:           h.envBuilder,
+		Alloc:                h.alloc,
 		MaxTemplateEventRate: h.emitRate,
 	})
 
@@ -497,6 +501,74 @@ func TestTaskTemplateManager_Unblock_Static(t *testing.T) {
 	}
 }
 
+func TestTaskTemplateManager_PluginFunctions(t *testing.T) {
+	ci.Parallel(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("test uses echo")
+	}
+
+	file := "my.tmpl"
+	template := &structs.Template{
+		EmbeddedTmpl: `password={{ externalSecret "db" }}`,
+		DestPath:     file,
+		ChangeMode:   structs.TemplateChangeModeNoop,
+	}
+
+	harness := newTestHarness(t, []*structs.Template{template}, false, false)
+	harness.config.TemplateConfig.PluginFunctions = []*config.TemplatePluginFunctionConfig{{
+		Name:    "externalSecret",
+		Command: "/bin/sh",
+		Args:    []string{"-c", `echo "$NOMAD_NAMESPACE/$NOMAD_JOB_ID/$1"`, "sh"},
+	}}
+	harness.start(t)
+	defer harness.stop()
+
+	// Wait for the unblock
+	select {
+	case <-harness.mockHooks.UnblockCh:
+	case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+		t.Fatalf("Task unblock should have been called")
+	}
+
+	// The output of the command, which is given the caller in its
+	// environment, is rendered
+	env := harness.envBuilder.Build().EnvMap
+	raw, err := ioutil.ReadFile(filepath.Join(harness.taskDir, file))
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("password=%s/%s/db", env[taskenv.Namespace], env[taskenv.JobID]), string(raw))
+}
+
+func TestTaskTemplateManager_PluginFunctions_EnvOverride(t *testing.T) {
+	ci.Parallel(t)
+
+	template := &structs.Template{
+		EmbeddedTmpl: `password={{ externalSecret "db" }}`,
+		DestPath:     "my.tmpl",
+		ChangeMode:   structs.TemplateChangeModeNoop,
+	}
+
+	// The task overrides the job ID in its environment with the only job
+	// allowed to call the function
+	harness := newTestHarness(t, []*structs.Template{template}, false, false)
+	task := harness.alloc.Job.TaskGroups[0].Tasks[0]
+	task.Env = map[string]string{taskenv.JobID: "trusted"}
+	harness.envBuilder = taskenv.NewBuilder(harness.node, harness.alloc, task, harness.config.Region)
+	harness.envBuilder.SetClientTaskRoot(harness.taskDir)
+	require.Equal(t, "trusted", harness.envBuilder.Build().EnvMap[taskenv.JobID])
+
+	harness.config.TemplateConfig.PluginFunctions = []*config.TemplatePluginFunctionConfig{{
+		Name:    "externalSecret",
+		Command: "/usr/bin/external-secret",
+		Jobs:    []string{harness.alloc.Namespace + "/trusted"},
+	}}
+	defer harness.stop()
+
+	// The call is rejected, as the caller is the job of the allocation
+	err := harness.startWithErr()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), fmt.Sprintf(`not allowed for job %q`, harness.alloc.JobID))
+}
+
 func TestTaskTemplateManager_Permissions(t *testing.T) {
 	ci.Parallel(t)
 	// Make a template that will render immediately
@@ -1185,6 +1257,124 @@ func TestTaskTemplateManager_Signal_Error(t *testing.T) {
 	require.Contains(harness.mockHooks.KillEvent.DisplayMessage, "failed to send signals")
 }
 
+// mockExecutor is a mock of the driver handle executing change scripts
+type mockExecutor struct {
+	exitCode int
+	execCh   chan []string
+}
+
+func (m *mockExecutor) Exec(timeout time.Duration, cmd string, args []string) ([]byte, int, error) {
+	m.execCh <- append([]string{cmd}, args...)
+	return nil, m.exitCode, nil
+}
+
+func TestTaskTemplateManager_Rerender_Script(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	// Make a file template and an env template rendered from files in the
+	// task directory, which run scripts when re-rendered
+	t1 := &structs.Template{
+		DestPath:   "local/secret.conf",
+		ChangeMode: structs.TemplateChangeModeScript,
+		ChangeScript: &structs.ChangeScript{
+			Command:         "/bin/reload",
+			Args:            []string{"-q"},
+			Timeout:         5 * time.Second,
+			PassChangedKeys: true,
+		},
+	}
+	t2 := &structs.Template{
+		DestPath:   "local/secrets.env",
+		ChangeMode: structs.TemplateChangeModeScript,
+		Envvars:    true,
+		ChangeScript: &structs.ChangeScript{
+			Command:         "/bin/rotate",
+			Timeout:         5 * time.Second,
+			PassChangedKeys: true,
+		},
+	}
+
+	harness := newTestHarness(t, []*structs.Template{t1, t2}, false, false)
+	t1.EmbeddedTmpl = fmt.Sprintf(`{{ file "%s" }}`, filepath.Join(harness.taskDir, "secret.txt"))
+	t2.EmbeddedTmpl = fmt.Sprintf(`{{ file "%s" }}`, filepath.Join(harness.taskDir, "secrets.env"))
+	require.NoError(os.WriteFile(filepath.Join(harness.taskDir, "secret.txt"), []byte("s1"), 0600))
+	require.NoError(os.WriteFile(filepath.Join(harness.taskDir, "secrets.env"), []byte("USER=nomad\nPASSWORD=p1\n"), 0600))
+	harness.start(t)
+	defer harness.stop()
+
+	executor := &mockExecutor{execCh: make(chan []string, 2)}
+	harness.manager.SetDriverHandle(executor)
+
+	select {
+	case <-harness.mockHooks.UnblockCh:
+	case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+		t.Fatalf("Task unblock should have been called")
+	}
+
+	// Rotating the password passes the changed variable to the script
+	require.NoError(os.WriteFile(filepath.Join(harness.taskDir, "secrets.env"), []byte("USER=nomad\nPASSWORD=p2\n"), 0600))
+	select {
+	case args := <-executor.execCh:
+		require.Equal([]string{"/bin/rotate", "PASSWORD"}, args)
+	case <-harness.mockHooks.RestartCh:
+		t.Fatalf("Restart with script change mode: %+v", harness.mockHooks)
+	case <-time.After(time.Duration(10*testutil.TestMultiplier()) * time.Second):
+		t.Fatalf("Should have run the change script")
+	}
+
+	// Changing the file passes its destination to the script
+	require.NoError(os.WriteFile(filepath.Join(harness.taskDir, "secret.txt"), []byte("s2"), 0600))
+	select {
+	case args := <-executor.execCh:
+		require.Equal([]string{"/bin/reload", "-q", "local/secret.conf"}, args)
+	case <-time.After(time.Duration(10*testutil.TestMultiplier()) * time.Second):
+		t.Fatalf("Should have run the change script")
+	}
+	require.Equal([]string{"-q"}, t1.ChangeScript.Args)
+}
+
+func TestTaskTemplateManager_Script_Error(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	template := &structs.Template{
+		DestPath:   "local/secret.conf",
+		ChangeMode: structs.TemplateChangeModeScript,
+		ChangeScript: &structs.ChangeScript{
+			Command:     "/bin/reload",
+			Timeout:     5 * time.Second,
+			FailOnError: true,
+		},
+	}
+
+	harness := newTestHarness(t, []*structs.Template{template}, false, false)
+	template.EmbeddedTmpl = fmt.Sprintf(`{{ file "%s" }}`, filepath.Join(harness.taskDir, "secret.txt"))
+	require.NoError(os.WriteFile(filepath.Join(harness.taskDir, "secret.txt"), []byte("s1"), 0600))
+	harness.start(t)
+	defer harness.stop()
+
+	executor := &mockExecutor{exitCode: 1, execCh: make(chan []string, 1)}
+	harness.manager.SetDriverHandle(executor)
+
+	select {
+	case <-harness.mockHooks.UnblockCh:
+	case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+		t.Fatalf("Task unblock should have been called")
+	}
+
+	require.NoError(os.WriteFile(filepath.Join(harness.taskDir, "secret.txt"), []byte("s2"), 0600))
+	select {
+	case <-harness.mockHooks.KillCh:
+	case <-time.After(time.Duration(10*testutil.TestMultiplier()) * time.Second):
+		t.Fatalf("Should have killed the task: %+v", harness.mockHooks)
+	}
+
+	require.NotNil(harness.mockHooks.KillEvent)
+	require.Contains(harness.mockHooks.KillEvent.DisplayMessage, "failed to run change script")
+	require.Contains(harness.mockHooks.KillEvent.DisplayMessage, "exited with code 1")
+}
+
 // TestTaskTemplateManager_FiltersProcessEnvVars asserts that we only render
 // environment variables found in task env-vars and not read the nomad host
 // process environment variables.  nomad host process environment variables
@@ -37,6 +37,9 @@ type templateHookConfig struct {
 	// envBuilder is the environment variable builder for the task.
 	envBuilder *taskenv.Builder
 
+	// alloc is the allocation of the task
+	alloc *structs.Allocation
+
 	// consulNamespace is the current Consul namespace
 	consulNamespace string
 }
@@ -47,9 +50,12 @@ type templateHook struct {
 	// logger is used to log
 	logger log.Logger
 
-	// templateManager is used to manage any consul-templates this task may have
-	templateManager *template.TaskTemplateManager
-	managerLock     sync.Mutex
+	// templateManagers are used to manage any consul-templates this task may
+	// have. The templates reading from each named Vault cluster are managed
+	// separately, indexed by the name of the cluster, as a consul-template
+	// runner only connects to a single Vault cluster.
+	templateManagers map[string]*template.TaskTemplateManager
+	managerLock      sync.Mutex
 
 	// consulNamespace is the current Consul namespace
 	consulNamespace string
@@ -60,8 +66,19 @@ type templateHook struct {
 	// vaultNamespace is the current Vault namespace
 	vaultNamespace string
 
+	// vaultClusterTokens are the current Vault tokens of the named Vault
+	// clusters
+	vaultClusterTokens map[string]string
+
+	// vault is the vault block of the task
+	vault *structs.Vault
+
 	// taskDir is the task directory
 	taskDir string
+
+	// driverHandle executes the change scripts of templates inside the task.
+	// It is set once the task has started.
+	driverHandle ti.ScriptExecutor
 }
 
 func newTemplateHook(config *templateHookConfig) *templateHook {
@@ -81,54 +98,107 @@ func (h *templateHook) Prestart(ctx context.Context, req *interfaces.TaskPrestar
 	defer h.managerLock.Unlock()
 
 	// If we have already run prerun before exit early.
-	if h.templateManager != nil {
+	if h.templateManagers != nil {
 		return nil
 	}
 
-	// Store the current Vault token and the task directory
+	// Store the current Vault tokens and the task directory
 	h.taskDir = req.TaskDir.Dir
 	h.vaultToken = req.VaultToken
+	h.vaultClusterTokens = req.VaultClusterTokens
 
 	// Set vault namespace if specified
 	if req.Task.Vault != nil {
 		h.vaultNamespace = req.Task.Vault.Namespace
+		h.vault = req.Task.Vault
 	}
 
-	unblockCh, err := h.newManager()
-	if err != nil {
-		return err
+	h.templateManagers = make(map[string]*template.TaskTemplateManager)
+	var unblockChs []chan struct{}
+	for cluster := range h.templatesByCluster() {
+		unblockCh, err := h.newManager(cluster)
+		if err != nil {
+			return err
+		}
+		unblockChs = append(unblockChs, unblockCh)
 	}
 
-	// Wait for the template to render
-	select {
-	case <-ctx.Done():
-	case <-unblockCh:
+	// Wait for the templates to render
+	for _, unblockCh := range unblockChs {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-unblockCh:
+		}
 	}
 
 	return nil
 }
 
-func (h *templateHook) newManager() (unblock chan struct{}, err error) {
+// templatesByCluster returns the templates of the task, indexed by the name
+// of the Vault cluster they read from.
+func (h *templateHook) templatesByCluster() map[string][]*structs.Template {
+	byCluster := make(map[string][]*structs.Template)
+	for _, tmpl := range h.config.templates {
+		byCluster[tmpl.VaultCluster] = append(byCluster[tmpl.VaultCluster], tmpl)
+	}
+	return byCluster
+}
+
+// Poststart passes the driver handle of the started task to the template
+// manager, which uses it to run change scripts.
+func (h *templateHook) Poststart(ctx context.Context, req *interfaces.TaskPoststartRequest, resp *interfaces.TaskPoststartResponse) error {
+	h.managerLock.Lock()
+	defer h.managerLock.Unlock()
+
+	if req.DriverExec == nil {
+		return nil
+	}
+
+	h.driverHandle = req.DriverExec
+	for _, m := range h.templateManagers {
+		m.SetDriverHandle(h.driverHandle)
+	}
+	return nil
+}
+
+// newManager creates the manager of the templates reading from the named
+// Vault cluster, or from the default cluster if the name is empty.
+func (h *templateHook) newManager(cluster string) (unblock chan struct{}, err error) {
+	vaultToken, vaultNamespace := h.vaultToken, h.vaultNamespace
+	if cluster != "" {
+		vaultToken, vaultNamespace = h.vaultClusterTokens[cluster], ""
+		if c := h.vault.Cluster(cluster); c != nil {
+			vaultNamespace = c.Namespace
+		}
+	}
+
 	unblock = make(chan struct{})
 	m, err := template.NewTaskTemplateManager(&template.TaskTemplateManagerConfig{
 		UnblockCh:            unblock,
 		Lifecycle:            h.config.lifecycle,
 		Events:               h.config.events,
-		Templates:            h.config.templates,
+		Templates:            h.templatesByCluster()[cluster],
 		ClientConfig:         h.config.clientConfig,
 		ConsulNamespace:      h.config.consulNamespace,
-		VaultToken:           h.vaultToken,
-		VaultNamespace:       h.vaultNamespace,
+		VaultToken:           vaultToken,
+		VaultNamespace:       vaultNamespace,
+		VaultCluster:         cluster,
 		TaskDir:              h.taskDir,
 		EnvBuilder:           h.config.envBuilder,
+		Alloc:                h.config.alloc,
 		MaxTemplateEventRate: template.DefaultMaxTemplateEventRate,
 	})
 	if err != nil {
-		h.logger.Error("failed to create template manager", "error", err)
+		h.logger.Error("failed to create template manager", "vault_cluster", cluster, "error", err)
 		return nil, err
 	}
 
-	h.templateManager = m
+	if h.driverHandle != nil {
+		m.SetDriverHandle(h.driverHandle)
+	}
+
+	h.templateManagers[cluster] = m
 	return unblock, nil
 }
 
@@ -137,42 +207,51 @@ func (h *templateHook) Stop(ctx context.Context, req *interfaces.TaskStopRequest
 	defer h.managerLock.Unlock()
 
 	// Shutdown any created template
-	if h.templateManager != nil {
-		h.templateManager.Stop()
+	for _, m := range h.templateManagers {
+		m.Stop()
 	}
 
 	return nil
 }
 
-// Handle new Vault token
+// Handle new Vault tokens
 func (h *templateHook) Update(ctx context.Context, req *interfaces.TaskUpdateRequest, resp *interfaces.TaskUpdateResponse) error {
 	h.managerLock.Lock()
 	defer h.managerLock.Unlock()
 
 	// Nothing to do
-	if h.templateManager == nil {
+	if h.templateManagers == nil {
 		return nil
 	}
 
-	// Check if the Vault token has changed
-	if req.VaultToken == h.vaultToken {
-		return nil
-	} else {
-		h.vaultToken = req.VaultToken
+	// Find the managers whose Vault token has changed
+	var changed []string
+	for cluster := range h.templateManagers {
+		if cluster == "" && req.VaultToken != h.vaultToken {
+			changed = append(changed, cluster)
+		}
+		if cluster != "" && req.VaultClusterTokens[cluster] != h.vaultClusterTokens[cluster] {
+			changed = append(changed, cluster)
+		}
 	}
-
-	// Shutdown the old template
-	h.templateManager.Stop()
-	h.templateManager = nil
-
-	// Create the new template
-	if _, err := h.newManager(); err != nil {
-		err := fmt.Errorf("failed to build template manager: %v", err)
-		h.logger.Error("failed to build template manager", "error", err)
-		h.config.lifecycle.Kill(context.Background(),
-			structs.NewTaskEvent(structs.TaskKilling).
-				SetFailsTask().
-				SetDisplayMessage(fmt.Sprintf("Template update %v", err)))
+	h.vaultToken = req.VaultToken
+	h.vaultClusterTokens = req.VaultClusterTokens
+
+	for _, cluster := range changed {
+		// Shutdown the old template
+		h.templateManagers[cluster].Stop()
+		delete(h.templateManagers, cluster)
+
+		// Create the new template
+		if _, err := h.newManager(cluster); err != nil {
+			err := fmt.Errorf("failed to build template manager: %v", err)
+			h.logger.Error("failed to build template manager", "vault_cluster", cluster, "error", err)
+			h.config.lifecycle.Kill(context.Background(),
+				structs.NewTaskEvent(structs.TaskKilling).
+					SetFailsTask().
+					SetDisplayMessage(fmt.Sprintf("Template update %v", err)))
+			break
+		}
 	}
 
 	return nil
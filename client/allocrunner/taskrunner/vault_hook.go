@@ -34,6 +34,7 @@ const (
 
 type vaultTokenUpdateHandler interface {
 	updatedVaultToken(token string)
+	updatedVaultClusterToken(cluster, token string)
 }
 
 func (tr *TaskRunner) updatedVaultToken(token string) {
@@ -44,8 +45,17 @@ func (tr *TaskRunner) updatedVaultToken(token string) {
 	tr.triggerUpdateHooks()
 }
 
+func (tr *TaskRunner) updatedVaultClusterToken(cluster, token string) {
+	// Update the environment
+	tr.setVaultClusterToken(cluster, token)
+
+	// Trigger update hooks with the new Vault token
+	tr.triggerUpdateHooks()
+}
+
 type vaultHookConfig struct {
 	vaultStanza *structs.Vault
+	cluster     string
 	client      vaultclient.VaultClient
 	events      ti.EventEmitter
 	lifecycle   ti.TaskLifecycle
@@ -59,6 +69,10 @@ type vaultHook struct {
 	// vaultStanza is the vault stanza for the task
 	vaultStanza *structs.Vault
 
+	// cluster is the name of the Vault cluster to retrieve the token from, or
+	// empty for the default cluster
+	cluster string
+
 	// eventEmitter is used to emit events to the task
 	eventEmitter ti.EventEmitter
 
@@ -98,6 +112,7 @@ func newVaultHook(config *vaultHookConfig) *vaultHook {
 	ctx, cancel := context.WithCancel(context.Background())
 	h := &vaultHook{
 		vaultStanza:  config.vaultStanza,
+		cluster:      config.cluster,
 		client:       config.client,
 		eventEmitter: config.events,
 		lifecycle:    config.lifecycle,
@@ -113,13 +128,38 @@ func newVaultHook(config *vaultHookConfig) *vaultHook {
 	return h
 }
 
-func (*vaultHook) Name() string {
+func (h *vaultHook) Name() string {
+	if h.cluster != "" {
+		return "vault_" + h.cluster
+	}
 	return "vault"
 }
 
+// tokenFile returns the name of the file holding the Vault token inside the
+// task's secret directory.
+func (h *vaultHook) tokenFile() string {
+	if h.cluster != "" {
+		return vaultTokenFile + "_" + h.cluster
+	}
+	return vaultTokenFile
+}
+
+// updatedToken updates the task with the Vault token.
+func (h *vaultHook) updatedToken(token string) {
+	if h.cluster != "" {
+		h.updater.updatedVaultClusterToken(h.cluster, token)
+		return
+	}
+	h.updater.updatedVaultToken(token)
+}
+
 func (h *vaultHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
 	// If we have already run prestart before exit early. We do not use the
 	// PrestartDone value because we want to recover the token on restoration.
+	if h.client == nil {
+		return fmt.Errorf("Vault cluster %q is not configured on the client", h.cluster)
+	}
+
 	first := h.firstRun
 	h.firstRun = false
 	if !first {
@@ -129,7 +169,7 @@ func (h *vaultHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRe
 	// Try to recover a token if it was previously written in the secrets
 	// directory
 	recoveredToken := ""
-	h.tokenPath = filepath.Join(req.TaskDir.SecretsDir, vaultTokenFile)
+	h.tokenPath = filepath.Join(req.TaskDir.SecretsDir, h.tokenFile())
 	data, err := ioutil.ReadFile(h.tokenPath)
 	if err != nil {
 		if !os.IsNotExist(err) {
@@ -152,7 +192,7 @@ func (h *vaultHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRe
 		return nil
 	}
 
-	h.updater.updatedVaultToken(h.future.Get())
+	h.updatedToken(h.future.Get())
 	return nil
 }
 
@@ -275,7 +315,7 @@ OUTER:
 			updatedToken = false
 
 			// Call the handler
-			h.updater.updatedVaultToken(token)
+			h.updatedToken(token)
 		}
 
 		// Start watching for renewal errors
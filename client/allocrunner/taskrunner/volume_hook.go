@@ -105,8 +105,27 @@ func partitionVolumesByType(xs map[string]*structs.VolumeRequest) map[string]map
 	return result
 }
 
+// hostVolumeSources returns the host volume requests with the source chosen by
+// the scheduler for the requests with alternative sources.
+func hostVolumeSources(alloc *structs.Allocation, volumes map[string]*structs.VolumeRequest) map[string]*structs.VolumeRequest {
+	if len(alloc.HostVolumeSources) == 0 {
+		return volumes
+	}
+
+	result := make(map[string]*structs.VolumeRequest, len(volumes))
+	for name, req := range volumes {
+		if source := alloc.HostVolumeSource(name, req); source != req.Source {
+			req = req.Copy()
+			req.Source = source
+		}
+		result[name] = req
+	}
+	return result
+}
+
 func (h *volumeHook) prepareHostVolumes(req *interfaces.TaskPrestartRequest, volumes map[string]*structs.VolumeRequest) ([]*drivers.MountConfig, error) {
 	hostVolumes := h.runner.clientConfig.Node.HostVolumes
+	volumes = hostVolumeSources(h.alloc, volumes)
 
 	// Always validate volumes to ensure that we do not allow volumes to be used
 	// if a host is restarted and loses the host volume configuration.
@@ -70,6 +70,25 @@ func TestVolumeHook_PartitionMountsByVolume_Works(t *testing.T) {
 	require.Equal(t, map[string][]*structs.VolumeMount{}, partitioned)
 }
 
+func TestVolumeHook_HostVolumeSources(t *testing.T) {
+	ci.Parallel(t)
+
+	volumes := map[string]*structs.VolumeRequest{
+		"data": {Type: structs.VolumeTypeHost, Source: "fast-ssd", AlternativeSources: []string{"bulk"}},
+		"logs": {Type: structs.VolumeTypeHost, Source: "logs"},
+	}
+
+	// Without sources chosen by the scheduler, the requests are unchanged
+	alloc := mock.Alloc()
+	require.Equal(t, volumes, hostVolumeSources(alloc, volumes))
+
+	alloc.HostVolumeSources = map[string]string{"data": "bulk"}
+	result := hostVolumeSources(alloc, volumes)
+	require.Equal(t, "bulk", result["data"].Source)
+	require.Equal(t, "logs", result["logs"].Source)
+	require.Equal(t, "fast-ssd", volumes["data"].Source, "requests of the job must not be modified")
+}
+
 func TestVolumeHook_prepareCSIVolumes(t *testing.T) {
 	ci.Parallel(t)
 
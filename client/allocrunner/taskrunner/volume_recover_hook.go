@@ -0,0 +1,349 @@
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
+	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// volumeRecoverProbeInterval is the interval at which the volumes
+	// mounted by a task are probed.
+	volumeRecoverProbeInterval = 10 * time.Second
+
+	// volumeRecoverSignal is the signal sent to the task when a volume
+	// mounted with on_recover = "signal" recovers.
+	volumeRecoverSignal = "SIGHUP"
+)
+
+type volumeRecoverHookConfig struct {
+	alloc       *structs.Allocation
+	task        *structs.Task
+	hostVolumes map[string]*structs.ClientHostVolumeConfig
+	csiMounts   func() map[string]*csimanager.MountInfo
+	lifecycle   ti.TaskLifecycle
+	events      ti.EventEmitter
+	logger      log.Logger
+}
+
+// watchedVolume is a volume mounted by the task whose availability is probed.
+type watchedVolume struct {
+	alias   string
+	path    string
+	action  string
+	healthy bool
+}
+
+// volumeRecoverHook probes the host and CSI volumes mounted by a task and,
+// when a volume that became unavailable recovers, applies the on_recover
+// action of its volume mounts.
+type volumeRecoverHook struct {
+	alloc       *structs.Allocation
+	task        *structs.Task
+	hostVolumes map[string]*structs.ClientHostVolumeConfig
+	csiMounts   func() map[string]*csimanager.MountInfo
+	lifecycle   ti.TaskLifecycle
+	events      ti.EventEmitter
+	logger      log.Logger
+
+	// interval is the probe interval, which is also used as the probe
+	// timeout.
+	interval time.Duration
+
+	// probe returns an error if the volume at the given path is
+	// unavailable.
+	probe func(path string) error
+
+	// probes are the results of the probes that timed out and haven't
+	// returned yet, by path. Such probes are likely blocked in a syscall on a
+	// hung mount, so they're waited on instead of starting new ones that
+	// would each pin an OS thread.
+	probes     map[string]chan error
+	probesLock sync.Mutex
+
+	// cancel is called by Exited
+	cancel context.CancelFunc
+
+	mu sync.Mutex
+}
+
+func newVolumeRecoverHook(config *volumeRecoverHookConfig) *volumeRecoverHook {
+	h := &volumeRecoverHook{
+		alloc:       config.alloc,
+		task:        config.task,
+		hostVolumes: config.hostVolumes,
+		csiMounts:   config.csiMounts,
+		lifecycle:   config.lifecycle,
+		events:      config.events,
+		interval:    volumeRecoverProbeInterval,
+		probe:       probeVolumePath,
+		probes:      make(map[string]chan error),
+	}
+	h.logger = config.logger.Named(h.Name())
+	return h
+}
+
+func (*volumeRecoverHook) Name() string {
+	return "volume_recover"
+}
+
+// hasVolumeRecoverActions returns true if any of the volume mounts of the task
+// has an on_recover action.
+func hasVolumeRecoverActions(task *structs.Task) bool {
+	for _, m := range task.VolumeMounts {
+		if volumeRecoverAction(m.OnRecover) != structs.VolumeMountOnRecoverNoop {
+			return true
+		}
+	}
+	return false
+}
+
+// volumeRecoverAction returns the normalized on_recover action.
+func volumeRecoverAction(onRecover string) string {
+	switch onRecover {
+	case structs.VolumeMountOnRecoverRestartTask, structs.VolumeMountOnRecoverSignal:
+		return onRecover
+	default:
+		return structs.VolumeMountOnRecoverNoop
+	}
+}
+
+func (h *volumeRecoverHook) Poststart(_ context.Context, _ *interfaces.TaskPoststartRequest, _ *interfaces.TaskPoststartResponse) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// This shouldn't happen, but better safe than risk leaking a goroutine
+	if h.cancel != nil {
+		h.logger.Debug("poststart called twice without exiting between")
+		h.cancel()
+	}
+
+	volumes := h.watchedVolumes()
+	if len(volumes) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	go h.watch(ctx, volumes)
+
+	return nil
+}
+
+func (h *volumeRecoverHook) Exited(context.Context, *interfaces.TaskExitedRequest, *interfaces.TaskExitedResponse) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cancel == nil {
+		return nil
+	}
+
+	h.cancel()
+	h.cancel = nil
+	return nil
+}
+
+func (h *volumeRecoverHook) Shutdown() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cancel == nil {
+		return
+	}
+
+	h.cancel()
+}
+
+// watchedVolumes returns the volumes mounted by the task with an on_recover
+// action, along with the host path they are mounted from. If a volume is
+// mounted several times, restarting the task takes precedence over
+// signaling it.
+func (h *volumeRecoverHook) watchedVolumes() []*watchedVolume {
+	tg := h.alloc.Job.LookupTaskGroup(h.alloc.TaskGroup)
+	if tg == nil {
+		return nil
+	}
+
+	var csiMounts map[string]*csimanager.MountInfo
+	if h.csiMounts != nil {
+		csiMounts = h.csiMounts()
+	}
+
+	byAlias := make(map[string]*watchedVolume)
+	var volumes []*watchedVolume
+	for _, m := range h.task.VolumeMounts {
+		action := volumeRecoverAction(m.OnRecover)
+		if action == structs.VolumeMountOnRecoverNoop {
+			continue
+		}
+
+		if v, ok := byAlias[m.Volume]; ok {
+			if action == structs.VolumeMountOnRecoverRestartTask {
+				v.action = action
+			}
+			continue
+		}
+
+		req, ok := tg.Volumes[m.Volume]
+		if !ok {
+			continue
+		}
+
+		var path string
+		switch req.Type {
+		case structs.VolumeTypeHost:
+			if hostVolume, ok := h.hostVolumes[h.alloc.HostVolumeSource(m.Volume, req)]; ok {
+				path = hostVolume.Path
+			}
+		case structs.VolumeTypeCSI:
+			if mountInfo, ok := csiMounts[m.Volume]; ok {
+				path = mountInfo.Source
+			}
+		}
+		if path == "" {
+			h.logger.Warn("unable to find host path of volume, not probing it", "volume", m.Volume)
+			continue
+		}
+
+		v := &watchedVolume{
+			alias:   m.Volume,
+			path:    path,
+			action:  action,
+			healthy: true,
+		}
+		byAlias[m.Volume] = v
+		volumes = append(volumes, v)
+	}
+
+	return volumes
+}
+
+// watch probes the volumes until the context is canceled or the task is
+// restarted.
+func (h *volumeRecoverHook) watch(ctx context.Context, volumes []*watchedVolume) {
+	timer := time.NewTimer(h.interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		for _, v := range volumes {
+			err := h.probeWithTimeout(v.path)
+			if ctx.Err() != nil {
+				return
+			}
+
+			switch {
+			case err != nil && v.healthy:
+				v.healthy = false
+				h.logger.Warn("volume unavailable", "volume", v.alias, "path", v.path, "error", err)
+				h.events.EmitEvent(structs.NewTaskEvent(structs.TaskVolumeUnavailable).
+					SetMessage(fmt.Sprintf("Volume %q unavailable: %v", v.alias, err)))
+
+			case err == nil && !v.healthy:
+				v.healthy = true
+				h.logger.Info("volume recovered", "volume", v.alias, "path", v.path, "on_recover", v.action)
+				h.events.EmitEvent(structs.NewTaskEvent(structs.TaskVolumeRecovered).
+					SetMessage(fmt.Sprintf("Volume %q recovered", v.alias)))
+
+				if h.recover(v) {
+					// The task is being restarted; Poststart starts a new
+					// watcher once it is running again.
+					return
+				}
+			}
+		}
+
+		timer.Reset(h.interval)
+	}
+}
+
+// recover applies the on_recover action of a recovered volume and returns
+// true if the task was restarted.
+func (h *volumeRecoverHook) recover(v *watchedVolume) bool {
+	reason := fmt.Sprintf("Volume %q recovered", v.alias)
+
+	switch v.action {
+	case structs.VolumeMountOnRecoverRestartTask:
+		event := structs.NewTaskEvent(structs.TaskRestartSignal).SetRestartReason(reason)
+		if err := h.lifecycle.Restart(context.Background(), event, false); err != nil {
+			h.logger.Error("failed to restart task after volume recovered", "volume", v.alias, "error", err)
+			return false
+		}
+		return true
+
+	case structs.VolumeMountOnRecoverSignal:
+		event := structs.NewTaskEvent(structs.TaskSignaling).
+			SetTaskSignal(syscall.SIGHUP).
+			SetTaskSignalReason(reason)
+		if err := h.lifecycle.Signal(event, volumeRecoverSignal); err != nil {
+			h.logger.Error("failed to signal task after volume recovered", "volume", v.alias, "error", err)
+		}
+	}
+
+	return false
+}
+
+// probeWithTimeout probes the volume at the given path, treating probes that
+// don't return within the probe interval, such as on hung network mounts, as
+// failures. A new probe isn't started while the previous probe of the path
+// is still outstanding, its result is waited on again instead.
+func (h *volumeRecoverHook) probeWithTimeout(path string) error {
+	h.probesLock.Lock()
+	errCh, ok := h.probes[path]
+	if !ok {
+		errCh = make(chan error, 1)
+		h.probes[path] = errCh
+		go func() {
+			errCh <- h.probe(path)
+		}()
+	}
+	h.probesLock.Unlock()
+
+	select {
+	case err := <-errCh:
+		h.probesLock.Lock()
+		delete(h.probes, path)
+		h.probesLock.Unlock()
+		return err
+	case <-time.After(h.interval):
+		return fmt.Errorf("timed out probing %s", path)
+	}
+}
+
+// probeVolumePath returns an error if the volume mounted at path is
+// unavailable. Directories are also read to detect stale network mounts.
+func probeVolumePath(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.Readdirnames(1); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
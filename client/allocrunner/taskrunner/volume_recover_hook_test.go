@@ -0,0 +1,242 @@
+package taskrunner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// Statically assert the volume recover hook implements the expected interfaces
+var _ interfaces.TaskPoststartHook = (*volumeRecoverHook)(nil)
+var _ interfaces.TaskExitedHook = (*volumeRecoverHook)(nil)
+var _ interfaces.ShutdownHook = (*volumeRecoverHook)(nil)
+
+// mockVolumeRecoverLifecycle records the restarts, signals and events of the
+// task.
+type mockVolumeRecoverLifecycle struct {
+	restartCh chan *structs.TaskEvent
+	signalCh  chan string
+	eventCh   chan *structs.TaskEvent
+}
+
+func newMockVolumeRecoverLifecycle() *mockVolumeRecoverLifecycle {
+	return &mockVolumeRecoverLifecycle{
+		restartCh: make(chan *structs.TaskEvent, 1),
+		signalCh:  make(chan string, 1),
+		eventCh:   make(chan *structs.TaskEvent, 10),
+	}
+}
+
+func (m *mockVolumeRecoverLifecycle) Restart(_ context.Context, event *structs.TaskEvent, _ bool) error {
+	m.restartCh <- event
+	return nil
+}
+func (m *mockVolumeRecoverLifecycle) Signal(_ *structs.TaskEvent, s string) error {
+	m.signalCh <- s
+	return nil
+}
+func (m *mockVolumeRecoverLifecycle) Kill(context.Context, *structs.TaskEvent) error { return nil }
+func (m *mockVolumeRecoverLifecycle) IsRunning() bool                                { return true }
+func (m *mockVolumeRecoverLifecycle) EmitEvent(event *structs.TaskEvent) {
+	m.eventCh <- event
+}
+
+// testVolumeRecoverHook returns a hook watching a host volume, whose probe
+// fails while unavailable is set.
+func testVolumeRecoverHook(t *testing.T, onRecover string) (*volumeRecoverHook, *mockVolumeRecoverLifecycle, *int32) {
+	alloc := mock.Alloc()
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	tg.Volumes = map[string]*structs.VolumeRequest{
+		"data": {Name: "data", Type: structs.VolumeTypeHost, Source: "shared"},
+	}
+	task := tg.Tasks[0]
+	task.VolumeMounts = []*structs.VolumeMount{
+		{Volume: "data", Destination: "/data", OnRecover: onRecover},
+	}
+
+	lifecycle := newMockVolumeRecoverLifecycle()
+	h := newVolumeRecoverHook(&volumeRecoverHookConfig{
+		alloc: alloc,
+		task:  task,
+		hostVolumes: map[string]*structs.ClientHostVolumeConfig{
+			"shared": {Name: "shared", Path: t.TempDir()},
+		},
+		lifecycle: lifecycle,
+		events:    lifecycle,
+		logger:    testlog.HCLogger(t),
+	})
+	h.interval = 10 * time.Millisecond
+
+	var unavailable int32
+	h.probe = func(string) error {
+		if atomic.LoadInt32(&unavailable) == 1 {
+			return errors.New("stale file handle")
+		}
+		return nil
+	}
+
+	return h, lifecycle, &unavailable
+}
+
+func TestVolumeRecoverHook_RestartTask(t *testing.T) {
+	ci.Parallel(t)
+
+	h, lifecycle, unavailable := testVolumeRecoverHook(t, structs.VolumeMountOnRecoverRestartTask)
+	require.NoError(t, h.Poststart(context.Background(), &interfaces.TaskPoststartRequest{}, nil))
+	defer h.Shutdown()
+
+	// The volume becomes unavailable
+	atomic.StoreInt32(unavailable, 1)
+	select {
+	case event := <-lifecycle.eventCh:
+		require.Equal(t, structs.TaskVolumeUnavailable, event.Type)
+		require.Contains(t, event.Message, "stale file handle")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for unavailable event")
+	}
+
+	// Then recovers
+	atomic.StoreInt32(unavailable, 0)
+	select {
+	case event := <-lifecycle.eventCh:
+		require.Equal(t, structs.TaskVolumeRecovered, event.Type)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for recovered event")
+	}
+
+	select {
+	case event := <-lifecycle.restartCh:
+		require.Equal(t, structs.TaskRestartSignal, event.Type)
+		require.Equal(t, `Volume "data" recovered`, event.RestartReason)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for restart")
+	}
+}
+
+func TestVolumeRecoverHook_Signal(t *testing.T) {
+	ci.Parallel(t)
+
+	h, lifecycle, unavailable := testVolumeRecoverHook(t, structs.VolumeMountOnRecoverSignal)
+	require.NoError(t, h.Poststart(context.Background(), &interfaces.TaskPoststartRequest{}, nil))
+	defer h.Shutdown()
+
+	atomic.StoreInt32(unavailable, 1)
+	select {
+	case event := <-lifecycle.eventCh:
+		require.Equal(t, structs.TaskVolumeUnavailable, event.Type)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for unavailable event")
+	}
+
+	atomic.StoreInt32(unavailable, 0)
+	select {
+	case s := <-lifecycle.signalCh:
+		require.Equal(t, "SIGHUP", s)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for signal")
+	}
+	require.Len(t, lifecycle.restartCh, 0)
+}
+
+func TestVolumeRecoverHook_WatchedVolumes(t *testing.T) {
+	ci.Parallel(t)
+
+	hostPath := t.TempDir()
+	csiPath := t.TempDir()
+
+	alloc := mock.Alloc()
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	tg.Volumes = map[string]*structs.VolumeRequest{
+		"host":    {Name: "host", Type: structs.VolumeTypeHost, Source: "shared"},
+		"csi":     {Name: "csi", Type: structs.VolumeTypeCSI, Source: "vol"},
+		"missing": {Name: "missing", Type: structs.VolumeTypeHost, Source: "missing"},
+		"noop":    {Name: "noop", Type: structs.VolumeTypeHost, Source: "shared"},
+	}
+	task := tg.Tasks[0]
+	task.VolumeMounts = []*structs.VolumeMount{
+		{Volume: "host", Destination: "/a", OnRecover: structs.VolumeMountOnRecoverSignal},
+		{Volume: "host", Destination: "/b", OnRecover: structs.VolumeMountOnRecoverRestartTask},
+		{Volume: "csi", Destination: "/c", OnRecover: structs.VolumeMountOnRecoverSignal},
+		{Volume: "missing", Destination: "/d", OnRecover: structs.VolumeMountOnRecoverSignal},
+		{Volume: "noop", Destination: "/e"},
+	}
+	require.True(t, hasVolumeRecoverActions(task))
+
+	h := newVolumeRecoverHook(&volumeRecoverHookConfig{
+		alloc: alloc,
+		task:  task,
+		hostVolumes: map[string]*structs.ClientHostVolumeConfig{
+			"shared": {Name: "shared", Path: hostPath},
+		},
+		csiMounts: func() map[string]*csimanager.MountInfo {
+			return map[string]*csimanager.MountInfo{
+				"csi": {Source: csiPath},
+			}
+		},
+		logger: testlog.HCLogger(t),
+	})
+
+	volumes := h.watchedVolumes()
+	require.Equal(t, []*watchedVolume{
+		{alias: "host", path: hostPath, action: structs.VolumeMountOnRecoverRestartTask, healthy: true},
+		{alias: "csi", path: csiPath, action: structs.VolumeMountOnRecoverSignal, healthy: true},
+	}, volumes)
+
+	task.VolumeMounts = task.VolumeMounts[4:]
+	require.False(t, hasVolumeRecoverActions(task))
+}
+
+func TestVolumeRecoverHook_ProbeWithTimeout(t *testing.T) {
+	ci.Parallel(t)
+
+	h, _, _ := testVolumeRecoverHook(t, structs.VolumeMountOnRecoverRestartTask)
+
+	// The probe blocks until released, like on a hung mount
+	var calls int32
+	release := make(chan struct{})
+	h.probe = func(string) error {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return nil
+	}
+
+	// Probes time out while the mount hangs, without starting new ones
+	for i := 0; i < 3; i++ {
+		require.EqualError(t, h.probeWithTimeout("/data"), "timed out probing /data")
+	}
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+
+	// Once the outstanding probe returns its result is used, and the next
+	// probe starts a new one
+	close(release)
+	succeeds := func() bool { return h.probeWithTimeout("/data") == nil }
+	require.Eventually(t, succeeds, time.Second, time.Millisecond)
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	require.Eventually(t, succeeds, time.Second, time.Millisecond)
+	require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+}
+
+func TestVolumeRecoverHook_ProbeVolumePath(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	require.NoError(t, probeVolumePath(dir))
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, []byte("x"), 0600))
+	require.NoError(t, probeVolumePath(file))
+
+	require.Error(t, probeVolumePath(filepath.Join(dir, "missing")))
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/hashicorp/nomad/client/allocrunner"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	arstate "github.com/hashicorp/nomad/client/allocrunner/state"
+	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/getter"
 	"github.com/hashicorp/nomad/client/allocwatcher"
 	"github.com/hashicorp/nomad/client/config"
 	consulApi "github.com/hashicorp/nomad/client/consul"
@@ -38,6 +39,7 @@ import (
 	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
+	"github.com/hashicorp/nomad/client/pluginmanager/taskhookmanager"
 	"github.com/hashicorp/nomad/client/servers"
 	"github.com/hashicorp/nomad/client/state"
 	"github.com/hashicorp/nomad/client/stats"
@@ -45,6 +47,7 @@ import (
 	"github.com/hashicorp/nomad/client/vaultclient"
 	"github.com/hashicorp/nomad/command/agent/consul"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/labelguard"
 	"github.com/hashicorp/nomad/helper/pool"
 	hstats "github.com/hashicorp/nomad/helper/stats"
 	"github.com/hashicorp/nomad/helper/tlsutil"
@@ -201,6 +204,18 @@ type Client struct {
 	// update it.
 	triggerNodeUpdate chan struct{}
 
+	// metaStatic is the Node metadata set in the client configuration and
+	// metaDynamic the metadata set via the NodeMeta endpoint, which is merged
+	// into it. Both are protected by configLock.
+	metaStatic  map[string]string
+	metaDynamic map[string]*string
+
+	// metaIndex is incremented each time the dynamic metadata changes, and
+	// metaUpdateCh is closed and replaced at the same time to wake up
+	// blocking reads of the metadata. Both are protected by configLock.
+	metaIndex    uint64
+	metaUpdateCh chan struct{}
+
 	// triggerEmitNodeEvent sends an event and triggers the client to update the
 	// server for the node event
 	triggerEmitNodeEvent chan *structs.NodeEvent
@@ -259,6 +274,10 @@ type Client struct {
 	// vaultClient is used to interact with Vault for token and secret renewals
 	vaultClient vaultclient.VaultClient
 
+	// vaultClusters are used to interact with the named Vault clusters,
+	// indexed by name
+	vaultClusters map[string]vaultclient.VaultClient
+
 	// garbageCollector is used to garbage collect terminal allocations present
 	// in the node automatically
 	garbageCollector *AllocGarbageCollector
@@ -280,13 +299,29 @@ type Client struct {
 	// devicemanger is responsible for managing device plugins.
 	devicemanager devicemanager.Manager
 
+	// taskhookmanager is responsible for managing task hook plugins.
+	taskhookmanager taskhookmanager.Manager
+
 	// drivermanager is responsible for managing driver plugins
 	drivermanager drivermanager.Manager
 
+	// artifactCache is the cache of artifacts shared by the allocations, or
+	// nil if artifacts aren't cached
+	artifactCache *getter.Cache
+
 	// baseLabels are used when emitting tagged metrics. All client metrics will
 	// have these tags, and optionally more.
 	baseLabels []metrics.Label
 
+	// namespaceLabels bounds the namespace label of the allocation metrics
+	// aggregated by namespace. It is nil if these metrics are disabled.
+	namespaceLabels *labelguard.Guard
+
+	// namespaceMetricLabels are the namespace labels published so far, which
+	// keep being published once their allocations are gone. It is only
+	// accessed by emitStats.
+	namespaceMetricLabels map[string]struct{}
+
 	// batchNodeUpdates is used to batch initial updates to the node
 	batchNodeUpdates *batchNodeUpdates
 
@@ -369,6 +404,11 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 		EnterpriseClient:     newEnterpriseClient(logger),
 	}
 
+	if cfg.NamespaceMetrics {
+		c.namespaceLabels = labelguard.New(cfg.NamespaceMetricsAllowlist, cfg.NamespaceMetricsLimit)
+		c.namespaceMetricLabels = make(map[string]struct{})
+	}
+
 	c.batchNodeUpdates = newBatchNodeUpdates(
 		c.updateNodeFromDriver,
 		c.updateNodeFromDevices,
@@ -410,6 +450,11 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 		return nil, fmt.Errorf("node setup failed: %v", err)
 	}
 
+	// Restore the dynamic node metadata
+	if err := c.setupNodeMeta(); err != nil {
+		return nil, fmt.Errorf("node metadata setup failed: %v", err)
+	}
+
 	// Store the config copy before restoring state but after it has been
 	// initialized.
 	c.configLock.Lock()
@@ -471,6 +516,16 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	c.devicemanager = devManager
 	c.pluginManagers.RegisterAndRun(devManager)
 
+	// Setup the task hook manager
+	taskHookConfig := &taskhookmanager.Config{
+		Logger:       c.logger,
+		Loader:       c.configCopy.PluginSingletonLoader,
+		PluginConfig: c.configCopy.NomadPluginConfig(),
+	}
+	taskHookManager := taskhookmanager.New(taskHookConfig)
+	c.taskhookmanager = taskHookManager
+	c.pluginManagers.RegisterAndRun(taskHookManager)
+
 	// Batching of initial fingerprints is done to reduce the number of node
 	// updates sent to the server on startup. This is the first RPC to the servers
 	go c.batchFirstFingerprints()
@@ -643,6 +698,17 @@ func (c *Client) init() error {
 
 	c.logger.Info("using alloc directory", "alloc_dir", c.config.AllocDir)
 
+	// Create the artifact cache if enabled
+	if c.config.ArtifactCacheMaxBytes > 0 {
+		cacheDir := filepath.Join(c.config.StateDir, "artifact_cache")
+		cache, err := getter.NewCache(cacheDir, c.config.ArtifactCacheMaxBytes, c.logger)
+		if err != nil {
+			return err
+		}
+		c.artifactCache = cache
+		c.logger.Info("using artifact cache", "cache_dir", cacheDir, "max_bytes", c.config.ArtifactCacheMaxBytes)
+	}
+
 	reserved := "<none>"
 	if c.config.Node != nil && c.config.Node.ReservedResources != nil {
 		// Node should always be non-nil due to initialization in the
@@ -764,6 +830,9 @@ func (c *Client) Shutdown() error {
 	if c.vaultClient != nil {
 		c.vaultClient.Stop()
 	}
+	for _, v := range c.vaultClusters {
+		v.Stop()
+	}
 
 	// Stop Garbage collector
 	c.garbageCollector.Stop()
@@ -1132,6 +1201,8 @@ func (c *Client) restoreState() error {
 			ConsulSI:            c.tokensClient,
 			ConsulProxies:       c.consulProxies,
 			Vault:               c.vaultClient,
+			VaultClusters:       c.vaultClusters,
+			IdentitySigner:      c,
 			PrevAllocWatcher:    prevAllocWatcher,
 			PrevAllocMigrator:   prevAllocMigrator,
 			DynamicRegistry:     c.dynamicRegistry,
@@ -1139,6 +1210,8 @@ func (c *Client) restoreState() error {
 			CpusetManager:       c.cpusetManager,
 			DeviceManager:       c.devicemanager,
 			DriverManager:       c.drivermanager,
+			TaskHookManager:     c.taskhookmanager,
+			ArtifactCache:       c.artifactCache,
 			ServersContactedCh:  c.serversContactedCh,
 			RPCClient:           c,
 		}
@@ -1445,6 +1518,152 @@ func (c *Client) setupNode() error {
 	return nil
 }
 
+// setupNodeMeta merges the dynamic metadata set by previous runs of the
+// client into the metadata of the node.
+func (c *Client) setupNodeMeta() error {
+	dynamic, err := c.stateDB.GetNodeMeta()
+	if err != nil {
+		return err
+	}
+	if dynamic == nil {
+		dynamic = make(map[string]*string)
+	}
+
+	c.metaStatic = helper.CopyMapStringString(c.config.Node.Meta)
+	c.metaDynamic = dynamic
+	c.metaIndex = 1
+	c.metaUpdateCh = make(chan struct{})
+	c.config.Node.Meta = mergeNodeMeta(c.metaStatic, c.metaDynamic)
+	return nil
+}
+
+// mergeNodeMeta returns the static metadata with the dynamic metadata
+// applied. Nil dynamic values remove the key.
+func mergeNodeMeta(static map[string]string, dynamic map[string]*string) map[string]string {
+	meta := make(map[string]string, len(static)+len(dynamic))
+	for k, v := range static {
+		meta[k] = v
+	}
+	for k, v := range dynamic {
+		if v == nil {
+			delete(meta, k)
+		} else {
+			meta[k] = *v
+		}
+	}
+	return meta
+}
+
+// applyNodeMeta sets or unsets dynamic metadata of the node, persists it and
+// triggers an update of the node if its metadata changed.
+func (c *Client) applyNodeMeta(meta map[string]*string) (*structs.NodeMetaResponse, error) {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+
+	dynamic := make(map[string]*string, len(c.metaDynamic)+len(meta))
+	for k, v := range c.metaDynamic {
+		dynamic[k] = v
+	}
+	for k, v := range meta {
+		// Unsetting a key that isn't set statically only needs to remove
+		// the dynamic value
+		if _, ok := c.metaStatic[k]; v == nil && !ok {
+			delete(dynamic, k)
+			continue
+		}
+		dynamic[k] = v
+	}
+
+	if err := c.stateDB.PutNodeMeta(dynamic); err != nil {
+		return nil, fmt.Errorf("failed to persist node metadata: %v", err)
+	}
+	if !nodeMetaDynamicEqual(c.metaDynamic, dynamic) {
+		c.metaIndex++
+		close(c.metaUpdateCh)
+		c.metaUpdateCh = make(chan struct{})
+	}
+	c.metaDynamic = dynamic
+
+	merged := mergeNodeMeta(c.metaStatic, c.metaDynamic)
+	if !helper.CompareMapStringString(c.config.Node.Meta, merged) {
+		c.config.Node.Meta = merged
+		c.updateNodeLocked()
+	}
+
+	return c.nodeMetaLocked(), nil
+}
+
+// nodeMeta returns the metadata of the node.
+func (c *Client) nodeMeta() *structs.NodeMetaResponse {
+	c.configLock.RLock()
+	defer c.configLock.RUnlock()
+	return c.nodeMetaLocked()
+}
+
+// blockingNodeMeta returns the metadata of the node once its index is
+// greater than the minimum index of the query, or once the time to block for
+// the query has elapsed.
+func (c *Client) blockingNodeMeta(q structs.QueryOptions) *structs.NodeMetaResponse {
+	if q.MinQueryIndex == 0 {
+		return c.nodeMeta()
+	}
+
+	// Apply a small amount of jitter to the request, as the servers do
+	timeout := q.TimeToBlock()
+	timeout += lib.RandomStagger(timeout / structs.JitterFraction)
+	timer, stop := helper.NewSafeTimer(timeout)
+	defer stop()
+
+	for {
+		c.configLock.RLock()
+		if c.metaIndex > q.MinQueryIndex {
+			resp := c.nodeMetaLocked()
+			c.configLock.RUnlock()
+			return resp
+		}
+		updateCh := c.metaUpdateCh
+		c.configLock.RUnlock()
+
+		select {
+		case <-updateCh:
+		case <-timer.C:
+			return c.nodeMeta()
+		case <-c.shutdownCh:
+			return c.nodeMeta()
+		}
+	}
+}
+
+// nodeMetaLocked returns the metadata of the node. The caller must hold the
+// configLock.
+func (c *Client) nodeMetaLocked() *structs.NodeMetaResponse {
+	dynamic := make(map[string]*string, len(c.metaDynamic))
+	for k, v := range c.metaDynamic {
+		dynamic[k] = v
+	}
+	return &structs.NodeMetaResponse{
+		Meta:      helper.CopyMapStringString(c.config.Node.Meta),
+		Dynamic:   dynamic,
+		Static:    helper.CopyMapStringString(c.metaStatic),
+		QueryMeta: structs.QueryMeta{Index: c.metaIndex},
+	}
+}
+
+// nodeMetaDynamicEqual returns whether the two sets of dynamic metadata set
+// and unset the same keys to the same values.
+func nodeMetaDynamicEqual(a, b map[string]*string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, av := range a {
+		bv, ok := b[k]
+		if !ok || (av == nil) != (bv == nil) || (av != nil && *av != *bv) {
+			return false
+		}
+	}
+	return true
+}
+
 // updateNodeFromFingerprint updates the node with the result of
 // fingerprinting the node from the diff that was created
 func (c *Client) updateNodeFromFingerprint(response *fingerprint.FingerprintResponse) *structs.Node {
@@ -2452,6 +2671,8 @@ func (c *Client) addAlloc(alloc *structs.Allocation, migrateToken string) error
 		ConsulProxies:       c.consulProxies,
 		ConsulSI:            c.tokensClient,
 		Vault:               c.vaultClient,
+		VaultClusters:       c.vaultClusters,
+		IdentitySigner:      c,
 		StateUpdater:        c,
 		DeviceStatsReporter: c,
 		PrevAllocWatcher:    prevAllocWatcher,
@@ -2461,6 +2682,8 @@ func (c *Client) addAlloc(alloc *structs.Allocation, migrateToken string) error
 		CpusetManager:       c.cpusetManager,
 		DeviceManager:       c.devicemanager,
 		DriverManager:       c.drivermanager,
+		TaskHookManager:     c.taskhookmanager,
+		ArtifactCache:       c.artifactCache,
 		RPCClient:           c,
 	}
 	c.configLock.RUnlock()
@@ -2505,14 +2728,42 @@ func (c *Client) setupVaultClient() error {
 	// Start renewing tokens and secrets
 	c.vaultClient.Start()
 
+	c.vaultClusters = make(map[string]vaultclient.VaultClient, len(c.config.VaultConfig.Clusters))
+	for _, cluster := range c.config.VaultConfig.Clusters {
+		logger := c.logger.With("vault_cluster", cluster.Name)
+		v, err := vaultclient.NewVaultClient(cluster.Config(), logger, c.deriveClusterToken(cluster.Name))
+		if err != nil {
+			return fmt.Errorf("vault cluster %q: %v", cluster.Name, err)
+		}
+		v.Start()
+		c.vaultClusters[cluster.Name] = v
+	}
+
 	return nil
 }
 
+// deriveClusterToken returns the function deriving vault tokens from the
+// named Vault cluster.
+func (c *Client) deriveClusterToken(cluster string) vaultclient.TokenDeriverFunc {
+	return func(alloc *structs.Allocation, taskNames []string, vclient *vaultapi.Client) (map[string]string, error) {
+		return c.deriveVaultToken(cluster, alloc, taskNames, vclient)
+	}
+}
+
 // deriveToken takes in an allocation and a set of tasks and derives vault
 // tokens for each of the tasks, unwraps all of them using the supplied vault
 // client and returns a map of unwrapped tokens, indexed by the task name.
 func (c *Client) deriveToken(alloc *structs.Allocation, taskNames []string, vclient *vaultapi.Client) (map[string]string, error) {
+	return c.deriveVaultToken("", alloc, taskNames, vclient)
+}
+
+// deriveVaultToken derives the vault tokens of the tasks from the named Vault
+// cluster, or from the default cluster if the name is empty.
+func (c *Client) deriveVaultToken(cluster string, alloc *structs.Allocation, taskNames []string, vclient *vaultapi.Client) (map[string]string, error) {
 	vlogger := c.logger.Named("vault")
+	if cluster != "" {
+		vlogger = vlogger.With("vault_cluster", cluster)
+	}
 
 	verifiedTasks, err := verifiedTasks(vlogger, alloc, taskNames)
 	if err != nil {
@@ -2526,6 +2777,7 @@ func (c *Client) deriveToken(alloc *structs.Allocation, taskNames []string, vcli
 		SecretID: c.secretNodeID(),
 		AllocID:  alloc.ID,
 		Tasks:    verifiedTasks,
+		Cluster:  cluster,
 		QueryOptions: structs.QueryOptions{
 			Region:     c.Region(),
 			AllowStale: false,
@@ -2637,6 +2889,30 @@ func (c *Client) deriveSIToken(alloc *structs.Allocation, taskNames []string) (m
 	return m, nil
 }
 
+// SignIdentities requests the signed workload identities of a task of the
+// allocation from the Nomad Server.
+func (c *Client) SignIdentities(alloc *structs.Allocation, task string, identities []string) (map[string]*structs.SignedWorkloadIdentity, error) {
+	req := &structs.SignIdentitiesRequest{
+		NodeID:       c.NodeID(),
+		SecretID:     c.secretNodeID(),
+		AllocID:      alloc.ID,
+		Task:         task,
+		Identities:   identities,
+		QueryOptions: structs.QueryOptions{Region: c.Region()},
+	}
+
+	var resp structs.SignIdentitiesResponse
+	if err := c.RPC("Node.SignIdentities", &req, &resp); err != nil {
+		c.logger.Error("error making sign identities RPC", "error", err)
+		return nil, fmt.Errorf("SignIdentities RPC failed: %v", err)
+	}
+	if err := resp.Error; err != nil {
+		c.logger.Error("error signing identities", "error", err)
+		return nil, structs.NewWrappedServerError(err)
+	}
+	return resp.Identities, nil
+}
+
 // verifiedTasks asserts each task in taskNames actually exists in the given alloc,
 // otherwise an error is returned.
 func verifiedTasks(logger hclog.Logger, alloc *structs.Allocation, taskNames []string) ([]string, error) {
@@ -2965,30 +3241,68 @@ func (c *Client) emitClientMetrics() {
 	c.setGaugeForAllocationStats(nodeID, labels)
 
 	// Emit allocation metrics
-	blocked, migrating, pending, running, terminal := 0, 0, 0, 0, 0
+	total := &allocationCounts{}
+	var nsCounts map[string]*allocationCounts
+	if c.namespaceLabels != nil {
+		nsCounts = make(map[string]*allocationCounts, len(c.namespaceMetricLabels))
+		for label := range c.namespaceMetricLabels {
+			nsCounts[label] = &allocationCounts{}
+		}
+	}
+
 	for _, ar := range c.getAllocRunners() {
-		switch ar.AllocState().ClientStatus {
-		case structs.AllocClientStatusPending:
-			switch {
-			case ar.IsWaiting():
-				blocked++
-			case ar.IsMigrating():
-				migrating++
-			default:
-				pending++
+		counts := []*allocationCounts{total}
+		if nsCounts != nil {
+			label := c.namespaceLabels.Value(ar.Alloc().Namespace)
+			if nsCounts[label] == nil {
+				nsCounts[label] = &allocationCounts{}
+				c.namespaceMetricLabels[label] = struct{}{}
+			}
+			counts = append(counts, nsCounts[label])
+		}
+
+		for _, count := range counts {
+			switch ar.AllocState().ClientStatus {
+			case structs.AllocClientStatusPending:
+				switch {
+				case ar.IsWaiting():
+					count.blocked++
+				case ar.IsMigrating():
+					count.migrating++
+				default:
+					count.pending++
+				}
+			case structs.AllocClientStatusRunning:
+				count.running++
+			case structs.AllocClientStatusComplete, structs.AllocClientStatusFailed:
+				count.terminal++
 			}
-		case structs.AllocClientStatusRunning:
-			running++
-		case structs.AllocClientStatusComplete, structs.AllocClientStatusFailed:
-			terminal++
 		}
 	}
 
-	metrics.SetGaugeWithLabels([]string{"client", "allocations", "migrating"}, float32(migrating), labels)
-	metrics.SetGaugeWithLabels([]string{"client", "allocations", "blocked"}, float32(blocked), labels)
-	metrics.SetGaugeWithLabels([]string{"client", "allocations", "pending"}, float32(pending), labels)
-	metrics.SetGaugeWithLabels([]string{"client", "allocations", "running"}, float32(running), labels)
-	metrics.SetGaugeWithLabels([]string{"client", "allocations", "terminal"}, float32(terminal), labels)
+	total.emit([]string{"client", "allocations"}, labels)
+	for label, count := range nsCounts {
+		nsLabels := append(append([]metrics.Label{}, labels...), metrics.Label{Name: "namespace", Value: label})
+		count.emit([]string{"client", "allocations", "namespace"}, nsLabels)
+	}
+}
+
+// allocationCounts counts the allocations of the client by state.
+type allocationCounts struct {
+	blocked, migrating, pending, running, terminal int
+}
+
+// emit publishes the counts as gauges under the key prefix.
+func (a *allocationCounts) emit(prefix []string, labels []metrics.Label) {
+	gauge := func(name string, value int) {
+		key := append(append([]string{}, prefix...), name)
+		metrics.SetGaugeWithLabels(key, float32(value), labels)
+	}
+	gauge("migrating", a.migrating)
+	gauge("blocked", a.blocked)
+	gauge("pending", a.pending)
+	gauge("running", a.running)
+	gauge("terminal", a.terminal)
 }
 
 // labels takes the base labels and appends the node state
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// DefaultArtifactFetcherTimeout is the time an artifact fetcher may run
+	// when its timeout isn't configured.
+	DefaultArtifactFetcherTimeout = 30 * time.Minute
+
+	// DefaultArtifactFetcherUser is the user an artifact fetcher runs as when
+	// its user isn't configured.
+	DefaultArtifactFetcherUser = "nobody"
+)
+
+// builtinArtifactSchemes are the URL schemes of the artifacts fetched by
+// go-getter or Nomad, which can't be registered for artifact fetchers.
+var builtinArtifactSchemes = []string{"git", "gcs", "hg", "s3", "http", "https", "oci"}
+
+// ArtifactFetcherConfig registers an external command fetching the artifacts
+// whose source URL uses one of its schemes, for stores go-getter doesn't
+// support or that require their own authentication.
+type ArtifactFetcherConfig struct {
+	// Name is the name of the fetcher.
+	Name string `hcl:",key"`
+
+	// Command is the absolute path of the command run to fetch an artifact,
+	// with Args as its arguments.
+	Command string   `hcl:"command"`
+	Args    []string `hcl:"args"`
+
+	// Env is the environment of the command. The command doesn't inherit the
+	// environment of the client, except for PATH.
+	Env map[string]string `hcl:"env"`
+
+	// User is the user the command runs as when the client runs as root,
+	// which is given ownership of the staging directory the artifact is
+	// downloaded into.
+	User string `hcl:"user"`
+
+	// Schemes are the URL schemes of the artifacts fetched by the command,
+	// which default to the name of the fetcher.
+	Schemes []string `hcl:"schemes"`
+
+	// Timeout is the time the command may run before it is killed.
+	Timeout    time.Duration `hcl:"-"`
+	TimeoutHCL string        `hcl:"timeout" json:"-"`
+}
+
+func (f *ArtifactFetcherConfig) Copy() *ArtifactFetcherConfig {
+	if f == nil {
+		return nil
+	}
+	nf := *f
+	nf.Args = helper.CopySliceString(f.Args)
+	nf.Env = helper.CopyMapStringString(f.Env)
+	nf.Schemes = helper.CopySliceString(f.Schemes)
+	return &nf
+}
+
+// Canonicalize sets the default schemes, timeout and user of the fetcher.
+func (f *ArtifactFetcherConfig) Canonicalize() {
+	if len(f.Schemes) == 0 {
+		f.Schemes = []string{f.Name}
+	}
+	if f.Timeout == 0 {
+		f.Timeout = DefaultArtifactFetcherTimeout
+	}
+	if f.User == "" {
+		f.User = DefaultArtifactFetcherUser
+	}
+}
+
+// Validate returns an error if the fetcher is invalid or would replace one of
+// the fetchers of go-getter.
+func (f *ArtifactFetcherConfig) Validate() error {
+	if !filepath.IsAbs(f.Command) {
+		return fmt.Errorf("artifact_fetcher %q: command must be an absolute path", f.Name)
+	}
+	if f.Timeout < 0 {
+		return fmt.Errorf("artifact_fetcher %q: timeout can't be negative", f.Name)
+	}
+	for _, scheme := range f.Schemes {
+		if helper.SliceStringContains(builtinArtifactSchemes, scheme) {
+			return fmt.Errorf("artifact_fetcher %q: scheme %q is fetched by Nomad", f.Name, scheme)
+		}
+	}
+	return nil
+}
+
+// OCIRegistryConfig configures the authentication to an OCI registry the
+// images of oci:// artifacts are pulled from.
+type OCIRegistryConfig struct {
+	// Host is the host of the registry, as written in image references.
+	Host string `hcl:",key"`
+
+	// Username and Password are the credentials of the registry.
+	Username string `hcl:"username"`
+	Password string `hcl:"password"`
+
+	// Token is an identity token exchanged for registry tokens instead of
+	// the username and password.
+	Token string `hcl:"token"`
+
+	// Insecure allows pulling images over plain HTTP.
+	Insecure bool `hcl:"insecure"`
+}
+
+func (r *OCIRegistryConfig) Copy() *OCIRegistryConfig {
+	if r == nil {
+		return nil
+	}
+	nr := *r
+	return &nr
+}
+
+// Validate returns an error if the registry is invalid.
+func (r *OCIRegistryConfig) Validate() error {
+	if r.Host == "" {
+		return fmt.Errorf("oci_registry requires a host")
+	}
+	if r.Token != "" && (r.Username != "" || r.Password != "") {
+		return fmt.Errorf("oci_registry %q: token can't be used with username and password", r.Host)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactFetcherConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name    string
+		fetcher *ArtifactFetcherConfig
+		err     string
+	}{
+		{
+			name:    "valid",
+			fetcher: &ArtifactFetcherConfig{Name: "corp", Command: "/usr/local/bin/corp-fetch"},
+		},
+		{
+			name:    "relative command",
+			fetcher: &ArtifactFetcherConfig{Name: "corp", Command: "corp-fetch"},
+			err:     `artifact_fetcher "corp": command must be an absolute path`,
+		},
+		{
+			name:    "builtin scheme",
+			fetcher: &ArtifactFetcherConfig{Name: "corp", Command: "/usr/local/bin/corp-fetch", Schemes: []string{"corp", "s3"}},
+			err:     `artifact_fetcher "corp": scheme "s3" is fetched by Nomad`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fetcher.Canonicalize()
+			err := tc.fetcher.Validate()
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []string{tc.fetcher.Name}, tc.fetcher.Schemes)
+			require.Equal(t, DefaultArtifactFetcherTimeout, tc.fetcher.Timeout)
+			require.Equal(t, DefaultArtifactFetcherUser, tc.fetcher.User)
+		})
+	}
+}
@@ -66,6 +66,16 @@ var (
 	DefaultTemplateMaxStale = 5 * time.Second
 )
 
+const (
+	// EphemeralDiskEnforcementSoft only accounts for the ephemeral disk used
+	// by allocations when garbage collecting them.
+	EphemeralDiskEnforcementSoft = "soft"
+
+	// EphemeralDiskEnforcementHard limits the disk space used by allocations
+	// to their ephemeral disk size with filesystem project quotas.
+	EphemeralDiskEnforcementHard = "hard"
+)
+
 // RPCHandler can be provided to the Client if there is a local server
 // to avoid going over the network. If not provided, the Client will
 // maintain a connection pool to the servers
@@ -173,6 +183,18 @@ type Config struct {
 	// allocation metrics to remote Telemetry sinks
 	PublishAllocationMetrics bool
 
+	// NamespaceMetrics determines whether nomad is going to publish the
+	// allocation counts aggregated by namespace
+	NamespaceMetrics bool
+
+	// NamespaceMetricsAllowlist is the namespaces published with their own
+	// namespace label. The others are aggregated under the "_other" label.
+	NamespaceMetricsAllowlist []string
+
+	// NamespaceMetricsLimit is the maximum number of namespaces published
+	// with their own namespace label when there is no allowlist.
+	NamespaceMetricsLimit int
+
 	// TLSConfig holds various TLS related configurations
 	TLSConfig *structsc.TLSConfig
 
@@ -215,6 +237,11 @@ type Config struct {
 	// DisableRemoteExec disables remote exec targeting tasks on this client
 	DisableRemoteExec bool
 
+	// EphemeralDiskEnforcement controls whether the ephemeral disk size of
+	// allocations is enforced with project quotas ("hard") or only accounted
+	// for ("soft", the default when empty)
+	EphemeralDiskEnforcement string
+
 	// TemplateConfig includes configuration for template rendering
 	TemplateConfig *ClientTemplateConfig
 
@@ -257,12 +284,44 @@ type Config struct {
 	// notation
 	BridgeNetworkAllocSubnet string
 
+	// BridgeNetworkFirewall enables the firewall blocking the allocations in
+	// bridge networking mode from reaching the client HTTP API, the cloud
+	// metadata endpoint and the BridgeNetworkFirewallDenyCIDRs, unless their
+	// job allows it.
+	BridgeNetworkFirewall bool
+
+	// BridgeNetworkFirewallDenyCIDRs are the destinations denied by the
+	// bridge network firewall in addition to the client HTTP API and the
+	// cloud metadata endpoint.
+	BridgeNetworkFirewallDenyCIDRs []string
+
 	// HostVolumes is a map of the configured host volumes by name.
 	HostVolumes map[string]*structs.ClientHostVolumeConfig
 
 	// HostNetworks is a map of the conigured host networks by name.
 	HostNetworks map[string]*structs.ClientHostNetworkConfig
 
+	// NamespaceIDRanges is a map of the host uid and gid ranges the exec and
+	// docker tasks of a Nomad namespace run as, by namespace.
+	NamespaceIDRanges map[string]*NamespaceIDRangeConfig
+
+	// ArtifactFetchers is a map of the external commands fetching artifacts
+	// by name.
+	ArtifactFetchers map[string]*ArtifactFetcherConfig
+
+	// OCIRegistries is a map of the authentication to the registries images
+	// are pulled from by host.
+	OCIRegistries map[string]*OCIRegistryConfig
+
+	// EnvoyVersions is the compatibility matrix selecting the Envoy version of
+	// Connect sidecars and gateways from the version of Consul. The first
+	// matching entry is used.
+	EnvoyVersions []*EnvoyVersionConfig
+
+	// ArtifactCacheMaxBytes is the size of the cache of artifacts verified by
+	// a checksum shared by the allocations. Artifacts aren't cached if 0.
+	ArtifactCacheMaxBytes int64
+
 	// BindWildcardDefaultHostNetwork toggles if the default host network should accept all
 	// destinations (true) or only filter on the IP of the default host network (false) when
 	// port mapping. This allows Nomad clients with no defined host networks to accept and
@@ -344,6 +403,10 @@ type ClientTemplateConfig struct {
 	// to wait for the cluster to become available, as is customary in distributed
 	// systems.
 	VaultRetry *RetryConfig `hcl:"vault_retry,optional"`
+
+	// PluginFunctions are the external commands exposed to templates as
+	// template functions.
+	PluginFunctions []*TemplatePluginFunctionConfig `hcl:"plugin_function"`
 }
 
 // Copy returns a deep copy of a ClientTemplateConfig
@@ -376,6 +439,13 @@ func (c *ClientTemplateConfig) Copy() *ClientTemplateConfig {
 		nc.VaultRetry = c.VaultRetry.Copy()
 	}
 
+	if c.PluginFunctions != nil {
+		nc.PluginFunctions = make([]*TemplatePluginFunctionConfig, len(c.PluginFunctions))
+		for i, f := range c.PluginFunctions {
+			nc.PluginFunctions[i] = f.Copy()
+		}
+	}
+
 	return nc
 }
 
@@ -442,6 +512,13 @@ func (c *ClientTemplateConfig) Merge(b *ClientTemplateConfig) *ClientTemplateCon
 		result.VaultRetry = result.VaultRetry.Merge(b.VaultRetry)
 	}
 
+	if len(b.PluginFunctions) != 0 {
+		result.PluginFunctions = make([]*TemplatePluginFunctionConfig, len(b.PluginFunctions))
+		for i, f := range b.PluginFunctions {
+			result.PluginFunctions[i] = f.Copy()
+		}
+	}
+
 	return &result
 }
 
@@ -459,7 +536,8 @@ func (c *ClientTemplateConfig) IsEmpty() bool {
 		c.MaxStaleHCL == "" &&
 		c.Wait.IsEmpty() &&
 		c.ConsulRetry.IsEmpty() &&
-		c.VaultRetry.IsEmpty()
+		c.VaultRetry.IsEmpty() &&
+		len(c.PluginFunctions) == 0
 }
 
 // WaitConfig is mirrored from templateconfig.WaitConfig because we need to handle
@@ -735,11 +813,37 @@ func (c *Config) Copy() *Config {
 	*nc = *c
 	nc.Node = nc.Node.Copy()
 	nc.Servers = helper.CopySliceString(nc.Servers)
+	nc.BridgeNetworkFirewallDenyCIDRs = helper.CopySliceString(nc.BridgeNetworkFirewallDenyCIDRs)
+	nc.NamespaceMetricsAllowlist = helper.CopySliceString(nc.NamespaceMetricsAllowlist)
 	nc.Options = helper.CopyMapStringString(nc.Options)
 	nc.HostVolumes = structs.CopyMapStringClientHostVolumeConfig(nc.HostVolumes)
 	nc.ConsulConfig = c.ConsulConfig.Copy()
 	nc.VaultConfig = c.VaultConfig.Copy()
 	nc.TemplateConfig = c.TemplateConfig.Copy()
+	if c.NamespaceIDRanges != nil {
+		nc.NamespaceIDRanges = make(map[string]*NamespaceIDRangeConfig, len(c.NamespaceIDRanges))
+		for ns, r := range c.NamespaceIDRanges {
+			nc.NamespaceIDRanges[ns] = r.Copy()
+		}
+	}
+	if c.ArtifactFetchers != nil {
+		nc.ArtifactFetchers = make(map[string]*ArtifactFetcherConfig, len(c.ArtifactFetchers))
+		for name, f := range c.ArtifactFetchers {
+			nc.ArtifactFetchers[name] = f.Copy()
+		}
+	}
+	if c.OCIRegistries != nil {
+		nc.OCIRegistries = make(map[string]*OCIRegistryConfig, len(c.OCIRegistries))
+		for host, r := range c.OCIRegistries {
+			nc.OCIRegistries[host] = r.Copy()
+		}
+	}
+	if c.EnvoyVersions != nil {
+		nc.EnvoyVersions = make([]*EnvoyVersionConfig, len(c.EnvoyVersions))
+		for i, e := range c.EnvoyVersions {
+			nc.EnvoyVersions[i] = e.Copy()
+		}
+	}
 	if c.ReservableCores != nil {
 		nc.ReservableCores = make([]uint16, len(c.ReservableCores))
 		copy(nc.ReservableCores, c.ReservableCores)
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// EnvoyVersionConfig is an entry of the compatibility matrix selecting the
+// version of the Envoy image of Connect sidecars and gateways from the version
+// of Consul fingerprinted on the node. It takes precedence over the versions
+// Consul reports as supported.
+type EnvoyVersionConfig struct {
+	// Envoy is the version of Envoy used with the versions of Consul matching
+	// the constraint.
+	Envoy string `hcl:",key"`
+
+	// Consul is the constraint on the Consul version, such as ">= 1.12.0,
+	// < 1.13.0".
+	Consul string `hcl:"consul"`
+}
+
+func (e *EnvoyVersionConfig) Copy() *EnvoyVersionConfig {
+	if e == nil {
+		return nil
+	}
+	ne := *e
+	return &ne
+}
+
+// Validate returns an error if the constraint or version of the entry can't
+// be parsed.
+func (e *EnvoyVersionConfig) Validate() error {
+	if _, err := version.NewConstraint(e.Consul); err != nil {
+		return fmt.Errorf("envoy_version %q: invalid consul constraint %q: %v", e.Envoy, e.Consul, err)
+	}
+	if _, err := version.NewVersion(e.Envoy); err != nil {
+		return fmt.Errorf("envoy_version %q: invalid envoy version: %v", e.Envoy, err)
+	}
+	return nil
+}
+
+// Matches returns true if the Consul version satisfies the constraint of the
+// entry.
+func (e *EnvoyVersionConfig) Matches(consulVersion *version.Version) bool {
+	constraint, err := version.NewConstraint(e.Consul)
+	if err != nil {
+		return false
+	}
+	return constraint.Check(consulVersion)
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultNamespaceIDRangeSize is the number of uids and gids of the range
+	// of a namespace when the size isn't configured.
+	DefaultNamespaceIDRangeSize = 65536
+)
+
+// NamespaceIDRangeConfig is a fixed range of host uids and gids the exec and
+// docker tasks of a Nomad namespace run as, so that files written by tasks of
+// different namespaces to shared host volumes can't collide or be read across
+// namespaces. The task user is overridden with a host uid and gid of the
+// range; tasks don't run in a user namespace, so the ids are the same inside
+// and outside of the task.
+type NamespaceIDRangeConfig struct {
+	// Namespace is the Nomad namespace whose tasks run as users of the range.
+	Namespace string `hcl:",key"`
+
+	// UID and GID are the first host uid and gid of the range.
+	UID int `hcl:"uid"`
+	GID int `hcl:"gid"`
+
+	// Size is the number of uids and gids in the range.
+	Size int `hcl:"size"`
+}
+
+func (r *NamespaceIDRangeConfig) Copy() *NamespaceIDRangeConfig {
+	if r == nil {
+		return nil
+	}
+	nr := *r
+	return &nr
+}
+
+// Canonicalize sets the default size of the range.
+func (r *NamespaceIDRangeConfig) Canonicalize() {
+	if r.Size == 0 {
+		r.Size = DefaultNamespaceIDRangeSize
+	}
+}
+
+// Validate returns an error if the range is invalid. Ranges can't include the
+// host's root user or group.
+func (r *NamespaceIDRangeConfig) Validate() error {
+	if r.UID <= 0 || r.GID <= 0 {
+		return fmt.Errorf("namespace_id_range %q: uid and gid must be greater than zero", r.Namespace)
+	}
+	if r.Size <= 0 {
+		return fmt.Errorf("namespace_id_range %q: size must be greater than zero", r.Namespace)
+	}
+	return nil
+}
+
+// Overlaps returns whether the uid or gid ranges of the two ranges overlap.
+func (r *NamespaceIDRangeConfig) Overlaps(o *NamespaceIDRangeConfig) bool {
+	overlaps := func(a, b int) bool {
+		return a < b+o.Size && b < a+r.Size
+	}
+	return overlaps(r.UID, o.UID) || overlaps(r.GID, o.GID)
+}
+
+// TaskUser returns the host "uid:gid" the task runs as instead of the task
+// user. The task user must be empty, which is the first uid and gid of the
+// range, or a numeric "uid" or "uid:gid" offset within the size of the range,
+// as named users can't be resolved by the client.
+func (r *NamespaceIDRangeConfig) TaskUser(user string) (string, error) {
+	var uid, gid int
+	if user != "" {
+		parts := strings.SplitN(user, ":", 2)
+		ids := make([]int, len(parts))
+		for i, part := range parts {
+			id, err := strconv.Atoi(part)
+			if err != nil || id < 0 {
+				return "", fmt.Errorf("task user %q must be a numeric uid or uid:gid to run in the id range of namespace %q", user, r.Namespace)
+			}
+			if id >= r.Size {
+				return "", fmt.Errorf("task user %q is outside the %d ids of the id range of namespace %q", user, r.Size, r.Namespace)
+			}
+			ids[i] = id
+		}
+		uid, gid = ids[0], ids[0]
+		if len(ids) == 2 {
+			gid = ids[1]
+		}
+	}
+	return fmt.Sprintf("%d:%d", r.UID+uid, r.GID+gid), nil
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceIDRangeConfig_TaskUser(t *testing.T) {
+	ci.Parallel(t)
+
+	idRange := &NamespaceIDRangeConfig{
+		Namespace: "tenant-a",
+		UID:       100000,
+		GID:       200000,
+		Size:      1000,
+	}
+
+	cases := []struct {
+		user     string
+		expected string
+		err      string
+	}{
+		{user: "", expected: "100000:200000"},
+		{user: "33", expected: "100033:200033"},
+		{user: "33:44", expected: "100033:200044"},
+		{user: "999:0", expected: "100999:200000"},
+		{user: "1000", err: `task user "1000" is outside the 1000 ids of the id range of namespace "tenant-a"`},
+		{user: "nobody", err: `task user "nobody" must be a numeric uid or uid:gid to run in the id range of namespace "tenant-a"`},
+		{user: "-1", err: `task user "-1" must be a numeric uid or uid:gid to run in the id range of namespace "tenant-a"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.user, func(t *testing.T) {
+			user, err := idRange.TaskUser(tc.user)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, user)
+		})
+	}
+}
+
+func TestNamespaceIDRangeConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	idRange := &NamespaceIDRangeConfig{Namespace: "tenant-a", UID: 100000, GID: 100000}
+	idRange.Canonicalize()
+	require.Equal(t, DefaultNamespaceIDRangeSize, idRange.Size)
+	require.NoError(t, idRange.Validate())
+
+	idRange.UID = 0
+	require.EqualError(t, idRange.Validate(), `namespace_id_range "tenant-a": uid and gid must be greater than zero`)
+}
+
+func TestNamespaceIDRangeConfig_Overlaps(t *testing.T) {
+	ci.Parallel(t)
+
+	a := &NamespaceIDRangeConfig{Namespace: "a", UID: 100000, GID: 100000, Size: 1000}
+	b := &NamespaceIDRangeConfig{Namespace: "b", UID: 101000, GID: 101000, Size: 1000}
+	require.False(t, a.Overlaps(b))
+	require.False(t, b.Overlaps(a))
+
+	b.GID = 100999
+	require.True(t, a.Overlaps(b))
+	require.True(t, b.Overlaps(a))
+}
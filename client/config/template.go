@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/consul-template/template"
+	"github.com/hashicorp/nomad/helper"
+)
+
+// validTemplateFunctionName matches the names templates can call functions by.
+var validTemplateFunctionName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// TemplatePluginFunctionConfig exposes an external command to the templates of
+// the tasks as a template function. The calls to the function run the command
+// through the plugin function of consul-template, with the arguments of the
+// call appended to the arguments of the command, and return its output.
+type TemplatePluginFunctionConfig struct {
+	// Name is the name templates call the function by.
+	Name string `hcl:",key"`
+
+	// Command is the absolute path of the command run by the function, with
+	// Args as its first arguments.
+	Command string   `hcl:"command"`
+	Args    []string `hcl:"args"`
+
+	// Namespaces and Jobs restrict the function to the jobs of the listed
+	// namespaces and to the listed jobs, given as "<namespace>/<job ID>". The
+	// function is available to every job if neither is set.
+	Namespaces []string `hcl:"namespaces"`
+	Jobs       []string `hcl:"jobs"`
+}
+
+func (f *TemplatePluginFunctionConfig) Copy() *TemplatePluginFunctionConfig {
+	if f == nil {
+		return nil
+	}
+	nf := *f
+	nf.Args = helper.CopySliceString(f.Args)
+	nf.Namespaces = helper.CopySliceString(f.Namespaces)
+	nf.Jobs = helper.CopySliceString(f.Jobs)
+	return &nf
+}
+
+// Allows returns whether the templates of the job with one of the given IDs in
+// the namespace may call the function. Dispatched and periodic jobs are
+// allowed by the ID of their parent job.
+func (f *TemplatePluginFunctionConfig) Allows(namespace string, jobIDs ...string) bool {
+	if len(f.Namespaces) == 0 && len(f.Jobs) == 0 {
+		return true
+	}
+	if helper.SliceStringContains(f.Namespaces, namespace) {
+		return true
+	}
+	for _, id := range jobIDs {
+		if id != "" && helper.SliceStringContains(f.Jobs, namespace+"/"+id) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error if the function is invalid or would replace one
+// of the functions of consul-template.
+func (f *TemplatePluginFunctionConfig) Validate() error {
+	if !validTemplateFunctionName.MatchString(f.Name) {
+		return fmt.Errorf("plugin_function %q: invalid function name", f.Name)
+	}
+	if !filepath.IsAbs(f.Command) {
+		return fmt.Errorf("plugin_function %q: command must be an absolute path", f.Name)
+	}
+	for _, job := range f.Jobs {
+		if parts := strings.SplitN(job, "/", 2); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("plugin_function %q: job %q must be given as \"<namespace>/<job ID>\"", f.Name, job)
+		}
+	}
+
+	// Templates calling an undefined function fail to parse, without the
+	// function being called.
+	tmpl, err := template.NewTemplate(&template.NewTemplateInput{
+		Contents: "{{ if false }}{{ " + f.Name + " }}{{ end }}",
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := tmpl.Execute(nil); err == nil {
+		return fmt.Errorf("plugin_function %q: function is provided by consul-template", f.Name)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplatePluginFunctionConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name     string
+		function *TemplatePluginFunctionConfig
+		err      string
+	}{
+		{
+			name:     "valid",
+			function: &TemplatePluginFunctionConfig{Name: "externalSecret", Command: "/usr/local/bin/external-secret"},
+		},
+		{
+			name:     "invalid name",
+			function: &TemplatePluginFunctionConfig{Name: "external-secret", Command: "/usr/local/bin/external-secret"},
+			err:      `plugin_function "external-secret": invalid function name`,
+		},
+		{
+			name:     "relative command",
+			function: &TemplatePluginFunctionConfig{Name: "externalSecret", Command: "external-secret"},
+			err:      `plugin_function "externalSecret": command must be an absolute path`,
+		},
+		{
+			name: "allowlist",
+			function: &TemplatePluginFunctionConfig{
+				Name:       "externalSecret",
+				Command:    "/usr/local/bin/external-secret",
+				Namespaces: []string{"prod"},
+				Jobs:       []string{"default/web"},
+			},
+		},
+		{
+			name: "job without namespace",
+			function: &TemplatePluginFunctionConfig{
+				Name:    "externalSecret",
+				Command: "/usr/local/bin/external-secret",
+				Jobs:    []string{"web"},
+			},
+			err: `plugin_function "externalSecret": job "web" must be given as "<namespace>/<job ID>"`,
+		},
+		{
+			name:     "builtin function",
+			function: &TemplatePluginFunctionConfig{Name: "secret", Command: "/usr/local/bin/external-secret"},
+			err:      `plugin_function "secret": function is provided by consul-template`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.function.Validate()
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTemplatePluginFunctionConfig_Allows(t *testing.T) {
+	ci.Parallel(t)
+
+	unrestricted := &TemplatePluginFunctionConfig{Name: "externalSecret"}
+	require.True(t, unrestricted.Allows("default", "web"))
+
+	f := &TemplatePluginFunctionConfig{
+		Name:       "externalSecret",
+		Namespaces: []string{"prod"},
+		Jobs:       []string{"default/web"},
+	}
+	require.True(t, f.Allows("prod", "api"))
+	require.True(t, f.Allows("default", "web"))
+	require.True(t, f.Allows("default", "web/dispatch-1634", "web"))
+	require.False(t, f.Allows("default", "api"))
+	require.False(t, f.Allows("dev", "web"))
+}
@@ -433,6 +433,10 @@ func (c *CSI) ControllerListSnapshots(req *structs.ClientCSIControllerListSnapsh
 		if entry.Snapshot == nil {
 			return fmt.Errorf("CSI.ControllerListSnapshot: plugin returned an invalid entry")
 		}
+		if req.SourceVolumeID != "" && entry.Snapshot.SourceVolumeID != req.SourceVolumeID {
+			// this should be done in the plugin already, but enforce it
+			continue
+		}
 		snap := &nstructs.CSISnapshot{
 			ID:                     entry.Snapshot.ID,
 			ExternalSourceVolumeID: entry.Snapshot.SourceVolumeID,
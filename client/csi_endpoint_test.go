@@ -861,6 +861,42 @@ func TestCSIController_ListSnapshots(t *testing.T) {
 				NextToken: "2",
 			},
 		},
+		{
+			Name: "filters by source volume",
+			ClientSetupFunc: func(fc *fake.Client) {
+				fc.NextControllerListSnapshotsResponse = &csi.ControllerListSnapshotsResponse{
+					Entries: []*csi.ListSnapshotsResponse_Entry{
+						{
+							Snapshot: &csi.Snapshot{
+								ID:             "snap-1",
+								SourceVolumeID: "vol-1",
+							},
+						},
+						{
+							Snapshot: &csi.Snapshot{
+								ID:             "snap-2",
+								SourceVolumeID: "vol-2",
+							},
+						},
+					},
+				}
+			},
+			Request: &structs.ClientCSIControllerListSnapshotsRequest{
+				CSIControllerQuery: structs.CSIControllerQuery{
+					PluginID: fakePlugin.Name,
+				},
+				SourceVolumeID: "vol-2",
+			},
+			ExpectedResponse: &structs.ClientCSIControllerListSnapshotsResponse{
+				Entries: []*nstructs.CSISnapshot{
+					{
+						ID:                     "snap-2",
+						ExternalSourceVolumeID: "vol-2",
+						PluginID:               fakePlugin.Name,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
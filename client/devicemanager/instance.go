@@ -8,6 +8,7 @@ import (
 
 	log "github.com/hashicorp/go-hclog"
 	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/pluginutils/singleton"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -40,6 +41,14 @@ type instanceManagerConfig struct {
 	// StoreReattach is used to store a plugins reattach config
 	StoreReattach StorePluginReattachFn
 
+	// ResetFailures are the devices the plugin failed to reset before the
+	// agent restarted, mapped to the failure
+	ResetFailures map[string]string
+
+	// StoreResetFailures is used to store the devices the plugin failed to
+	// reset
+	StoreResetFailures StoreResetFailuresFn
+
 	// PluginConfig is the config passed to the launched plugins
 	PluginConfig *base.AgentConfig
 
@@ -70,6 +79,10 @@ type instanceManager struct {
 	// storeReattach is used to store a plugins reattach config
 	storeReattach StorePluginReattachFn
 
+	// storeResetFailures is used to store the devices the plugin failed to
+	// reset
+	storeResetFailures StoreResetFailuresFn
+
 	// pluginConfig is the config passed to the launched plugins
 	pluginConfig *base.AgentConfig
 
@@ -95,6 +108,13 @@ type instanceManager struct {
 	devices    []*device.DeviceGroup
 	deviceLock sync.RWMutex
 
+	// resetFailures maps the IDs of the devices the plugin failed to reset to
+	// the failure. These devices are reported as unhealthy, regardless of
+	// their fingerprinted health, so they are not handed to another
+	// allocation, until the plugin no longer fingerprints them. It is
+	// persisted across restarts of the agent and guarded by deviceLock.
+	resetFailures map[string]string
+
 	// statsInterval is the interval at which we collect statistics.
 	statsInterval time.Duration
 
@@ -113,6 +133,11 @@ type instanceManager struct {
 // launched goroutines.
 func newInstanceManager(c *instanceManagerConfig) *instanceManager {
 
+	resetFailures := make(map[string]string, len(c.ResetFailures))
+	for id, desc := range c.ResetFailures {
+		resetFailures[id] = desc
+	}
+
 	ctx, cancel := context.WithCancel(c.Ctx)
 	i := &instanceManager{
 		logger:             c.Logger.With("plugin", c.Id.Name),
@@ -120,11 +145,13 @@ func newInstanceManager(c *instanceManagerConfig) *instanceManager {
 		cancel:             cancel,
 		loader:             c.Loader,
 		storeReattach:      c.StoreReattach,
+		storeResetFailures: c.StoreResetFailures,
 		pluginConfig:       c.PluginConfig,
 		id:                 c.Id,
 		fingerprintOutCh:   c.FingerprintOutCh,
 		statsInterval:      c.StatsInterval,
 		firstFingerprintCh: make(chan struct{}),
+		resetFailures:      resetFailures,
 	}
 
 	go i.run()
@@ -210,11 +237,86 @@ func (i *instanceManager) Reserve(d *structs.AllocatedDeviceResource) (*device.C
 	return devicePlugin.Reserve(d.DeviceIDs)
 }
 
-// Devices returns the detected devices.
+// Reset resets the given devices once the task they were reserved for has
+// exited. Devices that fail to reset, including because the plugin can't be
+// dispensed, are marked as unhealthy.
+func (i *instanceManager) Reset(d *structs.AllocatedDeviceResource) error {
+	err := i.reset(d)
+	if err == nil {
+		return nil
+	}
+
+	i.logger.Error("resetting devices failed, marking them unhealthy",
+		"devices", d.DeviceIDs, "error", err)
+
+	i.deviceLock.Lock()
+	defer i.deviceLock.Unlock()
+	for _, id := range d.DeviceIDs {
+		i.resetFailures[id] = fmt.Sprintf("failed to reset device: %v", err)
+	}
+	if storeErr := i.storeResetFailures(helper.CopyMapStringString(i.resetFailures)); storeErr != nil {
+		i.logger.Error("failed to persist devices that failed to reset", "error", storeErr)
+	}
+
+	// Trigger a node update with the now unhealthy devices
+	select {
+	case i.fingerprintOutCh <- struct{}{}:
+	default:
+	}
+
+	return err
+}
+
+// reset asks the plugin to reset the given devices. Plugins that don't support
+// resetting devices are skipped.
+func (i *instanceManager) reset(d *structs.AllocatedDeviceResource) error {
+	// Get a device plugin
+	devicePlugin, err := i.dispense()
+	if err != nil {
+		return err
+	}
+
+	resetter, ok := devicePlugin.(device.DeviceResetter)
+	if !ok {
+		return nil
+	}
+
+	err = resetter.Reset(d.DeviceIDs)
+	if err == device.ErrResetNotSupported {
+		return nil
+	}
+	return err
+}
+
+// Devices returns the detected devices. Devices the plugin failed to reset
+// are returned as unhealthy.
 func (i *instanceManager) Devices() []*device.DeviceGroup {
 	i.deviceLock.RLock()
 	defer i.deviceLock.RUnlock()
-	return i.devices
+
+	if len(i.resetFailures) == 0 {
+		return i.devices
+	}
+
+	out := make([]*device.DeviceGroup, len(i.devices))
+	for gi, group := range i.devices {
+		g := *group
+		g.Devices = make([]*device.Device, len(group.Devices))
+		for di, dev := range group.Devices {
+			desc, failed := i.resetFailures[dev.ID]
+			if !failed {
+				g.Devices[di] = dev
+				continue
+			}
+
+			d := *dev
+			d.Healthy = false
+			d.HealthDesc = desc
+			g.Devices[di] = &d
+		}
+		out[gi] = &g
+	}
+	return out
 }
 
 // WaitForFirstFingerprint waits until either the plugin fingerprints, the
@@ -429,6 +531,30 @@ func (i *instanceManager) handleFingerprint(f *device.FingerprintResponse) error
 	// Store the new devices
 	i.devices = f.Devices
 
+	// Forget the reset failures of devices that are no longer fingerprinted,
+	// such as replaced devices
+	if len(i.resetFailures) != 0 {
+		fingerprinted := make(map[string]struct{})
+		for _, group := range f.Devices {
+			for _, d := range group.Devices {
+				fingerprinted[d.ID] = struct{}{}
+			}
+		}
+
+		pruned := false
+		for id := range i.resetFailures {
+			if _, ok := fingerprinted[id]; !ok {
+				delete(i.resetFailures, id)
+				pruned = true
+			}
+		}
+		if pruned {
+			if err := i.storeResetFailures(helper.CopyMapStringString(i.resetFailures)); err != nil {
+				i.logger.Error("failed to persist devices that failed to reset", "error", err)
+			}
+		}
+	}
+
 	// Mark that we have received data
 	if !i.hasFingerprinted {
 		close(i.firstFingerprintCh)
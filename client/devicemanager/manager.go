@@ -26,6 +26,11 @@ type Manager interface {
 	// Reserve is used to reserve a set of devices
 	Reserve(d *structs.AllocatedDeviceResource) (*device.ContainerReservation, error)
 
+	// Reset is used to reset a set of devices once the task they were
+	// reserved for has exited. Devices that fail to reset are marked
+	// unhealthy.
+	Reset(d *structs.AllocatedDeviceResource) error
+
 	// AllStats is used to retrieve all the latest statistics for all devices.
 	AllStats() []*device.DeviceGroupStats
 
@@ -51,6 +56,9 @@ type UpdateNodeDevicesFn func(devices []*structs.NodeDeviceResource)
 // StorePluginReattachFn is used to store plugin reattachment configurations.
 type StorePluginReattachFn func(*plugin.ReattachConfig) error
 
+// StoreResetFailuresFn is used to store the devices a plugin failed to reset.
+type StoreResetFailuresFn func(map[string]string) error
+
 // Config is used to configure a device manager
 type Config struct {
 	// Logger is the logger used by the device manager
@@ -103,8 +111,14 @@ type manager struct {
 	instances map[loader.PluginID]*instanceManager
 
 	// reattachConfigs stores the plugin reattach configs
-	reattachConfigs    map[loader.PluginID]*pstructs.ReattachConfig
-	reattachConfigLock sync.Mutex
+	reattachConfigs map[loader.PluginID]*pstructs.ReattachConfig
+
+	// resetFailures stores the devices each plugin failed to reset
+	resetFailures map[loader.PluginID]map[string]string
+
+	// stateLock guards the reattach configs and reset failures, which are
+	// persisted together
+	stateLock sync.Mutex
 }
 
 // New returns a new device manager
@@ -121,6 +135,7 @@ func New(c *Config) *manager {
 		statsInterval:    c.StatsInterval,
 		instances:        make(map[loader.PluginID]*instanceManager),
 		reattachConfigs:  make(map[loader.PluginID]*pstructs.ReattachConfig),
+		resetFailures:    make(map[loader.PluginID]map[string]string),
 		fingerprintResCh: make(chan struct{}, 1),
 	}
 }
@@ -136,6 +151,12 @@ func (m *manager) Run() {
 	// and if there are shut them down.
 	m.cleanupStalePlugins()
 
+	// Devices that failed to reset before the agent restarted must remain
+	// unhealthy
+	if err := m.restoreResetFailures(); err != nil {
+		m.logger.Error("failed to restore devices that failed to reset", "error", err)
+	}
+
 	// Get device plugins
 	devices := m.loader.Catalog()[base.PluginTypeDevice]
 	if len(devices) == 0 {
@@ -150,15 +171,24 @@ func (m *manager) Run() {
 			id := id
 			return m.storePluginReattachConfig(id, c)
 		}
+		storeResetFailuresFn := func(failures map[string]string) error {
+			id := id
+			return m.storeResetFailures(id, failures)
+		}
+		m.stateLock.Lock()
+		resetFailures := m.resetFailures[id]
+		m.stateLock.Unlock()
 		m.instances[id] = newInstanceManager(&instanceManagerConfig{
-			Logger:           m.logger,
-			Ctx:              m.ctx,
-			Loader:           m.loader,
-			StoreReattach:    storeFn,
-			PluginConfig:     m.pluginConfig,
-			Id:               &id,
-			FingerprintOutCh: m.fingerprintResCh,
-			StatsInterval:    m.statsInterval,
+			Logger:             m.logger,
+			Ctx:                m.ctx,
+			Loader:             m.loader,
+			StoreReattach:      storeFn,
+			ResetFailures:      resetFailures,
+			StoreResetFailures: storeResetFailuresFn,
+			PluginConfig:       m.pluginConfig,
+			Id:                 &id,
+			FingerprintOutCh:   m.fingerprintResCh,
+			StatsInterval:      m.statsInterval,
 		})
 	}
 
@@ -236,6 +266,22 @@ func (m *manager) Reserve(d *structs.AllocatedDeviceResource) (*device.Container
 	return nil, UnknownDeviceErrFromAllocated("failed to reserve devices", d)
 }
 
+// Reset resets the given allocated device. If the device is unknown, an
+// UnknownDeviceErr is returned.
+func (m *manager) Reset(d *structs.AllocatedDeviceResource) error {
+	// Go through each plugin and see if it has the devices
+	for _, i := range m.instances {
+		if !i.HasDevices(d) {
+			continue
+		}
+
+		// We found a match so reset
+		return i.Reset(d)
+	}
+
+	return UnknownDeviceErrFromAllocated("failed to reset devices", d)
+}
+
 // AllStats returns statistics for all the devices
 func (m *manager) AllStats() []*device.DeviceGroupStats {
 	// Go through each plugin and collect stats
@@ -300,16 +346,58 @@ func (m *manager) cleanupStalePlugins() error {
 	return mErr.ErrorOrNil()
 }
 
+// restoreResetFailures reads the devices the plugins failed to reset from the
+// device managers state.
+func (m *manager) restoreResetFailures() error {
+	s, err := m.state.GetDevicePluginState()
+	if err != nil {
+		return fmt.Errorf("failed to read plugin state: %v", err)
+	}
+
+	// No state was stored so there is nothing to do.
+	if s == nil {
+		return nil
+	}
+
+	m.stateLock.Lock()
+	defer m.stateLock.Unlock()
+	for name, failures := range s.ResetFailures {
+		id := loader.PluginID{Name: name, PluginType: base.PluginTypeDevice}
+		m.resetFailures[id] = failures
+	}
+	return nil
+}
+
 // storePluginReattachConfig is used as a callback to the instance managers and
 // persists thhe plugin reattach configurations.
 func (m *manager) storePluginReattachConfig(id loader.PluginID, c *plugin.ReattachConfig) error {
-	m.reattachConfigLock.Lock()
-	defer m.reattachConfigLock.Unlock()
+	m.stateLock.Lock()
+	defer m.stateLock.Unlock()
 
 	// Store the new reattach config
 	m.reattachConfigs[id] = pstructs.ReattachConfigFromGoPlugin(c)
 
-	// Persist the state
+	return m.persistState()
+}
+
+// storeResetFailures is used as a callback to the instance managers and
+// persists the devices they failed to reset.
+func (m *manager) storeResetFailures(id loader.PluginID, failures map[string]string) error {
+	m.stateLock.Lock()
+	defer m.stateLock.Unlock()
+
+	if len(failures) == 0 {
+		delete(m.resetFailures, id)
+	} else {
+		m.resetFailures[id] = failures
+	}
+
+	return m.persistState()
+}
+
+// persistState persists the plugin reattach configurations and reset
+// failures. The caller must hold the stateLock.
+func (m *manager) persistState() error {
 	s := &state.PluginState{
 		ReattachConfigs: make(map[string]*pstructs.ReattachConfig, len(m.reattachConfigs)),
 	}
@@ -318,5 +406,12 @@ func (m *manager) storePluginReattachConfig(id loader.PluginID, c *plugin.Reatta
 		s.ReattachConfigs[id.Name] = c
 	}
 
+	if len(m.resetFailures) != 0 {
+		s.ResetFailures = make(map[string]map[string]string, len(m.resetFailures))
+		for id, failures := range m.resetFailures {
+			s.ResetFailures[id.Name] = failures
+		}
+	}
+
 	return m.state.PutDevicePluginState(s)
 }
@@ -427,6 +427,147 @@ func TestManager_Reserve(t *testing.T) {
 	}
 }
 
+// Test that devices which fail to reset are reported as unhealthy
+func TestManager_Reset(t *testing.T) {
+	ci.Parallel(t)
+	r := require.New(t)
+
+	config, updateCh, catalog := baseTestConfig(t)
+
+	// The nvidia plugin fails to reset its second device, while the intel
+	// plugin doesn't support resetting devices
+	pluginInfoNvidia := pluginInfoResponse("nvidia")
+	var reset []string
+	deviceNvidia := &device.MockDevicePlugin{
+		MockPlugin: &base.MockPlugin{
+			PluginInfoF:   base.StaticInfo(pluginInfoNvidia),
+			ConfigSchemaF: base.TestConfigSchema(),
+			SetConfigF:    base.NoopSetConfig(),
+		},
+		FingerprintF: device.StaticFingerprinter([]*device.DeviceGroup{nvidiaDeviceGroup}),
+		ReserveF:     deviceReserveFn,
+		StatsF:       device.StaticStats([]*device.DeviceGroupStats{nvidiaDeviceGroupStats}),
+		ResetF: func(ids []string) error {
+			reset = append(reset, ids...)
+			for _, id := range ids {
+				if id == nvidiaDevice1ID {
+					return fmt.Errorf("device busy")
+				}
+			}
+			return nil
+		},
+	}
+	pluginInfoIntel := pluginInfoResponse("intel")
+	deviceIntel := &device.MockDevicePlugin{
+		MockPlugin: &base.MockPlugin{
+			PluginInfoF:   base.StaticInfo(pluginInfoIntel),
+			ConfigSchemaF: base.TestConfigSchema(),
+			SetConfigF:    base.NoopSetConfig(),
+		},
+		FingerprintF: device.StaticFingerprinter([]*device.DeviceGroup{intelDeviceGroup}),
+		ReserveF:     deviceReserveFn,
+		StatsF:       device.StaticStats([]*device.DeviceGroupStats{intelDeviceGroupStats}),
+	}
+	configureCatalogWith(catalog, map[*base.PluginInfoResponse]loader.PluginInstance{
+		pluginInfoNvidia: loader.MockBasicExternalPlugin(deviceNvidia, device.ApiVersion010),
+		pluginInfoIntel:  loader.MockBasicExternalPlugin(deviceIntel, device.ApiVersion010),
+	})
+
+	m := New(config)
+	m.Run()
+	defer m.Shutdown()
+
+	// Wait till we get a fingerprint result
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	<-m.WaitForFirstFingerprint(ctx)
+	r.NoError(ctx.Err())
+
+	r.NoError(m.Reset(&structs.AllocatedDeviceResource{
+		Vendor:    "nvidia",
+		Type:      "gpu",
+		Name:      "1080ti",
+		DeviceIDs: []string{nvidiaDevice0ID},
+	}))
+	r.NoError(m.Reset(&structs.AllocatedDeviceResource{
+		Vendor:    "intel",
+		Type:      "gpu",
+		Name:      "640GT",
+		DeviceIDs: []string{intelDeviceID},
+	}))
+	r.Error(m.Reset(&structs.AllocatedDeviceResource{
+		Vendor:    "intel",
+		Type:      "gpu",
+		Name:      "foo",
+		DeviceIDs: []string{intelDeviceID},
+	}))
+
+	err := m.Reset(&structs.AllocatedDeviceResource{
+		Vendor:    "nvidia",
+		Type:      "gpu",
+		Name:      "1080ti",
+		DeviceIDs: []string{nvidiaDevice1ID},
+	})
+	r.Error(err)
+	r.Contains(err.Error(), "device busy")
+	r.Equal([]string{nvidiaDevice0ID, nvidiaDevice1ID}, reset)
+
+	// The device which failed to reset is reported as unhealthy
+	testutil.WaitForResult(func() (bool, error) {
+		var devices []*structs.NodeDeviceResource
+		select {
+		case devices = <-updateCh:
+		case <-time.After(100 * time.Millisecond):
+			return false, fmt.Errorf("no device update")
+		}
+		for _, d := range devices {
+			for _, inst := range d.Instances {
+				switch inst.ID {
+				case nvidiaDevice1ID:
+					if inst.Healthy || !strings.Contains(inst.HealthDescription, "device busy") {
+						return false, fmt.Errorf("expected unhealthy device: %#v", inst)
+					}
+				default:
+					if !inst.Healthy {
+						return false, fmt.Errorf("expected healthy device: %#v", inst)
+					}
+				}
+			}
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	// The fingerprinted devices aren't modified
+	r.True(nvidiaDeviceGroup.Devices[1].Healthy)
+
+	// The device which failed to reset is persisted
+	ps, err := config.State.GetDevicePluginState()
+	r.NoError(err)
+	r.Len(ps.ResetFailures, 1)
+	r.Contains(ps.ResetFailures["nvidia"][nvidiaDevice1ID], "device busy")
+
+	// and remains unhealthy after the agent restarts
+	m.Shutdown()
+	m2 := New(config)
+	m2.Run()
+	defer m2.Shutdown()
+
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel2()
+	<-m2.WaitForFirstFingerprint(ctx2)
+	r.NoError(ctx2.Err())
+
+	nvidia := m2.instances[loader.PluginInfoID(pluginInfoNvidia)]
+	r.NotNil(nvidia)
+	devices := nvidia.Devices()
+	r.Len(devices, 1)
+	r.True(devices[0].Devices[0].Healthy)
+	r.False(devices[0].Devices[1].Healthy)
+	r.Contains(devices[0].Devices[1].HealthDesc, "device busy")
+}
+
 // Test that shutdown shutsdown the plugins
 func TestManager_Shutdown(t *testing.T) {
 	ci.Parallel(t)
@@ -8,4 +8,8 @@ type PluginState struct {
 	// ReattachConfigs are the set of reattach configs for plugins launched by
 	// the device manager
 	ReattachConfigs map[string]*pstructs.ReattachConfig
+
+	// ResetFailures maps the names of the device plugins to the devices they
+	// failed to reset, which remain unhealthy across restarts of the agent
+	ResetFailures map[string]map[string]string
 }
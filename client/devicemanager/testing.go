@@ -7,6 +7,7 @@ import (
 )
 
 type ReserveFn func(d *structs.AllocatedDeviceResource) (*device.ContainerReservation, error)
+type ResetFn func(d *structs.AllocatedDeviceResource) error
 type AllStatsFn func() []*device.DeviceGroupStats
 type DeviceStatsFn func(d *structs.AllocatedDeviceResource) (*device.DeviceGroupStats, error)
 
@@ -14,6 +15,10 @@ func NoopReserve(*structs.AllocatedDeviceResource) (*device.ContainerReservation
 	return nil, nil
 }
 
+func NoopReset(*structs.AllocatedDeviceResource) error {
+	return nil
+}
+
 func NoopAllStats() []*device.DeviceGroupStats {
 	return nil
 }
@@ -25,6 +30,7 @@ func NoopDeviceStats(*structs.AllocatedDeviceResource) (*device.DeviceGroupStats
 func NoopMockManager() *MockManager {
 	return &MockManager{
 		ReserveF:     NoopReserve,
+		ResetF:       NoopReset,
 		AllStatsF:    NoopAllStats,
 		DeviceStatsF: NoopDeviceStats,
 	}
@@ -32,6 +38,7 @@ func NoopMockManager() *MockManager {
 
 type MockManager struct {
 	ReserveF     ReserveFn
+	ResetF       ResetFn
 	AllStatsF    AllStatsFn
 	DeviceStatsF DeviceStatsFn
 }
@@ -45,6 +52,10 @@ func (m *MockManager) Reserve(d *structs.AllocatedDeviceResource) (*device.Conta
 	return m.ReserveF(d)
 }
 
+func (m *MockManager) Reset(d *structs.AllocatedDeviceResource) error {
+	return m.ResetF(d)
+}
+
 func (m *MockManager) DeviceStats(d *structs.AllocatedDeviceResource) (*device.DeviceGroupStats, error) {
 	return m.DeviceStatsF(d)
 }
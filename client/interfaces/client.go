@@ -21,3 +21,9 @@ type AllocStateHandler interface {
 type DeviceStatsReporter interface {
 	LatestDeviceResourceStats([]*structs.AllocatedDeviceResource) []*device.DeviceGroupStats
 }
+
+// IdentitySigner requests the signed workload identities of tasks from the
+// servers.
+type IdentitySigner interface {
+	SignIdentities(alloc *structs.Allocation, task string, identities []string) (map[string]*structs.SignedWorkloadIdentity, error)
+}
@@ -0,0 +1,46 @@
+package cgutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// sysDevBlock is the directory of the block devices by device number.
+const sysDevBlock = "/sys/dev/block"
+
+// BlockDevice returns the major and minor numbers of the disk holding path,
+// which can be used to limit disk IO with cgroups. Partitions are resolved to
+// their disk since IO limits can only be set on whole disks.
+func BlockDevice(path string) (uint32, uint32, error) {
+	var st unix.Stat_t
+	if err := unix.Stat(path, &st); err != nil {
+		return 0, 0, err
+	}
+	major, minor := unix.Major(st.Dev), unix.Minor(st.Dev)
+
+	dir, err := filepath.EvalSymlinks(filepath.Join(sysDevBlock, fmt.Sprintf("%d:%d", major, minor)))
+	if os.IsNotExist(err) {
+		return 0, 0, fmt.Errorf("%s is not on a block device", path)
+	} else if err != nil {
+		return 0, 0, err
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "partition")); os.IsNotExist(err) {
+		return major, minor, nil
+	} else if err != nil {
+		return 0, 0, err
+	}
+
+	dev, err := os.ReadFile(filepath.Join(filepath.Dir(dir), "dev"))
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to find the disk of partition %d:%d: %v", major, minor, err)
+	}
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(dev)), "%d:%d", &major, &minor); err != nil {
+		return 0, 0, fmt.Errorf("failed to parse device number %q: %v", dev, err)
+	}
+	return major, minor, nil
+}
@@ -0,0 +1,38 @@
+package cgutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockDevice(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	major, minor, err := BlockDevice(dir)
+	if err != nil {
+		t.Skipf("temp dir isn't on a block device: %v", err)
+	}
+
+	// The device must be a whole disk, not a partition
+	sysDir := filepath.Join(sysDevBlock, fmt.Sprintf("%d:%d", major, minor))
+	_, err = os.Stat(sysDir)
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(sysDir, "partition"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestBlockDevice_NotBlockDevice(t *testing.T) {
+	ci.Parallel(t)
+
+	_, _, err := BlockDevice("/proc")
+	require.EqualError(t, err, "/proc is not on a block device")
+
+	_, _, err = BlockDevice(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
@@ -3,6 +3,8 @@
 
 package cgutil
 
+import "errors"
+
 const (
 	DefaultCgroupParent = ""
 )
@@ -12,3 +14,9 @@ const (
 func FindCgroupMountpointDir() (string, error) {
 	return "", nil
 }
+
+// BlockDevice returns the major and minor numbers of the disk holding path,
+// which is not supported on this platform.
+func BlockDevice(path string) (uint32, uint32, error) {
+	return 0, 0, errors.New("block devices are only supported on linux")
+}
@@ -9,7 +9,8 @@ import (
 
 // CpusetManager is used to setup cpuset cgroups for each task. A pool of shared cpus is managed for
 // tasks which don't require any reserved cores and a cgroup is managed seperetly for each task which
-// require reserved cores.
+// require reserved cores. The manager also tracks the NUMA topology of the host, so the memory of the
+// tasks with a NUMA affinity is bound to the NUMA nodes of their reserved cores.
 type CpusetManager interface {
 	// Init should be called before any tasks are managed to ensure the cgroup parent exists and
 	// check that proper permissions are granted to manage cgroups.
@@ -24,6 +25,10 @@ type CpusetManager interface {
 	// CgroupPathFor returns a callback for getting the cgroup path and any error that may have occurred during
 	// cgroup initialization. The callback will block if the cgroup has not been created
 	CgroupPathFor(allocID, taskName string) CgroupPathGetter
+
+	// MemoryNodesFor returns the NUMA nodes the memory of the task is bound to, which is empty if
+	// its memory isn't bound.
+	MemoryNodesFor(allocID, taskName string) cpuset.CPUSet
 }
 
 // CgroupPathGetter is a function which returns the cgroup path and any error which ocured during cgroup initialization.
@@ -34,6 +39,7 @@ type TaskCgroupInfo struct {
 	CgroupPath         string
 	RelativeCgroupPath string
 	Cpuset             cpuset.CPUSet
+	MemoryNodes        cpuset.CPUSet
 	Error              error
 }
 
@@ -54,3 +60,23 @@ func (n noopCpusetManager) RemoveAlloc(allocID string) {
 func (n noopCpusetManager) CgroupPathFor(allocID, task string) CgroupPathGetter {
 	return func(context.Context) (string, error) { return "", nil }
 }
+
+func (n noopCpusetManager) MemoryNodesFor(allocID, task string) cpuset.CPUSet {
+	return cpuset.New()
+}
+
+// taskNUMA returns the NUMA configuration of the task of the allocation.
+func taskNUMA(alloc *structs.Allocation, task string) *structs.NUMA {
+	if alloc.Job == nil {
+		return nil
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return nil
+	}
+	t := tg.LookupTask(task)
+	if t == nil || t.Resources == nil {
+		return nil
+	}
+	return t.Resources.NUMA
+}
@@ -43,6 +43,9 @@ type cpusetManager struct {
 
 	parentCpuset cpuset.CPUSet
 
+	// topology is the NUMA topology of the host, which is empty if unknown.
+	topology NUMATopology
+
 	// all exported functions are synchronized
 	mu sync.Mutex
 
@@ -65,11 +68,20 @@ func (c *cpusetManager) AddAlloc(alloc *structs.Allocation) {
 		if taskCpuset.Size() > 0 {
 			cgroupPath, relativeCgroupPath = c.getCgroupPathsForTask(alloc.ID, task)
 		}
-		allocInfo[task] = &TaskCgroupInfo{
+		info := &TaskCgroupInfo{
 			CgroupPath:         cgroupPath,
 			RelativeCgroupPath: relativeCgroupPath,
 			Cpuset:             taskCpuset,
+			MemoryNodes:        cpuset.New(),
+		}
+		if numa := taskNUMA(alloc, task); numa.Binds() && taskCpuset.Size() > 0 {
+			info.MemoryNodes = c.topology.NodesOf(taskCpuset)
+			if numa.Affinity == structs.NUMAAffinityRequire && info.MemoryNodes.Size() > 1 {
+				info.Error = fmt.Errorf("reserved cores %s span NUMA nodes %s but the NUMA affinity requires a single node",
+					taskCpuset, info.MemoryNodes)
+			}
 		}
+		allocInfo[task] = info
 	}
 	c.mu.Lock()
 	c.cgroupInfo[alloc.ID] = allocInfo
@@ -119,6 +131,17 @@ func (c *cpusetManager) CgroupPathFor(allocID, task string) CgroupPathGetter {
 
 }
 
+func (c *cpusetManager) MemoryNodesFor(allocID, task string) cpuset.CPUSet {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	taskInfo, ok := c.cgroupInfo[allocID][task]
+	if !ok {
+		return cpuset.New()
+	}
+	return taskInfo.MemoryNodes
+}
+
 type allocTaskCgroupInfo map[string]*TaskCgroupInfo
 
 // Init checks that the cgroup parent and expected child cgroups have been created
@@ -159,6 +182,12 @@ func (c *cpusetManager) Init() error {
 		return err
 	}
 
+	c.topology, err = readNUMATopology(numaNodesPath)
+	if err != nil {
+		c.logger.Warn("failed to detect NUMA topology, task memory won't be bound to NUMA nodes", "error", err)
+		c.topology = NUMATopology{}
+	}
+
 	c.doneCh = make(chan struct{})
 	c.signalCh = make(chan struct{})
 
@@ -239,15 +268,19 @@ func (c *cpusetManager) reconcileCpusets() {
 			continue
 		}
 
-		// copy cpuset.mems from parent
-		_, parentMems, err := getCpusetSubsystemSettings(filepath.Dir(info.CgroupPath))
-		if err != nil {
-			c.logger.Error("failed to read parent cgroup settings for task", "path", info.CgroupPath, "error", err)
-			info.Error = err
-			continue
+		// bind the memory to the NUMA nodes of the task or copy cpuset.mems from parent
+		mems := info.MemoryNodes.String()
+		if info.MemoryNodes.Size() == 0 {
+			_, parentMems, err := getCpusetSubsystemSettings(filepath.Dir(info.CgroupPath))
+			if err != nil {
+				c.logger.Error("failed to read parent cgroup settings for task", "path", info.CgroupPath, "error", err)
+				info.Error = err
+				continue
+			}
+			mems = parentMems
 		}
-		if err := fscommon.WriteFile(info.CgroupPath, "cpuset.mems", parentMems); err != nil {
-			c.logger.Error("failed to write cgroup cpuset.mems setting for task", "path", info.CgroupPath, "mems", parentMems, "error", err)
+		if err := fscommon.WriteFile(info.CgroupPath, "cpuset.mems", mems); err != nil {
+			c.logger.Error("failed to write cgroup cpuset.mems setting for task", "path", info.CgroupPath, "mems", mems, "error", err)
 			info.Error = err
 			continue
 		}
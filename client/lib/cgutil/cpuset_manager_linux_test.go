@@ -1,14 +1,17 @@
 package cgutil
 
 import (
+	"context"
 	"io/ioutil"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"syscall"
 	"testing"
 
 	"github.com/hashicorp/nomad/lib/cpuset"
 	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -177,3 +180,43 @@ func TestCpusetManager_RemoveAlloc(t *testing.T) {
 	require.True(t, reservedCpus.Equals(alloc2Cpuset))
 
 }
+
+func TestCpusetManager_AddAlloc_NUMA(t *testing.T) {
+	manager, cleanup := tmpCpusetManager(t)
+	defer cleanup()
+	require.NoError(t, manager.Init())
+
+	// the 0th core and the 0th NUMA node probably exist, the 1st node is
+	// only used to check allocs spanning several nodes
+	manager.topology = NUMATopology{
+		0: cpuset.New(0),
+		1: cpuset.New(1),
+	}
+
+	alloc := mock.Alloc()
+	alloc.AllocatedResources.Tasks["web"].Cpu.ReservedCores = cpuset.New(0).ToSlice()
+	alloc.Job.TaskGroups[0].Tasks[0].Resources.NUMA = &structs.NUMA{Affinity: structs.NUMAAffinityPrefer}
+	manager.AddAlloc(alloc)
+	require.Equal(t, []uint16{0}, manager.MemoryNodesFor(alloc.ID, "web").ToSlice())
+
+	// force reconcile
+	manager.reconcileCpusets()
+
+	// check that the task memory is bound to the NUMA node of its core
+	taskInfo := manager.cgroupInfo[alloc.ID]["web"]
+	require.NoError(t, taskInfo.Error)
+	taskMemsRaw, err := ioutil.ReadFile(filepath.Join(taskInfo.CgroupPath, "cpuset.mems"))
+	require.NoError(t, err)
+	require.Equal(t, "0", strings.TrimSpace(string(taskMemsRaw)))
+
+	// check that tasks requiring a single NUMA node fail if their cores span
+	// several nodes
+	spanning := mock.Alloc()
+	spanning.AllocatedResources.Tasks["web"].Cpu.ReservedCores = cpuset.New(0, 1).ToSlice()
+	spanning.Job.TaskGroups[0].Tasks[0].Resources.NUMA = &structs.NUMA{Affinity: structs.NUMAAffinityRequire}
+	manager.AddAlloc(spanning)
+	require.Equal(t, []uint16{0, 1}, manager.MemoryNodesFor(spanning.ID, "web").ToSlice())
+
+	_, err = manager.CgroupPathFor(spanning.ID, "web")(context.Background())
+	require.EqualError(t, err, "reserved cores 0-1 span NUMA nodes 0-1 but the NUMA affinity requires a single node")
+}
@@ -0,0 +1,64 @@
+package cgutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/lib/cpuset"
+)
+
+// numaNodesPath is the sysfs directory holding a nodeN directory for each NUMA
+// node of the host.
+var numaNodesPath = "/sys/devices/system/node"
+
+// NUMATopology maps the NUMA nodes of the host to their cpus.
+type NUMATopology map[uint16]cpuset.CPUSet
+
+// readNUMATopology reads the NUMA topology of the host from path, where the
+// cpulist file of each nodeN directory lists the cpus of the node. An empty
+// topology is returned if the host doesn't report NUMA nodes.
+func readNUMATopology(path string) (NUMATopology, error) {
+	topology := NUMATopology{}
+	entries, err := ioutil.ReadDir(path)
+	if os.IsNotExist(err) {
+		return topology, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || !strings.HasPrefix(name, "node") {
+			continue
+		}
+		node, err := strconv.ParseUint(strings.TrimPrefix(name, "node"), 10, 16)
+		if err != nil {
+			continue
+		}
+
+		raw, err := ioutil.ReadFile(filepath.Join(path, name, "cpulist"))
+		if err != nil {
+			return nil, err
+		}
+		cpus, err := cpuset.Parse(strings.TrimSpace(string(raw)))
+		if err != nil {
+			return nil, err
+		}
+		topology[uint16(node)] = cpus
+	}
+	return topology, nil
+}
+
+// NodesOf returns the NUMA nodes holding the cpus.
+func (t NUMATopology) NodesOf(cpus cpuset.CPUSet) cpuset.CPUSet {
+	var nodes []uint16
+	for node, nodeCpus := range t {
+		if nodeCpus.ContainsAny(cpus) {
+			nodes = append(nodes, node)
+		}
+	}
+	return cpuset.New(nodes...)
+}
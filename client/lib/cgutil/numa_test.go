@@ -0,0 +1,41 @@
+package cgutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/lib/cpuset"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNUMATopology(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	for node, cpus := range map[string]string{"node0": "0-3,8-11", "node1": "4-7,12-15"} {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, node), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, node, "cpulist"), []byte(cpus+"\n"), 0644))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "power"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "possible"), []byte("0-1\n"), 0644))
+
+	topology, err := readNUMATopology(dir)
+	require.NoError(t, err)
+	require.Len(t, topology, 2)
+	require.Equal(t, []uint16{0, 1, 2, 3, 8, 9, 10, 11}, topology[0].ToSlice())
+	require.Equal(t, []uint16{4, 5, 6, 7, 12, 13, 14, 15}, topology[1].ToSlice())
+
+	require.Equal(t, []uint16{0}, topology.NodesOf(cpuset.New(1, 9)).ToSlice())
+	require.Equal(t, []uint16{1}, topology.NodesOf(cpuset.New(12)).ToSlice())
+	require.Equal(t, []uint16{0, 1}, topology.NodesOf(cpuset.New(3, 4)).ToSlice())
+	require.Empty(t, topology.NodesOf(cpuset.New(32)).ToSlice())
+
+	// Hosts without NUMA nodes have an empty topology
+	topology, err = readNUMATopology(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	require.Empty(t, topology)
+	require.Empty(t, topology.NodesOf(cpuset.New(0)).ToSlice())
+}
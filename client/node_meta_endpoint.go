@@ -0,0 +1,52 @@
+package client
+
+import (
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	nstructs "github.com/hashicorp/nomad/nomad/structs"
+)
+
+// NodeMeta endpoint is used for reading and updating the dynamic metadata of
+// the node.
+type NodeMeta struct {
+	c *Client
+}
+
+// Apply is used to set or unset the dynamic metadata of the node.
+func (n *NodeMeta) Apply(args *nstructs.NodeMetaApplyRequest, reply *nstructs.NodeMetaResponse) error {
+	defer metrics.MeasureSince([]string{"client", "node_meta", "apply"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := n.c.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return nstructs.ErrPermissionDenied
+	}
+
+	if err := args.Validate(); err != nil {
+		return nstructs.NewErrRPCCoded(400, err.Error())
+	}
+
+	resp, err := n.c.applyNodeMeta(args.Meta)
+	if err != nil {
+		return err
+	}
+	*reply = *resp
+	return nil
+}
+
+// Read is used to read the metadata of the node.
+func (n *NodeMeta) Read(args *nstructs.NodeSpecificRequest, reply *nstructs.NodeMetaResponse) error {
+	defer metrics.MeasureSince([]string{"client", "node_meta", "read"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := n.c.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return nstructs.ErrPermissionDenied
+	}
+
+	*reply = *n.c.blockingNodeMeta(args.QueryOptions)
+	return nil
+}
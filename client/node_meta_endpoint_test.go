@@ -0,0 +1,177 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/state"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/mock"
+	nstructs "github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeMeta_Apply(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	client, cleanup := TestClient(t, func(c *config.Config) {
+		c.Node.Meta = map[string]string{
+			"static": "a",
+			"rack":   "r1",
+		}
+		c.StateDBFactory = func(logger hclog.Logger, _ string) (state.StateDB, error) {
+			return state.NewMemDB(logger), nil
+		}
+	})
+	defer cleanup()
+
+	// Set a new key, override a static key and unset another one
+	req := &nstructs.NodeMetaApplyRequest{
+		Meta: map[string]*string{
+			"zone":   helper.StringToPtr("z1"),
+			"rack":   helper.StringToPtr("r2"),
+			"static": nil,
+		},
+	}
+	var resp nstructs.NodeMetaResponse
+	require.NoError(client.ClientRPC("NodeMeta.Apply", req, &resp))
+	require.Equal("z1", resp.Meta["zone"])
+	require.Equal("r2", resp.Meta["rack"])
+	require.NotContains(resp.Meta, "static")
+	require.Equal("a", resp.Static["static"])
+	require.Equal("r1", resp.Static["rack"])
+	require.Contains(resp.Dynamic, "static")
+	require.Nil(resp.Dynamic["static"])
+	require.Equal(resp.Meta, client.Node().Meta)
+
+	// The dynamic metadata is persisted
+	dynamic, err := client.stateDB.GetNodeMeta()
+	require.NoError(err)
+	require.Equal(resp.Dynamic, dynamic)
+
+	// Unsetting a dynamic key removes it entirely
+	req = &nstructs.NodeMetaApplyRequest{
+		Meta: map[string]*string{"zone": nil},
+	}
+	require.NoError(client.ClientRPC("NodeMeta.Apply", req, &resp))
+	require.NotContains(resp.Dynamic, "zone")
+	require.NotContains(resp.Meta, "zone")
+
+	// Reading returns the same metadata
+	var readResp nstructs.NodeMetaResponse
+	require.NoError(client.ClientRPC("NodeMeta.Read", &nstructs.NodeSpecificRequest{}, &readResp))
+	require.Equal(resp, readResp)
+}
+
+func TestNodeMeta_Apply_Invalid(t *testing.T) {
+	ci.Parallel(t)
+
+	client, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	req := &nstructs.NodeMetaApplyRequest{
+		Meta: map[string]*string{"${bad}": helper.StringToPtr("x")},
+	}
+	var resp nstructs.NodeMetaResponse
+	err := client.ClientRPC("NodeMeta.Apply", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid characters")
+}
+
+func TestNodeMeta_ACL(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	server, addr, root, cleanupS := testACLServer(t, nil)
+	defer cleanupS()
+
+	client, cleanupC := TestClient(t, func(c *config.Config) {
+		c.Servers = []string{addr}
+		c.ACLEnabled = true
+	})
+	defer cleanupC()
+
+	applyReq := func(token string) *nstructs.NodeMetaApplyRequest {
+		req := &nstructs.NodeMetaApplyRequest{
+			Meta: map[string]*string{"zone": helper.StringToPtr("z1")},
+		}
+		req.AuthToken = token
+		return req
+	}
+
+	// Try request without a token and expect failure
+	var resp nstructs.NodeMetaResponse
+	err := client.ClientRPC("NodeMeta.Apply", applyReq(""), &resp)
+	require.EqualError(err, nstructs.ErrPermissionDenied.Error())
+
+	// Try request with a read token and expect failure to write but
+	// success to read
+	token := mock.CreatePolicyAndToken(t, server.State(), 1005, "read", mock.NodePolicy(acl.PolicyRead))
+	err = client.ClientRPC("NodeMeta.Apply", applyReq(token.SecretID), &resp)
+	require.EqualError(err, nstructs.ErrPermissionDenied.Error())
+
+	readReq := &nstructs.NodeSpecificRequest{}
+	readReq.AuthToken = token.SecretID
+	require.NoError(client.ClientRPC("NodeMeta.Read", readReq, &resp))
+
+	// Try request with a management token
+	require.NoError(client.ClientRPC("NodeMeta.Apply", applyReq(root.SecretID), &resp))
+	require.Equal("z1", resp.Meta["zone"])
+}
+
+func TestNodeMeta_Read_Blocking(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	client, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	var resp nstructs.NodeMetaResponse
+	require.NoError(client.ClientRPC("NodeMeta.Read", &nstructs.NodeSpecificRequest{}, &resp))
+	index := resp.Index
+	require.NotZero(index)
+
+	// Set the metadata after the blocking read started
+	time.AfterFunc(100*time.Millisecond, func() {
+		req := &nstructs.NodeMetaApplyRequest{
+			Meta: map[string]*string{"zone": helper.StringToPtr("z1")},
+		}
+		var applyResp nstructs.NodeMetaResponse
+		if err := client.ClientRPC("NodeMeta.Apply", req, &applyResp); err != nil {
+			t.Errorf("failed to apply metadata: %v", err)
+		}
+	})
+
+	req := &nstructs.NodeSpecificRequest{
+		QueryOptions: nstructs.QueryOptions{
+			MinQueryIndex: index,
+			MaxQueryTime:  5 * time.Second,
+		},
+	}
+	start := time.Now()
+	require.NoError(client.ClientRPC("NodeMeta.Read", req, &resp))
+	require.GreaterOrEqual(time.Since(start), 100*time.Millisecond)
+	require.Less(time.Since(start), 5*time.Second)
+	require.Equal("z1", resp.Meta["zone"])
+	require.Greater(resp.Index, index)
+
+	// Applying the same metadata again doesn't change the index
+	index = resp.Index
+	require.NoError(client.ClientRPC("NodeMeta.Apply", &nstructs.NodeMetaApplyRequest{
+		Meta: map[string]*string{"zone": helper.StringToPtr("z1")},
+	}, &resp))
+	require.Equal(index, resp.Index)
+
+	// Blocking reads time out without a change
+	req.MinQueryIndex = index
+	req.MaxQueryTime = 100 * time.Millisecond
+	start = time.Now()
+	require.NoError(client.ClientRPC("NodeMeta.Read", req, &resp))
+	require.GreaterOrEqual(time.Since(start), 100*time.Millisecond)
+	require.Equal(index, resp.Index)
+}
@@ -0,0 +1,130 @@
+// Package taskhookmanager is used to manage task hook plugins
+package taskhookmanager
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/pluginmanager"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/helper/pluginutils/singleton"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/taskhook"
+)
+
+// Manager is the interface used to manage task hook plugins
+type Manager interface {
+	pluginmanager.PluginManager
+
+	// Plugins returns the names of the task hook plugins in the order their
+	// hooks are called in.
+	Plugins() []string
+
+	// Dispense returns the task hook plugin of the given name, launching it
+	// if it isn't running.
+	Dispense(name string) (taskhook.TaskHookPlugin, error)
+}
+
+// Config is used to configure a task hook manager
+type Config struct {
+	// Logger is the logger used by the task hook manager
+	Logger log.Logger
+
+	// Loader is the plugin loader
+	Loader loader.PluginCatalog
+
+	// PluginConfig is the config passed to the launched plugins
+	PluginConfig *base.AgentConfig
+}
+
+// manager is used to manage a set of task hook plugins
+type manager struct {
+	// logger is the logger used by the task hook manager
+	logger log.Logger
+
+	// loader is the plugin loader
+	loader loader.PluginCatalog
+
+	// pluginConfig is the config passed to the launched plugins
+	pluginConfig *base.AgentConfig
+
+	// plugins are the names of the task hook plugins, sorted by name
+	plugins []string
+
+	// instances are the dispensed plugins, indexed by name
+	instances     map[string]loader.PluginInstance
+	instancesLock sync.Mutex
+}
+
+// New returns a new task hook manager
+func New(c *Config) *manager {
+	var plugins []string
+	for _, p := range c.Loader.Catalog()[base.PluginTypeTaskHook] {
+		plugins = append(plugins, p.Name)
+	}
+	sort.Strings(plugins)
+
+	return &manager{
+		logger:       c.Logger.Named("task_hook_mgr"),
+		loader:       c.Loader,
+		pluginConfig: c.PluginConfig,
+		plugins:      plugins,
+		instances:    make(map[string]loader.PluginInstance, len(plugins)),
+	}
+}
+
+// PluginType identifies this manager to the plugin manager and satisfies the PluginManager interface.
+func (*manager) PluginType() string { return base.PluginTypeTaskHook }
+
+// Run launches the task hook plugins, such that failures to launch them are
+// detected when the client starts rather than when the first task starts.
+func (m *manager) Run() {
+	for _, name := range m.plugins {
+		if _, err := m.Dispense(name); err != nil {
+			m.logger.Error("failed to launch task hook plugin", "plugin", name, "error", err)
+		}
+	}
+}
+
+// Shutdown kills the launched task hook plugins.
+func (m *manager) Shutdown() {
+	m.instancesLock.Lock()
+	defer m.instancesLock.Unlock()
+
+	for name, instance := range m.instances {
+		instance.Kill()
+		delete(m.instances, name)
+	}
+}
+
+func (m *manager) Plugins() []string {
+	return m.plugins
+}
+
+func (m *manager) Dispense(name string) (taskhook.TaskHookPlugin, error) {
+	m.instancesLock.Lock()
+	defer m.instancesLock.Unlock()
+
+	// See if we already have a running instance
+	instance, ok := m.instances[name]
+	if !ok || instance.Exited() {
+		var err error
+		instance, err = m.loader.Dispense(name, base.PluginTypeTaskHook, m.pluginConfig, m.logger)
+		if err == singleton.SingletonPluginExited {
+			// Retry as the error just indicates the singleton has exited
+			instance, err = m.loader.Dispense(name, base.PluginTypeTaskHook, m.pluginConfig, m.logger)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to start task hook plugin %q: %v", name, err)
+		}
+		m.instances[name] = instance
+	}
+
+	hook, ok := instance.Plugin().(taskhook.TaskHookPlugin)
+	if !ok {
+		return nil, fmt.Errorf("plugin %q does not implement the task hook plugin interface", name)
+	}
+	return hook, nil
+}
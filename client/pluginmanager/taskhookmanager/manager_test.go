@@ -0,0 +1,87 @@
+package taskhookmanager
+
+import (
+	"fmt"
+	"testing"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/taskhook"
+	"github.com/stretchr/testify/require"
+)
+
+func testManager(t *testing.T, plugins map[string]*taskhook.MockTaskHookPlugin) (*manager, map[string]int) {
+	dispensed := make(map[string]int)
+	catalog := &loader.MockCatalog{
+		CatalogF: func() map[string][]*base.PluginInfoResponse {
+			var infos []*base.PluginInfoResponse
+			for name := range plugins {
+				infos = append(infos, &base.PluginInfoResponse{
+					Name: name,
+					Type: base.PluginTypeTaskHook,
+				})
+			}
+			return map[string][]*base.PluginInfoResponse{
+				base.PluginTypeTaskHook: infos,
+				base.PluginTypeDevice:   {{Name: "nvidia", Type: base.PluginTypeDevice}},
+			}
+		},
+		DispenseF: func(name, pluginType string, _ *base.AgentConfig, _ log.Logger) (loader.PluginInstance, error) {
+			require.Equal(t, base.PluginTypeTaskHook, pluginType)
+			p, ok := plugins[name]
+			if !ok {
+				return nil, fmt.Errorf("no matching plugin")
+			}
+			dispensed[name]++
+			return loader.MockBasicExternalPlugin(p, taskhook.ApiVersion010), nil
+		},
+	}
+
+	return New(&Config{
+		Logger:       testlog.HCLogger(t),
+		Loader:       catalog,
+		PluginConfig: &base.AgentConfig{},
+	}), dispensed
+}
+
+func TestManager_Plugins(t *testing.T) {
+	ci.Parallel(t)
+
+	m, _ := testManager(t, map[string]*taskhook.MockTaskHookPlugin{
+		"quota":   {},
+		"billing": {},
+	})
+	require.Equal(t, []string{"billing", "quota"}, m.Plugins())
+	require.Equal(t, base.PluginTypeTaskHook, m.PluginType())
+}
+
+func TestManager_Dispense(t *testing.T) {
+	ci.Parallel(t)
+
+	billing := &taskhook.MockTaskHookPlugin{}
+	m, dispensed := testManager(t, map[string]*taskhook.MockTaskHookPlugin{
+		"billing": billing,
+	})
+
+	// Running the manager launches the plugins
+	m.Run()
+	require.Equal(t, 1, dispensed["billing"])
+
+	// Running instances are reused
+	p, err := m.Dispense("billing")
+	require.NoError(t, err)
+	require.Equal(t, billing, p)
+	require.Equal(t, 1, dispensed["billing"])
+
+	// Exited instances are launched again
+	m.Shutdown()
+	_, err = m.Dispense("billing")
+	require.NoError(t, err)
+	require.Equal(t, 2, dispensed["billing"])
+
+	_, err = m.Dispense("unknown")
+	require.Error(t, err)
+}
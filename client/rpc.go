@@ -24,6 +24,7 @@ type rpcEndpoints struct {
 	FileSystem  *FileSystem
 	Allocations *Allocations
 	Agent       *Agent
+	NodeMeta    *NodeMeta
 }
 
 // ClientRPC is used to make a local, client only RPC call
@@ -260,6 +261,7 @@ func (c *Client) setupClientRpc(rpcs map[string]interface{}) {
 		c.endpoints.FileSystem = NewFileSystemEndpoint(c)
 		c.endpoints.Allocations = NewAllocationsEndpoint(c)
 		c.endpoints.Agent = NewAgentEndpoint(c)
+		c.endpoints.NodeMeta = &NodeMeta{c}
 		c.setupClientRpcServer(c.rpcServer)
 	}
 
@@ -274,6 +276,7 @@ func (c *Client) setupClientRpcServer(server *rpc.Server) {
 	server.Register(c.endpoints.FileSystem)
 	server.Register(c.endpoints.Allocations)
 	server.Register(c.endpoints.Agent)
+	server.Register(c.endpoints.NodeMeta)
 }
 
 // rpcConnListener is a long lived function that listens for new connections
@@ -380,6 +380,34 @@ func TestStateDB_DynamicRegistry(t *testing.T) {
 	})
 }
 
+// TestStateDB_NodeMeta asserts the behavior of dynamic node metadata related
+// StateDB methods.
+func TestStateDB_NodeMeta(t *testing.T) {
+	ci.Parallel(t)
+
+	testDB(t, func(t *testing.T, db StateDB) {
+		require := require.New(t)
+
+		// Getting nonexistent state should return nils
+		meta, err := db.GetNodeMeta()
+		require.NoError(err)
+		require.Nil(meta)
+
+		// Putting metadata, including unset keys, should work
+		zone := "z1"
+		state := map[string]*string{
+			"zone": &zone,
+			"rack": nil,
+		}
+		require.NoError(db.PutNodeMeta(state))
+
+		// Getting should return the available state
+		meta, err = db.GetNodeMeta()
+		require.NoError(err)
+		require.Equal(state, meta)
+	})
+}
+
 // TestStateDB_Upgrade asserts calling Upgrade on new databases always
 // succeeds.
 func TestStateDB_Upgrade(t *testing.T) {
@@ -80,6 +80,14 @@ func (m *ErrDB) PutDynamicPluginRegistryState(state *dynamicplugins.RegistryStat
 	return fmt.Errorf("Error!")
 }
 
+func (m *ErrDB) GetNodeMeta() (map[string]*string, error) {
+	return nil, fmt.Errorf("Error!")
+}
+
+func (m *ErrDB) PutNodeMeta(map[string]*string) error {
+	return fmt.Errorf("Error!")
+}
+
 // GetDevicePluginState stores the device manager's plugin state or returns an
 // error.
 func (m *ErrDB) GetDevicePluginState() (*dmstate.PluginState, error) {
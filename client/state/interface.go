@@ -81,6 +81,12 @@ type StateDB interface {
 	// PutDynamicPluginRegistryState is used to store the dynamic plugin manager's state.
 	PutDynamicPluginRegistryState(state *dynamicplugins.RegistryState) error
 
+	// GetNodeMeta is used to retrieve the dynamic metadata of the node.
+	GetNodeMeta() (map[string]*string, error)
+
+	// PutNodeMeta is used to store the dynamic metadata of the node.
+	PutNodeMeta(map[string]*string) error
+
 	// Close the database. Unsafe for further use after calling regardless
 	// of return value.
 	Close() error
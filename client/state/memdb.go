@@ -36,6 +36,9 @@ type MemDB struct {
 	// dynamicmanager -> registry-state
 	dynamicManagerPs *dynamicplugins.RegistryState
 
+	// key -> value or nil
+	nodeMeta map[string]*string
+
 	logger hclog.Logger
 
 	mu sync.RWMutex
@@ -227,6 +230,19 @@ func (m *MemDB) PutDynamicPluginRegistryState(ps *dynamicplugins.RegistryState)
 	return nil
 }
 
+func (m *MemDB) GetNodeMeta() (map[string]*string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.nodeMeta, nil
+}
+
+func (m *MemDB) PutNodeMeta(nm map[string]*string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nodeMeta = nm
+	return nil
+}
+
 func (m *MemDB) Close() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
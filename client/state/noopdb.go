@@ -87,6 +87,14 @@ func (n NoopDB) GetDynamicPluginRegistryState() (*dynamicplugins.RegistryState,
 	return nil, nil
 }
 
+func (n NoopDB) GetNodeMeta() (map[string]*string, error) {
+	return nil, nil
+}
+
+func (n NoopDB) PutNodeMeta(map[string]*string) error {
+	return nil
+}
+
 func (n NoopDB) Close() error {
 	return nil
 }
@@ -95,6 +95,13 @@ var (
 
 	// registryStateKey is the key at which dynamic plugin registry state is stored
 	registryStateKey = []byte("registry_state")
+
+	// nodeMetaBucketName is the bucket name containing the dynamic metadata
+	// of the node
+	nodeMetaBucketName = []byte("nodemeta")
+
+	// nodeMetaKey is the key at which the dynamic node metadata is stored
+	nodeMetaKey = []byte("meta")
 )
 
 // taskBucketName returns the bucket name for the given task name.
@@ -717,6 +724,52 @@ func (s *BoltStateDB) GetDynamicPluginRegistryState() (*dynamicplugins.RegistryS
 	return ps, nil
 }
 
+// nodeMeta wraps the dynamic node metadata for storage.
+type nodeMeta struct {
+	Meta map[string]*string
+}
+
+// PutNodeMeta stores the dynamic metadata of the node or returns an error.
+func (s *BoltStateDB) PutNodeMeta(meta map[string]*string) error {
+	return s.db.Update(func(tx *boltdd.Tx) error {
+		bkt, err := tx.CreateBucketIfNotExists(nodeMetaBucketName)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(nodeMetaKey, &nodeMeta{Meta: meta})
+	})
+}
+
+// GetNodeMeta retrieves the dynamic metadata of the node or returns an
+// error. It returns nil if no metadata has been stored.
+func (s *BoltStateDB) GetNodeMeta() (map[string]*string, error) {
+	var meta map[string]*string
+
+	err := s.db.View(func(tx *boltdd.Tx) error {
+		bkt := tx.Bucket(nodeMetaBucketName)
+		if bkt == nil {
+			// No state, return
+			return nil
+		}
+
+		var nm nodeMeta
+		if err := bkt.Get(nodeMetaKey, &nm); err != nil {
+			if !boltdd.IsErrNotFound(err) {
+				return fmt.Errorf("failed to read dynamic node metadata: %v", err)
+			}
+			return nil
+		}
+		meta = nm.Meta
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return meta, nil
+}
+
 // init initializes metadata entries in a newly created state database.
 func (s *BoltStateDB) init() error {
 	return s.db.Update(func(tx *boltdd.Tx) error {
@@ -385,14 +385,19 @@ type ClientCSIControllerListSnapshotsRequest struct {
 	StartingToken string
 	Secrets       structs.CSISecrets
 
+	// SourceVolumeID is the storage provider's ID for the volume the
+	// snapshots are filtered by, if set
+	SourceVolumeID string
+
 	CSIControllerQuery
 }
 
 func (req *ClientCSIControllerListSnapshotsRequest) ToCSIRequest() *csi.ControllerListSnapshotsRequest {
 	return &csi.ControllerListSnapshotsRequest{
-		MaxEntries:    req.MaxEntries,
-		StartingToken: req.StartingToken,
-		Secrets:       req.Secrets,
+		MaxEntries:     req.MaxEntries,
+		StartingToken:  req.StartingToken,
+		SourceVolumeID: req.SourceVolumeID,
+		Secrets:        req.Secrets,
 	}
 }
 
@@ -39,6 +39,11 @@ type MonitorRequest struct {
 	// LogLevel is the log level filter we want to stream logs on
 	LogLevel string
 
+	// LogLevelOverrides is a comma separated list of subsystem=level pairs
+	// that stream logs from those subsystems at a different level than
+	// LogLevel, ex. "raft=TRACE,http=WARN"
+	LogLevelOverrides string
+
 	// LogJSON specifies if log format should be unstructured or json
 	LogJSON bool
 
@@ -2,6 +2,7 @@ package taskenv
 
 import (
 	"fmt"
+	"math"
 	"net"
 	"os"
 	"path/filepath"
@@ -40,6 +41,22 @@ const (
 	// CpuLimit is the environment variable with the tasks CPU limit in MHz.
 	CpuLimit = "NOMAD_CPU_LIMIT"
 
+	// CpuLimitCores is the environment variable with the number of cores the
+	// task may use, which may be fractional.
+	CpuLimitCores = "NOMAD_CPU_LIMIT_CORES"
+
+	// MemLimitBytes is the environment variable with the hard memory limit
+	// of the task in bytes.
+	MemLimitBytes = "NOMAD_MEMORY_LIMIT_BYTES"
+
+	// GoMaxProcs is the environment variable setting the number of threads
+	// running Go code, set if the task opts in.
+	GoMaxProcs = "GOMAXPROCS"
+
+	// JavaToolOptions is the environment variable passing options to the
+	// JVM, set if the task opts in.
+	JavaToolOptions = "JAVA_TOOL_OPTIONS"
+
 	// AllocID is the environment variable for passing the allocation ID.
 	AllocID = "NOMAD_ALLOC_ID"
 
@@ -364,6 +381,10 @@ type Builder struct {
 	// templateEnv are env vars set from templates
 	templateEnv map[string]string
 
+	// vaultClusterTemplateEnv are env vars set from the templates reading
+	// from each named Vault cluster
+	vaultClusterTemplateEnv map[string]map[string]string
+
 	// hostEnv are environment variables filtered from the host
 	hostEnv map[string]string
 
@@ -395,8 +416,12 @@ type Builder struct {
 	clientTaskSecretsDir string
 
 	cpuLimit         int64
+	cpuCores         int
+	nodeCpuShares    int64
+	nodeCpuCores     uint16
 	memLimit         int64
 	memMaxLimit      int64
+	runtimeEnv       *structs.RuntimeEnvConfig
 	taskName         string
 	allocIndex       int
 	datacenter       string
@@ -408,6 +433,7 @@ type Builder struct {
 	vaultToken       string
 	vaultNamespace   string
 	injectVaultToken bool
+	vaultClusters    map[string]*vaultClusterEnv
 	jobID            string
 	jobName          string
 	jobParentID      string
@@ -489,6 +515,37 @@ func (b *Builder) buildEnv(allocDir, localDir, secretsDir string,
 	if b.cpuLimit != 0 {
 		envMap[CpuLimit] = strconv.FormatInt(b.cpuLimit, 10)
 	}
+	cores := b.cpuLimitCores()
+	if cores != 0 {
+		envMap[CpuLimitCores] = strconv.FormatFloat(cores, 'f', -1, 64)
+	}
+	if b.memLimit != 0 {
+		memLimit := b.memLimit
+		if b.memMaxLimit > memLimit {
+			memLimit = b.memMaxLimit
+		}
+		envMap[MemLimitBytes] = strconv.FormatInt(memLimit*1024*1024, 10)
+	}
+
+	// Add the runtime hints the task opted into
+	if b.runtimeEnv != nil {
+		procs := int(math.Ceil(cores))
+		if b.runtimeEnv.GOMAXPROCS && procs != 0 {
+			envMap[GoMaxProcs] = strconv.Itoa(procs)
+		}
+		if b.runtimeEnv.JavaHeapPercent != 0 {
+			var opts []string
+			if b.memLimit != 0 {
+				opts = append(opts, fmt.Sprintf("-Xmx%dm", b.memLimit*int64(b.runtimeEnv.JavaHeapPercent)/100))
+			}
+			if procs != 0 {
+				opts = append(opts, fmt.Sprintf("-XX:ActiveProcessorCount=%d", procs))
+			}
+			if len(opts) != 0 {
+				envMap[JavaToolOptions] = strings.Join(opts, " ")
+			}
+		}
+	}
 
 	// Add the task metadata
 	if b.allocId != "" {
@@ -546,6 +603,20 @@ func (b *Builder) buildEnv(allocDir, localDir, secretsDir string,
 		envMap[VaultNamespace] = b.vaultNamespace
 	}
 
+	// Build the Vault Tokens and Namespaces of the named clusters
+	for cluster, v := range b.vaultClusters {
+		if !v.inject {
+			continue
+		}
+		suffix := vaultClusterEnvSuffix(cluster)
+		if v.token != "" {
+			envMap[VaultToken+suffix] = v.token
+		}
+		if v.namespace != "" {
+			envMap[VaultNamespace+suffix] = v.namespace
+		}
+	}
+
 	// Copy and interpolate task meta
 	for k, v := range b.taskMeta {
 		envMap[hargs.ReplaceEnv(k, nodeAttrs, envMap)] = hargs.ReplaceEnv(v, nodeAttrs, envMap)
@@ -586,6 +657,11 @@ func (b *Builder) buildEnv(allocDir, localDir, secretsDir string,
 	for k, v := range b.templateEnv {
 		envMap[k] = v
 	}
+	for _, m := range b.vaultClusterTemplateEnv {
+		for k, v := range m {
+			envMap[k] = v
+		}
+	}
 
 	// Clean keys (see #2405)
 	prefixesToClean := [...]string{AddrPrefix, IpPrefix, PortPrefix, HostPortPrefix, MetaPrefix}
@@ -664,6 +740,22 @@ func (b *Builder) SetDeviceHookEnv(hookName string, envs map[string]string) *Bui
 	return b.setHookEnvLocked(hookName, envs)
 }
 
+// cpuLimitCores returns the number of cores the task may use, rounded to two
+// decimals, or zero if it can't be determined. Tasks reserving cores may use
+// exactly those, other tasks may use the share of the cores of the node
+// matching their CPU limit.
+func (b *Builder) cpuLimitCores() float64 {
+	if b.cpuCores != 0 {
+		return float64(b.cpuCores)
+	}
+	if b.cpuLimit == 0 || b.nodeCpuShares == 0 || b.nodeCpuCores == 0 {
+		return 0
+	}
+
+	cores := float64(b.cpuLimit) * float64(b.nodeCpuCores) / float64(b.nodeCpuShares)
+	return math.Max(math.Round(cores*100)/100, 0.01)
+}
+
 // setTask is called from NewBuilder to populate task related environment
 // variables.
 func (b *Builder) setTask(task *structs.Task) *Builder {
@@ -671,6 +763,7 @@ func (b *Builder) setTask(task *structs.Task) *Builder {
 		return b
 	}
 	b.taskName = task.Name
+	b.runtimeEnv = task.RuntimeEnv.Copy()
 	b.envvars = make(map[string]string, len(task.Env))
 	for k, v := range task.Env {
 		b.envvars[k] = v
@@ -685,6 +778,7 @@ func (b *Builder) setTask(task *structs.Task) *Builder {
 		b.memLimit = int64(task.Resources.MemoryMB)
 		b.memMaxLimit = int64(task.Resources.MemoryMaxMB)
 		b.cpuLimit = int64(task.Resources.CPU)
+		b.cpuCores = task.Resources.Cores
 	}
 	return b
 }
@@ -735,6 +829,7 @@ func (b *Builder) setAlloc(alloc *structs.Allocation) *Builder {
 		// Populate task resources
 		if tr, ok := alloc.AllocatedResources.Tasks[b.taskName]; ok {
 			b.cpuLimit = tr.Cpu.CpuShares
+			b.cpuCores = len(tr.Cpu.ReservedCores)
 			b.memLimit = tr.Memory.MemoryMB
 			b.memMaxLimit = tr.Memory.MemoryMaxMB
 
@@ -802,6 +897,10 @@ func (b *Builder) setNode(n *structs.Node) *Builder {
 	b.nodeAttrs[nodeClassKey] = n.NodeClass
 	b.nodeAttrs[nodeDcKey] = n.Datacenter
 	b.datacenter = n.Datacenter
+	if n.NodeResources != nil {
+		b.nodeCpuShares = n.NodeResources.Cpu.CpuShares
+		b.nodeCpuCores = n.NodeResources.Cpu.TotalCpuCores
+	}
 
 	// Set up the attributes.
 	for k, v := range n.Attributes {
@@ -987,6 +1086,19 @@ func (b *Builder) SetTemplateEnv(m map[string]string) *Builder {
 	return b
 }
 
+// SetVaultClusterTemplateEnv sets the env vars of the templates reading from
+// the named Vault cluster, which are rendered separately from the other
+// templates of the task.
+func (b *Builder) SetVaultClusterTemplateEnv(cluster string, m map[string]string) *Builder {
+	b.mu.Lock()
+	if b.vaultClusterTemplateEnv == nil {
+		b.vaultClusterTemplateEnv = make(map[string]map[string]string)
+	}
+	b.vaultClusterTemplateEnv[cluster] = m
+	b.mu.Unlock()
+	return b
+}
+
 func (b *Builder) SetVaultToken(token, namespace string, inject bool) *Builder {
 	b.mu.Lock()
 	b.vaultToken = token
@@ -996,6 +1108,35 @@ func (b *Builder) SetVaultToken(token, namespace string, inject bool) *Builder {
 	return b
 }
 
+// vaultClusterEnv is the Vault token of a named Vault cluster.
+type vaultClusterEnv struct {
+	token     string
+	namespace string
+	inject    bool
+}
+
+// SetVaultClusterToken sets the Vault token of the named Vault cluster,
+// exposed as VAULT_TOKEN_<CLUSTER> if inject is true.
+func (b *Builder) SetVaultClusterToken(cluster, token, namespace string, inject bool) *Builder {
+	b.mu.Lock()
+	if b.vaultClusters == nil {
+		b.vaultClusters = make(map[string]*vaultClusterEnv)
+	}
+	b.vaultClusters[cluster] = &vaultClusterEnv{
+		token:     token,
+		namespace: namespace,
+		inject:    inject,
+	}
+	b.mu.Unlock()
+	return b
+}
+
+// vaultClusterEnvSuffix returns the suffix of the environment variables of
+// the named Vault cluster.
+func vaultClusterEnvSuffix(cluster string) string {
+	return "_" + strings.ToUpper(strings.Replace(cluster, "-", "_", -1))
+}
+
 // addPort keys and values for other tasks to an env var map
 func addPort(m map[string]string, taskName, ip, portLabel string, port int) {
 	key := fmt.Sprintf("%s%s_%s", AddrPrefix, taskName, portLabel)
@@ -1019,7 +1160,27 @@ func addGroupPort(m map[string]string, port structs.Port) {
 }
 
 func addPorts(m map[string]string, ports structs.AllocatedPorts) {
+	// Ports mapped on several host networks have a mapping for each of them.
+	// The first one sets the env vars of the port, and each sets env vars
+	// suffixed with the name of its host network.
+	networks := make(map[string]int, len(ports))
 	for _, p := range ports {
+		networks[p.Label]++
+	}
+
+	seen := make(map[string]bool, len(ports))
+	for _, p := range ports {
+		if networks[p.Label] > 1 && p.HostNetwork != "" {
+			suffix := "_" + helper.CleanEnvVar(p.HostNetwork, '_')
+			m[HostAddrPrefix+p.Label+suffix] = fmt.Sprintf("%s:%d", p.HostIP, p.Value)
+			m[HostIpPrefix+p.Label+suffix] = p.HostIP
+			m[HostPortPrefix+p.Label+suffix] = strconv.Itoa(p.Value)
+		}
+		if seen[p.Label] {
+			continue
+		}
+		seen[p.Label] = true
+
 		m[AddrPrefix+p.Label] = fmt.Sprintf("%s:%d", p.HostIP, p.Value)
 		m[HostAddrPrefix+p.Label] = fmt.Sprintf("%s:%d", p.HostIP, p.Value)
 		m[IpPrefix+p.Label] = p.HostIP
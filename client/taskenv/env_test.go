@@ -220,6 +220,7 @@ func TestEnvironment_AsList(t *testing.T) {
 		"NOMAD_REGION=global",
 		"NOMAD_MEMORY_LIMIT=256",
 		"NOMAD_MEMORY_MAX_LIMIT=512",
+		"NOMAD_MEMORY_LIMIT_BYTES=536870912",
 		"NOMAD_META_ELB_CHECK_INTERVAL=30s",
 		"NOMAD_META_ELB_CHECK_MIN=3",
 		"NOMAD_META_ELB_CHECK_TYPE=http",
@@ -241,6 +242,82 @@ func TestEnvironment_AsList(t *testing.T) {
 	require.Equal(t, exp, act)
 }
 
+func TestEnvironment_ResourceLimits(t *testing.T) {
+	ci.Parallel(t)
+
+	n := mock.Node()
+	n.NodeResources.Cpu.CpuShares = 4000
+	n.NodeResources.Cpu.TotalCpuCores = 4
+
+	cases := []struct {
+		name       string
+		cpu        structs.AllocatedCpuResources
+		runtimeEnv *structs.RuntimeEnvConfig
+		env        map[string]string
+		exp        map[string]string
+	}{
+		{
+			name: "shares",
+			cpu:  structs.AllocatedCpuResources{CpuShares: 1500},
+			exp: map[string]string{
+				CpuLimitCores: "1.5",
+				MemLimitBytes: "268435456",
+			},
+		},
+		{
+			name: "reserved cores",
+			cpu:  structs.AllocatedCpuResources{CpuShares: 2000, ReservedCores: []uint16{2, 3}},
+			exp: map[string]string{
+				CpuLimitCores: "2",
+				MemLimitBytes: "268435456",
+			},
+		},
+		{
+			name:       "runtime env",
+			cpu:        structs.AllocatedCpuResources{CpuShares: 1500},
+			runtimeEnv: &structs.RuntimeEnvConfig{GOMAXPROCS: true, JavaHeapPercent: 75},
+			exp: map[string]string{
+				CpuLimitCores:   "1.5",
+				MemLimitBytes:   "268435456",
+				GoMaxProcs:      "2",
+				JavaToolOptions: "-Xmx192m -XX:ActiveProcessorCount=2",
+			},
+		},
+		{
+			name:       "task env overrides",
+			cpu:        structs.AllocatedCpuResources{CpuShares: 100},
+			runtimeEnv: &structs.RuntimeEnvConfig{GOMAXPROCS: true},
+			env:        map[string]string{GoMaxProcs: "8"},
+			exp: map[string]string{
+				CpuLimitCores: "0.1",
+				MemLimitBytes: "268435456",
+				GoMaxProcs:    "8",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := mock.Alloc()
+			a.AllocatedResources.Tasks["web"].Cpu = tc.cpu
+			a.AllocatedResources.Tasks["web"].Memory = structs.AllocatedMemoryResources{MemoryMB: 256}
+			task := a.Job.TaskGroups[0].Tasks[0]
+			task.RuntimeEnv = tc.runtimeEnv
+			task.Env = tc.env
+
+			env := NewBuilder(n, a, task, "global").Build().Map()
+			for _, k := range []string{CpuLimitCores, MemLimitBytes, GoMaxProcs, JavaToolOptions} {
+				v, ok := tc.exp[k]
+				if !ok {
+					require.NotContains(t, env, k)
+					continue
+				}
+				require.Equal(t, v, env[k], k)
+			}
+		})
+	}
+}
+
 func TestEnvironment_AllValues(t *testing.T) {
 	ci.Parallel(t)
 
@@ -595,6 +672,36 @@ func TestEnvironment_DeviceHookVars(t *testing.T) {
 	require.Contains(all, "foo")
 }
 
+// TestEnvironment_VaultClusterTemplateEnv asserts the env vars of templates
+// reading from named Vault clusters are merged with the other template env
+// vars.
+func TestEnvironment_VaultClusterTemplateEnv(t *testing.T) {
+	ci.Parallel(t)
+
+	n := mock.Node()
+	a := mock.Alloc()
+	builder := NewBuilder(n, a, a.Job.TaskGroups[0].Tasks[0], "global")
+
+	builder.SetTemplateEnv(map[string]string{
+		"foo": "bar",
+	})
+	builder.SetVaultClusterTemplateEnv("other", map[string]string{
+		"baz": "quux",
+	})
+
+	out := builder.Build().All()
+	require.Equal(t, "bar", out["foo"])
+	require.Equal(t, "quux", out["baz"])
+
+	// Re-rendering the default templates keeps the other cluster's vars
+	builder.SetTemplateEnv(map[string]string{
+		"foo": "123",
+	})
+	out = builder.Build().All()
+	require.Equal(t, "123", out["foo"])
+	require.Equal(t, "quux", out["baz"])
+}
+
 func TestEnvironment_Interpolate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -665,6 +772,55 @@ func TestEnvironment_DashesInTaskName(t *testing.T) {
 	}
 }
 
+// TestEnvironment_PortsHostNetworks asserts the env vars of ports mapped on
+// several host networks.
+func TestEnvironment_PortsHostNetworks(t *testing.T) {
+	ci.Parallel(t)
+
+	a := mock.Alloc()
+	a.AllocatedResources.Shared.Ports = structs.AllocatedPorts{
+		{
+			Label:       "http",
+			Value:       25000,
+			To:          8080,
+			HostIP:      "10.0.0.1",
+			HostNetwork: "mgmt",
+		},
+		{
+			Label:       "http",
+			Value:       25000,
+			To:          8080,
+			HostIP:      "10.0.1.1",
+			HostNetwork: "data-plane",
+		},
+		{
+			Label:       "admin",
+			Value:       25001,
+			HostIP:      "10.0.0.1",
+			HostNetwork: "mgmt",
+		},
+	}
+	task := a.Job.TaskGroups[0].Tasks[0]
+	envMap := NewBuilder(mock.Node(), a, task, "global").Build().Map()
+
+	// The first host network sets the env vars of the port
+	require.Equal(t, "10.0.0.1:25000", envMap["NOMAD_ADDR_http"])
+	require.Equal(t, "10.0.0.1", envMap["NOMAD_HOST_IP_http"])
+	require.Equal(t, "8080", envMap["NOMAD_PORT_http"])
+	require.Equal(t, "25000", envMap["NOMAD_HOST_PORT_http"])
+
+	require.Equal(t, "10.0.0.1:25000", envMap["NOMAD_HOST_ADDR_http_mgmt"])
+	require.Equal(t, "10.0.0.1", envMap["NOMAD_HOST_IP_http_mgmt"])
+	require.Equal(t, "25000", envMap["NOMAD_HOST_PORT_http_mgmt"])
+	require.Equal(t, "10.0.1.1:25000", envMap["NOMAD_HOST_ADDR_http_data_plane"])
+	require.Equal(t, "10.0.1.1", envMap["NOMAD_HOST_IP_http_data_plane"])
+	require.Equal(t, "25000", envMap["NOMAD_HOST_PORT_http_data_plane"])
+
+	// Ports on a single host network don't have suffixed env vars
+	require.Equal(t, "10.0.0.1", envMap["NOMAD_HOST_IP_admin"])
+	require.NotContains(t, envMap, "NOMAD_HOST_IP_admin_mgmt")
+}
+
 // TestEnvironment_UpdateTask asserts env vars and task meta are updated when a
 // task is updated.
 func TestEnvironment_UpdateTask(t *testing.T) {
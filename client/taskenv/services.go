@@ -191,11 +191,13 @@ func interpolateTaskResources(taskEnv *TaskEnv, resources *structs.Resources) {
 
 		for p := 0; p < len(resources.Networks[i].DynamicPorts); p++ {
 			resources.Networks[i].DynamicPorts[p].HostNetwork = taskEnv.ReplaceEnv(resources.Networks[i].DynamicPorts[p].HostNetwork)
+			resources.Networks[i].DynamicPorts[p].HostNetworks = taskEnv.ParseAndReplace(resources.Networks[i].DynamicPorts[p].HostNetworks)
 			resources.Networks[i].DynamicPorts[p].Label = taskEnv.ReplaceEnv(resources.Networks[i].DynamicPorts[p].Label)
 		}
 
 		for p := 0; p < len(resources.Networks[i].ReservedPorts); p++ {
 			resources.Networks[i].ReservedPorts[p].HostNetwork = taskEnv.ReplaceEnv(resources.Networks[i].ReservedPorts[p].HostNetwork)
+			resources.Networks[i].ReservedPorts[p].HostNetworks = taskEnv.ParseAndReplace(resources.Networks[i].ReservedPorts[p].HostNetworks)
 			resources.Networks[i].ReservedPorts[p].Label = taskEnv.ReplaceEnv(resources.Networks[i].ReservedPorts[p].Label)
 		}
 	}
@@ -133,6 +133,10 @@ func formatKVACLToken(token *api.ACLToken) string {
 		output = append(output, "Policies|n/a")
 	} else {
 		output = append(output, fmt.Sprintf("Policies|%v", token.Policies))
+		for _, p := range token.ExpiringPolicies {
+			output = append(output, fmt.Sprintf("Expiring Policy|%s (expires %v)",
+				p.Name, formatTime(p.ExpirationTime)))
+		}
 	}
 
 	// Add the generic output
@@ -3,6 +3,7 @@ package command
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/posener/complete"
@@ -36,6 +37,12 @@ Create Options:
   -policy=""
     Specifies a policy to associate with the token. Can be specified multiple times,
     but only with client type tokens.
+
+  -expiring-policy=""
+    Specifies a policy to associate with the token for a limited amount of time,
+    in the form <policy>:<ttl>, for example "operator:8h". Once the TTL has
+    elapsed the policy no longer applies to the token. Can be specified multiple
+    times, but only with client type tokens.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -43,10 +50,11 @@ Create Options:
 func (c *ACLTokenCreateCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"name":   complete.PredictAnything,
-			"type":   complete.PredictAnything,
-			"global": complete.PredictNothing,
-			"policy": complete.PredictAnything,
+			"name":            complete.PredictAnything,
+			"type":            complete.PredictAnything,
+			"global":          complete.PredictNothing,
+			"policy":          complete.PredictAnything,
+			"expiring-policy": complete.PredictAnything,
 		})
 }
 
@@ -64,6 +72,7 @@ func (c *ACLTokenCreateCommand) Run(args []string) int {
 	var name, tokenType string
 	var global bool
 	var policies []string
+	var expiringPolicies []*api.ACLTokenExpiringPolicy
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.StringVar(&name, "name", "", "")
@@ -73,6 +82,14 @@ func (c *ACLTokenCreateCommand) Run(args []string) int {
 		policies = append(policies, s)
 		return nil
 	}), "policy", "")
+	flags.Var((funcVar)(func(s string) error {
+		p, err := parseExpiringPolicy(s)
+		if err != nil {
+			return err
+		}
+		expiringPolicies = append(expiringPolicies, p)
+		return nil
+	}), "expiring-policy", "")
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
@@ -91,6 +108,8 @@ func (c *ACLTokenCreateCommand) Run(args []string) int {
 		Type:     tokenType,
 		Policies: policies,
 		Global:   global,
+
+		ExpiringPolicies: expiringPolicies,
 	}
 
 	// Get the HTTP client
@@ -111,3 +130,23 @@ func (c *ACLTokenCreateCommand) Run(args []string) int {
 	c.Ui.Output(formatKVACLToken(token))
 	return 0
 }
+
+// parseExpiringPolicy parses an expiring policy flag value of the form
+// <policy>:<ttl>.
+func parseExpiringPolicy(s string) (*api.ACLTokenExpiringPolicy, error) {
+	idx := strings.LastIndex(s, ":")
+	if idx <= 0 {
+		return nil, fmt.Errorf("expiring policy %q must be in the form <policy>:<ttl>", s)
+	}
+	ttl, err := time.ParseDuration(s[idx+1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid TTL for expiring policy %q: %v", s, err)
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("TTL for expiring policy %q must be positive", s)
+	}
+	return &api.ACLTokenExpiringPolicy{
+		Name:          s[:idx],
+		ExpirationTTL: ttl,
+	}, nil
+}
@@ -3,11 +3,13 @@ package command
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/command/agent"
 	"github.com/mitchellh/cli"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestACLTokenCreateCommand(t *testing.T) {
@@ -41,3 +43,17 @@ func TestACLTokenCreateCommand(t *testing.T) {
 		t.Fatalf("bad: %v", out)
 	}
 }
+
+func TestACLTokenCreateCommand_ParseExpiringPolicy(t *testing.T) {
+	ci.Parallel(t)
+
+	p, err := parseExpiringPolicy("operator:8h")
+	require.NoError(t, err)
+	require.Equal(t, "operator", p.Name)
+	require.Equal(t, 8*time.Hour, p.ExpirationTTL)
+
+	for _, in := range []string{"operator", ":8h", "operator:forever", "operator:-1h"} {
+		_, err := parseExpiringPolicy(in)
+		require.Error(t, err, in)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/posener/complete"
 )
 
@@ -35,6 +36,12 @@ Update Options:
   -policy=""
     Specifies a policy to associate with the token. Can be specified multiple times,
     but only with client type tokens.
+
+  -expiring-policy=""
+    Specifies a policy to associate with the token for a limited amount of time,
+    in the form <policy>:<ttl>, for example "operator:8h". Once the TTL has
+    elapsed the policy no longer applies to the token. Can be specified multiple
+    times, but only with client type tokens.
 `
 
 	return strings.TrimSpace(helpText)
@@ -43,10 +50,11 @@ Update Options:
 func (c *ACLTokenUpdateCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"name":   complete.PredictAnything,
-			"type":   complete.PredictAnything,
-			"global": complete.PredictNothing,
-			"policy": complete.PredictAnything,
+			"name":            complete.PredictAnything,
+			"type":            complete.PredictAnything,
+			"global":          complete.PredictNothing,
+			"policy":          complete.PredictAnything,
+			"expiring-policy": complete.PredictAnything,
 		})
 }
 
@@ -64,6 +72,7 @@ func (c *ACLTokenUpdateCommand) Run(args []string) int {
 	var name, tokenType string
 	var global bool
 	var policies []string
+	var expiringPolicies []*api.ACLTokenExpiringPolicy
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.StringVar(&name, "name", "", "")
@@ -73,6 +82,14 @@ func (c *ACLTokenUpdateCommand) Run(args []string) int {
 		policies = append(policies, s)
 		return nil
 	}), "policy", "")
+	flags.Var((funcVar)(func(s string) error {
+		p, err := parseExpiringPolicy(s)
+		if err != nil {
+			return err
+		}
+		expiringPolicies = append(expiringPolicies, p)
+		return nil
+	}), "expiring-policy", "")
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
@@ -119,6 +136,10 @@ func (c *ACLTokenUpdateCommand) Run(args []string) int {
 		token.Policies = policies
 	}
 
+	if len(expiringPolicies) != 0 {
+		token.ExpiringPolicies = expiringPolicies
+	}
+
 	// Update the token
 	updatedToken, _, err := client.ACLTokens().Update(token, nil)
 	if err != nil {
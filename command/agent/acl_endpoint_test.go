@@ -3,8 +3,10 @@ package agent
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -515,3 +517,43 @@ func TestHTTP_OneTimeToken(t *testing.T) {
 		require.EqualError(t, err, structs.ErrPermissionDenied.Error())
 	})
 }
+
+func TestHTTP_ACLCheck(t *testing.T) {
+	ci.Parallel(t)
+	httpACLTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+		token := mock.CreatePolicyAndToken(t, state, 1000, "ci",
+			mock.NamespacePolicy("ci", "", []string{acl.NamespaceCapabilitySubmitJob}))
+
+		check := func(body, namespace string) (interface{}, error) {
+			url := "/v1/acl/check"
+			if namespace != "" {
+				url += "?namespace=" + namespace
+			}
+			req, err := http.NewRequest("POST", url, strings.NewReader(body))
+			require.NoError(t, err)
+			setToken(req, token)
+			return s.Server.ACLCheckRequest(httptest.NewRecorder(), req)
+		}
+
+		// The namespace can be given in the body
+		obj, err := check(`{"Resource": "namespace", "Operation": "submit-job", "Namespace": "ci"}`, "")
+		require.NoError(t, err)
+		require.True(t, obj.(structs.ACLCheckResponse).Allowed)
+
+		// The namespace of the query takes precedence
+		obj, err = check(`{"Resource": "namespace", "Operation": "submit-job", "Namespace": "ci"}`, "default")
+		require.NoError(t, err)
+		require.False(t, obj.(structs.ACLCheckResponse).Allowed)
+
+		_, err = check(`{"Resource": "volume", "Operation": "read"}`, "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid resource")
+
+		// Only PUT and POST are accepted
+		req, err := http.NewRequest("GET", "/v1/acl/check", nil)
+		require.NoError(t, err)
+		_, err = s.Server.ACLCheckRequest(httptest.NewRecorder(), req)
+		require.Error(t, err)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	golog "log"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -26,6 +27,8 @@ import (
 	"github.com/hashicorp/nomad/client/state"
 	"github.com/hashicorp/nomad/command/agent/consul"
 	"github.com/hashicorp/nomad/command/agent/event"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/accesslog"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad"
@@ -54,6 +57,26 @@ const (
 	// MaxRaftMultiplier is a fairly arbitrary upper bound that limits the
 	// amount of performance detuning that's possible.
 	MaxRaftMultiplier = 10
+
+	// defaultNodeScorerTimeout is the time allowed for the external node
+	// scorer to score a node if no timeout is configured.
+	defaultNodeScorerTimeout = 250 * time.Millisecond
+
+	// defaultAccessLogRotateDuration is the time period after which the
+	// access log file is rotated if not configured.
+	defaultAccessLogRotateDuration = 24 * time.Hour
+
+	// defaultSLOMetricsTimeout is the time allowed for the metrics endpoint
+	// to evaluate an SLO query if no timeout is configured.
+	defaultSLOMetricsTimeout = 5 * time.Second
+
+	// defaultGCAutoTuneMinThreshold is the threshold below which the GC
+	// thresholds are never shrunk if no minimum is configured.
+	defaultGCAutoTuneMinThreshold = 5 * time.Minute
+
+	// defaultArtifactCacheMaxSizeMB is the size of the artifact cache if no
+	// size is configured.
+	defaultArtifactCacheMaxSizeMB = 10 * 1024
 )
 
 // Agent is a long running daemon that is used to run both
@@ -70,6 +93,14 @@ type Agent struct {
 	httpLogger log.Logger
 	logOutput  io.Writer
 
+	// eventSink forwards the event stream to an external message broker. It
+	// is nil if disabled.
+	eventSink *eventSink
+
+	// accessLog writes an entry for every request served by the agent. It is
+	// nil if the access log isn't enabled.
+	accessLog *accesslog.Logger
+
 	// EnterpriseAgent holds information and methods for enterprise functionality
 	EnterpriseAgent *EnterpriseAgent
 
@@ -135,6 +166,10 @@ func NewAgent(config *Config, logger log.InterceptLogger, logOutput io.Writer, i
 		return nil, err
 	}
 
+	if err := a.setupAccessLog(); err != nil {
+		return nil, err
+	}
+
 	if err := a.setupServer(); err != nil {
 		return nil, err
 	}
@@ -149,6 +184,10 @@ func NewAgent(config *Config, logger log.InterceptLogger, logOutput io.Writer, i
 		return nil, fmt.Errorf("must have at least client or server mode enabled")
 	}
 
+	if err := a.setupEventSink(); err != nil {
+		return nil, err
+	}
+
 	return a, nil
 }
 
@@ -377,9 +416,17 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 	if maxHPS := agentConfig.Server.MaxHeartbeatsPerSecond; maxHPS != 0 {
 		conf.MaxHeartbeatsPerSecond = maxHPS
 	}
+	if maxStale := agentConfig.Server.MaxStale; maxStale != 0 {
+		conf.MaxStale = maxStale
+	}
 	if failoverTTL := agentConfig.Server.FailoverHeartbeatTTL; failoverTTL != 0 {
 		conf.FailoverHeartbeatTTL = failoverTTL
 	}
+	if limit := agentConfig.Server.JobRegisterQueueLimit; limit < 0 {
+		return nil, fmt.Errorf("job_register_queue_limit must not be negative")
+	} else if limit != 0 {
+		conf.JobRegisterQueueLimit = limit
+	}
 
 	if *agentConfig.Consul.AutoAdvertise && agentConfig.Consul.ServerServiceName == "" {
 		return nil, fmt.Errorf("server_service_name must be set when auto_advertise is enabled")
@@ -393,6 +440,9 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 	// Add the Consul and Vault configs
 	conf.ConsulConfig = agentConfig.Consul
 	conf.VaultConfig = agentConfig.Vault
+	if err := conf.VaultConfig.ValidateClusters(); err != nil {
+		return nil, err
+	}
 
 	// Set the TLS config
 	conf.TLSConfig = agentConfig.TLSConfig
@@ -400,6 +450,9 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 	// Setup telemetry related config
 	conf.StatsCollectionInterval = agentConfig.Telemetry.collectionInterval
 	conf.DisableDispatchedJobSummaryMetrics = agentConfig.Telemetry.DisableDispatchedJobSummaryMetrics
+	conf.NamespaceMetrics = agentConfig.Telemetry.NamespaceMetrics
+	conf.NamespaceMetricsAllowlist = agentConfig.Telemetry.NamespaceMetricsAllowlist
+	conf.NamespaceMetricsLimit = agentConfig.Telemetry.NamespaceMetricsLimit
 
 	if d, err := time.ParseDuration(agentConfig.Limits.RPCHandshakeTimeout); err != nil {
 		return nil, fmt.Errorf("error parsing rpc_handshake_timeout: %v", err)
@@ -449,6 +502,163 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		conf.RaftBoltNoFreelistSync = bolt.NoFreelistSync
 	}
 
+	// Add the scaling webhook configuration
+	if webhook := agentConfig.Server.ScalingWebhook; webhook != nil {
+		u, err := url.Parse(webhook.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse scaling_webhook url: %v", err)
+		}
+		if u.Scheme != "https" || u.Host == "" {
+			return nil, fmt.Errorf("scaling_webhook url must be an https URL")
+		}
+		if webhook.Secret == "" {
+			return nil, fmt.Errorf("scaling_webhook secret must be set")
+		}
+		conf.ScalingWebhookConfig = &structs.ScalingWebhookConfig{
+			URL:    webhook.URL,
+			Secret: webhook.Secret,
+			CAFile: webhook.CAFile,
+		}
+	}
+
+	// Add the deployment webhook configuration
+	if webhook := agentConfig.Server.DeploymentWebhook; webhook != nil {
+		u, err := url.Parse(webhook.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse deployment_webhook url: %v", err)
+		}
+		if u.Scheme != "https" || u.Host == "" {
+			return nil, fmt.Errorf("deployment_webhook url must be an https URL")
+		}
+		if webhook.Secret == "" {
+			return nil, fmt.Errorf("deployment_webhook secret must be set")
+		}
+		conf.DeploymentWebhookConfig = &structs.DeploymentWebhookConfig{
+			URL:    webhook.URL,
+			Secret: webhook.Secret,
+			CAFile: webhook.CAFile,
+		}
+	}
+
+	// Add the external node scorer configuration
+	if scorer := agentConfig.Server.NodeScorer; scorer != nil {
+		if (scorer.Command == "") == (scorer.URL == "") {
+			return nil, fmt.Errorf("node_scorer requires exactly one of command or url")
+		}
+		if scorer.URL != "" {
+			u, err := url.Parse(scorer.URL)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse node_scorer url: %v", err)
+			}
+			if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, fmt.Errorf("node_scorer url must be an http or https URL")
+			}
+		}
+		if scorer.Timeout < 0 {
+			return nil, fmt.Errorf("node_scorer timeout must not be negative")
+		}
+
+		timeout := scorer.Timeout
+		if timeout == 0 {
+			timeout = defaultNodeScorerTimeout
+		}
+		conf.NodeScorerConfig = &structs.NodeScorerConfig{
+			Command: scorer.Command,
+			Args:    helper.CopySliceString(scorer.Args),
+			URL:     scorer.URL,
+			Timeout: timeout,
+		}
+	}
+
+	// Add the metrics endpoint SLO queries are evaluated against
+	if metrics := agentConfig.Server.SLOMetrics; metrics != nil {
+		u, err := url.Parse(metrics.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse slo_metrics address: %v", err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("slo_metrics address must be an http or https URL")
+		}
+		if metrics.Timeout < 0 {
+			return nil, fmt.Errorf("slo_metrics timeout must not be negative")
+		}
+
+		timeout := metrics.Timeout
+		if timeout == 0 {
+			timeout = defaultSLOMetricsTimeout
+		}
+		conf.SLOMetricsConfig = &structs.SLOMetricsConfig{
+			Address: metrics.Address,
+			Timeout: timeout,
+		}
+	}
+
+	// Add the tuning of the GC thresholds
+	if tune := agentConfig.Server.GCAutoTune; tune != nil && tune.Enabled {
+		if tune.MaxHeapMB < 0 || tune.MaxSnapshotMB < 0 {
+			return nil, fmt.Errorf("gc_auto_tune limits must not be negative")
+		}
+		if tune.MaxHeapMB == 0 && tune.MaxSnapshotMB == 0 {
+			return nil, fmt.Errorf("gc_auto_tune requires max_heap_mb or max_snapshot_mb")
+		}
+		if tune.MinThreshold < 0 {
+			return nil, fmt.Errorf("gc_auto_tune min_threshold must not be negative")
+		}
+
+		minThreshold := tune.MinThreshold
+		if minThreshold == 0 {
+			minThreshold = defaultGCAutoTuneMinThreshold
+		}
+		conf.GCAutoTuneConfig = &structs.GCAutoTuneConfig{
+			MaxHeapBytes:     uint64(tune.MaxHeapMB) * 1024 * 1024,
+			MaxSnapshotBytes: uint64(tune.MaxSnapshotMB) * 1024 * 1024,
+			MinThreshold:     minThreshold,
+		}
+	}
+
+	// Add the signing of workload identities
+	if identity := agentConfig.Server.WorkloadIdentity; identity != nil {
+		u, err := url.Parse(identity.Issuer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse workload_identity issuer: %v", err)
+		}
+		if u.Scheme != "https" && u.Scheme != "http" || u.Host == "" {
+			return nil, fmt.Errorf("workload_identity issuer must be an http or https URL")
+		}
+		if identity.RotationInterval != 0 && identity.RotationInterval < structs.WorkloadIdentityMinRotationInterval {
+			return nil, fmt.Errorf("workload_identity rotation_interval must be at least %v", structs.WorkloadIdentityMinRotationInterval)
+		}
+		conf.WorkloadIdentityConfig = &structs.WorkloadIdentityConfig{
+			Issuer:           identity.Issuer,
+			SigningKeyFile:   identity.SigningKeyFile,
+			RotationInterval: identity.RotationInterval,
+		}
+	}
+
+	// Add the scoring plugins enabled on the server
+	seenPlugins := make(map[string]struct{}, len(agentConfig.Server.ScoringPlugins))
+	for _, p := range agentConfig.Server.ScoringPlugins {
+		if p.Name == "" {
+			return nil, fmt.Errorf("scoring_plugin requires a name")
+		}
+		if _, ok := seenPlugins[p.Name]; ok {
+			return nil, fmt.Errorf("scoring_plugin %q is configured more than once", p.Name)
+		}
+		seenPlugins[p.Name] = struct{}{}
+		if p.Weight < 0 {
+			return nil, fmt.Errorf("scoring_plugin %q weight must not be negative", p.Name)
+		}
+
+		weight := p.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		conf.ScoringPluginConfigs = append(conf.ScoringPluginConfigs, &structs.ScoringPluginConfig{
+			Name:   p.Name,
+			Weight: weight,
+		})
+	}
+
 	return conf, nil
 }
 
@@ -597,8 +807,29 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.MinDynamicPort = agentConfig.Client.MinDynamicPort
 	conf.DisableRemoteExec = agentConfig.Client.DisableRemoteExec
 
+	switch agentConfig.Client.EphemeralDiskEnforcement {
+	case "":
+	case clientconfig.EphemeralDiskEnforcementSoft, clientconfig.EphemeralDiskEnforcementHard:
+		conf.EphemeralDiskEnforcement = agentConfig.Client.EphemeralDiskEnforcement
+	default:
+		return nil, fmt.Errorf("Invalid ephemeral_disk_enforcement %q, must be %q or %q",
+			agentConfig.Client.EphemeralDiskEnforcement,
+			clientconfig.EphemeralDiskEnforcementSoft, clientconfig.EphemeralDiskEnforcementHard)
+	}
+
 	if agentConfig.Client.TemplateConfig != nil {
 		conf.TemplateConfig = agentConfig.Client.TemplateConfig.Copy()
+
+		names := make(map[string]struct{}, len(conf.TemplateConfig.PluginFunctions))
+		for _, f := range conf.TemplateConfig.PluginFunctions {
+			if err := f.Validate(); err != nil {
+				return nil, err
+			}
+			if _, ok := names[f.Name]; ok {
+				return nil, fmt.Errorf("plugin_function %q is defined more than once", f.Name)
+			}
+			names[f.Name] = struct{}{}
+		}
 	}
 
 	hvMap := make(map[string]*structs.ClientHostVolumeConfig, len(agentConfig.Client.HostVolumes))
@@ -607,6 +838,74 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	}
 	conf.HostVolumes = hvMap
 
+	conf.NamespaceIDRanges = make(map[string]*clientconfig.NamespaceIDRangeConfig, len(agentConfig.Client.NamespaceIDRanges))
+	for _, r := range agentConfig.Client.NamespaceIDRanges {
+		idRange := r.Copy()
+		idRange.Canonicalize()
+		if err := idRange.Validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := conf.NamespaceIDRanges[idRange.Namespace]; ok {
+			return nil, fmt.Errorf("namespace_id_range %q is defined more than once", idRange.Namespace)
+		}
+		for _, other := range conf.NamespaceIDRanges {
+			if idRange.Overlaps(other) {
+				return nil, fmt.Errorf("namespace_id_range %q overlaps with namespace_id_range %q", idRange.Namespace, other.Namespace)
+			}
+		}
+		conf.NamespaceIDRanges[idRange.Namespace] = idRange
+	}
+
+	conf.ArtifactFetchers = make(map[string]*clientconfig.ArtifactFetcherConfig, len(agentConfig.Client.ArtifactFetchers))
+	schemes := make(map[string]string)
+	for _, f := range agentConfig.Client.ArtifactFetchers {
+		fetcher := f.Copy()
+		fetcher.Canonicalize()
+		if err := fetcher.Validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := conf.ArtifactFetchers[fetcher.Name]; ok {
+			return nil, fmt.Errorf("artifact_fetcher %q is defined more than once", fetcher.Name)
+		}
+		for _, scheme := range fetcher.Schemes {
+			if other, ok := schemes[scheme]; ok {
+				return nil, fmt.Errorf("artifact_fetcher %q and artifact_fetcher %q both fetch scheme %q", other, fetcher.Name, scheme)
+			}
+			schemes[scheme] = fetcher.Name
+		}
+		conf.ArtifactFetchers[fetcher.Name] = fetcher
+	}
+
+	conf.OCIRegistries = make(map[string]*clientconfig.OCIRegistryConfig, len(agentConfig.Client.OCIRegistries))
+	for _, r := range agentConfig.Client.OCIRegistries {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := conf.OCIRegistries[r.Host]; ok {
+			return nil, fmt.Errorf("oci_registry %q is defined more than once", r.Host)
+		}
+		conf.OCIRegistries[r.Host] = r.Copy()
+	}
+
+	conf.EnvoyVersions = make([]*clientconfig.EnvoyVersionConfig, 0, len(agentConfig.Client.EnvoyVersions))
+	for _, e := range agentConfig.Client.EnvoyVersions {
+		if err := e.Validate(); err != nil {
+			return nil, err
+		}
+		conf.EnvoyVersions = append(conf.EnvoyVersions, e.Copy())
+	}
+
+	if cache := agentConfig.Client.ArtifactCache; cache != nil && cache.Enabled {
+		if cache.MaxSizeMB < 0 {
+			return nil, fmt.Errorf("artifact_cache max_size_mb must not be negative")
+		}
+		maxSizeMB := cache.MaxSizeMB
+		if maxSizeMB == 0 {
+			maxSizeMB = defaultArtifactCacheMaxSizeMB
+		}
+		conf.ArtifactCacheMaxBytes = int64(maxSizeMB) * 1024 * 1024
+	}
+
 	// Setup the node
 	conf.Node = new(structs.Node)
 	conf.Node.Datacenter = agentConfig.Datacenter
@@ -656,11 +955,17 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 
 	conf.ConsulConfig = agentConfig.Consul
 	conf.VaultConfig = agentConfig.Vault
+	if err := conf.VaultConfig.ValidateClusters(); err != nil {
+		return nil, err
+	}
 
 	// Set up Telemetry configuration
 	conf.StatsCollectionInterval = agentConfig.Telemetry.collectionInterval
 	conf.PublishNodeMetrics = agentConfig.Telemetry.PublishNodeMetrics
 	conf.PublishAllocationMetrics = agentConfig.Telemetry.PublishAllocationMetrics
+	conf.NamespaceMetrics = agentConfig.Telemetry.NamespaceMetrics
+	conf.NamespaceMetricsAllowlist = agentConfig.Telemetry.NamespaceMetricsAllowlist
+	conf.NamespaceMetricsLimit = agentConfig.Telemetry.NamespaceMetricsLimit
 
 	// Set the TLS related configs
 	conf.TLSConfig = agentConfig.TLSConfig
@@ -689,6 +994,15 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.CNIConfigDir = agentConfig.Client.CNIConfigDir
 	conf.BridgeNetworkName = agentConfig.Client.BridgeNetworkName
 	conf.BridgeNetworkAllocSubnet = agentConfig.Client.BridgeNetworkSubnet
+	if firewall := agentConfig.Client.BridgeNetworkFirewall; firewall != nil && firewall.Enabled {
+		for _, cidr := range firewall.DenyCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return nil, fmt.Errorf("invalid bridge_network_firewall deny_cidrs %q: %v", cidr, err)
+			}
+		}
+		conf.BridgeNetworkFirewall = true
+		conf.BridgeNetworkFirewallDenyCIDRs = helper.CopySliceString(firewall.DenyCIDRs)
+	}
 
 	for _, hn := range agentConfig.Client.HostNetworks {
 		conf.HostNetworks[hn.Name] = hn
@@ -707,6 +1021,71 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	return conf, nil
 }
 
+// setupAccessLog is used to setup the access log if enabled
+func (a *Agent) setupAccessLog() error {
+	conf := a.config.AccessLog
+	if conf == nil || !conf.Enabled {
+		return nil
+	}
+	if conf.Path == "" && conf.HTTPAddress == "" {
+		return fmt.Errorf("access_log requires a path or an http_address")
+	}
+
+	var sinks []io.Writer
+	if conf.Path != "" {
+		if conf.RotateDuration < 0 {
+			return fmt.Errorf("access_log rotate_duration must not be negative")
+		}
+		duration := conf.RotateDuration
+		if duration == 0 {
+			duration = defaultAccessLogRotateDuration
+		}
+
+		// If the path has no file name, then a default is used.
+		dir, fileName := filepath.Split(conf.Path)
+		if fileName == "" {
+			fileName = "access.log"
+		}
+		sinks = append(sinks, &logFile{
+			fileName: fileName,
+			logPath:  dir,
+			duration: duration,
+			MaxBytes: conf.RotateBytes,
+			MaxFiles: conf.RotateMaxFiles,
+		})
+	}
+
+	if conf.HTTPAddress != "" {
+		u, err := url.Parse(conf.HTTPAddress)
+		if err != nil {
+			return fmt.Errorf("failed to parse access_log http_address: %v", err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("access_log http_address must be an http or https URL")
+		}
+		sinks = append(sinks, accesslog.NewHTTPSink(conf.HTTPAddress, a.logger))
+	}
+
+	a.accessLog = accesslog.New(a.logger, sinks...)
+	return nil
+}
+
+// accessorID returns the accessor of the ACL token with the given secret, or
+// an empty string if ACLs are disabled or the token can't be resolved.
+func (a *Agent) accessorID(secretID string) string {
+	var token *structs.ACLToken
+	var err error
+	if a.server != nil {
+		token, err = a.server.ResolveSecretToken(secretID)
+	} else {
+		token, err = a.client.ResolveSecretToken(secretID)
+	}
+	if err != nil || token == nil {
+		return ""
+	}
+	return token.AccessorID
+}
+
 // setupServer is used to setup the server if enabled
 func (a *Agent) setupServer() error {
 	if !a.config.Server.Enabled {
@@ -725,6 +1104,9 @@ func (a *Agent) setupServer() error {
 		return fmt.Errorf("setting up server node ID failed: %s", err)
 	}
 
+	// Log the RPCs served by the server if the access log is enabled
+	conf.AccessLogger = a.accessLog
+
 	// Sets up the keyring for gossip encryption
 	if err := a.setupKeyrings(conf); err != nil {
 		return fmt.Errorf("failed to configure keyring: %v", err)
@@ -1010,6 +1392,9 @@ func (a *Agent) Shutdown() error {
 	}
 
 	a.logger.Info("requesting shutdown")
+	if a.eventSink != nil {
+		a.eventSink.Shutdown()
+	}
 	if a.client != nil {
 		if err := a.client.Shutdown(); err != nil {
 			a.logger.Error("client shutdown failed", "error", err)
@@ -1025,6 +1410,12 @@ func (a *Agent) Shutdown() error {
 		a.logger.Error("shutting down Consul client failed", "error", err)
 	}
 
+	if a.accessLog != nil {
+		if err := a.accessLog.Close(); err != nil {
+			a.logger.Error("closing access log failed", "error", err)
+		}
+	}
+
 	a.logger.Info("shutdown complete")
 	a.shutdown = true
 	close(a.shutdownCh)
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/api"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/command/agent/host"
+	"github.com/hashicorp/nomad/command/agent/monitor"
 	"github.com/hashicorp/nomad/command/agent/pprof"
 	"github.com/hashicorp/nomad/nomad"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -103,6 +104,36 @@ func (s *HTTPServer) AgentSelfRequest(resp http.ResponseWriter, req *http.Reques
 		self.Config.Telemetry.CirconusAPIToken = "<redacted>"
 	}
 
+	if self.Config != nil && self.Config.Server != nil && self.Config.Server.ScalingWebhook != nil &&
+		self.Config.Server.ScalingWebhook.Secret != "" {
+		self.Config.Server.ScalingWebhook.Secret = "<redacted>"
+	}
+
+	if self.Config != nil && self.Config.Server != nil && self.Config.Server.DeploymentWebhook != nil &&
+		self.Config.Server.DeploymentWebhook.Secret != "" {
+		self.Config.Server.DeploymentWebhook.Secret = "<redacted>"
+	}
+
+	if self.Config != nil && self.Config.Client != nil {
+		for _, r := range self.Config.Client.OCIRegistries {
+			if r.Password != "" {
+				r.Password = "<redacted>"
+			}
+			if r.Token != "" {
+				r.Token = "<redacted>"
+			}
+		}
+	}
+
+	if self.Config != nil && self.Config.EventSink != nil {
+		if self.Config.EventSink.Password != "" {
+			self.Config.EventSink.Password = "<redacted>"
+		}
+		if self.Config.EventSink.Token != "" {
+			self.Config.EventSink.Token = "<redacted>"
+		}
+	}
+
 	return self, nil
 }
 
@@ -160,6 +191,11 @@ func (s *HTTPServer) AgentMonitor(resp http.ResponseWriter, req *http.Request) (
 		return nil, CodedError(400, fmt.Sprintf("Unknown log level: %s", logLevel))
 	}
 
+	logLevelOverrides := req.URL.Query().Get("log_level_overrides")
+	if _, err := monitor.ParseLevelOverrides(logLevelOverrides); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
 	logJSON := false
 	logJSONStr := req.URL.Query().Get("log_json")
 	if logJSONStr != "" {
@@ -183,11 +219,12 @@ func (s *HTTPServer) AgentMonitor(resp http.ResponseWriter, req *http.Request) (
 	nodeID := req.URL.Query().Get("node_id")
 	// Build the request and parse the ACL token
 	args := cstructs.MonitorRequest{
-		NodeID:    nodeID,
-		ServerID:  req.URL.Query().Get("server_id"),
-		LogLevel:  logLevel,
-		LogJSON:   logJSON,
-		PlainText: plainText,
+		NodeID:            nodeID,
+		ServerID:          req.URL.Query().Get("server_id"),
+		LogLevel:          logLevel,
+		LogLevelOverrides: logLevelOverrides,
+		LogJSON:           logJSON,
+		PlainText:         plainText,
 	}
 
 	// if node and server were requested return error
@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pool"
 	"github.com/hashicorp/nomad/nomad/mock"
@@ -91,6 +92,58 @@ func TestHTTP_AgentSelf(t *testing.T) {
 		require.NoError(err)
 		self = obj.(agentSelf)
 		require.Equal("<redacted>", self.Config.Telemetry.CirconusAPIToken)
+
+		// Assign a scaling webhook secret and require it is redacted.
+		s.Config.Server.ScalingWebhook = &ScalingWebhook{
+			URL:    "https://example.com/scaling",
+			Secret: "s3cr3t",
+		}
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.AgentSelfRequest(respW, req)
+		require.NoError(err)
+		self = obj.(agentSelf)
+		require.Equal("<redacted>", self.Config.Server.ScalingWebhook.Secret)
+		require.Equal("s3cr3t", s.Config.Server.ScalingWebhook.Secret)
+
+		// Assign a deployment webhook secret and require it is redacted.
+		s.Config.Server.DeploymentWebhook = &DeploymentWebhook{
+			URL:    "https://example.com/deployments",
+			Secret: "s3cr3t",
+		}
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.AgentSelfRequest(respW, req)
+		require.NoError(err)
+		self = obj.(agentSelf)
+		require.Equal("<redacted>", self.Config.Server.DeploymentWebhook.Secret)
+		require.Equal("s3cr3t", s.Config.Server.DeploymentWebhook.Secret)
+
+		// Assign OCI registry credentials and require they are redacted.
+		s.Config.Client.OCIRegistries = []*config.OCIRegistryConfig{
+			{Host: "registry.example.com", Username: "nomad", Password: "hunter2"},
+		}
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.AgentSelfRequest(respW, req)
+		require.NoError(err)
+		self = obj.(agentSelf)
+		require.Equal("nomad", self.Config.Client.OCIRegistries[0].Username)
+		require.Equal("<redacted>", self.Config.Client.OCIRegistries[0].Password)
+		require.Equal("hunter2", s.Config.Client.OCIRegistries[0].Password)
+
+		// Assign event sink credentials and require they are redacted.
+		s.Config.EventSink = &EventSink{
+			Type:     "nats",
+			Username: "nomad",
+			Password: "hunter2",
+			Token:    "s3cr3t",
+		}
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.AgentSelfRequest(respW, req)
+		require.NoError(err)
+		self = obj.(agentSelf)
+		require.Equal("nomad", self.Config.EventSink.Username)
+		require.Equal("<redacted>", self.Config.EventSink.Password)
+		require.Equal("<redacted>", self.Config.EventSink.Token)
+		require.Equal("hunter2", s.Config.EventSink.Password)
 	})
 }
 
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/nomad/ci"
+	clientconfig "github.com/hashicorp/nomad/client/config"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/testlog"
@@ -315,6 +316,359 @@ func TestAgent_ServerConfig_Limits_Error(t *testing.T) {
 	}
 }
 
+func TestAgent_ServerConfig_NodeScorer(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name        string
+		expectedErr string
+		scorer      *NodeScorer
+	}{
+		{
+			name:        "Missing Command and URL",
+			expectedErr: "node_scorer requires exactly one of command or url",
+			scorer:      &NodeScorer{},
+		},
+		{
+			name:        "Both Command and URL",
+			expectedErr: "node_scorer requires exactly one of command or url",
+			scorer:      &NodeScorer{Command: "/usr/bin/score", URL: "http://127.0.0.1/score"},
+		},
+		{
+			name:        "Invalid URL Scheme",
+			expectedErr: "node_scorer url must be an http or https URL",
+			scorer:      &NodeScorer{URL: "ftp://127.0.0.1/score"},
+		},
+		{
+			name:        "Negative Timeout",
+			expectedErr: "node_scorer timeout must not be negative",
+			scorer:      &NodeScorer{Command: "/usr/bin/score", Timeout: -time.Second},
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			conf := DevConfig(nil)
+			require.NoError(t, conf.normalizeAddrs())
+
+			conf.Server.NodeScorer = tc.scorer
+			serverConf, err := convertServerConfig(conf)
+			assert.Nil(t, serverConf)
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+
+	t.Run("Default Timeout", func(t *testing.T) {
+		conf := DevConfig(nil)
+		require.NoError(t, conf.normalizeAddrs())
+
+		conf.Server.NodeScorer = &NodeScorer{URL: "http://127.0.0.1/score"}
+		serverConf, err := convertServerConfig(conf)
+		require.NoError(t, err)
+		require.Equal(t, "http://127.0.0.1/score", serverConf.NodeScorerConfig.URL)
+		require.Equal(t, defaultNodeScorerTimeout, serverConf.NodeScorerConfig.Timeout)
+	})
+}
+
+func TestAgent_ServerConfig_SLOMetrics(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name        string
+		expectedErr string
+		metrics     *SLOMetrics
+	}{
+		{
+			name:        "Missing Address",
+			expectedErr: "slo_metrics address must be an http or https URL",
+			metrics:     &SLOMetrics{},
+		},
+		{
+			name:        "Invalid Address Scheme",
+			expectedErr: "slo_metrics address must be an http or https URL",
+			metrics:     &SLOMetrics{Address: "tcp://127.0.0.1:9090"},
+		},
+		{
+			name:        "Negative Timeout",
+			expectedErr: "slo_metrics timeout must not be negative",
+			metrics:     &SLOMetrics{Address: "http://127.0.0.1:9090", Timeout: -time.Second},
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			conf := DevConfig(nil)
+			require.NoError(t, conf.normalizeAddrs())
+
+			conf.Server.SLOMetrics = tc.metrics
+			serverConf, err := convertServerConfig(conf)
+			assert.Nil(t, serverConf)
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+
+	t.Run("Default Timeout", func(t *testing.T) {
+		conf := DevConfig(nil)
+		require.NoError(t, conf.normalizeAddrs())
+
+		conf.Server.SLOMetrics = &SLOMetrics{Address: "http://127.0.0.1:9090"}
+		serverConf, err := convertServerConfig(conf)
+		require.NoError(t, err)
+		require.Equal(t, "http://127.0.0.1:9090", serverConf.SLOMetricsConfig.Address)
+		require.Equal(t, defaultSLOMetricsTimeout, serverConf.SLOMetricsConfig.Timeout)
+	})
+}
+
+func TestAgent_SetupAccessLog(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name        string
+		expectedErr string
+		accessLog   *AccessLog
+	}{
+		{
+			name:        "Missing Sinks",
+			expectedErr: "access_log requires a path or an http_address",
+			accessLog:   &AccessLog{Enabled: true},
+		},
+		{
+			name:        "Invalid Address Scheme",
+			expectedErr: "access_log http_address must be an http or https URL",
+			accessLog:   &AccessLog{Enabled: true, HTTPAddress: "tcp://127.0.0.1:8080"},
+		},
+		{
+			name:        "Negative Rotate Duration",
+			expectedErr: "access_log rotate_duration must not be negative",
+			accessLog:   &AccessLog{Enabled: true, Path: "access.log", RotateDuration: -time.Second},
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			a := &Agent{config: DevConfig(nil), logger: testlog.HCLogger(t)}
+			a.config.AccessLog = tc.accessLog
+			require.EqualError(t, a.setupAccessLog(), tc.expectedErr)
+			require.Nil(t, a.accessLog)
+		})
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		a := &Agent{config: DevConfig(nil), logger: testlog.HCLogger(t)}
+		a.config.AccessLog = &AccessLog{Path: "access.log"}
+		require.NoError(t, a.setupAccessLog())
+		require.Nil(t, a.accessLog)
+	})
+}
+
+func TestAgent_ServerConfig_GCAutoTune(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name        string
+		expectedErr string
+		tune        *GCAutoTune
+	}{
+		{
+			name:        "Missing Limits",
+			expectedErr: "gc_auto_tune requires max_heap_mb or max_snapshot_mb",
+			tune:        &GCAutoTune{Enabled: true},
+		},
+		{
+			name:        "Negative Limit",
+			expectedErr: "gc_auto_tune limits must not be negative",
+			tune:        &GCAutoTune{Enabled: true, MaxHeapMB: -1},
+		},
+		{
+			name:        "Negative Min Threshold",
+			expectedErr: "gc_auto_tune min_threshold must not be negative",
+			tune:        &GCAutoTune{Enabled: true, MaxHeapMB: 1024, MinThreshold: -time.Second},
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			conf := DevConfig(nil)
+			require.NoError(t, conf.normalizeAddrs())
+
+			conf.Server.GCAutoTune = tc.tune
+			serverConf, err := convertServerConfig(conf)
+			assert.Nil(t, serverConf)
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		conf := DevConfig(nil)
+		require.NoError(t, conf.normalizeAddrs())
+
+		conf.Server.GCAutoTune = &GCAutoTune{MaxHeapMB: 1024}
+		serverConf, err := convertServerConfig(conf)
+		require.NoError(t, err)
+		require.Nil(t, serverConf.GCAutoTuneConfig)
+	})
+
+	t.Run("Default Min Threshold", func(t *testing.T) {
+		conf := DevConfig(nil)
+		require.NoError(t, conf.normalizeAddrs())
+
+		conf.Server.GCAutoTune = &GCAutoTune{Enabled: true, MaxSnapshotMB: 512}
+		serverConf, err := convertServerConfig(conf)
+		require.NoError(t, err)
+		require.Equal(t, &structs.GCAutoTuneConfig{
+			MaxSnapshotBytes: 512 * 1024 * 1024,
+			MinThreshold:     defaultGCAutoTuneMinThreshold,
+		}, serverConf.GCAutoTuneConfig)
+	})
+}
+
+func TestAgent_ServerConfig_WorkloadIdentity(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name        string
+		expectedErr string
+		identity    *WorkloadIdentity
+	}{
+		{
+			name:        "Missing Issuer",
+			expectedErr: "workload_identity issuer must be an http or https URL",
+			identity:    &WorkloadIdentity{SigningKeyFile: "/etc/nomad.d/identity.pem"},
+		},
+		{
+			name:        "Invalid Issuer Scheme",
+			expectedErr: "workload_identity issuer must be an http or https URL",
+			identity:    &WorkloadIdentity{Issuer: "ftp://nomad.example.com", SigningKeyFile: "/etc/nomad.d/identity.pem"},
+		},
+		{
+			name:        "Short Rotation Interval",
+			expectedErr: "workload_identity rotation_interval must be at least 1h0m0s",
+			identity:    &WorkloadIdentity{Issuer: "https://nomad.example.com", RotationInterval: time.Minute},
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			conf := DevConfig(nil)
+			require.NoError(t, conf.normalizeAddrs())
+
+			conf.Server.WorkloadIdentity = tc.identity
+			serverConf, err := convertServerConfig(conf)
+			assert.Nil(t, serverConf)
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		conf := DevConfig(nil)
+		require.NoError(t, conf.normalizeAddrs())
+
+		conf.Server.WorkloadIdentity = &WorkloadIdentity{
+			Issuer:         "https://nomad.example.com",
+			SigningKeyFile: "/etc/nomad.d/identity.pem",
+		}
+		serverConf, err := convertServerConfig(conf)
+		require.NoError(t, err)
+		require.Equal(t, &structs.WorkloadIdentityConfig{
+			Issuer:         "https://nomad.example.com",
+			SigningKeyFile: "/etc/nomad.d/identity.pem",
+		}, serverConf.WorkloadIdentityConfig)
+	})
+
+	t.Run("Valid Generated Keys", func(t *testing.T) {
+		conf := DevConfig(nil)
+		require.NoError(t, conf.normalizeAddrs())
+
+		conf.Server.WorkloadIdentity = &WorkloadIdentity{
+			Issuer:           "https://nomad.example.com",
+			RotationInterval: 24 * time.Hour,
+		}
+		serverConf, err := convertServerConfig(conf)
+		require.NoError(t, err)
+		require.Equal(t, &structs.WorkloadIdentityConfig{
+			Issuer:           "https://nomad.example.com",
+			RotationInterval: 24 * time.Hour,
+		}, serverConf.WorkloadIdentityConfig)
+	})
+}
+
+func TestAgent_ServerConfig_JobRegisterQueueLimit(t *testing.T) {
+	ci.Parallel(t)
+
+	conf := DevConfig(nil)
+	require.NoError(t, conf.normalizeAddrs())
+
+	serverConf, err := convertServerConfig(conf)
+	require.NoError(t, err)
+	require.Equal(t, 100, serverConf.JobRegisterQueueLimit)
+
+	conf.Server.JobRegisterQueueLimit = 10
+	serverConf, err = convertServerConfig(conf)
+	require.NoError(t, err)
+	require.Equal(t, 10, serverConf.JobRegisterQueueLimit)
+
+	conf.Server.JobRegisterQueueLimit = -1
+	_, err = convertServerConfig(conf)
+	require.EqualError(t, err, "job_register_queue_limit must not be negative")
+}
+
+func TestAgent_ServerConfig_ScoringPlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name        string
+		expectedErr string
+		plugins     []*ScoringPlugin
+	}{
+		{
+			name:        "Missing Name",
+			expectedErr: "scoring_plugin requires a name",
+			plugins:     []*ScoringPlugin{{Weight: 1}},
+		},
+		{
+			name:        "Duplicate Name",
+			expectedErr: `scoring_plugin "cost" is configured more than once`,
+			plugins:     []*ScoringPlugin{{Name: "cost"}, {Name: "cost", Weight: 2}},
+		},
+		{
+			name:        "Negative Weight",
+			expectedErr: `scoring_plugin "cost" weight must not be negative`,
+			plugins:     []*ScoringPlugin{{Name: "cost", Weight: -1}},
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			conf := DevConfig(nil)
+			require.NoError(t, conf.normalizeAddrs())
+
+			conf.Server.ScoringPlugins = tc.plugins
+			serverConf, err := convertServerConfig(conf)
+			assert.Nil(t, serverConf)
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+
+	t.Run("Default Weight", func(t *testing.T) {
+		conf := DevConfig(nil)
+		require.NoError(t, conf.normalizeAddrs())
+
+		conf.Server.ScoringPlugins = []*ScoringPlugin{{Name: "cost"}, {Name: "power", Weight: 2.5}}
+		serverConf, err := convertServerConfig(conf)
+		require.NoError(t, err)
+		require.Equal(t, []*structs.ScoringPluginConfig{
+			{Name: "cost", Weight: 1},
+			{Name: "power", Weight: 2.5},
+		}, serverConf.ScoringPluginConfigs)
+	})
+}
+
 // TestAgent_ServerConfig_Limits_OK asserts valid Limits configurations do not
 // cause errors. This is the server-only (RPC) counterpart to
 // TestHTTPServer_Limits_OK.
@@ -537,6 +891,150 @@ func TestAgent_ClientConfig_ReservedCores(t *testing.T) {
 	require.Exactly(t, []uint16{0, 2, 3}, c.Node.ReservedResources.Cpu.ReservedCpuCores)
 }
 
+func TestAgent_ClientConfig_NamespaceIDRanges(t *testing.T) {
+	ci.Parallel(t)
+	conf := DefaultConfig()
+	conf.Client.Enabled = true
+	conf.Client.NamespaceIDRanges = []*clientconfig.NamespaceIDRangeConfig{
+		{Namespace: "tenant-a", UID: 100000, GID: 100000},
+		{Namespace: "tenant-b", UID: 200000, GID: 200000, Size: 1000},
+	}
+	a := &Agent{config: conf}
+	c, err := a.clientConfig()
+	require.NoError(t, err)
+	require.Len(t, c.NamespaceIDRanges, 2)
+	require.Equal(t, clientconfig.DefaultNamespaceIDRangeSize, c.NamespaceIDRanges["tenant-a"].Size)
+	require.Equal(t, 1000, c.NamespaceIDRanges["tenant-b"].Size)
+
+	conf.Client.NamespaceIDRanges[1].UID = 150000
+	_, err = a.clientConfig()
+	require.EqualError(t, err, `namespace_id_range "tenant-b" overlaps with namespace_id_range "tenant-a"`)
+
+	conf.Client.NamespaceIDRanges[1].Namespace = "tenant-a"
+	_, err = a.clientConfig()
+	require.EqualError(t, err, `namespace_id_range "tenant-a" is defined more than once`)
+}
+
+func TestAgent_ClientConfig_ArtifactFetchers(t *testing.T) {
+	ci.Parallel(t)
+	conf := DefaultConfig()
+	conf.Client.Enabled = true
+	conf.Client.ArtifactFetchers = []*clientconfig.ArtifactFetcherConfig{
+		{Name: "corp", Command: "/usr/local/bin/corp-fetch"},
+		{Name: "vault-store", Command: "/usr/local/bin/vault-fetch", Schemes: []string{"vs", "vss"}},
+	}
+	a := &Agent{config: conf}
+	c, err := a.clientConfig()
+	require.NoError(t, err)
+	require.Len(t, c.ArtifactFetchers, 2)
+	require.Equal(t, []string{"corp"}, c.ArtifactFetchers["corp"].Schemes)
+	require.Equal(t, clientconfig.DefaultArtifactFetcherTimeout, c.ArtifactFetchers["corp"].Timeout)
+
+	conf.Client.ArtifactFetchers[1].Schemes = []string{"vs", "corp"}
+	_, err = a.clientConfig()
+	require.EqualError(t, err, `artifact_fetcher "corp" and artifact_fetcher "vault-store" both fetch scheme "corp"`)
+
+	conf.Client.ArtifactFetchers[1].Name = "corp"
+	_, err = a.clientConfig()
+	require.EqualError(t, err, `artifact_fetcher "corp" is defined more than once`)
+}
+
+func TestAgent_ClientConfig_OCIRegistries(t *testing.T) {
+	ci.Parallel(t)
+	conf := DefaultConfig()
+	conf.Client.Enabled = true
+	conf.Client.OCIRegistries = []*clientconfig.OCIRegistryConfig{
+		{Host: "ghcr.io", Token: "t0k"},
+		{Host: "registry.example.com", Username: "nomad", Password: "hunter2"},
+	}
+	a := &Agent{config: conf}
+	c, err := a.clientConfig()
+	require.NoError(t, err)
+	require.Len(t, c.OCIRegistries, 2)
+	require.Equal(t, "t0k", c.OCIRegistries["ghcr.io"].Token)
+
+	conf.Client.OCIRegistries[0].Username = "nomad"
+	_, err = a.clientConfig()
+	require.EqualError(t, err, `oci_registry "ghcr.io": token can't be used with username and password`)
+
+	conf.Client.OCIRegistries[0] = &clientconfig.OCIRegistryConfig{Host: "registry.example.com"}
+	_, err = a.clientConfig()
+	require.EqualError(t, err, `oci_registry "registry.example.com" is defined more than once`)
+}
+
+func TestAgent_ClientConfig_EnvoyVersions(t *testing.T) {
+	ci.Parallel(t)
+	conf := DefaultConfig()
+	conf.Client.Enabled = true
+	conf.Client.EnvoyVersions = []*clientconfig.EnvoyVersionConfig{
+		{Envoy: "1.22.2", Consul: ">= 1.12.0, < 1.13.0"},
+		{Envoy: "1.20.4", Consul: "< 1.12.0"},
+	}
+	a := &Agent{config: conf}
+	c, err := a.clientConfig()
+	require.NoError(t, err)
+	require.Equal(t, conf.Client.EnvoyVersions, c.EnvoyVersions)
+
+	conf.Client.EnvoyVersions[1].Consul = "1.11"
+	_, err = a.clientConfig()
+	require.NoError(t, err)
+
+	conf.Client.EnvoyVersions[1].Consul = "~~ 1.11"
+	_, err = a.clientConfig()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `envoy_version "1.20.4": invalid consul constraint`)
+
+	conf.Client.EnvoyVersions[1] = &clientconfig.EnvoyVersionConfig{Envoy: "latest", Consul: "< 1.12.0"}
+	_, err = a.clientConfig()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `envoy_version "latest": invalid envoy version`)
+}
+
+func TestAgent_ClientConfig_ArtifactCache(t *testing.T) {
+	ci.Parallel(t)
+	conf := DefaultConfig()
+	conf.Client.Enabled = true
+	a := &Agent{config: conf}
+
+	c, err := a.clientConfig()
+	require.NoError(t, err)
+	require.Zero(t, c.ArtifactCacheMaxBytes)
+
+	conf.Client.ArtifactCache = &ArtifactCache{Enabled: true}
+	c, err = a.clientConfig()
+	require.NoError(t, err)
+	require.Equal(t, int64(defaultArtifactCacheMaxSizeMB)*1024*1024, c.ArtifactCacheMaxBytes)
+
+	conf.Client.ArtifactCache.MaxSizeMB = 512
+	c, err = a.clientConfig()
+	require.NoError(t, err)
+	require.Equal(t, int64(512*1024*1024), c.ArtifactCacheMaxBytes)
+
+	conf.Client.ArtifactCache.MaxSizeMB = -1
+	_, err = a.clientConfig()
+	require.EqualError(t, err, "artifact_cache max_size_mb must not be negative")
+}
+
+func TestAgent_ClientConfig_EphemeralDiskEnforcement(t *testing.T) {
+	ci.Parallel(t)
+	conf := DefaultConfig()
+	conf.Client.Enabled = true
+	a := &Agent{config: conf}
+
+	c, err := a.clientConfig()
+	require.NoError(t, err)
+	require.Empty(t, c.EphemeralDiskEnforcement)
+
+	conf.Client.EphemeralDiskEnforcement = "hard"
+	c, err = a.clientConfig()
+	require.NoError(t, err)
+	require.Equal(t, clientconfig.EphemeralDiskEnforcementHard, c.EphemeralDiskEnforcement)
+
+	conf.Client.EphemeralDiskEnforcement = "strict"
+	_, err = a.clientConfig()
+	require.EqualError(t, err, `Invalid ephemeral_disk_enforcement "strict", must be "soft" or "hard"`)
+}
+
 // Clients should inherit telemetry configuration
 func TestAgent_Client_TelemetryConfiguration(t *testing.T) {
 	ci.Parallel(t)
@@ -176,6 +176,14 @@ type Config struct {
 	// Audit contains the configuration for audit logging.
 	Audit *config.AuditConfig `hcl:"audit"`
 
+	// AccessLog contains the configuration for the structured log of the
+	// requests served by the agent.
+	AccessLog *AccessLog `hcl:"access_log"`
+
+	// EventSink contains the configuration for forwarding the event stream
+	// to an external message broker.
+	EventSink *EventSink `hcl:"event_sink"`
+
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
@@ -278,6 +286,11 @@ type ClientConfig struct {
 	// DisableRemoteExec disables remote exec targeting tasks on this client
 	DisableRemoteExec bool `hcl:"disable_remote_exec"`
 
+	// EphemeralDiskEnforcement controls whether the ephemeral disk size of
+	// allocations is enforced with filesystem project quotas ("hard") or only
+	// accounted for when garbage collecting allocations ("soft")
+	EphemeralDiskEnforcement string `hcl:"ephemeral_disk_enforcement"`
+
 	// TemplateConfig includes configuration for template rendering
 	TemplateConfig *client.ClientTemplateConfig `hcl:"template"`
 
@@ -288,6 +301,26 @@ type ClientConfig struct {
 	// available to jobs running on this node.
 	HostVolumes []*structs.ClientHostVolumeConfig `hcl:"host_volume"`
 
+	// NamespaceIDRanges maps Nomad namespaces to the host uid and gid ranges
+	// their exec and docker tasks run as.
+	NamespaceIDRanges []*client.NamespaceIDRangeConfig `hcl:"namespace_id_range"`
+
+	// ArtifactFetchers registers external commands fetching the artifacts
+	// whose source URL uses their schemes.
+	ArtifactFetchers []*client.ArtifactFetcherConfig `hcl:"artifact_fetcher"`
+
+	// OCIRegistries configures the authentication to the registries the
+	// images of oci:// artifacts are pulled from.
+	OCIRegistries []*client.OCIRegistryConfig `hcl:"oci_registry"`
+
+	// EnvoyVersions is the compatibility matrix selecting the Envoy version of
+	// Connect sidecars and gateways from the version of Consul.
+	EnvoyVersions []*client.EnvoyVersionConfig `hcl:"envoy_version"`
+
+	// ArtifactCache configures the cache of artifacts verified by a checksum
+	// shared by the allocations of the client.
+	ArtifactCache *ArtifactCache `hcl:"artifact_cache"`
+
 	// CNIPath is the path to search for CNI plugins, multiple paths can be
 	// specified colon delimited
 	CNIPath string `hcl:"cni_path"`
@@ -300,6 +333,10 @@ type ClientConfig struct {
 	// bridge network mode
 	BridgeNetworkName string `hcl:"bridge_network_name"`
 
+	// BridgeNetworkFirewall configures the firewall restricting the egress
+	// traffic of the allocations in bridge network mode
+	BridgeNetworkFirewall *BridgeNetworkFirewall `hcl:"bridge_network_firewall"`
+
 	// BridgeNetworkSubnet is the subnet to allocate IP addresses from when
 	// creating allocations with bridge networking mode. This range is local to
 	// the host
@@ -322,6 +359,30 @@ type ClientConfig struct {
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
 
+// ArtifactCache is used in clients to configure the cache artifacts verified
+// by a checksum are hard linked from instead of being downloaded again.
+type ArtifactCache struct {
+	// Enabled toggles the artifact cache.
+	Enabled bool `hcl:"enabled"`
+
+	// MaxSizeMB is the size above which the least recently used artifacts
+	// are removed from the cache.
+	MaxSizeMB int `hcl:"max_size_mb"`
+}
+
+// BridgeNetworkFirewall is used in clients to configure the firewall blocking
+// the allocations in bridge network mode from reaching the client HTTP API,
+// the cloud metadata endpoint and the denied CIDRs, unless their job allows
+// it.
+type BridgeNetworkFirewall struct {
+	// Enabled toggles the firewall.
+	Enabled bool `hcl:"enabled"`
+
+	// DenyCIDRs are the destinations denied in addition to the client HTTP
+	// API and the cloud metadata endpoint.
+	DenyCIDRs []string `hcl:"deny_cidrs"`
+}
+
 // ACLConfig is configuration specific to the ACL system
 type ACLConfig struct {
 	// Enabled controls if we are enforce and manage ACLs
@@ -443,6 +504,12 @@ type ServerConfig struct {
 	FailoverHeartbeatTTL    time.Duration
 	FailoverHeartbeatTTLHCL string `hcl:"failover_heartbeat_ttl" json:"-"`
 
+	// MaxStale is the default bound on the staleness of the reads followers
+	// serve for stale queries. Stale queries are forwarded to the leader if
+	// the follower hasn't heard from it within the bound.
+	MaxStale    time.Duration
+	MaxStaleHCL string `hcl:"max_stale" json:"-"`
+
 	// StartJoin is a list of addresses to attempt to join when the
 	// agent starts. If Serf is unable to communicate with any of these
 	// addresses, then the agent will error and exit.
@@ -526,6 +593,235 @@ type ServerConfig struct {
 
 	// RaftBoltConfig configures boltdb as used by raft.
 	RaftBoltConfig *RaftBoltConfig `hcl:"raft_boltdb"`
+
+	// ScalingWebhook configures the delivery of scaling events to an
+	// external HTTPS endpoint.
+	ScalingWebhook *ScalingWebhook `hcl:"scaling_webhook"`
+
+	// DeploymentWebhook configures the delivery of deployment status updates
+	// to an external HTTPS endpoint.
+	DeploymentWebhook *DeploymentWebhook `hcl:"deployment_webhook"`
+
+	// NodeScorer configures an external binary or HTTP endpoint that
+	// contributes a score to the ranking of candidate nodes.
+	NodeScorer *NodeScorer `hcl:"node_scorer"`
+
+	// ScoringPlugins enables scoring plugins compiled into the binary, which
+	// contribute weighted scores to the ranking of candidate nodes.
+	ScoringPlugins []*ScoringPlugin `hcl:"scoring_plugin"`
+
+	// SLOMetrics configures the metrics endpoint the SLO queries of
+	// deployments are evaluated against.
+	SLOMetrics *SLOMetrics `hcl:"slo_metrics"`
+
+	// GCAutoTune configures the shrinking of the job, evaluation and
+	// deployment GC thresholds when the state of the server grows too large.
+	GCAutoTune *GCAutoTune `hcl:"gc_auto_tune"`
+
+	// WorkloadIdentity configures the signing of the workload identities of
+	// tasks.
+	WorkloadIdentity *WorkloadIdentity `hcl:"workload_identity"`
+
+	// JobRegisterQueueLimit is the maximum number of asynchronous job
+	// registrations queued on the leader in each namespace.
+	JobRegisterQueueLimit int `hcl:"job_register_queue_limit"`
+}
+
+// WorkloadIdentity is used in servers to configure the signing of the
+// workload identities of tasks.
+type WorkloadIdentity struct {
+	// Issuer is the URL the OpenID Connect discovery document of the servers
+	// is served under, used as the issuer of workload identities.
+	Issuer string `hcl:"issuer"`
+
+	// SigningKeyFile is the path to the PEM encoded RSA or ECDSA P-256
+	// private key workload identities are signed with. All servers must
+	// share the same key. If empty, the leader generates and rotates the
+	// keys.
+	SigningKeyFile string `hcl:"signing_key_file"`
+
+	// RotationInterval is the interval the keys generated by the leader are
+	// rotated at.
+	RotationInterval    time.Duration `hcl:"-"`
+	RotationIntervalHCL string        `hcl:"rotation_interval" json:"-"`
+}
+
+// GCAutoTune is used in servers to configure the shrinking of the job,
+// evaluation and deployment GC thresholds when the heap of the server or its
+// raft snapshots grow too large.
+type GCAutoTune struct {
+	// Enabled toggles the tuning of the GC thresholds.
+	Enabled bool `hcl:"enabled"`
+
+	// MaxHeapMB is the heap size above which the thresholds are shrunk.
+	MaxHeapMB int `hcl:"max_heap_mb"`
+
+	// MaxSnapshotMB is the raft snapshot size above which the thresholds are
+	// shrunk.
+	MaxSnapshotMB int `hcl:"max_snapshot_mb"`
+
+	// MinThreshold is the threshold below which the thresholds are never
+	// shrunk.
+	MinThreshold    time.Duration `hcl:"-"`
+	MinThresholdHCL string        `hcl:"min_threshold" json:"-"`
+}
+
+// SLOMetrics is used in servers to configure the metrics endpoint the SLO
+// queries of deployments are evaluated against.
+type SLOMetrics struct {
+	// Address is the base URL of an HTTP API compatible with the Prometheus
+	// instant query API.
+	Address string `hcl:"address"`
+
+	// Timeout bounds the time taken to evaluate a single query.
+	Timeout    time.Duration `hcl:"-"`
+	TimeoutHCL string        `hcl:"timeout" json:"-"`
+}
+
+// AccessLog is used to configure a structured log of the HTTP requests served
+// by the agent and of the RPCs served by servers, written as JSON lines to a
+// file, an HTTP endpoint, or both.
+type AccessLog struct {
+	// Enabled controls whether requests are logged.
+	Enabled bool `hcl:"enabled"`
+
+	// Path is the file entries are written to. If the path ends with a
+	// path separator, entries are written to access.log in that directory.
+	Path string `hcl:"path"`
+
+	// RotateDuration is the time period after which the file is rotated.
+	// Defaults to 24h.
+	RotateDuration    time.Duration `hcl:"-"`
+	RotateDurationHCL string        `hcl:"rotate_duration" json:"-"`
+
+	// RotateBytes is the size after which the file is rotated.
+	RotateBytes int `hcl:"rotate_bytes"`
+
+	// RotateMaxFiles is the number of rotated files to keep.
+	RotateMaxFiles int `hcl:"rotate_max_files"`
+
+	// HTTPAddress is a URL entries are POSTed to in batches of
+	// newline-delimited JSON.
+	HTTPAddress string `hcl:"http_address"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+// EventSink is used to configure the forwarding of the event stream to an
+// external message broker, Kafka or NATS.
+type EventSink struct {
+	// Enabled controls whether events are forwarded.
+	Enabled bool `hcl:"enabled"`
+
+	// Type is the type of the broker, either "kafka" or "nats".
+	Type string `hcl:"type"`
+
+	// Address is the comma separated list of the Kafka brokers, or the
+	// address of the NATS server.
+	Address string `hcl:"address"`
+
+	// Username and Password authenticate to the broker.
+	Username string `hcl:"username"`
+	Password string `hcl:"password"`
+
+	// TLS enables TLS connections to the broker. CAFile is an optional CA
+	// certificate used to verify the broker's certificate, and CertFile and
+	// KeyFile an optional client certificate.
+	TLS      bool   `hcl:"tls"`
+	CAFile   string `hcl:"ca_file"`
+	CertFile string `hcl:"cert_file"`
+	KeyFile  string `hcl:"key_file"`
+
+	// Topic is the template of the broker topic, or NATS subject, events
+	// are published to.
+	Topic string `hcl:"topic"`
+
+	// EventTopics are the topics of the events forwarded, in the
+	// Topic:FilterKey form of the event stream API. Defaults to all events.
+	EventTopics []string `hcl:"event_topics"`
+
+	// Namespace is the namespace of the events forwarded. Defaults to all
+	// namespaces.
+	Namespace string `hcl:"namespace"`
+
+	// Token is the ACL token used to read the event stream.
+	Token string `hcl:"token"`
+
+	// Consumer is the name of the event cursor recording the events
+	// forwarded, so that forwarding resumes after them when the agent
+	// restarts.
+	Consumer string `hcl:"consumer"`
+
+	// BufferSize is the number of events buffered while the broker is slow
+	// or unavailable.
+	BufferSize int `hcl:"buffer_size"`
+
+	// BatchSize is the maximum number of events published at once.
+	BatchSize int `hcl:"batch_size"`
+
+	// OnFull is what happens to events once the buffer is full, either
+	// "block" the event stream or "drop" them.
+	OnFull string `hcl:"on_full"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+// ScoringPlugin is used in servers to enable a scoring plugin compiled into
+// the binary.
+type ScoringPlugin struct {
+	// Name is the name the plugin is registered under.
+	Name string `hcl:",key"`
+
+	// Weight is the weight of the plugin's score relative to each of the
+	// built-in scores. Defaults to 1.
+	Weight float64 `hcl:"weight"`
+}
+
+// NodeScorer is used in servers to configure an external binary or HTTP
+// endpoint that contributes a score to the ranking of candidate nodes.
+type NodeScorer struct {
+	// Command is the path of the binary executed to score a node.
+	Command string `hcl:"command"`
+
+	// Args are the arguments passed to Command.
+	Args []string `hcl:"args"`
+
+	// URL is the HTTP endpoint requests to score a node are POSTed to.
+	URL string `hcl:"url"`
+
+	// Timeout bounds the time taken to score a single node.
+	Timeout    time.Duration `hcl:"-"`
+	TimeoutHCL string        `hcl:"timeout" json:"-"`
+}
+
+// ScalingWebhook is used in servers to configure the delivery of scaling
+// events to an operator defined HTTPS endpoint.
+type ScalingWebhook struct {
+	// URL is the HTTPS endpoint scaling events are POSTed to.
+	URL string `hcl:"url"`
+
+	// Secret is the key used to sign each request body with HMAC-SHA256.
+	Secret string `hcl:"secret"`
+
+	// CAFile is an optional path to a PEM encoded CA certificate used to
+	// verify the endpoint's certificate.
+	CAFile string `hcl:"ca_file"`
+}
+
+// DeploymentWebhook is used in servers to configure the delivery of
+// deployment status updates to an operator defined HTTPS endpoint.
+type DeploymentWebhook struct {
+	// URL is the HTTPS endpoint deployment status updates are POSTed to.
+	URL string `hcl:"url"`
+
+	// Secret is the key used to sign each request body with HMAC-SHA256.
+	Secret string `hcl:"secret"`
+
+	// CAFile is an optional path to a PEM encoded CA certificate used to
+	// verify the endpoint's certificate.
+	CAFile string `hcl:"ca_file"`
 }
 
 // RaftBoltConfig is used in servers to configure parameters of the boltdb
@@ -661,6 +957,22 @@ type Telemetry struct {
 	// a small memory overhead.
 	DisableDispatchedJobSummaryMetrics bool `hcl:"disable_dispatched_job_summary_metrics"`
 
+	// NamespaceMetrics enables publishing the job summary, job status and
+	// client allocation metrics aggregated by namespace, for dashboards that
+	// need more than cluster level aggregates without the cardinality of the
+	// metrics of each job.
+	NamespaceMetrics bool `hcl:"namespace_metrics"`
+
+	// NamespaceMetricsAllowlist is the namespaces published with their own
+	// namespace label. The other namespaces are aggregated under the
+	// "_other" label.
+	NamespaceMetricsAllowlist []string `hcl:"namespace_metrics_allowlist"`
+
+	// NamespaceMetricsLimit bounds the cardinality of the namespace label
+	// when there is no allowlist: the namespaces seen once the limit is
+	// reached are aggregated under the "_other" label.
+	NamespaceMetricsLimit int `hcl:"namespace_metrics_limit"`
+
 	// Circonus: see https://github.com/circonus-labs/circonus-gometrics
 	// for more details on the various configuration options.
 	// Valid configuration combinations:
@@ -1139,6 +1451,19 @@ func (c *Config) Merge(b *Config) *Config {
 		result.Audit = result.Audit.Merge(b.Audit)
 	}
 
+	// Apply the access log config
+	if b.AccessLog != nil {
+		accessLog := *b.AccessLog
+		result.AccessLog = &accessLog
+	}
+
+	// Apply the event sink config
+	if b.EventSink != nil {
+		eventSink := *b.EventSink
+		eventSink.EventTopics = helper.CopySliceString(b.EventSink.EventTopics)
+		result.EventSink = &eventSink
+	}
+
 	// Apply the ports config
 	if result.Ports == nil && b.Ports != nil {
 		ports := *b.Ports
@@ -1535,6 +1860,12 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.FailoverHeartbeatTTLHCL != "" {
 		result.FailoverHeartbeatTTLHCL = b.FailoverHeartbeatTTLHCL
 	}
+	if b.MaxStale != 0 {
+		result.MaxStale = b.MaxStale
+	}
+	if b.MaxStaleHCL != "" {
+		result.MaxStaleHCL = b.MaxStaleHCL
+	}
 	if b.RetryMaxAttempts != 0 {
 		result.RetryMaxAttempts = b.RetryMaxAttempts
 	}
@@ -1596,6 +1927,45 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		}
 	}
 
+	if b.ScalingWebhook != nil {
+		webhook := *b.ScalingWebhook
+		result.ScalingWebhook = &webhook
+	}
+
+	if b.DeploymentWebhook != nil {
+		webhook := *b.DeploymentWebhook
+		result.DeploymentWebhook = &webhook
+	}
+
+	if b.NodeScorer != nil {
+		scorer := *b.NodeScorer
+		scorer.Args = helper.CopySliceString(b.NodeScorer.Args)
+		result.NodeScorer = &scorer
+	}
+
+	if b.SLOMetrics != nil {
+		metrics := *b.SLOMetrics
+		result.SLOMetrics = &metrics
+	}
+
+	if b.GCAutoTune != nil {
+		tune := *b.GCAutoTune
+		result.GCAutoTune = &tune
+	}
+
+	if b.JobRegisterQueueLimit != 0 {
+		result.JobRegisterQueueLimit = b.JobRegisterQueueLimit
+	}
+
+	if b.WorkloadIdentity != nil {
+		identity := *b.WorkloadIdentity
+		result.WorkloadIdentity = &identity
+	}
+
+	if len(b.ScoringPlugins) != 0 {
+		result.ScoringPlugins = mergeScoringPlugins(s.ScoringPlugins, b.ScoringPlugins)
+	}
+
 	if b.RaftBoltConfig != nil {
 		result.RaftBoltConfig = &RaftBoltConfig{
 			NoFreelistSync: b.RaftBoltConfig.NoFreelistSync,
@@ -1618,6 +1988,84 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	return &result
 }
 
+// mergeScoringPlugins merges two lists of scoring plugins. Plugins in b
+// override the plugins of the same name in a.
+func mergeScoringPlugins(a, b []*ScoringPlugin) []*ScoringPlugin {
+	result := make([]*ScoringPlugin, 0, len(a)+len(b))
+	overridden := make(map[string]struct{}, len(b))
+	for _, p := range b {
+		overridden[p.Name] = struct{}{}
+	}
+	for _, p := range a {
+		if _, ok := overridden[p.Name]; !ok {
+			plugin := *p
+			result = append(result, &plugin)
+		}
+	}
+	for _, p := range b {
+		plugin := *p
+		result = append(result, &plugin)
+	}
+	return result
+}
+
+// mergeNamespaceIDRanges merges two lists of namespace id ranges. Ranges in b
+// override the ranges of the same namespace in a.
+func mergeNamespaceIDRanges(a, b []*client.NamespaceIDRangeConfig) []*client.NamespaceIDRangeConfig {
+	result := make([]*client.NamespaceIDRangeConfig, 0, len(a)+len(b))
+	overridden := make(map[string]struct{}, len(b))
+	for _, r := range b {
+		overridden[r.Namespace] = struct{}{}
+	}
+	for _, r := range a {
+		if _, ok := overridden[r.Namespace]; !ok {
+			result = append(result, r.Copy())
+		}
+	}
+	for _, r := range b {
+		result = append(result, r.Copy())
+	}
+	return result
+}
+
+// mergeArtifactFetchers merges two lists of artifact fetchers. Fetchers in b
+// override the fetchers of the same name in a.
+func mergeArtifactFetchers(a, b []*client.ArtifactFetcherConfig) []*client.ArtifactFetcherConfig {
+	result := make([]*client.ArtifactFetcherConfig, 0, len(a)+len(b))
+	overridden := make(map[string]struct{}, len(b))
+	for _, f := range b {
+		overridden[f.Name] = struct{}{}
+	}
+	for _, f := range a {
+		if _, ok := overridden[f.Name]; !ok {
+			result = append(result, f.Copy())
+		}
+	}
+	for _, f := range b {
+		result = append(result, f.Copy())
+	}
+	return result
+}
+
+// mergeOCIRegistries merges two lists of OCI registries. Registries in b
+// override the registries of a with the same host.
+func mergeOCIRegistries(a, b []*client.OCIRegistryConfig) []*client.OCIRegistryConfig {
+	result := make([]*client.OCIRegistryConfig, 0, len(a)+len(b))
+	overridden := make(map[string]struct{}, len(b))
+	for _, r := range b {
+		overridden[r.Host] = struct{}{}
+	}
+	for _, r := range a {
+		if _, ok := overridden[r.Host]; !ok {
+			result = append(result, r.Copy())
+		}
+	}
+	for _, r := range b {
+		result = append(result, r.Copy())
+	}
+	return result
+}
+
 // Merge is used to merge two client configs together
 func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	result := *a
@@ -1697,6 +2145,10 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 		result.DisableRemoteExec = b.DisableRemoteExec
 	}
 
+	if b.EphemeralDiskEnforcement != "" {
+		result.EphemeralDiskEnforcement = b.EphemeralDiskEnforcement
+	}
+
 	if result.TemplateConfig == nil && b.TemplateConfig != nil {
 		templateConfig := *b.TemplateConfig
 		result.TemplateConfig = &templateConfig
@@ -1741,6 +2193,36 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 		result.HostVolumes = structs.HostVolumeSliceMerge(a.HostVolumes, b.HostVolumes)
 	}
 
+	if len(b.NamespaceIDRanges) != 0 {
+		result.NamespaceIDRanges = mergeNamespaceIDRanges(a.NamespaceIDRanges, b.NamespaceIDRanges)
+	}
+
+	if len(b.ArtifactFetchers) != 0 {
+		result.ArtifactFetchers = mergeArtifactFetchers(a.ArtifactFetchers, b.ArtifactFetchers)
+	}
+
+	if len(b.OCIRegistries) != 0 {
+		result.OCIRegistries = mergeOCIRegistries(a.OCIRegistries, b.OCIRegistries)
+	}
+
+	// The matrix is ordered, so a later matrix replaces the earlier one
+	if len(b.EnvoyVersions) != 0 {
+		result.EnvoyVersions = make([]*client.EnvoyVersionConfig, len(b.EnvoyVersions))
+		for i, e := range b.EnvoyVersions {
+			result.EnvoyVersions[i] = e.Copy()
+		}
+	}
+
+	if b.ArtifactCache != nil {
+		cache := *b.ArtifactCache
+		result.ArtifactCache = &cache
+	}
+	if b.BridgeNetworkFirewall != nil {
+		firewall := *b.BridgeNetworkFirewall
+		firewall.DenyCIDRs = helper.CopySliceString(b.BridgeNetworkFirewall.DenyCIDRs)
+		result.BridgeNetworkFirewall = &firewall
+	}
+
 	if b.CNIPath != "" {
 		result.CNIPath = b.CNIPath
 	}
@@ -1856,6 +2338,16 @@ func (a *Telemetry) Merge(b *Telemetry) *Telemetry {
 		result.DisableDispatchedJobSummaryMetrics = b.DisableDispatchedJobSummaryMetrics
 	}
 
+	if b.NamespaceMetrics {
+		result.NamespaceMetrics = b.NamespaceMetrics
+	}
+	if b.NamespaceMetricsAllowlist != nil {
+		result.NamespaceMetricsAllowlist = b.NamespaceMetricsAllowlist
+	}
+	if b.NamespaceMetricsLimit != 0 {
+		result.NamespaceMetricsLimit = b.NamespaceMetricsLimit
+	}
+
 	return &result
 }
 
@@ -66,6 +66,7 @@ func ParseConfigFile(path string) (*Config, error) {
 		{"server.heartbeat_grace", &c.Server.HeartbeatGrace, &c.Server.HeartbeatGraceHCL, nil},
 		{"server.min_heartbeat_ttl", &c.Server.MinHeartbeatTTL, &c.Server.MinHeartbeatTTLHCL, nil},
 		{"server.failover_heartbeat_ttl", &c.Server.FailoverHeartbeatTTL, &c.Server.FailoverHeartbeatTTLHCL, nil},
+		{"server.max_stale", &c.Server.MaxStale, &c.Server.MaxStaleHCL, nil},
 		{"server.retry_interval", &c.Server.RetryInterval, &c.Server.RetryIntervalHCL, nil},
 		{"server.server_join.retry_interval", &c.Server.ServerJoin.RetryInterval, &c.Server.ServerJoin.RetryIntervalHCL, nil},
 		{"consul.timeout", &c.Consul.Timeout, &c.Consul.TimeoutHCL, nil},
@@ -129,6 +130,36 @@ func ParseConfigFile(path string) (*Config, error) {
 			fmt.Sprintf("audit.sink.%d", i), &sink.RotateDuration, &sink.RotateDurationHCL, nil})
 	}
 
+	if c.AccessLog != nil {
+		tds = append(tds, durationConversionMap{
+			"access_log.rotate_duration", &c.AccessLog.RotateDuration, &c.AccessLog.RotateDurationHCL, nil})
+	}
+
+	if c.Server.NodeScorer != nil {
+		tds = append(tds, durationConversionMap{
+			"server.node_scorer.timeout", &c.Server.NodeScorer.Timeout, &c.Server.NodeScorer.TimeoutHCL, nil})
+	}
+
+	if c.Server.SLOMetrics != nil {
+		tds = append(tds, durationConversionMap{
+			"server.slo_metrics.timeout", &c.Server.SLOMetrics.Timeout, &c.Server.SLOMetrics.TimeoutHCL, nil})
+	}
+
+	if c.Server.WorkloadIdentity != nil {
+		tds = append(tds, durationConversionMap{
+			"server.workload_identity.rotation_interval", &c.Server.WorkloadIdentity.RotationInterval, &c.Server.WorkloadIdentity.RotationIntervalHCL, nil})
+	}
+
+	if c.Server.GCAutoTune != nil {
+		tds = append(tds, durationConversionMap{
+			"server.gc_auto_tune.min_threshold", &c.Server.GCAutoTune.MinThreshold, &c.Server.GCAutoTune.MinThresholdHCL, nil})
+	}
+
+	for _, f := range c.Client.ArtifactFetchers {
+		tds = append(tds, durationConversionMap{
+			fmt.Sprintf("client.artifact_fetcher.%s.timeout", f.Name), &f.Timeout, &f.TimeoutHCL, nil})
+	}
+
 	// convert strings to time.Durations
 	err = convertDurations(tds)
 	if err != nil {
@@ -209,12 +240,42 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "host_volume")
 	}
 
+	// Remove NamespaceIDRange extra keys
+	for _, r := range c.Client.NamespaceIDRanges {
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, r.Namespace)
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "namespace_id_range")
+	}
+
+	// Remove ArtifactFetcher extra keys
+	for _, f := range c.Client.ArtifactFetchers {
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, f.Name)
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "artifact_fetcher")
+	}
+
+	// Remove OCIRegistry extra keys
+	for _, r := range c.Client.OCIRegistries {
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, r.Host)
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "oci_registry")
+	}
+
+	// Remove EnvoyVersion extra keys
+	for _, e := range c.Client.EnvoyVersions {
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, e.Envoy)
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "envoy_version")
+	}
+
 	// Remove HostNetwork extra keys
 	for _, hn := range c.Client.HostNetworks {
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, hn.Name)
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "host_network")
 	}
 
+	// Remove ScoringPlugin extra keys
+	for _, p := range c.Server.ScoringPlugins {
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, p.Name)
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, "scoring_plugin")
+	}
+
 	// Remove AuditConfig extra keys
 	for _, f := range c.Audit.Filters {
 		helper.RemoveEqualFold(&c.Audit.ExtraKeysHCL, f.Name)
@@ -2,12 +2,14 @@ package agent
 
 import (
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 	"sort"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/nomad/ci"
+	client "github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/nomad/structs/config"
@@ -76,20 +78,51 @@ var basicConfig = &Config{
 			DiskMB:        10,
 			ReservedPorts: "1,100,10-12",
 		},
-		GCInterval:            6 * time.Second,
-		GCIntervalHCL:         "6s",
-		GCParallelDestroys:    6,
-		GCDiskUsageThreshold:  82,
-		GCInodeUsageThreshold: 91,
-		GCMaxAllocs:           50,
-		NoHostUUID:            helper.BoolToPtr(false),
-		DisableRemoteExec:     true,
+		GCInterval:               6 * time.Second,
+		GCIntervalHCL:            "6s",
+		GCParallelDestroys:       6,
+		GCDiskUsageThreshold:     82,
+		GCInodeUsageThreshold:    91,
+		GCMaxAllocs:              50,
+		NoHostUUID:               helper.BoolToPtr(false),
+		DisableRemoteExec:        true,
+		EphemeralDiskEnforcement: "hard",
 		HostVolumes: []*structs.ClientHostVolumeConfig{
 			{Name: "tmp", Path: "/tmp"},
 		},
+		ArtifactFetchers: []*client.ArtifactFetcherConfig{
+			{
+				Name:       "corp",
+				Command:    "/usr/local/bin/corp-fetch",
+				Args:       []string{"--region", "eu"},
+				Schemes:    []string{"corp"},
+				Timeout:    5 * time.Minute,
+				TimeoutHCL: "5m",
+				User:       "corp-fetch",
+			},
+		},
+		OCIRegistries: []*client.OCIRegistryConfig{
+			{
+				Host:     "registry.example.com",
+				Username: "nomad",
+				Password: "hunter2",
+			},
+		},
+		EnvoyVersions: []*client.EnvoyVersionConfig{
+			{Envoy: "1.22.2", Consul: ">= 1.12.0, < 1.13.0"},
+			{Envoy: "1.20.4", Consul: "< 1.12.0"},
+		},
+		ArtifactCache: &ArtifactCache{
+			Enabled:   true,
+			MaxSizeMB: 2048,
+		},
 		CNIPath:             "/tmp/cni_path",
 		BridgeNetworkName:   "custom_bridge_name",
 		BridgeNetworkSubnet: "custom_bridge_subnet",
+		BridgeNetworkFirewall: &BridgeNetworkFirewall{
+			Enabled:   true,
+			DenyCIDRs: []string{"10.0.0.0/8"},
+		},
 	},
 	Server: &ServerConfig{
 		Enabled:                   true,
@@ -114,6 +147,8 @@ var basicConfig = &Config{
 		MaxHeartbeatsPerSecond:    11.0,
 		FailoverHeartbeatTTL:      330 * time.Second,
 		FailoverHeartbeatTTLHCL:   "330s",
+		MaxStale:                  5 * time.Second,
+		MaxStaleHCL:               "5s",
 		RetryJoin:                 []string{"1.1.1.1", "2.2.2.2"},
 		StartJoin:                 []string{"1.1.1.1", "2.2.2.2"},
 		RetryInterval:             15 * time.Second,
@@ -228,6 +263,16 @@ var basicConfig = &Config{
 		TLSSkipVerify:        &trueValue,
 		TaskTokenTTL:         "1s",
 		Token:                "12345",
+		Clusters: []*config.VaultClusterConfig{
+			{
+				Name: "team",
+				VaultConfig: config.VaultConfig{
+					Addr:  "https://team-vault.example.com:8200",
+					Role:  "team_role",
+					Token: "67890",
+				},
+			},
+		},
 	},
 	TLSConfig: &config.TLSConfig{
 		EnableHTTP:                  true,
@@ -599,6 +644,92 @@ func TestConfig_ParseSliceExtra(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestConfig_ParseScoringPlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	c, err := ParseConfigFile("./testdata/scoring-plugins.hcl")
+	require.NoError(t, err)
+	require.Equal(t, []*ScoringPlugin{
+		{Name: "cost", Weight: 2},
+		{Name: "power"},
+	}, c.Server.ScoringPlugins)
+
+	// plugins configured again in a later file override earlier ones
+	merged := c.Merge(&Config{Server: &ServerConfig{
+		ScoringPlugins: []*ScoringPlugin{{Name: "cost", Weight: 0.5}},
+	}})
+	require.Equal(t, []*ScoringPlugin{
+		{Name: "power"},
+		{Name: "cost", Weight: 0.5},
+	}, merged.Server.ScoringPlugins)
+}
+
+func TestConfig_ParseNodeScorer(t *testing.T) {
+	ci.Parallel(t)
+
+	c, err := ParseConfigFile("./testdata/node-scorer.hcl")
+	require.NoError(t, err)
+	require.Equal(t, &NodeScorer{
+		Command:    "/usr/local/bin/score",
+		Args:       []string{"-region", "eu"},
+		Timeout:    250 * time.Millisecond,
+		TimeoutHCL: "250ms",
+	}, c.Server.NodeScorer)
+
+	// args is only a valid key under node_scorer
+	path := filepath.Join(t.TempDir(), "args.hcl")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`args = ["-region", "eu"]`), 0600))
+	_, err = ParseConfigFile(path)
+	require.EqualError(t, err, "unexpected keys args")
+}
+
+func TestConfig_ParseAccessLog(t *testing.T) {
+	ci.Parallel(t)
+
+	c, err := ParseConfigFile("./testdata/access-log.hcl")
+	require.NoError(t, err)
+	require.Equal(t, &AccessLog{
+		Enabled:           true,
+		Path:              "/var/log/nomad/",
+		RotateDuration:    time.Hour,
+		RotateDurationHCL: "1h",
+		RotateBytes:       1048576,
+		RotateMaxFiles:    5,
+		HTTPAddress:       "https://collector.example.com/ingest",
+	}, c.AccessLog)
+
+	// the access log configured in a later file replaces the earlier one
+	merged := c.Merge(&Config{AccessLog: &AccessLog{Enabled: false}})
+	require.Equal(t, &AccessLog{Enabled: false}, merged.AccessLog)
+}
+
+func TestConfig_ParseEventSink(t *testing.T) {
+	ci.Parallel(t)
+
+	c, err := ParseConfigFile("./testdata/event-sink.hcl")
+	require.NoError(t, err)
+	require.Equal(t, &EventSink{
+		Enabled:     true,
+		Type:        "nats",
+		Address:     "nats://nats.example.com:4222",
+		Username:    "nomad",
+		Password:    "secret",
+		TLS:         true,
+		CAFile:      "/etc/nats/ca.pem",
+		Topic:       "nomad.{{ .Namespace }}.{{ .Topic }}",
+		EventTopics: []string{"Job", "Allocation:example"},
+		Namespace:   "prod",
+		Consumer:    "nats-sink",
+		BufferSize:  4096,
+		BatchSize:   200,
+		OnFull:      "drop",
+	}, c.EventSink)
+
+	// the event sink configured in a later file replaces the earlier one
+	merged := c.Merge(&Config{EventSink: &EventSink{Enabled: false}})
+	require.Equal(t, &EventSink{Enabled: false}, merged.EventSink)
+}
+
 var sample0 = &Config{
 	Region:     "global",
 	Datacenter: "dc1",
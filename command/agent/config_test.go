@@ -278,6 +278,9 @@ func TestConfig_Merge(t *testing.T) {
 			CirconusBrokerSelectTag:            "dc:dc2",
 			PrefixFilter:                       []string{"prefix1", "prefix2"},
 			DisableDispatchedJobSummaryMetrics: true,
+			NamespaceMetrics:                   true,
+			NamespaceMetricsAllowlist:          []string{"default"},
+			NamespaceMetricsLimit:              10,
 			FilterDefault:                      helper.BoolToPtr(false),
 		},
 		Client: &ClientConfig{
@@ -1344,6 +1347,9 @@ func TestTelemetry_Parse(t *testing.T) {
 		prefix_filter = ["+nomad.raft"]
 		filter_default = false
 		disable_dispatched_job_summary_metrics = true
+		namespace_metrics = true
+		namespace_metrics_allowlist = ["default", "team-a"]
+		namespace_metrics_limit = 20
 	}`), 0600)
 	require.NoError(err)
 
@@ -1354,6 +1360,9 @@ func TestTelemetry_Parse(t *testing.T) {
 	require.False(*config.Telemetry.FilterDefault)
 	require.Exactly([]string{"+nomad.raft"}, config.Telemetry.PrefixFilter)
 	require.True(config.Telemetry.DisableDispatchedJobSummaryMetrics)
+	require.True(config.Telemetry.NamespaceMetrics)
+	require.Exactly([]string{"default", "team-a"}, config.Telemetry.NamespaceMetricsAllowlist)
+	require.Equal(20, config.Telemetry.NamespaceMetricsLimit)
 }
 
 func TestEventBroker_Parse(t *testing.T) {
@@ -1472,6 +1481,12 @@ func TestConfig_LoadConsulTemplateBasic(t *testing.T) {
 	require.NotNil(t, templateConfig)
 	require.True(t, templateConfig.DisableSandbox)
 	require.Len(t, templateConfig.FunctionDenylist, 1)
+	require.Equal(t, []*client.TemplatePluginFunctionConfig{{
+		Name:    "externalSecret",
+		Command: "/usr/local/bin/external-secret",
+		Args:    []string{"--role", "nomad"},
+		Jobs:    []string{"default/web"},
+	}}, templateConfig.PluginFunctions)
 
 	// json
 	agentConfig, err = LoadConfig("test-resources/client_with_basic_template.json")
@@ -1487,6 +1502,12 @@ func TestConfig_LoadConsulTemplateBasic(t *testing.T) {
 	require.NotNil(t, templateConfig)
 	require.True(t, templateConfig.DisableSandbox)
 	require.Len(t, templateConfig.FunctionDenylist, 1)
+	require.Equal(t, []*client.TemplatePluginFunctionConfig{{
+		Name:    "externalSecret",
+		Command: "/usr/local/bin/external-secret",
+		Args:    []string{"--role", "nomad"},
+		Jobs:    []string{"default/web"},
+	}}, templateConfig.PluginFunctions)
 }
 
 func TestParseMultipleIPTemplates(t *testing.T) {
@@ -69,10 +69,10 @@ func (m *MockCatalog) Service(service, tag string, q *api.QueryOptions) ([]*api.
 
 // MockAgent is a fake in-memory Consul backend for ServiceClient.
 type MockAgent struct {
-	// services tracks what services have been registered, per namespace
+	// services tracks what services have been registered, per scopeKey
 	services map[string]map[string]*api.AgentServiceRegistration
 
-	// checks tracks what checks have been registered, per namespace
+	// checks tracks what checks have been registered, per scopeKey
 	checks map[string]map[string]*api.AgentCheckRegistration
 
 	// hits is the total number of times agent methods have been called
@@ -193,10 +193,23 @@ func (c *MockAgent) Self() (map[string]map[string]interface{}, error) {
 }
 
 func getNamespace(q *api.QueryOptions) string {
-	if q == nil || q.Namespace == "" {
+	if q == nil {
 		return "default"
 	}
-	return q.Namespace
+	return scopeKey(q.Partition, q.Namespace)
+}
+
+// scopeKey returns the key of the services and checks registered in the
+// namespace of the admin partition: the namespace for the partition of the
+// agent, otherwise the partition and namespace separated by a slash.
+func scopeKey(partition, namespace string) string {
+	if namespace == "" {
+		namespace = "default"
+	}
+	if partition == "" {
+		return namespace
+	}
+	return partition + "/" + namespace
 }
 
 // ServicesWithFilterOpts implements AgentAPI
@@ -217,8 +230,16 @@ func (c *MockAgent) ServicesWithFilterOpts(_ string, q *api.QueryOptions) (map[s
 			Port:              v.Port,
 			Address:           v.Address,
 			EnableTagOverride: v.EnableTagOverride,
+			Namespace:         v.Namespace,
+			Partition:         v.Partition,
 		}
 		copy(r[k].Tags, v.Tags)
+
+		// Consul reports default weights if none were registered
+		r[k].Weights = api.AgentWeights{Passing: 1, Warning: 1}
+		if v.Weights != nil {
+			r[k].Weights = *v.Weights
+		}
 	}
 	return r, nil
 }
@@ -240,6 +261,8 @@ func (c *MockAgent) ChecksWithFilterOpts(_ string, q *api.QueryOptions) (map[str
 			Notes:       v.Notes,
 			ServiceID:   v.ServiceID,
 			ServiceName: c.services[namespace][v.ServiceID].Name,
+			Namespace:   v.Namespace,
+			Partition:   v.Partition,
 		}
 	}
 	return r, nil
@@ -270,18 +293,19 @@ func (c *MockAgent) CheckRegister(check *api.AgentCheckRegistration) error {
 	if check.Namespace == "" {
 		check.Namespace = "default"
 	}
+	scope := scopeKey(check.Partition, check.Namespace)
 
-	if c.checks[check.Namespace] == nil {
-		c.checks[check.Namespace] = make(map[string]*api.AgentCheckRegistration)
+	if c.checks[scope] == nil {
+		c.checks[scope] = make(map[string]*api.AgentCheckRegistration)
 	}
-	c.checks[check.Namespace][check.ID] = check
+	c.checks[scope][check.ID] = check
 
 	// Be nice and make checks reachable-by-service
 	serviceCheck := check.AgentServiceCheck
-	if c.services[check.Namespace] == nil {
-		c.services[check.Namespace] = make(map[string]*api.AgentServiceRegistration)
+	if c.services[scope] == nil {
+		c.services[scope] = make(map[string]*api.AgentServiceRegistration)
 	}
-	c.services[check.Namespace][check.ServiceID].Checks = append(c.services[check.Namespace][check.ServiceID].Checks, &serviceCheck)
+	c.services[scope][check.ServiceID].Checks = append(c.services[scope][check.ServiceID].Checks, &serviceCheck)
 	return nil
 }
 
@@ -310,10 +334,12 @@ func (c *MockAgent) ServiceRegister(service *api.AgentServiceRegistration) error
 		service.Namespace = "default"
 	}
 
-	if c.services[service.Namespace] == nil {
-		c.services[service.Namespace] = make(map[string]*api.AgentServiceRegistration)
+	scope := scopeKey(service.Partition, service.Namespace)
+
+	if c.services[scope] == nil {
+		c.services[scope] = make(map[string]*api.AgentServiceRegistration)
 	}
-	c.services[service.Namespace][service.ID] = service
+	c.services[scope][service.ID] = service
 	return nil
 }
 
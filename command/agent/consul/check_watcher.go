@@ -233,7 +233,7 @@ WATCHER:
 			now := time.Now()
 
 			// Get the list of all namespaces so we can iterate them.
-			namespaces, err := w.namespacesClient.List()
+			scopes, err := w.namespacesClient.Scopes()
 			if err != nil {
 				if !w.lastErr {
 					w.lastErr = true
@@ -243,8 +243,8 @@ WATCHER:
 			}
 
 			checkResults := make(map[string]*api.AgentCheck)
-			for _, namespace := range namespaces {
-				nsResults, err := w.checksAPI.ChecksWithFilterOpts("", &api.QueryOptions{Namespace: normalizeNamespace(namespace)})
+			for _, scope := range scopes {
+				nsResults, err := w.checksAPI.ChecksWithFilterOpts("", scope)
 				if err != nil {
 					if !w.lastErr {
 						w.lastErr = true
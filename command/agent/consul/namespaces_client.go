@@ -4,6 +4,8 @@ import (
 	"sort"
 	"sync"
 	"time"
+
+	"github.com/hashicorp/consul/api"
 )
 
 const (
@@ -22,13 +24,26 @@ type NamespacesClient struct {
 	lock    sync.Mutex
 	enabled bool      // namespaces requires Ent + Namespaces feature
 	updated time.Time // memoize response for a while
+
+	// partitionScopes are the namespaces of Consul admin partitions services
+	// were registered in. Consul only lists the namespaces of the partition of
+	// the agent, so these are queried in addition to them.
+	partitionScopes     map[partitionScope]struct{}
+	partitionScopesLock sync.Mutex
+}
+
+// partitionScope is a namespace of a Consul admin partition.
+type partitionScope struct {
+	partition string
+	namespace string
 }
 
 // NewNamespacesClient returns a NamespacesClient backed by a NamespaceAPI.
 func NewNamespacesClient(namespacesAPI NamespaceAPI, agentAPI AgentAPI) *NamespacesClient {
 	return &NamespacesClient{
-		namespacesAPI: namespacesAPI,
-		agentAPI:      agentAPI,
+		namespacesAPI:   namespacesAPI,
+		agentAPI:        agentAPI,
+		partitionScopes: make(map[partitionScope]struct{}),
 	}
 }
 
@@ -88,3 +103,41 @@ func (ns *NamespacesClient) List() ([]string, error) {
 	sort.Strings(result)
 	return result, nil
 }
+
+// AddPartitionScope records that services were registered in the namespace of
+// the Consul admin partition, so that Scopes returns it. An empty partition is
+// the partition of the agent, whose namespaces are always listed.
+func (ns *NamespacesClient) AddPartitionScope(partition, namespace string) {
+	if partition == "" {
+		return
+	}
+
+	ns.partitionScopesLock.Lock()
+	defer ns.partitionScopesLock.Unlock()
+	ns.partitionScopes[partitionScope{partition: partition, namespace: namespace}] = struct{}{}
+}
+
+// Scopes returns the query options of every namespace of the partition of the
+// agent and of the namespaces of other partitions recorded with
+// AddPartitionScope, to query the services and checks registered in them.
+func (ns *NamespacesClient) Scopes() ([]*api.QueryOptions, error) {
+	namespaces, err := ns.List()
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := make([]*api.QueryOptions, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		scopes = append(scopes, &api.QueryOptions{Namespace: normalizeNamespace(namespace)})
+	}
+
+	ns.partitionScopesLock.Lock()
+	defer ns.partitionScopesLock.Unlock()
+	for scope := range ns.partitionScopes {
+		scopes = append(scopes, &api.QueryOptions{
+			Partition: scope.partition,
+			Namespace: scope.namespace,
+		})
+	}
+	return scopes, nil
+}
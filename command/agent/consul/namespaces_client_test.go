@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/stretchr/testify/require"
 )
@@ -45,6 +46,28 @@ func TestNamespacesClient_List(t *testing.T) {
 	})
 }
 
+func TestNamespacesClient_Scopes(t *testing.T) {
+	ci.Parallel(t)
+
+	c := NewNamespacesClient(NewMockNamespaces([]string{"banana"}), NewMockAgent(Features{
+		Enterprise: true,
+		Namespaces: true,
+	}))
+	c.AddPartitionScope("", "banana")
+	c.AddPartitionScope("tenant-a", "")
+	c.AddPartitionScope("tenant-a", "cherry")
+	c.AddPartitionScope("tenant-a", "cherry")
+
+	scopes, err := c.Scopes()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*api.QueryOptions{
+		{Namespace: "banana"},
+		{Namespace: ""},
+		{Partition: "tenant-a", Namespace: ""},
+		{Partition: "tenant-a", Namespace: "cherry"},
+	}, scopes)
+}
+
 func TestNewNamespacesClient_stale(t *testing.T) {
 	ci.Parallel(t)
 
@@ -76,6 +76,10 @@ const (
 	// services registered in Consul but not in Nomad don't get deregistered,
 	// to allow for nomad restoring tasks
 	deregisterProbationPeriod = time.Minute
+
+	// serviceKindAPIGateway is the Consul service Kind of an API Gateway,
+	// which the Consul API client does not yet define.
+	serviceKindAPIGateway api.ServiceKind = "api-gateway"
 )
 
 // Additional Consul ACLs required
@@ -215,6 +219,8 @@ func different(wanted *api.AgentServiceRegistration, existing *api.AgentService,
 		return true
 	case !reflect.DeepEqual(wanted.Meta, existing.Meta):
 		return true
+	case weightsDifferent(wanted.Weights, existing.Weights):
+		return true
 	case tagsDifferent(wanted.Tags, existing.Tags):
 		return true
 	case connectSidecarDifferent(wanted, sidecar):
@@ -223,6 +229,20 @@ func different(wanted *api.AgentServiceRegistration, existing *api.AgentService,
 	return false
 }
 
+// weightsDifferent compares the wanted weights with those reported by Consul.
+// Consul uses weights of passing=1 and warning=1 if none were set, so unset
+// weights on either side are treated as the defaults.
+func weightsDifferent(wanted *api.AgentWeights, existing api.AgentWeights) bool {
+	defaults := api.AgentWeights{Passing: 1, Warning: 1}
+	if wanted == nil {
+		wanted = &defaults
+	}
+	if existing == (api.AgentWeights{}) {
+		existing = defaults
+	}
+	return *wanted != existing
+}
+
 func tagsDifferent(a, b []string) bool {
 	if len(a) != len(b) {
 		return true
@@ -772,7 +792,7 @@ func (c *ServiceClient) sync(reason syncReason) error {
 	var err error
 
 	// Get the list of all namespaces created so we can iterate them.
-	namespaces, err := c.namespacesClient.List()
+	scopes, err := c.namespacesClient.Scopes()
 	if err != nil {
 		metrics.IncrCounter([]string{"client", "consul", "sync_failure"}, 1)
 		return errors.Wrap(err, "failed to query Consul namespaces")
@@ -780,8 +800,8 @@ func (c *ServiceClient) sync(reason syncReason) error {
 
 	// Accumulate all services in Consul across all namespaces.
 	servicesInConsul := make(map[string]*api.AgentService)
-	for _, namespace := range namespaces {
-		if nsServices, err := c.agentAPI.ServicesWithFilterOpts("", &api.QueryOptions{Namespace: normalizeNamespace(namespace)}); err != nil {
+	for _, scope := range scopes {
+		if nsServices, err := c.agentAPI.ServicesWithFilterOpts("", scope); err != nil {
 			metrics.IncrCounter([]string{"client", "consul", "sync_failure"}, 1)
 			return errors.Wrap(err, "failed to query Consul services")
 		} else {
@@ -821,8 +841,11 @@ func (c *ServiceClient) sync(reason syncReason) error {
 			continue
 		}
 
-		// Get the Consul namespace this service is in.
-		ns := servicesInConsul[id].Namespace
+		// Get the Consul partition and namespace this service is in.
+		q := &api.QueryOptions{
+			Partition: servicesInConsul[id].Partition,
+			Namespace: servicesInConsul[id].Namespace,
+		}
 
 		// If this service has a sidecar, we need to remove the sidecar first,
 		// otherwise Consul will produce a warning and an error when removing
@@ -831,14 +854,14 @@ func (c *ServiceClient) sync(reason syncReason) error {
 		// The sidecar is not tracked on the Nomad side; it was registered
 		// implicitly through the parent service.
 		if sidecar := getNomadSidecar(id, servicesInConsul); sidecar != nil {
-			if err := c.agentAPI.ServiceDeregisterOpts(sidecar.ID, &api.QueryOptions{Namespace: ns}); err != nil {
+			if err := c.agentAPI.ServiceDeregisterOpts(sidecar.ID, q); err != nil {
 				metrics.IncrCounter([]string{"client", "consul", "sync_failure"}, 1)
 				return err
 			}
 		}
 
 		// Remove the unwanted service.
-		if err := c.agentAPI.ServiceDeregisterOpts(id, &api.QueryOptions{Namespace: ns}); err != nil {
+		if err := c.agentAPI.ServiceDeregisterOpts(id, q); err != nil {
 			if isOldNomadService(id) {
 				// Don't hard-fail on old entries. See #3620
 				continue
@@ -869,8 +892,8 @@ func (c *ServiceClient) sync(reason syncReason) error {
 	}
 
 	checksInConsul := make(map[string]*api.AgentCheck)
-	for _, namespace := range namespaces {
-		nsChecks, err := c.agentAPI.ChecksWithFilterOpts("", &api.QueryOptions{Namespace: normalizeNamespace(namespace)})
+	for _, scope := range scopes {
+		nsChecks, err := c.agentAPI.ChecksWithFilterOpts("", scope)
 		if err != nil {
 			metrics.IncrCounter([]string{"client", "consul", "sync_failure"}, 1)
 			return errors.Wrap(err, "failed to query Consul checks")
@@ -907,7 +930,7 @@ func (c *ServiceClient) sync(reason syncReason) error {
 		}
 
 		// Unknown Nomad managed check; remove
-		if err := c.agentAPI.CheckDeregisterOpts(id, &api.QueryOptions{Namespace: check.Namespace}); err != nil {
+		if err := c.agentAPI.CheckDeregisterOpts(id, &api.QueryOptions{Partition: check.Partition, Namespace: check.Namespace}); err != nil {
 			if isOldNomadService(check.ServiceID) {
 				// Don't hard-fail on old entries.
 				continue
@@ -1128,6 +1151,8 @@ func (c *ServiceClient) serviceRegs(ops *operations, service *structs.Service, w
 				}
 			}
 		}
+	case service.Connect.IsAPIGateway():
+		kind = serviceKindAPIGateway
 	}
 
 	// Build the Consul Service registration request
@@ -1135,7 +1160,8 @@ func (c *ServiceClient) serviceRegs(ops *operations, service *structs.Service, w
 		Kind:              kind,
 		ID:                id,
 		Name:              service.Name,
-		Namespace:         workload.ConsulNamespace,
+		Namespace:         serviceNamespace(service, workload),
+		Partition:         service.Partition,
 		Tags:              tags,
 		EnableTagOverride: service.EnableTagOverride,
 		Address:           ip,
@@ -1144,8 +1170,18 @@ func (c *ServiceClient) serviceRegs(ops *operations, service *structs.Service, w
 		Connect:           connect, // will be nil if no Connect stanza
 		Proxy:             gateway, // will be nil if no Connect Gateway stanza
 	}
+	if service.Weights != nil {
+		serviceReg.Weights = &api.AgentWeights{
+			Passing: service.Weights.Passing,
+			Warning: service.Weights.Warning,
+		}
+	}
 	ops.regServices = append(ops.regServices, serviceReg)
 
+	// Services in other partitions are only found by sync if it knows to
+	// query their partition
+	c.namespacesClient.AddPartitionScope(serviceReg.Partition, serviceReg.Namespace)
+
 	// Build the check registrations
 	checkRegs, err := c.checkRegs(id, service, workload, sreg)
 	if err != nil {
@@ -1188,10 +1224,13 @@ func (c *ServiceClient) checkRegs(serviceID string, service *structs.Service,
 		}
 
 		checkID := MakeCheckID(serviceID, check)
-		registration, err := createCheckReg(serviceID, checkID, check, ip, port, workload.ConsulNamespace)
+		// Checks are registered in the namespace and partition of their
+		// service, which validation ensures any set by the check match
+		registration, err := createCheckReg(serviceID, checkID, check, ip, port, serviceNamespace(service, workload))
 		if err != nil {
 			return nil, fmt.Errorf("failed to add check %q: %v", check.Name, err)
 		}
+		registration.Partition = service.Partition
 		sreg.CheckOnUpdate[checkID] = check.OnUpdate
 		registrations = append(registrations, registration)
 	}
@@ -1404,6 +1443,18 @@ func normalizeNamespace(namespace string) string {
 	return namespace
 }
 
+// serviceNamespace returns the Consul namespace to register the service of the
+// workload in. Services inheriting the "default" namespace are registered in
+// the namespace of the agent's token, while services explicitly in the
+// "default" namespace are registered in it.
+func serviceNamespace(service *structs.Service, workload *WorkloadServices) string {
+	namespace := service.ConsulNamespace(workload.ConsulNamespace)
+	if service.ExplicitNamespace {
+		return namespace
+	}
+	return normalizeNamespace(namespace)
+}
+
 // AllocRegistrations returns the registrations for the given allocation. If the
 // allocation has no registrations, the response is a nil object.
 func (c *ServiceClient) AllocRegistrations(allocID string) (*AllocRegistration, error) {
@@ -1420,7 +1471,7 @@ func (c *ServiceClient) AllocRegistrations(allocID string) (*AllocRegistration,
 	c.allocRegistrationsLock.RUnlock()
 
 	// Get the list of all namespaces created so we can iterate them.
-	namespaces, err := c.namespacesClient.List()
+	scopes, err := c.namespacesClient.Scopes()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to retrieve namespaces from consul")
 	}
@@ -1429,8 +1480,8 @@ func (c *ServiceClient) AllocRegistrations(allocID string) (*AllocRegistration,
 	checks := make(map[string]*api.AgentCheck)
 
 	// Query the services and checks to populate the allocation registrations.
-	for _, namespace := range namespaces {
-		nsServices, err := c.agentAPI.ServicesWithFilterOpts("", &api.QueryOptions{Namespace: normalizeNamespace(namespace)})
+	for _, scope := range scopes {
+		nsServices, err := c.agentAPI.ServicesWithFilterOpts("", scope)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to retrieve services from consul")
 		}
@@ -1438,7 +1489,7 @@ func (c *ServiceClient) AllocRegistrations(allocID string) (*AllocRegistration,
 			services[k] = v
 		}
 
-		nsChecks, err := c.agentAPI.ChecksWithFilterOpts("", &api.QueryOptions{Namespace: normalizeNamespace(namespace)})
+		nsChecks, err := c.agentAPI.ChecksWithFilterOpts("", scope)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to retrieve checks from consul")
 		}
@@ -1464,9 +1515,9 @@ func (c *ServiceClient) AllocRegistrations(allocID string) (*AllocRegistration,
 
 // UpdateTTL is used to update the TTL of a check. Typically this will only be
 // called to heartbeat script checks.
-func (c *ServiceClient) UpdateTTL(id, namespace, output, status string) error {
+func (c *ServiceClient) UpdateTTL(id, namespace, partition, output, status string) error {
 	ns := normalizeNamespace(namespace)
-	return c.agentAPI.UpdateTTLOpts(id, output, status, &api.QueryOptions{Namespace: ns})
+	return c.agentAPI.UpdateTTLOpts(id, output, status, &api.QueryOptions{Partition: partition, Namespace: ns})
 }
 
 // Shutdown the Consul client. Update running task registrations and deregister
@@ -1507,14 +1558,14 @@ func (c *ServiceClient) Shutdown() error {
 		}
 	}
 
-	namespaces, err := c.namespacesClient.List()
+	scopes, err := c.namespacesClient.Scopes()
 	if err != nil {
 		c.logger.Error("failed to retrieve namespaces from consul", "error", err)
 	}
 
 	remainingChecks := make(map[string]*api.AgentCheck)
-	for _, namespace := range namespaces {
-		nsChecks, err := c.agentAPI.ChecksWithFilterOpts("", &api.QueryOptions{Namespace: normalizeNamespace(namespace)})
+	for _, scope := range scopes {
+		nsChecks, err := c.agentAPI.ChecksWithFilterOpts("", scope)
 		if err != nil {
 			c.logger.Error("failed to retrieve checks from consul", "error", err)
 		}
@@ -1616,7 +1667,7 @@ func createCheckReg(serviceID, checkID string, check *structs.ServiceCheck, host
 		ID:        checkID,
 		Name:      check.Name,
 		ServiceID: serviceID,
-		Namespace: normalizeNamespace(namespace),
+		Namespace: namespace,
 	}
 	chkReg.Status = check.InitialStatus
 	chkReg.Timeout = check.Timeout.String()
@@ -1651,6 +1702,7 @@ func createCheckReg(serviceID, checkID string, check *structs.ServiceCheck, host
 		chkReg.Method = check.Method
 		chkReg.Header = check.Header
 		chkReg.Body = check.Body
+		chkReg.TLSServerName = check.TLSServerName
 
 	case structs.ServiceCheckTCP:
 		chkReg.TCP = net.JoinHostPort(host, strconv.Itoa(port))
@@ -1661,8 +1713,16 @@ func createCheckReg(serviceID, checkID string, check *structs.ServiceCheck, host
 		chkReg.Interval = ""
 
 	case structs.ServiceCheckGRPC:
+		if check.RequiresClientTLS() {
+			// Consul can't present a per-check client certificate, so the
+			// check is run by the Nomad client like a script check
+			chkReg.TTL = (check.Interval + ttlCheckBuffer).String()
+			chkReg.Interval = ""
+			break
+		}
 		chkReg.GRPC = fmt.Sprintf("%s/%s", net.JoinHostPort(host, strconv.Itoa(port)), check.GRPCService)
 		chkReg.GRPCUseTLS = check.GRPCUseTLS
+		chkReg.TLSServerName = check.TLSServerName
 		if check.TLSSkipVerify {
 			chkReg.TLSSkipVerify = true
 		}
@@ -120,6 +120,13 @@ func TestSyncLogic_agentServiceUpdateRequired(t *testing.T) {
 		})
 	})
 
+	t.Run("different weights", func(t *testing.T) {
+		try(t, true, syncNewOps, func(w asr) *asr {
+			w.Weights = &api.AgentWeights{Passing: 5, Warning: 1}
+			return &w
+		})
+	})
+
 	t.Run("different name", func(t *testing.T) {
 		try(t, true, syncNewOps, func(w asr) *asr {
 			w.Name = "bob"
@@ -286,6 +293,36 @@ func TestSyncLogic_tagsDifferent(t *testing.T) {
 	})
 }
 
+func TestSyncLogic_weightsDifferent(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("nil default", func(t *testing.T) {
+		require.False(t, weightsDifferent(nil, api.AgentWeights{Passing: 1, Warning: 1}))
+	})
+
+	t.Run("nil unset", func(t *testing.T) {
+		require.False(t, weightsDifferent(nil, api.AgentWeights{}))
+	})
+
+	t.Run("nil set", func(t *testing.T) {
+		require.True(t, weightsDifferent(nil, api.AgentWeights{Passing: 10, Warning: 1}))
+	})
+
+	t.Run("same", func(t *testing.T) {
+		require.False(t, weightsDifferent(
+			&api.AgentWeights{Passing: 10, Warning: 2},
+			api.AgentWeights{Passing: 10, Warning: 2},
+		))
+	})
+
+	t.Run("different", func(t *testing.T) {
+		require.True(t, weightsDifferent(
+			&api.AgentWeights{Passing: 10, Warning: 2},
+			api.AgentWeights{Passing: 10, Warning: 1},
+		))
+	})
+}
+
 func TestSyncLogic_sidecarTagsDifferent(t *testing.T) {
 	ci.Parallel(t)
 
@@ -647,3 +684,111 @@ func TestSyncLogic_maybeSidecarProxyCheck(t *testing.T) {
 	try("service:_nomad-task-2f5fb517-57d4-44ee-7780-dc1cb6e103cd-group-api-count-api-9001-sidecar-proxy: ", false)
 	try("service", false)
 }
+
+func TestServiceClient_serviceRegs_namespace(t *testing.T) {
+	ci.Parallel(t)
+
+	mockAgent := NewMockAgent(ossFeatures)
+	namespacesClient := NewNamespacesClient(NewMockNamespaces(nil), mockAgent)
+	logger := testlog.HCLogger(t)
+	sc := NewServiceClient(mockAgent, namespacesClient, logger, true)
+
+	ws := &WorkloadServices{
+		AllocID:         uuid.Generate(),
+		Task:            "taskname",
+		ConsulNamespace: "group-ns",
+		Networks: []*structs.NetworkResource{{
+			DynamicPorts: []structs.Port{{Label: "x", Value: xPort}},
+		}},
+	}
+
+	check := func(name, namespace string) *structs.ServiceCheck {
+		return &structs.ServiceCheck{
+			Name:      name,
+			Type:      "tcp",
+			Interval:  time.Second,
+			Timeout:   time.Second,
+			PortLabel: "x",
+			Namespace: namespace,
+		}
+	}
+
+	t.Run("inherit group namespace", func(t *testing.T) {
+		ops := new(operations)
+		service := &structs.Service{
+			Name:      "inherit",
+			PortLabel: "x",
+			Checks:    []*structs.ServiceCheck{check("c1", "")},
+		}
+		_, err := sc.serviceRegs(ops, service, ws)
+		require.NoError(t, err)
+		require.Equal(t, "group-ns", ops.regServices[0].Namespace)
+		require.Equal(t, "group-ns", ops.regChecks[0].Namespace)
+	})
+
+	t.Run("pre-upgrade job", func(t *testing.T) {
+		// Jobs stored by earlier versions have the namespace of every service
+		// canonicalized to "default", which inherits the group namespace
+		ops := new(operations)
+		service := &structs.Service{
+			Name:      "stored",
+			PortLabel: "x",
+			Namespace: "default",
+			Checks:    []*structs.ServiceCheck{check("c1", "")},
+		}
+		_, err := sc.serviceRegs(ops, service, ws)
+		require.NoError(t, err)
+		require.Equal(t, "group-ns", ops.regServices[0].Namespace)
+		require.Equal(t, "group-ns", ops.regChecks[0].Namespace)
+	})
+
+	t.Run("explicit default namespace", func(t *testing.T) {
+		// Services explicitly in the "default" namespace don't inherit the
+		// namespace of the group
+		ops := new(operations)
+		service := &structs.Service{
+			Name:              "default",
+			PortLabel:         "x",
+			Namespace:         "default",
+			ExplicitNamespace: true,
+			Checks:            []*structs.ServiceCheck{check("c1", "")},
+		}
+		_, err := sc.serviceRegs(ops, service, ws)
+		require.NoError(t, err)
+		require.Equal(t, "default", ops.regServices[0].Namespace)
+		require.Equal(t, "default", ops.regChecks[0].Namespace)
+	})
+
+	t.Run("override namespace", func(t *testing.T) {
+		ops := new(operations)
+		service := &structs.Service{
+			Name:      "override",
+			PortLabel: "x",
+			Namespace: "service-ns",
+			Checks:    []*structs.ServiceCheck{check("c1", ""), check("c2", "service-ns")},
+		}
+		_, err := sc.serviceRegs(ops, service, ws)
+		require.NoError(t, err)
+		require.Equal(t, "service-ns", ops.regServices[0].Namespace)
+		require.Equal(t, "service-ns", ops.regChecks[0].Namespace)
+		require.Equal(t, "service-ns", ops.regChecks[1].Namespace)
+	})
+	t.Run("partition", func(t *testing.T) {
+		ops := new(operations)
+		service := &structs.Service{
+			Name:      "partition",
+			PortLabel: "x",
+			Partition: "tenant-a",
+			Checks:    []*structs.ServiceCheck{check("c1", "")},
+		}
+		_, err := sc.serviceRegs(ops, service, ws)
+		require.NoError(t, err)
+		require.Equal(t, "tenant-a", ops.regServices[0].Partition)
+		require.Equal(t, "tenant-a", ops.regChecks[0].Partition)
+		require.Equal(t, "group-ns", ops.regServices[0].Namespace)
+
+		scopes, err := namespacesClient.Scopes()
+		require.NoError(t, err)
+		require.Contains(t, scopes, &api.QueryOptions{Partition: "tenant-a", Namespace: "group-ns"})
+	})
+}
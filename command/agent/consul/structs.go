@@ -96,6 +96,22 @@ func (ws *WorkloadServices) Copy() *WorkloadServices {
 	return newTS
 }
 
+// AddBlueGreenTag appends the tag returned by Allocation.BlueGreenTag to the
+// tags and canary tags of the services, which must be copies owned by the
+// caller.
+func AddBlueGreenTag(services []*structs.Service, tag string) {
+	if tag == "" {
+		return
+	}
+
+	for _, service := range services {
+		service.Tags = append(service.Tags, tag)
+		if len(service.CanaryTags) > 0 {
+			service.CanaryTags = append(service.CanaryTags, tag)
+		}
+	}
+}
+
 func (ws *WorkloadServices) Name() string {
 	if ws.Task != "" {
 		return ws.Task
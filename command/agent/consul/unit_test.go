@@ -1122,6 +1122,44 @@ func TestConsul_CanaryTags(t *testing.T) {
 	require.Len(ctx.FakeConsul.services["default"], 0)
 }
 
+// TestConsul_Partition asserts services are registered, found and
+// deregistered in the admin partition they set.
+func TestConsul_Partition(t *testing.T) {
+	ci.Parallel(t)
+
+	require := require.New(t)
+	ctx := setupFake(t)
+
+	ctx.Workload.Services[0].Partition = "tenant-a"
+	ctx.Workload.Services[0].Checks = []*structs.ServiceCheck{{
+		Name:     "tcp",
+		Type:     "tcp",
+		Interval: time.Second,
+		Timeout:  time.Second,
+	}}
+
+	require.NoError(ctx.ServiceClient.RegisterWorkload(ctx.Workload))
+	require.NoError(ctx.syncOnce(syncNewOps))
+	require.Len(ctx.FakeConsul.services["default"], 0)
+	require.Len(ctx.FakeConsul.services["tenant-a/default"], 1)
+	require.Len(ctx.FakeConsul.checks["tenant-a/default"], 1)
+
+	reg, err := ctx.ServiceClient.AllocRegistrations(ctx.Workload.AllocID)
+	require.NoError(err)
+	require.Equal(1, reg.NumServices())
+	require.Equal(1, reg.NumChecks())
+
+	// A periodic sync leaves the services and checks in place
+	require.NoError(ctx.syncOnce(syncPeriodic))
+	require.Len(ctx.FakeConsul.services["tenant-a/default"], 1)
+	require.Len(ctx.FakeConsul.checks["tenant-a/default"], 1)
+
+	ctx.ServiceClient.RemoveWorkload(ctx.Workload)
+	require.NoError(ctx.syncOnce(syncNewOps))
+	require.Len(ctx.FakeConsul.services["tenant-a/default"], 0)
+	require.Len(ctx.FakeConsul.checks["tenant-a/default"], 0)
+}
+
 // TestConsul_CanaryTags_NoTags asserts Tags are used when Canary=true and there
 // are no specified canary tags
 func TestConsul_CanaryTags_NoTags(t *testing.T) {
@@ -1366,11 +1404,48 @@ func TestCreateCheckReg_GRPC(t *testing.T) {
 		},
 	}
 
-	actual, err := createCheckReg(serviceID, checkID, check, "localhost", 8080, "default")
+	actual, err := createCheckReg(serviceID, checkID, check, "localhost", 8080, "")
 	require.NoError(t, err)
 	require.Equal(t, expected, actual)
 }
 
+// TestCreateCheckReg_GRPC_ClientTLS asserts gRPC checks with client
+// certificates are registered as TTL checks, and that the server name is
+// passed through for checks run by Consul.
+func TestCreateCheckReg_GRPC_ClientTLS(t *testing.T) {
+	ci.Parallel(t)
+
+	check := &structs.ServiceCheck{
+		Name:          "name",
+		Type:          "grpc",
+		PortLabel:     "label",
+		GRPCService:   "foo.Bar",
+		GRPCUseTLS:    true,
+		TLSServerName: "foo.service.consul",
+		Timeout:       time.Second,
+		Interval:      time.Minute,
+	}
+
+	serviceID := "testService"
+	checkID := check.Hash(serviceID)
+
+	actual, err := createCheckReg(serviceID, checkID, check, "localhost", 8080, "default")
+	require.NoError(t, err)
+	require.Equal(t, "localhost:8080/foo.Bar", actual.GRPC)
+	require.Equal(t, "foo.service.consul", actual.TLSServerName)
+	require.Empty(t, actual.TTL)
+
+	check.TLSClientCert = "${NOMAD_SECRETS_DIR}/client.pem"
+	check.TLSClientKey = "${NOMAD_SECRETS_DIR}/client-key.pem"
+	checkID = check.Hash(serviceID)
+
+	actual, err = createCheckReg(serviceID, checkID, check, "localhost", 8080, "default")
+	require.NoError(t, err)
+	require.Empty(t, actual.GRPC)
+	require.Empty(t, actual.Interval)
+	require.Equal(t, (time.Minute + ttlCheckBuffer).String(), actual.TTL)
+}
+
 // TestGetAddress asserts Nomad uses the correct ip and port for services and
 // checks depending on port labels, driver networks, and address mode.
 func TestGetAddress(t *testing.T) {
@@ -328,6 +328,7 @@ func (s *HTTPServer) csiSnapshotList(resp http.ResponseWriter, req *http.Request
 
 	query := req.URL.Query()
 	args.PluginID = query.Get("plugin_id")
+	args.VolumeID = query.Get("volume_id")
 
 	secrets := parseCSISecrets(req)
 	args.Secrets = secrets
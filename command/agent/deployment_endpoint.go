@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"io"
 	"net/http"
 	"strings"
 
@@ -60,9 +61,19 @@ func (s *HTTPServer) deploymentFail(resp http.ResponseWriter, req *http.Request,
 	if req.Method != http.MethodPut && req.Method != http.MethodPost {
 		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
 	}
-	args := structs.DeploymentFailRequest{
-		DeploymentID: deploymentID,
+
+	// The request body is optional and carries the reason for failing the
+	// deployment
+	var args structs.DeploymentFailRequest
+	if req.Body != nil && req.Body != http.NoBody {
+		if err := decodeBody(req, &args); err != nil && err != io.EOF {
+			return nil, CodedError(http.StatusBadRequest, err.Error())
+		}
+		if args.DeploymentID != "" && args.DeploymentID != deploymentID {
+			return nil, CodedError(http.StatusBadRequest, "Deployment ID does not match")
+		}
 	}
+	args.DeploymentID = deploymentID
 	s.parseWriteRequest(req, &args.WriteRequest)
 
 	var out structs.DeploymentUpdateResponse
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHTTP_DeploymentList(t *testing.T) {
@@ -322,3 +323,36 @@ func TestHTTP_DeploymentFail(t *testing.T) {
 		assert.NotZero(respW.Result().Header.Get("X-Nomad-Index"), "missing index")
 	})
 }
+
+func TestHTTP_DeploymentFail_Reason(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		j := mock.Job()
+		d := mock.Deployment()
+		d.JobID = j.ID
+		require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 998, j))
+		require.NoError(state.UpsertDeployment(999, d))
+
+		// A request body for another deployment is rejected
+		buf := encodeReq(&structs.DeploymentFailRequest{DeploymentID: "foo"})
+		req, err := http.NewRequest("PUT", "/v1/deployment/fail/"+d.ID, buf)
+		require.NoError(err)
+		_, err = s.Server.DeploymentSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Contains(err.Error(), "Deployment ID does not match")
+
+		buf = encodeReq(&structs.DeploymentFailRequest{Reason: "bad canaries"})
+		req, err = http.NewRequest("PUT", "/v1/deployment/fail/"+d.ID, buf)
+		require.NoError(err)
+		_, err = s.Server.DeploymentSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(err)
+
+		out, err := state.DeploymentByID(nil, d.ID)
+		require.NoError(err)
+		require.Equal(structs.DeploymentStatusFailed, out.Status)
+		require.Equal("bad canaries", out.StatusReason)
+	})
+}
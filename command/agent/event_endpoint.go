@@ -39,8 +39,17 @@ func (s *HTTPServer) EventStream(resp http.ResponseWriter, req *http.Request) (i
 	}
 
 	args := &structs.EventStreamRequest{
-		Topics: topics,
-		Index:  index,
+		Topics:     topics,
+		Index:      index,
+		Consumer:   query.Get("consumer"),
+		JobIDs:     query["job_id"],
+		TaskGroups: query["task_group"],
+	}
+
+	// The namespace may be repeated to stream the events of several
+	// namespaces
+	if namespaces := query["namespace"]; len(namespaces) > 1 {
+		args.Namespaces = namespaces
 	}
 	resp.Header().Set("Content-Type", "application/json")
 	resp.Header().Set("Cache-Control", "no-cache")
@@ -49,16 +58,7 @@ func (s *HTTPServer) EventStream(resp http.ResponseWriter, req *http.Request) (i
 	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
 
 	// Determine the RPC handler to use to find a server
-	var handler structs.StreamingRpcHandler
-	var handlerErr error
-	if server := s.agent.Server(); server != nil {
-		handler, handlerErr = server.StreamingRpcHandler("Event.Stream")
-	} else if client := s.agent.Client(); client != nil {
-		handler, handlerErr = client.RemoteStreamingRpcHandler("Event.Stream")
-	} else {
-		handlerErr = fmt.Errorf("misconfigured connection")
-	}
-
+	handler, handlerErr := s.agent.eventStreamHandler()
 	if handlerErr != nil {
 		return nil, CodedError(500, handlerErr.Error())
 	}
@@ -130,6 +130,17 @@ func (s *HTTPServer) EventStream(resp http.ResponseWriter, req *http.Request) (i
 	return nil, codedErr
 }
 
+// eventStreamHandler returns the handler of the Event.Stream streaming RPC,
+// served by the agent's server or forwarded by its client.
+func (a *Agent) eventStreamHandler() (structs.StreamingRpcHandler, error) {
+	if server := a.Server(); server != nil {
+		return server.StreamingRpcHandler("Event.Stream")
+	} else if client := a.Client(); client != nil {
+		return client.RemoteStreamingRpcHandler("Event.Stream")
+	}
+	return nil, fmt.Errorf("misconfigured connection")
+}
+
 func parseEventTopics(query url.Values) (map[structs.Topic][]string, error) {
 	raw, ok := query["topic"]
 	if !ok {
@@ -162,3 +173,105 @@ func parseTopic(topic string) (string, string, error) {
 func allTopics() map[structs.Topic][]string {
 	return map[structs.Topic][]string{"*": {"*"}}
 }
+
+// EventCursorsRequest is used to list the cursors of the durable consumers of
+// the event stream.
+func (s *HTTPServer) EventCursorsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.EventCursorListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.EventCursorListResponse
+	if err := s.agent.RPC("Event.ListCursors", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Cursors == nil {
+		out.Cursors = make([]*structs.EventCursorStatus, 0)
+	}
+	return out.Cursors, nil
+}
+
+// EventCursorSpecificRequest is used to read, update or delete the cursor of
+// a durable consumer of the event stream.
+func (s *HTTPServer) EventCursorSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	name := strings.TrimPrefix(req.URL.Path, "/v1/event/cursor/")
+	if len(name) == 0 {
+		return nil, CodedError(400, "Missing event cursor name")
+	}
+	switch req.Method {
+	case http.MethodGet:
+		return s.eventCursorQuery(resp, req, name)
+	case http.MethodPut, http.MethodPost:
+		return s.eventCursorUpdate(resp, req, name)
+	case http.MethodDelete:
+		return s.eventCursorDelete(resp, req, name)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) eventCursorQuery(resp http.ResponseWriter, req *http.Request, name string) (interface{}, error) {
+	args := structs.EventCursorSpecificRequest{
+		Name: name,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.EventCursorResponse
+	if err := s.agent.RPC("Event.GetCursor", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Cursor == nil {
+		return nil, CodedError(404, "event cursor not found")
+	}
+	return out.Cursor, nil
+}
+
+func (s *HTTPServer) eventCursorUpdate(resp http.ResponseWriter, req *http.Request, name string) (interface{}, error) {
+	var cursor structs.EventCursor
+	if err := decodeBody(req, &cursor); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	// Ensure the cursor name matches
+	if cursor.Name != "" && cursor.Name != name {
+		return nil, CodedError(400, "Event cursor name does not match request path")
+	}
+	cursor.Name = name
+
+	args := structs.EventCursorUpsertRequest{
+		Cursor: &cursor,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("Event.UpsertCursor", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return nil, nil
+}
+
+func (s *HTTPServer) eventCursorDelete(resp http.ResponseWriter, req *http.Request, name string) (interface{}, error) {
+	args := structs.EventCursorDeleteRequest{
+		Name: name,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("Event.DeleteCursor", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return nil, nil
+}
@@ -202,3 +202,55 @@ func TestEventStream_QueryParse(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTP_EventCursors(t *testing.T) {
+	ci.Parallel(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create the cursor
+		buf := encodeReq(structs.EventCursor{Index: 42})
+		req, err := http.NewRequest("PUT", "/v1/event/cursor/consumer", buf)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		_, err = s.Server.EventCursorSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.Header().Get("X-Nomad-Index"))
+
+		// Read it back
+		req, err = http.NewRequest("GET", "/v1/event/cursor/consumer", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err := s.Server.EventCursorSpecificRequest(respW, req)
+		require.NoError(t, err)
+		cursor := obj.(*structs.EventCursorStatus)
+		require.Equal(t, "consumer", cursor.Name)
+		require.EqualValues(t, 42, cursor.Index)
+
+		req, err = http.NewRequest("GET", "/v1/event/cursors", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.EventCursorsRequest(respW, req)
+		require.NoError(t, err)
+		require.Len(t, obj.([]*structs.EventCursorStatus), 1)
+
+		// The name of the body must match the path
+		buf = encodeReq(structs.EventCursor{Name: "other", Index: 43})
+		req, err = http.NewRequest("PUT", "/v1/event/cursor/consumer", buf)
+		require.NoError(t, err)
+		_, err = s.Server.EventCursorSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match")
+
+		// Delete the cursor
+		req, err = http.NewRequest("DELETE", "/v1/event/cursor/consumer", nil)
+		require.NoError(t, err)
+		_, err = s.Server.EventCursorSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+
+		req, err = http.NewRequest("GET", "/v1/event/cursor/consumer", nil)
+		require.NoError(t, err)
+		_, err = s.Server.EventCursorSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not found")
+	})
+}
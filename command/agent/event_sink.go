@@ -0,0 +1,366 @@
+package agent
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-msgpack/codec"
+	"github.com/hashicorp/nomad/helper/eventsink"
+	"github.com/hashicorp/nomad/nomad/stream"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// eventSinkCursorInterval is the interval at which the cursor of the
+	// event sink consumer records the events delivered.
+	eventSinkCursorInterval = 10 * time.Second
+
+	// eventSinkMinRetry and eventSinkMaxRetry bound the exponential backoff
+	// between attempts at streaming events.
+	eventSinkMinRetry = 1 * time.Second
+	eventSinkMaxRetry = 30 * time.Second
+)
+
+// eventSink forwards the event stream of the agent to an external message
+// broker.
+type eventSink struct {
+	agent     *Agent
+	conf      *EventSink
+	topics    map[structs.Topic][]string
+	forwarder *eventsink.Forwarder
+	logger    log.Logger
+
+	// cursorIndex is the index last recorded in the cursor of the consumer,
+	// accessed atomically
+	cursorIndex uint64
+
+	cancel context.CancelFunc
+	doneCh chan struct{}
+}
+
+// setupEventSink is used to start forwarding the event stream if enabled
+func (a *Agent) setupEventSink() error {
+	conf := a.config.EventSink
+	if conf == nil || !conf.Enabled {
+		return nil
+	}
+
+	var tlsConf *tls.Config
+	if conf.TLS || conf.CAFile != "" || conf.CertFile != "" || conf.KeyFile != "" {
+		var err error
+		tlsConf, err = eventsink.TLSConfig(conf.CAFile, conf.CertFile, conf.KeyFile)
+		if err != nil {
+			return fmt.Errorf("invalid event_sink TLS configuration: %v", err)
+		}
+	}
+
+	var sink eventsink.Sink
+	var err error
+	switch conf.Type {
+	case "kafka":
+		sink, err = eventsink.NewKafkaSink(conf.Address, conf.Username, conf.Password, tlsConf)
+	case "nats":
+		sink, err = eventsink.NewNATSSink(conf.Address, conf.Username, conf.Password, tlsConf)
+	default:
+		return fmt.Errorf("event_sink type must be one of \"kafka\" or \"nats\", got %q", conf.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid event_sink: %v", err)
+	}
+
+	dropOnFull := false
+	switch conf.OnFull {
+	case "", "block":
+	case "drop":
+		dropOnFull = true
+	default:
+		return fmt.Errorf("event_sink on_full must be one of \"block\" or \"drop\", got %q", conf.OnFull)
+	}
+
+	if conf.Consumer != "" {
+		cursor := &structs.EventCursor{Name: conf.Consumer}
+		if err := cursor.Validate(); err != nil {
+			return fmt.Errorf("invalid event_sink consumer: %v", err)
+		}
+	}
+
+	topics := allTopics()
+	if len(conf.EventTopics) > 0 {
+		topics = make(map[structs.Topic][]string)
+		for _, topic := range conf.EventTopics {
+			k, v, err := parseTopic(topic)
+			if err != nil {
+				return fmt.Errorf("invalid event_sink event_topics: %v", err)
+			}
+			topics[structs.Topic(k)] = append(topics[structs.Topic(k)], v)
+		}
+	}
+
+	forwarder, err := eventsink.NewForwarder(sink, &eventsink.Config{
+		Topic:      conf.Topic,
+		BufferSize: conf.BufferSize,
+		BatchSize:  conf.BatchSize,
+		DropOnFull: dropOnFull,
+	}, a.logger)
+	if err != nil {
+		return fmt.Errorf("invalid event_sink: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &eventSink{
+		agent:     a,
+		conf:      conf,
+		topics:    topics,
+		forwarder: forwarder,
+		logger:    a.logger.Named("event_sink"),
+		cancel:    cancel,
+		doneCh:    make(chan struct{}),
+	}
+	go s.run(ctx)
+
+	a.eventSink = s
+	return nil
+}
+
+// run streams events into the forwarder until the context is done,
+// streaming again after the events forwarded whenever the stream fails.
+func (s *eventSink) run(ctx context.Context) {
+	defer close(s.doneCh)
+
+	if s.conf.Consumer != "" {
+		go s.recordCursor(ctx)
+	}
+
+	var last uint64
+	wait := eventSinkMinRetry
+	for {
+		start := last
+		err := s.stream(ctx, &last)
+		if ctx.Err() != nil {
+			return
+		}
+		if last != start {
+			wait = eventSinkMinRetry
+		}
+
+		// The stream only ends without error once the cursor was reset, in
+		// which case it continues right away
+		if err == nil {
+			continue
+		}
+
+		s.logger.Warn("event stream interrupted", "error", err, "retry", wait)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		wait *= 2
+		if wait > eventSinkMaxRetry {
+			wait = eventSinkMaxRetry
+		}
+	}
+}
+
+// stream writes the events following last into the forwarder, updating last
+// with the index of the events written. It returns without error once the
+// cursor of the consumer expired and was reset.
+func (s *eventSink) stream(ctx context.Context, last *uint64) error {
+	args := &structs.EventStreamRequest{
+		Topics: s.topics,
+		QueryOptions: structs.QueryOptions{
+			Region:    s.agent.config.Region,
+			Namespace: s.conf.Namespace,
+			AuthToken: s.conf.Token,
+		},
+	}
+	if args.Namespace == "" {
+		args.Namespace = "*"
+	}
+
+	// A consumer resumes after the events recorded in its cursor when the
+	// agent starts, and after the events already forwarded otherwise.
+	if *last == 0 && s.conf.Consumer != "" {
+		if err := s.ensureCursor(); err != nil {
+			return err
+		}
+		args.Consumer = s.conf.Consumer
+	} else {
+		args.Index = int(*last + 1)
+	}
+
+	handler, err := s.agent.eventStreamHandler()
+	if err != nil {
+		return err
+	}
+
+	conn, handlerConn := net.Pipe()
+	defer conn.Close()
+	go handler(handlerConn)
+
+	// Unblock the stream if the context is done first
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-streamCtx.Done()
+		conn.Close()
+	}()
+
+	encoder := codec.NewEncoder(conn, structs.MsgpackHandle)
+	decoder := codec.NewDecoder(conn, structs.MsgpackHandle)
+	if err := encoder.Encode(args); err != nil {
+		return err
+	}
+
+	for {
+		var res structs.EventStreamWrapper
+		if err := decoder.Decode(&res); err != nil {
+			return err
+		}
+		decoder.Reset(conn)
+
+		if res.Error != nil {
+			if args.Consumer != "" && strings.Contains(res.Error.Error(), stream.ErrResumeIndexLost.Error()) {
+				return s.resetCursor(last, res.Error)
+			}
+			return res.Error
+		}
+		if res.Event == nil {
+			continue
+		}
+
+		var index struct{ Index uint64 }
+		if err := json.Unmarshal(res.Event.Data, &index); err != nil {
+			return fmt.Errorf("failed to decode events: %v", err)
+		}
+
+		// Skip heartbeats and the events already forwarded
+		if index.Index == 0 || index.Index <= *last {
+			continue
+		}
+		if err := s.forwarder.Write(ctx, res.Event.Data); err != nil {
+			return err
+		}
+		*last = index.Index
+	}
+}
+
+// ensureCursor creates the cursor of the consumer if it doesn't exist yet, so
+// that a new consumer starts with the events still buffered by the servers.
+func (s *eventSink) ensureCursor() error {
+	args := structs.EventCursorSpecificRequest{
+		Name: s.conf.Consumer,
+		QueryOptions: structs.QueryOptions{
+			Region:    s.agent.config.Region,
+			AuthToken: s.conf.Token,
+		},
+	}
+	var out structs.EventCursorResponse
+	if err := s.agent.RPC("Event.GetCursor", &args, &out); err != nil {
+		return fmt.Errorf("failed to read event cursor: %v", err)
+	}
+	if out.Cursor != nil {
+		atomic.StoreUint64(&s.cursorIndex, out.Cursor.Index)
+		return nil
+	}
+	return s.updateCursor(0)
+}
+
+// resetCursor moves the cursor of the consumer to the latest events published
+// when the events following it are no longer buffered by the servers, so that
+// the stream continues from there instead of failing to resume forever. The
+// events in between are lost.
+func (s *eventSink) resetCursor(last *uint64, lostErr error) error {
+	args := structs.EventCursorSpecificRequest{
+		Name: s.conf.Consumer,
+		QueryOptions: structs.QueryOptions{
+			Region:    s.agent.config.Region,
+			AuthToken: s.conf.Token,
+		},
+	}
+	var out structs.EventCursorResponse
+	if err := s.agent.RPC("Event.GetCursor", &args, &out); err != nil {
+		return fmt.Errorf("failed to read event cursor: %v", err)
+	}
+	if out.Cursor == nil || out.Cursor.LatestIndex == 0 {
+		return lostErr
+	}
+
+	s.logger.Warn("events following the event cursor were lost, continuing with the latest events",
+		"consumer", s.conf.Consumer, "cursor", out.Cursor.Index, "latest", out.Cursor.LatestIndex, "error", lostErr)
+	if err := s.updateCursor(out.Cursor.LatestIndex); err != nil {
+		return err
+	}
+	*last = out.Cursor.LatestIndex
+	return nil
+}
+
+// updateCursor records the index of the events delivered in the cursor of
+// the consumer.
+func (s *eventSink) updateCursor(index uint64) error {
+	args := structs.EventCursorUpsertRequest{
+		Cursor: &structs.EventCursor{
+			Name:  s.conf.Consumer,
+			Index: index,
+		},
+		WriteRequest: structs.WriteRequest{
+			Region:    s.agent.config.Region,
+			AuthToken: s.conf.Token,
+		},
+	}
+	var out structs.GenericResponse
+	if err := s.agent.RPC("Event.UpsertCursor", &args, &out); err != nil {
+		return fmt.Errorf("failed to update event cursor: %v", err)
+	}
+	atomic.StoreUint64(&s.cursorIndex, index)
+	return nil
+}
+
+// recordCursor periodically records the events delivered in the cursor of
+// the consumer until the context is done.
+func (s *eventSink) recordCursor(ctx context.Context) {
+	ticker := time.NewTicker(eventSinkCursorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s.recordDelivered()
+	}
+}
+
+// recordDelivered records the events delivered in the cursor of the consumer
+// if they changed since it was last updated.
+func (s *eventSink) recordDelivered() {
+	if delivered := s.forwarder.Delivered(); delivered > atomic.LoadUint64(&s.cursorIndex) {
+		if err := s.updateCursor(delivered); err != nil {
+			s.logger.Warn("failed to record events delivered", "error", err)
+		}
+	}
+}
+
+// Shutdown stops streaming events, delivers the events buffered and records
+// them in the cursor of the consumer.
+func (s *eventSink) Shutdown() {
+	s.cancel()
+	<-s.doneCh
+
+	if err := s.forwarder.Close(); err != nil {
+		s.logger.Warn("failed to close event sink", "error", err)
+	}
+
+	if s.conf.Consumer != "" {
+		s.recordDelivered()
+	}
+}
@@ -0,0 +1,234 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgent_SetupEventSink(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name        string
+		expectedErr string
+		eventSink   *EventSink
+	}{
+		{
+			name:        "Invalid Type",
+			expectedErr: `event_sink type must be one of "kafka" or "nats", got "amqp"`,
+			eventSink:   &EventSink{Enabled: true, Type: "amqp"},
+		},
+		{
+			name:        "Invalid Kafka Address",
+			expectedErr: `invalid event_sink: Kafka broker address "http://kafka:8082" must be a host:port address`,
+			eventSink:   &EventSink{Enabled: true, Type: "kafka", Address: "http://kafka:8082"},
+		},
+		{
+			name:        "Invalid CA File",
+			expectedErr: "invalid event_sink TLS configuration: failed to read CA file: open /does/not/exist: no such file or directory",
+			eventSink:   &EventSink{Enabled: true, Type: "nats", Address: "127.0.0.1", CAFile: "/does/not/exist"},
+		},
+		{
+			name:        "Missing NATS Address",
+			expectedErr: "invalid event_sink: missing NATS address",
+			eventSink:   &EventSink{Enabled: true, Type: "nats"},
+		},
+		{
+			name:        "Invalid On Full",
+			expectedErr: `event_sink on_full must be one of "block" or "drop", got "wait"`,
+			eventSink:   &EventSink{Enabled: true, Type: "nats", Address: "127.0.0.1", OnFull: "wait"},
+		},
+		{
+			name:        "Invalid Consumer",
+			expectedErr: `invalid event_sink consumer: invalid event cursor name "my sink"`,
+			eventSink:   &EventSink{Enabled: true, Type: "nats", Address: "127.0.0.1", Consumer: "my sink"},
+		},
+		{
+			name:        "Invalid Event Topics",
+			expectedErr: "invalid event_sink event_topics: Invalid key value pair for topic, topic: Job:a:b",
+			eventSink:   &EventSink{Enabled: true, Type: "nats", Address: "127.0.0.1", EventTopics: []string{"Job:a:b"}},
+		},
+		{
+			name:        "Invalid Topic",
+			expectedErr: "invalid event_sink: failed to parse topic template: template: topic:1: unclosed action",
+			eventSink:   &EventSink{Enabled: true, Type: "nats", Address: "127.0.0.1", Topic: "nomad.{{ .Topic"},
+		},
+	}
+
+	for i := range cases {
+		tc := cases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			a := &Agent{config: DevConfig(nil), logger: testlog.HCLogger(t)}
+			a.config.EventSink = tc.eventSink
+			require.EqualError(t, a.setupEventSink(), tc.expectedErr)
+			require.Nil(t, a.eventSink)
+		})
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		a := &Agent{config: DevConfig(nil), logger: testlog.HCLogger(t)}
+		a.config.EventSink = &EventSink{Type: "nats", Address: "127.0.0.1"}
+		require.NoError(t, a.setupEventSink())
+		require.Nil(t, a.eventSink)
+	})
+}
+
+// forwarded returns whether the event of the job was published to the
+// subject.
+func forwarded(events [][]byte, job *structs.Job) (bool, error) {
+	for _, value := range events {
+		var event struct{ Key string }
+		if err := json.Unmarshal(value, &event); err != nil {
+			return false, err
+		}
+		if event.Key == job.ID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestAgent_EventSink(t *testing.T) {
+	ci.Parallel(t)
+
+	nats := testutil.NewTestNATSServer(t, nil)
+	defer nats.Stop()
+
+	s := makeHTTPServer(t, func(c *Config) {
+		c.Client.Enabled = false
+		c.EventSink = &EventSink{
+			Enabled:     true,
+			Type:        "nats",
+			Address:     nats.Addr,
+			Topic:       "nomad-{{ .Topic }}",
+			EventTopics: []string{"Job"},
+			Consumer:    "nats-sink",
+		}
+	})
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.Agent.RPC)
+
+	job := mock.Job()
+	regReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	require.NoError(t, s.Agent.RPC("Job.Register", regReq, &regResp))
+
+	testutil.WaitForResult(func() (bool, error) {
+		if ok, err := forwarded(nats.Messages("nomad-Job"), job); !ok {
+			return false, fmt.Errorf("job event not forwarded: %v", err)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	require.Empty(t, nats.Messages("nomad-Evaluation"), "only job events should be forwarded")
+
+	// The events delivered are recorded in the cursor of the consumer on
+	// shutdown
+	s.Agent.eventSink.Shutdown()
+	cursor, err := s.Agent.server.State().EventCursorByName(nil, "nats-sink")
+	require.NoError(t, err)
+	require.NotNil(t, cursor)
+	require.GreaterOrEqual(t, cursor.Index, regResp.JobModifyIndex)
+}
+
+// TestAgent_EventSink_ExpiredCursor asserts a consumer whose cursor expired
+// continues with the latest events instead of failing to resume forever.
+func TestAgent_EventSink_ExpiredCursor(t *testing.T) {
+	ci.Parallel(t)
+
+	nats := testutil.NewTestNATSServer(t, nil)
+	defer nats.Stop()
+
+	s := makeHTTPServer(t, func(c *Config) {
+		c.Client.Enabled = false
+		c.Server.EventBufferSize = helper.IntToPtr(2)
+	})
+	defer s.Shutdown()
+	testutil.WaitForLeader(t, s.Agent.RPC)
+
+	register := func() *structs.Job {
+		job := mock.Job()
+		regReq := &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var regResp structs.JobRegisterResponse
+		require.NoError(t, s.Agent.RPC("Job.Register", regReq, &regResp))
+		return job
+	}
+
+	// The events following the cursor are pushed out of the event buffer
+	upsertReq := &structs.EventCursorUpsertRequest{
+		Cursor:       &structs.EventCursor{Name: "nats-sink", Index: 1},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	require.NoError(t, s.Agent.RPC("Event.UpsertCursor", upsertReq, &structs.GenericResponse{}))
+	for i := 0; i < 5; i++ {
+		register()
+	}
+	broker, err := s.Agent.server.State().EventBroker()
+	require.NoError(t, err)
+	testutil.WaitForResult(func() (bool, error) {
+		if lost := broker.LostIndex(); lost <= 1 {
+			return false, fmt.Errorf("events not lost yet: %d", lost)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	s.Agent.config.EventSink = &EventSink{
+		Enabled:     true,
+		Type:        "nats",
+		Address:     nats.Addr,
+		EventTopics: []string{"Job"},
+		Consumer:    "nats-sink",
+	}
+	require.NoError(t, s.Agent.setupEventSink())
+	defer s.Agent.eventSink.Shutdown()
+
+	// The cursor is moved to the latest events, and the events published
+	// from then on are forwarded
+	testutil.WaitForResult(func() (bool, error) {
+		cursor, err := s.Agent.server.State().EventCursorByName(nil, "nats-sink")
+		if err != nil {
+			return false, err
+		}
+		if cursor.Index < broker.LostIndex() {
+			return false, fmt.Errorf("cursor not reset: %d", cursor.Index)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	job := register()
+	testutil.WaitForResult(func() (bool, error) {
+		if ok, err := forwarded(nats.Messages("nomad.Job"), job); !ok {
+			return false, fmt.Errorf("job event not forwarded: %v", err)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/rs/cors"
 
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper/accesslog"
 	"github.com/hashicorp/nomad/helper/noxssrw"
 	"github.com/hashicorp/nomad/helper/tlsutil"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -294,6 +295,8 @@ func (s *HTTPServer) ResolveToken(req *http.Request) (*acl.ACL, error) {
 func (s HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/jobs", s.wrap(s.JobsRequest))
 	s.mux.HandleFunc("/v1/jobs/parse", s.wrap(s.JobsParseRequest))
+	s.mux.HandleFunc("/v1/jobs/bundle", s.wrap(s.JobsBundleRequest))
+	s.mux.HandleFunc("/v1/jobs/submission/", s.wrap(s.JobsSubmissionRequest))
 	s.mux.HandleFunc("/v1/job/", s.wrap(s.JobSpecificRequest))
 
 	s.mux.HandleFunc("/v1/nodes", s.wrap(s.NodesRequest))
@@ -321,6 +324,7 @@ func (s HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/acl/token/onetime", s.wrap(s.UpsertOneTimeToken))
 	s.mux.HandleFunc("/v1/acl/token/onetime/exchange", s.wrap(s.ExchangeOneTimeToken))
 	s.mux.HandleFunc("/v1/acl/bootstrap", s.wrap(s.ACLTokenBootstrap))
+	s.mux.HandleFunc("/v1/acl/check", s.wrap(s.ACLCheckRequest))
 	s.mux.HandleFunc("/v1/acl/tokens", s.wrap(s.ACLTokensRequest))
 	s.mux.HandleFunc("/v1/acl/token", s.wrap(s.ACLTokenSpecificRequest))
 	s.mux.HandleFunc("/v1/acl/token/", s.wrap(s.ACLTokenSpecificRequest))
@@ -329,6 +333,7 @@ func (s HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/client/gc", s.wrap(s.ClientGCRequest))
 	s.mux.Handle("/v1/client/stats", wrapCORS(s.wrap(s.ClientStatsRequest)))
 	s.mux.Handle("/v1/client/allocation/", wrapCORS(s.wrap(s.ClientAllocRequest)))
+	s.mux.HandleFunc("/v1/client/metadata", s.wrap(s.NodeMetaRequest))
 
 	s.mux.HandleFunc("/v1/agent/self", s.wrap(s.AgentSelfRequest))
 	s.mux.HandleFunc("/v1/agent/join", s.wrap(s.AgentJoinRequest))
@@ -358,6 +363,7 @@ func (s HTTPServer) registerHandlers(enableDebug bool) {
 
 	s.mux.HandleFunc("/v1/scaling/policies", s.wrap(s.ScalingPoliciesRequest))
 	s.mux.HandleFunc("/v1/scaling/policy/", s.wrap(s.ScalingPolicySpecificRequest))
+	s.mux.HandleFunc("/v1/scaling/actions", s.wrap(s.ScheduledScalingActionsRequest))
 
 	s.mux.HandleFunc("/v1/status/leader", s.wrap(s.StatusLeaderRequest))
 	s.mux.HandleFunc("/v1/status/peers", s.wrap(s.StatusPeersRequest))
@@ -375,12 +381,22 @@ func (s HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/system/reconcile/summaries", s.wrap(s.ReconcileJobSummaries))
 
 	s.mux.HandleFunc("/v1/operator/scheduler/configuration", s.wrap(s.OperatorSchedulerConfiguration))
+	s.mux.HandleFunc("/v1/operator/scheduler/queues", s.wrap(s.OperatorSchedulerQueues))
+	s.mux.HandleFunc("/v1/operator/scheduler/fragmentation", s.wrap(s.OperatorSchedulerFragmentation))
+	s.mux.HandleFunc("/v1/operator/workload-identity/keys", s.wrap(s.OperatorWorkloadIdentityKeys))
+	s.mux.HandleFunc("/v1/operator/workload-identity/rotate", s.wrap(s.OperatorWorkloadIdentityKeyRotate))
 
 	s.mux.HandleFunc("/v1/event/stream", s.wrap(s.EventStream))
+	s.mux.HandleFunc("/v1/event/cursors", s.wrap(s.EventCursorsRequest))
+	s.mux.HandleFunc("/v1/event/cursor/", s.wrap(s.EventCursorSpecificRequest))
 	s.mux.HandleFunc("/v1/namespaces", s.wrap(s.NamespacesRequest))
+	s.mux.HandleFunc("/v1/namespaces/usage", s.wrap(s.NamespacesUsageRequest))
 	s.mux.HandleFunc("/v1/namespace", s.wrap(s.NamespaceCreateRequest))
 	s.mux.HandleFunc("/v1/namespace/", s.wrap(s.NamespaceSpecificRequest))
 
+	s.mux.HandleFunc("/.well-known/openid-configuration", s.wrap(s.WorkloadIdentityDiscoveryRequest))
+	s.mux.HandleFunc("/.well-known/jwks.json", s.wrap(s.WorkloadIdentityKeysRequest))
+
 	uiConfigEnabled := s.agent.config.UI != nil && s.agent.config.UI.Enabled
 
 	if uiEnabled && uiConfigEnabled {
@@ -511,16 +527,19 @@ func (s *HTTPServer) wrap(handler func(resp http.ResponseWriter, req *http.Reque
 		// Invoke the handler
 		reqURL := req.URL.String()
 		start := time.Now()
+		code := http.StatusOK
+		errMsg := ""
 		defer func() {
 			s.logger.Debug("request complete", "method", req.Method, "path", reqURL, "duration", time.Since(start))
+			s.logAccess(req, start, code, errMsg)
 		}()
 		obj, err := s.auditHandler(handler)(resp, req)
 
 		// Check for an error
 	HAS_ERR:
 		if err != nil {
-			code := 500
-			errMsg := err.Error()
+			code = 500
+			errMsg = err.Error()
 			if http, ok := err.(HTTPCodedError); ok {
 				code = http.Code()
 			} else if ecode, emsg, ok := structs.CodeFromRPCCodedErr(err); ok {
@@ -593,14 +612,17 @@ func (s *HTTPServer) wrapNonJSON(handler func(resp http.ResponseWriter, req *htt
 		// Invoke the handler
 		reqURL := req.URL.String()
 		start := time.Now()
+		code := http.StatusOK
+		errMsg := ""
 		defer func() {
 			s.logger.Debug("request complete", "method", req.Method, "path", reqURL, "duration", time.Since(start))
+			s.logAccess(req, start, code, errMsg)
 		}()
 		obj, err := s.auditNonJSONHandler(handler)(resp, req)
 
 		// Check for an error
 		if err != nil {
-			code, errMsg := errCodeFromHandler(err)
+			code, errMsg = errCodeFromHandler(err)
 			resp.WriteHeader(code)
 			resp.Write([]byte(errMsg))
 			if isAPIClientError(code) {
@@ -619,6 +641,34 @@ func (s *HTTPServer) wrapNonJSON(handler func(resp http.ResponseWriter, req *htt
 	return f
 }
 
+// logAccess writes an access log entry for the request if the access log of
+// the agent is enabled.
+func (s *HTTPServer) logAccess(req *http.Request, start time.Time, code int, errMsg string) {
+	if s.agent.accessLog == nil {
+		return
+	}
+
+	var secretID, region, namespace string
+	s.parseToken(req, &secretID)
+	s.parseRegion(req, &region)
+	parseNamespace(req, &namespace)
+
+	entry := &accesslog.Entry{
+		Time:       start,
+		Type:       accesslog.TypeHTTP,
+		AccessorID: s.agent.accessorID(secretID),
+		Method:     req.Method,
+		Endpoint:   req.URL.Path,
+		Namespace:  namespace,
+		Region:     region,
+		RemoteAddr: req.RemoteAddr,
+		Status:     code,
+	}
+	entry.SetResult(errMsg)
+	entry.SetDuration(start)
+	s.agent.accessLog.Log(entry)
+}
+
 // isAPIClientError returns true if the passed http code represents a client error
 func isAPIClientError(code int) bool {
 	return 400 <= code && code <= 499
@@ -655,6 +705,11 @@ func setLastContact(resp http.ResponseWriter, last time.Duration) {
 	resp.Header().Set("X-Nomad-LastContact", strconv.FormatUint(lastMsec, 10))
 }
 
+// setIndexLag is used to set the index lag header
+func setIndexLag(resp http.ResponseWriter, lag uint64) {
+	resp.Header().Set("X-Nomad-IndexLag", strconv.FormatUint(lag, 10))
+}
+
 // setNextToken is used to set the next token header for pagination
 func setNextToken(resp http.ResponseWriter, nextToken string) {
 	if nextToken != "" {
@@ -666,6 +721,7 @@ func setNextToken(resp http.ResponseWriter, nextToken string) {
 func setMeta(resp http.ResponseWriter, m *structs.QueryMeta) {
 	setIndex(resp, m.Index)
 	setLastContact(resp, m.LastContact)
+	setIndexLag(resp, m.IndexLag)
 	setKnownLeader(resp, m.KnownLeader)
 	setNextToken(resp, m.NextToken)
 }
@@ -710,6 +766,21 @@ func parseConsistency(req *http.Request, b *structs.QueryOptions) {
 	}
 }
 
+// parseMaxStale is used to parse the X-Nomad-Max-Stale header bounding the
+// staleness of stale queries. Returns true on error
+func parseMaxStale(resp http.ResponseWriter, req *http.Request, b *structs.QueryOptions) bool {
+	if maxStale := req.Header.Get("X-Nomad-Max-Stale"); maxStale != "" {
+		dur, err := time.ParseDuration(maxStale)
+		if err != nil || dur < 0 {
+			resp.WriteHeader(400)
+			resp.Write([]byte("Invalid max stale"))
+			return true
+		}
+		b.MaxStale = dur
+	}
+	return false
+}
+
 // parsePrefix is used to parse the ?prefix query param
 func parsePrefix(req *http.Request, b *structs.QueryOptions) {
 	query := req.URL.Query()
@@ -789,6 +860,9 @@ func (s *HTTPServer) parse(resp http.ResponseWriter, req *http.Request, r *strin
 	parsePagination(req, b)
 	parseFilter(req, b)
 	parseReverse(req, b)
+	if parseMaxStale(resp, req, b) {
+		return true
+	}
 	return parseWait(resp, req, b)
 }
 
@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"bufio"
 	"bytes"
 	"crypto/tls"
 	"crypto/x509"
@@ -14,6 +15,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -24,6 +26,7 @@ import (
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/accesslog"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -197,6 +200,7 @@ func TestSetMeta(t *testing.T) {
 		Index:       1000,
 		KnownLeader: true,
 		LastContact: 123456 * time.Microsecond,
+		IndexLag:    3,
 	}
 	resp := httptest.NewRecorder()
 	setMeta(resp, &meta)
@@ -212,6 +216,10 @@ func TestSetMeta(t *testing.T) {
 	if header != "123" {
 		t.Fatalf("Bad: %v", header)
 	}
+	header = resp.Header().Get("X-Nomad-IndexLag")
+	if header != "3" {
+		t.Fatalf("Bad: %v", header)
+	}
 }
 
 func TestSetHeaders(t *testing.T) {
@@ -501,6 +509,79 @@ func TestParseConsistency(t *testing.T) {
 	}
 }
 
+func TestParseMaxStale(t *testing.T) {
+	ci.Parallel(t)
+	var b structs.QueryOptions
+
+	req, err := http.NewRequest("GET", "/v1/jobs?stale", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Nomad-Max-Stale", "5s")
+
+	resp := httptest.NewRecorder()
+	require.False(t, parseMaxStale(resp, req, &b))
+	require.Equal(t, 5*time.Second, b.MaxStale)
+
+	req.Header.Set("X-Nomad-Max-Stale", "soon")
+	resp = httptest.NewRecorder()
+	require.True(t, parseMaxStale(resp, req, &b))
+	require.Equal(t, 400, resp.Code)
+}
+
+func TestHTTP_AccessLog(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	httpACLTest(t, func(c *Config) {
+		c.AccessLog = &AccessLog{
+			Enabled: true,
+			Path:    dir + string(os.PathSeparator),
+		}
+	}, func(s *TestAgent) {
+		// A request with a management token succeeds
+		req := httptest.NewRequest("GET", "/v1/jobs?namespace=prod", nil)
+		setToken(req, s.RootToken)
+		respW := httptest.NewRecorder()
+		s.Server.wrap(s.Server.JobsRequest)(respW, req)
+		require.Equal(t, http.StatusOK, respW.Code)
+
+		// A request without a token is denied
+		req = httptest.NewRequest("GET", "/v1/jobs", nil)
+		respW = httptest.NewRecorder()
+		s.Server.wrap(s.Server.JobsRequest)(respW, req)
+		require.Equal(t, http.StatusForbidden, respW.Code)
+
+		f, err := os.Open(filepath.Join(dir, "access.log"))
+		require.NoError(t, err)
+		defer f.Close()
+
+		// The file also holds the RPCs of the client, so only keep the
+		// HTTP entries
+		var entries []*accesslog.Entry
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var e accesslog.Entry
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+			if e.Type == accesslog.TypeHTTP {
+				entries = append(entries, &e)
+			}
+		}
+		require.Len(t, entries, 2)
+
+		require.Equal(t, "GET", entries[0].Method)
+		require.Equal(t, "/v1/jobs", entries[0].Endpoint)
+		require.Equal(t, "prod", entries[0].Namespace)
+		require.Equal(t, s.RootToken.AccessorID, entries[0].AccessorID)
+		require.Equal(t, req.RemoteAddr, entries[0].RemoteAddr)
+		require.Equal(t, http.StatusOK, entries[0].Status)
+		require.Equal(t, accesslog.ResultOK, entries[0].Result)
+
+		require.Equal(t, structs.DefaultNamespace, entries[1].Namespace)
+		require.Equal(t, structs.AnonymousACLToken.AccessorID, entries[1].AccessorID)
+		require.Equal(t, http.StatusForbidden, entries[1].Status)
+		require.Equal(t, accesslog.ResultDenied, entries[1].Result)
+	})
+}
+
 func TestParseRegion(t *testing.T) {
 	ci.Parallel(t)
 	s := makeHTTPServer(t, nil)
@@ -0,0 +1,45 @@
+package agent
+
+import (
+	"net/http"
+)
+
+// WorkloadIdentityDiscoveryRequest serves the OpenID Connect discovery
+// document of the workload identity issuer, which third parties such as the
+// OIDC federation of cloud providers use to verify workload identities.
+func (s *HTTPServer) WorkloadIdentityDiscoveryRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	srv := s.agent.Server()
+	if srv == nil {
+		return nil, CodedError(http.StatusBadRequest, ErrServerOnly)
+	}
+	discovery := srv.WorkloadIdentityDiscovery()
+	if discovery == nil {
+		return nil, CodedError(http.StatusNotFound, "workload identities are not configured")
+	}
+	return discovery, nil
+}
+
+// WorkloadIdentityKeysRequest serves the set of keys verifying workload
+// identities.
+func (s *HTTPServer) WorkloadIdentityKeysRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	srv := s.agent.Server()
+	if srv == nil {
+		return nil, CodedError(http.StatusBadRequest, ErrServerOnly)
+	}
+	keys, err := srv.WorkloadIdentityKeys()
+	if err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		return nil, CodedError(http.StatusNotFound, "workload identities are not configured")
+	}
+	return keys, nil
+}
@@ -0,0 +1,75 @@
+package agent
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+func TestHTTP_WorkloadIdentity(t *testing.T) {
+	ci.Parallel(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "identity.pem")
+	raw := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	require.NoError(t, ioutil.WriteFile(keyFile, raw, 0600))
+
+	cb := func(c *Config) {
+		c.Server.WorkloadIdentity = &WorkloadIdentity{
+			Issuer:         "https://nomad.example.com",
+			SigningKeyFile: keyFile,
+		}
+	}
+	httpTest(t, cb, func(s *TestAgent) {
+		// The discovery document points at the key set
+		req, err := http.NewRequest("GET", "/.well-known/openid-configuration", nil)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		s.Server.mux.ServeHTTP(respW, req)
+		require.Equal(t, http.StatusOK, respW.Code)
+
+		var discovery map[string]interface{}
+		require.NoError(t, json.Unmarshal(respW.Body.Bytes(), &discovery))
+		require.Equal(t, "https://nomad.example.com", discovery["issuer"])
+		require.Equal(t, "https://nomad.example.com/.well-known/jwks.json", discovery["jwks_uri"])
+
+		// The key set contains the public key only
+		req, err = http.NewRequest("GET", "/.well-known/jwks.json", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		s.Server.mux.ServeHTTP(respW, req)
+		require.Equal(t, http.StatusOK, respW.Code)
+
+		var keys jose.JSONWebKeySet
+		require.NoError(t, json.Unmarshal(respW.Body.Bytes(), &keys))
+		require.Len(t, keys.Keys, 1)
+		require.True(t, keys.Keys[0].IsPublic())
+		require.Equal(t, "RS256", keys.Keys[0].Algorithm)
+		require.Equal(t, key.Public(), keys.Keys[0].Key)
+	})
+}
+
+func TestHTTP_WorkloadIdentity_NotConfigured(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		req, err := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		_, err = s.Server.WorkloadIdentityKeysRequest(respW, req)
+		require.Error(t, err)
+		require.Equal(t, http.StatusNotFound, err.(HTTPCodedError).Code())
+	})
+}
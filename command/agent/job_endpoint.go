@@ -62,12 +62,21 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/plan"):
 		jobName := strings.TrimSuffix(path, "/plan")
 		return s.jobPlan(resp, req, jobName)
+	case strings.HasSuffix(path, "/plan-results"):
+		jobName := strings.TrimSuffix(path, "/plan-results")
+		return s.jobPlanResults(resp, req, jobName)
 	case strings.HasSuffix(path, "/summary"):
 		jobName := strings.TrimSuffix(path, "/summary")
 		return s.jobSummaryRequest(resp, req, jobName)
 	case strings.HasSuffix(path, "/dispatch"):
 		jobName := strings.TrimSuffix(path, "/dispatch")
 		return s.jobDispatchRequest(resp, req, jobName)
+	case strings.HasSuffix(path, "/versions/tag"):
+		jobName := strings.TrimSuffix(path, "/versions/tag")
+		return s.jobVersionTag(resp, req, jobName)
+	case strings.HasSuffix(path, "/versions/diff"):
+		jobName := strings.TrimSuffix(path, "/versions/diff")
+		return s.jobVersionsDiff(resp, req, jobName)
 	case strings.HasSuffix(path, "/versions"):
 		jobName := strings.TrimSuffix(path, "/versions")
 		return s.jobVersions(resp, req, jobName)
@@ -80,6 +89,9 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/deployment"):
 		jobName := strings.TrimSuffix(path, "/deployment")
 		return s.jobLatestDeployment(resp, req, jobName)
+	case strings.HasSuffix(path, "/move"):
+		jobName := strings.TrimSuffix(path, "/move")
+		return s.jobMove(resp, req, jobName)
 	case strings.HasSuffix(path, "/stable"):
 		jobName := strings.TrimSuffix(path, "/stable")
 		return s.jobStable(resp, req, jobName)
@@ -252,6 +264,9 @@ func (s *HTTPServer) jobEvaluations(resp http.ResponseWriter, req *http.Request,
 	args := structs.JobSpecificRequest{
 		JobID: jobName,
 	}
+	if err := parseCreateIndexWindow(req, &args); err != nil {
+		return nil, err
+	}
 	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
 		return nil, nil
 	}
@@ -268,6 +283,30 @@ func (s *HTTPServer) jobEvaluations(resp http.ResponseWriter, req *http.Request,
 	return out.Evaluations, nil
 }
 
+func (s *HTTPServer) jobPlanResults(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+	args := structs.JobSpecificRequest{
+		JobID: jobName,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobPlanResultsResponse
+	if err := s.agent.RPC("Job.PlanResults", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.PlanResults == nil {
+		out.PlanResults = make([]*structs.PlanApplyResult, 0)
+	}
+	return out.PlanResults, nil
+}
+
 func (s *HTTPServer) jobDeployments(resp http.ResponseWriter, req *http.Request,
 	jobName string) (interface{}, error) {
 	if req.Method != "GET" {
@@ -278,6 +317,9 @@ func (s *HTTPServer) jobDeployments(resp http.ResponseWriter, req *http.Request,
 		JobID: jobName,
 		All:   all,
 	}
+	if err := parseCreateIndexWindow(req, &args); err != nil {
+		return nil, err
+	}
 	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
 		return nil, nil
 	}
@@ -294,6 +336,30 @@ func (s *HTTPServer) jobDeployments(resp http.ResponseWriter, req *http.Request,
 	return out.Deployments, nil
 }
 
+// parseCreateIndexWindow parses the from_index and to_index query parameters
+// bounding the create indexes of the deployments and evaluations of a job.
+func parseCreateIndexWindow(req *http.Request, args *structs.JobSpecificRequest) error {
+	indexes := []struct {
+		param string
+		index *uint64
+	}{
+		{"from_index", &args.FromIndex},
+		{"to_index", &args.ToIndex},
+	}
+	for _, i := range indexes {
+		raw := req.URL.Query().Get(i.param)
+		if raw == "" {
+			continue
+		}
+		index, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return CodedError(400, fmt.Sprintf("Failed to parse value of %q (%v) as a uint64: %v", i.param, raw, err))
+		}
+		*i.index = index
+	}
+	return nil
+}
+
 func (s *HTTPServer) jobLatestDeployment(resp http.ResponseWriter, req *http.Request,
 	jobName string) (interface{}, error) {
 	if req.Method != "GET" {
@@ -400,7 +466,13 @@ func (s *HTTPServer) jobUpdate(resp http.ResponseWriter, req *http.Request,
 		}
 	}
 
+	async, err := parseBool(req, "async")
+	if err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
 	sJob, writeReq := s.apiJobAndRequestToStructs(args.Job, req, args.WriteRequest)
+	parseIdempotencyToken(req, &writeReq.IdempotencyToken)
 	regReq := structs.JobRegisterRequest{
 		Job:            sJob,
 		EnforceIndex:   args.EnforceIndex,
@@ -408,6 +480,7 @@ func (s *HTTPServer) jobUpdate(resp http.ResponseWriter, req *http.Request,
 		PolicyOverride: args.PolicyOverride,
 		PreserveCounts: args.PreserveCounts,
 		EvalPriority:   args.EvalPriority,
+		Async:          async != nil && *async,
 		WriteRequest:   *writeReq,
 	}
 
@@ -543,11 +616,15 @@ func (s *HTTPServer) jobScaleAction(resp http.ResponseWriter, req *http.Request,
 		JobID:          jobName,
 		Target:         args.Target,
 		Count:          args.Count,
+		Resources:      apiScalingResourcesToStructs(args.Resources),
 		PolicyOverride: args.PolicyOverride,
 		Message:        args.Message,
 		Error:          args.Error,
 		Meta:           args.Meta,
 	}
+	if args.ScheduleAt != nil {
+		scaleReq.ScheduleAt = *args.ScheduleAt
+	}
 	// parseWriteRequest overrides Namespace, Region and AuthToken
 	// based on values from the original http request
 	s.parseWriteRequest(req, &scaleReq.WriteRequest)
@@ -560,6 +637,25 @@ func (s *HTTPServer) jobScaleAction(resp http.ResponseWriter, req *http.Request,
 	return out, nil
 }
 
+func apiScalingResourcesToStructs(in map[string]*api.ScalingResources) map[string]*structs.ScalingResources {
+	if in == nil {
+		return nil
+	}
+
+	out := make(map[string]*structs.ScalingResources, len(in))
+	for task, res := range in {
+		if res == nil {
+			out[task] = nil
+			continue
+		}
+		out[task] = &structs.ScalingResources{
+			CPU:      res.CPU,
+			MemoryMB: res.MemoryMB,
+		}
+	}
+	return out
+}
+
 func (s *HTTPServer) jobVersions(resp http.ResponseWriter, req *http.Request,
 	jobName string) (interface{}, error) {
 
@@ -594,6 +690,52 @@ func (s *HTTPServer) jobVersions(resp http.ResponseWriter, req *http.Request,
 	return out, nil
 }
 
+func (s *HTTPServer) jobVersionsDiff(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.JobVersionsDiffRequest{
+		JobID: jobName,
+	}
+	versions := []struct {
+		param   string
+		version *uint64
+	}{
+		{"from", &args.FromVersion},
+		{"to", &args.ToVersion},
+	}
+	for _, v := range versions {
+		param := v.param
+		raw := req.URL.Query().Get(param)
+		if raw == "" {
+			return nil, CodedError(400, fmt.Sprintf("missing %q version", param))
+		}
+		version, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Failed to parse value of %q (%v) as a uint64: %v", param, raw, err))
+		}
+		*v.version = version
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobVersionsDiffResponse
+	if err := s.agent.RPC("Job.DiffVersions", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Diff == nil {
+		return nil, CodedError(404, "job version not found")
+	}
+
+	return out, nil
+}
+
 func (s *HTTPServer) jobRevert(resp http.ResponseWriter, req *http.Request,
 	jobName string) (interface{}, error) {
 
@@ -652,6 +794,67 @@ func (s *HTTPServer) jobStable(resp http.ResponseWriter, req *http.Request,
 	return out, nil
 }
 
+func (s *HTTPServer) jobMove(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var moveRequest structs.JobMoveRequest
+	if err := decodeBody(req, &moveRequest); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if moveRequest.JobID == "" {
+		return nil, CodedError(400, "JobID must be specified")
+	}
+	if moveRequest.JobID != jobName {
+		return nil, CodedError(400, "Job ID does not match")
+	}
+
+	// The evaluation is created by the server
+	moveRequest.Eval = nil
+
+	s.parseWriteRequest(req, &moveRequest.WriteRequest)
+
+	var out structs.JobMoveResponse
+	if err := s.agent.RPC("Job.Move", &moveRequest, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
+func (s *HTTPServer) jobVersionTag(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var tagRequest structs.JobVersionTagRequest
+	if err := decodeBody(req, &tagRequest); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if tagRequest.JobID == "" {
+		return nil, CodedError(400, "JobID must be specified")
+	}
+	if tagRequest.JobID != jobName {
+		return nil, CodedError(400, "Job ID does not match")
+	}
+
+	s.parseWriteRequest(req, &tagRequest.WriteRequest)
+
+	var out structs.JobVersionTagResponse
+	if err := s.agent.RPC("Job.TagVersion", &tagRequest, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) jobSummaryRequest(resp http.ResponseWriter, req *http.Request, name string) (interface{}, error) {
 	args := structs.JobSummaryRequest{
 		JobID: name,
@@ -698,6 +901,73 @@ func (s *HTTPServer) jobDispatchRequest(resp http.ResponseWriter, req *http.Requ
 	return out, nil
 }
 
+// JobsSubmissionRequest reads the status of an asynchronous job registration
+func (s *HTTPServer) JobsSubmissionRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	submissionID := strings.TrimPrefix(req.URL.Path, "/v1/jobs/submission/")
+	if submissionID == "" || strings.Contains(submissionID, "/") {
+		return nil, CodedError(404, "submission ID required")
+	}
+
+	args := structs.JobRegisterSubmissionRequest{SubmissionID: submissionID}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobRegisterSubmissionResponse
+	if err := s.agent.RPC("Job.GetRegisterSubmission", &args, &out); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &out.QueryMeta)
+	return out.Submission, nil
+}
+
+// JobsBundleRequest registers a set of jobs atomically
+func (s *HTTPServer) JobsBundleRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args api.JobBundleRegisterRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if len(args.Jobs) == 0 {
+		return nil, CodedError(400, "Jobs must be specified")
+	}
+
+	bundleReq := structs.JobBundleRegisterRequest{
+		BundleID:       args.BundleID,
+		Jobs:           make([]*structs.Job, 0, len(args.Jobs)),
+		PolicyOverride: args.PolicyOverride,
+	}
+	for i, job := range args.Jobs {
+		if job == nil || job.ID == nil {
+			return nil, CodedError(400, "Job ID hasn't been provided")
+		}
+
+		sJob, writeReq := s.apiJobAndRequestToStructs(job, req, args.WriteRequest)
+		if i > 0 && writeReq.Namespace != bundleReq.Namespace {
+			return nil, CodedError(400, "All jobs of a bundle must be in the same namespace")
+		}
+		if i > 0 && writeReq.Region != bundleReq.Region {
+			return nil, CodedError(400, "All jobs of a bundle must be in the same region")
+		}
+		bundleReq.WriteRequest = *writeReq
+		bundleReq.Jobs = append(bundleReq.Jobs, sJob)
+	}
+
+	var out structs.JobBundleRegisterResponse
+	if err := s.agent.RPC("Job.RegisterBundle", &bundleReq, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 // JobsParseRequest parses a hcl jobspec and returns a api.Job
 func (s *HTTPServer) JobsParseRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	if req.Method != http.MethodPut && req.Method != http.MethodPost {
@@ -867,6 +1137,7 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		Priority:       *job.Priority,
 		AllAtOnce:      *job.AllAtOnce,
 		Datacenters:    job.Datacenters,
+		DependsOn:      job.DependsOn,
 		Payload:        job.Payload,
 		Meta:           job.Meta,
 		ConsulToken:    *job.ConsulToken,
@@ -874,6 +1145,8 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		VaultNamespace: *job.VaultNamespace,
 		Constraints:    ApiConstraintsToStructs(job.Constraints),
 		Affinities:     ApiAffinitiesToStructs(job.Affinities),
+
+		VaultClusterTokens: job.VaultClusterTokens,
 	}
 
 	// Update has been pushed into the task groups. stagger and max_parallel are
@@ -972,6 +1245,10 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		tg.StopAfterClientDisconnect = taskGroup.StopAfterClientDisconnect
 	}
 
+	if taskGroup.Priority != nil {
+		tg.Priority = *taskGroup.Priority
+	}
+
 	if taskGroup.ReschedulePolicy != nil {
 		tg.ReschedulePolicy = &structs.ReschedulePolicy{
 			Attempts:      *taskGroup.ReschedulePolicy.Attempts,
@@ -980,6 +1257,8 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 			DelayFunction: *taskGroup.ReschedulePolicy.DelayFunction,
 			MaxDelay:      *taskGroup.ReschedulePolicy.MaxDelay,
 			Unlimited:     *taskGroup.ReschedulePolicy.Unlimited,
+			Jitter:        *taskGroup.ReschedulePolicy.Jitter,
+			MaxLifetime:   *taskGroup.ReschedulePolicy.MaxLifetime,
 		}
 	}
 
@@ -1019,13 +1298,14 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 			}
 
 			vol := &structs.VolumeRequest{
-				Name:           v.Name,
-				Type:           v.Type,
-				ReadOnly:       v.ReadOnly,
-				Source:         v.Source,
-				AttachmentMode: structs.CSIVolumeAttachmentMode(v.AttachmentMode),
-				AccessMode:     structs.CSIVolumeAccessMode(v.AccessMode),
-				PerAlloc:       v.PerAlloc,
+				Name:               v.Name,
+				Type:               v.Type,
+				ReadOnly:           v.ReadOnly,
+				Source:             v.Source,
+				AlternativeSources: v.AlternativeSources,
+				AttachmentMode:     structs.CSIVolumeAttachmentMode(v.AttachmentMode),
+				AccessMode:         structs.CSIVolumeAccessMode(v.AccessMode),
+				PerAlloc:           v.PerAlloc,
 			}
 
 			if v.MountOptions != nil {
@@ -1058,6 +1338,19 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		if taskGroup.Update.AutoPromote != nil {
 			tg.Update.AutoPromote = *taskGroup.Update.AutoPromote
 		}
+
+		// SLO fields are unset unless the update stanza defines an SLO query
+		if taskGroup.Update.SLOQuery != nil {
+			tg.Update.SLOQuery = *taskGroup.Update.SLOQuery
+		}
+
+		if taskGroup.Update.SLOInterval != nil {
+			tg.Update.SLOInterval = *taskGroup.Update.SLOInterval
+		}
+
+		if taskGroup.Update.BlueGreen != nil {
+			tg.Update.BlueGreen = *taskGroup.Update.BlueGreen
+		}
 	}
 
 	if len(taskGroup.Tasks) > 0 {
@@ -1115,6 +1408,7 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 						Destination:     *mount.Destination,
 						ReadOnly:        *mount.ReadOnly,
 						PropagationMode: *mount.PropagationMode,
+						OnRecover:       *mount.OnRecover,
 					})
 			}
 		}
@@ -1160,6 +1454,27 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 			ChangeMode:   *apiTask.Vault.ChangeMode,
 			ChangeSignal: *apiTask.Vault.ChangeSignal,
 		}
+
+		for _, cluster := range apiTask.Vault.Clusters {
+			structsTask.Vault.Clusters = append(structsTask.Vault.Clusters,
+				&structs.VaultCluster{
+					Name:      cluster.Name,
+					Policies:  cluster.Policies,
+					Namespace: *cluster.Namespace,
+					Env:       *cluster.Env,
+				})
+		}
+	}
+
+	for _, identity := range apiTask.Identities {
+		structsTask.Identities = append(structsTask.Identities,
+			&structs.WorkloadIdentity{
+				Name:     identity.Name,
+				Audience: identity.Audience,
+				TTL:      *identity.TTL,
+				Env:      *identity.Env,
+				File:     *identity.File,
+			})
 	}
 
 	if len(apiTask.Templates) > 0 {
@@ -1172,12 +1487,14 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 					EmbeddedTmpl: *template.EmbeddedTmpl,
 					ChangeMode:   *template.ChangeMode,
 					ChangeSignal: *template.ChangeSignal,
+					ChangeScript: apiChangeScriptToStructsChangeScript(template.ChangeScript),
 					Splay:        *template.Splay,
 					Perms:        *template.Perms,
 					LeftDelim:    *template.LeftDelim,
 					RightDelim:   *template.RightDelim,
 					Envvars:      *template.Envvars,
 					VaultGrace:   *template.VaultGrace,
+					VaultCluster: *template.VaultCluster,
 					Wait:         ApiWaitConfigToStructsWaitConfig(template.Wait),
 				})
 		}
@@ -1185,16 +1502,41 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 
 	if apiTask.DispatchPayload != nil {
 		structsTask.DispatchPayload = &structs.DispatchPayloadConfig{
-			File: apiTask.DispatchPayload.File,
+			File:  apiTask.DispatchPayload.File,
+			Stdin: apiTask.DispatchPayload.Stdin,
 		}
 	}
 
 	if apiTask.Lifecycle != nil {
 		structsTask.Lifecycle = &structs.TaskLifecycleConfig{
-			Hook:    apiTask.Lifecycle.Hook,
-			Sidecar: apiTask.Lifecycle.Sidecar,
+			Hook:      apiTask.Lifecycle.Hook,
+			Sidecar:   apiTask.Lifecycle.Sidecar,
+			OnFailure: apiTask.Lifecycle.OnFailure,
 		}
 	}
+
+	if apiTask.RuntimeEnv != nil {
+		structsTask.RuntimeEnv = &structs.RuntimeEnvConfig{
+			GOMAXPROCS:      apiTask.RuntimeEnv.GOMAXPROCS,
+			JavaHeapPercent: apiTask.RuntimeEnv.JavaHeapPercent,
+		}
+	}
+}
+
+// apiChangeScriptToStructsChangeScript converts the script of a template with
+// change mode script.
+func apiChangeScriptToStructsChangeScript(changeScript *api.ChangeScript) *structs.ChangeScript {
+	if changeScript == nil {
+		return nil
+	}
+
+	return &structs.ChangeScript{
+		Command:         *changeScript.Command,
+		Args:            changeScript.Args,
+		Timeout:         *changeScript.Timeout,
+		FailOnError:     *changeScript.FailOnError,
+		PassChangedKeys: *changeScript.PassChangedKeys,
+	}
 }
 
 // ApiWaitConfigToStructsWaitConfig is a copy and type conversion between the API
@@ -1240,6 +1582,20 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 		out.MemoryMaxMB = *in.MemoryMaxMB
 	}
 
+	if in.DiskIOPS != nil {
+		out.DiskIOPS = *in.DiskIOPS
+	}
+
+	if in.DiskBandwidthMB != nil {
+		out.DiskBandwidthMB = *in.DiskBandwidthMB
+	}
+
+	if in.NUMA != nil {
+		out.NUMA = &structs.NUMA{
+			Affinity: *in.NUMA.Affinity,
+		}
+	}
+
 	// COMPAT(0.10): Only being used to issue warnings
 	if in.IOPS != nil {
 		out.IOPS = *in.IOPS
@@ -1279,6 +1635,10 @@ func ApiNetworkResourceToStructs(in []*api.NetworkResource) []*structs.NetworkRe
 			MBits:    nw.Megabits(),
 		}
 
+		if nw.Bandwidth != nil {
+			out[i].Bandwidth = *nw.Bandwidth
+		}
+
 		if nw.DNS != nil {
 			out[i].DNS = &structs.DNSConfig{
 				Servers:  nw.DNS.Servers,
@@ -1287,6 +1647,14 @@ func ApiNetworkResourceToStructs(in []*api.NetworkResource) []*structs.NetworkRe
 			}
 		}
 
+		if nw.Firewall != nil {
+			out[i].Firewall = &structs.NetworkFirewall{
+				AllowClientAPI: nw.Firewall.AllowClientAPI,
+				AllowMetadata:  nw.Firewall.AllowMetadata,
+				AllowCIDRs:     nw.Firewall.AllowCIDRs,
+			}
+		}
+
 		if l := len(nw.DynamicPorts); l != 0 {
 			out[i].DynamicPorts = make([]structs.Port, l)
 			for j, dp := range nw.DynamicPorts {
@@ -1307,10 +1675,11 @@ func ApiNetworkResourceToStructs(in []*api.NetworkResource) []*structs.NetworkRe
 
 func ApiPortToStructs(in api.Port) structs.Port {
 	return structs.Port{
-		Label:       in.Label,
-		Value:       in.Value,
-		To:          in.To,
-		HostNetwork: in.HostNetwork,
+		Label:        in.Label,
+		Value:        in.Value,
+		To:           in.To,
+		HostNetwork:  in.HostNetwork,
+		HostNetworks: helper.CopySliceString(in.HostNetworks),
 	}
 }
 
@@ -1332,6 +1701,16 @@ func ApiServicesToStructs(in []*api.Service, group bool) []*structs.Service {
 			Meta:              helper.CopyMapStringString(s.Meta),
 			CanaryMeta:        helper.CopyMapStringString(s.CanaryMeta),
 			OnUpdate:          s.OnUpdate,
+			Namespace:         s.Namespace,
+			ExplicitNamespace: s.Namespace != "",
+			Partition:         s.Partition,
+		}
+
+		if s.Weights != nil {
+			out[i].Weights = &structs.ServiceWeights{
+				Passing: *s.Weights.Passing,
+				Warning: *s.Weights.Warning,
+			}
 		}
 
 		if l := len(s.Checks); l != 0 {
@@ -1360,9 +1739,15 @@ func ApiServicesToStructs(in []*api.Service, group bool) []*structs.Service {
 					Body:                   check.Body,
 					GRPCService:            check.GRPCService,
 					GRPCUseTLS:             check.GRPCUseTLS,
+					TLSServerName:          check.TLSServerName,
+					TLSCACert:              check.TLSCACert,
+					TLSClientCert:          check.TLSClientCert,
+					TLSClientKey:           check.TLSClientKey,
 					SuccessBeforePassing:   check.SuccessBeforePassing,
 					FailuresBeforeCritical: check.FailuresBeforeCritical,
 					OnUpdate:               onUpdate,
+					Namespace:              check.Namespace,
+					Partition:              check.Partition,
 				}
 
 				if group {
@@ -1398,6 +1783,18 @@ func ApiConsulConnectToStructs(in *api.ConsulConnect) *structs.ConsulConnect {
 		SidecarService: apiConnectSidecarServiceToStructs(in.SidecarService),
 		SidecarTask:    apiConnectSidecarTaskToStructs(in.SidecarTask),
 		Gateway:        apiConnectGatewayToStructs(in.Gateway),
+		Certs:          apiConnectCertsToStructs(in.Certs),
+	}
+}
+
+func apiConnectCertsToStructs(in *api.ConsulConnectCerts) *structs.ConsulConnectCerts {
+	if in == nil {
+		return nil
+	}
+
+	return &structs.ConsulConnectCerts{
+		ChangeMode:   in.ChangeMode,
+		ChangeSignal: in.ChangeSignal,
 	}
 }
 
@@ -1411,6 +1808,7 @@ func apiConnectGatewayToStructs(in *api.ConsulGateway) *structs.ConsulGateway {
 		Ingress:     apiConnectIngressGatewayToStructs(in.Ingress),
 		Terminating: apiConnectTerminatingGatewayToStructs(in.Terminating),
 		Mesh:        apiConnectMeshGatewayToStructs(in.Mesh),
+		APIGateway:  apiConnectAPIGatewayToStructs(in.APIGateway),
 	}
 }
 
@@ -1550,6 +1948,55 @@ func apiConnectMeshGatewayToStructs(in *api.ConsulMeshConfigEntry) *structs.Cons
 	return new(structs.ConsulMeshConfigEntry)
 }
 
+func apiConnectAPIGatewayToStructs(in *api.ConsulAPIGatewayConfigEntry) *structs.ConsulAPIGatewayConfigEntry {
+	if in == nil {
+		return nil
+	}
+
+	return &structs.ConsulAPIGatewayConfigEntry{
+		Listeners: apiConnectAPIGatewayListenersToStructs(in.Listeners),
+	}
+}
+
+func apiConnectAPIGatewayListenersToStructs(in []*api.ConsulAPIGatewayListener) []*structs.ConsulAPIGatewayListener {
+	if len(in) == 0 {
+		return nil
+	}
+
+	listeners := make([]*structs.ConsulAPIGatewayListener, len(in))
+	for i, listener := range in {
+		listeners[i] = apiConnectAPIGatewayListenerToStructs(listener)
+	}
+	return listeners
+}
+
+func apiConnectAPIGatewayListenerToStructs(in *api.ConsulAPIGatewayListener) *structs.ConsulAPIGatewayListener {
+	if in == nil {
+		return nil
+	}
+
+	return &structs.ConsulAPIGatewayListener{
+		Name:     in.Name,
+		Hostname: in.Hostname,
+		Port:     in.Port,
+		Protocol: in.Protocol,
+		TLS:      apiConnectAPIGatewayTLSConfig(in.TLS),
+	}
+}
+
+func apiConnectAPIGatewayTLSConfig(in *api.ConsulAPIGatewayTLSConfig) *structs.ConsulAPIGatewayTLSConfig {
+	if in == nil {
+		return nil
+	}
+
+	return &structs.ConsulAPIGatewayTLSConfig{
+		Certificates:  helper.CopySliceString(in.Certificates),
+		TLSMinVersion: in.TLSMinVersion,
+		TLSMaxVersion: in.TLSMaxVersion,
+		CipherSuites:  helper.CopySliceString(in.CipherSuites),
+	}
+}
+
 func apiConnectSidecarServiceToStructs(in *api.ConsulSidecarService) *structs.ConsulSidecarService {
 	if in == nil {
 		return nil
@@ -1631,17 +2078,18 @@ func apiConnectSidecarTaskToStructs(in *api.SidecarTask) *structs.SidecarTask {
 		return nil
 	}
 	return &structs.SidecarTask{
-		Name:          in.Name,
-		Driver:        in.Driver,
-		User:          in.User,
-		Config:        in.Config,
-		Env:           in.Env,
-		Resources:     ApiResourcesToStructs(in.Resources),
-		Meta:          in.Meta,
-		ShutdownDelay: in.ShutdownDelay,
-		KillSignal:    in.KillSignal,
-		KillTimeout:   in.KillTimeout,
-		LogConfig:     apiLogConfigToStructs(in.LogConfig),
+		Name:           in.Name,
+		Driver:         in.Driver,
+		User:           in.User,
+		Config:         in.Config,
+		Env:            in.Env,
+		Resources:      ApiResourcesToStructs(in.Resources),
+		Meta:           in.Meta,
+		ShutdownDelay:  in.ShutdownDelay,
+		KillSignal:     in.KillSignal,
+		KillTimeout:    in.KillTimeout,
+		LogConfig:      apiLogConfigToStructs(in.LogConfig),
+		ScaleResources: in.ScaleResources,
 	}
 }
 
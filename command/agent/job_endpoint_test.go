@@ -9,10 +9,12 @@ import (
 	"time"
 
 	"github.com/golang/snappy"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
 	"github.com/hashicorp/nomad/acl"
 	api "github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/stretchr/testify/assert"
@@ -224,6 +226,43 @@ func TestHTTP_JobsRegister(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobsBundle(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		job1, job2 := MockJob(), MockJob()
+		args := api.JobBundleRegisterRequest{
+			Jobs:         []*api.Job{job1, job2},
+			WriteRequest: api.WriteRequest{Region: "global"},
+		}
+
+		req, err := http.NewRequest("PUT", "/v1/jobs/bundle", encodeReq(args))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.JobsBundleRequest(respW, req)
+		require.NoError(t, err)
+		out := obj.(structs.JobBundleRegisterResponse)
+		require.NotEmpty(t, out.BundleID)
+		require.Len(t, out.JobEvals, 2)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+
+		// Check the jobs are registered
+		for _, job := range []*api.Job{job1, job2} {
+			sJob, err := s.Agent.server.State().JobByID(nil, structs.DefaultNamespace, *job.ID)
+			require.NoError(t, err)
+			require.NotNil(t, sJob)
+			require.Equal(t, out.BundleID, sJob.BundleID)
+		}
+
+		// Jobs of a bundle must be in the same namespace
+		job2.Namespace = helper.StringToPtr("other")
+		req, err = http.NewRequest("PUT", "/v1/jobs/bundle", encodeReq(args))
+		require.NoError(t, err)
+		_, err = s.Server.JobsBundleRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "All jobs of a bundle must be in the same namespace")
+	})
+}
+
 func TestHTTP_JobsRegister_IgnoresParentID(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
@@ -287,6 +326,48 @@ func TestHTTP_JobsRegister_IgnoresParentID(t *testing.T) {
 }
 
 // Test that ACL token is properly threaded through to the RPC endpoint
+func TestHTTP_JobsRegister_Async(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		job := MockJob()
+		args := api.JobRegisterRequest{
+			Job:          job,
+			WriteRequest: api.WriteRequest{Region: "global"},
+		}
+		req, err := http.NewRequest("PUT", "/v1/jobs?async=true", encodeReq(args))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.JobsRequest(respW, req)
+		require.NoError(t, err)
+		regResp := obj.(structs.JobRegisterResponse)
+		require.NotEmpty(t, regResp.SubmissionID)
+		require.Empty(t, regResp.EvalID)
+
+		// Poll the status of the submission
+		retry.Run(t, func(r *retry.R) {
+			req, err := http.NewRequest("GET", "/v1/jobs/submission/"+regResp.SubmissionID, nil)
+			require.NoError(r, err)
+			respW := httptest.NewRecorder()
+
+			obj, err := s.Server.JobsSubmissionRequest(respW, req)
+			require.NoError(r, err)
+			sub := obj.(*structs.JobRegisterSubmission)
+			require.Equal(r, structs.JobRegisterSubmissionStatusComplete, sub.Status)
+			require.Equal(r, *job.ID, sub.JobID)
+			require.NotEmpty(r, sub.EvalID)
+		})
+
+		// Unknown submissions aren't found
+		req, err = http.NewRequest("GET", "/v1/jobs/submission/"+uuid.Generate(), nil)
+		require.NoError(t, err)
+		_, err = s.Server.JobsSubmissionRequest(httptest.NewRecorder(), req)
+		require.Error(t, err)
+		code, _ := errCodeFromHandler(err)
+		require.Equal(t, http.StatusNotFound, code)
+	})
+}
+
 func TestHTTP_JobsRegister_ACL(t *testing.T) {
 	ci.Parallel(t)
 	httpACLTest(t, nil, func(s *TestAgent) {
@@ -1374,6 +1455,38 @@ func TestHTTP_JobEvaluations(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobPlanResults(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Record a plan result for a job
+		job := mock.Job()
+		result := &structs.PlanApplyResult{
+			EvalID:        uuid.Generate(),
+			RejectedNodes: []*structs.PlanRejectedNode{{NodeID: uuid.Generate(), Reason: "node does not exist"}},
+		}
+		state := s.Agent.server.State()
+		require.NoError(t, state.UpsertPlanResults(structs.MsgTypeTestSetup, 1000, &structs.ApplyPlanResultsRequest{
+			AllocUpdateRequest: structs.AllocUpdateRequest{Job: job},
+			PlanApplyResult:    result,
+		}))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/job/"+job.ID+"/plan-results", nil)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		require.NoError(t, err)
+
+		// Check the response
+		results := obj.([]*structs.PlanApplyResult)
+		require.Len(t, results, 1)
+		require.Equal(t, result.EvalID, results[0].EvalID)
+		require.Equal(t, "1000", respW.Result().Header.Get("X-Nomad-Index"))
+	})
+}
+
 func TestHTTP_JobAllocations(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
@@ -1480,6 +1593,19 @@ func TestHTTP_JobDeployments(t *testing.T) {
 		assert.NotZero(respW.Result().Header.Get("X-Nomad-Index"), "missing index")
 		assert.Equal("true", respW.Result().Header.Get("X-Nomad-KnownLeader"), "missing known leader")
 		assert.NotZero(respW.Result().Header.Get("X-Nomad-LastContact"), "missing last contact")
+
+		// Deployments outside the create index window aren't listed
+		req, err = http.NewRequest("GET", "/v1/job/"+j.ID+"/deployments?from_index=1001", nil)
+		assert.Nil(err, "HTTP")
+		obj, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		assert.Nil(err, "JobSpecificRequest")
+		assert.Empty(obj.([]*structs.Deployment), "deployments")
+
+		req, err = http.NewRequest("GET", "/v1/job/"+j.ID+"/deployments?to_index=foo", nil)
+		assert.Nil(err, "HTTP")
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		assert.Error(err)
+		assert.Contains(err.Error(), `Failed to parse value of "to_index"`)
 	})
 }
 
@@ -1526,6 +1652,51 @@ func TestHTTP_JobDeployment(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobVersionsDiff(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create two versions of the job
+		job := mock.Job()
+		args := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		require.NoError(t, s.Agent.RPC("Job.Register", &args, &resp))
+
+		job.Priority = 100
+		require.NoError(t, s.Agent.RPC("Job.Register", &args, &resp))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/job/"+job.ID+"/versions/diff?from=0&to=1", nil)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		require.NoError(t, err)
+
+		diff := obj.(structs.JobVersionsDiffResponse).Diff
+		require.NotNil(t, diff)
+		require.Equal(t, structs.DiffTypeEdited, diff.Type)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+
+		// Unknown versions are not found
+		req, err = http.NewRequest("GET", "/v1/job/"+job.ID+"/versions/diff?from=0&to=5", nil)
+		require.NoError(t, err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "job version not found")
+
+		// Both versions are required
+		req, err = http.NewRequest("GET", "/v1/job/"+job.ID+"/versions/diff?from=0", nil)
+		require.NoError(t, err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, `missing "to" version`)
+	})
+}
+
 func TestHTTP_JobVersions(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
@@ -1934,6 +2105,110 @@ func TestHTTP_JobStable(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobVersionTag(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create the job
+		job := mock.Job()
+		regReq := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var regResp structs.JobRegisterResponse
+		require.NoError(t, s.Agent.RPC("Job.Register", &regReq, &regResp))
+
+		args := structs.JobVersionTagRequest{
+			JobID:      job.ID,
+			JobVersion: 0,
+			Tag:        &structs.JobVersionTag{Name: "golden"},
+		}
+
+		// Make the HTTP request
+		req, err := http.NewRequest("PUT", "/v1/job/"+job.ID+"/versions/tag", encodeReq(args))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.NotZero(t, obj.(structs.JobVersionTagResponse).Index)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+
+		// Check the version was tagged
+		out, err := s.Agent.server.State().JobByIDAndVersion(nil, structs.DefaultNamespace, job.ID, 0)
+		require.NoError(t, err)
+		require.Equal(t, "golden", out.VersionTag.Name)
+
+		// Mismatched job IDs are rejected
+		args.JobID = "other"
+		req, err = http.NewRequest("PUT", "/v1/job/"+job.ID+"/versions/tag", encodeReq(args))
+		require.NoError(t, err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "Job ID does not match")
+	})
+}
+
+func TestHTTP_JobMove(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create the job
+		job := mock.Job()
+		regReq := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var regResp structs.JobRegisterResponse
+		require.NoError(t, s.Agent.RPC("Job.Register", &regReq, &regResp))
+
+		// Wait for the registration evaluation to complete, since jobs
+		// with pending evaluations can't be moved
+		state := s.Agent.server.State()
+		retry.Run(t, func(r *retry.R) {
+			eval, err := state.EvalByID(nil, regResp.EvalID)
+			if err != nil {
+				r.Fatalf("err: %v", err)
+			}
+			if eval == nil || !eval.TerminalStatus() {
+				r.Fatalf("evaluation %q not complete", regResp.EvalID)
+			}
+		})
+
+		args := structs.JobMoveRequest{
+			JobID:    job.ID,
+			NewJobID: "renamed",
+		}
+
+		// Make the HTTP request
+		req, err := http.NewRequest("PUT", "/v1/job/"+job.ID+"/move", encodeReq(args))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		require.NoError(t, err)
+		moveResp := obj.(structs.JobMoveResponse)
+		require.NotZero(t, moveResp.Index)
+		require.NotEmpty(t, moveResp.EvalID)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+
+		// Check the job was renamed
+		out, err := state.JobByID(nil, structs.DefaultNamespace, "renamed")
+		require.NoError(t, err)
+		require.NotNil(t, out)
+
+		// Mismatched job IDs are rejected
+		args.JobID = "other"
+		req, err = http.NewRequest("PUT", "/v1/job/"+job.ID+"/move", encodeReq(args))
+		require.NoError(t, err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "Job ID does not match")
+	})
+}
+
 func TestJobs_ParsingWriteRequest(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2405,6 +2680,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 						CanaryTags:        []string{"d", "e"},
 						EnableTagOverride: true,
 						PortLabel:         "1234",
+						Weights: &api.ServiceWeights{
+							Passing: helper.IntToPtr(10),
+							Warning: helper.IntToPtr(1),
+						},
 						Meta: map[string]string{
 							"servicemeta": "foobar",
 						},
@@ -2482,6 +2761,7 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 								Destination:     helper.StringToPtr("dest"),
 								ReadOnly:        helper.BoolToPtr(false),
 								PropagationMode: helper.StringToPtr("a"),
+								OnRecover:       helper.StringToPtr("signal"),
 							},
 						},
 						RestartPolicy: &api.RestartPolicy{
@@ -2544,9 +2824,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							MemoryMB: helper.IntToPtr(10),
 							Networks: []*api.NetworkResource{
 								{
-									IP:       "10.10.11.1",
-									MBits:    helper.IntToPtr(10),
-									Hostname: "foobar",
+									IP:        "10.10.11.1",
+									MBits:     helper.IntToPtr(10),
+									Hostname:  "foobar",
+									Bandwidth: helper.IntToPtr(50),
 									ReservedPorts: []api.Port{
 										{
 											Label: "http",
@@ -2596,6 +2877,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							MaxFiles:      helper.IntToPtr(10),
 							MaxFileSizeMB: helper.IntToPtr(100),
 						},
+						RuntimeEnv: &api.TaskRuntimeEnv{
+							GOMAXPROCS:      true,
+							JavaHeapPercent: 50,
+						},
 						Artifacts: []*api.TaskArtifact{
 							{
 								GetterSource: helper.StringToPtr("source"),
@@ -2802,6 +3087,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 						EnableTagOverride: true,
 						PortLabel:         "1234",
 						AddressMode:       "auto",
+						Weights: &structs.ServiceWeights{
+							Passing: 10,
+							Warning: 1,
+						},
 						Meta: map[string]string{
 							"servicemeta": "foobar",
 						},
@@ -2877,6 +3166,7 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 								Destination:     "dest",
 								ReadOnly:        false,
 								PropagationMode: "a",
+								OnRecover:       "signal",
 							},
 						},
 						RestartPolicy: &structs.RestartPolicy{
@@ -2941,9 +3231,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							MemoryMB: 10,
 							Networks: []*structs.NetworkResource{
 								{
-									IP:       "10.10.11.1",
-									MBits:    10,
-									Hostname: "foobar",
+									IP:        "10.10.11.1",
+									MBits:     10,
+									Hostname:  "foobar",
+									Bandwidth: 50,
 									ReservedPorts: []structs.Port{
 										{
 											Label: "http",
@@ -2993,6 +3284,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							MaxFiles:      10,
 							MaxFileSizeMB: 100,
 						},
+						RuntimeEnv: &structs.RuntimeEnvConfig{
+							GOMAXPROCS:      true,
+							JavaHeapPercent: 50,
+						},
 						Artifacts: []*structs.TaskArtifact{
 							{
 								GetterSource: "source",
@@ -3140,6 +3435,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							MaxFiles:      helper.IntToPtr(10),
 							MaxFileSizeMB: helper.IntToPtr(100),
 						},
+						RuntimeEnv: &api.TaskRuntimeEnv{
+							GOMAXPROCS:      true,
+							JavaHeapPercent: 50,
+						},
 						Artifacts: []*api.TaskArtifact{
 							{
 								GetterSource:  helper.StringToPtr("source"),
@@ -3265,6 +3564,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							MaxFiles:      10,
 							MaxFileSizeMB: 100,
 						},
+						RuntimeEnv: &structs.RuntimeEnvConfig{
+							GOMAXPROCS:      true,
+							JavaHeapPercent: 50,
+						},
 						Artifacts: []*structs.TaskArtifact{
 							{
 								GetterSource:  "source",
@@ -3477,6 +3780,24 @@ func TestConversion_apiResourcesToStructs(t *testing.T) {
 				MemoryMaxMB: 300,
 			},
 		},
+		{
+			"with numa",
+			&api.Resources{
+				CPU:      helper.IntToPtr(0),
+				Cores:    helper.IntToPtr(2),
+				MemoryMB: helper.IntToPtr(200),
+				NUMA: &api.NUMAResource{
+					Affinity: helper.StringToPtr("require"),
+				},
+			},
+			&structs.Resources{
+				Cores:    2,
+				MemoryMB: 200,
+				NUMA: &structs.NUMA{
+					Affinity: structs.NUMAAffinityRequire,
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -3587,6 +3908,29 @@ func TestConversion_apiConsulMeshGatewayToStructs(t *testing.T) {
 		apiMeshGatewayToStructs(&api.ConsulMeshGateway{Mode: "remote"}))
 }
 
+func TestConversion_ApiServicesToStructs_Namespace(t *testing.T) {
+	ci.Parallel(t)
+
+	// Only services setting a namespace, including "default", are marked as
+	// setting it explicitly
+	services := ApiServicesToStructs([]*api.Service{
+		{Name: "unset"},
+		{Name: "default", Namespace: "default"},
+	}, true)
+	require.Equal(t, "", services[0].Namespace)
+	require.False(t, services[0].ExplicitNamespace)
+	require.Equal(t, "default", services[1].Namespace)
+	require.True(t, services[1].ExplicitNamespace)
+
+	partitioned := ApiServicesToStructs([]*api.Service{{
+		Name:      "partitioned",
+		Partition: "tenant-a",
+		Checks:    []api.ServiceCheck{{Name: "check", Type: "tcp", Partition: "tenant-a"}},
+	}}, true)
+	require.Equal(t, "tenant-a", partitioned[0].Partition)
+	require.Equal(t, "tenant-a", partitioned[0].Checks[0].Partition)
+}
+
 func TestConversion_apiConnectSidecarServiceProxyToStructs(t *testing.T) {
 	ci.Parallel(t)
 	require.Nil(t, apiConnectSidecarServiceProxyToStructs(nil))
@@ -19,7 +19,8 @@ var (
 
 // logFile is used to setup a file based logger that also performs log rotation
 type logFile struct {
-	// Log level Filter to filter out logs that do not matcch LogLevel criteria
+	// Log level Filter to filter out logs that do not matcch LogLevel criteria.
+	// Nothing is filtered if nil.
 	logFilter *logutils.LevelFilter
 
 	//Name of the log file
@@ -135,7 +136,7 @@ func (l *logFile) pruneFiles() error {
 // Write is used to implement io.Writer
 func (l *logFile) Write(b []byte) (int, error) {
 	// Filter out log entries that do not match log level criteria
-	if !l.logFilter.Check(b) {
+	if l.logFilter != nil && !l.logFilter.Check(b) {
 		return 0, nil
 	}
 
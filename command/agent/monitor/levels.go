@@ -0,0 +1,91 @@
+package monitor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/hashicorp/go-hclog"
+)
+
+// ParseLevelOverrides parses a comma separated list of subsystem=level
+// pairs, such as "raft=TRACE,http=WARN", into a map of subsystem to level.
+func ParseLevelOverrides(s string) (map[string]log.Level, error) {
+	overrides := map[string]log.Level{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid log level override %q, expected subsystem=level", pair)
+		}
+		level := log.LevelFromString(parts[1])
+		if level == log.NoLevel {
+			return nil, fmt.Errorf("unknown log level %q for subsystem %q", parts[1], parts[0])
+		}
+		overrides[strings.ToLower(parts[0])] = level
+	}
+	return overrides, nil
+}
+
+// FormatLevelOverrides is the inverse of ParseLevelOverrides.
+func FormatLevelOverrides(overrides map[string]log.Level) string {
+	pairs := make([]string, 0, len(overrides))
+	for subsystem, level := range overrides {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", subsystem, strings.ToUpper(level.String())))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// levelSink wraps a SinkAdapter and filters messages by the level configured
+// for the subsystem that logged them, falling back to a default level.
+type levelSink struct {
+	sink         log.SinkAdapter
+	defaultLevel log.Level
+	overrides    map[string]log.Level
+}
+
+// Accept implements log.SinkAdapter
+func (s *levelSink) Accept(name string, level log.Level, msg string, args ...interface{}) {
+	if level < s.levelFor(name) {
+		return
+	}
+	s.sink.Accept(name, level, msg, args...)
+}
+
+// levelFor returns the level for a logger name. A subsystem matches a logger
+// if it is a contiguous run of the logger's dot separated name segments, so
+// "raft" matches "nomad.raft" and "client.alloc_runner" matches
+// "client.alloc_runner.task_runner". The longest matching subsystem wins.
+func (s *levelSink) levelFor(name string) log.Level {
+	level := s.defaultLevel
+	if name == "" {
+		return level
+	}
+
+	segments := "." + strings.ToLower(name) + "."
+	best := 0
+	for subsystem, l := range s.overrides {
+		if len(subsystem) <= best {
+			continue
+		}
+		if strings.Contains(segments, "."+subsystem+".") {
+			level = l
+			best = len(subsystem)
+		}
+	}
+	return level
+}
+
+// minLevel returns the most verbose of the default level and overrides.
+func minLevel(level log.Level, overrides map[string]log.Level) log.Level {
+	for _, l := range overrides {
+		if l < level {
+			level = l
+		}
+	}
+	return level
+}
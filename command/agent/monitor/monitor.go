@@ -52,10 +52,17 @@ type monitor struct {
 // New creates a new Monitor. Start must be called in order to actually start
 // streaming logs
 func New(buf int, logger log.InterceptLogger, opts *log.LoggerOptions) Monitor {
-	return new(buf, logger, opts)
+	return new(buf, logger, opts, nil)
 }
 
-func new(buf int, logger log.InterceptLogger, opts *log.LoggerOptions) *monitor {
+// NewWithOverrides creates a new Monitor that streams logs from the
+// subsystems in overrides at their own level instead of the level in opts.
+// Start must be called in order to actually start streaming logs
+func NewWithOverrides(buf int, logger log.InterceptLogger, opts *log.LoggerOptions, overrides map[string]log.Level) Monitor {
+	return new(buf, logger, opts, overrides)
+}
+
+func new(buf int, logger log.InterceptLogger, opts *log.LoggerOptions, overrides map[string]log.Level) *monitor {
 	sw := &monitor{
 		logger:          logger,
 		logCh:           make(chan []byte, buf),
@@ -65,8 +72,23 @@ func new(buf int, logger log.InterceptLogger, opts *log.LoggerOptions) *monitor
 	}
 
 	opts.Output = sw
-	sink := log.NewSinkAdapter(opts)
-	sw.sink = sink
+	if len(overrides) == 0 {
+		sw.sink = log.NewSinkAdapter(opts)
+		return sw
+	}
+
+	// The wrapped sink must accept every message the overrides allow, and
+	// leave filtering by subsystem to the levelSink
+	defaultLevel := opts.Level
+	if defaultLevel == log.NoLevel {
+		defaultLevel = log.DefaultLevel
+	}
+	opts.Level = minLevel(defaultLevel, overrides)
+	sw.sink = &levelSink{
+		sink:         log.NewSinkAdapter(opts),
+		defaultLevel: defaultLevel,
+		overrides:    overrides,
+	}
 
 	return sw
 }
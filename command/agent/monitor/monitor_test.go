@@ -51,7 +51,7 @@ func TestMonitor_DroppedMessages(t *testing.T) {
 
 	m := new(5, logger, &log.LoggerOptions{
 		Level: log.Debug,
-	})
+	}, nil)
 	m.droppedDuration = 5 * time.Millisecond
 
 	doneCh := make(chan struct{})
@@ -87,3 +87,84 @@ TEST:
 		}
 	}
 }
+
+func TestMonitor_LevelOverrides(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := log.NewInterceptLogger(&log.LoggerOptions{
+		Level: log.Error,
+	})
+
+	overrides, err := ParseLevelOverrides("raft=TRACE, http=warn")
+	require.NoError(t, err)
+
+	m := NewWithOverrides(512, logger, &log.LoggerOptions{
+		Level: log.Info,
+	}, overrides)
+
+	logCh := m.Start()
+	defer m.Stop()
+
+	go func() {
+		logger.Named("nomad").Named("raft").Trace("raft trace")
+		logger.ResetNamed("http").Info("http info")
+		logger.Named("nomad").Debug("nomad debug")
+		logger.Named("nomad").Info("nomad info")
+		logger.ResetNamed("http").Warn("http warn")
+	}()
+
+	var received []string
+	for len(received) < 3 {
+		select {
+		case log := <-logCh:
+			received = append(received, string(log))
+		case <-time.After(3 * time.Second):
+			t.Fatalf("Expected to receive from log channel, got %v", received)
+		}
+	}
+	require.Contains(t, received[0], "[TRACE] nomad.raft: raft trace")
+	require.Contains(t, received[1], "[INFO]  nomad: nomad info")
+	require.Contains(t, received[2], "[WARN]  http: http warn")
+}
+
+func TestMonitor_ParseLevelOverrides(t *testing.T) {
+	ci.Parallel(t)
+
+	overrides, err := ParseLevelOverrides("")
+	require.NoError(t, err)
+	require.Empty(t, overrides)
+
+	overrides, err = ParseLevelOverrides("Raft=TRACE,client.alloc_runner=debug")
+	require.NoError(t, err)
+	require.Equal(t, map[string]log.Level{
+		"raft":                log.Trace,
+		"client.alloc_runner": log.Debug,
+	}, overrides)
+	require.Equal(t, "client.alloc_runner=DEBUG,raft=TRACE", FormatLevelOverrides(overrides))
+
+	_, err = ParseLevelOverrides("raft")
+	require.EqualError(t, err, `invalid log level override "raft", expected subsystem=level`)
+
+	_, err = ParseLevelOverrides("raft=LOUD")
+	require.EqualError(t, err, `unknown log level "LOUD" for subsystem "raft"`)
+}
+
+func TestMonitor_levelSink_levelFor(t *testing.T) {
+	ci.Parallel(t)
+
+	s := &levelSink{
+		defaultLevel: log.Info,
+		overrides: map[string]log.Level{
+			"client":              log.Warn,
+			"client.alloc_runner": log.Trace,
+			"raft":                log.Debug,
+		},
+	}
+
+	require.Equal(t, log.Info, s.levelFor(""))
+	require.Equal(t, log.Info, s.levelFor("nomad"))
+	require.Equal(t, log.Debug, s.levelFor("nomad.raft"))
+	require.Equal(t, log.Info, s.levelFor("nomad.raftish"))
+	require.Equal(t, log.Warn, s.levelFor("client.gc"))
+	require.Equal(t, log.Trace, s.levelFor("client.alloc_runner.task_runner"))
+}
@@ -29,6 +29,28 @@ func (s *HTTPServer) NamespacesRequest(resp http.ResponseWriter, req *http.Reque
 	return out.Namespaces, nil
 }
 
+func (s *HTTPServer) NamespacesUsageRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.NamespaceListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.NamespaceUsageResponse
+	if err := s.agent.RPC("Namespace.ListNamespaceUsage", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Namespaces == nil {
+		out.Namespaces = make([]*structs.NamespaceUsage, 0)
+	}
+	return out, nil
+}
+
 func (s *HTTPServer) NamespaceSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	name := strings.TrimPrefix(req.URL.Path, "/v1/namespace/")
 	if len(name) == 0 {
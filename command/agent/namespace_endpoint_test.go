@@ -44,6 +44,29 @@ func TestHTTP_NamespaceList(t *testing.T) {
 	})
 }
 
+func TestHTTP_NamespaceUsage(t *testing.T) {
+	ci.Parallel(t)
+	assert := assert.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/namespaces/usage", nil)
+		assert.Nil(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.NamespacesUsageRequest(respW, req)
+		assert.Nil(err)
+
+		// Check for the index
+		assert.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// Check the output contains the default namespace
+		out := obj.(structs.NamespaceUsageResponse)
+		assert.Len(out.Namespaces, 1)
+		assert.Equal(structs.DefaultNamespace, out.Namespaces[0].Namespace)
+	})
+}
+
 func TestHTTP_NamespaceQuery(t *testing.T) {
 	ci.Parallel(t)
 	assert := assert.New(t)
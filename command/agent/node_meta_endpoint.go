@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) NodeMetaRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	switch req.Method {
+	case "GET":
+		return s.nodeMetaRead(resp, req)
+	case "PUT", "POST":
+		return s.nodeMetaApply(resp, req)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) nodeMetaRead(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	// Get the requested Node ID
+	requestedNode := req.URL.Query().Get("node_id")
+
+	// Build the request and parse the ACL token
+	args := structs.NodeSpecificRequest{
+		NodeID: requestedNode,
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	// Determine the handler to use
+	useLocalClient, useClientRPC, useServerRPC := s.rpcHandlerForNode(requestedNode)
+
+	// Make the RPC
+	var reply structs.NodeMetaResponse
+	var rpcErr error
+	if useLocalClient {
+		rpcErr = s.agent.Client().ClientRPC("NodeMeta.Read", &args, &reply)
+	} else if useClientRPC {
+		rpcErr = s.agent.Client().RPC("NodeMeta.Read", &args, &reply)
+	} else if useServerRPC {
+		rpcErr = s.agent.Server().RPC("NodeMeta.Read", &args, &reply)
+	} else {
+		rpcErr = CodedError(400, "No local Node and node_id not provided")
+	}
+
+	if rpcErr != nil {
+		return nil, nodeMetaRPCError(rpcErr)
+	}
+
+	setIndex(resp, reply.Index)
+	return reply, nil
+}
+
+func (s *HTTPServer) nodeMetaApply(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.NodeMetaApplyRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	// The node may be given as a query parameter or in the body
+	if args.NodeID == "" {
+		args.NodeID = req.URL.Query().Get("node_id")
+	}
+	s.parse(resp, req, &args.QueryOptions.Region, &args.QueryOptions)
+
+	if err := args.Validate(); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	// Determine the handler to use
+	useLocalClient, useClientRPC, useServerRPC := s.rpcHandlerForNode(args.NodeID)
+
+	// Make the RPC
+	var reply structs.NodeMetaResponse
+	var rpcErr error
+	if useLocalClient {
+		rpcErr = s.agent.Client().ClientRPC("NodeMeta.Apply", &args, &reply)
+	} else if useClientRPC {
+		rpcErr = s.agent.Client().RPC("NodeMeta.Apply", &args, &reply)
+	} else if useServerRPC {
+		rpcErr = s.agent.Server().RPC("NodeMeta.Apply", &args, &reply)
+	} else {
+		rpcErr = CodedError(400, "No local Node and node_id not provided")
+	}
+
+	if rpcErr != nil {
+		return nil, nodeMetaRPCError(rpcErr)
+	}
+
+	setIndex(resp, reply.Index)
+	return reply, nil
+}
+
+// nodeMetaRPCError converts errors of the NodeMeta RPCs for missing or
+// unreachable nodes to 404s.
+func nodeMetaRPCError(err error) error {
+	if structs.IsErrNoNodeConn(err) || strings.Contains(err.Error(), "Unknown node") {
+		return CodedError(404, err.Error())
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+package agent
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_NodeMetaRequest(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+
+		// Set metadata on the local node
+		{
+			body := bytes.NewBufferString(`{"Meta": {"zone": "z1"}}`)
+			req, err := http.NewRequest("PUT", "/v1/client/metadata", body)
+			require.NoError(err)
+
+			respW := httptest.NewRecorder()
+			obj, err := s.Server.NodeMetaRequest(respW, req)
+			require.NoError(err)
+
+			resp := obj.(structs.NodeMetaResponse)
+			require.Equal("z1", resp.Meta["zone"])
+		}
+
+		// Read it back
+		{
+			req, err := http.NewRequest("GET", "/v1/client/metadata", nil)
+			require.NoError(err)
+
+			respW := httptest.NewRecorder()
+			obj, err := s.Server.NodeMetaRequest(respW, req)
+			require.NoError(err)
+
+			resp := obj.(structs.NodeMetaResponse)
+			require.Equal("z1", resp.Meta["zone"])
+			require.Equal("z1", *resp.Dynamic["zone"])
+			require.Equal(strconv.FormatUint(resp.Index, 10), respW.Header().Get("X-Nomad-Index"))
+		}
+
+		// Blocking reads wait for a change of the metadata
+		{
+			req, err := http.NewRequest("GET", "/v1/client/metadata", nil)
+			require.NoError(err)
+			respW := httptest.NewRecorder()
+			obj, err := s.Server.NodeMetaRequest(respW, req)
+			require.NoError(err)
+			index := obj.(structs.NodeMetaResponse).Index
+
+			go func() {
+				time.Sleep(100 * time.Millisecond)
+				body := bytes.NewBufferString(`{"Meta": {"zone": "z2"}}`)
+				req, _ := http.NewRequest("PUT", "/v1/client/metadata", body)
+				if _, err := s.Server.NodeMetaRequest(httptest.NewRecorder(), req); err != nil {
+					t.Errorf("failed to apply metadata: %v", err)
+				}
+			}()
+
+			req, err = http.NewRequest("GET", fmt.Sprintf("/v1/client/metadata?index=%d&wait=5s", index), nil)
+			require.NoError(err)
+			respW = httptest.NewRecorder()
+			obj, err = s.Server.NodeMetaRequest(respW, req)
+			require.NoError(err)
+
+			resp := obj.(structs.NodeMetaResponse)
+			require.Equal("z2", resp.Meta["zone"])
+			require.Greater(resp.Index, index)
+		}
+
+		// Invalid keys are rejected
+		{
+			body := bytes.NewBufferString(`{"Meta": {"${zone}": "z1"}}`)
+			req, err := http.NewRequest("PUT", "/v1/client/metadata", body)
+			require.NoError(err)
+
+			respW := httptest.NewRecorder()
+			_, err = s.Server.NodeMetaRequest(respW, req)
+			require.Error(err)
+			require.Equal(400, err.(HTTPCodedError).Code())
+		}
+
+		// Unknown nodes return a 404
+		{
+			req, err := http.NewRequest("GET", fmt.Sprintf("/v1/client/metadata?node_id=%s", uuid.Generate()), nil)
+			require.NoError(err)
+
+			respW := httptest.NewRecorder()
+			_, err = s.Server.NodeMetaRequest(respW, req)
+			require.Error(err)
+			require.Equal(404, err.(HTTPCodedError).Code())
+		}
+	})
+}
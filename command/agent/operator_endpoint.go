@@ -262,6 +262,7 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 		SchedulerAlgorithm:            structs.SchedulerAlgorithm(conf.SchedulerAlgorithm),
 		MemoryOversubscriptionEnabled: conf.MemoryOversubscriptionEnabled,
 		RejectJobRegistration:         conf.RejectJobRegistration,
+		EvalTracingEnabled:            conf.EvalTracingEnabled,
 		PreemptionConfig: structs.PreemptionConfig{
 			SystemSchedulerEnabled:   conf.PreemptionConfig.SystemSchedulerEnabled,
 			SysBatchSchedulerEnabled: conf.PreemptionConfig.SysBatchSchedulerEnabled,
@@ -292,6 +293,97 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 	return reply, nil
 }
 
+// OperatorSchedulerQueues is used to inspect the queue depth of each namespace
+// in the eval broker.
+func (s *HTTPServer) OperatorSchedulerQueues(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.GenericRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.SchedulerQueuesResponse
+	if err := s.agent.RPC("Operator.SchedulerGetQueues", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply, nil
+}
+
+// OperatorSchedulerFragmentation is used to inspect how fragmented the free
+// capacity of the nodes in each datacenter is.
+func (s *HTTPServer) OperatorSchedulerFragmentation(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.SchedulerFragmentationRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	if suggest := req.URL.Query().Get("suggest"); suggest != "" {
+		var err error
+		if args.Suggest, err = strconv.ParseBool(suggest); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("Failed to parse suggest parameter: %v", err))
+		}
+	}
+
+	var reply structs.SchedulerFragmentationResponse
+	if err := s.agent.RPC("Operator.SchedulerGetFragmentation", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply, nil
+}
+
+// OperatorWorkloadIdentityKeys is used to list the keys signing workload
+// identities, such that third parties can pin the keys they trust.
+func (s *HTTPServer) OperatorWorkloadIdentityKeys(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.GenericRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.WorkloadIdentityKeyListResponse
+	if err := s.agent.RPC("Operator.WorkloadIdentityKeyList", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	if reply.Keys == nil {
+		reply.Keys = make([]*structs.WorkloadIdentityKeyStub, 0)
+	}
+	return reply.Keys, nil
+}
+
+// OperatorWorkloadIdentityKeyRotate is used to rotate the keys signing
+// workload identities ahead of the rotation interval.
+func (s *HTTPServer) OperatorWorkloadIdentityKeyRotate(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.WorkloadIdentityKeyRotateRequest
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var reply structs.WorkloadIdentityKeyRotateResponse
+	if err := s.agent.RPC("Operator.WorkloadIdentityKeyRotate", &args, &reply); err != nil {
+		return nil, err
+	}
+	setIndex(resp, reply.Index)
+	return reply, nil
+}
+
 func (s *HTTPServer) SnapshotRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	switch req.Method {
 	case "GET":
@@ -292,6 +292,90 @@ func TestOperator_SchedulerGetConfiguration(t *testing.T) {
 	})
 }
 
+func TestOperator_SchedulerGetQueues(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		req, err := http.NewRequest("GET", "/v1/operator/scheduler/queues", nil)
+		require.NoError(t, err)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorSchedulerQueues(resp, req)
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.Code)
+		require.NotEmpty(t, resp.Header().Get("X-Nomad-Index"))
+
+		out, ok := obj.(structs.SchedulerQueuesResponse)
+		require.True(t, ok)
+		require.NotNil(t, out.Namespaces)
+	})
+}
+
+func TestOperator_SchedulerGetFragmentation(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		req, err := http.NewRequest("GET", "/v1/operator/scheduler/fragmentation?suggest=true", nil)
+		require.NoError(t, err)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorSchedulerFragmentation(resp, req)
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.Code)
+		require.NotEmpty(t, resp.Header().Get("X-Nomad-Index"))
+
+		out, ok := obj.(structs.SchedulerFragmentationResponse)
+		require.True(t, ok)
+		require.NotNil(t, out.Datacenters)
+
+		// Invalid suggest parameter
+		req, err = http.NewRequest("GET", "/v1/operator/scheduler/fragmentation?suggest=maybe", nil)
+		require.NoError(t, err)
+		_, err = s.Server.OperatorSchedulerFragmentation(httptest.NewRecorder(), req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Failed to parse suggest parameter")
+	})
+}
+
+func TestOperator_WorkloadIdentityKeys(t *testing.T) {
+	ci.Parallel(t)
+	cb := func(c *Config) {
+		c.Server.WorkloadIdentity = &WorkloadIdentity{
+			Issuer: "https://nomad.example.com",
+		}
+	}
+	httpTest(t, cb, func(s *TestAgent) {
+		list := func() []*structs.WorkloadIdentityKeyStub {
+			req, err := http.NewRequest("GET", "/v1/operator/workload-identity/keys", nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			obj, err := s.Server.OperatorWorkloadIdentityKeys(resp, req)
+			require.NoError(t, err)
+			require.NotEmpty(t, resp.Header().Get("X-Nomad-Index"))
+			return obj.([]*structs.WorkloadIdentityKeyStub)
+		}
+
+		// The leader bootstraps the active and the next key
+		retry.Run(t, func(r *retry.R) {
+			if keys := list(); len(keys) != 2 {
+				r.Fatalf("expected 2 keys, got %d", len(keys))
+			}
+		})
+		next := list()[1]
+
+		req, err := http.NewRequest("PUT", "/v1/operator/workload-identity/rotate", nil)
+		require.NoError(t, err)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorWorkloadIdentityKeyRotate(resp, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.Header().Get("X-Nomad-Index"))
+		out, ok := obj.(structs.WorkloadIdentityKeyRotateResponse)
+		require.True(t, ok)
+		require.Equal(t, next.KeyID, out.KeyID)
+
+		keys := list()
+		require.Len(t, keys, 3)
+		require.Equal(t, structs.WorkloadIdentityKeyStateRetired, keys[0].State)
+		require.Equal(t, structs.WorkloadIdentityKeyStateActive, keys[1].State)
+	})
+}
+
 func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
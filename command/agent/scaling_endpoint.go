@@ -12,6 +12,8 @@ func (s *HTTPServer) ScalingPoliciesRequest(resp http.ResponseWriter, req *http.
 	switch req.Method {
 	case "GET":
 		return s.scalingPoliciesListRequest(resp, req)
+	case "PUT", "POST":
+		return s.scalingPolicyUpsert(resp, req, "")
 	default:
 		return nil, CodedError(405, ErrInvalidMethod)
 	}
@@ -41,6 +43,31 @@ func (s *HTTPServer) scalingPoliciesListRequest(resp http.ResponseWriter, req *h
 	return out.Policies, nil
 }
 
+func (s *HTTPServer) ScheduledScalingActionsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.ScheduledScalingActionListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+	if job := req.URL.Query().Get("job"); job != "" {
+		args.Job = job
+	}
+
+	var out structs.ScheduledScalingActionListResponse
+	if err := s.agent.RPC("Scaling.ListScheduledActions", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Actions == nil {
+		out.Actions = make([]*structs.ScheduledScalingAction, 0)
+	}
+	return out.Actions, nil
+}
+
 func (s *HTTPServer) ScalingPolicySpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	path := strings.TrimPrefix(req.URL.Path, "/v1/scaling/policy/")
 	return s.scalingPolicyCRUD(resp, req, path)
@@ -51,6 +78,10 @@ func (s *HTTPServer) scalingPolicyCRUD(resp http.ResponseWriter, req *http.Reque
 	switch req.Method {
 	case "GET":
 		return s.scalingPolicyQuery(resp, req, policyID)
+	case "PUT", "POST":
+		return s.scalingPolicyUpsert(resp, req, policyID)
+	case "DELETE":
+		return s.scalingPolicyDelete(resp, req, policyID)
 	default:
 		return nil, CodedError(405, ErrInvalidMethod)
 	}
@@ -78,6 +109,63 @@ func (s *HTTPServer) scalingPolicyQuery(resp http.ResponseWriter, req *http.Requ
 	return out.Policy, nil
 }
 
+func (s *HTTPServer) scalingPolicyUpsert(resp http.ResponseWriter, req *http.Request,
+	policyID string) (interface{}, error) {
+
+	var policy api.ScalingPolicy
+	if err := decodeBody(req, &policy); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+
+	// Ensure the policy ID matches
+	if policyID != "" {
+		if policy.ID == "" {
+			policy.ID = policyID
+		} else if policy.ID != policyID {
+			return nil, CodedError(400, "Scaling policy ID does not match request path")
+		}
+	}
+
+	args := structs.ScalingPolicyUpsertRequest{
+		Policy: ApiStandaloneScalingPolicyToStructs(&policy),
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.ScalingPolicyUpsertResponse
+	if err := s.agent.RPC("Scaling.UpsertPolicy", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
+func (s *HTTPServer) scalingPolicyDelete(resp http.ResponseWriter, req *http.Request,
+	policyID string) (interface{}, error) {
+
+	args := structs.ScalingPolicyDeleteRequest{
+		ID: policyID,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.GenericResponse
+	if err := s.agent.RPC("Scaling.DeletePolicy", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return nil, nil
+}
+
+// ApiStandaloneScalingPolicyToStructs converts a standalone scaling policy,
+// which isn't part of a job and so sets its own ID and target.
+func ApiStandaloneScalingPolicyToStructs(ap *api.ScalingPolicy) *structs.ScalingPolicy {
+	p := ApiScalingPolicyToStructs(0, ap)
+	p.ID = ap.ID
+	for k, v := range ap.Target {
+		p.Target[k] = v
+	}
+	return p
+}
+
 func ApiScalingPolicyToStructs(count int, ap *api.ScalingPolicy) *structs.ScalingPolicy {
 	p := structs.ScalingPolicy{
 		Type:   ap.Type,
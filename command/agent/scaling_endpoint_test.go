@@ -4,8 +4,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/stretchr/testify/require"
@@ -99,6 +102,62 @@ func TestHTTP_ScalingPoliciesList_Filter(t *testing.T) {
 	})
 }
 
+func TestHTTP_ScheduledScalingActionsList(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		var jobs []*structs.Job
+		for i := 0; i < 2; i++ {
+			job := mock.Job()
+			jobs = append(jobs, job)
+			args := structs.JobRegisterRequest{
+				Job: job,
+				WriteRequest: structs.WriteRequest{
+					Region:    "global",
+					Namespace: structs.DefaultNamespace,
+				},
+			}
+			var resp structs.JobRegisterResponse
+			require.NoError(s.Agent.RPC("Job.Register", &args, &resp))
+
+			// Schedule a count change of the job
+			scale := structs.JobScaleRequest{
+				JobID: job.ID,
+				Target: map[string]string{
+					structs.ScalingTargetGroup: job.TaskGroups[0].Name,
+				},
+				Count:      helper.Int64ToPtr(5),
+				ScheduleAt: time.Now().Add(time.Hour),
+				WriteRequest: structs.WriteRequest{
+					Region:    "global",
+					Namespace: structs.DefaultNamespace,
+				},
+			}
+			require.NoError(s.Agent.RPC("Job.Scale", &scale, &resp))
+		}
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/scaling/actions", nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.ScheduledScalingActionsRequest(respW, req)
+		require.NoError(err)
+		require.NotEmpty(respW.Header().Get("X-Nomad-Index"), "missing index")
+		require.Len(obj.([]*structs.ScheduledScalingAction), 2)
+
+		// Filter by job
+		req, err = http.NewRequest("GET", "/v1/scaling/actions?job="+jobs[0].ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.ScheduledScalingActionsRequest(respW, req)
+		require.NoError(err)
+		actions := obj.([]*structs.ScheduledScalingAction)
+		require.Len(actions, 1)
+		require.Equal(jobs[0].ID, actions[0].JobID)
+		require.EqualValues(5, actions[0].Count)
+	})
+}
+
 func TestHTTP_ScalingPolicyGet(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -134,3 +193,73 @@ func TestHTTP_ScalingPolicyGet(t *testing.T) {
 		require.Equal(p.ID, obj.(*structs.ScalingPolicy).ID)
 	})
 }
+
+func TestHTTP_ScalingPolicyUpsertDelete(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create the job
+		job := mock.Job()
+		args := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		require.NoError(s.Agent.RPC("Job.Register", &args, &resp))
+
+		// Create a standalone policy targeting its group
+		policy := &api.ScalingPolicy{
+			Max: helper.Int64ToPtr(5),
+			Target: map[string]string{
+				"Job":   job.ID,
+				"Group": job.TaskGroups[0].Name,
+			},
+		}
+		buf := encodeReq(policy)
+		req, err := http.NewRequest("PUT", "/v1/scaling/policies", buf)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.ScalingPoliciesRequest(respW, req)
+		require.NoError(err)
+		require.NotEmpty(respW.Header().Get("X-Nomad-Index"), "missing index")
+		id := obj.(structs.ScalingPolicyUpsertResponse).ID
+		require.NotEmpty(id)
+
+		out, err := s.Agent.server.State().ScalingPolicyByID(nil, id)
+		require.NoError(err)
+		require.NotNil(out)
+		require.True(out.Standalone)
+		require.True(out.Enabled)
+		require.EqualValues(0, out.Min)
+		require.EqualValues(5, out.Max)
+
+		// Update it by ID
+		policy.Max = helper.Int64ToPtr(10)
+		buf = encodeReq(policy)
+		req, err = http.NewRequest("PUT", "/v1/scaling/policy/"+id, buf)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.ScalingPolicySpecificRequest(respW, req)
+		require.NoError(err)
+
+		out, err = s.Agent.server.State().ScalingPolicyByID(nil, id)
+		require.NoError(err)
+		require.EqualValues(10, out.Max)
+
+		// Delete it
+		req, err = http.NewRequest("DELETE", "/v1/scaling/policy/"+id, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.ScalingPolicySpecificRequest(respW, req)
+		require.NoError(err)
+		require.NotEmpty(respW.Header().Get("X-Nomad-Index"), "missing index")
+
+		out, err = s.Agent.server.State().ScalingPolicyByID(nil, id)
+		require.NoError(err)
+		require.Nil(out)
+	})
+}
@@ -1,9 +1,14 @@
-
 client {
   enabled = true
 
   template {
     disable_file_sandbox = true
     function_denylist    = []
+
+    plugin_function "externalSecret" {
+      command = "/usr/local/bin/external-secret"
+      args    = ["--role", "nomad"]
+      jobs    = ["default/web"]
+    }
   }
 }
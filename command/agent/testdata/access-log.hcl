@@ -0,0 +1,8 @@
+access_log {
+  enabled          = true
+  path             = "/var/log/nomad/"
+  rotate_duration  = "1h"
+  rotate_bytes     = 1048576
+  rotate_max_files = 5
+  http_address     = "https://collector.example.com/ingest"
+}
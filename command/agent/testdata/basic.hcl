@@ -92,13 +92,46 @@ client {
   no_host_uuid             = false
   disable_remote_exec      = true
 
+  ephemeral_disk_enforcement = "hard"
+
   host_volume "tmp" {
     path = "/tmp"
   }
 
+  artifact_fetcher "corp" {
+    command = "/usr/local/bin/corp-fetch"
+    args    = ["--region", "eu"]
+    schemes = ["corp"]
+    timeout = "5m"
+    user    = "corp-fetch"
+  }
+
+  oci_registry "registry.example.com" {
+    username = "nomad"
+    password = "hunter2"
+  }
+
+  envoy_version "1.22.2" {
+    consul = ">= 1.12.0, < 1.13.0"
+  }
+
+  envoy_version "1.20.4" {
+    consul = "< 1.12.0"
+  }
+
+  artifact_cache {
+    enabled     = true
+    max_size_mb = 2048
+  }
+
   cni_path              = "/tmp/cni_path"
   bridge_network_name   = "custom_bridge_name"
   bridge_network_subnet = "custom_bridge_subnet"
+
+  bridge_network_firewall {
+    enabled    = true
+    deny_cidrs = ["10.0.0.0/8"]
+  }
 }
 
 server {
@@ -120,6 +153,7 @@ server {
   min_heartbeat_ttl             = "33s"
   max_heartbeats_per_second     = 11.0
   failover_heartbeat_ttl        = "330s"
+  max_stale                     = "5s"
   retry_join                    = ["1.1.1.1", "2.2.2.2"]
   start_join                    = ["1.1.1.1", "2.2.2.2"]
   retry_max                     = 3
@@ -241,6 +275,12 @@ vault {
   tls_server_name       = "foobar"
   tls_skip_verify       = true
   create_from_role      = "test_role"
+
+  cluster "team" {
+    address          = "https://team-vault.example.com:8200"
+    token            = "67890"
+    create_from_role = "team_role"
+  }
 }
 
 tls {
@@ -0,0 +1,16 @@
+event_sink {
+  enabled      = true
+  type         = "nats"
+  address      = "nats://nats.example.com:4222"
+  username     = "nomad"
+  password     = "secret"
+  tls          = true
+  ca_file      = "/etc/nats/ca.pem"
+  topic        = "nomad.{{ .Namespace }}.{{ .Topic }}"
+  event_topics = ["Job", "Allocation:example"]
+  namespace    = "prod"
+  consumer     = "nats-sink"
+  buffer_size  = 4096
+  batch_size   = 200
+  on_full      = "drop"
+}
@@ -0,0 +1,7 @@
+server {
+  node_scorer {
+    command = "/usr/local/bin/score"
+    args    = ["-region", "eu"]
+    timeout = "250ms"
+  }
+}
@@ -0,0 +1,9 @@
+server {
+  enabled = true
+
+  scoring_plugin "cost" {
+    weight = 2
+  }
+
+  scoring_plugin "power" {}
+}
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/hashicorp/nomad/api"
+	agentmonitor "github.com/hashicorp/nomad/command/agent/monitor"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
 	"github.com/mitchellh/cli"
 )
 
@@ -39,6 +41,13 @@ Monitor Specific Options:
   -log-level <level>
     Sets the log level to monitor (default: INFO)
 
+  -log-level-override <subsystem=level>
+    Sets the log level to monitor for a subsystem, overriding -log-level for
+    logs from that subsystem. A subsystem matches any part of a logger's
+    name, such as "raft" or "client.alloc_runner". May be specified multiple
+    times, ex. -log-level-override raft=TRACE -log-level-override http=WARN.
+    The agent's configured log level is not changed.
+
   -node-id <node-id>
     Sets the specific node to monitor
 
@@ -46,7 +55,9 @@ Monitor Specific Options:
     Sets the specific server to monitor
 
   -json
-    Sets log output to JSON format
+    Sets log output to JSON format. Each line is a JSON object with the
+    "@level", "@message", "@module" and "@timestamp" of the log along with
+    its structured fields.
   `
 	return strings.TrimSpace(helpText)
 }
@@ -69,6 +80,7 @@ func (c *MonitorCommand) Run(args []string) int {
 	var nodeID string
 	var serverID string
 	var logJSON bool
+	var logLevelOverrides flaghelper.StringFlag
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -76,6 +88,7 @@ func (c *MonitorCommand) Run(args []string) int {
 	flags.StringVar(&nodeID, "node-id", "", "")
 	flags.StringVar(&serverID, "server-id", "", "")
 	flags.BoolVar(&logJSON, "json", false, "")
+	flags.Var(&logLevelOverrides, "log-level-override", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -128,6 +141,14 @@ func (c *MonitorCommand) Run(args []string) int {
 		"server_id": serverID,
 		"log_json":  strconv.FormatBool(logJSON),
 	}
+	if len(logLevelOverrides) > 0 {
+		overrides, err := agentmonitor.ParseLevelOverrides(strings.Join(logLevelOverrides, ","))
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error parsing log level overrides: %s", err))
+			return 1
+		}
+		params["log_level_overrides"] = agentmonitor.FormatLevelOverrides(overrides)
+	}
 
 	query := &api.QueryOptions{
 		Params: params,
@@ -0,0 +1,117 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+)
+
+const (
+	// allocBulkConcurrency is the number of allocations acted on at the same
+	// time by the alloc commands targeting several allocations.
+	allocBulkConcurrency = 8
+)
+
+// allocBulkUsage is the usage of the options shared by the alloc commands that
+// can target several allocations.
+const allocBulkUsage = `  -job <job-id>
+    Target all the running allocations of the job instead of a single
+    allocation. May be combined with -filter.
+
+  -filter <expression>
+    Target all the running allocations matching the filter expression instead
+    of a single allocation. When used with -job, only the allocations of the
+    job are considered.
+`
+
+// resolveBulkAllocs returns the non-terminal allocations targeted by the -job
+// and -filter options of the alloc commands. The allocations and the filter
+// are resolved by the servers in a single query, so they form a consistent
+// set even if allocations are placed or stopped concurrently.
+func resolveBulkAllocs(client *api.Client, jobID, filter string) ([]*api.AllocationListStub, error) {
+	q := &api.QueryOptions{Filter: filter}
+
+	var stubs []*api.AllocationListStub
+	var err error
+	if jobID != "" {
+		stubs, _, err = client.Jobs().Allocations(jobID, false, q)
+	} else {
+		stubs, _, err = client.Allocations().List(q)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	allocs := make([]*api.AllocationListStub, 0, len(stubs))
+	for _, stub := range stubs {
+		if stub.DesiredStatus != api.AllocDesiredStatusRun {
+			continue
+		}
+		if stub.ClientStatus != api.AllocClientStatusPending && stub.ClientStatus != api.AllocClientStatusRunning {
+			continue
+		}
+		allocs = append(allocs, stub)
+	}
+	sort.Slice(allocs, func(i, j int) bool {
+		return allocs[i].ID < allocs[j].ID
+	})
+	return allocs, nil
+}
+
+// runAllocBulkAction runs the action on the allocations, with at most
+// allocBulkConcurrency actions running at the same time, and outputs a summary
+// of the results. The action returns the result to display for successful
+// allocations. It returns the exit code of the command.
+func runAllocBulkAction(ui cli.Ui, allocs []*api.AllocationListStub, length int, verb, pastVerb string,
+	action func(*api.AllocationListStub) (string, error)) int {
+
+	if len(allocs) == 0 {
+		ui.Error("No running allocations matched")
+		return 1
+	}
+
+	ui.Output(fmt.Sprintf("==> %s %d allocation(s)", verb, len(allocs)))
+
+	results := make([]string, len(allocs))
+	errs := make([]error, len(allocs))
+	sem := make(chan struct{}, allocBulkConcurrency)
+	var wg sync.WaitGroup
+	for i, alloc := range allocs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, alloc *api.AllocationListStub) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = action(alloc)
+		}(i, alloc)
+	}
+	wg.Wait()
+
+	failed := 0
+	out := make([]string, len(allocs)+1)
+	out[0] = "ID|Job ID|Task Group|Node ID|Result"
+	for i, alloc := range allocs {
+		result := results[i]
+		if errs[i] != nil {
+			failed++
+			result = fmt.Sprintf("Error: %v", errs[i])
+		}
+		out[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s",
+			limit(alloc.ID, length),
+			alloc.JobID,
+			alloc.TaskGroup,
+			limit(alloc.NodeID, length),
+			result)
+	}
+	ui.Output(formatList(out))
+
+	if failed != 0 {
+		ui.Error(fmt.Sprintf("==> Failed on %d of %d allocation(s)", failed, len(allocs)))
+		return 1
+	}
+	ui.Output(fmt.Sprintf("==> %s %d allocation(s)", pastVerb, len(allocs)))
+	return 0
+}
@@ -16,10 +16,12 @@ type AllocRestartCommand struct {
 func (c *AllocRestartCommand) Help() string {
 	helpText := `
 Usage: nomad alloc restart [options] <allocation> <task>
+       nomad alloc restart [options] -job <job-id> -filter <expression>
 
   Restart an existing allocation. This command is used to restart a specific alloc
   and its tasks. If no task is provided then all of the allocation's tasks will
-  be restarted.
+  be restarted. The -job and -filter options restart all the matching running
+  allocations instead, a few at a time.
 
   When ACLs are enabled, this command requires a token with the
   'alloc-lifecycle', 'read-job', and 'list-jobs' capabilities for the
@@ -31,6 +33,7 @@ General Options:
 
 Restart Specific Options:
 
+` + allocBulkUsage + `
   -task <task-name>
 	Specify the individual task to restart. If task name is given with both an 
 	argument and the '-task' option, preference is given to the '-task' option.
@@ -45,19 +48,30 @@ func (c *AllocRestartCommand) Name() string { return "alloc restart" }
 
 func (c *AllocRestartCommand) Run(args []string) int {
 	var verbose bool
-	var task string
+	var task, jobID, filter string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.StringVar(&task, "task", "", "")
+	flags.StringVar(&jobID, "job", "", "")
+	flags.StringVar(&filter, "filter", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
 
-	// Check that we got exactly one alloc
 	args = flags.Args()
+	if jobID != "" || filter != "" {
+		if len(args) != 0 {
+			c.Ui.Error("This command takes no arguments when -job or -filter is set")
+			c.Ui.Error(commandErrorText(c))
+			return 1
+		}
+		return c.runBulk(jobID, filter, task, verbose)
+	}
+
+	// Check that we got exactly one alloc
 	if len(args) < 1 || len(args) > 2 {
 		c.Ui.Error("This command takes one or two arguments: <alloc-id> <task-name>")
 		c.Ui.Error(commandErrorText(c))
@@ -136,6 +150,35 @@ func (c *AllocRestartCommand) Run(args []string) int {
 	return 0
 }
 
+// runBulk restarts all the allocations matching the job and filter.
+func (c *AllocRestartCommand) runBulk(jobID, filter, task string, verbose bool) int {
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	allocs, err := resolveBulkAllocs(client, jobID, filter)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocations: %s", err))
+		return 1
+	}
+
+	return runAllocBulkAction(c.Ui, allocs, length, "Restarting", "Restarted",
+		func(stub *api.AllocationListStub) (string, error) {
+			q := &api.QueryOptions{Namespace: stub.Namespace}
+			if err := client.Allocations().Restart(&api.Allocation{ID: stub.ID}, task, q); err != nil {
+				return "", err
+			}
+			return "Restarted", nil
+		})
+}
+
 func validateTaskExistsInAllocation(taskName string, alloc *api.Allocation) error {
 	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
 	if tg == nil {
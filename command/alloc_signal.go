@@ -16,10 +16,12 @@ type AllocSignalCommand struct {
 func (c *AllocSignalCommand) Help() string {
 	helpText := `
 Usage: nomad alloc signal [options] <allocation> <task>
+       nomad alloc signal [options] -job <job-id> -filter <expression>
 
   Signal an existing allocation. This command is used to signal a specific alloc
   and its subtasks. If no task is provided then all of the allocations subtasks
-  will receive the signal.
+  will receive the signal. The -job and -filter options signal all the matching
+  running allocations instead, a few at a time.
 
   When ACLs are enabled, this command requires a token with the
   'alloc-lifecycle', 'read-job', and 'list-jobs' capabilities for the
@@ -31,6 +33,7 @@ General Options:
 
 Signal Specific Options:
 
+` + allocBulkUsage + `
   -s
     Specify the signal that the selected tasks should receive. Defaults to SIGKILL.
 
@@ -49,20 +52,31 @@ func (c *AllocSignalCommand) Name() string { return "alloc signal" }
 
 func (c *AllocSignalCommand) Run(args []string) int {
 	var verbose bool
-	var signal, task string
+	var signal, task, jobID, filter string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.StringVar(&signal, "s", "SIGKILL", "")
 	flags.StringVar(&task, "task", "", "")
+	flags.StringVar(&jobID, "job", "", "")
+	flags.StringVar(&filter, "filter", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
 
-	// Check that we got exactly one alloc
 	args = flags.Args()
+	if jobID != "" || filter != "" {
+		if len(args) != 0 {
+			c.Ui.Error("This command takes no arguments when -job or -filter is set")
+			c.Ui.Error(commandErrorText(c))
+			return 1
+		}
+		return c.runBulk(jobID, filter, task, signal, verbose)
+	}
+
+	// Check that we got exactly one alloc
 	if len(args) < 1 || len(args) > 2 {
 		c.Ui.Error("This command takes up to two arguments: <alloc-id> <task>")
 		c.Ui.Error(commandErrorText(c))
@@ -141,6 +155,35 @@ func (c *AllocSignalCommand) Run(args []string) int {
 	return 0
 }
 
+// runBulk signals all the allocations matching the job and filter.
+func (c *AllocSignalCommand) runBulk(jobID, filter, task, signal string, verbose bool) int {
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	allocs, err := resolveBulkAllocs(client, jobID, filter)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocations: %s", err))
+		return 1
+	}
+
+	return runAllocBulkAction(c.Ui, allocs, length, "Signaling", "Signaled",
+		func(stub *api.AllocationListStub) (string, error) {
+			q := &api.QueryOptions{Namespace: stub.Namespace}
+			if err := client.Allocations().Signal(&api.Allocation{ID: stub.ID}, q, task, signal); err != nil {
+				return "", err
+			}
+			return "Signaled", nil
+		})
+}
+
 func (c *AllocSignalCommand) Synopsis() string {
 	return "Signal a running allocation"
 }
@@ -150,6 +193,8 @@ func (c *AllocSignalCommand) AutocompleteFlags() complete.Flags {
 		complete.Flags{
 			"-s":       complete.PredictNothing,
 			"-verbose": complete.PredictNothing,
+			"-job":     complete.PredictAnything,
+			"-filter":  complete.PredictAnything,
 		})
 }
 func (c *AllocSignalCommand) AutocompleteArgs() complete.Predictor {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
@@ -55,6 +56,21 @@ func TestAllocSignalCommand_Fails(t *testing.T) {
 	require.Equal(1, cmd.Run([]string{"-address=" + url, "2"}))
 	require.Contains(ui.ErrorWriter.String(), "must contain at least two characters.")
 	ui.ErrorWriter.Reset()
+
+	// Fails on alloc ID with -job
+	require.Equal(1, cmd.Run([]string{"-address=" + url, "-job=example", "26470238"}))
+	require.Contains(ui.ErrorWriter.String(), "takes no arguments when -job or -filter is set")
+	ui.ErrorWriter.Reset()
+
+	// Fails when no allocation matches
+	require.Equal(1, cmd.Run([]string{"-address=" + url, "-job=example"}))
+	require.Contains(ui.ErrorWriter.String(), "No running allocations matched")
+	ui.ErrorWriter.Reset()
+
+	// Fails on invalid filter
+	require.Equal(1, cmd.Run([]string{"-address=" + url, "-filter=ClientStatus =="}))
+	require.Contains(ui.ErrorWriter.String(), "Error querying allocations")
+	ui.ErrorWriter.Reset()
 }
 
 func TestAllocSignalCommand_AutocompleteArgs(t *testing.T) {
@@ -145,3 +161,72 @@ func TestAllocSignalCommand_Run(t *testing.T) {
 
 	ui.OutputWriter.Reset()
 }
+
+func TestAllocSignalCommand_Run_Bulk(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Wait for a node to be ready
+	testutil.WaitForResult(func() (bool, error) {
+		nodes, _, err := client.Nodes().List(nil)
+		if err != nil {
+			return false, err
+		}
+		for _, node := range nodes {
+			if _, ok := node.Drivers["mock_driver"]; ok &&
+				node.Status == structs.NodeStatusReady {
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("no ready nodes")
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	ui := cli.NewMockUi()
+	cmd := &AllocSignalCommand{Meta: Meta{Ui: ui}}
+
+	jobID := "job1_bulk"
+	job := testJob(jobID)
+	job.TaskGroups[0].Count = helper.IntToPtr(2)
+	resp, _, err := client.Jobs().Register(job, nil)
+	require.NoError(t, err)
+	if code := waitForSuccess(ui, client, fullId, t, resp.EvalID); code != 0 {
+		t.Fatalf("status code non zero saw %d", code)
+	}
+
+	// Wait for the allocs to be running
+	testutil.WaitForResult(func() (bool, error) {
+		allocs, _, err := client.Jobs().Allocations(jobID, false, nil)
+		if err != nil {
+			return false, err
+		}
+		if len(allocs) != 2 {
+			return false, fmt.Errorf("expected 2 allocs, got %d", len(allocs))
+		}
+		for _, alloc := range allocs {
+			if alloc.ClientStatus != api.AllocClientStatusRunning {
+				return false, fmt.Errorf("alloc is not running, is: %s", alloc.ClientStatus)
+			}
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+	ui.OutputWriter.Reset()
+
+	// Signal all the allocations of the job
+	code := cmd.Run([]string{"-address=" + url, "-job=" + jobID, "-s=SIGUSR1"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "Signaling 2 allocation(s)")
+	require.Contains(t, out, "Signaled 2 allocation(s)")
+	ui.OutputWriter.Reset()
+
+	// Only signal the allocations matching the filter
+	code = cmd.Run([]string{"-address=" + url, "-job=" + jobID, "-s=SIGUSR1", `-filter=Name contains "[1]"`})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Signaled 1 allocation(s)")
+}
@@ -286,12 +286,24 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 				}
 			}
 
+			if alloc.DeploymentStatus.Manual {
+				health += " (set manually)"
+			}
+
 			canary = alloc.DeploymentStatus.Canary
 		}
 
 		basic = append(basic,
 			fmt.Sprintf("Deployment ID|%s", limit(alloc.DeploymentID, uuidLength)),
 			fmt.Sprintf("Deployment Health|%s", health))
+		if ds := alloc.DeploymentStatus; ds != nil && ds.Manual {
+			if ds.Reason != "" {
+				basic = append(basic, fmt.Sprintf("Deployment Health Reason|%s", ds.Reason))
+			}
+			if ds.SetBy != "" {
+				basic = append(basic, fmt.Sprintf("Deployment Health Set By|%s", limit(ds.SetBy, uuidLength)))
+			}
+		}
 		if canary {
 			basic = append(basic, fmt.Sprintf("Canary|%v", true))
 		}
@@ -412,6 +424,9 @@ func (c *AllocStatusCommand) outputTaskStatus(state *api.TaskState) {
 		fmt.Sprintf("Finished At|%s", formatTaskTimes(state.FinishedAt)),
 		fmt.Sprintf("Total Restarts|%d", state.Restarts),
 		fmt.Sprintf("Last Restart|%s", formatTaskTimes(state.LastRestart))}
+	if state.DriverStatus != "" {
+		basic = append(basic, fmt.Sprintf("Driver Status|%s", state.DriverStatus))
+	}
 
 	c.Ui.Output("Task Events:")
 	c.Ui.Output(formatKV(basic))
@@ -14,13 +14,15 @@ type AllocStopCommand struct {
 func (c *AllocStopCommand) Help() string {
 	helpText := `
 Usage: nomad alloc stop [options] <allocation>
+       nomad alloc stop [options] -job <job-id> -filter <expression>
 Alias: nomad stop
 
   Stop an existing allocation. This command is used to signal a specific alloc
   to shut down. When the allocation has been shut down, it will then be
   rescheduled. An interactive monitoring session will display log lines as the
   allocation completes shutting down. It is safe to exit the monitor early with
-  ctrl-c.
+  ctrl-c. The -job and -filter options stop all the matching running
+  allocations instead, a few at a time, without monitoring them.
 
   When ACLs are enabled, this command requires a token with the
   'alloc-lifecycle', 'read-job', and 'list-jobs' capabilities for the
@@ -32,6 +34,7 @@ General Options:
 
 Stop Specific Options:
 
+` + allocBulkUsage + `
   -detach
     Return immediately instead of entering monitor mode. After the
     stop command is submitted, a new evaluation ID is printed to the
@@ -54,19 +57,31 @@ func (c *AllocStopCommand) Name() string { return "alloc stop" }
 
 func (c *AllocStopCommand) Run(args []string) int {
 	var detach, verbose, noShutdownDelay bool
+	var jobID, filter string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&detach, "detach", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.BoolVar(&noShutdownDelay, "no-shutdown-delay", false, "")
+	flags.StringVar(&jobID, "job", "", "")
+	flags.StringVar(&filter, "filter", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
 
-	// Check that we got exactly one alloc
 	args = flags.Args()
+	if jobID != "" || filter != "" {
+		if len(args) != 0 {
+			c.Ui.Error("This command takes no arguments when -job or -filter is set")
+			c.Ui.Error(commandErrorText(c))
+			return 1
+		}
+		return c.runBulk(jobID, filter, noShutdownDelay, verbose)
+	}
+
+	// Check that we got exactly one alloc
 	if len(args) != 1 {
 		c.Ui.Error("This command takes one argument: <alloc-id>")
 		c.Ui.Error(commandErrorText(c))
@@ -142,6 +157,39 @@ func (c *AllocStopCommand) Run(args []string) int {
 	return mon.monitor(resp.EvalID)
 }
 
+// runBulk stops all the allocations matching the job and filter.
+func (c *AllocStopCommand) runBulk(jobID, filter string, noShutdownDelay, verbose bool) int {
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	allocs, err := resolveBulkAllocs(client, jobID, filter)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocations: %s", err))
+		return 1
+	}
+
+	return runAllocBulkAction(c.Ui, allocs, length, "Stopping", "Stopped",
+		func(stub *api.AllocationListStub) (string, error) {
+			q := &api.QueryOptions{Namespace: stub.Namespace}
+			if noShutdownDelay {
+				q.Params = map[string]string{"no_shutdown_delay": "true"}
+			}
+			resp, err := client.Allocations().Stop(&api.Allocation{ID: stub.ID}, q)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Stopped, evaluation %s", limit(resp.EvalID, length)), nil
+		})
+}
+
 func (c *AllocStopCommand) Synopsis() string {
 	return "Stop and reschedule a running allocation"
 }
@@ -370,6 +370,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job move": func() (cli.Command, error) {
+			return &JobMoveCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job revert": func() (cli.Command, error) {
 			return &JobRevertCommand{
 				Meta: meta,
@@ -400,6 +405,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job tag": func() (cli.Command, error) {
+			return &JobTagCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job validate": func() (cli.Command, error) {
 			return &JobValidateCommand{
 				Meta: meta,
@@ -455,6 +465,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"namespace usage": func() (cli.Command, error) {
+			return &NamespaceUsageCommand{
+				Meta: meta,
+			}, nil
+		},
 		"node": func() (cli.Command, error) {
 			return &NodeCommand{
 				Meta: meta,
@@ -524,6 +539,21 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"operator gossip": func() (cli.Command, error) {
+			return &OperatorGossipCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator gossip keyring": func() (cli.Command, error) {
+			return &OperatorGossipKeyringCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator gossip keyring rotate": func() (cli.Command, error) {
+			return &OperatorGossipKeyringRotateCommand{
+				Meta: meta,
+			}, nil
+		},
 		"operator keygen": func() (cli.Command, error) {
 			return &OperatorKeygenCommand{
 				Meta: meta,
@@ -572,6 +602,17 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 			}, nil
 		},
 
+		"operator scheduler": func() (cli.Command, error) {
+			return &OperatorSchedulerCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator scheduler fragmentation": func() (cli.Command, error) {
+			return &OperatorSchedulerFragmentationCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"operator snapshot": func() (cli.Command, error) {
 			return &OperatorSnapshotCommand{
 				Meta: meta,
@@ -774,6 +815,21 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"setup": func() (cli.Command, error) {
+			return &SetupCommand{
+				Meta: meta,
+			}, nil
+		},
+		"setup consul": func() (cli.Command, error) {
+			return &SetupConsulCommand{
+				Meta: meta,
+			}, nil
+		},
+		"setup vault": func() (cli.Command, error) {
+			return &SetupVaultCommand{
+				Meta: meta,
+			}, nil
+		},
 		"status": func() (cli.Command, error) {
 			return &StatusCommand{
 				Meta: meta,
@@ -35,6 +35,10 @@ Fail Options:
     resume, the evaluation ID will be printed to the screen, which can be used
     to examine the evaluation using the eval-status command.
 
+  -reason
+    The reason for failing the deployment. The reason is recorded on the
+    deployment and included in deployment events and webhook notifications.
+
   -verbose
     Display full information.
 `
@@ -49,6 +53,7 @@ func (c *DeploymentFailCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-detach":  complete.PredictNothing,
+			"-reason":  complete.PredictAnything,
 			"-verbose": complete.PredictNothing,
 		})
 }
@@ -72,11 +77,13 @@ func (c *DeploymentFailCommand) Name() string { return "deployment fail" }
 
 func (c *DeploymentFailCommand) Run(args []string) int {
 	var detach, verbose bool
+	var reason string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&detach, "detach", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.StringVar(&reason, "reason", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -117,7 +124,7 @@ func (c *DeploymentFailCommand) Run(args []string) int {
 		return 1
 	}
 
-	u, _, err := client.Deployments().Fail(deploy.ID, nil)
+	u, _, err := client.Deployments().FailWithReason(deploy.ID, reason, nil)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error failing deployment: %s", err))
 		return 1
@@ -39,6 +39,12 @@ Promote Options:
     Group may be specified many times and is used to promote that particular
     group. If no specific groups are specified, all groups are promoted.
 
+  -percent
+    Percentage of each promoted group's allocations the deployment may roll
+    out to, between 1 and 100. The remaining allocations keep running the
+    previous job version until the deployment is promoted again with a higher
+    percentage. Defaults to 100, which promotes the groups fully.
+
   -detach
     Return immediately instead of entering monitor mode. After deployment
     resume, the evaluation ID will be printed to the screen, which can be used
@@ -58,6 +64,7 @@ func (c *DeploymentPromoteCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-group":   complete.PredictAnything,
+			"-percent": complete.PredictAnything,
 			"-detach":  complete.PredictNothing,
 			"-verbose": complete.PredictNothing,
 		})
@@ -83,17 +90,25 @@ func (c *DeploymentPromoteCommand) Name() string { return "deployment promote" }
 func (c *DeploymentPromoteCommand) Run(args []string) int {
 	var detach, verbose bool
 	var groups []string
+	var percent int
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&detach, "detach", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.Var((*flaghelper.StringFlag)(&groups), "group", "")
+	flags.IntVar(&percent, "percent", 100, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
 
+	if percent < 1 || percent > 100 {
+		c.Ui.Error("Percent must be between 1 and 100")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
 	// Check that we got exactly one argument
 	args = flags.Args()
 	if l := len(args); l != 1 {
@@ -129,7 +144,9 @@ func (c *DeploymentPromoteCommand) Run(args []string) int {
 	}
 
 	var u *api.DeploymentUpdateResponse
-	if len(groups) == 0 {
+	if percent < 100 {
+		u, _, err = client.Deployments().PromotePercent(deploy.ID, groups, percent, nil)
+	} else if len(groups) == 0 {
 		u, _, err = client.Deployments().PromoteAll(deploy.ID, nil)
 	} else {
 		u, _, err = client.Deployments().PromoteGroups(deploy.ID, groups, nil)
@@ -37,6 +37,15 @@ func TestDeploymentPromoteCommand_Fails(t *testing.T) {
 		t.Fatalf("expected failed query error, got: %s", out)
 	}
 	ui.ErrorWriter.Reset()
+
+	// Fails on an invalid percentage
+	if code := cmd.Run([]string{"-percent=0", "12"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Percent must be between 1 and 100") {
+		t.Fatalf("expected percent error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
 }
 
 func TestDeploymentPromoteCommand_AutocompleteArgs(t *testing.T) {
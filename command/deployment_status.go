@@ -502,6 +502,9 @@ func formatDeployment(c *api.Client, d *api.Deployment, uuidLength int) string {
 		fmt.Sprintf("Status|%s", d.Status),
 		fmt.Sprintf("Description|%s", d.StatusDescription),
 	}
+	if d.StatusReason != "" {
+		high = append(high, fmt.Sprintf("Reason|%s", d.StatusReason))
+	}
 
 	base := formatKV(high)
 
@@ -638,7 +641,10 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 			row += fmt.Sprintf("%v|", state.AutoRevert)
 		}
 		if canaries {
-			if state.DesiredCanaries > 0 {
+			if state.DesiredCanaries > 0 && state.Promoted && state.PromotedPercent > 0 && state.PromotedPercent < 100 {
+				// Partially promoted groups show the percentage rolled out to
+				row += fmt.Sprintf("%d%%|", state.PromotedPercent)
+			} else if state.DesiredCanaries > 0 {
 				row += fmt.Sprintf("%v|", state.Promoted)
 			} else {
 				row += fmt.Sprintf("%v|", "N/A")
@@ -35,6 +35,11 @@ Eval Status Options:
   -verbose
     Show full information.
 
+  -trace
+    Display the timeline of scheduler decisions recorded for the evaluation.
+    Evaluations are only traced when eval tracing is enabled in the scheduler
+    configuration.
+
   -json
     Output the evaluation in its JSON format.
 
@@ -55,6 +60,7 @@ func (c *EvalStatusCommand) AutocompleteFlags() complete.Flags {
 			"-json":    complete.PredictNothing,
 			"-monitor": complete.PredictNothing,
 			"-t":       complete.PredictAnything,
+			"-trace":   complete.PredictNothing,
 			"-verbose": complete.PredictNothing,
 		})
 }
@@ -81,13 +87,14 @@ func (c *EvalStatusCommand) AutocompleteArgs() complete.Predictor {
 func (c *EvalStatusCommand) Name() string { return "eval status" }
 
 func (c *EvalStatusCommand) Run(args []string) int {
-	var monitor, verbose, json bool
+	var monitor, verbose, trace, json bool
 	var tmpl string
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&monitor, "monitor", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&trace, "trace", false, "")
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
 
@@ -238,6 +245,11 @@ func (c *EvalStatusCommand) Run(args []string) int {
 			fmt.Sprintf("Wait Until|%s", formatTime(eval.WaitUntil)))
 	}
 
+	if !eval.BlockedUntil.IsZero() {
+		basic = append(basic,
+			fmt.Sprintf("Blocked Until|%s", formatTime(eval.BlockedUntil)))
+	}
+
 	if verbose {
 		// NextEval, PreviousEval, BlockedEval
 		basic = append(basic,
@@ -268,9 +280,47 @@ func (c *EvalStatusCommand) Run(args []string) int {
 		}
 	}
 
+	if trace {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Trace[reset]"))
+		c.Ui.Output(formatEvalTrace(eval.Trace, length))
+	}
+
 	return 0
 }
 
+// formatEvalTrace formats the trace of an evaluation, with the time of each
+// event as an offset from the first one.
+func formatEvalTrace(trace *api.EvalTrace, length int) string {
+	if trace == nil || len(trace.Events) == 0 {
+		return "No trace recorded for this evaluation"
+	}
+
+	start := trace.Events[0].Time
+	out := make([]string, 0, len(trace.Events)+1)
+	out = append(out, "Offset|Task Group|Event|Node ID|Node Name|Reason|Score")
+	for _, e := range trace.Events {
+		score := ""
+		switch e.Type {
+		case "node-scored", "placed":
+			score = fmt.Sprintf("%.3g", e.Score)
+		}
+		out = append(out, fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s",
+			time.Duration(e.Time-start),
+			e.TaskGroup,
+			e.Type,
+			limit(e.NodeID, length),
+			e.NodeName,
+			e.Reason,
+			score))
+	}
+
+	formatted := formatList(out)
+	if trace.Truncated {
+		formatted += fmt.Sprintf("\n\nTrace truncated after %d events", len(trace.Events))
+	}
+	return formatted
+}
+
 func sortedTaskGroupFromMetrics(groups map[string]*api.AllocationMetric) []string {
 	tgs := make([]string, 0, len(groups))
 	for tg := range groups {
@@ -10,6 +10,7 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEvalStatusCommand_Implements(t *testing.T) {
@@ -85,3 +86,51 @@ func TestEvalStatusCommand_AutocompleteArgs(t *testing.T) {
 	assert.Equal(1, len(res))
 	assert.Equal(e.ID, res[0])
 }
+
+func TestEvalStatusCommand_Trace(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	state := srv.Agent.Server().State()
+	traced := mock.Eval()
+	traced.Trace = &structs.EvalTrace{
+		Events: []*structs.EvalTraceEvent{
+			{
+				Time:      1000,
+				Type:      structs.EvalTraceNodeFiltered,
+				TaskGroup: "web",
+				NodeID:    "a0b1c2d3-0000-0000-0000-000000000000",
+				NodeName:  "node-1",
+				Reason:    "${node.class} = traced",
+			},
+			{
+				Time:      3000,
+				Type:      structs.EvalTracePlaced,
+				TaskGroup: "web",
+				NodeID:    "e4f5a6b7-0000-0000-0000-000000000000",
+				NodeName:  "node-2",
+				Score:     0.75,
+			},
+		},
+		Truncated: true,
+	}
+	untraced := mock.Eval()
+	require.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 1000, []*structs.Evaluation{traced, untraced}))
+
+	ui := cli.NewMockUi()
+	cmd := &EvalStatusCommand{Meta: Meta{Ui: ui}}
+	require.Equal(t, 0, cmd.Run([]string{"-address=" + url, "-trace", traced.ID}))
+
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "Trace")
+	require.Regexp(t, `0s\s+web\s+node-filtered\s+a0b1c2d3\s+node-1\s+\$\{node.class\} = traced\s+<none>`, out)
+	require.Regexp(t, `2µs\s+web\s+placed\s+e4f5a6b7\s+node-2\s+<none>\s+0.75`, out)
+	require.Contains(t, out, "Trace truncated after 2 events")
+
+	ui = cli.NewMockUi()
+	cmd = &EvalStatusCommand{Meta: Meta{Ui: ui}}
+	require.Equal(t, 0, cmd.Run([]string{"-address=" + url, "-trace", untraced.ID}))
+	require.Contains(t, ui.OutputWriter.String(), "No trace recorded for this evaluation")
+}
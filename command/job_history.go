@@ -265,6 +265,13 @@ func (c *JobHistoryCommand) formatJobVersion(job *api.Job, diff *api.JobDiff, ne
 		fmt.Sprintf("Submit Date|%v", formatTime(time.Unix(0, *job.SubmitTime))),
 	}
 
+	if job.VersionTag != nil {
+		basic = append(basic, fmt.Sprintf("Tag|%s", job.VersionTag.Name))
+		if job.VersionTag.Description != "" {
+			basic = append(basic, fmt.Sprintf("Tag Description|%s", job.VersionTag.Description))
+		}
+	}
+
 	if diff != nil {
 		//diffStr := fmt.Sprintf("Difference between version %d and %d:", *job.Version, nextVersion)
 		basic = append(basic, fmt.Sprintf("Diff|\n%s", strings.TrimSpace(formatJobDiff(diff, false))))
@@ -0,0 +1,210 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobMoveCommand struct {
+	Meta
+}
+
+func (c *JobMoveCommand) Help() string {
+	helpText := `
+Usage: nomad job move [options] <job>
+
+  Move is used to move a job to a different namespace, to rename it, or both.
+  The versions, scaling policies and allocations of the job are preserved, and
+  the running allocations are not restarted.
+
+  Periodic, parameterized and multiregion jobs, child jobs and jobs running CSI
+  plugins can't be moved. Jobs with pending evaluations or active deployments
+  can't be moved until those complete.
+
+  The job is admitted to the new namespace as if it were registered there, so
+  the namespace's task driver restrictions and job limits apply, and the
+  Vault policies and Consul usages of the job are checked against the tokens
+  passed with the -vault-token and -consul-token options.
+
+  When ACLs are enabled, this command requires a token with the 'submit-job'
+  capability for both the job's namespace and the namespace it is moved to.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Move Options:
+
+  -new-namespace=<namespace>
+    The namespace to move the job to. Defaults to the namespace of the job.
+
+  -new-id=<id>
+    The ID to rename the job to. Defaults to the ID of the job.
+
+  -consul-token
+    The Consul token the Consul usages of the job are checked against when
+    the servers require one. This overrides the token found in
+    $CONSUL_HTTP_TOKEN environment variable.
+
+  -vault-token
+    The Vault token the Vault policies of the job are checked against when
+    the servers require one. This overrides the token found in $VAULT_TOKEN
+    environment variable.
+
+  -detach
+    Return immediately instead of entering monitor mode. After the job is
+    moved, the evaluation ID will be printed to the screen, which can be used
+    to examine the evaluation using the eval-status command.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobMoveCommand) Synopsis() string {
+	return "Move a job to a different namespace or rename it"
+}
+
+func (c *JobMoveCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-new-namespace": NamespacePredictor(c.Meta.Client, nil),
+			"-new-id":        complete.PredictAnything,
+			"-consul-token":  complete.PredictNothing,
+			"-vault-token":   complete.PredictAnything,
+			"-detach":        complete.PredictNothing,
+			"-verbose":       complete.PredictNothing,
+		})
+}
+
+func (c *JobMoveCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobMoveCommand) Name() string { return "job move" }
+
+func (c *JobMoveCommand) Run(args []string) int {
+	var detach, verbose bool
+	var newNamespace, newID, consulToken, vaultToken string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&detach, "detach", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.StringVar(&newNamespace, "new-namespace", "", "")
+	flags.StringVar(&newID, "new-id", "", "")
+	flags.StringVar(&consulToken, "consul-token", "", "")
+	flags.StringVar(&vaultToken, "vault-token", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if newNamespace == "" && newID == "" {
+		c.Ui.Error("At least one of -new-namespace or -new-id must be set")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Parse the Consul and Vault tokens
+	if consulToken == "" {
+		consulToken = os.Getenv("CONSUL_HTTP_TOKEN")
+	}
+	if vaultToken == "" {
+		vaultToken = os.Getenv("VAULT_TOKEN")
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobID := strings.TrimSpace(args[0])
+	jobs, _, err := client.Jobs().PrefixList(jobID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing jobs: %s", err))
+		return 1
+	}
+	if len(jobs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No job(s) with prefix or id %q found", jobID))
+		return 1
+	}
+	if len(jobs) > 1 {
+		if (jobID != jobs[0].ID) || (c.allNamespaces() && jobs[0].ID == jobs[1].ID) {
+			c.Ui.Error(fmt.Sprintf("Prefix matched multiple jobs\n\n%s", createStatusListOutput(jobs, c.allNamespaces())))
+			return 1
+		}
+	}
+
+	// Prefix lookup matched a single job
+	namespace := jobs[0].JobSummary.Namespace
+	q := &api.WriteOptions{Namespace: namespace}
+	opts := &api.MoveOptions{
+		NewNamespace: newNamespace,
+		NewJobID:     newID,
+		VaultToken:   vaultToken,
+		ConsulToken:  consulToken,
+	}
+	resp, _, err := client.Jobs().MoveOpts(jobs[0].ID, opts, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error moving job: %s", err))
+		return 1
+	}
+
+	if newNamespace == "" {
+		newNamespace = namespace
+	}
+	if newID == "" {
+		newID = jobs[0].ID
+	}
+	c.Ui.Output(fmt.Sprintf("Job %q in namespace %q moved to job %q in namespace %q",
+		jobs[0].ID, namespace, newID, newNamespace))
+
+	// Nothing to do
+	evalCreated := resp.EvalID != ""
+	if !evalCreated {
+		return 0
+	}
+	if detach {
+		c.Ui.Output("Evaluation ID: " + resp.EvalID)
+		return 0
+	}
+
+	// The evaluation is in the namespace the job was moved to
+	client.SetNamespace(newNamespace)
+	mon := newMonitor(c.Ui, client, length)
+	return mon.monitor(resp.EvalID)
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobMoveCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobMoveCommand{}
+}
+
+func TestJobMoveCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &JobMoveCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"foo"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "At least one of -new-namespace or -new-id must be set")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=nope", "-new-id=bar", "foo"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error listing jobs")
+	ui.ErrorWriter.Reset()
+}
+
+func TestJobMoveCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	state := srv.Agent.Server().State()
+	ns := mock.Namespace()
+	require.NoError(t, state.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	// A stopped job has no evaluation to monitor
+	j := mock.Job()
+	j.Stop = true
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, j))
+
+	ui := cli.NewMockUi()
+	cmd := &JobMoveCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	code := cmd.Run([]string{"-address=" + url, "-new-namespace=" + ns.Name, "-new-id=moved", j.ID})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(),
+		`Job "`+j.ID+`" in namespace "default" moved to job "moved" in namespace "`+ns.Name+`"`)
+
+	_, _, err := client.Jobs().Info(j.ID, nil)
+	require.Error(t, err)
+
+	out, err := state.JobByID(nil, ns.Name, "moved")
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
@@ -25,6 +25,12 @@ potentially invalid.`
 	// preemptionDisplayThreshold is an upper bound used to limit and summarize
 	// the details of preempted jobs in the output
 	preemptionDisplayThreshold = 10
+
+	// diffFormatText, diffFormatJSON and diffFormatMarkdown are the values
+	// accepted by the -diff-format flag.
+	diffFormatText     = "text"
+	diffFormatJSON     = "json"
+	diffFormatMarkdown = "markdown"
 )
 
 type JobPlanCommand struct {
@@ -76,6 +82,13 @@ Plan Options:
     Determines whether the diff between the remote job and planned job is shown.
     Defaults to true.
 
+  -diff-format=<text|json|markdown>
+    Sets the format of the plan output. "text" is the default colored terminal
+    output. "json" emits a JSON document containing the diff, the dry-run
+    results and the job modify index. "markdown" renders the same information
+    as markdown, suitable for posting as a pull request comment. Defaults to
+    "text".
+
   -hcl1
     Parses the job file as HCLv1.
 
@@ -94,7 +107,8 @@ Plan Options:
     Path to HCL2 file containing user variables.
 
   -verbose
-    Increase diff verbosity.
+    Increase diff verbosity and list every preempted allocation with full
+    IDs.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -107,6 +121,7 @@ func (c *JobPlanCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-diff":            complete.PredictNothing,
+			"-diff-format":     complete.PredictSet(diffFormatText, diffFormatJSON, diffFormatMarkdown),
 			"-policy-override": complete.PredictNothing,
 			"-verbose":         complete.PredictNothing,
 			"-hcl1":            complete.PredictNothing,
@@ -123,11 +138,13 @@ func (c *JobPlanCommand) AutocompleteArgs() complete.Predictor {
 func (c *JobPlanCommand) Name() string { return "job plan" }
 func (c *JobPlanCommand) Run(args []string) int {
 	var diff, policyOverride, verbose, hcl2Strict bool
+	var diffFormat string
 	var varArgs, varFiles flaghelper.StringFlag
 
 	flagSet := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flagSet.Usage = func() { c.Ui.Output(c.Help()) }
 	flagSet.BoolVar(&diff, "diff", true, "")
+	flagSet.StringVar(&diffFormat, "diff-format", diffFormatText, "")
 	flagSet.BoolVar(&policyOverride, "policy-override", false, "")
 	flagSet.BoolVar(&verbose, "verbose", false, "")
 	flagSet.BoolVar(&c.JobGetter.hcl1, "hcl1", false, "")
@@ -147,6 +164,14 @@ func (c *JobPlanCommand) Run(args []string) int {
 		return 255
 	}
 
+	switch diffFormat {
+	case diffFormatText, diffFormatJSON, diffFormatMarkdown:
+	default:
+		c.Ui.Error(fmt.Sprintf("Invalid -diff-format %q: must be one of %q, %q or %q",
+			diffFormat, diffFormatText, diffFormatJSON, diffFormatMarkdown))
+		return 255
+	}
+
 	path := args[0]
 	// Get Job struct from Jobfile
 	job, err := c.JobGetter.ApiJobWithArgs(args[0], varArgs, varFiles, hcl2Strict)
@@ -182,7 +207,7 @@ func (c *JobPlanCommand) Run(args []string) int {
 	}
 
 	if job.IsMultiregion() {
-		return c.multiregionPlan(client, job, opts, diff, verbose)
+		return c.multiregionPlan(client, job, opts, diff, verbose, diffFormat)
 	}
 
 	// Submit the job
@@ -201,12 +226,20 @@ func (c *JobPlanCommand) Run(args []string) int {
 		runArgs.WriteString(fmt.Sprintf("-var-file=%q ", varFile))
 	}
 
+	switch diffFormat {
+	case diffFormatJSON:
+		return c.outputPlanJSON([]*planOutput{newPlanOutput("", job, resp, diff)}, false)
+	case diffFormatMarkdown:
+		c.Ui.Output(formatPlanMarkdown(newPlanOutput("", job, resp, diff), verbose))
+		return getExitCode(resp)
+	}
+
 	exitCode := c.outputPlannedJob(job, resp, diff, verbose)
 	c.Ui.Output(c.Colorize().Color(formatJobModifyIndex(resp.JobModifyIndex, runArgs.String(), path)))
 	return exitCode
 }
 
-func (c *JobPlanCommand) multiregionPlan(client *api.Client, job *api.Job, opts *api.PlanOptions, diff, verbose bool) int {
+func (c *JobPlanCommand) multiregionPlan(client *api.Client, job *api.Job, opts *api.PlanOptions, diff, verbose bool, diffFormat string) int {
 
 	var exitCode int
 	plans := map[string]*api.JobPlanResponse{}
@@ -229,6 +262,31 @@ func (c *JobPlanCommand) multiregionPlan(client *api.Client, job *api.Job, opts
 		return exitCode
 	}
 
+	if diffFormat != diffFormatText {
+		regions := make([]string, 0, len(plans))
+		for regionName := range plans {
+			regions = append(regions, regionName)
+		}
+		sort.Strings(regions)
+
+		outputs := make([]*planOutput, 0, len(regions))
+		for _, regionName := range regions {
+			outputs = append(outputs, newPlanOutput(regionName, job, plans[regionName], diff))
+		}
+
+		if diffFormat == diffFormatJSON {
+			return c.outputPlanJSON(outputs, true)
+		}
+
+		for _, out := range outputs {
+			c.Ui.Output(formatPlanMarkdown(out, verbose))
+			if out.ExitCode > exitCode {
+				exitCode = out.ExitCode
+			}
+		}
+		return exitCode
+	}
+
 	for regionName, resp := range plans {
 		c.Ui.Output(c.Colorize().Color(fmt.Sprintf("[bold]Region: %q[reset]", regionName)))
 		regionExitCode := c.outputPlannedJob(job, resp, diff, verbose)
@@ -260,20 +318,109 @@ func (c *JobPlanCommand) outputPlannedJob(job *api.Job, resp *api.JobPlanRespons
 
 	// Print preemptions if there are any
 	if resp.Annotations != nil && len(resp.Annotations.PreemptedAllocs) > 0 {
-		c.addPreemptions(resp)
+		c.addPreemptions(resp, verbose)
 	}
 
 	return getExitCode(resp)
 }
 
-// addPreemptions shows details about preempted allocations
-func (c *JobPlanCommand) addPreemptions(resp *api.JobPlanResponse) {
+// planOutput is the stable document produced by the json and markdown diff
+// formats. Field names are part of the command's output contract and must
+// not be renamed.
+type planOutput struct {
+	// Region is only set for multiregion jobs.
+	Region string `json:",omitempty"`
+
+	JobID          string
+	JobType        string
+	JobModifyIndex uint64
+
+	// ExitCode is the exit code the command returns for this plan: 0 if no
+	// allocations are created or destroyed, 1 otherwise.
+	ExitCode int
+
+	Diff               *api.JobDiff `json:",omitempty"`
+	DesiredTGUpdates   map[string]*api.DesiredUpdates
+	FailedTGAllocs     map[string]*api.AllocationMetric
+	PreemptedAllocs    []*api.AllocationListStub
+	NextPeriodicLaunch *time.Time `json:",omitempty"`
+	Warnings           string
+}
+
+// newPlanOutput builds the structured output for a single plan response.
+// The diff is omitted if diff is false.
+func newPlanOutput(region string, job *api.Job, resp *api.JobPlanResponse, diff bool) *planOutput {
+	out := &planOutput{
+		Region:         region,
+		JobID:          *job.ID,
+		JobModifyIndex: resp.JobModifyIndex,
+		ExitCode:       getExitCode(resp),
+		FailedTGAllocs: resp.FailedTGAllocs,
+		Warnings:       resp.Warnings,
+	}
+	if job.Type != nil {
+		out.JobType = *job.Type
+	}
+	if diff {
+		out.Diff = resp.Diff
+	}
+	if resp.Annotations != nil {
+		out.DesiredTGUpdates = resp.Annotations.DesiredTGUpdates
+		out.PreemptedAllocs = resp.Annotations.PreemptedAllocs
+	}
+	if next := resp.NextPeriodicLaunch; !next.IsZero() && !job.IsParameterized() {
+		out.NextPeriodicLaunch = &next
+	}
+	return out
+}
+
+// outputPlanJSON writes the plans as a single JSON document and returns the
+// highest exit code among them. Multiregion plans are emitted as a list,
+// otherwise the single plan is emitted as an object.
+func (c *JobPlanCommand) outputPlanJSON(plans []*planOutput, multiregion bool) int {
+	var data interface{} = plans
+	if !multiregion {
+		data = plans[0]
+	}
+
+	out, err := Format(true, "", data)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error formatting plan: %s", err))
+		return 255
+	}
+	c.Ui.Output(out)
+
+	exitCode := 0
+	for _, p := range plans {
+		if p.ExitCode > exitCode {
+			exitCode = p.ExitCode
+		}
+	}
+	return exitCode
+}
+
+// addPreemptions shows details about preempted allocations. Each preempted
+// allocation is listed if there are only a few of them or if verbose is set,
+// otherwise they are summarized.
+func (c *JobPlanCommand) addPreemptions(resp *api.JobPlanResponse, verbose bool) {
 	c.Ui.Output(c.Colorize().Color("[bold][yellow]Preemptions:\n[reset]"))
-	if len(resp.Annotations.PreemptedAllocs) < preemptionDisplayThreshold {
+	if verbose || len(resp.Annotations.PreemptedAllocs) < preemptionDisplayThreshold {
+		length := shortId
+		if verbose {
+			length = fullId
+		}
+
 		var allocs []string
-		allocs = append(allocs, "Alloc ID|Job ID|Task Group")
+		allocs = append(allocs, "Alloc ID|Job ID|Namespace|Task Group|Node ID|Node Name|Preempted By")
 		for _, alloc := range resp.Annotations.PreemptedAllocs {
-			allocs = append(allocs, fmt.Sprintf("%s|%s|%s", alloc.ID, alloc.JobID, alloc.TaskGroup))
+			allocs = append(allocs, fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s",
+				limit(alloc.ID, length),
+				alloc.JobID,
+				alloc.Namespace,
+				alloc.TaskGroup,
+				limit(alloc.NodeID, length),
+				alloc.NodeName,
+				resp.Annotations.PreemptingTaskGroups[alloc.ID]))
 		}
 		c.Ui.Output(formatList(allocs))
 		return
@@ -318,7 +465,7 @@ func (c *JobPlanCommand) addPreemptions(resp *api.JobPlanResponse) {
 		}
 	}
 	c.Ui.Output(formatList(output))
-
+	c.Ui.Output("\nRun with -verbose to list every preempted allocation.")
 }
 
 type namespaceIdPair struct {
@@ -471,6 +618,8 @@ func formatTaskGroupDiff(tg *api.TaskGroupDiff, tgPrefix int, verbose bool) stri
 				color = "[yellow]"
 			case scheduler.UpdateTypeCanary:
 				color = "[light_yellow]"
+			case scheduler.UpdateTypeDisplaced:
+				color = "[red]"
 			}
 			updates = append(updates, fmt.Sprintf("[reset]%s%d %s", color, count, updateType))
 		}
@@ -681,3 +830,196 @@ func colorAnnotations(annotations []string) string {
 
 	return strings.Join(colored, ", ")
 }
+
+// formatPlanMarkdown renders a plan as markdown. The diff is rendered as a
+// fenced "diff" code block so that additions and removals are highlighted
+// when displayed by code hosting services. If verbose is set, added or
+// deleted task groups and tasks are expanded.
+func formatPlanMarkdown(plan *planOutput, verbose bool) string {
+	var b strings.Builder
+
+	if plan.Region != "" {
+		fmt.Fprintf(&b, "### Job: `%s` (region: `%s`)\n\n", plan.JobID, plan.Region)
+	} else {
+		fmt.Fprintf(&b, "### Job: `%s`\n\n", plan.JobID)
+	}
+
+	if len(plan.DesiredTGUpdates) != 0 {
+		b.WriteString("| Task Group | Changes |\n")
+		b.WriteString("| --- | --- |\n")
+		groups := make([]string, 0, len(plan.DesiredTGUpdates))
+		for tg := range plan.DesiredTGUpdates {
+			groups = append(groups, tg)
+		}
+		sort.Strings(groups)
+		for _, tg := range groups {
+			fmt.Fprintf(&b, "| %s | %s |\n", tg, formatDesiredUpdatesMarkdown(plan.DesiredTGUpdates[tg]))
+		}
+		b.WriteString("\n")
+	}
+
+	if plan.Diff != nil {
+		b.WriteString("```diff\n")
+		writeJobDiffMarkdown(&b, plan.Diff, verbose)
+		b.WriteString("```\n\n")
+	}
+
+	b.WriteString("**Scheduler dry-run:**\n\n")
+	if len(plan.FailedTGAllocs) == 0 {
+		b.WriteString("- All tasks successfully allocated.\n")
+	} else {
+		for _, tg := range sortedTaskGroupFromMetrics(plan.FailedTGAllocs) {
+			metrics := plan.FailedTGAllocs[tg]
+			noun := "allocation"
+			if metrics.CoalescedFailures > 0 {
+				noun += "s"
+			}
+			fmt.Fprintf(&b, "- :warning: Task group `%s` failed to place %d %s.\n",
+				tg, metrics.CoalescedFailures+1, noun)
+		}
+	}
+	if plan.NextPeriodicLaunch != nil {
+		fmt.Fprintf(&b, "- If submitted now, next periodic launch would be at %s.\n",
+			formatTime(*plan.NextPeriodicLaunch))
+	}
+	if n := len(plan.PreemptedAllocs); n != 0 {
+		fmt.Fprintf(&b, "- :warning: %d allocation(s) would be preempted.\n", n)
+	}
+
+	if plan.Warnings != "" {
+		fmt.Fprintf(&b, "\n**Job Warnings:**\n\n```\n%s\n```\n", strings.TrimSpace(plan.Warnings))
+	}
+
+	if plan.Region == "" {
+		fmt.Fprintf(&b, "\n**Job Modify Index:** %d\n", plan.JobModifyIndex)
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// formatDesiredUpdatesMarkdown returns a comma separated summary of the
+// non-zero counts of the desired updates.
+func formatDesiredUpdatesMarkdown(u *api.DesiredUpdates) string {
+	if u == nil {
+		return "none"
+	}
+
+	counts := []struct {
+		count uint64
+		name  string
+	}{
+		{u.Place, scheduler.UpdateTypeCreate},
+		{u.Stop, scheduler.UpdateTypeDestroy},
+		{u.Migrate, scheduler.UpdateTypeMigrate},
+		{u.InPlaceUpdate, scheduler.UpdateTypeInplaceUpdate},
+		{u.DestructiveUpdate, scheduler.UpdateTypeDestructiveUpdate},
+		{u.Canary, scheduler.UpdateTypeCanary},
+		{u.Ignore, scheduler.UpdateTypeIgnore},
+	}
+
+	var parts []string
+	for _, c := range counts {
+		if c.count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.count, c.name))
+		}
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// writeJobDiffMarkdown writes the body of a "diff" code block for the job.
+// Each line is prefixed with "+", "-" or a space so that it is highlighted
+// as an addition, removal or context line.
+func writeJobDiffMarkdown(b *strings.Builder, job *api.JobDiff, verbose bool) {
+	writeDiffLineMarkdown(b, job.Type, 0, fmt.Sprintf("job %q {", job.ID))
+	if job.Type == "Edited" || verbose {
+		writeFieldsAndObjectsMarkdown(b, job.Type, job.Fields, job.Objects, 1)
+	}
+
+	for _, tg := range job.TaskGroups {
+		header := fmt.Sprintf("group %q {", tg.Name)
+		if len(tg.Updates) != 0 {
+			order := make([]string, 0, len(tg.Updates))
+			for updateType := range tg.Updates {
+				order = append(order, updateType)
+			}
+			sort.Strings(order)
+
+			updates := make([]string, 0, len(order))
+			for _, updateType := range order {
+				updates = append(updates, fmt.Sprintf("%d %s", tg.Updates[updateType], updateType))
+			}
+			header += fmt.Sprintf(" # %s", strings.Join(updates, ", "))
+		}
+		writeDiffLineMarkdown(b, tg.Type, 1, header)
+		if tg.Type == "Edited" || verbose {
+			writeFieldsAndObjectsMarkdown(b, tg.Type, tg.Fields, tg.Objects, 2)
+		}
+
+		for _, task := range tg.Tasks {
+			header := fmt.Sprintf("task %q {", task.Name)
+			if len(task.Annotations) != 0 {
+				header += fmt.Sprintf(" # %s", strings.Join(task.Annotations, ", "))
+			}
+			writeDiffLineMarkdown(b, task.Type, 2, header)
+			if task.Type == "Edited" || verbose {
+				writeFieldsAndObjectsMarkdown(b, task.Type, task.Fields, task.Objects, 3)
+			}
+			writeDiffLineMarkdown(b, task.Type, 2, "}")
+		}
+		writeDiffLineMarkdown(b, tg.Type, 1, "}")
+	}
+	writeDiffLineMarkdown(b, job.Type, 0, "}")
+}
+
+// writeFieldsAndObjectsMarkdown writes the field and object diffs nested in a
+// parent of type parentType. Edited fields are written as a removal of the old
+// value followed by an addition of the new one.
+func writeFieldsAndObjectsMarkdown(b *strings.Builder, parentType string,
+	fields []*api.FieldDiff, objects []*api.ObjectDiff, depth int) {
+
+	for _, field := range fields {
+		var annotations string
+		if len(field.Annotations) != 0 {
+			annotations = fmt.Sprintf(" # %s", strings.Join(field.Annotations, ", "))
+		}
+
+		switch field.Type {
+		case "Added":
+			writeDiffLineMarkdown(b, "Added", depth, fmt.Sprintf("%s: %q%s", field.Name, field.New, annotations))
+		case "Deleted":
+			writeDiffLineMarkdown(b, "Deleted", depth, fmt.Sprintf("%s: %q%s", field.Name, field.Old, annotations))
+		case "Edited":
+			writeDiffLineMarkdown(b, "Deleted", depth, fmt.Sprintf("%s: %q", field.Name, field.Old))
+			writeDiffLineMarkdown(b, "Added", depth, fmt.Sprintf("%s: %q%s", field.Name, field.New, annotations))
+		default:
+			writeDiffLineMarkdown(b, parentType, depth, fmt.Sprintf("%s: %q%s", field.Name, field.New, annotations))
+		}
+	}
+
+	for _, object := range objects {
+		objType := object.Type
+		if objType == "None" || objType == "" {
+			objType = parentType
+		}
+		writeDiffLineMarkdown(b, objType, depth, fmt.Sprintf("%s {", object.Name))
+		writeFieldsAndObjectsMarkdown(b, objType, object.Fields, object.Objects, depth+1)
+		writeDiffLineMarkdown(b, objType, depth, "}")
+	}
+}
+
+// writeDiffLineMarkdown writes a single line of a "diff" code block, indented
+// by depth levels and prefixed by the marker for the diff type. Edited and
+// unchanged lines are written as context.
+func writeDiffLineMarkdown(b *strings.Builder, diffType string, depth int, line string) {
+	marker := " "
+	switch diffType {
+	case "Added":
+		marker = "+"
+	case "Deleted":
+		marker = "-"
+	}
+	fmt.Fprintf(b, "%s %s%s\n", marker, strings.Repeat("  ", depth), line)
+}
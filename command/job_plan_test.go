@@ -1,6 +1,7 @@
 package command
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"strconv"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/mitchellh/cli"
 	"github.com/stretchr/testify/require"
@@ -189,14 +191,23 @@ func TestPlanCommad_Preemptions(t *testing.T) {
 					TaskGroup: "meta",
 					JobType:   "batch",
 					Namespace: "test",
+					NodeID:    "node1",
+					NodeName:  "client-1",
 				},
 			},
+			PreemptingTaskGroups: map[string]string{
+				"alloc1": "web",
+			},
 		},
 	}
-	cmd.addPreemptions(resp1)
+	cmd.addPreemptions(resp1, false)
 	out := ui.OutputWriter.String()
 	require.Contains(out, "Alloc ID")
 	require.Contains(out, "alloc1")
+	require.Contains(out, "Node Name")
+	require.Contains(out, "client-1")
+	require.Contains(out, "Preempted By")
+	require.Contains(out, "web")
 
 	// Less than 10 unique job ids
 	var preemptedAllocs []*api.AllocationListStub
@@ -218,10 +229,20 @@ func TestPlanCommad_Preemptions(t *testing.T) {
 		},
 	}
 	ui.OutputWriter.Reset()
-	cmd.addPreemptions(resp2)
+	cmd.addPreemptions(resp2, false)
 	out = ui.OutputWriter.String()
 	require.Contains(out, "Job ID")
 	require.Contains(out, "Namespace")
+	require.NotContains(out, "Alloc ID")
+	require.Contains(out, "-verbose")
+
+	// Verbose output lists every preempted alloc
+	ui.OutputWriter.Reset()
+	cmd.addPreemptions(resp2, true)
+	out = ui.OutputWriter.String()
+	require.Contains(out, "Alloc ID")
+	// Title, blank line and header, then one line per alloc
+	require.Equal(len(preemptedAllocs)+3, strings.Count(out, "\n"))
 
 	// More than 10 unique job IDs
 	preemptedAllocs = make([]*api.AllocationListStub, 0)
@@ -249,9 +270,141 @@ func TestPlanCommad_Preemptions(t *testing.T) {
 		},
 	}
 	ui.OutputWriter.Reset()
-	cmd.addPreemptions(resp3)
+	cmd.addPreemptions(resp3, false)
 	out = ui.OutputWriter.String()
 	require.Contains(out, "Job Type")
 	require.Contains(out, "batch")
 	require.Contains(out, "service")
 }
+
+func TestPlanCommand_DiffFormat(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &JobPlanCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-diff-format=yaml", "job.nomad"})
+	require.Equal(t, 255, code)
+	require.Contains(t, ui.ErrorWriter.String(), `Invalid -diff-format "yaml"`)
+
+	job := &api.Job{
+		ID:   helper.StringToPtr("example"),
+		Type: helper.StringToPtr("service"),
+	}
+	resp := &api.JobPlanResponse{
+		JobModifyIndex: 42,
+		Diff: &api.JobDiff{
+			Type: "Edited",
+			ID:   "example",
+			Fields: []*api.FieldDiff{
+				{Type: "Edited", Name: "Priority", Old: "50", New: "60"},
+			},
+			TaskGroups: []*api.TaskGroupDiff{
+				{
+					Type:    "Edited",
+					Name:    "cache",
+					Updates: map[string]uint64{"create/destroy update": 1},
+					Tasks: []*api.TaskDiff{
+						{
+							Type:        "Edited",
+							Name:        "redis",
+							Annotations: []string{"forces create/destroy update"},
+							Objects: []*api.ObjectDiff{
+								{
+									Type: "Edited",
+									Name: "Config",
+									Fields: []*api.FieldDiff{
+										{Type: "Edited", Name: "image", Old: "redis:6", New: "redis:7"},
+										{Type: "Added", Name: "command", New: "redis-server"},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Type: "Added",
+					Name: "web",
+					Tasks: []*api.TaskDiff{
+						{Type: "Added", Name: "nginx"},
+					},
+				},
+			},
+		},
+		Annotations: &api.PlanAnnotations{
+			DesiredTGUpdates: map[string]*api.DesiredUpdates{
+				"cache": {DestructiveUpdate: 1},
+				"web":   {Place: 2},
+			},
+		},
+		FailedTGAllocs: map[string]*api.AllocationMetric{
+			"web": {CoalescedFailures: 1},
+		},
+		Warnings: "some warning",
+	}
+
+	t.Run("json", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		cmd := &JobPlanCommand{Meta: Meta{Ui: ui}}
+
+		plan := newPlanOutput("", job, resp, true)
+		code := cmd.outputPlanJSON([]*planOutput{plan}, false)
+		require.Equal(t, 1, code)
+
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &decoded))
+		require.Equal(t, "example", decoded["JobID"])
+		require.Equal(t, "service", decoded["JobType"])
+		require.EqualValues(t, 42, decoded["JobModifyIndex"])
+		require.EqualValues(t, 1, decoded["ExitCode"])
+		require.Equal(t, "some warning", decoded["Warnings"])
+		require.NotContains(t, decoded, "Region")
+		require.Contains(t, decoded, "Diff")
+		require.Contains(t, decoded["FailedTGAllocs"], "web")
+
+		// Without a diff the field is omitted
+		ui.OutputWriter.Reset()
+		plan = newPlanOutput("", job, resp, false)
+		require.Equal(t, 1, cmd.outputPlanJSON([]*planOutput{plan}, false))
+		decoded = nil
+		require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &decoded))
+		require.NotContains(t, decoded, "Diff")
+
+		// Multiregion plans are emitted as a list
+		ui.OutputWriter.Reset()
+		plans := []*planOutput{
+			newPlanOutput("east", job, resp, true),
+			newPlanOutput("west", job, &api.JobPlanResponse{Annotations: &api.PlanAnnotations{}}, true),
+		}
+		require.Equal(t, 1, cmd.outputPlanJSON(plans, true))
+		var list []map[string]interface{}
+		require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &list))
+		require.Len(t, list, 2)
+		require.Equal(t, "east", list[0]["Region"])
+		require.Equal(t, "west", list[1]["Region"])
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		out := formatPlanMarkdown(newPlanOutput("", job, resp, true), false)
+
+		require.Contains(t, out, "### Job: `example`")
+		require.Contains(t, out, "| cache | 1 create/destroy update |")
+		require.Contains(t, out, "| web | 2 create |")
+		require.Contains(t, out, "```diff\n")
+		require.Contains(t, out, "-   Priority: \"50\"\n")
+		require.Contains(t, out, "+   Priority: \"60\"\n")
+		require.Contains(t, out, "      task \"redis\" { # forces create/destroy update\n")
+		require.Contains(t, out, "-         image: \"redis:6\"\n")
+		require.Contains(t, out, "+         image: \"redis:7\"\n")
+		require.Contains(t, out, "+         command: \"redis-server\"\n")
+		require.Contains(t, out, "+   group \"web\" {\n")
+		require.Contains(t, out, "- :warning: Task group `web` failed to place 2 allocations.")
+		require.Contains(t, out, "**Job Warnings:**")
+		require.True(t, strings.HasSuffix(out, "**Job Modify Index:** 42"))
+
+		// Multiregion plans include the region and no job modify index
+		out = formatPlanMarkdown(newPlanOutput("east", job, resp, false), false)
+		require.Contains(t, out, "### Job: `example` (region: `east`)")
+		require.NotContains(t, out, "```diff")
+		require.NotContains(t, out, "Job Modify Index")
+	})
+}
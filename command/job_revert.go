@@ -16,10 +16,12 @@ type JobRevertCommand struct {
 
 func (c *JobRevertCommand) Help() string {
 	helpText := `
-Usage: nomad job revert [options] <job> <version>
+Usage: nomad job revert [options] <job> <version|tag>
 
   Revert is used to revert a job to a prior version of the job. The available
-  versions to revert to can be found using "nomad job history" command.
+  versions to revert to can be found using "nomad job history" command. The
+  version can also be referenced by the tag set with the "nomad job tag"
+  command.
 
   When ACLs are enabled, this command requires a token with the 'submit-job'
   and 'list-jobs' capabilities for the job's namespace.
@@ -102,7 +104,7 @@ func (c *JobRevertCommand) Run(args []string) int {
 	// Check that we got two args
 	args = flags.Args()
 	if l := len(args); l != 2 {
-		c.Ui.Error("This command takes two arguments: <job> <version>")
+		c.Ui.Error("This command takes two arguments: <job> <version|tag>")
 		c.Ui.Error(commandErrorText(c))
 		return 1
 	}
@@ -129,12 +131,14 @@ func (c *JobRevertCommand) Run(args []string) int {
 	jobID := strings.TrimSpace(args[0])
 	revertVersion, ok, err := parseVersion(args[1])
 	if !ok {
-		c.Ui.Error("The job version to revert to must be specified using the -job-version flag")
+		c.Ui.Error("The job version to revert to must be specified")
 		return 1
 	}
+
+	// A version that isn't a number is a version tag
+	revertTag := ""
 	if err != nil {
-		c.Ui.Error(fmt.Sprintf("Failed to parse job-version flag: %v", err))
-		return 1
+		revertTag = strings.TrimSpace(args[1])
 	}
 
 	// Check if the job exists
@@ -154,6 +158,20 @@ func (c *JobRevertCommand) Run(args []string) int {
 		}
 	}
 
+	// Resolve the version tag
+	if revertTag != "" {
+		versions, _, _, err := client.Jobs().Versions(jobs[0].ID, false, &api.QueryOptions{Namespace: jobs[0].JobSummary.Namespace})
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error retrieving job versions: %s", err))
+			return 1
+		}
+		revertVersion, ok = versionByTag(versions, revertTag)
+		if !ok {
+			c.Ui.Error(fmt.Sprintf("No version of job %q is tagged %q", jobs[0].ID, revertTag))
+			return 1
+		}
+	}
+
 	// Prefix lookup matched a single job
 	q := &api.WriteOptions{Namespace: jobs[0].JobSummary.Namespace}
 	resp, _, err := client.Jobs().Revert(jobs[0].ID, revertVersion, nil, q, consulToken, vaultToken)
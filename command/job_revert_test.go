@@ -63,3 +63,21 @@ func TestJobRevertCommand_AutocompleteArgs(t *testing.T) {
 	assert.Equal(1, len(res))
 	assert.Equal(j.ID, res[0])
 }
+
+func TestJobRevertCommand_UnknownTag(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	state := srv.Agent.Server().State()
+	j := mock.Job()
+	assert.Nil(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, j))
+
+	ui := cli.NewMockUi()
+	cmd := &JobRevertCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	code := cmd.Run([]string{"-address=" + url, j.ID, "golden"})
+	assert.Equal(t, 1, code)
+	assert.Contains(t, ui.ErrorWriter.String(), `is tagged "golden"`)
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -34,6 +36,11 @@ Alias: nomad run
   the specification located at <path>. This is the main command
   used to interact with Nomad.
 
+  If the -bundle flag is set, <path> is a directory and all the jobs of
+  the "*.nomad" and "*.hcl" files of the directory are planned and then
+  registered atomically as a bundle: either all the jobs are registered or
+  none are.
+
   If the supplied path is "-", the jobfile is read from stdin. Otherwise
   it is read from the file at the supplied path or downloaded and
   read from URL specified.
@@ -73,6 +80,12 @@ General Options:
 
 Run Options:
 
+  -bundle
+    Register all the jobs of the directory at <path> atomically. The jobs must
+    all be in the same namespace and region. Can't be used with the
+    -check-index, -eval-priority, -output, -policy-override or
+    -preserve-counts flags.
+
   -check-index
     If set, the job is only registered or updated if the passed
     job modify index matches the server side version. If a check-index value of
@@ -120,6 +133,11 @@ Run Options:
     the job file. This overrides the token found in $VAULT_TOKEN environment
     variable and that found in the job.
 
+  -vault-cluster-token <cluster>=<token>
+    The Vault token of a named Vault cluster the tasks of the job request
+    policies from, which is checked by the Nomad servers unless the cluster
+    allows unauthenticated access. The flag may be specified once per cluster.
+
   -vault-namespace
     If set, the passed Vault namespace is stored in the job before sending to the
     Nomad servers.
@@ -143,20 +161,22 @@ func (c *JobRunCommand) Synopsis() string {
 func (c *JobRunCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-check-index":     complete.PredictNothing,
-			"-detach":          complete.PredictNothing,
-			"-verbose":         complete.PredictNothing,
-			"-consul-token":    complete.PredictNothing,
-			"-vault-token":     complete.PredictAnything,
-			"-vault-namespace": complete.PredictAnything,
-			"-output":          complete.PredictNothing,
-			"-policy-override": complete.PredictNothing,
-			"-preserve-counts": complete.PredictNothing,
-			"-hcl1":            complete.PredictNothing,
-			"-hcl2-strict":     complete.PredictNothing,
-			"-var":             complete.PredictAnything,
-			"-var-file":        complete.PredictFiles("*.var"),
-			"-eval-priority":   complete.PredictNothing,
+			"-bundle":              complete.PredictNothing,
+			"-check-index":         complete.PredictNothing,
+			"-detach":              complete.PredictNothing,
+			"-verbose":             complete.PredictNothing,
+			"-consul-token":        complete.PredictNothing,
+			"-vault-token":         complete.PredictAnything,
+			"-vault-cluster-token": complete.PredictAnything,
+			"-vault-namespace":     complete.PredictAnything,
+			"-output":              complete.PredictNothing,
+			"-policy-override":     complete.PredictNothing,
+			"-preserve-counts":     complete.PredictNothing,
+			"-hcl1":                complete.PredictNothing,
+			"-hcl2-strict":         complete.PredictNothing,
+			"-var":                 complete.PredictAnything,
+			"-var-file":            complete.PredictFiles("*.var"),
+			"-eval-priority":       complete.PredictNothing,
 		})
 }
 
@@ -167,14 +187,15 @@ func (c *JobRunCommand) AutocompleteArgs() complete.Predictor {
 func (c *JobRunCommand) Name() string { return "job run" }
 
 func (c *JobRunCommand) Run(args []string) int {
-	var detach, verbose, output, override, preserveCounts, hcl2Strict bool
+	var detach, verbose, output, override, preserveCounts, hcl2Strict, bundle bool
 	var checkIndexStr, consulToken, consulNamespace, vaultToken, vaultNamespace string
-	var varArgs, varFiles flaghelper.StringFlag
+	var varArgs, varFiles, vaultClusterTokens flaghelper.StringFlag
 	var evalPriority int
 
 	flagSet := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flagSet.Usage = func() { c.Ui.Output(c.Help()) }
 	flagSet.BoolVar(&detach, "detach", false, "")
+	flagSet.BoolVar(&bundle, "bundle", false, "")
 	flagSet.BoolVar(&verbose, "verbose", false, "")
 	flagSet.BoolVar(&output, "output", false, "")
 	flagSet.BoolVar(&override, "policy-override", false, "")
@@ -186,6 +207,7 @@ func (c *JobRunCommand) Run(args []string) int {
 	flagSet.StringVar(&consulNamespace, "consul-namespace", "", "")
 	flagSet.StringVar(&vaultToken, "vault-token", "", "")
 	flagSet.StringVar(&vaultNamespace, "vault-namespace", "", "")
+	flagSet.Var(&vaultClusterTokens, "vault-cluster-token", "")
 	flagSet.Var(&varArgs, "var", "")
 	flagSet.Var(&varFiles, "var-file", "")
 	flagSet.IntVar(&evalPriority, "eval-priority", 0, "")
@@ -208,6 +230,56 @@ func (c *JobRunCommand) Run(args []string) int {
 		return 1
 	}
 
+	// Parse the Consul token
+	if consulToken == "" {
+		// Check the environment variable
+		consulToken = os.Getenv("CONSUL_HTTP_TOKEN")
+	}
+
+	// Parse the Vault token
+	if vaultToken == "" {
+		// Check the environment variable
+		vaultToken = os.Getenv("VAULT_TOKEN")
+	}
+
+	// Parse the Vault tokens of the named clusters
+	clusterTokens := make(map[string]string, len(vaultClusterTokens))
+	for _, kv := range vaultClusterTokens {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			c.Ui.Error(fmt.Sprintf("Invalid -vault-cluster-token %q, must be <cluster>=<token>", kv))
+			return 1
+		}
+		clusterTokens[parts[0]] = parts[1]
+	}
+
+	setJobTokens := func(job *api.Job) {
+		if consulToken != "" {
+			job.ConsulToken = helper.StringToPtr(consulToken)
+		}
+		if consulNamespace != "" {
+			job.ConsulNamespace = helper.StringToPtr(consulNamespace)
+		}
+		if vaultToken != "" {
+			job.VaultToken = helper.StringToPtr(vaultToken)
+		}
+		if len(clusterTokens) != 0 {
+			job.VaultClusterTokens = clusterTokens
+		}
+		if vaultNamespace != "" {
+			job.VaultNamespace = helper.StringToPtr(vaultNamespace)
+		}
+	}
+
+	if bundle {
+		if checkIndexStr != "" || evalPriority != 0 || output || override || preserveCounts {
+			c.Ui.Error("The -bundle flag can't be used with the -check-index, -eval-priority, -output, -policy-override or -preserve-counts flags")
+			c.Ui.Error(commandErrorText(c))
+			return 1
+		}
+		return c.runBundle(args[0], varArgs, varFiles, hcl2Strict, setJobTokens, detach, length)
+	}
+
 	// Get Job struct from Jobfile
 	job, err := c.JobGetter.ApiJobWithArgs(args[0], varArgs, varFiles, hcl2Strict)
 	if err != nil {
@@ -237,33 +309,7 @@ func (c *JobRunCommand) Run(args []string) int {
 	paramjob := job.IsParameterized()
 	multiregion := job.IsMultiregion()
 
-	// Parse the Consul token
-	if consulToken == "" {
-		// Check the environment variable
-		consulToken = os.Getenv("CONSUL_HTTP_TOKEN")
-	}
-
-	if consulToken != "" {
-		job.ConsulToken = helper.StringToPtr(consulToken)
-	}
-
-	if consulNamespace != "" {
-		job.ConsulNamespace = helper.StringToPtr(consulNamespace)
-	}
-
-	// Parse the Vault token
-	if vaultToken == "" {
-		// Check the environment variable
-		vaultToken = os.Getenv("VAULT_TOKEN")
-	}
-
-	if vaultToken != "" {
-		job.VaultToken = helper.StringToPtr(vaultToken)
-	}
-
-	if vaultNamespace != "" {
-		job.VaultNamespace = helper.StringToPtr(vaultNamespace)
-	}
+	setJobTokens(job)
 
 	if output {
 		req := struct {
@@ -353,6 +399,123 @@ func (c *JobRunCommand) Run(args []string) int {
 
 }
 
+// runBundle plans all the jobs of the directory and then registers them
+// atomically as a bundle.
+func (c *JobRunCommand) runBundle(dir string, varArgs, varFiles []string, hcl2Strict bool,
+	setJobTokens func(*api.Job), detach bool, length int) int {
+
+	var paths []string
+	for _, pattern := range []string{"*.nomad", "*.hcl"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error listing job files: %s", err))
+			return 1
+		}
+		paths = append(paths, matches...)
+	}
+	if len(paths) == 0 {
+		c.Ui.Error(fmt.Sprintf("No job files found in %q", dir))
+		return 1
+	}
+	sort.Strings(paths)
+
+	jobs := make([]*api.Job, 0, len(paths))
+	for _, path := range paths {
+		job, err := c.JobGetter.ApiJobWithArgs(path, varArgs, varFiles, hcl2Strict)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error getting job struct from %q: %s", path, err))
+			return 1
+		}
+		if job.IsMultiregion() {
+			c.Ui.Error(fmt.Sprintf("Multiregion job %q can't be registered in a bundle", *job.ID))
+			return 1
+		}
+		setJobTokens(job)
+		jobs = append(jobs, job)
+	}
+
+	// The jobs of a bundle must all be in the same namespace and region
+	value := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	first := jobs[0]
+	for _, job := range jobs[1:] {
+		if value(job.Namespace) != value(first.Namespace) {
+			c.Ui.Error("All jobs of a bundle must be in the same namespace")
+			return 1
+		}
+		if value(job.Region) != value(first.Region) {
+			c.Ui.Error("All jobs of a bundle must be in the same region")
+			return 1
+		}
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Force the region and namespace to be that of the jobs.
+	if r := first.Region; r != nil {
+		client.SetRegion(*r)
+	}
+	if n := first.Namespace; n != nil {
+		client.SetNamespace(*n)
+	}
+
+	// Plan all the jobs before registering any of them
+	for _, job := range jobs {
+		resp, _, err := client.Jobs().Plan(job, true, nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error during plan of job %q: %s", *job.ID, err))
+			return 1
+		}
+		c.Ui.Output(fmt.Sprintf("%s\n", formatJobDiff(resp.Diff, false)))
+	}
+
+	resp, _, err := client.Jobs().RegisterBundle(jobs, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error submitting job bundle: %s", err))
+		return 1
+	}
+
+	// Print any warnings if there are any
+	if resp.Warnings != "" {
+		c.Ui.Output(
+			c.Colorize().Color(fmt.Sprintf("[bold][yellow]Job Warnings:\n%s[reset]\n", resp.Warnings)))
+	}
+
+	c.Ui.Output("Bundle registration successful")
+	c.Ui.Output("Bundle ID: " + resp.BundleID)
+	for _, job := range jobs {
+		if evalID, ok := resp.JobEvals[*job.ID]; ok {
+			c.Ui.Output(fmt.Sprintf("Evaluation ID for job %q: %s", *job.ID, evalID))
+		}
+	}
+	if detach {
+		return 0
+	}
+
+	// Monitor the evaluation of each job, returning the worst exit code
+	code := 0
+	for _, job := range jobs {
+		evalID, ok := resp.JobEvals[*job.ID]
+		if !ok {
+			continue
+		}
+		mon := newMonitor(c.Ui, client, length)
+		if r := mon.monitor(evalID); r > code {
+			code = r
+		}
+	}
+	return code
+}
+
 // parseCheckIndex parses the check-index flag and returns the index, whether it
 // was set and potentially an error during parsing.
 func parseCheckIndex(input string) (uint64, bool, error) {
@@ -3,12 +3,14 @@ package command
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRunCommand_Implements(t *testing.T) {
@@ -215,3 +217,60 @@ func TestRunCommand_From_URL(t *testing.T) {
 		t.Fatalf("expected error getting jobfile, got: %s", out)
 	}
 }
+
+func TestRunCommand_Bundle(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	dir := t.TempDir()
+	for _, name := range []string{"db", "web"} {
+		job := `
+job "` + name + `" {
+	type = "service"
+	datacenters = [ "dc1" ]
+	group "group1" {
+		task "task1" {
+			driver = "exec"
+			resources {
+				cpu = 100
+				memory = 64
+			}
+		}
+	}
+}`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".nomad"), []byte(job), 0644))
+	}
+
+	ui := cli.NewMockUi()
+	cmd := &JobRunCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Fails with flags that don't apply to bundles
+	code := cmd.Run([]string{"-bundle", "-check-index=0", dir})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "can't be used with")
+	ui.ErrorWriter.Reset()
+
+	// Fails without job files
+	code = cmd.Run([]string{"-bundle", t.TempDir()})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "No job files found")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-bundle", "-detach", dir})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "Bundle registration successful")
+	require.Contains(t, out, `Evaluation ID for job "db"`)
+	require.Contains(t, out, `Evaluation ID for job "web"`)
+
+	// Both jobs were registered with the same bundle ID
+	db, _, err := client.Jobs().Info("db", nil)
+	require.NoError(t, err)
+	web, _, err := client.Jobs().Info("web", nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, *db.BundleID)
+	require.Equal(t, *db.BundleID, *web.BundleID)
+	require.Equal(t, *db.JobModifyIndex, *web.JobModifyIndex)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/mitchellh/cli"
@@ -46,6 +47,12 @@ Scale Options:
     the evaluation ID will be printed to the screen, which can be used to
     examine the evaluation using the eval-status command.
 
+  -schedule-at=<time>
+    Defer the count change until the given RFC3339 timestamp, for example
+    "2030-01-02T15:04:05Z". The change is applied by the Nomad servers at that
+    time and recorded as a scaling event for the job. The command returns once
+    the scaling action has been scheduled.
+
   -verbose
     Display full information.
 `
@@ -60,8 +67,9 @@ func (j *JobScaleCommand) Synopsis() string {
 func (j *JobScaleCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(j.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-detach":  complete.PredictNothing,
-			"-verbose": complete.PredictNothing,
+			"-detach":      complete.PredictNothing,
+			"-schedule-at": complete.PredictAnything,
+			"-verbose":     complete.PredictNothing,
 		})
 }
 
@@ -71,11 +79,13 @@ func (j *JobScaleCommand) Name() string { return "job scale" }
 // Run satisfies the cli.Command Run function.
 func (j *JobScaleCommand) Run(args []string) int {
 	var detach, verbose bool
+	var scheduleAtString string
 
 	flags := j.Meta.FlagSet(j.Name(), FlagSetClient)
 	flags.Usage = func() { j.Ui.Output(j.Help()) }
 	flags.BoolVar(&detach, "detach", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.StringVar(&scheduleAtString, "schedule-at", "", "")
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
@@ -103,6 +113,15 @@ func (j *JobScaleCommand) Run(args []string) int {
 		return 1
 	}
 
+	var scheduleAt time.Time
+	if scheduleAtString != "" {
+		scheduleAt, err = time.Parse(time.RFC3339, scheduleAtString)
+		if err != nil {
+			j.Ui.Error(fmt.Sprintf("Failed to parse schedule time as RFC3339: %s", err))
+			return 1
+		}
+	}
+
 	// Get the HTTP client.
 	client, err := j.Meta.Client()
 	if err != nil {
@@ -126,6 +145,19 @@ func (j *JobScaleCommand) Run(args []string) int {
 	// This is our default message added to scaling submissions.
 	msg := "submitted using the Nomad CLI"
 
+	// Scheduled scaling actions don't create an evaluation until they are
+	// applied, so there is nothing to monitor.
+	if !scheduleAt.IsZero() {
+		_, _, err := client.Jobs().ScaleAt(jobString, groupString, count, scheduleAt, msg, nil, nil)
+		if err != nil {
+			j.Ui.Error(fmt.Sprintf("Error submitting scaling request: %s", err))
+			return 1
+		}
+		j.Ui.Output(fmt.Sprintf("Scaling of group %q to %d scheduled for %s",
+			groupString, count, formatTime(scheduleAt)))
+		return 0
+	}
+
 	// Perform the scaling action.
 	resp, _, err := client.Jobs().Scale(jobString, groupString, &count, msg, false, nil, nil)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
@@ -122,3 +123,33 @@ func TestJobScaleCommand_MultiGroup(t *testing.T) {
 		t.Fatalf("Expected Evaluation ID within output: %v", out)
 	}
 }
+
+func TestJobScaleCommand_ScheduleAt(t *testing.T) {
+	ci.Parallel(t)
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &JobScaleCommand{Meta: Meta{Ui: ui}}
+
+	// Invalid timestamps are rejected before contacting the servers.
+	if code := cmd.Run([]string{"-address=" + url, "-schedule-at=tomorrow", "scale_cmd_schedule_at", "2"}); code != 1 {
+		t.Fatalf("expected cmd run exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Failed to parse schedule time") {
+		t.Fatalf("Expected parse error within output: %v", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	if _, _, err := client.Jobs().Register(testJob("scale_cmd_schedule_at"), nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	scheduleAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	if code := cmd.Run([]string{"-address=" + url, "-schedule-at=" + scheduleAt, "scale_cmd_schedule_at", "2"}); code != 0 {
+		t.Fatalf("expected cmd run exit code 0, got: %d: %s", code, ui.ErrorWriter.String())
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, "scheduled for") {
+		t.Fatalf("Expected scheduled message within output: %v", out)
+	}
+}
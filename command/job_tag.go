@@ -0,0 +1,185 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobTagCommand struct {
+	Meta
+}
+
+func (c *JobTagCommand) Help() string {
+	helpText := `
+Usage: nomad job tag [options] <job> <tag>
+
+  Tag is used to give a name to a version of a job. Tagged versions are not
+  garbage collected when the job is updated, and the tag can be used in place
+  of the version number with the "nomad job revert" command. Tag names must be
+  unique among the versions of the job and can't be numbers.
+
+  When ACLs are enabled, this command requires a token with the 'submit-job',
+  'list-jobs', and 'read-job' capabilities for the job's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Tag Options:
+
+  -version
+    The version of the job to tag. Defaults to the current version of the job.
+
+  -description
+    A description of the tagged version.
+
+  -unset
+    Remove the tag from the version it is set on instead of setting it.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobTagCommand) Synopsis() string {
+	return "Tag a version of a job"
+}
+
+func (c *JobTagCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-version":     complete.PredictAnything,
+			"-description": complete.PredictAnything,
+			"-unset":       complete.PredictNothing,
+		})
+}
+
+func (c *JobTagCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobTagCommand) Name() string { return "job tag" }
+
+func (c *JobTagCommand) Run(args []string) int {
+	var unset bool
+	var versionStr, description string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&versionStr, "version", "", "")
+	flags.StringVar(&description, "description", "", "")
+	flags.BoolVar(&unset, "unset", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got two args
+	args = flags.Args()
+	if l := len(args); l != 2 {
+		c.Ui.Error("This command takes two arguments: <job> <tag>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if unset && (versionStr != "" || description != "") {
+		c.Ui.Error("The -unset flag can't be used with the -version or -description flags")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	version, versionSet, err := parseVersion(versionStr)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing version value %q: %v", versionStr, err))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobID := strings.TrimSpace(args[0])
+	tag := strings.TrimSpace(args[1])
+	jobs, _, err := client.Jobs().PrefixList(jobID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing jobs: %s", err))
+		return 1
+	}
+	if len(jobs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No job(s) with prefix or id %q found", jobID))
+		return 1
+	}
+	if len(jobs) > 1 {
+		if (jobID != jobs[0].ID) || (c.allNamespaces() && jobs[0].ID == jobs[1].ID) {
+			c.Ui.Error(fmt.Sprintf("Prefix matched multiple jobs\n\n%s", createStatusListOutput(jobs, c.allNamespaces())))
+			return 1
+		}
+	}
+
+	// Prefix lookup matched a single job
+	jobID = jobs[0].ID
+	namespace := jobs[0].JobSummary.Namespace
+	q := &api.WriteOptions{Namespace: namespace}
+
+	if unset {
+		versions, _, _, err := client.Jobs().Versions(jobID, false, &api.QueryOptions{Namespace: namespace})
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error retrieving job versions: %s", err))
+			return 1
+		}
+		tagged, ok := versionByTag(versions, tag)
+		if !ok {
+			c.Ui.Error(fmt.Sprintf("No version of job %q is tagged %q", jobID, tag))
+			return 1
+		}
+		if _, err := client.Jobs().UntagVersion(jobID, tagged, q); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error removing version tag: %s", err))
+			return 1
+		}
+		c.Ui.Output(fmt.Sprintf("Removed tag %q from version %d of job %q", tag, tagged, jobID))
+		return 0
+	}
+
+	if !versionSet {
+		job, _, err := client.Jobs().Info(jobID, &api.QueryOptions{Namespace: namespace})
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error retrieving job: %s", err))
+			return 1
+		}
+		version = *job.Version
+	}
+
+	if _, err := client.Jobs().TagVersion(jobID, version, tag, description, q); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error tagging job version: %s", err))
+		return 1
+	}
+	c.Ui.Output(fmt.Sprintf("Tagged version %d of job %q as %q", version, jobID, tag))
+	return 0
+}
+
+// versionByTag returns the version of the job tagged with the given name.
+func versionByTag(versions []*api.Job, tag string) (uint64, bool) {
+	for _, v := range versions {
+		if v.VersionTag != nil && v.VersionTag.Name == tag {
+			return *v.Version, true
+		}
+	}
+	return 0, false
+}
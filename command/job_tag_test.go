@@ -0,0 +1,85 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobTagCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobTagCommand{}
+}
+
+func TestJobTagCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &JobTagCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-unset", "-version=1", "foo", "golden"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "can't be used with")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=nope", "foo", "golden"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error listing jobs")
+	ui.ErrorWriter.Reset()
+}
+
+func TestJobTagCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Create a job with two versions
+	state := srv.Agent.Server().State()
+	j := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, j))
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, j.Copy()))
+
+	ui := cli.NewMockUi()
+	cmd := &JobTagCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Tag the first version
+	code := cmd.Run([]string{"-address=" + url, "-version=0", "-description=known good", j.ID, "golden"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), `Tagged version 0 of job "`+j.ID+`" as "golden"`)
+
+	versions, _, _, err := client.Jobs().Versions(j.ID, false, nil)
+	require.NoError(t, err)
+	v, ok := versionByTag(versions, "golden")
+	require.True(t, ok)
+	require.Equal(t, uint64(0), v)
+
+	// Tag the current version by default
+	code = cmd.Run([]string{"-address=" + url, j.ID, "latest"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	job, _, err := client.Jobs().Info(j.ID, nil)
+	require.NoError(t, err)
+	require.Equal(t, &api.JobVersionTag{Name: "latest", TaggedTime: job.VersionTag.TaggedTime}, job.VersionTag)
+
+	// Remove the tag
+	code = cmd.Run([]string{"-address=" + url, "-unset", j.ID, "latest"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	job, _, err = client.Jobs().Info(j.ID, nil)
+	require.NoError(t, err)
+	require.Nil(t, job.VersionTag)
+
+	code = cmd.Run([]string{"-address=" + url, "-unset", j.ID, "latest"})
+	require.Equal(t, 1, code)
+	require.True(t, strings.Contains(ui.ErrorWriter.String(), "is tagged"))
+}
@@ -33,6 +33,10 @@ Usage: nomad namespace <subcommand> [options] [args]
 
       $ nomad namespace status <name>
 
+  Watch the resources allocated to each namespace:
+
+      $ nomad namespace usage -watch -sort cpu
+
   Please see the individual subcommand help for detailed usage information.
 `
 
@@ -220,6 +220,7 @@ func parseNamespaceSpecImpl(result *api.Namespace, list *ast.ObjectList) error {
 	}
 
 	delete(m, "capabilities")
+	delete(m, "job_limits")
 	delete(m, "meta")
 
 	// Decode the rest
@@ -243,6 +244,22 @@ func parseNamespaceSpecImpl(result *api.Namespace, list *ast.ObjectList) error {
 		}
 	}
 
+	lObj := list.Filter("job_limits")
+	if len(lObj.Items) > 0 {
+		for _, o := range lObj.Elem().Items {
+			ot, ok := o.Val.(*ast.ObjectType)
+			if !ok {
+				break
+			}
+			var opts *api.NamespaceJobLimits
+			if err := hcl.DecodeObject(&opts, ot.List); err != nil {
+				return err
+			}
+			result.JobLimits = opts
+			break
+		}
+	}
+
 	if metaO := list.Filter("meta"); len(metaO.Items) > 0 {
 		for _, o := range metaO.Elem().Items {
 			var m map[string]interface{}
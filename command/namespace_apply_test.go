@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/mitchellh/cli"
 	"github.com/stretchr/testify/assert"
@@ -57,3 +58,33 @@ func TestNamespaceApplyCommand_Good(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Len(t, namespaces, 2)
 }
+
+func TestNamespaceApplyCommand_parseNamespaceSpec(t *testing.T) {
+	ci.Parallel(t)
+
+	spec := `
+name        = "batch"
+description = "Batch jobs"
+
+capabilities {
+  enabled_task_drivers = ["docker"]
+}
+
+job_limits {
+  max_job_size_bytes  = 1048576
+  max_groups          = 10
+  max_tasks_per_group = 5
+  max_group_count     = 100
+}
+`
+	ns, err := parseNamespaceSpec([]byte(spec))
+	assert.NoError(t, err)
+	assert.Equal(t, "batch", ns.Name)
+	assert.Equal(t, []string{"docker"}, ns.Capabilities.EnabledTaskDrivers)
+	assert.Equal(t, &api.NamespaceJobLimits{
+		MaxJobSizeBytes:  1048576,
+		MaxGroups:        10,
+		MaxTasksPerGroup: 5,
+		MaxGroupCount:    100,
+	}, ns.JobLimits)
+}
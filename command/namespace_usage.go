@@ -0,0 +1,249 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/term"
+	"github.com/gosuri/uilive"
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+const (
+	// namespaceUsageSortName, namespaceUsageSortAllocs, namespaceUsageSortCPU
+	// and namespaceUsageSortMemory are the fields the namespace usage can be
+	// sorted by.
+	namespaceUsageSortName   = "name"
+	namespaceUsageSortAllocs = "allocs"
+	namespaceUsageSortCPU    = "cpu"
+	namespaceUsageSortMemory = "memory"
+
+	// namespaceUsageWatchInterval is the minimum interval between updates of
+	// the namespace usage when watching it.
+	namespaceUsageWatchInterval = time.Second
+)
+
+type NamespaceUsageCommand struct {
+	Meta
+}
+
+func (c *NamespaceUsageCommand) Help() string {
+	helpText := `
+Usage: nomad namespace usage [options]
+
+  Usage is used to view the resources allocated to the running allocations of
+  each namespace, and their share of the resources of the cluster.
+
+  If ACLs are enabled, this command requires a management ACL token to view
+  all namespaces. A non-management token can be used to view the usage of
+  namespaces for which it has an associated capability. The share of the
+  cluster is only shown if the token has node:read.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Usage Options:
+
+  -sort <field>
+    Sort the namespaces by the given field, in descending order for all but
+    the name. One of "name", "allocs", "cpu" or "memory". Defaults to "name".
+
+  -watch
+    Continuously update the usage as allocations and nodes change, until
+    interrupted.
+
+  -json
+    Output the namespace usage in a JSON format.
+
+  -t
+    Format and display the namespace usage using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NamespaceUsageCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-sort": complete.PredictSet(namespaceUsageSortName, namespaceUsageSortAllocs,
+				namespaceUsageSortCPU, namespaceUsageSortMemory),
+			"-watch": complete.PredictNothing,
+			"-json":  complete.PredictNothing,
+			"-t":     complete.PredictAnything,
+		})
+}
+
+func (c *NamespaceUsageCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *NamespaceUsageCommand) Synopsis() string {
+	return "Display the resources allocated to each namespace"
+}
+
+func (c *NamespaceUsageCommand) Name() string { return "namespace usage" }
+
+func (c *NamespaceUsageCommand) Run(args []string) int {
+	var json, watch bool
+	var tmpl, sortBy string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+	flags.StringVar(&sortBy, "sort", namespaceUsageSortName, "")
+	flags.BoolVar(&watch, "watch", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	args = flags.Args()
+	if l := len(args); l != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	switch sortBy {
+	case namespaceUsageSortName, namespaceUsageSortAllocs, namespaceUsageSortCPU, namespaceUsageSortMemory:
+	default:
+		c.Ui.Error(fmt.Sprintf("Invalid -sort field %q", sortBy))
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Check that json or tmpl isn't set with watch
+	if watch && (json || len(tmpl) > 0) {
+		c.Ui.Error("The -json and -t options cannot be used with -watch")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	if watch {
+		return c.watch(client, sortBy)
+	}
+
+	usage, _, err := client.Namespaces().Usage(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving namespace usage: %s", err))
+		return 1
+	}
+	sortNamespaceUsage(usage.Namespaces, sortBy)
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, usage)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(formatNamespaceUsage(usage))
+	return 0
+}
+
+// watch continuously outputs the namespace usage, using blocking queries to
+// wait for changes. It prints in place if stdout is a terminal.
+func (c *NamespaceUsageCommand) watch(client *api.Client, sortBy string) int {
+	writer := uilive.New()
+	writer.Start()
+	defer writer.Stop()
+
+	_, isStdoutTerminal := term.GetFdInfo(os.Stdout)
+
+	q := &api.QueryOptions{
+		AllowStale: true,
+		WaitTime:   5 * time.Minute,
+	}
+
+	for {
+		start := time.Now()
+		usage, meta, err := client.Namespaces().Usage(q)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error retrieving namespace usage: %s", err))
+			return 1
+		}
+
+		// Only render when the usage changed
+		if meta.LastIndex != q.WaitIndex {
+			sortNamespaceUsage(usage.Namespaces, sortBy)
+			msg := fmt.Sprintf("%s\n\n%s\n", formatTime(time.Now()), formatNamespaceUsage(usage))
+			if isStdoutTerminal {
+				fmt.Fprint(writer, msg)
+			} else {
+				c.Ui.Output(msg)
+			}
+		}
+		q.WaitIndex = meta.LastIndex
+
+		// Limit the rate of updates of busy clusters
+		time.Sleep(namespaceUsageWatchInterval - time.Since(start))
+	}
+}
+
+// sortNamespaceUsage sorts the namespace usage by the given field. All fields
+// but the name sort in descending order, with ties sorted by name.
+func sortNamespaceUsage(usages []*api.NamespaceUsage, sortBy string) {
+	sort.Slice(usages, func(i, j int) bool {
+		a, b := usages[i], usages[j]
+		switch sortBy {
+		case namespaceUsageSortAllocs:
+			if a.Allocations != b.Allocations {
+				return a.Allocations > b.Allocations
+			}
+		case namespaceUsageSortCPU:
+			if a.CPU != b.CPU {
+				return a.CPU > b.CPU
+			}
+		case namespaceUsageSortMemory:
+			if a.MemoryMB != b.MemoryMB {
+				return a.MemoryMB > b.MemoryMB
+			}
+		}
+		return a.Namespace < b.Namespace
+	})
+}
+
+func formatNamespaceUsage(usage *api.NamespaceUsageResponse) string {
+	if len(usage.Namespaces) == 0 {
+		return "No namespaces found"
+	}
+
+	rows := make([]string, len(usage.Namespaces)+1)
+	rows[0] = "Name|Allocations|CPU (MHz)|CPU Share|Memory (MiB)|Memory Share"
+	for i, ns := range usage.Namespaces {
+		rows[i+1] = fmt.Sprintf("%s|%d|%d|%s|%d|%s",
+			ns.Namespace,
+			ns.Allocations,
+			ns.CPU,
+			formatClusterShare(ns.CPU, usage.ClusterCPU),
+			ns.MemoryMB,
+			formatClusterShare(ns.MemoryMB, usage.ClusterMemoryMB))
+	}
+	return formatList(rows)
+}
+
+// formatClusterShare formats the share of the cluster resources that is
+// allocated, or "-" if the cluster resources are unknown.
+func formatClusterShare(allocated, cluster int64) string {
+	if cluster <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(allocated)/float64(cluster)*100)
+}
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+var _ cli.Command = (*NamespaceUsageCommand)(nil)
+
+func TestNamespaceUsageCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &NamespaceUsageCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-sort=disk"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), `Invalid -sort field "disk"`)
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-watch", "-json"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "cannot be used with -watch")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=nope"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error retrieving namespace usage")
+	ui.ErrorWriter.Reset()
+}
+
+func TestNamespaceUsageCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &NamespaceUsageCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "CPU Share")
+	require.Contains(t, out, "default")
+	ui.OutputWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-json"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out = ui.OutputWriter.String()
+	require.Contains(t, out, "ClusterMemoryMB")
+	ui.OutputWriter.Reset()
+}
+
+func TestNamespaceUsageCommand_Format(t *testing.T) {
+	ci.Parallel(t)
+
+	usage := &api.NamespaceUsageResponse{
+		Namespaces: []*api.NamespaceUsage{
+			{Namespace: "batch", Allocations: 10, CPU: 500, MemoryMB: 4096},
+			{Namespace: "default", Allocations: 2, CPU: 1000, MemoryMB: 512},
+			{Namespace: "web", Allocations: 2, CPU: 250, MemoryMB: 256},
+		},
+		ClusterCPU:      4000,
+		ClusterMemoryMB: 8192,
+	}
+
+	sortNamespaceUsage(usage.Namespaces, namespaceUsageSortCPU)
+	require.Equal(t, "default", usage.Namespaces[0].Namespace)
+
+	sortNamespaceUsage(usage.Namespaces, namespaceUsageSortAllocs)
+	require.Equal(t, "batch", usage.Namespaces[0].Namespace)
+	require.Equal(t, "default", usage.Namespaces[1].Namespace)
+
+	out := formatNamespaceUsage(usage)
+	lines := strings.Split(out, "\n")
+	require.Len(t, lines, 4)
+	require.Contains(t, lines[1], "12.5%")
+	require.Contains(t, lines[1], "50.0%")
+
+	// The share is unknown without the cluster resources
+	usage.ClusterCPU, usage.ClusterMemoryMB = 0, 0
+	require.NotContains(t, formatNamespaceUsage(usage), "%")
+}
@@ -0,0 +1,68 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type OperatorGossipCommand struct {
+	Meta
+}
+
+func (c *OperatorGossipCommand) Help() string {
+	helpText := `
+Usage: nomad operator gossip <subcommand> [options]
+
+  This command groups subcommands for interacting with the gossip layer used
+  between Nomad servers.
+
+  Rotate the gossip encryption key:
+
+      $ nomad operator gossip keyring rotate
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorGossipCommand) Synopsis() string {
+	return "Provides access to the gossip subsystem"
+}
+
+func (c *OperatorGossipCommand) Name() string { return "operator gossip" }
+
+func (c *OperatorGossipCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+type OperatorGossipKeyringCommand struct {
+	Meta
+}
+
+func (c *OperatorGossipKeyringCommand) Help() string {
+	helpText := `
+Usage: nomad operator gossip keyring <subcommand> [options]
+
+  This command groups subcommands for managing the encryption keys used for
+  gossip messages between Nomad servers. Individual keys can still be
+  installed, used, and removed with the "nomad operator keyring" command.
+
+  Rotate the gossip encryption key:
+
+      $ nomad operator gossip keyring rotate
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorGossipKeyringCommand) Synopsis() string {
+	return "Manages gossip layer encryption keys"
+}
+
+func (c *OperatorGossipKeyringCommand) Name() string { return "operator gossip keyring" }
+
+func (c *OperatorGossipKeyringCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,177 @@
+package command
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+// OperatorGossipKeyringRotateCommand is a Command implementation that rotates
+// the gossip encryption key by installing a new key, verifying it reached
+// every server, making it primary, and removing all other keys.
+type OperatorGossipKeyringRotateCommand struct {
+	Meta
+}
+
+func (c *OperatorGossipKeyringRotateCommand) Help() string {
+	helpText := `
+Usage: nomad operator gossip keyring rotate [options] [<key>]
+
+  Rotates the encryption key used for gossip messages between Nomad servers.
+  The rotation is performed in the following steps, stopping at the first
+  failure:
+
+    1. Install the new key on all servers.
+    2. Verify that every server has the new key installed.
+    3. Make the new key the primary encryption key.
+    4. Remove all other keys from the keyring.
+
+  If no key is given, a new 32-byte key is generated and printed. Servers
+  persist their keyring in the data directory, so the "encrypt" configuration
+  value only needs updating for servers that start with an empty data
+  directory.
+
+  If ACLs are enabled, this command requires a token with the 'agent:write'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorGossipKeyringRotateCommand) Synopsis() string {
+	return "Rotates the gossip encryption key"
+}
+
+func (c *OperatorGossipKeyringRotateCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *OperatorGossipKeyringRotateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *OperatorGossipKeyringRotateCommand) Name() string {
+	return "operator gossip keyring rotate"
+}
+
+func (c *OperatorGossipKeyringRotateCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Accept at most a single key argument
+	args = flags.Args()
+	if len(args) > 1 {
+		c.Ui.Error("This command takes at most one argument: [<key>]")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	var newKey string
+	if len(args) == 1 {
+		newKey = args[0]
+	} else {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading random data: %s", err))
+			return 1
+		}
+		newKey = base64.StdEncoding.EncodeToString(key)
+		c.Ui.Output(fmt.Sprintf("Generated new gossip encryption key: %s", newKey))
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating nomad cli client: %s", err))
+		return 1
+	}
+	agent := client.Agent()
+
+	c.Ui.Output("==> Gathering installed encryption keys...")
+	resp, err := agent.ListKeys()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing keys: %s", err))
+		return 1
+	}
+	oldKeys := make([]string, 0, len(resp.Keys))
+	for k := range resp.Keys {
+		if k != newKey {
+			oldKeys = append(oldKeys, k)
+		}
+	}
+	sort.Strings(oldKeys)
+
+	c.Ui.Output("==> Installing new gossip encryption key...")
+	if resp, err = agent.InstallKey(newKey); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error installing key: %s", err))
+		return 1
+	}
+	if !c.reportMessages(resp) {
+		return 1
+	}
+
+	c.Ui.Output("==> Verifying key propagation...")
+	if resp, err = agent.ListKeys(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing keys: %s", err))
+		return 1
+	}
+	if installed := resp.Keys[newKey]; installed < resp.NumNodes {
+		c.Ui.Error(fmt.Sprintf(
+			"New key is installed on %d of %d servers; not making it primary",
+			installed, resp.NumNodes))
+		c.Ui.Error("Re-run this command with the same key once all servers are reachable")
+		return 1
+	}
+	c.Ui.Output(fmt.Sprintf("    New key is installed on all %d servers", resp.NumNodes))
+
+	c.Ui.Output("==> Changing primary gossip encryption key...")
+	if resp, err = agent.UseKey(newKey); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error changing primary key: %s", err))
+		return 1
+	}
+	if !c.reportMessages(resp) {
+		return 1
+	}
+
+	for _, oldKey := range oldKeys {
+		c.Ui.Output(fmt.Sprintf("==> Removing gossip encryption key %s...", oldKey))
+		if resp, err = agent.RemoveKey(oldKey); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error removing key: %s", err))
+			return 1
+		}
+		if !c.reportMessages(resp) {
+			return 1
+		}
+	}
+
+	c.Ui.Output("==> Gossip encryption key rotated successfully")
+	return 0
+}
+
+// reportMessages outputs any per-server error messages from a keyring
+// operation and returns false if there were any.
+func (c *OperatorGossipKeyringRotateCommand) reportMessages(resp *api.KeyringResponse) bool {
+	if len(resp.Messages) == 0 {
+		return true
+	}
+	servers := make([]string, 0, len(resp.Messages))
+	for server := range resp.Messages {
+		servers = append(servers, server)
+	}
+	sort.Strings(servers)
+	for _, server := range servers {
+		c.Ui.Error(fmt.Sprintf("    %s: %s", server, resp.Messages[server]))
+	}
+	return false
+}
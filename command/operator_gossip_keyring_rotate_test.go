@@ -0,0 +1,50 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorGossipKeyringRotateCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorGossipKeyringRotateCommand{}
+}
+
+func TestOperatorGossipKeyringRotateCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &OperatorGossipKeyringRotateCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on too many arguments
+	code := cmd.Run([]string{"foo", "bar"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "This command takes at most one argument")
+}
+
+func TestOperatorGossipKeyringRotateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	oldKey := "HS5lJ+XuTlYKWaeGYyG+/A=="
+	newKey := "fcqS/uv6OJ2eTHLxzV8zqA=="
+
+	srv, client, url := testServer(t, false, func(c *agent.Config) {
+		c.Server.EncryptKey = oldKey
+	})
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorGossipKeyringRotateCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, newKey})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "rotated successfully")
+
+	resp, err := client.Agent().ListKeys()
+	require.NoError(t, err)
+	require.Len(t, resp.Keys, 1)
+	require.Contains(t, resp.Keys, newKey)
+}
@@ -0,0 +1,41 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type OperatorSchedulerCommand struct {
+	Meta
+}
+
+func (c *OperatorSchedulerCommand) Name() string { return "operator scheduler" }
+
+func (c *OperatorSchedulerCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *OperatorSchedulerCommand) Synopsis() string {
+	return "Provides tools for inspecting the scheduler"
+}
+
+func (c *OperatorSchedulerCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler <subcommand> [options]
+
+  This command groups subcommands for inspecting how the Nomad schedulers
+  place allocations on the nodes of the cluster.
+
+  Report how fragmented the free capacity of each datacenter is:
+
+      $ nomad operator scheduler fragmentation
+
+  Include candidate migrations that would free up whole nodes:
+
+      $ nomad operator scheduler fragmentation -suggest
+
+  Please see the individual subcommand help for detailed usage information.
+  `
+	return strings.TrimSpace(helpText)
+}
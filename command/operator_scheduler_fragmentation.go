@@ -0,0 +1,197 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type OperatorSchedulerFragmentationCommand struct {
+	Meta
+}
+
+func (c *OperatorSchedulerFragmentationCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler fragmentation [options]
+
+  Reports how fragmented the free capacity of the nodes in each datacenter is.
+  The free capacity is measured against the most common CPU and memory shapes
+  of the running service and batch allocations. Capacity is stranded when the
+  node it is on can't fit an allocation of any of these shapes.
+
+  The report is computed by the leader from the current state of the cluster.
+
+  If ACLs are enabled, this command requires a token with the 'operator:read'
+  capability. Suggesting migrations also requires the 'node:read' capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Fragmentation Options:
+
+  -suggest
+    Include candidate migrations that would free up whole nodes by moving
+    their allocations to the free capacity of other nodes. Only CPU and memory
+    are considered, so constraints, ports and devices must be reviewed before
+    acting on a suggestion.
+
+  -verbose
+    Display full information.
+
+  -json
+    Output the report in its JSON format.
+
+  -t
+    Format and display the report using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorSchedulerFragmentationCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-suggest": complete.PredictNothing,
+			"-verbose": complete.PredictNothing,
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+		})
+}
+
+func (c *OperatorSchedulerFragmentationCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorSchedulerFragmentationCommand) Synopsis() string {
+	return "Report how fragmented the free capacity of the cluster is"
+}
+
+func (c *OperatorSchedulerFragmentationCommand) Name() string {
+	return "operator scheduler fragmentation"
+}
+
+func (c *OperatorSchedulerFragmentationCommand) Run(args []string) int {
+	var suggest, verbose, json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&suggest, "suggest", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	args = flags.Args()
+	if l := len(args); l != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	report, _, err := client.Operator().SchedulerGetFragmentation(suggest, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving fragmentation report: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, report)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	c.Ui.Output(c.Colorize().Color("[bold]Allocation Shapes[reset]"))
+	c.Ui.Output(formatAllocShapes(report.Shapes))
+
+	dcs := make([]string, 0, len(report.Datacenters))
+	for dc := range report.Datacenters {
+		dcs = append(dcs, dc)
+	}
+	sort.Strings(dcs)
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Datacenters[reset]"))
+	c.Ui.Output(formatDatacenterFragmentation(dcs, report.Datacenters))
+
+	if suggest {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Suggested Migrations[reset]"))
+		c.Ui.Output(formatSchedulerMigrations(dcs, report.Datacenters, length))
+	}
+	return 0
+}
+
+func formatAllocShapes(shapes []*api.SchedulerAllocShape) string {
+	if len(shapes) == 0 {
+		return "No running service or batch allocations"
+	}
+
+	rows := make([]string, len(shapes)+1)
+	rows[0] = "Shape|CPU (MHz)|Memory (MiB)|Allocations"
+	for i, shape := range shapes {
+		rows[i+1] = fmt.Sprintf("%d|%d|%d|%d",
+			i+1, shape.CPU, shape.MemoryMB, shape.Count)
+	}
+	return formatList(rows)
+}
+
+func formatDatacenterFragmentation(dcs []string, reports map[string]*api.SchedulerDatacenterFragmentation) string {
+	if len(dcs) == 0 {
+		return "No nodes eligible for scheduling"
+	}
+
+	rows := make([]string, len(dcs)+1)
+	rows[0] = "Datacenter|Nodes|Free CPU (MHz)|Free Memory (MiB)|Stranded CPU (MHz)|Stranded Memory (MiB)|Placements by Shape"
+	for i, dc := range dcs {
+		r := reports[dc]
+		placements := make([]string, len(r.Placements))
+		for j, p := range r.Placements {
+			placements[j] = strconv.Itoa(p)
+		}
+		rows[i+1] = fmt.Sprintf("%s|%d|%d|%d|%d|%d|%s",
+			dc, r.Nodes, r.FreeCPU, r.FreeMemoryMB,
+			r.StrandedCPU, r.StrandedMemoryMB, strings.Join(placements, ", "))
+	}
+	return formatList(rows)
+}
+
+func formatSchedulerMigrations(dcs []string, reports map[string]*api.SchedulerDatacenterFragmentation, length int) string {
+	rows := []string{"Datacenter|Node ID|Alloc ID|Namespace|Job ID|Target Node ID"}
+	for _, dc := range dcs {
+		for _, m := range reports[dc].Migrations {
+			for _, alloc := range m.Allocs {
+				rows = append(rows, fmt.Sprintf("%s|%s|%s|%s|%s|%s",
+					dc, limit(m.NodeID, length), limit(alloc.ID, length),
+					alloc.Namespace, alloc.JobID, limit(alloc.TargetNodeID, length)))
+			}
+		}
+	}
+	if len(rows) == 1 {
+		return "No migrations would free up a node"
+	}
+	return formatList(rows)
+}
@@ -0,0 +1,74 @@
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorSchedulerFragmentationCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSchedulerFragmentationCommand{}
+}
+
+func TestOperatorSchedulerFragmentationCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, addr := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &OperatorSchedulerFragmentationCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	require.Equal(t, 1, cmd.Run([]string{"-address=" + addr, "extra"}))
+	require.Contains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+	ui.ErrorWriter.Reset()
+
+	// Without nodes or allocations there is nothing to report
+	require.Equal(t, 0, cmd.Run([]string{"-address=" + addr, "-suggest"}), ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "No running service or batch allocations")
+	require.Contains(t, out, "No nodes eligible for scheduling")
+	require.Contains(t, out, "No migrations would free up a node")
+	ui.OutputWriter.Reset()
+
+	// JSON output
+	require.Equal(t, 0, cmd.Run([]string{"-address=" + addr, "-json"}), ui.ErrorWriter.String())
+	var report api.SchedulerFragmentationResponse
+	require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &report))
+	require.Empty(t, report.Datacenters)
+}
+
+func TestFormatDatacenterFragmentation(t *testing.T) {
+	ci.Parallel(t)
+
+	reports := map[string]*api.SchedulerDatacenterFragmentation{
+		"dc1": {
+			Nodes:            3,
+			FreeCPU:          3400,
+			FreeMemoryMB:     4096,
+			StrandedMemoryMB: 512,
+			Placements:       []int{6, 2},
+			Migrations: []*api.SchedulerMigration{{
+				NodeID: "11111111-2222-3333-4444-555555555555",
+				Allocs: []*api.SchedulerMigrationAlloc{{
+					ID:           "aaaaaaaa-2222-3333-4444-555555555555",
+					Namespace:    "default",
+					JobID:        "web",
+					TargetNodeID: "bbbbbbbb-2222-3333-4444-555555555555",
+				}},
+			}},
+		},
+	}
+
+	out := formatDatacenterFragmentation([]string{"dc1"}, reports)
+	require.Contains(t, out, "Placements by Shape")
+	require.Regexp(t, `dc1\s+3\s+3400\s+4096\s+0\s+512\s+6, 2`, out)
+
+	out = formatSchedulerMigrations([]string{"dc1"}, reports, shortId)
+	require.Regexp(t, `dc1\s+11111111\s+aaaaaaaa\s+default\s+web\s+bbbbbbbb`, out)
+}
@@ -0,0 +1,142 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+)
+
+const (
+	// setupDefaultAuthMethod is the name of the auth method the setup
+	// commands create for Nomad workload identities.
+	setupDefaultAuthMethod = "nomad-workloads"
+)
+
+// setupClaimMappings maps the claims of workload identities to the metadata
+// of the Consul and Vault tokens they are exchanged for.
+var setupClaimMappings = map[string]string{
+	"nomad_namespace":     "nomad_namespace",
+	"nomad_job_id":        "nomad_job_id",
+	"nomad_task":          "nomad_task",
+	"nomad_allocation_id": "nomad_allocation_id",
+}
+
+type SetupCommand struct {
+	Meta
+}
+
+func (c *SetupCommand) Help() string {
+	helpText := `
+Usage: nomad setup <subcommand> [options] [args]
+
+  This command groups subcommands for configuring Consul and Vault to accept
+  the workload identities signed by the Nomad servers. Tasks exchange their
+  identities for Consul and Vault tokens through the JWT auth methods created
+  by these commands.
+
+  Configure Consul:
+
+      $ nomad setup consul -dry-run
+
+  Configure Vault:
+
+      $ nomad setup vault -dry-run
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *SetupCommand) Synopsis() string {
+	return "Configure Consul and Vault for Nomad workload identities"
+}
+
+func (c *SetupCommand) Name() string { return "setup" }
+
+func (c *SetupCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// setupIssuer is the OpenID Connect discovery document of the workload
+// identity issuer of the Nomad servers.
+type setupIssuer struct {
+	Issuer      string   `json:"issuer"`
+	JWKS        string   `json:"jwks_uri"`
+	SigningAlgs []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// workloadIdentityIssuer reads the discovery document of the workload
+// identity issuer from the Nomad servers.
+func workloadIdentityIssuer(client *api.Client) (*setupIssuer, error) {
+	var issuer setupIssuer
+	if _, err := client.Raw().Query("/.well-known/openid-configuration", &issuer, nil); err != nil {
+		return nil, err
+	}
+	if issuer.Issuer == "" || issuer.JWKS == "" {
+		return nil, fmt.Errorf("workload identity issuer has no issuer or JWKS URL")
+	}
+	return &issuer, nil
+}
+
+// readSetupCACert reads the PEM encoded CA certificate Consul or Vault use to
+// verify the JWKS URL, if any.
+func readSetupCACert(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// setupChange is a change made by the setup commands in Consul or Vault.
+type setupChange struct {
+	// desc describes the object created, such as `Consul ACL policy "foo"`
+	desc string
+
+	// exists is true if the object already exists, in which case it is
+	// left unchanged
+	exists bool
+
+	// body is the object created, printed on dry runs. Strings are printed
+	// as is, anything else as JSON.
+	body interface{}
+
+	// apply creates the object
+	apply func() error
+}
+
+// applySetupChange prints the change and applies it unless this is a dry run.
+// Objects that already exist are never modified, so that the commands can be
+// run again safely.
+func applySetupChange(ui cli.Ui, dryRun bool, change *setupChange) error {
+	if change.exists {
+		ui.Output(fmt.Sprintf("%s already exists, skipping", change.desc))
+		return nil
+	}
+
+	if dryRun {
+		body, ok := change.body.(string)
+		if !ok {
+			raw, err := json.MarshalIndent(change.body, "", "  ")
+			if err != nil {
+				return err
+			}
+			body = string(raw)
+		}
+		ui.Output(fmt.Sprintf("Would create %s:\n%s\n", change.desc, strings.TrimSpace(body)))
+		return nil
+	}
+
+	if err := change.apply(); err != nil {
+		return fmt.Errorf("failed to create %s: %v", change.desc, err)
+	}
+	ui.Output(fmt.Sprintf("Created %s", change.desc))
+	return nil
+}
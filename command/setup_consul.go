@@ -0,0 +1,271 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/posener/complete"
+)
+
+const (
+	// setupConsulDefaultAudience is the audience Consul expects workload
+	// identities to be signed for, unless configured otherwise.
+	setupConsulDefaultAudience = "consul.io"
+
+	// setupConsulTasks is the name of the ACL policy and role granted to the
+	// tokens tasks log in with.
+	setupConsulTasks = "nomad-tasks"
+
+	// setupConsulTasksRules are the rules of the ACL policy granted to tasks,
+	// which allows templates to read the KV store and the catalog.
+	setupConsulTasksRules = `key_prefix "" {
+  policy = "read"
+}
+
+service_prefix "" {
+  policy = "read"
+}
+`
+)
+
+type SetupConsulCommand struct {
+	Meta
+}
+
+func (c *SetupConsulCommand) Help() string {
+	helpText := `
+Usage: nomad setup consul [options]
+
+  Configures Consul to accept the workload identities signed by the Nomad
+  servers. The command creates a JWT auth method verifying the identities
+  against the keys published by the Nomad servers, an ACL policy and role
+  granting read access to the KV store and the catalog, and a binding rule
+  granting the role to the tokens tasks log in with.
+
+  Tasks log in with an identity signed for the audience of the auth method:
+
+      $ consul login -method nomad-workloads \
+          -bearer-token-file ${NOMAD_SECRETS_DIR}/nomad_consul.jwt \
+          -token-sink-file ${NOMAD_SECRETS_DIR}/consul_token
+
+  Objects that already exist in Consul are left unchanged. The Consul address
+  and token are read from the CONSUL_HTTP_ADDR and CONSUL_HTTP_TOKEN
+  environment variables. The token requires the "acl:write" permission.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Setup Consul Options:
+
+  -consul-address=<addr>
+    The address of the Consul agent. Overrides the CONSUL_HTTP_ADDR
+    environment variable.
+
+  -audience=<aud>
+    The audience workload identities must be signed for. Defaults to
+    "consul.io".
+
+  -auth-method=<name>
+    The name of the auth method to create. Defaults to "nomad-workloads".
+
+  -jwks-ca-file=<path>
+    The path to the PEM encoded CA certificate Consul verifies the JWKS URL of
+    the Nomad servers with.
+
+  -dry-run
+    Print the objects that would be created in Consul without creating them.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *SetupConsulCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-consul-address": complete.PredictAnything,
+			"-audience":       complete.PredictAnything,
+			"-auth-method":    complete.PredictAnything,
+			"-jwks-ca-file":   complete.PredictFiles("*"),
+			"-dry-run":        complete.PredictNothing,
+		})
+}
+
+func (c *SetupConsulCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *SetupConsulCommand) Synopsis() string {
+	return "Configure Consul for Nomad workload identities"
+}
+
+func (c *SetupConsulCommand) Name() string { return "setup consul" }
+
+func (c *SetupConsulCommand) Run(args []string) int {
+	var consulAddr, audience, authMethod, caFile string
+	var dryRun bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&consulAddr, "consul-address", "", "")
+	flags.StringVar(&audience, "audience", setupConsulDefaultAudience, "")
+	flags.StringVar(&authMethod, "auth-method", setupDefaultAuthMethod, "")
+	flags.StringVar(&caFile, "jwks-ca-file", "", "")
+	flags.BoolVar(&dryRun, "dry-run", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	caCert, err := readSetupCACert(caFile)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading JWKS CA certificate: %s", err))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	issuer, err := workloadIdentityIssuer(client)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading workload identity issuer: %s", err))
+		return 1
+	}
+
+	consulConf := consulapi.DefaultConfig()
+	if consulAddr != "" {
+		consulConf.Address = consulAddr
+	}
+	consul, err := consulapi.NewClient(consulConf)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing Consul client: %s", err))
+		return 1
+	}
+
+	changes, err := c.changes(consul.ACL(), issuer, audience, authMethod, caCert)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading Consul ACL objects: %s", err))
+		return 1
+	}
+	for _, change := range changes {
+		if err := applySetupChange(c.Ui, dryRun, change); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error configuring Consul: %s", err))
+			return 1
+		}
+	}
+	return 0
+}
+
+// changes returns the changes configuring Consul, in the order they must be
+// applied in.
+func (c *SetupConsulCommand) changes(acl *consulapi.ACL, issuer *setupIssuer, audience, authMethod, caCert string) ([]*setupChange, error) {
+	method, _, err := acl.AuthMethodRead(authMethod, nil)
+	if err != nil {
+		return nil, err
+	}
+	policy, _, err := acl.PolicyReadByName(setupConsulTasks, nil)
+	if err != nil {
+		return nil, err
+	}
+	role, _, err := acl.RoleReadByName(setupConsulTasks, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleExists := false
+	if method != nil {
+		rules, _, err := acl.BindingRuleList(authMethod, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			if rule.BindType == consulapi.BindingRuleBindTypeRole && rule.BindName == setupConsulTasks {
+				ruleExists = true
+			}
+		}
+	}
+
+	methodConfig := map[string]interface{}{
+		"JWKSURL":          issuer.JWKS,
+		"JWTSupportedAlgs": issuer.SigningAlgs,
+		"BoundIssuer":      issuer.Issuer,
+		"BoundAudiences":   []string{audience},
+		"ClaimMappings":    setupClaimMappings,
+	}
+	if caCert != "" {
+		methodConfig["JWKSCACert"] = caCert
+	}
+	newMethod := &consulapi.ACLAuthMethod{
+		Name:        authMethod,
+		Type:        "jwt",
+		DisplayName: "Nomad workload identities",
+		Description: "Login method for the workload identities of Nomad tasks",
+		Config:      methodConfig,
+	}
+	newPolicy := &consulapi.ACLPolicy{
+		Name:        setupConsulTasks,
+		Description: "Read access for Nomad tasks",
+		Rules:       setupConsulTasksRules,
+	}
+	newRole := &consulapi.ACLRole{
+		Name:        setupConsulTasks,
+		Description: "Role of Nomad tasks",
+		Policies:    []*consulapi.ACLRolePolicyLink{{Name: setupConsulTasks}},
+	}
+	newRule := &consulapi.ACLBindingRule{
+		Description: "Grants the Nomad tasks role to Nomad workload identities",
+		AuthMethod:  authMethod,
+		BindType:    consulapi.BindingRuleBindTypeRole,
+		BindName:    setupConsulTasks,
+	}
+
+	return []*setupChange{
+		{
+			desc:   fmt.Sprintf("Consul ACL auth method %q", authMethod),
+			exists: method != nil,
+			body:   newMethod,
+			apply: func() error {
+				_, _, err := acl.AuthMethodCreate(newMethod, nil)
+				return err
+			},
+		},
+		{
+			desc:   fmt.Sprintf("Consul ACL policy %q", setupConsulTasks),
+			exists: policy != nil,
+			body:   newPolicy.Rules,
+			apply: func() error {
+				_, _, err := acl.PolicyCreate(newPolicy, nil)
+				return err
+			},
+		},
+		{
+			desc:   fmt.Sprintf("Consul ACL role %q", setupConsulTasks),
+			exists: role != nil,
+			body:   newRole,
+			apply: func() error {
+				_, _, err := acl.RoleCreate(newRole, nil)
+				return err
+			},
+		},
+		{
+			desc:   fmt.Sprintf("Consul ACL binding rule for auth method %q", authMethod),
+			exists: ruleExists,
+			body:   newRule,
+			apply: func() error {
+				_, _, err := acl.BindingRuleCreate(newRule, nil)
+				return err
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,162 @@
+package command
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupConsulCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &SetupConsulCommand{}
+}
+
+// testSetupServer starts a Nomad server signing workload identities.
+func testSetupServer(t *testing.T) string {
+	_, _, url := testServer(t, false, func(c *agent.Config) {
+		c.Server.WorkloadIdentity = &agent.WorkloadIdentity{
+			Issuer: "https://nomad.example.com",
+		}
+	})
+	return url
+}
+
+// fakeSetupAPI records the writes made by the setup commands and answers
+// reads of missing objects with 404.
+type fakeSetupAPI struct {
+	l      sync.Mutex
+	writes map[string]map[string]interface{}
+	get    func(path string) interface{}
+}
+
+func (f *fakeSetupAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.l.Lock()
+	defer f.l.Unlock()
+
+	if r.Method == "GET" {
+		if f.get != nil {
+			if out := f.get(r.URL.Path); out != nil {
+				json.NewEncoder(w).Encode(out)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var body map[string]interface{}
+	json.NewDecoder(r.Body).Decode(&body)
+	f.writes[r.Method+" "+r.URL.Path] = body
+	w.Write([]byte("{}"))
+}
+
+func (f *fakeSetupAPI) written() map[string]map[string]interface{} {
+	f.l.Lock()
+	defer f.l.Unlock()
+	return f.writes
+}
+
+func TestSetupConsulCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &SetupConsulCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails without a workload identity issuer
+	_, _, url := testServer(t, false, nil)
+	code = cmd.Run([]string{"-address=" + url, "-dry-run"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "workload identities are not configured")
+}
+
+func TestSetupConsulCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	url := testSetupServer(t)
+
+	consul := &fakeSetupAPI{writes: map[string]map[string]interface{}{}}
+	consulSrv := httptest.NewServer(consul)
+	defer consulSrv.Close()
+
+	// Dry runs print the objects without creating them
+	ui := cli.NewMockUi()
+	cmd := &SetupConsulCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-consul-address=" + consulSrv.URL, "-dry-run"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, `Would create Consul ACL auth method "nomad-workloads"`)
+	require.Contains(t, out, `"JWKSURL": "https://nomad.example.com/.well-known/jwks.json"`)
+	require.Contains(t, out, `Would create Consul ACL policy "nomad-tasks"`)
+	require.Contains(t, out, `key_prefix ""`)
+	require.Contains(t, out, `Would create Consul ACL role "nomad-tasks"`)
+	require.Contains(t, out, `Would create Consul ACL binding rule for auth method "nomad-workloads"`)
+	require.Empty(t, consul.written())
+
+	// Objects are created in order
+	ui = cli.NewMockUi()
+	cmd = &SetupConsulCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-consul-address=" + consulSrv.URL, "-audience=nomad"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	writes := consul.written()
+	require.Len(t, writes, 4)
+	method := writes["PUT /v1/acl/auth-method"]
+	require.Equal(t, "jwt", method["Type"])
+	config := method["Config"].(map[string]interface{})
+	require.Equal(t, "https://nomad.example.com", config["BoundIssuer"])
+	require.Equal(t, []interface{}{"nomad"}, config["BoundAudiences"])
+	require.Equal(t, []interface{}{"ES256"}, config["JWTSupportedAlgs"])
+	require.Equal(t, "nomad-tasks", writes["PUT /v1/acl/policy"]["Name"])
+	require.Equal(t, "nomad-tasks", writes["PUT /v1/acl/role"]["Name"])
+	require.Equal(t, "role", writes["PUT /v1/acl/binding-rule"]["BindType"])
+	require.Equal(t, "nomad-tasks", writes["PUT /v1/acl/binding-rule"]["BindName"])
+}
+
+func TestSetupConsulCommand_Existing(t *testing.T) {
+	ci.Parallel(t)
+	url := testSetupServer(t)
+
+	// Existing objects are left unchanged
+	consul := &fakeSetupAPI{
+		writes: map[string]map[string]interface{}{},
+		get: func(path string) interface{} {
+			switch {
+			case path == "/v1/acl/binding-rules":
+				return []map[string]string{{"BindType": "role", "BindName": "nomad-tasks"}}
+			case strings.HasPrefix(path, "/v1/acl/policy/name/"):
+				return nil
+			default:
+				return map[string]string{"Name": "existing"}
+			}
+		},
+	}
+	consulSrv := httptest.NewServer(consul)
+	defer consulSrv.Close()
+
+	ui := cli.NewMockUi()
+	cmd := &SetupConsulCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-consul-address=" + consulSrv.URL})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, `Consul ACL auth method "nomad-workloads" already exists, skipping`)
+	require.Contains(t, out, `Created Consul ACL policy "nomad-tasks"`)
+	require.Contains(t, out, `Consul ACL role "nomad-tasks" already exists, skipping`)
+	require.Contains(t, out, `binding rule for auth method "nomad-workloads" already exists, skipping`)
+
+	writes := consul.written()
+	require.Len(t, writes, 1)
+	require.Contains(t, writes, "PUT /v1/acl/policy")
+}
@@ -0,0 +1,301 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/posener/complete"
+)
+
+const (
+	// setupVaultDefaultAudience is the audience Vault expects workload
+	// identities to be signed for, unless configured otherwise.
+	setupVaultDefaultAudience = "vault.io"
+
+	// setupVaultDefaultPath is the path the JWT auth method is enabled at,
+	// unless configured otherwise.
+	setupVaultDefaultPath = "jwt-nomad"
+
+	// setupVaultWorkloads is the name of the policy and role granted to the
+	// tokens tasks log in with.
+	setupVaultWorkloads = "nomad-workloads"
+
+	// setupVaultWorkloadsRules are the rules of the policy granted to tasks,
+	// which allows them to read the secrets under their namespace and job in
+	// the KV v2 secrets engine at "secret". The accessor of the auth method
+	// is substituted for %[1]s.
+	setupVaultWorkloadsRules = `path "secret/data/{{identity.entity.aliases.%[1]s.metadata.nomad_namespace}}/{{identity.entity.aliases.%[1]s.metadata.nomad_job_id}}/*" {
+  capabilities = ["read"]
+}
+
+path "secret/data/{{identity.entity.aliases.%[1]s.metadata.nomad_namespace}}/{{identity.entity.aliases.%[1]s.metadata.nomad_job_id}}" {
+  capabilities = ["read"]
+}
+
+path "secret/metadata/{{identity.entity.aliases.%[1]s.metadata.nomad_namespace}}/*" {
+  capabilities = ["list"]
+}
+`
+)
+
+type SetupVaultCommand struct {
+	Meta
+}
+
+func (c *SetupVaultCommand) Help() string {
+	helpText := `
+Usage: nomad setup vault [options]
+
+  Configures Vault to accept the workload identities signed by the Nomad
+  servers. The command enables a JWT auth method verifying the identities
+  against the keys published by the Nomad servers, and creates a role and a
+  policy granting tasks read access to the secrets under
+  "secret/<namespace>/<job>" of the KV v2 secrets engine.
+
+  Tasks log in with an identity signed for the audience of the role:
+
+      $ vault write auth/jwt-nomad/login role=nomad-workloads \
+          jwt=@${NOMAD_SECRETS_DIR}/nomad_vault.jwt
+
+  Objects that already exist in Vault are left unchanged. The Vault address
+  and token are read from the VAULT_ADDR and VAULT_TOKEN environment
+  variables. The token must be allowed to enable auth methods, and to write
+  policies and the configuration of the auth method.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Setup Vault Options:
+
+  -vault-address=<addr>
+    The address of the Vault server. Overrides the VAULT_ADDR environment
+    variable.
+
+  -audience=<aud>
+    The audience workload identities must be signed for. Defaults to
+    "vault.io".
+
+  -path=<path>
+    The path to enable the auth method at. Defaults to "jwt-nomad".
+
+  -jwks-ca-file=<path>
+    The path to the PEM encoded CA certificate Vault verifies the JWKS URL of
+    the Nomad servers with.
+
+  -dry-run
+    Print the objects that would be created in Vault without creating them.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *SetupVaultCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-vault-address": complete.PredictAnything,
+			"-audience":      complete.PredictAnything,
+			"-path":          complete.PredictAnything,
+			"-jwks-ca-file":  complete.PredictFiles("*"),
+			"-dry-run":       complete.PredictNothing,
+		})
+}
+
+func (c *SetupVaultCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *SetupVaultCommand) Synopsis() string {
+	return "Configure Vault for Nomad workload identities"
+}
+
+func (c *SetupVaultCommand) Name() string { return "setup vault" }
+
+func (c *SetupVaultCommand) Run(args []string) int {
+	var vaultAddr, audience, path, caFile string
+	var dryRun bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&vaultAddr, "vault-address", "", "")
+	flags.StringVar(&audience, "audience", setupVaultDefaultAudience, "")
+	flags.StringVar(&path, "path", setupVaultDefaultPath, "")
+	flags.StringVar(&caFile, "jwks-ca-file", "", "")
+	flags.BoolVar(&dryRun, "dry-run", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	if len(flags.Args()) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	path = strings.Trim(path, "/")
+
+	caCert, err := readSetupCACert(caFile)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading JWKS CA certificate: %s", err))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	issuer, err := workloadIdentityIssuer(client)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reading workload identity issuer: %s", err))
+		return 1
+	}
+
+	vaultConf := vaultapi.DefaultConfig()
+	if vaultAddr != "" {
+		vaultConf.Address = vaultAddr
+	}
+	vault, err := vaultapi.NewClient(vaultConf)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing Vault client: %s", err))
+		return 1
+	}
+
+	if err := c.setup(vault, dryRun, issuer, audience, path, caCert); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error configuring Vault: %s", err))
+		return 1
+	}
+	return 0
+}
+
+// setup applies the changes configuring Vault in order. The policy refers to
+// the accessor of the auth method, so the auth method is enabled first.
+func (c *SetupVaultCommand) setup(vault *vaultapi.Client, dryRun bool, issuer *setupIssuer, audience, path, caCert string) error {
+	mount, err := setupVaultAuthMount(vault, path)
+	if err != nil {
+		return err
+	}
+	err = applySetupChange(c.Ui, dryRun, &setupChange{
+		desc:   fmt.Sprintf("Vault JWT auth method at %q", path),
+		exists: mount != nil,
+		body:   map[string]string{"type": "jwt"},
+		apply: func() error {
+			return vault.Sys().EnableAuthWithOptions(path, &vaultapi.EnableAuthOptions{
+				Type:        "jwt",
+				Description: "Login method for the workload identities of Nomad tasks",
+			})
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// The configuration and role of a new auth method don't exist yet
+	var config, role *vaultapi.Secret
+	if mount != nil {
+		config, err = vault.Logical().Read("auth/" + path + "/config")
+		if err != nil {
+			return err
+		}
+		role, err = vault.Logical().Read("auth/" + path + "/role/" + setupVaultWorkloads)
+		if err != nil {
+			return err
+		}
+	} else if !dryRun {
+		if mount, err = setupVaultAuthMount(vault, path); err != nil {
+			return err
+		}
+		if mount == nil {
+			return fmt.Errorf("auth method at %q not found after enabling it", path)
+		}
+	}
+
+	newConfig := map[string]interface{}{
+		"jwks_url":           issuer.JWKS,
+		"jwt_supported_algs": issuer.SigningAlgs,
+		"bound_issuer":       issuer.Issuer,
+		"default_role":       setupVaultWorkloads,
+	}
+	if caCert != "" {
+		newConfig["jwks_ca_pem"] = caCert
+	}
+	err = applySetupChange(c.Ui, dryRun, &setupChange{
+		desc:   fmt.Sprintf("Vault JWT auth method configuration at %q", path),
+		exists: setupVaultConfigured(config),
+		body:   newConfig,
+		apply: func() error {
+			_, err := vault.Logical().Write("auth/"+path+"/config", newConfig)
+			return err
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	policy, err := vault.Sys().GetPolicy(setupVaultWorkloads)
+	if err != nil {
+		return err
+	}
+	accessor := "<accessor>"
+	if mount != nil {
+		accessor = mount.Accessor
+	}
+	rules := fmt.Sprintf(setupVaultWorkloadsRules, accessor)
+	err = applySetupChange(c.Ui, dryRun, &setupChange{
+		desc:   fmt.Sprintf("Vault policy %q", setupVaultWorkloads),
+		exists: policy != "",
+		body:   rules,
+		apply: func() error {
+			return vault.Sys().PutPolicy(setupVaultWorkloads, rules)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	newRole := map[string]interface{}{
+		"role_type":       "jwt",
+		"bound_audiences": []string{audience},
+		"user_claim":      "sub",
+		"claim_mappings":  setupClaimMappings,
+		"token_type":      "service",
+		"token_policies":  []string{setupVaultWorkloads},
+		"token_period":    "30m",
+	}
+	return applySetupChange(c.Ui, dryRun, &setupChange{
+		desc:   fmt.Sprintf("Vault JWT auth method role %q", setupVaultWorkloads),
+		exists: role != nil,
+		body:   newRole,
+		apply: func() error {
+			_, err := vault.Logical().Write("auth/"+path+"/role/"+setupVaultWorkloads, newRole)
+			return err
+		},
+	})
+}
+
+// setupVaultAuthMount returns the auth method enabled at the path, or nil if
+// there is none.
+func setupVaultAuthMount(vault *vaultapi.Client, path string) (*vaultapi.AuthMount, error) {
+	mounts, err := vault.Sys().ListAuth()
+	if err != nil {
+		return nil, err
+	}
+	return mounts[path+"/"], nil
+}
+
+// setupVaultConfigured returns true if the configuration of a JWT auth method
+// sets the keys verifying tokens.
+func setupVaultConfigured(config *vaultapi.Secret) bool {
+	if config == nil {
+		return false
+	}
+	for _, key := range []string{"jwks_url", "oidc_discovery_url"} {
+		if v, ok := config.Data[key].(string); ok && v != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupVaultCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &SetupVaultCommand{}
+}
+
+func TestSetupVaultCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	url := testSetupServer(t)
+
+	vault := &fakeSetupAPI{writes: map[string]map[string]interface{}{}}
+	vault.get = func(path string) interface{} {
+		if path != "/v1/sys/auth" {
+			return nil
+		}
+		// The auth method is listed once it is enabled
+		data := map[string]interface{}{}
+		if _, ok := vault.writes["POST /v1/sys/auth/jwt-nomad"]; ok {
+			data["jwt-nomad/"] = map[string]string{"type": "jwt", "accessor": "auth_jwt_1234"}
+		}
+		return map[string]interface{}{"data": data}
+	}
+	vaultSrv := httptest.NewServer(vault)
+	defer vaultSrv.Close()
+
+	// Dry runs print the objects without creating them
+	ui := cli.NewMockUi()
+	cmd := &SetupVaultCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-vault-address=" + vaultSrv.URL, "-dry-run"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, `Would create Vault JWT auth method at "jwt-nomad"`)
+	require.Contains(t, out, `"jwks_url": "https://nomad.example.com/.well-known/jwks.json"`)
+	require.Contains(t, out, `Would create Vault policy "nomad-workloads"`)
+	require.Contains(t, out, `identity.entity.aliases.<accessor>.metadata.nomad_job_id`)
+	require.Contains(t, out, `Would create Vault JWT auth method role "nomad-workloads"`)
+	require.Empty(t, vault.written())
+
+	// The policy refers to the accessor of the new auth method
+	ui = cli.NewMockUi()
+	cmd = &SetupVaultCommand{Meta: Meta{Ui: ui}}
+	code = cmd.Run([]string{"-address=" + url, "-vault-address=" + vaultSrv.URL})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	writes := vault.written()
+	require.Len(t, writes, 4)
+	require.Equal(t, "jwt", writes["POST /v1/sys/auth/jwt-nomad"]["type"])
+	config := writes["PUT /v1/auth/jwt-nomad/config"]
+	require.Equal(t, "https://nomad.example.com", config["bound_issuer"])
+	require.Equal(t, "nomad-workloads", config["default_role"])
+	policy := writes["PUT /v1/sys/policies/acl/nomad-workloads"]["policy"].(string)
+	require.True(t, strings.Contains(policy, "identity.entity.aliases.auth_jwt_1234.metadata.nomad_namespace"))
+	role := writes["PUT /v1/auth/jwt-nomad/role/nomad-workloads"]
+	require.Equal(t, []interface{}{"vault.io"}, role["bound_audiences"])
+	require.Equal(t, []interface{}{"nomad-workloads"}, role["token_policies"])
+}
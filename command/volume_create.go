@@ -23,7 +23,8 @@ Usage: nomad volume create [options] <input>
   is read from the file at the supplied path.
 
   When ACLs are enabled, this command requires a token with the
-  'csi-write-volume' capability for the volume's namespace.
+  'csi-write-volume' or 'csi-create-volume' capability for the volume's
+  namespace.
 
 General Options:
 
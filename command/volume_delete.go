@@ -23,8 +23,8 @@ Usage: nomad volume delete [options] <vol id>
   return without an error.
 
   When ACLs are enabled, this command requires a token with the
-  'csi-write-volume' and 'csi-read-volume' capabilities for the volume's
-  namespace.
+  'csi-write-volume' or 'csi-delete-volume' capability, and the
+  'csi-read-volume' capability for the volume's namespace.
 
 General Options:
 
@@ -21,7 +21,8 @@ Usage: nomad volume deregister [options] <id>
   Remove an unused volume from Nomad.
 
   When ACLs are enabled, this command requires a token with the
-  'csi-write-volume' capability for the volume's namespace.
+  'csi-write-volume' or 'csi-delete-volume' capability for the volume's
+  namespace.
 
 General Options:
 
@@ -106,7 +107,7 @@ func (c *VolumeDeregisterCommand) Run(args []string) int {
 	if len(vols) > 1 {
 		if (volID != vols[0].ID) || (c.allNamespaces() && vols[0].ID == vols[1].ID) {
 			sort.Slice(vols, func(i, j int) bool { return vols[i].ID < vols[j].ID })
-			out, err := csiFormatSortedVolumes(vols)
+			out, err := csiFormatSortedVolumes(vols, c.allNamespaces())
 			if err != nil {
 				c.Ui.Error(fmt.Sprintf("Error formatting: %s", err))
 				return 1
@@ -116,6 +117,7 @@ func (c *VolumeDeregisterCommand) Run(args []string) int {
 		}
 	}
 	volID = vols[0].ID
+	volNS := vols[0].Namespace
 
 	// Confirm the -force flag
 	if force {
@@ -142,7 +144,7 @@ func (c *VolumeDeregisterCommand) Run(args []string) int {
 
 	// Deregister only works on CSI volumes, but could be extended to support other
 	// network interfaces or host volumes
-	err = client.CSIVolumes().Deregister(volID, force, nil)
+	err = client.CSIVolumes().Deregister(volID, force, &api.WriteOptions{Namespace: volNS})
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error deregistering volume: %s", err))
 		return 1
@@ -128,7 +128,7 @@ func (c *VolumeDetachCommand) Run(args []string) int {
 		if len(vols) > 1 {
 			if (volID != vols[0].ID) || (c.allNamespaces() && vols[0].ID == vols[1].ID) {
 				sort.Slice(vols, func(i, j int) bool { return vols[i].ID < vols[j].ID })
-				out, err := csiFormatSortedVolumes(vols)
+				out, err := csiFormatSortedVolumes(vols, c.allNamespaces())
 				if err != nil {
 					c.Ui.Error(fmt.Sprintf("Error formatting: %s", err))
 					return 1
@@ -139,7 +139,7 @@ func (c *VolumeDetachCommand) Run(args []string) int {
 		}
 		volID = vols[0].ID
 
-		vol, _, err := client.CSIVolumes().Info(volID, nil)
+		vol, _, err := client.CSIVolumes().Info(volID, &api.QueryOptions{Namespace: vols[0].Namespace})
 		if err != nil {
 			c.Ui.Error(fmt.Sprintf("Error querying volume: %s", err))
 			return 1
@@ -26,7 +26,8 @@ Usage: nomad volume register [options] <input>
   is read from the file at the supplied path.
 
   When ACLs are enabled, this command requires a token with the
-  'csi-write-volume' capability for the volume's namespace.
+  'csi-write-volume' or 'csi-register-volume' capability for the volume's
+  namespace.
 
 General Options:
 
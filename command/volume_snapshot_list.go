@@ -21,7 +21,7 @@ type VolumeSnapshotListCommand struct {
 
 func (c *VolumeSnapshotListCommand) Help() string {
 	helpText := `
-Usage: nomad volume snapshot list [-plugin plugin_id]
+Usage: nomad volume snapshot list [-plugin plugin_id] [-volume volume_id]
 
   Display a list of CSI volume snapshots for a plugin along
   with their source volume ID as known to the external
@@ -39,6 +39,10 @@ List Options:
   -plugin: Display only snapshots managed by a particular plugin. This
     parameter is required.
 
+  -volume: Display only snapshots created from a particular volume. Accepts
+    either the ID of a volume registered with Nomad or the storage provider's
+    ID for the volume.
+
   -secret
     Secrets to pass to the plugin to list snapshots. Accepts multiple
     flags in the form -secret key=value
@@ -79,7 +83,7 @@ func (c *VolumeSnapshotListCommand) AutocompleteArgs() complete.Predictor {
 func (c *VolumeSnapshotListCommand) Name() string { return "volume snapshot list" }
 
 func (c *VolumeSnapshotListCommand) Run(args []string) int {
-	var pluginID string
+	var pluginID, volumeID string
 	var verbose bool
 	var secretsArgs flaghelper.StringFlag
 	var perPage int
@@ -88,6 +92,7 @@ func (c *VolumeSnapshotListCommand) Run(args []string) int {
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.StringVar(&pluginID, "plugin", "", "")
+	flags.StringVar(&volumeID, "volume", "", "")
 	flags.BoolVar(&verbose, "verbose", false, "")
 	flags.Var(&secretsArgs, "secret", "secrets for snapshot, ex. -secret key=value")
 	flags.IntVar(&perPage, "per-page", 30, "")
@@ -144,6 +149,7 @@ func (c *VolumeSnapshotListCommand) Run(args []string) int {
 
 	req := &api.CSISnapshotListRequest{
 		PluginID: pluginID,
+		VolumeID: volumeID,
 		Secrets:  secrets,
 		QueryOptions: api.QueryOptions{
 			PerPage:   int32(perPage),
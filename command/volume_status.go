@@ -15,6 +15,7 @@ type VolumeStatusCommand struct {
 	verbose  bool
 	json     bool
 	template string
+	events   bool
 }
 
 func (c *VolumeStatusCommand) Help() string {
@@ -44,6 +45,11 @@ Status Options:
   -verbose
     Display full allocation information.
 
+  -events
+    Stream claim, release and plugin health events for the volume instead of
+    displaying its current allocations. Requires a volume id. The stream
+    runs until interrupted.
+
   -json
     Output the allocation in its JSON format.
 
@@ -63,6 +69,7 @@ func (c *VolumeStatusCommand) AutocompleteFlags() complete.Flags {
 			"-type":    predictVolumeType,
 			"-short":   complete.PredictNothing,
 			"-verbose": complete.PredictNothing,
+			"-events":  complete.PredictNothing,
 			"-json":    complete.PredictNothing,
 			"-t":       complete.PredictAnything,
 		})
@@ -93,6 +100,7 @@ func (c *VolumeStatusCommand) Run(args []string) int {
 	flags.StringVar(&typeArg, "type", "", "")
 	flags.BoolVar(&c.short, "short", false, "")
 	flags.BoolVar(&c.verbose, "verbose", false, "")
+	flags.BoolVar(&c.events, "events", false, "")
 	flags.BoolVar(&c.json, "json", false, "")
 	flags.StringVar(&c.template, "t", "", "")
 
@@ -109,6 +117,12 @@ func (c *VolumeStatusCommand) Run(args []string) int {
 		return 1
 	}
 
+	if c.events && len(args) != 1 {
+		c.Ui.Error("The -events flag requires a volume id")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
 	// Truncate alloc and node IDs unless full length is requested
 	c.length = shortId
 	if c.verbose {
@@ -1,11 +1,16 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"os/signal"
 	"sort"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -46,13 +51,18 @@ func (c *VolumeStatusCommand) csiStatus(client *api.Client, id string) int {
 	}
 	id = vols[0].ID
 
-	// Try querying the volume
-	vol, _, err := client.CSIVolumes().Info(id, nil)
+	// Try querying the volume in the namespace it was found in
+	q := &api.QueryOptions{Namespace: vols[0].Namespace}
+	vol, _, err := client.CSIVolumes().Info(id, q)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error querying volume: %s", err))
 		return 1
 	}
 
+	if c.events {
+		return c.csiVolumeEvents(client, vol)
+	}
+
 	str, err := c.formatBasic(vol)
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error formatting volume: %s", err))
@@ -63,6 +73,153 @@ func (c *VolumeStatusCommand) csiStatus(client *api.Client, id string) int {
 	return 0
 }
 
+// csiVolumeEvents tails the event stream for a single volume, reporting
+// allocation claims and releases as well as changes to the health of the
+// volume's plugin. It returns once the stream is closed or interrupted.
+func (c *VolumeStatusCommand) csiVolumeEvents(client *api.Client, vol *api.CSIVolume) int {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-signalCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	topics := map[api.Topic][]string{api.TopicCSIVolume: {vol.ID}}
+	q := &api.QueryOptions{Namespace: vol.Namespace}
+	eventCh, err := client.EventStream().Stream(ctx, topics, vol.ModifyIndex+1, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error streaming volume events: %s", err))
+		return 1
+	}
+
+	if !(c.json || len(c.template) > 0) {
+		c.Ui.Output(fmt.Sprintf("Streaming events for volume %q at index %d",
+			vol.ID, vol.ModifyIndex))
+	}
+
+	last := vol
+	for {
+		select {
+		case <-ctx.Done():
+			return 0
+		case events, ok := <-eventCh:
+			if !ok {
+				return 0
+			}
+			if events.Err != nil {
+				c.Ui.Error(fmt.Sprintf("Error streaming volume events: %s", events.Err))
+				return 1
+			}
+			if events.IsHeartbeat() {
+				continue
+			}
+			for _, event := range events.Events {
+				// The subscription filters on the volume ID as the key
+				// or filter key, so skip events for other volumes that
+				// share a plugin ID with the requested volume.
+				if event.Key != vol.ID {
+					continue
+				}
+				next, err := event.CSIVolume()
+				if err != nil {
+					c.Ui.Error(fmt.Sprintf("Error decoding volume event: %s", err))
+					return 1
+				}
+				if c.json || len(c.template) > 0 {
+					out, err := Format(c.json, c.template, event)
+					if err != nil {
+						c.Ui.Error(fmt.Sprintf("Error formatting event: %s", err))
+						return 1
+					}
+					c.Ui.Output(out)
+					continue
+				}
+				for _, line := range csiVolumeEventLines(event, last, next) {
+					c.Ui.Output(fmt.Sprintf("%s  %-8d %s",
+						formatTime(time.Now()), event.Index, line))
+				}
+				if next != nil {
+					last = next
+				}
+			}
+		}
+	}
+}
+
+// csiVolumeEventLines describes the difference between the previous and
+// current state of a volume carried by a CSIVolume event.
+func csiVolumeEventLines(event api.Event, prev, next *api.CSIVolume) []string {
+	if event.Type == "CSIVolumeDeregistered" || next == nil {
+		return []string{"Volume deregistered"}
+	}
+
+	var lines []string
+	if event.Type == "CSIVolumeRegistered" {
+		lines = append(lines, "Volume registered")
+	}
+
+	claimed := func(v *api.CSIVolume, allocID string) string {
+		if _, ok := v.WriteAllocs[allocID]; ok {
+			return "write"
+		}
+		if _, ok := v.ReadAllocs[allocID]; ok {
+			return "read"
+		}
+		return ""
+	}
+
+	allocIDs := map[string]struct{}{}
+	for _, v := range []*api.CSIVolume{prev, next} {
+		for id := range v.ReadAllocs {
+			allocIDs[id] = struct{}{}
+		}
+		for id := range v.WriteAllocs {
+			allocIDs[id] = struct{}{}
+		}
+	}
+	ids := make([]string, 0, len(allocIDs))
+	for id := range allocIDs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		before, after := claimed(prev, id), claimed(next, id)
+		switch {
+		case before == after:
+		case after == "":
+			lines = append(lines, fmt.Sprintf("Allocation %q released %s claim", id, before))
+		default:
+			lines = append(lines, fmt.Sprintf("Allocation %q claimed volume for %s", id, after))
+		}
+	}
+
+	// CSIVolumePluginUpdated events are emitted whenever the health of any
+	// controller or node of the plugin changes, even if the counts don't.
+	if event.Type == "CSIVolumePluginUpdated" ||
+		prev.Schedulable != next.Schedulable ||
+		prev.ControllersHealthy != next.ControllersHealthy ||
+		prev.NodesHealthy != next.NodesHealthy {
+		state := "healthy"
+		if !next.Schedulable {
+			state = "unhealthy"
+		}
+		lines = append(lines, fmt.Sprintf(
+			"Plugin %q %s: controllers healthy %d/%d, nodes healthy %d/%d",
+			next.PluginID, state,
+			next.ControllersHealthy, next.ControllersExpected,
+			next.NodesHealthy, next.NodesExpected))
+	}
+
+	return lines
+}
+
 func (c *VolumeStatusCommand) listVolumes(client *api.Client) int {
 
 	c.csiBanner()
@@ -160,21 +317,36 @@ func (c *VolumeStatusCommand) csiFormatVolumes(vols []*api.CSIVolumeListStub) (s
 		return out, nil
 	}
 
-	return csiFormatSortedVolumes(vols)
+	return csiFormatSortedVolumes(vols, c.allNamespaces())
 }
 
-// Format the volumes, assumes that we're already sorted by volume ID
-func csiFormatSortedVolumes(vols []*api.CSIVolumeListStub) (string, error) {
+// Format the volumes, assumes that we're already sorted by volume ID. The
+// namespace of the volumes is displayed if displayNS is set.
+func csiFormatSortedVolumes(vols []*api.CSIVolumeListStub, displayNS bool) (string, error) {
 	rows := make([]string, len(vols)+1)
-	rows[0] = "ID|Name|Plugin ID|Schedulable|Access Mode"
-	for i, v := range vols {
-		rows[i+1] = fmt.Sprintf("%s|%s|%s|%t|%s",
-			v.ID,
-			v.Name,
-			v.PluginID,
-			v.Schedulable,
-			v.AccessMode,
-		)
+	if displayNS {
+		rows[0] = "ID|Name|Namespace|Plugin ID|Schedulable|Access Mode"
+		for i, v := range vols {
+			rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%t|%s",
+				v.ID,
+				v.Name,
+				v.Namespace,
+				v.PluginID,
+				v.Schedulable,
+				v.AccessMode,
+			)
+		}
+	} else {
+		rows[0] = "ID|Name|Plugin ID|Schedulable|Access Mode"
+		for i, v := range vols {
+			rows[i+1] = fmt.Sprintf("%s|%s|%s|%t|%s",
+				v.ID,
+				v.Name,
+				v.PluginID,
+				v.Schedulable,
+				v.AccessMode,
+			)
+		}
 	}
 	return formatList(rows), nil
 }
@@ -3,6 +3,7 @@ package command
 import (
 	"testing"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -28,6 +29,66 @@ func TestCSIVolumeStatusCommand_Fails(t *testing.T) {
 	out := ui.ErrorWriter.String()
 	require.Contains(t, out, commandErrorText(cmd))
 	ui.ErrorWriter.Reset()
+
+	// Fails when streaming events without a volume
+	code = cmd.Run([]string{"-events"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "requires a volume id")
+}
+
+func TestCSIVolumeStatusCommand_EventLines(t *testing.T) {
+	ci.Parallel(t)
+
+	prev := &api.CSIVolume{
+		ID:          "vol",
+		PluginID:    "plugin",
+		Schedulable: true,
+		ReadAllocs:  map[string]*api.Allocation{"alloc1": nil},
+		WriteAllocs: map[string]*api.Allocation{},
+	}
+	next := &api.CSIVolume{
+		ID:                 "vol",
+		PluginID:           "plugin",
+		Schedulable:        false,
+		ControllersHealthy: 0,
+		NodesHealthy:       1,
+		NodesExpected:      2,
+		ReadAllocs:         map[string]*api.Allocation{},
+		WriteAllocs:        map[string]*api.Allocation{"alloc2": nil},
+	}
+
+	lines := csiVolumeEventLines(api.Event{Type: "CSIVolumeClaim"}, prev, next)
+	require.Equal(t, []string{
+		`Allocation "alloc1" released read claim`,
+		`Allocation "alloc2" claimed volume for write`,
+		`Plugin "plugin" unhealthy: controllers healthy 0/0, nodes healthy 1/2`,
+	}, lines)
+
+	lines = csiVolumeEventLines(api.Event{Type: "CSIVolumePluginUpdated"}, next, next)
+	require.Equal(t, []string{
+		`Plugin "plugin" unhealthy: controllers healthy 0/0, nodes healthy 1/2`,
+	}, lines)
+
+	lines = csiVolumeEventLines(api.Event{Type: "CSIVolumeDeregistered"}, next, next)
+	require.Equal(t, []string{"Volume deregistered"}, lines)
+}
+
+func TestCSIVolumeStatusCommand_FormatAllNamespaces(t *testing.T) {
+	ci.Parallel(t)
+
+	vols := []*api.CSIVolumeListStub{
+		{ID: "vol", Name: "vol", Namespace: "default", PluginID: "plugin"},
+		{ID: "vol", Name: "vol", Namespace: "prod", PluginID: "plugin"},
+	}
+
+	out, err := csiFormatSortedVolumes(vols, false)
+	require.NoError(t, err)
+	require.NotContains(t, out, "Namespace")
+
+	out, err = csiFormatSortedVolumes(vols, true)
+	require.NoError(t, err)
+	require.Contains(t, out, "Namespace")
+	require.Contains(t, out, "prod")
 }
 
 func TestCSIVolumeStatusCommand_AutocompleteArgs(t *testing.T) {
@@ -413,6 +413,7 @@ var (
 		},
 		MustInitiateNetwork: true,
 		MountConfigs:        drivers.MountConfigSupportAll,
+		Stdin:               true,
 	}
 )
 
@@ -379,19 +379,22 @@ func (d *dockerCoordinator) emitEvent(image, message string, annotations map[str
 	}
 }
 
-func (d *dockerCoordinator) handlePullInactivity(image, msg string, timestamp time.Time) {
+func (d *dockerCoordinator) handlePullInactivity(image, msg string, _ *imageProgressStats, timestamp time.Time) {
 	d.logger.Error("image pull aborted due to inactivity", "image_name", image,
 		"last_event_timestamp", timestamp.String(), "last_event", msg)
 }
 
-func (d *dockerCoordinator) handlePullProgressReport(image, msg string, _ time.Time) {
+func (d *dockerCoordinator) handlePullProgressReport(image, msg string, _ *imageProgressStats, _ time.Time) {
 	d.logger.Debug("image pull progress", "image_name", image, "message", msg)
 }
 
-func (d *dockerCoordinator) handleSlowPullProgressReport(image, msg string, _ time.Time) {
-	d.emitEvent(image, fmt.Sprintf("Docker image pull progress: %s", msg), map[string]string{
-		"image": image,
-	})
+func (d *dockerCoordinator) handleSlowPullProgressReport(image, msg string, stats *imageProgressStats, _ time.Time) {
+	annotations := map[string]string{}
+	if stats != nil {
+		annotations = stats.details()
+	}
+	annotations["image"] = image
+	d.emitEvent(image, fmt.Sprintf("Docker image pull progress: %s", msg), annotations)
 }
 
 // recoverablePullError wraps the error gotten when trying to pull and image if
@@ -20,6 +20,8 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	multierror "github.com/hashicorp/go-multierror"
 	plugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
+	"github.com/hashicorp/nomad/client/lib/fifo"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/drivers/docker/docklog"
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
@@ -309,6 +311,18 @@ CREATE:
 	// since we don't create containers which are already present on the host
 	// and are running
 	if !container.State.Running {
+		// Stream the dispatch payload to the container stdin once started
+		if cfg.StdinPath != "" {
+			if err := d.attachStdin(client, container.ID, cfg.StdinPath); err != nil {
+				d.logger.Error("failed to attach container stdin", "container_id", container.ID, "error", err)
+				client.RemoveContainer(docker.RemoveContainerOptions{
+					ID:    container.ID,
+					Force: true,
+				})
+				return nil, nil, err
+			}
+		}
+
 		// Start the container
 		if err := d.startContainer(container); err != nil {
 			d.logger.Error("failed to start container", "container_id", container.ID, "error", err)
@@ -316,8 +330,10 @@ CREATE:
 				ID:    container.ID,
 				Force: true,
 			})
-			// Some sort of docker race bug, recreating the container usually works
-			if strings.Contains(err.Error(), "OCI runtime create failed: container with id exists:") && startAttempts < 5 {
+			// Some sort of docker race bug, recreating the container usually works.
+			// The stdin fifo can only be read once, so tasks reading their
+			// payload from stdin are restarted instead.
+			if strings.Contains(err.Error(), "OCI runtime create failed: container with id exists:") && startAttempts < 5 && cfg.StdinPath == "" {
 				startAttempts++
 				d.logger.Debug("reattempting container create/start sequence", "attempt", startAttempts, "container_id", id)
 				goto CREATE
@@ -491,6 +507,42 @@ CREATE:
 	return nil, recoverableErrTimeouts(createErr)
 }
 
+// attachStdin streams the fifo at path to the stdin of the container until
+// the fifo is closed by its writer. The container must have been created with
+// its stdin open.
+func (d *Driver) attachStdin(client *docker.Client, containerID, path string) error {
+	stdin, err := fifo.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("failed to open stdin: %v", err)
+	}
+
+	success := make(chan struct{})
+	cw, err := client.AttachToContainerNonBlocking(docker.AttachToContainerOptions{
+		Container:   containerID,
+		InputStream: stdin,
+		Stdin:       true,
+		Stream:      true,
+		Success:     success,
+	})
+	if err != nil {
+		stdin.Close()
+		return fmt.Errorf("failed to attach to container stdin: %v", err)
+	}
+
+	// Wait for the attach to be established so that the task can't start
+	// before its stdin is connected
+	<-success
+	success <- struct{}{}
+
+	go func() {
+		if err := cw.Wait(); err != nil {
+			d.logger.Warn("failed to stream stdin to container", "container_id", containerID, "error", err)
+		}
+		stdin.Close()
+	}()
+	return nil
+}
+
 // startContainer starts the passed container. It attempts to handle any
 // transient Docker errors.
 func (d *Driver) startContainer(c *docker.Container) error {
@@ -806,6 +858,14 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 		OpenStdin:  driverConfig.Interactive,
 	}
 
+	// Tasks reading their dispatch payload from stdin get it streamed once,
+	// after which the container stdin is closed
+	if task.StdinPath != "" {
+		config.OpenStdin = true
+		config.StdinOnce = true
+		config.AttachStdin = true
+	}
+
 	if driverConfig.WorkDir != "" {
 		config.WorkingDir = driverConfig.WorkDir
 	}
@@ -889,6 +949,26 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 		hostConfig.CPUQuota = int64(task.Resources.LinuxResources.PercentTicks*float64(driverConfig.CPUCFSPeriod)) * int64(numCores)
 	}
 
+	// Limit the disk IO of the task on the disk holding the allocation
+	// directory, which docker translates to io.max on cgroups v2.
+	if lr := task.Resources.LinuxResources; lr.DiskIOPS > 0 || lr.DiskBandwidthBytes > 0 {
+		major, minor, err := cgutil.BlockDevice(task.AllocDir)
+		if err != nil {
+			return c, fmt.Errorf("failed to find the disk to limit IO on: %v", err)
+		}
+		device := fmt.Sprintf("/dev/block/%d:%d", major, minor)
+		if lr.DiskIOPS > 0 {
+			limit := []docker.BlockLimit{{Path: device, Rate: lr.DiskIOPS}}
+			hostConfig.BlkioDeviceReadIOps = limit
+			hostConfig.BlkioDeviceWriteIOps = limit
+		}
+		if lr.DiskBandwidthBytes > 0 {
+			limit := []docker.BlockLimit{{Path: device, Rate: lr.DiskBandwidthBytes}}
+			hostConfig.BlkioDeviceReadBps = limit
+			hostConfig.BlkioDeviceWriteBps = limit
+		}
+	}
+
 	// Windows does not support MemorySwap/MemorySwappiness #2193
 	if runtime.GOOS == "windows" {
 		hostConfig.MemorySwap = 0
@@ -921,7 +1001,8 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 	logger.Debug("configured resources",
 		"memory", hostConfig.Memory, "memory_reservation", hostConfig.MemoryReservation,
 		"cpu_shares", hostConfig.CPUShares, "cpu_quota", hostConfig.CPUQuota,
-		"cpu_period", hostConfig.CPUPeriod)
+		"cpu_period", hostConfig.CPUPeriod, "disk_iops", task.Resources.LinuxResources.DiskIOPS,
+		"disk_bandwidth", task.Resources.LinuxResources.DiskBandwidthBytes)
 
 	logger.Debug("binding directories", "binds", hclog.Fmt("%#v", hostConfig.Binds))
 
@@ -1105,9 +1186,16 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 		}
 
 		for _, port := range driverConfig.Ports {
-			if mapping, ok := task.Resources.Ports.Get(port); ok {
-				ports.add(mapping.Label, mapping.HostIP, mapping.Value, mapping.To)
-			} else {
+			// Ports mapped on several host networks have a mapping for each
+			// of them, which are all bound to the container port
+			found := false
+			for _, mapping := range *task.Resources.Ports {
+				if mapping.Label == port {
+					ports.add(mapping.Label, mapping.HostIP, mapping.Value, mapping.To)
+					found = true
+				}
+			}
+			if !found {
 				return c, fmt.Errorf("Port %q not found, check network stanza", port)
 			}
 		}
@@ -18,6 +18,7 @@ import (
 	docker "github.com/fsouza/go-dockerclient"
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/client/testutil"
 	"github.com/hashicorp/nomad/helper/freeport"
@@ -983,6 +984,61 @@ func TestDockerDriver_CreateContainerConfig(t *testing.T) {
 	require.Equal(t, containerName, c.Name)
 }
 
+func TestDockerDriver_CreateContainerConfig_Stdin(t *testing.T) {
+	ci.Parallel(t)
+
+	task, cfg, ports := dockerTask(t)
+	defer freeport.Return(ports)
+	require.NoError(t, task.EncodeConcreteDriverConfig(cfg))
+
+	dh := dockerDriverHarness(t, nil)
+	driver := dh.Impl().(*Driver)
+
+	// Stdin is closed by default
+	c, err := driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	require.NoError(t, err)
+	require.False(t, c.Config.OpenStdin)
+	require.False(t, c.Config.StdinOnce)
+
+	// Tasks reading their payload from stdin get it streamed once
+	task.StdinPath = "/tmp/stdin.fifo"
+	c, err = driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	require.NoError(t, err)
+	require.True(t, c.Config.OpenStdin)
+	require.True(t, c.Config.StdinOnce)
+	require.True(t, c.Config.AttachStdin)
+}
+
+func TestDockerDriver_CreateContainerConfig_DiskIOLimits(t *testing.T) {
+	ci.Parallel(t)
+
+	task, cfg, ports := dockerTask(t)
+	defer freeport.Return(ports)
+	require.NoError(t, task.EncodeConcreteDriverConfig(cfg))
+
+	task.AllocDir = t.TempDir()
+	major, minor, err := cgutil.BlockDevice(task.AllocDir)
+	if err != nil {
+		t.Skipf("alloc dir isn't on a block device: %v", err)
+	}
+	task.Resources.LinuxResources.DiskIOPS = 500
+	task.Resources.LinuxResources.DiskBandwidthBytes = 50 * 1024 * 1024
+
+	dh := dockerDriverHarness(t, nil)
+	driver := dh.Impl().(*Driver)
+
+	c, err := driver.createContainerConfig(task, cfg, "org/repo:0.1")
+	require.NoError(t, err)
+
+	device := fmt.Sprintf("/dev/block/%d:%d", major, minor)
+	expectedIOPS := []docker.BlockLimit{{Path: device, Rate: 500}}
+	require.Equal(t, expectedIOPS, c.HostConfig.BlkioDeviceReadIOps)
+	require.Equal(t, expectedIOPS, c.HostConfig.BlkioDeviceWriteIOps)
+	expectedBps := []docker.BlockLimit{{Path: device, Rate: 50 * 1024 * 1024}}
+	require.Equal(t, expectedBps, c.HostConfig.BlkioDeviceReadBps)
+	require.Equal(t, expectedBps, c.HostConfig.BlkioDeviceWriteBps)
+}
+
 func TestDockerDriver_CreateContainerConfig_RuntimeConflict(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -80,46 +81,84 @@ type imageProgress struct {
 	pullStart   time.Time
 }
 
-// get returns a status message and the timestamp of the last status update
-func (p *imageProgress) get() (string, time.Time) {
-	p.RLock()
-	defer p.RUnlock()
+// imageProgressStats is a point in time summary of an image pull
+type imageProgressStats struct {
+	layers         int
+	pulled         int
+	pulling        int
+	waiting        int
+	currentBytes   int64
+	totalBytes     int64
+	bytesPerSecond int64
+	remaining      time.Duration
+}
 
-	if p.lastMessage == nil {
-		return "No progress", p.timestamp
+// details returns the stats as task event annotations
+func (s *imageProgressStats) details() map[string]string {
+	return map[string]string{
+		"layers_total":     strconv.Itoa(s.layers),
+		"layers_pulled":    strconv.Itoa(s.pulled),
+		"layers_pulling":   strconv.Itoa(s.pulling),
+		"layers_waiting":   strconv.Itoa(s.waiting),
+		"bytes_pulled":     strconv.FormatInt(s.currentBytes, 10),
+		"bytes_total":      strconv.FormatInt(s.totalBytes, 10),
+		"bytes_per_second": strconv.FormatInt(s.bytesPerSecond, 10),
 	}
+}
 
-	var pulled, pulling, waiting int
+// stats summarizes the progress of the pull. The caller is responsible for
+// acquiring a read lock on the imageProgress struct
+func (p *imageProgress) stats() *imageProgressStats {
+	s := &imageProgressStats{layers: len(p.layers)}
 	for _, l := range p.layers {
 		switch {
 		case l.status == layerProgressStatusStarting ||
 			l.status == layerProgressStatusWaiting:
-			waiting++
+			s.waiting++
 		case l.status == layerProgressStatusDownloading ||
 			l.status == layerProgressStatusVerifying:
-			pulling++
+			s.pulling++
 		case l.status >= layerProgressStatusDownloaded:
-			pulled++
+			s.pulled++
 		}
 	}
 
 	elapsed := time.Since(p.pullStart)
-	cur := p.currentBytes()
-	total := p.totalBytes()
-	var est int64
-	if cur != 0 {
-		est = (elapsed.Nanoseconds() / cur * total) - elapsed.Nanoseconds()
+	s.currentBytes = p.currentBytes()
+	s.totalBytes = p.totalBytes()
+	if secs := elapsed.Seconds(); secs > 0 {
+		s.bytesPerSecond = int64(float64(s.currentBytes) / secs)
+	}
+	if s.currentBytes != 0 {
+		s.remaining = time.Duration((elapsed.Nanoseconds() / s.currentBytes * s.totalBytes) - elapsed.Nanoseconds())
 	}
+	return s
+}
+
+// get returns a status message, the stats of the pull and the timestamp of
+// the last status update. The stats are nil if no progress has been received.
+func (p *imageProgress) get() (string, *imageProgressStats, time.Time) {
+	p.RLock()
+	defer p.RUnlock()
+
+	if p.lastMessage == nil {
+		return "No progress", nil, p.timestamp
+	}
+
+	s := p.stats()
 
 	var msg strings.Builder
 	fmt.Fprintf(&msg, "Pulled %d/%d (%s/%s) layers: %d waiting/%d pulling",
-		pulled, len(p.layers), units.BytesSize(float64(cur)), units.BytesSize(float64(total)),
-		waiting, pulling)
+		s.pulled, s.layers, units.BytesSize(float64(s.currentBytes)), units.BytesSize(float64(s.totalBytes)),
+		s.waiting, s.pulling)
 
-	if est > 0 {
-		fmt.Fprintf(&msg, " - est %.1fs remaining", time.Duration(est).Seconds())
+	if s.bytesPerSecond > 0 {
+		fmt.Fprintf(&msg, " at %s/s", units.BytesSize(float64(s.bytesPerSecond)))
+	}
+	if s.remaining > 0 {
+		fmt.Fprintf(&msg, " - est %.1fs remaining", s.remaining.Seconds())
 	}
-	return msg.String(), p.timestamp
+	return msg.String(), s, p.timestamp
 }
 
 // set takes a status message received from the docker engine api during an image
@@ -173,9 +212,10 @@ func (p *imageProgress) totalBytes() int64 {
 }
 
 // progressReporterFunc defines the method for handling inactivity and report
-// events from the imageProgressManager. The image name, current status message
-// and timestamp of last received status update are passed in.
-type progressReporterFunc func(image string, msg string, timestamp time.Time)
+// events from the imageProgressManager. The image name, current status message,
+// pull stats and timestamp of last received status update are passed in. The
+// stats are nil if no progress has been received yet.
+type progressReporterFunc func(image string, msg string, stats *imageProgressStats, timestamp time.Time)
 
 // imageProgressManager tracks the progress of pulling a docker image from an
 // image repository.
@@ -231,18 +271,18 @@ func (pm *imageProgressManager) start() {
 		for {
 			select {
 			case <-ticker.C:
-				msg, lastStatusTime := pm.imageProgress.get()
+				msg, stats, lastStatusTime := pm.imageProgress.get()
 				t := time.Now()
 				if t.Sub(lastStatusTime) > pm.activityDeadline {
-					pm.inactivityFunc(pm.image, msg, lastStatusTime)
+					pm.inactivityFunc(pm.image, msg, stats, lastStatusTime)
 					pm.cancel()
 					return
 				}
 				if t.Sub(pm.lastSlowReport) > pm.slowReportInterval {
-					pm.slowReporter(pm.image, msg, lastStatusTime)
+					pm.slowReporter(pm.image, msg, stats, lastStatusTime)
 					pm.lastSlowReport = t
 				}
-				pm.reporter(pm.image, msg, lastStatusTime)
+				pm.reporter(pm.image, msg, stats, lastStatusTime)
 			case <-pm.stopCh:
 				return
 			}
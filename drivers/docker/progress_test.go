@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/stretchr/testify/require"
 )
@@ -52,3 +53,34 @@ func Test_DockerImageProgressManager(t *testing.T) {
 	require.Equal(t, int64(4449071), pm.imageProgress.currentBytes())
 	require.Equal(t, int64(15443505), pm.imageProgress.totalBytes())
 }
+
+func Test_DockerImageProgress_Stats(t *testing.T) {
+	ci.Parallel(t)
+
+	p := &imageProgress{
+		timestamp: time.Now(),
+		pullStart: time.Now().Add(-10 * time.Second),
+		layers:    make(map[string]*layerProgress),
+	}
+
+	msg, stats, _ := p.get()
+	require.Equal(t, "No progress", msg)
+	require.Nil(t, stats)
+
+	p.set(&jsonmessage.JSONMessage{Status: "Pulling fs layer", ID: "c73ab1c6897b"})
+	p.set(&jsonmessage.JSONMessage{Status: "Downloading", ID: "b542772b4177",
+		Progress: &jsonmessage.JSONProgress{Current: 1000000, Total: 4000000}})
+
+	msg, stats, _ = p.get()
+	require.Contains(t, msg, "Pulled 0/2 (976.6KiB/3.815MiB) layers: 1 waiting/1 pulling at ")
+	require.Contains(t, msg, "/s - est ")
+
+	details := stats.details()
+	require.Equal(t, "2", details["layers_total"])
+	require.Equal(t, "0", details["layers_pulled"])
+	require.Equal(t, "1", details["layers_pulling"])
+	require.Equal(t, "1", details["layers_waiting"])
+	require.Equal(t, "1000000", details["bytes_pulled"])
+	require.Equal(t, "4000000", details["bytes_total"])
+	require.NotEqual(t, "0", details["bytes_per_second"])
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
 	"github.com/hashicorp/nomad/drivers/shared/resolvconf"
+	"github.com/hashicorp/nomad/drivers/shared/seccomp"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/plugins/base"
@@ -79,17 +80,23 @@ var (
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
 		),
+		"default_seccomp_profile": hclspec.NewDefault(
+			hclspec.NewAttr("default_seccomp_profile", "string", false),
+			hclspec.NewLiteral(`"unconfined"`),
+		),
+		"allowed_seccomp_profiles": hclspec.NewAttr("allowed_seccomp_profiles", "list(string)", false),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
 	// a task within a job. It is returned in the TaskConfigSchema RPC
 	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
-		"command":  hclspec.NewAttr("command", "string", true),
-		"args":     hclspec.NewAttr("args", "list(string)", false),
-		"pid_mode": hclspec.NewAttr("pid_mode", "string", false),
-		"ipc_mode": hclspec.NewAttr("ipc_mode", "string", false),
-		"cap_add":  hclspec.NewAttr("cap_add", "list(string)", false),
-		"cap_drop": hclspec.NewAttr("cap_drop", "list(string)", false),
+		"command":         hclspec.NewAttr("command", "string", true),
+		"args":            hclspec.NewAttr("args", "list(string)", false),
+		"pid_mode":        hclspec.NewAttr("pid_mode", "string", false),
+		"ipc_mode":        hclspec.NewAttr("ipc_mode", "string", false),
+		"cap_add":         hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":        hclspec.NewAttr("cap_drop", "list(string)", false),
+		"seccomp_profile": hclspec.NewAttr("seccomp_profile", "string", false),
 	})
 
 	// driverCapabilities represents the RPC response for what features are
@@ -101,8 +108,10 @@ var (
 		NetIsolationModes: []drivers.NetIsolationMode{
 			drivers.NetIsolationModeHost,
 			drivers.NetIsolationModeGroup,
+			drivers.NetIsolationModeTask,
 		},
 		MountConfigs: drivers.MountConfigSupportAll,
+		Stdin:        true,
 	}
 )
 
@@ -152,6 +161,14 @@ type Config struct {
 	// AllowCaps configures which Linux Capabilities are enabled for tasks
 	// running on this node.
 	AllowCaps []string `codec:"allow_caps"`
+
+	// DefaultSeccompProfile is the seccomp profile applied to tasks that
+	// don't set one.
+	DefaultSeccompProfile string `codec:"default_seccomp_profile"`
+
+	// AllowedSeccompProfiles are glob patterns matching the paths of the
+	// custom seccomp profiles tasks running on this node may use.
+	AllowedSeccompProfiles []string `codec:"allowed_seccomp_profiles"`
 }
 
 func (c *Config) validate() error {
@@ -172,6 +189,10 @@ func (c *Config) validate() error {
 		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
 	}
 
+	if _, err := seccomp.Resolve(c.DefaultSeccompProfile, c.AllowedSeccompProfiles); err != nil {
+		return fmt.Errorf("invalid default_seccomp_profile: %v", err)
+	}
+
 	return nil
 }
 
@@ -196,6 +217,10 @@ type TaskConfig struct {
 
 	// CapDrop is a set of linux capabilities to disable.
 	CapDrop []string `codec:"cap_drop"`
+
+	// SeccompProfile is the seccomp profile applied to the task. It overrides
+	// the default_seccomp_profile of the driver.
+	SeccompProfile string `codec:"seccomp_profile"`
 }
 
 func (tc *TaskConfig) validate() error {
@@ -232,6 +257,7 @@ type TaskState struct {
 	TaskConfig     *drivers.TaskConfig
 	Pid            int
 	StartedAt      time.Time
+	SeccompProfile string
 }
 
 // NewExecDriver returns a new DrivePlugin implementation
@@ -367,6 +393,7 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 	}
 
 	fp.Attributes["driver.exec"] = pstructs.NewBoolAttribute(true)
+	fp.Attributes["driver.exec.seccomp"] = pstructs.NewBoolAttribute(seccomp.Supported())
 	d.setFingerprintSuccess()
 	return fp
 }
@@ -412,14 +439,15 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 	}
 
 	h := &taskHandle{
-		exec:         exec,
-		pid:          taskState.Pid,
-		pluginClient: pluginClient,
-		taskConfig:   taskState.TaskConfig,
-		procState:    drivers.TaskStateRunning,
-		startedAt:    taskState.StartedAt,
-		exitResult:   &drivers.ExitResult{},
-		logger:       d.logger,
+		exec:           exec,
+		pid:            taskState.Pid,
+		pluginClient:   pluginClient,
+		taskConfig:     taskState.TaskConfig,
+		procState:      drivers.TaskStateRunning,
+		startedAt:      taskState.StartedAt,
+		exitResult:     &drivers.ExitResult{},
+		logger:         d.logger,
+		seccompProfile: taskState.SeccompProfile,
 	}
 
 	d.tasks.Set(taskState.TaskConfig.ID, h)
@@ -442,6 +470,18 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
 	}
 
+	profile := driverConfig.SeccompProfile
+	if profile == "" {
+		profile = d.config.DefaultSeccompProfile
+	}
+	seccompProfile, err := seccomp.Resolve(profile, d.config.AllowedSeccompProfiles)
+	if err != nil {
+		return nil, nil, err
+	}
+	if seccompProfile != nil && !seccomp.Supported() {
+		return nil, nil, fmt.Errorf("seccomp profile %q can't be applied: seccomp isn't supported on this client", profile)
+	}
+
 	d.logger.Info("starting task", "driver_cfg", hclog.Fmt("%+v", driverConfig))
 	handle := drivers.NewTaskHandle(taskHandleVersion)
 	handle.Config = cfg
@@ -492,12 +532,14 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		TaskDir:          cfg.TaskDir().Dir,
 		StdoutPath:       cfg.StdoutPath,
 		StderrPath:       cfg.StderrPath,
+		StdinPath:        cfg.StdinPath,
 		Mounts:           cfg.Mounts,
 		Devices:          cfg.Devices,
 		NetworkIsolation: cfg.NetworkIsolation,
 		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
 		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
 		Capabilities:     caps,
+		SeccompProfile:   seccompProfile,
 	}
 
 	ps, err := exec.Launch(execCmd)
@@ -515,12 +557,16 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		startedAt:    time.Now().Round(time.Millisecond),
 		logger:       d.logger,
 	}
+	if seccompProfile != nil {
+		h.seccompProfile = profile
+	}
 
 	driverState := TaskState{
 		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(pluginClient.ReattachConfig()),
 		Pid:            ps.Pid,
 		TaskConfig:     cfg,
 		StartedAt:      h.startedAt,
+		SeccompProfile: h.seccompProfile,
 	}
 
 	if err := handle.SetDriverState(&driverState); err != nil {
@@ -560,6 +606,9 @@ func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *dr
 			ExitCode: ps.ExitCode,
 			Signal:   ps.Signal,
 		}
+		if handle.seccompProfile != "" && seccomp.KilledBySeccomp(ps.Signal) {
+			result.Err = fmt.Errorf("task was killed by SIGSYS after making a syscall denied by seccomp profile %q", handle.seccompProfile)
+		}
 	}
 
 	select {
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/ci"
 	ctestutils "github.com/hashicorp/nomad/client/testutil"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/drivers/shared/seccomp"
 	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/testtask"
@@ -93,6 +94,9 @@ func TestExecDriver_Fingerprint(t *testing.T) {
 	case finger := <-fingerCh:
 		require.Equal(drivers.HealthStateHealthy, finger.Health)
 		require.True(finger.Attributes["driver.exec"].GetBool())
+		seccompAttr, ok := finger.Attributes["driver.exec.seccomp"].GetBool()
+		require.True(ok)
+		require.Equal(seccomp.Supported(), seccompAttr)
 	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
 		require.Fail("timeout receiving fingerprint")
 	}
@@ -710,11 +714,13 @@ func TestConfig_ParseAllHCL(t *testing.T) {
 config {
   command = "/bin/bash"
   args = ["-c", "echo hello"]
+  seccomp_profile = "default"
 }`
 
 	expected := &TaskConfig{
-		Command: "/bin/bash",
-		Args:    []string{"-c", "echo hello"},
+		Command:        "/bin/bash",
+		Args:           []string{"-c", "echo hello"},
+		SeccompProfile: "default",
 	}
 
 	var tc *TaskConfig
@@ -764,6 +770,50 @@ func TestExecDriver_NoPivotRoot(t *testing.T) {
 	r.NotNil(handle)
 }
 
+func TestExecDriver_SeccompProfileNotAllowed(t *testing.T) {
+	ci.Parallel(t)
+	r := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	config := &Config{
+		DefaultModePID:         executor.IsolationModePrivate,
+		DefaultModeIPC:         executor.IsolationModePrivate,
+		DefaultSeccompProfile:  "unconfined",
+		AllowedSeccompProfiles: []string{"/etc/nomad/seccomp/*.json"},
+	}
+
+	var data []byte
+	r.NoError(basePlug.MsgPackEncode(&data, config))
+	bconfig := &basePlug.Config{PluginConfig: data}
+	r.NoError(harness.SetConfig(bconfig))
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		Name:      "sleep",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// Profiles outside of the allowed paths are rejected before the task is
+	// started
+	tc := &TaskConfig{
+		Command:        "/bin/sleep",
+		Args:           []string{"100"},
+		SeccompProfile: "/tmp/profile.json",
+	}
+	r.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	_, _, err := harness.StartTask(task)
+	r.Error(err)
+	r.Contains(err.Error(), `seccomp profile "/tmp/profile.json" is not allowed on this client`)
+}
+
 func TestDriver_Config_validate(t *testing.T) {
 	ci.Parallel(t)
 	t.Run("pid/ipc", func(t *testing.T) {
@@ -803,6 +853,32 @@ func TestDriver_Config_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("seccomp", func(t *testing.T) {
+		dir := t.TempDir()
+		profile := filepath.Join(dir, "profile.json")
+		require.NoError(t, ioutil.WriteFile(profile, []byte(`{"defaultAction":"SCMP_ACT_ALLOW"}`), 0644))
+
+		for _, tc := range []struct {
+			profile string
+			allowed []string
+			exp     error
+		}{
+			{profile: "", exp: nil},
+			{profile: "unconfined", exp: nil},
+			{profile: "default", exp: nil},
+			{profile: profile, allowed: []string{filepath.Join(dir, "*.json")}, exp: nil},
+			{profile: "profile.json", exp: errors.New(`invalid default_seccomp_profile: seccomp profile must be "default", "unconfined", or an absolute path, got "profile.json"`)},
+			{profile: profile, exp: fmt.Errorf("invalid default_seccomp_profile: seccomp profile %q is not allowed on this client", profile)},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID:         "private",
+				DefaultModeIPC:         "private",
+				DefaultSeccompProfile:  tc.profile,
+				AllowedSeccompProfiles: tc.allowed,
+			}).validate())
+		}
+	})
 }
 
 func TestDriver_TaskConfig_validate(t *testing.T) {
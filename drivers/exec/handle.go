@@ -18,6 +18,10 @@ type taskHandle struct {
 	pluginClient *plugin.Client
 	logger       hclog.Logger
 
+	// seccompProfile is the seccomp profile applied to the task, or empty
+	// if it is unconfined.
+	seccompProfile string
+
 	// stateLock syncs access to all fields below
 	stateLock sync.RWMutex
 
@@ -38,6 +38,7 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 		Resources:          drivers.ResourcesToProto(cmd.Resources),
 		StdoutPath:         cmd.StdoutPath,
 		StderrPath:         cmd.StderrPath,
+		StdinPath:          cmd.StdinPath,
 		Env:                cmd.Env,
 		User:               cmd.User,
 		TaskDir:            cmd.TaskDir,
@@ -50,6 +51,7 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 		DefaultPidMode:     cmd.ModePID,
 		DefaultIpcMode:     cmd.ModeIPC,
 		Capabilities:       cmd.Capabilities,
+		SeccompProfile:     cmd.SeccompProfile,
 	}
 	resp, err := c.client.Launch(ctx, req)
 	if err != nil {
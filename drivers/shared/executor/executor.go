@@ -114,6 +114,11 @@ type ExecCommand struct {
 	StderrPath string
 	stderr     io.WriteCloser
 
+	// StdinPath is the path of the fifo the process stdin should be read
+	// from. The process has no stdin if empty.
+	StdinPath string
+	stdin     io.ReadCloser
+
 	// Env is the list of KEY=val pairs of environment variables to be set
 	Env []string
 
@@ -154,6 +159,10 @@ type ExecCommand struct {
 
 	// Capabilities are the linux capabilities to be enabled by the task driver.
 	Capabilities []string
+
+	// SeccompProfile is the encoded seccomp profile applied to the task, as
+	// returned by seccomp.Resolve. The task is unconfined if empty.
+	SeccompProfile []byte
 }
 
 // SetWriters sets the writer for the process stdout and stderr. This should
@@ -208,7 +217,23 @@ func (c *ExecCommand) Stderr() (io.WriteCloser, error) {
 	return c.stderr, nil
 }
 
+// Stdin returns a reader for the configured file descriptor, or nil if the
+// process has no stdin. Opening the fifo blocks until its writer opens it.
+func (c *ExecCommand) Stdin() (io.ReadCloser, error) {
+	if c.stdin == nil && c.StdinPath != "" {
+		f, err := fifo.OpenReader(c.StdinPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open stdin: %v", err)
+		}
+		c.stdin = f
+	}
+	return c.stdin, nil
+}
+
 func (c *ExecCommand) Close() {
+	if c.stdin != nil {
+		c.stdin.Close()
+	}
 	if c.stdout != nil {
 		c.stdout.Close()
 	}
@@ -316,8 +341,16 @@ func (e *UniversalExecutor) Launch(command *ExecCommand) (*ProcessState, error)
 		return nil, err
 	}
 
+	stdin, err := e.commandCfg.Stdin()
+	if err != nil {
+		return nil, err
+	}
+
 	e.childCmd.Stdout = stdout
 	e.childCmd.Stderr = stderr
+	if stdin != nil {
+		e.childCmd.Stdin = stdin
+	}
 
 	// Look up the binary path and make it executable
 	absPath, err := lookupBin(command.TaskDir, command.Cmd)
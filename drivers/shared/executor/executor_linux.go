@@ -19,9 +19,11 @@ import (
 	"github.com/hashicorp/consul-template/signals"
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/stats"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
+	"github.com/hashicorp/nomad/drivers/shared/seccomp"
 	shelpers "github.com/hashicorp/nomad/helper/stats"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -158,6 +160,10 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	if err != nil {
 		return nil, err
 	}
+	stdin, err := command.Stdin()
+	if err != nil {
+		return nil, err
+	}
 
 	l.logger.Debug("launching", "command", command.Cmd, "args", strings.Join(command.Args, " "))
 
@@ -169,6 +175,9 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		Stderr: stderr,
 		Init:   true,
 	}
+	if stdin != nil {
+		process.Stdin = stdin
+	}
 
 	if command.User != "" {
 		process.User = command.User
@@ -704,6 +713,10 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 	cfg.Cgroups.Resources.CpuShares = uint64(cpuShares)
 	cfg.Cgroups.Resources.CpuWeight = cgroups.ConvertCPUSharesToCgroupV2Value(uint64(cpuShares))
 
+	if err := configureDiskIOLimits(cfg, command); err != nil {
+		return err
+	}
+
 	if command.Resources.LinuxResources != nil && command.Resources.LinuxResources.CpusetCgroupPath != "" {
 		cfg.Hooks = lconfigs.Hooks{
 			lconfigs.CreateRuntime: lconfigs.HookList{
@@ -715,6 +728,39 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 	return nil
 }
 
+// configureDiskIOLimits limits the disk IO of the task on the disk holding its
+// task dir, which libcontainer writes to io.max on cgroups v2 and to the blkio
+// throttle files on cgroups v1.
+func configureDiskIOLimits(cfg *lconfigs.Config, command *ExecCommand) error {
+	lr := command.Resources.LinuxResources
+	if lr == nil || (lr.DiskIOPS <= 0 && lr.DiskBandwidthBytes <= 0) {
+		return nil
+	}
+
+	major, minor, err := cgutil.BlockDevice(command.TaskDir)
+	if err != nil {
+		return fmt.Errorf("failed to find the disk to limit IO on: %v", err)
+	}
+
+	if lr.DiskIOPS > 0 {
+		cfg.Cgroups.Resources.BlkioThrottleReadIOPSDevice = []*lconfigs.ThrottleDevice{
+			lconfigs.NewThrottleDevice(int64(major), int64(minor), uint64(lr.DiskIOPS)),
+		}
+		cfg.Cgroups.Resources.BlkioThrottleWriteIOPSDevice = []*lconfigs.ThrottleDevice{
+			lconfigs.NewThrottleDevice(int64(major), int64(minor), uint64(lr.DiskIOPS)),
+		}
+	}
+	if lr.DiskBandwidthBytes > 0 {
+		cfg.Cgroups.Resources.BlkioThrottleReadBpsDevice = []*lconfigs.ThrottleDevice{
+			lconfigs.NewThrottleDevice(int64(major), int64(minor), uint64(lr.DiskBandwidthBytes)),
+		}
+		cfg.Cgroups.Resources.BlkioThrottleWriteBpsDevice = []*lconfigs.ThrottleDevice{
+			lconfigs.NewThrottleDevice(int64(major), int64(minor), uint64(lr.DiskBandwidthBytes)),
+		}
+	}
+	return nil
+}
+
 func configureBasicCgroups(cfg *lconfigs.Config) error {
 	id := uuid.Generate()
 
@@ -753,6 +799,24 @@ func getCgroupPathHelper(subsystem, cgroup string) (string, error) {
 	return filepath.Join(mnt, relCgroup), nil
 }
 
+// configureSeccomp filters the syscalls of the task with its seccomp profile,
+// if it has one.
+func configureSeccomp(cfg *lconfigs.Config, command *ExecCommand) error {
+	if len(command.SeccompProfile) == 0 {
+		return nil
+	}
+
+	spec, err := seccomp.Parse(command.SeccompProfile)
+	if err != nil {
+		return fmt.Errorf("failed to parse seccomp profile: %v", err)
+	}
+	cfg.Seccomp, err = specconv.SetupSeccomp(spec)
+	if err != nil {
+		return fmt.Errorf("failed to configure seccomp profile: %v", err)
+	}
+	return nil
+}
+
 func newLibcontainerConfig(command *ExecCommand) (*lconfigs.Config, error) {
 	cfg := &lconfigs.Config{
 		Cgroups: &lconfigs.Cgroup{
@@ -777,6 +841,10 @@ func newLibcontainerConfig(command *ExecCommand) (*lconfigs.Config, error) {
 		return nil, err
 	}
 
+	if err := configureSeccomp(cfg, command); err != nil {
+		return nil, err
+	}
+
 	if err := configureCgroups(cfg, command); err != nil {
 		return nil, err
 	}
@@ -14,9 +14,11 @@ import (
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/client/testutil"
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
+	"github.com/hashicorp/nomad/drivers/shared/seccomp"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/plugins/drivers"
@@ -307,6 +309,69 @@ func TestExecutor_CgroupPaths(t *testing.T) {
 	}, func(err error) { t.Error(err) })
 }
 
+// TestExecutor_configureSeccomp asserts that the seccomp profile of the task is
+// converted to the libcontainer format
+func TestExecutor_configureSeccomp(t *testing.T) {
+	ci.Parallel(t)
+
+	cfg := &lconfigs.Config{}
+	require.NoError(t, configureSeccomp(cfg, &ExecCommand{}))
+	require.Nil(t, cfg.Seccomp)
+
+	profile, err := seccomp.Resolve(seccomp.ProfileDefault, nil)
+	require.NoError(t, err)
+	require.NoError(t, configureSeccomp(cfg, &ExecCommand{SeccompProfile: profile}))
+	require.NotNil(t, cfg.Seccomp)
+	require.Equal(t, lconfigs.Allow, cfg.Seccomp.DefaultAction)
+	require.NotEmpty(t, cfg.Seccomp.Syscalls)
+	for _, call := range cfg.Seccomp.Syscalls {
+		require.Equal(t, lconfigs.Errno, call.Action)
+	}
+
+	err = configureSeccomp(cfg, &ExecCommand{SeccompProfile: []byte(`{"defaultAction":"SCMP_ACT_UNKNOWN"}`)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to configure seccomp profile")
+}
+
+// TestExecutor_configureDiskIOLimits asserts that the disk IO limits of the
+// task are set on the disk holding its task dir
+func TestExecutor_configureDiskIOLimits(t *testing.T) {
+	ci.Parallel(t)
+
+	taskDir := t.TempDir()
+	major, minor, err := cgutil.BlockDevice(taskDir)
+	if err != nil {
+		t.Skipf("task dir isn't on a block device: %v", err)
+	}
+
+	cfg := &lconfigs.Config{Cgroups: &lconfigs.Cgroup{Resources: &lconfigs.Resources{}}}
+	command := &ExecCommand{
+		TaskDir: taskDir,
+		Resources: &drivers.Resources{
+			LinuxResources: &drivers.LinuxResources{
+				DiskIOPS:           500,
+				DiskBandwidthBytes: 50 * 1024 * 1024,
+			},
+		},
+	}
+	require.NoError(t, configureDiskIOLimits(cfg, command))
+
+	res := cfg.Cgroups.Resources
+	expectedIOPS := []*lconfigs.ThrottleDevice{lconfigs.NewThrottleDevice(int64(major), int64(minor), 500)}
+	require.Equal(t, expectedIOPS, res.BlkioThrottleReadIOPSDevice)
+	require.Equal(t, expectedIOPS, res.BlkioThrottleWriteIOPSDevice)
+	expectedBps := []*lconfigs.ThrottleDevice{lconfigs.NewThrottleDevice(int64(major), int64(minor), 50*1024*1024)}
+	require.Equal(t, expectedBps, res.BlkioThrottleReadBpsDevice)
+	require.Equal(t, expectedBps, res.BlkioThrottleWriteBpsDevice)
+
+	// Without limits nothing is set
+	cfg = &lconfigs.Config{Cgroups: &lconfigs.Cgroup{Resources: &lconfigs.Resources{}}}
+	command.Resources.LinuxResources = &drivers.LinuxResources{}
+	require.NoError(t, configureDiskIOLimits(cfg, command))
+	require.Empty(t, cfg.Cgroups.Resources.BlkioThrottleReadIOPSDevice)
+	require.Empty(t, cfg.Cgroups.Resources.BlkioThrottleReadBpsDevice)
+}
+
 // TestExecutor_CgroupPaths asserts that all cgroups created for a task
 // are destroyed on shutdown
 func TestExecutor_CgroupPathsAreDestroyed(t *testing.T) {
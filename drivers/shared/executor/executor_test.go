@@ -18,6 +18,7 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/lib/fifo"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
@@ -212,6 +213,57 @@ func TestExecutor_Start_Wait(t *testing.T) {
 	}
 }
 
+func TestExecutor_Start_Stdin(t *testing.T) {
+	ci.Parallel(t)
+	for name, factory := range executorFactories {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			testExecCmd := testExecutorCommand(t)
+			execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+			execCmd.Cmd = "/bin/sh"
+			execCmd.Args = []string{"-c", `read line && echo "got: $line"`}
+			factory.configureExecCmd(t, execCmd)
+			defer allocDir.Destroy()
+
+			// Stream the input through a fifo like the dispatch hook does
+			execCmd.StdinPath = filepath.Join(t.TempDir(), "stdin.fifo")
+			_, err := fifo.CreateAndRead(execCmd.StdinPath)
+			require.NoError(err)
+			go func() {
+				w, err := fifo.OpenWriter(execCmd.StdinPath)
+				if err != nil {
+					return
+				}
+				defer w.Close()
+				w.Write([]byte("hello from stdin\n"))
+			}()
+
+			executor := factory.new(testlog.HCLogger(t))
+			defer executor.Shutdown("", 0)
+
+			ps, err := executor.Launch(execCmd)
+			require.NoError(err)
+			require.NotZero(ps.Pid)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			ps, err = executor.Wait(ctx)
+			require.NoError(err)
+			require.Zero(ps.ExitCode)
+
+			tu.WaitForResult(func() (bool, error) {
+				act := strings.TrimSpace(testExecCmd.stdout.String())
+				if act != "got: hello from stdin" {
+					return false, fmt.Errorf("unexpected stdout: %q", act)
+				}
+				return true, nil
+			}, func(err error) {
+				require.NoError(err)
+			})
+		})
+	}
+}
+
 func TestExecutor_Start_Wait_Children(t *testing.T) {
 	ci.Parallel(t)
 	for name, factory := range executorFactories {
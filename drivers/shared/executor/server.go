@@ -26,6 +26,7 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 		Resources:          drivers.ResourcesFromProto(req.Resources),
 		StdoutPath:         req.StdoutPath,
 		StderrPath:         req.StderrPath,
+		StdinPath:          req.StdinPath,
 		Env:                req.Env,
 		User:               req.User,
 		TaskDir:            req.TaskDir,
@@ -38,6 +39,7 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 		ModePID:            req.DefaultPidMode,
 		ModeIPC:            req.DefaultIpcMode,
 		Capabilities:       req.Capabilities,
+		SeccompProfile:     req.SeccompProfile,
 	})
 
 	if err != nil {
@@ -0,0 +1,147 @@
+// Package seccomp resolves the seccomp profiles applied to the tasks of the
+// exec-based task drivers.
+package seccomp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	// ProfileUnconfined disables seccomp filtering for a task.
+	ProfileUnconfined = "unconfined"
+
+	// ProfileDefault is the profile shipped with Nomad. See DefaultProfile.
+	ProfileDefault = "default"
+)
+
+// defaultBlockedSyscalls are the syscalls the default profile denies. They
+// allow a task to modify the kernel or the host, to escape its namespaces, or
+// to inspect other processes, and are not needed by typical workloads.
+var defaultBlockedSyscalls = []string{
+	"acct",
+	"add_key",
+	"bpf",
+	"clock_adjtime",
+	"clock_settime",
+	"create_module",
+	"delete_module",
+	"finit_module",
+	"get_kernel_syms",
+	"get_mempolicy",
+	"init_module",
+	"ioperm",
+	"iopl",
+	"kcmp",
+	"kexec_file_load",
+	"kexec_load",
+	"keyctl",
+	"lookup_dcookie",
+	"mbind",
+	"mount",
+	"move_pages",
+	"name_to_handle_at",
+	"nfsservctl",
+	"open_by_handle_at",
+	"perf_event_open",
+	"pivot_root",
+	"process_vm_readv",
+	"process_vm_writev",
+	"ptrace",
+	"query_module",
+	"quotactl",
+	"reboot",
+	"request_key",
+	"set_mempolicy",
+	"setns",
+	"settimeofday",
+	"stime",
+	"swapoff",
+	"swapon",
+	"syslog",
+	"umount",
+	"umount2",
+	"unshare",
+	"uselib",
+	"userfaultfd",
+	"ustat",
+	"vm86",
+	"vm86old",
+}
+
+// DefaultProfile returns the profile applied to tasks using ProfileDefault.
+// It allows every syscall except those in defaultBlockedSyscalls, which fail
+// with EPERM rather than killing the task.
+func DefaultProfile() *specs.LinuxSeccomp {
+	names := make([]string, len(defaultBlockedSyscalls))
+	copy(names, defaultBlockedSyscalls)
+
+	return &specs.LinuxSeccomp{
+		DefaultAction: specs.ActAllow,
+		Syscalls: []specs.LinuxSyscall{
+			{
+				Names:  names,
+				Action: specs.ActErrno,
+			},
+		},
+	}
+}
+
+// Resolve returns the encoded profile named by profile, or nil if the task is
+// unconfined. Besides the built-in profiles, profile may be the absolute path
+// of a JSON file in the format of the seccomp section of the OCI runtime
+// specification. The path must match one of the allowed glob patterns.
+func Resolve(profile string, allowed []string) ([]byte, error) {
+	switch profile {
+	case "", ProfileUnconfined:
+		return nil, nil
+	case ProfileDefault:
+		return json.Marshal(DefaultProfile())
+	}
+
+	if !filepath.IsAbs(profile) {
+		return nil, fmt.Errorf("seccomp profile must be %q, %q, or an absolute path, got %q",
+			ProfileDefault, ProfileUnconfined, profile)
+	}
+	if !isAllowed(profile, allowed) {
+		return nil, fmt.Errorf("seccomp profile %q is not allowed on this client", profile)
+	}
+
+	b, err := ioutil.ReadFile(profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seccomp profile: %v", err)
+	}
+	spec, err := Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse seccomp profile %q: %v", profile, err)
+	}
+
+	// Encode the profile again so only the fields used are passed along
+	return json.Marshal(spec)
+}
+
+// Parse decodes a profile returned by Resolve.
+func Parse(b []byte) (*specs.LinuxSeccomp, error) {
+	var spec specs.LinuxSeccomp
+	if err := json.Unmarshal(b, &spec); err != nil {
+		return nil, err
+	}
+	if spec.DefaultAction == "" {
+		return nil, fmt.Errorf("missing defaultAction")
+	}
+	return &spec, nil
+}
+
+// isAllowed returns whether path matches one of the glob patterns.
+func isAllowed(path string, allowed []string) bool {
+	for _, pattern := range allowed {
+		if ok, err := filepath.Match(pattern, path); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package seccomp
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolve(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	custom := filepath.Join(dir, "custom.json")
+	require.NoError(t, ioutil.WriteFile(custom, []byte(`{
+  "defaultAction": "SCMP_ACT_ERRNO",
+  "archMap": [{"architecture": "SCMP_ARCH_X86_64"}],
+  "syscalls": [{"names": ["read", "write"], "action": "SCMP_ACT_ALLOW"}]
+}`), 0644))
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, ioutil.WriteFile(invalid, []byte(`{"syscalls": []}`), 0644))
+	allowed := []string{filepath.Join(dir, "*.json")}
+
+	t.Run("unconfined", func(t *testing.T) {
+		for _, profile := range []string{"", ProfileUnconfined} {
+			b, err := Resolve(profile, nil)
+			require.NoError(t, err)
+			require.Nil(t, b)
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		b, err := Resolve(ProfileDefault, nil)
+		require.NoError(t, err)
+
+		spec, err := Parse(b)
+		require.NoError(t, err)
+		require.Equal(t, DefaultProfile(), spec)
+		require.Equal(t, specs.ActAllow, spec.DefaultAction)
+		require.Contains(t, spec.Syscalls[0].Names, "kexec_load")
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		b, err := Resolve(custom, allowed)
+		require.NoError(t, err)
+
+		// Fields outside of the OCI format are dropped
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal(b, &fields))
+		require.NotContains(t, fields, "archMap")
+
+		spec, err := Parse(b)
+		require.NoError(t, err)
+		require.Equal(t, specs.ActErrno, spec.DefaultAction)
+		require.Equal(t, []string{"read", "write"}, spec.Syscalls[0].Names)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := Resolve("custom.json", allowed)
+		require.EqualError(t, err, `seccomp profile must be "default", "unconfined", or an absolute path, got "custom.json"`)
+
+		_, err = Resolve(custom, nil)
+		require.EqualError(t, err, `seccomp profile "`+custom+`" is not allowed on this client`)
+
+		_, err = Resolve(filepath.Join(dir, "missing.json"), allowed)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read seccomp profile")
+
+		_, err = Resolve(invalid, allowed)
+		require.EqualError(t, err, `failed to parse seccomp profile "`+invalid+`": missing defaultAction`)
+	})
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+// +build !linux
+
+package seccomp
+
+// Supported returns whether seccomp profiles can be applied to tasks, which is
+// never the case outside of Linux.
+func Supported() bool {
+	return false
+}
+
+// KilledBySeccomp returns whether a task killed by signal was most likely
+// killed for making a syscall its seccomp profile denies.
+func KilledBySeccomp(signal int) bool {
+	return false
+}
@@ -0,0 +1,38 @@
+//go:build linux
+// +build linux
+
+package seccomp
+
+import (
+	"syscall"
+
+	"github.com/opencontainers/runc/libcontainer/seccomp"
+	"golang.org/x/sys/unix"
+)
+
+// Supported returns whether seccomp profiles can be applied to tasks. The
+// kernel must support seccomp filters and Nomad must be built with the
+// seccomp build tag, which links libseccomp.
+func Supported() bool {
+	if major, _, _ := seccomp.Version(); major == 0 {
+		return false
+	}
+
+	// PR_GET_SECCOMP fails with EINVAL if the kernel wasn't built with
+	// CONFIG_SECCOMP.
+	if err := unix.Prctl(unix.PR_GET_SECCOMP, 0, 0, 0, 0); err == unix.EINVAL {
+		return false
+	}
+
+	// Installing a nil filter fails with EFAULT if the kernel supports
+	// filters, and with EINVAL if it wasn't built with
+	// CONFIG_SECCOMP_FILTER.
+	err := unix.Prctl(unix.PR_SET_SECCOMP, unix.SECCOMP_MODE_FILTER, 0, 0, 0)
+	return err != unix.EINVAL
+}
+
+// KilledBySeccomp returns whether a task killed by signal was most likely
+// killed for making a syscall its seccomp profile denies.
+func KilledBySeccomp(signal int) bool {
+	return signal == int(syscall.SIGSYS)
+}
@@ -0,0 +1,155 @@
+// Package accesslog writes a structured record of each request served by a
+// Nomad agent, one JSON document per line, to a set of sinks.
+package accesslog
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// TypeHTTP marks an entry for a request made to the HTTP API.
+	TypeHTTP = "http"
+
+	// TypeRPC marks an entry for an RPC served by a Nomad server.
+	TypeRPC = "rpc"
+
+	// ResultOK is the result of a request that succeeded.
+	ResultOK = "ok"
+
+	// ResultDenied is the result of a request rejected because its token
+	// was unknown or lacked the required capabilities.
+	ResultDenied = "denied"
+
+	// ResultError is the result of a request that failed for any other
+	// reason.
+	ResultError = "error"
+)
+
+// Entry is a single access log record.
+type Entry struct {
+	// Time is when the request was received.
+	Time time.Time `json:"time"`
+
+	// Type is either TypeHTTP or TypeRPC.
+	Type string `json:"type"`
+
+	// AccessorID is the accessor of the ACL token the request was made
+	// with. It is empty when ACLs are disabled or the token is unknown.
+	AccessorID string `json:"accessor_id,omitempty"`
+
+	// Method is the HTTP method of the request. It is empty for RPCs.
+	Method string `json:"method,omitempty"`
+
+	// Endpoint is the URL path of an HTTP request or the name of an RPC.
+	Endpoint string `json:"endpoint"`
+
+	// Namespace is the namespace the request targets.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Region is the region the request targets.
+	Region string `json:"region,omitempty"`
+
+	// RemoteAddr is the address of the caller.
+	RemoteAddr string `json:"remote_addr,omitempty"`
+
+	// Status is the HTTP status code of the response. It is zero for RPCs.
+	Status int `json:"status,omitempty"`
+
+	// Result is one of ResultOK, ResultDenied or ResultError.
+	Result string `json:"result"`
+
+	// Error is the error message returned to the caller, if any.
+	Error string `json:"error,omitempty"`
+
+	// DurationMS is the time taken to serve the request in milliseconds.
+	DurationMS float64 `json:"duration_ms"`
+}
+
+// SetResult sets the Result and Error of the entry from the error message
+// returned to the caller.
+func (e *Entry) SetResult(errMsg string) {
+	e.Error = errMsg
+	switch {
+	case errMsg == "":
+		e.Result = ResultOK
+	case structs.IsErrPermissionDenied(errorString(errMsg)),
+		structs.IsErrTokenNotFound(errorString(errMsg)):
+		e.Result = ResultDenied
+	default:
+		e.Result = ResultError
+	}
+}
+
+// SetDuration sets DurationMS to the time elapsed since start.
+func (e *Entry) SetDuration(start time.Time) {
+	e.DurationMS = float64(time.Since(start)) / float64(time.Millisecond)
+}
+
+// errorString allows matching an error message received over the wire with
+// the structs error helpers.
+type errorString string
+
+func (e errorString) Error() string { return string(e) }
+
+// Logger encodes entries and writes them to every sink.
+type Logger struct {
+	logger log.Logger
+
+	// l serializes writes so lines from concurrent requests are not
+	// interleaved.
+	l     sync.Mutex
+	sinks []io.Writer
+}
+
+// New returns a Logger writing to the given sinks. Sinks that implement
+// io.Closer are closed when the Logger is closed.
+func New(logger log.Logger, sinks ...io.Writer) *Logger {
+	return &Logger{
+		logger: logger.Named("access_log"),
+		sinks:  sinks,
+	}
+}
+
+// Log writes the entry to every sink. Failures are logged and do not affect
+// the request the entry describes.
+func (l *Logger) Log(e *Entry) {
+	buf, err := json.Marshal(e)
+	if err != nil {
+		l.logger.Warn("failed to encode access log entry", "error", err)
+		return
+	}
+	buf = append(buf, '\n')
+
+	l.l.Lock()
+	defer l.l.Unlock()
+	for _, sink := range l.sinks {
+		if _, err := sink.Write(buf); err != nil {
+			metrics.IncrCounter([]string{"nomad", "access_log", "write_error"}, 1)
+			l.logger.Warn("failed to write access log entry", "error", err)
+		}
+	}
+}
+
+// Close closes the sinks of the Logger.
+func (l *Logger) Close() error {
+	l.l.Lock()
+	defer l.l.Unlock()
+
+	var mErr multierror.Error
+	for _, sink := range l.sinks {
+		if c, ok := sink.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				_ = multierror.Append(&mErr, err)
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
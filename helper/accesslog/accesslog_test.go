@@ -0,0 +1,106 @@
+package accesslog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntry_SetResult(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		errMsg   string
+		expected string
+	}{
+		{"", ResultOK},
+		{structs.ErrPermissionDenied.Error(), ResultDenied},
+		{"rpc error: " + structs.ErrTokenNotFound.Error(), ResultDenied},
+		{"job not found", ResultError},
+	}
+
+	for _, tc := range cases {
+		e := &Entry{}
+		e.SetResult(tc.errMsg)
+		require.Equal(t, tc.expected, e.Result, tc.errMsg)
+		require.Equal(t, tc.errMsg, e.Error)
+	}
+}
+
+func TestLogger_Log(t *testing.T) {
+	ci.Parallel(t)
+
+	var first, second bytes.Buffer
+	l := New(testlog.HCLogger(t), &first, &second)
+
+	now := time.Now().UTC()
+	l.Log(&Entry{Time: now, Type: TypeHTTP, Method: "GET", Endpoint: "/v1/jobs", Status: 200, Result: ResultOK})
+	l.Log(&Entry{Time: now, Type: TypeRPC, Endpoint: "Job.Register", Namespace: "prod", Result: ResultDenied})
+	require.NoError(t, l.Close())
+
+	// Every sink receives one JSON document per line
+	require.Equal(t, first.String(), second.String())
+
+	var entries []*Entry
+	scanner := bufio.NewScanner(&first)
+	for scanner.Scan() {
+		var e Entry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		entries = append(entries, &e)
+	}
+	require.Len(t, entries, 2)
+	require.Equal(t, "/v1/jobs", entries[0].Endpoint)
+	require.Equal(t, 200, entries[0].Status)
+	require.True(t, now.Equal(entries[0].Time))
+	require.Equal(t, "Job.Register", entries[1].Endpoint)
+	require.Equal(t, "prod", entries[1].Namespace)
+	require.Equal(t, ResultDenied, entries[1].Result)
+}
+
+func TestHTTPSink(t *testing.T) {
+	ci.Parallel(t)
+
+	var l sync.Mutex
+	var lines []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		l.Lock()
+		defer l.Unlock()
+		lines = append(lines, strings.Split(strings.TrimSpace(string(body)), "\n")...)
+	}))
+	defer srv.Close()
+
+	sink := NewHTTPSink(srv.URL, testlog.HCLogger(t))
+	logger := New(testlog.HCLogger(t), sink)
+	for i := 0; i < 3; i++ {
+		logger.Log(&Entry{Type: TypeHTTP, Endpoint: "/v1/jobs", Result: ResultOK})
+	}
+
+	// Closing the sink delivers the buffered entries
+	require.NoError(t, logger.Close())
+
+	l.Lock()
+	defer l.Unlock()
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		var e Entry
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+		require.Equal(t, "/v1/jobs", e.Endpoint)
+	}
+}
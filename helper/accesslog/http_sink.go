@@ -0,0 +1,145 @@
+package accesslog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+)
+
+const (
+	// httpSinkBufferSize is the number of entries the HTTP sink buffers
+	// while a batch is being delivered. Entries are dropped once it is full.
+	httpSinkBufferSize = 4096
+
+	// httpSinkBatchSize is the maximum number of entries sent in one
+	// request.
+	httpSinkBatchSize = 256
+
+	// httpSinkFlushInterval is the maximum time an entry is buffered before
+	// it is sent.
+	httpSinkFlushInterval = 1 * time.Second
+
+	// httpSinkTimeout bounds the time taken to deliver a batch.
+	httpSinkTimeout = 10 * time.Second
+)
+
+// HTTPSink is an io.Writer that delivers access log entries to an HTTP
+// endpoint. Entries are batched and POSTed as newline-delimited JSON so that
+// a slow or unavailable endpoint never blocks the requests being logged.
+type HTTPSink struct {
+	address string
+	client  *http.Client
+	logger  log.Logger
+
+	entriesCh chan []byte
+	stopCh    chan struct{}
+	doneCh    chan struct{}
+}
+
+// NewHTTPSink returns an HTTPSink delivering entries to address and starts
+// its delivery loop.
+func NewHTTPSink(address string, logger log.Logger) *HTTPSink {
+	s := &HTTPSink{
+		address:   address,
+		client:    &http.Client{Timeout: httpSinkTimeout},
+		logger:    logger.Named("access_log"),
+		entriesCh: make(chan []byte, httpSinkBufferSize),
+		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Write queues a single encoded entry for delivery.
+func (s *HTTPSink) Write(p []byte) (int, error) {
+	// The caller may reuse p once Write returns
+	entry := make([]byte, len(p))
+	copy(entry, p)
+
+	select {
+	case s.entriesCh <- entry:
+		return len(p), nil
+	default:
+		return 0, fmt.Errorf("access log HTTP sink buffer full, dropping entry")
+	}
+}
+
+// Close delivers the queued entries and stops the sink.
+func (s *HTTPSink) Close() error {
+	close(s.stopCh)
+	<-s.doneCh
+	return nil
+}
+
+func (s *HTTPSink) run() {
+	defer close(s.doneCh)
+
+	ticker := time.NewTicker(httpSinkFlushInterval)
+	defer ticker.Stop()
+
+	var batch bytes.Buffer
+	entries := 0
+	flush := func() {
+		if entries == 0 {
+			return
+		}
+		if err := s.send(batch.Bytes()); err != nil {
+			s.logger.Warn("failed to deliver access log entries", "entries", entries, "error", err)
+		}
+		batch.Reset()
+		entries = 0
+	}
+
+	for {
+		select {
+		case entry := <-s.entriesCh:
+			batch.Write(entry)
+			entries++
+			if entries >= httpSinkBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-s.stopCh:
+			for {
+				select {
+				case entry := <-s.entriesCh:
+					batch.Write(entry)
+					entries++
+					if entries >= httpSinkBatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *HTTPSink) send(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, s.address, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+	return nil
+}
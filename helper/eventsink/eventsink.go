@@ -0,0 +1,409 @@
+// Package eventsink forwards the Nomad event stream to an external message
+// broker, one message per event.
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// DefaultTopic is the template of the broker topic events are published
+	// to when none is configured.
+	DefaultTopic = "nomad.{{ .Topic }}"
+
+	// DefaultBufferSize is the number of events buffered while a batch is
+	// being delivered when no buffer size is configured.
+	DefaultBufferSize = 1024
+
+	// DefaultBatchSize is the maximum number of events delivered at once
+	// when no batch size is configured.
+	DefaultBatchSize = 100
+
+	// flushInterval is the maximum time an event is buffered before it is
+	// delivered.
+	flushInterval = 1 * time.Second
+
+	// minRetryInterval and maxRetryInterval bound the exponential backoff
+	// between failed deliveries of a batch.
+	minRetryInterval = 1 * time.Second
+	maxRetryInterval = 30 * time.Second
+
+	// closeTimeout bounds the last attempt at delivering the buffered
+	// events when the forwarder is closed.
+	closeTimeout = 10 * time.Second
+)
+
+// Message is a single event to publish to the broker.
+type Message struct {
+	// Topic is the broker topic, or NATS subject, rendered from the topic
+	// template.
+	Topic string
+
+	// Key is the key of the event, used by Kafka to pick the partition.
+	Key string
+
+	// Value is the JSON encoded event.
+	Value []byte
+
+	// index is the index of the events the message is part of. It is set on
+	// the last message of these events only, so that a batch ending with it
+	// completes their delivery.
+	index uint64
+}
+
+// Sink publishes batches of messages to a broker.
+type Sink interface {
+	// Send publishes the messages, returning once the broker acknowledged
+	// them. Errors of messages the broker rejects permanently are marked
+	// unrecoverable with structs.NewRecoverableError, so that they are
+	// dropped instead of retried.
+	Send(ctx context.Context, messages []*Message) error
+
+	// Close releases the connections to the broker.
+	Close() error
+}
+
+// Config configures a Forwarder.
+type Config struct {
+	// Topic is the text/template rendering the broker topic of an event,
+	// from its Topic, Type, Key, Namespace and Index fields.
+	Topic string
+
+	// BufferSize is the number of events buffered while a batch is being
+	// delivered.
+	BufferSize int
+
+	// BatchSize is the maximum number of events delivered at once.
+	BatchSize int
+
+	// DropOnFull drops events once the buffer is full rather than blocking
+	// the event stream until the broker catches up.
+	DropOnFull bool
+}
+
+// topicData is the data the topic template is rendered with.
+type topicData struct {
+	Topic     string
+	Type      string
+	Key       string
+	Namespace string
+	Index     uint64
+}
+
+// events is the subset of a frame of the event stream the forwarder needs.
+type events struct {
+	Index  uint64
+	Events []json.RawMessage
+}
+
+// TLSConfig returns the configuration of TLS connections to a broker. The
+// certificate of the broker is verified against the CA certificate in caFile,
+// or the system roots if empty, and the client certificate in certFile and
+// keyFile is presented if set.
+func TLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	conf := &tls.Config{}
+	if caFile != "" {
+		data, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("failed to parse any valid certificates in CA file: %s", caFile)
+		}
+		conf.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %v", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	return conf, nil
+}
+
+// Forwarder buffers the events written to it and delivers them to a Sink in
+// batches, retrying failed deliveries until they succeed. Events the broker
+// rejects permanently are dropped.
+type Forwarder struct {
+	sink       Sink
+	topic      *template.Template
+	batchSize  int
+	dropOnFull bool
+	logger     log.Logger
+
+	// delivered is the index of the latest events fully delivered
+	delivered uint64
+
+	// dropped is the number of events dropped since the last report
+	dropped uint64
+
+	messagesCh chan *Message
+	stopCh     chan struct{}
+	doneCh     chan struct{}
+	stopOnce   sync.Once
+}
+
+// NewForwarder returns a Forwarder delivering events to sink and starts its
+// delivery loop.
+func NewForwarder(sink Sink, conf *Config, logger log.Logger) (*Forwarder, error) {
+	topic := conf.Topic
+	if topic == "" {
+		topic = DefaultTopic
+	}
+	tmpl, err := template.New("topic").Parse(topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse topic template: %v", err)
+	}
+
+	bufferSize := conf.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	batchSize := conf.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	f := &Forwarder{
+		sink:       sink,
+		topic:      tmpl,
+		batchSize:  batchSize,
+		dropOnFull: conf.DropOnFull,
+		logger:     logger.Named("event_sink"),
+		messagesCh: make(chan *Message, bufferSize),
+		stopCh:     make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+	go f.run()
+	return f, nil
+}
+
+// Write queues the events of a frame of the event stream, encoded as JSON,
+// for delivery. Heartbeats are ignored. Once the buffer is full, Write either
+// drops the events or blocks until there is room for them, the context is
+// done or the forwarder is closed.
+func (f *Forwarder) Write(ctx context.Context, frame []byte) error {
+	var evs events
+	if err := json.Unmarshal(frame, &evs); err != nil {
+		return fmt.Errorf("failed to decode events: %v", err)
+	}
+	if len(evs.Events) == 0 {
+		return nil
+	}
+
+	messages := make([]*Message, 0, len(evs.Events))
+	for _, raw := range evs.Events {
+		msg, err := f.message(raw)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, msg)
+	}
+	messages[len(messages)-1].index = evs.Index
+
+	for _, msg := range messages {
+		if f.dropOnFull {
+			select {
+			case f.messagesCh <- msg:
+			default:
+				atomic.AddUint64(&f.dropped, 1)
+				metrics.IncrCounter([]string{"nomad", "event_sink", "dropped"}, 1)
+			}
+			continue
+		}
+
+		select {
+		case f.messagesCh <- msg:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-f.stopCh:
+			return fmt.Errorf("event sink closed")
+		}
+	}
+	return nil
+}
+
+// message returns the message publishing the JSON encoded event.
+func (f *Forwarder) message(raw json.RawMessage) (*Message, error) {
+	var data topicData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode event: %v", err)
+	}
+
+	var topic bytes.Buffer
+	if err := f.topic.Execute(&topic, &data); err != nil {
+		return nil, fmt.Errorf("failed to render topic of event: %v", err)
+	}
+
+	return &Message{
+		Topic: topic.String(),
+		Key:   data.Key,
+		Value: raw,
+	}, nil
+}
+
+// Delivered returns the index of the latest events fully delivered to the
+// broker.
+func (f *Forwarder) Delivered() uint64 {
+	return atomic.LoadUint64(&f.delivered)
+}
+
+// Close makes one last attempt at delivering the buffered events, then
+// stops the forwarder and closes the sink.
+func (f *Forwarder) Close() error {
+	f.stopOnce.Do(func() { close(f.stopCh) })
+	<-f.doneCh
+	return f.sink.Close()
+}
+
+func (f *Forwarder) run() {
+	defer close(f.doneCh)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Message, 0, f.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if f.deliver(batch) {
+			batch = batch[:0]
+		}
+	}
+
+	for {
+		select {
+		case msg := <-f.messagesCh:
+			batch = append(batch, msg)
+			if len(batch) >= f.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+			if dropped := atomic.SwapUint64(&f.dropped, 0); dropped > 0 {
+				f.logger.Warn("event sink buffer full, dropped events", "events", dropped)
+			}
+		case <-f.stopCh:
+			ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+			defer cancel()
+		drain:
+			for {
+				select {
+				case msg := <-f.messagesCh:
+					batch = append(batch, msg)
+				default:
+					break drain
+				}
+			}
+			for len(batch) > 0 {
+				n := len(batch)
+				if n > f.batchSize {
+					n = f.batchSize
+				}
+				if err := f.send(ctx, batch[:n]); err != nil {
+					f.logger.Warn("failed to deliver events on close", "events", len(batch), "error", err)
+					return
+				}
+				batch = batch[n:]
+			}
+			return
+		}
+	}
+}
+
+// send sends the batch to the sink and records its delivery.
+func (f *Forwarder) send(ctx context.Context, batch []*Message) error {
+	if err := f.sink.Send(ctx, batch); err != nil {
+		metrics.IncrCounter([]string{"nomad", "event_sink", "errors"}, 1)
+		return err
+	}
+
+	metrics.IncrCounter([]string{"nomad", "event_sink", "delivered"}, float32(len(batch)))
+	f.markDelivered(batch)
+	return nil
+}
+
+// markDelivered records the delivery of the events completed by the batch.
+func (f *Forwarder) markDelivered(batch []*Message) {
+	for i := len(batch) - 1; i >= 0; i-- {
+		if index := batch[i].index; index != 0 {
+			atomic.StoreUint64(&f.delivered, index)
+			break
+		}
+	}
+}
+
+// rejected returns whether the error is a permanent rejection of the batch by
+// the broker, which retrying can't fix.
+func rejected(err error) bool {
+	re, ok := err.(structs.Recoverable)
+	return ok && !re.IsRecoverable()
+}
+
+// deliver sends the batch to the sink, retrying with an exponential backoff
+// until it succeeds or the forwarder is closed. When the broker rejects the
+// batch permanently, its messages are delivered one by one so that only the
+// rejected messages are dropped. It returns whether the batch was delivered.
+func (f *Forwarder) deliver(batch []*Message) bool {
+	wait := minRetryInterval
+	for {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			select {
+			case <-f.stopCh:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		err := f.send(ctx, batch)
+		cancel()
+		if err == nil {
+			return true
+		}
+
+		if rejected(err) {
+			if len(batch) > 1 {
+				for i := range batch {
+					if !f.deliver(batch[i : i+1]) {
+						return false
+					}
+				}
+				return true
+			}
+
+			f.logger.Error("event rejected by the broker, dropping it", "topic", batch[0].Topic, "key", batch[0].Key, "error", err)
+			metrics.IncrCounter([]string{"nomad", "event_sink", "rejected"}, 1)
+			f.markDelivered(batch)
+			return true
+		}
+
+		f.logger.Warn("failed to deliver events", "events", len(batch), "error", err, "retry", wait)
+
+		select {
+		case <-f.stopCh:
+			return false
+		case <-time.After(wait):
+		}
+		wait *= 2
+		if wait > maxRetryInterval {
+			wait = maxRetryInterval
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package eventsink
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+// testSink records the messages it is sent and fails while failing is set.
+// Batches with a message to the "rejected" topic are rejected permanently.
+type testSink struct {
+	l        sync.Mutex
+	messages []*Message
+	failing  bool
+	sendCh   chan struct{}
+}
+
+func newTestSink() *testSink {
+	return &testSink{sendCh: make(chan struct{}, 100)}
+}
+
+func (s *testSink) Send(_ context.Context, messages []*Message) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	defer func() { s.sendCh <- struct{}{} }()
+	if s.failing {
+		return fmt.Errorf("broker unavailable")
+	}
+	for _, msg := range messages {
+		if msg.Topic == "rejected" {
+			return structs.NewRecoverableError(fmt.Errorf("message rejected"), false)
+		}
+	}
+	s.messages = append(s.messages, messages...)
+	return nil
+}
+
+func (s *testSink) Close() error { return nil }
+
+func (s *testSink) setFailing(failing bool) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.failing = failing
+}
+
+func (s *testSink) topics() []string {
+	s.l.Lock()
+	defer s.l.Unlock()
+	var topics []string
+	for _, msg := range s.messages {
+		topics = append(topics, msg.Topic)
+	}
+	return topics
+}
+
+func testFrame(index uint64, topics ...string) []byte {
+	var events []string
+	for i, topic := range topics {
+		events = append(events, fmt.Sprintf(`{"Topic":%q,"Type":"Updated","Key":"key-%d","Namespace":"default","Index":%d,"Payload":{}}`, topic, i, index))
+	}
+	return []byte(fmt.Sprintf(`{"Index":%d,"Events":[%s]}`, index, strings.Join(events, ",")))
+}
+
+func TestForwarder_Write(t *testing.T) {
+	ci.Parallel(t)
+
+	sink := newTestSink()
+	f, err := NewForwarder(sink, &Config{
+		Topic:     "nomad.{{ .Namespace }}.{{ .Topic }}",
+		BatchSize: 3,
+	}, testlog.HCLogger(t))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, f.Write(ctx, []byte(`{}`)))
+	require.NoError(t, f.Write(ctx, testFrame(10, "Job", "Evaluation")))
+	require.NoError(t, f.Write(ctx, testFrame(11, "Allocation")))
+
+	// The first batch is full and is delivered at once
+	<-sink.sendCh
+	require.Equal(t, []string{"nomad.default.Job", "nomad.default.Evaluation", "nomad.default.Allocation"}, sink.topics())
+	require.Equal(t, uint64(11), f.Delivered())
+
+	sink.l.Lock()
+	msg := sink.messages[0]
+	sink.l.Unlock()
+	require.Equal(t, "key-0", msg.Key)
+	require.Contains(t, string(msg.Value), `"Topic":"Job"`)
+
+	// A batch ending in the middle of the events of an index doesn't
+	// complete their delivery
+	require.NoError(t, f.Write(ctx, testFrame(12, "Job", "Job", "Job", "Job")))
+	<-sink.sendCh
+	require.Equal(t, uint64(11), f.Delivered())
+
+	require.NoError(t, f.Close())
+	require.Len(t, sink.topics(), 7)
+	require.Equal(t, uint64(12), f.Delivered())
+}
+
+func TestForwarder_Retry(t *testing.T) {
+	ci.Parallel(t)
+
+	sink := newTestSink()
+	sink.setFailing(true)
+	f, err := NewForwarder(sink, &Config{BatchSize: 1}, testlog.HCLogger(t))
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.NoError(t, f.Write(context.Background(), testFrame(5, "Node")))
+	<-sink.sendCh
+	require.Zero(t, f.Delivered())
+
+	sink.setFailing(false)
+	select {
+	case <-sink.sendCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("batch not retried")
+	}
+	require.Equal(t, []string{"nomad.Node"}, sink.topics())
+	require.Equal(t, uint64(5), f.Delivered())
+}
+
+func TestForwarder_Rejected(t *testing.T) {
+	ci.Parallel(t)
+
+	sink := newTestSink()
+	f, err := NewForwarder(sink, &Config{Topic: "{{ .Topic }}", BatchSize: 3}, testlog.HCLogger(t))
+	require.NoError(t, err)
+	defer f.Close()
+
+	// The rejected event is dropped and the others of its batch delivered
+	require.NoError(t, f.Write(context.Background(), testFrame(7, "Job", "rejected", "Node")))
+	testutil.WaitForResult(func() (bool, error) {
+		if delivered := f.Delivered(); delivered != 7 {
+			return false, fmt.Errorf("events not delivered: %d", delivered)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+	require.Equal(t, []string{"Job", "Node"}, sink.topics())
+}
+
+func TestForwarder_Backpressure(t *testing.T) {
+	ci.Parallel(t)
+
+	// Block mode waits for room in the buffer
+	sink := newTestSink()
+	sink.setFailing(true)
+	f, err := NewForwarder(sink, &Config{BufferSize: 1, BatchSize: 1}, testlog.HCLogger(t))
+	require.NoError(t, err)
+
+	require.NoError(t, f.Write(context.Background(), testFrame(1, "Job")))
+	<-sink.sendCh
+	require.NoError(t, f.Write(context.Background(), testFrame(2, "Job")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.Equal(t, context.DeadlineExceeded, f.Write(ctx, testFrame(3, "Job")))
+	f.Close()
+
+	// Drop mode drops the events that don't fit
+	sink = newTestSink()
+	sink.setFailing(true)
+	f, err = NewForwarder(sink, &Config{BufferSize: 1, BatchSize: 1, DropOnFull: true}, testlog.HCLogger(t))
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.NoError(t, f.Write(context.Background(), testFrame(1, "Job")))
+	<-sink.sendCh
+	require.NoError(t, f.Write(context.Background(), testFrame(2, "Job", "Job", "Job")))
+	require.NotZero(t, atomic.LoadUint64(&f.dropped))
+}
+
+// natsConnect is the subset of the CONNECT options of the NATS client the
+// tests check.
+type natsConnect struct {
+	Name string `json:"name"`
+	User string `json:"user"`
+	Pass string `json:"pass"`
+}
+
+func TestNATSSink_Send(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := testutil.NewTestNATSServer(t, nil)
+	defer srv.Stop()
+	srv.Deny("rejected")
+
+	sink, err := NewNATSSink("nats://nomad:secret@"+srv.Addr, "", "", nil)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	err = sink.Send(context.Background(), []*Message{
+		{Topic: "nomad.Job", Value: []byte(`{"Topic":"Job"}`)},
+		{Topic: "nomad.Node", Value: []byte(`{"Topic":"Node"}`)},
+	})
+	require.NoError(t, err)
+
+	connects := srv.Connects()
+	require.Len(t, connects, 1)
+	var connect natsConnect
+	require.NoError(t, json.Unmarshal([]byte(connects[0]), &connect))
+	require.Equal(t, "nomad", connect.Name)
+	require.Equal(t, "nomad", connect.User)
+	require.Equal(t, "secret", connect.Pass)
+
+	require.Equal(t, [][]byte{[]byte(`{"Topic":"Job"}`)}, srv.Messages("nomad.Job"))
+	require.Equal(t, [][]byte{[]byte(`{"Topic":"Node"}`)}, srv.Messages("nomad.Node"))
+
+	// Publishing to a denied subject is rejected permanently
+	err = sink.Send(context.Background(), []*Message{{Topic: "rejected", Value: []byte(`{}`)}})
+	require.Error(t, err)
+	require.True(t, rejected(err))
+
+	// The sink connects again after an error
+	err = sink.Send(context.Background(), []*Message{{Topic: "nomad.Job", Value: []byte(`{}`)}})
+	require.NoError(t, err)
+	require.Len(t, srv.Messages("nomad.Job"), 2)
+}
+
+func TestNATSSink_TLS(t *testing.T) {
+	ci.Parallel(t)
+
+	// Borrow the certificate of an httptest server, valid for 127.0.0.1
+	https := httptest.NewTLSServer(http.NotFoundHandler())
+	defer https.Close()
+	pool := x509.NewCertPool()
+	pool.AddCert(https.Certificate())
+
+	srv := testutil.NewTestNATSServer(t, &tls.Config{Certificates: https.TLS.Certificates})
+	defer srv.Stop()
+
+	// Without the CA certificate the server can't be verified
+	sink, err := NewNATSSink(srv.Addr, "nomad", "secret", nil)
+	require.NoError(t, err)
+	err = sink.Send(context.Background(), []*Message{{Topic: "nomad.Job", Value: []byte(`{}`)}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "certificate")
+	sink.Close()
+	require.Empty(t, srv.Connects())
+
+	sink, err = NewNATSSink(srv.Addr, "nomad", "secret", &tls.Config{RootCAs: pool})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	err = sink.Send(context.Background(), []*Message{{Topic: "nomad.Job", Value: []byte(`{"Topic":"Job"}`)}})
+	require.NoError(t, err)
+
+	connects := srv.Connects()
+	require.Len(t, connects, 1)
+	var connect natsConnect
+	require.NoError(t, json.Unmarshal([]byte(connects[0]), &connect))
+	require.Equal(t, "nomad", connect.User)
+	require.Equal(t, [][]byte{[]byte(`{"Topic":"Job"}`)}, srv.Messages("nomad.Job"))
+}
+
+func TestNewKafkaSink(t *testing.T) {
+	ci.Parallel(t)
+
+	sink, err := NewKafkaSink("kafka-1:9093, kafka-2", "", "", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"kafka-1:9093", "kafka-2:9092"}, sink.brokers)
+	require.Nil(t, sink.transport.SASL)
+
+	sink, err = NewKafkaSink("kafka:9092", "nomad", "secret", &tls.Config{})
+	require.NoError(t, err)
+	require.NotNil(t, sink.transport.SASL)
+	require.NotNil(t, sink.transport.TLS)
+
+	_, err = NewKafkaSink("http://kafka-rest:8082", "", "", nil)
+	require.Error(t, err)
+
+	_, err = NewKafkaSink(" , ", "", "", nil)
+	require.EqualError(t, err, "missing Kafka broker address")
+}
+
+func TestKafkaRejected(t *testing.T) {
+	ci.Parallel(t)
+
+	require.True(t, kafkaRejected(kafka.MessageSizeTooLarge))
+	require.True(t, kafkaRejected(kafka.InvalidTopic))
+	require.True(t, kafkaRejected(kafka.MessageTooLargeError{}))
+	require.False(t, kafkaRejected(kafka.NotLeaderForPartition))
+	require.False(t, kafkaRejected(kafka.TopicAuthorizationFailed))
+	require.False(t, kafkaRejected(fmt.Errorf("connection refused")))
+
+	// Records that failed along others are only rejected if none of them
+	// can be published again
+	require.True(t, kafkaRejected(kafka.WriteErrors{nil, kafka.MessageSizeTooLarge}))
+	require.False(t, kafkaRejected(kafka.WriteErrors{kafka.MessageSizeTooLarge, kafka.NotLeaderForPartition}))
+}
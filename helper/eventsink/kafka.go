@@ -0,0 +1,184 @@
+package eventsink
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/nomad/structs"
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+)
+
+const (
+	// kafkaDefaultPort is the port of the Kafka brokers when the address has
+	// none.
+	kafkaDefaultPort = "9092"
+
+	// kafkaTimeout bounds the time taken to connect to the Kafka brokers and
+	// to publish the records of a topic.
+	kafkaTimeout = 10 * time.Second
+
+	// kafkaBatchTimeout is the time the writer of a topic waits for more
+	// records before publishing them. The forwarder already batches events,
+	// so it is kept short.
+	kafkaBatchTimeout = 10 * time.Millisecond
+)
+
+// KafkaSink publishes messages to the Kafka brokers, using the message topic
+// as the Kafka topic and the event key as the record key, so that the events
+// of an object are published to the same partition in order.
+type KafkaSink struct {
+	brokers   []string
+	transport *kafka.Transport
+
+	l       sync.Mutex
+	writers map[string]*kafka.Writer
+}
+
+// NewKafkaSink returns a KafkaSink publishing to the Kafka brokers in the
+// comma separated list of host:port addresses. The sink authenticates with
+// SASL PLAIN when a username is set, and connects over TLS when tlsConf is
+// set.
+func NewKafkaSink(address, username, password string, tlsConf *tls.Config) (*KafkaSink, error) {
+	var brokers []string
+	for _, broker := range strings.Split(address, ",") {
+		broker = strings.TrimSpace(broker)
+		if broker == "" {
+			continue
+		}
+		if strings.Contains(broker, "://") {
+			return nil, fmt.Errorf("Kafka broker address %q must be a host:port address", broker)
+		}
+		if _, _, err := net.SplitHostPort(broker); err != nil {
+			broker = net.JoinHostPort(broker, kafkaDefaultPort)
+		}
+		brokers = append(brokers, broker)
+	}
+	if len(brokers) == 0 {
+		return nil, fmt.Errorf("missing Kafka broker address")
+	}
+
+	transport := &kafka.Transport{
+		ClientID:    "nomad",
+		DialTimeout: kafkaTimeout,
+		TLS:         tlsConf,
+	}
+	if username != "" {
+		transport.SASL = plain.Mechanism{
+			Username: username,
+			Password: password,
+		}
+	}
+
+	return &KafkaSink{
+		brokers:   brokers,
+		transport: transport,
+		writers:   make(map[string]*kafka.Writer),
+	}, nil
+}
+
+// Send publishes the messages of each topic, in the order of their first
+// message. A batch with records the brokers reject permanently, for example
+// because they are too large, returns an unrecoverable error.
+func (s *KafkaSink) Send(ctx context.Context, messages []*Message) error {
+	var topics []string
+	records := make(map[string][]kafka.Message)
+	for _, msg := range messages {
+		if _, ok := records[msg.Topic]; !ok {
+			topics = append(topics, msg.Topic)
+		}
+		records[msg.Topic] = append(records[msg.Topic], kafka.Message{
+			Key:   []byte(msg.Key),
+			Value: msg.Value,
+		})
+	}
+
+	for _, topic := range topics {
+		if err := s.writer(topic).WriteMessages(ctx, records[topic]...); err != nil {
+			return structs.NewRecoverableError(
+				fmt.Errorf("failed to publish to Kafka topic %q: %v", topic, err),
+				!kafkaRejected(err))
+		}
+	}
+	return nil
+}
+
+// writer returns the writer of the topic, creating it on first use.
+func (s *KafkaSink) writer(topic string) *kafka.Writer {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	w, ok := s.writers[topic]
+	if !ok {
+		w = &kafka.Writer{
+			Addr:         kafka.TCP(s.brokers...),
+			Topic:        topic,
+			Transport:    s.transport,
+			Balancer:     &kafka.Hash{},
+			MaxAttempts:  1,
+			BatchTimeout: kafkaBatchTimeout,
+			ReadTimeout:  kafkaTimeout,
+			WriteTimeout: kafkaTimeout,
+			RequiredAcks: kafka.RequireAll,
+		}
+		s.writers[topic] = w
+	}
+	return w
+}
+
+// kafkaRejected returns whether the error is a rejection of the records by
+// the brokers that publishing them again can't fix.
+func kafkaRejected(err error) bool {
+	// The writer reports the error of each record when only some of them
+	// failed
+	var writeErrs kafka.WriteErrors
+	if errors.As(err, &writeErrs) {
+		for _, err := range writeErrs {
+			if err != nil && !kafkaRejected(err) {
+				return false
+			}
+		}
+		return writeErrs.Count() > 0
+	}
+
+	var tooLarge kafka.MessageTooLargeError
+	if errors.As(err, &tooLarge) {
+		return true
+	}
+
+	var kerr kafka.Error
+	if errors.As(err, &kerr) {
+		switch kerr {
+		case kafka.MessageSizeTooLarge,
+			kafka.InvalidTopic,
+			kafka.RecordListTooLarge,
+			kafka.PolicyViolation:
+			return true
+		}
+	}
+	return false
+}
+
+// Close closes the writers of the topics and their connections to the
+// brokers.
+func (s *KafkaSink) Close() error {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	var mErr multierror.Error
+	for topic, w := range s.writers {
+		if err := w.Close(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("failed to close Kafka writer of topic %q: %v", topic, err))
+		}
+		delete(s.writers, topic)
+	}
+	s.transport.CloseIdleConnections()
+	return mErr.ErrorOrNil()
+}
@@ -0,0 +1,154 @@
+package eventsink
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	// natsTimeout bounds the time taken to connect to the NATS server and to
+	// publish a batch.
+	natsTimeout = 10 * time.Second
+)
+
+// NATSSink publishes messages to a NATS server, using the message topic as
+// the subject. Each batch is flushed so that Send only returns once the
+// server processed the batch.
+type NATSSink struct {
+	address string
+	opts    []nats.Option
+
+	l    sync.Mutex
+	conn *nats.Conn
+}
+
+// NewNATSSink returns a NATSSink publishing to the NATS server at address,
+// either host:port or a nats:// or tls:// URL. The connection is established
+// on the first Send. It is upgraded to TLS when tlsConf is set, the address
+// is a tls:// URL or the server requires it.
+func NewNATSSink(address, username, password string, tlsConf *tls.Config) (*NATSSink, error) {
+	if strings.Contains(address, "://") {
+		u, err := url.Parse(address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse NATS address: %v", err)
+		}
+		switch u.Scheme {
+		case "nats", "tls":
+		default:
+			return nil, fmt.Errorf("unsupported NATS address scheme %q", u.Scheme)
+		}
+		if u.Host == "" {
+			return nil, fmt.Errorf("missing NATS address")
+		}
+	}
+	if address == "" {
+		return nil, fmt.Errorf("missing NATS address")
+	}
+
+	opts := []nats.Option{
+		nats.Name("nomad"),
+		nats.Timeout(natsTimeout),
+	}
+	if username != "" {
+		opts = append(opts, nats.UserInfo(username, password))
+	}
+	if tlsConf != nil {
+		opts = append(opts, nats.Secure(tlsConf))
+	}
+
+	return &NATSSink{
+		address: address,
+		opts:    opts,
+	}, nil
+}
+
+// Send publishes the messages and waits for the server to process them. The
+// connection is closed on error and established again on the next Send.
+func (s *NATSSink) Send(ctx context.Context, messages []*Message) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	if s.conn == nil {
+		conn, err := nats.Connect(s.address, s.opts...)
+		if err != nil {
+			return fmt.Errorf("failed to connect to NATS server: %v", err)
+		}
+		s.conn = conn
+	}
+
+	if err := s.publish(ctx, messages); err != nil {
+		s.closeConn()
+		return err
+	}
+	return nil
+}
+
+// publish publishes the messages and flushes the connection. The server
+// reports rejected messages asynchronously, so the last error of the
+// connection is compared before and after the flush to detect them.
+func (s *NATSSink) publish(ctx context.Context, messages []*Message) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, natsTimeout)
+		defer cancel()
+	}
+
+	lastErr := s.conn.LastError()
+	for _, msg := range messages {
+		if err := s.conn.Publish(msg.Topic, msg.Value); err != nil {
+			return structs.NewRecoverableError(
+				fmt.Errorf("failed to publish to NATS server: %v", err),
+				!natsRejected(err))
+		}
+	}
+	if err := s.conn.FlushWithContext(ctx); err != nil {
+		if connErr := s.conn.LastError(); connErr != nil && connErr != lastErr {
+			err = connErr
+		}
+		return structs.NewRecoverableError(
+			fmt.Errorf("failed to publish to NATS server: %v", err),
+			!natsRejected(err))
+	}
+	if err := s.conn.LastError(); err != nil && err != lastErr {
+		return structs.NewRecoverableError(
+			fmt.Errorf("NATS server error: %v", err),
+			!natsRejected(err))
+	}
+	return nil
+}
+
+// natsRejected returns whether the NATS error is a rejection of the published
+// messages that publishing them again can't fix.
+func natsRejected(err error) bool {
+	if errors.Is(err, nats.ErrMaxPayload) || errors.Is(err, nats.ErrBadSubject) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "permissions violation for publish") ||
+		strings.Contains(msg, "maximum payload violation") ||
+		strings.Contains(msg, "invalid publish subject")
+}
+
+func (s *NATSSink) closeConn() {
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// Close closes the connection to the NATS server.
+func (s *NATSSink) Close() error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.closeConn()
+	return nil
+}
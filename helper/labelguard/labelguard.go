@@ -0,0 +1,70 @@
+// Package labelguard bounds the cardinality of the values of a metric label.
+package labelguard
+
+import "sync"
+
+const (
+	// OtherValue is the value of the label for the values beyond the limit
+	// or outside the allowlist.
+	OtherValue = "_other"
+
+	// DefaultLimit is the number of distinct values of the label when no
+	// limit is given.
+	DefaultLimit = 100
+)
+
+// Guard maps the values of a label to a bounded set of values. Values in the
+// allowlist are kept as is and the others are replaced by OtherValue. Without
+// an allowlist, the first values seen are kept as is, up to the limit, and the
+// following ones are replaced by OtherValue.
+//
+// Guard is safe for concurrent use.
+type Guard struct {
+	allowlist map[string]struct{}
+	limit     int
+
+	l    sync.Mutex
+	seen map[string]struct{}
+}
+
+// New returns a Guard keeping the values in the allowlist, or the first limit
+// values seen if the allowlist is empty. A limit of zero or less is replaced by
+// DefaultLimit.
+func New(allowlist []string, limit int) *Guard {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	g := &Guard{
+		limit: limit,
+		seen:  make(map[string]struct{}),
+	}
+	if len(allowlist) > 0 {
+		g.allowlist = make(map[string]struct{}, len(allowlist))
+		for _, v := range allowlist {
+			g.allowlist[v] = struct{}{}
+		}
+	}
+	return g
+}
+
+// Value returns the value of the label for v.
+func (g *Guard) Value(v string) string {
+	if g.allowlist != nil {
+		if _, ok := g.allowlist[v]; ok {
+			return v
+		}
+		return OtherValue
+	}
+
+	g.l.Lock()
+	defer g.l.Unlock()
+	if _, ok := g.seen[v]; ok {
+		return v
+	}
+	if len(g.seen) < g.limit {
+		g.seen[v] = struct{}{}
+		return v
+	}
+	return OtherValue
+}
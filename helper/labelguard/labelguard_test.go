@@ -0,0 +1,37 @@
+package labelguard
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuard_Limit(t *testing.T) {
+	ci.Parallel(t)
+
+	g := New(nil, 2)
+	require.Equal(t, "default", g.Value("default"))
+	require.Equal(t, "team-a", g.Value("team-a"))
+	require.Equal(t, OtherValue, g.Value("team-b"))
+
+	// Values seen before the limit was reached are kept
+	require.Equal(t, "default", g.Value("default"))
+	require.Equal(t, OtherValue, g.Value("team-c"))
+}
+
+func TestGuard_Allowlist(t *testing.T) {
+	ci.Parallel(t)
+
+	g := New([]string{"team-a", "team-b"}, 1)
+	require.Equal(t, "team-a", g.Value("team-a"))
+	require.Equal(t, "team-b", g.Value("team-b"))
+	require.Equal(t, OtherValue, g.Value("default"))
+}
+
+func TestGuard_DefaultLimit(t *testing.T) {
+	ci.Parallel(t)
+
+	g := New(nil, 0)
+	require.Equal(t, DefaultLimit, g.limit)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/nomad/plugins/device"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+	"github.com/hashicorp/nomad/plugins/taskhook"
 )
 
 // PluginCatalog is used to retrieve plugins, either external or internal
@@ -272,6 +273,8 @@ func getPluginMap(pluginType string, logger log.Logger) map[string]plugin.Plugin
 		pmap[base.PluginTypeDevice] = &device.PluginDevice{}
 	case base.PluginTypeDriver:
 		pmap[base.PluginTypeDriver] = drivers.NewDriverPlugin(nil, logger)
+	case base.PluginTypeTaskHook:
+		pmap[base.PluginTypeTaskHook] = &taskhook.PluginTaskHook{}
 	}
 
 	return pmap
@@ -193,6 +193,7 @@ func StateAsMap(store *state.StateStore) map[string][]interface{} {
 		"CSIPlugins":       toArray(store.CSIPlugins(nil)),
 		"CSIVolumes":       toArray(store.CSIVolumes(nil)),
 		"Deployments":      toArray(store.Deployments(nil, state.SortDefault)),
+		"EventCursors":     toArray(store.EventCursors(nil)),
 		"Evals":            toArray(store.Evals(nil, state.SortDefault)),
 		"Indexes":          toArray(store.Indexes()),
 		"JobSummaries":     toArray(store.JobSummaries(nil)),
@@ -203,6 +204,7 @@ func StateAsMap(store *state.StateStore) map[string][]interface{} {
 		"SITokenAccessors": toArray(store.SITokenAccessors(nil)),
 		"ScalingEvents":    toArray(store.ScalingEvents(nil)),
 		"ScalingPolicies":  toArray(store.ScalingPolicies(nil)),
+		"ScheduledScaling": toArray(store.ScheduledScalingActions(nil)),
 		"VaultAccessors":   toArray(store.VaultAccessors(nil)),
 	}
 
@@ -51,6 +51,16 @@ var msgTypeNames = map[structs.MessageType]string{
 	structs.OneTimeTokenUpsertRequestType:                "OneTimeTokenUpsertRequestType",
 	structs.OneTimeTokenDeleteRequestType:                "OneTimeTokenDeleteRequestType",
 	structs.OneTimeTokenExpireRequestType:                "OneTimeTokenExpireRequestType",
+	structs.ScheduledScalingUpsertRequestType:            "ScheduledScalingUpsertRequestType",
+	structs.ScheduledScalingDeleteRequestType:            "ScheduledScalingDeleteRequestType",
+	structs.JobVersionTagRequestType:                     "JobVersionTagRequestType",
+	structs.JobBundleRegisterRequestType:                 "JobBundleRegisterRequestType",
+	structs.JobMoveRequestType:                           "JobMoveRequestType",
+	structs.WorkloadIdentityKeysUpsertRequestType:        "WorkloadIdentityKeysUpsertRequestType",
+	structs.EventCursorUpsertRequestType:                 "EventCursorUpsertRequestType",
+	structs.EventCursorDeleteRequestType:                 "EventCursorDeleteRequestType",
+	structs.ScalingPolicyUpsertRequestType:               "ScalingPolicyUpsertRequestType",
+	structs.ScalingPolicyDeleteRequestType:               "ScalingPolicyDeleteRequestType",
 	structs.NamespaceUpsertRequestType:                   "NamespaceUpsertRequestType",
 	structs.NamespaceDeleteRequestType:                   "NamespaceDeleteRequestType",
 }
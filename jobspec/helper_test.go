@@ -22,3 +22,8 @@ func int64ToPtr(i int64) *int64 {
 func uint64ToPtr(u uint64) *uint64 {
 	return &u
 }
+
+// float64ToPtr returns the pointer to a float64
+func float64ToPtr(f float64) *float64 {
+	return &f
+}
@@ -98,6 +98,8 @@ func parseReschedulePolicy(final **api.ReschedulePolicy, list *ast.ObjectList) e
 		"delay",
 		"max_delay",
 		"delay_function",
+		"jitter",
+		"max_lifetime",
 	}
 	if err := checkHCLKeys(obj.Val, valid); err != nil {
 		return err
@@ -135,6 +137,7 @@ func parseConstraints(result *[]*api.Constraint, list *ast.ObjectList) error {
 			"operator",
 			"regexp",
 			"set_contains",
+			"time_window",
 			"value",
 			"version",
 			"semver",
@@ -180,6 +183,13 @@ func parseConstraints(result *[]*api.Constraint, list *ast.ObjectList) error {
 			m["RTarget"] = constraint
 		}
 
+		// If "time_window" is provided, set the operand
+		// to "time_window" and the value to the "RTarget"
+		if constraint, ok := m[api.ConstraintTimeWindow]; ok {
+			m["Operand"] = api.ConstraintTimeWindow
+			m["RTarget"] = constraint
+		}
+
 		if value, ok := m[api.ConstraintDistinctHosts]; ok {
 			enabled, err := parseBool(value)
 			if err != nil {
@@ -431,6 +441,9 @@ func parseUpdate(result **api.UpdateStrategy, list *ast.ObjectList) error {
 		"auto_revert",
 		"auto_promote",
 		"canary",
+		"slo_query",
+		"slo_interval",
+		"blue_green",
 	}
 	if err := checkHCLKeys(o.Val, valid); err != nil {
 		return err
@@ -510,6 +523,7 @@ func parseVault(result *api.Vault, list *ast.ObjectList) error {
 		"env",
 		"change_mode",
 		"change_signal",
+		"cluster",
 	}
 	if err := checkHCLKeys(listVal, valid); err != nil {
 		return multierror.Prefix(err, "vault ->")
@@ -519,10 +533,49 @@ func parseVault(result *api.Vault, list *ast.ObjectList) error {
 	if err := hcl.DecodeObject(&m, o.Val); err != nil {
 		return err
 	}
+	delete(m, "cluster")
 
 	if err := mapstructure.WeakDecode(m, result); err != nil {
 		return err
 	}
 
+	if o := listVal.Filter("cluster"); len(o.Items) > 0 {
+		if err := parseVaultClusters(&result.Clusters, o); err != nil {
+			return multierror.Prefix(err, "vault ->")
+		}
+	}
+
+	return nil
+}
+
+func parseVaultClusters(result *[]*api.VaultCluster, list *ast.ObjectList) error {
+	for _, item := range list.Items {
+		if len(item.Keys) != 1 {
+			return fmt.Errorf("cluster block should have exactly one name")
+		}
+		name := item.Keys[0].Token.Value().(string)
+
+		valid := []string{
+			"namespace",
+			"policies",
+			"env",
+		}
+		if err := checkHCLKeys(item.Val, valid); err != nil {
+			return multierror.Prefix(err, fmt.Sprintf("cluster %q ->", name))
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, item.Val); err != nil {
+			return err
+		}
+
+		cluster := &api.VaultCluster{Name: name}
+		if err := mapstructure.WeakDecode(m, cluster); err != nil {
+			return err
+		}
+
+		*result = append(*result, cluster)
+	}
+
 	return nil
 }
@@ -63,6 +63,7 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		"affinity",
 		"spread",
 		"datacenters",
+		"depends_on",
 		"group",
 		"id",
 		"meta",
@@ -24,6 +24,8 @@ func ParseNetwork(o *ast.ObjectList) (*api.NetworkResource, error) {
 		"dns",
 		"port",
 		"hostname",
+		"bandwidth",
+		"firewall",
 	}
 	if err := checkHCLKeys(o.Items[0].Val, valid); err != nil {
 		return nil, multierror.Prefix(err, "network ->")
@@ -36,6 +38,7 @@ func ParseNetwork(o *ast.ObjectList) (*api.NetworkResource, error) {
 	}
 
 	delete(m, "dns")
+	delete(m, "firewall")
 	if err := mapstructure.WeakDecode(m, &r); err != nil {
 		return nil, err
 	}
@@ -64,6 +67,20 @@ func ParseNetwork(o *ast.ObjectList) (*api.NetworkResource, error) {
 		r.DNS = d
 	}
 
+	// Filter firewall
+	if firewall := networkObj.Filter("firewall"); len(firewall.Items) > 0 {
+		if len(firewall.Items) > 1 {
+			return nil, multierror.Prefix(fmt.Errorf("cannot have more than 1 firewall stanza"), "network ->")
+		}
+
+		fw, err := parseFirewall(firewall.Items[0])
+		if err != nil {
+			return nil, multierror.Prefix(err, "network ->")
+		}
+
+		r.Firewall = fw
+	}
+
 	return &r, nil
 }
 
@@ -80,6 +97,7 @@ func parsePorts(networkObj *ast.ObjectList, nw *api.NetworkResource) error {
 			"static",
 			"to",
 			"host_network",
+			"host_networks",
 		}
 		if err := checkHCLKeys(port.Val, valid); err != nil {
 			return err
@@ -135,3 +153,27 @@ func parseDNS(dns *ast.ObjectItem) (*api.DNSConfig, error) {
 
 	return &dnsCfg, nil
 }
+
+func parseFirewall(firewall *ast.ObjectItem) (*api.NetworkFirewall, error) {
+	valid := []string{
+		"allow_client_api",
+		"allow_metadata",
+		"allow_cidrs",
+	}
+
+	if err := checkHCLKeys(firewall.Val, valid); err != nil {
+		return nil, multierror.Prefix(err, "firewall ->")
+	}
+
+	var fw api.NetworkFirewall
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, firewall.Val); err != nil {
+		return nil, err
+	}
+
+	if err := mapstructure.WeakDecode(m, &fw); err != nil {
+		return nil, err
+	}
+
+	return &fw, nil
+}
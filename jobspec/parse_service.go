@@ -51,6 +51,9 @@ func parseService(o *ast.ObjectItem) (*api.Service, error) {
 		"meta",
 		"canary_meta",
 		"on_update",
+		"weights",
+		"namespace",
+		"partition",
 	}
 	if err := checkHCLKeys(o.Val, valid); err != nil {
 		return nil, err
@@ -67,6 +70,7 @@ func parseService(o *ast.ObjectItem) (*api.Service, error) {
 	delete(m, "connect")
 	delete(m, "meta")
 	delete(m, "canary_meta")
+	delete(m, "weights")
 
 	if err := mapstructure.WeakDecode(m, &service); err != nil {
 		return nil, err
@@ -99,6 +103,18 @@ func parseService(o *ast.ObjectItem) (*api.Service, error) {
 
 	}
 
+	// Filter weights
+	if wo := listVal.Filter("weights"); len(wo.Items) > 0 {
+		if len(wo.Items) > 1 {
+			return nil, fmt.Errorf("weights '%s': cannot have more than 1 weights stanza", service.Name)
+		}
+		w, err := parseServiceWeights(wo.Items[0])
+		if err != nil {
+			return nil, multierror.Prefix(err, fmt.Sprintf("'%s',", service.Name))
+		}
+		service.Weights = w
+	}
+
 	// Filter connect
 	if co := listVal.Filter("connect"); len(co.Items) > 0 {
 		if len(co.Items) > 1 {
@@ -148,6 +164,7 @@ func parseConnect(co *ast.ObjectItem) (*api.ConsulConnect, error) {
 		"gateway",
 		"sidecar_service",
 		"sidecar_task",
+		"certs",
 	}
 
 	if err := checkHCLKeys(co.Val, valid); err != nil {
@@ -163,6 +180,7 @@ func parseConnect(co *ast.ObjectItem) (*api.ConsulConnect, error) {
 	delete(m, "gateway")
 	delete(m, "sidecar_service")
 	delete(m, "sidecar_task")
+	delete(m, "certs")
 
 	if err := mapstructure.WeakDecode(m, &connect); err != nil {
 		return nil, err
@@ -213,15 +231,52 @@ func parseConnect(co *ast.ObjectItem) (*api.ConsulConnect, error) {
 		connect.SidecarTask = t
 	}
 
+	// Parse the certs
+	o = connectList.Filter("certs")
+	if len(o.Items) > 1 {
+		return nil, fmt.Errorf("only one 'certs' block allowed per connect block")
+	}
+	if len(o.Items) == 1 {
+		c, err := parseConnectCerts(o.Items[0])
+		if err != nil {
+			return nil, fmt.Errorf("certs, %v", err)
+		}
+		connect.Certs = c
+	}
+
 	return &connect, nil
 }
 
+func parseConnectCerts(o *ast.ObjectItem) (*api.ConsulConnectCerts, error) {
+	valid := []string{
+		"change_mode",
+		"change_signal",
+	}
+
+	if err := checkHCLKeys(o.Val, valid); err != nil {
+		return nil, multierror.Prefix(err, "certs ->")
+	}
+
+	var certs api.ConsulConnectCerts
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, o.Val); err != nil {
+		return nil, err
+	}
+
+	if err := mapstructure.WeakDecode(m, &certs); err != nil {
+		return nil, err
+	}
+
+	return &certs, nil
+}
+
 func parseGateway(o *ast.ObjectItem) (*api.ConsulGateway, error) {
 	valid := []string{
 		"proxy",
 		"ingress",
 		"terminating",
 		"mesh",
+		"api_gateway",
 	}
 
 	if err := checkHCLKeys(o.Val, valid); err != nil {
@@ -238,6 +293,7 @@ func parseGateway(o *ast.ObjectItem) (*api.ConsulGateway, error) {
 	delete(m, "ingress")
 	delete(m, "terminating")
 	delete(m, "mesh")
+	delete(m, "api_gateway")
 
 	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
@@ -308,6 +364,18 @@ func parseGateway(o *ast.ObjectItem) (*api.ConsulGateway, error) {
 		gateway.Mesh = &api.ConsulMeshConfigEntry{}
 	}
 
+	if ao := listVal.Filter("api_gateway"); len(ao.Items) > 0 {
+		if len(ao.Items) > 1 {
+			return nil, fmt.Errorf("api_gateway, %s", "multiple api_gateway stanzas not allowed")
+		}
+
+		apiGateway, err := parseAPIGatewayConfigEntry(ao.Items[0])
+		if err != nil {
+			return nil, fmt.Errorf("api_gateway, %v", err)
+		}
+		gateway.APIGateway = apiGateway
+	}
+
 	return &gateway, nil
 }
 
@@ -638,6 +706,127 @@ func parseTerminatingConfigEntry(o *ast.ObjectItem) (*api.ConsulTerminatingConfi
 	return &terminating, nil
 }
 
+func parseConsulAPIGatewayTLS(o *ast.ObjectItem) (*api.ConsulAPIGatewayTLSConfig, error) {
+	valid := []string{
+		"certificates",
+		"tls_min_version",
+		"tls_max_version",
+		"cipher_suites",
+	}
+
+	if err := checkHCLKeys(o.Val, valid); err != nil {
+		return nil, multierror.Prefix(err, "tls ->")
+	}
+
+	var tls api.ConsulAPIGatewayTLSConfig
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, o.Val); err != nil {
+		return nil, err
+	}
+
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result: &tls,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dec.Decode(m); err != nil {
+		return nil, err
+	}
+
+	return &tls, nil
+}
+
+func parseConsulAPIGatewayListener(o *ast.ObjectItem) (*api.ConsulAPIGatewayListener, error) {
+	valid := []string{
+		"name",
+		"hostname",
+		"port",
+		"protocol",
+		"tls",
+	}
+
+	if err := checkHCLKeys(o.Val, valid); err != nil {
+		return nil, multierror.Prefix(err, "listener ->")
+	}
+
+	var listener api.ConsulAPIGatewayListener
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, o.Val); err != nil {
+		return nil, err
+	}
+
+	delete(m, "tls")
+
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result: &listener,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dec.Decode(m); err != nil {
+		return nil, err
+	}
+
+	// Parse tls
+
+	var listVal *ast.ObjectList
+	if ot, ok := o.Val.(*ast.ObjectType); ok {
+		listVal = ot.List
+	} else {
+		return nil, fmt.Errorf("listener: should be an object")
+	}
+
+	if to := listVal.Filter("tls"); len(to.Items) > 1 {
+		return nil, fmt.Errorf("only 1 tls object supported")
+	} else if len(to.Items) == 1 {
+		if tls, err := parseConsulAPIGatewayTLS(to.Items[0]); err != nil {
+			return nil, err
+		} else {
+			listener.TLS = tls
+		}
+	}
+
+	return &listener, nil
+}
+
+func parseAPIGatewayConfigEntry(o *ast.ObjectItem) (*api.ConsulAPIGatewayConfigEntry, error) {
+	valid := []string{
+		"listener",
+	}
+
+	if err := checkHCLKeys(o.Val, valid); err != nil {
+		return nil, multierror.Prefix(err, "api_gateway ->")
+	}
+
+	var apiGateway api.ConsulAPIGatewayConfigEntry
+
+	// Parse listener(s)
+
+	var listVal *ast.ObjectList
+	if ot, ok := o.Val.(*ast.ObjectType); ok {
+		listVal = ot.List
+	} else {
+		return nil, fmt.Errorf("api_gateway: should be an object")
+	}
+
+	lo := listVal.Filter("listener")
+	if len(lo.Items) > 0 {
+		apiGateway.Listeners = make([]*api.ConsulAPIGatewayListener, len(lo.Items))
+		for i := range lo.Items {
+			listener, err := parseConsulAPIGatewayListener(lo.Items[i])
+			if err != nil {
+				return nil, err
+			}
+			apiGateway.Listeners[i] = listener
+		}
+	}
+
+	return &apiGateway, nil
+}
+
 func parseSidecarService(o *ast.ObjectItem) (*api.ConsulSidecarService, error) {
 	valid := []string{
 		"port",
@@ -721,7 +910,8 @@ func parseSidecarTask(item *ast.ObjectItem) (*api.SidecarTask, error) {
 	}
 
 	m = map[string]interface{}{
-		"shutdown_delay": m["shutdown_delay"],
+		"shutdown_delay":  m["shutdown_delay"],
+		"scale_resources": m["scale_resources"],
 	}
 
 	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
@@ -997,11 +1187,17 @@ func parseChecks(service *api.Service, checkObjs *ast.ObjectList) error {
 			"address_mode",
 			"grpc_service",
 			"grpc_use_tls",
+			"tls_server_name",
+			"tls_ca_cert",
+			"tls_client_cert",
+			"tls_client_key",
 			"task",
 			"success_before_passing",
 			"failures_before_critical",
 			"on_update",
 			"body",
+			"namespace",
+			"partition",
 		}
 		if err := checkHCLKeys(co.Val, valid); err != nil {
 			return multierror.Prefix(err, "check ->")
@@ -1113,3 +1309,26 @@ func parseCheckRestart(cro *ast.ObjectItem) (*api.CheckRestart, error) {
 
 	return &checkRestart, nil
 }
+
+func parseServiceWeights(wo *ast.ObjectItem) (*api.ServiceWeights, error) {
+	valid := []string{
+		"passing",
+		"warning",
+	}
+
+	if err := checkHCLKeys(wo.Val, valid); err != nil {
+		return nil, multierror.Prefix(err, "weights ->")
+	}
+
+	var weights api.ServiceWeights
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, wo.Val); err != nil {
+		return nil, err
+	}
+
+	if err := mapstructure.WeakDecode(m, &weights); err != nil {
+		return nil, err
+	}
+
+	return &weights, nil
+}
@@ -33,11 +33,13 @@ var (
 		"affinity",
 		"dispatch_payload",
 		"lifecycle",
+		"runtime_env",
 		"leader",
 		"restart",
 		"service",
 		"template",
 		"vault",
+		"identity",
 		"kind",
 		"volume_mount",
 		"csi_plugin",
@@ -45,6 +47,7 @@ var (
 
 	sidecarTaskKeys = append(commonTaskKeys,
 		"name",
+		"scale_resources",
 	)
 )
 
@@ -102,6 +105,7 @@ func parseTask(item *ast.ObjectItem, keys []string) (*api.Task, error) {
 	delete(m, "affinity")
 	delete(m, "dispatch_payload")
 	delete(m, "lifecycle")
+	delete(m, "runtime_env")
 	delete(m, "env")
 	delete(m, "logs")
 	delete(m, "meta")
@@ -110,6 +114,7 @@ func parseTask(item *ast.ObjectItem, keys []string) (*api.Task, error) {
 	delete(m, "service")
 	delete(m, "template")
 	delete(m, "vault")
+	delete(m, "identity")
 	delete(m, "volume_mount")
 	delete(m, "csi_plugin")
 	delete(m, "scaling")
@@ -300,6 +305,13 @@ func parseTask(item *ast.ObjectItem, keys []string) (*api.Task, error) {
 		t.Vault = v
 	}
 
+	// Parse the workload identities
+	if o := listVal.Filter("identity"); len(o.Items) > 0 {
+		if err := parseWorkloadIdentities(&t.Identities, o); err != nil {
+			return nil, err
+		}
+	}
+
 	// If we have a dispatch_payload block parse that
 	if o := listVal.Filter("dispatch_payload"); len(o.Items) > 0 {
 		if len(o.Items) > 1 {
@@ -311,6 +323,7 @@ func parseTask(item *ast.ObjectItem, keys []string) (*api.Task, error) {
 		// Check for invalid keys
 		valid := []string{
 			"file",
+			"stdin",
 		}
 		if err := checkHCLKeys(dispatchBlock.Val, valid); err != nil {
 			return nil, multierror.Prefix(err, "dispatch_payload ->")
@@ -339,6 +352,7 @@ func parseTask(item *ast.ObjectItem, keys []string) (*api.Task, error) {
 		valid := []string{
 			"hook",
 			"sidecar",
+			"on_failure",
 		}
 		if err := checkHCLKeys(lifecycleBlock.Val, valid); err != nil {
 			return nil, multierror.Prefix(err, "lifecycle ->")
@@ -353,6 +367,34 @@ func parseTask(item *ast.ObjectItem, keys []string) (*api.Task, error) {
 			return nil, err
 		}
 	}
+
+	// If we have a runtime_env block parse that
+	if o := listVal.Filter("runtime_env"); len(o.Items) > 0 {
+		if len(o.Items) > 1 {
+			return nil, fmt.Errorf("only one runtime_env block is allowed in a task. Number of runtime_env blocks found: %d", len(o.Items))
+		}
+
+		var m map[string]interface{}
+		runtimeEnvBlock := o.Items[0]
+
+		// Check for invalid keys
+		valid := []string{
+			"gomaxprocs",
+			"java_heap_percent",
+		}
+		if err := checkHCLKeys(runtimeEnvBlock.Val, valid); err != nil {
+			return nil, multierror.Prefix(err, "runtime_env ->")
+		}
+
+		if err := hcl.DecodeObject(&m, runtimeEnvBlock.Val); err != nil {
+			return nil, err
+		}
+
+		t.RuntimeEnv = &api.TaskRuntimeEnv{}
+		if err := mapstructure.WeakDecode(m, t.RuntimeEnv); err != nil {
+			return nil, err
+		}
+	}
 	return &t, nil
 }
 
@@ -428,6 +470,7 @@ func parseTemplates(result *[]*api.Template, list *ast.ObjectList) error {
 		// Check for invalid keys
 		valid := []string{
 			"change_mode",
+			"change_script",
 			"change_signal",
 			"data",
 			"destination",
@@ -437,6 +480,7 @@ func parseTemplates(result *[]*api.Template, list *ast.ObjectList) error {
 			"source",
 			"splay",
 			"env",
+			"vault_cluster",
 			"vault_grace", //COMPAT(0.12) not used; emits warning in 0.11.
 		}
 		if err := checkHCLKeys(o.Val, valid); err != nil {
@@ -447,6 +491,7 @@ func parseTemplates(result *[]*api.Template, list *ast.ObjectList) error {
 		if err := hcl.DecodeObject(&m, o.Val); err != nil {
 			return err
 		}
+		delete(m, "change_script")
 
 		templ := &api.Template{
 			ChangeMode: stringToPtr("restart"),
@@ -466,12 +511,62 @@ func parseTemplates(result *[]*api.Template, list *ast.ObjectList) error {
 			return err
 		}
 
+		// Parse the change script
+		var listVal *ast.ObjectList
+		if ot, ok := o.Val.(*ast.ObjectType); ok {
+			listVal = ot.List
+		} else {
+			return fmt.Errorf("template: should be an object")
+		}
+		if o := listVal.Filter("change_script"); len(o.Items) > 0 {
+			if len(o.Items) > 1 {
+				return fmt.Errorf("only one 'change_script' block allowed per template")
+			}
+			if err := parseChangeScript(&templ.ChangeScript, o.Items[0]); err != nil {
+				return multierror.Prefix(err, "change_script ->")
+			}
+		}
+
 		*result = append(*result, templ)
 	}
 
 	return nil
 }
 
+func parseChangeScript(result **api.ChangeScript, o *ast.ObjectItem) error {
+	valid := []string{
+		"command",
+		"args",
+		"timeout",
+		"fail_on_error",
+		"pass_changed_keys",
+	}
+	if err := checkHCLKeys(o.Val, valid); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, o.Val); err != nil {
+		return err
+	}
+
+	var script api.ChangeScript
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		Result:           &script,
+	})
+	if err != nil {
+		return err
+	}
+	if err := dec.Decode(m); err != nil {
+		return err
+	}
+
+	*result = &script
+	return nil
+}
+
 func parseTaskScalingPolicies(result *[]*api.ScalingPolicy, list *ast.ObjectList) error {
 	if len(list.Items) == 0 {
 		return nil
@@ -549,9 +644,12 @@ func parseResources(result *api.Resources, list *ast.ObjectList) error {
 		"disk",
 		"memory",
 		"memory_max",
+		"disk_iops",
+		"disk_bandwidth",
 		"network",
 		"device",
 		"cores",
+		"numa",
 	}
 	if err := checkHCLKeys(listVal, valid); err != nil {
 		return multierror.Prefix(err, "resources ->")
@@ -563,11 +661,33 @@ func parseResources(result *api.Resources, list *ast.ObjectList) error {
 	}
 	delete(m, "network")
 	delete(m, "device")
+	delete(m, "numa")
 
 	if err := mapstructure.WeakDecode(m, result); err != nil {
 		return err
 	}
 
+	// Parse the NUMA configuration
+	if o := listVal.Filter("numa"); len(o.Items) > 0 {
+		if len(o.Items) > 1 {
+			return fmt.Errorf("only one 'numa' block allowed per resources")
+		}
+		item := o.Items[0]
+		if err := checkHCLKeys(item.Val, []string{"affinity"}); err != nil {
+			return multierror.Prefix(err, "resources, numa ->")
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, item.Val); err != nil {
+			return err
+		}
+		var numa api.NUMAResource
+		if err := mapstructure.WeakDecode(m, &numa); err != nil {
+			return err
+		}
+		result.NUMA = &numa
+	}
+
 	// Parse the network resources
 	if o := listVal.Filter("network"); len(o.Items) > 0 {
 		r, err := ParseNetwork(o)
@@ -653,6 +773,7 @@ func parseVolumeMounts(out *[]*api.VolumeMount, list *ast.ObjectList) error {
 			"read_only",
 			"destination",
 			"propagation_mode",
+			"on_recover",
 		}
 		if err := checkHCLKeys(item.Val, valid); err != nil {
 			return err
@@ -681,3 +802,44 @@ func parseVolumeMounts(out *[]*api.VolumeMount, list *ast.ObjectList) error {
 	*out = mounts
 	return nil
 }
+
+func parseWorkloadIdentities(result *[]*api.WorkloadIdentity, list *ast.ObjectList) error {
+	for _, item := range list.Items {
+		if len(item.Keys) != 1 {
+			return fmt.Errorf("identity block should have exactly one name")
+		}
+		name := item.Keys[0].Token.Value().(string)
+
+		valid := []string{
+			"aud",
+			"ttl",
+			"env",
+			"file",
+		}
+		if err := checkHCLKeys(item.Val, valid); err != nil {
+			return multierror.Prefix(err, fmt.Sprintf("identity %q ->", name))
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, item.Val); err != nil {
+			return err
+		}
+
+		identity := &api.WorkloadIdentity{Name: name}
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			WeaklyTypedInput: true,
+			Result:           identity,
+		})
+		if err != nil {
+			return err
+		}
+		if err := dec.Decode(m); err != nil {
+			return err
+		}
+
+		*result = append(*result, identity)
+	}
+
+	return nil
+}
@@ -131,10 +131,11 @@ func TestParse(t *testing.T) {
 						},
 						Volumes: map[string]*api.VolumeRequest{
 							"foo": {
-								Name:         "foo",
-								Type:         "host",
-								Source:       "/path",
-								ExtraKeysHCL: nil,
+								Name:               "foo",
+								Type:               "host",
+								Source:             "/path",
+								AlternativeSources: []string{"/fallback"},
+								ExtraKeysHCL:       nil,
 							},
 							"bar": {
 								Name:           "bar",
@@ -218,6 +219,9 @@ func TestParse(t *testing.T) {
 							AutoRevert:       boolToPtr(false),
 							AutoPromote:      boolToPtr(false),
 							Canary:           intToPtr(2),
+							SLOQuery:         stringToPtr("sum(rate(http_errors[5m])) < bool 1"),
+							SLOInterval:      timeToPtr(1 * time.Minute),
+							BlueGreen:        boolToPtr(true),
 						},
 						Migrate: &api.MigrateStrategy{
 							MaxParallel:     intToPtr(2),
@@ -243,6 +247,7 @@ func TestParse(t *testing.T) {
 									{
 										Volume:      stringToPtr("foo"),
 										Destination: stringToPtr("/mnt/foo"),
+										OnRecover:   stringToPtr("restart_task"),
 									},
 								},
 								Affinities: []*api.Affinity{
@@ -290,9 +295,14 @@ func TestParse(t *testing.T) {
 									"LOREM": "ipsum",
 								},
 								Resources: &api.Resources{
-									CPU:         intToPtr(500),
-									MemoryMB:    intToPtr(128),
-									MemoryMaxMB: intToPtr(256),
+									CPU:             intToPtr(500),
+									MemoryMB:        intToPtr(128),
+									MemoryMaxMB:     intToPtr(256),
+									DiskIOPS:        intToPtr(500),
+									DiskBandwidthMB: intToPtr(50),
+									NUMA: &api.NUMAResource{
+										Affinity: stringToPtr("prefer"),
+									},
 									Networks: []*api.NetworkResource{
 										{
 											MBits:         intToPtr(100),
@@ -374,6 +384,20 @@ func TestParse(t *testing.T) {
 										LeftDelim:  stringToPtr("--"),
 										RightDelim: stringToPtr("__"),
 									},
+									{
+										SourcePath: stringToPtr("baz"),
+										DestPath:   stringToPtr("baz"),
+										ChangeMode: stringToPtr("script"),
+										ChangeScript: &api.ChangeScript{
+											Command:         stringToPtr("/bin/reload"),
+											Args:            []string{"-q"},
+											Timeout:         timeToPtr(10 * time.Second),
+											FailOnError:     boolToPtr(true),
+											PassChangedKeys: boolToPtr(true),
+										},
+										Splay: timeToPtr(5 * time.Second),
+										Perms: stringToPtr("0644"),
+									},
 								},
 								Leader:     true,
 								KillSignal: "",
@@ -386,6 +410,10 @@ func TestParse(t *testing.T) {
 									Hook:    "prestart",
 									Sidecar: true,
 								},
+								RuntimeEnv: &api.TaskRuntimeEnv{
+									GOMAXPROCS:      true,
+									JavaHeapPercent: 75,
+								},
 								Config: map[string]interface{}{
 									"image": "hashicorp/storagelocker",
 								},
@@ -489,6 +517,21 @@ func TestParse(t *testing.T) {
 			false,
 		},
 
+		{
+			"time-window-constraint.hcl",
+			&api.Job{
+				ID:   stringToPtr("foo"),
+				Name: stringToPtr("foo"),
+				Constraints: []*api.Constraint{
+					{
+						RTarget: "CRON_TZ=Europe/Paris * 22-23,0-5 * * *",
+						Operand: api.ConstraintTimeWindow,
+					},
+				},
+			},
+			false,
+		},
+
 		{
 			"distinctHosts-constraint.hcl",
 			&api.Job{
@@ -533,6 +576,17 @@ func TestParse(t *testing.T) {
 			false,
 		},
 
+		{
+			"depends-on.hcl",
+			&api.Job{
+				ID:        stringToPtr("web"),
+				Name:      stringToPtr("web"),
+				Type:      stringToPtr("batch"),
+				DependsOn: []string{"db", "cache"},
+			},
+			false,
+		},
+
 		{
 			"specify-job.hcl",
 			&api.Job{
@@ -793,6 +847,72 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"vault_clusters.hcl",
+			&api.Job{
+				ID:   stringToPtr("example"),
+				Name: stringToPtr("example"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("cache"),
+						Tasks: []*api.Task{
+							{
+								Name: "redis",
+								Vault: &api.Vault{
+									Policies:   []string{"platform"},
+									Env:        boolToPtr(true),
+									ChangeMode: stringToPtr(vaultChangeModeRestart),
+									Clusters: []*api.VaultCluster{
+										{
+											Name:      "team",
+											Policies:  []string{"team-read", "team-write"},
+											Namespace: stringToPtr("team"),
+										},
+										{
+											Name:     "audit",
+											Policies: []string{"audit"},
+											Env:      boolToPtr(false),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"identities.hcl",
+			&api.Job{
+				ID:   stringToPtr("example"),
+				Name: stringToPtr("example"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("cache"),
+						Tasks: []*api.Task{
+							{
+								Name: "redis",
+								Identities: []*api.WorkloadIdentity{
+									{
+										Name:     "aws",
+										Audience: []string{"sts.amazonaws.com"},
+										TTL:      timeToPtr(15 * time.Minute),
+										File:     boolToPtr(true),
+									},
+									{
+										Name:     "gcp",
+										Audience: []string{"//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/nomad/providers/nomad", "gcp"},
+										Env:      boolToPtr(true),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
 		{
 			"parameterized_job.hcl",
 			&api.Job{
@@ -1019,6 +1139,8 @@ func TestParse(t *testing.T) {
 					Delay:         timeToPtr(10 * time.Second),
 					MaxDelay:      timeToPtr(120 * time.Second),
 					Unlimited:     boolToPtr(true),
+					Jitter:        float64ToPtr(0.25),
+					MaxLifetime:   timeToPtr(24 * time.Hour),
 				},
 				TaskGroups: []*api.TaskGroup{
 					{
@@ -1090,7 +1212,8 @@ func TestParse(t *testing.T) {
 						Count:         intToPtr(3),
 						Networks: []*api.NetworkResource{
 							{
-								Mode: "bridge",
+								Mode:      "bridge",
+								Bandwidth: intToPtr(100),
 								ReservedPorts: []api.Port{
 									{
 										Label:       "http",
@@ -1099,10 +1222,21 @@ func TestParse(t *testing.T) {
 										HostNetwork: "public",
 									},
 								},
+								DynamicPorts: []api.Port{
+									{
+										Label:        "metrics",
+										To:           9090,
+										HostNetworks: []string{"public", "private"},
+									},
+								},
 								DNS: &api.DNSConfig{
 									Servers: []string{"8.8.8.8"},
 									Options: []string{"ndots:2", "edns0"},
 								},
+								Firewall: &api.NetworkFirewall{
+									AllowMetadata: true,
+									AllowCIDRs:    []string{"10.0.0.0/8"},
+								},
 							},
 						},
 						Services: []*api.Service{
@@ -1312,6 +1446,26 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"tg-service-connect-scale-resources.hcl",
+			&api.Job{
+				ID:   stringToPtr("sidecar_task_scale_resources"),
+				Name: stringToPtr("sidecar_task_scale_resources"),
+				Type: stringToPtr("service"),
+				TaskGroups: []*api.TaskGroup{{
+					Name: stringToPtr("group"),
+					Services: []*api.Service{{
+						Name: "example",
+						Connect: &api.ConsulConnect{
+							SidecarTask: &api.SidecarTask{
+								ScaleResources: true,
+							},
+						},
+					}},
+				}},
+			},
+			false,
+		},
 		{
 			"tg-service-connect-proxy.hcl",
 			&api.Job{
@@ -1421,6 +1575,10 @@ func TestParse(t *testing.T) {
 						TaskName: "task1",
 						Connect: &api.ConsulConnect{
 							Native: true,
+							Certs: &api.ConsulConnectCerts{
+								ChangeMode:   "signal",
+								ChangeSignal: "SIGUSR1",
+							},
 						},
 					}},
 				}},
@@ -1442,6 +1600,49 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"tg-service-weights.hcl",
+			&api.Job{
+				ID:   stringToPtr("group_service_weights"),
+				Name: stringToPtr("group_service_weights"),
+				TaskGroups: []*api.TaskGroup{{
+					Name: stringToPtr("group"),
+					Services: []*api.Service{{
+						Name: "example",
+						Weights: &api.ServiceWeights{
+							Passing: intToPtr(10),
+							Warning: intToPtr(0),
+						},
+					}},
+				}},
+			},
+			false,
+		},
+		{
+			"tg-service-namespace.hcl",
+			&api.Job{
+				ID:   stringToPtr("group_service_namespace"),
+				Name: stringToPtr("group_service_namespace"),
+				TaskGroups: []*api.TaskGroup{{
+					Name: stringToPtr("group"),
+					Services: []*api.Service{{
+						Name:      "example",
+						Namespace: "team-a",
+						Partition: "tenant-a",
+						Checks: []api.ServiceCheck{{
+							Name:      "example-check",
+							Type:      "tcp",
+							PortLabel: "http",
+							Interval:  10 * time.Second,
+							Timeout:   2 * time.Second,
+							Namespace: "team-a",
+							Partition: "tenant-a",
+						}},
+					}},
+				}},
+			},
+			false,
+		},
 		{
 			"tg-scaling-policy.hcl",
 			&api.Job{
@@ -1633,6 +1834,41 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"tg-service-connect-gateway-api.hcl",
+			&api.Job{
+				ID:   stringToPtr("connect_gateway_api"),
+				Name: stringToPtr("connect_gateway_api"),
+				TaskGroups: []*api.TaskGroup{{
+					Name: stringToPtr("group"),
+					Services: []*api.Service{{
+						Name: "api-gateway-service",
+						Connect: &api.ConsulConnect{
+							Gateway: &api.ConsulGateway{
+								Proxy: &api.ConsulGatewayProxy{},
+								APIGateway: &api.ConsulAPIGatewayConfigEntry{
+									Listeners: []*api.ConsulAPIGatewayListener{{
+										Name:     "http",
+										Hostname: "*.example.com",
+										Port:     8443,
+										Protocol: "http",
+										TLS: &api.ConsulAPIGatewayTLSConfig{
+											Certificates:  []string{"example-cert"},
+											TLSMinVersion: "TLSv1_2",
+											CipherSuites:  []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+										},
+									}, {
+										Name: "tcp",
+										Port: 9090,
+									}},
+								},
+							},
+						},
+					}},
+				}},
+			},
+			false,
+		},
 		{
 			"tg-scaling-policy-minimal.hcl",
 			&api.Job{
@@ -71,8 +71,9 @@ job "binstore-storagelocker" {
     count = 5
 
     volume "foo" {
-      type   = "host"
-      source = "/path"
+      type                = "host"
+      source              = "/path"
+      alternative_sources = ["/fallback"]
     }
 
     volume "bar" {
@@ -124,6 +125,9 @@ job "binstore-storagelocker" {
       auto_revert       = false
       auto_promote      = false
       canary            = 2
+      slo_query         = "sum(rate(http_errors[5m])) < bool 1"
+      slo_interval      = "1m"
+      blue_green        = true
     }
 
     migrate {
@@ -183,6 +187,7 @@ job "binstore-storagelocker" {
       volume_mount {
         volume      = "foo"
         destination = "/mnt/foo"
+        on_recover  = "restart_task"
       }
 
       restart {
@@ -231,9 +236,15 @@ job "binstore-storagelocker" {
       }
 
       resources {
-        cpu        = 500
-        memory     = 128
-        memory_max = 256
+        cpu            = 500
+        memory         = 128
+        memory_max     = 256
+        disk_iops      = 500
+        disk_bandwidth = 50
+
+        numa {
+          affinity = "prefer"
+        }
 
         network {
           mbits = "100"
@@ -320,6 +331,20 @@ job "binstore-storagelocker" {
         left_delimiter  = "--"
         right_delimiter = "__"
       }
+
+      template {
+        source      = "baz"
+        destination = "baz"
+        change_mode = "script"
+
+        change_script {
+          command           = "/bin/reload"
+          args              = ["-q"]
+          timeout           = "10s"
+          fail_on_error     = true
+          pass_changed_keys = true
+        }
+      }
     }
 
     task "storagelocker" {
@@ -330,6 +355,11 @@ job "binstore-storagelocker" {
         sidecar = true
       }
 
+      runtime_env {
+        gomaxprocs        = true
+        java_heap_percent = 75
+      }
+
       config {
         image = "hashicorp/storagelocker"
       }
@@ -0,0 +1,4 @@
+job "web" {
+  type       = "batch"
+  depends_on = ["db", "cache"]
+}
@@ -0,0 +1,16 @@
+job "example" {
+  group "cache" {
+    task "redis" {
+      identity "aws" {
+        aud  = ["sts.amazonaws.com"]
+        ttl  = "15m"
+        file = true
+      }
+
+      identity "gcp" {
+        aud = ["//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/nomad/providers/nomad", "gcp"]
+        env = true
+      }
+    }
+  }
+}
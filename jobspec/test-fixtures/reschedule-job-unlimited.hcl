@@ -7,6 +7,8 @@ job "foo" {
     delay_function = "exponential"
     max_delay      = "120s"
     unlimited      = true
+    jitter         = 0.25
+    max_lifetime   = "24h"
   }
 
   group "bar" {
@@ -6,7 +6,8 @@ job "foo" {
     shutdown_delay = "14s"
 
     network {
-      mode = "bridge"
+      mode      = "bridge"
+      bandwidth = 100
 
       port "http" {
         static       = 80
@@ -14,10 +15,20 @@ job "foo" {
         host_network = "public"
       }
 
+      port "metrics" {
+        to            = 9090
+        host_networks = ["public", "private"]
+      }
+
       dns {
         servers = ["8.8.8.8"]
         options = ["ndots:2", "edns0"]
       }
+
+      firewall {
+        allow_metadata = true
+        allow_cidrs    = ["10.0.0.0/8"]
+      }
     }
 
     service {
@@ -0,0 +1,33 @@
+job "connect_gateway_api" {
+  group "group" {
+    service {
+      name = "api-gateway-service"
+
+      connect {
+        gateway {
+          proxy {}
+
+          api_gateway {
+            listener {
+              name     = "http"
+              hostname = "*.example.com"
+              port     = 8443
+              protocol = "http"
+
+              tls {
+                certificates    = ["example-cert"]
+                tls_min_version = "TLSv1_2"
+                cipher_suites   = ["TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"]
+              }
+            }
+
+            listener {
+              name = "tcp"
+              port = 9090
+            }
+          }
+        }
+      }
+    }
+  }
+}
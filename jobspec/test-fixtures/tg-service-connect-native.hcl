@@ -6,6 +6,11 @@ job "connect_native_service" {
 
       connect {
         native = true
+
+        certs {
+          change_mode   = "signal"
+          change_signal = "SIGUSR1"
+        }
       }
     }
   }
@@ -0,0 +1,15 @@
+job "sidecar_task_scale_resources" {
+  type = "service"
+
+  group "group" {
+    service {
+      name = "example"
+
+      connect {
+        sidecar_task {
+          scale_resources = true
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,19 @@
+job "group_service_namespace" {
+  group "group" {
+    service {
+      name      = "example"
+      namespace = "team-a"
+      partition = "tenant-a"
+
+      check {
+        name      = "example-check"
+        type      = "tcp"
+        port      = "http"
+        interval  = "10s"
+        timeout   = "2s"
+        namespace = "team-a"
+        partition = "tenant-a"
+      }
+    }
+  }
+}
@@ -0,0 +1,12 @@
+job "group_service_weights" {
+  group "group" {
+    service {
+      name = "example"
+
+      weights {
+        passing = 10
+        warning = 0
+      }
+    }
+  }
+}
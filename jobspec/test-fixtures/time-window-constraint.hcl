@@ -0,0 +1,5 @@
+job "foo" {
+  constraint {
+    time_window = "CRON_TZ=Europe/Paris * 22-23,0-5 * * *"
+  }
+}
@@ -0,0 +1,19 @@
+job "example" {
+  group "cache" {
+    task "redis" {
+      vault {
+        policies = ["platform"]
+
+        cluster "team" {
+          policies  = ["team-read", "team-write"]
+          namespace = "team"
+        }
+
+        cluster "audit" {
+          policies = ["audit"]
+          env      = false
+        }
+      }
+    }
+  }
+}
@@ -181,6 +181,7 @@ var constraintSpec = hcldec.ObjectSpec{
 	api.ConstraintSetContainsAny:    &hcldec.AttrSpec{Name: api.ConstraintSetContainsAny, Type: cty.String, Required: false},
 	api.ConstraintAttributeIsSet:    &hcldec.AttrSpec{Name: api.ConstraintAttributeIsSet, Type: cty.String, Required: false},
 	api.ConstraintAttributeIsNotSet: &hcldec.AttrSpec{Name: api.ConstraintAttributeIsNotSet, Type: cty.String, Required: false},
+	api.ConstraintTimeWindow:        &hcldec.AttrSpec{Name: api.ConstraintTimeWindow, Type: cty.String, Required: false},
 }
 
 func decodeConstraint(body hcl.Body, ctx *hcl.EvalContext, val interface{}) hcl.Diagnostics {
@@ -231,6 +232,13 @@ func decodeConstraint(body hcl.Body, ctx *hcl.EvalContext, val interface{}) hcl.
 		c.RTarget = constraint
 	}
 
+	// If "time_window" is provided, set the operand
+	// to "time_window" and the value to the "RTarget"
+	if constraint := attr(api.ConstraintTimeWindow); constraint != "" {
+		c.Operand = api.ConstraintTimeWindow
+		c.RTarget = constraint
+	}
+
 	if d := v.GetAttr(api.ConstraintDistinctHosts); !d.IsNull() && d.True() {
 		c.Operand = api.ConstraintDistinctHosts
 	}
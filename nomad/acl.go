@@ -61,9 +61,11 @@ func resolveTokenFromSnapshotCache(snap *state.StateSnapshot, cache *lru.TwoQueu
 		return acl.ManagementACL, nil
 	}
 
-	// Get all associated policies
-	policies := make([]*structs.ACLPolicy, 0, len(token.Policies))
-	for _, policyName := range token.Policies {
+	// Get all associated policies, skipping expiring policies whose
+	// expiration time has passed
+	tokenPolicies := token.ActivePolicies(time.Now())
+	policies := make([]*structs.ACLPolicy, 0, len(tokenPolicies))
+	for _, policyName := range tokenPolicies {
 		policy, err := snap.ACLPolicyByName(nil, policyName)
 		if err != nil {
 			return nil, err
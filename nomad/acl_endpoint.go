@@ -147,8 +147,9 @@ func (a *ACL) ListPolicies(args *structs.ACLPolicyListRequest, reply *structs.AC
 			return structs.ErrTokenNotFound
 		}
 
-		policies = make(map[string]struct{}, len(token.Policies))
-		for _, p := range token.Policies {
+		active := token.ActivePolicies(time.Now())
+		policies = make(map[string]struct{}, len(active))
+		for _, p := range active {
 			policies[p] = struct{}{}
 		}
 	}
@@ -232,7 +233,7 @@ func (a *ACL) GetPolicy(args *structs.ACLPolicySpecificRequest, reply *structs.S
 		}
 
 		found := false
-		for _, p := range token.Policies {
+		for _, p := range token.ActivePolicies(time.Now()) {
 			if p == args.Name {
 				found = true
 				break
@@ -500,11 +501,29 @@ func (a *ACL) UpsertTokens(args *structs.ACLTokenUpsertRequest, reply *structs.A
 	}
 
 	// Validate each token
+	now := time.Now().UTC()
 	for idx, token := range args.Tokens {
 		if err := token.Validate(); err != nil {
 			return structs.NewErrRPCCodedf(400, "token %d invalid: %v", idx, err)
 		}
 
+		// Anchor the TTL of expiring policies to now and drop the ones which
+		// have already expired
+		expiring := make([]*structs.ACLTokenExpiringPolicy, 0, len(token.ExpiringPolicies))
+		for _, p := range token.ExpiringPolicies {
+			if p.ExpirationTTL != 0 {
+				p.ExpirationTime = now.Add(p.ExpirationTTL)
+				p.ExpirationTTL = 0
+			}
+			if !p.Expired(now) {
+				expiring = append(expiring, p)
+			}
+		}
+		if len(expiring) == 0 {
+			expiring = nil
+		}
+		token.ExpiringPolicies = expiring
+
 		// Generate an accessor and secret ID if new
 		if token.AccessorID == "" {
 			token.AccessorID = uuid.Generate()
@@ -868,6 +887,60 @@ func (a *ACL) ResolveToken(args *structs.ResolveACLTokenRequest, reply *structs.
 	return nil
 }
 
+// Check is used to check whether a token is allowed to perform an operation
+// without performing it. No permission is required to check the token of the
+// request, but checking another token requires a management token so that the
+// endpoint can't be used to probe for valid secrets.
+func (a *ACL) Check(args *structs.ACLCheckRequest, reply *structs.ACLCheckResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if done, err := a.srv.forward("ACL.Check", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "check"}, time.Now())
+
+	// Setup the query meta
+	a.srv.setQueryMeta(&reply.QueryMeta)
+
+	secretID := args.SecretID
+	if secretID == "" {
+		secretID = args.AuthToken
+	} else if secretID != args.AuthToken {
+		// Check management level permissions
+		if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+			return err
+		} else if acl == nil || !acl.IsManagement() {
+			return structs.ErrPermissionDenied
+		}
+	}
+	aclObj, err := a.srv.ResolveToken(secretID)
+	if err != nil {
+		return err
+	}
+
+	allowed, err := aclObj.AllowOperation(args.Resource, args.Operation, args.RequestNamespace())
+	if err != nil {
+		return structs.NewErrRPCCoded(400, err.Error())
+	}
+	reply.Allowed = allowed
+
+	// Use the last index that affected the token and policy tables
+	index, err := a.srv.State().Index("acl_token")
+	if err != nil {
+		return err
+	}
+	policyIndex, err := a.srv.State().Index("acl_policy")
+	if err != nil {
+		return err
+	}
+	if policyIndex > index {
+		index = policyIndex
+	}
+	reply.Index = index
+	return nil
+}
+
 func (a *ACL) UpsertOneTimeToken(args *structs.OneTimeTokenUpsertRequest, reply *structs.OneTimeTokenUpsertResponse) error {
 	if !a.srv.config.ACLEnabled {
 		return aclDisabled
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
@@ -1469,6 +1470,47 @@ func TestACLEndpoint_UpsertTokens(t *testing.T) {
 	assert.Equal(t, created, out)
 }
 
+func TestACLEndpoint_UpsertTokens_ExpiringPolicies(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	p1 := mock.ACLToken()
+	p1.AccessorID = ""
+	p1.ExpiringPolicies = []*structs.ACLTokenExpiringPolicy{
+		{Name: "operator", ExpirationTTL: 8 * time.Hour},
+		{Name: "expired", ExpirationTime: time.Now().Add(-time.Minute)},
+	}
+
+	req := &structs.ACLTokenUpsertRequest{
+		Tokens: []*structs.ACLToken{p1},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLTokenUpsertResponse
+	start := time.Now()
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp))
+
+	// The TTL is converted into an expiration time and the links which have
+	// already expired are dropped
+	created := resp.Tokens[0]
+	require.Len(t, created.ExpiringPolicies, 1)
+	link := created.ExpiringPolicies[0]
+	require.Equal(t, "operator", link.Name)
+	require.Zero(t, link.ExpirationTTL)
+	require.False(t, link.ExpirationTime.Before(start.Add(8*time.Hour)))
+	require.True(t, link.ExpirationTime.Before(time.Now().Add(8*time.Hour)))
+
+	out, err := s1.fsm.State().ACLTokenByAccessorID(nil, created.AccessorID)
+	require.NoError(t, err)
+	require.Equal(t, created, out)
+}
+
 func TestACLEndpoint_UpsertTokens_Invalid(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1529,6 +1571,130 @@ func TestACLEndpoint_ResolveToken(t *testing.T) {
 	assert.Nil(t, resp.Token)
 }
 
+func TestACLEndpoint_Check(t *testing.T) {
+	ci.Parallel(t)
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	state := s1.fsm.State()
+	token := mock.CreatePolicyAndToken(t, state, 1000, "ci",
+		mock.NamespacePolicy("ci", "", []string{acl.NamespaceCapabilitySubmitJob}))
+
+	cases := []struct {
+		name      string
+		authToken string
+		secretID  string
+		resource  string
+		operation string
+		namespace string
+		allowed   bool
+		err       string
+	}{
+		{
+			name:      "request token allowed",
+			authToken: token.SecretID,
+			resource:  acl.ResourceNamespace,
+			operation: acl.NamespaceCapabilitySubmitJob,
+			namespace: "ci",
+			allowed:   true,
+		},
+		{
+			name:      "request token denied in namespace",
+			authToken: token.SecretID,
+			resource:  acl.ResourceNamespace,
+			operation: acl.NamespaceCapabilitySubmitJob,
+			namespace: structs.DefaultNamespace,
+		},
+		{
+			name:      "request token denied operation",
+			authToken: token.SecretID,
+			resource:  acl.ResourceNode,
+			operation: acl.PolicyWrite,
+		},
+		{
+			name:      "other token",
+			authToken: root.SecretID,
+			secretID:  token.SecretID,
+			resource:  acl.ResourceNamespace,
+			operation: acl.NamespaceCapabilityReadJob,
+			namespace: "ci",
+		},
+		{
+			name:      "own token",
+			authToken: token.SecretID,
+			secretID:  token.SecretID,
+			resource:  acl.ResourceNamespace,
+			operation: acl.NamespaceCapabilitySubmitJob,
+			namespace: "ci",
+			allowed:   true,
+		},
+		{
+			name:      "other token without management",
+			authToken: token.SecretID,
+			secretID:  root.SecretID,
+			resource:  acl.ResourceOperator,
+			operation: acl.PolicyWrite,
+			err:       structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:      "management",
+			authToken: root.SecretID,
+			resource:  acl.ResourceOperator,
+			operation: acl.PolicyWrite,
+			allowed:   true,
+		},
+		{
+			name:      "unknown token without management",
+			secretID:  uuid.Generate(),
+			resource:  acl.ResourceNode,
+			operation: acl.PolicyRead,
+			err:       structs.ErrPermissionDenied.Error(),
+		},
+		{
+			name:      "unknown token",
+			authToken: root.SecretID,
+			secretID:  uuid.Generate(),
+			resource:  acl.ResourceNode,
+			operation: acl.PolicyRead,
+			err:       structs.ErrTokenNotFound.Error(),
+		},
+		{
+			name:      "invalid resource",
+			authToken: token.SecretID,
+			resource:  "volume",
+			operation: acl.PolicyRead,
+			err:       "invalid resource",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &structs.ACLCheckRequest{
+				SecretID:  tc.secretID,
+				Resource:  tc.resource,
+				Operation: tc.operation,
+				QueryOptions: structs.QueryOptions{
+					Region:    "global",
+					Namespace: tc.namespace,
+					AuthToken: tc.authToken,
+				},
+			}
+			var resp structs.ACLCheckResponse
+			err := msgpackrpc.CallWithCodec(codec, "ACL.Check", req, &resp)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.allowed, resp.Allowed)
+			require.Equal(t, uint64(1001), resp.Index)
+		})
+	}
+}
+
 func TestACLEndpoint_OneTimeToken(t *testing.T) {
 	ci.Parallel(t)
 
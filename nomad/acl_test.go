@@ -2,6 +2,7 @@ package nomad
 
 import (
 	"testing"
+	"time"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/hashicorp/nomad/acl"
@@ -12,6 +13,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResolveACLToken(t *testing.T) {
@@ -93,6 +95,51 @@ func TestResolveACLToken(t *testing.T) {
 	}
 }
 
+func TestResolveACLToken_ExpiringPolicies(t *testing.T) {
+	ci.Parallel(t)
+
+	state := state.TestStateStore(t)
+	cache, err := lru.New2Q(16)
+	require.NoError(t, err)
+
+	policy := mock.ACLPolicy()
+	policy.Rules = `namespace "default" { policy = "read" }`
+	policy.SetHash()
+	elevated := mock.ACLPolicy()
+	elevated.Rules = `operator { policy = "write" }`
+	elevated.SetHash()
+	require.NoError(t, state.UpsertACLPolicies(structs.MsgTypeTestSetup, 100,
+		[]*structs.ACLPolicy{policy, elevated}))
+
+	token := mock.ACLToken()
+	token.Policies = []string{policy.Name}
+	token.ExpiringPolicies = []*structs.ACLTokenExpiringPolicy{
+		{Name: elevated.Name, ExpirationTime: time.Now().Add(time.Hour)},
+	}
+	expired := mock.ACLToken()
+	expired.Policies = []string{policy.Name}
+	expired.ExpiringPolicies = []*structs.ACLTokenExpiringPolicy{
+		{Name: elevated.Name, ExpirationTime: time.Now().Add(-time.Second)},
+	}
+	require.NoError(t, state.UpsertACLTokens(structs.MsgTypeTestSetup, 110,
+		[]*structs.ACLToken{token, expired}))
+
+	snap, err := state.Snapshot()
+	require.NoError(t, err)
+
+	// The unexpired policy is granted along with the token's policies
+	aclObj, err := resolveTokenFromSnapshotCache(snap, cache, token.SecretID)
+	require.NoError(t, err)
+	require.True(t, aclObj.AllowOperatorWrite())
+	require.True(t, aclObj.AllowNamespaceOperation("default", acl.NamespaceCapabilityReadJob))
+
+	// The expired policy silently drops off
+	aclObj, err = resolveTokenFromSnapshotCache(snap, cache, expired.SecretID)
+	require.NoError(t, err)
+	require.False(t, aclObj.AllowOperatorWrite())
+	require.True(t, aclObj.AllowNamespaceOperation("default", acl.NamespaceCapabilityReadJob))
+}
+
 func TestResolveACLToken_LeaderToken(t *testing.T) {
 	ci.Parallel(t)
 	assert := assert.New(t)
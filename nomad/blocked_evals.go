@@ -64,6 +64,10 @@ type BlockedEvals struct {
 	// time they are being blocked.
 	unblockIndexes map[string]uint64
 
+	// timers unblock the evaluations blocked until a given time, such as the
+	// opening of the time windows of their job. They are indexed by eval ID.
+	timers map[string]*time.Timer
+
 	// duplicates is the set of evaluations for jobs that had pre-existing
 	// blocked evaluations. These should be marked as cancelled since only one
 	// blocked eval is needed per job.
@@ -106,6 +110,7 @@ func NewBlockedEvals(evalBroker *EvalBroker, logger log.Logger) *BlockedEvals {
 		system:           newSystemEvals(),
 		jobs:             make(map[structs.NamespacedID]string),
 		unblockIndexes:   make(map[string]uint64),
+		timers:           make(map[string]*time.Timer),
 		capacityChangeCh: make(chan *capacityUpdate, unblockBuffer),
 		duplicateCh:      make(chan struct{}, 1),
 		stopCh:           make(chan struct{}),
@@ -208,6 +213,11 @@ func (b *BlockedEvals) processBlock(eval *structs.Evaluation, token string) {
 		token: token,
 	}
 
+	// Unblock the evaluation at its time regardless of capacity changes.
+	if !eval.BlockedUntil.IsZero() {
+		b.unblockAt(eval.ID, eval.BlockedUntil)
+	}
+
 	// If the eval has escaped, meaning computed node classes could not capture
 	// the constraints of the job, we store the eval separately as we have to
 	// unblock it whenever node capacity changes. This is because we don't know
@@ -229,6 +239,53 @@ func (b *BlockedEvals) processBlock(eval *structs.Evaluation, token string) {
 	b.captured[eval.ID] = wrapped
 }
 
+// unblockAt starts a timer unblocking the evaluation at the given time. This
+// should be called with the lock held.
+func (b *BlockedEvals) unblockAt(evalID string, at time.Time) {
+	if timer, ok := b.timers[evalID]; ok {
+		timer.Stop()
+	}
+	b.timers[evalID] = time.AfterFunc(time.Until(at), func() {
+		b.unblockTimer(evalID)
+	})
+}
+
+// unblockTimer unblocks the evaluation whose timer fired, if it is still
+// blocked.
+func (b *BlockedEvals) unblockTimer(evalID string) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	delete(b.timers, evalID)
+
+	// Protect against the case of a flush.
+	if !b.enabled {
+		return
+	}
+
+	wrapped, ok := b.captured[evalID]
+	if ok {
+		delete(b.captured, evalID)
+	} else if wrapped, ok = b.escaped[evalID]; ok {
+		delete(b.escaped, evalID)
+		b.stats.TotalEscaped--
+	} else {
+		// The evaluation was already unblocked or untracked
+		return
+	}
+
+	if wrapped.eval.Type == structs.JobTypeSystem {
+		b.system.Remove(wrapped.eval)
+	}
+	delete(b.jobs, structs.NewNamespacedID(wrapped.eval.JobID, wrapped.eval.Namespace))
+	if wrapped.eval.QuotaLimitReached != "" {
+		b.stats.TotalQuotaLimit--
+	}
+	b.stats.Unblock(wrapped.eval)
+
+	b.evalBroker.EnqueueAll(map[*structs.Evaluation]string{wrapped.eval: wrapped.token})
+}
+
 // processBlockJobDuplicate handles the case where the new eval is for a job
 // that we are already tracking. If the eval is a duplicate, we add the older
 // evaluation by Raft index to the list of duplicates such that it can be
@@ -679,6 +736,10 @@ func (b *BlockedEvals) Flush() {
 	b.escaped = make(map[string]wrappedEval)
 	b.jobs = make(map[structs.NamespacedID]string)
 	b.unblockIndexes = make(map[string]uint64)
+	for _, timer := range b.timers {
+		timer.Stop()
+	}
+	b.timers = make(map[string]*time.Timer)
 	b.timetable = nil
 	b.duplicates = nil
 	b.capacityChangeCh = make(chan *capacityUpdate, unblockBuffer)
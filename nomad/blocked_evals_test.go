@@ -231,6 +231,29 @@ func TestBlockedEvals_UnblockEligible(t *testing.T) {
 	requireBlockedEvalsEnqueued(t, blocked, broker, 1)
 }
 
+func TestBlockedEvals_UnblockAt(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	blocked, broker := testBlockedEvals(t)
+
+	// Create a blocked eval that waits for a time window to open and add it
+	// to the blocked tracker.
+	e := mock.BlockedEval()
+	e.Status = structs.EvalStatusBlocked
+	e.ClassEligibility = map[string]bool{"v1:123": false}
+	e.BlockedUntil = time.Now().Add(50 * time.Millisecond)
+	blocked.Block(e)
+
+	// Verify block caused the eval to be tracked
+	blockedStats := blocked.Stats()
+	require.Equal(1, blockedStats.TotalBlocked)
+
+	// The eval is unblocked once the time window opens without any capacity
+	// changes.
+	requireBlockedEvalsEnqueued(t, blocked, broker, 1)
+}
+
 func TestBlockedEvals_UnblockIneligible(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
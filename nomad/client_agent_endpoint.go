@@ -144,6 +144,12 @@ func (a *Agent) monitor(conn io.ReadWriteCloser) {
 		return
 	}
 
+	overrides, err := monitor.ParseLevelOverrides(args.LogLevelOverrides)
+	if err != nil {
+		handleStreamResultError(err, helper.Int64ToPtr(400), encoder)
+		return
+	}
+
 	// Targeting a node, forward request to node
 	if args.NodeID != "" {
 		a.forwardMonitorClient(conn, args, encoder, decoder)
@@ -178,10 +184,10 @@ func (a *Agent) monitor(conn io.ReadWriteCloser) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	monitor := monitor.New(512, a.srv.logger, &log.LoggerOptions{
+	monitor := monitor.NewWithOverrides(512, a.srv.logger, &log.LoggerOptions{
 		Level:      logLevel,
 		JSONFormat: args.LogJSON,
-	})
+	}, overrides)
 
 	frames := make(chan *sframer.StreamFrame, 32)
 	errCh := make(chan error)
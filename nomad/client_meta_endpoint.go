@@ -0,0 +1,92 @@
+package nomad
+
+import (
+	"errors"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// NodeMeta is used to forward RPC requests to the targeted Nomad client's
+// NodeMeta endpoint.
+type NodeMeta struct {
+	srv    *Server
+	logger log.Logger
+}
+
+// Apply is used to set or unset the dynamic metadata of a client.
+func (n *NodeMeta) Apply(args *structs.NodeMetaApplyRequest, reply *structs.NodeMetaResponse) error {
+	// We only allow stale reads since the only potentially stale information is
+	// the Node registration and the cost is fairly high for adding another hop
+	// in the forwarding chain.
+	args.QueryOptions.AllowStale = true
+
+	// Potentially forward to a different region.
+	if done, err := n.srv.forward("NodeMeta.Apply", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client_node_meta", "apply"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	return n.forwardClient(args.NodeID, "NodeMeta.Apply", args, reply)
+}
+
+// Read is used to read the metadata of a client.
+func (n *NodeMeta) Read(args *structs.NodeSpecificRequest, reply *structs.NodeMetaResponse) error {
+	// We only allow stale reads since the only potentially stale information is
+	// the Node registration and the cost is fairly high for adding another hop
+	// in the forwarding chain.
+	args.QueryOptions.AllowStale = true
+
+	// Potentially forward to a different region.
+	if done, err := n.srv.forward("NodeMeta.Read", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client_node_meta", "read"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	return n.forwardClient(args.NodeID, "NodeMeta.Read", args, reply)
+}
+
+// forwardClient forwards the request to the client, either directly or via
+// the server connected to it.
+func (n *NodeMeta) forwardClient(nodeID, method string, args, reply interface{}) error {
+	// Verify the arguments.
+	if nodeID == "" {
+		return errors.New("missing NodeID")
+	}
+
+	// Make sure Node is valid and new enough to support RPC
+	snap, err := n.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	_, err = getNodeForRpc(snap, nodeID)
+	if err != nil {
+		return err
+	}
+
+	// Get the connection to the client
+	state, ok := n.srv.getNodeConn(nodeID)
+	if !ok {
+		return findNodeConnAndForward(n.srv, nodeID, method, args, reply)
+	}
+
+	// Make the RPC
+	return NodeRpc(state.Session, method, args, reply)
+}
@@ -0,0 +1,137 @@
+package nomad
+
+import (
+	"fmt"
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeMeta_Apply_Local(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	// Start a server and client
+	s, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	c, cleanupC := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{s.config.RPCAddr.String()}
+	})
+	defer cleanupC()
+
+	testutil.WaitForResult(func() (bool, error) {
+		nodes := s.connectedNodes()
+		return len(nodes) == 1, nil
+	}, func(err error) {
+		t.Fatalf("should have a clients")
+	})
+
+	// Make the request without having a node-id
+	req := &structs.NodeMetaApplyRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+		Meta:         map[string]*string{"zone": helper.StringToPtr("z1")},
+	}
+	var resp structs.NodeMetaResponse
+	err := msgpackrpc.CallWithCodec(codec, "NodeMeta.Apply", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "missing")
+
+	// Make the request setting the node id
+	req.NodeID = c.NodeID()
+	require.NoError(msgpackrpc.CallWithCodec(codec, "NodeMeta.Apply", req, &resp))
+	require.Equal("z1", resp.Meta["zone"])
+
+	// The updated metadata is registered with the servers
+	testutil.WaitForResult(func() (bool, error) {
+		node, err := s.State().NodeByID(nil, c.NodeID())
+		if err != nil {
+			return false, err
+		}
+		if node == nil || node.Meta["zone"] != "z1" {
+			return false, fmt.Errorf("node metadata not updated")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	// Reading returns the dynamic metadata
+	readReq := &structs.NodeSpecificRequest{
+		NodeID:       c.NodeID(),
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var readResp structs.NodeMetaResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "NodeMeta.Read", readReq, &readResp))
+	require.Equal("z1", readResp.Meta["zone"])
+	require.Equal("z1", *readResp.Dynamic["zone"])
+}
+
+func TestNodeMeta_Apply_Local_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	// Start a server
+	s, root, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+
+	// Create a read-only and a write token
+	policyRead := mock.NodePolicy(acl.PolicyRead)
+	tokenRead := mock.CreatePolicyAndToken(t, s.State(), 1005, "read", policyRead)
+
+	policyWrite := mock.NodePolicy(acl.PolicyWrite)
+	tokenWrite := mock.CreatePolicyAndToken(t, s.State(), 1009, "write", policyWrite)
+
+	cases := []struct {
+		Name          string
+		Token         string
+		ExpectedError string
+	}{
+		{
+			Name:          "bad token",
+			Token:         tokenRead.SecretID,
+			ExpectedError: structs.ErrPermissionDenied.Error(),
+		},
+		{
+			Name:          "good token",
+			Token:         tokenWrite.SecretID,
+			ExpectedError: structs.ErrUnknownNodePrefix,
+		},
+		{
+			Name:          "root token",
+			Token:         root.SecretID,
+			ExpectedError: structs.ErrUnknownNodePrefix,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := &structs.NodeMetaApplyRequest{
+				NodeID: uuid.Generate(),
+				Meta:   map[string]*string{"zone": helper.StringToPtr("z1")},
+				QueryOptions: structs.QueryOptions{
+					Region:    "global",
+					AuthToken: c.Token,
+				},
+			}
+
+			var resp structs.NodeMetaResponse
+			err := msgpackrpc.CallWithCodec(codec, "NodeMeta.Apply", req, &resp)
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), c.ExpectedError)
+		})
+	}
+}
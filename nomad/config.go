@@ -10,6 +10,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 
 	"github.com/hashicorp/memberlist"
+	"github.com/hashicorp/nomad/helper/accesslog"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/deploymentwatcher"
@@ -251,6 +252,13 @@ type Config struct {
 	// of all the heartbeats.
 	FailoverHeartbeatTTL time.Duration
 
+	// MaxStale is the default bound on the staleness of stale reads served
+	// by followers, used when the request doesn't set its own. Followers
+	// forward stale reads to the leader if they haven't been in contact with
+	// it within the bound, or haven't applied the entries it had committed.
+	// Zero means stale reads are unbounded.
+	MaxStale time.Duration
+
 	// ConsulConfig is this Agent's Consul configuration
 	ConsulConfig *config.ConsulConfig
 
@@ -292,6 +300,18 @@ type Config struct {
 	// publishing Job summary metrics
 	DisableDispatchedJobSummaryMetrics bool
 
+	// NamespaceMetrics enables publishing the job summary and job status
+	// metrics aggregated by namespace.
+	NamespaceMetrics bool
+
+	// NamespaceMetricsAllowlist is the namespaces published with their own
+	// namespace label. The others are aggregated under the "_other" label.
+	NamespaceMetricsAllowlist []string
+
+	// NamespaceMetricsLimit is the maximum number of namespaces published
+	// with their own namespace label when there is no allowlist.
+	NamespaceMetricsLimit int
+
 	// AutopilotConfig is used to apply the initial autopilot config when
 	// bootstrapping.
 	AutopilotConfig *structs.AutopilotConfig
@@ -342,6 +362,44 @@ type Config struct {
 	// RaftBoltNoFreelistSync configures whether freelist syncing is enabled.
 	RaftBoltNoFreelistSync bool
 
+	// ScalingWebhookConfig configures the delivery of scaling events to an
+	// external endpoint. Scaling events are not delivered if nil.
+	ScalingWebhookConfig *structs.ScalingWebhookConfig
+
+	// DeploymentWebhookConfig configures the delivery of deployment status
+	// updates to an external endpoint. Updates are not delivered if nil.
+	DeploymentWebhookConfig *structs.DeploymentWebhookConfig
+
+	// NodeScorerConfig configures an external scorer of candidate nodes used
+	// by the schedulers. Nodes are only scored internally if nil.
+	NodeScorerConfig *structs.NodeScorerConfig
+
+	// ScoringPluginConfigs enables scoring plugins registered with the
+	// scheduler package, which contribute weighted scores to the ranking of
+	// candidate nodes.
+	ScoringPluginConfigs []*structs.ScoringPluginConfig
+
+	// SLOMetricsConfig configures the metrics endpoint the SLO queries of
+	// deployments are evaluated against. SLO queries are ignored if nil.
+	SLOMetricsConfig *structs.SLOMetricsConfig
+
+	// JobRegisterQueueLimit is the maximum number of asynchronous job
+	// registrations queued on the leader in each namespace.
+	JobRegisterQueueLimit int
+
+	// GCAutoTuneConfig configures the shrinking of the job, evaluation and
+	// deployment GC thresholds when the state of the server grows too large.
+	// The thresholds aren't tuned if nil.
+	GCAutoTuneConfig *structs.GCAutoTuneConfig
+
+	// AccessLogger writes an access log entry for every RPC served over the
+	// network. RPCs aren't logged if nil.
+	AccessLogger *accesslog.Logger
+
+	// WorkloadIdentityConfig configures the signing of workload identities.
+	// Tasks can't request workload identities if nil.
+	WorkloadIdentityConfig *structs.WorkloadIdentityConfig
+
 	// AgentShutdown is used to call agent.Shutdown from the context of a Server
 	// It is used primarily for licensing
 	AgentShutdown func() error
@@ -393,6 +451,7 @@ func DefaultConfig() *Config {
 		EvalFailedFollowupDelayRange:     5 * time.Minute,
 		MinHeartbeatTTL:                  10 * time.Second,
 		MaxHeartbeatsPerSecond:           50.0,
+		JobRegisterQueueLimit:            100,
 		HeartbeatGrace:                   10 * time.Second,
 		FailoverHeartbeatTTL:             300 * time.Second,
 		ConsulConfig:                     config.DefaultConsulConfig(),
@@ -503,7 +503,7 @@ func (s *Server) purgeSITokenAccessors(accessors []*structs.SITokenAccessor) err
 // ConsulConfigsAPI is an abstraction over the consul/api.ConfigEntries API used by
 // Nomad Server.
 //
-// Nomad will only perform write operations on Consul Ingress/Terminating Gateway
+// Nomad will only perform write operations on Consul Ingress/Terminating/API Gateway
 // Configuration Entries. Removing the entries is not yet safe, given that multiple
 // Nomad clusters may be writing to the same config entries, which are global in
 // the Consul scope. There was a Meta field introduced which Nomad can leverage
@@ -519,6 +519,10 @@ type ConsulConfigsAPI interface {
 	// the previous entry if set.
 	SetTerminatingCE(ctx context.Context, namespace, service string, entry *structs.ConsulTerminatingConfigEntry) error
 
+	// SetAPIGatewayCE adds the given ConfigEntry to Consul, overwriting
+	// the previous entry if set.
+	SetAPIGatewayCE(ctx context.Context, namespace, service string, entry *structs.ConsulAPIGatewayConfigEntry) error
+
 	// Stop is used to stop additional creations of Configuration Entries. Intended to
 	// be used on Nomad Server shutdown.
 	Stop()
@@ -563,6 +567,10 @@ func (c *consulConfigsAPI) SetTerminatingCE(ctx context.Context, namespace, serv
 	return c.setCE(ctx, convertTerminatingCE(namespace, service, entry))
 }
 
+func (c *consulConfigsAPI) SetAPIGatewayCE(ctx context.Context, namespace, service string, entry *structs.ConsulAPIGatewayConfigEntry) error {
+	return c.setCE(ctx, convertAPIGatewayCE(namespace, service, entry))
+}
+
 // setCE will set the Configuration Entry of any type Consul supports.
 func (c *consulConfigsAPI) setCE(ctx context.Context, entry api.ConfigEntry) error {
 	defer metrics.MeasureSince([]string{"nomad", "consul", "create_config_entry"}, time.Now())
@@ -634,3 +642,87 @@ func convertTerminatingCE(namespace, service string, entry *structs.ConsulTermin
 		Services:  linked,
 	}
 }
+
+const (
+	// apiGatewayKind is the Consul Configuration Entry kind of an API Gateway.
+	apiGatewayKind = "api-gateway"
+
+	// inlineCertificateKind is the Consul Configuration Entry kind of the
+	// certificates referenced by API Gateway listeners.
+	inlineCertificateKind = "inline-certificate"
+)
+
+// apiGatewayConfigEntry is the Consul Configuration Entry for an API Gateway.
+// The Consul API client Nomad uses predates API Gateways, so the subset of the
+// entry Nomad manages is defined here, matching the JSON encoding Consul
+// expects.
+type apiGatewayConfigEntry struct {
+	Kind        string
+	Name        string
+	Namespace   string            `json:",omitempty"`
+	Partition   string            `json:",omitempty"`
+	Meta        map[string]string `json:",omitempty"`
+	Listeners   []apiGatewayListener
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+type apiGatewayListener struct {
+	Name     string
+	Hostname string `json:",omitempty"`
+	Port     int
+	Protocol string
+	TLS      apiGatewayTLSConfig
+}
+
+type apiGatewayTLSConfig struct {
+	Certificates []apiGatewayResourceReference
+	MinVersion   string   `json:",omitempty"`
+	MaxVersion   string   `json:",omitempty"`
+	CipherSuites []string `json:",omitempty"`
+}
+
+type apiGatewayResourceReference struct {
+	Kind string
+	Name string
+}
+
+func (e *apiGatewayConfigEntry) GetKind() string            { return e.Kind }
+func (e *apiGatewayConfigEntry) GetName() string            { return e.Name }
+func (e *apiGatewayConfigEntry) GetNamespace() string       { return e.Namespace }
+func (e *apiGatewayConfigEntry) GetPartition() string       { return e.Partition }
+func (e *apiGatewayConfigEntry) GetMeta() map[string]string { return e.Meta }
+func (e *apiGatewayConfigEntry) GetCreateIndex() uint64     { return e.CreateIndex }
+func (e *apiGatewayConfigEntry) GetModifyIndex() uint64     { return e.ModifyIndex }
+
+func convertAPIGatewayCE(namespace, service string, entry *structs.ConsulAPIGatewayConfigEntry) api.ConfigEntry {
+	var listeners []apiGatewayListener = nil
+	for _, listener := range entry.Listeners {
+		var tls apiGatewayTLSConfig
+		if listener.TLS != nil {
+			for _, cert := range listener.TLS.Certificates {
+				tls.Certificates = append(tls.Certificates, apiGatewayResourceReference{
+					Kind: inlineCertificateKind,
+					Name: cert,
+				})
+			}
+			tls.MinVersion = listener.TLS.TLSMinVersion
+			tls.MaxVersion = listener.TLS.TLSMaxVersion
+			tls.CipherSuites = helper.CopySliceString(listener.TLS.CipherSuites)
+		}
+		listeners = append(listeners, apiGatewayListener{
+			Name:     listener.Name,
+			Hostname: listener.Hostname,
+			Port:     listener.Port,
+			Protocol: listener.Protocol,
+			TLS:      tls,
+		})
+	}
+
+	return &apiGatewayConfigEntry{
+		Namespace: namespace,
+		Kind:      apiGatewayKind,
+		Name:      service,
+		Listeners: listeners,
+	}
+}
@@ -2,6 +2,7 @@ package nomad
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"sync"
 	"testing"
@@ -71,9 +72,63 @@ func TestConsulConfigsAPI_SetCE(t *testing.T) {
 		})
 	})
 
+	apiGatewayCE := new(structs.ConsulAPIGatewayConfigEntry)
+	t.Run("api gateway ok", func(t *testing.T) {
+		try(t, nil, func(c ConsulConfigsAPI) error {
+			return c.SetAPIGatewayCE(ctx, consulNamespace, "ag", apiGatewayCE)
+		})
+	})
+
+	t.Run("api gateway fail", func(t *testing.T) {
+		try(t, errors.New("consul broke"), func(c ConsulConfigsAPI) error {
+			return c.SetAPIGatewayCE(ctx, consulNamespace, "ag", apiGatewayCE)
+		})
+	})
+
 	// also mesh
 }
 
+func TestConsulConfigsAPI_convertAPIGatewayCE(t *testing.T) {
+	ci.Parallel(t)
+
+	entry := convertAPIGatewayCE("ns1", "api-gateway", &structs.ConsulAPIGatewayConfigEntry{
+		Listeners: []*structs.ConsulAPIGatewayListener{{
+			Name:     "https",
+			Hostname: "*.example.com",
+			Port:     8443,
+			Protocol: "http",
+			TLS: &structs.ConsulAPIGatewayTLSConfig{
+				Certificates:  []string{"example-cert"},
+				TLSMinVersion: "TLSv1_2",
+			},
+		}},
+	})
+
+	require.Equal(t, "api-gateway", entry.GetKind())
+	require.Equal(t, "api-gateway", entry.GetName())
+	require.Equal(t, "ns1", entry.GetNamespace())
+
+	b, err := json.Marshal(entry)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"Kind": "api-gateway",
+		"Name": "api-gateway",
+		"Namespace": "ns1",
+		"Listeners": [{
+			"Name": "https",
+			"Hostname": "*.example.com",
+			"Port": 8443,
+			"Protocol": "http",
+			"TLS": {
+				"Certificates": [{"Kind": "inline-certificate", "Name": "example-cert"}],
+				"MinVersion": "TLSv1_2"
+			}
+		}],
+		"CreateIndex": 0,
+		"ModifyIndex": 0
+	}`, string(b))
+}
+
 type revokeRequest struct {
 	accessorID string
 	committed  bool
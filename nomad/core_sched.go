@@ -108,10 +108,11 @@ func (c *CoreScheduler) jobGC(eval *structs.Evaluation) error {
 	} else {
 		// Get the time table to calculate GC cutoffs.
 		tt := c.srv.fsm.TimeTable()
-		cutoff := time.Now().UTC().Add(-1 * c.srv.config.JobGCThreshold)
+		threshold := c.srv.gcTuner.threshold(c.srv.config.JobGCThreshold)
+		cutoff := time.Now().UTC().Add(-1 * threshold)
 		oldThreshold = tt.NearestIndex(cutoff)
 		c.logger.Debug("job GC scanning before cutoff index",
-			"index", oldThreshold, "job_gc_threshold", c.srv.config.JobGCThreshold)
+			"index", oldThreshold, "job_gc_threshold", threshold)
 	}
 
 	// Collect the allocations, evaluations and jobs to GC
@@ -247,10 +248,11 @@ func (c *CoreScheduler) evalGC(eval *structs.Evaluation) error {
 		// time table.  This is a rough mapping of a time to the
 		// Raft index it belongs to.
 		tt := c.srv.fsm.TimeTable()
-		cutoff := time.Now().UTC().Add(-1 * c.srv.config.EvalGCThreshold)
+		threshold := c.srv.gcTuner.threshold(c.srv.config.EvalGCThreshold)
+		cutoff := time.Now().UTC().Add(-1 * threshold)
 		oldThreshold = tt.NearestIndex(cutoff)
 		c.logger.Debug("eval GC scanning before cutoff index",
-			"index", oldThreshold, "eval_gc_threshold", c.srv.config.EvalGCThreshold)
+			"index", oldThreshold, "eval_gc_threshold", threshold)
 	}
 
 	// Collect the allocations and evaluations to GC
@@ -561,10 +563,11 @@ func (c *CoreScheduler) deploymentGC(eval *structs.Evaluation) error {
 		// time table.  This is a rough mapping of a time to the
 		// Raft index it belongs to.
 		tt := c.srv.fsm.TimeTable()
-		cutoff := time.Now().UTC().Add(-1 * c.srv.config.DeploymentGCThreshold)
+		threshold := c.srv.gcTuner.threshold(c.srv.config.DeploymentGCThreshold)
+		cutoff := time.Now().UTC().Add(-1 * threshold)
 		oldThreshold = tt.NearestIndex(cutoff)
 		c.logger.Debug("deployment GC scanning before cutoff index",
-			"index", oldThreshold, "deployment_gc_threshold", c.srv.config.DeploymentGCThreshold)
+			"index", oldThreshold, "deployment_gc_threshold", threshold)
 	}
 
 	// Collect the deployments to GC
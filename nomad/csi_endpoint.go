@@ -307,7 +307,7 @@ func (v *CSIVolume) Register(args *structs.CSIVolumeRegisterRequest, reply *stru
 		return err
 	}
 
-	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityCSIWriteVolume)
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityCSIWriteVolume, acl.NamespaceCapabilityCSIRegisterVolume)
 	aclObj, err := v.srv.WriteACLObj(&args.WriteRequest, false)
 	if err != nil {
 		return err
@@ -400,7 +400,7 @@ func (v *CSIVolume) Deregister(args *structs.CSIVolumeDeregisterRequest, reply *
 		return err
 	}
 
-	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityCSIWriteVolume)
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityCSIWriteVolume, acl.NamespaceCapabilityCSIDeleteVolume)
 	aclObj, err := v.srv.WriteACLObj(&args.WriteRequest, false)
 	if err != nil {
 		return err
@@ -885,7 +885,7 @@ func (v *CSIVolume) Create(args *structs.CSIVolumeCreateRequest, reply *structs.
 
 	defer metrics.MeasureSince([]string{"nomad", "volume", "create"}, time.Now())
 
-	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityCSIWriteVolume)
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityCSIWriteVolume, acl.NamespaceCapabilityCSICreateVolume)
 	aclObj, err := v.srv.WriteACLObj(&args.WriteRequest, false)
 	if err != nil {
 		return err
@@ -1007,7 +1007,7 @@ func (v *CSIVolume) Delete(args *structs.CSIVolumeDeleteRequest, reply *structs.
 
 	defer metrics.MeasureSince([]string{"nomad", "volume", "delete"}, time.Now())
 
-	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityCSIWriteVolume)
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityCSIWriteVolume, acl.NamespaceCapabilityCSIDeleteVolume)
 	aclObj, err := v.srv.WriteACLObj(&args.WriteRequest, false)
 	if err != nil {
 		return err
@@ -1315,11 +1315,30 @@ func (v *CSIVolume) ListSnapshots(args *structs.CSISnapshotListRequest, reply *s
 		return fmt.Errorf("plugin does not support listing snapshots")
 	}
 
+	// The volume filter can be either a volume registered with Nomad, in which
+	// case the plugin is queried for its external ID, or an ID only known to
+	// the storage provider
+	var vol *structs.CSIVolume
+	sourceVolumeID := args.VolumeID
+	if args.VolumeID != "" {
+		vol, err = snap.CSIVolumeByID(nil, args.RequestNamespace(), args.VolumeID)
+		if err != nil {
+			return err
+		}
+		if vol != nil {
+			if vol.PluginID != plugin.ID {
+				return fmt.Errorf("volume %q is not managed by plugin %q", vol.ID, plugin.ID)
+			}
+			sourceVolumeID = vol.ExternalID
+		}
+	}
+
 	method := "ClientCSI.ControllerListSnapshots"
 	cReq := &cstructs.ClientCSIControllerListSnapshotsRequest{
-		MaxEntries:    args.PerPage,
-		StartingToken: args.NextToken,
-		Secrets:       args.Secrets,
+		MaxEntries:     args.PerPage,
+		StartingToken:  args.NextToken,
+		SourceVolumeID: sourceVolumeID,
+		Secrets:        args.Secrets,
 	}
 	cReq.PluginID = plugin.ID
 	cResp := &cstructs.ClientCSIControllerListSnapshotsResponse{}
@@ -1328,11 +1347,26 @@ func (v *CSIVolume) ListSnapshots(args *structs.CSISnapshotListRequest, reply *s
 	if err != nil {
 		return err
 	}
-	if args.PerPage > 0 && args.PerPage < int32(len(cResp.Entries)) {
+
+	entries := cResp.Entries
+	if sourceVolumeID != "" {
+		// this should be done in the plugin already, but enforce it
+		entries = make([]*structs.CSISnapshot, 0, len(cResp.Entries))
+		for _, entry := range cResp.Entries {
+			if entry.ExternalSourceVolumeID != sourceVolumeID {
+				continue
+			}
+			if vol != nil {
+				entry.SourceVolumeID = vol.ID
+			}
+			entries = append(entries, entry)
+		}
+	}
+	if args.PerPage > 0 && args.PerPage < int32(len(entries)) {
 		// this should be done in the plugin already, but enforce it
-		reply.Snapshots = cResp.Entries[:args.PerPage]
+		reply.Snapshots = entries[:args.PerPage]
 	} else {
-		reply.Snapshots = cResp.Entries
+		reply.Snapshots = entries
 	}
 	reply.NextToken = cResp.NextToken
 
@@ -213,6 +213,76 @@ func TestCSIVolumeEndpoint_Register(t *testing.T) {
 	require.Nil(t, resp2.Volume)
 }
 
+func TestCSIVolumeEndpoint_Register_ACL(t *testing.T) {
+	ci.Parallel(t)
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	ns := structs.DefaultNamespace
+
+	state := srv.fsm.State()
+	state.BootstrapACLTokens(structs.MsgTypeTestSetup, 1, 0, mock.ACLManagementToken())
+	srv.config.ACLEnabled = true
+	registerPolicy := mock.NamespacePolicy(ns, "", []string{acl.NamespaceCapabilityCSIRegisterVolume}) +
+		mock.PluginPolicy("read")
+	registerToken := mock.CreatePolicyAndToken(t, state, 1001, "csi-register", registerPolicy)
+	deletePolicy := mock.NamespacePolicy(ns, "", []string{acl.NamespaceCapabilityCSIDeleteVolume})
+	deleteToken := mock.CreatePolicyAndToken(t, state, 1002, "csi-delete", deletePolicy)
+
+	codec := rpcClient(t, srv)
+
+	node := mock.Node()
+	node.CSINodePlugins = map[string]*structs.CSIInfo{
+		"minnie": {PluginID: "minnie",
+			Healthy:  true,
+			NodeInfo: &structs.CSINodeInfo{},
+		},
+	}
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1003, node))
+
+	id0 := uuid.Generate()
+	regReq := &structs.CSIVolumeRegisterRequest{
+		Volumes: []*structs.CSIVolume{{
+			ID:       id0,
+			PluginID: "minnie",
+			RequestedCapabilities: []*structs.CSIVolumeCapability{{
+				AccessMode:     structs.CSIVolumeAccessModeMultiNodeReader,
+				AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
+			}},
+		}},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: ns,
+		},
+	}
+	deregReq := &structs.CSIVolumeDeregisterRequest{
+		VolumeIDs: []string{id0},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: ns,
+		},
+	}
+
+	// Registering requires csi-register-volume
+	regReq.AuthToken = deleteToken.SecretID
+	err := msgpackrpc.CallWithCodec(codec, "CSIVolume.Register", regReq, &structs.CSIVolumeRegisterResponse{})
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	regReq.AuthToken = registerToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "CSIVolume.Register", regReq, &structs.CSIVolumeRegisterResponse{}))
+
+	// Deregistering requires csi-delete-volume
+	deregReq.AuthToken = registerToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "CSIVolume.Deregister", deregReq, &structs.CSIVolumeDeregisterResponse{})
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	deregReq.AuthToken = deleteToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "CSIVolume.Deregister", deregReq, &structs.CSIVolumeDeregisterResponse{}))
+}
+
 // TestCSIVolumeEndpoint_Claim exercises the VolumeClaim RPC, verifying that claims
 // are honored only if the volume exists, the mode is permitted, and the volume
 // is schedulable according to its count of claims.
@@ -1585,6 +1655,33 @@ func TestCSIVolumeEndpoint_ListSnapshots(t *testing.T) {
 	require.Equal(t, "vol-abcde", resp.Snapshots[1].ExternalSourceVolumeID)
 	require.True(t, resp.Snapshots[0].IsReady)
 	require.Equal(t, "page2", resp.NextToken)
+
+	// List snapshots for a volume registered with Nomad
+	vol := &structs.CSIVolume{
+		ID:         "vol-nomad",
+		Namespace:  structs.DefaultNamespace,
+		ExternalID: "vol-abcde",
+		PluginID:   "minnie",
+	}
+	index++
+	require.NoError(t, state.UpsertCSIVolume(index, []*structs.CSIVolume{vol}))
+
+	req.VolumeID = "vol-nomad"
+	resp = &structs.CSISnapshotListResponse{}
+	err = msgpackrpc.CallWithCodec(codec, "CSIVolume.ListSnapshots", req, resp)
+	require.NoError(t, err)
+	require.Len(t, resp.Snapshots, 1)
+	require.Equal(t, "snap-abcde", resp.Snapshots[0].ID)
+	require.Equal(t, "vol-nomad", resp.Snapshots[0].SourceVolumeID)
+
+	// List snapshots for a volume only known to the storage provider
+	req.VolumeID = "vol-12345"
+	resp = &structs.CSISnapshotListResponse{}
+	err = msgpackrpc.CallWithCodec(codec, "CSIVolume.ListSnapshots", req, resp)
+	require.NoError(t, err)
+	require.Len(t, resp.Snapshots, 1)
+	require.Equal(t, "snap-12345", resp.Snapshots[0].ID)
+	require.Empty(t, resp.Snapshots[0].SourceVolumeID)
 }
 
 func TestCSIPluginEndpoint_RegisterViaFingerprint(t *testing.T) {
@@ -181,6 +181,9 @@ func (d *Deployment) Promote(args *structs.DeploymentPromoteRequest, reply *stru
 	if args.DeploymentID == "" {
 		return fmt.Errorf("missing deployment ID")
 	}
+	if args.Percent < 0 || args.Percent > 100 {
+		return fmt.Errorf("promotion percent must be between 1 and 100")
+	}
 
 	// Lookup the deployment
 	snap, err := d.srv.fsm.State().Snapshot()
@@ -351,8 +354,9 @@ func (d *Deployment) SetAllocHealth(args *structs.DeploymentAllocHealthRequest,
 		return fmt.Errorf("missing deployment ID")
 	}
 
-	if len(args.HealthyAllocationIDs)+len(args.UnhealthyAllocationIDs) == 0 {
-		return fmt.Errorf("must specify at least one healthy/unhealthy allocation ID")
+	if len(args.HealthyAllocationIDs)+len(args.UnhealthyAllocationIDs)+
+		len(args.HealthyTaskGroups)+len(args.UnhealthyTaskGroups) == 0 {
+		return fmt.Errorf("must specify at least one healthy/unhealthy allocation ID or task group")
 	}
 
 	// Lookup the deployment
@@ -381,10 +385,98 @@ func (d *Deployment) SetAllocHealth(args *structs.DeploymentAllocHealthRequest,
 		return structs.ErrDeploymentTerminalNoSetHealth
 	}
 
+	// Resolve the task groups into their allocations
+	if err := resolveAllocHealthGroups(snap, deploy, args); err != nil {
+		return err
+	}
+
+	// Record who set the health
+	args.SetBy = ""
+	if token, err := d.srv.ResolveSecretToken(args.AuthToken); err != nil {
+		return err
+	} else if token != nil {
+		args.SetBy = token.AccessorID
+	}
+
 	// Call into the deployment watcher
 	return d.srv.deploymentWatcher.SetAllocHealth(args, reply)
 }
 
+// resolveAllocHealthGroups adds the non-terminal allocations of the healthy
+// and unhealthy task groups of the request to its allocation IDs, and
+// validates that no allocation is marked both healthy and unhealthy and that
+// reasons are only given for the allocations whose health is set.
+func resolveAllocHealthGroups(snap *state.StateSnapshot, deploy *structs.Deployment, args *structs.DeploymentAllocHealthRequest) error {
+	healthy := make(map[string]bool)
+	add := func(id string, isHealthy bool) error {
+		if existing, ok := healthy[id]; ok && existing != isHealthy {
+			return fmt.Errorf("allocation %q can't be marked both healthy and unhealthy", id)
+		}
+		healthy[id] = isHealthy
+		return nil
+	}
+	for _, id := range args.HealthyAllocationIDs {
+		if err := add(id, true); err != nil {
+			return err
+		}
+	}
+	for _, id := range args.UnhealthyAllocationIDs {
+		if err := add(id, false); err != nil {
+			return err
+		}
+	}
+
+	if len(args.HealthyTaskGroups)+len(args.UnhealthyTaskGroups) != 0 {
+		allocs, err := snap.AllocsByDeployment(nil, deploy.ID)
+		if err != nil {
+			return err
+		}
+
+		addGroup := func(group string, isHealthy bool) ([]string, error) {
+			if _, ok := deploy.TaskGroups[group]; !ok {
+				return nil, fmt.Errorf("task group %q is not part of the deployment", group)
+			}
+			var ids []string
+			for _, alloc := range allocs {
+				if alloc.TaskGroup != group || alloc.TerminalStatus() {
+					continue
+				}
+				if _, ok := healthy[alloc.ID]; !ok {
+					ids = append(ids, alloc.ID)
+				}
+				if err := add(alloc.ID, isHealthy); err != nil {
+					return nil, err
+				}
+			}
+			return ids, nil
+		}
+		for _, group := range args.HealthyTaskGroups {
+			ids, err := addGroup(group, true)
+			if err != nil {
+				return err
+			}
+			args.HealthyAllocationIDs = append(args.HealthyAllocationIDs, ids...)
+		}
+		for _, group := range args.UnhealthyTaskGroups {
+			ids, err := addGroup(group, false)
+			if err != nil {
+				return err
+			}
+			args.UnhealthyAllocationIDs = append(args.UnhealthyAllocationIDs, ids...)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return fmt.Errorf("no allocation to set the health of")
+	}
+	for id := range args.Reasons {
+		if _, ok := healthy[id]; !ok {
+			return fmt.Errorf("reason given for allocation %q whose health is not set", id)
+		}
+	}
+	return nil
+}
+
 // List returns the list of deployments in the system
 func (d *Deployment) List(args *structs.DeploymentListRequest, reply *structs.DeploymentListResponse) error {
 	if done, err := d.srv.forward("Deployment.List", args, args, reply); done {
@@ -1,6 +1,7 @@
 package nomad
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -784,9 +785,114 @@ func TestDeploymentEndpoint_SetAllocHealth_ACL(t *testing.T) {
 		assert.NotNil(aout.DeploymentStatus, "alloc deployment status")
 		assert.NotNil(aout.DeploymentStatus.Healthy, "alloc deployment healthy")
 		assert.True(*aout.DeploymentStatus.Healthy, "alloc deployment healthy")
+		assert.True(aout.DeploymentStatus.Manual, "alloc deployment health set manually")
+		assert.Equal(validToken.AccessorID, aout.DeploymentStatus.SetBy, "alloc deployment health set by")
 	}
 }
 
+func TestDeploymentEndpoint_SetAllocHealth_TaskGroups(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the deployment, job and allocations of the web group, one of
+	// them terminal
+	j := mock.Job()
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	d := mock.Deployment()
+	d.JobID = j.ID
+	a1 := mock.Alloc()
+	a1.JobID = j.ID
+	a1.DeploymentID = d.ID
+	a2 := mock.Alloc()
+	a2.JobID = j.ID
+	a2.DeploymentID = d.ID
+	a3 := mock.Alloc()
+	a3.JobID = j.ID
+	a3.DeploymentID = d.ID
+	a3.DesiredStatus = structs.AllocDesiredStatusStop
+
+	state := s1.fsm.State()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, j))
+	require.NoError(t, state.UpsertDeployment(1000, d))
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{a1, a2, a3}))
+
+	cases := []struct {
+		name        string
+		req         structs.DeploymentAllocHealthRequest
+		expectedErr string
+	}{
+		{
+			name:        "Unknown Task Group",
+			req:         structs.DeploymentAllocHealthRequest{HealthyTaskGroups: []string{"api"}},
+			expectedErr: `task group "api" is not part of the deployment`,
+		},
+		{
+			name: "Healthy And Unhealthy",
+			req: structs.DeploymentAllocHealthRequest{
+				HealthyTaskGroups:      []string{"web"},
+				UnhealthyAllocationIDs: []string{a1.ID},
+			},
+			expectedErr: fmt.Sprintf("allocation %q can't be marked both healthy and unhealthy", a1.ID),
+		},
+		{
+			name: "Reason Without Health",
+			req: structs.DeploymentAllocHealthRequest{
+				HealthyAllocationIDs: []string{a1.ID},
+				Reasons:              map[string]string{a2.ID: "verified"},
+			},
+			expectedErr: fmt.Sprintf("reason given for allocation %q whose health is not set", a2.ID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := tc.req
+			req.DeploymentID = d.ID
+			req.WriteRequest = structs.WriteRequest{Region: "global"}
+			var resp structs.DeploymentUpdateResponse
+			err := msgpackrpc.CallWithCodec(codec, "Deployment.SetAllocHealth", &req, &resp)
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+
+	// Mark the web group healthy, with a reason for one of its allocations
+	req := &structs.DeploymentAllocHealthRequest{
+		DeploymentID:      d.ID,
+		HealthyTaskGroups: []string{"web"},
+		Reason:            "smoke tests passed",
+		Reasons:           map[string]string{a2.ID: "verified by hand"},
+		SetBy:             "forged",
+		WriteRequest:      structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.DeploymentUpdateResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Deployment.SetAllocHealth", req, &resp))
+
+	dout, err := state.DeploymentByID(nil, d.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, dout.TaskGroups["web"].HealthyAllocs)
+
+	aout, err := state.AllocByID(nil, a1.ID)
+	require.NoError(t, err)
+	require.True(t, aout.DeploymentStatus.IsHealthy())
+	require.True(t, aout.DeploymentStatus.Manual)
+	require.Equal(t, "smoke tests passed", aout.DeploymentStatus.Reason)
+	require.Empty(t, aout.DeploymentStatus.SetBy)
+
+	aout, err = state.AllocByID(nil, a2.ID)
+	require.NoError(t, err)
+	require.True(t, aout.DeploymentStatus.IsHealthy())
+	require.Equal(t, "verified by hand", aout.DeploymentStatus.Reason)
+
+	aout, err = state.AllocByID(nil, a3.ID)
+	require.NoError(t, err)
+	require.False(t, aout.DeploymentStatus.HasHealth(), "terminal allocations are skipped")
+}
+
 func TestDeploymentEndpoint_SetAllocHealth_Rollback(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,133 @@
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// sloQueryMaxResponseSize is the maximum size of a response from the
+	// metrics endpoint SLO queries are evaluated against.
+	sloQueryMaxResponseSize = 1024 * 1024
+)
+
+// sloQueryResponse is the response of the Prometheus instant query API.
+type sloQueryResponse struct {
+	Status string
+	Error  string
+	Data   struct {
+		ResultType string
+		Result     json.RawMessage
+	}
+}
+
+// sloQuerySample is a sample of an instant vector returned by the
+// Prometheus instant query API.
+type sloQuerySample struct {
+	Value []interface{}
+}
+
+// prometheusSLOQuerier evaluates the SLO queries of deployments using the
+// instant query API of Prometheus or a compatible metrics store. It
+// implements the deploymentwatcher.SLOQuerier interface.
+type prometheusSLOQuerier struct {
+	config *structs.SLOMetricsConfig
+	client *http.Client
+}
+
+// newPrometheusSLOQuerier returns a prometheusSLOQuerier for the given
+// configuration.
+func newPrometheusSLOQuerier(config *structs.SLOMetricsConfig) *prometheusSLOQuerier {
+	return &prometheusSLOQuerier{
+		config: config,
+		client: cleanhttp.DefaultPooledClient(),
+	}
+}
+
+// Query evaluates the instant query. The SLO is met if the query returns a
+// scalar, or an instant vector with at least one sample, whose values are all
+// non-zero.
+func (q *prometheusSLOQuerier) Query(ctx context.Context, query string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, q.config.Timeout)
+	defer cancel()
+
+	u := strings.TrimSuffix(q.config.Address, "/") + "/api/v1/query?" +
+		url.Values{"query": []string{query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := q.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, sloQueryMaxResponseSize))
+	if err != nil {
+		return false, err
+	}
+
+	var out sloQueryResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return false, fmt.Errorf("failed to decode query response with status code %d: %v", resp.StatusCode, err)
+	}
+	if out.Status != "success" {
+		return false, fmt.Errorf("query failed with status code %d: %s", resp.StatusCode, out.Error)
+	}
+
+	switch out.Data.ResultType {
+	case "scalar":
+		var value []interface{}
+		if err := json.Unmarshal(out.Data.Result, &value); err != nil {
+			return false, fmt.Errorf("failed to decode scalar result: %v", err)
+		}
+		return sloSampleHealthy(value)
+	case "vector":
+		var samples []*sloQuerySample
+		if err := json.Unmarshal(out.Data.Result, &samples); err != nil {
+			return false, fmt.Errorf("failed to decode vector result: %v", err)
+		}
+		if len(samples) == 0 {
+			return false, nil
+		}
+		for _, sample := range samples {
+			healthy, err := sloSampleHealthy(sample.Value)
+			if err != nil || !healthy {
+				return false, err
+			}
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported result type %q", out.Data.ResultType)
+	}
+}
+
+// sloSampleHealthy returns whether the value of a [timestamp, "value"] sample
+// is non-zero.
+func sloSampleHealthy(sample []interface{}) (bool, error) {
+	if len(sample) != 2 {
+		return false, fmt.Errorf("invalid sample %v", sample)
+	}
+	raw, ok := sample[1].(string)
+	if !ok {
+		return false, fmt.Errorf("invalid sample value %v", sample[1])
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid sample value %q: %v", raw, err)
+	}
+	return value != 0 && !math.IsNaN(value), nil
+}
@@ -0,0 +1,101 @@
+package nomad
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusSLOQuerier(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name        string
+		response    string
+		healthy     bool
+		expectedErr string
+	}{
+		{
+			name:     "vector healthy",
+			response: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1435781451.781,"1"]},{"metric":{},"value":[1435781451.781,"0.5"]}]}}`,
+			healthy:  true,
+		},
+		{
+			name:     "vector zero sample",
+			response: `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1435781451.781,"1"]},{"metric":{},"value":[1435781451.781,"0"]}]}}`,
+		},
+		{
+			name:     "vector empty",
+			response: `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		},
+		{
+			name:     "scalar healthy",
+			response: `{"status":"success","data":{"resultType":"scalar","result":[1435781451.781,"1"]}}`,
+			healthy:  true,
+		},
+		{
+			name:     "scalar NaN",
+			response: `{"status":"success","data":{"resultType":"scalar","result":[1435781451.781,"NaN"]}}`,
+		},
+		{
+			name:        "query error",
+			response:    `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+			expectedErr: "query failed with status code 200: parse error",
+		},
+		{
+			name:        "matrix",
+			response:    `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			expectedErr: `unsupported result type "matrix"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			queryCh := make(chan string, 1)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				queryCh <- r.URL.Path + "?" + r.URL.Query().Get("query")
+				w.Write([]byte(tc.response))
+			}))
+			defer srv.Close()
+
+			querier := newPrometheusSLOQuerier(&structs.SLOMetricsConfig{
+				Address: srv.URL + "/",
+				Timeout: 5 * time.Second,
+			})
+			healthy, err := querier.Query(context.Background(), `sum(rate(errors[5m])) < 1`)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.healthy, healthy)
+			require.Equal(t, "/api/v1/query?sum(rate(errors[5m])) < 1", <-queryCh)
+		})
+	}
+}
+
+func TestPrometheusSLOQuerier_Timeout(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	querier := newPrometheusSLOQuerier(&structs.SLOMetricsConfig{
+		Address: srv.URL,
+		Timeout: 50 * time.Millisecond,
+	})
+	healthy, err := querier.Query(context.Background(), "up")
+	require.Error(t, err)
+	require.False(t, healthy)
+}
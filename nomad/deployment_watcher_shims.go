@@ -10,6 +10,10 @@ import (
 type deploymentWatcherRaftShim struct {
 	// apply is used to apply a message to Raft
 	apply raftApplyFn
+
+	// statusUpdated is called with the index and update of each applied
+	// deployment status update, if set.
+	statusUpdated func(index uint64, u *structs.DeploymentStatusUpdate)
 }
 
 // convertApplyErrors parses the results of a raftApply and returns the index at
@@ -37,7 +41,11 @@ func (d *deploymentWatcherRaftShim) UpsertJob(job *structs.Job) (uint64, error)
 
 func (d *deploymentWatcherRaftShim) UpdateDeploymentStatus(u *structs.DeploymentStatusUpdateRequest) (uint64, error) {
 	fsmErrIntf, index, raftErr := d.apply(structs.DeploymentStatusUpdateRequestType, u)
-	return d.convertApplyErrors(fsmErrIntf, index, raftErr)
+	index, err := d.convertApplyErrors(fsmErrIntf, index, raftErr)
+	if err == nil && d.statusUpdated != nil {
+		d.statusUpdated(index, u.DeploymentUpdate)
+	}
+	return index, err
 }
 
 func (d *deploymentWatcherRaftShim) UpdateDeploymentPromotion(req *structs.ApplyDeploymentPromoteRequest) (uint64, error) {
@@ -0,0 +1,58 @@
+package nomad
+
+import (
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// deploymentWebhookPayload is the body POSTed to the deployment webhook for
+// each change of the status of a deployment.
+type deploymentWebhookPayload struct {
+	// Index is the Raft index at which the status was updated
+	Index uint64
+
+	Namespace         string
+	JobID             string
+	JobVersion        uint64
+	DeploymentID      string
+	Status            string
+	StatusDescription string
+	StatusReason      string
+}
+
+// deploymentWebhook delivers the status updates of deployments to an operator
+// defined endpoint.
+type deploymentWebhook struct {
+	*webhook
+}
+
+// newDeploymentWebhook returns a deploymentWebhook for the given
+// configuration.
+func newDeploymentWebhook(config *structs.DeploymentWebhookConfig, logger log.Logger) (*deploymentWebhook, error) {
+	w, err := newWebhook("deployment status update", config.URL, config.Secret, config.CAFile,
+		logger.Named("deployment_webhook"))
+	if err != nil {
+		return nil, err
+	}
+	return &deploymentWebhook{webhook: w}, nil
+}
+
+// notify queues the status update u of deployment d for delivery. It never
+// blocks; updates are dropped if the queue is full. It is safe to call on a
+// nil deploymentWebhook.
+func (w *deploymentWebhook) notify(index uint64, d *structs.Deployment, u *structs.DeploymentStatusUpdate) {
+	if w == nil {
+		return
+	}
+
+	w.enqueue(&deploymentWebhookPayload{
+		Index:             index,
+		Namespace:         d.Namespace,
+		JobID:             d.JobID,
+		JobVersion:        d.JobVersion,
+		DeploymentID:      d.ID,
+		Status:            u.Status,
+		StatusDescription: u.StatusDescription,
+		StatusReason:      u.StatusReason,
+	}, "namespace", d.Namespace, "job_id", d.JobID, "deployment_id", d.ID, "index", index)
+}
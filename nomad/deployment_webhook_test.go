@@ -0,0 +1,70 @@
+package nomad
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeploymentEndpoint_Fail_ReasonWebhook(t *testing.T) {
+	ci.Parallel(t)
+
+	config, reqCh := testScalingWebhookServer(t, 0)
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.DeploymentWebhookConfig = &structs.DeploymentWebhookConfig{
+			URL:    config.URL,
+			Secret: config.Secret,
+			CAFile: config.CAFile,
+		}
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	j := mock.Job()
+	d := mock.Deployment()
+	d.JobID = j.ID
+	state := s1.fsm.State()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 999, j))
+	require.NoError(t, state.UpsertDeployment(1000, d))
+
+	req := &structs.DeploymentFailRequest{
+		DeploymentID: d.ID,
+		Reason:       "error rate doubled in canaries",
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.DeploymentUpdateResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Deployment.Fail", req, &resp))
+
+	// The reason is recorded on the deployment
+	dout, err := state.DeploymentByID(nil, d.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.DeploymentStatusFailed, dout.Status)
+	require.Equal(t, "error rate doubled in canaries", dout.StatusReason)
+
+	// The status update is delivered to the webhook
+	var webhookReq scalingWebhookRequest
+	select {
+	case webhookReq = <-reqCh:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for deployment webhook")
+	}
+	require.Equal(t, "sha256="+webhookSignature("s3cr3t", webhookReq.body), webhookReq.signature)
+
+	var payload deploymentWebhookPayload
+	require.NoError(t, json.Unmarshal(webhookReq.body, &payload))
+	require.Equal(t, resp.DeploymentModifyIndex, payload.Index)
+	require.Equal(t, j.ID, payload.JobID)
+	require.Equal(t, d.ID, payload.DeploymentID)
+	require.Equal(t, structs.DeploymentStatusFailed, payload.Status)
+	require.Equal(t, structs.DeploymentStatusDescriptionFailedByUser, payload.StatusDescription)
+	require.Equal(t, "error rate doubled in canaries", payload.StatusReason)
+}
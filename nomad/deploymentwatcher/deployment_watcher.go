@@ -98,6 +98,16 @@ type deploymentWatcher struct {
 	// by holding the lock or using the setter and getter methods.
 	latestEval uint64
 
+	// sloQuerier evaluates the SLO queries of the task groups. SLO queries
+	// are ignored if nil.
+	sloQuerier SLOQuerier
+
+	// sloCheckedAt and sloPassed track when the SLO query of each task group
+	// was last evaluated and whether it was ever healthy. They are only
+	// accessed by the watch loop.
+	sloCheckedAt map[string]time.Time
+	sloPassed    map[string]bool
+
 	logger log.Logger
 	ctx    context.Context
 	exitFn context.CancelFunc
@@ -109,7 +119,7 @@ type deploymentWatcher struct {
 func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 	logger log.Logger, state *state.StateStore, d *structs.Deployment,
 	j *structs.Job, triggers deploymentTriggers,
-	deploymentRPC DeploymentRPC, jobRPC JobRPC) *deploymentWatcher {
+	deploymentRPC DeploymentRPC, jobRPC JobRPC, sloQuerier SLOQuerier) *deploymentWatcher {
 
 	ctx, exitFn := context.WithCancel(parent)
 	w := &deploymentWatcher{
@@ -122,6 +132,9 @@ func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 		deploymentTriggers: triggers,
 		DeploymentRPC:      deploymentRPC,
 		JobRPC:             jobRPC,
+		sloQuerier:         sloQuerier,
+		sloCheckedAt:       make(map[string]time.Time),
+		sloPassed:          make(map[string]bool),
 		logger:             logger.With("deployment_id", d.ID, "job", j.NamespacedID()),
 		ctx:                ctx,
 		exitFn:             exitFn,
@@ -285,7 +298,7 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 
 	// AutoPromote iff every task group with canaries is marked auto_promote and is healthy. The whole
 	// job version has been incremented, so we promote together. See also AutoRevert
-	for _, dstate := range d.TaskGroups {
+	for name, dstate := range d.TaskGroups {
 
 		// skip auto promote canary validation if the task group has no canaries
 		// to prevent auto promote hanging on mixed canary/non-canary taskgroup deploys
@@ -297,6 +310,16 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 			return nil
 		}
 
+		// Leave the rollout of partially promoted groups to the operator
+		if dstate.PartiallyPromoted() {
+			return nil
+		}
+
+		// Wait for the SLO query of the group to be healthy
+		if w.sloPending(name) {
+			return nil
+		}
+
 		// Find the health status of each canary
 		for _, c := range dstate.PlacedCanaries {
 			for _, a := range allocs {
@@ -378,6 +401,7 @@ func (w *deploymentWatcher) FailDeployment(
 
 	// Commit the change
 	update := w.getDeploymentStatusUpdate(status, desc)
+	update.StatusReason = req.Reason
 	eval := w.getEval()
 	i, err := w.upsertDeploymentStatusUpdate(update, eval, rollbackJob)
 	if err != nil {
@@ -426,7 +450,16 @@ func (w *deploymentWatcher) watch() {
 	allocsCh := w.getAllocsCh(allocIndex)
 	var updates *allocUpdates
 
-	rollback, deadlineHit := false, false
+	// Check whether SLO queries are due on an interval if any task group
+	// defines one
+	var sloCh <-chan time.Time
+	if interval := w.sloTickInterval(); interval > 0 {
+		sloTicker := time.NewTicker(interval)
+		defer sloTicker.Stop()
+		sloCh = sloTicker.C
+	}
+
+	rollback, deadlineHit, sloFailed := false, false, false
 
 FAIL:
 	for {
@@ -495,6 +528,27 @@ FAIL:
 				break FAIL
 			}
 
+		case now := <-sloCh:
+			fail, rback := w.checkSLOs(now)
+			if !fail {
+				// A healthy SLO query may unblock automatic promotion
+				if updates != nil {
+					if err := w.autoPromoteDeployment(updates.allocs); err != nil {
+						w.logger.Error("failed to auto promote deployment", "error", err)
+					}
+				}
+				continue
+			}
+
+			w.logger.Debug("SLO query unhealthy", "rollback", rback)
+			sloFailed = true
+			rollback = rback
+			err := w.nextRegion(structs.DeploymentStatusFailed)
+			if err != nil {
+				w.logger.Error("multiregion deployment error", "error", err)
+			}
+			break FAIL
+
 		case updates = <-allocsCh:
 			if err := updates.err; err != nil {
 				if err == context.Canceled || w.ctx.Err() == context.Canceled {
@@ -547,7 +601,9 @@ FAIL:
 
 	// Change the deployments status to failed
 	desc := structs.DeploymentStatusDescriptionFailedAllocations
-	if deadlineHit {
+	if sloFailed {
+		desc = structs.DeploymentStatusDescriptionFailedSLO
+	} else if deadlineHit {
 		desc = structs.DeploymentStatusDescriptionProgressDeadline
 	}
 
@@ -675,7 +731,7 @@ func (w *deploymentWatcher) shouldFail() (fail, rollback bool, err error) {
 			if dstate.HealthyAllocs >= dstate.DesiredCanaries {
 				continue
 			}
-		} else if dstate.HealthyAllocs >= dstate.DesiredTotal {
+		} else if dstate.HealthyAllocs >= dstate.PromotedTotal() {
 			continue
 		}
 
@@ -81,6 +81,10 @@ type Watcher struct {
 	// server interface for Job RPCs
 	jobRPC JobRPC
 
+	// sloQuerier evaluates the SLO queries of deployments, which are ignored
+	// if nil
+	sloQuerier SLOQuerier
+
 	// watchers is the set of active watchers, one per deployment
 	watchers map[string]*deploymentWatcher
 
@@ -114,6 +118,14 @@ func NewDeploymentsWatcher(logger log.Logger,
 	}
 }
 
+// SetSLOQuerier sets the querier used to evaluate the SLO queries of
+// deployments. It must be called before the watcher is enabled.
+func (w *Watcher) SetSLOQuerier(querier SLOQuerier) {
+	w.l.Lock()
+	defer w.l.Unlock()
+	w.sloQuerier = querier
+}
+
 // SetEnabled is used to control if the watcher is enabled. The watcher
 // should only be enabled on the active leader. When being enabled the state is
 // passed in as it is no longer valid once a leader election has taken place.
@@ -269,7 +281,7 @@ func (w *Watcher) addLocked(d *structs.Deployment) (*deploymentWatcher, error) {
 	}
 
 	watcher := newDeploymentWatcher(w.ctx, w.queryLimiter, w.logger, w.state, d, job,
-		w, w.deploymentRPC, w.jobRPC)
+		w, w.deploymentRPC, w.jobRPC, w.sloQuerier)
 	w.watchers[d.ID] = watcher
 	return watcher, nil
 }
@@ -1,6 +1,7 @@
 package deploymentwatcher
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -1756,6 +1757,117 @@ func TestDeploymentWatcher_RollbackFailed(t *testing.T) {
 }
 
 // Test allocation updates and evaluation creation is batched between watchers
+// Tests that the deployment fails once the SLO query of a group with healthy
+// canaries is unhealthy
+func TestDeploymentWatcher_Watch_SLOQuery_Unhealthy(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	w, m := defaultTestDeploymentWatcher(t)
+	w.SetSLOQuerier(&testSLOQuerier{healthy: false})
+
+	// Create a job with a canary and an SLO query, and a deployment whose
+	// canary is healthy
+	j := mock.Job()
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.Canary = 1
+	j.TaskGroups[0].Update.SLOQuery = "up"
+	j.TaskGroups[0].Update.SLOInterval = 50 * time.Millisecond
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups["web"].DesiredCanaries = 1
+	d.TaskGroups["web"].PlacedCanaries = []string{uuid.Generate()}
+	d.TaskGroups["web"].HealthyAllocs = 1
+	require.Nil(m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), j), "UpsertJob")
+	require.Nil(m.state.UpsertDeployment(m.nextIndex(), d), "UpsertDeployment")
+
+	// require that we get a call to UpsertDeploymentStatusUpdate
+	matchConfig := &matchDeploymentStatusUpdateConfig{
+		DeploymentID:      d.ID,
+		Status:            structs.DeploymentStatusFailed,
+		StatusDescription: structs.DeploymentStatusDescriptionFailedSLO,
+		Eval:              true,
+	}
+	matcher := matchDeploymentStatusUpdateRequest(matchConfig)
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(matcher)).Return(nil)
+
+	w.SetEnabled(true, m.state)
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := m.state.DeploymentByID(nil, d.ID)
+		if err != nil {
+			return false, err
+		}
+		if out.Status != structs.DeploymentStatusFailed {
+			return false, fmt.Errorf("got status %q", out.Status)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(err)
+	})
+	m.AssertCalled(t, "UpdateDeploymentStatus", mocker.MatchedBy(matcher))
+}
+
+func TestDeploymentWatcher_CheckSLOs(t *testing.T) {
+	ci.Parallel(t)
+
+	j := mock.Job()
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.Canary = 1
+	j.TaskGroups[0].Update.SLOQuery = "up"
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups["web"].AutoRevert = true
+	d.TaskGroups["web"].DesiredCanaries = 1
+	d.TaskGroups["web"].PlacedCanaries = []string{uuid.Generate()}
+
+	querier := &testSLOQuerier{healthy: true}
+	w := &deploymentWatcher{
+		d:            d,
+		j:            j,
+		sloQuerier:   querier,
+		sloCheckedAt: make(map[string]time.Time),
+		sloPassed:    make(map[string]bool),
+		logger:       testlog.HCLogger(t),
+		ctx:          context.Background(),
+	}
+	require.Equal(t, structs.DefaultUpdateStrategySLOInterval, w.sloTickInterval())
+	now := time.Now()
+
+	// The query is not evaluated before the canaries are healthy, which
+	// holds automatic promotion
+	fail, rollback := w.checkSLOs(now)
+	require.False(t, fail)
+	require.False(t, rollback)
+	require.Empty(t, w.sloCheckedAt)
+	require.True(t, w.sloPending("web"))
+
+	// A healthy query unblocks automatic promotion
+	d.TaskGroups["web"].HealthyAllocs = 1
+	fail, _ = w.checkSLOs(now)
+	require.False(t, fail)
+	require.False(t, w.sloPending("web"))
+
+	// The query is not evaluated again before its interval elapses
+	querier.healthy = false
+	fail, _ = w.checkSLOs(now.Add(time.Second))
+	require.False(t, fail)
+
+	// Unhealthy queries fail the deployment and roll back if auto revert is
+	// set
+	fail, rollback = w.checkSLOs(now.Add(structs.DefaultUpdateStrategySLOInterval))
+	require.True(t, fail)
+	require.True(t, rollback)
+
+	// Queries that can't be evaluated don't fail the deployment
+	querier.err = fmt.Errorf("connection refused")
+	fail, _ = w.checkSLOs(now.Add(2 * structs.DefaultUpdateStrategySLOInterval))
+	require.False(t, fail)
+
+	// Queries are ignored without a querier
+	w.sloQuerier = nil
+	require.Zero(t, w.sloTickInterval())
+}
+
 func TestWatcher_BatchAllocUpdates(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
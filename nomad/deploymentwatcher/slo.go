@@ -0,0 +1,111 @@
+package deploymentwatcher
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// SLOQuerier evaluates the SLO queries of task groups against an external
+// metrics endpoint.
+type SLOQuerier interface {
+	// Query evaluates the instant query and returns whether the SLO it
+	// expresses is met.
+	Query(ctx context.Context, query string) (healthy bool, err error)
+}
+
+// sloTickInterval returns the interval at which the watch loop checks whether
+// any SLO query is due, or zero if the deployment has no SLO queries to
+// evaluate.
+func (w *deploymentWatcher) sloTickInterval() time.Duration {
+	if w.sloQuerier == nil {
+		return 0
+	}
+
+	var interval time.Duration
+	for _, tg := range w.j.TaskGroups {
+		if tg.Update == nil || tg.Update.SLOQuery == "" {
+			continue
+		}
+		if i := sloInterval(tg.Update); interval == 0 || i < interval {
+			interval = i
+		}
+	}
+	return interval
+}
+
+// sloInterval returns the interval at which the SLO query of the update
+// strategy is evaluated.
+func sloInterval(u *structs.UpdateStrategy) time.Duration {
+	if u.SLOInterval == 0 {
+		return structs.DefaultUpdateStrategySLOInterval
+	}
+	return u.SLOInterval
+}
+
+// checkSLOs evaluates the SLO queries of the task groups whose canaries are
+// all healthy and awaiting promotion, if their interval has elapsed. It
+// returns whether the deployment should be failed because a query is
+// unhealthy and whether the job should be rolled back. Queries that can't be
+// evaluated, for example because the metrics endpoint is unreachable, are
+// retried on the next interval.
+func (w *deploymentWatcher) checkSLOs(now time.Time) (fail, rollback bool) {
+	d := w.getDeployment()
+	if d.Status != structs.DeploymentStatusRunning {
+		return false, false
+	}
+
+	for name, dstate := range d.TaskGroups {
+		if !sloCheckable(dstate) {
+			continue
+		}
+
+		tg := w.j.LookupTaskGroup(name)
+		if tg == nil || tg.Update == nil || tg.Update.SLOQuery == "" {
+			continue
+		}
+		if last, ok := w.sloCheckedAt[name]; ok && now.Sub(last) < sloInterval(tg.Update) {
+			continue
+		}
+		w.sloCheckedAt[name] = now
+
+		healthy, err := w.sloQuerier.Query(w.ctx, tg.Update.SLOQuery)
+		if err != nil {
+			w.logger.Warn("failed to evaluate SLO query", "task_group", name, "error", err)
+			continue
+		}
+		if healthy {
+			w.sloPassed[name] = true
+			continue
+		}
+
+		w.logger.Info("SLO query is unhealthy", "task_group", name, "query", tg.Update.SLOQuery)
+		fail = true
+		if dstate.AutoRevert {
+			rollback = true
+		}
+	}
+
+	return fail, rollback
+}
+
+// sloCheckable returns whether the SLO query of the task group is evaluated,
+// which is the case while all its canaries are placed, healthy and awaiting
+// promotion.
+func sloCheckable(dstate *structs.DeploymentState) bool {
+	return dstate.DesiredCanaries > 0 && !dstate.Promoted &&
+		len(dstate.PlacedCanaries) >= dstate.DesiredCanaries &&
+		dstate.HealthyAllocs >= dstate.DesiredCanaries
+}
+
+// sloPending returns whether automatic promotion of the task group has to
+// wait for its SLO query to be healthy at least once.
+func (w *deploymentWatcher) sloPending(name string) bool {
+	if w.sloQuerier == nil || w.sloPassed[name] {
+		return false
+	}
+
+	tg := w.j.LookupTaskGroup(name)
+	return tg != nil && tg.Update != nil && tg.Update.SLOQuery != ""
+}
@@ -1,6 +1,7 @@
 package deploymentwatcher
 
 import (
+	"context"
 	"reflect"
 	"strings"
 	"sync"
@@ -267,3 +268,13 @@ func matchDeploymentAllocHealthRequest(c *matchDeploymentAllocHealthRequestConfi
 		return true
 	}
 }
+
+// testSLOQuerier is an SLOQuerier returning a fixed result.
+type testSLOQuerier struct {
+	healthy bool
+	err     error
+}
+
+func (q *testSLOQuerier) Query(context.Context, string) (bool, error) {
+	return q.healthy, q.err
+}
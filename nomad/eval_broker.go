@@ -62,8 +62,9 @@ type EvalBroker struct {
 	// blocked tracks the blocked evaluations by JobID in a priority queue
 	blocked map[structs.NamespacedID]PendingEvaluations
 
-	// ready tracks the ready jobs by scheduler in a priority queue
-	ready map[string]PendingEvaluations
+	// ready tracks the ready jobs by scheduler in a priority queue per
+	// namespace
+	ready map[string]*readyQueue
 
 	// unack is a map of evalID to an un-acknowledged evaluation
 	unack map[string]*unackEval
@@ -116,6 +117,108 @@ type unackEval struct {
 // priority queue
 type PendingEvaluations []*structs.Evaluation
 
+// readyQueue holds the ready evaluations of a single scheduler. Evaluations
+// are kept in a priority queue per namespace and the namespaces whose next
+// evaluation has the highest priority are dequeued from in turn, so that a
+// namespace with a large backlog cannot starve the others.
+type readyQueue struct {
+	// namespaces is the priority queue of ready evaluations by namespace
+	namespaces map[string]PendingEvaluations
+
+	// lastDequeue tracks when each namespace with ready evaluations was last
+	// dequeued from, as a value of dequeues
+	lastDequeue map[string]uint64
+
+	// dequeues is the number of evaluations dequeued from the queue
+	dequeues uint64
+
+	// readySince tracks when each namespace last became ready, as a value
+	// of pushes, to break ties between namespaces in the order they queued
+	readySince map[string]uint64
+
+	// pushes is the number of namespaces that became ready in the queue
+	pushes uint64
+}
+
+// newReadyQueue returns an empty readyQueue.
+func newReadyQueue() *readyQueue {
+	return &readyQueue{
+		namespaces:  make(map[string]PendingEvaluations),
+		lastDequeue: make(map[string]uint64),
+		readySince:  make(map[string]uint64),
+	}
+}
+
+// Push adds an evaluation to the queue of its namespace.
+func (q *readyQueue) Push(eval *structs.Evaluation) {
+	pending, ok := q.namespaces[eval.Namespace]
+	if !ok {
+		pending = make([]*structs.Evaluation, 0, 16)
+		q.pushes++
+		q.readySince[eval.Namespace] = q.pushes
+	}
+	heap.Push(&pending, eval)
+	q.namespaces[eval.Namespace] = pending
+}
+
+// next returns the namespace that should be dequeued from next. Of the
+// namespaces whose next evaluation has the highest priority, this is the one
+// dequeued from least recently, falling back to the oldest evaluation and
+// then the namespace that has been ready the longest.
+func (q *readyQueue) next() string {
+	var nextNS string
+	var next *structs.Evaluation
+	for ns, pending := range q.namespaces {
+		eval := pending.Peek()
+		if eval == nil {
+			continue
+		}
+
+		switch {
+		case next == nil, eval.Priority > next.Priority:
+		case eval.Priority < next.Priority:
+			continue
+		case q.lastDequeue[ns] < q.lastDequeue[nextNS]:
+		case q.lastDequeue[ns] > q.lastDequeue[nextNS]:
+			continue
+		case eval.CreateIndex < next.CreateIndex:
+		case eval.CreateIndex > next.CreateIndex:
+			continue
+		case q.readySince[ns] < q.readySince[nextNS]:
+		default:
+			continue
+		}
+		nextNS, next = ns, eval
+	}
+	return nextNS
+}
+
+// Peek returns the evaluation that would be dequeued next.
+func (q *readyQueue) Peek() *structs.Evaluation {
+	return q.namespaces[q.next()].Peek()
+}
+
+// Pop removes and returns the evaluation that should be dequeued next.
+func (q *readyQueue) Pop() *structs.Evaluation {
+	ns := q.next()
+	pending, ok := q.namespaces[ns]
+	if !ok || len(pending) == 0 {
+		return nil
+	}
+
+	raw := heap.Pop(&pending)
+	q.dequeues++
+	if len(pending) > 0 {
+		q.namespaces[ns] = pending
+		q.lastDequeue[ns] = q.dequeues
+	} else {
+		delete(q.namespaces, ns)
+		delete(q.readySince, ns)
+		delete(q.lastDequeue, ns)
+	}
+	return raw.(*structs.Evaluation)
+}
+
 // NewEvalBroker creates a new evaluation broker. This is parameterized
 // with the timeout used for messages that are not acknowledged before we
 // assume a Nack and attempt to redeliver as well as the deliveryLimit
@@ -135,7 +238,7 @@ func NewEvalBroker(timeout, initialNackDelay, subsequentNackDelay time.Duration,
 		evals:                make(map[string]int),
 		jobEvals:             make(map[structs.NamespacedID]string),
 		blocked:              make(map[structs.NamespacedID]PendingEvaluations),
-		ready:                make(map[string]PendingEvaluations),
+		ready:                make(map[string]*readyQueue),
 		unack:                make(map[string]*unackEval),
 		waiting:              make(map[string]chan struct{}),
 		requeue:              make(map[string]*structs.Evaluation),
@@ -146,6 +249,7 @@ func NewEvalBroker(timeout, initialNackDelay, subsequentNackDelay time.Duration,
 		delayedEvalsUpdateCh: make(chan struct{}, 1),
 	}
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 
 	return b, nil
@@ -301,15 +405,15 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, queue string) {
 	// Find the pending by scheduler class
 	pending, ok := b.ready[queue]
 	if !ok {
-		pending = make([]*structs.Evaluation, 0, 16)
+		pending = newReadyQueue()
+		b.ready[queue] = pending
 		if _, ok := b.waiting[queue]; !ok {
 			b.waiting[queue] = make(chan struct{}, 1)
 		}
 	}
 
 	// Push onto the heap
-	heap.Push(&pending, eval)
-	b.ready[queue] = pending
+	pending.Push(eval)
 
 	// Update the stats
 	b.stats.TotalReady += 1
@@ -319,6 +423,7 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, queue string) {
 		b.stats.ByScheduler[queue] = bySched
 	}
 	bySched.Ready += 1
+	b.namespaceStats(eval.Namespace).Ready += 1
 
 	// Unblock any blocked dequeues
 	select {
@@ -429,10 +534,7 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 // This assumes locks are held and that this scheduler has work
 func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string, error) {
 	// Get the pending queue
-	pending := b.ready[sched]
-	raw := heap.Pop(&pending)
-	b.ready[sched] = pending
-	eval := raw.(*structs.Evaluation)
+	eval := b.ready[sched].Pop()
 
 	// Generate a UUID for the token
 	token := uuid.Generate()
@@ -458,6 +560,9 @@ func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string,
 	bySched := b.stats.ByScheduler[sched]
 	bySched.Ready -= 1
 	bySched.Unacked += 1
+	byNamespace := b.namespaceStats(eval.Namespace)
+	byNamespace.Ready -= 1
+	byNamespace.Unacked += 1
 
 	return eval, token, nil
 }
@@ -565,6 +670,8 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 	}
 	bySched := b.stats.ByScheduler[queue]
 	bySched.Unacked -= 1
+	b.namespaceStats(unack.Eval.Namespace).Unacked -= 1
+	b.pruneNamespaceStats(unack.Eval.Namespace)
 
 	// Cleanup
 	delete(b.unack, evalID)
@@ -625,6 +732,8 @@ func (b *EvalBroker) Nack(evalID, token string) error {
 	b.stats.TotalUnacked -= 1
 	bySched := b.stats.ByScheduler[unack.Eval.Type]
 	bySched.Unacked -= 1
+	b.namespaceStats(unack.Eval.Namespace).Unacked -= 1
+	b.pruneNamespaceStats(unack.Eval.Namespace)
 
 	// Check if we've hit the delivery limit, and re-enqueue
 	// in the failedQueue
@@ -727,10 +836,11 @@ func (b *EvalBroker) flush() {
 	b.stats.TotalWaiting = 0
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.evals = make(map[string]int)
 	b.jobEvals = make(map[structs.NamespacedID]string)
 	b.blocked = make(map[structs.NamespacedID]PendingEvaluations)
-	b.ready = make(map[string]PendingEvaluations)
+	b.ready = make(map[string]*readyQueue)
 	b.unack = make(map[string]*unackEval)
 	b.timeWait = make(map[string]*time.Timer)
 	b.delayHeap = delayheap.NewDelayHeap()
@@ -813,6 +923,7 @@ func (b *EvalBroker) Stats() *BrokerStats {
 	stats := new(BrokerStats)
 	stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	stats.ByScheduler = make(map[string]*SchedulerStats)
+	stats.ByNamespace = make(map[string]*NamespaceStats)
 
 	b.l.RLock()
 	defer b.l.RUnlock()
@@ -830,9 +941,34 @@ func (b *EvalBroker) Stats() *BrokerStats {
 		subStatCopy := *subStat
 		stats.ByScheduler[sched] = &subStatCopy
 	}
+	for ns, subStat := range b.stats.ByNamespace {
+		subStatCopy := *subStat
+		stats.ByNamespace[ns] = &subStatCopy
+	}
 	return stats
 }
 
+// namespaceStats returns the stats of the namespace, creating them if
+// necessary. This assumes locks are held.
+func (b *EvalBroker) namespaceStats(namespace string) *NamespaceStats {
+	byNamespace, ok := b.stats.ByNamespace[namespace]
+	if !ok {
+		byNamespace = &NamespaceStats{}
+		b.stats.ByNamespace[namespace] = byNamespace
+	}
+	return byNamespace
+}
+
+// pruneNamespaceStats removes the stats of the namespace once it has no ready
+// or unacknowledged evaluations, so that deleted namespaces are not tracked
+// forever. This assumes locks are held.
+func (b *EvalBroker) pruneNamespaceStats(namespace string) {
+	if byNamespace, ok := b.stats.ByNamespace[namespace]; ok &&
+		byNamespace.Ready == 0 && byNamespace.Unacked == 0 {
+		delete(b.stats.ByNamespace, namespace)
+	}
+}
+
 // EmitStats is used to export metrics about the broker while enabled
 func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 	timer, stop := helper.NewSafeTimer(period)
@@ -861,6 +997,11 @@ func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 				metrics.SetGauge([]string{"nomad", "broker", sched, "ready"}, float32(schedStats.Ready))
 				metrics.SetGauge([]string{"nomad", "broker", sched, "unacked"}, float32(schedStats.Unacked))
 			}
+			for ns, nsStats := range stats.ByNamespace {
+				labels := []metrics.Label{{Name: "namespace", Value: ns}}
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace_ready"}, float32(nsStats.Ready), labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace_unacked"}, float32(nsStats.Unacked), labels)
+			}
 
 		case <-stopCh:
 			return
@@ -876,6 +1017,7 @@ type BrokerStats struct {
 	TotalWaiting int
 	DelayedEvals map[string]*structs.Evaluation
 	ByScheduler  map[string]*SchedulerStats
+	ByNamespace  map[string]*NamespaceStats
 }
 
 // SchedulerStats returns the stats per scheduler
@@ -884,6 +1026,12 @@ type SchedulerStats struct {
 	Unacked int
 }
 
+// NamespaceStats returns the stats per namespace
+type NamespaceStats struct {
+	Ready   int
+	Unacked int
+}
+
 // Len is for the sorting interface
 func (p PendingEvaluations) Len() int {
 	return len(p)
@@ -828,6 +828,92 @@ func TestEvalBroker_Dequeue_FIFO(t *testing.T) {
 	}
 }
 
+// Ensure fairness between namespaces at fixed priority
+func TestEvalBroker_Dequeue_NamespaceFairness(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	// A large backlog of evaluations in a single namespace
+	for i := 0; i < 100; i++ {
+		eval := mock.Eval()
+		eval.Namespace = "batch"
+		eval.CreateIndex = uint64(i)
+		b.Enqueue(eval)
+	}
+
+	// Newer evaluations in other namespaces
+	other1 := mock.Eval()
+	other1.Namespace = "other"
+	other1.CreateIndex = 1000
+	b.Enqueue(other1)
+
+	other2 := mock.Eval()
+	other2.Namespace = "other"
+	other2.CreateIndex = 1001
+	b.Enqueue(other2)
+
+	high := mock.Eval()
+	high.Namespace = "batch"
+	high.Priority = 90
+	high.CreateIndex = 2000
+	b.Enqueue(high)
+
+	stats := b.Stats()
+	require.Equal(t, 101, stats.ByNamespace["batch"].Ready)
+	require.Equal(t, 2, stats.ByNamespace["other"].Ready)
+
+	// Higher priority evaluations are still dequeued first
+	out, _, err := b.Dequeue(defaultSched, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, high, out)
+
+	// The namespaces are then dequeued from in turn
+	expected := []string{"other", "batch", "other", "batch", "batch"}
+	for i, ns := range expected {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		require.NoError(t, err)
+		require.Equal(t, ns, out.Namespace, "dequeue %d", i)
+	}
+
+	stats = b.Stats()
+	require.Equal(t, 97, stats.ByNamespace["batch"].Ready)
+	require.Equal(t, 4, stats.ByNamespace["batch"].Unacked)
+	require.Equal(t, 0, stats.ByNamespace["other"].Ready)
+	require.Equal(t, 2, stats.ByNamespace["other"].Unacked)
+}
+
+// Ensure the stats of a namespace are removed once it has no evaluations
+func TestEvalBroker_NamespaceStats_Prune(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	eval := mock.Eval()
+	eval.Namespace = "other"
+	b.Enqueue(eval)
+
+	out, token, err := b.Dequeue(defaultSched, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, eval, out)
+
+	stats := b.Stats()
+	require.Equal(t, &NamespaceStats{Unacked: 1}, stats.ByNamespace["other"])
+
+	// The ready queue no longer tracks the namespace
+	b.l.Lock()
+	ready := b.ready[eval.Type]
+	require.NotContains(t, ready.namespaces, "other")
+	require.NotContains(t, ready.readySince, "other")
+	require.NotContains(t, ready.lastDequeue, "other")
+	b.l.Unlock()
+
+	require.NoError(t, b.Ack(eval.ID, token))
+
+	stats = b.Stats()
+	require.NotContains(t, stats.ByNamespace, "other")
+}
+
 // Ensure fairness between schedulers
 func TestEvalBroker_Dequeue_Fairness(t *testing.T) {
 	ci.Parallel(t)
@@ -2,12 +2,16 @@ package nomad
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"time"
 
+	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/go-msgpack/codec"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/stream"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -47,6 +51,31 @@ func (e *Event) stream(conn io.ReadWriteCloser) {
 		Topics:    args.Topics,
 		Index:     uint64(args.Index),
 		Namespace: args.Namespace,
+
+		Namespaces: args.Namespaces,
+		JobIDs:     args.JobIDs,
+		TaskGroups: args.TaskGroups,
+	}
+
+	// The filter is evaluated by the server so only the matching events are
+	// sent to the subscriber
+	if args.Filter != "" {
+		evaluator, err := bexpr.CreateEvaluator(args.Filter)
+		if err != nil {
+			handleJsonResultError(fmt.Errorf("failed to read filter expression: %v", err), helper.Int64ToPtr(400), encoder)
+			return
+		}
+		subReq.Filter = evaluator
+	}
+
+	// A durable consumer resumes the stream after the index of its cursor
+	if args.Consumer != "" {
+		cursor, code, err := e.consumerCursor(args.AuthToken, args.Consumer)
+		if err != nil {
+			handleJsonResultError(err, helper.Int64ToPtr(code), encoder)
+			return
+		}
+		subReq.ResumeIndex = cursor.Index
 	}
 
 	// Get the servers broker and subscribe
@@ -65,7 +94,10 @@ func (e *Event) stream(conn io.ReadWriteCloser) {
 	} else {
 		subscription, subErr = publisher.Subscribe(subReq)
 	}
-	if subErr != nil {
+	if subErr == stream.ErrResumeIndexLost {
+		handleJsonResultError(fmt.Errorf("event cursor %q has expired: %v", args.Consumer, subErr), helper.Int64ToPtr(400), encoder)
+		return
+	} else if subErr != nil {
 		handleJsonResultError(subErr, helper.Int64ToPtr(500), encoder)
 		return
 	}
@@ -147,6 +179,197 @@ OUTER:
 
 }
 
+// consumerCursor returns the cursor of the durable consumer with the given
+// name, along with the status code of the error if it can't be read.
+func (e *Event) consumerCursor(token, name string) (*structs.EventCursor, int64, error) {
+	// Reading the cursor requires operator read access.
+	rule, err := e.srv.ResolveToken(token)
+	if err != nil {
+		return nil, 500, err
+	} else if rule != nil && !rule.AllowOperatorRead() {
+		return nil, 403, structs.ErrPermissionDenied
+	}
+
+	cursor, err := e.srv.State().EventCursorByName(nil, name)
+	if err != nil {
+		return nil, 500, err
+	} else if cursor == nil {
+		return nil, 404, fmt.Errorf("event cursor %q not found", name)
+	}
+	return cursor, 0, nil
+}
+
+// UpsertCursor is used to create or update the cursor of a durable consumer
+// of the event stream.
+func (e *Event) UpsertCursor(args *structs.EventCursorUpsertRequest, reply *structs.GenericResponse) error {
+	if done, err := e.srv.forward("Event.UpsertCursor", args, args, reply); done {
+		return err
+	}
+
+	// This action requires operator write access.
+	rule, err := e.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.Cursor == nil {
+		return structs.NewErrRPCCodedf(400, "missing event cursor")
+	}
+	if err := args.Cursor.Validate(); err != nil {
+		return structs.NewErrRPCCodedf(400, "%v", err)
+	}
+
+	_, index, err := e.srv.raftApply(structs.EventCursorUpsertRequestType, args)
+	if err != nil {
+		e.srv.logger.Error("upserting event cursor failed", "error", err)
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// DeleteCursor is used to delete the cursor of a durable consumer of the
+// event stream.
+func (e *Event) DeleteCursor(args *structs.EventCursorDeleteRequest, reply *structs.GenericResponse) error {
+	if done, err := e.srv.forward("Event.DeleteCursor", args, args, reply); done {
+		return err
+	}
+
+	// This action requires operator write access.
+	rule, err := e.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	cursor, err := e.srv.State().EventCursorByName(nil, args.Name)
+	if err != nil {
+		return err
+	} else if cursor == nil {
+		return structs.NewErrRPCCodedf(404, "event cursor %q not found", args.Name)
+	}
+
+	_, index, err := e.srv.raftApply(structs.EventCursorDeleteRequestType, args)
+	if err != nil {
+		e.srv.logger.Error("deleting event cursor failed", "error", err)
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// GetCursor is used to read the cursor of a durable consumer of the event
+// stream, along with how far it lags behind the published events.
+func (e *Event) GetCursor(args *structs.EventCursorSpecificRequest, reply *structs.EventCursorResponse) error {
+	if done, err := e.srv.forward("Event.GetCursor", args, args, reply); done {
+		return err
+	}
+
+	// This action requires operator read access.
+	rule, err := e.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			cursor, err := state.EventCursorByName(ws, args.Name)
+			if err != nil {
+				return err
+			}
+
+			reply.Cursor = nil
+			if cursor != nil {
+				reply.Cursor = e.cursorStatus(cursor)
+				reply.Index = cursor.ModifyIndex
+			} else {
+				index, err := state.Index("event_cursors")
+				if err != nil {
+					return err
+				}
+				reply.Index = helper.Uint64Max(1, index)
+			}
+			e.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		},
+	}
+	return e.srv.blockingRPC(&opts)
+}
+
+// ListCursors is used to list the cursors of the durable consumers of the
+// event stream, along with how far they lag behind the published events.
+func (e *Event) ListCursors(args *structs.EventCursorListRequest, reply *structs.EventCursorListResponse) error {
+	if done, err := e.srv.forward("Event.ListCursors", args, args, reply); done {
+		return err
+	}
+
+	// This action requires operator read access.
+	rule, err := e.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			iter, err := state.EventCursors(ws)
+			if err != nil {
+				return err
+			}
+
+			reply.Cursors = []*structs.EventCursorStatus{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				reply.Cursors = append(reply.Cursors, e.cursorStatus(raw.(*structs.EventCursor)))
+			}
+
+			index, err := state.Index("event_cursors")
+			if err != nil {
+				return err
+			}
+			reply.Index = helper.Uint64Max(1, index)
+			e.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		},
+	}
+	return e.srv.blockingRPC(&opts)
+}
+
+// cursorStatus returns the status of the cursor against the events published
+// by the event broker of the server. The lag is only reported when the event
+// broker is enabled. The broker is read from the state store of the server,
+// as the snapshots blocking queries run against have none.
+func (e *Event) cursorStatus(cursor *structs.EventCursor) *structs.EventCursorStatus {
+	status := &structs.EventCursorStatus{
+		Name:        cursor.Name,
+		Index:       cursor.Index,
+		CreateIndex: cursor.CreateIndex,
+		ModifyIndex: cursor.ModifyIndex,
+	}
+
+	broker, err := e.srv.State().EventBroker()
+	if err != nil {
+		return status
+	}
+	status.LatestIndex = broker.LatestIndex()
+	status.Expired = cursor.Index < broker.LostIndex()
+	if status.LatestIndex > cursor.Index {
+		status.Lag = status.LatestIndex - cursor.Index
+	}
+	return status
+}
+
 func (e *Event) forwardStreamingRPC(region string, method string, args interface{}, in io.ReadWriteCloser) error {
 	server, err := e.srv.findRegionServer(region)
 	if err != nil {
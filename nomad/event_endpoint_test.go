@@ -15,6 +15,7 @@ import (
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/stream"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -125,6 +126,74 @@ OUTER:
 	}
 }
 
+// TestEventStream_Filter asserts the server only sends the events matching the
+// filter expression of the request
+func TestEventStream_Filter(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.EnableEventBroker = true
+	})
+	defer cleanupS1()
+
+	handler, err := s1.StreamingRpcHandler("Event.Stream")
+	require.NoError(t, err)
+
+	subscribe := func(filter string) (*codec.Decoder, func()) {
+		p1, p2 := net.Pipe()
+		go handler(p2)
+
+		req := structs.EventStreamRequest{
+			Topics: map[structs.Topic][]string{"*": {"*"}},
+			QueryOptions: structs.QueryOptions{
+				Region: s1.Region(),
+				Filter: filter,
+			},
+		}
+		require.NoError(t, codec.NewEncoder(p1, structs.MsgpackHandle).Encode(req))
+		return codec.NewDecoder(p1, structs.MsgpackHandle), func() {
+			p1.Close()
+			p2.Close()
+		}
+	}
+
+	// Invalid filters are rejected
+	decoder, cleanup := subscribe(`Key ==`)
+	defer cleanup()
+	var msg structs.EventStreamWrapper
+	require.NoError(t, decoder.Decode(&msg))
+	require.NotNil(t, msg.Error)
+	require.Equal(t, int64(400), *msg.Error.Code)
+	require.Contains(t, msg.Error.Error(), "failed to read filter expression")
+
+	decoder, cleanup = subscribe(`Key == "two"`)
+	defer cleanup()
+
+	publisher, err := s1.State().EventBroker()
+	require.NoError(t, err)
+	node := mock.Node()
+	publisher.Publish(&structs.Events{Index: uint64(1), Events: []structs.Event{{Topic: "test", Key: "one", Payload: node}}})
+	publisher.Publish(&structs.Events{Index: uint64(2), Events: []structs.Event{{Topic: "test", Key: "two", Payload: node}}})
+
+	for {
+		var msg structs.EventStreamWrapper
+		require.NoError(t, decoder.Decode(&msg))
+		require.Nil(t, msg.Error)
+
+		// ignore heartbeat
+		if bytes.Equal(msg.Event.Data, stream.JsonHeartbeat.Data) {
+			continue
+		}
+
+		var events structs.Events
+		require.NoError(t, json.Unmarshal(msg.Event.Data, &events))
+		require.Equal(t, uint64(2), events.Index)
+		require.Len(t, events.Events, 1)
+		require.Equal(t, "two", events.Events[0].Key)
+		break
+	}
+}
+
 // TestEventStream_StreamErr asserts an error is returned when an event publisher
 // closes its subscriptions
 func TestEventStream_StreamErr(t *testing.T) {
@@ -625,3 +694,193 @@ OUTER:
 		}
 	}
 }
+
+// TestEventStream_Consumer asserts a durable consumer resumes the stream after
+// the index of its cursor
+func TestEventStream_Consumer(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.EnableEventBroker = true
+	})
+	defer cleanupS1()
+	rpcCodec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	publisher, err := s1.State().EventBroker()
+	require.NoError(t, err)
+	node := mock.Node()
+	for i := 1000; i <= 1002; i++ {
+		publisher.Publish(&structs.Events{Index: uint64(i), Events: []structs.Event{{Topic: "test", Index: uint64(i), Payload: node}}})
+	}
+
+	upsertReq := structs.EventCursorUpsertRequest{
+		Cursor:       &structs.EventCursor{Name: "consumer", Index: 1000},
+		WriteRequest: structs.WriteRequest{Region: s1.Region()},
+	}
+	var upsertResp structs.GenericResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(rpcCodec, "Event.UpsertCursor", &upsertReq, &upsertResp))
+
+	streamConsumer := func(consumer string) (<-chan *structs.EventStreamWrapper, func()) {
+		handler, err := s1.StreamingRpcHandler("Event.Stream")
+		require.NoError(t, err)
+
+		p1, p2 := net.Pipe()
+		go handler(p2)
+
+		req := structs.EventStreamRequest{
+			Topics:       map[structs.Topic][]string{"test": {"*"}},
+			Consumer:     consumer,
+			QueryOptions: structs.QueryOptions{Region: s1.Region()},
+		}
+		encoder := codec.NewEncoder(p1, structs.MsgpackHandle)
+		require.NoError(t, encoder.Encode(req))
+
+		streamMsg := make(chan *structs.EventStreamWrapper, 10)
+		go func() {
+			decoder := codec.NewDecoder(p1, structs.MsgpackHandle)
+			for {
+				var msg structs.EventStreamWrapper
+				if err := decoder.Decode(&msg); err != nil {
+					return
+				}
+				streamMsg <- &msg
+			}
+		}()
+		return streamMsg, func() {
+			p1.Close()
+			p2.Close()
+		}
+	}
+
+	nextMsg := func(ch <-chan *structs.EventStreamWrapper) *structs.EventStreamWrapper {
+		for {
+			select {
+			case msg := <-ch:
+				if msg.Error == nil && bytes.Equal(msg.Event.Data, stream.JsonHeartbeat.Data) {
+					continue
+				}
+				return msg
+			case <-time.After(5 * time.Second):
+				t.Fatal("timeout waiting for event stream")
+			}
+		}
+	}
+
+	// The stream starts after the index of the cursor
+	streamMsg, cleanup := streamConsumer("consumer")
+	defer cleanup()
+	for _, index := range []uint64{1001, 1002} {
+		msg := nextMsg(streamMsg)
+		require.Nil(t, msg.Error)
+
+		var events structs.Events
+		require.NoError(t, json.Unmarshal(msg.Event.Data, &events))
+		require.Equal(t, index, events.Index)
+	}
+
+	// Streaming from a missing cursor fails
+	missingMsg, cleanupMissing := streamConsumer("missing")
+	defer cleanupMissing()
+	msg := nextMsg(missingMsg)
+	require.NotNil(t, msg.Error)
+	require.EqualValues(t, 404, *msg.Error.Code)
+	require.Contains(t, msg.Error.Error(), `event cursor "missing" not found`)
+}
+
+func TestEvent_Cursors(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.EnableEventBroker = true
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Updating a cursor requires operator write access
+	readToken := mock.CreatePolicyAndToken(t, s1.fsm.State(), 1001, "test-operator-read",
+		`operator { policy = "read" }`)
+	upsertReq := structs.EventCursorUpsertRequest{
+		Cursor: &structs.EventCursor{Name: "consumer", Index: 10},
+		WriteRequest: structs.WriteRequest{
+			Region:    s1.Region(),
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var upsertResp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "Event.UpsertCursor", &upsertReq, &upsertResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	upsertReq.AuthToken = root.SecretID
+	upsertReq.Cursor.Name = "invalid name"
+	err = msgpackrpc.CallWithCodec(codec, "Event.UpsertCursor", &upsertReq, &upsertResp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid event cursor name")
+
+	upsertReq.Cursor.Name = "consumer"
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Event.UpsertCursor", &upsertReq, &upsertResp))
+	require.NotZero(t, upsertResp.Index)
+
+	// Publish events following the cursor
+	node := mock.Node()
+	require.NoError(t, s1.fsm.State().UpsertNode(structs.NodeRegisterRequestType, 1002, node))
+
+	// Reading the cursor reports its lag behind the published events
+	getReq := structs.EventCursorSpecificRequest{
+		Name: "consumer",
+		QueryOptions: structs.QueryOptions{
+			Region:    s1.Region(),
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var getResp structs.EventCursorResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Event.GetCursor", &getReq, &getResp))
+	require.NotNil(t, getResp.Cursor)
+	require.EqualValues(t, 10, getResp.Cursor.Index)
+	require.Equal(t, upsertResp.Index, getResp.Index)
+
+	publisher, err := s1.State().EventBroker()
+	require.NoError(t, err)
+	require.Equal(t, publisher.LatestIndex(), getResp.Cursor.LatestIndex)
+	require.GreaterOrEqual(t, getResp.Cursor.LatestIndex, uint64(1002))
+	require.Equal(t, getResp.Cursor.LatestIndex-10, getResp.Cursor.Lag)
+
+	listReq := structs.EventCursorListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    s1.Region(),
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var listResp structs.EventCursorListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Event.ListCursors", &listReq, &listResp))
+	require.Len(t, listResp.Cursors, 1)
+	require.Equal(t, "consumer", listResp.Cursors[0].Name)
+
+	// Reading requires operator read access
+	listReq.AuthToken = uuid.Generate()
+	err = msgpackrpc.CallWithCodec(codec, "Event.ListCursors", &listReq, &listResp)
+	require.Error(t, err)
+
+	// Deleting requires operator write access, and the cursor must exist
+	deleteReq := structs.EventCursorDeleteRequest{
+		Name: "consumer",
+		WriteRequest: structs.WriteRequest{
+			Region:    s1.Region(),
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var deleteResp structs.GenericResponse
+	err = msgpackrpc.CallWithCodec(codec, "Event.DeleteCursor", &deleteReq, &deleteResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	deleteReq.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Event.DeleteCursor", &deleteReq, &deleteResp))
+	err = msgpackrpc.CallWithCodec(codec, "Event.DeleteCursor", &deleteReq, &deleteResp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not found")
+
+	getReq.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Event.GetCursor", &getReq, &getResp))
+	require.Nil(t, getResp.Cursor)
+}
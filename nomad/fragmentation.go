@@ -0,0 +1,276 @@
+package nomad
+
+import (
+	"sort"
+
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// fragmentationShapes is the number of most common allocation shapes the
+	// free capacity of the nodes is measured against.
+	fragmentationShapes = 5
+
+	// fragmentationMigrations is the maximum number of candidate migrations
+	// suggested for each datacenter.
+	fragmentationMigrations = 10
+)
+
+// fragmentationNode is the free capacity of a node eligible for scheduling.
+type fragmentationNode struct {
+	id       string
+	cpu      int64
+	memoryMB int64
+
+	// usedCPU is the CPU allocated to the movable allocations
+	usedCPU int64
+
+	// allocs are the running service and batch allocations, which could be
+	// moved to other nodes
+	allocs []*structs.Allocation
+}
+
+// fragmentationReport computes how fragmented the free capacity of the nodes
+// in each datacenter is. Capacity is stranded when it can't fit an allocation
+// of any of the most common shapes. When suggest is set, candidate migrations
+// that would free up whole nodes are computed as well. Only CPU and memory are
+// considered, so the migrations don't account for constraints, ports or
+// devices and must be reviewed before being acted on.
+func fragmentationReport(snap *state.StateSnapshot, suggest bool) (*structs.SchedulerFragmentationResponse, error) {
+	iter, err := snap.Nodes(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	shapeCounts := make(map[structs.SchedulerAllocShape]int)
+	byDC := make(map[string][]*fragmentationNode)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		allocs, err := snap.AllocsByNode(nil, node.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		free := node.ComparableResources()
+		free.Subtract(node.ComparableReservedResources())
+		fn := &fragmentationNode{id: node.ID}
+		for _, alloc := range allocs {
+			if alloc.TerminalStatus() {
+				continue
+			}
+			cr := alloc.ComparableResources()
+			free.Subtract(cr)
+
+			if !isMovableAlloc(alloc) {
+				continue
+			}
+			shape := structs.SchedulerAllocShape{
+				CPU:      cr.Flattened.Cpu.CpuShares,
+				MemoryMB: cr.Flattened.Memory.MemoryMB,
+			}
+			shapeCounts[shape]++
+			fn.usedCPU += shape.CPU
+			fn.allocs = append(fn.allocs, alloc)
+		}
+
+		// Only the nodes eligible for scheduling have usable capacity
+		if !node.Ready() {
+			continue
+		}
+		fn.cpu = clampZero(free.Flattened.Cpu.CpuShares)
+		fn.memoryMB = clampZero(free.Flattened.Memory.MemoryMB)
+		byDC[node.Datacenter] = append(byDC[node.Datacenter], fn)
+	}
+
+	reply := &structs.SchedulerFragmentationResponse{
+		Shapes:      commonShapes(shapeCounts, fragmentationShapes),
+		Datacenters: make(map[string]*structs.SchedulerDatacenterFragmentation, len(byDC)),
+	}
+	for dc, nodes := range byDC {
+		report := &structs.SchedulerDatacenterFragmentation{
+			Nodes:      len(nodes),
+			Placements: make([]int, len(reply.Shapes)),
+		}
+		for _, n := range nodes {
+			report.FreeCPU += n.cpu
+			report.FreeMemoryMB += n.memoryMB
+
+			fits := false
+			for i, shape := range reply.Shapes {
+				if count := shapeFitCount(shape, n.cpu, n.memoryMB); count > 0 {
+					report.Placements[i] += count
+					fits = true
+				}
+			}
+			if !fits && len(reply.Shapes) > 0 {
+				report.StrandedCPU += n.cpu
+				report.StrandedMemoryMB += n.memoryMB
+			}
+		}
+		if suggest {
+			report.Migrations = suggestMigrations(nodes, fragmentationMigrations)
+		}
+		reply.Datacenters[dc] = report
+	}
+
+	return reply, nil
+}
+
+// isMovableAlloc returns whether the allocation could be rescheduled on
+// another node. System allocations run on every node and are left in place.
+func isMovableAlloc(alloc *structs.Allocation) bool {
+	if alloc.Job == nil {
+		return false
+	}
+	switch alloc.Job.Type {
+	case structs.JobTypeService, structs.JobTypeBatch:
+		return true
+	}
+	return false
+}
+
+// commonShapes returns the n shapes with the most allocations.
+func commonShapes(counts map[structs.SchedulerAllocShape]int, n int) []*structs.SchedulerAllocShape {
+	shapes := make([]*structs.SchedulerAllocShape, 0, len(counts))
+	for shape, count := range counts {
+		if shape.CPU == 0 && shape.MemoryMB == 0 {
+			continue
+		}
+		shape := shape
+		shape.Count = count
+		shapes = append(shapes, &shape)
+	}
+
+	sort.Slice(shapes, func(i, j int) bool {
+		if shapes[i].Count != shapes[j].Count {
+			return shapes[i].Count > shapes[j].Count
+		}
+		if shapes[i].CPU != shapes[j].CPU {
+			return shapes[i].CPU < shapes[j].CPU
+		}
+		return shapes[i].MemoryMB < shapes[j].MemoryMB
+	})
+	if len(shapes) > n {
+		shapes = shapes[:n]
+	}
+	return shapes
+}
+
+// shapeFitCount returns how many allocations of the shape fit in the given
+// free CPU and memory.
+func shapeFitCount(shape *structs.SchedulerAllocShape, cpu, memoryMB int64) int {
+	if !shape.Fits(cpu, memoryMB) {
+		return 0
+	}
+
+	count := int64(-1)
+	if shape.CPU > 0 {
+		count = cpu / shape.CPU
+	}
+	if shape.MemoryMB > 0 {
+		if byMem := memoryMB / shape.MemoryMB; count < 0 || byMem < count {
+			count = byMem
+		}
+	}
+	return int(count)
+}
+
+// suggestMigrations greedily looks for the least loaded nodes whose movable
+// allocations all fit in the free capacity of the other nodes, and returns at
+// most limit migrations. Nodes receiving allocations are never freed up
+// themselves.
+func suggestMigrations(nodes []*fragmentationNode, limit int) []*structs.SchedulerMigration {
+	sorted := make([]*fragmentationNode, len(nodes))
+	copy(sorted, nodes)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].usedCPU != sorted[j].usedCPU {
+			return sorted[i].usedCPU < sorted[j].usedCPU
+		}
+		return sorted[i].id < sorted[j].id
+	})
+
+	// Track the free capacity as migrations are suggested
+	type capacity struct{ cpu, memoryMB int64 }
+	free := make(map[string]*capacity, len(sorted))
+	for _, n := range sorted {
+		free[n.id] = &capacity{cpu: n.cpu, memoryMB: n.memoryMB}
+	}
+	freed := make(map[string]bool)
+	targets := make(map[string]bool)
+
+	var migrations []*structs.SchedulerMigration
+	for _, candidate := range sorted {
+		if len(migrations) == limit {
+			break
+		}
+		if len(candidate.allocs) == 0 || targets[candidate.id] {
+			continue
+		}
+
+		// Place the largest allocations first
+		allocs := make([]*structs.Allocation, len(candidate.allocs))
+		copy(allocs, candidate.allocs)
+		sort.Slice(allocs, func(i, j int) bool {
+			return allocs[i].ComparableResources().Flattened.Cpu.CpuShares >
+				allocs[j].ComparableResources().Flattened.Cpu.CpuShares
+		})
+
+		// Pack onto the most loaded nodes first, so the least loaded ones
+		// remain candidates
+		placed := make(map[string]*capacity)
+		migration := &structs.SchedulerMigration{NodeID: candidate.id}
+		for _, alloc := range allocs {
+			cr := alloc.ComparableResources()
+			cpu, mem := cr.Flattened.Cpu.CpuShares, cr.Flattened.Memory.MemoryMB
+
+			target := ""
+			for i := len(sorted) - 1; i >= 0; i-- {
+				n := sorted[i]
+				if n.id == candidate.id || freed[n.id] {
+					continue
+				}
+				c, ok := placed[n.id]
+				if !ok {
+					c = &capacity{}
+				}
+				if free[n.id].cpu-c.cpu >= cpu && free[n.id].memoryMB-c.memoryMB >= mem {
+					c.cpu += cpu
+					c.memoryMB += mem
+					placed[n.id] = c
+					target = n.id
+					break
+				}
+			}
+			if target == "" {
+				migration = nil
+				break
+			}
+			migration.Allocs = append(migration.Allocs, &structs.SchedulerMigrationAlloc{
+				ID:           alloc.ID,
+				Namespace:    alloc.Namespace,
+				JobID:        alloc.JobID,
+				TargetNodeID: target,
+			})
+		}
+		if migration == nil {
+			continue
+		}
+
+		for id, c := range placed {
+			free[id].cpu -= c.cpu
+			free[id].memoryMB -= c.memoryMB
+			targets[id] = true
+		}
+		freed[candidate.id] = true
+		migrations = append(migrations, migration)
+	}
+	return migrations
+}
+
+func clampZero(v int64) int64 {
+	if v < 0 {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,86 @@
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFragmentationReport(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	newNode := func(dc string, cpu, memoryMB int64) *structs.Node {
+		node := mock.Node()
+		node.Datacenter = dc
+		node.NodeResources.Cpu.CpuShares = cpu
+		node.NodeResources.Memory.MemoryMB = memoryMB
+		node.Reserved = nil
+		node.ReservedResources = nil
+		return node
+	}
+	newAlloc := func(node *structs.Node) *structs.Allocation {
+		alloc := mock.Alloc()
+		alloc.NodeID = node.ID
+		return alloc
+	}
+
+	// full has CPU left but no memory for another allocation
+	full := newNode("dc1", 1000, 1024)
+	// partial and empty have room for more allocations
+	partial := newNode("dc1", 2000, 2048)
+	empty := newNode("dc1", 2000, 2048)
+	// down isn't eligible for scheduling
+	down := newNode("dc2", 2000, 2048)
+	down.Status = structs.NodeStatusDown
+
+	for i, node := range []*structs.Node{full, partial, empty, down} {
+		require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(1000+i), node))
+	}
+
+	stopped := newAlloc(partial)
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	system := newAlloc(empty)
+	system.Job.Type = structs.JobTypeSystem
+	system.AllocatedResources.Tasks["web"].Cpu.CpuShares = 100
+	allocs := []*structs.Allocation{
+		newAlloc(full), newAlloc(full), newAlloc(partial), stopped, system,
+	}
+	require.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1010, allocs))
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+
+	// Without suggestions
+	report, err := fragmentationReport(snap, false)
+	require.NoError(t, err)
+	require.Equal(t, []*structs.SchedulerAllocShape{{CPU: 500, MemoryMB: 256, Count: 3}}, report.Shapes)
+	require.Len(t, report.Datacenters, 1)
+	require.Equal(t, &structs.SchedulerDatacenterFragmentation{
+		Nodes:            3,
+		FreeCPU:          0 + 1500 + 1900,
+		FreeMemoryMB:     512 + 1792 + 1792,
+		StrandedCPU:      0,
+		StrandedMemoryMB: 512,
+		Placements:       []int{3 + 3},
+	}, report.Datacenters["dc1"])
+
+	// With suggestions the allocations of full and partial both fit on empty
+	report, err = fragmentationReport(snap, true)
+	require.NoError(t, err)
+	migrations := report.Datacenters["dc1"].Migrations
+	require.Len(t, migrations, 2)
+	require.Equal(t, partial.ID, migrations[0].NodeID)
+	require.Len(t, migrations[0].Allocs, 1)
+	require.Equal(t, allocs[2].ID, migrations[0].Allocs[0].ID)
+	require.Equal(t, empty.ID, migrations[0].Allocs[0].TargetNodeID)
+	require.Equal(t, full.ID, migrations[1].NodeID)
+	require.Len(t, migrations[1].Allocs, 2)
+	for _, alloc := range migrations[1].Allocs {
+		require.Equal(t, empty.ID, alloc.TargetNodeID)
+	}
+}
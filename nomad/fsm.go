@@ -54,6 +54,10 @@ const (
 	CSIVolumeSnapshot                    SnapshotType = 18
 	ScalingEventsSnapshot                SnapshotType = 19
 	EventSinkSnapshot                    SnapshotType = 20
+	ScheduledScalingSnapshot             SnapshotType = 21
+	PlanResultsSnapshot                  SnapshotType = 22
+	WorkloadIdentityKeySnapshot          SnapshotType = 23
+	EventCursorSnapshot                  SnapshotType = 24
 	// Namespace appliers were moved from enterprise and therefore start at 64
 	NamespaceSnapshot SnapshotType = 64
 )
@@ -306,6 +310,26 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyOneTimeTokenDelete(msgType, buf[1:], log.Index)
 	case structs.OneTimeTokenExpireRequestType:
 		return n.applyOneTimeTokenExpire(msgType, buf[1:], log.Index)
+	case structs.ScheduledScalingUpsertRequestType:
+		return n.applyScheduledScalingUpsert(msgType, buf[1:], log.Index)
+	case structs.ScheduledScalingDeleteRequestType:
+		return n.applyScheduledScalingDelete(msgType, buf[1:], log.Index)
+	case structs.JobVersionTagRequestType:
+		return n.applyJobVersionTag(buf[1:], log.Index)
+	case structs.JobBundleRegisterRequestType:
+		return n.applyJobBundleRegister(msgType, buf[1:], log.Index)
+	case structs.JobMoveRequestType:
+		return n.applyJobMove(msgType, buf[1:], log.Index)
+	case structs.ScalingPolicyUpsertRequestType:
+		return n.applyScalingPolicyUpsert(buf[1:], log.Index)
+	case structs.ScalingPolicyDeleteRequestType:
+		return n.applyScalingPolicyDelete(buf[1:], log.Index)
+	case structs.WorkloadIdentityKeysUpsertRequestType:
+		return n.applyWorkloadIdentityKeysUpsert(msgType, buf[1:], log.Index)
+	case structs.EventCursorUpsertRequestType:
+		return n.applyEventCursorUpsert(msgType, buf[1:], log.Index)
+	case structs.EventCursorDeleteRequestType:
+		return n.applyEventCursorDelete(msgType, buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -512,7 +536,17 @@ func (n *nomadFSM) applyUpsertJob(msgType structs.MessageType, buf []byte, index
 	 */
 	req.Job.Canonicalize()
 
-	if err := n.state.UpsertJob(msgType, index, req.Job); err != nil {
+	// Registrations with an idempotency token are checked against the
+	// versions of the job in the same transaction they are applied in, so
+	// that concurrent retries are only registered once
+	if req.IdempotencyToken != "" && req.IdempotencyToken == req.Job.RegisterIdempotencyToken {
+		if err := n.state.UpsertJobIdempotent(msgType, index, req.Job); err != nil {
+			if _, ok := err.(*structs.JobRegisterIdempotentError); !ok {
+				n.logger.Error("UpsertJobIdempotent failed", "error", err)
+			}
+			return err
+		}
+	} else if err := n.state.UpsertJob(msgType, index, req.Job); err != nil {
 		n.logger.Error("UpsertJob failed", "error", err)
 		return err
 	}
@@ -669,6 +703,82 @@ func (n *nomadFSM) applyBatchDeregisterJob(msgType structs.MessageType, buf []by
 	return nil
 }
 
+// applyJobBundleRegister is used to register a bundle of jobs and their
+// evaluations in a single transaction.
+func (n *nomadFSM) applyJobBundleRegister(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "bundle_register_job"}, time.Now())
+	var req structs.JobBundleRegisterRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	registered := make(map[structs.NamespacedID]struct{}, len(req.Jobs))
+	for _, job := range req.Jobs {
+		job.Canonicalize()
+		registered[job.NamespacedID()] = struct{}{}
+	}
+
+	// Evaluations of jobs registered by the bundle are for this index, the
+	// others are for jobs whose spec didn't change.
+	for _, eval := range req.Evals {
+		if _, ok := registered[structs.NamespacedID{ID: eval.JobID, Namespace: eval.Namespace}]; ok {
+			eval.JobModifyIndex = index
+		}
+	}
+
+	// Perform all store updates atomically so that the jobs of the bundle
+	// are either all registered or none are.
+	err := n.state.WithWriteTransaction(msgType, index, func(tx state.Txn) error {
+		for _, job := range req.Jobs {
+			if err := n.state.UpsertJobTxn(index, job, tx); err != nil {
+				n.logger.Error("UpsertJob failed", "job", job.NamespacedID(), "bundle_id", req.BundleID, "error", err)
+				return err
+			}
+		}
+
+		if err := n.state.UpsertEvalsTxn(index, req.Evals, tx); err != nil {
+			n.logger.Error("UpsertEvals failed", "error", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// perform the side effects outside the transactions
+	for _, job := range req.Jobs {
+		if err := n.periodicDispatcher.Add(job); err != nil {
+			n.logger.Error("periodicDispatcher.Add failed", "error", err)
+			return fmt.Errorf("failed adding job to periodic dispatcher: %v", err)
+		}
+
+		// Record the insertion time of active periodic jobs as a launch, as
+		// is done for jobs registered individually.
+		if job.IsPeriodicActive() {
+			prevLaunch, err := n.state.PeriodicLaunchByID(nil, job.Namespace, job.ID)
+			if err != nil {
+				n.logger.Error("PeriodicLaunchByID failed", "error", err)
+				return err
+			}
+			if prevLaunch == nil {
+				launch := &structs.PeriodicLaunch{
+					ID:        job.ID,
+					Namespace: job.Namespace,
+					Launch:    time.Now(),
+				}
+				if err := n.state.UpsertPeriodicLaunch(index, launch); err != nil {
+					n.logger.Error("UpsertPeriodicLaunch failed", "error", err)
+					return err
+				}
+			}
+		}
+	}
+	n.handleUpsertedEvals(req.Evals)
+	return nil
+}
+
 // handleJobDeregister is used to deregister a job. Leaves error logging up to
 // caller.
 func (n *nomadFSM) handleJobDeregister(index uint64, jobID, namespace string, purge bool, noShutdownDelay bool, tx state.Txn) error {
@@ -1097,6 +1207,58 @@ func (n *nomadFSM) applyJobStability(buf []byte, index uint64) interface{} {
 	return nil
 }
 
+// applyJobVersionTag is used to set or remove the tag of a job version
+func (n *nomadFSM) applyJobVersionTag(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_job_version_tag"}, time.Now())
+	var req structs.JobVersionTagRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateJobVersionTag(index, req.Namespace, req.JobID, req.JobVersion, req.Tag); err != nil {
+		n.logger.Error("UpdateJobVersionTag failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyJobMove is used to move a job to a different namespace or ID, along
+// with the evaluation of the moved job
+func (n *nomadFSM) applyJobMove(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_job_move"}, time.Now())
+	var req structs.JobMoveRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	err := n.state.WithWriteTransaction(msgType, index, func(tx state.Txn) error {
+		if err := n.state.MoveJobTxn(index, req.Namespace, req.JobID, req.NewNamespace, req.NewJobID, tx); err != nil {
+			n.logger.Error("MoveJob failed", "job", req.JobID, "namespace", req.Namespace, "error", err)
+			return err
+		}
+
+		if req.Eval != nil {
+			if err := n.state.UpsertEvalsTxn(index, []*structs.Evaluation{req.Eval}, tx); err != nil {
+				n.logger.Error("UpsertEvals failed", "error", err)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Blocked evaluations of the job were cancelled by the move
+	n.blockedEvals.Untrack(req.JobID, req.Namespace)
+
+	if req.Eval != nil {
+		n.handleUpsertedEval(req.Eval)
+	}
+	return nil
+}
+
 // applyACLPolicyUpsert is used to upsert a set of policies
 func (n *nomadFSM) applyACLPolicyUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_policy_upsert"}, time.Now())
@@ -1617,6 +1779,46 @@ func (n *nomadFSM) Restore(old io.ReadCloser) error {
 				return err
 			}
 
+		case ScheduledScalingSnapshot:
+			action := new(structs.ScheduledScalingAction)
+			if err := dec.Decode(action); err != nil {
+				return err
+			}
+
+			if err := restore.ScheduledScalingActionRestore(action); err != nil {
+				return err
+			}
+
+		case PlanResultsSnapshot:
+			jobResults := new(structs.JobPlanResults)
+			if err := dec.Decode(jobResults); err != nil {
+				return err
+			}
+
+			if err := restore.PlanResultsRestore(jobResults); err != nil {
+				return err
+			}
+
+		case WorkloadIdentityKeySnapshot:
+			key := new(structs.WorkloadIdentityKey)
+			if err := dec.Decode(key); err != nil {
+				return err
+			}
+
+			if err := restore.WorkloadIdentityKeyRestore(key); err != nil {
+				return err
+			}
+
+		case EventCursorSnapshot:
+			cursor := new(structs.EventCursor)
+			if err := dec.Decode(cursor); err != nil {
+				return err
+			}
+
+			if err := restore.EventCursorRestore(cursor); err != nil {
+				return err
+			}
+
 		case ScalingPolicySnapshot:
 			scalingPolicy := new(structs.ScalingPolicy)
 			if err := dec.Decode(scalingPolicy); err != nil {
@@ -1877,6 +2079,122 @@ func (n *nomadFSM) applyUpsertScalingEvent(buf []byte, index uint64) interface{}
 	return nil
 }
 
+// applyScheduledScalingUpsert is used to store a scheduled scaling action
+func (n *nomadFSM) applyScheduledScalingUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "upsert_scheduled_scaling"}, time.Now())
+	var req structs.ScheduledScalingUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertScheduledScalingAction(msgType, index, req.Action); err != nil {
+		n.logger.Error("UpsertScheduledScalingAction failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyWorkloadIdentityKeysUpsert is used to apply a rotation of the keys
+// signing workload identities
+func (n *nomadFSM) applyWorkloadIdentityKeysUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "upsert_workload_identity_keys"}, time.Now())
+	var req structs.WorkloadIdentityKeysUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertWorkloadIdentityKeys(msgType, index, req.Keys, req.DeleteKeyIDs); err != nil {
+		n.logger.Error("UpsertWorkloadIdentityKeys failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyEventCursorUpsert is used to store the cursor of a durable consumer
+// of the event stream
+func (n *nomadFSM) applyEventCursorUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "upsert_event_cursor"}, time.Now())
+	var req structs.EventCursorUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertEventCursor(msgType, index, req.Cursor); err != nil {
+		n.logger.Error("UpsertEventCursor failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyEventCursorDelete is used to delete the cursor of a durable consumer
+// of the event stream
+func (n *nomadFSM) applyEventCursorDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "delete_event_cursor"}, time.Now())
+	var req structs.EventCursorDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteEventCursors(msgType, index, []string{req.Name}); err != nil {
+		n.logger.Error("DeleteEventCursors failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyScheduledScalingDelete is used to delete a set of scheduled scaling
+// actions
+func (n *nomadFSM) applyScheduledScalingDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "delete_scheduled_scaling"}, time.Now())
+	var req structs.ScheduledScalingDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteScheduledScalingActions(msgType, index, req.IDs); err != nil {
+		n.logger.Error("DeleteScheduledScalingActions failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyScalingPolicyUpsert is used to upsert a standalone scaling policy
+func (n *nomadFSM) applyScalingPolicyUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "upsert_scaling_policy"}, time.Now())
+	var req structs.ScalingPolicyUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertScalingPolicies(index, []*structs.ScalingPolicy{req.Policy}); err != nil {
+		n.logger.Error("UpsertScalingPolicies failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyScalingPolicyDelete is used to delete a standalone scaling policy
+func (n *nomadFSM) applyScalingPolicyDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "delete_scaling_policy"}, time.Now())
+	var req structs.ScalingPolicyDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteScalingPolicies(index, []string{req.ID}); err != nil {
+		n.logger.Error("DeleteScalingPolicies failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "persist"}, time.Now())
 	// Register the nodes
@@ -1949,6 +2267,22 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistScheduledScaling(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistPlanResults(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistWorkloadIdentityKeys(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistEventCursors(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	if err := s.persistCSIPlugins(sink, encoder); err != nil {
 		sink.Cancel()
 		return err
@@ -2454,6 +2788,114 @@ func (s *nomadSnapshot) persistScalingEvents(sink raft.SnapshotSink, encoder *co
 	return nil
 }
 
+func (s *nomadSnapshot) persistScheduledScaling(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	// Get all the scheduled scaling actions
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.ScheduledScalingActions(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		action := raw.(*structs.ScheduledScalingAction)
+
+		// Write out a scheduled scaling action snapshot
+		sink.Write([]byte{byte(ScheduledScalingSnapshot)})
+		if err := encoder.Encode(action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistWorkloadIdentityKeys(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	// Get all the keys signing workload identities
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.WorkloadIdentityKeys(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		key := raw.(*structs.WorkloadIdentityKey)
+
+		// Write out a workload identity key snapshot
+		sink.Write([]byte{byte(WorkloadIdentityKeySnapshot)})
+		if err := encoder.Encode(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistEventCursors(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	// Get all the event cursors
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.EventCursors(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		cursor := raw.(*structs.EventCursor)
+
+		// Write out an event cursor snapshot
+		sink.Write([]byte{byte(EventCursorSnapshot)})
+		if err := encoder.Encode(cursor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistPlanResults(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	// Get all the plan results
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.PlanResults(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		results := raw.(*structs.JobPlanResults)
+
+		// Write out a plan results snapshot
+		sink.Write([]byte{byte(PlanResultsSnapshot)})
+		if err := encoder.Encode(results); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *nomadSnapshot) persistCSIPlugins(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 
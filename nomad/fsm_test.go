@@ -943,6 +943,109 @@ func TestFSM_BatchDeregisterJob(t *testing.T) {
 	require.Nil(jobOut2)
 }
 
+func TestFSM_JobBundleRegister(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	job := mock.Job()
+	periodic := mock.PeriodicJob()
+	eval := mock.Eval()
+	eval.JobID = job.ID
+	req := structs.JobBundleRegisterRequest{
+		BundleID: "bundle",
+		Jobs:     []*structs.Job{job, periodic},
+		Evals:    []*structs.Evaluation{eval},
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	}
+	buf, err := structs.Encode(structs.JobBundleRegisterRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify the jobs and the eval were registered
+	for _, j := range []*structs.Job{job, periodic} {
+		out, err := fsm.State().JobByID(nil, j.Namespace, j.ID)
+		require.NoError(t, err)
+		require.NotNil(t, out)
+		require.Equal(t, uint64(1), out.JobModifyIndex)
+	}
+	evalOut, err := fsm.State().EvalByID(nil, eval.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), evalOut.JobModifyIndex)
+
+	// Verify the periodic job was added to the periodic runner
+	require.Contains(t, fsm.periodicDispatcher.tracked, periodic.NamespacedID())
+	launch, err := fsm.State().PeriodicLaunchByID(nil, periodic.Namespace, periodic.ID)
+	require.NoError(t, err)
+	require.NotNil(t, launch)
+
+	// A job that fails to be registered fails the whole bundle
+	job2 := mock.Job()
+	missing := mock.Job()
+	missing.Namespace = "missing"
+	req.Jobs = []*structs.Job{job2, missing}
+	req.Evals = nil
+	buf, err = structs.Encode(structs.JobBundleRegisterRequestType, req)
+	require.NoError(t, err)
+	resp := fsm.Apply(makeLog(buf))
+	require.Error(t, resp.(error))
+
+	out, err := fsm.State().JobByID(nil, job2.Namespace, job2.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestFSM_JobMove(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+	fsm.evalBroker.SetEnabled(true)
+
+	job := mock.Job()
+	require.NoError(t, fsm.State().UpsertJob(structs.MsgTypeTestSetup, 1, job))
+
+	eval := mock.Eval()
+	eval.JobID = "moved"
+	req := structs.JobMoveRequest{
+		JobID:        job.ID,
+		NewNamespace: job.Namespace,
+		NewJobID:     "moved",
+		Eval:         eval,
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	}
+	buf, err := structs.Encode(structs.JobMoveRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify the job was moved and the eval was created and enqueued
+	out, err := fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+	out, err = fsm.State().JobByID(nil, job.Namespace, "moved")
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	evalOut, err := fsm.State().EvalByID(nil, eval.ID)
+	require.NoError(t, err)
+	require.NotNil(t, evalOut)
+	require.Equal(t, 1, fsm.evalBroker.Stats().TotalReady)
+
+	// A failed move doesn't create the eval
+	eval2 := mock.Eval()
+	req.JobID = job.ID
+	req.Eval = eval2
+	buf, err = structs.Encode(structs.JobMoveRequestType, req)
+	require.NoError(t, err)
+	resp := fsm.Apply(makeLog(buf))
+	require.EqualError(t, resp.(error), "job not found")
+
+	evalOut, err = fsm.State().EvalByID(nil, eval2.ID)
+	require.NoError(t, err)
+	require.Nil(t, evalOut)
+}
+
 func TestFSM_UpdateEval(t *testing.T) {
 	ci.Parallel(t)
 	fsm := testFSM(t)
@@ -2846,6 +2949,100 @@ func TestFSM_SnapshotRestore_SchedulerConfiguration(t *testing.T) {
 	require.Equal(schedConfig, out)
 }
 
+func TestFSM_SnapshotRestore_ScheduledScaling(t *testing.T) {
+	ci.Parallel(t)
+
+	fsm := testFSM(t)
+	state := fsm.State()
+	action := &structs.ScheduledScalingAction{
+		ID:         uuid.Generate(),
+		Namespace:  structs.DefaultNamespace,
+		JobID:      "example",
+		TaskGroup:  "web",
+		Count:      5,
+		ScheduleAt: time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+	require.NoError(t, state.UpsertScheduledScalingAction(structs.MsgTypeTestSetup, 1000, action))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	out, err := fsm2.State().ScheduledScalingActionByID(nil, action.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, action.JobID, out.JobID)
+	require.Equal(t, action.Count, out.Count)
+	require.True(t, action.ScheduleAt.Equal(out.ScheduleAt))
+	require.EqualValues(t, 1000, out.CreateIndex)
+}
+
+func TestFSM_SnapshotRestore_WorkloadIdentityKeys(t *testing.T) {
+	ci.Parallel(t)
+
+	fsm := testFSM(t)
+	state := fsm.State()
+	key, err := generateIdentityKey(time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+	require.NoError(t, state.UpsertWorkloadIdentityKeys(structs.MsgTypeTestSetup, 1000,
+		[]*structs.WorkloadIdentityKey{key}, nil))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	out, err := fsm2.State().WorkloadIdentityKeyByID(nil, key.KeyID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, key.Key, out.Key)
+	require.Equal(t, key.State, out.State)
+	require.True(t, key.CreateTime.Equal(out.CreateTime))
+	require.EqualValues(t, 1000, out.CreateIndex)
+}
+
+func TestFSM_SnapshotRestore_EventCursors(t *testing.T) {
+	ci.Parallel(t)
+
+	fsm := testFSM(t)
+	state := fsm.State()
+	cursor := &structs.EventCursor{Name: "consumer", Index: 42}
+	require.NoError(t, state.UpsertEventCursor(structs.MsgTypeTestSetup, 1000, cursor))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	out, err := fsm2.State().EventCursorByName(nil, cursor.Name)
+	require.NoError(t, err)
+	require.Equal(t, cursor, out)
+}
+
+func TestFSM_SnapshotRestore_PlanResults(t *testing.T) {
+	ci.Parallel(t)
+
+	fsm := testFSM(t)
+	state := fsm.State()
+	job := mock.Job()
+	eval := mock.Eval()
+	eval.JobID = job.ID
+	require.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 999, []*structs.Evaluation{eval}))
+
+	result := &structs.PlanApplyResult{
+		EvalID:        eval.ID,
+		AcceptedNodes: []string{uuid.Generate()},
+		RejectedNodes: []*structs.PlanRejectedNode{{NodeID: uuid.Generate(), Reason: "node does not exist"}},
+		RefreshIndex:  999,
+		CreateTime:    time.Now().UnixNano(),
+	}
+	req := &structs.ApplyPlanResultsRequest{
+		AllocUpdateRequest: structs.AllocUpdateRequest{Job: job},
+		EvalID:             eval.ID,
+		PlanApplyResult:    result,
+	}
+	require.NoError(t, state.UpsertPlanResults(structs.MsgTypeTestSetup, 1000, req))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	out, index, err := fsm2.State().PlanResultsByJob(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, index)
+	require.Equal(t, []*structs.PlanApplyResult{result}, out)
+}
+
 func TestFSM_SnapshotRestore_ClusterMetadata(t *testing.T) {
 	ci.Parallel(t)
 
@@ -0,0 +1,216 @@
+package nomad
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// gcTunerInterval is the interval at which the size of the state of the
+	// server is checked against the limits of the GC auto-tuning.
+	gcTunerInterval = 1 * time.Minute
+
+	// gcTunerRelaxRatio is the ratio of the limits below which the size of the
+	// state must fall before the shrunk thresholds are grown back.
+	gcTunerRelaxRatio = 0.5
+
+	// gcTunerMinScale bounds the factor the configured thresholds can be
+	// shrunk by.
+	gcTunerMinScale = 1.0 / 1024
+)
+
+// gcTuner shrinks the job, evaluation and deployment GC thresholds of the
+// server when the size of its heap or of its latest raft snapshot crosses the
+// configured limits, so the objects retained by the state store are collected
+// sooner. The thresholds are halved each time the size is checked and above a
+// limit, and doubled back towards their configured value once the size falls
+// well below the limits.
+//
+// Every server tunes its own thresholds, as the core jobs collecting objects
+// may be processed by any of them, but the servers converge to the same
+// decisions as they hold the same state.
+type gcTuner struct {
+	config *structs.GCAutoTuneConfig
+	srv    *Server
+	logger log.Logger
+
+	// heapSize and snapshotSize measure the state of the server, they are
+	// only replaced by tests.
+	heapSize     func() uint64
+	snapshotSize func() uint64
+
+	l     sync.RWMutex
+	scale float64
+}
+
+// newGCTuner returns a tuner for the GC thresholds of the server. The
+// thresholds are never tuned if config is nil.
+func newGCTuner(srv *Server, config *structs.GCAutoTuneConfig) *gcTuner {
+	t := &gcTuner{
+		config: config,
+		srv:    srv,
+		logger: srv.logger.Named("gc_tuner"),
+		scale:  1,
+	}
+	t.heapSize = heapSize
+	t.snapshotSize = t.latestSnapshotSize
+	return t
+}
+
+// run periodically tunes the thresholds until the context is done.
+func (t *gcTuner) run(ctx context.Context) {
+	if t.config == nil {
+		return
+	}
+
+	ticker := time.NewTicker(gcTunerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.tune()
+		}
+	}
+}
+
+// tune measures the state of the server and shrinks or grows the thresholds
+// accordingly.
+func (t *gcTuner) tune() {
+	heap := t.heapSize()
+	snapshot := t.snapshotSize()
+
+	pressure := 0.0
+	if limit := t.config.MaxHeapBytes; limit != 0 {
+		pressure = float64(heap) / float64(limit)
+	}
+	if limit := t.config.MaxSnapshotBytes; limit != 0 {
+		if p := float64(snapshot) / float64(limit); p > pressure {
+			pressure = p
+		}
+	}
+
+	metrics.SetGauge([]string{"nomad", "gc_tuner", "heap_bytes"}, float32(heap))
+	metrics.SetGauge([]string{"nomad", "gc_tuner", "snapshot_bytes"}, float32(snapshot))
+
+	t.l.Lock()
+	scale := t.scale
+	switch {
+	case pressure >= 1 && scale > gcTunerMinScale:
+		scale /= 2
+	case pressure < gcTunerRelaxRatio && scale < 1:
+		scale *= 2
+	}
+	changed := scale != t.scale
+	t.scale = scale
+	t.l.Unlock()
+
+	metrics.SetGauge([]string{"nomad", "gc_tuner", "scale"}, float32(scale))
+	if !changed {
+		return
+	}
+
+	event := &structs.GCTuneEvent{
+		Server:                t.srv.config.NodeName,
+		Scale:                 scale,
+		HeapBytes:             heap,
+		SnapshotBytes:         snapshot,
+		JobGCThreshold:        t.threshold(t.srv.config.JobGCThreshold),
+		EvalGCThreshold:       t.threshold(t.srv.config.EvalGCThreshold),
+		DeploymentGCThreshold: t.threshold(t.srv.config.DeploymentGCThreshold),
+	}
+	t.logger.Info("tuned GC thresholds to the size of the state",
+		"scale", scale, "heap_bytes", heap, "snapshot_bytes", snapshot,
+		"job_gc_threshold", event.JobGCThreshold,
+		"eval_gc_threshold", event.EvalGCThreshold,
+		"deployment_gc_threshold", event.DeploymentGCThreshold)
+	t.publish(event)
+}
+
+// publish emits the decision on the event stream of the server.
+func (t *gcTuner) publish(event *structs.GCTuneEvent) {
+	state := t.srv.State()
+	broker, err := state.EventBroker()
+	if err != nil {
+		return
+	}
+	index, err := state.LatestIndex()
+	if err != nil {
+		t.logger.Warn("failed to determine state store's index", "error", err)
+		return
+	}
+
+	broker.Publish(&structs.Events{
+		Index: index,
+		Events: []structs.Event{
+			{
+				Topic:   structs.TopicGC,
+				Type:    structs.TypeGCThresholdsTuned,
+				Key:     event.Server,
+				Index:   index,
+				Payload: event,
+			},
+		},
+	})
+}
+
+// threshold returns the threshold in effect for the configured threshold.
+// Shrunk thresholds never fall below the configured minimum threshold, nor
+// grow above their configured value.
+func (t *gcTuner) threshold(configured time.Duration) time.Duration {
+	if t == nil || t.config == nil {
+		return configured
+	}
+
+	t.l.RLock()
+	scale := t.scale
+	t.l.RUnlock()
+	if scale >= 1 {
+		return configured
+	}
+
+	tuned := time.Duration(float64(configured) * scale)
+	min := t.config.MinThreshold
+	if min > configured {
+		min = configured
+	}
+	if tuned < min {
+		return min
+	}
+	return tuned
+}
+
+// latestSnapshotSize returns the size of the latest raft snapshot of the
+// server, or zero if it hasn't taken any.
+func (t *gcTuner) latestSnapshotSize() uint64 {
+	if t.srv.raftSnapshots == nil {
+		return 0
+	}
+
+	snapshots, err := t.srv.raftSnapshots.List()
+	if err != nil {
+		t.logger.Warn("failed to list raft snapshots", "error", err)
+		return 0
+	}
+
+	// Snapshot stores list the newest snapshots first
+	if len(snapshots) == 0 || snapshots[0].Size < 0 {
+		return 0
+	}
+	return uint64(snapshots[0].Size)
+}
+
+// heapSize returns the size of the objects allocated on the heap, most of
+// which are held by the state store.
+func heapSize() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
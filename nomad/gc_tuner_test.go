@@ -0,0 +1,109 @@
+package nomad
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/stream"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGCTuner_Disabled(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+
+	require.Equal(t, s1.config.JobGCThreshold, s1.gcTuner.threshold(s1.config.JobGCThreshold))
+
+	var nilTuner *gcTuner
+	require.Equal(t, time.Hour, nilTuner.threshold(time.Hour))
+}
+
+func TestGCTuner_Tune(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.JobGCThreshold = 4 * time.Hour
+		c.EvalGCThreshold = 1 * time.Hour
+		c.DeploymentGCThreshold = 10 * time.Minute
+		c.GCAutoTuneConfig = &structs.GCAutoTuneConfig{
+			MaxHeapBytes:     1000,
+			MaxSnapshotBytes: 1000,
+			MinThreshold:     15 * time.Minute,
+		}
+	})
+	defer cleanupS1()
+
+	broker, err := s1.State().EventBroker()
+	require.NoError(t, err)
+	sub, err := broker.Subscribe(&stream.SubscribeRequest{
+		Topics: map[structs.Topic][]string{
+			structs.TopicGC: {"*"},
+		},
+	})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	var heap, snapshot uint64
+	tuner := s1.gcTuner
+	tuner.heapSize = func() uint64 { return heap }
+	tuner.snapshotSize = func() uint64 { return snapshot }
+
+	requireThresholds := func(job, eval, deployment time.Duration) {
+		t.Helper()
+		require.Equal(t, job, tuner.threshold(s1.config.JobGCThreshold))
+		require.Equal(t, eval, tuner.threshold(s1.config.EvalGCThreshold))
+		require.Equal(t, deployment, tuner.threshold(s1.config.DeploymentGCThreshold))
+	}
+
+	// The thresholds aren't tuned below the limits
+	heap, snapshot = 800, 800
+	tuner.tune()
+	requireThresholds(4*time.Hour, time.Hour, 10*time.Minute)
+
+	// The thresholds are halved each time a limit is crossed, but not below
+	// the minimum threshold or the configured threshold
+	snapshot = 1200
+	tuner.tune()
+	requireThresholds(2*time.Hour, 30*time.Minute, 10*time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events, err := sub.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, events.Events, 1)
+	require.Equal(t, structs.TypeGCThresholdsTuned, events.Events[0].Type)
+	require.Equal(t, &structs.GCTuneEvent{
+		Server:                s1.config.NodeName,
+		Scale:                 0.5,
+		HeapBytes:             800,
+		SnapshotBytes:         1200,
+		JobGCThreshold:        2 * time.Hour,
+		EvalGCThreshold:       30 * time.Minute,
+		DeploymentGCThreshold: 10 * time.Minute,
+	}, events.Events[0].Payload)
+
+	heap = 1000
+	tuner.tune()
+	tuner.tune()
+	requireThresholds(30*time.Minute, 15*time.Minute, 10*time.Minute)
+
+	// The thresholds are kept while the state is close to the limits
+	heap, snapshot = 800, 800
+	tuner.tune()
+	requireThresholds(30*time.Minute, 15*time.Minute, 10*time.Minute)
+
+	// The thresholds grow back once the state is well below the limits
+	heap, snapshot = 100, 100
+	tuner.tune()
+	requireThresholds(time.Hour, 15*time.Minute, 10*time.Minute)
+	tuner.tune()
+	tuner.tune()
+	requireThresholds(4*time.Hour, time.Hour, 10*time.Minute)
+	tuner.tune()
+	requireThresholds(4*time.Hour, time.Hour, 10*time.Minute)
+}
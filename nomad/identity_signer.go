@@ -0,0 +1,377 @@
+package nomad
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	jose "gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// OIDCDiscoveryConfig is the OpenID Connect discovery document of the
+// workload identity issuer, which third parties use to find the keys
+// verifying workload identities.
+type OIDCDiscoveryConfig struct {
+	Issuer        string   `json:"issuer"`
+	JWKS          string   `json:"jwks_uri"`
+	SigningAlgs   []string `json:"id_token_signing_alg_values_supported"`
+	ResponseTypes []string `json:"response_types_supported"`
+	Subjects      []string `json:"subject_types_supported"`
+}
+
+// identitySigner signs the workload identities of tasks.
+type identitySigner struct {
+	issuer string
+
+	// rotationInterval is the interval the leader rotates the keys it
+	// generates at.
+	rotationInterval time.Duration
+
+	// static is the key read from the configured signing key file. If nil,
+	// the keys are generated by the leader and read from the state store.
+	static *identityKey
+
+	// state returns the state store the keys generated by the leader are
+	// read from.
+	state func() *state.StateStore
+
+	// keys caches the parsed keys of the state store by key ID.
+	keys     map[string]*identityKey
+	keysLock sync.Mutex
+
+	// rotateLock serializes the rotations of the keys by the leader.
+	rotateLock sync.Mutex
+}
+
+// identityKey is a parsed key signing workload identities.
+type identityKey struct {
+	alg    jose.SignatureAlgorithm
+	signer jose.Signer
+
+	// public is the key verifying the signed identities.
+	public jose.JSONWebKey
+}
+
+// newIdentitySigner returns an identitySigner for the given configuration.
+func newIdentitySigner(config *structs.WorkloadIdentityConfig, stateFn func() *state.StateStore) (*identitySigner, error) {
+	if config.Issuer == "" {
+		return nil, fmt.Errorf("workload identity issuer must be set")
+	}
+
+	s := &identitySigner{
+		issuer:           strings.TrimSuffix(config.Issuer, "/"),
+		rotationInterval: config.RotationInterval,
+		state:            stateFn,
+		keys:             make(map[string]*identityKey),
+	}
+	if s.rotationInterval == 0 {
+		s.rotationInterval = structs.WorkloadIdentityDefaultRotationInterval
+	}
+
+	if config.SigningKeyFile == "" {
+		return s, nil
+	}
+
+	raw, err := ioutil.ReadFile(config.SigningKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workload identity signing key: %v", err)
+	}
+	key, err := parseIdentitySigningKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workload identity signing key: %v", err)
+	}
+	s.static, err = newIdentityKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// newIdentityKey returns the identityKey of the given private key.
+func newIdentityKey(key crypto.Signer) (*identityKey, error) {
+	var alg jose.SignatureAlgorithm
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		alg = jose.RS256
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("workload identity signing key must use the P-256 curve")
+		}
+		alg = jose.ES256
+	default:
+		return nil, fmt.Errorf("workload identity signing key must be an RSA or ECDSA key")
+	}
+
+	// The key ID is derived from the key such that all servers sharing the
+	// key agree on it.
+	jwk := jose.JSONWebKey{Key: key, Algorithm: string(alg), Use: "sig"}
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute workload identity key ID: %v", err)
+	}
+	jwk.KeyID = base64.RawURLEncoding.EncodeToString(thumbprint)
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: jwk},
+		(&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &identityKey{
+		alg:    alg,
+		signer: signer,
+		public: jwk.Public(),
+	}, nil
+}
+
+// generateIdentityKey generates a new ECDSA P-256 key to sign workload
+// identities.
+func generateIdentityKey(now time.Time) (*structs.WorkloadIdentityKey, error) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate workload identity key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode workload identity key: %v", err)
+	}
+	key, err := newIdentityKey(private)
+	if err != nil {
+		return nil, err
+	}
+
+	return &structs.WorkloadIdentityKey{
+		KeyID:      key.public.KeyID,
+		Algorithm:  string(key.alg),
+		Key:        der,
+		State:      structs.WorkloadIdentityKeyStateNext,
+		CreateTime: now,
+	}, nil
+}
+
+// managed returns whether the keys are generated and rotated by the leader.
+func (s *identitySigner) managed() bool {
+	return s.static == nil
+}
+
+// parsedKey returns the parsed key of the given key of the state store.
+func (s *identitySigner) parsedKey(key *structs.WorkloadIdentityKey) (*identityKey, error) {
+	s.keysLock.Lock()
+	defer s.keysLock.Unlock()
+
+	if parsed, ok := s.keys[key.KeyID]; ok {
+		return parsed, nil
+	}
+
+	private, err := x509.ParsePKCS8PrivateKey(key.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workload identity key %q: %v", key.KeyID, err)
+	}
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %T of workload identity key %q", private, key.KeyID)
+	}
+	parsed, err := newIdentityKey(signer)
+	if err != nil {
+		return nil, err
+	}
+	s.keys[key.KeyID] = parsed
+	return parsed, nil
+}
+
+// stateKeys returns the keys of the state store in the order of their
+// rotation.
+func (s *identitySigner) stateKeys() ([]*structs.WorkloadIdentityKey, error) {
+	iter, err := s.state().WorkloadIdentityKeys(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []*structs.WorkloadIdentityKey
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		keys = append(keys, raw.(*structs.WorkloadIdentityKey))
+	}
+	sortIdentityKeys(keys)
+	return keys, nil
+}
+
+// identityKeyStateOrder is the order of the states of keys in their rotation.
+var identityKeyStateOrder = map[string]int{
+	structs.WorkloadIdentityKeyStateRetired: 0,
+	structs.WorkloadIdentityKeyStateActive:  1,
+	structs.WorkloadIdentityKeyStateNext:    2,
+}
+
+// sortIdentityKeys sorts keys in the order of their rotation: retired keys
+// from oldest to newest, the active key and the next key. The active and the
+// next key are generated at the same time when bootstrapping the keys, so the
+// creation time alone doesn't order them.
+func sortIdentityKeys(keys []*structs.WorkloadIdentityKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		si, sj := identityKeyStateOrder[keys[i].State], identityKeyStateOrder[keys[j].State]
+		if si != sj {
+			return si < sj
+		}
+		return keys[i].CreateTime.Before(keys[j].CreateTime)
+	})
+}
+
+// activeKey returns the key currently signing identities.
+func (s *identitySigner) activeKey() (*identityKey, error) {
+	if !s.managed() {
+		return s.static, nil
+	}
+
+	keys, err := s.stateKeys()
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		if key.State == structs.WorkloadIdentityKeyStateActive {
+			return s.parsedKey(key)
+		}
+	}
+	return nil, fmt.Errorf("no active workload identity key")
+}
+
+// parseIdentitySigningKey parses a PEM encoded PKCS#1, PKCS#8 or SEC 1 private
+// key.
+func parseIdentitySigningKey(raw []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded key found")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported key type %T", key)
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+// sign signs the given identity of a task of the allocation.
+func (s *identitySigner) sign(region string, alloc *structs.Allocation, task string,
+	identity *structs.WorkloadIdentity, now time.Time) (*structs.SignedWorkloadIdentity, error) {
+
+	expiration := now.Add(identity.TTL)
+	claims := jwt.Claims{
+		ID:        uuid.Generate(),
+		Issuer:    s.issuer,
+		Subject:   structs.WorkloadIdentitySubject(region, alloc, task, identity.Name),
+		Audience:  jwt.Audience(identity.Audience),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		Expiry:    jwt.NewNumericDate(expiration),
+	}
+	nomadClaims := structs.IdentityClaims{
+		Namespace:    alloc.Namespace,
+		JobID:        alloc.JobID,
+		AllocationID: alloc.ID,
+		Task:         task,
+	}
+
+	key, err := s.activeKey()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := jwt.Signed(key.signer).Claims(claims).Claims(nomadClaims).CompactSerialize()
+	if err != nil {
+		return nil, err
+	}
+	return &structs.SignedWorkloadIdentity{
+		Name:       identity.Name,
+		JWT:        token,
+		Expiration: expiration,
+	}, nil
+}
+
+// keySet returns the set of keys verifying signed identities. When the keys
+// are generated by the leader, the set includes the next key, such that third
+// parties learn about it before it signs identities, and the retired keys
+// whose identities may not have expired yet.
+func (s *identitySigner) keySet() (*jose.JSONWebKeySet, error) {
+	if !s.managed() {
+		return &jose.JSONWebKeySet{Keys: []jose.JSONWebKey{s.static.public}}, nil
+	}
+
+	keys, err := s.stateKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	set := &jose.JSONWebKeySet{Keys: make([]jose.JSONWebKey, 0, len(keys))}
+	for _, key := range keys {
+		parsed, err := s.parsedKey(key)
+		if err != nil {
+			return nil, err
+		}
+		set.Keys = append(set.Keys, parsed.public)
+	}
+	return set, nil
+}
+
+// discoveryConfig returns the OpenID Connect discovery document of the
+// issuer.
+func (s *identitySigner) discoveryConfig() *OIDCDiscoveryConfig {
+	// The keys generated by the leader are always ECDSA P-256 keys
+	alg := jose.ES256
+	if !s.managed() {
+		alg = s.static.alg
+	}
+
+	return &OIDCDiscoveryConfig{
+		Issuer:        s.issuer,
+		JWKS:          s.issuer + "/.well-known/jwks.json",
+		SigningAlgs:   []string{string(alg)},
+		ResponseTypes: []string{"id_token"},
+		Subjects:      []string{"public"},
+	}
+}
+
+// WorkloadIdentityKeys returns the set of keys verifying workload identities,
+// or nil if workload identities are not configured.
+func (s *Server) WorkloadIdentityKeys() (*jose.JSONWebKeySet, error) {
+	if s.identitySigner == nil {
+		return nil, nil
+	}
+	return s.identitySigner.keySet()
+}
+
+// WorkloadIdentityDiscovery returns the OpenID Connect discovery document of
+// the workload identity issuer, or nil if workload identities are not
+// configured.
+func (s *Server) WorkloadIdentityDiscovery() *OIDCDiscoveryConfig {
+	if s.identitySigner == nil {
+		return nil
+	}
+	return s.identitySigner.discoveryConfig()
+}
@@ -0,0 +1,130 @@
+package nomad
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+// testIdentitySigningKey writes a PEM encoded RSA private key to a temporary
+// directory and returns its path.
+func testIdentitySigningKey(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "identity.pem")
+	raw := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	require.NoError(t, ioutil.WriteFile(path, raw, 0600))
+	return path
+}
+
+func TestIdentitySigner_Sign(t *testing.T) {
+	ci.Parallel(t)
+
+	signer, err := newIdentitySigner(&structs.WorkloadIdentityConfig{
+		Issuer:         "https://nomad.example.com/",
+		SigningKeyFile: testIdentitySigningKey(t),
+	}, nil)
+	require.NoError(t, err)
+
+	alloc := mock.Alloc()
+	identity := &structs.WorkloadIdentity{
+		Name:     "aws",
+		Audience: []string{"sts.amazonaws.com"},
+		TTL:      15 * time.Minute,
+		File:     true,
+	}
+	now := time.Now().Truncate(time.Second)
+	signed, err := signer.sign("global", alloc, "web", identity, now)
+	require.NoError(t, err)
+	require.Equal(t, "aws", signed.Name)
+	require.True(t, signed.Expiration.Equal(now.Add(15*time.Minute)))
+
+	// The identity is verified by the published key set
+	token, err := jwt.ParseSigned(signed.JWT)
+	require.NoError(t, err)
+	keys, err := signer.keySet()
+	require.NoError(t, err)
+	require.Len(t, keys.Keys, 1)
+	require.Equal(t, keys.Keys[0].KeyID, token.Headers[0].KeyID)
+
+	var claims jwt.Claims
+	var nomadClaims structs.IdentityClaims
+	require.NoError(t, token.Claims(keys.Keys[0], &claims, &nomadClaims))
+	require.NoError(t, claims.ValidateWithLeeway(jwt.Expected{
+		Issuer:   "https://nomad.example.com",
+		Audience: jwt.Audience{"sts.amazonaws.com"},
+		Time:     now,
+	}, 0))
+	require.Equal(t, "global:default:"+alloc.JobID+":web:web:aws", claims.Subject)
+	require.Equal(t, structs.IdentityClaims{
+		Namespace:    alloc.Namespace,
+		JobID:        alloc.JobID,
+		AllocationID: alloc.ID,
+		Task:         "web",
+	}, nomadClaims)
+
+	// The discovery document points at the key set
+	discovery := signer.discoveryConfig()
+	require.Equal(t, "https://nomad.example.com", discovery.Issuer)
+	require.Equal(t, "https://nomad.example.com/.well-known/jwks.json", discovery.JWKS)
+	require.Equal(t, []string{"RS256"}, discovery.SigningAlgs)
+}
+
+func TestIdentitySigner_Keys(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	write := func(name string, block *pem.Block) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, pem.EncodeToMemory(block), 0600))
+		return path
+	}
+
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p256Raw, err := x509.MarshalPKCS8PrivateKey(p256)
+	require.NoError(t, err)
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	p384Raw, err := x509.MarshalECPrivateKey(p384)
+	require.NoError(t, err)
+
+	// ECDSA P-256 keys sign with ES256
+	signer, err := newIdentitySigner(&structs.WorkloadIdentityConfig{
+		Issuer:         "https://nomad.example.com",
+		SigningKeyFile: write("p256.pem", &pem.Block{Type: "PRIVATE KEY", Bytes: p256Raw}),
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ES256"}, signer.discoveryConfig().SigningAlgs)
+
+	// Other curves are rejected
+	_, err = newIdentitySigner(&structs.WorkloadIdentityConfig{
+		Issuer:         "https://nomad.example.com",
+		SigningKeyFile: write("p384.pem", &pem.Block{Type: "EC PRIVATE KEY", Bytes: p384Raw}),
+	}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "P-256")
+
+	// Files without keys are rejected
+	_, err = newIdentitySigner(&structs.WorkloadIdentityConfig{
+		Issuer:         "https://nomad.example.com",
+		SigningKeyFile: write("cert.pem", &pem.Block{Type: "CERTIFICATE", Bytes: []byte("foo")}),
+	}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unsupported PEM block type")
+}
@@ -0,0 +1,140 @@
+package nomad
+
+import (
+	"context"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+)
+
+const (
+	// jobDependencyRetryInterval is how long the leader waits before retrying
+	// after failing to check the dependencies of jobs.
+	jobDependencyRetryInterval = 5 * time.Second
+
+	// jobDependencyRateLimit is the minimum time between two checks of the
+	// dependencies of jobs, as the jobs table changes frequently.
+	jobDependencyRateLimit = 1 * time.Second
+)
+
+// watchJobDependencies is a long lived function that creates evaluations for
+// the jobs held by their dependencies once the dependencies are ready, while
+// we are leader.
+func (s *Server) watchJobDependencies(stopCh chan struct{}) {
+	for {
+		ws := memdb.NewWatchSet()
+		ready, err := s.readyDependentJobs(ws)
+		if err == nil && len(ready) != 0 {
+			err = s.evalDependentJobs(ready)
+		}
+		if err != nil {
+			s.logger.Error("failed to check job dependencies", "error", err)
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(jobDependencyRetryInterval):
+				continue
+			}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		select {
+		case <-stopCh:
+		case <-ws.WatchCh(ctx):
+		}
+		cancel()
+
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(jobDependencyRateLimit):
+		}
+	}
+}
+
+// readyDependentJobs returns the pending jobs that have dependencies which
+// are all ready, skipping the job versions that were already evaluated
+// because of it.
+func (s *Server) readyDependentJobs(ws memdb.WatchSet) ([]*structs.Job, error) {
+	store := s.fsm.State()
+	ws.Add(store.AbandonCh())
+
+	// Watch the deployments of the dependencies as well as the jobs
+	if _, err := store.Deployments(ws, state.SortDefault); err != nil {
+		return nil, err
+	}
+	iter, err := store.Jobs(ws)
+	if err != nil {
+		return nil, err
+	}
+
+	var ready []*structs.Job
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		job := raw.(*structs.Job)
+		if len(job.DependsOn) == 0 || job.Stopped() || job.Status != structs.JobStatusPending {
+			continue
+		}
+		evaluated, err := dependencyEvalExists(store, job)
+		if err != nil {
+			return nil, err
+		}
+		if evaluated {
+			continue
+		}
+
+		pending, err := scheduler.JobDependenciesPending(store, job.Namespace, job.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(pending) == 0 {
+			ready = append(ready, job)
+		}
+	}
+	return ready, nil
+}
+
+// dependencyEvalExists returns whether an evaluation was already created for
+// the current version of the job because its dependencies became ready.
+func dependencyEvalExists(store *state.StateStore, job *structs.Job) (bool, error) {
+	evals, err := store.EvalsByJob(nil, job.Namespace, job.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, eval := range evals {
+		if eval.TriggeredBy == structs.EvalTriggerJobDependency && eval.JobModifyIndex == job.JobModifyIndex {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evalDependentJobs creates evaluations for jobs whose dependencies are ready.
+func (s *Server) evalDependentJobs(jobs []*structs.Job) error {
+	now := time.Now().UnixNano()
+	evals := make([]*structs.Evaluation, 0, len(jobs))
+	for _, job := range jobs {
+		evals = append(evals, &structs.Evaluation{
+			ID:             uuid.Generate(),
+			Namespace:      job.Namespace,
+			Priority:       job.Priority,
+			Type:           job.Type,
+			TriggeredBy:    structs.EvalTriggerJobDependency,
+			JobID:          job.ID,
+			JobModifyIndex: job.JobModifyIndex,
+			Status:         structs.EvalStatusPending,
+			CreateTime:     now,
+			ModifyTime:     now,
+		})
+	}
+
+	req := &structs.EvalUpdateRequest{
+		Evals:        evals,
+		WriteRequest: structs.WriteRequest{Region: s.config.Region},
+	}
+	_, _, err := s.raftApply(structs.EvalUpdateRequestType, req)
+	return err
+}
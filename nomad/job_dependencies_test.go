@@ -0,0 +1,71 @@
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeader_WatchJobDependencies(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Create the dependency, which isn't running yet, and a job depending on
+	// it
+	dep := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, dep))
+
+	job := mock.BatchJob()
+	job.DependsOn = []string{dep.ID}
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, job))
+
+	dependencyEvals := func() []*structs.Evaluation {
+		evals, err := state.EvalsByJob(nil, job.Namespace, job.ID)
+		require.NoError(t, err)
+		var out []*structs.Evaluation
+		for _, eval := range evals {
+			if eval.TriggeredBy == structs.EvalTriggerJobDependency {
+				out = append(out, eval)
+			}
+		}
+		return out
+	}
+
+	// No evaluation is created while the dependency isn't ready
+	time.Sleep(2 * jobDependencyRateLimit)
+	require.Empty(t, dependencyEvals())
+
+	// Start the dependency
+	alloc := mock.Alloc()
+	alloc.Job = dep
+	alloc.JobID = dep.ID
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1002, []*structs.Allocation{alloc}))
+
+	// An evaluation is created for the job
+	testutil.WaitForResult(func() (bool, error) {
+		return len(dependencyEvals()) == 1, nil
+	}, func(err error) {
+		t.Fatalf("expected an evaluation for the job")
+	})
+	eval := dependencyEvals()[0]
+	require.Equal(t, job.JobModifyIndex, eval.JobModifyIndex)
+	require.Equal(t, structs.EvalStatusPending, eval.Status)
+
+	// The job is only evaluated once per version, even if it stays pending
+	other := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1003, other))
+	time.Sleep(2 * jobDependencyRateLimit)
+	require.Len(t, dependencyEvals(), 1)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/go-bexpr"
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
 	multierror "github.com/hashicorp/go-multierror"
@@ -96,10 +97,406 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		return fmt.Errorf("mismatched request namespace in request: %q, %q", args.RequestNamespace(), args.Job.Namespace)
 	}
 
+	if args.Async {
+		return j.registerAsync(args, reply)
+	}
+
+	// Run admission controllers and check job submission permissions
+	warnings, err := j.authorizeRegister(args)
+	if err != nil {
+		return err
+	}
+
+	// Set the warning message
+	reply.Warnings = structs.MergeMultierrorWarnings(warnings...)
+
+	// Lookup the job
+	snap, err := j.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	ws := memdb.NewWatchSet()
+	existingJob, err := snap.JobByID(ws, args.RequestNamespace(), args.Job.ID)
+	if err != nil {
+		return err
+	}
+
+	// Avoid registering the job again for retry requests, by using the
+	// idempotency token. This is checked before the modify index is enforced
+	// as the retry is expected to conflict with the original registration.
+	// Retries racing the original registration are caught when it's applied.
+	if args.IdempotencyToken != "" && existingJob != nil {
+		done, err := registerIdempotentReply(snap, args, reply)
+		if err != nil || done {
+			return err
+		}
+	}
+
+	// If EnforceIndex set, check it before trying to apply
+	if args.EnforceIndex {
+		jmi := args.JobModifyIndex
+		if existingJob != nil {
+			if jmi == 0 {
+				return fmt.Errorf("%s 0: job already exists", RegisterEnforceIndexErrPrefix)
+			} else if jmi != existingJob.JobModifyIndex {
+				return fmt.Errorf("%s %d: job exists with conflicting job modify index: %d",
+					RegisterEnforceIndexErrPrefix, jmi, existingJob.JobModifyIndex)
+			}
+		} else if jmi != 0 {
+			return fmt.Errorf("%s %d: job does not exist", RegisterEnforceIndexErrPrefix, jmi)
+		}
+	}
+
+	// Validate the job against the existing job and the Vault, Consul and
+	// Sentinel policies
+	policyWarnings, err := j.validateRegister(args, existingJob)
+	if err != nil {
+		return err
+	}
+	if policyWarnings != nil {
+		warnings = append(warnings, policyWarnings)
+		reply.Warnings = structs.MergeMultierrorWarnings(warnings...)
+	}
+
+	// Create or Update Consul Configuration Entries defined in the job
+	if err := j.setConsulConfigEntries(args.Job); err != nil {
+		return err
+	}
+
+	// Submit a multiregion job to other regions (enterprise only).
+	// The job will have its region interpolated.
+	var newVersion uint64
+	if existingJob != nil {
+		newVersion = existingJob.Version + 1
+	}
+	isRunner, err := j.multiregionRegister(args, reply, newVersion)
+	if err != nil {
+		return err
+	}
+
+	// Create a new evaluation
+	now := time.Now().UnixNano()
+	submittedEval := false
+	var eval *structs.Evaluation
+
+	// Set the submit time
+	args.Job.SubmitTime = now
+	args.Job.RegisterIdempotencyToken = args.IdempotencyToken
+
+	// Tags are only set on existing versions, so a job submitted with the
+	// tag of the version it was read from doesn't carry it over.
+	args.Job.VersionTag = nil
+
+	// If the job is periodic or parameterized, we don't create an eval.
+	if !(args.Job.IsPeriodic() || args.Job.IsParameterized()) {
+
+		// Initially set the eval priority to that of the job priority. If the
+		// user supplied an eval priority override, we subsequently use this.
+		evalPriority := args.Job.Priority
+		if args.EvalPriority > 0 {
+			evalPriority = args.EvalPriority
+		}
+
+		eval = &structs.Evaluation{
+			ID:          uuid.Generate(),
+			Namespace:   args.RequestNamespace(),
+			Priority:    evalPriority,
+			Type:        args.Job.Type,
+			TriggeredBy: structs.EvalTriggerJobRegister,
+			JobID:       args.Job.ID,
+			Status:      structs.EvalStatusPending,
+			CreateTime:  now,
+			ModifyTime:  now,
+		}
+		reply.EvalID = eval.ID
+	}
+
+	// Check if the job has changed at all
+	if existingJob == nil || existingJob.SpecChanged(args.Job) {
+
+		// COMPAT(1.1.0): Remove the ServerMeetMinimumVersion check to always set args.Eval
+		// 0.12.1 introduced atomic eval job registration
+		if eval != nil && ServersMeetMinimumVersion(j.srv.Members(), minJobRegisterAtomicEvalVersion, false) {
+			args.Eval = eval
+			submittedEval = true
+		}
+
+		// Commit this update via Raft
+		fsmErr, index, err := j.srv.raftApply(structs.JobRegisterRequestType, args)
+		if idempotentErr, ok := fsmErr.(*structs.JobRegisterIdempotentError); ok {
+			snap, err := j.srv.State().Snapshot()
+			if err != nil {
+				return err
+			}
+			return setRegisterIdempotentReply(snap, args, idempotentErr.JobModifyIndex, reply)
+		}
+		if err, ok := fsmErr.(error); ok && err != nil {
+			j.logger.Error("registering job failed", "error", err, "fsm", true)
+			return err
+		}
+		if err != nil {
+			j.logger.Error("registering job failed", "error", err, "raft", true)
+			return err
+		}
+
+		// Populate the reply with job information
+		reply.JobModifyIndex = index
+		reply.Index = index
+
+		if submittedEval {
+			reply.EvalCreateIndex = index
+		}
+
+	} else {
+		reply.JobModifyIndex = existingJob.JobModifyIndex
+	}
+
+	// used for multiregion start
+	args.Job.JobModifyIndex = reply.JobModifyIndex
+
+	if eval == nil {
+		// For dispatch jobs we return early, so we need to drop regions
+		// here rather than after eval for deployments is kicked off
+		err = j.multiregionDrop(args, reply)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if eval != nil && !submittedEval {
+		eval.JobModifyIndex = reply.JobModifyIndex
+		update := &structs.EvalUpdateRequest{
+			Evals:        []*structs.Evaluation{eval},
+			WriteRequest: structs.WriteRequest{Region: args.Region},
+		}
+
+		// Commit this evaluation via Raft
+		// There is a risk of partial failure where the JobRegister succeeds
+		// but that the EvalUpdate does not, before 0.12.1
+		_, evalIndex, err := j.srv.raftApply(structs.EvalUpdateRequestType, update)
+		if err != nil {
+			j.logger.Error("eval create failed", "error", err, "method", "register")
+			return err
+		}
+
+		reply.EvalCreateIndex = evalIndex
+		reply.Index = evalIndex
+	}
+
+	// Kick off a multiregion deployment (enterprise only).
+	if isRunner {
+		err = j.multiregionStart(args, reply)
+		if err != nil {
+			return err
+		}
+		// We drop any unwanted regions only once we know all jobs have
+		// been registered and we've kicked off the deployment. This keeps
+		// dropping regions close in semantics to dropping task groups in
+		// single-region deployments
+		err = j.multiregionDrop(args, reply)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RegisterBundle is used to register a set of jobs atomically. The jobs are
+// all validated before any of them is committed, and are committed along
+// with their evaluations in a single Raft log, so that interdependent jobs
+// never end up partially updated.
+func (j *Job) RegisterBundle(args *structs.JobBundleRegisterRequest, reply *structs.JobBundleRegisterResponse) error {
+	if done, err := j.srv.forward("Job.RegisterBundle", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "register_bundle"}, time.Now())
+
+	// Validate the arguments
+	if len(args.Jobs) == 0 {
+		return fmt.Errorf("missing jobs for bundle registration")
+	}
+	if args.BundleID == "" {
+		args.BundleID = uuid.Generate()
+	}
+
+	snap, err := j.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Validate all the jobs before committing any of them
+	var warnings []error
+	var changed []*structs.Job
+	jobs := make([]*structs.Job, 0, len(args.Jobs))
+	existingJobs := make(map[string]*structs.Job, len(args.Jobs))
+	for _, job := range args.Jobs {
+		if job == nil {
+			return fmt.Errorf("missing job for bundle registration")
+		}
+
+		// defensive check; http layer and RPC requester should ensure namespaces are set consistently
+		if args.RequestNamespace() != job.Namespace {
+			return fmt.Errorf("mismatched request namespace in request: %q, %q", args.RequestNamespace(), job.Namespace)
+		}
+		if _, ok := existingJobs[job.ID]; ok {
+			return fmt.Errorf("job %q is included more than once in the bundle", job.ID)
+		}
+		if job.IsMultiregion() {
+			return fmt.Errorf("multiregion job %q can't be registered in a bundle", job.ID)
+		}
+
+		jobArgs := &structs.JobRegisterRequest{
+			Job:            job,
+			PolicyOverride: args.PolicyOverride,
+			WriteRequest:   args.WriteRequest,
+		}
+		jobWarnings, err := j.authorizeRegister(jobArgs)
+		if err != nil {
+			return fmt.Errorf("job %q: %w", job.ID, err)
+		}
+		warnings = append(warnings, jobWarnings...)
+
+		existingJob, err := snap.JobByID(nil, args.RequestNamespace(), jobArgs.Job.ID)
+		if err != nil {
+			return err
+		}
+		existingJobs[job.ID] = existingJob
+
+		policyWarnings, err := j.validateRegister(jobArgs, existingJob)
+		if err != nil {
+			return fmt.Errorf("job %q: %w", job.ID, err)
+		}
+		if policyWarnings != nil {
+			warnings = append(warnings, policyWarnings)
+		}
+
+		jobs = append(jobs, jobArgs.Job)
+	}
+	reply.Warnings = structs.MergeMultierrorWarnings(warnings...)
+
+	// Create or Update Consul Configuration Entries defined in the jobs only
+	// once all the jobs are known to be valid
+	for _, job := range jobs {
+		if err := j.setConsulConfigEntries(job); err != nil {
+			return err
+		}
+	}
+
+	// Only register the jobs that changed and create an evaluation for all
+	// of them, as is done for jobs registered individually.
+	now := time.Now().UnixNano()
+	reply.JobEvals = make(map[string]string, len(jobs))
+	var evals []*structs.Evaluation
+	for _, job := range jobs {
+		job.SubmitTime = now
+		job.BundleID = args.BundleID
+		job.VersionTag = nil
+
+		existingJob := existingJobs[job.ID]
+		if existingJob == nil || existingJob.SpecChanged(job) {
+			changed = append(changed, job)
+		}
+
+		// If the job is periodic or parameterized, we don't create an eval.
+		if job.IsPeriodic() || job.IsParameterized() {
+			continue
+		}
+
+		eval := &structs.Evaluation{
+			ID:          uuid.Generate(),
+			Namespace:   args.RequestNamespace(),
+			Priority:    job.Priority,
+			Type:        job.Type,
+			TriggeredBy: structs.EvalTriggerJobRegister,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+			CreateTime:  now,
+			ModifyTime:  now,
+		}
+		if existingJob != nil {
+			eval.JobModifyIndex = existingJob.JobModifyIndex
+		}
+		evals = append(evals, eval)
+		reply.JobEvals[job.ID] = eval.ID
+	}
+	args.Jobs = changed
+	args.Evals = evals
+
+	// Commit the bundle via Raft
+	fsmErr, index, err := j.srv.raftApply(structs.JobBundleRegisterRequestType, args)
+	if err, ok := fsmErr.(error); ok && err != nil {
+		j.logger.Error("registering job bundle failed", "bundle_id", args.BundleID, "error", err, "fsm", true)
+		return err
+	}
+	if err != nil {
+		j.logger.Error("registering job bundle failed", "bundle_id", args.BundleID, "error", err, "raft", true)
+		return err
+	}
+
+	reply.BundleID = args.BundleID
+	reply.JobModifyIndex = index
+	reply.Index = index
+	return nil
+}
+
+// registerAsync enqueues the registration on the leader. Only the permission
+// to submit jobs in the namespace is checked, the registration is validated
+// and authorized when it's dequeued.
+func (j *Job) registerAsync(args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse) error {
+	aclObj, err := j.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	submission, err := j.srv.jobRegisterQueue.Enqueue(args)
+	if err != nil {
+		return err
+	}
+	reply.SubmissionID = submission.ID
+	return nil
+}
+
+// GetRegisterSubmission is used to read the status of an asynchronous job
+// registration.
+func (j *Job) GetRegisterSubmission(args *structs.JobRegisterSubmissionRequest, reply *structs.JobRegisterSubmissionResponse) error {
+	// The submissions are only tracked by the leader, so we fix the args
+	// since we don't support stale queries.
+	args.AllowStale = false
+	if done, err := j.srv.forward("Job.GetRegisterSubmission", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "get_register_submission"}, time.Now())
+
+	aclObj, err := j.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+
+	submission := j.srv.jobRegisterQueue.Get(args.SubmissionID)
+	if submission == nil {
+		return structs.NewErrRPCCodedf(404, "job registration submission %q not found", args.SubmissionID)
+	}
+	if aclObj != nil && !aclObj.AllowNsOp(submission.Namespace, acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	reply.Submission = submission
+	j.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
+// authorizeRegister runs the admission controllers on the job of a
+// registration request and checks that the request's token is allowed to
+// submit it. It returns the warnings of the admission controllers.
+func (j *Job) authorizeRegister(args *structs.JobRegisterRequest) ([]error, error) {
 	// Run admission controllers
 	job, warnings, err := j.admissionControllers(args.Job)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	args.Job = job
 
@@ -107,22 +504,19 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 	// can reference the token later
 	tokenID, err := j.srv.ResolveSecretToken(args.AuthToken)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if tokenID != nil {
 		args.Job.NomadTokenID = tokenID.AccessorID
 	}
 
-	// Set the warning message
-	reply.Warnings = structs.MergeMultierrorWarnings(warnings...)
-
 	// Check job submission permissions
 	aclObj, err := j.srv.ResolveToken(args.AuthToken)
 	if err != nil {
-		return err
+		return nil, err
 	} else if aclObj != nil {
 		if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
-			return structs.ErrPermissionDenied
+			return nil, structs.ErrPermissionDenied
 		}
 
 		// Validate Volume Permissions
@@ -131,7 +525,7 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 				switch vol.Type {
 				case structs.VolumeTypeCSI:
 					if !allowCSIMount(aclObj, args.RequestNamespace()) {
-						return structs.ErrPermissionDenied
+						return nil, structs.ErrPermissionDenied
 					}
 				case structs.VolumeTypeHost:
 					// If a volume is readonly, then we allow access if the user has ReadOnly
@@ -140,15 +534,15 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 					if vol.ReadOnly {
 						if !aclObj.AllowHostVolumeOperation(vol.Source, acl.HostVolumeCapabilityMountReadOnly) &&
 							!aclObj.AllowHostVolumeOperation(vol.Source, acl.HostVolumeCapabilityMountReadWrite) {
-							return structs.ErrPermissionDenied
+							return nil, structs.ErrPermissionDenied
 						}
 					} else {
 						if !aclObj.AllowHostVolumeOperation(vol.Source, acl.HostVolumeCapabilityMountReadWrite) {
-							return structs.ErrPermissionDenied
+							return nil, structs.ErrPermissionDenied
 						}
 					}
 				default:
-					return structs.ErrPermissionDenied
+					return nil, structs.ErrPermissionDenied
 				}
 			}
 
@@ -157,13 +551,13 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 					vol := tg.Volumes[vm.Volume]
 					if vm.PropagationMode == structs.VolumeMountPropagationBidirectional &&
 						!aclObj.AllowHostVolumeOperation(vol.Source, acl.HostVolumeCapabilityMountReadWrite) {
-						return structs.ErrPermissionDenied
+						return nil, structs.ErrPermissionDenied
 					}
 				}
 
 				if t.CSIPluginConfig != nil {
 					if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityCSIRegisterPlugin) {
-						return structs.ErrPermissionDenied
+						return nil, structs.ErrPermissionDenied
 					}
 				}
 			}
@@ -173,7 +567,7 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		if args.PolicyOverride {
 			if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySentinelOverride) {
 				j.logger.Warn("policy override attempted without permissions for job", "job", args.Job.ID)
-				return structs.ErrPermissionDenied
+				return nil, structs.ErrPermissionDenied
 			}
 			j.logger.Warn("policy override set for job", "job", args.Job.ID)
 		}
@@ -181,43 +575,24 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 
 	if ok, err := registrationsAreAllowed(aclObj, j.srv.State()); !ok || err != nil {
 		j.logger.Warn("job registration is currently disabled for non-management ACL")
-		return structs.ErrJobRegistrationDisabled
-	}
-
-	// Lookup the job
-	snap, err := j.srv.State().Snapshot()
-	if err != nil {
-		return err
-	}
-	ws := memdb.NewWatchSet()
-	existingJob, err := snap.JobByID(ws, args.RequestNamespace(), args.Job.ID)
-	if err != nil {
-		return err
+		return nil, structs.ErrJobRegistrationDisabled
 	}
 
-	// If EnforceIndex set, check it before trying to apply
-	if args.EnforceIndex {
-		jmi := args.JobModifyIndex
-		if existingJob != nil {
-			if jmi == 0 {
-				return fmt.Errorf("%s 0: job already exists", RegisterEnforceIndexErrPrefix)
-			} else if jmi != existingJob.JobModifyIndex {
-				return fmt.Errorf("%s %d: job exists with conflicting job modify index: %d",
-					RegisterEnforceIndexErrPrefix, jmi, existingJob.JobModifyIndex)
-			}
-		} else if jmi != 0 {
-			return fmt.Errorf("%s %d: job does not exist", RegisterEnforceIndexErrPrefix, jmi)
-		}
-	}
+	return warnings, nil
+}
 
+// validateRegister validates the job of a registration request against the
+// existing job and the Vault, Consul and Sentinel policies, and prepares it to
+// be committed. It returns the warnings of the Sentinel policies.
+func (j *Job) validateRegister(args *structs.JobRegisterRequest, existingJob *structs.Job) (error, error) {
 	// Validate job transitions if its an update
 	if err := validateJobUpdate(existingJob, args.Job); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Ensure that all scaling policies have an appropriate ID
 	if err := propagateScalingPolicyIDs(existingJob, args.Job); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Ensure that the job has permissions for the requested Vault tokens
@@ -225,30 +600,30 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 	if len(policies) != 0 {
 		vconf := j.srv.config.VaultConfig
 		if !vconf.IsEnabled() {
-			return fmt.Errorf("Vault not enabled and Vault policies requested")
+			return nil, fmt.Errorf("Vault not enabled and Vault policies requested")
 		}
 
 		// Have to check if the user has permissions
 		if !vconf.AllowsUnauthenticated() {
 			if args.Job.VaultToken == "" {
-				return fmt.Errorf("Vault policies requested but missing Vault Token")
+				return nil, fmt.Errorf("Vault policies requested but missing Vault Token")
 			}
 
 			vault := j.srv.vault
 			s, err := vault.LookupToken(context.Background(), args.Job.VaultToken)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			allowedPolicies, err := PoliciesFrom(s)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			// Check Namespaces
 			namespaceErr := j.multiVaultNamespaceValidation(policies, s)
 			if namespaceErr != nil {
-				return namespaceErr
+				return nil, namespaceErr
 			}
 
 			// If we are given a root token it can access all policies
@@ -256,11 +631,39 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 				flatPolicies := structs.VaultPoliciesSet(policies)
 				subset, offending := helper.SliceStringIsSubset(allowedPolicies, flatPolicies)
 				if !subset {
-					return fmt.Errorf("Passed Vault Token doesn't allow access to the following policies: %s",
+					return nil, fmt.Errorf("Passed Vault Token doesn't allow access to the following policies: %s",
 						strings.Join(offending, ", "))
 				}
 			}
 		}
+
+		if err := j.validateVaultClusters(args.Job, policies); err != nil {
+			return nil, err
+		}
+	}
+
+	// Ensure the servers can sign the workload identities of the job
+	if signer := j.srv.identitySigner; signer == nil {
+		for _, tg := range args.Job.TaskGroups {
+			for _, task := range tg.Tasks {
+				if len(task.Identities) != 0 {
+					return nil, fmt.Errorf("Task %q requests workload identities but workload identities are not configured on the servers", task.Name)
+				}
+			}
+		}
+	} else if signer.managed() {
+		// Retired keys are only published for one rotation interval, so
+		// identities must expire before their key is removed
+		for _, tg := range args.Job.TaskGroups {
+			for _, task := range tg.Tasks {
+				for _, identity := range task.Identities {
+					if identity.TTL > signer.rotationInterval {
+						return nil, fmt.Errorf("Task %q requests workload identity %q with a TTL longer than the key rotation interval of %v",
+							task.Name, identity.Name, signer.rotationInterval)
+					}
+				}
+			}
+		}
 	}
 
 	// helper function that checks if the Consul token supplied with the job has
@@ -287,47 +690,19 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 
 	// Enforce the job-submitter has a Consul token with necessary ACL permissions.
 	if err := checkConsulToken(args.Job.ConsulUsages()); err != nil {
-		return err
-	}
-
-	// Create or Update Consul Configuration Entries defined in the job. For now
-	// Nomad only supports Configuration Entries types
-	// - "ingress-gateway" for managing Ingress Gateways
-	// - "terminating-gateway" for managing Terminating Gateways
-	//
-	// This is done as a blocking operation that prevents the job from being
-	// submitted if the configuration entries cannot be set in Consul.
-	//
-	// Every job update will re-write the Configuration Entry into Consul.
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	for ns, entries := range args.Job.ConfigEntries() {
-		for service, entry := range entries.Ingress {
-			if errCE := j.srv.consulConfigEntries.SetIngressCE(ctx, ns, service, entry); errCE != nil {
-				return errCE
-			}
-		}
-		for service, entry := range entries.Terminating {
-			if errCE := j.srv.consulConfigEntries.SetTerminatingCE(ctx, ns, service, entry); errCE != nil {
-				return errCE
-			}
-		}
+		return nil, err
 	}
 
 	// Enforce Sentinel policies. Pass a copy of the job to prevent
 	// sentinel from altering it.
 	policyWarnings, err := j.enforceSubmitJob(args.PolicyOverride, args.Job.Copy())
 	if err != nil {
-		return err
-	}
-	if policyWarnings != nil {
-		warnings = append(warnings, policyWarnings)
-		reply.Warnings = structs.MergeMultierrorWarnings(warnings...)
+		return nil, err
 	}
 
-	// Clear the Vault token
+	// Clear the Vault tokens
 	args.Job.VaultToken = ""
+	args.Job.VaultClusterTokens = nil
 
 	// Clear the Consul token
 	args.Job.ConsulToken = ""
@@ -345,131 +720,155 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		}
 	}
 
-	// Submit a multiregion job to other regions (enterprise only).
-	// The job will have its region interpolated.
-	var newVersion uint64
-	if existingJob != nil {
-		newVersion = existingJob.Version + 1
-	}
-	isRunner, err := j.multiregionRegister(args, reply, newVersion)
-	if err != nil {
-		return err
-	}
-
-	// Create a new evaluation
-	now := time.Now().UnixNano()
-	submittedEval := false
-	var eval *structs.Evaluation
-
-	// Set the submit time
-	args.Job.SubmitTime = now
-
-	// If the job is periodic or parameterized, we don't create an eval.
-	if !(args.Job.IsPeriodic() || args.Job.IsParameterized()) {
+	return policyWarnings, nil
+}
 
-		// Initially set the eval priority to that of the job priority. If the
-		// user supplied an eval priority override, we subsequently use this.
-		evalPriority := args.Job.Priority
-		if args.EvalPriority > 0 {
-			evalPriority = args.EvalPriority
+// validateVaultClusters ensures the named Vault clusters requested by the
+// tasks are configured, and that the Vault token submitted for each cluster
+// that doesn't allow unauthenticated access has access to the policies
+// requested from it.
+func (j *Job) validateVaultClusters(job *structs.Job, policies map[string]map[string]*structs.Vault) error {
+	// Collect the policies requested from each cluster
+	clusterPolicies := make(map[string][]string)
+	for _, tg := range policies {
+		for _, vault := range tg {
+			for _, c := range vault.Clusters {
+				clusterPolicies[c.Name] = append(clusterPolicies[c.Name], c.Policies...)
+			}
 		}
+	}
 
-		eval = &structs.Evaluation{
-			ID:          uuid.Generate(),
-			Namespace:   args.RequestNamespace(),
-			Priority:    evalPriority,
-			Type:        args.Job.Type,
-			TriggeredBy: structs.EvalTriggerJobRegister,
-			JobID:       args.Job.ID,
-			Status:      structs.EvalStatusPending,
-			CreateTime:  now,
-			ModifyTime:  now,
+	for name, requested := range clusterPolicies {
+		cluster := j.srv.config.VaultConfig.Cluster(name)
+		if cluster == nil {
+			return fmt.Errorf("Vault cluster %q is not configured", name)
 		}
-		reply.EvalID = eval.ID
-	}
 
-	// Check if the job has changed at all
-	if existingJob == nil || existingJob.SpecChanged(args.Job) {
+		conf := cluster.Config()
+		if !conf.IsEnabled() {
+			return fmt.Errorf("Vault cluster %q not enabled and Vault policies requested", name)
+		}
+		if conf.AllowsUnauthenticated() {
+			continue
+		}
 
-		// COMPAT(1.1.0): Remove the ServerMeetMinimumVersion check to always set args.Eval
-		// 0.12.1 introduced atomic eval job registration
-		if eval != nil && ServersMeetMinimumVersion(j.srv.Members(), minJobRegisterAtomicEvalVersion, false) {
-			args.Eval = eval
-			submittedEval = true
+		// Have to check if the user has permissions
+		token := job.VaultClusterTokens[name]
+		if token == "" {
+			return fmt.Errorf("Vault cluster %q policies requested but missing Vault Token", name)
 		}
 
-		// Commit this update via Raft
-		fsmErr, index, err := j.srv.raftApply(structs.JobRegisterRequestType, args)
-		if err, ok := fsmErr.(error); ok && err != nil {
-			j.logger.Error("registering job failed", "error", err, "fsm", true)
+		vault, err := j.srv.vaultClusterClient(name)
+		if err != nil {
 			return err
 		}
+		s, err := vault.LookupToken(context.Background(), token)
 		if err != nil {
-			j.logger.Error("registering job failed", "error", err, "raft", true)
-			return err
+			return fmt.Errorf("Vault cluster %q: %v", name, err)
 		}
 
-		// Populate the reply with job information
-		reply.JobModifyIndex = index
-		reply.Index = index
-
-		if submittedEval {
-			reply.EvalCreateIndex = index
+		allowedPolicies, err := PoliciesFrom(s)
+		if err != nil {
+			return fmt.Errorf("Vault cluster %q: %v", name, err)
 		}
 
-	} else {
-		reply.JobModifyIndex = existingJob.JobModifyIndex
+		// If we are given a root token it can access all policies
+		if !lib.StrContains(allowedPolicies, "root") {
+			subset, offending := helper.SliceStringIsSubset(allowedPolicies, requested)
+			if !subset {
+				return fmt.Errorf("Passed Vault Token for cluster %q doesn't allow access to the following policies: %s",
+					name, strings.Join(offending, ", "))
+			}
+		}
 	}
+	return nil
+}
 
-	// used for multiregion start
-	args.Job.JobModifyIndex = reply.JobModifyIndex
+// setConsulConfigEntries creates or updates the Consul Configuration Entries
+// defined in the job.
+func (j *Job) setConsulConfigEntries(job *structs.Job) error {
+	// Create or Update Consul Configuration Entries defined in the job. For now
+	// Nomad only supports Configuration Entries types
+	// - "ingress-gateway" for managing Ingress Gateways
+	// - "terminating-gateway" for managing Terminating Gateways
+	// - "api-gateway" for managing API Gateways
+	//
+	// This is done as a blocking operation that prevents the job from being
+	// submitted if the configuration entries cannot be set in Consul.
+	//
+	// Every job update will re-write the Configuration Entry into Consul.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	if eval == nil {
-		// For dispatch jobs we return early, so we need to drop regions
-		// here rather than after eval for deployments is kicked off
-		err = j.multiregionDrop(args, reply)
-		if err != nil {
-			return err
+	for ns, entries := range job.ConfigEntries() {
+		for service, entry := range entries.Ingress {
+			if errCE := j.srv.consulConfigEntries.SetIngressCE(ctx, ns, service, entry); errCE != nil {
+				return errCE
+			}
 		}
-		return nil
-	}
-
-	if eval != nil && !submittedEval {
-		eval.JobModifyIndex = reply.JobModifyIndex
-		update := &structs.EvalUpdateRequest{
-			Evals:        []*structs.Evaluation{eval},
-			WriteRequest: structs.WriteRequest{Region: args.Region},
+		for service, entry := range entries.Terminating {
+			if errCE := j.srv.consulConfigEntries.SetTerminatingCE(ctx, ns, service, entry); errCE != nil {
+				return errCE
+			}
 		}
-
-		// Commit this evaluation via Raft
-		// There is a risk of partial failure where the JobRegister succeeds
-		// but that the EvalUpdate does not, before 0.12.1
-		_, evalIndex, err := j.srv.raftApply(structs.EvalUpdateRequestType, update)
-		if err != nil {
-			j.logger.Error("eval create failed", "error", err, "method", "register")
-			return err
+		for service, entry := range entries.APIGateway {
+			if errCE := j.srv.consulConfigEntries.SetAPIGatewayCE(ctx, ns, service, entry); errCE != nil {
+				return errCE
+			}
 		}
+	}
 
-		reply.EvalCreateIndex = evalIndex
-		reply.Index = evalIndex
+	return nil
+}
+
+// registerIdempotentReply sets the reply of a job registration to the result
+// of the original registration with the same idempotency token, if the job
+// version it created is still tracked and was submitted within the TTL. It
+// returns whether the original registration was found.
+func registerIdempotentReply(snap *state.StateSnapshot, args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse) (bool, error) {
+	versions, err := snap.JobVersionsByID(nil, args.RequestNamespace(), args.Job.ID)
+	if err != nil {
+		return false, err
 	}
 
-	// Kick off a multiregion deployment (enterprise only).
-	if isRunner {
-		err = j.multiregionStart(args, reply)
-		if err != nil {
-			return err
+	cutoff := time.Now().Add(-structs.JobRegisterIdempotencyTTL).UnixNano()
+	for _, version := range versions {
+		if version.RegisterIdempotencyToken != args.IdempotencyToken || version.SubmitTime < cutoff {
+			continue
 		}
-		// We drop any unwanted regions only once we know all jobs have
-		// been registered and we've kicked off the deployment. This keeps
-		// dropping regions close in semantics to dropping task groups in
-		// single-region deployments
-		err = j.multiregionDrop(args, reply)
-		if err != nil {
-			return err
+		return true, setRegisterIdempotentReply(snap, args, version.JobModifyIndex, reply)
+	}
+	return false, nil
+}
+
+// setRegisterIdempotentReply sets the reply of a job registration to the
+// result of the original registration that created the job version with the
+// given modify index.
+func setRegisterIdempotentReply(snap *state.StateSnapshot, args *structs.JobRegisterRequest, jobModifyIndex uint64, reply *structs.JobRegisterResponse) error {
+	reply.JobModifyIndex = jobModifyIndex
+	reply.Index = jobModifyIndex
+	reply.EvalID = ""
+	reply.EvalCreateIndex = 0
+
+	// Find the evaluation created by the original registration, unless it
+	// has been garbage collected already
+	evals, err := snap.EvalsByJob(nil, args.RequestNamespace(), args.Job.ID)
+	if err != nil {
+		return err
+	}
+	for _, eval := range evals {
+		if eval.TriggeredBy != structs.EvalTriggerJobRegister ||
+			eval.JobModifyIndex != jobModifyIndex {
+			continue
+		}
+		if reply.EvalID == "" || eval.CreateIndex < reply.EvalCreateIndex {
+			reply.EvalID = eval.ID
+			reply.EvalCreateIndex = eval.CreateIndex
 		}
 	}
-
+	if reply.EvalCreateIndex > reply.Index {
+		reply.Index = reply.EvalCreateIndex
+	}
 	return nil
 }
 
@@ -714,6 +1113,180 @@ func (j *Job) Stable(args *structs.JobStabilityRequest, reply *structs.JobStabil
 	return nil
 }
 
+// TagVersion is used to set or remove the tag of a job version
+func (j *Job) TagVersion(args *structs.JobVersionTagRequest, reply *structs.JobVersionTagResponse) error {
+	if done, err := j.srv.forward("Job.TagVersion", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "tag_version"}, time.Now())
+
+	// Check for submit-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate the arguments
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID for tagging job version")
+	}
+	if args.Tag != nil {
+		if err := args.Tag.Validate(); err != nil {
+			return err
+		}
+		args.Tag.TaggedTime = time.Now().UTC().UnixNano()
+	}
+
+	// Lookup the job versions
+	snap, err := j.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	ws := memdb.NewWatchSet()
+	versions, err := snap.JobVersionsByID(ws, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, v := range versions {
+		if v.Version == args.JobVersion {
+			found = true
+		} else if args.Tag != nil && v.VersionTag != nil && v.VersionTag.Name == args.Tag.Name {
+			return fmt.Errorf("version tag %q is already used by version %d of job %q", args.Tag.Name, v.Version, args.JobID)
+		}
+	}
+	if !found {
+		return fmt.Errorf("job %q in namespace %q at version %d not found", args.JobID, args.RequestNamespace(), args.JobVersion)
+	}
+
+	// Commit this tag request via Raft
+	_, modifyIndex, err := j.srv.raftApply(structs.JobVersionTagRequestType, args)
+	if err != nil {
+		j.logger.Error("submitting job version tag request failed", "error", err)
+		return err
+	}
+
+	// Setup the reply
+	reply.Index = modifyIndex
+	return nil
+}
+
+// Move is used to move a job to a different namespace, to rename it, or both.
+// The versions, scaling policies and allocations of the job are preserved.
+func (j *Job) Move(args *structs.JobMoveRequest, reply *structs.JobMoveResponse) error {
+	if done, err := j.srv.forward("Job.Move", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "move"}, time.Now())
+
+	// Validate the arguments
+	if args.JobID == "" {
+		return fmt.Errorf("missing job ID for move")
+	}
+	if args.NewNamespace == "" {
+		args.NewNamespace = args.RequestNamespace()
+	}
+	if args.NewJobID == "" {
+		args.NewJobID = args.JobID
+	}
+	if args.NewNamespace == args.RequestNamespace() && args.NewJobID == args.JobID {
+		return fmt.Errorf("job move requires a new namespace or job ID")
+	}
+
+	// Check for submit-job permissions in the namespace of the job. The new
+	// namespace is authorized below, as when the job is registered there.
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := j.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	ws := memdb.NewWatchSet()
+	job, err := snap.JobByID(ws, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return fmt.Errorf("job %q in namespace %q not found", args.JobID, args.RequestNamespace())
+	}
+	if job.IsMultiregion() {
+		return fmt.Errorf("multiregion jobs can't be moved")
+	}
+
+	// Admit the job to the new namespace as if it were registered there, so
+	// the namespace's driver restrictions and job limits, the submit-job
+	// authorization and the Vault and Consul policy checks all apply. Only
+	// the outcome is used, the stored job is moved as is.
+	moved := job.CopyWithID(args.NewNamespace, args.NewJobID)
+	moved.VaultToken = args.VaultToken
+	moved.ConsulToken = args.ConsulToken
+	regArgs := &structs.JobRegisterRequest{
+		Job: moved,
+		WriteRequest: structs.WriteRequest{
+			Region:    args.Region,
+			Namespace: args.NewNamespace,
+			AuthToken: args.AuthToken,
+		},
+	}
+	if _, err := j.authorizeRegister(regArgs); err != nil {
+		return err
+	}
+	if _, err := j.validateRegister(regArgs, nil); err != nil {
+		return err
+	}
+
+	// The tokens must not be written to raft
+	args.VaultToken = ""
+	args.ConsulToken = ""
+
+	// Create an evaluation so that the scheduler reconciles the moved job.
+	// Stopped jobs have nothing to reconcile.
+	args.Eval = nil
+	if !job.Stopped() {
+		now := time.Now().UnixNano()
+		args.Eval = &structs.Evaluation{
+			ID:             uuid.Generate(),
+			Namespace:      args.NewNamespace,
+			Priority:       job.Priority,
+			Type:           job.Type,
+			TriggeredBy:    structs.EvalTriggerJobRegister,
+			JobID:          args.NewJobID,
+			JobModifyIndex: job.JobModifyIndex,
+			Status:         structs.EvalStatusPending,
+			CreateTime:     now,
+			ModifyTime:     now,
+		}
+	}
+
+	// Commit the move via Raft. The remaining checks, such as whether the
+	// target is free, are done by the state store.
+	fsmErr, index, err := j.srv.raftApply(structs.JobMoveRequestType, args)
+	if err, ok := fsmErr.(error); ok && err != nil {
+		j.logger.Error("moving job failed", "job", args.JobID, "error", err, "fsm", true)
+		return err
+	}
+	if err != nil {
+		j.logger.Error("moving job failed", "job", args.JobID, "error", err, "raft", true)
+		return err
+	}
+
+	reply.JobModifyIndex = job.JobModifyIndex
+	if args.Eval != nil {
+		reply.EvalID = args.Eval.ID
+		reply.EvalCreateIndex = index
+	}
+	reply.Index = index
+	return nil
+}
+
 // Evaluate is used to force a job for re-evaluation
 func (j *Job) Evaluate(args *structs.JobEvaluateRequest, reply *structs.JobRegisterResponse) error {
 	if done, err := j.srv.forward("Job.Evaluate", args, args, reply); done {
@@ -1059,6 +1632,9 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 		return structs.NewErrRPCCoded(404, fmt.Sprintf("job %q not found", args.JobID))
 	}
 
+	// Copy the job so the state store's copy isn't modified by the update
+	job = job.Copy()
+
 	// Find target group in job TaskGroups
 	groupName := args.Target[structs.ScalingTargetGroup]
 	var group *structs.TaskGroup
@@ -1085,6 +1661,7 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 			Time:          now,
 			PreviousCount: prevCount,
 			Count:         args.Count,
+			Resources:     args.Resources,
 			Message:       args.Message,
 			Error:         args.Error,
 			Meta:          args.Meta,
@@ -1092,23 +1669,69 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 	}
 
 	if args.Count != nil {
-		// Further validation for count-based scaling event
-		if group.Scaling != nil {
-			if *args.Count < group.Scaling.Min {
+		// Further validation for count-based scaling event, against the
+		// group's policy or else a standalone policy targeting the group
+		policy := group.Scaling
+		if policy == nil {
+			target := map[string]string{
+				structs.ScalingTargetNamespace: job.Namespace,
+				structs.ScalingTargetJob:       job.ID,
+				structs.ScalingTargetGroup:     groupName,
+			}
+			policy, err = snap.ScalingPolicyByTargetAndType(nil, target, structs.ScalingPolicyTypeHorizontal)
+			if err != nil {
+				return err
+			}
+		}
+		if policy != nil {
+			if *args.Count < policy.Min {
 				return structs.NewErrRPCCoded(400,
 					fmt.Sprintf("group count was less than scaling policy minimum: %d < %d",
-						*args.Count, group.Scaling.Min))
+						*args.Count, policy.Min))
 			}
-			if group.Scaling.Max < *args.Count {
+			if policy.Max < *args.Count {
 				return structs.NewErrRPCCoded(400,
 					fmt.Sprintf("group count was greater than scaling policy maximum: %d > %d",
-						*args.Count, group.Scaling.Max))
+						*args.Count, policy.Max))
 			}
 		}
 
+		// The count must stay within the job limits of the namespace
+		ns, err := snap.NamespaceByName(nil, job.Namespace)
+		if err != nil {
+			return err
+		}
+		if ns != nil && ns.JobLimits != nil && ns.JobLimits.MaxGroupCount != 0 &&
+			*args.Count > int64(ns.JobLimits.MaxGroupCount) {
+			return structs.NewErrRPCCoded(400, (&structs.JobLimitError{
+				Namespace: ns.Name,
+				JobID:     job.ID,
+				Group:     groupName,
+				Limit:     structs.JobLimitMaxGroupCount,
+				Max:       ns.JobLimits.MaxGroupCount,
+				Actual:    int(*args.Count),
+			}).Error())
+		}
+
+		// Scheduled count changes are stored and applied by the leader at
+		// the requested time, which emits the scaling event
+		if !args.ScheduleAt.IsZero() {
+			return j.scheduleScale(args, job, reply)
+		}
+
 		// Update group count
 		group.Count = int(*args.Count)
+	}
+
+	if len(args.Resources) != 0 {
+		prevResources, err := scaleTaskResources(group, args.Resources)
+		if err != nil {
+			return err
+		}
+		event.ScalingEvent.PreviousResources = prevResources
+	}
 
+	if args.Count != nil || len(args.Resources) != 0 {
 		// Block scaling event if there's an active deployment
 		deployment, err := snap.LatestDeploymentByJobID(ws, namespace, args.JobID)
 		if err != nil {
@@ -1177,6 +1800,7 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 		j.logger.Error("scaling event create failed", "error", err)
 		return err
 	}
+	j.srv.scalingWebhook.notify(eventIndex, event)
 
 	reply.Index = eventIndex
 
@@ -1185,6 +1809,103 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 	return nil
 }
 
+// scheduleScale stores a scaling request as a scheduled scaling action that
+// the leader applies at the requested time.
+func (j *Job) scheduleScale(args *structs.JobScaleRequest, job *structs.Job, reply *structs.JobRegisterResponse) error {
+	if !args.ScheduleAt.After(time.Now()) {
+		return structs.NewErrRPCCoded(400,
+			fmt.Sprintf("scheduled scaling time %s is not in the future", args.ScheduleAt.Format(time.RFC3339)))
+	}
+
+	req := &structs.ScheduledScalingUpsertRequest{
+		Action: &structs.ScheduledScalingAction{
+			ID:         uuid.Generate(),
+			Namespace:  job.Namespace,
+			JobID:      job.ID,
+			TaskGroup:  args.Target[structs.ScalingTargetGroup],
+			Count:      *args.Count,
+			Message:    args.Message,
+			Meta:       args.Meta,
+			ScheduleAt: args.ScheduleAt,
+		},
+		WriteRequest: args.WriteRequest,
+	}
+
+	_, index, err := j.srv.raftApply(structs.ScheduledScalingUpsertRequestType, req)
+	if err != nil {
+		j.logger.Error("scheduled scaling action create failed", "error", err)
+		return err
+	}
+
+	reply.JobModifyIndex = job.ModifyIndex
+	reply.Index = index
+	j.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
+// scaleTaskResources applies the resources of a vertical scaling request to the
+// tasks of the group, validating them against the tasks' vertical scaling
+// policies. It returns the previous resources of the scaled tasks.
+func scaleTaskResources(group *structs.TaskGroup, resources map[string]*structs.ScalingResources) (map[string]*structs.ScalingResources, error) {
+	prev := make(map[string]*structs.ScalingResources, len(resources))
+	for taskName, res := range resources {
+		task := group.LookupTask(taskName)
+		if task == nil {
+			return nil, structs.NewErrRPCCoded(400,
+				fmt.Sprintf("task %q specified for scaling does not exist in task group %q", taskName, group.Name))
+		}
+		if task.Resources == nil {
+			return nil, structs.NewErrRPCCoded(400,
+				fmt.Sprintf("task %q specified for scaling has no resources", taskName))
+		}
+
+		for _, policy := range task.ScalingPolicies {
+			var value int
+			var resource string
+			switch policy.Type {
+			case structs.ScalingPolicyTypeVerticalCPU:
+				value, resource = res.CPU, "cpu"
+			case structs.ScalingPolicyTypeVerticalMem:
+				value, resource = res.MemoryMB, "memory"
+			default:
+				continue
+			}
+			if value == 0 {
+				continue
+			}
+			if int64(value) < policy.Min {
+				return nil, structs.NewErrRPCCoded(400,
+					fmt.Sprintf("task %q %s was less than scaling policy minimum: %d < %d",
+						taskName, resource, value, policy.Min))
+			}
+			if policy.Max < int64(value) {
+				return nil, structs.NewErrRPCCoded(400,
+					fmt.Sprintf("task %q %s was greater than scaling policy maximum: %d > %d",
+						taskName, resource, value, policy.Max))
+			}
+		}
+
+		if res.MemoryMB != 0 && task.Resources.MemoryMaxMB != 0 && res.MemoryMB > task.Resources.MemoryMaxMB {
+			return nil, structs.NewErrRPCCoded(400,
+				fmt.Sprintf("task %q memory was greater than memory_max: %d > %d",
+					taskName, res.MemoryMB, task.Resources.MemoryMaxMB))
+		}
+
+		prev[taskName] = &structs.ScalingResources{
+			CPU:      task.Resources.CPU,
+			MemoryMB: task.Resources.MemoryMB,
+		}
+		if res.CPU != 0 {
+			task.Resources.CPU = res.CPU
+		}
+		if res.MemoryMB != 0 {
+			task.Resources.MemoryMB = res.MemoryMB
+		}
+	}
+
+	return prev, nil
+}
+
 // GetJob is used to request information about a specific job
 func (j *Job) GetJob(args *structs.JobSpecificRequest,
 	reply *structs.SingleJobResponse) error {
@@ -1289,6 +2010,58 @@ func (j *Job) GetJobVersions(args *structs.JobVersionsRequest,
 	return j.srv.blockingRPC(&opts)
 }
 
+// DiffVersions is used to diff two arbitrary versions of a job
+func (j *Job) DiffVersions(args *structs.JobVersionsDiffRequest,
+	reply *structs.JobVersionsDiffResponse) error {
+	if done, err := j.srv.forward("Job.DiffVersions", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "diff_versions"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			reply.Diff = nil
+
+			from, err := state.JobByIDAndVersion(ws, args.RequestNamespace(), args.JobID, args.FromVersion)
+			if err != nil {
+				return err
+			}
+			to, err := state.JobByIDAndVersion(ws, args.RequestNamespace(), args.JobID, args.ToVersion)
+			if err != nil {
+				return err
+			}
+
+			if from != nil && to != nil {
+				reply.Diff, err = from.Diff(to, true)
+				if err != nil {
+					return fmt.Errorf("failed to create job diff: %v", err)
+				}
+			}
+
+			// Use the last index that affected the job versions table
+			index, err := state.Index("job_version")
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
 // allowedNSes returns a set (as map of ns->true) of the namespaces a token has access to.
 // Returns `nil` set if the token has access to all namespaces
 // and ErrPermissionDenied if the token has no capabilities on any namespace.
@@ -1473,6 +2246,17 @@ func (j *Job) Allocations(args *structs.JobSpecificRequest,
 		return fmt.Errorf("missing job ID")
 	}
 
+	// The filter is evaluated against the allocations of the same snapshot,
+	// so the allocations matching it can be acted on as a consistent set
+	var evaluator *bexpr.Evaluator
+	if args.Filter != "" {
+		var err error
+		evaluator, err = bexpr.CreateEvaluator(args.Filter)
+		if err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "failed to read filter expression: %v", err)
+		}
+	}
+
 	// Setup the blocking query
 	opts := blockingOptions{
 		queryOpts: &args.QueryOptions,
@@ -1488,6 +2272,15 @@ func (j *Job) Allocations(args *structs.JobSpecificRequest,
 			if len(allocs) > 0 {
 				reply.Allocations = make([]*structs.AllocListStub, 0, len(allocs))
 				for _, alloc := range allocs {
+					if evaluator != nil {
+						match, err := evaluator.Evaluate(alloc)
+						if err != nil {
+							return structs.NewErrRPCCodedf(http.StatusBadRequest, "failed to apply filter: %v", err)
+						}
+						if !match {
+							continue
+						}
+					}
 					reply.Allocations = append(reply.Allocations, alloc.Stub(nil))
 				}
 			}
@@ -1528,11 +2321,24 @@ func (j *Job) Evaluations(args *structs.JobSpecificRequest,
 		queryMeta: &reply.QueryMeta,
 		run: func(ws memdb.WatchSet, state *state.StateStore) error {
 			// Capture the evals
-			var err error
-			reply.Evaluations, err = state.EvalsByJob(ws, args.RequestNamespace(), args.JobID)
+			evals, err := state.EvalsByJob(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+
+			items := make([]interface{}, len(evals))
+			for i, eval := range evals {
+				items[i] = eval
+			}
+			reply.Evaluations = nil
+			nextToken, err := jobListPage(args, items, func(raw interface{}) error {
+				reply.Evaluations = append(reply.Evaluations, raw.(*structs.Evaluation))
+				return nil
+			})
 			if err != nil {
 				return err
 			}
+			reply.QueryMeta.NextToken = nextToken
 
 			// Use the last index that affected the evals table
 			index, err := state.Index("evals")
@@ -1549,6 +2355,99 @@ func (j *Job) Evaluations(args *structs.JobSpecificRequest,
 	return j.srv.blockingRPC(&opts)
 }
 
+// jobListPage appends the page of the deployments or evaluations of a job
+// selected by the request, ordered by create index, and returns the token of
+// the next page. Only the items created within the create index window of the
+// request are listed.
+func jobListPage(args *structs.JobSpecificRequest, items []interface{}, appendFn func(interface{}) error) (string, error) {
+	if args.FromIndex != 0 && args.ToIndex != 0 && args.FromIndex > args.ToIndex {
+		return "", structs.NewErrRPCCodedf(http.StatusBadRequest,
+			"from_index %d is greater than to_index %d", args.FromIndex, args.ToIndex)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i].(paginator.CreateIndexGetter), items[j].(paginator.CreateIndexGetter)
+		if a.GetCreateIndex() == b.GetCreateIndex() {
+			less := items[i].(paginator.IDGetter).GetID() < items[j].(paginator.IDGetter).GetID()
+			return less != args.Reverse
+		}
+		less := a.GetCreateIndex() < b.GetCreateIndex()
+		return less != args.Reverse
+	})
+
+	iter := state.NewSliceIterator()
+	for _, item := range items {
+		iter.Add(item)
+	}
+	tokenizer := paginator.NewStructsTokenizer(iter, paginator.StructsTokenizerOptions{
+		WithCreateIndex: true,
+		WithID:          true,
+	})
+	window := paginator.GenericFilter{
+		Allow: func(raw interface{}) (bool, error) {
+			index := raw.(paginator.CreateIndexGetter).GetCreateIndex()
+			return (args.FromIndex == 0 || index >= args.FromIndex) &&
+				(args.ToIndex == 0 || index <= args.ToIndex), nil
+		},
+	}
+
+	pager, err := paginator.NewPaginator(iter, tokenizer, []paginator.Filter{window}, args.QueryOptions, appendFn)
+	if err != nil {
+		return "", structs.NewErrRPCCodedf(
+			http.StatusBadRequest, "failed to create result paginator: %v", err)
+	}
+	nextToken, err := pager.Page()
+	if err != nil {
+		return "", structs.NewErrRPCCodedf(
+			http.StatusBadRequest, "failed to read result page: %v", err)
+	}
+	return nextToken, nil
+}
+
+// PlanResults is used to list the most recent plan apply results of a job
+func (j *Job) PlanResults(args *structs.JobSpecificRequest,
+	reply *structs.JobPlanResultsResponse) error {
+	if done, err := j.srv.forward("Job.PlanResults", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "plan_results"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			results, index, err := state.PlanResultsByJob(ws, args.RequestNamespace(), args.JobID)
+			if err != nil {
+				return err
+			}
+			reply.PlanResults = results
+
+			// Use the last index that affected the plan_result table if the
+			// job has no plan results
+			if index == 0 {
+				index, err = state.Index("plan_result")
+				if err != nil {
+					return err
+				}
+			}
+			reply.Index = helper.Uint64Max(1, index)
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+
+	return j.srv.blockingRPC(&opts)
+}
+
 // Deployments is used to list the deployments for a job
 func (j *Job) Deployments(args *structs.JobSpecificRequest,
 	reply *structs.DeploymentListResponse) error {
@@ -1575,13 +2474,26 @@ func (j *Job) Deployments(args *structs.JobSpecificRequest,
 				return err
 			}
 
+			items := make([]interface{}, len(deploys))
+			for i, deploy := range deploys {
+				items[i] = deploy
+			}
+			reply.Deployments = nil
+			nextToken, err := jobListPage(args, items, func(raw interface{}) error {
+				reply.Deployments = append(reply.Deployments, raw.(*structs.Deployment))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			reply.QueryMeta.NextToken = nextToken
+
 			// Use the last index that affected the deployment table
 			index, err := state.Index("deployment")
 			if err != nil {
 				return err
 			}
 			reply.Index = index
-			reply.Deployments = deploys
 
 			// Set the query response
 			j.srv.setQueryMeta(&reply.QueryMeta)
@@ -1944,6 +2856,7 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	dispatchJob.Status = ""
 	dispatchJob.StatusDescription = ""
 	dispatchJob.DispatchIdempotencyToken = args.IdempotencyToken
+	dispatchJob.VersionTag = nil
 
 	// Merge in the meta data
 	for k, v := range args.Meta {
@@ -89,6 +89,17 @@ func connectGatewayVersionConstraint() *structs.Constraint {
 	}
 }
 
+// connectAPIGatewayVersionConstraint is used when building a connect API
+// gateway task to ensure a Consul version is used that supports Configuration
+// Entries of type api-gateway.
+func connectAPIGatewayVersionConstraint() *structs.Constraint {
+	return &structs.Constraint{
+		LTarget: "${attr.consul.version}",
+		RTarget: ">= 1.16.0",
+		Operand: structs.ConstraintSemver,
+	}
+}
+
 func connectListenerConstraint() *structs.Constraint {
 	return &structs.Constraint{
 		LTarget: "${attr.consul.grpc}",
@@ -159,6 +170,8 @@ func hasGatewayTaskForService(tg *structs.TaskGroup, service string) bool {
 			return true
 		case isMeshGatewayForService(t, service):
 			return true
+		case isAPIGatewayForService(t, service):
+			return true
 		}
 	}
 	return false
@@ -176,6 +189,10 @@ func isMeshGatewayForService(t *structs.Task, svc string) bool {
 	return t.Kind == structs.NewTaskKind(structs.ConnectMeshPrefix, svc)
 }
 
+func isAPIGatewayForService(t *structs.Task, svc string) bool {
+	return t.Kind == structs.NewTaskKind(structs.ConnectAPIGatewayPrefix, svc)
+}
+
 // getNamedTaskForNativeService retrieves the Task with the name specified in the
 // group service definition. If the task name is empty and there is only one task
 // in the group, infer the name from the only option.
@@ -435,6 +452,11 @@ func gatewayBindAddressesIngressForBridge(ingress *structs.ConsulIngressConfigEn
 }
 
 func newConnectGatewayTask(prefix, service string, netHost bool) *structs.Task {
+	versionConstraint := connectGatewayVersionConstraint()
+	if prefix == structs.ConnectAPIGatewayPrefix {
+		versionConstraint = connectAPIGatewayVersionConstraint()
+	}
+
 	return &structs.Task{
 		// Name is used in container name so must start with '[A-Za-z0-9]'
 		Name:          fmt.Sprintf("%s-%s", prefix, service),
@@ -448,7 +470,7 @@ func newConnectGatewayTask(prefix, service string, netHost bool) *structs.Task {
 		},
 		Resources: connectSidecarResources(),
 		Constraints: structs.Constraints{
-			connectGatewayVersionConstraint(),
+			versionConstraint,
 			connectListenerConstraint(),
 		},
 	}
@@ -627,6 +627,15 @@ func TestJobEndpointConnect_newConnectGatewayTask_host(t *testing.T) {
 		require.Equal(t, "host", task.Config["network_mode"])
 		require.Nil(t, task.Lifecycle)
 	})
+
+	t.Run("api gateway", func(t *testing.T) {
+		task := newConnectGatewayTask(structs.ConnectAPIGatewayPrefix, "baz", true)
+		require.Equal(t, "connect-api-gateway-baz", task.Name)
+		require.Equal(t, "connect-api-gateway:baz", string(task.Kind))
+		require.Equal(t, ">= 1.16.0", task.Constraints[0].RTarget)
+		require.Equal(t, "host", task.Config["network_mode"])
+		require.Nil(t, task.Lifecycle)
+	})
 }
 
 func TestJobEndpointConnect_newConnectGatewayTask_bridge(t *testing.T) {
@@ -682,6 +691,17 @@ func TestJobEndpointConnect_hasGatewayTaskForService(t *testing.T) {
 		}, "my-service")
 		require.True(t, result)
 	})
+
+	t.Run("has api gateway task", func(t *testing.T) {
+		result := hasGatewayTaskForService(&structs.TaskGroup{
+			Name: "group",
+			Tasks: []*structs.Task{{
+				Name: "api-gateway-my-service",
+				Kind: structs.NewTaskKind(structs.ConnectAPIGatewayPrefix, "my-service"),
+			}},
+		}, "my-service")
+		require.True(t, result)
+	})
 }
 
 func TestJobEndpointConnect_gatewayProxyIsDefault(t *testing.T) {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/hashicorp/raft"
 	"github.com/kr/pretty"
@@ -201,6 +203,171 @@ func TestJobEndpoint_Register_EvalPriority(t *testing.T) {
 	requireAssert.Equal(99, out[0].Priority)
 }
 
+func TestJobEndpoint_Register_IdempotencyToken(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register the job enforcing it is new, as a CI pipeline would
+	job := mock.Job()
+	req := &structs.JobRegisterRequest{
+		Job:          job,
+		EnforceIndex: true,
+		WriteRequest: structs.WriteRequest{
+			Region:           "global",
+			Namespace:        job.Namespace,
+			IdempotencyToken: "ci-run-1",
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	require.NotEmpty(t, resp.EvalID)
+
+	// Retrying the registration returns the original result rather than
+	// failing to enforce the index or creating another evaluation
+	job2 := job.Copy()
+	job2.Meta["retry"] = "true"
+	req.Job = job2
+	var retryResp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &retryResp))
+	require.Equal(t, resp.EvalID, retryResp.EvalID)
+	require.Equal(t, resp.EvalCreateIndex, retryResp.EvalCreateIndex)
+	require.Equal(t, resp.JobModifyIndex, retryResp.JobModifyIndex)
+
+	state := s1.fsm.State()
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), out.Version)
+	require.Equal(t, "ci-run-1", out.RegisterIdempotencyToken)
+
+	evals, err := state.EvalsByJob(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Len(t, evals, 1)
+
+	// A registration with another token updates the job
+	req.EnforceIndex = false
+	req.IdempotencyToken = "ci-run-2"
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	require.NotEqual(t, retryResp.EvalID, resp.EvalID)
+
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), out.Version)
+	require.Equal(t, "ci-run-2", out.RegisterIdempotencyToken)
+
+	// Tokens are only honored within the TTL
+	expired := mock.Job()
+	expired.RegisterIdempotencyToken = "ci-run-3"
+	expired.SubmitTime = time.Now().Add(-structs.JobRegisterIdempotencyTTL - time.Minute).UnixNano()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, out.ModifyIndex+1, expired))
+
+	req.Job = expired.Copy()
+	req.Job.Meta["retry"] = "true"
+	req.IdempotencyToken = "ci-run-3"
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	out, err = state.JobByID(nil, expired.Namespace, expired.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), out.Version)
+}
+
+func TestJobEndpoint_Register_IdempotencyToken_Concurrent(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Apply retries of the same registration concurrently, as retries racing
+	// each other past the checks done by the endpoint would
+	job := mock.Job()
+	now := time.Now().UnixNano()
+	const retries = 10
+	errCh := make(chan error, retries)
+	start := make(chan struct{})
+	for i := 0; i < retries; i++ {
+		retry := job.Copy()
+		retry.Meta["retry"] = strconv.Itoa(i)
+		retry.SubmitTime = now
+		retry.RegisterIdempotencyToken = "ci-run-1"
+		req := &structs.JobRegisterRequest{
+			Job: retry,
+			Eval: &structs.Evaluation{
+				ID:          uuid.Generate(),
+				Namespace:   job.Namespace,
+				Priority:    job.Priority,
+				Type:        job.Type,
+				TriggeredBy: structs.EvalTriggerJobRegister,
+				JobID:       job.ID,
+				Status:      structs.EvalStatusPending,
+			},
+			WriteRequest: structs.WriteRequest{
+				Region:           "global",
+				Namespace:        job.Namespace,
+				IdempotencyToken: "ci-run-1",
+			},
+		}
+		go func() {
+			<-start
+			fsmErr, _, err := s1.raftApply(structs.JobRegisterRequestType, req)
+			if err == nil {
+				err, _ = fsmErr.(error)
+			}
+			errCh <- err
+		}()
+	}
+	close(start)
+
+	// Ensure the job was registered once
+	var idempotentErrs []*structs.JobRegisterIdempotentError
+	for i := 0; i < retries; i++ {
+		err := <-errCh
+		if err == nil {
+			continue
+		}
+		idempotentErr, ok := err.(*structs.JobRegisterIdempotentError)
+		require.True(t, ok, "unexpected error: %v", err)
+		idempotentErrs = append(idempotentErrs, idempotentErr)
+	}
+	require.Len(t, idempotentErrs, retries-1)
+
+	state := s1.fsm.State()
+	versions, err := state.JobVersionsByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	for _, err := range idempotentErrs {
+		require.Equal(t, versions[0].JobModifyIndex, err.JobModifyIndex)
+	}
+
+	evals, err := state.EvalsByJob(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Len(t, evals, 1)
+
+	// Another retry through the endpoint gets the original result
+	retry := job.Copy()
+	retry.Meta["retry"] = "endpoint"
+	req := &structs.JobRegisterRequest{
+		Job: retry,
+		WriteRequest: structs.WriteRequest{
+			Region:           "global",
+			Namespace:        job.Namespace,
+			IdempotencyToken: "ci-run-1",
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	require.Equal(t, versions[0].JobModifyIndex, resp.JobModifyIndex)
+	require.Equal(t, evals[0].ID, resp.EvalID)
+}
+
 func TestJobEndpoint_Register_Connect(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -734,6 +901,163 @@ func TestJobEndpoint_Register_Connect_ValidatesWithoutSidecarTask(t *testing.T)
 	require.Contains(t, err.Error(), "exposed_no_sidecar requires use of sidecar_proxy")
 }
 
+func TestJobEndpoint_RegisterBundle(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job1 := mock.Job()
+	job2 := mock.Job()
+	periodic := mock.PeriodicJob()
+	req := &structs.JobBundleRegisterRequest{
+		Jobs: []*structs.Job{job1, job2, periodic},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+
+	var resp structs.JobBundleRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.RegisterBundle", req, &resp))
+	require.NotEmpty(t, resp.BundleID)
+	require.NotZero(t, resp.Index)
+
+	// All jobs were registered at the same index with the bundle ID
+	for _, job := range []*structs.Job{job1, job2, periodic} {
+		out, err := state.JobByID(nil, job.Namespace, job.ID)
+		require.NoError(t, err)
+		require.NotNil(t, out)
+		require.Equal(t, resp.Index, out.JobModifyIndex)
+		require.Equal(t, resp.BundleID, out.BundleID)
+	}
+
+	// Evaluations were created for the non-periodic jobs only
+	require.Len(t, resp.JobEvals, 2)
+	for _, job := range []*structs.Job{job1, job2} {
+		eval, err := state.EvalByID(nil, resp.JobEvals[job.ID])
+		require.NoError(t, err)
+		require.NotNil(t, eval)
+		require.Equal(t, job.ID, eval.JobID)
+		require.Equal(t, resp.Index, eval.JobModifyIndex)
+	}
+	_, ok := s1.periodicDispatcher.tracked[periodic.NamespacedID()]
+	require.True(t, ok)
+
+	// Registering the bundle again with one changed job only registers a
+	// new version of the changed job
+	job1 = job1.Copy()
+	job1.Priority = 90
+	req.Jobs = []*structs.Job{job1, job2.Copy()}
+	req.BundleID = "bundle-2"
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.RegisterBundle", req, &resp))
+	require.Equal(t, "bundle-2", resp.BundleID)
+
+	out, err := state.JobByID(nil, job1.Namespace, job1.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), out.Version)
+	require.Equal(t, "bundle-2", out.BundleID)
+
+	out, err = state.JobByID(nil, job2.Namespace, job2.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), out.Version)
+	require.Len(t, resp.JobEvals, 2)
+}
+
+func TestJobEndpoint_RegisterBundle_AllOrNothing(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	valid := mock.Job()
+	invalid := mock.Job()
+	invalid.TaskGroups[0].Count = -1
+
+	req := &structs.JobBundleRegisterRequest{
+		Jobs: []*structs.Job{valid, invalid},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+
+	// An invalid job fails the whole bundle
+	var resp structs.JobBundleRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.RegisterBundle", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), invalid.ID)
+
+	out, err := state.JobByID(nil, valid.Namespace, valid.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	// Jobs can't be included twice
+	req.Jobs = []*structs.Job{valid, valid.Copy()}
+	err = msgpackrpc.CallWithCodec(codec, "Job.RegisterBundle", req, &resp)
+	require.EqualError(t, err, fmt.Sprintf("job %q is included more than once in the bundle", valid.ID))
+
+	// Jobs must be in the namespace of the request
+	other := mock.Job()
+	other.Namespace = "other"
+	req.Jobs = []*structs.Job{valid, other}
+	err = msgpackrpc.CallWithCodec(codec, "Job.RegisterBundle", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "mismatched request namespace")
+
+	out, err = state.JobByID(nil, valid.Namespace, valid.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestJobEndpoint_RegisterBundle_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	req := &structs.JobBundleRegisterRequest{
+		Jobs: []*structs.Job{mock.Job(), mock.Job()},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+
+	// Expect failure without a token or with an invalid token
+	var resp structs.JobBundleRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.RegisterBundle", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), structs.ErrPermissionDenied.Error())
+
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1003, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	req.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.RegisterBundle", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), structs.ErrPermissionDenied.Error())
+
+	// Expect success with a management token
+	req.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.RegisterBundle", req, &resp))
+	require.Len(t, resp.JobEvals, 2)
+}
+
 func TestJobEndpoint_Register_ACL(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1226,6 +1550,82 @@ func TestJobEndpoint_Register_Dispatched(t *testing.T) {
 	require.Contains(err.Error(), "job can't be submitted with 'Dispatched'")
 }
 
+func TestJobEndpoint_Register_Async(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	submitToken := mock.CreatePolicyAndToken(t, s1.State(), 1001, "test-submit-job",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+
+	job := mock.Job()
+	req := &structs.JobRegisterRequest{
+		Job:   job,
+		Async: true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Enqueueing requires the submit-job capability
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = submitToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	require.NotEmpty(t, resp.SubmissionID)
+	require.Empty(t, resp.EvalID)
+
+	// Poll for the result of the registration
+	subReq := &structs.JobRegisterSubmissionRequest{
+		SubmissionID: resp.SubmissionID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var subResp structs.JobRegisterSubmissionResponse
+	testutil.WaitForResult(func() (bool, error) {
+		if err := msgpackrpc.CallWithCodec(codec, "Job.GetRegisterSubmission", subReq, &subResp); err != nil {
+			return false, err
+		}
+		if !subResp.Submission.Terminal() {
+			return false, fmt.Errorf("submission is %s", subResp.Submission.Status)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	sub := subResp.Submission
+	require.Equal(t, structs.JobRegisterSubmissionStatusComplete, sub.Status, sub.Error)
+	require.Equal(t, job.ID, sub.JobID)
+	require.NotEmpty(t, sub.EvalID)
+
+	out, err := s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, out.JobModifyIndex, sub.JobModifyIndex)
+
+	// Reading the submission requires the read-job capability
+	subReq.AuthToken = submitToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.GetRegisterSubmission", subReq, &subResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	subReq.AuthToken = root.SecretID
+	subReq.SubmissionID = uuid.Generate()
+	err = msgpackrpc.CallWithCodec(codec, "Job.GetRegisterSubmission", subReq, &subResp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not found")
+}
+
 func TestJobEndpoint_Register_EnforceIndex(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1449,6 +1849,144 @@ func TestJobEndpoint_Register_Vault_AllowUnauthenticated(t *testing.T) {
 	}
 }
 
+// TestJobEndpoint_Register_Vault_Clusters asserts that the Vault tokens of the
+// named Vault clusters are checked against the policies requested from them.
+func TestJobEndpoint_Register_Vault_Clusters(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Enable vault and configure the named clusters
+	tr, f := true, false
+	s1.config.VaultConfig.Enabled = &tr
+	s1.config.VaultConfig.AllowUnauthenticated = &tr
+	s1.config.VaultConfig.Clusters = []*config.VaultClusterConfig{
+		{Name: "team"},
+		{Name: "locked", VaultConfig: config.VaultConfig{AllowUnauthenticated: &f}},
+	}
+	s1.vault = &TestVaultClient{}
+	locked := &TestVaultClient{}
+	locked.SetLookupTokenAllowedPolicies("allowed", []string{"bar"})
+	locked.SetLookupTokenAllowedPolicies("denied", []string{"baz"})
+	locked.SetLookupTokenAllowedPolicies("root", []string{"root"})
+	s1.vaultClusters = map[string]VaultClient{"locked": locked}
+
+	register := func(cluster, token string) error {
+		job := mock.Job()
+		job.TaskGroups[0].Tasks[0].Vault = &structs.Vault{
+			Policies:   []string{"foo"},
+			ChangeMode: structs.VaultChangeModeRestart,
+			Clusters: []*structs.VaultCluster{
+				{Name: cluster, Policies: []string{"bar"}},
+			},
+		}
+		if token != "" {
+			job.VaultClusterTokens = map[string]string{cluster: token}
+		}
+		req := &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		if err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp); err != nil {
+			return err
+		}
+
+		// The tokens are not stored
+		stored, err := s1.State().JobByID(nil, job.Namespace, job.ID)
+		require.NoError(err)
+		require.Empty(stored.VaultClusterTokens)
+		return nil
+	}
+
+	require.NoError(register("team", ""))
+
+	err := register("unknown", "")
+	require.Error(err)
+	require.Contains(err.Error(), `Vault cluster "unknown" is not configured`)
+
+	// Clusters that don't allow unauthenticated access require a token with
+	// access to the requested policies
+	err = register("locked", "")
+	require.Error(err)
+	require.Contains(err.Error(), `Vault cluster "locked" policies requested but missing Vault Token`)
+
+	err = register("locked", "denied")
+	require.Error(err)
+	require.Contains(err.Error(), `Passed Vault Token for cluster "locked" doesn't allow access to the following policies: bar`)
+
+	require.NoError(register("locked", "allowed"))
+	require.NoError(register("locked", "root"))
+}
+
+func TestJobEndpoint_Register_WorkloadIdentities(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	register := func(ttl time.Duration) error {
+		job := mock.Job()
+		job.TaskGroups[0].Tasks[0].Identities = []*structs.WorkloadIdentity{{
+			Name:     "aws",
+			Audience: []string{"sts.amazonaws.com"},
+			TTL:      ttl,
+			File:     true,
+		}}
+		req := &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		return msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	}
+
+	// Identities can't be requested without workload identities configured
+	err := register(time.Hour)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "workload identities are not configured")
+
+	signer, err := newIdentitySigner(&structs.WorkloadIdentityConfig{
+		Issuer:         "https://nomad.example.com",
+		SigningKeyFile: testIdentitySigningKey(t),
+	}, s1.State)
+	require.NoError(t, err)
+	s1.identitySigner = signer
+
+	require.NoError(t, register(time.Hour))
+	require.NoError(t, register(48*time.Hour))
+
+	// Identities signed by generated keys must expire before the retired
+	// keys are removed
+	signer, err = newIdentitySigner(&structs.WorkloadIdentityConfig{
+		Issuer:           "https://nomad.example.com",
+		RotationInterval: 24 * time.Hour,
+	}, s1.State)
+	require.NoError(t, err)
+	s1.identitySigner = signer
+
+	require.NoError(t, register(time.Hour))
+	err = register(48 * time.Hour)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "TTL longer than the key rotation interval")
+}
+
 // TestJobEndpoint_Register_Vault_OverrideConstraint asserts that job
 // submitters can specify their own Vault constraint to override the
 // automatically injected one.
@@ -2854,6 +3392,288 @@ func TestJobEndpoint_Stable(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_TagVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register the job twice to get two versions
+	job := mock.Job()
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	job2 := job.Copy()
+	job2.Priority = 99
+	req.Job = job2
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	// Tag the first version
+	tagReq := &structs.JobVersionTagRequest{
+		JobID:      job.ID,
+		JobVersion: 0,
+		Tag:        &structs.JobVersionTag{Name: "golden", Description: "known good"},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var tagResp structs.JobVersionTagResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp))
+	require.NotZero(t, tagResp.Index)
+
+	state := s1.fsm.State()
+	out, err := state.JobByIDAndVersion(nil, job.Namespace, job.ID, 0)
+	require.NoError(t, err)
+	require.Equal(t, "golden", out.VersionTag.Name)
+	require.Equal(t, "known good", out.VersionTag.Description)
+	require.NotZero(t, out.VersionTag.TaggedTime)
+
+	// The tag name can't be used by another version
+	tagReq.JobVersion = 1
+	err = msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp)
+	require.EqualError(t, err, fmt.Sprintf("version tag %q is already used by version 0 of job %q", "golden", job.ID))
+
+	// Numeric tag names are rejected
+	tagReq.Tag = &structs.JobVersionTag{Name: "3"}
+	err = msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp)
+	require.EqualError(t, err, `version tag name "3" must not be a number`)
+
+	// Missing versions are rejected
+	tagReq.JobVersion = 10
+	tagReq.Tag = &structs.JobVersionTag{Name: "missing"}
+	err = msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not found")
+
+	// Reverting to the tagged version doesn't carry over the tag
+	revertReq := &structs.JobRevertRequest{
+		JobID:      job.ID,
+		JobVersion: 0,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Revert", revertReq, &resp))
+	current, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), current.Version)
+	require.Nil(t, current.VersionTag)
+
+	// Remove the tag
+	tagReq.JobVersion = 0
+	tagReq.Tag = nil
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &tagResp))
+	out, err = state.JobByIDAndVersion(nil, job.Namespace, job.ID, 0)
+	require.NoError(t, err)
+	require.Nil(t, out.VersionTag)
+}
+
+func TestJobEndpoint_TagVersion_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	state := s1.fsm.State()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	tagReq := &structs.JobVersionTagRequest{
+		JobID: job.ID,
+		Tag:   &structs.JobVersionTag{Name: "golden"},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Expect failure without a token or with an invalid token
+	var resp structs.JobVersionTagResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1003, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	tagReq.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Expect success with a submit-job token or a management token
+	validToken := mock.CreatePolicyAndToken(t, state, 1005, "test-valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+	tagReq.AuthToken = validToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &resp))
+
+	tagReq.AuthToken = root.SecretID
+	tagReq.Tag = nil
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.TagVersion", tagReq, &resp))
+}
+
+func TestJobEndpoint_Move(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	state := s1.fsm.State()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	ns := mock.Namespace()
+	require.NoError(t, state.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	job := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, job))
+
+	moveReq := &structs.JobMoveRequest{
+		JobID: job.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Moving requires a new namespace or ID
+	var resp structs.JobMoveResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Move", moveReq, &resp)
+	require.EqualError(t, err, "job move requires a new namespace or job ID")
+
+	// Moving a missing job fails
+	moveReq.JobID = "missing"
+	moveReq.NewNamespace = ns.Name
+	err = msgpackrpc.CallWithCodec(codec, "Job.Move", moveReq, &resp)
+	require.EqualError(t, err, fmt.Sprintf("job %q in namespace %q not found", "missing", job.Namespace))
+
+	// The job is admitted to the new namespace like a registered job
+	restricted := mock.Namespace()
+	restricted.Capabilities = &structs.NamespaceCapabilities{DisabledTaskDrivers: []string{"exec"}}
+	require.NoError(t, state.UpsertNamespaces(1002, []*structs.Namespace{restricted}))
+	moveReq.JobID = job.ID
+	moveReq.NewNamespace = restricted.Name
+	err = msgpackrpc.CallWithCodec(codec, "Job.Move", moveReq, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), fmt.Sprintf("used task driver %q is not allowed in namespace %q", "exec", restricted.Name))
+
+	// Move the job to the new namespace, keeping its ID
+	moveReq.NewNamespace = ns.Name
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Move", moveReq, &resp))
+	require.NotZero(t, resp.Index)
+	require.NotEmpty(t, resp.EvalID)
+	require.Equal(t, job.JobModifyIndex, resp.JobModifyIndex)
+
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+	out, err = state.JobByID(nil, ns.Name, job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	eval, err := state.EvalByID(nil, resp.EvalID)
+	require.NoError(t, err)
+	require.NotNil(t, eval)
+	require.Equal(t, ns.Name, eval.Namespace)
+	require.Equal(t, job.ID, eval.JobID)
+	require.Equal(t, structs.EvalTriggerJobRegister, eval.TriggeredBy)
+	require.Equal(t, resp.EvalCreateIndex, eval.CreateIndex)
+
+	// Errors from the state store are returned, such as a job with a pending
+	// evaluation
+	moveReq.Namespace = ns.Name
+	moveReq.NewNamespace = ""
+	moveReq.NewJobID = "renamed"
+	err = msgpackrpc.CallWithCodec(codec, "Job.Move", moveReq, &resp)
+	require.EqualError(t, err, fmt.Sprintf("job has non-terminal evaluation %q", eval.ID))
+}
+
+func TestJobEndpoint_Move_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	state := s1.fsm.State()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	ns := mock.Namespace()
+	require.NoError(t, state.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	job := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, job))
+
+	moveReq := &structs.JobMoveRequest{
+		JobID:        job.ID,
+		NewNamespace: ns.Name,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Expect failure without a token
+	var resp structs.JobMoveResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Move", moveReq, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Expect failure with submit-job on the source namespace only
+	sourceToken := mock.CreatePolicyAndToken(t, state, 1003, "test-source",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}))
+	moveReq.AuthToken = sourceToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.Move", moveReq, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Expect failure with submit-job on the destination namespace only
+	destToken := mock.CreatePolicyAndToken(t, state, 1005, "test-dest",
+		mock.NamespacePolicy(ns.Name, "", []string{acl.NamespaceCapabilitySubmitJob}))
+	moveReq.AuthToken = destToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.Move", moveReq, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Expect success with submit-job on both namespaces
+	policy := mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilitySubmitJob}) +
+		mock.NamespacePolicy(ns.Name, "", []string{acl.NamespaceCapabilitySubmitJob})
+	validToken := mock.CreatePolicyAndToken(t, state, 1007, "test-valid", policy)
+	moveReq.AuthToken = validToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Move", moveReq, &resp))
+
+	// Expect success with a management token, moving the job back
+	moveReq.AuthToken = root.SecretID
+	moveReq.Namespace = ns.Name
+	moveReq.NewNamespace = structs.DefaultNamespace
+	moveReq.NewJobID = "renamed"
+
+	// Complete the evaluation of the first move so the job can move again
+	eval, err := state.EvalByID(nil, resp.EvalID)
+	require.NoError(t, err)
+	eval = eval.Copy()
+	eval.Status = structs.EvalStatusComplete
+	require.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 2000, []*structs.Evaluation{eval}))
+
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Move", moveReq, &resp))
+	out, err := state.JobByID(nil, structs.DefaultNamespace, "renamed")
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
 func TestJobEndpoint_Stable_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -4347,6 +5167,102 @@ func TestJobEndpoint_GetJob_Blocking(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_DiffVersions(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register three versions of the job
+	job := mock.Job()
+	reg := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	for _, priority := range []int{50, 60, 70} {
+		job.Priority = priority
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", reg, &resp))
+	}
+
+	// Diff the first and last versions
+	req := &structs.JobVersionsDiffRequest{
+		JobID:       job.ID,
+		FromVersion: 0,
+		ToVersion:   2,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var diffResp structs.JobVersionsDiffResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.DiffVersions", req, &diffResp))
+	require.Equal(t, resp.JobModifyIndex, diffResp.Index)
+	require.NotNil(t, diffResp.Diff)
+	require.Equal(t, structs.DiffTypeEdited, diffResp.Diff.Type)
+
+	var priority *structs.FieldDiff
+	for _, field := range diffResp.Diff.Fields {
+		if field.Name == "Priority" {
+			priority = field
+		}
+	}
+	require.NotNil(t, priority)
+	require.Equal(t, "50", priority.Old)
+	require.Equal(t, "70", priority.New)
+
+	// Missing versions have no diff
+	req.ToVersion = 3
+	diffResp = structs.JobVersionsDiffResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.DiffVersions", req, &diffResp))
+	require.Nil(t, diffResp.Diff)
+}
+
+func TestJobEndpoint_DiffVersions_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	state := s1.fsm.State()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	req := &structs.JobVersionsDiffRequest{
+		JobID:       job.ID,
+		FromVersion: 0,
+		ToVersion:   0,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Attempt to diff without a token
+	var resp structs.JobVersionsDiffResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.DiffVersions", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Diff with a valid token
+	token := mock.CreatePolicyAndToken(t, state, 1001, "test-valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	req.AuthToken = token.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.DiffVersions", req, &resp))
+	require.NotNil(t, resp.Diff)
+	require.Equal(t, structs.DiffTypeNone, resp.Diff.Type)
+
+	// Diff with the management token
+	req.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.DiffVersions", req, &resp))
+}
+
 func TestJobEndpoint_GetJobVersions(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5370,6 +6286,43 @@ func TestJobEndpoint_Allocations(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_Allocations_Filter(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	alloc1 := mock.Alloc()
+	alloc1.ClientStatus = structs.AllocClientStatusRunning
+	alloc2 := mock.Alloc()
+	alloc2.JobID = alloc1.JobID
+	alloc2.ClientStatus = structs.AllocClientStatusPending
+	state := s1.fsm.State()
+	require.NoError(t, state.UpsertJobSummary(998, mock.JobSummary(alloc1.JobID)))
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc1, alloc2}))
+
+	get := &structs.JobSpecificRequest{
+		JobID: alloc1.JobID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: alloc1.Job.Namespace,
+			Filter:    `ClientStatus == "running"`,
+		},
+	}
+	var resp structs.JobAllocationsResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Allocations", get, &resp))
+	require.Len(t, resp.Allocations, 1)
+	require.Equal(t, alloc1.ID, resp.Allocations[0].ID)
+
+	// Invalid filters are rejected
+	get.Filter = `ClientStatus ==`
+	err := msgpackrpc.CallWithCodec(codec, "Job.Allocations", get, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read filter expression")
+}
+
 func TestJobEndpoint_Allocations_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -5553,6 +6506,43 @@ func TestJobEndpoint_Evaluations(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_Evaluations_Pagination(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	var evals []*structs.Evaluation
+	for i := 0; i < 3; i++ {
+		eval := mock.Eval()
+		if i > 0 {
+			eval.JobID = evals[0].JobID
+		}
+		require.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, uint64(1000+i), []*structs.Evaluation{eval}))
+		evals = append(evals, eval)
+	}
+
+	// The newest evaluations within the window are listed first
+	get := &structs.JobSpecificRequest{
+		JobID:   evals[0].JobID,
+		ToIndex: 1001,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: evals[0].Namespace,
+			PerPage:   1,
+			Reverse:   true,
+		},
+	}
+	var resp structs.JobEvaluationsResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Evaluations", get, &resp))
+	require.Len(t, resp.Evaluations, 1)
+	require.Equal(t, evals[1].ID, resp.Evaluations[0].ID)
+	require.Equal(t, "1000."+evals[0].ID, resp.NextToken)
+}
+
 func TestJobEndpoint_Evaluations_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -5613,6 +6603,136 @@ func TestJobEndpoint_Evaluations_ACL(t *testing.T) {
 	require.Equal(2, len(validResp2.Evaluations))
 }
 
+func TestJobEndpoint_PlanResults(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	require.NoError(s1.fsm.State().UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	// Dequeue an eval to submit plans for
+	eval := mock.Eval()
+	require.NoError(s1.fsm.State().UpsertEvals(structs.MsgTypeTestSetup, 1001, []*structs.Evaluation{eval}))
+	s1.evalBroker.Enqueue(eval)
+	_, token, err := s1.evalBroker.Dequeue([]string{eval.Type}, time.Second)
+	require.NoError(err)
+
+	job := mock.Job()
+	submit := func(nodeIDs ...string) {
+		plan := &structs.Plan{
+			EvalID:         eval.ID,
+			EvalToken:      token,
+			Job:            job,
+			NodeAllocation: make(map[string][]*structs.Allocation),
+		}
+		for _, nodeID := range nodeIDs {
+			alloc := mock.Alloc()
+			alloc.NodeID = nodeID
+			alloc.Job = job
+			alloc.JobID = job.ID
+			plan.NodeAllocation[nodeID] = []*structs.Allocation{alloc}
+		}
+		req := &structs.PlanRequest{
+			Plan:         plan,
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.PlanResponse
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Plan.Submit", req, &resp))
+	}
+
+	get := &structs.JobSpecificRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// An entirely rejected plan isn't applied, so its result is held until
+	// the next plan is applied
+	missing1, missing2 := uuid.Generate(), uuid.Generate()
+	submit(missing1)
+
+	var resp structs.JobPlanResultsResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.PlanResults", get, &resp))
+	require.Empty(resp.PlanResults)
+
+	// A partially rejected plan is recorded along with the held result
+	submit(node.ID, missing2)
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.PlanResults", get, &resp))
+	require.Len(resp.PlanResults, 2)
+	require.Equal(resp.PlanResults[0].CreateIndex, resp.Index)
+
+	// Results are sorted from newest to oldest
+	latest := resp.PlanResults[0]
+	require.Equal(eval.ID, latest.EvalID)
+	require.Equal([]string{node.ID}, latest.AcceptedNodes)
+	require.Equal([]*structs.PlanRejectedNode{{NodeID: missing2, Reason: "node does not exist"}}, latest.RejectedNodes)
+	require.NotZero(latest.RefreshIndex)
+
+	previous := resp.PlanResults[1]
+	require.Empty(previous.AcceptedNodes)
+	require.Equal([]*structs.PlanRejectedNode{{NodeID: missing1, Reason: "node does not exist"}}, previous.RejectedNodes)
+	require.NotZero(previous.RefreshIndex)
+
+	// Jobs without plan results return an empty list
+	get.JobID = "unknown"
+	var emptyResp structs.JobPlanResultsResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.PlanResults", get, &emptyResp))
+	require.Empty(emptyResp.PlanResults)
+	require.NotZero(emptyResp.Index)
+}
+
+func TestJobEndpoint_PlanResults_ACL(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	get := &structs.JobSpecificRequest{
+		JobID: "example",
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+
+	// Attempt to fetch without providing a token
+	var resp structs.JobPlanResultsResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.PlanResults", get, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Attempt to fetch the response with an invalid token
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityListJobs}))
+
+	get.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Job.PlanResults", get, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Attempt to fetch with valid token should succeed
+	validToken := mock.CreatePolicyAndToken(t, state, 1003, "test-valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+
+	get.AuthToken = validToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.PlanResults", get, &resp))
+
+	// Attempt to fetch with valid management token should succeed
+	get.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.PlanResults", get, &resp))
+}
+
 func TestJobEndpoint_Evaluations_Blocking(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5706,6 +6826,77 @@ func TestJobEndpoint_Deployments(t *testing.T) {
 	require.Len(resp.Deployments, 2, "deployments for job")
 }
 
+func TestJobEndpoint_Deployments_Pagination(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	j := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, j))
+
+	var ids []string
+	for i := 0; i < 4; i++ {
+		d := mock.Deployment()
+		d.JobID = j.ID
+		d.JobCreateIndex = j.CreateIndex
+		require.NoError(t, state.UpsertDeployment(uint64(1001+i), d))
+		ids = append(ids, d.ID)
+	}
+
+	list := func(args *structs.JobSpecificRequest) ([]string, string) {
+		args.JobID = j.ID
+		args.Region = "global"
+		args.Namespace = j.Namespace
+		var resp structs.DeploymentListResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Deployments", args, &resp))
+		var out []string
+		for _, d := range resp.Deployments {
+			out = append(out, d.ID)
+		}
+		return out, resp.NextToken
+	}
+
+	// Deployments are listed by create index
+	out, token := list(&structs.JobSpecificRequest{})
+	require.Equal(t, ids, out)
+	require.Empty(t, token)
+
+	// The newest deployments are listed first in reverse, a page at a time
+	out, token = list(&structs.JobSpecificRequest{
+		QueryOptions: structs.QueryOptions{PerPage: 2, Reverse: true},
+	})
+	require.Equal(t, []string{ids[3], ids[2]}, out)
+	require.Equal(t, "1002."+ids[1], token)
+
+	out, token = list(&structs.JobSpecificRequest{
+		QueryOptions: structs.QueryOptions{PerPage: 2, Reverse: true, NextToken: token},
+	})
+	require.Equal(t, []string{ids[1], ids[0]}, out)
+	require.Empty(t, token)
+
+	// Only the deployments created within the window are listed
+	out, _ = list(&structs.JobSpecificRequest{FromIndex: 1002, ToIndex: 1003})
+	require.Equal(t, []string{ids[1], ids[2]}, out)
+
+	out, _ = list(&structs.JobSpecificRequest{FromIndex: 1003})
+	require.Equal(t, []string{ids[2], ids[3]}, out)
+
+	args := &structs.JobSpecificRequest{
+		JobID:        j.ID,
+		FromIndex:    1003,
+		ToIndex:      1002,
+		QueryOptions: structs.QueryOptions{Region: "global", Namespace: j.Namespace},
+	}
+	var resp structs.DeploymentListResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Deployments", args, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "from_index 1003 is greater than to_index 1002")
+}
+
 func TestJobEndpoint_Deployments_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -7571,6 +8762,247 @@ func TestJobEndpoint_Scale_OutOfBounds(t *testing.T) {
 	require.Contains(err.Error(), "group count was less than scaling policy minimum: 2 < 3")
 }
 
+func TestJobEndpoint_Scale_NamespaceJobLimits(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	ns := mock.Namespace()
+	ns.JobLimits = &structs.NamespaceJobLimits{MaxGroupCount: 5}
+	require.NoError(state.UpsertNamespaces(999, []*structs.Namespace{ns}))
+
+	job := mock.Job()
+	job.Namespace = ns.Name
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	var resp structs.JobRegisterResponse
+	scale := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: job.TaskGroups[0].Name,
+		},
+		Count:   helper.Int64ToPtr(6),
+		Message: "above the namespace limit",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	err := msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), fmt.Sprintf(
+		"group \"web\" of job %q exceeds limit max_group_count of namespace %q: 6 > 5", job.ID, ns.Name))
+
+	scale.Count = helper.Int64ToPtr(5)
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp))
+}
+
+func TestJobEndpoint_Scale_Resources(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	task := job.TaskGroups[0].Tasks[0]
+	originalCPU := task.Resources.CPU
+	originalMemoryMB := task.Resources.MemoryMB
+	err := state.UpsertJob(structs.MsgTypeTestSetup, 1000, job)
+	require.Nil(err)
+
+	groupName := job.TaskGroups[0].Name
+	scale := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: groupName,
+		},
+		Resources: map[string]*structs.ScalingResources{
+			task.Name: {CPU: originalCPU * 2},
+		},
+		Message: "because of the load",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	require.NoError(err)
+	require.NotEmpty(resp.EvalID)
+
+	// only the requested resource was updated
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal(originalCPU*2, out.TaskGroups[0].Tasks[0].Resources.CPU)
+	require.Equal(originalMemoryMB, out.TaskGroups[0].Tasks[0].Resources.MemoryMB)
+	require.Equal(job.TaskGroups[0].Count, out.TaskGroups[0].Count)
+
+	events, _, _ := state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.Len(events[groupName], 1)
+	require.Equal(scale.Resources, events[groupName][0].Resources)
+	require.Equal(map[string]*structs.ScalingResources{
+		task.Name: {CPU: originalCPU, MemoryMB: originalMemoryMB},
+	}, events[groupName][0].PreviousResources)
+
+	// unknown tasks are rejected
+	scale.Resources = map[string]*structs.ScalingResources{
+		"missing": {CPU: 100},
+	}
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), `task "missing" specified for scaling does not exist`)
+}
+
+func TestJobEndpoint_Scale_Scheduled(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	originalCount := job.TaskGroups[0].Count
+	err := state.UpsertJob(structs.MsgTypeTestSetup, 1000, job)
+	require.Nil(err)
+
+	groupName := job.TaskGroups[0].Name
+	scale := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: groupName,
+		},
+		Count:   helper.Int64ToPtr(int64(originalCount + 1)),
+		Message: "planned traffic",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// times in the past are rejected
+	scale.ScheduleAt = time.Now().Add(-time.Minute)
+	var resp structs.JobRegisterResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "is not in the future")
+
+	scale.ScheduleAt = time.Now().Add(time.Second)
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	require.NoError(err)
+	require.Empty(resp.EvalID)
+
+	// the count change is only stored until it is due
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal(originalCount, out.TaskGroups[0].Count)
+
+	iter, err := state.ScheduledScalingActions(nil)
+	require.NoError(err)
+	action := iter.Next().(*structs.ScheduledScalingAction)
+	require.Equal(job.ID, action.JobID)
+	require.Equal(groupName, action.TaskGroup)
+	require.Equal(int64(originalCount+1), action.Count)
+
+	// the leader applies the change and emits a scaling event
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := state.JobByID(nil, job.Namespace, job.ID)
+		if err != nil {
+			return false, err
+		}
+		if out.TaskGroups[0].Count != originalCount+1 {
+			return false, fmt.Errorf("expected count %d, got %d", originalCount+1, out.TaskGroups[0].Count)
+		}
+		pending, err := state.ScheduledScalingActionByID(nil, action.ID)
+		if err != nil {
+			return false, err
+		}
+		if pending != nil {
+			return false, fmt.Errorf("scheduled scaling action was not removed")
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(err)
+	})
+
+	events, _, _ := state.ScalingEventsByJob(nil, job.Namespace, job.ID)
+	require.Len(events[groupName], 1)
+	require.Equal("planned traffic", events[groupName][0].Message)
+	require.Equal(int64(originalCount), events[groupName][0].PreviousCount)
+	require.NotNil(events[groupName][0].EvalID)
+}
+
+func TestJobEndpoint_Scale_Resources_OutOfBounds(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	task := job.TaskGroups[0].Tasks[0]
+	task.Resources.MemoryMaxMB = 1024
+	task.ScalingPolicies = []*structs.ScalingPolicy{{
+		ID:   uuid.Generate(),
+		Type: structs.ScalingPolicyTypeVerticalCPU,
+		Min:  100,
+		Max:  1000,
+		Target: map[string]string{
+			structs.ScalingTargetNamespace: job.Namespace,
+			structs.ScalingTargetJob:       job.ID,
+			structs.ScalingTargetGroup:     job.TaskGroups[0].Name,
+			structs.ScalingTargetTask:      task.Name,
+		},
+		Enabled: true,
+	}}
+
+	err := state.UpsertJob(structs.MsgTypeTestSetup, 1000, job)
+	require.Nil(err)
+
+	var resp structs.JobRegisterResponse
+	scale := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: job.TaskGroups[0].Name,
+		},
+		Resources: map[string]*structs.ScalingResources{
+			task.Name: {CPU: 1001},
+		},
+		Message: "out of bounds",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), `task "web" cpu was greater than scaling policy maximum: 1001 > 1000`)
+
+	scale.Resources[task.Name].CPU = 50
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), `task "web" cpu was less than scaling policy minimum: 50 < 100`)
+
+	scale.Resources[task.Name] = &structs.ScalingResources{MemoryMB: 2048}
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), `task "web" memory was greater than memory_max: 2048 > 1024`)
+}
+
 func TestJobEndpoint_Scale_NoEval(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
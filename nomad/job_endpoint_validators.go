@@ -1,6 +1,9 @@
 package nomad
 
 import (
+	"bytes"
+
+	"github.com/hashicorp/go-msgpack/codec"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/pkg/errors"
 )
@@ -41,6 +44,17 @@ func (c jobNamespaceConstraintCheckHook) Validate(job *structs.Job) (warnings []
 				"used task drivers %q are not allowed in namespace %q", disallowedDrivers, ns.Name)
 		}
 	}
+
+	if ns.JobLimits != nil {
+		// The size is checked against the job as it would be written to raft
+		var buf bytes.Buffer
+		if err := codec.NewEncoder(&buf, structs.MsgpackHandle).Encode(job); err != nil {
+			return nil, err
+		}
+		if err := ns.CheckJobLimits(job, buf.Len()); err != nil {
+			return nil, err
+		}
+	}
 	return nil, nil
 }
 
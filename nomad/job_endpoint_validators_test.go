@@ -1,8 +1,10 @@
 package nomad
 
 import (
+	"fmt"
 	"testing"
 
+	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -116,3 +118,53 @@ func TestJobNamespaceConstraintCheckHook_validate(t *testing.T) {
 	_, err = hook.Validate(job)
 	require.Equal(t, err.Error(), "used task drivers [\"exec\" \"raw_exec\"] are not allowed in namespace \"default\"")
 }
+
+func TestJobNamespaceConstraintCheckHook_jobLimits(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	ns := mock.Namespace()
+	ns.JobLimits = &structs.NamespaceJobLimits{
+		MaxGroups:        2,
+		MaxTasksPerGroup: 1,
+		MaxGroupCount:    10,
+	}
+	require.NoError(t, s1.fsm.State().UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	hook := jobNamespaceConstraintCheckHook{srv: s1}
+	job := mock.Job()
+	job.Namespace = ns.Name
+	_, err := hook.Validate(job)
+	require.NoError(t, err)
+
+	// Every exceeded limit is reported
+	job.TaskGroups[0].Count = 11
+	job.TaskGroups[0].Tasks = append(job.TaskGroups[0].Tasks, job.TaskGroups[0].Tasks[0].Copy())
+	_, err = hook.Validate(job)
+	require.Error(t, err)
+
+	var limitErrs []*structs.JobLimitError
+	for _, err := range err.(*multierror.Error).Errors {
+		limitErr, ok := err.(*structs.JobLimitError)
+		require.True(t, ok, "unexpected error %v", err)
+		limitErrs = append(limitErrs, limitErr)
+	}
+	require.Len(t, limitErrs, 2)
+	require.Equal(t, structs.JobLimitMaxTasksPerGroup, limitErrs[0].Limit)
+	require.Equal(t, 2, limitErrs[0].Actual)
+	require.Equal(t, structs.JobLimitMaxGroupCount, limitErrs[1].Limit)
+	require.Equal(t, "web", limitErrs[1].Group)
+	require.Contains(t, err.Error(), fmt.Sprintf(
+		`group "web" of job %q exceeds limit max_group_count of namespace %q: 11 > 10`, job.ID, ns.Name))
+
+	// The size of the job is checked once encoded
+	job = mock.Job()
+	job.Namespace = ns.Name
+	ns.JobLimits = &structs.NamespaceJobLimits{MaxJobSizeBytes: 1024}
+	require.NoError(t, s1.fsm.State().UpsertNamespaces(1001, []*structs.Namespace{ns}))
+	_, err = hook.Validate(job)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exceeds limit max_job_size_bytes")
+}
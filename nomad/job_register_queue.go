@@ -0,0 +1,242 @@
+package nomad
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// jobRegisterSubmissionRetention is how long the status of complete and
+	// failed submissions can be read.
+	jobRegisterSubmissionRetention = time.Hour
+)
+
+var (
+	// errJobRegisterQueueDisabled is returned when enqueueing a registration
+	// on a server which isn't the leader.
+	errJobRegisterQueueDisabled = errors.New("job register queue is disabled")
+)
+
+// registerFn applies a job registration.
+type registerFn func(*structs.JobRegisterRequest, *structs.JobRegisterResponse) error
+
+// jobRegisterQueue is used by the leader to apply asynchronous job
+// registrations one at a time, in the order they were submitted. This smooths
+// out bursts of registrations, such as CI systems deploying many jobs at
+// once. The number of registrations queued in each namespace is limited.
+//
+// The queue and the status of the submissions are only held in memory by the
+// leader, so they are lost when the leadership changes.
+type jobRegisterQueue struct {
+	logger   log.Logger
+	register registerFn
+
+	// limit is the maximum number of pending and running registrations in
+	// each namespace.
+	limit int
+
+	// retention is how long terminal submissions are kept.
+	retention time.Duration
+
+	enabled bool
+	stopCh  chan struct{}
+
+	ready   []*pendingRegistration
+	readyCh chan struct{}
+
+	// pending is the number of pending and running registrations by
+	// namespace.
+	pending map[string]int
+
+	// submissions are the submissions by ID.
+	submissions map[string]*structs.JobRegisterSubmission
+
+	l sync.Mutex
+}
+
+// pendingRegistration is a registration waiting to be applied.
+type pendingRegistration struct {
+	args       *structs.JobRegisterRequest
+	submission *structs.JobRegisterSubmission
+}
+
+// newJobRegisterQueue returns a disabled queue applying the registrations
+// with the register function.
+func newJobRegisterQueue(logger log.Logger, limit int, register registerFn) *jobRegisterQueue {
+	return &jobRegisterQueue{
+		logger:      logger.Named("job_register_queue"),
+		register:    register,
+		limit:       limit,
+		retention:   jobRegisterSubmissionRetention,
+		readyCh:     make(chan struct{}, 1),
+		pending:     make(map[string]int),
+		submissions: make(map[string]*structs.JobRegisterSubmission),
+	}
+}
+
+// SetEnabled is used to enable or disable the queue. Disabling the queue
+// drops the pending registrations and the status of all the submissions.
+func (q *jobRegisterQueue) SetEnabled(enabled bool) {
+	q.l.Lock()
+	defer q.l.Unlock()
+
+	if enabled == q.enabled {
+		return
+	}
+	q.enabled = enabled
+
+	if enabled {
+		q.stopCh = make(chan struct{})
+		go q.run(q.stopCh)
+		return
+	}
+
+	close(q.stopCh)
+	if len(q.ready) > 0 {
+		q.logger.Warn("dropping pending job registrations", "count", len(q.ready))
+	}
+	q.ready = nil
+	q.pending = make(map[string]int)
+	q.submissions = make(map[string]*structs.JobRegisterSubmission)
+}
+
+// Enqueue adds the registration to the queue and returns its submission. An
+// error is returned if the queue of the namespace of the registration is
+// full.
+func (q *jobRegisterQueue) Enqueue(args *structs.JobRegisterRequest) (*structs.JobRegisterSubmission, error) {
+	q.l.Lock()
+	defer q.l.Unlock()
+
+	if !q.enabled {
+		return nil, errJobRegisterQueueDisabled
+	}
+	q.prune(time.Now())
+
+	ns := args.RequestNamespace()
+	if q.limit > 0 && q.pending[ns] >= q.limit {
+		return nil, structs.NewErrRPCCodedf(429,
+			"job register queue of namespace %q is full: %d registrations pending", ns, q.pending[ns])
+	}
+
+	now := time.Now().UnixNano()
+	submission := &structs.JobRegisterSubmission{
+		ID:         uuid.Generate(),
+		Namespace:  ns,
+		JobID:      args.Job.ID,
+		Status:     structs.JobRegisterSubmissionStatusPending,
+		SubmitTime: now,
+		ModifyTime: now,
+	}
+
+	// The queued registration is applied synchronously
+	queued := *args
+	queued.Async = false
+
+	q.ready = append(q.ready, &pendingRegistration{args: &queued, submission: submission})
+	q.pending[ns]++
+	q.submissions[submission.ID] = submission
+
+	select {
+	case q.readyCh <- struct{}{}:
+	default:
+	}
+	return submission.Copy(), nil
+}
+
+// Get returns the submission with the given ID, or nil if it doesn't exist
+// or has expired.
+func (q *jobRegisterQueue) Get(id string) *structs.JobRegisterSubmission {
+	q.l.Lock()
+	defer q.l.Unlock()
+	return q.submissions[id].Copy()
+}
+
+// run applies the queued registrations until the stop channel is closed.
+func (q *jobRegisterQueue) run(stopCh chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-q.readyCh:
+		}
+
+		for {
+			select {
+			case <-stopCh:
+				return
+			default:
+			}
+
+			next := q.next()
+			if next == nil {
+				break
+			}
+			q.apply(next)
+		}
+	}
+}
+
+// next dequeues the oldest registration and marks it as running.
+func (q *jobRegisterQueue) next() *pendingRegistration {
+	q.l.Lock()
+	defer q.l.Unlock()
+
+	if len(q.ready) == 0 {
+		return nil
+	}
+	next := q.ready[0]
+	q.ready[0] = nil
+	q.ready = q.ready[1:]
+
+	next.submission.Status = structs.JobRegisterSubmissionStatusRunning
+	next.submission.ModifyTime = time.Now().UnixNano()
+	return next
+}
+
+// apply applies the registration and records its result.
+func (q *jobRegisterQueue) apply(p *pendingRegistration) {
+	defer metrics.MeasureSince([]string{"nomad", "job", "register_queue", "apply"}, time.Now())
+
+	var reply structs.JobRegisterResponse
+	err := q.register(p.args, &reply)
+
+	q.l.Lock()
+	defer q.l.Unlock()
+
+	sub := p.submission
+	if err != nil {
+		q.logger.Debug("failed to apply job registration", "submission_id", sub.ID,
+			"namespace", sub.Namespace, "job_id", sub.JobID, "error", err)
+		sub.Status = structs.JobRegisterSubmissionStatusFailed
+		sub.Error = err.Error()
+	} else {
+		sub.Status = structs.JobRegisterSubmissionStatusComplete
+		sub.EvalID = reply.EvalID
+		sub.JobModifyIndex = reply.JobModifyIndex
+		sub.Warnings = reply.Warnings
+	}
+	sub.ModifyTime = time.Now().UnixNano()
+
+	// The counts are reset if the queue was disabled in the meantime
+	if q.submissions[sub.ID] == sub {
+		if q.pending[sub.Namespace]--; q.pending[sub.Namespace] <= 0 {
+			delete(q.pending, sub.Namespace)
+		}
+	}
+}
+
+// prune removes the terminal submissions older than the retention.
+func (q *jobRegisterQueue) prune(now time.Time) {
+	cutoff := now.Add(-q.retention).UnixNano()
+	for id, sub := range q.submissions {
+		if sub.Terminal() && sub.ModifyTime < cutoff {
+			delete(q.submissions, id)
+		}
+	}
+}
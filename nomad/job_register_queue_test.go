@@ -0,0 +1,114 @@
+package nomad
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobRegisterQueue(t *testing.T) {
+	ci.Parallel(t)
+
+	// The registrations block until they are released
+	release := make(chan error)
+	var orderLock sync.Mutex
+	var order []string
+	q := newJobRegisterQueue(testlog.HCLogger(t), 2,
+		func(args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse) error {
+			require.False(t, args.Async)
+			orderLock.Lock()
+			order = append(order, args.Job.ID)
+			orderLock.Unlock()
+			reply.EvalID = "eval-" + args.Job.ID
+			reply.JobModifyIndex = 10
+			return <-release
+		})
+
+	register := func(ns string) *structs.JobRegisterRequest {
+		job := mock.Job()
+		job.Namespace = ns
+		return &structs.JobRegisterRequest{
+			Job:          job,
+			Async:        true,
+			WriteRequest: structs.WriteRequest{Namespace: ns},
+		}
+	}
+
+	// Registrations are rejected while the queue is disabled
+	_, err := q.Enqueue(register("default"))
+	require.Equal(t, errJobRegisterQueueDisabled, err)
+
+	q.SetEnabled(true)
+	defer q.SetEnabled(false)
+
+	first := register("default")
+	sub1, err := q.Enqueue(first)
+	require.NoError(t, err)
+	second := register("default")
+	sub2, err := q.Enqueue(second)
+	require.NoError(t, err)
+
+	// The namespace is full until a registration completes
+	_, err = q.Enqueue(register("default"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "429")
+	require.Contains(t, err.Error(), `namespace "default" is full`)
+	_, err = q.Enqueue(register("other"))
+	require.NoError(t, err)
+
+	waitForStatus := func(id, status string) *structs.JobRegisterSubmission {
+		var sub *structs.JobRegisterSubmission
+		testutil.WaitForResult(func() (bool, error) {
+			sub = q.Get(id)
+			if sub.Status != status {
+				return false, fmt.Errorf("expected status %q, got %q", status, sub.Status)
+			}
+			return true, nil
+		}, func(err error) {
+			require.NoError(t, err)
+		})
+		return sub
+	}
+
+	waitForStatus(sub1.ID, structs.JobRegisterSubmissionStatusRunning)
+	require.Equal(t, structs.JobRegisterSubmissionStatusPending, q.Get(sub2.ID).Status)
+	release <- nil
+	sub := waitForStatus(sub1.ID, structs.JobRegisterSubmissionStatusComplete)
+	require.Equal(t, "eval-"+first.Job.ID, sub.EvalID)
+	require.Equal(t, uint64(10), sub.JobModifyIndex)
+
+	waitForStatus(sub2.ID, structs.JobRegisterSubmissionStatusRunning)
+	_, err = q.Enqueue(register("default"))
+	require.NoError(t, err)
+
+	release <- fmt.Errorf("validation failed")
+	sub = waitForStatus(sub2.ID, structs.JobRegisterSubmissionStatusFailed)
+	require.Equal(t, "validation failed", sub.Error)
+	orderLock.Lock()
+	require.Equal(t, first.Job.ID, order[0])
+	require.Equal(t, second.Job.ID, order[1])
+	orderLock.Unlock()
+
+	// Terminal submissions expire
+	q.l.Lock()
+	q.prune(time.Now().Add(2 * jobRegisterSubmissionRetention))
+	q.l.Unlock()
+	require.Nil(t, q.Get(sub1.ID))
+	require.Nil(t, q.Get(sub2.ID))
+
+	// Disabling the queue drops the pending registrations
+	q.SetEnabled(false)
+	close(release)
+	q.l.Lock()
+	defer q.l.Unlock()
+	require.Empty(t, q.ready)
+	require.Empty(t, q.submissions)
+}
@@ -326,11 +326,14 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	}
 
 	// Activate the vault client
-	s.vault.SetActive(true)
+	s.setVaultActive(true)
 
 	// Enable the periodic dispatcher, since we are now the leader.
 	s.periodicDispatcher.SetEnabled(true)
 
+	// Enable the queue of asynchronous job registrations
+	s.jobRegisterQueue.SetEnabled(true)
+
 	// Activate RPC now that local FSM caught up with Raft (as evident by Barrier call success)
 	// and all leader related components (e.g. broker queue) are enabled.
 	// Auxiliary processes (e.g. background, bookkeeping, and cleanup tasks can start after)
@@ -346,6 +349,17 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Scheduler periodic jobs
 	go s.schedulePeriodic(stopCh)
 
+	// Apply scheduled scaling actions when they are due
+	go s.applyScheduledScaling(stopCh)
+
+	// Evaluate jobs held by their dependencies once they are ready
+	go s.watchJobDependencies(stopCh)
+
+	// Generate and rotate the keys signing workload identities
+	if s.identitySigner != nil && s.identitySigner.managed() {
+		go s.manageWorkloadIdentityKeys(stopCh)
+	}
+
 	// Reap any failed evaluations
 	go s.reapFailedEvaluations(stopCh)
 
@@ -625,7 +639,7 @@ func (s *Server) revokeVaultAccessorsOnRestore() error {
 	if len(revoke) != 0 {
 		s.logger.Info("revoking vault accessors after becoming leader", "accessors", len(revoke))
 
-		if err := s.vault.MarkForRevocation(revoke); err != nil {
+		if err := s.markVaultAccessorsForRevocation(revoke); err != nil {
 			return fmt.Errorf("failed to revoke tokens: %v", err)
 		}
 	}
@@ -962,12 +976,28 @@ func (s *Server) publishJobSummaryMetrics(stopCh chan struct{}) {
 				continue
 			}
 
+			var nsSummaries map[string]*structs.TaskGroupSummary
+			if s.namespaceLabels != nil {
+				labels, err := s.namespaceMetricLabels(state)
+				if err != nil {
+					s.logger.Error("failed to get namespaces", "error", err)
+					continue
+				}
+				nsSummaries = make(map[string]*structs.TaskGroupSummary, len(labels))
+				for _, label := range labels {
+					nsSummaries[label] = &structs.TaskGroupSummary{}
+				}
+			}
+
 			for {
 				raw := iter.Next()
 				if raw == nil {
 					break
 				}
 				summary := raw.(*structs.JobSummary)
+				if nsSummaries != nil {
+					addNamespaceSummary(nsSummaries, s.namespaceLabels.Value(summary.Namespace), summary)
+				}
 				if s.config.DisableDispatchedJobSummaryMetrics {
 					job, err := state.JobByID(ws, summary.Namespace, summary.JobID)
 					if err != nil {
@@ -980,10 +1010,73 @@ func (s *Server) publishJobSummaryMetrics(stopCh chan struct{}) {
 				}
 				s.iterateJobSummaryMetrics(summary)
 			}
+
+			for label, tgSummary := range nsSummaries {
+				emitNamespaceSummaryMetrics(label, tgSummary)
+			}
 		}
 	}
 }
 
+// namespaceMetricLabels returns the distinct namespace labels of the existing
+// namespaces, so that the metrics aggregated by namespace are published for
+// the namespaces without jobs too.
+func (s *Server) namespaceMetricLabels(state *state.StateSnapshot) ([]string, error) {
+	iter, err := state.Namespaces(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var labels []string
+	seen := make(map[string]struct{})
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+		label := s.namespaceLabels.Value(raw.(*structs.Namespace).Name)
+		if _, ok := seen[label]; !ok {
+			seen[label] = struct{}{}
+			labels = append(labels, label)
+		}
+	}
+	return labels, nil
+}
+
+// addNamespaceSummary adds the task group summaries of the job to the summary
+// of its namespace label.
+func addNamespaceSummary(nsSummaries map[string]*structs.TaskGroupSummary, label string, summary *structs.JobSummary) {
+	nsSummary, ok := nsSummaries[label]
+	if !ok {
+		nsSummary = &structs.TaskGroupSummary{}
+		nsSummaries[label] = nsSummary
+	}
+	for _, tgSummary := range summary.Summary {
+		nsSummary.Queued += tgSummary.Queued
+		nsSummary.Complete += tgSummary.Complete
+		nsSummary.Failed += tgSummary.Failed
+		nsSummary.Running += tgSummary.Running
+		nsSummary.Starting += tgSummary.Starting
+		nsSummary.Lost += tgSummary.Lost
+	}
+}
+
+func emitNamespaceSummaryMetrics(label string, tgSummary *structs.TaskGroupSummary) {
+	labels := []metrics.Label{{Name: "namespace", Value: label}}
+	metrics.SetGaugeWithLabels([]string{"nomad", "job_summary", "namespace", "queued"},
+		float32(tgSummary.Queued), labels)
+	metrics.SetGaugeWithLabels([]string{"nomad", "job_summary", "namespace", "complete"},
+		float32(tgSummary.Complete), labels)
+	metrics.SetGaugeWithLabels([]string{"nomad", "job_summary", "namespace", "failed"},
+		float32(tgSummary.Failed), labels)
+	metrics.SetGaugeWithLabels([]string{"nomad", "job_summary", "namespace", "running"},
+		float32(tgSummary.Running), labels)
+	metrics.SetGaugeWithLabels([]string{"nomad", "job_summary", "namespace", "starting"},
+		float32(tgSummary.Starting), labels)
+	metrics.SetGaugeWithLabels([]string{"nomad", "job_summary", "namespace", "lost"},
+		float32(tgSummary.Lost), labels)
+}
+
 func (s *Server) iterateJobSummaryMetrics(summary *structs.JobSummary) {
 	for name, tgSummary := range summary.Summary {
 		labels := []metrics.Label{
@@ -1061,16 +1154,37 @@ func (s *Server) publishJobStatusMetrics(stopCh chan struct{}) {
 				continue
 			}
 
-			s.iterateJobStatusMetrics(&iter)
+			var nsLabels []string
+			if s.namespaceLabels != nil {
+				nsLabels, err = s.namespaceMetricLabels(state)
+				if err != nil {
+					s.logger.Error("failed to get namespaces", "error", err)
+					continue
+				}
+			}
+
+			s.iterateJobStatusMetrics(&iter, nsLabels)
 		}
 	}
 }
 
-func (s *Server) iterateJobStatusMetrics(jobs *memdb.ResultIterator) {
+// iterateJobStatusMetrics publishes the job statuses, and the job statuses
+// aggregated by namespace if enabled. nsLabels are the namespace labels
+// published even if no job has them.
+func (s *Server) iterateJobStatusMetrics(jobs *memdb.ResultIterator, nsLabels []string) {
 	var pending int64 // Sum of all jobs in 'pending' state
 	var running int64 // Sum of all jobs in 'running' state
 	var dead int64    // Sum of all jobs in 'dead' state
 
+	// Sums of the jobs in each state by namespace label
+	var nsStatuses map[string]map[string]int64
+	if s.namespaceLabels != nil {
+		nsStatuses = make(map[string]map[string]int64, len(nsLabels))
+		for _, label := range nsLabels {
+			nsStatuses[label] = make(map[string]int64)
+		}
+	}
+
 	for {
 		raw := (*jobs).Next()
 		if raw == nil {
@@ -1087,11 +1201,27 @@ func (s *Server) iterateJobStatusMetrics(jobs *memdb.ResultIterator) {
 		case structs.JobStatusDead:
 			dead++
 		}
+
+		if nsStatuses != nil {
+			label := s.namespaceLabels.Value(job.Namespace)
+			if nsStatuses[label] == nil {
+				nsStatuses[label] = make(map[string]int64)
+			}
+			nsStatuses[label][job.Status]++
+		}
 	}
 
 	metrics.SetGauge([]string{"nomad", "job_status", "pending"}, float32(pending))
 	metrics.SetGauge([]string{"nomad", "job_status", "running"}, float32(running))
 	metrics.SetGauge([]string{"nomad", "job_status", "dead"}, float32(dead))
+
+	for label, statuses := range nsStatuses {
+		labels := []metrics.Label{{Name: "namespace", Value: label}}
+		for _, status := range []string{structs.JobStatusPending, structs.JobStatusRunning, structs.JobStatusDead} {
+			metrics.SetGaugeWithLabels([]string{"nomad", "job_status", "namespace", status},
+				float32(statuses[status]), labels)
+		}
+	}
 }
 
 // revokeLeadership is invoked once we step down as leader.
@@ -1119,8 +1249,11 @@ func (s *Server) revokeLeadership() error {
 	// Disable the periodic dispatcher, since it is only useful as a leader
 	s.periodicDispatcher.SetEnabled(false)
 
+	// Disable the queue of asynchronous job registrations
+	s.jobRegisterQueue.SetEnabled(false)
+
 	// Disable the Vault client as it is only useful as a leader.
-	s.vault.SetActive(false)
+	s.setVaultActive(false)
 
 	// Disable the deployment watcher as it is only useful as a leader.
 	s.deploymentWatcher.SetEnabled(false, nil)
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/labelguard"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -1536,6 +1537,38 @@ func TestLeader_ReplicateNamespaces(t *testing.T) {
 	})
 }
 
+func TestLeader_NamespaceMetrics(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	ns1 := mock.Namespace()
+	ns2 := mock.Namespace()
+	require.NoError(t, store.UpsertNamespaces(100, []*structs.Namespace{ns1, ns2}))
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+
+	// Namespaces outside the allowlist share the "_other" label
+	s := &Server{namespaceLabels: labelguard.New([]string{structs.DefaultNamespace}, 0)}
+	labels, err := s.namespaceMetricLabels(snap)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{structs.DefaultNamespace, labelguard.OtherValue}, labels)
+
+	// The task group summaries are summed by namespace label
+	nsSummaries := map[string]*structs.TaskGroupSummary{}
+	for _, ns := range []string{structs.DefaultNamespace, ns1.Name, ns2.Name} {
+		summary := &structs.JobSummary{
+			Namespace: ns,
+			Summary: map[string]structs.TaskGroupSummary{
+				"web":   {Running: 2, Queued: 1},
+				"cache": {Running: 1, Failed: 1},
+			},
+		}
+		addNamespaceSummary(nsSummaries, s.namespaceLabels.Value(ns), summary)
+	}
+	require.Equal(t, &structs.TaskGroupSummary{Running: 3, Queued: 1, Failed: 1}, nsSummaries[structs.DefaultNamespace])
+	require.Equal(t, &structs.TaskGroupSummary{Running: 6, Queued: 2, Failed: 2}, nsSummaries[labelguard.OtherValue])
+}
+
 func TestLeader_DiffNamespaces(t *testing.T) {
 	ci.Parallel(t)
 
@@ -272,6 +272,124 @@ func (n *Namespace) ListNamespaces(args *structs.NamespaceListRequest, reply *st
 	return n.srv.blockingRPC(&opts)
 }
 
+// ListNamespaceUsage is used to list the resources allocated to each namespace
+func (n *Namespace) ListNamespaceUsage(args *structs.NamespaceListRequest, reply *structs.NamespaceUsageResponse) error {
+	if done, err := n.srv.forward("Namespace.ListNamespaceUsage", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "namespace", "list_namespace_usage"}, time.Now())
+
+	// Resolve token to acl to filter namespace list
+	aclObj, err := n.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, s *state.StateStore) error {
+			var err error
+			var iter memdb.ResultIterator
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = s.NamespacesByNamePrefix(ws, prefix)
+			} else {
+				iter, err = s.Namespaces(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			// Only return namespaces allowed by acl
+			usages := make(map[string]*structs.NamespaceUsage)
+			reply.Namespaces = nil
+			for {
+				raw := iter.Next()
+				if raw == nil {
+					break
+				}
+				ns := raw.(*structs.Namespace)
+				if aclObj == nil || aclObj.AllowNamespace(ns.Name) {
+					usage := &structs.NamespaceUsage{Namespace: ns.Name}
+					usages[ns.Name] = usage
+					reply.Namespaces = append(reply.Namespaces, usage)
+				}
+			}
+
+			// Sum the resources of the non-terminal allocations
+			iter, err = s.Allocs(ws, state.SortDefault)
+			if err != nil {
+				return err
+			}
+			for {
+				raw := iter.Next()
+				if raw == nil {
+					break
+				}
+				alloc := raw.(*structs.Allocation)
+				usage, ok := usages[alloc.Namespace]
+				if !ok || alloc.TerminalStatus() {
+					continue
+				}
+
+				resources := alloc.ComparableResources()
+				usage.Allocations++
+				usage.CPU += resources.Flattened.Cpu.CpuShares
+				usage.MemoryMB += resources.Flattened.Memory.MemoryMB
+			}
+
+			// Sum the resources of the ready nodes, less their reserved
+			// resources, if the request may read nodes
+			reply.ClusterCPU, reply.ClusterMemoryMB = 0, 0
+			if aclObj == nil || aclObj.AllowNodeRead() {
+				iter, err = s.Nodes(ws)
+				if err != nil {
+					return err
+				}
+				for {
+					raw := iter.Next()
+					if raw == nil {
+						break
+					}
+					node := raw.(*structs.Node)
+					if !node.Ready() {
+						continue
+					}
+
+					resources := node.ComparableResources()
+					resources.Subtract(node.ComparableReservedResources())
+					reply.ClusterCPU += resources.Flattened.Cpu.CpuShares
+					reply.ClusterMemoryMB += resources.Flattened.Memory.MemoryMB
+				}
+			}
+
+			// Use the last index that affected the tables used
+			index, err := s.Index("allocs")
+			if err != nil {
+				return err
+			}
+			for _, table := range []string{"nodes", state.TableNamespaces} {
+				tableIndex, err := s.Index(table)
+				if err != nil {
+					return err
+				}
+				if tableIndex > index {
+					index = tableIndex
+				}
+			}
+
+			// Ensure we never set the index to zero, otherwise a blocking query cannot be used.
+			// We floor the index at one, since realistically the first write must have a higher index.
+			if index == 0 {
+				index = 1
+			}
+			reply.Index = index
+			return nil
+		}}
+	return n.srv.blockingRPC(&opts)
+}
+
 // GetNamespace is used to get a specific namespace
 func (n *Namespace) GetNamespace(args *structs.NamespaceSpecificRequest, reply *structs.SingleNamespaceResponse) error {
 	if done, err := n.srv.forward("Namespace.GetNamespace", args, args, reply); done {
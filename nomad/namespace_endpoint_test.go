@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNamespaceEndpoint_GetNamespace(t *testing.T) {
@@ -410,6 +411,71 @@ func TestNamespaceEndpoint_List_ACL(t *testing.T) {
 	}
 }
 
+func TestNamespaceEndpoint_ListUsage(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	ns1 := mock.Namespace()
+	ns1.Name = "aaaaaaaa-3350-4b4b-d185-0e1992ed43e9"
+	require.NoError(state.UpsertNamespaces(1000, []*structs.Namespace{ns1}))
+
+	node := mock.Node()
+	require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 1001, node))
+
+	// Only the non-terminal allocations of a namespace are counted
+	alloc1 := mock.Alloc()
+	alloc1.Namespace = ns1.Name
+	alloc2 := mock.Alloc()
+	alloc2.Namespace = ns1.Name
+	alloc2.DesiredStatus = structs.AllocDesiredStatusStop
+	alloc3 := mock.Alloc()
+	require.NoError(state.UpsertAllocs(structs.MsgTypeTestSetup, 1002, []*structs.Allocation{alloc1, alloc2, alloc3}))
+
+	token := mock.CreatePolicyAndToken(t, state, 1003, "test-valid",
+		mock.NamespacePolicy(ns1.Name, "", []string{acl.NamespaceCapabilityReadJob}))
+
+	get := &structs.NamespaceListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.NamespaceUsageResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.ListNamespaceUsage", get, &resp))
+	require.EqualValues(1002, resp.Index)
+	require.Len(resp.Namespaces, 2)
+
+	resources := alloc1.ComparableResources()
+	require.Equal(&structs.NamespaceUsage{
+		Namespace:   ns1.Name,
+		Allocations: 1,
+		CPU:         resources.Flattened.Cpu.CpuShares,
+		MemoryMB:    resources.Flattened.Memory.MemoryMB,
+	}, resp.Namespaces[0])
+	require.Equal(structs.DefaultNamespace, resp.Namespaces[1].Namespace)
+	require.Equal(1, resp.Namespaces[1].Allocations)
+
+	capacity := node.ComparableResources()
+	capacity.Subtract(node.ComparableReservedResources())
+	require.Equal(capacity.Flattened.Cpu.CpuShares, resp.ClusterCPU)
+	require.Equal(capacity.Flattened.Memory.MemoryMB, resp.ClusterMemoryMB)
+
+	// A token for a single namespace only sees its usage and not the
+	// resources of the cluster
+	get.AuthToken = token.SecretID
+	var resp2 structs.NamespaceUsageResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Namespace.ListNamespaceUsage", get, &resp2))
+	require.Len(resp2.Namespaces, 1)
+	require.Equal(ns1.Name, resp2.Namespaces[0].Namespace)
+	require.Zero(resp2.ClusterCPU)
+	require.Zero(resp2.ClusterMemoryMB)
+}
+
 func TestNamespaceEndpoint_List_Blocking(t *testing.T) {
 	ci.Parallel(t)
 	assert := assert.New(t)
@@ -385,7 +385,7 @@ func (n *Node) deregister(args *structs.NodeBatchDeregisterRequest,
 			return err
 		} else if l := len(accessors); l > 0 {
 			n.logger.Debug("revoking vault accessors on node due to deregister", "num_accessors", l, "node_id", nodeID)
-			if err := n.srv.vault.RevokeTokens(context.Background(), accessors, true); err != nil {
+			if err := n.srv.revokeVaultAccessors(context.Background(), accessors, true); err != nil {
 				n.logger.Error("revoking vault accessors for node failed", "node_id", nodeID, "error", err)
 				return err
 			}
@@ -508,7 +508,7 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 			return err
 		} else if l := len(accessors); l > 0 {
 			n.logger.Debug("revoking vault accessors on node due to down state", "num_accessors", l, "node_id", args.NodeID)
-			if err := n.srv.vault.RevokeTokens(context.Background(), accessors, true); err != nil {
+			if err := n.srv.revokeVaultAccessors(context.Background(), accessors, true); err != nil {
 				n.logger.Error("revoking vault accessors for node failed", "node_id", args.NodeID, "error", err)
 				return err
 			}
@@ -1284,7 +1284,7 @@ func (n *Node) batchUpdate(future *structs.BatchFuture, updates []*structs.Alloc
 	// Revoke any orphaned Vault token accessors
 	if l := len(revokeVault); l > 0 {
 		n.logger.Debug("revoking vault accessors due to terminal allocations", "num_accessors", l)
-		if err := n.srv.vault.RevokeTokens(context.Background(), revokeVault, true); err != nil {
+		if err := n.srv.revokeVaultAccessors(context.Background(), revokeVault, true); err != nil {
 			n.logger.Error("batched vault accessor revocation failed", "error", err)
 			mErr.Errors = append(mErr.Errors, err)
 		}
@@ -1559,16 +1559,28 @@ func (n *Node) DeriveVaultToken(args *structs.DeriveVaultTokenRequest, reply *st
 		taskVault := tg[task]
 		if taskVault == nil || len(taskVault.Policies) == 0 {
 			unneeded = append(unneeded, task)
+		} else if args.Cluster != "" && taskVault.Cluster(args.Cluster) == nil {
+			unneeded = append(unneeded, task)
 		}
 	}
 
 	if len(unneeded) != 0 {
 		e := fmt.Errorf("Requested Vault tokens for tasks without defined Vault policies: %s",
 			strings.Join(unneeded, ", "))
+		if args.Cluster != "" {
+			e = fmt.Errorf("Requested Vault cluster %q tokens for tasks without defined Vault cluster policies: %s",
+				args.Cluster, strings.Join(unneeded, ", "))
+		}
 		setError(e, false)
 		return nil
 	}
 
+	vault, err := n.srv.vaultClusterClient(args.Cluster)
+	if err != nil {
+		setError(err, false)
+		return nil
+	}
+
 	// At this point the request is valid and we should contact Vault for
 	// tokens.
 
@@ -1595,7 +1607,7 @@ func (n *Node) DeriveVaultToken(args *structs.DeriveVaultTokenRequest, reply *st
 						return nil
 					}
 
-					secret, err := n.srv.vault.CreateToken(ctx, alloc, task)
+					secret, err := vault.CreateToken(ctx, alloc, task)
 					if err != nil {
 						return err
 					}
@@ -1635,6 +1647,7 @@ func (n *Node) DeriveVaultToken(args *structs.DeriveVaultTokenRequest, reply *st
 			NodeID:      alloc.NodeID,
 			AllocID:     alloc.ID,
 			CreationTTL: w.TTL,
+			Cluster:     args.Cluster,
 		}
 
 		accessors = append(accessors, accessor)
@@ -1644,7 +1657,7 @@ func (n *Node) DeriveVaultToken(args *structs.DeriveVaultTokenRequest, reply *st
 	if createErr != nil {
 		n.logger.Error("Vault token creation for alloc failed", "alloc_id", alloc.ID, "error", createErr)
 
-		if revokeErr := n.srv.vault.RevokeTokens(context.Background(), accessors, false); revokeErr != nil {
+		if revokeErr := n.srv.revokeVaultAccessors(context.Background(), accessors, false); revokeErr != nil {
 			n.logger.Error("Vault token revocation for alloc failed", "alloc_id", alloc.ID, "error", revokeErr)
 		}
 
@@ -1893,6 +1906,118 @@ func (n *Node) DeriveSIToken(args *structs.DeriveSITokenRequest, reply *structs.
 	return nil
 }
 
+// SignIdentities is used by clients to request the signed workload identities
+// of a task of an allocation running on the client.
+func (n *Node) SignIdentities(args *structs.SignIdentitiesRequest, reply *structs.SignIdentitiesResponse) error {
+	setError := func(e error, recoverable bool) {
+		if e != nil {
+			if re, ok := e.(*structs.RecoverableError); ok {
+				reply.Error = re // No need to wrap if error is already a RecoverableError
+			} else {
+				reply.Error = structs.NewRecoverableError(e, recoverable).(*structs.RecoverableError)
+			}
+			n.logger.Error("SignIdentities failed", "recoverable", recoverable, "error", e)
+		}
+	}
+
+	if done, err := n.srv.forward("Node.SignIdentities", args, args, reply); done {
+		setError(err, structs.IsRecoverable(err) || err == structs.ErrNoLeader)
+		return nil
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client", "sign_identities"}, time.Now())
+
+	// Verify the arguments
+	if err := args.Validate(); err != nil {
+		setError(err, false)
+		return nil
+	}
+
+	signer := n.srv.identitySigner
+	if signer == nil {
+		setError(fmt.Errorf("Workload identities are not configured on the servers"), false)
+		return nil
+	}
+
+	// Verify the following:
+	// * The Node exists and has the correct SecretID.
+	// * The Allocation exists on the specified Node.
+	// * The Allocation contains the given task, which has the given
+	//   identities.
+
+	snap, err := n.srv.fsm.State().Snapshot()
+	if err != nil {
+		setError(err, false)
+		return nil
+	}
+	node, err := snap.NodeByID(nil, args.NodeID)
+	if err != nil {
+		setError(err, false)
+		return nil
+	}
+	if node == nil {
+		setError(fmt.Errorf("Node %q does not exist", args.NodeID), false)
+		return nil
+	}
+	if node.SecretID != args.SecretID {
+		setError(fmt.Errorf("SecretID mismatch"), false)
+		return nil
+	}
+
+	alloc, err := snap.AllocByID(nil, args.AllocID)
+	if err != nil {
+		setError(err, false)
+		return nil
+	}
+	if alloc == nil {
+		setError(fmt.Errorf("Allocation %q does not exist", args.AllocID), false)
+		return nil
+	}
+	if alloc.NodeID != args.NodeID {
+		setError(fmt.Errorf("Allocation %q not running on node %q", args.AllocID, args.NodeID), false)
+		return nil
+	}
+	if alloc.TerminalStatus() {
+		setError(fmt.Errorf("Can't sign identities for terminal allocation"), false)
+		return nil
+	}
+
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		setError(fmt.Errorf("Allocation %q does not contain TaskGroup %q", args.AllocID, alloc.TaskGroup), false)
+		return nil
+	}
+	task := tg.LookupTask(args.Task)
+	if task == nil {
+		setError(fmt.Errorf("Allocation %q does not contain Task %q", args.AllocID, args.Task), false)
+		return nil
+	}
+
+	identities := make(map[string]*structs.WorkloadIdentity, len(task.Identities))
+	for _, identity := range task.Identities {
+		identities[identity.Name] = identity
+	}
+
+	now := time.Now()
+	signed := make(map[string]*structs.SignedWorkloadIdentity, len(args.Identities))
+	for _, name := range args.Identities {
+		identity, ok := identities[name]
+		if !ok {
+			setError(fmt.Errorf("Task %q does not have identity %q", args.Task, name), false)
+			return nil
+		}
+		s, err := signer.sign(n.srv.Region(), alloc, task.Name, identity, now)
+		if err != nil {
+			setError(fmt.Errorf("Failed to sign identity %q: %v", name, err), false)
+			return nil
+		}
+		signed[name] = s
+	}
+
+	reply.Identities = signed
+	n.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
 func connectTasks(tg *structs.TaskGroup, tasks []string) ([]string, []connectTask) {
 	var notConnect []string
 	var usesConnect []connectTask
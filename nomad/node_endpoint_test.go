@@ -3384,6 +3384,142 @@ func TestClientEndpoint_DeriveVaultToken(t *testing.T) {
 	}
 }
 
+func TestClientEndpoint_SignIdentities(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	state := s1.fsm.State()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	node := mock.Node()
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 2, node))
+
+	// Create an allocation with a task that has a workload identity
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Identities = []*structs.WorkloadIdentity{{
+		Name:     "aws",
+		Audience: []string{"sts.amazonaws.com"},
+		TTL:      time.Hour,
+		File:     true,
+	}}
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 3, []*structs.Allocation{alloc}))
+
+	req := &structs.SignIdentitiesRequest{
+		NodeID:       node.ID,
+		SecretID:     node.SecretID,
+		AllocID:      alloc.ID,
+		Task:         task.Name,
+		Identities:   []string{"aws"},
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+
+	// Signing fails without workload identities configured
+	var resp structs.SignIdentitiesResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.SignIdentities", req, &resp))
+	require.NotNil(t, resp.Error)
+	require.Contains(t, resp.Error.Error(), "not configured")
+
+	signer, err := newIdentitySigner(&structs.WorkloadIdentityConfig{
+		Issuer:         "https://nomad.example.com",
+		SigningKeyFile: testIdentitySigningKey(t),
+	}, s1.State)
+	require.NoError(t, err)
+	s1.identitySigner = signer
+
+	resp = structs.SignIdentitiesResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.SignIdentities", req, &resp))
+	require.Nil(t, resp.Error)
+	require.Len(t, resp.Identities, 1)
+	require.NotEmpty(t, resp.Identities["aws"].JWT)
+	require.True(t, resp.Identities["aws"].Expiration.After(time.Now()))
+
+	// Requesting an identity the task doesn't have fails
+	req.Identities = []string{"gcp"}
+	resp = structs.SignIdentitiesResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.SignIdentities", req, &resp))
+	require.NotNil(t, resp.Error)
+	require.Contains(t, resp.Error.Error(), `does not have identity "gcp"`)
+
+	// Requesting an identity with a mismatched secret fails
+	req.Identities = []string{"aws"}
+	req.SecretID = uuid.Generate()
+	resp = structs.SignIdentitiesResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Node.SignIdentities", req, &resp))
+	require.NotNil(t, resp.Error)
+	require.Contains(t, resp.Error.Error(), "SecretID mismatch")
+}
+
+func TestClientEndpoint_DeriveVaultToken_Cluster(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	state := s1.fsm.State()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Replace the Vault Clients on the server
+	tvc := &TestVaultClient{}
+	s1.vault = &TestVaultClient{}
+	s1.vaultClusters = map[string]VaultClient{"team": tvc}
+
+	node := mock.Node()
+	require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 2, node))
+
+	// Create an allocation with a task requiring a token of the cluster
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Vault = &structs.Vault{
+		Policies: []string{"a"},
+		Clusters: []*structs.VaultCluster{{Name: "team", Policies: []string{"b"}}},
+	}
+	require.NoError(state.UpsertAllocs(structs.MsgTypeTestSetup, 3, []*structs.Allocation{alloc}))
+
+	accessor := uuid.Generate()
+	tvc.SetCreateTokenSecret(alloc.ID, task.Name, &vapi.Secret{
+		WrapInfo: &vapi.SecretWrapInfo{
+			Token:           uuid.Generate(),
+			WrappedAccessor: accessor,
+			TTL:             10,
+		},
+	})
+
+	req := &structs.DeriveVaultTokenRequest{
+		NodeID:   node.ID,
+		SecretID: node.SecretID,
+		AllocID:  alloc.ID,
+		Tasks:    []string{task.Name},
+		Cluster:  "team",
+		QueryOptions: structs.QueryOptions{
+			Region: "global",
+		},
+	}
+
+	var resp structs.DeriveVaultTokenResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Node.DeriveVaultToken", req, &resp))
+	require.Nil(resp.Error)
+	require.Len(resp.Tasks, 1)
+
+	// The accessor records the cluster its token was created on
+	va, err := state.VaultAccessor(memdb.NewWatchSet(), accessor)
+	require.NoError(err)
+	require.NotNil(va)
+	require.Equal("team", va.Cluster)
+
+	// Requesting a token of a cluster the task doesn't use fails
+	req.Cluster = "other"
+	resp = structs.DeriveVaultTokenResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Node.DeriveVaultToken", req, &resp))
+	require.NotNil(resp.Error)
+	require.Contains(resp.Error.Error(), "without defined Vault cluster policies")
+}
+
 func TestClientEndpoint_DeriveVaultToken_VaultError(t *testing.T) {
 	ci.Parallel(t)
 
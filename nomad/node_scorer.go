@@ -0,0 +1,165 @@
+package nomad
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os/exec"
+
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// nodeScorerMaxResponseSize is the maximum size of a response from the
+	// external node scorer.
+	nodeScorerMaxResponseSize = 64 * 1024
+)
+
+// nodeScoreRequest is the request sent to the external node scorer for each
+// candidate node.
+type nodeScoreRequest struct {
+	Namespace string
+	JobID     string
+	JobType   string
+	Priority  int
+	TaskGroup string
+
+	// CPU and MemoryMB are the resources requested by the tasks of the task
+	// group
+	CPU      int
+	MemoryMB int
+
+	Node *nodeScoreRequestNode
+}
+
+// nodeScoreRequestNode is the subset of the node sent to the external node
+// scorer. It intentionally omits the node's secret ID.
+type nodeScoreRequestNode struct {
+	ID         string
+	Name       string
+	Datacenter string
+	NodeClass  string
+	Attributes map[string]string
+	Meta       map[string]string
+}
+
+// nodeScoreResponse is the response of the external node scorer.
+type nodeScoreResponse struct {
+	// Score is the score of the node, between -1 and 1
+	Score float64
+}
+
+// externalNodeScorer scores nodes by executing an operator defined binary or
+// POSTing to an operator defined HTTP endpoint. It implements the
+// scheduler.NodeScorer interface.
+type externalNodeScorer struct {
+	config *structs.NodeScorerConfig
+	client *http.Client
+	logger log.Logger
+}
+
+// newExternalNodeScorer returns an externalNodeScorer for the given
+// configuration.
+func newExternalNodeScorer(config *structs.NodeScorerConfig, logger log.Logger) *externalNodeScorer {
+	return &externalNodeScorer{
+		config: config,
+		client: cleanhttp.DefaultPooledClient(),
+		logger: logger.Named("node_scorer"),
+	}
+}
+
+// ScoreNode returns the external score of placing the task group of the job
+// on the node.
+func (s *externalNodeScorer) ScoreNode(job *structs.Job, tg *structs.TaskGroup, node *structs.Node) (float64, error) {
+	req := &nodeScoreRequest{
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+		JobType:   job.Type,
+		Priority:  job.Priority,
+		TaskGroup: tg.Name,
+		Node: &nodeScoreRequestNode{
+			ID:         node.ID,
+			Name:       node.Name,
+			Datacenter: node.Datacenter,
+			NodeClass:  node.NodeClass,
+			Attributes: node.Attributes,
+			Meta:       node.Meta,
+		},
+	}
+	for _, task := range tg.Tasks {
+		if task.Resources != nil {
+			req.CPU += task.Resources.CPU
+			req.MemoryMB += task.Resources.MemoryMB
+		}
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.Timeout)
+	defer cancel()
+
+	var out []byte
+	if s.config.Command != "" {
+		out, err = s.exec(ctx, body)
+	} else {
+		out, err = s.post(ctx, body)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var resp nodeScoreResponse
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return 0, fmt.Errorf("failed to decode node score: %v", err)
+	}
+	return resp.Score, nil
+}
+
+// exec runs the scorer binary with the request on its stdin and returns its
+// stdout.
+func (s *externalNodeScorer) exec(ctx context.Context, body []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.config.Command, s.config.Args...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("node scorer failed: %v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// post sends the request to the scorer endpoint and returns the response
+// body.
+func (s *externalNodeScorer) post(ctx context.Context, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil, fmt.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+	return ioutil.ReadAll(io.LimitReader(resp.Body, nodeScorerMaxResponseSize))
+}
@@ -0,0 +1,99 @@
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalNodeScorer_URL(t *testing.T) {
+	ci.Parallel(t)
+
+	reqCh := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		reqCh <- body
+		w.Write([]byte(`{"Score": 0.5}`))
+	}))
+	defer srv.Close()
+
+	scorer := newExternalNodeScorer(&structs.NodeScorerConfig{
+		URL:     srv.URL,
+		Timeout: 5 * time.Second,
+	}, testlog.HCLogger(t))
+
+	job := mock.Job()
+	node := mock.Node()
+	score, err := scorer.ScoreNode(job, job.TaskGroups[0], node)
+	require.NoError(t, err)
+	require.Equal(t, 0.5, score)
+
+	body := <-reqCh
+	require.NotContains(t, string(body), node.SecretID)
+
+	var req nodeScoreRequest
+	require.NoError(t, json.Unmarshal(body, &req))
+	require.Equal(t, job.ID, req.JobID)
+	require.Equal(t, job.TaskGroups[0].Name, req.TaskGroup)
+	require.Equal(t, 500, req.CPU)
+	require.Equal(t, 256, req.MemoryMB)
+	require.Equal(t, node.ID, req.Node.ID)
+	require.Equal(t, node.Attributes, req.Node.Attributes)
+}
+
+func TestExternalNodeScorer_URL_Error(t *testing.T) {
+	ci.Parallel(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	scorer := newExternalNodeScorer(&structs.NodeScorerConfig{
+		URL:     srv.URL,
+		Timeout: 5 * time.Second,
+	}, testlog.HCLogger(t))
+
+	job := mock.Job()
+	_, err := scorer.ScoreNode(job, job.TaskGroups[0], mock.Node())
+	require.EqualError(t, err, "unexpected response code 500")
+}
+
+func TestExternalNodeScorer_Command(t *testing.T) {
+	ci.Parallel(t)
+
+	scorer := newExternalNodeScorer(&structs.NodeScorerConfig{
+		Command: "/bin/sh",
+		Args:    []string{"-c", `cat >/dev/null; echo '{"Score": -0.25}'`},
+		Timeout: 5 * time.Second,
+	}, testlog.HCLogger(t))
+
+	job := mock.Job()
+	score, err := scorer.ScoreNode(job, job.TaskGroups[0], mock.Node())
+	require.NoError(t, err)
+	require.Equal(t, -0.25, score)
+}
+
+func TestExternalNodeScorer_Command_Timeout(t *testing.T) {
+	ci.Parallel(t)
+
+	scorer := newExternalNodeScorer(&structs.NodeScorerConfig{
+		Command: "/bin/sh",
+		Args:    []string{"-c", "exec sleep 10"},
+		Timeout: 50 * time.Millisecond,
+	}, testlog.HCLogger(t))
+
+	job := mock.Job()
+	_, err := scorer.ScoreNode(job, job.TaskGroups[0], mock.Node())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
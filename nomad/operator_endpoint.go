@@ -8,11 +8,14 @@ import (
 	"time"
 
 	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/go-msgpack/codec"
 
 	"github.com/hashicorp/consul/agent/consul/autopilot"
 	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/snapshot"
+	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/raft"
 	"github.com/hashicorp/serf/serf"
@@ -368,6 +371,163 @@ func (op *Operator) SchedulerGetConfiguration(args *structs.GenericRequest, repl
 	return nil
 }
 
+// SchedulerGetQueues is used to retrieve the queue depth of each namespace in
+// the eval broker.
+func (op *Operator) SchedulerGetQueues(args *structs.GenericRequest, reply *structs.SchedulerQueuesResponse) error {
+	// The eval broker only runs on the leader, so we fix the args since we
+	// are re-using a structure where we don't support all the options.
+	args.AllowStale = false
+	if done, err := op.srv.forward("Operator.SchedulerGetQueues", args, args, reply); done {
+		return err
+	}
+
+	// This action requires operator read access.
+	rule, err := op.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	stats := op.srv.evalBroker.Stats()
+	reply.Namespaces = make(map[string]*structs.SchedulerNamespaceQueue, len(stats.ByNamespace))
+	for ns, nsStats := range stats.ByNamespace {
+		reply.Namespaces[ns] = &structs.SchedulerNamespaceQueue{
+			Ready:   nsStats.Ready,
+			Unacked: nsStats.Unacked,
+		}
+	}
+	op.srv.setQueryMeta(&reply.QueryMeta)
+
+	return nil
+}
+
+// SchedulerGetFragmentation is used to retrieve the fragmentation report of
+// the free capacity of the nodes in each datacenter.
+func (op *Operator) SchedulerGetFragmentation(args *structs.SchedulerFragmentationRequest, reply *structs.SchedulerFragmentationResponse) error {
+	// The report is computed by the leader, so we fix the args since we don't
+	// support stale queries.
+	args.AllowStale = false
+	if done, err := op.srv.forward("Operator.SchedulerGetFragmentation", args, args, reply); done {
+		return err
+	}
+
+	// This action requires operator read access. Suggesting migrations also
+	// requires node read access since the nodes are named.
+	rule, err := op.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	} else if rule != nil && args.Suggest && !rule.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := op.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	report, err := fragmentationReport(snap, args.Suggest)
+	if err != nil {
+		return err
+	}
+	reply.Shapes = report.Shapes
+	reply.Datacenters = report.Datacenters
+
+	index, err := snap.LatestIndex()
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	op.srv.setQueryMeta(&reply.QueryMeta)
+
+	return nil
+}
+
+// WorkloadIdentityKeyRotate is used to rotate the keys signing workload
+// identities ahead of the rotation interval.
+func (op *Operator) WorkloadIdentityKeyRotate(args *structs.WorkloadIdentityKeyRotateRequest, reply *structs.WorkloadIdentityKeyRotateResponse) error {
+	if done, err := op.srv.forward("Operator.WorkloadIdentityKeyRotate", args, args, reply); done {
+		return err
+	}
+
+	// This action requires operator write access.
+	rule, err := op.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	signer := op.srv.identitySigner
+	if signer == nil {
+		return errIdentityNotConfigured
+	} else if !signer.managed() {
+		return errIdentityKeysNotManaged
+	}
+
+	plan, index, err := op.srv.rotateWorkloadIdentityKeys(true)
+	if err != nil {
+		op.logger.Error("failed rotating workload identity keys", "error", err)
+		return err
+	}
+
+	reply.KeyID = plan.ActiveKeyID
+	reply.Index = index
+	return nil
+}
+
+// WorkloadIdentityKeyList is used to list the keys signing workload
+// identities, without their private key material.
+func (op *Operator) WorkloadIdentityKeyList(args *structs.GenericRequest, reply *structs.WorkloadIdentityKeyListResponse) error {
+	if done, err := op.srv.forward("Operator.WorkloadIdentityKeyList", args, args, reply); done {
+		return err
+	}
+
+	// This action requires operator read access.
+	rule, err := op.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	if op.srv.identitySigner == nil {
+		return errIdentityNotConfigured
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			iter, err := state.WorkloadIdentityKeys(ws)
+			if err != nil {
+				return err
+			}
+
+			var keys []*structs.WorkloadIdentityKey
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				keys = append(keys, raw.(*structs.WorkloadIdentityKey))
+			}
+			sortIdentityKeys(keys)
+
+			reply.Keys = make([]*structs.WorkloadIdentityKeyStub, len(keys))
+			for i, key := range keys {
+				reply.Keys[i] = key.Stub()
+			}
+
+			index, err := state.Index("workload_identity_keys")
+			if err != nil {
+				return err
+			}
+			reply.Index = helper.Uint64Max(1, index)
+			op.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		},
+	}
+	return op.srv.blockingRPC(&opts)
+}
+
 func (op *Operator) forwardStreamingRPC(region string, method string, args interface{}, in io.ReadWriteCloser) error {
 	server, err := op.srv.findRegionServer(region)
 	if err != nil {
@@ -28,6 +28,7 @@ import (
 	"github.com/hashicorp/raft"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 func TestOperator_RaftGetConfiguration(t *testing.T) {
@@ -484,6 +485,214 @@ func TestOperator_SchedulerGetConfiguration_ACL(t *testing.T) {
 
 }
 
+func TestOperator_SchedulerGetQueues(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	eval1 := mock.Eval()
+	eval1.Namespace = "other"
+	s1.evalBroker.Enqueue(eval1)
+
+	eval2 := mock.Eval()
+	eval2.Namespace = "other"
+	s1.evalBroker.Enqueue(eval2)
+
+	arg := structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+	var reply structs.SchedulerQueuesResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerGetQueues", &arg, &reply))
+	require.Equal(t, &structs.SchedulerNamespaceQueue{Ready: 2}, reply.Namespaces["other"])
+}
+
+func TestOperator_SchedulerGetQueues_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid", mock.NodePolicy(acl.PolicyWrite))
+
+	arg := structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+	var reply structs.SchedulerQueuesResponse
+
+	// Try with no token and expect permission denied
+	err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerGetQueues", &arg, &reply)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with an invalid token and expect permission denied
+	arg.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.SchedulerGetQueues", &arg, &reply)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with root token, should succeed
+	arg.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerGetQueues", &arg, &reply))
+}
+
+func TestOperator_SchedulerGetFragmentation_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	operatorToken := mock.CreatePolicyAndToken(t, state, 1001, "test-operator", `operator { policy = "read" }`)
+
+	node := mock.Node()
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1002, node))
+
+	arg := structs.SchedulerFragmentationRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+	var reply structs.SchedulerFragmentationResponse
+
+	// Try with no token and expect permission denied
+	err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerGetFragmentation", &arg, &reply)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with an operator token, should succeed
+	arg.AuthToken = operatorToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerGetFragmentation", &arg, &reply))
+	require.Equal(t, 1, reply.Datacenters[node.Datacenter].Nodes)
+	require.NotZero(t, reply.Index)
+
+	// Suggesting migrations requires node read access as well
+	arg.Suggest = true
+	err = msgpackrpc.CallWithCodec(codec, "Operator.SchedulerGetFragmentation", &arg, &reply)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Try with root token, should succeed
+	arg.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerGetFragmentation", &arg, &reply))
+}
+
+func TestOperator_WorkloadIdentityKeyRotate(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.WorkloadIdentityConfig = &structs.WorkloadIdentityConfig{
+			Issuer: "https://nomad.example.com",
+		}
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// The leader bootstraps the active and the next key
+	listReq := structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    s1.config.Region,
+			AuthToken: root.SecretID,
+		},
+	}
+	var listResp structs.WorkloadIdentityKeyListResponse
+	testutil.WaitForResult(func() (bool, error) {
+		if err := msgpackrpc.CallWithCodec(codec, "Operator.WorkloadIdentityKeyList", &listReq, &listResp); err != nil {
+			return false, err
+		}
+		return len(listResp.Keys) == 2, fmt.Errorf("expected 2 keys, got %d", len(listResp.Keys))
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+	active, next := listResp.Keys[0], listResp.Keys[1]
+	require.Equal(t, structs.WorkloadIdentityKeyStateActive, active.State)
+	require.Equal(t, structs.WorkloadIdentityKeyStateNext, next.State)
+
+	identity := &structs.WorkloadIdentity{
+		Name:     "aws",
+		Audience: []string{"sts.amazonaws.com"},
+		TTL:      time.Hour,
+		File:     true,
+	}
+	signed, err := s1.identitySigner.sign("global", mock.Alloc(), "web", identity, time.Now())
+	require.NoError(t, err)
+
+	// Rotating requires operator write access
+	invalidToken := mock.CreatePolicyAndToken(t, s1.fsm.State(), 1001, "test-invalid", mock.NodePolicy(acl.PolicyWrite))
+	rotateReq := structs.WorkloadIdentityKeyRotateRequest{
+		WriteRequest: structs.WriteRequest{
+			Region:    s1.config.Region,
+			AuthToken: invalidToken.SecretID,
+		},
+	}
+	var rotateResp structs.WorkloadIdentityKeyRotateResponse
+	err = msgpackrpc.CallWithCodec(codec, "Operator.WorkloadIdentityKeyRotate", &rotateReq, &rotateResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// The next key, which was already published, becomes active
+	rotateReq.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.WorkloadIdentityKeyRotate", &rotateReq, &rotateResp))
+	require.Equal(t, next.KeyID, rotateResp.KeyID)
+	require.NotZero(t, rotateResp.Index)
+
+	listReq.MinQueryIndex = listResp.Index
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.WorkloadIdentityKeyList", &listReq, &listResp))
+	require.Len(t, listResp.Keys, 3)
+	require.Equal(t, active.KeyID, listResp.Keys[0].KeyID)
+	require.Equal(t, structs.WorkloadIdentityKeyStateRetired, listResp.Keys[0].State)
+	require.Equal(t, next.KeyID, listResp.Keys[1].KeyID)
+	require.Equal(t, structs.WorkloadIdentityKeyStateActive, listResp.Keys[1].State)
+	require.Equal(t, structs.WorkloadIdentityKeyStateNext, listResp.Keys[2].State)
+
+	// Identities signed by the retired key are still verified by the key set
+	keys, err := s1.WorkloadIdentityKeys()
+	require.NoError(t, err)
+	require.Len(t, keys.Keys, 3)
+	token, err := jwt.ParseSigned(signed.JWT)
+	require.NoError(t, err)
+	require.Equal(t, active.KeyID, token.Headers[0].KeyID)
+	var claims jwt.Claims
+	require.NoError(t, token.Claims(keys.Key(active.KeyID)[0], &claims))
+
+	// New identities are signed by the new active key
+	signed, err = s1.identitySigner.sign("global", mock.Alloc(), "web", identity, time.Now())
+	require.NoError(t, err)
+	token, err = jwt.ParseSigned(signed.JWT)
+	require.NoError(t, err)
+	require.Equal(t, next.KeyID, token.Headers[0].KeyID)
+}
+
+func TestOperator_WorkloadIdentityKeyRotate_Static(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.WorkloadIdentityConfig = &structs.WorkloadIdentityConfig{
+			Issuer:         "https://nomad.example.com",
+			SigningKeyFile: testIdentitySigningKey(t),
+		}
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	req := structs.WorkloadIdentityKeyRotateRequest{
+		WriteRequest: structs.WriteRequest{Region: s1.config.Region},
+	}
+	var resp structs.WorkloadIdentityKeyRotateResponse
+	err := msgpackrpc.CallWithCodec(codec, "Operator.WorkloadIdentityKeyRotate", &req, &resp)
+	require.EqualError(t, err, errIdentityKeysNotManaged.Error())
+}
+
 func TestOperator_SchedulerSetConfiguration_ACL(t *testing.T) {
 	ci.Parallel(t)
 
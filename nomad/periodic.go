@@ -450,6 +450,7 @@ func (p *PeriodicDispatch) deriveJob(periodicJob *structs.Job, time time.Time) (
 	derived.ID = p.derivedJobID(periodicJob, time)
 	derived.Name = derived.ID
 	derived.Periodic = nil
+	derived.VersionTag = nil
 	derived.Status = ""
 	derived.StatusDescription = ""
 	return
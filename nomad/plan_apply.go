@@ -16,6 +16,12 @@ import (
 	"github.com/hashicorp/raft"
 )
 
+const (
+	// maxRejectedPlanResults is the maximum number of results of rejected
+	// plans the planner holds until the next plan is applied
+	maxRejectedPlanResults = 512
+)
+
 // planner is used to manage the submitted allocation plans that are waiting
 // to be accessed by the leader
 type planner struct {
@@ -25,6 +31,12 @@ type planner struct {
 	// planQueue is used to manage the submitted allocation
 	// plans that are waiting to be assessed by the leader
 	planQueue *PlanQueue
+
+	// rejectedPlanResults are the results of the plans whose nodes were all
+	// rejected since the last plan was applied. They are only held in memory
+	// and recorded along with the next applied plan, so a rejected plan
+	// doesn't cost a Raft apply of its own.
+	rejectedPlanResults []*structs.JobPlanResults
 }
 
 // newPlanner returns a new planner to be used for managing allocation plans.
@@ -42,6 +54,24 @@ func newPlanner(s *Server) (*planner, error) {
 	}, nil
 }
 
+// holdRejectedPlanResult holds the result of a plan whose nodes were all
+// rejected until the next plan is applied. Only the most recent
+// maxRejectedPlanResults results are held.
+func (p *planner) holdRejectedPlanResult(plan *structs.Plan, result *structs.PlanResult) {
+	if plan.Job == nil || len(result.RejectedNodes) == 0 {
+		return
+	}
+
+	p.rejectedPlanResults = append(p.rejectedPlanResults, &structs.JobPlanResults{
+		Namespace:   plan.Job.Namespace,
+		JobID:       plan.Job.ID,
+		PlanResults: []*structs.PlanApplyResult{structs.NewPlanApplyResult(plan, result, time.Now().UTC().UnixNano())},
+	})
+	if n := len(p.rejectedPlanResults); n > maxRejectedPlanResults {
+		p.rejectedPlanResults = p.rejectedPlanResults[n-maxRejectedPlanResults:]
+	}
+}
+
 // planApply is a long lived goroutine that reads plan allocations from
 // the plan queue, determines if they can be applied safely and applies
 // them via Raft.
@@ -146,6 +176,7 @@ func (p *planner) planApply() {
 
 		// Fast-path the response if there is nothing to do
 		if result.IsNoOp() {
+			p.holdRejectedPlanResult(pending.plan, result)
 			pending.respond(result, nil)
 			continue
 		}
@@ -301,6 +332,14 @@ func (p *planner) applyPlan(plan *structs.Plan, result *structs.PlanResult, snap
 	}
 	req.PreemptionEvals = evals
 
+	// Record the outcome of the plan in the plan result history of the job,
+	// along with the plans rejected since the last applied plan
+	if plan.Job != nil {
+		req.PlanApplyResult = structs.NewPlanApplyResult(plan, result, now)
+	}
+	req.RejectedPlanResults = p.rejectedPlanResults
+	p.rejectedPlanResults = nil
+
 	// Dispatch the Raft transaction
 	future, err := p.raftApplyFuture(structs.ApplyPlanResultsRequestType, &req)
 	if err != nil {
@@ -444,6 +483,7 @@ func evaluatePlanPlacements(pool *EvaluatePool, snap *state.StateSnapshot, plan
 		Deployment:        plan.Deployment.Copy(),
 		DeploymentUpdates: plan.DeploymentUpdates,
 		NodePreemptions:   make(map[string][]*structs.Allocation),
+		RejectedNodes:     make(map[string]string),
 	}
 
 	// Collect all the nodeIDs
@@ -488,6 +528,9 @@ func evaluatePlanPlacements(pool *EvaluatePool, snap *state.StateSnapshot, plan
 				logger.Info("plan for node rejected, refer to https://www.nomadproject.io/s/port-plan-failure for more information",
 					"node_id", nodeID, "reason", reason, "eval_id", plan.EvalID)
 			}
+			// Record the rejection in the plan result history of the job
+			result.RejectedNodes[nodeID] = reason
+
 			// Set that this is a partial commit
 			partialCommit = true
 
@@ -604,6 +604,9 @@ func TestPlanApply_EvalPlan_Partial(t *testing.T) {
 	if result.RefreshIndex != 1001 {
 		t.Fatalf("bad: %d", result.RefreshIndex)
 	}
+
+	// Check the rejected node was recorded
+	require.Equal(t, map[string]string{node2.ID: "cpu"}, result.RejectedNodes)
 }
 
 func TestPlanApply_EvalPlan_Partial_AllAtOnce(t *testing.T) {
@@ -11,7 +11,9 @@ import (
 	"math/rand"
 	"net"
 	"net/rpc"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
@@ -59,8 +61,27 @@ type rpcHandler struct {
 
 	logger   log.Logger
 	gologger *golog.Logger
+
+	// commitIndex caches the commit index of raft, which is only exposed by
+	// the raft stats, see raftCommitIndex
+	commitIndexLock    sync.Mutex
+	commitIndex        uint64
+	commitIndexUpdated time.Time
 }
 
+const (
+	// raftCommitIndexTTL is how long the commit index read from the raft
+	// stats is cached for. Building the stats on every stale read would be
+	// expensive.
+	raftCommitIndexTTL = 10 * time.Millisecond
+
+	// staleReadApplyWait bounds how long a server serving a stale read waits
+	// to apply the entries committed by the leader, checking every
+	// staleReadApplyPoll, before forwarding the read to the leader.
+	staleReadApplyWait = 100 * time.Millisecond
+	staleReadApplyPoll = 5 * time.Millisecond
+)
+
 func newRpcHandler(s *Server) *rpcHandler {
 	logger := s.logger.NamedIntercept("rpc")
 
@@ -405,6 +426,9 @@ func (r *rpcHandler) handleMultiplex(ctx context.Context, conn net.Conn, rpcCtx
 func (r *rpcHandler) handleNomadConn(ctx context.Context, conn net.Conn, server *rpc.Server) {
 	defer conn.Close()
 	rpcCodec := pool.NewServerCodec(conn)
+	if r.config.AccessLogger != nil {
+		rpcCodec = newAccessLogCodec(rpcCodec, r.Server, conn.RemoteAddr().String())
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -549,7 +573,7 @@ func (r *rpcHandler) forward(method string, info structs.RPCInfo, args interface
 	}
 
 	// Check if we can allow a stale read
-	if info.IsRead() && info.AllowStaleRead() {
+	if info.IsRead() && info.AllowStaleRead() && r.withinMaxStale(info.MaxStaleRead()) {
 		return false, nil
 	}
 
@@ -569,6 +593,64 @@ func (r *rpcHandler) forward(method string, info structs.RPCInfo, args interface
 	return true, err
 }
 
+// withinMaxStale returns whether this server's state is fresh enough to serve
+// a stale read bounded by maxStale, or by the server's default bound if unset.
+// A follower learns the leader's commit index when the leader contacts it, so
+// once it has applied every entry up to that index its state is as fresh as
+// the leader's was at the time of the contact.
+func (r *rpcHandler) withinMaxStale(maxStale time.Duration) bool {
+	if maxStale == 0 {
+		maxStale = r.config.MaxStale
+	}
+	if maxStale <= 0 || r.IsLeader() {
+		return true
+	}
+
+	// The contact is read first, so that the commit index compared with the
+	// applied index is at least the one learned at the contact. The server
+	// briefly waits for the entries committed at the contact to be applied,
+	// as long as the read stays within the bound.
+	lastContact := r.raft.LastContact()
+	deadline := lastContact.Add(maxStale)
+	commitIndex := r.raftCommitIndex()
+	if r.raft.AppliedIndex() < commitIndex {
+		wait := time.Now().Add(staleReadApplyWait)
+		for r.raft.AppliedIndex() < commitIndex && time.Now().Before(wait) && time.Now().Before(deadline) {
+			time.Sleep(staleReadApplyPoll)
+		}
+	}
+
+	if r.raft.AppliedIndex() < commitIndex || time.Now().After(deadline) {
+		metrics.IncrCounter([]string{"nomad", "rpc", "query", "stale_exceeded"}, 1)
+		return false
+	}
+	return true
+}
+
+// raftCommitIndex returns the commit index of raft, read from the raft stats
+// at most raftCommitIndexTTL ago.
+func (r *rpcHandler) raftCommitIndex() uint64 {
+	r.commitIndexLock.Lock()
+	defer r.commitIndexLock.Unlock()
+
+	if time.Since(r.commitIndexUpdated) > raftCommitIndexTTL {
+		r.commitIndex, _ = strconv.ParseUint(r.raft.Stats()["commit_index"], 10, 64)
+		r.commitIndexUpdated = time.Now()
+	}
+	return r.commitIndex
+}
+
+// raftIndexLag returns the number of log entries this server knows the leader
+// has committed but hasn't applied yet.
+func (r *rpcHandler) raftIndexLag() uint64 {
+	commitIndex := r.raftCommitIndex()
+	appliedIndex := r.raft.AppliedIndex()
+	if appliedIndex >= commitIndex {
+		return 0
+	}
+	return commitIndex - appliedIndex
+}
+
 // getLeaderForRPC returns the server info of the currently known leader, or
 // nil if this server is the current leader.  If the local server is the leader
 // it blocks until it is ready to handle consistent RPC invocations.  If leader
@@ -786,6 +868,7 @@ func (r *rpcHandler) setQueryMeta(m *structs.QueryMeta) {
 	} else {
 		m.LastContact = time.Since(r.raft.LastContact())
 		m.KnownLeader = (r.raft.Leader() != "")
+		m.IndexLag = r.raftIndexLag()
 	}
 }
 
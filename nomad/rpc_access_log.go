@@ -0,0 +1,86 @@
+package nomad
+
+import (
+	"net/rpc"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/accesslog"
+)
+
+// accessLogCodec wraps the codec of an RPC connection and writes an access
+// log entry for every request served over it. The rpc.Server reads the
+// request and writes its response from the same goroutine, so the codec
+// tracks a single request at a time.
+type accessLogCodec struct {
+	rpc.ServerCodec
+
+	srv        *Server
+	log        *accesslog.Logger
+	remoteAddr string
+
+	start  time.Time
+	method string
+	args   interface{}
+}
+
+func newAccessLogCodec(codec rpc.ServerCodec, srv *Server, remoteAddr string) *accessLogCodec {
+	return &accessLogCodec{
+		ServerCodec: codec,
+		srv:         srv,
+		log:         srv.config.AccessLogger,
+		remoteAddr:  remoteAddr,
+	}
+}
+
+func (c *accessLogCodec) ReadRequestHeader(r *rpc.Request) error {
+	if err := c.ServerCodec.ReadRequestHeader(r); err != nil {
+		return err
+	}
+	c.start = time.Now()
+	c.method = r.ServiceMethod
+	c.args = nil
+	return nil
+}
+
+func (c *accessLogCodec) ReadRequestBody(body interface{}) error {
+	if err := c.ServerCodec.ReadRequestBody(body); err != nil {
+		return err
+	}
+	c.args = body
+	return nil
+}
+
+func (c *accessLogCodec) WriteResponse(r *rpc.Response, body interface{}) error {
+	err := c.ServerCodec.WriteResponse(r, body)
+
+	entry := &accesslog.Entry{
+		Time:       c.start,
+		Type:       accesslog.TypeRPC,
+		Endpoint:   c.method,
+		RemoteAddr: c.remoteAddr,
+	}
+	if args, ok := c.args.(interface{ RequestNamespace() string }); ok {
+		entry.Namespace = args.RequestNamespace()
+	}
+	if args, ok := c.args.(interface{ RequestRegion() string }); ok {
+		entry.Region = args.RequestRegion()
+	}
+	if args, ok := c.args.(interface{ RequestAuthToken() string }); ok {
+		entry.AccessorID = c.accessorID(args.RequestAuthToken())
+	}
+	entry.SetResult(r.Error)
+	entry.SetDuration(c.start)
+	c.log.Log(entry)
+
+	return err
+}
+
+// accessorID returns the accessor of the token with the given secret, or an
+// empty string if ACLs are disabled or the token does not exist.
+func (c *accessLogCodec) accessorID(secretID string) string {
+	token, err := c.srv.ResolveSecretToken(secretID)
+	if err != nil || token == nil {
+		return ""
+	}
+	return token.AccessorID
+}
@@ -0,0 +1,86 @@
+package nomad
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/accesslog"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// entryWriter passes every access log entry written to it over a channel.
+type entryWriter chan *accesslog.Entry
+
+func (w entryWriter) Write(p []byte) (int, error) {
+	var e accesslog.Entry
+	if err := json.Unmarshal(bytes.TrimSpace(p), &e); err != nil {
+		return 0, err
+	}
+	w <- &e
+	return len(p), nil
+}
+
+func TestRPC_AccessLog(t *testing.T) {
+	ci.Parallel(t)
+
+	entries := make(entryWriter, 100)
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.AccessLogger = accesslog.New(testlog.HCLogger(t), entries)
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// The server may make RPCs to itself, so skip any unrelated entries
+	nextEntry := func() *accesslog.Entry {
+		for {
+			select {
+			case e := <-entries:
+				if e.Endpoint == "Job.List" {
+					return e
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for access log entry")
+				return nil
+			}
+		}
+	}
+
+	// A request with a management token is logged with its accessor
+	req := &structs.JobListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: "prod",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.JobListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.List", req, &resp))
+
+	e := nextEntry()
+	require.Equal(t, accesslog.TypeRPC, e.Type)
+	require.Equal(t, "Job.List", e.Endpoint)
+	require.Equal(t, "prod", e.Namespace)
+	require.Equal(t, "global", e.Region)
+	require.Equal(t, root.AccessorID, e.AccessorID)
+	require.Equal(t, accesslog.ResultOK, e.Result)
+	require.NotEmpty(t, e.RemoteAddr)
+	require.False(t, e.Time.IsZero())
+
+	// A request with an unknown token is logged as denied
+	req.AuthToken = uuid.Generate()
+	require.Error(t, msgpackrpc.CallWithCodec(codec, "Job.List", req, &resp))
+
+	e = nextEntry()
+	require.Empty(t, e.AccessorID)
+	require.Equal(t, accesslog.ResultDenied, e.Result)
+	require.Equal(t, structs.ErrTokenNotFound.Error(), e.Error)
+}
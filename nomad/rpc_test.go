@@ -47,6 +47,62 @@ func rpcClient(t *testing.T, s *Server) rpc.ClientCodec {
 	return pool.NewClientCodec(conn)
 }
 
+func TestRPC_forward_MaxStale(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.BootstrapExpect = 2
+	})
+	defer cleanupS1()
+	s2, cleanupS2 := TestServer(t, func(c *Config) {
+		c.BootstrapExpect = 2
+	})
+	defer cleanupS2()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	follower := s1
+	if s1.IsLeader() {
+		follower = s2
+	}
+	codec := rpcClient(t, follower)
+
+	list := func(maxStale time.Duration) *structs.JobListResponse {
+		req := &structs.JobListRequest{
+			QueryOptions: structs.QueryOptions{
+				Region:     "global",
+				Namespace:  structs.DefaultNamespace,
+				AllowStale: true,
+				MaxStale:   maxStale,
+			},
+		}
+		var resp structs.JobListResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.List", req, &resp))
+		return &resp
+	}
+
+	// Followers within the bound serve the read once they have applied the
+	// entries committed by the leader
+	testutil.WaitForResult(func() (bool, error) {
+		resp := list(time.Hour)
+		if resp.LastContact == 0 {
+			return false, fmt.Errorf("read forwarded to the leader")
+		}
+		return resp.IndexLag == 0, fmt.Errorf("index lag %d", resp.IndexLag)
+	}, func(err error) {
+		t.Fatalf("follower didn't serve the read: %v", err)
+	})
+	require.NotZero(t, list(0).LastContact)
+
+	// Followers outside the bound forward the read to the leader
+	require.Zero(t, list(time.Nanosecond).LastContact)
+
+	// The server's default bound applies if the request doesn't set one
+	follower.config.MaxStale = time.Nanosecond
+	require.Zero(t, list(0).LastContact)
+}
+
 func TestRPC_forwardLeader(t *testing.T) {
 	ci.Parallel(t)
 
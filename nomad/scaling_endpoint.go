@@ -1,6 +1,7 @@
 package nomad
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -10,11 +11,12 @@ import (
 
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
-// Scaling endpoint is used for listing and retrieving scaling policies
+// Scaling endpoint is used for listing, retrieving and managing scaling policies
 type Scaling struct {
 	srv    *Server
 	logger log.Logger
@@ -185,6 +187,9 @@ func (p *Scaling) listAllNamespaces(args *structs.ScalingPolicyListRequest, repl
 				if prefix != "" && !strings.HasPrefix(policy.ID, prefix) {
 					continue
 				}
+				if args.Job != "" && policy.Target[structs.ScalingTargetJob] != args.Job {
+					continue
+				}
 				policies = append(policies, policy.Stub())
 			}
 			reply.Policies = policies
@@ -202,3 +207,244 @@ func (p *Scaling) listAllNamespaces(args *structs.ScalingPolicyListRequest, repl
 		}}
 	return p.srv.blockingRPC(&opts)
 }
+
+// ListScheduledActions is used to list the scheduled scaling actions that
+// haven't been applied yet
+func (p *Scaling) ListScheduledActions(args *structs.ScheduledScalingActionListRequest,
+	reply *structs.ScheduledScalingActionListResponse) error {
+
+	if done, err := p.srv.forward("Scaling.ListScheduledActions", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "scaling", "list_scheduled_actions"}, time.Now())
+
+	// Check for read-job or read-job-scaling permissions, as for the scaling
+	// status of a job
+	aclObj, err := p.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+	allow := func(ns string) bool {
+		return aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob) ||
+			aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJobScaling)
+	}
+	namespace := args.RequestNamespace()
+	if namespace != structs.AllNamespacesSentinel && aclObj != nil && !allow(namespace) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			var allowed map[string]bool
+			var iter memdb.ResultIterator
+			var err error
+			if namespace == structs.AllNamespacesSentinel {
+				allowed, err = allowedNSes(aclObj, state, allow)
+				if err == structs.ErrPermissionDenied {
+					// return empty if token isn't authorized for any namespace
+					reply.Actions = []*structs.ScheduledScalingAction{}
+					return nil
+				} else if err != nil {
+					return err
+				}
+				iter, err = state.ScheduledScalingActions(ws)
+			} else {
+				iter, err = state.ScheduledScalingActionsByNamespace(ws, namespace)
+			}
+			if err != nil {
+				return err
+			}
+
+			var actions []*structs.ScheduledScalingAction
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				action := raw.(*structs.ScheduledScalingAction)
+				if allowed != nil && !allowed[action.Namespace] {
+					continue
+				}
+				if args.Job != "" && action.JobID != args.Job {
+					continue
+				}
+				actions = append(actions, action)
+			}
+			reply.Actions = actions
+
+			// Use the last index that affected the scheduled scaling table
+			index, err := state.Index("scheduled_scaling")
+			if err != nil {
+				return err
+			}
+			reply.Index = helper.Uint64Max(1, index)
+
+			// Set the query response
+			p.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return p.srv.blockingRPC(&opts)
+}
+
+// UpsertPolicy is used to create or update a standalone scaling policy, which
+// is managed independently of its job's specification. Policies are
+// identified by their target and type, so upserting a policy for the target
+// and type of an existing standalone policy updates it.
+func (p *Scaling) UpsertPolicy(args *structs.ScalingPolicyUpsertRequest,
+	reply *structs.ScalingPolicyUpsertResponse) error {
+
+	if done, err := p.srv.forward("Scaling.UpsertPolicy", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "scaling", "upsert_policy"}, time.Now())
+
+	namespace := args.RequestNamespace()
+
+	// Check for scale-job permissions
+	if aclObj, err := p.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityScaleJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	policy := args.Policy
+	if policy == nil {
+		return structs.NewErrRPCCoded(400, "missing scaling policy")
+	}
+	if policy.Target == nil {
+		policy.Target = make(map[string]string)
+	}
+	if ns := policy.Target[structs.ScalingTargetNamespace]; ns == "" {
+		policy.Target[structs.ScalingTargetNamespace] = namespace
+	} else if ns != namespace {
+		return structs.NewErrRPCCoded(400,
+			fmt.Sprintf("scaling policy target namespace %q doesn't match request namespace %q", ns, namespace))
+	}
+	policy.Canonicalize()
+	policy.Standalone = true
+	if err := policy.Validate(); err != nil {
+		return structs.NewErrRPCCoded(400, err.Error())
+	}
+
+	snap, err := p.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	if err := validateScalingPolicyTarget(snap, policy); err != nil {
+		return err
+	}
+
+	existing, err := snap.ScalingPolicyByTargetAndType(nil, policy.Target, policy.Type)
+	if err != nil {
+		return err
+	}
+	if existing != nil && !existing.Standalone {
+		return structs.NewErrRPCCoded(400,
+			fmt.Sprintf("a %s scaling policy for this target is defined in the specification of job %q",
+				policy.Type, existing.Target[structs.ScalingTargetJob]))
+	}
+
+	if policy.ID != "" {
+		// Updates of a policy by ID can't move it to another target
+		current, err := snap.ScalingPolicyByID(nil, policy.ID)
+		if err != nil {
+			return err
+		}
+		if current == nil || current.Target[structs.ScalingTargetNamespace] != namespace {
+			return structs.NewErrRPCCoded(404, fmt.Sprintf("scaling policy %q not found", policy.ID))
+		}
+		if !current.Standalone {
+			return structs.NewErrRPCCoded(400,
+				fmt.Sprintf("scaling policy %q is defined in the specification of job %q",
+					policy.ID, current.Target[structs.ScalingTargetJob]))
+		}
+		if existing == nil || existing.ID != policy.ID {
+			return structs.NewErrRPCCoded(400,
+				fmt.Sprintf("the target and type of scaling policy %q can't be changed", policy.ID))
+		}
+	} else if existing != nil {
+		policy.ID = existing.ID
+	} else {
+		policy.ID = uuid.Generate()
+	}
+
+	_, index, err := p.srv.raftApply(structs.ScalingPolicyUpsertRequestType, args)
+	if err != nil {
+		p.logger.Error("upsert scaling policy failed", "error", err)
+		return err
+	}
+
+	reply.ID = policy.ID
+	reply.Index = index
+	return nil
+}
+
+// DeletePolicy is used to delete a standalone scaling policy. Policies defined
+// in a job's specification can only be removed from it.
+func (p *Scaling) DeletePolicy(args *structs.ScalingPolicyDeleteRequest, reply *structs.GenericResponse) error {
+	if done, err := p.srv.forward("Scaling.DeletePolicy", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "scaling", "delete_policy"}, time.Now())
+
+	namespace := args.RequestNamespace()
+
+	// Check for scale-job permissions
+	if aclObj, err := p.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityScaleJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.ID == "" {
+		return structs.NewErrRPCCoded(400, "missing scaling policy ID")
+	}
+
+	policy, err := p.srv.fsm.State().ScalingPolicyByID(nil, args.ID)
+	if err != nil {
+		return err
+	}
+	if policy == nil || policy.Target[structs.ScalingTargetNamespace] != namespace {
+		return structs.NewErrRPCCoded(404, fmt.Sprintf("scaling policy %q not found", args.ID))
+	}
+	if !policy.Standalone {
+		return structs.NewErrRPCCoded(400,
+			fmt.Sprintf("scaling policy %q is defined in the specification of job %q and can't be deleted",
+				args.ID, policy.Target[structs.ScalingTargetJob]))
+	}
+
+	_, index, err := p.srv.raftApply(structs.ScalingPolicyDeleteRequestType, args)
+	if err != nil {
+		p.logger.Error("delete scaling policy failed", "error", err)
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// validateScalingPolicyTarget checks that the job, group and task targeted by
+// the policy exist.
+func validateScalingPolicyTarget(snap *state.StateSnapshot, policy *structs.ScalingPolicy) error {
+	namespace := policy.Target[structs.ScalingTargetNamespace]
+	jobID := policy.Target[structs.ScalingTargetJob]
+	job, err := snap.JobByID(nil, namespace, jobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return structs.NewErrRPCCoded(400, fmt.Sprintf("scaling policy target job %q not found", jobID))
+	}
+
+	groupName := policy.Target[structs.ScalingTargetGroup]
+	group := job.LookupTaskGroup(groupName)
+	if group == nil {
+		return structs.NewErrRPCCoded(400,
+			fmt.Sprintf("scaling policy target group %q not found in job %q", groupName, jobID))
+	}
+
+	if taskName := policy.Target[structs.ScalingTargetTask]; taskName != "" && group.LookupTask(taskName) == nil {
+		return structs.NewErrRPCCoded(400,
+			fmt.Sprintf("scaling policy target task %q not found in group %q", taskName, groupName))
+	}
+	return nil
+}
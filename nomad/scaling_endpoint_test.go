@@ -1,12 +1,14 @@
 package nomad
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -208,25 +210,28 @@ func TestScalingEndpoint_ListPolicies(t *testing.T) {
 		},
 	}
 
-	for _, tc := range cases {
-		t.Run(tc.Label, func(t *testing.T) {
-			get := &structs.ScalingPolicyListRequest{
-				Job:  tc.Job,
-				Type: tc.Type,
-				QueryOptions: structs.QueryOptions{
-					Region:    "global",
-					Namespace: "default",
-				},
-			}
-			var resp structs.ScalingPolicyListResponse
-			err = msgpackrpc.CallWithCodec(codec, "Scaling.ListPolicies", get, &resp)
-			require.NoError(t, err)
-			stubs := []*structs.ScalingPolicyListStub{}
-			for _, p := range tc.Expected {
-				stubs = append(stubs, p.Stub())
-			}
-			require.ElementsMatch(t, stubs, resp.Policies)
-		})
+	// The filters must apply whether the namespace is given or the wildcard
+	for _, ns := range []string{structs.DefaultNamespace, structs.AllNamespacesSentinel} {
+		for _, tc := range cases {
+			t.Run(fmt.Sprintf("%s in %s", tc.Label, ns), func(t *testing.T) {
+				get := &structs.ScalingPolicyListRequest{
+					Job:  tc.Job,
+					Type: tc.Type,
+					QueryOptions: structs.QueryOptions{
+						Region:    "global",
+						Namespace: ns,
+					},
+				}
+				var resp structs.ScalingPolicyListResponse
+				err = msgpackrpc.CallWithCodec(codec, "Scaling.ListPolicies", get, &resp)
+				require.NoError(t, err)
+				stubs := []*structs.ScalingPolicyListStub{}
+				for _, p := range tc.Expected {
+					stubs = append(stubs, p.Stub())
+				}
+				require.ElementsMatch(t, stubs, resp.Policies)
+			})
+		}
 	}
 }
 
@@ -353,3 +358,409 @@ func TestScalingEndpoint_ListPolicies_Blocking(t *testing.T) {
 	require.Len(resp.Policies, 2)
 	require.ElementsMatch([]string{p1.ID, p2.ID}, []string{resp.Policies[0].ID, resp.Policies[1].ID})
 }
+
+func TestScalingEndpoint_UpsertPolicy(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+	scaledJob, _ := mock.JobWithScalingPolicy()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1001, scaledJob))
+
+	newPolicy := func(job *structs.Job, group string) *structs.ScalingPolicy {
+		return &structs.ScalingPolicy{
+			Min: 1,
+			Max: 5,
+			Target: map[string]string{
+				structs.ScalingTargetJob:   job.ID,
+				structs.ScalingTargetGroup: group,
+			},
+			Policy:  map[string]interface{}{"a": "b"},
+			Enabled: true,
+		}
+	}
+	upsert := func(policy *structs.ScalingPolicy) (*structs.ScalingPolicyUpsertResponse, error) {
+		req := &structs.ScalingPolicyUpsertRequest{
+			Policy: policy,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var resp structs.ScalingPolicyUpsertResponse
+		err := msgpackrpc.CallWithCodec(codec, "Scaling.UpsertPolicy", req, &resp)
+		return &resp, err
+	}
+
+	// Create a policy
+	resp, err := upsert(newPolicy(job, "web"))
+	require.NoError(err)
+	require.NotEmpty(resp.ID)
+	require.NotZero(resp.Index)
+
+	policy, err := state.ScalingPolicyByID(nil, resp.ID)
+	require.NoError(err)
+	require.NotNil(policy)
+	require.True(policy.Standalone)
+	require.Equal(structs.ScalingPolicyTypeHorizontal, policy.Type)
+	require.Equal(structs.DefaultNamespace, policy.Target[structs.ScalingTargetNamespace])
+	require.EqualValues(5, policy.Max)
+
+	// Upserting a policy for the same target updates it
+	updated := newPolicy(job, "web")
+	updated.Max = 10
+	resp2, err := upsert(updated)
+	require.NoError(err)
+	require.Equal(resp.ID, resp2.ID)
+
+	policy, err = state.ScalingPolicyByID(nil, resp.ID)
+	require.NoError(err)
+	require.EqualValues(10, policy.Max)
+	require.Equal(resp.Index, policy.CreateIndex)
+
+	// Updating by ID can't change the target
+	moved := newPolicy(scaledJob, "web")
+	moved.ID = resp.ID
+	_, err = upsert(moved)
+	require.Error(err)
+
+	// Unknown IDs are rejected
+	unknown := newPolicy(job, "web")
+	unknown.ID = uuid.Generate()
+	_, err = upsert(unknown)
+	require.Error(err)
+	require.Contains(err.Error(), "not found")
+
+	// The target must exist
+	_, err = upsert(newPolicy(job, "nope"))
+	require.Error(err)
+	require.Contains(err.Error(), `target group "nope" not found`)
+
+	missingJob := newPolicy(mock.Job(), "web")
+	_, err = upsert(missingJob)
+	require.Error(err)
+	require.Contains(err.Error(), "target job")
+
+	// Policies can't replace the ones defined in job specifications
+	_, err = upsert(newPolicy(scaledJob, "web"))
+	require.Error(err)
+	require.Contains(err.Error(), "is defined in the specification of job")
+
+	// Invalid policies are rejected
+	invalid := newPolicy(job, "web")
+	invalid.Max = -1
+	_, err = upsert(invalid)
+	require.Error(err)
+	require.Contains(err.Error(), "maximum count must be specified")
+
+	// The policy bounds the count of the group
+	scale := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: "web",
+		},
+		Count: helper.Int64ToPtr(11),
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var scaleResp structs.JobRegisterResponse
+	err = msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &scaleResp)
+	require.Error(err)
+	require.Contains(err.Error(), "group count was greater than scaling policy maximum: 11 > 10")
+}
+
+func TestScalingEndpoint_UpsertPolicy_ACL(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	req := &structs.ScalingPolicyUpsertRequest{
+		Policy: &structs.ScalingPolicy{
+			Max: 5,
+			Target: map[string]string{
+				structs.ScalingTargetJob:   job.ID,
+				structs.ScalingTargetGroup: "web",
+			},
+			Enabled: true,
+		},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+
+	// Upsert without a token should fail
+	var resp structs.ScalingPolicyUpsertResponse
+	err := msgpackrpc.CallWithCodec(codec, "Scaling.UpsertPolicy", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
+
+	// Upsert with a read token should fail
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "test-read",
+		mock.NamespacePolicy(structs.DefaultNamespace, "read", nil))
+	req.AuthToken = readToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Scaling.UpsertPolicy", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
+
+	// Upsert with the scale-job capability should succeed
+	scaleToken := mock.CreatePolicyAndToken(t, state, 1003, "test-scale",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityScaleJob}))
+	req.AuthToken = scaleToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.UpsertPolicy", req, &resp))
+	require.NotEmpty(resp.ID)
+
+	// And so should deleting it
+	del := &structs.ScalingPolicyDeleteRequest{
+		ID: resp.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var delResp structs.GenericResponse
+	err = msgpackrpc.CallWithCodec(codec, "Scaling.DeletePolicy", del, &delResp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
+
+	del.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Scaling.DeletePolicy", del, &delResp))
+}
+
+func TestScalingEndpoint_DeletePolicy(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job, jobPolicy := mock.JobWithScalingPolicy()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	policy := mock.ScalingPolicy()
+	policy.Standalone = true
+	require.NoError(state.UpsertScalingPolicies(1001, []*structs.ScalingPolicy{policy}))
+
+	del := func(id string) error {
+		req := &structs.ScalingPolicyDeleteRequest{
+			ID: id,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var resp structs.GenericResponse
+		return msgpackrpc.CallWithCodec(codec, "Scaling.DeletePolicy", req, &resp)
+	}
+
+	// Policies defined in a job can't be deleted
+	err := del(jobPolicy.ID)
+	require.Error(err)
+	require.Contains(err.Error(), "can't be deleted")
+
+	// Standalone policies can
+	require.NoError(del(policy.ID))
+	out, err := state.ScalingPolicyByID(nil, policy.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	// Unknown policies are reported
+	err = del(policy.ID)
+	require.Error(err)
+	require.Contains(err.Error(), "not found")
+}
+
+func TestScalingEndpoint_ListScheduledActions(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	action1 := &structs.ScheduledScalingAction{
+		ID:         uuid.Generate(),
+		Namespace:  structs.DefaultNamespace,
+		JobID:      "example",
+		TaskGroup:  "web",
+		Count:      5,
+		ScheduleAt: time.Now().Add(time.Hour),
+	}
+	action2 := *action1
+	action2.ID = uuid.Generate()
+	action2.JobID = "other-job"
+	action3 := *action1
+	action3.ID = uuid.Generate()
+	action3.Namespace = "other"
+	require.NoError(state.UpsertScheduledScalingAction(structs.MsgTypeTestSetup, 1000, action1))
+	require.NoError(state.UpsertScheduledScalingAction(structs.MsgTypeTestSetup, 1001, &action2))
+	require.NoError(state.UpsertScheduledScalingAction(structs.MsgTypeTestSetup, 1002, &action3))
+
+	cases := []struct {
+		Label     string
+		Namespace string
+		Job       string
+		Expected  []string
+	}{
+		{
+			Label:     "namespace",
+			Namespace: structs.DefaultNamespace,
+			Expected:  []string{action1.ID, action2.ID},
+		},
+		{
+			Label:     "job filter",
+			Namespace: structs.DefaultNamespace,
+			Job:       action1.JobID,
+			Expected:  []string{action1.ID},
+		},
+		{
+			Label:     "all namespaces",
+			Namespace: structs.AllNamespacesSentinel,
+			Expected:  []string{action1.ID, action2.ID, action3.ID},
+		},
+		{
+			Label:     "job filter in all namespaces",
+			Namespace: structs.AllNamespacesSentinel,
+			Job:       action1.JobID,
+			Expected:  []string{action1.ID, action3.ID},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Label, func(t *testing.T) {
+			get := &structs.ScheduledScalingActionListRequest{
+				Job: tc.Job,
+				QueryOptions: structs.QueryOptions{
+					Region:    "global",
+					Namespace: tc.Namespace,
+				},
+			}
+			var resp structs.ScheduledScalingActionListResponse
+			err := msgpackrpc.CallWithCodec(codec, "Scaling.ListScheduledActions", get, &resp)
+			require.NoError(err)
+			require.EqualValues(1002, resp.Index)
+
+			var ids []string
+			for _, action := range resp.Actions {
+				ids = append(ids, action.ID)
+			}
+			require.ElementsMatch(tc.Expected, ids)
+		})
+	}
+}
+
+func TestScalingEndpoint_ListScheduledActions_ACL(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	ns := mock.Namespace()
+	ns.Name = "other"
+	require.NoError(state.UpsertNamespaces(900, []*structs.Namespace{ns}))
+
+	action1 := &structs.ScheduledScalingAction{
+		ID:         uuid.Generate(),
+		Namespace:  structs.DefaultNamespace,
+		JobID:      "example",
+		TaskGroup:  "web",
+		Count:      5,
+		ScheduleAt: time.Now().Add(time.Hour),
+	}
+	action2 := *action1
+	action2.ID = uuid.Generate()
+	action2.Namespace = ns.Name
+	require.NoError(state.UpsertScheduledScalingAction(structs.MsgTypeTestSetup, 1000, action1))
+	require.NoError(state.UpsertScheduledScalingAction(structs.MsgTypeTestSetup, 1001, &action2))
+
+	get := &structs.ScheduledScalingActionListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+
+	// lookup without token should fail
+	var resp structs.ScheduledScalingActionListResponse
+	err := msgpackrpc.CallWithCodec(codec, "Scaling.ListScheduledActions", get, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
+
+	// Expect failure for request with a token of another namespace
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1003, "test-invalid",
+		mock.NamespacePolicy(ns.Name, "", []string{acl.NamespaceCapabilityReadJob}))
+	get.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Scaling.ListScheduledActions", get, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "Permission denied")
+
+	// The wildcard namespace only lists the actions of the namespaces the
+	// token can read
+	get.Namespace = structs.AllNamespacesSentinel
+	err = msgpackrpc.CallWithCodec(codec, "Scaling.ListScheduledActions", get, &resp)
+	require.NoError(err)
+	require.Len(resp.Actions, 1)
+	require.Equal(action2.ID, resp.Actions[0].ID)
+	get.Namespace = structs.DefaultNamespace
+
+	cases := []struct {
+		name      string
+		authToken string
+	}{
+		{
+			name:      "mgmt token should succeed",
+			authToken: root.SecretID,
+		},
+		{
+			name: "read disposition should succeed",
+			authToken: mock.CreatePolicyAndToken(t, state, 1005, "test-valid-read",
+				mock.NamespacePolicy(structs.DefaultNamespace, "read", nil)).SecretID,
+		},
+		{
+			name: "autoscaler disposition should succeed",
+			authToken: mock.CreatePolicyAndToken(t, state, 1005, "test-valid-autoscaler",
+				mock.NamespacePolicy(structs.DefaultNamespace, "scale", nil)).SecretID,
+		},
+		{
+			name: "read-job-scaling capability should succeed",
+			authToken: mock.CreatePolicyAndToken(t, state, 1005, "test-valid-read-job-scaling",
+				mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJobScaling})).SecretID,
+		},
+	}
+
+	for _, tc := range cases {
+		get.AuthToken = tc.authToken
+		err = msgpackrpc.CallWithCodec(codec, "Scaling.ListScheduledActions", get, &resp)
+		require.NoError(err, tc.name)
+		require.EqualValues(1001, resp.Index)
+		require.Len(resp.Actions, 1)
+		require.Equal(action1.ID, resp.Actions[0].ID)
+	}
+}
@@ -0,0 +1,49 @@
+package nomad
+
+import (
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// scalingWebhookPayload is the body POSTed to the scaling webhook for each
+// scaling event.
+type scalingWebhookPayload struct {
+	// Index is the Raft index at which the scaling event was stored
+	Index uint64
+
+	Namespace string
+	JobID     string
+	TaskGroup string
+	Event     *structs.ScalingEvent
+}
+
+// scalingWebhook delivers scaling events to an operator defined endpoint.
+type scalingWebhook struct {
+	*webhook
+}
+
+// newScalingWebhook returns a scalingWebhook for the given configuration.
+func newScalingWebhook(config *structs.ScalingWebhookConfig, logger log.Logger) (*scalingWebhook, error) {
+	w, err := newWebhook("scaling event", config.URL, config.Secret, config.CAFile,
+		logger.Named("scaling_webhook"))
+	if err != nil {
+		return nil, err
+	}
+	return &scalingWebhook{webhook: w}, nil
+}
+
+// notify queues a scaling event for delivery. It never blocks; events are
+// dropped if the queue is full. It is safe to call on a nil scalingWebhook.
+func (w *scalingWebhook) notify(index uint64, req *structs.ScalingEventRequest) {
+	if w == nil {
+		return
+	}
+
+	w.enqueue(&scalingWebhookPayload{
+		Index:     index,
+		Namespace: req.Namespace,
+		JobID:     req.JobID,
+		TaskGroup: req.TaskGroup,
+		Event:     req.ScalingEvent,
+	}, "namespace", req.Namespace, "job_id", req.JobID, "task_group", req.TaskGroup, "index", index)
+}
@@ -0,0 +1,156 @@
+package nomad
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type scalingWebhookRequest struct {
+	signature string
+	body      []byte
+}
+
+// testScalingWebhookServer starts a TLS server that records the requests it
+// receives, failing the first failures of them, and returns its
+// configuration with the server's certificate as the CA file.
+func testScalingWebhookServer(t *testing.T, failures int) (*structs.ScalingWebhookConfig, <-chan scalingWebhookRequest) {
+	reqCh := make(chan scalingWebhookRequest, 10)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		reqCh <- scalingWebhookRequest{
+			signature: r.Header.Get(webhookSignatureHeader),
+			body:      body,
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, ca, 0600))
+
+	return &structs.ScalingWebhookConfig{
+		URL:    srv.URL,
+		Secret: "s3cr3t",
+		CAFile: caFile,
+	}, reqCh
+}
+
+func TestScalingWebhook_Deliver(t *testing.T) {
+	ci.Parallel(t)
+
+	config, reqCh := testScalingWebhookServer(t, 1)
+	webhook, err := newScalingWebhook(config, testlog.HCLogger(t))
+	require.NoError(t, err)
+
+	webhook.notify(42, &structs.ScalingEventRequest{
+		Namespace:    "default",
+		JobID:        "example",
+		TaskGroup:    "web",
+		ScalingEvent: structs.NewScalingEvent("because"),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go webhook.run(ctx)
+
+	// the first attempt fails and the event is retried
+	var req scalingWebhookRequest
+	select {
+	case req = <-reqCh:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for scaling webhook")
+	}
+
+	require.Equal(t, "sha256="+webhookSignature("s3cr3t", req.body), req.signature)
+
+	var payload scalingWebhookPayload
+	require.NoError(t, json.Unmarshal(req.body, &payload))
+	require.Equal(t, uint64(42), payload.Index)
+	require.Equal(t, "example", payload.JobID)
+	require.Equal(t, "web", payload.TaskGroup)
+	require.Equal(t, "because", payload.Event.Message)
+}
+
+func TestScalingWebhook_BadCAFile(t *testing.T) {
+	ci.Parallel(t)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte("not a cert"), 0600))
+
+	_, err := newScalingWebhook(&structs.ScalingWebhookConfig{
+		URL:    "https://example.com",
+		Secret: "s3cr3t",
+		CAFile: caFile,
+	}, testlog.HCLogger(t))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to parse any valid certificates")
+}
+
+func TestJobEndpoint_Scale_Webhook(t *testing.T) {
+	ci.Parallel(t)
+
+	config, reqCh := testScalingWebhookServer(t, 0)
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.ScalingWebhookConfig = config
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	require.NoError(t, s1.fsm.State().UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	scale := &structs.JobScaleRequest{
+		JobID: job.ID,
+		Target: map[string]string{
+			structs.ScalingTargetGroup: job.TaskGroups[0].Name,
+		},
+		Count:   helper.Int64ToPtr(int64(job.TaskGroups[0].Count + 1)),
+		Message: "because of the load",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Scale", scale, &resp))
+
+	var req scalingWebhookRequest
+	select {
+	case req = <-reqCh:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for scaling webhook")
+	}
+
+	var payload scalingWebhookPayload
+	require.NoError(t, json.Unmarshal(req.body, &payload))
+	require.Equal(t, resp.Index, payload.Index)
+	require.Equal(t, job.Namespace, payload.Namespace)
+	require.Equal(t, job.ID, payload.JobID)
+	require.Equal(t, job.TaskGroups[0].Name, payload.TaskGroup)
+	require.Equal(t, "because of the load", payload.Event.Message)
+	require.Equal(t, int64(job.TaskGroups[0].Count), payload.Event.PreviousCount)
+	require.Equal(t, resp.EvalID, *payload.Event.EvalID)
+}
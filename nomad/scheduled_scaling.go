@@ -0,0 +1,161 @@
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// scheduledScalingRetryInterval is how long the leader waits before
+	// retrying after failing to read or remove scheduled scaling actions.
+	scheduledScalingRetryInterval = 5 * time.Second
+)
+
+// applyScheduledScaling is a long lived function that applies scheduled
+// scaling actions once they are due while we are leader. Applied actions are
+// removed from the state store whether or not the scaling succeeded; failures
+// are recorded as scaling events on the job.
+func (s *Server) applyScheduledScaling(stopCh chan struct{}) {
+	for {
+		ws := memdb.NewWatchSet()
+		due, next, err := s.scheduledScalingActions(ws, time.Now())
+		if err != nil {
+			s.logger.Error("failed to read scheduled scaling actions", "error", err)
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(scheduledScalingRetryInterval):
+				continue
+			}
+		}
+
+		if len(due) != 0 {
+			if err := s.applyScheduledScalingActions(due); err != nil {
+				s.logger.Error("failed to remove applied scheduled scaling actions", "error", err)
+				select {
+				case <-stopCh:
+					return
+				case <-time.After(scheduledScalingRetryInterval):
+				}
+			}
+			continue
+		}
+
+		// Wait for the next action to become due or for the set of actions
+		// to change
+		var timerCh <-chan time.Time
+		var timer *time.Timer
+		if !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			timerCh = timer.C
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		select {
+		case <-stopCh:
+		case <-timerCh:
+		case <-ws.WatchCh(ctx):
+		}
+		cancel()
+		if timer != nil {
+			timer.Stop()
+		}
+
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+	}
+}
+
+// scheduledScalingActions returns the scheduled scaling actions that are due
+// at now and the time at which the next pending action becomes due, which is
+// zero if there are none.
+func (s *Server) scheduledScalingActions(ws memdb.WatchSet, now time.Time) ([]*structs.ScheduledScalingAction, time.Time, error) {
+	iter, err := s.State().ScheduledScalingActions(ws)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var due []*structs.ScheduledScalingAction
+	var next time.Time
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		action := raw.(*structs.ScheduledScalingAction)
+		if !action.ScheduleAt.After(now) {
+			due = append(due, action)
+			continue
+		}
+		if next.IsZero() || action.ScheduleAt.Before(next) {
+			next = action.ScheduleAt
+		}
+	}
+	return due, next, nil
+}
+
+// applyScheduledScalingActions scales the jobs targeted by the given actions
+// and removes the actions from the state store.
+func (s *Server) applyScheduledScalingActions(actions []*structs.ScheduledScalingAction) error {
+	ids := make([]string, 0, len(actions))
+	for _, action := range actions {
+		s.applyScheduledScalingAction(action)
+		ids = append(ids, action.ID)
+	}
+
+	req := &structs.ScheduledScalingDeleteRequest{
+		IDs:          ids,
+		WriteRequest: structs.WriteRequest{Region: s.config.Region},
+	}
+	_, _, err := s.raftApply(structs.ScheduledScalingDeleteRequestType, req)
+	return err
+}
+
+// applyScheduledScalingAction scales the task group targeted by the action.
+// If scaling fails, an error scaling event is recorded for the job instead.
+func (s *Server) applyScheduledScalingAction(action *structs.ScheduledScalingAction) {
+	logger := s.logger.With("namespace", action.Namespace, "job_id", action.JobID,
+		"task_group", action.TaskGroup, "scheduled_scaling_id", action.ID)
+
+	count := action.Count
+	req := &structs.JobScaleRequest{
+		JobID: action.JobID,
+		Target: map[string]string{
+			structs.ScalingTargetJob:   action.JobID,
+			structs.ScalingTargetGroup: action.TaskGroup,
+		},
+		Count:   &count,
+		Message: action.Message,
+		Meta:    action.Meta,
+		WriteRequest: structs.WriteRequest{
+			Region:    s.config.Region,
+			Namespace: action.Namespace,
+			AuthToken: s.getLeaderAcl(),
+		},
+	}
+
+	var resp structs.JobRegisterResponse
+	err := s.RPC("Job.Scale", req, &resp)
+	if err == nil {
+		logger.Debug("applied scheduled scaling action", "count", action.Count)
+		return
+	}
+
+	// There is nothing to record the failure against if the job is gone
+	if code, _, ok := structs.CodeFromRPCCodedErr(err); ok && code == 404 {
+		logger.Debug("dropping scheduled scaling action for missing job")
+		return
+	}
+
+	logger.Warn("failed to apply scheduled scaling action", "error", err)
+
+	req.Count = nil
+	req.Error = true
+	req.Message = fmt.Sprintf("failed to apply scheduled scaling to count %d: %v", action.Count, err)
+	if err := s.RPC("Job.Scale", req, &resp); err != nil {
+		logger.Error("failed to record scheduled scaling failure", "error", err)
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/hashicorp/nomad/command/agent/consul"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/codec"
+	"github.com/hashicorp/nomad/helper/labelguard"
 	"github.com/hashicorp/nomad/helper/pool"
 	"github.com/hashicorp/nomad/helper/stats"
 	"github.com/hashicorp/nomad/helper/tlsutil"
@@ -110,6 +111,7 @@ type Server struct {
 	raftStore     *raftboltdb.BoltStore
 	raftInmem     *raft.InmemStore
 	raftTransport *raft.NetworkTransport
+	raftSnapshots raft.SnapshotStore
 
 	// reassertLeaderCh is used to signal that the leader loop must
 	// re-establish leadership.
@@ -201,6 +203,33 @@ type Server struct {
 	// volumeWatcher is used to release volume claims
 	volumeWatcher *volumewatcher.Watcher
 
+	// scalingWebhook delivers scaling events to an external endpoint. It is
+	// nil if no webhook is configured.
+	scalingWebhook *scalingWebhook
+
+	// deploymentWebhook delivers deployment status updates to an external
+	// endpoint. It is nil if no webhook is configured.
+	deploymentWebhook *deploymentWebhook
+
+	// identitySigner signs the workload identities of tasks. It is nil if
+	// workload identities are not configured.
+	identitySigner *identitySigner
+
+	// nodeScorer contributes an external score to the ranking of nodes by
+	// the schedulers. It is nil if no scorer is configured.
+	nodeScorer scheduler.NodeScorer
+
+	// gcTuner tunes the GC thresholds to the size of the state of the
+	// server.
+	gcTuner *gcTuner
+
+	// jobRegisterQueue applies the asynchronous job registrations on the
+	// leader.
+	jobRegisterQueue *jobRegisterQueue
+
+	// scoringPlugins are the scoring plugins enabled on the server
+	scoringPlugins []*scheduler.WeightedScoringPlugin
+
 	// evalBroker is used to manage the in-progress evaluations
 	// that are waiting to be brokered to a sub-scheduler
 	evalBroker *EvalBroker
@@ -228,6 +257,10 @@ type Server struct {
 	// vault is the client for communicating with Vault.
 	vault VaultClient
 
+	// vaultClusters are the clients for communicating with the named Vault
+	// clusters, indexed by name.
+	vaultClusters map[string]VaultClient
+
 	// Worker used for processing
 	workers          []*Worker
 	workerLock       sync.RWMutex
@@ -250,6 +283,10 @@ type Server struct {
 	// Nomad router.
 	statsFetcher *StatsFetcher
 
+	// namespaceLabels bounds the namespace label of the metrics aggregated
+	// by namespace. It is nil if these metrics are disabled.
+	namespaceLabels *labelguard.Guard
+
 	// EnterpriseState is used to fill in state for Pro/Ent builds
 	EnterpriseState
 
@@ -287,6 +324,7 @@ type endpoints struct {
 	Agent             *Agent
 	ClientAllocations *ClientAllocations
 	ClientCSI         *ClientCSI
+	NodeMeta          *NodeMeta
 }
 
 // NewServer is used to construct a new Nomad server from the
@@ -347,6 +385,10 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigEntr
 	s.shutdownCtx, s.shutdownCancel = context.WithCancel(context.Background())
 	s.shutdownCh = s.shutdownCtx.Done()
 
+	if config.NamespaceMetrics {
+		s.namespaceLabels = labelguard.New(config.NamespaceMetricsAllowlist, config.NamespaceMetricsLimit)
+	}
+
 	// Create the RPC handler
 	s.rpcHandler = newRpcHandler(s)
 
@@ -426,6 +468,57 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigEntr
 	// Setup the node drainer.
 	s.setupNodeDrainer()
 
+	// Setup the scaling webhook
+	if err := s.setupScalingWebhook(); err != nil {
+		s.logger.Error("failed to create scaling webhook", "error", err)
+		return nil, fmt.Errorf("failed to create scaling webhook: %v", err)
+	}
+
+	// Setup the deployment webhook
+	if err := s.setupDeploymentWebhook(); err != nil {
+		s.logger.Error("failed to create deployment webhook", "error", err)
+		return nil, fmt.Errorf("failed to create deployment webhook: %v", err)
+	}
+
+	// Setup the signing of workload identities
+	if s.config.WorkloadIdentityConfig != nil {
+		signer, err := newIdentitySigner(s.config.WorkloadIdentityConfig, s.State)
+		if err != nil {
+			s.logger.Error("failed to create workload identity signer", "error", err)
+			return nil, fmt.Errorf("failed to create workload identity signer: %v", err)
+		}
+		s.identitySigner = signer
+	}
+
+	// Setup the tuning of the GC thresholds
+	s.gcTuner = newGCTuner(s, s.config.GCAutoTuneConfig)
+	go s.gcTuner.run(s.shutdownCtx)
+
+	// Setup the queue of asynchronous job registrations
+	s.jobRegisterQueue = newJobRegisterQueue(s.logger, s.config.JobRegisterQueueLimit,
+		func(args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse) error {
+			return s.RPC("Job.Register", args, reply)
+		})
+
+	// Setup the external node scorer
+	if s.config.NodeScorerConfig != nil {
+		s.nodeScorer = newExternalNodeScorer(s.config.NodeScorerConfig, s.logger)
+	}
+
+	// Setup the scoring plugins
+	for _, p := range s.config.ScoringPluginConfigs {
+		plugin, ok := scheduler.LookupScoringPlugin(p.Name)
+		if !ok {
+			return nil, fmt.Errorf("scoring plugin %q is not registered; registered plugins: %v",
+				p.Name, scheduler.ScoringPluginNames())
+		}
+		s.scoringPlugins = append(s.scoringPlugins, &scheduler.WeightedScoringPlugin{
+			Name:   p.Name,
+			Weight: p.Weight,
+			Plugin: plugin,
+		})
+	}
+
 	// Setup the enterprise state
 	if err := s.setupEnterprise(config); err != nil {
 		return nil, err
@@ -663,6 +756,9 @@ func (s *Server) Shutdown() error {
 	if s.vault != nil {
 		s.vault.Stop()
 	}
+	for _, v := range s.vaultClusters {
+		v.Stop()
+	}
 
 	// Stop the Consul ACLs token revocations
 	s.consulACLs.Stop()
@@ -792,6 +888,16 @@ func (s *Server) Reload(newConfig *Config) error {
 			_ = multierror.Append(&mErr, err)
 		}
 	}
+	for _, cluster := range newConfig.VaultConfig.Clusters {
+		v, ok := s.vaultClusters[cluster.Name]
+		if !ok {
+			s.logger.Warn("adding a Vault cluster requires a restart", "vault_cluster", cluster.Name)
+			continue
+		}
+		if err := v.SetConfig(cluster.Config()); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("vault cluster %q: %v", cluster.Name, err))
+		}
+	}
 
 	shouldReloadTLS, err := tlsutil.ShouldReloadRPCConnections(s.config.TLSConfig, newConfig.TLSConfig)
 	if err != nil {
@@ -1021,7 +1127,8 @@ func (s *Server) setupDeploymentWatcher() error {
 	// Create the raft shim type to restrict the set of raft methods that can be
 	// made
 	raftShim := &deploymentWatcherRaftShim{
-		apply: s.raftApply,
+		apply:         s.raftApply,
+		statusUpdated: s.notifyDeploymentWebhook,
 	}
 
 	// Create the deployment watcher
@@ -1034,6 +1141,12 @@ func (s *Server) setupDeploymentWatcher() error {
 		deploymentwatcher.CrossDeploymentUpdateBatchDuration,
 	)
 
+	// Evaluate the SLO queries of deployments if a metrics endpoint is
+	// configured
+	if s.config.SLOMetricsConfig != nil {
+		s.deploymentWatcher.SetSLOQuerier(newPrometheusSLOQuerier(s.config.SLOMetricsConfig))
+	}
+
 	return nil
 }
 
@@ -1062,6 +1175,53 @@ func (s *Server) setupNodeDrainer() {
 	s.nodeDrainer = drainer.NewNodeDrainer(c)
 }
 
+// setupScalingWebhook creates and starts the delivery of scaling events to
+// the configured webhook, if any.
+func (s *Server) setupScalingWebhook() error {
+	if s.config.ScalingWebhookConfig == nil {
+		return nil
+	}
+
+	webhook, err := newScalingWebhook(s.config.ScalingWebhookConfig, s.logger)
+	if err != nil {
+		return err
+	}
+	s.scalingWebhook = webhook
+	go webhook.run(s.shutdownCtx)
+	return nil
+}
+
+// setupDeploymentWebhook creates and starts the delivery of deployment status
+// updates to the configured webhook, if any.
+func (s *Server) setupDeploymentWebhook() error {
+	if s.config.DeploymentWebhookConfig == nil {
+		return nil
+	}
+
+	webhook, err := newDeploymentWebhook(s.config.DeploymentWebhookConfig, s.logger)
+	if err != nil {
+		return err
+	}
+	s.deploymentWebhook = webhook
+	go webhook.run(s.shutdownCtx)
+	return nil
+}
+
+// notifyDeploymentWebhook queues the deployment status update applied at
+// index for delivery to the deployment webhook, if any.
+func (s *Server) notifyDeploymentWebhook(index uint64, u *structs.DeploymentStatusUpdate) {
+	if s.deploymentWebhook == nil {
+		return
+	}
+
+	d, err := s.fsm.State().DeploymentByID(nil, u.DeploymentID)
+	if err != nil || d == nil {
+		s.logger.Warn("failed to lookup deployment for webhook", "deployment_id", u.DeploymentID, "error", err)
+		return
+	}
+	s.deploymentWebhook.notify(index, d, u)
+}
+
 // setupConsul is used to setup Server specific consul components.
 func (s *Server) setupConsul(consulConfigEntries consul.ConfigAPI, consulACLs consul.ACLsAPI) {
 	s.consulConfigEntries = NewConsulConfigsAPI(consulConfigEntries, s.logger)
@@ -1076,6 +1236,17 @@ func (s *Server) setupVaultClient() error {
 		return err
 	}
 	s.vault = v
+
+	s.vaultClusters = make(map[string]VaultClient, len(s.config.VaultConfig.Clusters))
+	for _, cluster := range s.config.VaultConfig.Clusters {
+		logger := s.logger.With("vault_cluster", cluster.Name)
+		v, err := NewVaultClient(cluster.Config(), logger, s.purgeVaultAccessors, delegate)
+		if err != nil {
+			return fmt.Errorf("vault cluster %q: %v", cluster.Name, err)
+		}
+		v.cluster = cluster.Name
+		s.vaultClusters[cluster.Name] = v
+	}
 	return nil
 }
 
@@ -1173,6 +1344,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 		s.staticEndpoints.ClientAllocations = &ClientAllocations{srv: s, logger: s.logger.Named("client_allocs")}
 		s.staticEndpoints.ClientAllocations.register()
 		s.staticEndpoints.ClientCSI = &ClientCSI{srv: s, logger: s.logger.Named("client_csi")}
+		s.staticEndpoints.NodeMeta = &NodeMeta{srv: s, logger: s.logger.Named("client_node_meta")}
 
 		// Streaming endpoints
 		s.staticEndpoints.FileSystem = &FileSystem{srv: s, logger: s.logger.Named("client_fs")}
@@ -1192,6 +1364,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	server.Register(s.staticEndpoints.CSIVolume)
 	server.Register(s.staticEndpoints.CSIPlugin)
 	server.Register(s.staticEndpoints.Operator)
+	server.Register(s.staticEndpoints.Event)
 	server.Register(s.staticEndpoints.Periodic)
 	server.Register(s.staticEndpoints.Region)
 	server.Register(s.staticEndpoints.Scaling)
@@ -1202,6 +1375,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	server.Register(s.staticEndpoints.ClientStats)
 	server.Register(s.staticEndpoints.ClientAllocations)
 	server.Register(s.staticEndpoints.ClientCSI)
+	server.Register(s.staticEndpoints.NodeMeta)
 	server.Register(s.staticEndpoints.FileSystem)
 	server.Register(s.staticEndpoints.Agent)
 	server.Register(s.staticEndpoints.Namespace)
@@ -1319,6 +1493,7 @@ func (s *Server) setupRaft() error {
 			return err
 		}
 		snap = snapshots
+		s.raftSnapshots = snapshots
 
 		// For an existing cluster being upgraded to the new version of
 		// Raft, we almost never want to run recovery based on the old
@@ -1855,6 +2030,9 @@ func (s *Server) Stats() map[string]map[string]string {
 		"runtime": stats.RuntimeStats(),
 		"vault":   s.vault.Stats(),
 	}
+	for name, v := range s.vaultClusters {
+		stats["vault_"+name] = v.Stats()
+	}
 
 	return stats
 }
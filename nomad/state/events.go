@@ -28,6 +28,10 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.ACLTokenUpsertRequestType:               structs.TypeACLTokenUpserted,
 	structs.ACLPolicyDeleteRequestType:              structs.TypeACLPolicyDeleted,
 	structs.ACLPolicyUpsertRequestType:              structs.TypeACLPolicyUpserted,
+	structs.CSIVolumeRegisterRequestType:            structs.TypeCSIVolumeRegistered,
+	structs.CSIVolumeDeregisterRequestType:          structs.TypeCSIVolumeDeregistered,
+	structs.CSIVolumeClaimRequestType:               structs.TypeCSIVolumeClaim,
+	structs.CSIVolumeClaimBatchRequestType:          structs.TypeCSIVolumeClaim,
 }
 
 func eventsFromChanges(tx ReadTxn, changes Changes) *structs.Events {
@@ -41,13 +45,111 @@ func eventsFromChanges(tx ReadTxn, changes Changes) *structs.Events {
 		if event, ok := eventFromChange(change); ok {
 			event.Type = eventType
 			event.Index = changes.Index
+			if event.Topic == structs.TopicCSIVolume && !change.Deleted() {
+				denormalizeCSIVolumeEvent(tx, event)
+			}
 			events = append(events, event)
 		}
 	}
+	events = append(events, csiVolumeEventsFromPlugins(tx, changes)...)
 
 	return &structs.Events{Index: changes.Index, Events: events}
 }
 
+// denormalizeCSIVolumeEvent copies the current health of the volume's plugin
+// onto the volume carried by a CSIVolume event, so that consecutive events for
+// a volume can be compared against each other.
+func denormalizeCSIVolumeEvent(tx ReadTxn, event structs.Event) {
+	if tx == nil {
+		return
+	}
+	payload, ok := event.Payload.(*structs.CSIVolumeEvent)
+	if !ok {
+		return
+	}
+	var plug *structs.CSIPlugin
+	raw, err := tx.First("csi_plugins", "id", payload.Volume.PluginID)
+	if err == nil && raw != nil {
+		plug = raw.(*structs.CSIPlugin)
+	}
+	denormalizeCSIVolumePlugin(payload.Volume, plug)
+}
+
+// csiVolumeEventsFromPlugins returns a CSIVolume event for every volume whose
+// plugin health changed in the given changes. The health of a plugin is
+// denormalized onto its volumes, so without these events subscribers would
+// miss plugins becoming healthy or unhealthy.
+func csiVolumeEventsFromPlugins(tx ReadTxn, changes Changes) []structs.Event {
+	if tx == nil {
+		return nil
+	}
+
+	var events []structs.Event
+	for _, change := range changes.Changes {
+		if change.Table != "csi_plugins" {
+			continue
+		}
+		before, _ := change.Before.(*structs.CSIPlugin)
+		after, _ := change.After.(*structs.CSIPlugin)
+		if !csiPluginHealthChanged(before, after) {
+			continue
+		}
+
+		plug := after
+		if plug == nil {
+			plug = before
+		}
+		iter, err := tx.Get("csi_volumes", "plugin_id", plug.ID)
+		if err != nil {
+			continue
+		}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			vol := raw.(*structs.CSIVolume)
+			payload := structs.NewCSIVolumeEvent(vol)
+			denormalizeCSIVolumePlugin(payload.Volume, after)
+			events = append(events, structs.Event{
+				Topic:      structs.TopicCSIVolume,
+				Type:       structs.TypeCSIVolumePluginUpdated,
+				Key:        vol.ID,
+				Namespace:  vol.Namespace,
+				FilterKeys: []string{vol.PluginID},
+				Index:      changes.Index,
+				Payload:    payload,
+			})
+		}
+	}
+	return events
+}
+
+// csiPluginHealthChanged returns true if the plugin was created or deleted, or
+// if the health of any of its controllers or nodes changed.
+func csiPluginHealthChanged(before, after *structs.CSIPlugin) bool {
+	if before == nil || after == nil {
+		return before != after
+	}
+	if before.ControllersHealthy != after.ControllersHealthy ||
+		before.ControllersExpected != after.ControllersExpected ||
+		before.NodesHealthy != after.NodesHealthy ||
+		before.NodesExpected != after.NodesExpected {
+		return true
+	}
+	return csiInfoHealthChanged(before.Controllers, after.Controllers) ||
+		csiInfoHealthChanged(before.Nodes, after.Nodes)
+}
+
+func csiInfoHealthChanged(before, after map[string]*structs.CSIInfo) bool {
+	if len(before) != len(after) {
+		return true
+	}
+	for id, a := range after {
+		b, ok := before[id]
+		if !ok || b.Healthy != a.Healthy || b.HealthDescription != a.HealthDescription {
+			return true
+		}
+	}
+	return false
+}
+
 func eventFromChange(change memdb.Change) (structs.Event, bool) {
 	if change.Deleted() {
 		switch change.Table {
@@ -88,6 +190,18 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 					Node: before,
 				},
 			}, true
+		case "csi_volumes":
+			before, ok := change.Before.(*structs.CSIVolume)
+			if !ok {
+				return structs.Event{}, false
+			}
+			return structs.Event{
+				Topic:      structs.TopicCSIVolume,
+				Key:        before.ID,
+				Namespace:  before.Namespace,
+				FilterKeys: []string{before.PluginID},
+				Payload:    structs.NewCSIVolumeEvent(before),
+			}, true
 		}
 		return structs.Event{}, false
 	}
@@ -198,6 +312,18 @@ func eventFromChange(change memdb.Change) (structs.Event, bool) {
 				Deployment: after,
 			},
 		}, true
+	case "csi_volumes":
+		after, ok := change.After.(*structs.CSIVolume)
+		if !ok {
+			return structs.Event{}, false
+		}
+		return structs.Event{
+			Topic:      structs.TopicCSIVolume,
+			Key:        after.ID,
+			Namespace:  after.Namespace,
+			FilterKeys: []string{after.PluginID},
+			Payload:    structs.NewCSIVolumeEvent(after),
+		}, true
 	}
 
 	return structs.Event{}, false
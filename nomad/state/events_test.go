@@ -647,6 +647,96 @@ func TestEventsFromChanges_JobDeregisterRequestType(t *testing.T) {
 	t.SkipNow()
 }
 
+func TestEventsFromChanges_CSIVolumeRequestTypes(t *testing.T) {
+	ci.Parallel(t)
+	s := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer s.StopEventBroker()
+
+	vol := mock.CSIVolume(mock.CSIPlugin())
+	vol.Secrets = structs.CSISecrets{"password": "hunter2"}
+
+	require.NoError(t, s.UpsertCSIVolume(100, []*structs.CSIVolume{vol}))
+
+	events := WaitForEvents(t, s, 100, 1, 1*time.Second)
+	require.Len(t, events, 1)
+
+	e := events[0]
+	require.Equal(t, structs.TopicCSIVolume, e.Topic)
+	require.Equal(t, structs.TypeCSIVolumeRegistered, e.Type)
+	require.Equal(t, vol.ID, e.Key)
+	require.Equal(t, vol.Namespace, e.Namespace)
+	require.Contains(t, e.FilterKeys, vol.PluginID)
+	event := e.Payload.(*structs.CSIVolumeEvent)
+	require.Empty(t, event.Volume.Secrets)
+
+	// The volume stored in the state store must not be modified
+	got, err := s.CSIVolumeByID(nil, vol.Namespace, vol.ID)
+	require.NoError(t, err)
+	require.Equal(t, "hunter2", got.Secrets["password"])
+
+	require.NoError(t, s.CSIVolumeDeregister(101, vol.Namespace, []string{vol.ID}, false))
+
+	events = WaitForEvents(t, s, 101, 1, 1*time.Second)
+	require.Len(t, events, 1)
+	require.Equal(t, structs.TopicCSIVolume, events[0].Topic)
+	require.Equal(t, structs.TypeCSIVolumeDeregistered, events[0].Type)
+	require.Equal(t, vol.ID, events[0].Key)
+}
+
+func TestEventsFromChanges_CSIPluginUpdate(t *testing.T) {
+	ci.Parallel(t)
+	s := TestStateStoreCfg(t, TestStateStorePublisher(t))
+	defer s.StopEventBroker()
+
+	node := mock.Node()
+	node.CSINodePlugins = map[string]*structs.CSIInfo{
+		"minnie": {
+			PluginID:          "minnie",
+			Healthy:           true,
+			HealthDescription: "healthy",
+			NodeInfo:          &structs.CSINodeInfo{ID: node.ID},
+		},
+	}
+	require.NoError(t, s.UpsertNode(structs.NodeRegisterRequestType, 100, node))
+
+	plug := mock.CSIPlugin()
+	plug.ID = "minnie"
+	vol := mock.CSIVolume(plug)
+	require.NoError(t, s.UpsertCSIVolume(101, []*structs.CSIVolume{vol}))
+
+	// Re-registering the node without changing the plugin's health must not
+	// emit any volume events
+	node = node.Copy()
+	node.Name = "renamed"
+	require.NoError(t, s.UpsertNode(structs.NodeRegisterRequestType, 102, node))
+	for _, e := range WaitForEvents(t, s, 102, 1, 1*time.Second) {
+		require.NotEqual(t, structs.TopicCSIVolume, e.Topic)
+	}
+
+	node = node.Copy()
+	node.CSINodePlugins["minnie"].Healthy = false
+	node.CSINodePlugins["minnie"].HealthDescription = "probe failed"
+	require.NoError(t, s.UpsertNode(structs.NodeRegisterRequestType, 103, node))
+
+	var volEvents []structs.Event
+	for _, e := range WaitForEvents(t, s, 103, 2, 1*time.Second) {
+		if e.Topic == structs.TopicCSIVolume {
+			volEvents = append(volEvents, e)
+		}
+	}
+	require.Len(t, volEvents, 1)
+
+	e := volEvents[0]
+	require.Equal(t, structs.TypeCSIVolumePluginUpdated, e.Type)
+	require.Equal(t, vol.ID, e.Key)
+	require.Equal(t, vol.Namespace, e.Namespace)
+	require.Contains(t, e.FilterKeys, "minnie")
+
+	event := e.Payload.(*structs.CSIVolumeEvent)
+	require.Equal(t, 0, event.Volume.NodesHealthy)
+	require.False(t, event.Volume.Schedulable)
+}
+
 func TestEventsFromChanges_WithDeletion(t *testing.T) {
 	ci.Parallel(t)
 
@@ -57,6 +57,10 @@ func init() {
 		csiPluginTableSchema,
 		scalingPolicyTableSchema,
 		scalingEventTableSchema,
+		scheduledScalingTableSchema,
+		planResultTableSchema,
+		workloadIdentityKeyTableSchema,
+		eventCursorTableSchema,
 		namespaceTableSchema,
 	}...)
 }
@@ -1051,6 +1055,97 @@ func scalingPolicyTableSchema() *memdb.TableSchema {
 	}
 }
 
+// scheduledScalingTableSchema returns the memdb schema for scaling actions
+// that are applied at a later time
+func scheduledScalingTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "scheduled_scaling",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.UUIDFieldIndex{
+					Field: "ID",
+				},
+			},
+			"namespace": {
+				Name:         "namespace",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Namespace",
+				},
+			},
+		},
+	}
+}
+
+// workloadIdentityKeyTableSchema returns the memdb schema for the keys
+// signing workload identities
+func workloadIdentityKeyTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "workload_identity_keys",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "KeyID",
+				},
+			},
+		},
+	}
+}
+
+// eventCursorTableSchema returns the memdb schema for the cursors of the
+// durable consumers of the event stream
+func eventCursorTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "event_cursors",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// planResultTableSchema returns the memdb schema for the plan apply results
+// of jobs
+func planResultTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "plan_result",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+
+				// Use a compound index so the tuple of (Namespace, JobID) is
+				// uniquely identifying
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 // scalingEventTableSchema returns the memdb schema for job scaling events
 func scalingEventTableSchema() *memdb.TableSchema {
 	return &memdb.TableSchema{
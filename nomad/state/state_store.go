@@ -380,6 +380,21 @@ func (s *StateStore) UpsertPlanResults(msgType structs.MessageType, index uint64
 		}
 	}
 
+	// Add the results of the rejected plans, which are older than the result
+	// of this plan, and the plan apply result to the history of the jobs
+	for _, rejected := range results.RejectedPlanResults {
+		for _, result := range rejected.PlanResults {
+			if err := s.upsertPlanApplyResult(index, rejected.Namespace, rejected.JobID, result, txn); err != nil {
+				return err
+			}
+		}
+	}
+	if results.PlanApplyResult != nil && results.Job != nil {
+		if err := s.upsertPlanApplyResult(index, results.Job.Namespace, results.Job.ID, results.PlanApplyResult, txn); err != nil {
+			return err
+		}
+	}
+
 	numAllocs := 0
 	if len(results.Alloc) > 0 || len(results.NodePreemptions) > 0 {
 		// COMPAT 0.11: This branch will be removed, when Alloc is removed
@@ -857,6 +872,313 @@ func (s *StateStore) ScalingEventsByJob(ws memdb.WatchSet, namespace, jobID stri
 	return nil, 0, nil
 }
 
+// upsertPlanApplyResult adds a plan apply result to the history of the job.
+// Only the most recent JobTrackedPlanResults will be kept.
+func (s *StateStore) upsertPlanApplyResult(index uint64, namespace, jobID string, result *structs.PlanApplyResult, txn *txn) error {
+	existing, err := txn.First("plan_result", "id", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("plan result lookup failed: %v", err)
+	}
+
+	result.CreateIndex = index
+	results := []*structs.PlanApplyResult{result}
+	if existing != nil {
+		results = append(results, existing.(*structs.JobPlanResults).PlanResults...)
+	}
+
+	// Truncate older results
+	if len(results) > structs.JobTrackedPlanResults {
+		results = results[0:structs.JobTrackedPlanResults]
+	}
+
+	jobResults := &structs.JobPlanResults{
+		Namespace:   namespace,
+		JobID:       jobID,
+		PlanResults: results,
+		ModifyIndex: index,
+	}
+	if err := txn.Insert("plan_result", jobResults); err != nil {
+		return fmt.Errorf("plan result insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"plan_result", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// PlanResults returns an iterator over the plan apply results of all jobs
+func (s *StateStore) PlanResults(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("plan_result", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// PlanResultsByJob returns the plan apply results of a job, sorted from
+// newest to oldest, and the index at which they were last modified.
+func (s *StateStore) PlanResultsByJob(ws memdb.WatchSet, namespace, jobID string) ([]*structs.PlanApplyResult, uint64, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch("plan_result", "id", namespace, jobID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("job plan results lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		results := existing.(*structs.JobPlanResults)
+		return results.PlanResults, results.ModifyIndex, nil
+	}
+	return nil, 0, nil
+}
+
+// UpsertScheduledScalingAction is used to insert or update a scheduled
+// scaling action.
+func (s *StateStore) UpsertScheduledScalingAction(msgType structs.MessageType, index uint64, action *structs.ScheduledScalingAction) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First("scheduled_scaling", "id", action.ID)
+	if err != nil {
+		return fmt.Errorf("scheduled scaling action lookup failed: %v", err)
+	}
+
+	if existing != nil {
+		action.CreateIndex = existing.(*structs.ScheduledScalingAction).CreateIndex
+	} else {
+		action.CreateIndex = index
+	}
+	action.ModifyIndex = index
+
+	if err := txn.Insert("scheduled_scaling", action); err != nil {
+		return fmt.Errorf("scheduled scaling action insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"scheduled_scaling", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
+
+// DeleteScheduledScalingActions is used to delete a set of scheduled scaling
+// actions by ID.
+func (s *StateStore) DeleteScheduledScalingActions(msgType structs.MessageType, index uint64, ids []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	var deleted int
+	for _, id := range ids {
+		d, err := txn.DeleteAll("scheduled_scaling", "id", id)
+		if err != nil {
+			return fmt.Errorf("scheduled scaling action delete failed: %v", err)
+		}
+		deleted += d
+	}
+
+	if deleted > 0 {
+		if err := txn.Insert("index", &IndexEntry{"scheduled_scaling", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+	return txn.Commit()
+}
+
+// ScheduledScalingActions returns an iterator over all the scheduled scaling
+// actions.
+func (s *StateStore) ScheduledScalingActions(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("scheduled_scaling", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ScheduledScalingActionsByNamespace returns an iterator over the scheduled
+// scaling actions of the jobs in the namespace.
+func (s *StateStore) ScheduledScalingActionsByNamespace(ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("scheduled_scaling", "namespace", namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ScheduledScalingActionByID is used to lookup a scheduled scaling action by
+// its ID.
+func (s *StateStore) ScheduledScalingActionByID(ws memdb.WatchSet, id string) (*structs.ScheduledScalingAction, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch("scheduled_scaling", "id", id)
+	if err != nil {
+		return nil, fmt.Errorf("scheduled scaling action lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ScheduledScalingAction), nil
+	}
+	return nil, nil
+}
+
+// UpsertWorkloadIdentityKeys is used to insert or update and delete keys
+// signing workload identities in a single transaction, such that a rotation
+// of the keys is applied atomically.
+func (s *StateStore) UpsertWorkloadIdentityKeys(msgType structs.MessageType, index uint64, keys []*structs.WorkloadIdentityKey, deleteIDs []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, id := range deleteIDs {
+		if _, err := txn.DeleteAll("workload_identity_keys", "id", id); err != nil {
+			return fmt.Errorf("workload identity key delete failed: %v", err)
+		}
+	}
+
+	for _, key := range keys {
+		existing, err := txn.First("workload_identity_keys", "id", key.KeyID)
+		if err != nil {
+			return fmt.Errorf("workload identity key lookup failed: %v", err)
+		}
+
+		if existing != nil {
+			key.CreateIndex = existing.(*structs.WorkloadIdentityKey).CreateIndex
+		} else {
+			key.CreateIndex = index
+		}
+		key.ModifyIndex = index
+
+		if err := txn.Insert("workload_identity_keys", key); err != nil {
+			return fmt.Errorf("workload identity key insert failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert("index", &IndexEntry{"workload_identity_keys", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
+
+// WorkloadIdentityKeys returns an iterator over all the keys signing
+// workload identities.
+func (s *StateStore) WorkloadIdentityKeys(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("workload_identity_keys", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// WorkloadIdentityKeyByID is used to lookup a key signing workload
+// identities by its ID.
+func (s *StateStore) WorkloadIdentityKeyByID(ws memdb.WatchSet, id string) (*structs.WorkloadIdentityKey, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch("workload_identity_keys", "id", id)
+	if err != nil {
+		return nil, fmt.Errorf("workload identity key lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.WorkloadIdentityKey), nil
+	}
+	return nil, nil
+}
+
+// UpsertEventCursor is used to create or update an event cursor.
+func (s *StateStore) UpsertEventCursor(msgType structs.MessageType, index uint64, cursor *structs.EventCursor) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First("event_cursors", "id", cursor.Name)
+	if err != nil {
+		return fmt.Errorf("event cursor lookup failed: %v", err)
+	}
+
+	if existing != nil {
+		cursor.CreateIndex = existing.(*structs.EventCursor).CreateIndex
+	} else {
+		cursor.CreateIndex = index
+	}
+	cursor.ModifyIndex = index
+
+	if err := txn.Insert("event_cursors", cursor); err != nil {
+		return fmt.Errorf("event cursor insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"event_cursors", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
+
+// DeleteEventCursors is used to delete a set of event cursors by name.
+func (s *StateStore) DeleteEventCursors(msgType structs.MessageType, index uint64, names []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	var deleted int
+	for _, name := range names {
+		d, err := txn.DeleteAll("event_cursors", "id", name)
+		if err != nil {
+			return fmt.Errorf("event cursor delete failed: %v", err)
+		}
+		deleted += d
+	}
+
+	if deleted > 0 {
+		if err := txn.Insert("index", &IndexEntry{"event_cursors", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+	return txn.Commit()
+}
+
+// EventCursors returns an iterator over all the event cursors, sorted by
+// name.
+func (s *StateStore) EventCursors(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("event_cursors", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// EventCursorByName is used to lookup an event cursor by name.
+func (s *StateStore) EventCursorByName(ws memdb.WatchSet, name string) (*structs.EventCursor, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch("event_cursors", "id", name)
+	if err != nil {
+		return nil, fmt.Errorf("event cursor lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.EventCursor), nil
+	}
+	return nil, nil
+}
+
 // UpsertNode is used to register a node or update a node definition
 // This is assumed to be triggered by the client, so we retain the value
 // of drain/eligibility which is set by the scheduler.
@@ -1586,6 +1908,37 @@ func (s *StateStore) UpsertJob(msgType structs.MessageType, index uint64, job *s
 	return txn.Commit()
 }
 
+// UpsertJobIdempotent is used to register a job like UpsertJob, unless a
+// version of the job with the same register idempotency token was submitted
+// within structs.JobRegisterIdempotencyTTL of it. In that case the job isn't
+// registered again and a *structs.JobRegisterIdempotentError is returned.
+func (s *StateStore) UpsertJobIdempotent(msgType structs.MessageType, index uint64, job *structs.Job) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	if job.RegisterIdempotencyToken != "" {
+		versions, err := s.jobVersionByID(txn, nil, job.Namespace, job.ID)
+		if err != nil {
+			return err
+		}
+
+		// The cutoff is relative to the submit time set by the leader so
+		// that every server applies the registration the same way
+		cutoff := job.SubmitTime - structs.JobRegisterIdempotencyTTL.Nanoseconds()
+		for _, version := range versions {
+			if version.RegisterIdempotencyToken == job.RegisterIdempotencyToken &&
+				version.SubmitTime >= cutoff {
+				return &structs.JobRegisterIdempotentError{JobModifyIndex: version.JobModifyIndex}
+			}
+		}
+	}
+
+	if err := s.upsertJobImpl(index, job, false, txn); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
+
 // UpsertJobTxn is used to register a job or update a job definition, like UpsertJob,
 // but in a transaction.  Useful for when making multiple modifications atomically
 func (s *StateStore) UpsertJobTxn(index uint64, job *structs.Job, txn Txn) error {
@@ -1796,9 +2149,343 @@ func (s *StateStore) DeleteJobTxn(index uint64, namespace, jobID string, txn Txn
 		return fmt.Errorf("index update failed: %v", err)
 	}
 
+	// Delete the plan results
+	if _, err = txn.DeleteAll("plan_result", "id", namespace, jobID); err != nil {
+		return fmt.Errorf("deleting job plan results failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"plan_result", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return nil
+}
+
+// MoveJobTxn moves a job to a different namespace, renames it, or both, in
+// the given transaction. The versions, summary, scaling policies and events
+// and the allocations of the job are moved along with it, as are its
+// terminal evaluations and deployments, so that the history of the job is
+// preserved. The job modify index and versions are left unchanged so that
+// moving a job doesn't cause its allocations to be updated by the scheduler.
+//
+// Blocked evaluations of the job are cancelled, since the evaluation of the
+// moved job blocks again if its allocations still can't be placed. Jobs that
+// have child jobs, are children of another job, run CSI plugins, or have
+// pending evaluations or active deployments can't be moved.
+func (s *StateStore) MoveJobTxn(index uint64, namespace, jobID, newNamespace, newJobID string, txn Txn) error {
+	existing, err := txn.First("jobs", "id", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("job lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("job not found")
+	}
+	job := existing.(*structs.Job)
+
+	if namespace == newNamespace && jobID == newJobID {
+		return fmt.Errorf("job is already %q in namespace %q", jobID, namespace)
+	}
+	if job.IsPeriodic() || job.IsParameterized() {
+		return fmt.Errorf("periodic and parameterized jobs can't be moved")
+	}
+	if job.ParentID != "" {
+		return fmt.Errorf("child jobs can't be moved")
+	}
+	if job.IsMultiregion() {
+		return fmt.Errorf("multiregion jobs can't be moved")
+	}
+	for _, tg := range job.TaskGroups {
+		for _, task := range tg.Tasks {
+			if task.CSIPluginConfig != nil {
+				return fmt.Errorf("jobs running CSI plugins can't be moved")
+			}
+		}
+	}
+
+	// Assert the target is free
+	if exists, err := s.namespaceExists(txn, newNamespace); err != nil {
+		return err
+	} else if !exists {
+		return fmt.Errorf("namespace %q does not exist", newNamespace)
+	}
+	if target, err := txn.First("jobs", "id", newNamespace, newJobID); err != nil {
+		return fmt.Errorf("job lookup failed: %v", err)
+	} else if target != nil {
+		return fmt.Errorf("job %q already exists in namespace %q", newJobID, newNamespace)
+	}
+	if target, err := txn.First("allocs", "job", newNamespace, newJobID); err != nil {
+		return fmt.Errorf("alloc lookup failed: %v", err)
+	} else if target != nil {
+		return fmt.Errorf("job %q in namespace %q still has allocations", newJobID, newNamespace)
+	}
+
+	// Collect the evaluations and deployments, which must all be terminal
+	var evals []*structs.Evaluation
+	iter, err := txn.Get("evals", "job_prefix", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("eval lookup failed: %v", err)
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		eval := raw.(*structs.Evaluation)
+		if eval.JobID != jobID {
+			continue
+		}
+		switch {
+		case eval.Status == structs.EvalStatusBlocked:
+			eval = eval.Copy()
+			eval.Status = structs.EvalStatusCancelled
+			eval.StatusDescription = "job moved"
+		case !eval.TerminalStatus():
+			return fmt.Errorf("job has non-terminal evaluation %q", eval.ID)
+		}
+		evals = append(evals, eval)
+	}
+
+	var deployments []*structs.Deployment
+	iter, err = txn.Get("deployment", "job", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("deployment lookup failed: %v", err)
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		d := raw.(*structs.Deployment)
+		if d.Active() {
+			return fmt.Errorf("job has active deployment %q", d.ID)
+		}
+		deployments = append(deployments, d)
+	}
+
+	// Move the job versions, keeping the moved versions around for the
+	// allocations that reference them
+	versions, err := s.jobVersionByID(txn, nil, namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to look up job versions for %q: %v", jobID, err)
+	}
+
+	// CSI volumes are namespaced, and the allocations of any version of the
+	// job claim and release them in the namespace of their job, so the claims
+	// can't follow the job to another namespace
+	if namespace != newNamespace {
+		for _, version := range append(versions, job) {
+			if jobRequestsCSIVolumes(version) {
+				return fmt.Errorf("jobs using CSI volumes can't be moved to another namespace")
+			}
+		}
+	}
+
+	if err := s.deleteJobVersions(index, job, txn); err != nil {
+		return err
+	}
+	moved := make(map[uint64]*structs.Job, len(versions))
+	for _, version := range versions {
+		mv := version.CopyWithID(newNamespace, newJobID)
+		if err := txn.Insert("job_version", mv); err != nil {
+			return fmt.Errorf("failed to insert job into job_version table: %v", err)
+		}
+		moved[mv.Version] = mv
+	}
+
+	// Move the job itself
+	newJob := job.CopyWithID(newNamespace, newJobID)
+	newJob.ModifyIndex = index
+	if err := txn.Delete("jobs", job); err != nil {
+		return fmt.Errorf("job delete failed: %v", err)
+	}
+	if err := txn.Insert("jobs", newJob); err != nil {
+		return fmt.Errorf("job insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"jobs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	// Move the job summary
+	summaryRaw, err := txn.First("job_summary", "id", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("job summary lookup failed: %v", err)
+	}
+	if summaryRaw != nil {
+		summary := summaryRaw.(*structs.JobSummary).Copy()
+		summary.Namespace = newNamespace
+		summary.JobID = newJobID
+		summary.ModifyIndex = index
+		if err := txn.Delete("job_summary", summaryRaw); err != nil {
+			return fmt.Errorf("job summary delete failed: %v", err)
+		}
+		if err := txn.Insert("job_summary", summary); err != nil {
+			return fmt.Errorf("job summary insert failed: %v", err)
+		}
+		if err := txn.Insert("index", &IndexEntry{"job_summary", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+
+	// Retarget the scaling policies, including the standalone ones
+	iter, err = s.ScalingPoliciesByJobTxn(nil, namespace, jobID, txn)
+	if err != nil {
+		return fmt.Errorf("ScalingPoliciesByJob lookup failed: %v", err)
+	}
+	var policies []*structs.ScalingPolicy
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		p := raw.(*structs.ScalingPolicy).Copy()
+		p.Target[structs.ScalingTargetNamespace] = newNamespace
+		p.Target[structs.ScalingTargetJob] = newJobID
+		p.ModifyIndex = index
+		policies = append(policies, p)
+	}
+	for _, p := range policies {
+		if err := txn.Insert("scaling_policy", p); err != nil {
+			return fmt.Errorf("scaling policy insert failed: %v", err)
+		}
+	}
+	if len(policies) > 0 {
+		if err := txn.Insert("index", &IndexEntry{"scaling_policy", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+
+	// Move the scaling events and plan results
+	if raw, err := txn.First("scaling_event", "id", namespace, jobID); err != nil {
+		return fmt.Errorf("scaling event lookup failed: %v", err)
+	} else if raw != nil {
+		events := *raw.(*structs.JobScalingEvents)
+		events.Namespace = newNamespace
+		events.JobID = newJobID
+		events.ModifyIndex = index
+		if err := txn.Delete("scaling_event", raw); err != nil {
+			return fmt.Errorf("scaling event delete failed: %v", err)
+		}
+		if err := txn.Insert("scaling_event", &events); err != nil {
+			return fmt.Errorf("scaling event insert failed: %v", err)
+		}
+		if err := txn.Insert("index", &IndexEntry{"scaling_event", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+	if raw, err := txn.First("plan_result", "id", namespace, jobID); err != nil {
+		return fmt.Errorf("plan result lookup failed: %v", err)
+	} else if raw != nil {
+		results := *raw.(*structs.JobPlanResults)
+		results.Namespace = newNamespace
+		results.JobID = newJobID
+		results.ModifyIndex = index
+		if err := txn.Delete("plan_result", raw); err != nil {
+			return fmt.Errorf("plan result delete failed: %v", err)
+		}
+		if err := txn.Insert("plan_result", &results); err != nil {
+			return fmt.Errorf("plan result insert failed: %v", err)
+		}
+		if err := txn.Insert("index", &IndexEntry{"plan_result", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+
+	// Retarget the scheduled scaling actions
+	iter, err = txn.Get("scheduled_scaling", "id")
+	if err != nil {
+		return fmt.Errorf("scheduled scaling lookup failed: %v", err)
+	}
+	var actions []*structs.ScheduledScalingAction
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		action := raw.(*structs.ScheduledScalingAction)
+		if action.Namespace != namespace || action.JobID != jobID {
+			continue
+		}
+		updated := *action
+		updated.Namespace = newNamespace
+		updated.JobID = newJobID
+		updated.ModifyIndex = index
+		actions = append(actions, &updated)
+	}
+	for _, action := range actions {
+		if err := txn.Insert("scheduled_scaling", action); err != nil {
+			return fmt.Errorf("scheduled scaling insert failed: %v", err)
+		}
+	}
+	if len(actions) > 0 {
+		if err := txn.Insert("index", &IndexEntry{"scheduled_scaling", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+
+	// Move the allocations. Their modify index is updated so that clients
+	// pull the updated allocations and re-register their services.
+	iter, err = txn.Get("allocs", "job", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("alloc lookup failed: %v", err)
+	}
+	var allocs []*structs.Allocation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		alloc := raw.(*structs.Allocation).Copy()
+		if alloc.Job != nil {
+			if mv, ok := moved[alloc.Job.Version]; ok && mv.CreateIndex == alloc.Job.CreateIndex {
+				alloc.Job = mv
+			} else {
+				alloc.Job = alloc.Job.CopyWithID(newNamespace, newJobID)
+			}
+		}
+		// The name embeds the job ID, so it's renamed with the same index
+		alloc.Name = structs.AllocName(newJobID, alloc.TaskGroup, alloc.Index())
+		alloc.Namespace = newNamespace
+		alloc.JobID = newJobID
+		alloc.ModifyIndex = index
+		alloc.AllocModifyIndex = index
+		allocs = append(allocs, alloc)
+	}
+	for _, alloc := range allocs {
+		if err := txn.Insert("allocs", alloc); err != nil {
+			return fmt.Errorf("alloc insert failed: %v", err)
+		}
+	}
+	if len(allocs) > 0 {
+		if err := txn.Insert("index", &IndexEntry{"allocs", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+
+	// Move the evaluations and deployments, which are all terminal now
+	for _, eval := range evals {
+		eval = eval.Copy()
+		eval.Namespace = newNamespace
+		eval.JobID = newJobID
+		eval.ModifyIndex = index
+		if err := txn.Insert("evals", eval); err != nil {
+			return fmt.Errorf("eval insert failed: %v", err)
+		}
+	}
+	if len(evals) > 0 {
+		if err := txn.Insert("index", &IndexEntry{"evals", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+	for _, d := range deployments {
+		d = d.Copy()
+		d.Namespace = newNamespace
+		d.JobID = newJobID
+		d.ModifyIndex = index
+		if err := txn.Insert("deployment", d); err != nil {
+			return fmt.Errorf("deployment insert failed: %v", err)
+		}
+	}
+	if len(deployments) > 0 {
+		if err := txn.Insert("index", &IndexEntry{"deployment", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+
 	return nil
 }
 
+// jobRequestsCSIVolumes returns whether any task group of the job requests a
+// CSI volume.
+func jobRequestsCSIVolumes(job *structs.Job) bool {
+	for _, tg := range job.TaskGroups {
+		for _, req := range tg.Volumes {
+			if req.Type == structs.VolumeTypeCSI {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // deleteJobScalingPolicies deletes any scaling policies associated with the job
 func (s *StateStore) deleteJobScalingPolicies(index uint64, job *structs.Job, txn *txn) error {
 	iter, err := s.ScalingPoliciesByJobTxn(nil, job.Namespace, job.ID, txn)
@@ -1889,6 +2576,15 @@ func (s *StateStore) upsertJobVersion(index uint64, job *structs.Job, txn *txn)
 		return fmt.Errorf("failed to look up job versions for %q: %v", job.ID, err)
 	}
 
+	// Tagged versions are kept regardless of the limit
+	untagged := all[:0]
+	for _, j := range all {
+		if j.VersionTag == nil {
+			untagged = append(untagged, j)
+		}
+	}
+	all = untagged
+
 	// If we are below the limit there is no GCing to be done
 	if len(all) <= structs.JobTrackedVersions {
 		return nil
@@ -2197,7 +2893,7 @@ func (s *StateStore) JobSummaryByPrefix(ws memdb.WatchSet, namespace, id string)
 
 // UpsertCSIVolume inserts a volume in the state store.
 func (s *StateStore) UpsertCSIVolume(index uint64, volumes []*structs.CSIVolume) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.CSIVolumeRegisterRequestType, index)
 	defer txn.Abort()
 
 	for _, v := range volumes {
@@ -2422,7 +3118,7 @@ func (s *StateStore) CSIVolumesByNamespace(ws memdb.WatchSet, namespace, prefix
 
 // CSIVolumeClaim updates the volume's claim count and allocation list
 func (s *StateStore) CSIVolumeClaim(index uint64, namespace, id string, claim *structs.CSIVolumeClaim) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.CSIVolumeClaimRequestType, index)
 	defer txn.Abort()
 
 	row, err := txn.First("csi_volumes", "id", namespace, id)
@@ -2498,7 +3194,7 @@ func (s *StateStore) CSIVolumeClaim(index uint64, namespace, id string, claim *s
 
 // CSIVolumeDeregister removes the volume from the server
 func (s *StateStore) CSIVolumeDeregister(index uint64, namespace string, ids []string, force bool) error {
-	txn := s.db.WriteTxn(index)
+	txn := s.db.WriteTxnMsgT(structs.CSIVolumeDeregisterRequestType, index)
 	defer txn.Abort()
 
 	for _, id := range ids {
@@ -2583,11 +3279,17 @@ func (s *StateStore) csiVolumeDenormalizePluginsTxn(txn Txn, vol *structs.CSIVol
 	if err != nil {
 		return nil, fmt.Errorf("plugin lookup error: %s %v", vol.PluginID, err)
 	}
+	return denormalizeCSIVolumePlugin(vol, plug), nil
+}
+
+// denormalizeCSIVolumePlugin copies the health of the plugin onto the volume.
+// A nil plugin marks the volume as unschedulable.
+func denormalizeCSIVolumePlugin(vol *structs.CSIVolume, plug *structs.CSIPlugin) *structs.CSIVolume {
 	if plug == nil {
 		vol.ControllersHealthy = 0
 		vol.NodesHealthy = 0
 		vol.Schedulable = false
-		return vol, nil
+		return vol
 	}
 
 	vol.Provider = plug.Provider
@@ -2605,7 +3307,7 @@ func (s *StateStore) csiVolumeDenormalizePluginsTxn(txn Txn, vol *structs.CSIVol
 		vol.Schedulable = vol.ControllersHealthy > 0 && vol.Schedulable
 	}
 
-	return vol, nil
+	return vol
 }
 
 // CSIVolumeDenormalize returns a CSIVolume with its current
@@ -3420,6 +4122,9 @@ func (s *StateStore) nestedUpdateAllocFromClient(txn *txn, index uint64, alloc *
 			// Updated deployment health and timestamp
 			copyAlloc.DeploymentStatus.Healthy = helper.BoolToPtr(*alloc.DeploymentStatus.Healthy)
 			copyAlloc.DeploymentStatus.Timestamp = alloc.DeploymentStatus.Timestamp
+			copyAlloc.DeploymentStatus.Manual = false
+			copyAlloc.DeploymentStatus.Reason = ""
+			copyAlloc.DeploymentStatus.SetBy = ""
 			copyAlloc.DeploymentStatus.ModifyIndex = index
 		}
 	} else if alloc.DeploymentStatus != nil {
@@ -4251,6 +4956,7 @@ func (s *StateStore) updateDeploymentStatusImpl(index uint64, u *structs.Deploym
 	copy := deployment.Copy()
 	copy.Status = u.Status
 	copy.StatusDescription = u.StatusDescription
+	copy.StatusReason = u.StatusReason
 	copy.ModifyIndex = index
 
 	// Insert the deployment
@@ -4309,6 +5015,62 @@ func (s *StateStore) updateJobStabilityImpl(index uint64, namespace, jobID strin
 	return s.upsertJobImpl(index, copy, true, txn)
 }
 
+// UpdateJobVersionTag sets the tag of the given job version, or removes it if
+// the tag is nil. Tag names must be unique among the versions of the job.
+func (s *StateStore) UpdateJobVersionTag(index uint64, namespace, jobID string, jobVersion uint64, tag *structs.JobVersionTag) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	versions, err := s.jobVersionByID(txn, nil, namespace, jobID)
+	if err != nil {
+		return err
+	}
+
+	var job *structs.Job
+	for _, v := range versions {
+		if v.Version == jobVersion {
+			job = v
+		} else if tag != nil && v.VersionTag != nil && v.VersionTag.Name == tag.Name {
+			return fmt.Errorf("version tag %q is already used by version %d of job %q", tag.Name, v.Version, jobID)
+		}
+	}
+	if job == nil {
+		return fmt.Errorf("job %q in namespace %q at version %d not found", jobID, namespace, jobVersion)
+	}
+
+	copy := job.Copy()
+	copy.VersionTag = tag.Copy()
+	copy.ModifyIndex = index
+
+	// Tagging a version doesn't go through upsertJobImpl, as the version may
+	// not be the current version of the job.
+	if err := txn.Insert("job_version", copy); err != nil {
+		return fmt.Errorf("failed to insert job into job_version table: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"job_version", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	// Keep the current version of the job in sync with its job_version entry
+	existing, err := txn.First("jobs", "id", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("job lookup failed: %v", err)
+	}
+	if existing != nil && existing.(*structs.Job).Version == jobVersion {
+		current := existing.(*structs.Job).Copy()
+		current.VersionTag = tag.Copy()
+		current.ModifyIndex = index
+		if err := txn.Insert("jobs", current); err != nil {
+			return fmt.Errorf("job insert failed: %v", err)
+		}
+		if err := txn.Insert("index", &IndexEntry{"jobs", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+
+	return txn.Commit()
+}
+
 // UpdateDeploymentPromotion is used to promote canaries in a deployment and
 // potentially make a evaluation
 func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, index uint64, req *structs.ApplyDeploymentPromoteRequest) error {
@@ -4401,20 +5163,51 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 		return err
 	}
 
+	// A zero percentage promotes the groups fully
+	percent := req.Percent
+	if percent == 0 {
+		percent = 100
+	}
+
 	// Update deployment
 	copy := deployment.Copy()
 	copy.ModifyIndex = index
+	var promotedErr multierror.Error
+	staged := make(map[string]struct{})
 	for tg, status := range copy.TaskGroups {
 		_, ok := groupIndex[tg]
 		if !req.All && !ok {
 			continue
 		}
 
+		// A partial promotion may only increase the rollout percentage
+		if percent < 100 && status.Promoted {
+			current := status.PromotedPercent
+			if !status.PartiallyPromoted() {
+				current = 100
+			}
+			if percent <= current {
+				multierror.Append(&promotedErr, fmt.Errorf("Task group %q is already promoted to %d%%", tg, current))
+				continue
+			}
+		}
+
 		// reset the progress deadline
 		if status.ProgressDeadline > 0 && !status.RequireProgressBy.IsZero() {
 			status.RequireProgressBy = time.Now().Add(status.ProgressDeadline)
 		}
 		status.Promoted = true
+		status.PromotedPercent = percent
+
+		// Blue/green groups swap the tags of the allocations being replaced
+		// once fully promoted
+		if status.BlueGreen && !status.PartiallyPromoted() {
+			staged[tg] = struct{}{}
+		}
+	}
+
+	if err := promotedErr.ErrorOrNil(); err != nil {
+		return err
 	}
 
 	// If the deployment no longer needs promotion, update its status
@@ -4447,6 +5240,14 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 		}
 	}
 
+	// Mark the allocations of the previous job versions of blue/green groups
+	// as staged so that their services are registered with the staged tag
+	if len(staged) != 0 {
+		if err := s.stageBlueGreenAllocs(index, copy, staged, txn); err != nil {
+			return err
+		}
+	}
+
 	// Update the alloc index
 	if err := txn.Insert("index", &IndexEntry{"allocs", index}); err != nil {
 		return fmt.Errorf("index update failed: %v", err)
@@ -4455,6 +5256,47 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 	return txn.Commit()
 }
 
+// stageBlueGreenAllocs marks the running allocations of the given task groups
+// that aren't part of the deployment as staged.
+func (s *StateStore) stageBlueGreenAllocs(index uint64, deployment *structs.Deployment, groups map[string]struct{}, txn *txn) error {
+	iter, err := txn.Get("allocs", "job", deployment.Namespace, deployment.JobID)
+	if err != nil {
+		return err
+	}
+
+	var stage []*structs.Allocation
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		alloc := raw.(*structs.Allocation)
+		if _, ok := groups[alloc.TaskGroup]; !ok {
+			continue
+		}
+		if alloc.DeploymentID == deployment.ID || alloc.TerminalStatus() {
+			continue
+		}
+		if alloc.DeploymentStatus != nil && alloc.DeploymentStatus.Staged {
+			continue
+		}
+		stage = append(stage, alloc)
+	}
+
+	for _, alloc := range stage {
+		staged := alloc.Copy()
+		if staged.DeploymentStatus == nil {
+			staged.DeploymentStatus = &structs.AllocDeploymentStatus{}
+		}
+		staged.DeploymentStatus.Staged = true
+		staged.DeploymentStatus.ModifyIndex = index
+		staged.ModifyIndex = index
+		staged.AllocModifyIndex = index
+
+		if err := txn.Insert("allocs", staged); err != nil {
+			return fmt.Errorf("alloc insert failed: %v", err)
+		}
+	}
+
+	return nil
+}
+
 // UpdateDeploymentAllocHealth is used to update the health of allocations as
 // part of the deployment and potentially make a evaluation
 func (s *StateStore) UpdateDeploymentAllocHealth(msgType structs.MessageType, index uint64, req *structs.ApplyDeploymentAllocHealthRequest) error {
@@ -4495,6 +5337,9 @@ func (s *StateStore) UpdateDeploymentAllocHealth(msgType structs.MessageType, in
 			}
 			copy.DeploymentStatus.Healthy = helper.BoolToPtr(healthy)
 			copy.DeploymentStatus.Timestamp = ts
+			copy.DeploymentStatus.Manual = true
+			copy.DeploymentStatus.Reason = req.AllocReason(id)
+			copy.DeploymentStatus.SetBy = req.SetBy
 			copy.DeploymentStatus.ModifyIndex = index
 			copy.ModifyIndex = index
 
@@ -4941,6 +5786,11 @@ func (s *StateStore) getJobStatus(txn *txn, job *structs.Job, evalDelete bool) (
 		}
 	}
 
+	// Jobs held by their dependencies are pending until they're first placed.
+	if len(job.DependsOn) != 0 && !job.Stop && !hasAlloc {
+		return structs.JobStatusPending, nil
+	}
+
 	// The job is dead if all the allocations and evals are terminal or if there
 	// are no evals because of garbage collection.
 	if evalDelete || hasEval || hasAlloc {
@@ -5007,7 +5857,8 @@ func (s *StateStore) updateSummaryWithJob(index uint64, job *structs.Job,
 }
 
 // updateJobScalingPolicies upserts any scaling policies contained in the job and removes
-// any previous scaling policies that were removed from the job
+// any previous scaling policies that were removed from the job. Standalone
+// policies are kept, unless the job now contains a policy for their target.
 func (s *StateStore) updateJobScalingPolicies(index uint64, job *structs.Job, txn *txn) error {
 
 	ws := memdb.NewWatchSet()
@@ -5025,7 +5876,7 @@ func (s *StateStore) updateJobScalingPolicies(index uint64, job *structs.Job, tx
 	}
 	for raw := iter.Next(); raw != nil; raw = iter.Next() {
 		oldPolicy := raw.(*structs.ScalingPolicy)
-		if !newTargets[oldPolicy.JobKey()] {
+		if !newTargets[oldPolicy.JobKey()] && !oldPolicy.Standalone {
 			deletedPolicies = append(deletedPolicies, oldPolicy.ID)
 		}
 	}
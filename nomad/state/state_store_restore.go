@@ -182,6 +182,39 @@ func (r *StateRestore) ScalingEventsRestore(jobEvents *structs.JobScalingEvents)
 	return nil
 }
 
+// ScheduledScalingActionRestore is used to restore a scheduled scaling action
+func (r *StateRestore) ScheduledScalingActionRestore(action *structs.ScheduledScalingAction) error {
+	if err := r.txn.Insert("scheduled_scaling", action); err != nil {
+		return fmt.Errorf("scheduled scaling action insert failed: %v", err)
+	}
+	return nil
+}
+
+// WorkloadIdentityKeyRestore is used to restore a key signing workload
+// identities
+func (r *StateRestore) WorkloadIdentityKeyRestore(key *structs.WorkloadIdentityKey) error {
+	if err := r.txn.Insert("workload_identity_keys", key); err != nil {
+		return fmt.Errorf("workload identity key insert failed: %v", err)
+	}
+	return nil
+}
+
+// EventCursorRestore is used to restore an event cursor
+func (r *StateRestore) EventCursorRestore(cursor *structs.EventCursor) error {
+	if err := r.txn.Insert("event_cursors", cursor); err != nil {
+		return fmt.Errorf("event cursor insert failed: %v", err)
+	}
+	return nil
+}
+
+// PlanResultsRestore is used to restore the plan apply results of a job
+func (r *StateRestore) PlanResultsRestore(jobResults *structs.JobPlanResults) error {
+	if err := r.txn.Insert("plan_result", jobResults); err != nil {
+		return fmt.Errorf("plan result insert failed: %v", err)
+	}
+	return nil
+}
+
 // NamespaceRestore is used to restore a namespace
 func (r *StateRestore) NamespaceRestore(ns *structs.Namespace) error {
 	if err := r.txn.Insert(TableNamespaces, ns); err != nil {
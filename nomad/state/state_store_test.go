@@ -436,6 +436,99 @@ func TestStateStore_UpsertPlanResults_PreemptedAllocs(t *testing.T) {
 }
 
 // This test checks that deployment updates are applied correctly
+func TestStateStore_UpsertPlanResults_PlanApplyResult(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	state := testStateStore(t)
+
+	job := mock.Job()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 998, job))
+
+	eval := mock.Eval()
+	eval.JobID = job.ID
+	require.NoError(state.UpsertEvals(structs.MsgTypeTestSetup, 999, []*structs.Evaluation{eval}))
+
+	ws := memdb.NewWatchSet()
+	_, _, err := state.PlanResultsByJob(ws, job.Namespace, job.ID)
+	require.NoError(err)
+
+	// Apply more plans than are tracked
+	for i := 0; i < structs.JobTrackedPlanResults+2; i++ {
+		res := &structs.ApplyPlanResultsRequest{
+			AllocUpdateRequest: structs.AllocUpdateRequest{
+				Job: job,
+			},
+			EvalID: eval.ID,
+			PlanApplyResult: &structs.PlanApplyResult{
+				EvalID:        eval.ID,
+				RejectedNodes: []*structs.PlanRejectedNode{{NodeID: uuid.Generate(), Reason: "node is not ready for placements"}},
+			},
+		}
+		require.NoError(state.UpsertPlanResults(structs.MsgTypeTestSetup, uint64(1000+i), res))
+	}
+	require.True(watchFired(ws))
+
+	// Only the most recent results are kept, newest first
+	results, index, err := state.PlanResultsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Len(results, structs.JobTrackedPlanResults)
+	require.EqualValues(1000+structs.JobTrackedPlanResults+1, index)
+	require.EqualValues(index, results[0].CreateIndex)
+	require.EqualValues(1002, results[len(results)-1].CreateIndex)
+
+	// Deleting the job deletes its plan results
+	require.NoError(state.DeleteJob(2000, job.Namespace, job.ID))
+	results, index, err = state.PlanResultsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Nil(results)
+	require.Zero(index)
+}
+
+func TestStateStore_UpsertPlanResults_RejectedPlanResults(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	state := testStateStore(t)
+
+	job := mock.Job()
+	other := mock.Job()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 998, job))
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 999, other))
+
+	// The held results of rejected plans are recorded with the applied plan,
+	// before its own result
+	rejected := &structs.PlanApplyResult{
+		EvalID:        uuid.Generate(),
+		RejectedNodes: []*structs.PlanRejectedNode{{NodeID: uuid.Generate(), Reason: "node does not exist"}},
+	}
+	otherRejected := &structs.PlanApplyResult{
+		EvalID:        uuid.Generate(),
+		RejectedNodes: []*structs.PlanRejectedNode{{NodeID: uuid.Generate(), Reason: "node does not exist"}},
+	}
+	applied := &structs.PlanApplyResult{
+		EvalID:        uuid.Generate(),
+		AcceptedNodes: []string{uuid.Generate()},
+	}
+	res := &structs.ApplyPlanResultsRequest{
+		AllocUpdateRequest: structs.AllocUpdateRequest{
+			Job: job,
+		},
+		PlanApplyResult: applied,
+		RejectedPlanResults: []*structs.JobPlanResults{
+			{Namespace: job.Namespace, JobID: job.ID, PlanResults: []*structs.PlanApplyResult{rejected}},
+			{Namespace: other.Namespace, JobID: other.ID, PlanResults: []*structs.PlanApplyResult{otherRejected}},
+		},
+	}
+	require.NoError(state.UpsertPlanResults(structs.MsgTypeTestSetup, 1000, res))
+
+	results, _, err := state.PlanResultsByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal([]*structs.PlanApplyResult{applied, rejected}, results)
+
+	results, _, err = state.PlanResultsByJob(nil, other.Namespace, other.ID)
+	require.NoError(err)
+	require.Equal([]*structs.PlanApplyResult{otherRejected}, results)
+}
+
 func TestStateStore_UpsertPlanResults_DeploymentUpdates(t *testing.T) {
 	ci.Parallel(t)
 	state := testStateStore(t)
@@ -1981,6 +2074,36 @@ func TestStateStore_UpsertJob_BadNamespace(t *testing.T) {
 	assert.Nil(out)
 }
 
+func TestStateStore_UpsertJobIdempotent(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	job := mock.Job()
+	job.RegisterIdempotencyToken = "ci-run-1"
+	job.SubmitTime = time.Now().UnixNano()
+	require.NoError(t, state.UpsertJobIdempotent(structs.MsgTypeTestSetup, 1000, job))
+
+	// A retry of the registration isn't applied
+	retry := job.Copy()
+	retry.Meta["retry"] = "true"
+	retry.SubmitTime = job.SubmitTime + time.Minute.Nanoseconds()
+	err := state.UpsertJobIdempotent(structs.MsgTypeTestSetup, 1001, retry)
+	require.Equal(t, &structs.JobRegisterIdempotentError{JobModifyIndex: 1000}, err)
+
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), out.ModifyIndex)
+	require.NotContains(t, out.Meta, "retry")
+
+	// A retry after the TTL is applied
+	retry.SubmitTime = job.SubmitTime + (structs.JobRegisterIdempotencyTTL + time.Minute).Nanoseconds()
+	require.NoError(t, state.UpsertJobIdempotent(structs.MsgTypeTestSetup, 1002, retry))
+
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), out.Version)
+}
+
 // Upsert a job that is the child of a parent job and ensures its summary gets
 // updated.
 func TestStateStore_UpsertJob_ChildJob(t *testing.T) {
@@ -7200,6 +7323,78 @@ func TestStateStore_UpdateJobStability(t *testing.T) {
 	require.False(t, jout.Stable)
 }
 
+func TestStateStore_UpdateJobVersionTag(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	// Insert a job twice to get two versions
+	job := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1, job))
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 2, job.Copy()))
+
+	// Tag the first version, which leaves the current version untouched
+	tag := &structs.JobVersionTag{Name: "golden", Description: "known good"}
+	require.NoError(t, state.UpdateJobVersionTag(3, job.Namespace, job.ID, 0, tag))
+
+	ws := memdb.NewWatchSet()
+	jout, err := state.JobByIDAndVersion(ws, job.Namespace, job.ID, 0)
+	require.NoError(t, err)
+	require.Equal(t, tag, jout.VersionTag)
+	require.Equal(t, uint64(3), jout.ModifyIndex)
+
+	current, err := state.JobByID(ws, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), current.Version)
+	require.Nil(t, current.VersionTag)
+
+	// Tag names are unique among the versions of the job
+	err = state.UpdateJobVersionTag(4, job.Namespace, job.ID, 1, tag)
+	require.EqualError(t, err, fmt.Sprintf("version tag %q is already used by version 0 of job %q", "golden", job.ID))
+
+	// Tagging the current version updates the current job
+	require.NoError(t, state.UpdateJobVersionTag(5, job.Namespace, job.ID, 1, &structs.JobVersionTag{Name: "latest"}))
+	current, err = state.JobByID(ws, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, "latest", current.VersionTag.Name)
+	require.Equal(t, uint64(5), current.ModifyIndex)
+
+	// Removing the tag clears it
+	require.NoError(t, state.UpdateJobVersionTag(6, job.Namespace, job.ID, 1, nil))
+	current, err = state.JobByID(ws, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Nil(t, current.VersionTag)
+
+	// Tagging a missing version fails
+	err = state.UpdateJobVersionTag(7, job.Namespace, job.ID, 10, tag)
+	require.Error(t, err)
+}
+
+func TestStateStore_UpsertJob_TaggedVersionsKept(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	job := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1, job))
+	tag := &structs.JobVersionTag{Name: "golden"}
+	require.NoError(t, state.UpdateJobVersionTag(2, job.Namespace, job.ID, 0, tag))
+
+	// Register enough new versions for the first version to be garbage
+	// collected if it wasn't tagged
+	for i := 0; i < structs.JobTrackedVersions+2; i++ {
+		require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, uint64(10+i), job.Copy()))
+	}
+
+	versions, err := state.JobVersionsByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, structs.JobTrackedVersions+1)
+
+	tagged := versions[len(versions)-1]
+	require.Equal(t, uint64(0), tagged.Version)
+	require.Equal(t, tag, tagged.VersionTag)
+}
+
 // Test that nonexistent deployment can't be promoted
 func TestStateStore_UpsertDeploymentPromotion_Nonexistent(t *testing.T) {
 	ci.Parallel(t)
@@ -7526,6 +7721,164 @@ func TestStateStore_UpsertDeploymentPromotion_Subset(t *testing.T) {
 	require.True(aout3.DeploymentStatus.Canary)
 }
 
+// Test promoting a deployment's canaries partially and then fully
+func TestStateStore_UpsertDeploymentPromotion_Percent(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	state := testStateStore(t)
+
+	j := mock.Job()
+	require.Nil(state.UpsertJob(structs.MsgTypeTestSetup, 1, j))
+
+	// Create a deployment
+	d := mock.Deployment()
+	d.StatusDescription = structs.DeploymentStatusDescriptionRunningNeedsPromotion
+	d.JobID = j.ID
+	d.TaskGroups = map[string]*structs.DeploymentState{
+		"web": {
+			DesiredTotal:    10,
+			DesiredCanaries: 1,
+		},
+	}
+
+	// Create a healthy canary
+	c := mock.Alloc()
+	c.JobID = j.ID
+	c.DeploymentID = d.ID
+	d.TaskGroups[c.TaskGroup].PlacedCanaries = []string{c.ID}
+	c.DeploymentStatus = &structs.AllocDeploymentStatus{
+		Healthy: helper.BoolToPtr(true),
+		Canary:  true,
+	}
+	require.Nil(state.UpsertDeployment(2, d))
+	require.Nil(state.UpsertAllocs(structs.MsgTypeTestSetup, 3, []*structs.Allocation{c}))
+
+	promote := func(index uint64, percent int) error {
+		return state.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, index, &structs.ApplyDeploymentPromoteRequest{
+			DeploymentPromoteRequest: structs.DeploymentPromoteRequest{
+				DeploymentID: d.ID,
+				All:          true,
+				Percent:      percent,
+			},
+		})
+	}
+
+	// Promote the canaries to half of the group
+	require.Nil(promote(4, 50))
+
+	ws := memdb.NewWatchSet()
+	dout, err := state.DeploymentByID(ws, d.ID)
+	require.Nil(err)
+	require.True(dout.TaskGroups["web"].Promoted)
+	require.True(dout.TaskGroups["web"].PartiallyPromoted())
+	require.Equal(5, dout.TaskGroups["web"].PromotedTotal())
+	require.True(dout.RequiresPromotion())
+	require.Equal(structs.DeploymentStatusDescriptionRunningNeedsPromotion, dout.StatusDescription)
+
+	aout, err := state.AllocByID(ws, c.ID)
+	require.Nil(err)
+	require.False(aout.DeploymentStatus.Canary)
+
+	// The percentage can only be increased
+	require.EqualError(promote(5, 50), `1 error occurred:
+	* Task group "web" is already promoted to 50%
+
+`)
+
+	// Promote the canaries fully
+	require.Nil(promote(6, 0))
+
+	dout, err = state.DeploymentByID(ws, d.ID)
+	require.Nil(err)
+	require.True(dout.TaskGroups["web"].Promoted)
+	require.False(dout.TaskGroups["web"].PartiallyPromoted())
+	require.Equal(10, dout.TaskGroups["web"].PromotedTotal())
+	require.False(dout.RequiresPromotion())
+	require.Equal(structs.DeploymentStatusDescriptionRunning, dout.StatusDescription)
+
+	// A fully promoted deployment can't be partially promoted
+	require.Error(promote(7, 75))
+}
+
+// Test that promoting a blue/green deployment stages the allocations of the
+// previous job version
+func TestStateStore_UpsertDeploymentPromotion_BlueGreen(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	state := testStateStore(t)
+
+	j := mock.Job()
+	require.Nil(state.UpsertJob(structs.MsgTypeTestSetup, 1, j))
+
+	// Create a blue/green deployment
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups = map[string]*structs.DeploymentState{
+		"web": {
+			DesiredTotal:    1,
+			DesiredCanaries: 1,
+			BlueGreen:       true,
+		},
+	}
+
+	// Create a running allocation of the previous version and a healthy
+	// canary
+	old := mock.Alloc()
+	old.JobID = j.ID
+
+	stopped := mock.Alloc()
+	stopped.JobID = j.ID
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	stopped.ClientStatus = structs.AllocClientStatusComplete
+
+	c := mock.Alloc()
+	c.JobID = j.ID
+	c.DeploymentID = d.ID
+	d.TaskGroups[c.TaskGroup].PlacedCanaries = []string{c.ID}
+	c.DeploymentStatus = &structs.AllocDeploymentStatus{
+		Healthy: helper.BoolToPtr(true),
+		Canary:  true,
+	}
+	require.Nil(state.UpsertDeployment(2, d))
+	require.Nil(state.UpsertAllocs(structs.MsgTypeTestSetup, 3, []*structs.Allocation{old, stopped, c}))
+
+	// Promote the canaries partially, which doesn't stage anything
+	req := &structs.ApplyDeploymentPromoteRequest{
+		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{
+			DeploymentID: d.ID,
+			All:          true,
+			Percent:      50,
+		},
+	}
+	require.Nil(state.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, 4, req))
+
+	ws := memdb.NewWatchSet()
+	out, err := state.AllocByID(ws, old.ID)
+	require.Nil(err)
+	require.Nil(out.DeploymentStatus)
+
+	// Promote the canaries fully
+	req.Percent = 0
+	require.Nil(state.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, 5, req))
+
+	out, err = state.AllocByID(ws, old.ID)
+	require.Nil(err)
+	require.True(out.DeploymentStatus.Staged)
+	require.Equal(uint64(5), out.AllocModifyIndex)
+	require.Equal(structs.BlueGreenStagedTag, out.BlueGreenTag())
+
+	out, err = state.AllocByID(ws, stopped.ID)
+	require.Nil(err)
+	require.Nil(out.DeploymentStatus)
+
+	out, err = state.AllocByID(ws, c.ID)
+	require.Nil(err)
+	require.False(out.DeploymentStatus.Staged)
+	require.False(out.DeploymentStatus.Canary)
+}
+
 // Test that allocation health can't be set against a nonexistent deployment
 func TestStateStore_UpsertDeploymentAllocHealth_Nonexistent(t *testing.T) {
 	ci.Parallel(t)
@@ -7782,6 +8135,9 @@ func TestStateStore_UpsertDeploymentAllocHealth(t *testing.T) {
 			DeploymentID:           d.ID,
 			HealthyAllocationIDs:   []string{a1.ID},
 			UnhealthyAllocationIDs: []string{a2.ID},
+			Reason:                 "smoke tests",
+			Reasons:                map[string]string{a2.ID: "errors in logs"},
+			SetBy:                  "accessor",
 		},
 		Job:              j,
 		Eval:             e,
@@ -7844,6 +8200,22 @@ func TestStateStore_UpsertDeploymentAllocHealth(t *testing.T) {
 	if !out2.DeploymentStatus.Timestamp.Equal(ts) {
 		t.Fatalf("bad: alloc %q had timestamp %v; want %v", out2.ID, out2.DeploymentStatus.Timestamp, ts)
 	}
+
+	require.True(t, out1.DeploymentStatus.Manual)
+	require.Equal(t, "smoke tests", out1.DeploymentStatus.Reason)
+	require.Equal(t, "accessor", out1.DeploymentStatus.SetBy)
+	require.Equal(t, "errors in logs", out2.DeploymentStatus.Reason)
+
+	// Health reported by the client replaces the health set manually
+	update := out2.Copy()
+	update.DeploymentStatus.Healthy = helper.BoolToPtr(true)
+	require.NoError(t, state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 4, []*structs.Allocation{update}))
+	out2, err = state.AllocByID(ws, a2.ID)
+	require.NoError(t, err)
+	require.True(t, out2.DeploymentStatus.IsHealthy())
+	require.False(t, out2.DeploymentStatus.Manual)
+	require.Empty(t, out2.DeploymentStatus.Reason)
+	require.Empty(t, out2.DeploymentStatus.SetBy)
 }
 
 func TestStateStore_UpsertVaultAccessors(t *testing.T) {
@@ -9196,6 +9568,44 @@ func TestStateStore_UpsertJob_UpdateScalingPolicy(t *testing.T) {
 	require.Greater(index, oldIndex, "table index should have advanced")
 }
 
+func TestStateStore_UpsertJob_StandaloneScalingPolicy(t *testing.T) {
+	ci.Parallel(t)
+
+	require := require.New(t)
+
+	state := testStateStore(t)
+	job := mock.Job()
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+
+	policy := mock.ScalingPolicy()
+	policy.Standalone = true
+	policy.TargetTaskGroup(job, job.TaskGroups[0])
+	require.NoError(state.UpsertScalingPolicies(1100, []*structs.ScalingPolicy{policy}))
+
+	// Standalone policies are kept when the job is updated
+	job = job.Copy()
+	job.Meta["updated"] = "true"
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1200, job))
+
+	out, err := state.ScalingPolicyByID(nil, policy.ID)
+	require.NoError(err)
+	require.NotNil(out)
+	require.True(out.Standalone)
+
+	// Unless the job now defines a policy for their target
+	job, jobPolicy := mock.JobWithScalingPolicy()
+	job.ID = policy.Target[structs.ScalingTargetJob]
+	jobPolicy.TargetTaskGroup(job, job.TaskGroups[0])
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1300, job))
+
+	out, err = state.ScalingPolicyByTargetAndType(nil, policy.Target, policy.Type)
+	require.NoError(err)
+	require.NotNil(out)
+	require.False(out.Standalone)
+	require.Equal(policy.ID, out.ID)
+	require.Equal(jobPolicy.Max, out.Max)
+}
+
 func TestStateStore_DeleteScalingPolicies(t *testing.T) {
 	ci.Parallel(t)
 
@@ -9709,6 +10119,199 @@ func TestStateStore_ScalingPolicyByTargetAndType(t *testing.T) {
 	require.Equal(policyC, found)
 }
 
+func TestStateStore_ScheduledScalingActions(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	state := testStateStore(t)
+	action := &structs.ScheduledScalingAction{
+		ID:         uuid.Generate(),
+		Namespace:  structs.DefaultNamespace,
+		JobID:      "example",
+		TaskGroup:  "web",
+		Count:      5,
+		ScheduleAt: time.Now().Add(time.Hour),
+	}
+
+	ws := memdb.NewWatchSet()
+	_, err := state.ScheduledScalingActions(ws)
+	require.NoError(err)
+
+	require.NoError(state.UpsertScheduledScalingAction(structs.MsgTypeTestSetup, 1000, action))
+	require.True(watchFired(ws))
+
+	out, err := state.ScheduledScalingActionByID(nil, action.ID)
+	require.NoError(err)
+	require.Equal(action, out)
+	require.EqualValues(1000, out.CreateIndex)
+
+	index, err := state.Index("scheduled_scaling")
+	require.NoError(err)
+	require.EqualValues(1000, index)
+
+	// updates keep the create index
+	updated := *action
+	updated.Count = 6
+	require.NoError(state.UpsertScheduledScalingAction(structs.MsgTypeTestSetup, 1001, &updated))
+	out, err = state.ScheduledScalingActionByID(nil, action.ID)
+	require.NoError(err)
+	require.EqualValues(1000, out.CreateIndex)
+	require.EqualValues(1001, out.ModifyIndex)
+
+	ws = memdb.NewWatchSet()
+	_, err = state.ScheduledScalingActionByID(ws, action.ID)
+	require.NoError(err)
+
+	require.NoError(state.DeleteScheduledScalingActions(structs.MsgTypeTestSetup, 1002, []string{action.ID}))
+	require.True(watchFired(ws))
+
+	out, err = state.ScheduledScalingActionByID(nil, action.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	index, err = state.Index("scheduled_scaling")
+	require.NoError(err)
+	require.EqualValues(1002, index)
+}
+
+func TestStateStore_ScheduledScalingActionsByNamespace(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	state := testStateStore(t)
+	action1 := &structs.ScheduledScalingAction{
+		ID:         uuid.Generate(),
+		Namespace:  structs.DefaultNamespace,
+		JobID:      "example",
+		TaskGroup:  "web",
+		Count:      5,
+		ScheduleAt: time.Now().Add(time.Hour),
+	}
+	action2 := *action1
+	action2.ID = uuid.Generate()
+	action2.Namespace = "other"
+
+	require.NoError(state.UpsertScheduledScalingAction(structs.MsgTypeTestSetup, 1000, action1))
+	require.NoError(state.UpsertScheduledScalingAction(structs.MsgTypeTestSetup, 1001, &action2))
+
+	iter, err := state.ScheduledScalingActionsByNamespace(nil, "other")
+	require.NoError(err)
+	var out []*structs.ScheduledScalingAction
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		out = append(out, raw.(*structs.ScheduledScalingAction))
+	}
+	require.Equal([]*structs.ScheduledScalingAction{&action2}, out)
+}
+
+func TestStateStore_UpsertWorkloadIdentityKeys(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	state := testStateStore(t)
+	active := &structs.WorkloadIdentityKey{
+		KeyID:        "key1",
+		Algorithm:    "ES256",
+		Key:          []byte("private1"),
+		State:        structs.WorkloadIdentityKeyStateActive,
+		CreateTime:   time.Now(),
+		ActivateTime: time.Now(),
+	}
+	next := &structs.WorkloadIdentityKey{
+		KeyID:      "key2",
+		Algorithm:  "ES256",
+		Key:        []byte("private2"),
+		State:      structs.WorkloadIdentityKeyStateNext,
+		CreateTime: time.Now(),
+	}
+
+	ws := memdb.NewWatchSet()
+	_, err := state.WorkloadIdentityKeys(ws)
+	require.NoError(err)
+
+	require.NoError(state.UpsertWorkloadIdentityKeys(structs.MsgTypeTestSetup, 1000,
+		[]*structs.WorkloadIdentityKey{active, next}, nil))
+	require.True(watchFired(ws))
+
+	out, err := state.WorkloadIdentityKeyByID(nil, next.KeyID)
+	require.NoError(err)
+	require.Equal(next, out)
+	require.EqualValues(1000, out.CreateIndex)
+
+	// Updates keep the create index, and deletes are applied in the same
+	// transaction
+	promoted := next.Copy()
+	promoted.State = structs.WorkloadIdentityKeyStateActive
+	require.NoError(state.UpsertWorkloadIdentityKeys(structs.MsgTypeTestSetup, 1001,
+		[]*structs.WorkloadIdentityKey{promoted}, []string{active.KeyID}))
+
+	out, err = state.WorkloadIdentityKeyByID(nil, next.KeyID)
+	require.NoError(err)
+	require.Equal(structs.WorkloadIdentityKeyStateActive, out.State)
+	require.EqualValues(1000, out.CreateIndex)
+	require.EqualValues(1001, out.ModifyIndex)
+
+	out, err = state.WorkloadIdentityKeyByID(nil, active.KeyID)
+	require.NoError(err)
+	require.Nil(out)
+
+	index, err := state.Index("workload_identity_keys")
+	require.NoError(err)
+	require.EqualValues(1001, index)
+}
+
+func TestStateStore_EventCursors(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	state := testStateStore(t)
+
+	ws := memdb.NewWatchSet()
+	_, err := state.EventCursors(ws)
+	require.NoError(err)
+
+	cursor := &structs.EventCursor{Name: "consumer", Index: 10}
+	require.NoError(state.UpsertEventCursor(structs.MsgTypeTestSetup, 1000, cursor))
+	require.True(watchFired(ws))
+
+	// Updates keep the create index
+	ws = memdb.NewWatchSet()
+	_, err = state.EventCursorByName(ws, "consumer")
+	require.NoError(err)
+	require.NoError(state.UpsertEventCursor(structs.MsgTypeTestSetup, 1001,
+		&structs.EventCursor{Name: "consumer", Index: 20}))
+	require.True(watchFired(ws))
+
+	out, err := state.EventCursorByName(nil, "consumer")
+	require.NoError(err)
+	require.EqualValues(20, out.Index)
+	require.EqualValues(1000, out.CreateIndex)
+	require.EqualValues(1001, out.ModifyIndex)
+
+	require.NoError(state.UpsertEventCursor(structs.MsgTypeTestSetup, 1002,
+		&structs.EventCursor{Name: "another", Index: 5}))
+	iter, err := state.EventCursors(nil)
+	require.NoError(err)
+	var names []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		names = append(names, raw.(*structs.EventCursor).Name)
+	}
+	require.Equal([]string{"another", "consumer"}, names)
+
+	// Deleting a missing cursor doesn't bump the index
+	require.NoError(state.DeleteEventCursors(structs.MsgTypeTestSetup, 1003, []string{"missing"}))
+	index, err := state.Index("event_cursors")
+	require.NoError(err)
+	require.EqualValues(1002, index)
+
+	require.NoError(state.DeleteEventCursors(structs.MsgTypeTestSetup, 1004, []string{"consumer"}))
+	out, err = state.EventCursorByName(nil, "consumer")
+	require.NoError(err)
+	require.Nil(out)
+	index, err = state.Index("event_cursors")
+	require.NoError(err)
+	require.EqualValues(1004, index)
+}
+
 func TestStateStore_UpsertScalingEvent(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -10056,3 +10659,183 @@ func nextIndex(s *StateStore) uint64 {
 	index++
 	return index
 }
+
+func TestStateStore_MoveJobTxn(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	ns := mock.Namespace()
+	require.NoError(t, state.UpsertNamespaces(1, []*structs.Namespace{ns}))
+
+	// Register a job with a scaling policy twice to get two versions
+	job, _ := mock.JobWithScalingPolicy()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 2, job))
+	job2 := job.Copy()
+	job2.Priority = 99
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 3, job2))
+	current, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+
+	// Add an allocation, a terminal evaluation and a terminal deployment
+	alloc := mock.Alloc()
+	alloc.Job = current
+	alloc.JobID = job.ID
+	alloc.Namespace = job.Namespace
+	alloc.Name = structs.AllocName(job.ID, alloc.TaskGroup, 3)
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 4, []*structs.Allocation{alloc}))
+
+	eval := mock.Eval()
+	eval.JobID = job.ID
+	eval.Namespace = job.Namespace
+	eval.Status = structs.EvalStatusComplete
+	blocked := mock.Eval()
+	blocked.JobID = job.ID
+	blocked.Namespace = job.Namespace
+	blocked.Status = structs.EvalStatusBlocked
+	require.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 5, []*structs.Evaluation{eval, blocked}))
+
+	d := mock.Deployment()
+	d.JobID = job.ID
+	d.Namespace = job.Namespace
+	d.Status = structs.DeploymentStatusSuccessful
+	require.NoError(t, state.UpsertDeployment(6, d))
+
+	move := func(index uint64, newNamespace, newJobID string) error {
+		return state.WithWriteTransaction(structs.MsgTypeTestSetup, index, func(txn Txn) error {
+			return state.MoveJobTxn(index, job.Namespace, job.ID, newNamespace, newJobID, txn)
+		})
+	}
+
+	// Moving to a missing namespace or over an existing job fails and
+	// leaves the job in place
+	err = move(7, "missing", "moved")
+	require.EqualError(t, err, `namespace "missing" does not exist`)
+
+	other := mock.Job()
+	other.Namespace = ns.Name
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 8, other))
+	err = move(9, ns.Name, other.ID)
+	require.EqualError(t, err, fmt.Sprintf("job %q already exists in namespace %q", other.ID, ns.Name))
+
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	// Move the job
+	require.NoError(t, move(10, ns.Name, "moved"))
+
+	out, err = state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	out, err = state.JobByID(nil, ns.Name, "moved")
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, current.Version, out.Version)
+	require.Equal(t, current.JobModifyIndex, out.JobModifyIndex)
+	require.Equal(t, current.CreateIndex, out.CreateIndex)
+	require.Equal(t, uint64(10), out.ModifyIndex)
+	require.Equal(t, ns.Name, out.TaskGroups[0].Scaling.Target[structs.ScalingTargetNamespace])
+	require.Equal(t, "moved", out.TaskGroups[0].Scaling.Target[structs.ScalingTargetJob])
+
+	versions, err := state.JobVersionsByID(nil, ns.Name, "moved")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	versions, err = state.JobVersionsByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Empty(t, versions)
+
+	summary, err := state.JobSummaryByID(nil, ns.Name, "moved")
+	require.NoError(t, err)
+	require.NotNil(t, summary)
+	summary, err = state.JobSummaryByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Nil(t, summary)
+
+	iter, err := state.ScalingPoliciesByJob(nil, ns.Name, "moved", "")
+	require.NoError(t, err)
+	require.NotNil(t, iter.Next())
+
+	allocs, err := state.AllocsByJob(nil, ns.Name, "moved", true)
+	require.NoError(t, err)
+	require.Len(t, allocs, 1)
+	require.Equal(t, alloc.ID, allocs[0].ID)
+	require.Equal(t, "moved", allocs[0].Job.ID)
+	require.Equal(t, ns.Name, allocs[0].Job.Namespace)
+	require.Equal(t, uint64(10), allocs[0].AllocModifyIndex)
+	require.Equal(t, structs.AllocName("moved", alloc.TaskGroup, 3), allocs[0].Name)
+	require.Equal(t, uint(3), allocs[0].Index())
+
+	evals, err := state.EvalsByJob(nil, ns.Name, "moved")
+	require.NoError(t, err)
+	require.Len(t, evals, 2)
+
+	// Blocked evaluations are cancelled
+	blockedOut, err := state.EvalByID(nil, blocked.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.EvalStatusCancelled, blockedOut.Status)
+	require.Equal(t, "moved", blockedOut.JobID)
+
+	deployments, err := state.DeploymentsByJobID(nil, ns.Name, "moved", true)
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+
+	// The original job is left untouched by the move
+	require.Equal(t, job.ID, job.TaskGroups[0].Scaling.Target[structs.ScalingTargetJob])
+}
+
+func TestStateStore_MoveJobTxn_Guards(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+
+	move := func(index uint64, job *structs.Job) error {
+		return state.WithWriteTransaction(structs.MsgTypeTestSetup, index, func(txn Txn) error {
+			return state.MoveJobTxn(index, job.Namespace, job.ID, job.Namespace, "moved", txn)
+		})
+	}
+
+	// Periodic jobs can't be moved
+	periodic := mock.PeriodicJob()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1, periodic))
+	require.EqualError(t, move(2, periodic), "periodic and parameterized jobs can't be moved")
+
+	// Jobs with pending evaluations can't be moved
+	job := mock.Job()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 3, job))
+	eval := mock.Eval()
+	eval.JobID = job.ID
+	require.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 4, []*structs.Evaluation{eval}))
+	require.EqualError(t, move(5, job), fmt.Sprintf("job has non-terminal evaluation %q", eval.ID))
+
+	// Jobs with active deployments can't be moved
+	eval = eval.Copy()
+	eval.Status = structs.EvalStatusComplete
+	require.NoError(t, state.UpsertEvals(structs.MsgTypeTestSetup, 6, []*structs.Evaluation{eval}))
+	d := mock.Deployment()
+	d.JobID = job.ID
+	require.NoError(t, state.UpsertDeployment(7, d))
+	require.EqualError(t, move(8, job), fmt.Sprintf("job has active deployment %q", d.ID))
+
+	// Missing jobs can't be moved
+	require.EqualError(t, move(9, mock.Job()), "job not found")
+
+	// Jobs using CSI volumes can be renamed, but not moved to another
+	// namespace, even if only a previous version used the volumes
+	ns := mock.Namespace()
+	require.NoError(t, state.UpsertNamespaces(10, []*structs.Namespace{ns}))
+	csiJob := mock.Job()
+	csiJob.TaskGroups[0].Volumes = map[string]*structs.VolumeRequest{
+		"data": {Name: "data", Type: structs.VolumeTypeCSI, Source: "volume0"},
+	}
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 11, csiJob))
+	csiJob = csiJob.Copy()
+	csiJob.TaskGroups[0].Volumes = nil
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 12, csiJob))
+	err := state.WithWriteTransaction(structs.MsgTypeTestSetup, 13, func(txn Txn) error {
+		return state.MoveJobTxn(13, csiJob.Namespace, csiJob.ID, ns.Name, csiJob.ID, txn)
+	})
+	require.EqualError(t, err, "jobs using CSI volumes can't be moved to another namespace")
+	require.NoError(t, move(14, csiJob))
+}
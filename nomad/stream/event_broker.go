@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-memdb"
@@ -45,6 +46,12 @@ type EventBroker struct {
 
 	aclCh chan *structs.Event
 
+	// aclExpiry holds a timer per token with expiring policies, which
+	// re-checks the subscriptions of the token once its earliest policy
+	// expires. It is protected by aclExpiryLock.
+	aclExpiryLock sync.Mutex
+	aclExpiry     map[string]*time.Timer
+
 	logger hclog.Logger
 }
 
@@ -75,6 +82,7 @@ func NewEventBroker(ctx context.Context, aclDelegate ACLDelegate, cfg EventBroke
 		aclCh:       make(chan *structs.Event, 10),
 		aclDelegate: aclDelegate,
 		aclCache:    aclCache,
+		aclExpiry:   make(map[string]*time.Timer),
 		subscriptions: &subscriptions{
 			byToken: make(map[string]map[*SubscribeRequest]*Subscription),
 		},
@@ -91,6 +99,21 @@ func (e *EventBroker) Len() int {
 	return e.eventBuf.Len()
 }
 
+// LatestIndex returns the index of the latest events published to the
+// broker.
+func (e *EventBroker) LatestIndex() uint64 {
+	if index := e.eventBuf.Tail().Events.Index; index > 0 {
+		return index
+	}
+	return e.eventBuf.LostIndex()
+}
+
+// LostIndex returns the highest index whose events may no longer be in the
+// event buffer. Subscriptions can only be resumed after this index.
+func (e *EventBroker) LostIndex() uint64 {
+	return e.eventBuf.LostIndex()
+}
+
 // Publish events to all subscribers of the event Topic.
 func (e *EventBroker) Publish(events *structs.Events) {
 	if len(events.Events) == 0 {
@@ -120,7 +143,13 @@ func (e *EventBroker) SubscribeWithACLCheck(req *SubscribeRequest) (*Subscriptio
 		return nil, structs.ErrPermissionDenied
 	}
 
-	return e.Subscribe(req)
+	sub, err := e.Subscribe(req)
+	if err != nil {
+		return nil, err
+	}
+
+	e.scheduleACLExpiry(e.aclDelegate.TokenProvider(), req.Token)
+	return sub, nil
 }
 
 // Subscribe returns a new Subscription for a given request. A Subscription
@@ -133,12 +162,19 @@ func (e *EventBroker) SubscribeWithACLCheck(req *SubscribeRequest) (*Subscriptio
 // set and the index is no longer in the buffer or not yet in the buffer an error
 // will be returned.
 //
+// A Subscription with a ResumeIndex will start with the events following it,
+// and an error will be returned if they are no longer in the buffer.
+//
 // When a caller is finished with the subscription it must call Subscription.Unsubscribe
 // to free ACL tracking resources.
 func (e *EventBroker) Subscribe(req *SubscribeRequest) (*Subscription, error) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
+	if req.ResumeIndex != 0 {
+		return e.resume(req)
+	}
+
 	var head *bufferItem
 	var offset int
 	if req.Index != 0 {
@@ -164,6 +200,43 @@ func (e *EventBroker) Subscribe(req *SubscribeRequest) (*Subscription, error) {
 	return sub, nil
 }
 
+// resume returns a new Subscription starting with the events following the
+// ResumeIndex of the request.
+func (e *EventBroker) resume(req *SubscribeRequest) (*Subscription, error) {
+	// Find the first item following the resume index, if it was published
+	// already
+	var last, next *bufferItem
+	for item := e.eventBuf.Head(); item != nil; item = item.NextNoBlock() {
+		if item.Events.Index > req.ResumeIndex {
+			next = item
+			break
+		}
+		last = item
+	}
+
+	// The buffer may have advanced while searching it, so the lost index is
+	// checked last
+	if req.ResumeIndex < e.eventBuf.LostIndex() {
+		return nil, ErrResumeIndexLost
+	}
+
+	var start *bufferItem
+	if next == nil {
+		// The subscriber is caught up, so it waits for the item following
+		// the last one searched
+		start = last
+	} else {
+		start = newBufferItem(&structs.Events{Index: req.ResumeIndex})
+		start.link.next.Store(next)
+		close(start.link.nextCh)
+	}
+
+	sub := newSubscription(req, start, e.subscriptions.unsubscribeFn(req))
+
+	e.subscriptions.add(req, sub)
+	return sub, nil
+}
+
 // CloseAll closes all subscriptions
 func (e *EventBroker) CloseAll() {
 	e.subscriptions.closeAll()
@@ -185,6 +258,7 @@ func (e *EventBroker) handleACLUpdates(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
+			e.stopACLExpiry()
 			return
 		case update := <-e.aclCh:
 			switch payload := update.Payload.(type) {
@@ -197,21 +271,7 @@ func (e *EventBroker) handleACLUpdates(ctx context.Context) {
 					continue
 				}
 
-				// If broker cannot fetch state there is nothing more to do
-				if e.aclDelegate == nil {
-					continue
-				}
-
-				aclObj, err := aclObjFromSnapshotForTokenSecretID(e.aclDelegate.TokenProvider(), e.aclCache, tokenSecretID)
-				if err != nil || aclObj == nil {
-					e.logger.Error("failed resolving ACL for secretID, closing subscriptions", "error", err)
-					e.subscriptions.closeSubscriptionsForTokens([]string{tokenSecretID})
-					continue
-				}
-
-				e.subscriptions.closeSubscriptionFunc(tokenSecretID, func(sub *Subscription) bool {
-					return !aclAllowsSubscription(aclObj, sub.req)
-				})
+				e.checkSubscriptionsForToken(tokenSecretID)
 
 			case *structs.ACLPolicyEvent:
 				// Re-evaluate each subscriptions permissions since a policy
@@ -222,6 +282,78 @@ func (e *EventBroker) handleACLUpdates(ctx context.Context) {
 	}
 }
 
+// checkSubscriptionsForToken evaluates whether the token used for
+// subscriptions still allows them, closing the subscriptions it no longer
+// allows, and schedules the next check for when its policies expire.
+func (e *EventBroker) checkSubscriptionsForToken(tokenSecretID string) {
+	// If broker cannot fetch state there is nothing more to do
+	if e.aclDelegate == nil {
+		return
+	}
+
+	aclSnapshot := e.aclDelegate.TokenProvider()
+	aclObj, err := aclObjFromSnapshotForTokenSecretID(aclSnapshot, e.aclCache, tokenSecretID)
+	if err != nil || aclObj == nil {
+		e.logger.Error("failed resolving ACL for secretID, closing subscriptions", "error", err)
+		e.subscriptions.closeSubscriptionsForTokens([]string{tokenSecretID})
+		return
+	}
+
+	e.subscriptions.closeSubscriptionFunc(tokenSecretID, func(sub *Subscription) bool {
+		return !aclAllowsSubscription(aclObj, sub.req)
+	})
+
+	e.scheduleACLExpiry(aclSnapshot, tokenSecretID)
+}
+
+// scheduleACLExpiry schedules a check of the subscriptions of the token for
+// when its earliest expiring policy expires. No token or policy update is
+// published when a policy expires, so subscriptions would otherwise keep
+// receiving events the token no longer allows.
+func (e *EventBroker) scheduleACLExpiry(aclSnapshot ACLTokenProvider, tokenSecretID string) {
+	// if tokenSecretID is empty ACLs were disabled at time of subscribing
+	if tokenSecretID == "" {
+		return
+	}
+
+	var next time.Time
+	now := time.Now()
+	aclToken, err := aclSnapshot.ACLTokenBySecretID(nil, tokenSecretID)
+	if err == nil && aclToken != nil {
+		for _, p := range aclToken.ExpiringPolicies {
+			if !p.Expired(now) && (next.IsZero() || p.ExpirationTime.Before(next)) {
+				next = p.ExpirationTime
+			}
+		}
+	}
+
+	e.aclExpiryLock.Lock()
+	defer e.aclExpiryLock.Unlock()
+
+	if timer, ok := e.aclExpiry[tokenSecretID]; ok {
+		timer.Stop()
+		delete(e.aclExpiry, tokenSecretID)
+	}
+	if next.IsZero() || !e.subscriptions.hasToken(tokenSecretID) {
+		return
+	}
+
+	e.aclExpiry[tokenSecretID] = time.AfterFunc(next.Sub(now), func() {
+		e.checkSubscriptionsForToken(tokenSecretID)
+	})
+}
+
+// stopACLExpiry stops the scheduled checks of expiring policies.
+func (e *EventBroker) stopACLExpiry() {
+	e.aclExpiryLock.Lock()
+	defer e.aclExpiryLock.Unlock()
+
+	for tokenSecretID, timer := range e.aclExpiry {
+		timer.Stop()
+		delete(e.aclExpiry, tokenSecretID)
+	}
+}
+
 // checkSubscriptionsAgainstPolicyChange iterates over the brokers
 // subscriptions and evaluates whether the token used for the subscription is
 // still valid. If it is not valid it closes the subscriptions belonging to the
@@ -272,8 +404,9 @@ func aclObjFromSnapshotForTokenSecretID(aclSnapshot ACLTokenProvider, aclCache *
 		return acl.ManagementACL, nil
 	}
 
-	aclPolicies := make([]*structs.ACLPolicy, 0, len(aclToken.Policies))
-	for _, policyName := range aclToken.Policies {
+	tokenPolicies := aclToken.ActivePolicies(time.Now())
+	aclPolicies := make([]*structs.ACLPolicy, 0, len(tokenPolicies))
+	for _, policyName := range tokenPolicies {
 		policy, err := aclSnapshot.ACLPolicyByName(nil, policyName)
 		if err != nil || policy == nil {
 			return nil, errors.New("error finding acl policy")
@@ -300,13 +433,17 @@ func aclAllowsSubscription(aclObj *acl.ACL, subReq *SubscribeRequest) bool {
 			structs.TopicEvaluation,
 			structs.TopicAllocation,
 			structs.TopicJob:
-			if ok := aclObj.AllowNsOp(subReq.Namespace, acl.NamespaceCapabilityReadJob); !ok {
+			if ok := aclAllowsNamespaces(aclObj, subReq, acl.NamespaceCapabilityReadJob); !ok {
 				return false
 			}
 		case structs.TopicNode:
 			if ok := aclObj.AllowNodeRead(); !ok {
 				return false
 			}
+		case structs.TopicCSIVolume:
+			if ok := aclAllowsNamespaces(aclObj, subReq, acl.NamespaceCapabilityCSIReadVolume); !ok {
+				return false
+			}
 		default:
 			if ok := aclObj.IsManagement(); !ok {
 				return false
@@ -317,6 +454,17 @@ func aclAllowsSubscription(aclObj *acl.ACL, subReq *SubscribeRequest) bool {
 	return true
 }
 
+// aclAllowsNamespaces returns whether the capability is granted for every
+// namespace pattern of the subscription.
+func aclAllowsNamespaces(aclObj *acl.ACL, subReq *SubscribeRequest, op string) bool {
+	for _, ns := range subReq.namespaces() {
+		if ok := aclObj.AllowNsOp(ns, op); !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *Subscription) forceClose() {
 	if atomic.CompareAndSwapUint32(&s.state, subscriptionStateOpen, subscriptionStateClosed) {
 		close(s.forceClosed)
@@ -348,6 +496,14 @@ func (s *subscriptions) add(req *SubscribeRequest, sub *Subscription) {
 	subsByToken[req] = sub
 }
 
+// hasToken returns whether there are subscriptions using the token.
+func (s *subscriptions) hasToken(tokenSecretID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.byToken[tokenSecretID]) > 0
+}
+
 func (s *subscriptions) closeSubscriptionsForTokens(tokenSecretIDs []string) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -578,6 +578,65 @@ func TestEventBroker_handleACLUpdates_policyupdated(t *testing.T) {
 	}
 }
 
+func TestEventBroker_ExpiringPolicy(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	policy := &structs.ACLPolicy{
+		Name:  "some-policy",
+		Rules: mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}),
+	}
+	policy.SetHash()
+
+	secretID := "some-secret-id"
+	tokenProvider := &fakeACLTokenProvider{
+		policy: policy,
+		token: &structs.ACLToken{
+			SecretID: secretID,
+			Type:     structs.ACLClientToken,
+			ExpiringPolicies: []*structs.ACLTokenExpiringPolicy{{
+				Name:           policy.Name,
+				ExpirationTime: time.Now().Add(200 * time.Millisecond),
+			}},
+		},
+	}
+
+	publisher, err := NewEventBroker(ctx, &fakeACLDelegate{tokenProvider: tokenProvider}, EventBrokerCfg{})
+	require.NoError(t, err)
+
+	sub, err := publisher.SubscribeWithACLCheck(&SubscribeRequest{
+		Topics:    map[structs.Topic][]string{structs.TopicJob: {"*"}},
+		Namespace: structs.DefaultNamespace,
+		Token:     secretID,
+	})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	event := structs.Event{
+		Topic:     structs.TopicJob,
+		Type:      structs.TypeJobRegistered,
+		Namespace: structs.DefaultNamespace,
+		Payload:   structs.JobEvent{Job: mock.Job()},
+	}
+	publisher.Publish(&structs.Events{Index: 100, Events: []structs.Event{event}})
+
+	eventCh := consumeSubscription(ctx, sub)
+	next := nextResult(t, eventCh)
+	require.NoError(t, next.Err)
+	require.Len(t, next.Events, 1)
+
+	// The subscription is closed once the policy expires, without any token
+	// or policy update
+	select {
+	case next := <-eventCh:
+		require.Equal(t, ErrSubscriptionClosed, next.Err)
+	case <-time.After(2 * time.Second):
+		t.Fatalf("subscription not closed after policy expired")
+	}
+}
+
 func consumeSubscription(ctx context.Context, sub *Subscription) <-chan subNextResult {
 	eventCh := make(chan subNextResult, 1)
 	go func() {
@@ -630,3 +689,78 @@ func consumeSub(ctx context.Context, sub *Subscription) error {
 		}
 	}
 }
+
+func TestEventBroker_aclAllowsSubscription_Namespaces(t *testing.T) {
+	ci.Parallel(t)
+
+	policy, err := acl.Parse(mock.NamespacePolicy("prod-*", "", []string{acl.NamespaceCapabilityReadJob}))
+	require.NoError(t, err)
+	aclObj, err := acl.NewACL(false, []*acl.Policy{policy})
+	require.NoError(t, err)
+
+	req := &SubscribeRequest{
+		Topics:     map[structs.Topic][]string{structs.TopicJob: {"*"}},
+		Namespace:  structs.DefaultNamespace,
+		Namespaces: []string{"prod-api", "prod-*"},
+	}
+	require.True(t, aclAllowsSubscription(aclObj, req))
+
+	// Every namespace must be allowed
+	req.Namespaces = append(req.Namespaces, "dev")
+	require.False(t, aclAllowsSubscription(aclObj, req))
+
+	// Patterns broader than the policy aren't allowed
+	req.Namespaces = []string{"*"}
+	require.False(t, aclAllowsSubscription(aclObj, req))
+}
+
+func TestEventBroker_Subscribe_ResumeIndex(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	publisher, err := NewEventBroker(ctx, nil, EventBrokerCfg{EventBufferSize: 4})
+	require.NoError(t, err)
+
+	for i := 1; i <= 7; i++ {
+		publisher.eventBuf.Append(&structs.Events{Index: uint64(i), Events: []structs.Event{{
+			Index: uint64(i),
+			Topic: "Test",
+		}}})
+	}
+	require.Equal(t, uint64(7), publisher.LatestIndex())
+	require.Equal(t, uint64(2), publisher.LostIndex())
+
+	topics := map[structs.Topic][]string{"Test": {"*"}}
+
+	// The events following the resume index were dropped
+	_, err = publisher.Subscribe(&SubscribeRequest{Topics: topics, ResumeIndex: 1})
+	require.Equal(t, ErrResumeIndexLost, err)
+
+	// The subscription starts after the resume index
+	sub, err := publisher.Subscribe(&SubscribeRequest{Topics: topics, ResumeIndex: 5})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	eventCh := consumeSubscription(ctx, sub)
+	for i := 6; i <= 7; i++ {
+		result := nextResult(t, eventCh)
+		require.NoError(t, result.Err)
+		require.Equal(t, uint64(i), result.Events[0].Index)
+	}
+	assertNoResult(t, eventCh)
+
+	// A caught up subscription waits for the next events
+	caughtUp, err := publisher.Subscribe(&SubscribeRequest{Topics: topics, ResumeIndex: 7})
+	require.NoError(t, err)
+	defer caughtUp.Unsubscribe()
+	caughtUpCh := consumeSubscription(ctx, caughtUp)
+	assertNoResult(t, caughtUpCh)
+
+	publisher.Publish(&structs.Events{Index: 8, Events: []structs.Event{{Index: 8, Topic: "Test"}}})
+	for _, ch := range []<-chan subNextResult{eventCh, caughtUpCh} {
+		result := nextResult(t, ch)
+		require.NoError(t, result.Err)
+		require.Equal(t, uint64(8), result.Events[0].Index)
+	}
+}
@@ -45,6 +45,14 @@ import (
 type eventBuffer struct {
 	size *int64
 
+	// lost is the highest index whose events may no longer be in the buffer,
+	// either because they were dropped or because they were published before
+	// the first appended events. It must be accessed atomically.
+	lost *uint64
+
+	// appended is set once events have been appended to the buffer.
+	appended bool
+
 	head atomic.Value
 	tail atomic.Value
 
@@ -57,6 +65,7 @@ func newEventBuffer(size int64) *eventBuffer {
 	b := &eventBuffer{
 		maxSize: size,
 		size:    &zero,
+		lost:    new(uint64),
 	}
 
 	item := newBufferItem(&structs.Events{Index: 0, Events: nil})
@@ -77,6 +86,13 @@ func (b *eventBuffer) Append(events *structs.Events) {
 }
 
 func (b *eventBuffer) appendItem(item *bufferItem) {
+	// The events published before the first appended ones were never in
+	// the buffer
+	if !b.appended && item.Events != nil && item.Events.Index > 0 {
+		b.appended = true
+		atomic.StoreUint64(b.lost, item.Events.Index-1)
+	}
+
 	// Store the next item to the old tail
 	oldTail := b.Tail()
 	oldTail.link.next.Store(item)
@@ -115,6 +131,9 @@ func (b *eventBuffer) advanceHead() {
 
 	// notify readers that old is being dropped
 	close(old.link.droppedCh)
+	if old.Events != nil && old.Events.Index > atomic.LoadUint64(b.lost) {
+		atomic.StoreUint64(b.lost, old.Events.Index)
+	}
 
 	// store the next value to head
 	b.head.Store(next)
@@ -178,6 +197,12 @@ func (b *eventBuffer) StartAtClosest(index uint64) (*bufferItem, int) {
 	}
 }
 
+// LostIndex returns the highest index whose events may no longer be in the
+// buffer. Only the events with a higher index can be read from the buffer.
+func (b *eventBuffer) LostIndex() uint64 {
+	return atomic.LoadUint64(b.lost)
+}
+
 // Len returns the current length of the buffer
 func (b *eventBuffer) Len() int {
 	return int(atomic.LoadInt64(b.size))
@@ -246,3 +246,23 @@ func TestEventBuffer_StartAt_CurrentIdx_Past_Start(t *testing.T) {
 		})
 	}
 }
+
+func TestEventBuffer_LostIndex(t *testing.T) {
+	ci.Parallel(t)
+
+	b := newEventBuffer(5)
+	require.Zero(t, b.LostIndex())
+
+	// The events before the first appended ones were never in the buffer
+	for i := 11; i <= 15; i++ {
+		b.Append(&structs.Events{Index: uint64(i), Events: []structs.Event{{Index: uint64(i)}}})
+	}
+	require.Equal(t, uint64(10), b.LostIndex())
+
+	// Dropped events are lost
+	for i := 16; i <= 18; i++ {
+		b.Append(&structs.Events{Index: uint64(i), Events: []structs.Event{{Index: uint64(i)}}})
+	}
+	require.Equal(t, uint64(12), b.LostIndex())
+	require.Equal(t, uint64(13), b.Head().Events.Index)
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"sync/atomic"
 
+	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/ryanuber/go-glob"
 )
 
 const (
@@ -24,6 +26,10 @@ const (
 var ErrSubscriptionClosed = errors.New("subscription closed by server, client should resubscribe")
 var ErrACLInvalid = errors.New("Provided ACL token is invalid for requested topics")
 
+// ErrResumeIndexLost is returned when resuming a subscription after an index
+// whose following events are no longer in the event buffer.
+var ErrResumeIndexLost = errors.New("events following the resume index are no longer in the event buffer")
+
 type Subscription struct {
 	// state must be accessed atomically 0 means open, 1 means closed with reload
 	state uint32
@@ -52,11 +58,32 @@ type SubscribeRequest struct {
 
 	Topics map[structs.Topic][]string
 
+	// Namespaces are the namespaces to receive events for when subscribing
+	// to more than one namespace, overriding Namespace. Both Namespace and
+	// Namespaces may contain glob wildcards.
+	Namespaces []string
+
+	// JobIDs and TaskGroups restrict the events to those of the given jobs
+	// and task groups, if any. Events of objects that don't belong to a job
+	// or task group don't match.
+	JobIDs     []string
+	TaskGroups []string
+
+	// Filter is the filter expression events must match in addition to the
+	// topics and namespace, if any. Events whose payload doesn't have the
+	// fields selected by the expression don't match.
+	Filter *bexpr.Evaluator
+
 	// StartExactlyAtIndex specifies if a subscription needs to
 	// start exactly at the requested Index. If set to false,
 	// the closest index in the buffer will be returned if there is not
 	// an exact match
 	StartExactlyAtIndex bool
+
+	// ResumeIndex resumes a subscription after the given index, overriding
+	// Index. Only the events with a higher index are received, and an error
+	// is returned if some of them are no longer in the buffer.
+	ResumeIndex uint64
 }
 
 func newSubscription(req *SubscribeRequest, item *bufferItem, unsub func()) *Subscription {
@@ -83,7 +110,7 @@ func (s *Subscription) Next(ctx context.Context) (structs.Events, error) {
 		}
 		s.currentItem = next
 
-		events := filter(s.req, next.Events.Events)
+		events := filterExpr(s.req, filter(s.req, next.Events.Events))
 		if len(events) == 0 {
 			continue
 		}
@@ -103,7 +130,7 @@ func (s *Subscription) NextNoBlock() ([]structs.Event, error) {
 		}
 		s.currentItem = next
 
-		events := filter(s.req, next.Events.Events)
+		events := filterExpr(s.req, filter(s.req, next.Events.Events))
 		if len(events) == 0 {
 			continue
 		}
@@ -122,16 +149,22 @@ func filter(req *SubscribeRequest, events []structs.Event) []structs.Event {
 	}
 
 	allTopicKeys := req.Topics[structs.TopicAll]
+	namespaces := req.namespaces()
+	allNamespaces := len(namespaces) == 1 && namespaces[0] == "*"
+	filterObjects := len(req.JobIDs) > 0 || len(req.TaskGroups) > 0
 
 	// Return all events if subscribed to all namespaces and all topics
-	if req.Namespace == "*" && len(allTopicKeys) == 1 && allTopicKeys[0] == string(structs.TopicAll) {
+	if allNamespaces && !filterObjects && len(allTopicKeys) == 1 && allTopicKeys[0] == string(structs.TopicAll) {
 		return events
 	}
 
 	var result []structs.Event
 
 	for _, event := range events {
-		if req.Namespace != "*" && event.Namespace != "" && event.Namespace != req.Namespace {
+		if !allNamespaces && event.Namespace != "" && !matchesAny(namespaces, event.Namespace) {
+			continue
+		}
+		if filterObjects && !eventMatchesObjects(req, event) {
 			continue
 		}
 
@@ -163,6 +196,93 @@ func filter(req *SubscribeRequest, events []structs.Event) []structs.Event {
 	return result
 }
 
+// filterExpr filters events to only those that match the filter expression of
+// the subscription, if any. The events are copied so the slices shared by all
+// the subscriptions aren't modified.
+func filterExpr(req *SubscribeRequest, events []structs.Event) []structs.Event {
+	if req.Filter == nil || len(events) == 0 {
+		return events
+	}
+
+	var result []structs.Event
+	for _, event := range events {
+		// Evaluation errors are caused by payloads missing the selected
+		// fields, such as events of other topics
+		if match, err := req.Filter.Evaluate(event); err == nil && match {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// namespaces returns the namespace patterns the subscription receives events
+// for.
+func (req *SubscribeRequest) namespaces() []string {
+	if len(req.Namespaces) > 0 {
+		return req.Namespaces
+	}
+	return []string{req.Namespace}
+}
+
+// matchesAny returns whether the value matches any of the glob patterns.
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if glob.Glob(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// eventMatchesObjects returns whether the event belongs to one of the jobs
+// and one of the task groups of the subscription. The job and task groups are
+// read from the event payload.
+func eventMatchesObjects(req *SubscribeRequest, event structs.Event) bool {
+	var jobID string
+	var groups []string
+	switch p := event.Payload.(type) {
+	case *structs.JobEvent:
+		jobID = p.Job.ID
+		for _, tg := range p.Job.TaskGroups {
+			groups = append(groups, tg.Name)
+		}
+	case *structs.AllocationEvent:
+		jobID = p.Allocation.JobID
+		groups = []string{p.Allocation.TaskGroup}
+	case *structs.EvaluationEvent:
+		jobID = p.Evaluation.JobID
+	case *structs.DeploymentEvent:
+		jobID = p.Deployment.JobID
+		for name := range p.Deployment.TaskGroups {
+			groups = append(groups, name)
+		}
+	default:
+		return false
+	}
+
+	if len(req.JobIDs) > 0 && !containsString(req.JobIDs, jobID) {
+		return false
+	}
+	if len(req.TaskGroups) > 0 {
+		for _, group := range groups {
+			if containsString(req.TaskGroups, group) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func eventMatchesKey(event structs.Event, key string) bool {
 	if event.Key == key {
 		return true
@@ -3,6 +3,7 @@ package stream
 import (
 	"testing"
 
+	"github.com/hashicorp/go-bexpr"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/stretchr/testify/require"
@@ -208,3 +209,105 @@ func TestFilter_FilterKeys(t *testing.T) {
 
 	require.Equal(t, 1, cap(actual))
 }
+
+func TestFilter_FilterExpr(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := &structs.AllocationEvent{Allocation: &structs.Allocation{ID: "a1", JobID: "api"}}
+	other := &structs.AllocationEvent{Allocation: &structs.Allocation{ID: "a2", JobID: "web"}}
+	job := &structs.JobEvent{Job: &structs.Job{ID: "api"}}
+	events := []structs.Event{
+		{Topic: structs.TopicAllocation, Key: "a1", Payload: alloc},
+		{Topic: structs.TopicAllocation, Key: "a2", Payload: other},
+		{Topic: structs.TopicJob, Key: "api", Payload: job},
+	}
+
+	evaluator, err := bexpr.CreateEvaluator(`Topic == "Allocation" and Payload.Allocation.JobID == "api"`)
+	require.NoError(t, err)
+	req := &SubscribeRequest{
+		Namespace: "*",
+		Topics: map[structs.Topic][]string{
+			"*": {"*"},
+		},
+		Filter: evaluator,
+	}
+	actual := filterExpr(req, filter(req, events))
+	require.Equal(t, events[:1], actual)
+
+	// Events without the selected fields don't match
+	evaluator, err = bexpr.CreateEvaluator(`Payload.Job.ID == "api"`)
+	require.NoError(t, err)
+	req.Filter = evaluator
+	actual = filterExpr(req, filter(req, events))
+	require.Equal(t, events[2:], actual)
+
+	// The shared events aren't modified
+	require.Len(t, events, 3)
+	require.Equal(t, "a1", events[0].Key)
+}
+
+func TestFilter_NamespaceGlob(t *testing.T) {
+	ci.Parallel(t)
+
+	events := []structs.Event{
+		{Topic: "Test", Key: "One", Namespace: "prod-api"},
+		{Topic: "Test", Key: "Two", Namespace: "dev"},
+		{Topic: "Test", Key: "Three", Namespace: "staging"},
+		{Topic: "Test", Key: "Four"},
+	}
+
+	req := &SubscribeRequest{
+		Topics: map[structs.Topic][]string{
+			"*": {"*"},
+		},
+		Namespace:  "default",
+		Namespaces: []string{"prod-*", "staging"},
+	}
+	actual := filter(req, events)
+	expected := []structs.Event{
+		{Topic: "Test", Key: "One", Namespace: "prod-api"},
+		{Topic: "Test", Key: "Three", Namespace: "staging"},
+		{Topic: "Test", Key: "Four"},
+	}
+	require.Equal(t, expected, actual)
+}
+
+func TestFilter_JobsAndTaskGroups(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &structs.Job{ID: "api", TaskGroups: []*structs.TaskGroup{{Name: "web"}, {Name: "cache"}}}
+	events := []structs.Event{
+		{Topic: structs.TopicJob, Key: "api", Payload: &structs.JobEvent{Job: job}},
+		{Topic: structs.TopicAllocation, Key: "a1", Payload: &structs.AllocationEvent{
+			Allocation: &structs.Allocation{ID: "a1", JobID: "api", TaskGroup: "web"}}},
+		{Topic: structs.TopicAllocation, Key: "a2", Payload: &structs.AllocationEvent{
+			Allocation: &structs.Allocation{ID: "a2", JobID: "api", TaskGroup: "cache"}}},
+		{Topic: structs.TopicAllocation, Key: "a3", Payload: &structs.AllocationEvent{
+			Allocation: &structs.Allocation{ID: "a3", JobID: "other", TaskGroup: "web"}}},
+		{Topic: structs.TopicEvaluation, Key: "e1", Payload: &structs.EvaluationEvent{
+			Evaluation: &structs.Evaluation{ID: "e1", JobID: "api"}}},
+		{Topic: structs.TopicDeployment, Key: "d1", Payload: &structs.DeploymentEvent{
+			Deployment: &structs.Deployment{ID: "d1", JobID: "api",
+				TaskGroups: map[string]*structs.DeploymentState{"web": {}}}}},
+		{Topic: structs.TopicNode, Key: "n1", Payload: &structs.NodeStreamEvent{Node: &structs.Node{ID: "n1"}}},
+	}
+
+	req := &SubscribeRequest{
+		Topics: map[structs.Topic][]string{
+			"*": {"*"},
+		},
+		Namespace: "*",
+		JobIDs:    []string{"api"},
+	}
+	actual := filter(req, events)
+	require.Equal(t, []structs.Event{events[0], events[1], events[2], events[4], events[5]}, actual)
+
+	// Evaluations don't belong to a task group
+	req.TaskGroups = []string{"web"}
+	actual = filter(req, events)
+	require.Equal(t, []structs.Event{events[0], events[1], events[5]}, actual)
+
+	req.JobIDs = nil
+	actual = filter(req, events)
+	require.Equal(t, []structs.Event{events[0], events[1], events[3], events[5]}, actual)
+}
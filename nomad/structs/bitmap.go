@@ -63,6 +63,13 @@ func (b Bitmap) Clear() {
 	}
 }
 
+// Or sets the indexes set in other, which must have the same size
+func (b Bitmap) Or(other Bitmap) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
 // IndexesInRange returns the indexes in which the values are either set or unset based
 // on the passed parameter in the passed range
 func (b Bitmap) IndexesInRange(set bool, from, to uint) []int {
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/hashicorp/nomad/helper"
@@ -79,6 +81,64 @@ type VaultConfig struct {
 
 	// TLSServerName, if set, is used to set the SNI host when connecting via TLS.
 	TLSServerName string `hcl:"tls_server_name"`
+
+	// Clusters are the named Vault clusters tasks can request tokens from in
+	// addition to the default cluster configured above.
+	Clusters []*VaultClusterConfig `hcl:"cluster"`
+}
+
+// VaultClusterConfig is the configuration of a named Vault cluster. The
+// cluster is enabled unless its configuration disables it.
+type VaultClusterConfig struct {
+	// Name is the name of the cluster tasks request tokens from
+	Name string `hcl:",key"`
+
+	VaultConfig `hcl:",squash"`
+}
+
+// validVaultClusterName matches the valid names of Vault clusters.
+var validVaultClusterName = regexp.MustCompile("^[a-zA-Z0-9_-]+$")
+
+// ValidateClusters returns an error if the configured Vault clusters are
+// invalid.
+func (c *VaultConfig) ValidateClusters() error {
+	names := make(map[string]struct{}, len(c.Clusters))
+	for _, cluster := range c.Clusters {
+		if !validVaultClusterName.MatchString(cluster.Name) {
+			return fmt.Errorf("vault cluster %q: name must only contain alphanumeric, dash and underscore characters", cluster.Name)
+		}
+		if _, ok := names[cluster.Name]; ok {
+			return fmt.Errorf("vault cluster %q is defined more than once", cluster.Name)
+		}
+		names[cluster.Name] = struct{}{}
+		if len(cluster.Clusters) != 0 {
+			return fmt.Errorf("vault cluster %q: clusters can't be nested", cluster.Name)
+		}
+	}
+	return nil
+}
+
+// Copy returns a copy of this Vault cluster config.
+func (c *VaultClusterConfig) Copy() *VaultClusterConfig {
+	if c == nil {
+		return nil
+	}
+
+	return &VaultClusterConfig{
+		Name:        c.Name,
+		VaultConfig: *c.VaultConfig.Copy(),
+	}
+}
+
+// Config returns the Vault configuration of the cluster, with the defaults
+// applied.
+func (c *VaultClusterConfig) Config() *VaultConfig {
+	conf := DefaultVaultConfig().Merge(&c.VaultConfig)
+	if conf.Enabled == nil {
+		conf.Enabled = helper.BoolToPtr(true)
+	}
+	conf.Clusters = nil
+	return conf
 }
 
 // DefaultVaultConfig returns the canonical defaults for the Nomad
@@ -149,9 +209,38 @@ func (c *VaultConfig) Merge(b *VaultConfig) *VaultConfig {
 		result.Enabled = b.Enabled
 	}
 
+	// Merge the clusters by name
+	if len(b.Clusters) != 0 {
+		result.Clusters = make([]*VaultClusterConfig, 0, len(c.Clusters)+len(b.Clusters))
+		merged := make(map[string]*VaultClusterConfig, len(c.Clusters))
+		for _, cluster := range c.Clusters {
+			cluster = cluster.Copy()
+			merged[cluster.Name] = cluster
+			result.Clusters = append(result.Clusters, cluster)
+		}
+		for _, cluster := range b.Clusters {
+			if existing, ok := merged[cluster.Name]; ok {
+				existing.VaultConfig = *existing.VaultConfig.Merge(&cluster.VaultConfig)
+				continue
+			}
+			result.Clusters = append(result.Clusters, cluster.Copy())
+		}
+	}
+
 	return &result
 }
 
+// Cluster returns the configuration of the named Vault cluster, or nil if it
+// isn't configured.
+func (c *VaultConfig) Cluster(name string) *VaultClusterConfig {
+	for _, cluster := range c.Clusters {
+		if cluster.Name == name {
+			return cluster
+		}
+	}
+	return nil
+}
+
 // ApiConfig returns a usable Vault config that can be passed directly to
 // hashicorp/vault/api.
 func (c *VaultConfig) ApiConfig() (*vault.Config, error) {
@@ -185,6 +274,12 @@ func (c *VaultConfig) Copy() *VaultConfig {
 
 	nc := new(VaultConfig)
 	*nc = *c
+	if c.Clusters != nil {
+		nc.Clusters = make([]*VaultClusterConfig, len(c.Clusters))
+		for i, cluster := range c.Clusters {
+			nc.Clusters[i] = cluster.Copy()
+		}
+	}
 	return nc
 }
 
@@ -237,5 +332,13 @@ func (c *VaultConfig) IsEqual(b *VaultConfig) bool {
 	if c.Enabled != b.Enabled {
 		return false
 	}
+	if len(c.Clusters) != len(b.Clusters) {
+		return false
+	}
+	for i, cluster := range c.Clusters {
+		if cluster.Name != b.Clusters[i].Name || !cluster.VaultConfig.IsEqual(&b.Clusters[i].VaultConfig) {
+			return false
+		}
+	}
 	return true
 }
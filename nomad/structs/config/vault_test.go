@@ -132,3 +132,66 @@ func TestVaultConfig_IsEqual(t *testing.T) {
 	}
 	require.False(c3.IsEqual(c4))
 }
+
+func TestVaultConfig_Merge_Clusters(t *testing.T) {
+	ci.Parallel(t)
+
+	c1 := &VaultConfig{
+		Clusters: []*VaultClusterConfig{
+			{Name: "team", VaultConfig: VaultConfig{Addr: "1", Token: "1"}},
+			{Name: "audit", VaultConfig: VaultConfig{Addr: "1"}},
+		},
+	}
+	c2 := &VaultConfig{
+		Clusters: []*VaultClusterConfig{
+			{Name: "team", VaultConfig: VaultConfig{Token: "2"}},
+			{Name: "other", VaultConfig: VaultConfig{Addr: "2"}},
+		},
+	}
+
+	result := c1.Merge(c2)
+	require.Equal(t, []*VaultClusterConfig{
+		{Name: "team", VaultConfig: VaultConfig{Addr: "1", Token: "2"}},
+		{Name: "audit", VaultConfig: VaultConfig{Addr: "1"}},
+		{Name: "other", VaultConfig: VaultConfig{Addr: "2"}},
+	}, result.Clusters)
+
+	// The merged clusters must not be shared with the merged configs
+	require.Equal(t, "1", c1.Clusters[0].Token)
+}
+
+func TestVaultConfig_ValidateClusters(t *testing.T) {
+	ci.Parallel(t)
+
+	valid := &VaultConfig{
+		Clusters: []*VaultClusterConfig{{Name: "team"}, {Name: "audit_2"}},
+	}
+	require.NoError(t, valid.ValidateClusters())
+
+	duplicate := &VaultConfig{
+		Clusters: []*VaultClusterConfig{{Name: "team"}, {Name: "team"}},
+	}
+	require.Error(t, duplicate.ValidateClusters())
+
+	invalid := &VaultConfig{
+		Clusters: []*VaultClusterConfig{{Name: "team vault"}},
+	}
+	require.Error(t, invalid.ValidateClusters())
+}
+
+func TestVaultClusterConfig_Config(t *testing.T) {
+	ci.Parallel(t)
+
+	cluster := &VaultClusterConfig{
+		Name:        "team",
+		VaultConfig: VaultConfig{Addr: "https://team-vault:8200"},
+	}
+	conf := cluster.Config()
+	require.True(t, conf.IsEnabled())
+	require.Equal(t, "https://team-vault:8200", conf.Addr)
+	require.Equal(t, DefaultVaultConnectRetryIntv, conf.ConnectionRetryIntv)
+
+	falseValue := false
+	cluster.Enabled = &falseValue
+	require.False(t, cluster.Config().IsEnabled())
+}
@@ -5,6 +5,7 @@ package structs
 type ConsulConfigEntries struct {
 	Ingress     map[string]*ConsulIngressConfigEntry
 	Terminating map[string]*ConsulTerminatingConfigEntry
+	APIGateway  map[string]*ConsulAPIGatewayConfigEntry
 }
 
 // ConfigEntries accumulates the Consul Configuration Entries defined in task groups
@@ -20,6 +21,7 @@ func (j *Job) ConfigEntries() map[string]*ConsulConfigEntries {
 			collection[ns] = &ConsulConfigEntries{
 				Ingress:     make(map[string]*ConsulIngressConfigEntry),
 				Terminating: make(map[string]*ConsulTerminatingConfigEntry),
+				APIGateway:  make(map[string]*ConsulAPIGatewayConfigEntry),
 			}
 		}
 
@@ -30,6 +32,8 @@ func (j *Job) ConfigEntries() map[string]*ConsulConfigEntries {
 					collection[ns].Ingress[service.Name] = ig
 				} else if term := gateway.Terminating; term != nil {
 					collection[ns].Terminating[service.Name] = term
+				} else if apigw := gateway.APIGateway; apigw != nil {
+					collection[ns].APIGateway[service.Name] = apigw
 				}
 			}
 		}
@@ -58,28 +58,34 @@ func (cu *ConsulUsage) Used() bool {
 // a boolean indicating if Consul KV is in use.
 func (j *Job) ConsulUsages() map[string]*ConsulUsage {
 	m := make(map[string]*ConsulUsage)
+	usage := func(namespace string) *ConsulUsage {
+		if _, exists := m[namespace]; !exists {
+			m[namespace] = new(ConsulUsage)
+		}
+		return m[namespace]
+	}
 
 	for _, tg := range j.TaskGroups {
 		namespace := j.ConsulNamespace
 		if tgNamespace := tg.Consul.GetNamespace(); tgNamespace != "" {
 			namespace = tgNamespace
 		}
-		if _, exists := m[namespace]; !exists {
-			m[namespace] = new(ConsulUsage)
-		}
+		usage(namespace)
 
 		// Gather group services
 		for _, service := range tg.Services {
-			m[namespace].Services = append(m[namespace].Services, service.Name)
+			u := usage(service.ConsulNamespace(namespace))
+			u.Services = append(u.Services, service.Name)
 		}
 
 		// Gather task services and KV usage
 		for _, task := range tg.Tasks {
 			for _, service := range task.Services {
-				m[namespace].Services = append(m[namespace].Services, service.Name)
+				u := usage(service.ConsulNamespace(namespace))
+				u.Services = append(u.Services, service.Name)
 			}
 			if len(task.Templates) > 0 {
-				m[namespace].KV = true
+				usage(namespace).KV = true
 			}
 		}
 	}
@@ -25,6 +25,12 @@ func TestJob_ConfigEntries(t *testing.T) {
 		},
 	}
 
+	apiGateway := &ConsulConnect{
+		Gateway: &ConsulGateway{
+			APIGateway: new(ConsulAPIGatewayConfigEntry),
+		},
+	}
+
 	j := &Job{
 		TaskGroups: []*TaskGroup{{
 			Name:   "group1",
@@ -69,6 +75,9 @@ func TestJob_ConfigEntries(t *testing.T) {
 			Services: []*Service{{
 				Name:    "group5-service1",
 				Connect: ingress,
+			}, {
+				Name:    "group5-service2",
+				Connect: apiGateway,
 			}},
 		}},
 	}
@@ -87,6 +96,9 @@ func TestJob_ConfigEntries(t *testing.T) {
 				"group1-service3": new(ConsulTerminatingConfigEntry),
 				"group4-service2": new(ConsulTerminatingConfigEntry),
 			},
+			APIGateway: map[string]*ConsulAPIGatewayConfigEntry{
+				"group5-service2": new(ConsulAPIGatewayConfigEntry),
+			},
 		},
 	}
 
@@ -60,3 +60,38 @@ func TestConsul_Validate(t *testing.T) {
 		require.Nil(t, result)
 	})
 }
+
+func TestJob_ConsulUsages_serviceNamespace(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &Job{
+		TaskGroups: []*TaskGroup{{
+			Name: "group",
+			Services: []*Service{
+				{Name: "group-service"},
+				{Name: "group-service-default", Namespace: "default"},
+				{Name: "group-service-ns", Namespace: "team-a"},
+			},
+			Tasks: []*Task{{
+				Name: "task",
+				Services: []*Service{
+					{Name: "task-service-ns", Namespace: "team-b"},
+				},
+				Templates: []*Template{{}},
+			}},
+		}},
+	}
+
+	require.Equal(t, map[string]*ConsulUsage{
+		"": {
+			Services: []string{"group-service", "group-service-default"},
+			KV:       true,
+		},
+		"team-a": {
+			Services: []string{"group-service-ns"},
+		},
+		"team-b": {
+			Services: []string{"task-service-ns"},
+		},
+	}, job.ConsulUsages())
+}
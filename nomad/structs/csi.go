@@ -1054,7 +1054,12 @@ type CSISnapshotDeleteResponse struct {
 // fields
 type CSISnapshotListRequest struct {
 	PluginID string
-	Secrets  CSISecrets
+
+	// VolumeID filters the snapshots by their source volume. It can be either
+	// the ID of a volume registered with Nomad or the storage provider's ID.
+	VolumeID string
+
+	Secrets CSISecrets
 	QueryOptions
 }
 
@@ -1,5 +1,17 @@
 package structs
 
+const (
+	// DeviceTypeMIG is the device type of GPU MIG (Multi-Instance GPU)
+	// slices, which device plugins fingerprint as a device group per MIG
+	// profile.
+	DeviceTypeMIG = "mig"
+
+	// DeviceAttrMIGComputeSlices and DeviceAttrMIGMemory are the attributes
+	// giving the size of the MIG slices of a device group.
+	DeviceAttrMIGComputeSlices = "compute_slices"
+	DeviceAttrMIGMemory        = "memory"
+)
+
 // DeviceAccounter is used to account for device usage on a node. It can detect
 // when a node is oversubscribed and can be used for deciding what devices are
 // free
@@ -69,7 +69,8 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 	diff := &JobDiff{Type: DiffTypeNone}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
 	filter := []string{"ID", "Status", "StatusDescription", "Version", "Stable", "CreateIndex",
-		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "NomadTokenID"}
+		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "NomadTokenID",
+		"RegisterIdempotencyToken", "VersionTag", "BundleID"}
 
 	if j == nil && other == nil {
 		return diff, nil
@@ -101,6 +102,11 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, setDiff)
 	}
 
+	// DependsOn diff
+	if setDiff := stringSetDiff(j.DependsOn, other.DependsOn, "DependsOn", contextual); setDiff != nil && setDiff.Type != DiffTypeNone {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
 	// Constraints diff
 	conDiff := primitiveObjectSetDiff(
 		interfaceSlice(j.Constraints),
@@ -502,6 +508,12 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 		diff.Objects = append(diff.Objects, dDiff)
 	}
 
+	// Runtime env diff
+	reDiff := primitiveObjectDiff(t.RuntimeEnv, other.RuntimeEnv, nil, "RuntimeEnv", contextual)
+	if reDiff != nil {
+		diff.Objects = append(diff.Objects, reDiff)
+	}
+
 	// Artifacts diff
 	diffs := primitiveObjectSetDiff(
 		interfaceSlice(t.Artifacts),
@@ -524,6 +536,11 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 		diff.Objects = append(diff.Objects, vDiff)
 	}
 
+	// Identities diff
+	if idDiffs := workloadIdentityDiffs(t.Identities, other.Identities, contextual); idDiffs != nil {
+		diff.Objects = append(diff.Objects, idDiffs...)
+	}
+
 	// Template diff
 	tmplDiffs := templateDiffs(t.Templates, other.Templates, contextual)
 	if tmplDiffs != nil {
@@ -640,6 +657,11 @@ func serviceDiff(old, new *Service, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, conDiffs)
 	}
 
+	// Weights diffs
+	if wDiff := primitiveObjectDiff(old.Weights, new.Weights, nil, "Weights", contextual); wDiff != nil {
+		diff.Objects = append(diff.Objects, wDiff)
+	}
+
 	return diff
 }
 
@@ -904,6 +926,11 @@ func connectDiffs(old, new *ConsulConnect, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, gatewayDiff)
 	}
 
+	// Diff the object field Certs.
+	if certsDiff := primitiveObjectDiff(old.Certs, new.Certs, nil, "Certs", contextual); certsDiff != nil {
+		diff.Objects = append(diff.Objects, certsDiff)
+	}
+
 	return diff
 }
 
@@ -954,6 +981,133 @@ func connectGatewayDiff(prev, next *ConsulGateway, contextual bool) *ObjectDiff
 		diff.Objects = append(diff.Objects, gatewayMeshDiff)
 	}
 
+	// Diff the API gateway fields.
+	gatewayAPIGatewayDiff := connectGatewayAPIGatewayDiff(prev.APIGateway, next.APIGateway, contextual)
+	if gatewayAPIGatewayDiff != nil {
+		diff.Objects = append(diff.Objects, gatewayAPIGatewayDiff)
+	}
+
+	return diff
+}
+
+func connectGatewayAPIGatewayDiff(prev, next *ConsulAPIGatewayConfigEntry, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "APIGateway"}
+
+	if reflect.DeepEqual(prev, next) {
+		return nil
+	} else if prev == nil {
+		prev = new(ConsulAPIGatewayConfigEntry)
+		diff.Type = DiffTypeAdded
+	} else if next == nil {
+		next = new(ConsulAPIGatewayConfigEntry)
+		diff.Type = DiffTypeDeleted
+	} else {
+		diff.Type = DiffTypeEdited
+	}
+
+	// Diff the Listeners lists.
+	if diffs := connectGatewayAPIGatewayListenersDiff(prev.Listeners, next.Listeners, contextual); diffs != nil {
+		diff.Objects = append(diff.Objects, diffs...)
+	}
+
+	return diff
+}
+
+// connectGatewayAPIGatewayListenersDiff diffs are a set of listeners keyed by
+// their name, which must be unique within an API gateway.
+func connectGatewayAPIGatewayListenersDiff(prev, next []*ConsulAPIGatewayListener, contextual bool) []*ObjectDiff {
+	prevMap := make(map[string]*ConsulAPIGatewayListener, len(prev))
+	nextMap := make(map[string]*ConsulAPIGatewayListener, len(next))
+
+	for _, l := range prev {
+		prevMap[l.Name] = l
+	}
+	for _, l := range next {
+		nextMap[l.Name] = l
+	}
+
+	var diffs []*ObjectDiff
+	for k, prevL := range prevMap {
+		// Diff the same, deleted, and edited
+		if diff := connectGatewayAPIGatewayListenerDiff(prevL, nextMap[k], contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	for k, nextL := range nextMap {
+		// Diff the added
+		if old, ok := prevMap[k]; !ok {
+			if diff := connectGatewayAPIGatewayListenerDiff(old, nextL, contextual); diff != nil {
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
+func connectGatewayAPIGatewayListenerDiff(prev, next *ConsulAPIGatewayListener, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Listener"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(prev, next) {
+		return nil
+	} else if prev == nil {
+		prev = new(ConsulAPIGatewayListener)
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(next, nil, true)
+	} else if next == nil {
+		next = new(ConsulAPIGatewayListener)
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(prev, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(prev, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(next, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Diff the TLS objects.
+	if tlsDiff := connectGatewayAPIGatewayTLSConfigDiff(prev.TLS, next.TLS, contextual); tlsDiff != nil {
+		diff.Objects = append(diff.Objects, tlsDiff)
+	}
+
+	return diff
+}
+
+func connectGatewayAPIGatewayTLSConfigDiff(prev, next *ConsulAPIGatewayTLSConfig, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "TLS"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(prev, next) {
+		return nil
+	} else if prev == nil {
+		prev = new(ConsulAPIGatewayTLSConfig)
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(next, nil, true)
+	} else if next == nil {
+		next = new(ConsulAPIGatewayTLSConfig)
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(prev, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(prev, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(next, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Diff the certificates and cipher suites.
+	if setDiff := stringSetDiff(prev.Certificates, next.Certificates, "Certificates", contextual); setDiff != nil {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+	if setDiff := stringSetDiff(prev.CipherSuites, next.CipherSuites, "CipherSuites", contextual); setDiff != nil {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
 	return diff
 }
 
@@ -1599,6 +1753,135 @@ func vaultDiff(old, new *Vault, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, setDiff)
 	}
 
+	// Clusters diffs
+	diff.Objects = append(diff.Objects, vaultClusterDiffs(old.Clusters, new.Clusters, contextual)...)
+
+	return diff
+}
+
+// vaultClusterDiffs diffs a set of Vault cluster blocks, matched by name. If
+// contextual diff is enabled, unchanged fields within objects nested in the
+// clusters will be returned.
+func vaultClusterDiffs(old, new []*VaultCluster, contextual bool) []*ObjectDiff {
+	oldMap := make(map[string]*VaultCluster, len(old))
+	newMap := make(map[string]*VaultCluster, len(new))
+	for _, c := range old {
+		oldMap[c.Name] = c
+	}
+	for _, c := range new {
+		newMap[c.Name] = c
+	}
+
+	var diffs []*ObjectDiff
+	for name, oldCluster := range oldMap {
+		if diff := vaultClusterDiff(oldCluster, newMap[name], contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	for name, newCluster := range newMap {
+		if _, ok := oldMap[name]; ok {
+			continue
+		}
+		if diff := vaultClusterDiff(nil, newCluster, contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
+// vaultClusterDiff returns the diff of two Vault cluster blocks. If contextual
+// diff is enabled, all fields will be returned, even if no diff occurred.
+func vaultClusterDiff(old, new *VaultCluster, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Cluster"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &VaultCluster{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		new = &VaultCluster{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	if setDiff := stringSetDiff(old.Policies, new.Policies, "Policies", contextual); setDiff != nil {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
+	return diff
+}
+
+// workloadIdentityDiffs diffs a set of workload identity blocks, matched by
+// name.
+func workloadIdentityDiffs(old, new []*WorkloadIdentity, contextual bool) []*ObjectDiff {
+	oldMap := make(map[string]*WorkloadIdentity, len(old))
+	newMap := make(map[string]*WorkloadIdentity, len(new))
+	for _, w := range old {
+		oldMap[w.Name] = w
+	}
+	for _, w := range new {
+		newMap[w.Name] = w
+	}
+
+	var diffs []*ObjectDiff
+	for name, oldIdentity := range oldMap {
+		if diff := workloadIdentityDiff(oldIdentity, newMap[name], contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+	for name, newIdentity := range newMap {
+		if _, ok := oldMap[name]; ok {
+			continue
+		}
+		if diff := workloadIdentityDiff(nil, newIdentity, contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
+// workloadIdentityDiff returns the diff of two workload identity blocks. If
+// contextual diff is enabled, all fields will be returned, even if no diff
+// occurred.
+func workloadIdentityDiff(old, new *WorkloadIdentity, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Identity"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &WorkloadIdentity{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		new = &WorkloadIdentity{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	if setDiff := stringSetDiff(old.Audience, new.Audience, "Audience", contextual); setDiff != nil {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
 	return diff
 }
 
@@ -1658,6 +1941,44 @@ func templateDiff(old, new *Template, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, waitDiffs)
 	}
 
+	// ChangeScript diffs
+	if scriptDiffs := changeScriptDiff(old.ChangeScript, new.ChangeScript, contextual); scriptDiffs != nil {
+		diff.Objects = append(diff.Objects, scriptDiffs)
+	}
+
+	return diff
+}
+
+// changeScriptDiff returns the diff of two ChangeScript objects. If contextual
+// diff is enabled, all fields will be returned, even if no diff occurred.
+func changeScriptDiff(old, new *ChangeScript, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "ChangeScript"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &ChangeScript{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		new = &ChangeScript{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Args diffs
+	if setDiff := stringSetDiff(old.Args, new.Args, "Args", contextual); setDiff != nil {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
 	return diff
 }
 
@@ -1958,6 +2279,10 @@ func volumeDiff(oldVR, newVR *VolumeRequest, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, mOptsDiff)
 	}
 
+	if setDiff := stringSetDiff(oldVR.AlternativeSources, newVR.AlternativeSources, "AlternativeSources", contextual); setDiff != nil {
+		diff.Objects = append(diff.Objects, setDiff)
+	}
+
 	return diff
 }
 
@@ -2030,6 +2355,11 @@ func (r *Resources) Diff(other *Resources, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, nDiffs...)
 	}
 
+	// NUMA diff
+	if nDiff := primitiveObjectDiff(r.NUMA, other.NUMA, nil, "NUMA", contextual); nDiff != nil {
+		diff.Objects = append(diff.Objects, nDiff)
+	}
+
 	return diff
 }
 
@@ -2073,6 +2403,47 @@ func (n *NetworkResource) Diff(other *NetworkResource, contextual bool) *ObjectD
 		diff.Objects = append(diff.Objects, dnsDiff)
 	}
 
+	if firewallDiff := n.Firewall.Diff(other.Firewall, contextual); firewallDiff != nil {
+		diff.Objects = append(diff.Objects, firewallDiff)
+	}
+
+	return diff
+}
+
+// Diff returns a diff of two NetworkFirewall structs
+func (f *NetworkFirewall) Diff(other *NetworkFirewall, contextual bool) *ObjectDiff {
+	if reflect.DeepEqual(f, other) {
+		return nil
+	}
+
+	flatten := func(fw *NetworkFirewall) map[string]string {
+		m := map[string]string{
+			"AllowClientAPI": fmt.Sprintf("%t", fw.AllowClientAPI),
+			"AllowMetadata":  fmt.Sprintf("%t", fw.AllowMetadata),
+		}
+		if len(fw.AllowCIDRs) > 0 {
+			m["AllowCIDRs"] = strings.Join(fw.AllowCIDRs, ",")
+		}
+		return m
+	}
+
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Firewall"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+	if f == nil {
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatten(other)
+	} else if other == nil {
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatten(f)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatten(f)
+		newPrimitiveFlat = flatten(other)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
 	return diff
 }
 
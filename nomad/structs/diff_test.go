@@ -902,6 +902,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "",
 								New:  "1",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Priority",
+								Old:  "",
+								New:  "0",
+							},
 						},
 					},
 					{
@@ -930,6 +936,12 @@ func TestJobDiff(t *testing.T) {
 								Old:  "1",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Priority",
+								Old:  "0",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -1979,12 +1991,24 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "15000000000",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Jitter",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "MaxDelay",
 								Old:  "",
 								New:  "20000000000",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxLifetime",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Unlimited",
@@ -2040,12 +2064,24 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "15000000000",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Jitter",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "MaxDelay",
 								Old:  "20000000000",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxLifetime",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Unlimited",
@@ -2077,6 +2113,8 @@ func TestTaskGroupDiff(t *testing.T) {
 					Delay:         30 * time.Second,
 					MaxDelay:      1 * time.Minute,
 					Unlimited:     true,
+					Jitter:        0.25,
+					MaxLifetime:   1 * time.Hour,
 				},
 			},
 			Expected: &TaskGroupDiff{
@@ -2110,6 +2148,18 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "1000000000",
 								New:  "2000000000",
 							},
+							{
+								Type: DiffTypeEdited,
+								Name: "Jitter",
+								Old:  "0",
+								New:  "0.25",
+							},
+							{
+								Type: DiffTypeEdited,
+								Name: "MaxLifetime",
+								Old:  "0",
+								New:  "3600000000000",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Unlimited",
@@ -2167,12 +2217,24 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "1000000000",
 								New:  "2000000000",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Jitter",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "MaxDelay",
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "MaxLifetime",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Unlimited",
@@ -2211,6 +2273,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "true",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "BlueGreen",
+								Old:  "false",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Canary",
@@ -2241,6 +2309,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "0",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "SLOInterval",
+								Old:  "0",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -2273,6 +2347,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "BlueGreen",
+								Old:  "",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Canary",
@@ -2303,6 +2383,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "SLOInterval",
+								Old:  "",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -2440,6 +2526,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "true",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "BlueGreen",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Canary",
@@ -2476,6 +2568,18 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "30000000000",
 								New:  "30000000000",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "SLOInterval",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "SLOQuery",
+								Old:  "",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -2853,6 +2957,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "false",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "ExplicitNamespace",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Name",
@@ -2871,6 +2981,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Partition",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "PortLabel",
@@ -2955,12 +3071,24 @@ func TestTaskGroupDiff(t *testing.T) {
 										Old:  "foo",
 										New:  "foo",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "Namespace",
+										Old:  "",
+										New:  "",
+									},
 									{
 										Type: DiffTypeNone,
 										Name: "OnUpdate",
 										Old:  "",
 										New:  "",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "Partition",
+										Old:  "",
+										New:  "",
+									},
 									{
 										Type: DiffTypeEdited,
 										Name: "Path",
@@ -2985,6 +3113,30 @@ func TestTaskGroupDiff(t *testing.T) {
 										Old:  "3",
 										New:  "5",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "TLSCACert",
+										Old:  "",
+										New:  "",
+									},
+									{
+										Type: DiffTypeNone,
+										Name: "TLSClientCert",
+										Old:  "",
+										New:  "",
+									},
+									{
+										Type: DiffTypeNone,
+										Name: "TLSClientKey",
+										Old:  "",
+										New:  "",
+									},
+									{
+										Type: DiffTypeNone,
+										Name: "TLSServerName",
+										Old:  "",
+										New:  "",
+									},
 									{
 										Type: DiffTypeNone,
 										Name: "TLSSkipVerify",
@@ -3156,6 +3308,12 @@ func TestTaskGroupDiff(t *testing.T) {
 												Old:  "sidecar",
 												New:  "",
 											},
+											{
+												Type: DiffTypeDeleted,
+												Name: "ScaleResources",
+												Old:  "false",
+												New:  "",
+											},
 										},
 										Objects: []*ObjectDiff{
 											{
@@ -3459,6 +3617,10 @@ func TestTaskGroupDiff(t *testing.T) {
 						DNS: &DNSConfig{
 							Servers: []string{"1.1.1.1"},
 						},
+						Firewall: &NetworkFirewall{
+							AllowMetadata: true,
+							AllowCIDRs:    []string{"10.0.0.0/8"},
+						},
 					},
 				},
 			},
@@ -3469,6 +3631,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "Network",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Bandwidth",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Hostname",
@@ -3525,12 +3693,42 @@ func TestTaskGroupDiff(t *testing.T) {
 									},
 								},
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Firewall",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "AllowCIDRs",
+										Old:  "",
+										New:  "10.0.0.0/8",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "AllowClientAPI",
+										Old:  "",
+										New:  "false",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "AllowMetadata",
+										Old:  "",
+										New:  "true",
+									},
+								},
+							},
 						},
 					},
 					{
 						Type: DiffTypeDeleted,
 						Name: "Network",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "Bandwidth",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Hostname",
@@ -3904,6 +4102,59 @@ func TestTaskGroupDiff(t *testing.T) {
 				},
 			},
 		},
+
+		{
+			TestCase: "TaskGroup volume alternative sources edited",
+			Old: &TaskGroup{
+				Volumes: map[string]*VolumeRequest{
+					"data": {
+						Name:               "data",
+						Type:               "host",
+						Source:             "fast-ssd",
+						AlternativeSources: []string{"bulk"},
+					},
+				},
+			},
+			New: &TaskGroup{
+				Volumes: map[string]*VolumeRequest{
+					"data": {
+						Name:               "data",
+						Type:               "host",
+						Source:             "fast-ssd",
+						AlternativeSources: []string{"archive"},
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Volume",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "AlternativeSources",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "AlternativeSources",
+										Old:  "",
+										New:  "archive",
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "AlternativeSources",
+										Old:  "bulk",
+										New:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for i, c := range cases {
@@ -4664,6 +4915,18 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "DiskBandwidthMB",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "DiskIOPS",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "DiskMB",
@@ -4767,6 +5030,18 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "DiskBandwidthMB",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "DiskIOPS",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "DiskMB",
@@ -4857,6 +5132,12 @@ func TestTaskDiff(t *testing.T) {
 								Type: DiffTypeAdded,
 								Name: "Network",
 								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Bandwidth",
+										Old:  "",
+										New:  "0",
+									},
 									{
 										Type: DiffTypeAdded,
 										Name: "MBits",
@@ -4913,6 +5194,12 @@ func TestTaskDiff(t *testing.T) {
 								Type: DiffTypeDeleted,
 								Name: "Network",
 								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeDeleted,
+										Name: "Bandwidth",
+										Old:  "0",
+										New:  "",
+									},
 									{
 										Type: DiffTypeDeleted,
 										Name: "MBits",
@@ -5132,6 +5419,18 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "0",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "DiskBandwidthMB",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "DiskIOPS",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "DiskMB",
@@ -5415,6 +5714,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "boom.HostNetworks",
+								Old:  "nil",
+								New:  "nil",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "boom.Label",
@@ -5503,6 +5808,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "ExplicitNamespace",
+								Old:  "",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Name",
@@ -5527,6 +5838,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "false",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "ExplicitNamespace",
+								Old:  "false",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Name",
@@ -5584,6 +5901,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "false",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "ExplicitNamespace",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Name",
@@ -5602,6 +5925,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Partition",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "PortLabel",
@@ -5736,6 +6065,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "false",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "ExplicitNamespace",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Name",
@@ -5748,6 +6083,10 @@ func TestTaskDiff(t *testing.T) {
 								Type: DiffTypeNone,
 								Name: "OnUpdate",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Partition",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "PortLabel",
@@ -6249,6 +6588,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "false",
 								New:  "false",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "ExplicitNamespace",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Name",
@@ -6265,6 +6610,10 @@ func TestTaskDiff(t *testing.T) {
 								Type: DiffTypeNone,
 								Name: "OnUpdate",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Partition",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "PortLabel",
@@ -6349,12 +6698,24 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "foo",
 										New:  "foo",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "Namespace",
+										Old:  "",
+										New:  "",
+									},
 									{
 										Type: DiffTypeEdited,
 										Name: "OnUpdate",
 										Old:  "require_healthy",
 										New:  "ignore_warnings",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "Partition",
+										Old:  "",
+										New:  "",
+									},
 									{
 										Type: DiffTypeNone,
 										Name: "Path",
@@ -6379,6 +6740,30 @@ func TestTaskDiff(t *testing.T) {
 										Old:  "4",
 										New:  "4",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "TLSCACert",
+										Old:  "",
+										New:  "",
+									},
+									{
+										Type: DiffTypeNone,
+										Name: "TLSClientCert",
+										Old:  "",
+										New:  "",
+									},
+									{
+										Type: DiffTypeNone,
+										Name: "TLSClientKey",
+										Old:  "",
+										New:  "",
+									},
+									{
+										Type: DiffTypeNone,
+										Name: "TLSServerName",
+										Old:  "",
+										New:  "",
+									},
 									{
 										Type: DiffTypeNone,
 										Name: "TLSSkipVerify",
@@ -6622,6 +7007,36 @@ func TestTaskDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Identity edited",
+			Old: &Task{
+				Identities: []*WorkloadIdentity{
+					{Name: "aws", Audience: []string{"sts.amazonaws.com"}, TTL: time.Hour, File: true},
+				},
+			},
+			New: &Task{
+				Identities: []*WorkloadIdentity{
+					{Name: "aws", Audience: []string{"sts.amazonaws.com"}, TTL: time.Hour, File: true, Env: true},
+				},
+			},
+			Expected: &TaskDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Identity",
+						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Env",
+								Old:  "false",
+								New:  "true",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name: "Vault added",
 			Old:  &Task{},
@@ -7155,6 +7570,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "",
 								New:  "foo",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Stdin",
+								Old:  "",
+								New:  "false",
+							},
 						},
 					},
 				},
@@ -7181,6 +7602,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "foo",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "Stdin",
+								Old:  "false",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -7217,7 +7644,6 @@ func TestTaskDiff(t *testing.T) {
 			},
 		},
 		{
-			// Place holder for if more fields are added
 			Name:       "DispatchPayload edited with context",
 			Contextual: true,
 			Old: &Task{
@@ -7243,6 +7669,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "foo",
 								New:  "bar",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Stdin",
+								Old:  "false",
+								New:  "false",
+							},
 						},
 					},
 				},
@@ -7336,6 +7768,12 @@ func TestServicesDiff(t *testing.T) {
 							Old:  "true",
 							New:  "false",
 						},
+						{
+							Type: DiffTypeNone,
+							Name: "ExplicitNamespace",
+							Old:  "false",
+							New:  "false",
+						},
 						{
 							Type: DiffTypeEdited,
 							Name: "Name",
@@ -7350,6 +7788,10 @@ func TestServicesDiff(t *testing.T) {
 							Type: DiffTypeNone,
 							Name: "OnUpdate",
 						},
+						{
+							Type: DiffTypeNone,
+							Name: "Partition",
+						},
 						{
 							Type: DiffTypeEdited,
 							Name: "PortLabel",
@@ -7423,6 +7865,11 @@ func TestServicesDiff(t *testing.T) {
 							Name: "EnableTagOverride",
 							New:  "false",
 						},
+						{
+							Type: DiffTypeAdded,
+							Name: "ExplicitNamespace",
+							New:  "false",
+						},
 						{
 							Type: DiffTypeAdded,
 							Name: "Name",
@@ -7436,6 +7883,10 @@ func TestServicesDiff(t *testing.T) {
 							Type: DiffTypeNone,
 							Name: "OnUpdate",
 						},
+						{
+							Type: DiffTypeNone,
+							Name: "Partition",
+						},
 						{
 							Type: DiffTypeAdded,
 							Name: "PortLabel",
@@ -7482,6 +7933,11 @@ func TestServicesDiff(t *testing.T) {
 							Name: "EnableTagOverride",
 							New:  "false",
 						},
+						{
+							Type: DiffTypeAdded,
+							Name: "ExplicitNamespace",
+							New:  "false",
+						},
 						{
 							Type: DiffTypeAdded,
 							Name: "Name",
@@ -7495,6 +7951,10 @@ func TestServicesDiff(t *testing.T) {
 							Type: DiffTypeNone,
 							Name: "OnUpdate",
 						},
+						{
+							Type: DiffTypeNone,
+							Name: "Partition",
+						},
 						{
 							Type: DiffTypeAdded,
 							Name: "PortLabel",
@@ -7546,6 +8006,12 @@ func TestServicesDiff(t *testing.T) {
 							Old:  "false",
 							New:  "false",
 						},
+						{
+							Type: DiffTypeNone,
+							Name: "ExplicitNamespace",
+							Old:  "false",
+							New:  "false",
+						},
 						{
 							Type: DiffTypeNone,
 							Name: "Name",
@@ -7560,6 +8026,10 @@ func TestServicesDiff(t *testing.T) {
 							Type: DiffTypeNone,
 							Name: "OnUpdate",
 						},
+						{
+							Type: DiffTypeNone,
+							Name: "Partition",
+						},
 						{
 							Type: DiffTypeEdited,
 							Name: "PortLabel",
@@ -7616,6 +8086,12 @@ func TestServicesDiff(t *testing.T) {
 							Old:  "false",
 							New:  "false",
 						},
+						{
+							Type: DiffTypeNone,
+							Name: "ExplicitNamespace",
+							Old:  "false",
+							New:  "false",
+						},
 						{
 							Type: DiffTypeNone,
 							Name: "Name",
@@ -7630,6 +8106,10 @@ func TestServicesDiff(t *testing.T) {
 							Type: DiffTypeNone,
 							Name: "OnUpdate",
 						},
+						{
+							Type: DiffTypeNone,
+							Name: "Partition",
+						},
 						{
 							Type: DiffTypeNone,
 							Name: "PortLabel",
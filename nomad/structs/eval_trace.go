@@ -0,0 +1,119 @@
+package structs
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// MaxEvalTraceEvents is the maximum number of events recorded in the
+	// trace of a single evaluation. Events past the limit are dropped and
+	// the trace is marked as truncated.
+	MaxEvalTraceEvents = 1000
+
+	// EvalTraceNodeFiltered is recorded when a node is filtered out by a
+	// constraint or a feasibility check.
+	EvalTraceNodeFiltered = "node-filtered"
+
+	// EvalTraceNodeExhausted is recorded when a feasible node doesn't have
+	// enough resources left.
+	EvalTraceNodeExhausted = "node-exhausted"
+
+	// EvalTraceNodeScored is recorded once the final score of a node is
+	// computed.
+	EvalTraceNodeScored = "node-scored"
+
+	// EvalTracePlaced is recorded when an allocation is placed on a node.
+	EvalTracePlaced = "placed"
+
+	// EvalTracePlacementFailed is recorded when no node could be found for
+	// an allocation.
+	EvalTracePlacementFailed = "placement-failed"
+)
+
+// AllocMetricTracer is notified of the per-node decisions recorded in an
+// AllocMetric while the scheduler selects a node.
+type AllocMetricTracer interface {
+	// NodeFiltered is called when the node is filtered by the constraint.
+	NodeFiltered(node *Node, constraint string)
+
+	// NodeExhausted is called when the node is exhausted on the dimension.
+	NodeExhausted(node *Node, dimension string)
+
+	// NodeScored is called once the normalized score of the node is known.
+	NodeScored(node *Node, meta *NodeScoreMeta)
+}
+
+// EvalTrace is the timeline of scheduler decisions recorded while processing
+// an evaluation, used to debug placements.
+type EvalTrace struct {
+	// Events are the recorded decisions in the order they were made.
+	Events []*EvalTraceEvent
+
+	// Truncated is set if events were dropped because the trace reached
+	// MaxEvalTraceEvents.
+	Truncated bool
+}
+
+// EvalTraceEvent is a single scheduler decision.
+type EvalTraceEvent struct {
+	// Time is the time of the decision in nanoseconds since the epoch.
+	Time int64
+
+	// Type is the type of decision, one of the EvalTrace* constants.
+	Type string
+
+	// TaskGroup is the task group being placed.
+	TaskGroup string
+
+	// NodeID and NodeName identify the node the decision is about, if any.
+	NodeID   string
+	NodeName string
+
+	// Reason is the constraint that filtered the node, the exhausted
+	// dimension or, for failed placements, a summary of the failure.
+	Reason string
+
+	// Score is the normalized score of the node, and Scores the score of
+	// each scorer, for scored and placed nodes.
+	Score  float64
+	Scores map[string]float64
+}
+
+// Add appends the event to the trace, unless the trace is full.
+func (t *EvalTrace) Add(event *EvalTraceEvent) {
+	if len(t.Events) >= MaxEvalTraceEvents {
+		t.Truncated = true
+		return
+	}
+	if event.Time == 0 {
+		event.Time = time.Now().UnixNano()
+	}
+	t.Events = append(t.Events, event)
+}
+
+func (t *EvalTrace) Copy() *EvalTrace {
+	if t == nil {
+		return nil
+	}
+	nt := new(EvalTrace)
+	*nt = *t
+	if t.Events != nil {
+		nt.Events = make([]*EvalTraceEvent, len(t.Events))
+		for i, e := range t.Events {
+			nt.Events[i] = e.Copy()
+		}
+	}
+	return nt
+}
+
+func (e *EvalTraceEvent) Copy() *EvalTraceEvent {
+	if e == nil {
+		return nil
+	}
+	ne := new(EvalTraceEvent)
+	*ne = *e
+	ne.Scores = helper.CopyMapStringFloat64(e.Scores)
+	return ne
+}
@@ -1,10 +1,33 @@
 package structs
 
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// validEventCursorName is used to validate the name of an event cursor
+var validEventCursorName = regexp.MustCompile("^[a-zA-Z0-9-_.]{1,128}$")
+
 // EventStreamRequest is used to stream events from a servers EventBroker
 type EventStreamRequest struct {
 	Topics map[Topic][]string
 	Index  int
 
+	// Consumer is the name of the cursor to resume the stream from. The
+	// stream starts with the events published after the index of the cursor,
+	// and Index is ignored.
+	Consumer string
+
+	// Namespaces are the namespaces to stream events for, overriding the
+	// namespace of the query options. They may contain glob wildcards.
+	Namespaces []string
+
+	// JobIDs and TaskGroups restrict the events to those of the given jobs
+	// and task groups.
+	JobIDs     []string
+	TaskGroups []string
+
 	QueryOptions
 }
 
@@ -23,6 +46,8 @@ const (
 	TopicNode       Topic = "Node"
 	TopicACLPolicy  Topic = "ACLPolicy"
 	TopicACLToken   Topic = "ACLToken"
+	TopicCSIVolume  Topic = "CSIVolume"
+	TopicGC         Topic = "GC"
 	TopicAll        Topic = "*"
 
 	TypeNodeRegistration              = "NodeRegistration"
@@ -45,6 +70,11 @@ const (
 	TypeACLTokenUpserted              = "ACLTokenUpserted"
 	TypeACLPolicyDeleted              = "ACLPolicyDeleted"
 	TypeACLPolicyUpserted             = "ACLPolicyUpserted"
+	TypeCSIVolumeRegistered           = "CSIVolumeRegistered"
+	TypeCSIVolumeDeregistered         = "CSIVolumeDeregistered"
+	TypeCSIVolumeClaim                = "CSIVolumeClaim"
+	TypeCSIVolumePluginUpdated        = "CSIVolumePluginUpdated"
+	TypeGCThresholdsTuned             = "GCThresholdsTuned"
 )
 
 // Event represents a change in Nomads state.
@@ -118,6 +148,40 @@ type NodeStreamEvent struct {
 	Node *Node
 }
 
+// CSIVolumeEvent holds a newly updated CSI volume. The volume's secrets
+// and denormalized allocations have been removed.
+type CSIVolumeEvent struct {
+	Volume *CSIVolume
+}
+
+// NewCSIVolumeEvent takes a volume and creates a new CSIVolumeEvent. It
+// creates a copy of the passed in volume with its secrets emptied out.
+func NewCSIVolumeEvent(vol *CSIVolume) *CSIVolumeEvent {
+	c := vol.Copy()
+	c.Secrets = nil
+	return &CSIVolumeEvent{Volume: c}
+}
+
+// GCTuneEvent holds the GC thresholds of a server after they have been tuned
+// to the size of its state.
+type GCTuneEvent struct {
+	// Server is the name of the server that tuned its thresholds.
+	Server string
+
+	// Scale is the factor the configured thresholds are multiplied by.
+	Scale float64
+
+	// HeapBytes and SnapshotBytes are the sizes of the heap and of the
+	// latest raft snapshot that triggered the decision.
+	HeapBytes     uint64
+	SnapshotBytes uint64
+
+	// The thresholds in effect after the decision.
+	JobGCThreshold        time.Duration
+	EvalGCThreshold       time.Duration
+	DeploymentGCThreshold time.Duration
+}
+
 type ACLTokenEvent struct {
 	ACLToken *ACLToken
 	secretID string
@@ -142,3 +206,91 @@ func (a *ACLTokenEvent) SecretID() string {
 type ACLPolicyEvent struct {
 	ACLPolicy *ACLPolicy
 }
+
+// EventCursor is the position of a durable consumer of the event stream,
+// stored by the servers under the name of the consumer so it can resume the
+// stream where it left off.
+type EventCursor struct {
+	// Name is the name of the consumer.
+	Name string
+
+	// Index is the index of the last event processed by the consumer.
+	Index uint64
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Validate returns an error if the cursor is invalid.
+func (c *EventCursor) Validate() error {
+	if !validEventCursorName.MatchString(c.Name) {
+		return fmt.Errorf("invalid event cursor name %q", c.Name)
+	}
+	return nil
+}
+
+// Copy returns a copy of the cursor.
+func (c *EventCursor) Copy() *EventCursor {
+	if c == nil {
+		return nil
+	}
+	nc := *c
+	return &nc
+}
+
+// EventCursorStatus is an event cursor along with how far its consumer lags
+// behind the events published by the server.
+type EventCursorStatus struct {
+	Name  string
+	Index uint64
+
+	// LatestIndex is the index of the latest events published by the server.
+	LatestIndex uint64
+
+	// Lag is the number of raft indexes between the cursor and the latest
+	// events.
+	Lag uint64
+
+	// Expired is set when events published after the cursor may no longer
+	// be in the event buffer of the server, so the stream can't be resumed
+	// from the cursor.
+	Expired bool
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// EventCursorUpsertRequest is used to create or update an event cursor.
+type EventCursorUpsertRequest struct {
+	Cursor *EventCursor
+	WriteRequest
+}
+
+// EventCursorDeleteRequest is used to delete an event cursor.
+type EventCursorDeleteRequest struct {
+	Name string
+	WriteRequest
+}
+
+// EventCursorSpecificRequest is used to read an event cursor.
+type EventCursorSpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// EventCursorListRequest is used to list the event cursors.
+type EventCursorListRequest struct {
+	QueryOptions
+}
+
+// EventCursorResponse is used to return an event cursor.
+type EventCursorResponse struct {
+	Cursor *EventCursorStatus
+	QueryMeta
+}
+
+// EventCursorListResponse is used to return the event cursors.
+type EventCursorListResponse struct {
+	Cursors []*EventCursorStatus
+	QueryMeta
+}
@@ -116,7 +116,7 @@ func TestAllocsFit_PortsOvercommitted_Old(t *testing.T) {
 						Device:        "eth0",
 						IP:            "10.0.0.1",
 						MBits:         50,
-						ReservedPorts: []Port{{"main", 8000, 80, ""}},
+						ReservedPorts: []Port{{Label: "main", Value: 8000, To: 80}},
 					},
 				},
 			},
@@ -170,7 +170,7 @@ func TestAllocsFit_Old(t *testing.T) {
 					Device:        "eth0",
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"main", 80, 0, ""}},
+					ReservedPorts: []Port{{Label: "main", Value: 80}},
 				},
 			},
 		},
@@ -186,7 +186,7 @@ func TestAllocsFit_Old(t *testing.T) {
 					Device:        "eth0",
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"main", 8000, 80, ""}},
+					ReservedPorts: []Port{{Label: "main", Value: 8000, To: 80}},
 				},
 			},
 		},
@@ -235,7 +235,7 @@ func TestAllocsFit_TerminalAlloc_Old(t *testing.T) {
 					Device:        "eth0",
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"main", 80, 0, ""}},
+					ReservedPorts: []Port{{Label: "main", Value: 80}},
 				},
 			},
 		},
@@ -251,7 +251,7 @@ func TestAllocsFit_TerminalAlloc_Old(t *testing.T) {
 					Device:        "eth0",
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"main", 8000, 0, ""}},
+					ReservedPorts: []Port{{Label: "main", Value: 8000}},
 				},
 			},
 		},
@@ -346,7 +346,7 @@ func TestAllocsFit(t *testing.T) {
 					{
 						Mode:          "host",
 						IP:            "10.0.0.1",
-						ReservedPorts: []Port{{"main", 8000, 0, ""}},
+						ReservedPorts: []Port{{Label: "main", Value: 8000}},
 					},
 				},
 				Ports: AllocatedPorts{
@@ -473,7 +473,7 @@ func TestAllocsFit_TerminalAlloc(t *testing.T) {
 							Device:        "eth0",
 							IP:            "10.0.0.1",
 							MBits:         50,
-							ReservedPorts: []Port{{"main", 8000, 80, ""}},
+							ReservedPorts: []Port{{Label: "main", Value: 8000, To: 80}},
 						},
 					},
 				},
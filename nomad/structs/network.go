@@ -433,67 +433,82 @@ func (idx *NetworkIndex) AssignPorts(ask *NetworkResource) (AllocatedPorts, erro
 	reservedIdx := map[string][]Port{}
 
 	for _, port := range ask.ReservedPorts {
-		reservedIdx[port.HostNetwork] = append(reservedIdx[port.HostNetwork], port)
+		for _, hostNetwork := range port.HostNetworkNames() {
+			reservedIdx[hostNetwork] = append(reservedIdx[hostNetwork], port)
+		}
+	}
 
-		// allocPort is set in the inner for loop if a port mapping can be created
-		// if allocPort is still nil after the loop, the port wasn't available for reservation
-		var allocPort *AllocatedPortMapping
-		var addrErr error
-		for _, addr := range idx.AvailAddresses[port.HostNetwork] {
-			used := idx.getUsedPortsFor(addr.Address)
-			// Guard against invalid port
-			if port.Value < 0 || port.Value >= MaxValidPort {
-				return nil, fmt.Errorf("invalid port %d (out of range)", port.Value)
-			}
+	for _, port := range ask.ReservedPorts {
+		// The port is reserved with the same value on each of its host
+		// networks
+		for _, hostNetwork := range port.HostNetworkNames() {
+			// allocPort is set in the inner for loop if a port mapping can be created
+			// if allocPort is still nil after the loop, the port wasn't available for reservation
+			var allocPort *AllocatedPortMapping
+			for _, addr := range idx.AvailAddresses[hostNetwork] {
+				used := idx.getUsedPortsFor(addr.Address)
+				// Guard against invalid port
+				if port.Value < 0 || port.Value >= MaxValidPort {
+					return nil, fmt.Errorf("invalid port %d (out of range)", port.Value)
+				}
 
-			// Check if in use
-			if used != nil && used.Check(uint(port.Value)) {
-				return nil, fmt.Errorf("reserved port collision %s=%d", port.Label, port.Value)
-			}
+				// Check if in use
+				if used != nil && used.Check(uint(port.Value)) {
+					return nil, fmt.Errorf("reserved port collision %s=%d", port.Label, port.Value)
+				}
 
-			allocPort = &AllocatedPortMapping{
-				Label:  port.Label,
-				Value:  port.Value,
-				To:     port.To,
-				HostIP: addr.Address,
+				allocPort = &AllocatedPortMapping{
+					Label:       port.Label,
+					Value:       port.Value,
+					To:          port.To,
+					HostIP:      addr.Address,
+					HostNetwork: hostNetwork,
+				}
+				break
 			}
-			break
-		}
 
-		if allocPort == nil {
-			if addrErr != nil {
-				return nil, addrErr
+			if allocPort == nil {
+				return nil, fmt.Errorf("no addresses available for %s network", hostNetwork)
 			}
 
-			return nil, fmt.Errorf("no addresses available for %s network", port.HostNetwork)
+			offer = append(offer, *allocPort)
 		}
-
-		offer = append(offer, *allocPort)
 	}
 
 	for _, port := range ask.DynamicPorts {
+		if len(port.HostNetworks) > 1 {
+			mappings, err := idx.assignMultiNetworkDynamicPort(port, reservedIdx)
+			if err != nil {
+				return nil, err
+			}
+			offer = append(offer, mappings...)
+			continue
+		}
+
+		hostNetwork := port.HostNetworkNames()[0]
 		var allocPort *AllocatedPortMapping
 		var addrErr error
-		for _, addr := range idx.AvailAddresses[port.HostNetwork] {
+		for _, addr := range idx.AvailAddresses[hostNetwork] {
 			used := idx.getUsedPortsFor(addr.Address)
 			// Try to stochastically pick the dynamic ports as it is faster and
 			// lower memory usage.
 			var dynPorts []int
 			// TODO: its more efficient to find multiple dynamic ports at once
-			dynPorts, addrErr = getDynamicPortsStochastic(used, idx.MinDynamicPort, idx.MaxDynamicPort, reservedIdx[port.HostNetwork], 1)
+			dynPorts, addrErr = getDynamicPortsStochastic(used, idx.MinDynamicPort, idx.MaxDynamicPort, reservedIdx[hostNetwork], 1)
 			if addrErr != nil {
 				// Fall back to the precise method if the random sampling failed.
-				dynPorts, addrErr = getDynamicPortsPrecise(used, idx.MinDynamicPort, idx.MaxDynamicPort, reservedIdx[port.HostNetwork], 1)
+				dynPorts, addrErr = getDynamicPortsPrecise(used, idx.MinDynamicPort, idx.MaxDynamicPort, reservedIdx[hostNetwork], 1)
 				if addrErr != nil {
 					continue
 				}
 			}
 
 			allocPort = &AllocatedPortMapping{
-				Label:  port.Label,
-				Value:  dynPorts[0],
-				To:     port.To,
-				HostIP: addr.Address,
+				Label:       port.Label,
+				Value:       dynPorts[0],
+				To:          port.To,
+				HostIP:      addr.Address,
+				HostNetwork: hostNetwork,
 			}
 			if allocPort.To == -1 {
 				allocPort.To = allocPort.Value
@@ -506,7 +521,7 @@ func (idx *NetworkIndex) AssignPorts(ask *NetworkResource) (AllocatedPorts, erro
 				return nil, addrErr
 			}
 
-			return nil, fmt.Errorf("no addresses available for %s network", port.HostNetwork)
+			return nil, fmt.Errorf("no addresses available for %s network", hostNetwork)
 		}
 		offer = append(offer, *allocPort)
 	}
@@ -514,6 +529,53 @@ func (idx *NetworkIndex) AssignPorts(ask *NetworkResource) (AllocatedPorts, erro
 	return offer, nil
 }
 
+// assignMultiNetworkDynamicPort assigns a dynamic port with the same value on
+// each of the host networks of the port. The value is picked among the ports
+// free on all the addresses of these networks, and mapped on the first address
+// of each of them.
+func (idx *NetworkIndex) assignMultiNetworkDynamicPort(port Port, reservedIdx map[string][]Port) ([]AllocatedPortMapping, error) {
+	used, err := NewBitmap(MaxValidPort)
+	if err != nil {
+		return nil, err
+	}
+
+	var reserved []Port
+	for _, hostNetwork := range port.HostNetworks {
+		addrs := idx.AvailAddresses[hostNetwork]
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("no addresses available for %s network", hostNetwork)
+		}
+		for _, addr := range addrs {
+			used.Or(idx.getUsedPortsFor(addr.Address))
+		}
+		reserved = append(reserved, reservedIdx[hostNetwork]...)
+	}
+
+	dynPorts, err := getDynamicPortsStochastic(used, idx.MinDynamicPort, idx.MaxDynamicPort, reserved, 1)
+	if err != nil {
+		dynPorts, err = getDynamicPortsPrecise(used, idx.MinDynamicPort, idx.MaxDynamicPort, reserved, 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	to := port.To
+	if to == -1 {
+		to = dynPorts[0]
+	}
+	mappings := make([]AllocatedPortMapping, 0, len(port.HostNetworks))
+	for _, hostNetwork := range port.HostNetworks {
+		mappings = append(mappings, AllocatedPortMapping{
+			Label:       port.Label,
+			Value:       dynPorts[0],
+			To:          to,
+			HostIP:      idx.AvailAddresses[hostNetwork][0].Address,
+			HostNetwork: hostNetwork,
+		})
+	}
+	return mappings, nil
+}
+
 // AssignNetwork is used to assign network resources given an ask.
 // If the ask cannot be satisfied, returns nil
 func (idx *NetworkIndex) AssignNetwork(ask *NetworkResource) (out *NetworkResource, err error) {
@@ -65,7 +65,7 @@ func TestNetworkIndex_Copy(t *testing.T) {
 								Device:        "eth0",
 								IP:            "192.168.0.100",
 								MBits:         20,
-								ReservedPorts: []Port{{"one", 8000, 0, ""}, {"two", 9000, 0, ""}},
+								ReservedPorts: []Port{{Label: "one", Value: 8000}, {Label: "two", Value: 9000}},
 							},
 						},
 					},
@@ -81,7 +81,7 @@ func TestNetworkIndex_Copy(t *testing.T) {
 								Device:        "eth0",
 								IP:            "192.168.0.100",
 								MBits:         50,
-								ReservedPorts: []Port{{"one", 10000, 0, ""}},
+								ReservedPorts: []Port{{Label: "one", Value: 10000}},
 							},
 						},
 					},
@@ -110,7 +110,7 @@ func TestNetworkIndex_Copy(t *testing.T) {
 							Device:        "eth1",
 							IP:            "192.168.0.104",
 							MBits:         50,
-							ReservedPorts: []Port{{"one", 4567, 0, ""}},
+							ReservedPorts: []Port{{Label: "one", Value: 4567}},
 						},
 					},
 				},
@@ -134,7 +134,7 @@ func TestNetworkIndex_Overcommitted(t *testing.T) {
 		Device:        "eth0",
 		IP:            "192.168.0.100",
 		MBits:         505,
-		ReservedPorts: []Port{{"one", 8000, 0, ""}, {"two", 9000, 0, ""}},
+		ReservedPorts: []Port{{Label: "one", Value: 8000}, {Label: "two", Value: 9000}},
 	}
 	collide, reasons := idx.AddReserved(reserved)
 	if collide || len(reasons) != 0 {
@@ -219,7 +219,7 @@ func TestNetworkIndex_AddAllocs(t *testing.T) {
 								Device:        "eth0",
 								IP:            "192.168.0.100",
 								MBits:         20,
-								ReservedPorts: []Port{{"one", 8000, 0, ""}, {"two", 9000, 0, ""}},
+								ReservedPorts: []Port{{Label: "one", Value: 8000}, {Label: "two", Value: 9000}},
 							},
 						},
 					},
@@ -235,7 +235,7 @@ func TestNetworkIndex_AddAllocs(t *testing.T) {
 								Device:        "eth0",
 								IP:            "192.168.0.100",
 								MBits:         50,
-								ReservedPorts: []Port{{"one", 10000, 0, ""}},
+								ReservedPorts: []Port{{Label: "one", Value: 10000}},
 							},
 						},
 					},
@@ -271,7 +271,7 @@ func TestNetworkIndex_AddReserved(t *testing.T) {
 		Device:        "eth0",
 		IP:            "192.168.0.100",
 		MBits:         20,
-		ReservedPorts: []Port{{"one", 8000, 0, ""}, {"two", 9000, 0, ""}},
+		ReservedPorts: []Port{{Label: "one", Value: 8000}, {Label: "two", Value: 9000}},
 	}
 	collide, reasons := idx.AddReserved(reserved)
 	if collide || len(reasons) > 0 {
@@ -352,7 +352,7 @@ func TestNetworkIndex_AssignNetwork(t *testing.T) {
 							Device:        "eth0",
 							IP:            "192.168.0.100",
 							MBits:         20,
-							ReservedPorts: []Port{{"one", 8000, 0, ""}, {"two", 9000, 0, ""}},
+							ReservedPorts: []Port{{Label: "one", Value: 8000}, {Label: "two", Value: 9000}},
 						},
 					},
 				},
@@ -366,7 +366,7 @@ func TestNetworkIndex_AssignNetwork(t *testing.T) {
 							Device:        "eth0",
 							IP:            "192.168.0.100",
 							MBits:         50,
-							ReservedPorts: []Port{{"main", 10000, 0, ""}},
+							ReservedPorts: []Port{{Label: "main", Value: 10000}},
 						},
 					},
 				},
@@ -377,19 +377,19 @@ func TestNetworkIndex_AssignNetwork(t *testing.T) {
 
 	// Ask for a reserved port
 	ask := &NetworkResource{
-		ReservedPorts: []Port{{"main", 8000, 0, ""}},
+		ReservedPorts: []Port{{Label: "main", Value: 8000}},
 	}
 	offer, err := idx.AssignNetwork(ask)
 	require.NoError(t, err)
 	require.NotNil(t, offer)
 	require.Equal(t, "192.168.0.101", offer.IP)
-	rp := Port{"main", 8000, 0, ""}
+	rp := Port{Label: "main", Value: 8000}
 	require.Len(t, offer.ReservedPorts, 1)
 	require.Exactly(t, rp, offer.ReservedPorts[0])
 
 	// Ask for dynamic ports
 	ask = &NetworkResource{
-		DynamicPorts: []Port{{"http", 0, 80, ""}, {"https", 0, 443, ""}, {"admin", 0, -1, ""}},
+		DynamicPorts: []Port{{Label: "http", Value: 0, To: 80}, {Label: "https", Value: 0, To: 443}, {Label: "admin", Value: 0, To: -1}},
 	}
 	offer, err = idx.AssignNetwork(ask)
 	require.NoError(t, err)
@@ -407,15 +407,15 @@ func TestNetworkIndex_AssignNetwork(t *testing.T) {
 
 	// Ask for reserved + dynamic ports
 	ask = &NetworkResource{
-		ReservedPorts: []Port{{"main", 2345, 0, ""}},
-		DynamicPorts:  []Port{{"http", 0, 80, ""}, {"https", 0, 443, ""}, {"admin", 0, 8080, ""}},
+		ReservedPorts: []Port{{Label: "main", Value: 2345}},
+		DynamicPorts:  []Port{{Label: "http", Value: 0, To: 80}, {Label: "https", Value: 0, To: 443}, {Label: "admin", Value: 0, To: 8080}},
 	}
 	offer, err = idx.AssignNetwork(ask)
 	require.NoError(t, err)
 	require.NotNil(t, offer)
 	require.Equal(t, "192.168.0.100", offer.IP)
 
-	rp = Port{"main", 2345, 0, ""}
+	rp = Port{Label: "main", Value: 2345}
 	require.Len(t, offer.ReservedPorts, 1)
 	require.Exactly(t, rp, offer.ReservedPorts[0])
 
@@ -457,7 +457,7 @@ func TestNetworkIndex_AssignNetwork_Dynamic_Contention(t *testing.T) {
 
 	// Ask for dynamic ports
 	ask := &NetworkResource{
-		DynamicPorts: []Port{{"http", 0, 80, ""}},
+		DynamicPorts: []Port{{Label: "http", Value: 0, To: 80}},
 	}
 	offer, err := idx.AssignNetwork(ask)
 	if err != nil {
@@ -497,7 +497,7 @@ func TestNetworkIndex_SetNode_Old(t *testing.T) {
 				{
 					Device:        "eth0",
 					IP:            "192.168.0.100",
-					ReservedPorts: []Port{{"ssh", 22, 0, ""}},
+					ReservedPorts: []Port{{Label: "ssh", Value: 22}},
 					MBits:         1,
 				},
 			},
@@ -536,7 +536,7 @@ func TestNetworkIndex_AddAllocs_Old(t *testing.T) {
 							Device:        "eth0",
 							IP:            "192.168.0.100",
 							MBits:         20,
-							ReservedPorts: []Port{{"one", 8000, 0, ""}, {"two", 9000, 0, ""}},
+							ReservedPorts: []Port{{Label: "one", Value: 8000}, {Label: "two", Value: 9000}},
 						},
 					},
 				},
@@ -550,7 +550,7 @@ func TestNetworkIndex_AddAllocs_Old(t *testing.T) {
 							Device:        "eth0",
 							IP:            "192.168.0.100",
 							MBits:         50,
-							ReservedPorts: []Port{{"one", 10000, 0, ""}},
+							ReservedPorts: []Port{{Label: "one", Value: 10000}},
 						},
 					},
 				},
@@ -596,7 +596,7 @@ func TestNetworkIndex_yieldIP_Old(t *testing.T) {
 				{
 					Device:        "eth0",
 					IP:            "192.168.0.100",
-					ReservedPorts: []Port{{"ssh", 22, 0, ""}},
+					ReservedPorts: []Port{{Label: "ssh", Value: 22}},
 					MBits:         1,
 				},
 			},
@@ -637,7 +637,7 @@ func TestNetworkIndex_AssignNetwork_Old(t *testing.T) {
 				{
 					Device:        "eth0",
 					IP:            "192.168.0.100",
-					ReservedPorts: []Port{{"ssh", 22, 0, ""}},
+					ReservedPorts: []Port{{Label: "ssh", Value: 22}},
 					MBits:         1,
 				},
 			},
@@ -654,7 +654,7 @@ func TestNetworkIndex_AssignNetwork_Old(t *testing.T) {
 							Device:        "eth0",
 							IP:            "192.168.0.100",
 							MBits:         20,
-							ReservedPorts: []Port{{"one", 8000, 0, ""}, {"two", 9000, 0, ""}},
+							ReservedPorts: []Port{{Label: "one", Value: 8000}, {Label: "two", Value: 9000}},
 						},
 					},
 				},
@@ -668,7 +668,7 @@ func TestNetworkIndex_AssignNetwork_Old(t *testing.T) {
 							Device:        "eth0",
 							IP:            "192.168.0.100",
 							MBits:         50,
-							ReservedPorts: []Port{{"main", 10000, 0, ""}},
+							ReservedPorts: []Port{{Label: "main", Value: 10000}},
 						},
 					},
 				},
@@ -679,7 +679,7 @@ func TestNetworkIndex_AssignNetwork_Old(t *testing.T) {
 
 	// Ask for a reserved port
 	ask := &NetworkResource{
-		ReservedPorts: []Port{{"main", 8000, 0, ""}},
+		ReservedPorts: []Port{{Label: "main", Value: 8000}},
 	}
 	offer, err := idx.AssignNetwork(ask)
 	if err != nil {
@@ -691,14 +691,14 @@ func TestNetworkIndex_AssignNetwork_Old(t *testing.T) {
 	if offer.IP != "192.168.0.101" {
 		t.Fatalf("bad: %#v", offer)
 	}
-	rp := Port{"main", 8000, 0, ""}
-	if len(offer.ReservedPorts) != 1 || offer.ReservedPorts[0] != rp {
+	rp := Port{Label: "main", Value: 8000}
+	if len(offer.ReservedPorts) != 1 || !reflect.DeepEqual(offer.ReservedPorts[0], rp) {
 		t.Fatalf("bad: %#v", offer)
 	}
 
 	// Ask for dynamic ports
 	ask = &NetworkResource{
-		DynamicPorts: []Port{{"http", 0, 80, ""}, {"https", 0, 443, ""}, {"admin", 0, 8080, ""}},
+		DynamicPorts: []Port{{Label: "http", Value: 0, To: 80}, {Label: "https", Value: 0, To: 443}, {Label: "admin", Value: 0, To: 8080}},
 	}
 	offer, err = idx.AssignNetwork(ask)
 	if err != nil {
@@ -721,8 +721,8 @@ func TestNetworkIndex_AssignNetwork_Old(t *testing.T) {
 
 	// Ask for reserved + dynamic ports
 	ask = &NetworkResource{
-		ReservedPorts: []Port{{"main", 2345, 0, ""}},
-		DynamicPorts:  []Port{{"http", 0, 80, ""}, {"https", 0, 443, ""}, {"admin", 0, 8080, ""}},
+		ReservedPorts: []Port{{Label: "main", Value: 2345}},
+		DynamicPorts:  []Port{{Label: "http", Value: 0, To: 80}, {Label: "https", Value: 0, To: 443}, {Label: "admin", Value: 0, To: 8080}},
 	}
 	offer, err = idx.AssignNetwork(ask)
 	if err != nil {
@@ -735,8 +735,8 @@ func TestNetworkIndex_AssignNetwork_Old(t *testing.T) {
 		t.Fatalf("bad: %#v", offer)
 	}
 
-	rp = Port{"main", 2345, 0, ""}
-	if len(offer.ReservedPorts) != 1 || offer.ReservedPorts[0] != rp {
+	rp = Port{Label: "main", Value: 2345}
+	if len(offer.ReservedPorts) != 1 || !reflect.DeepEqual(offer.ReservedPorts[0], rp) {
 		t.Fatalf("bad: %#v", offer)
 	}
 
@@ -789,7 +789,7 @@ func TestNetworkIndex_AssignNetwork_Dynamic_Contention_Old(t *testing.T) {
 
 	// Ask for dynamic ports
 	ask := &NetworkResource{
-		DynamicPorts: []Port{{"http", 0, 80, ""}},
+		DynamicPorts: []Port{{Label: "http", Value: 0, To: 80}},
 	}
 	offer, err := idx.AssignNetwork(ask)
 	if err != nil {
@@ -823,3 +823,79 @@ func TestIntContains(t *testing.T) {
 		t.Fatalf("bad")
 	}
 }
+
+func TestNetworkIndex_AssignPorts_HostNetworks(t *testing.T) {
+	ci.Parallel(t)
+
+	n := &Node{
+		NodeResources: &NodeResources{
+			NodeNetworks: []*NodeNetworkResource{
+				{
+					Mode:   "host",
+					Device: "eth0",
+					Speed:  1000,
+					Addresses: []NodeNetworkAddress{
+						{
+							Alias:   "mgmt",
+							Address: "10.0.0.1",
+							Family:  NodeNetworkAF_IPv4,
+						},
+					},
+				},
+				{
+					Mode:   "host",
+					Device: "eth1",
+					Speed:  1000,
+					Addresses: []NodeNetworkAddress{
+						{
+							Alias:         "data",
+							Address:       "10.0.1.1",
+							Family:        NodeNetworkAF_IPv4,
+							ReservedPorts: "20000-20008",
+						},
+					},
+				},
+			},
+			MinDynamicPort: 20000,
+			MaxDynamicPort: 20010,
+		},
+	}
+
+	idx := NewNetworkIndex()
+	collide, reason := idx.SetNode(n)
+	require.False(t, collide, reason)
+
+	ask := &NetworkResource{
+		ReservedPorts: []Port{{Label: "admin", Value: 8080, HostNetworks: []string{"mgmt", "data"}}},
+		DynamicPorts:  []Port{{Label: "http", To: 80, HostNetworks: []string{"mgmt", "data"}}},
+	}
+	offer, err := idx.AssignPorts(ask)
+	require.NoError(t, err)
+	require.Len(t, offer, 4)
+
+	require.Equal(t, AllocatedPortMapping{Label: "admin", Value: 8080, HostIP: "10.0.0.1", HostNetwork: "mgmt"}, offer[0])
+	require.Equal(t, AllocatedPortMapping{Label: "admin", Value: 8080, HostIP: "10.0.1.1", HostNetwork: "data"}, offer[1])
+
+	// The dynamic port has the same value on both networks, which must be
+	// free on the data network
+	require.Equal(t, "10.0.0.1", offer[2].HostIP)
+	require.Equal(t, "mgmt", offer[2].HostNetwork)
+	require.Equal(t, "10.0.1.1", offer[3].HostIP)
+	require.Equal(t, "data", offer[3].HostNetwork)
+	require.Equal(t, offer[2].Value, offer[3].Value)
+	require.Contains(t, []int{20009, 20010}, offer[2].Value)
+	require.Equal(t, 80, offer[3].To)
+
+	// The reserved port must be free on all the networks
+	collide, _ = idx.AddReservedPortsForIP("8080", "10.0.1.1")
+	require.False(t, collide)
+	_, err = idx.AssignPorts(ask)
+	require.EqualError(t, err, "reserved port collision admin=8080")
+
+	// All the networks must be available
+	ask = &NetworkResource{
+		DynamicPorts: []Port{{Label: "http", HostNetworks: []string{"mgmt", "public"}}},
+	}
+	_, err = idx.AssignPorts(ask)
+	require.EqualError(t, err, "no addresses available for public network")
+}
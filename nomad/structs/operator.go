@@ -156,6 +156,10 @@ type SchedulerConfiguration struct {
 	// management ACL token
 	RejectJobRegistration bool `hcl:"reject_job_registration"`
 
+	// EvalTracingEnabled makes the scheduler record a timeline of its
+	// decisions on each evaluation it processes
+	EvalTracingEnabled bool `hcl:"eval_tracing_enabled"`
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -197,6 +201,110 @@ type SchedulerConfigurationResponse struct {
 	QueryMeta
 }
 
+// SchedulerQueuesResponse is the response object that wraps the queue depth of
+// each namespace in the eval broker
+type SchedulerQueuesResponse struct {
+	// Namespaces is the queue depth of each namespace with outstanding
+	// evaluations
+	Namespaces map[string]*SchedulerNamespaceQueue
+
+	QueryMeta
+}
+
+// SchedulerNamespaceQueue is the queue depth of a namespace in the eval broker
+type SchedulerNamespaceQueue struct {
+	// Ready is the number of evaluations waiting to be dequeued
+	Ready int
+
+	// Unacked is the number of evaluations being processed by schedulers
+	Unacked int
+}
+
+// SchedulerFragmentationRequest is used to request the fragmentation report
+// of the cluster.
+type SchedulerFragmentationRequest struct {
+	// Suggest requests candidate migrations that would free up whole nodes
+	Suggest bool
+
+	QueryOptions
+}
+
+// SchedulerFragmentationResponse is the response object that wraps the
+// fragmentation report computed by the leader.
+type SchedulerFragmentationResponse struct {
+	// Shapes are the most common shapes of the running service and batch
+	// allocations, which the free capacity of the nodes is measured against
+	Shapes []*SchedulerAllocShape
+
+	// Datacenters is the fragmentation report of each datacenter with nodes
+	// eligible for scheduling
+	Datacenters map[string]*SchedulerDatacenterFragmentation
+
+	QueryMeta
+}
+
+// SchedulerAllocShape is the resources requested by an allocation.
+type SchedulerAllocShape struct {
+	// CPU is the CPU shares in MHz
+	CPU int64
+
+	// MemoryMB is the memory in MB
+	MemoryMB int64
+
+	// Count is the number of running allocations with this shape
+	Count int
+}
+
+// Fits returns whether an allocation with the shape fits in the given free
+// CPU and memory.
+func (s *SchedulerAllocShape) Fits(cpu, memoryMB int64) bool {
+	return s.CPU <= cpu && s.MemoryMB <= memoryMB
+}
+
+// SchedulerDatacenterFragmentation is the fragmentation report of the nodes
+// of a datacenter.
+type SchedulerDatacenterFragmentation struct {
+	// Nodes is the number of nodes eligible for scheduling
+	Nodes int
+
+	// FreeCPU and FreeMemoryMB are the unallocated resources of the nodes
+	FreeCPU      int64
+	FreeMemoryMB int64
+
+	// StrandedCPU and StrandedMemoryMB are the unallocated resources of the
+	// nodes that can't fit an allocation of any of the common shapes
+	StrandedCPU      int64
+	StrandedMemoryMB int64
+
+	// Placements is the number of additional allocations of each shape, in
+	// the order of the response Shapes, that fit in the datacenter
+	Placements []int
+
+	// Migrations are the candidate migrations that would free up whole
+	// nodes. They are only computed when requested.
+	Migrations []*SchedulerMigration
+}
+
+// SchedulerMigration is a set of allocations that could be moved off a node
+// to free it up entirely.
+type SchedulerMigration struct {
+	// NodeID is the node that would be freed up
+	NodeID string
+
+	// Allocs are the allocations to move
+	Allocs []*SchedulerMigrationAlloc
+}
+
+// SchedulerMigrationAlloc is an allocation to move to another node.
+type SchedulerMigrationAlloc struct {
+	ID        string
+	Namespace string
+	JobID     string
+
+	// TargetNodeID is the node with enough free capacity for the allocation
+	TargetNodeID string
+}
+
 // SchedulerSetConfigurationResponse is the response object used
 // when updating scheduler configuration
 type SchedulerSetConfigurationResponse struct {
@@ -59,11 +59,17 @@ type ServiceCheck struct {
 	CheckRestart           *CheckRestart       // If and when a task should be restarted based on checks
 	GRPCService            string              // Service for GRPC checks
 	GRPCUseTLS             bool                // Whether or not to use TLS for GRPC checks
+	TLSServerName          string              // Name the server certificate SAN must match for TLS checks
+	TLSCACert              string              // Path to the CA certificate used to verify the server for client TLS checks
+	TLSClientCert          string              // Path to the client certificate presented by GRPC checks
+	TLSClientKey           string              // Path to the client key presented by GRPC checks
 	TaskName               string              // What task to execute this check in
 	SuccessBeforePassing   int                 // Number of consecutive successes required before considered healthy
 	FailuresBeforeCritical int                 // Number of consecutive failures required before considered unhealthy
 	Body                   string              // Body to use in HTTP check
 	OnUpdate               string
+	Namespace              string // Consul namespace of the check, must match the service namespace
+	Partition              string // Consul admin partition of the check, must match the service partition
 }
 
 // Copy the stanza recursively. Returns nil if nil.
@@ -125,6 +131,22 @@ func (sc *ServiceCheck) Equals(o *ServiceCheck) bool {
 		return false
 	}
 
+	if sc.TLSServerName != o.TLSServerName {
+		return false
+	}
+
+	if sc.TLSCACert != o.TLSCACert {
+		return false
+	}
+
+	if sc.TLSClientCert != o.TLSClientCert {
+		return false
+	}
+
+	if sc.TLSClientKey != o.TLSClientKey {
+		return false
+	}
+
 	// Use DeepEqual here as order of slice values could matter
 	if !reflect.DeepEqual(sc.Header, o.Header) {
 		return false
@@ -178,6 +200,14 @@ func (sc *ServiceCheck) Equals(o *ServiceCheck) bool {
 		return false
 	}
 
+	if sc.Namespace != o.Namespace {
+		return false
+	}
+
+	if sc.Partition != o.Partition {
+		return false
+	}
+
 	return true
 }
 
@@ -235,6 +265,34 @@ func (sc *ServiceCheck) validate() error {
 		return fmt.Errorf(`invalid type (%+q), must be one of "http", "tcp", or "script" type`, sc.Type)
 	}
 
+	// Validate TLS options
+	if sc.TLSServerName != "" {
+		switch checkType {
+		case ServiceCheckGRPC, ServiceCheckHTTP: // ok
+		default:
+			return fmt.Errorf("tls_server_name may only be set on HTTP or gRPC checks")
+		}
+	}
+	if sc.TLSClientCert != "" || sc.TLSClientKey != "" || sc.TLSCACert != "" {
+		if checkType != ServiceCheckGRPC {
+			return fmt.Errorf("tls_client_cert, tls_client_key, and tls_ca_cert may only be set on gRPC checks")
+		}
+		if sc.TLSClientCert == "" || sc.TLSClientKey == "" {
+			return fmt.Errorf("tls_client_cert and tls_client_key must be set together")
+		}
+		if !sc.GRPCUseTLS {
+			return fmt.Errorf("gRPC checks with client certificates must set grpc_use_tls")
+		}
+		if sc.Expose {
+			return fmt.Errorf("expose may not be set on gRPC checks with client certificates")
+		}
+		switch sc.AddressMode {
+		case "", AddressModeHost:
+		default:
+			return fmt.Errorf("gRPC checks with client certificates only support address_mode %q", AddressModeHost)
+		}
+	}
+
 	// Validate interval and timeout
 	if sc.Interval == 0 {
 		return fmt.Errorf("missing required value interval. Interval cannot be less than %v", minCheckInterval)
@@ -335,6 +393,13 @@ func (sc *ServiceCheck) RequiresPort() bool {
 	}
 }
 
+// RequiresClientTLS returns whether the service check presents a client
+// certificate. Consul agents cannot run such checks, so the Nomad client runs
+// them and reports the result to Consul as a TTL check.
+func (sc *ServiceCheck) RequiresClientTLS() bool {
+	return sc.Type == ServiceCheckGRPC && sc.TLSClientCert != ""
+}
+
 // TriggersRestarts returns true if this check should be watched and trigger a restart
 // on failure.
 func (sc *ServiceCheck) TriggersRestarts() bool {
@@ -360,6 +425,8 @@ func (sc *ServiceCheck) Hash(serviceID string) string {
 	hashString(h, sc.Method)
 	hashString(h, sc.Body)
 	hashString(h, sc.OnUpdate)
+	hashFieldIfNonEmpty(h, "namespace", sc.Namespace)
+	hashFieldIfNonEmpty(h, "partition", sc.Partition)
 
 	// use name "true" to maintain ID stability
 	hashBool(h, sc.TLSSkipVerify, "true")
@@ -376,6 +443,12 @@ func (sc *ServiceCheck) Hash(serviceID string) string {
 	// use name "true" to maintain ID stability
 	hashBool(h, sc.GRPCUseTLS, "true")
 
+	// Only include TLS options if set to maintain ID stability with Nomad < 1.3
+	hashFieldIfNonEmpty(h, "tls_server_name", sc.TLSServerName)
+	hashFieldIfNonEmpty(h, "tls_ca_cert", sc.TLSCACert)
+	hashFieldIfNonEmpty(h, "tls_client_cert", sc.TLSClientCert)
+	hashFieldIfNonEmpty(h, "tls_client_key", sc.TLSClientKey)
+
 	// Only include pass/fail if non-zero to maintain ID stability with Nomad < 0.12
 	hashIntIfNonZero(h, "success", sc.SuccessBeforePassing)
 	hashIntIfNonZero(h, "failures", sc.FailuresBeforeCritical)
@@ -395,6 +468,14 @@ func hashStringIfNonEmpty(h hash.Hash, s string) {
 	}
 }
 
+// hashFieldIfNonEmpty hashes the value prefixed with the name of its field, so
+// that the same value in different fields hashes differently.
+func hashFieldIfNonEmpty(h hash.Hash, name, s string) {
+	if len(s) > 0 {
+		hashString(h, fmt.Sprintf("%s:%s", name, s))
+	}
+}
+
 func hashIntIfNonZero(h hash.Hash, name string, i int) {
 	if i != 0 {
 		hashString(h, fmt.Sprintf("%s:%d", name, i))
@@ -459,15 +540,29 @@ type Service struct {
 	Meta       map[string]string // Consul service meta
 	CanaryMeta map[string]string // Consul service meta when it is a canary
 
-	// The consul namespace in which this service will be registered. Namespace
-	// at the service.check level is not part of the Nomad API - it must be
-	// set at the job or group level. This field is managed internally so
-	// that Hash can work correctly.
+	// Namespace is the Consul namespace in which this service will be
+	// registered. If empty, the namespace of the job or group is used.
+	// Canonicalize sets an empty namespace to "default", as earlier versions
+	// did for every service, so a "default" namespace is only used as such if
+	// ExplicitNamespace is set.
 	Namespace string
 
+	// ExplicitNamespace is set if the job set Namespace, telling services
+	// explicitly in the "default" namespace apart from services without one.
+	ExplicitNamespace bool
+
+	// Partition is the Consul admin partition in which this service and its
+	// checks will be registered. If empty, the partition of the Consul agent
+	// is used.
+	Partition string
+
 	// OnUpdate Specifies how the service and its checks should be evaluated
 	// during an update
 	OnUpdate string
+
+	// Weights of the service in Consul DNS SRV responses. Consul's defaults
+	// are used if nil.
+	Weights *ServiceWeights
 }
 
 const (
@@ -495,6 +590,7 @@ func (s *Service) Copy() *Service {
 	}
 
 	ns.Connect = s.Connect.Copy()
+	ns.Weights = s.Weights.Copy()
 
 	ns.Meta = helper.CopyMapStringString(s.Meta)
 	ns.CanaryMeta = helper.CopyMapStringString(s.CanaryMeta)
@@ -535,6 +631,37 @@ func (s *Service) Canonicalize(job string, taskGroup string, task string) {
 	}
 }
 
+// ConsulNamespace returns the Consul namespace the service is registered in.
+// Canonicalize sets the namespace of every service that doesn't set one to
+// "default", as it always has, so services in the "default" namespace inherit
+// the namespace of the job or group unless they set it explicitly.
+func (s *Service) ConsulNamespace(groupNamespace string) string {
+	if s.Namespace != "" && (s.Namespace != "default" || s.ExplicitNamespace) {
+		return s.Namespace
+	}
+	return groupNamespace
+}
+
+// validateCheckTenancy checks that the checks setting a Consul namespace or
+// admin partition are in the namespace and partition of the service, as
+// Consul registers checks of a service in its namespace and partition. The
+// namespace of the service defaults to the namespace of its group.
+func (s *Service) validateCheckTenancy(groupNamespace string) error {
+	var mErr multierror.Error
+	namespace := s.ConsulNamespace(groupNamespace)
+	for _, c := range s.Checks {
+		if c.Namespace != "" && c.Namespace != namespace {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Check %s invalid: namespace %q must match the namespace %q of service %s",
+				c.Name, c.Namespace, namespace, s.Name))
+		}
+		if c.Partition != "" && c.Partition != s.Partition {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Check %s invalid: partition %q must match the partition %q of service %s",
+				c.Name, c.Partition, s.Partition, s.Name))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
 // Validate checks if the Service definition is valid
 func (s *Service) Validate() error {
 	var mErr multierror.Error
@@ -583,6 +710,11 @@ func (s *Service) Validate() error {
 		}
 	}
 
+	// check weights
+	if err := s.Weights.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
 	// check connect
 	if s.Connect != nil {
 		if err := s.Connect.Validate(); err != nil {
@@ -631,6 +763,9 @@ func (s *Service) Hash(allocID, taskName string, canary bool) string {
 	hashConnect(h, s.Connect)
 	hashString(h, s.OnUpdate)
 	hashString(h, s.Namespace)
+	hashBool(h, s.ExplicitNamespace, "ExplicitNamespace")
+	hashFieldIfNonEmpty(h, "partition", s.Partition)
+	hashWeights(h, s.Weights)
 
 	// Base32 is used for encoding the hash as sha1 hashes can always be
 	// encoded without padding, only 4 bytes larger than base64, and saves
@@ -657,6 +792,13 @@ func hashConnect(h hash.Hash, connect *ConsulConnect) {
 	}
 }
 
+func hashWeights(h hash.Hash, weights *ServiceWeights) {
+	if weights != nil {
+		hashString(h, strconv.Itoa(weights.Passing))
+		hashString(h, strconv.Itoa(weights.Warning))
+	}
+}
+
 func hashString(h hash.Hash, s string) {
 	_, _ = io.WriteString(h, s)
 }
@@ -687,7 +829,11 @@ func (s *Service) Equals(o *Service) bool {
 		return s == o
 	}
 
-	if s.Namespace != o.Namespace {
+	if s.Namespace != o.Namespace || s.ExplicitNamespace != o.ExplicitNamespace {
+		return false
+	}
+
+	if s.Partition != o.Partition {
 		return false
 	}
 
@@ -748,9 +894,52 @@ OUTER:
 		return false
 	}
 
+	if !s.Weights.Equals(o.Weights) {
+		return false
+	}
+
 	return true
 }
 
+// ServiceWeights are the weights of a service in Consul DNS SRV responses
+// when the service is passing or warning.
+type ServiceWeights struct {
+	Passing int
+	Warning int
+}
+
+// Copy the weights. Returns nil if nil.
+func (w *ServiceWeights) Copy() *ServiceWeights {
+	if w == nil {
+		return nil
+	}
+	nw := new(ServiceWeights)
+	*nw = *w
+	return nw
+}
+
+// Equals returns true if the weights are equal.
+func (w *ServiceWeights) Equals(o *ServiceWeights) bool {
+	if w == nil || o == nil {
+		return w == o
+	}
+	return *w == *o
+}
+
+// Validate checks the weights are within the range accepted by Consul.
+func (w *ServiceWeights) Validate() error {
+	if w == nil {
+		return nil
+	}
+	if w.Passing < 1 {
+		return fmt.Errorf("Service weights passing must be at least 1; got %d", w.Passing)
+	}
+	if w.Warning < 0 {
+		return fmt.Errorf("Service weights warning must not be negative; got %d", w.Warning)
+	}
+	return nil
+}
+
 // ConsulConnect represents a Consul Connect jobspec stanza.
 type ConsulConnect struct {
 	// Native indicates whether the service is Consul Connect Native enabled.
@@ -764,6 +953,10 @@ type ConsulConnect struct {
 
 	// Gateway is a Consul Connect Gateway Proxy.
 	Gateway *ConsulGateway
+
+	// Certs configures how a Connect native task is notified when the
+	// certificates Nomad delivers to it change. Only valid if Native is set.
+	Certs *ConsulConnectCerts
 }
 
 // Copy the stanza recursively. Returns nil if nil.
@@ -777,6 +970,7 @@ func (c *ConsulConnect) Copy() *ConsulConnect {
 		SidecarService: c.SidecarService.Copy(),
 		SidecarTask:    c.SidecarTask.Copy(),
 		Gateway:        c.Gateway.Copy(),
+		Certs:          c.Certs.Copy(),
 	}
 }
 
@@ -802,6 +996,10 @@ func (c *ConsulConnect) Equals(o *ConsulConnect) bool {
 		return false
 	}
 
+	if !c.Certs.Equals(o.Certs) {
+		return false
+	}
+
 	return true
 }
 
@@ -834,6 +1032,11 @@ func (c *ConsulConnect) IsMesh() bool {
 	return c.IsGateway() && c.Gateway.Mesh != nil
 }
 
+// IsAPIGateway checks if the service is an API gateway.
+func (c *ConsulConnect) IsAPIGateway() bool {
+	return c.IsGateway() && c.Gateway.APIGateway != nil
+}
+
 // Validate that the Connect block represents exactly one of:
 // - Connect non-native service sidecar proxy
 // - Connect native service
@@ -869,11 +1072,85 @@ func (c *ConsulConnect) Validate() error {
 		}
 	}
 
+	if c.Certs != nil {
+		if !c.IsNative() {
+			return fmt.Errorf("Consul Connect certs are only supported by native services")
+		}
+		if err := c.Certs.Validate(); err != nil {
+			return err
+		}
+	}
+
 	// The Native and Sidecar cases are validated up at the service level.
 
 	return nil
 }
 
+const (
+	// ConnectCertsChangeModeNoop takes no action when the certificates of a
+	// Connect native task change.
+	ConnectCertsChangeModeNoop = "noop"
+
+	// ConnectCertsChangeModeSignal signals the task when its certificates
+	// change.
+	ConnectCertsChangeModeSignal = "signal"
+
+	// ConnectCertsChangeModeRestart restarts the task when its certificates
+	// change.
+	ConnectCertsChangeModeRestart = "restart"
+)
+
+// ConsulConnectCerts configures how a Connect native task is notified when
+// the leaf certificate and CA roots Nomad writes into its secrets directory
+// are rotated.
+type ConsulConnectCerts struct {
+	// ChangeMode is the action taken when the certificates change.
+	ChangeMode string
+
+	// ChangeSignal is the signal sent to the task when the certificates
+	// change. This is only valid when using the signal change mode.
+	ChangeSignal string
+}
+
+// Copy the stanza. Returns nil if nil.
+func (c *ConsulConnectCerts) Copy() *ConsulConnectCerts {
+	if c == nil {
+		return nil
+	}
+
+	nc := new(ConsulConnectCerts)
+	*nc = *c
+	return nc
+}
+
+// Equals returns true if the certs blocks are equal.
+func (c *ConsulConnectCerts) Equals(o *ConsulConnectCerts) bool {
+	if c == nil || o == nil {
+		return c == o
+	}
+
+	return c.ChangeMode == o.ChangeMode && c.ChangeSignal == o.ChangeSignal
+}
+
+// Validate the certs block.
+func (c *ConsulConnectCerts) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	switch c.ChangeMode {
+	case "", ConnectCertsChangeModeNoop, ConnectCertsChangeModeRestart:
+	case ConnectCertsChangeModeSignal:
+		if c.ChangeSignal == "" {
+			return fmt.Errorf("Consul Connect certs signal change mode requires a change signal")
+		}
+	default:
+		return fmt.Errorf("Consul Connect certs change mode %q is invalid. Must be one of: noop, signal, restart", c.ChangeMode)
+	}
+
+	return nil
+}
+
 // ConsulSidecarService represents a Consul Connect SidecarService jobspec
 // stanza.
 type ConsulSidecarService struct {
@@ -972,6 +1249,10 @@ type SidecarTask struct {
 	// KillSignal is the kill signal to use for the task. This is an optional
 	// specification and defaults to SIGINT
 	KillSignal string
+
+	// ScaleResources indicates the default resources of the task were sized
+	// by the number of upstreams and expose paths of the sidecar service
+	ScaleResources bool
 }
 
 func (t *SidecarTask) Equals(o *SidecarTask) bool {
@@ -1024,6 +1305,10 @@ func (t *SidecarTask) Equals(o *SidecarTask) bool {
 		return false
 	}
 
+	if t.ScaleResources != o.ScaleResources {
+		return false
+	}
+
 	return true
 }
 
@@ -1414,12 +1699,17 @@ type ConsulGateway struct {
 
 	// Mesh indicates the Consul service should be a Mesh Gateway.
 	Mesh *ConsulMeshConfigEntry
+
+	// APIGateway represents the Consul Configuration Entry for an API Gateway.
+	APIGateway *ConsulAPIGatewayConfigEntry
 }
 
 func (g *ConsulGateway) Prefix() string {
 	switch {
 	case g.Mesh != nil:
 		return ConnectMeshPrefix
+	case g.APIGateway != nil:
+		return ConnectAPIGatewayPrefix
 	case g.Ingress != nil:
 		return ConnectIngressPrefix
 	default:
@@ -1437,6 +1727,7 @@ func (g *ConsulGateway) Copy() *ConsulGateway {
 		Ingress:     g.Ingress.Copy(),
 		Terminating: g.Terminating.Copy(),
 		Mesh:        g.Mesh.Copy(),
+		APIGateway:  g.APIGateway.Copy(),
 	}
 }
 
@@ -1461,6 +1752,10 @@ func (g *ConsulGateway) Equals(o *ConsulGateway) bool {
 		return false
 	}
 
+	if !g.APIGateway.Equals(o.APIGateway) {
+		return false
+	}
+
 	return true
 }
 
@@ -1485,7 +1780,11 @@ func (g *ConsulGateway) Validate() error {
 		return err
 	}
 
-	// Exactly 1 of ingress/terminating/mesh must be set.
+	if err := g.APIGateway.Validate(); err != nil {
+		return err
+	}
+
+	// Exactly 1 of ingress/terminating/mesh/api_gateway must be set.
 	count := 0
 	if g.Ingress != nil {
 		count++
@@ -1496,6 +1795,9 @@ func (g *ConsulGateway) Validate() error {
 	if g.Mesh != nil {
 		count++
 	}
+	if g.APIGateway != nil {
+		count++
+	}
 	if count != 1 {
 		return fmt.Errorf("One Consul Gateway Configuration must be set")
 	}
@@ -2089,3 +2391,203 @@ func (e *ConsulMeshConfigEntry) Equals(o *ConsulMeshConfigEntry) bool {
 func (e *ConsulMeshConfigEntry) Validate() error {
 	return nil
 }
+
+// ConsulAPIGatewayTLSConfig is used to configure TLS for a listener of an API
+// Gateway.
+type ConsulAPIGatewayTLSConfig struct {
+	// Certificates are the names of the Consul certificate Configuration
+	// Entries used by the listener.
+	Certificates  []string
+	TLSMinVersion string
+	TLSMaxVersion string
+	CipherSuites  []string
+}
+
+func (c *ConsulAPIGatewayTLSConfig) Copy() *ConsulAPIGatewayTLSConfig {
+	if c == nil {
+		return nil
+	}
+
+	return &ConsulAPIGatewayTLSConfig{
+		Certificates:  helper.CopySliceString(c.Certificates),
+		TLSMinVersion: c.TLSMinVersion,
+		TLSMaxVersion: c.TLSMaxVersion,
+		CipherSuites:  helper.CopySliceString(c.CipherSuites),
+	}
+}
+
+func (c *ConsulAPIGatewayTLSConfig) Equals(o *ConsulAPIGatewayTLSConfig) bool {
+	if c == nil || o == nil {
+		return c == o
+	}
+
+	switch {
+	case c.TLSMinVersion != o.TLSMinVersion:
+		return false
+	case c.TLSMaxVersion != o.TLSMaxVersion:
+		return false
+	case !helper.CompareSliceSetString(c.Certificates, o.Certificates):
+		return false
+	case !helper.CompareSliceSetString(c.CipherSuites, o.CipherSuites):
+		return false
+	}
+
+	return true
+}
+
+func (c *ConsulAPIGatewayTLSConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if len(c.Certificates) == 0 {
+		return fmt.Errorf("Consul API Gateway Listener TLS requires at least one certificate")
+	}
+
+	versions := []string{"TLS_AUTO", "TLSv1_0", "TLSv1_1", "TLSv1_2", "TLSv1_3"}
+	if c.TLSMinVersion != "" && !helper.SliceStringContains(versions, c.TLSMinVersion) {
+		return fmt.Errorf("Consul API Gateway Listener TLS requires tls_min_version of %s, got %q", strings.Join(versions, ", "), c.TLSMinVersion)
+	}
+	if c.TLSMaxVersion != "" && !helper.SliceStringContains(versions, c.TLSMaxVersion) {
+		return fmt.Errorf("Consul API Gateway Listener TLS requires tls_max_version of %s, got %q", strings.Join(versions, ", "), c.TLSMaxVersion)
+	}
+
+	return nil
+}
+
+// ConsulAPIGatewayListener is used to configure a listener of an API Gateway.
+type ConsulAPIGatewayListener struct {
+	Name     string
+	Hostname string
+	Port     int
+	Protocol string
+	TLS      *ConsulAPIGatewayTLSConfig
+}
+
+func (l *ConsulAPIGatewayListener) Copy() *ConsulAPIGatewayListener {
+	if l == nil {
+		return nil
+	}
+
+	return &ConsulAPIGatewayListener{
+		Name:     l.Name,
+		Hostname: l.Hostname,
+		Port:     l.Port,
+		Protocol: l.Protocol,
+		TLS:      l.TLS.Copy(),
+	}
+}
+
+func (l *ConsulAPIGatewayListener) Equals(o *ConsulAPIGatewayListener) bool {
+	if l == nil || o == nil {
+		return l == o
+	}
+
+	switch {
+	case l.Name != o.Name:
+		return false
+	case l.Hostname != o.Hostname:
+		return false
+	case l.Port != o.Port:
+		return false
+	case l.Protocol != o.Protocol:
+		return false
+	}
+
+	return l.TLS.Equals(o.TLS)
+}
+
+func (l *ConsulAPIGatewayListener) Validate() error {
+	if l == nil {
+		return nil
+	}
+
+	if l.Name == "" {
+		return fmt.Errorf("Consul API Gateway Listener requires Name")
+	}
+
+	if l.Port <= 0 {
+		return fmt.Errorf("Consul API Gateway Listener requires valid Port")
+	}
+
+	protocols := []string{"tcp", "http"}
+	if !helper.SliceStringContains(protocols, l.Protocol) {
+		return fmt.Errorf(`Consul API Gateway Listener requires protocol of %s, got %q`, strings.Join(protocols, ", "), l.Protocol)
+	}
+
+	return l.TLS.Validate()
+}
+
+// ConsulAPIGatewayConfigEntry represents the Consul Configuration Entry type
+// for an API Gateway.
+//
+// https://www.consul.io/docs/connect/config-entries/api-gateway
+type ConsulAPIGatewayConfigEntry struct {
+	Listeners []*ConsulAPIGatewayListener
+}
+
+func (e *ConsulAPIGatewayConfigEntry) Copy() *ConsulAPIGatewayConfigEntry {
+	if e == nil {
+		return nil
+	}
+
+	var listeners []*ConsulAPIGatewayListener = nil
+	if n := len(e.Listeners); n > 0 {
+		listeners = make([]*ConsulAPIGatewayListener, n)
+		for i := 0; i < n; i++ {
+			listeners[i] = e.Listeners[i].Copy()
+		}
+	}
+
+	return &ConsulAPIGatewayConfigEntry{
+		Listeners: listeners,
+	}
+}
+
+func (e *ConsulAPIGatewayConfigEntry) Equals(o *ConsulAPIGatewayConfigEntry) bool {
+	if e == nil || o == nil {
+		return e == o
+	}
+
+	return apiGatewayListenersEqual(e.Listeners, o.Listeners)
+}
+
+func (e *ConsulAPIGatewayConfigEntry) Validate() error {
+	if e == nil {
+		return nil
+	}
+
+	if len(e.Listeners) == 0 {
+		return fmt.Errorf("Consul API Gateway requires at least one listener")
+	}
+
+	names := make(map[string]bool, len(e.Listeners))
+	for _, listener := range e.Listeners {
+		if err := listener.Validate(); err != nil {
+			return err
+		}
+		if names[listener.Name] {
+			return fmt.Errorf("Consul API Gateway Listener %q defined more than once", listener.Name)
+		}
+		names[listener.Name] = true
+	}
+
+	return nil
+}
+
+func apiGatewayListenersEqual(listenersA, listenersB []*ConsulAPIGatewayListener) bool {
+	if len(listenersA) != len(listenersB) {
+		return false
+	}
+
+COMPARE: // order does not matter
+	for _, listenerA := range listenersA {
+		for _, listenerB := range listenersB {
+			if listenerA.Equals(listenerB) {
+				continue COMPARE
+			}
+		}
+		return false
+	}
+	return true
+}
@@ -51,6 +51,34 @@ func TestServiceCheck_Hash(t *testing.T) {
 	t.Run("failures_before_critical", func(t *testing.T) {
 		try(t, func(s *sc) { s.FailuresBeforeCritical = 99 })
 	})
+
+	t.Run("tls_server_name", func(t *testing.T) {
+		try(t, func(s *sc) { s.TLSServerName = "api.example.com" })
+	})
+
+	t.Run("tls_client_cert", func(t *testing.T) {
+		try(t, func(s *sc) { s.TLSClientCert = "secrets/client.pem" })
+	})
+
+	t.Run("value moved between fields", func(t *testing.T) {
+		// the same value in different fields hashes differently
+		moved := func(fields ...func(*sc)) {
+			hashes := make(map[string]bool)
+			for _, set := range fields {
+				c := original.Copy()
+				set(c)
+				hashes[hash(c)] = true
+			}
+			require.Len(t, hashes, len(fields))
+		}
+		moved(
+			func(s *sc) { s.TLSServerName = "x" },
+			func(s *sc) { s.TLSCACert = "x" },
+			func(s *sc) { s.TLSClientCert = "x" },
+			func(s *sc) { s.TLSClientKey = "x" },
+			func(s *sc) { s.Namespace = "x" },
+		)
+	})
 }
 
 func TestServiceCheck_validate_PassingTypes(t *testing.T) {
@@ -186,6 +214,68 @@ func TestServiceCheck_validate_OnUpdate_CheckRestart_Conflict(t *testing.T) {
 	})
 }
 
+func TestServiceCheck_validate_TLS(t *testing.T) {
+	ci.Parallel(t)
+
+	base := func() *ServiceCheck {
+		return &ServiceCheck{
+			Name:          "check",
+			Type:          ServiceCheckGRPC,
+			Interval:      1 * time.Second,
+			Timeout:       2 * time.Second,
+			GRPCUseTLS:    true,
+			TLSServerName: "api.example.com",
+			TLSCACert:     "local/ca.pem",
+			TLSClientCert: "secrets/client.pem",
+			TLSClientKey:  "secrets/client-key.pem",
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		sc := base()
+		require.NoError(t, sc.validate())
+		require.True(t, sc.RequiresClientTLS())
+	})
+
+	t.Run("server name on tcp", func(t *testing.T) {
+		sc := &ServiceCheck{
+			Name:          "check",
+			Type:          ServiceCheckTCP,
+			Interval:      1 * time.Second,
+			Timeout:       2 * time.Second,
+			TLSServerName: "api.example.com",
+		}
+		require.EqualError(t, sc.validate(), "tls_server_name may only be set on HTTP or gRPC checks")
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		sc := base()
+		sc.TLSClientKey = ""
+		require.EqualError(t, sc.validate(), "tls_client_cert and tls_client_key must be set together")
+	})
+
+	t.Run("without tls", func(t *testing.T) {
+		sc := base()
+		sc.GRPCUseTLS = false
+		require.EqualError(t, sc.validate(), "gRPC checks with client certificates must set grpc_use_tls")
+	})
+
+	t.Run("driver address mode", func(t *testing.T) {
+		sc := base()
+		sc.AddressMode = AddressModeDriver
+		require.EqualError(t, sc.validate(), `gRPC checks with client certificates only support address_mode "host"`)
+	})
+
+	t.Run("no client cert", func(t *testing.T) {
+		sc := base()
+		sc.TLSCACert = ""
+		sc.TLSClientCert = ""
+		sc.TLSClientKey = ""
+		require.NoError(t, sc.validate())
+		require.False(t, sc.RequiresClientTLS())
+	})
+}
+
 func TestService_Hash(t *testing.T) {
 	ci.Parallel(t)
 
@@ -291,6 +381,68 @@ func TestService_Hash(t *testing.T) {
 	t.Run("mod connect sidecar proxy upstream dest local bind port", func(t *testing.T) {
 		try(t, func(s *svc) { s.Connect.SidecarService.Proxy.Upstreams[0].LocalBindPort = 29999 })
 	})
+
+	t.Run("mod weights", func(t *testing.T) {
+		try(t, func(s *svc) { s.Weights = &ServiceWeights{Passing: 10, Warning: 1} })
+	})
+}
+
+func TestServiceWeights_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	require.NoError(t, (*ServiceWeights)(nil).Validate())
+	require.NoError(t, (&ServiceWeights{Passing: 1, Warning: 0}).Validate())
+	require.EqualError(t, (&ServiceWeights{Passing: 0, Warning: 1}).Validate(),
+		"Service weights passing must be at least 1; got 0")
+	require.EqualError(t, (&ServiceWeights{Passing: 1, Warning: -1}).Validate(),
+		"Service weights warning must not be negative; got -1")
+}
+
+func TestServiceWeights_CopyEquals(t *testing.T) {
+	ci.Parallel(t)
+
+	w := &ServiceWeights{Passing: 10, Warning: 2}
+	c := w.Copy()
+	require.True(t, w.Equals(c))
+
+	c.Warning = 3
+	require.False(t, w.Equals(c))
+	require.False(t, w.Equals(nil))
+	require.True(t, (*ServiceWeights)(nil).Equals(nil))
+}
+
+func TestService_ConsulNamespace(t *testing.T) {
+	ci.Parallel(t)
+
+	// Services stored by earlier versions always have the "default"
+	// namespace, which is also the canonical namespace of services without
+	// one, so they are equal and keep their hash
+	stored := &Service{Name: "web", PortLabel: "http", Namespace: "default"}
+	job := &Job{Name: "job", TaskGroups: []*TaskGroup{{
+		Name:     "group",
+		Consul:   &Consul{Namespace: "group-ns"},
+		Services: []*Service{{Name: "web", PortLabel: "http"}},
+	}}}
+	job.Canonicalize()
+	registered := job.TaskGroups[0].Services[0]
+	require.Equal(t, "default", registered.Namespace)
+	require.True(t, stored.Equals(registered))
+	require.Equal(t, stored.Hash("alloc", "task", false), registered.Hash("alloc", "task", false))
+
+	// Services without a namespace or canonicalized to the "default"
+	// namespace fall back to the namespace of the group
+	unset := &Service{Name: "web", PortLabel: "http"}
+	require.Equal(t, "group-ns", unset.ConsulNamespace("group-ns"))
+	require.Equal(t, "group-ns", stored.ConsulNamespace("group-ns"))
+	require.Equal(t, "", stored.ConsulNamespace(""))
+
+	// Explicit namespaces, including "default", take precedence
+	explicit := &Service{Name: "web", PortLabel: "http", Namespace: "default", ExplicitNamespace: true}
+	require.Equal(t, "default", explicit.ConsulNamespace("group-ns"))
+	require.False(t, explicit.Equals(stored))
+	require.NotEqual(t, stored.Hash("alloc", "task", false), explicit.Hash("alloc", "task", false))
+	override := &Service{Name: "web", PortLabel: "http", Namespace: "team-a"}
+	require.Equal(t, "team-a", override.ConsulNamespace("group-ns"))
 }
 
 func TestConsulConnect_Validate(t *testing.T) {
@@ -312,6 +464,39 @@ func TestConsulConnect_Validate(t *testing.T) {
 	require.NoError(t, c.Validate())
 }
 
+func TestConsulConnect_Validate_Certs(t *testing.T) {
+	ci.Parallel(t)
+
+	c := &ConsulConnect{
+		Native: true,
+		Certs:  &ConsulConnectCerts{ChangeMode: ConnectCertsChangeModeRestart},
+	}
+	require.NoError(t, c.Validate())
+
+	c.Certs.ChangeMode = ConnectCertsChangeModeSignal
+	require.EqualError(t, c.Validate(), "Consul Connect certs signal change mode requires a change signal")
+
+	c.Certs.ChangeSignal = "SIGHUP"
+	require.NoError(t, c.Validate())
+
+	c.Certs.ChangeMode = "reload"
+	require.EqualError(t, c.Validate(), `Consul Connect certs change mode "reload" is invalid. Must be one of: noop, signal, restart`)
+
+	// certs are only delivered to native services
+	c.Native = false
+	c.SidecarService = &ConsulSidecarService{}
+	c.Certs.ChangeMode = ConnectCertsChangeModeNoop
+	require.EqualError(t, c.Validate(), "Consul Connect certs are only supported by native services")
+
+	// copies include the certs block
+	c.Native = true
+	c.SidecarService = nil
+	o := c.Copy()
+	require.True(t, c.Equals(o))
+	o.Certs.ChangeSignal = "SIGUSR1"
+	require.False(t, c.Equals(o))
+}
+
 func TestConsulConnect_CopyEquals(t *testing.T) {
 	ci.Parallel(t)
 
@@ -698,6 +883,29 @@ var (
 			// nothing
 		},
 	}
+
+	consulAPIGateway1 = &ConsulGateway{
+		Proxy: &ConsulGatewayProxy{
+			ConnectTimeout: helper.TimeToPtr(1 * time.Second),
+		},
+		APIGateway: &ConsulAPIGatewayConfigEntry{
+			Listeners: []*ConsulAPIGatewayListener{{
+				Name:     "http",
+				Hostname: "*.example.com",
+				Port:     8080,
+				Protocol: "http",
+				TLS: &ConsulAPIGatewayTLSConfig{
+					Certificates:  []string{"example-cert"},
+					TLSMinVersion: "TLSv1_2",
+					CipherSuites:  []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"},
+				},
+			}, {
+				Name:     "tcp",
+				Port:     9090,
+				Protocol: "tcp",
+			}},
+		},
+	}
 )
 
 func TestConsulGateway_Prefix(t *testing.T) {
@@ -717,6 +925,11 @@ func TestConsulGateway_Prefix(t *testing.T) {
 		result := (&ConsulGateway{Mesh: new(ConsulMeshConfigEntry)}).Prefix()
 		require.Equal(t, ConnectMeshPrefix, result)
 	})
+
+	t.Run("api gateway", func(t *testing.T) {
+		result := (&ConsulGateway{APIGateway: new(ConsulAPIGatewayConfigEntry)}).Prefix()
+		require.Equal(t, ConnectAPIGatewayPrefix, result)
+	})
 }
 
 func TestConsulGateway_Copy(t *testing.T) {
@@ -748,6 +961,13 @@ func TestConsulGateway_Copy(t *testing.T) {
 		require.True(t, result.Equals(consulMeshGateway1))
 		require.True(t, consulMeshGateway1.Equals(result))
 	})
+
+	t.Run("as api gateway", func(t *testing.T) {
+		result := consulAPIGateway1.Copy()
+		require.Equal(t, consulAPIGateway1, result)
+		require.True(t, result.Equals(consulAPIGateway1))
+		require.True(t, consulAPIGateway1.Equals(result))
+	})
 }
 
 func TestConsulGateway_Equals_mesh(t *testing.T) {
@@ -971,6 +1191,55 @@ func TestConsulGateway_ingressListenersEqual(t *testing.T) {
 	require.True(t, ingressListenersEqual(ils1, reversed))
 }
 
+func TestConsulGateway_Equals_apiGateway(t *testing.T) {
+	ci.Parallel(t)
+
+	original := consulAPIGateway1.Copy()
+
+	type cg = ConsulGateway
+	type tweaker = func(c *cg)
+
+	t.Run("reflexive", func(t *testing.T) {
+		require.True(t, original.Equals(original))
+	})
+
+	try := func(t *testing.T, tweak tweaker) {
+		modifiable := original.Copy()
+		tweak(modifiable)
+		require.False(t, original.Equals(modifiable))
+		require.False(t, modifiable.Equals(original))
+		require.True(t, modifiable.Equals(modifiable))
+	}
+
+	t.Run("mod listeners count", func(t *testing.T) {
+		try(t, func(g *cg) { g.APIGateway.Listeners = g.APIGateway.Listeners[:1] })
+	})
+
+	t.Run("mod listeners name", func(t *testing.T) {
+		try(t, func(g *cg) { g.APIGateway.Listeners[0].Name = "https" })
+	})
+
+	t.Run("mod listeners hostname", func(t *testing.T) {
+		try(t, func(g *cg) { g.APIGateway.Listeners[0].Hostname = "example.org" })
+	})
+
+	t.Run("mod listeners port", func(t *testing.T) {
+		try(t, func(g *cg) { g.APIGateway.Listeners[0].Port = 7777 })
+	})
+
+	t.Run("mod listeners protocol", func(t *testing.T) {
+		try(t, func(g *cg) { g.APIGateway.Listeners[0].Protocol = "tcp" })
+	})
+
+	t.Run("mod listeners tls", func(t *testing.T) {
+		try(t, func(g *cg) { g.APIGateway.Listeners[0].TLS = nil })
+		try(t, func(g *cg) { g.APIGateway.Listeners[0].TLS.Certificates = []string{"other-cert"} })
+		try(t, func(g *cg) { g.APIGateway.Listeners[0].TLS.TLSMinVersion = "TLSv1_3" })
+		try(t, func(g *cg) { g.APIGateway.Listeners[0].TLS.TLSMaxVersion = "TLSv1_3" })
+		try(t, func(g *cg) { g.APIGateway.Listeners[0].TLS.CipherSuites = nil })
+	})
+}
+
 func TestConsulGateway_Validate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1037,6 +1306,20 @@ func TestConsulGateway_Validate(t *testing.T) {
 		}).Validate()
 		require.NoError(t, err)
 	})
+
+	t.Run("bad api gateway config entry", func(t *testing.T) {
+		err := (&ConsulGateway{
+			APIGateway: &ConsulAPIGatewayConfigEntry{
+				Listeners: nil,
+			},
+		}).Validate()
+		require.EqualError(t, err, "Consul API Gateway requires at least one listener")
+	})
+
+	t.Run("ok api gateway", func(t *testing.T) {
+		err := consulAPIGateway1.Validate()
+		require.NoError(t, err)
+	})
 }
 
 func TestConsulGatewayBindAddress_Validate(t *testing.T) {
@@ -1435,6 +1718,69 @@ func TestConsulTerminatingConfigEntry_Validate(t *testing.T) {
 	})
 }
 
+func TestConsulAPIGatewayConfigEntry_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	listener := func(tweak func(l *ConsulAPIGatewayListener)) *ConsulAPIGatewayConfigEntry {
+		l := &ConsulAPIGatewayListener{
+			Name:     "http",
+			Port:     8080,
+			Protocol: "http",
+		}
+		tweak(l)
+		return &ConsulAPIGatewayConfigEntry{Listeners: []*ConsulAPIGatewayListener{l}}
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		err := (*ConsulAPIGatewayConfigEntry)(nil).Validate()
+		require.NoError(t, err)
+	})
+
+	t.Run("listener no name", func(t *testing.T) {
+		err := listener(func(l *ConsulAPIGatewayListener) { l.Name = "" }).Validate()
+		require.EqualError(t, err, "Consul API Gateway Listener requires Name")
+	})
+
+	t.Run("listener bad port", func(t *testing.T) {
+		err := listener(func(l *ConsulAPIGatewayListener) { l.Port = 0 }).Validate()
+		require.EqualError(t, err, "Consul API Gateway Listener requires valid Port")
+	})
+
+	t.Run("listener bad protocol", func(t *testing.T) {
+		err := listener(func(l *ConsulAPIGatewayListener) { l.Protocol = "grpc" }).Validate()
+		require.EqualError(t, err, `Consul API Gateway Listener requires protocol of tcp, http, got "grpc"`)
+	})
+
+	t.Run("listener tls no certificates", func(t *testing.T) {
+		err := listener(func(l *ConsulAPIGatewayListener) {
+			l.TLS = new(ConsulAPIGatewayTLSConfig)
+		}).Validate()
+		require.EqualError(t, err, "Consul API Gateway Listener TLS requires at least one certificate")
+	})
+
+	t.Run("listener tls bad version", func(t *testing.T) {
+		err := listener(func(l *ConsulAPIGatewayListener) {
+			l.TLS = &ConsulAPIGatewayTLSConfig{
+				Certificates:  []string{"cert"},
+				TLSMinVersion: "SSLv3",
+			}
+		}).Validate()
+		require.EqualError(t, err, `Consul API Gateway Listener TLS requires tls_min_version of TLS_AUTO, TLSv1_0, TLSv1_1, TLSv1_2, TLSv1_3, got "SSLv3"`)
+	})
+
+	t.Run("duplicate listener names", func(t *testing.T) {
+		entry := listener(func(*ConsulAPIGatewayListener) {})
+		entry.Listeners = append(entry.Listeners, entry.Listeners[0].Copy())
+		err := entry.Validate()
+		require.EqualError(t, err, `Consul API Gateway Listener "http" defined more than once`)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		err := listener(func(*ConsulAPIGatewayListener) {}).Validate()
+		require.NoError(t, err)
+	})
+}
+
 func TestConsulMeshGateway_Copy(t *testing.T) {
 	ci.Parallel(t)
 
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"hash"
 	"hash/crc32"
+	"hash/fnv"
 	"math"
 	"net"
 	"os"
@@ -105,6 +106,16 @@ const (
 	OneTimeTokenUpsertRequestType                MessageType = 44
 	OneTimeTokenDeleteRequestType                MessageType = 45
 	OneTimeTokenExpireRequestType                MessageType = 46
+	ScheduledScalingUpsertRequestType            MessageType = 47
+	ScheduledScalingDeleteRequestType            MessageType = 48
+	JobVersionTagRequestType                     MessageType = 49
+	JobBundleRegisterRequestType                 MessageType = 50
+	ScalingPolicyUpsertRequestType               MessageType = 51
+	ScalingPolicyDeleteRequestType               MessageType = 52
+	WorkloadIdentityKeysUpsertRequestType        MessageType = 53
+	JobMoveRequestType                           MessageType = 54
+	EventCursorUpsertRequestType                 MessageType = 55
+	EventCursorDeleteRequestType                 MessageType = 56
 
 	// Namespace types were moved from enterprise and therefore start at 64
 	NamespaceUpsertRequestType MessageType = 64
@@ -201,6 +212,7 @@ type RPCInfo interface {
 	RequestRegion() string
 	IsRead() bool
 	AllowStaleRead() bool
+	MaxStaleRead() time.Duration
 	IsForwarded() bool
 	SetForwarded()
 	TimeToBlock() time.Duration
@@ -253,6 +265,12 @@ type QueryOptions struct {
 	// may be arbitrarily stale.
 	AllowStale bool
 
+	// MaxStale bounds the staleness of reads when AllowStale is set. Followers
+	// that haven't been in contact with the leader within the bound, or
+	// haven't applied the entries it had committed, forward the request to
+	// the leader. Zero uses the server's default.
+	MaxStale time.Duration
+
 	// If set, used as prefix for resource list searches
 	Prefix string
 
@@ -313,6 +331,12 @@ func (q QueryOptions) RequestNamespace() string {
 	return q.Namespace
 }
 
+// RequestAuthToken returns the secret ID of the ACL token the request is made
+// with.
+func (q QueryOptions) RequestAuthToken() string {
+	return q.AuthToken
+}
+
 // IsRead only applies to reads, so always true.
 func (q QueryOptions) IsRead() bool {
 	return true
@@ -322,6 +346,10 @@ func (q QueryOptions) AllowStaleRead() bool {
 	return q.AllowStale
 }
 
+func (q QueryOptions) MaxStaleRead() time.Duration {
+	return q.MaxStale
+}
+
 // AgentPprofRequest is used to request a pprof report for a given node.
 type AgentPprofRequest struct {
 	// ReqType specifies the profile to use
@@ -409,6 +437,12 @@ func (w WriteRequest) RequestNamespace() string {
 	return w.Namespace
 }
 
+// RequestAuthToken returns the secret ID of the ACL token the request is made
+// with.
+func (w WriteRequest) RequestAuthToken() string {
+	return w.AuthToken
+}
+
 // IsRead only applies to writes, always false.
 func (w WriteRequest) IsRead() bool {
 	return false
@@ -418,6 +452,10 @@ func (w WriteRequest) AllowStaleRead() bool {
 	return false
 }
 
+func (w WriteRequest) MaxStaleRead() time.Duration {
+	return 0
+}
+
 // QueryMeta allows a query response to include potentially
 // useful metadata about a query
 type QueryMeta struct {
@@ -426,9 +464,16 @@ type QueryMeta struct {
 
 	// If AllowStale is used, this is time elapsed since
 	// last contact between the follower and leader. This
-	// can be used to gauge staleness.
+	// can be used to gauge staleness, and is always within
+	// the MaxStale bound of the request.
 	LastContact time.Duration
 
+	// If AllowStale is used, this is the number of log entries
+	// the follower knows the leader has committed but hasn't
+	// applied yet. Reads bounded by MaxStale are only served
+	// by followers without any lag.
+	IndexLag uint64
+
 	// Used to indicate if there is a known leader node
 	KnownLeader bool
 
@@ -567,6 +612,59 @@ type NodeSpecificRequest struct {
 	QueryOptions
 }
 
+// NodeMetaApplyRequest is used to set or unset the dynamic metadata of a
+// node via the NodeMeta.Apply endpoint.
+type NodeMetaApplyRequest struct {
+	// QueryOptions is used rather than WriteRequest because the request is
+	// applied by the client and never written to Raft, so any server can
+	// forward it to the node.
+	QueryOptions
+
+	NodeID string
+
+	// Meta maps keys to their new value. A nil value unsets the key, even if
+	// it is set in the client configuration.
+	Meta map[string]*string
+}
+
+// Validate returns an error if the request doesn't contain any metadata or
+// contains an invalid key.
+func (r *NodeMetaApplyRequest) Validate() error {
+	if len(r.Meta) == 0 {
+		return fmt.Errorf("missing required Meta object")
+	}
+
+	var mErr multierror.Error
+	for k := range r.Meta {
+		if k == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("metadata keys must not be empty"))
+		} else if strings.ContainsAny(k, " \t\n${}") {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("metadata key %q contains invalid characters", k))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// NodeMetaResponse is the metadata of a node, returned by the NodeMeta
+// endpoints.
+type NodeMetaResponse struct {
+	// Meta is the metadata of the node, which merges the Dynamic metadata
+	// into the Static metadata
+	Meta map[string]string
+
+	// Dynamic is the metadata set via the API, where a nil value unsets a
+	// key of the Static metadata
+	Dynamic map[string]*string
+
+	// Static is the metadata set in the client configuration
+	Static map[string]string
+
+	// QueryMeta.Index is the index of the metadata on the node, which is
+	// incremented each time the metadata changes and can be used for
+	// blocking queries. It is reset when the client restarts.
+	QueryMeta
+}
+
 // JobRegisterRequest is used for Job.Register endpoint
 // to register a job as being a schedulable entity.
 type JobRegisterRequest struct {
@@ -597,9 +695,21 @@ type JobRegisterRequest struct {
 	// Eval is the evaluation that is associated with the job registration
 	Eval *Evaluation
 
+	// Async enqueues the registration on the leader instead of applying it
+	// right away. The response only holds the ID of the submission, which is
+	// polled for the result of the registration.
+	Async bool
+
 	WriteRequest
 }
 
+// JobRegisterSubmissionRequest is used for Job.GetRegisterSubmission endpoint
+// to read the status of an asynchronous job registration.
+type JobRegisterSubmissionRequest struct {
+	SubmissionID string
+	QueryOptions
+}
+
 // JobDeregisterRequest is used for Job.Deregister endpoint
 // to deregister a job as being a schedulable entity.
 type JobDeregisterRequest struct {
@@ -644,6 +754,26 @@ type JobBatchDeregisterRequest struct {
 	WriteRequest
 }
 
+// JobBundleRegisterRequest is used to register a set of jobs atomically.
+// Either all the jobs of the bundle are registered or none are.
+type JobBundleRegisterRequest struct {
+	// BundleID identifies the bundle. It's generated by the server if not
+	// set.
+	BundleID string
+
+	// Jobs is the set of jobs to register. All jobs must be in the
+	// namespace of the request.
+	Jobs []*Job
+
+	// Evals is the set of evaluations to create, set by the server.
+	Evals []*Evaluation
+
+	// PolicyOverride is set when the user is attempting to override any policies
+	PolicyOverride bool
+
+	WriteRequest
+}
+
 // JobDeregisterOptions configures how a job is deregistered.
 type JobDeregisterOptions struct {
 	// Purge controls whether the deregister purges the job from the system or
@@ -668,6 +798,12 @@ type EvalOptions struct {
 type JobSpecificRequest struct {
 	JobID string
 	All   bool
+
+	// FromIndex and ToIndex bound the create indexes of the deployments and
+	// evaluations listed for the job. Zero values leave the window open.
+	FromIndex uint64
+	ToIndex   uint64
+
 	QueryOptions
 }
 
@@ -689,14 +825,20 @@ type JobPlanRequest struct {
 // JobScaleRequest is used for the Job.Scale endpoint to scale one of the
 // scaling targets in a job
 type JobScaleRequest struct {
-	JobID   string
-	Target  map[string]string
-	Count   *int64
-	Message string
-	Error   bool
-	Meta    map[string]interface{}
+	JobID  string
+	Target map[string]string
+	Count  *int64
+	// Resources are the new resources of the group's tasks, keyed by task
+	// name, for vertical scaling
+	Resources map[string]*ScalingResources
+	Message   string
+	Error     bool
+	Meta      map[string]interface{}
 	// PolicyOverride is set when the user is attempting to override any policies
 	PolicyOverride bool
+	// ScheduleAt defers a count change until the given time. The zero value
+	// applies the change immediately.
+	ScheduleAt time.Time
 	WriteRequest
 }
 
@@ -733,6 +875,28 @@ func (r *JobScaleRequest) Validate() error {
 		}
 	}
 
+	if len(r.Resources) != 0 && r.Error {
+		return NewErrRPCCoded(400, "scaling action should not contain resources if error is true")
+	}
+
+	for task, res := range r.Resources {
+		if res == nil {
+			return NewErrRPCCoded(400, fmt.Sprintf("missing scaling action resources for task %q", task))
+		}
+		if res.CPU < 0 || res.MemoryMB < 0 {
+			return NewErrRPCCoded(400, fmt.Sprintf("scaling action resources for task %q can't be negative", task))
+		}
+	}
+
+	if !r.ScheduleAt.IsZero() {
+		if r.Count == nil {
+			return NewErrRPCCoded(400, "scheduled scaling action must contain a count")
+		}
+		if len(r.Resources) != 0 {
+			return NewErrRPCCoded(400, "scheduled scaling action should not contain resources")
+		}
+	}
+
 	return nil
 }
 
@@ -805,6 +969,58 @@ type JobStabilityResponse struct {
 	WriteMeta
 }
 
+// JobVersionTagRequest is used to tag a job version, or to remove the tag
+// of a job version.
+type JobVersionTagRequest struct {
+	// Job and version to tag
+	JobID      string
+	JobVersion uint64
+
+	// Tag is the tag to set, or nil to remove the tag of the version
+	Tag *JobVersionTag
+	WriteRequest
+}
+
+// JobVersionTagResponse is the response when tagging a job version.
+type JobVersionTagResponse struct {
+	WriteMeta
+}
+
+// JobMoveRequest is used to move a job to a different namespace, to rename
+// it, or both. The request namespace is the namespace the job is moved from.
+type JobMoveRequest struct {
+	// JobID is the ID of the job being moved
+	JobID string
+
+	// NewNamespace is the namespace the job is moved to. It defaults to the
+	// request namespace.
+	NewNamespace string
+
+	// NewJobID is the ID of the job once moved. It defaults to JobID.
+	NewJobID string
+
+	// VaultToken and ConsulToken are the tokens the Vault policies and the
+	// Consul usages of the job are checked against when it's admitted to the
+	// new namespace, as when the job is registered. They are cleared before
+	// the move is committed.
+	VaultToken  string
+	ConsulToken string
+
+	// Eval is the evaluation created for the moved job. It is set by the
+	// leader and is nil for stopped jobs.
+	Eval *Evaluation
+
+	WriteRequest
+}
+
+// JobMoveResponse is the response when moving a job.
+type JobMoveResponse struct {
+	EvalID          string
+	EvalCreateIndex uint64
+	JobModifyIndex  uint64
+	WriteMeta
+}
+
 // NodeListRequest is used to parameterize a list request
 type NodeListRequest struct {
 	QueryOptions
@@ -910,6 +1126,15 @@ type ApplyPlanResultsRequest struct {
 	// PreemptionEvals is a slice of follow up evals for jobs whose allocations
 	// have been preempted to place allocs in this plan
 	PreemptionEvals []*Evaluation
+
+	// PlanApplyResult records the nodes accepted and rejected by the plan
+	// applier. It is added to the plan result history of the job.
+	PlanApplyResult *PlanApplyResult
+
+	// RejectedPlanResults are the results of the plans whose nodes were all
+	// rejected since the previous plan was applied. The plan applier doesn't
+	// apply those plans, so their results are recorded with this one.
+	RejectedPlanResults []*JobPlanResults
 }
 
 // AllocUpdateRequest is used to submit changes to allocations, either
@@ -1045,6 +1270,10 @@ type DeriveVaultTokenRequest struct {
 	SecretID string
 	AllocID  string
 	Tasks    []string
+
+	// Cluster is the name of the Vault cluster to derive the tokens from,
+	// or empty for the default cluster.
+	Cluster string
 	QueryOptions
 }
 
@@ -1062,6 +1291,10 @@ type VaultAccessor struct {
 	Accessor    string
 	CreationTTL int
 
+	// Cluster is the name of the Vault cluster the token was created on, or
+	// empty for the default cluster.
+	Cluster string
+
 	// Raft Indexes
 	CreateIndex uint64
 }
@@ -1124,9 +1357,36 @@ type DeploymentAllocHealthRequest struct {
 	// Any unhealthy allocations fail the deployment
 	UnhealthyAllocationIDs []string
 
+	// HealthyTaskGroups and UnhealthyTaskGroups mark the non-terminal
+	// allocations of these task groups as healthy or unhealthy. They are
+	// resolved into allocation IDs by the leader.
+	HealthyTaskGroups   []string
+	UnhealthyTaskGroups []string
+
+	// Reason is the reason recorded for the allocations without a reason
+	// in Reasons.
+	Reason string
+
+	// Reasons is the reason recorded for each allocation, keyed by
+	// allocation ID.
+	Reasons map[string]string
+
+	// SetBy is the accessor ID of the ACL token setting the health. It is
+	// set by the leader and is empty if ACLs are disabled.
+	SetBy string
+
 	WriteRequest
 }
 
+// AllocReason returns the reason recorded for setting the health of the
+// allocation.
+func (r *DeploymentAllocHealthRequest) AllocReason(allocID string) string {
+	if reason, ok := r.Reasons[allocID]; ok {
+		return reason
+	}
+	return r.Reason
+}
+
 // ApplyDeploymentAllocHealthRequest is used to apply an alloc health request via Raft
 type ApplyDeploymentAllocHealthRequest struct {
 	DeploymentAllocHealthRequest
@@ -1156,6 +1416,10 @@ type DeploymentPromoteRequest struct {
 	// Groups is used to set the promotion status per task group
 	Groups []string
 
+	// Percent is the percentage of each promoted group's allocations the
+	// deployment may roll out to. Zero promotes the groups fully.
+	Percent int
+
 	WriteRequest
 }
 
@@ -1211,6 +1475,11 @@ type DeploymentSpecificRequest struct {
 // DeploymentFailRequest is used to fail a particular deployment
 type DeploymentFailRequest struct {
 	DeploymentID string
+
+	// Reason is an optional explanation of why the deployment is failed,
+	// recorded on the deployment.
+	Reason string
+
 	WriteRequest
 }
 
@@ -1226,6 +1495,26 @@ type SingleScalingPolicyResponse struct {
 	QueryMeta
 }
 
+// ScalingPolicyUpsertRequest is used to create or update a standalone scaling
+// policy, which is managed independently of its job's specification
+type ScalingPolicyUpsertRequest struct {
+	Policy *ScalingPolicy
+	WriteRequest
+}
+
+// ScalingPolicyUpsertResponse is used to respond to a scaling policy upsert
+type ScalingPolicyUpsertResponse struct {
+	// ID is the ID of the created or updated policy
+	ID string
+	WriteMeta
+}
+
+// ScalingPolicyDeleteRequest is used to delete a standalone scaling policy
+type ScalingPolicyDeleteRequest struct {
+	ID string
+	WriteRequest
+}
+
 // ScalingPolicyListRequest is used to parameterize a scaling policy list request
 type ScalingPolicyListRequest struct {
 	Job  string
@@ -1268,6 +1557,98 @@ type JobRegisterResponse struct {
 	// deprecation warnings.
 	Warnings string
 
+	// SubmissionID is the ID of the submission of an asynchronous
+	// registration.
+	SubmissionID string
+
+	QueryMeta
+}
+
+// JobRegisterIdempotencyTTL is how long after a job is registered retries of
+// the registration with the same idempotency token return the original
+// result.
+const JobRegisterIdempotencyTTL = 1 * time.Hour
+
+// JobRegisterIdempotentError is returned when applying a job registration
+// that retries an earlier registration with the same idempotency token. The
+// registration isn't applied again.
+type JobRegisterIdempotentError struct {
+	// JobModifyIndex is the modify index of the job version created by the
+	// original registration.
+	JobModifyIndex uint64
+}
+
+func (e *JobRegisterIdempotentError) Error() string {
+	return fmt.Sprintf("job already registered with the same idempotency token at index %d", e.JobModifyIndex)
+}
+
+// JobRegisterSubmissionResponse is used to return the status of an
+// asynchronous job registration.
+type JobRegisterSubmissionResponse struct {
+	Submission *JobRegisterSubmission
+	QueryMeta
+}
+
+const (
+	JobRegisterSubmissionStatusPending  = "pending"
+	JobRegisterSubmissionStatusRunning  = "running"
+	JobRegisterSubmissionStatusComplete = "complete"
+	JobRegisterSubmissionStatusFailed   = "failed"
+)
+
+// JobRegisterSubmission is the status of an asynchronous job registration
+// queued on the leader.
+type JobRegisterSubmission struct {
+	ID        string
+	Namespace string
+	JobID     string
+
+	// Status is the status of the registration, one of pending, running,
+	// complete or failed.
+	Status string
+
+	// Error is the error the registration failed with.
+	Error string
+
+	// The result of a complete registration.
+	EvalID         string
+	JobModifyIndex uint64
+	Warnings       string
+
+	SubmitTime int64
+	ModifyTime int64
+}
+
+// Copy returns a copy of the submission.
+func (s *JobRegisterSubmission) Copy() *JobRegisterSubmission {
+	if s == nil {
+		return nil
+	}
+	ns := *s
+	return &ns
+}
+
+// Terminal returns whether the registration has completed or failed.
+func (s *JobRegisterSubmission) Terminal() bool {
+	switch s.Status {
+	case JobRegisterSubmissionStatusComplete, JobRegisterSubmissionStatusFailed:
+		return true
+	}
+	return false
+}
+
+// JobBundleRegisterResponse is used to respond to a bundle registration
+type JobBundleRegisterResponse struct {
+	BundleID       string
+	JobModifyIndex uint64
+
+	// JobEvals maps the ID of each job of the bundle to its created
+	// evaluation
+	JobEvals map[string]string
+
+	// Warnings contains any warnings about the given jobs.
+	Warnings string
+
 	QueryMeta
 }
 
@@ -1441,6 +1822,21 @@ type JobVersionsResponse struct {
 	QueryMeta
 }
 
+// JobVersionsDiffRequest is used to diff two arbitrary versions of a job
+type JobVersionsDiffRequest struct {
+	JobID       string
+	FromVersion uint64
+	ToVersion   uint64
+	QueryOptions
+}
+
+// JobVersionsDiffResponse is used for a job versions diff request. The diff is
+// nil if either version doesn't exist.
+type JobVersionsDiffResponse struct {
+	Diff *JobDiff
+	QueryMeta
+}
+
 // JobPlanResponse is used to respond to a job plan request
 type JobPlanResponse struct {
 	// Annotations stores annotations explaining decisions the scheduler made.
@@ -1497,6 +1893,12 @@ type JobEvaluationsResponse struct {
 	QueryMeta
 }
 
+// JobPlanResultsResponse is used to return the plan apply results of a job
+type JobPlanResultsResponse struct {
+	PlanResults []*PlanApplyResult
+	QueryMeta
+}
+
 // SingleEvalResponse is used to return a single evaluation
 type SingleEvalResponse struct {
 	Eval *Evaluation
@@ -2251,12 +2653,74 @@ type Resources struct {
 	IOPS        int // COMPAT(0.10): Only being used to issue warnings
 	Networks    Networks
 	Devices     ResourceDevices
+
+	// DiskIOPS and DiskBandwidthMB limit the read and write operations per
+	// second and the read and write MB per second of the task on the disk
+	// holding its allocation directory. They aren't used for scheduling.
+	DiskIOPS        int
+	DiskBandwidthMB int
+
+	// NUMA configures the binding of the memory of the task to the NUMA
+	// nodes of its reserved cores.
+	NUMA *NUMA
 }
 
 const (
 	BytesInMegabyte = 1024 * 1024
 )
 
+const (
+	// NUMAAffinityNone leaves the memory of the task unbound.
+	NUMAAffinityNone = "none"
+
+	// NUMAAffinityPrefer binds the memory of the task to the NUMA nodes of
+	// its reserved cores.
+	NUMAAffinityPrefer = "prefer"
+
+	// NUMAAffinityRequire binds the memory of the task to the NUMA node of
+	// its reserved cores, and fails the task if they span several nodes.
+	NUMAAffinityRequire = "require"
+)
+
+// NUMA configures the binding of the memory of a task to the NUMA nodes of
+// its reserved cores, which avoids the latency of accessing the memory of
+// another node.
+type NUMA struct {
+	// Affinity is one of NUMAAffinityNone, NUMAAffinityPrefer or
+	// NUMAAffinityRequire.
+	Affinity string
+}
+
+func (n *NUMA) Copy() *NUMA {
+	if n == nil {
+		return nil
+	}
+	nn := *n
+	return &nn
+}
+
+func (n *NUMA) Equals(o *NUMA) bool {
+	if n == nil || o == nil {
+		return n == o
+	}
+	return n.Affinity == o.Affinity
+}
+
+// Binds returns whether the memory of the task is bound to NUMA nodes.
+func (n *NUMA) Binds() bool {
+	return n != nil && n.Affinity != "" && n.Affinity != NUMAAffinityNone
+}
+
+func (n *NUMA) Validate() error {
+	switch n.Affinity {
+	case "", NUMAAffinityNone, NUMAAffinityPrefer, NUMAAffinityRequire:
+		return nil
+	default:
+		return fmt.Errorf("NUMA affinity must be one of %q, %q or %q; got %q",
+			NUMAAffinityNone, NUMAAffinityPrefer, NUMAAffinityRequire, n.Affinity)
+	}
+}
+
 // DefaultResources is a small resources object that contains the
 // default resources requests that we will provide to an object.
 // ---  THIS FUNCTION IS REPLICATED IN api/resources.go and should
@@ -2313,6 +2777,21 @@ func (r *Resources) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("MemoryMaxMB value (%d) should be larger than MemoryMB value (%d)", r.MemoryMaxMB, r.MemoryMB))
 	}
 
+	if r.DiskIOPS < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("DiskIOPS value (%d) can't be negative", r.DiskIOPS))
+	}
+	if r.DiskBandwidthMB < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("DiskBandwidthMB value (%d) can't be negative", r.DiskBandwidthMB))
+	}
+
+	if r.NUMA != nil {
+		if err := r.NUMA.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		} else if r.NUMA.Binds() && r.Cores == 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("NUMA affinity requires reserving 'cores'"))
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -2334,6 +2813,15 @@ func (r *Resources) Merge(other *Resources) {
 	if other.DiskMB != 0 {
 		r.DiskMB = other.DiskMB
 	}
+	if other.DiskIOPS != 0 {
+		r.DiskIOPS = other.DiskIOPS
+	}
+	if other.DiskBandwidthMB != 0 {
+		r.DiskBandwidthMB = other.DiskBandwidthMB
+	}
+	if other.NUMA != nil {
+		r.NUMA = other.NUMA.Copy()
+	}
 	if len(other.Networks) != 0 {
 		r.Networks = other.Networks
 	}
@@ -2358,6 +2846,9 @@ func (r *Resources) Equals(o *Resources) bool {
 		r.MemoryMaxMB == o.MemoryMaxMB &&
 		r.DiskMB == o.DiskMB &&
 		r.IOPS == o.IOPS &&
+		r.DiskIOPS == o.DiskIOPS &&
+		r.DiskBandwidthMB == o.DiskBandwidthMB &&
+		r.NUMA.Equals(o.NUMA) &&
 		r.Networks.Equals(&o.Networks) &&
 		r.Devices.Equals(&o.Devices)
 }
@@ -2435,6 +2926,8 @@ func (r *Resources) Copy() *Resources {
 	newR := new(Resources)
 	*newR = *r
 
+	newR.NUMA = r.NUMA.Copy()
+
 	// Copy the network objects
 	newR.Networks = r.Networks.Copy()
 
@@ -2552,6 +3045,10 @@ type AllocatedPortMapping struct {
 	Value  int
 	To     int
 	HostIP string
+
+	// HostNetwork is the name of the host network of HostIP. Ports mapped
+	// on several host networks have a mapping for each of them.
+	HostNetwork string
 }
 
 type AllocatedPorts []AllocatedPortMapping
@@ -2583,6 +3080,49 @@ type Port struct {
 	// to. Jobs with a HostNetwork set can only be placed on nodes with
 	// that host network available.
 	HostNetwork string
+
+	// HostNetworks are the names of the networks this port should be
+	// assigned to with the same value, instead of the single HostNetwork.
+	// Jobs with HostNetworks set can only be placed on nodes with all these
+	// host networks available.
+	HostNetworks []string
+}
+
+// HostNetworkNames returns the names of the host networks the port should be
+// assigned to.
+func (p Port) HostNetworkNames() []string {
+	if len(p.HostNetworks) > 0 {
+		return p.HostNetworks
+	}
+	return []string{p.HostNetwork}
+}
+
+func (p Port) Copy() Port {
+	p.HostNetworks = helper.CopySliceString(p.HostNetworks)
+	return p
+}
+
+// validateHostNetworks returns an error if the port sets both HostNetwork and
+// HostNetworks, or lists a host network several times.
+func (p Port) validateHostNetworks() error {
+	if len(p.HostNetworks) == 0 {
+		return nil
+	}
+	if p.HostNetwork != "" {
+		return fmt.Errorf("Port %q can't set both host_network and host_networks", p.Label)
+	}
+
+	seen := make(map[string]struct{}, len(p.HostNetworks))
+	for _, hostNetwork := range p.HostNetworks {
+		if hostNetwork == "" {
+			return fmt.Errorf("Port %q has an empty host network", p.Label)
+		}
+		if _, ok := seen[hostNetwork]; ok {
+			return fmt.Errorf("Port %q lists host network %q more than once", p.Label, hostNetwork)
+		}
+		seen[hostNetwork] = struct{}{}
+	}
+	return nil
 }
 
 type DNSConfig struct {
@@ -2605,23 +3145,66 @@ func (d *DNSConfig) Copy() *DNSConfig {
 	return newD
 }
 
+// NetworkFirewall is the egress traffic of a bridge network allowed through
+// the firewall enforced by clients with bridge_network_firewall enabled.
+type NetworkFirewall struct {
+	// AllowClientAPI allows the tasks to reach the HTTP API of the client.
+	AllowClientAPI bool
+
+	// AllowMetadata allows the tasks to reach the metadata endpoint of the
+	// cloud provider.
+	AllowMetadata bool
+
+	// AllowCIDRs are the destinations allowed regardless of the denied ones.
+	AllowCIDRs []string
+}
+
+func (f *NetworkFirewall) Copy() *NetworkFirewall {
+	if f == nil {
+		return nil
+	}
+	nf := new(NetworkFirewall)
+	*nf = *f
+	nf.AllowCIDRs = helper.CopySliceString(f.AllowCIDRs)
+	return nf
+}
+
+// Validate returns an error if an allowed CIDR is invalid.
+func (f *NetworkFirewall) Validate() error {
+	var mErr multierror.Error
+	for _, cidr := range f.AllowCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid firewall allowed CIDR %q: %v", cidr, err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
 // NetworkResource is used to represent available network
 // resources
 type NetworkResource struct {
-	Mode          string     // Mode of the network
-	Device        string     // Name of the device
-	CIDR          string     // CIDR block of addresses
-	IP            string     // Host IP address
-	Hostname      string     `json:",omitempty"` // Hostname of the network namespace
-	MBits         int        // Throughput
-	DNS           *DNSConfig // DNS Configuration
-	ReservedPorts []Port     // Host Reserved ports
-	DynamicPorts  []Port     // Host Dynamically assigned ports
+	Mode          string           // Mode of the network
+	Device        string           // Name of the device
+	CIDR          string           // CIDR block of addresses
+	IP            string           // Host IP address
+	Hostname      string           `json:",omitempty"` // Hostname of the network namespace
+	MBits         int              // Throughput
+	Bandwidth     int              `json:",omitempty"` // Enforced bandwidth limit in MBits/s
+	DNS           *DNSConfig       // DNS Configuration
+	Firewall      *NetworkFirewall `json:",omitempty"` // Egress allowed by the client firewall
+	ReservedPorts []Port           // Host Reserved ports
+	DynamicPorts  []Port           // Host Dynamically assigned ports
 }
 
 func (n *NetworkResource) Hash() uint32 {
 	var data []byte
 	data = append(data, []byte(fmt.Sprintf("%s%s%s%s%s%d", n.Mode, n.Device, n.CIDR, n.IP, n.Hostname, n.MBits))...)
+	if n.Bandwidth != 0 {
+		data = append(data, []byte(fmt.Sprintf("b%d", n.Bandwidth))...)
+	}
+	if f := n.Firewall; f != nil {
+		data = append(data, []byte(fmt.Sprintf("f%t%t%s", f.AllowClientAPI, f.AllowMetadata, strings.Join(f.AllowCIDRs, ",")))...)
+	}
 
 	for i, port := range n.ReservedPorts {
 		data = append(data, []byte(fmt.Sprintf("r%d%s%d%d", i, port.Label, port.Value, port.To))...)
@@ -2649,12 +3232,12 @@ func (n *NetworkResource) Canonicalize() {
 	}
 
 	for i, p := range n.DynamicPorts {
-		if p.HostNetwork == "" {
+		if p.HostNetwork == "" && len(p.HostNetworks) == 0 {
 			n.DynamicPorts[i].HostNetwork = "default"
 		}
 	}
 	for i, p := range n.ReservedPorts {
-		if p.HostNetwork == "" {
+		if p.HostNetwork == "" && len(p.HostNetworks) == 0 {
 			n.ReservedPorts[i].HostNetwork = "default"
 		}
 	}
@@ -2668,17 +3251,53 @@ func (n *NetworkResource) Copy() *NetworkResource {
 	newR := new(NetworkResource)
 	*newR = *n
 	newR.DNS = n.DNS.Copy()
+	newR.Firewall = n.Firewall.Copy()
 	if n.ReservedPorts != nil {
 		newR.ReservedPorts = make([]Port, len(n.ReservedPorts))
-		copy(newR.ReservedPorts, n.ReservedPorts)
+		for i, port := range n.ReservedPorts {
+			newR.ReservedPorts[i] = port.Copy()
+		}
 	}
 	if n.DynamicPorts != nil {
 		newR.DynamicPorts = make([]Port, len(n.DynamicPorts))
-		copy(newR.DynamicPorts, n.DynamicPorts)
+		for i, port := range n.DynamicPorts {
+			newR.DynamicPorts[i] = port.Copy()
+		}
 	}
 	return newR
 }
 
+// validateBandwidth validates the bandwidth limit of the network, which can
+// only be enforced on the network namespaces created by the client.
+func (n *NetworkResource) validateBandwidth() error {
+	if n.Bandwidth < 0 {
+		return fmt.Errorf("Network bandwidth (%d) can't be negative", n.Bandwidth)
+	}
+	mode := strings.ToLower(n.Mode)
+	if mode == "" {
+		mode = "host"
+	}
+	if n.Bandwidth > 0 && mode != "bridge" && !strings.HasPrefix(mode, "cni/") {
+		return fmt.Errorf("Network bandwidth can't be enforced in %q network mode, only in bridge and cni modes", mode)
+	}
+	return nil
+}
+
+// validateFirewall validates the firewall of the network, which can only be
+// enforced on the bridge network of a task group.
+func (n *NetworkResource) validateFirewall() error {
+	if n.Firewall == nil {
+		return nil
+	}
+	if mode := strings.ToLower(n.Mode); mode != "bridge" {
+		if mode == "" {
+			mode = "host"
+		}
+		return fmt.Errorf("Network firewall can't be enforced in %q network mode, only in bridge mode", mode)
+	}
+	return n.Firewall.Validate()
+}
+
 // Add adds the resources of the delta to this, potentially
 // returning an error if not possible.
 func (n *NetworkResource) Add(delta *NetworkResource) {
@@ -3557,6 +4176,9 @@ func (a *AllocatedResources) Comparable() *ComparableResources {
 			} else {
 				prestartEphemeralTasks.Add(r)
 			}
+		} else if lc.Hook == TaskLifecycleHookPrestop {
+			// Prestop tasks run while the main tasks are still running
+			main.Add(r)
 		} else if lc.Hook == TaskLifecycleHookPoststop {
 			poststopTasks.Add(r)
 		}
@@ -4040,6 +4662,10 @@ const (
 	// JobTrackedScalingEvents is the number of scaling events that are
 	// kept for a single task group.
 	JobTrackedScalingEvents = 20
+
+	// JobTrackedPlanResults is the number of plan apply results that are
+	// kept for a single job.
+	JobTrackedPlanResults = 10
 )
 
 // Job is the scope of a scheduling request to Nomad. It is the largest
@@ -4088,6 +4714,10 @@ type Job struct {
 	// Datacenters contains all the datacenters this job is allowed to span
 	Datacenters []string
 
+	// DependsOn are the IDs of the jobs of the same namespace that must be
+	// ready before the job is first placed. See ReadyAsDependency.
+	DependsOn []string
+
 	// Constraints can be specified at a job level and apply to
 	// all the task groups and tasks.
 	Constraints []*Constraint
@@ -4125,6 +4755,15 @@ type Job struct {
 	// non-terminal siblings which have the same token value.
 	DispatchIdempotencyToken string
 
+	// BundleID is the ID of the bundle this version of the job was
+	// registered with, if any.
+	BundleID string
+
+	// RegisterIdempotencyToken is the idempotency token, if any, of the
+	// registration that created this version of the job. Retries of the
+	// registration with the same token return the original result.
+	RegisterIdempotencyToken string
+
 	// Payload is the payload supplied when the job was dispatched.
 	Payload []byte
 
@@ -4146,6 +4785,12 @@ type Job struct {
 	// transfer the token and is not stored after Job submission.
 	VaultToken string
 
+	// VaultClusterTokens are the Vault tokens, indexed by the name of their
+	// Vault cluster, that prove the submitter of the job has access to the
+	// Vault policies of the named Vault clusters. This field is only used to
+	// transfer the tokens and is not stored after Job submission.
+	VaultClusterTokens map[string]string
+
 	// VaultNamespace is the Vault namespace
 	VaultNamespace string
 
@@ -4167,6 +4812,11 @@ type Job struct {
 	// update stanza.
 	Stable bool
 
+	// VersionTag is the tag of this version of the job, if any. Tagged
+	// versions aren't garbage collected and can be referenced by name when
+	// reverting the job.
+	VersionTag *JobVersionTag
+
 	// Version is a monotonically increasing version number that is incremented
 	// on each job register.
 	Version uint64
@@ -4253,6 +4903,43 @@ func (j *Job) Canonicalize() {
 
 // Copy returns a deep copy of the Job. It is expected that callers use recover.
 // This job can panic if the deep copy failed as it uses reflection.
+// CopyWithID returns a copy of the job moved to the given namespace and ID.
+// The targets of the job's scaling policies are updated accordingly, and the
+// name of the job is updated if it was the same as its ID.
+func (j *Job) CopyWithID(namespace, id string) *Job {
+	if j == nil {
+		return nil
+	}
+	nj := j.Copy()
+	if nj.Name == nj.ID {
+		nj.Name = id
+	}
+	nj.ID = id
+	nj.Namespace = namespace
+
+	retarget := func(p *ScalingPolicy) {
+		if p != nil && p.Target != nil {
+			p.Target[ScalingTargetNamespace] = namespace
+			p.Target[ScalingTargetJob] = id
+		}
+	}
+	for _, tg := range nj.TaskGroups {
+		retarget(tg.Scaling)
+		for _, task := range tg.Tasks {
+			// Task copies share their scaling policies
+			policies := make([]*ScalingPolicy, len(task.ScalingPolicies))
+			for i, p := range task.ScalingPolicies {
+				policies[i] = p.Copy()
+				retarget(policies[i])
+			}
+			if task.ScalingPolicies != nil {
+				task.ScalingPolicies = policies
+			}
+		}
+	}
+	return nj
+}
+
 func (j *Job) Copy() *Job {
 	if j == nil {
 		return nil
@@ -4260,6 +4947,7 @@ func (j *Job) Copy() *Job {
 	nj := new(Job)
 	*nj = *j
 	nj.Datacenters = helper.CopySliceString(nj.Datacenters)
+	nj.DependsOn = helper.CopySliceString(nj.DependsOn)
 	nj.Constraints = CopySliceConstraints(nj.Constraints)
 	nj.Affinities = CopySliceAffinities(nj.Affinities)
 	nj.Multiregion = nj.Multiregion.Copy()
@@ -4274,7 +4962,9 @@ func (j *Job) Copy() *Job {
 
 	nj.Periodic = nj.Periodic.Copy()
 	nj.Meta = helper.CopyMapStringString(nj.Meta)
+	nj.VaultClusterTokens = helper.CopyMapStringString(nj.VaultClusterTokens)
 	nj.ParameterizedJob = nj.ParameterizedJob.Copy()
+	nj.VersionTag = nj.VersionTag.Copy()
 	return nj
 }
 
@@ -4319,6 +5009,24 @@ func (j *Job) Validate() error {
 			}
 		}
 	}
+	if len(j.DependsOn) != 0 {
+		if j.Type != JobTypeService && j.Type != JobTypeBatch {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf(
+				"Job dependencies can only be used with %q or %q scheduler", JobTypeService, JobTypeBatch,
+			))
+		}
+		seen := make(map[string]struct{}, len(j.DependsOn))
+		for _, dep := range j.DependsOn {
+			if dep == "" {
+				mErr.Errors = append(mErr.Errors, errors.New("Job dependency must be non-empty string"))
+			} else if dep == j.ID {
+				mErr.Errors = append(mErr.Errors, errors.New("Job can't depend on itself"))
+			} else if _, ok := seen[dep]; ok {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Job dependency %q is listed more than once", dep))
+			}
+			seen[dep] = struct{}{}
+		}
+	}
 	if len(j.TaskGroups) == 0 {
 		mErr.Errors = append(mErr.Errors, errors.New("Missing job task groups"))
 	}
@@ -4505,9 +5213,62 @@ func (j *Job) CombinedTaskMeta(groupName, taskName string) map[string]string {
 	return meta
 }
 
-// Stopped returns if a job is stopped.
-func (j *Job) Stopped() bool {
-	return j == nil || j.Stop
+// Stopped returns if a job is stopped.
+func (j *Job) Stopped() bool {
+	return j == nil || j.Stop
+}
+
+// ReadyAsDependency returns whether the jobs that depend on the job can be
+// placed, given the latest deployment and the allocations of the job. The job
+// is ready once it is running and, for service jobs with an update strategy,
+// the deployment of its current version is successful. Batch and sysbatch
+// jobs are also ready once they are dead after their allocations completed.
+func (j *Job) ReadyAsDependency(latest *Deployment, allocs []*Allocation) bool {
+	if j.Stopped() {
+		return false
+	}
+	switch j.Status {
+	case JobStatusRunning:
+	case JobStatusDead:
+		return j.allocsCompleted(allocs)
+	default:
+		return false
+	}
+
+	// Service jobs with an update strategy always have a deployment, which
+	// may not be created yet for the current version
+	if j.Type != JobTypeService || !j.HasUpdateStrategy() {
+		return true
+	}
+	if latest == nil || latest.JobCreateIndex != j.CreateIndex || latest.JobVersion != j.Version {
+		return false
+	}
+	return latest.Status == DeploymentStatusSuccessful
+}
+
+// allocsCompleted returns whether the job is a batch or sysbatch job with
+// allocations that all completed, ignoring the allocations that were
+// replaced, such as failed allocations that were rescheduled.
+func (j *Job) allocsCompleted(allocs []*Allocation) bool {
+	if j.Type != JobTypeBatch && j.Type != JobTypeSysBatch {
+		return false
+	}
+
+	completed := false
+	for _, alloc := range allocs {
+		// Skip the allocations of an earlier job with the same ID
+		if alloc.Job != nil && alloc.Job.CreateIndex != j.CreateIndex {
+			continue
+		}
+		switch {
+		case alloc.ClientStatus == AllocClientStatusComplete:
+			completed = true
+		case alloc.NextAllocation != "":
+		default:
+			return false
+		}
+	}
+	return completed
 }
 
 // HasUpdateStrategy returns if any task group in the job has an update strategy
@@ -4629,6 +5390,16 @@ func (j *Job) RequiredSignals() map[string]map[string][]string {
 				taskSignals[task.KillSignal] = struct{}{}
 			}
 
+			// Check if the certs of a Connect native service use signals
+			for _, service := range tg.Services {
+				if !service.Connect.IsNative() || service.TaskName != task.Name {
+					continue
+				}
+				if certs := service.Connect.Certs; certs != nil && certs.ChangeMode == ConnectCertsChangeModeSignal {
+					taskSignals[certs.ChangeSignal] = struct{}{}
+				}
+			}
+
 			// Check if any template change mode uses signals
 			for _, t := range task.Templates {
 				if t.ChangeMode != TemplateChangeModeSignal {
@@ -4677,11 +5448,14 @@ func (j *Job) SpecChanged(new *Job) bool {
 	c.Status = j.Status
 	c.StatusDescription = j.StatusDescription
 	c.Stable = j.Stable
+	c.VersionTag = j.VersionTag
 	c.Version = j.Version
 	c.CreateIndex = j.CreateIndex
 	c.ModifyIndex = j.ModifyIndex
 	c.JobModifyIndex = j.JobModifyIndex
 	c.SubmitTime = j.SubmitTime
+	c.RegisterIdempotencyToken = j.RegisterIdempotencyToken
+	c.BundleID = j.BundleID
 
 	// cgbaker: FINISH: probably need some consideration of scaling policy ID here
 
@@ -4693,6 +5467,40 @@ func (j *Job) SetSubmitTime() {
 	j.SubmitTime = time.Now().UTC().UnixNano()
 }
 
+// JobVersionTag is a name given to a version of a job. Tag names are unique
+// among the versions of a job.
+type JobVersionTag struct {
+	Name        string
+	Description string
+
+	// TaggedTime is the time at which the version was tagged as a UnixNano
+	// in UTC
+	TaggedTime int64
+}
+
+func (t *JobVersionTag) Copy() *JobVersionTag {
+	if t == nil {
+		return nil
+	}
+	nt := *t
+	return &nt
+}
+
+// Validate returns an error if the tag is invalid. Tag names can't be
+// numeric so they can't be mistaken for a version number.
+func (t *JobVersionTag) Validate() error {
+	if t.Name == "" {
+		return errors.New("missing version tag name")
+	}
+	if strings.ContainsAny(t.Name, " \000") {
+		return fmt.Errorf("version tag name %q contains a space or null character", t.Name)
+	}
+	if _, err := strconv.ParseUint(t.Name, 10, 64); err == nil {
+		return fmt.Errorf("version tag name %q must not be a number", t.Name)
+	}
+	return nil
+}
+
 // JobListStub is used to return a subset of job information
 // for the job list
 type JobListStub struct {
@@ -4790,6 +5598,19 @@ const (
 	// allocations is healthy. This allows more advanced health checking that is
 	// outside of the scope of Nomad.
 	UpdateStrategyHealthCheck_Manual = "manual"
+
+	// BlueGreenLiveTag is the tag the services of allocations serving traffic
+	// are registered with when the task group uses blue/green deployments.
+	BlueGreenLiveTag = "live"
+
+	// BlueGreenStagedTag is the tag the services of canaries and of
+	// allocations replaced by a promoted deployment are registered with when
+	// the task group uses blue/green deployments.
+	BlueGreenStagedTag = "staged"
+
+	// DefaultUpdateStrategySLOInterval is the interval at which the SLO query
+	// of a task group is evaluated if the update strategy doesn't set one.
+	DefaultUpdateStrategySLOInterval = 30 * time.Second
 )
 
 var (
@@ -4849,6 +5670,23 @@ type UpdateStrategy struct {
 	// Canary is the number of canaries to deploy when a change to the task
 	// group is detected.
 	Canary int
+
+	// SLOQuery is an instant query evaluated against the metrics endpoint
+	// configured on the servers while the group's canaries await promotion.
+	// The deployment fails if the query returns no samples or any sample
+	// with a value of zero.
+	SLOQuery string
+
+	// SLOInterval is the interval at which SLOQuery is evaluated. If zero,
+	// DefaultUpdateStrategySLOInterval is used.
+	SLOInterval time.Duration
+
+	// BlueGreen declares that the services of the group's canaries are
+	// registered with the BlueGreenStagedTag and those of the other
+	// allocations with the BlueGreenLiveTag. When the deployment is promoted
+	// the tags of the allocations of the previous job version are swapped to
+	// the BlueGreenStagedTag.
+	BlueGreen bool
 }
 
 func (u *UpdateStrategy) Copy() *UpdateStrategy {
@@ -4882,6 +5720,15 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Canary == 0 && u.AutoPromote {
 		_ = multierror.Append(&mErr, fmt.Errorf("Auto Promote requires a Canary count greater than zero"))
 	}
+	if u.Canary == 0 && u.SLOQuery != "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("SLO query requires a Canary count greater than zero"))
+	}
+	if u.Canary == 0 && u.BlueGreen {
+		_ = multierror.Append(&mErr, fmt.Errorf("Blue/green deployments require a Canary count greater than zero"))
+	}
+	if u.SLOInterval < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("SLO interval may not be less than zero: %v", u.SLOInterval))
+	}
 	if u.MinHealthyTime < 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Minimum healthy time may not be less than zero: %v", u.MinHealthyTime))
 	}
@@ -4991,6 +5838,9 @@ type Namespace struct {
 	// Capabilities is the set of capabilities allowed for this namespace
 	Capabilities *NamespaceCapabilities
 
+	// JobLimits bounds the size of the jobs registered in this namespace
+	JobLimits *NamespaceJobLimits
+
 	// Meta is the set of metadata key/value pairs that attached to the namespace
 	Meta map[string]string
 
@@ -5010,6 +5860,107 @@ type NamespaceCapabilities struct {
 	DisabledTaskDrivers []string
 }
 
+const (
+	// The names of the namespace job limits, as set in namespace
+	// specifications and reported by JobLimitError.
+	JobLimitMaxJobSizeBytes  = "max_job_size_bytes"
+	JobLimitMaxGroups        = "max_groups"
+	JobLimitMaxTasksPerGroup = "max_tasks_per_group"
+	JobLimitMaxGroupCount    = "max_group_count"
+)
+
+// NamespaceJobLimits bounds the size of the jobs registered in a namespace, to
+// protect the raft log and the schedulers from pathological job
+// specifications. A limit of zero is unlimited.
+type NamespaceJobLimits struct {
+	// MaxJobSizeBytes is the maximum size of the encoded job.
+	MaxJobSizeBytes int
+
+	// MaxGroups is the maximum number of task groups of a job.
+	MaxGroups int
+
+	// MaxTasksPerGroup is the maximum number of tasks of a task group.
+	MaxTasksPerGroup int
+
+	// MaxGroupCount is the maximum count of a task group.
+	MaxGroupCount int
+}
+
+func (l *NamespaceJobLimits) Validate() error {
+	var mErr multierror.Error
+	limits := []struct {
+		name  string
+		value int
+	}{
+		{JobLimitMaxJobSizeBytes, l.MaxJobSizeBytes},
+		{JobLimitMaxGroups, l.MaxGroups},
+		{JobLimitMaxTasksPerGroup, l.MaxTasksPerGroup},
+		{JobLimitMaxGroupCount, l.MaxGroupCount},
+	}
+	for _, limit := range limits {
+		if limit.value < 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("job limit %s must not be negative", limit.name))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// JobLimitError is returned when a job exceeds one of the job limits of its
+// namespace.
+type JobLimitError struct {
+	Namespace string
+	JobID     string
+
+	// Group is the task group exceeding the limit, if the limit applies to
+	// task groups.
+	Group string
+
+	// Limit is the name of the exceeded limit.
+	Limit  string
+	Max    int
+	Actual int
+}
+
+func (e *JobLimitError) Error() string {
+	subject := fmt.Sprintf("job %q", e.JobID)
+	if e.Group != "" {
+		subject = fmt.Sprintf("group %q of job %q", e.Group, e.JobID)
+	}
+	return fmt.Sprintf("%s exceeds limit %s of namespace %q: %d > %d",
+		subject, e.Limit, e.Namespace, e.Actual, e.Max)
+}
+
+// CheckJobLimits returns an error listing the job limits of the namespace
+// exceeded by the job, whose encoded size is size bytes.
+func (n *Namespace) CheckJobLimits(job *Job, size int) error {
+	l := n.JobLimits
+	if l == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	exceeds := func(group, limit string, max, actual int) {
+		if max != 0 && actual > max {
+			mErr.Errors = append(mErr.Errors, &JobLimitError{
+				Namespace: n.Name,
+				JobID:     job.ID,
+				Group:     group,
+				Limit:     limit,
+				Max:       max,
+				Actual:    actual,
+			})
+		}
+	}
+
+	exceeds("", JobLimitMaxJobSizeBytes, l.MaxJobSizeBytes, size)
+	exceeds("", JobLimitMaxGroups, l.MaxGroups, len(job.TaskGroups))
+	for _, tg := range job.TaskGroups {
+		exceeds(tg.Name, JobLimitMaxTasksPerGroup, l.MaxTasksPerGroup, len(tg.Tasks))
+		exceeds(tg.Name, JobLimitMaxGroupCount, l.MaxGroupCount, tg.Count)
+	}
+	return mErr.ErrorOrNil()
+}
+
 func (n *Namespace) Validate() error {
 	var mErr multierror.Error
 
@@ -5022,6 +5973,11 @@ func (n *Namespace) Validate() error {
 		err := fmt.Errorf("description longer than %d", maxNamespaceDescriptionLength)
 		mErr.Errors = append(mErr.Errors, err)
 	}
+	if n.JobLimits != nil {
+		if err := n.JobLimits.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	}
 
 	return mErr.ErrorOrNil()
 }
@@ -5046,6 +6002,10 @@ func (n *Namespace) SetHash() []byte {
 			_, _ = hash.Write([]byte(driver))
 		}
 	}
+	if l := n.JobLimits; l != nil {
+		_, _ = hash.Write([]byte(fmt.Sprintf("%d/%d/%d/%d",
+			l.MaxJobSizeBytes, l.MaxGroups, l.MaxTasksPerGroup, l.MaxGroupCount)))
+	}
 
 	// sort keys to ensure hash stability when meta is stored later
 	var keys []string
@@ -5078,6 +6038,10 @@ func (n *Namespace) Copy() *Namespace {
 		c.DisabledTaskDrivers = helper.CopySliceString(n.Capabilities.DisabledTaskDrivers)
 		nc.Capabilities = c
 	}
+	if n.JobLimits != nil {
+		l := *n.JobLimits
+		nc.JobLimits = &l
+	}
 	if n.Meta != nil {
 		nc.Meta = make(map[string]string, len(n.Meta))
 		for k, v := range n.Meta {
@@ -5099,6 +6063,29 @@ type NamespaceListResponse struct {
 	QueryMeta
 }
 
+// NamespaceUsageResponse is used to return the resources allocated to each
+// namespace
+type NamespaceUsageResponse struct {
+	Namespaces []*NamespaceUsage
+
+	// ClusterCPU and ClusterMemoryMB are the resources of the ready nodes in
+	// the cluster that can be allocated. They are only set if the request is
+	// allowed to read nodes.
+	ClusterCPU      int64
+	ClusterMemoryMB int64
+
+	QueryMeta
+}
+
+// NamespaceUsage is the resources allocated to the non-terminal allocations of
+// a namespace
+type NamespaceUsage struct {
+	Namespace   string
+	Allocations int
+	CPU         int64
+	MemoryMB    int64
+}
+
 // NamespaceSpecificRequest is used to query a specific namespace
 type NamespaceSpecificRequest struct {
 	Name string
@@ -5369,6 +6356,10 @@ func DispatchedID(templateID string, t time.Time) string {
 type DispatchPayloadConfig struct {
 	// File specifies a relative path to where the input data should be written
 	File string
+
+	// Stdin streams the input data to the task's stdin instead of writing it
+	// to a file
+	Stdin bool
 }
 
 func (d *DispatchPayloadConfig) Copy() *DispatchPayloadConfig {
@@ -5381,6 +6372,10 @@ func (d *DispatchPayloadConfig) Copy() *DispatchPayloadConfig {
 }
 
 func (d *DispatchPayloadConfig) Validate() error {
+	if d.Stdin && d.File != "" {
+		return fmt.Errorf("file and stdin are mutually exclusive")
+	}
+
 	// Verify the destination doesn't escape
 	escaped, err := escapingfs.PathEscapesAllocViaRelative("task/local/", d.File)
 	if err != nil {
@@ -5395,12 +6390,17 @@ func (d *DispatchPayloadConfig) Validate() error {
 const (
 	TaskLifecycleHookPrestart  = "prestart"
 	TaskLifecycleHookPoststart = "poststart"
+	TaskLifecycleHookPrestop   = "prestop"
 	TaskLifecycleHookPoststop  = "poststop"
 )
 
 type TaskLifecycleConfig struct {
 	Hook    string
 	Sidecar bool
+
+	// OnFailure restricts poststop tasks to run only if any of the main
+	// tasks failed.
+	OnFailure bool
 }
 
 func (d *TaskLifecycleConfig) Copy() *TaskLifecycleConfig {
@@ -5420,6 +6420,10 @@ func (d *TaskLifecycleConfig) Validate() error {
 	switch d.Hook {
 	case TaskLifecycleHookPrestart:
 	case TaskLifecycleHookPoststart:
+	case TaskLifecycleHookPrestop:
+		if d.Sidecar {
+			return fmt.Errorf("%s tasks can't be sidecars", d.Hook)
+		}
 	case TaskLifecycleHookPoststop:
 	case "":
 		return fmt.Errorf("no lifecycle hook provided")
@@ -5427,6 +6431,46 @@ func (d *TaskLifecycleConfig) Validate() error {
 		return fmt.Errorf("invalid hook: %v", d.Hook)
 	}
 
+	if d.OnFailure && d.Hook != TaskLifecycleHookPoststop {
+		return fmt.Errorf("on_failure can only be set for %s tasks", TaskLifecycleHookPoststop)
+	}
+
+	return nil
+}
+
+// RuntimeEnvConfig opts a task into the environment variables that size the
+// thread pools and heaps of common runtimes to the resources of the task.
+// These variables change the behavior of the runtimes, so unlike the resource
+// limits variables they aren't set by default.
+type RuntimeEnvConfig struct {
+	// GOMAXPROCS sets the GOMAXPROCS variable to the number of cores the
+	// task may use.
+	GOMAXPROCS bool
+
+	// JavaHeapPercent sets the JAVA_TOOL_OPTIONS variable to limit the heap
+	// of the JVM to the percentage of the memory of the task, and its
+	// processor count to the number of cores the task may use. Zero leaves
+	// the variable unset.
+	JavaHeapPercent int
+}
+
+func (r *RuntimeEnvConfig) Copy() *RuntimeEnvConfig {
+	if r == nil {
+		return nil
+	}
+	nr := new(RuntimeEnvConfig)
+	*nr = *r
+	return nr
+}
+
+func (r *RuntimeEnvConfig) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	if r.JavaHeapPercent < 0 || r.JavaHeapPercent > 100 {
+		return fmt.Errorf("java_heap_percent must be between 0 and 100; got %d", r.JavaHeapPercent)
+	}
 	return nil
 }
 
@@ -5516,6 +6560,14 @@ type ScalingEvent struct {
 	// PreviousCount is the count at the time of the scaling event
 	PreviousCount int64
 
+	// Resources are the new resources of the group's tasks, keyed by task
+	// name, if provided
+	Resources map[string]*ScalingResources
+
+	// PreviousResources are the resources of the tasks in Resources at the
+	// time of the scaling event
+	PreviousResources map[string]*ScalingResources
+
 	// Message is the message describing a scaling event
 	Message string
 
@@ -5532,6 +6584,13 @@ type ScalingEvent struct {
 	CreateIndex uint64
 }
 
+// ScalingResources are the resources of a task requested by a vertical
+// scaling action. Zero values leave the task's resource unchanged.
+type ScalingResources struct {
+	CPU      int
+	MemoryMB int
+}
+
 func (e *ScalingEvent) SetError(error bool) *ScalingEvent {
 	e.Error = error
 	return e
@@ -5557,6 +6616,141 @@ type ScalingEventRequest struct {
 	ScalingEvent *ScalingEvent
 }
 
+// ScalingWebhookConfig is used in servers to configure the delivery of
+// scaling events to an operator defined HTTPS endpoint.
+type ScalingWebhookConfig struct {
+	// URL is the HTTPS endpoint scaling events are POSTed to.
+	URL string
+
+	// Secret is the key used to sign the request body with HMAC-SHA256. The
+	// signature is sent in the X-Nomad-Signature header.
+	Secret string
+
+	// CAFile is an optional path to a PEM encoded CA certificate used to
+	// verify the endpoint's certificate.
+	CAFile string
+}
+
+// DeploymentWebhookConfig is used in servers to configure the delivery of
+// deployment status updates to an operator defined HTTPS endpoint.
+type DeploymentWebhookConfig struct {
+	// URL is the HTTPS endpoint deployment status updates are POSTed to.
+	URL string
+
+	// Secret is the key used to sign the request body with HMAC-SHA256. The
+	// signature is sent in the X-Nomad-Signature header.
+	Secret string
+
+	// CAFile is an optional path to a PEM encoded CA certificate used to
+	// verify the endpoint's certificate.
+	CAFile string
+}
+
+// NodeScorerConfig is used in servers to configure an external binary or HTTP
+// endpoint that contributes a score to the ranking of candidate nodes. Exactly
+// one of Command and URL is set.
+type NodeScorerConfig struct {
+	// Command is the path of the binary executed to score a node. The
+	// request is written to its stdin and the response read from its stdout.
+	Command string
+
+	// Args are the arguments passed to Command.
+	Args []string
+
+	// URL is the HTTP endpoint requests to score a node are POSTed to.
+	URL string
+
+	// Timeout bounds the time taken to score a single node. Nodes that are
+	// not scored in time are ranked without the external score.
+	Timeout time.Duration
+}
+
+// SLOMetricsConfig is used in servers to configure the metrics endpoint the
+// SLO queries of deployments are evaluated against.
+type SLOMetricsConfig struct {
+	// Address is the base URL of an HTTP API compatible with the Prometheus
+	// instant query API.
+	Address string
+
+	// Timeout bounds the time taken to evaluate a single query.
+	Timeout time.Duration
+}
+
+// ScoringPluginConfig is used in servers to enable a scoring plugin compiled
+// into the binary.
+type ScoringPluginConfig struct {
+	// Name is the name the plugin is registered under.
+	Name string
+
+	// Weight is the weight of the plugin's score relative to each of the
+	// built-in scores.
+	Weight float64
+}
+
+// GCAutoTuneConfig is used in servers to configure the shrinking of the GC
+// thresholds of jobs, evaluations and deployments when the state of the
+// server grows too large.
+type GCAutoTuneConfig struct {
+	// MaxHeapBytes is the heap size above which the thresholds are shrunk.
+	// It isn't checked if zero.
+	MaxHeapBytes uint64
+
+	// MaxSnapshotBytes is the size of the latest raft snapshot above which
+	// the thresholds are shrunk. It isn't checked if zero.
+	MaxSnapshotBytes uint64
+
+	// MinThreshold is the threshold below which the configured thresholds
+	// are never shrunk.
+	MinThreshold time.Duration
+}
+
+// ScheduledScalingAction is a change to the count of a task group that has
+// been deferred until ScheduleAt. It is applied by the leader, which emits a
+// scaling event for the job at that time.
+type ScheduledScalingAction struct {
+	// ID is a generated UUID used for looking up the scheduled action
+	ID string
+
+	Namespace string
+	JobID     string
+	TaskGroup string
+	Count     int64
+	Message   string
+	Meta      map[string]interface{}
+
+	// ScheduleAt is the time at which the count change is applied
+	ScheduleAt time.Time
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ScheduledScalingUpsertRequest is used to store a scheduled scaling action.
+type ScheduledScalingUpsertRequest struct {
+	Action *ScheduledScalingAction
+	WriteRequest
+}
+
+// ScheduledScalingDeleteRequest is used to delete scheduled scaling actions
+// once they have been applied.
+type ScheduledScalingDeleteRequest struct {
+	IDs []string
+	WriteRequest
+}
+
+// ScheduledScalingActionListRequest is used to list the scheduled scaling
+// actions that haven't been applied yet, optionally of a single job.
+type ScheduledScalingActionListRequest struct {
+	Job string
+	QueryOptions
+}
+
+// ScheduledScalingActionListResponse is used for a list request
+type ScheduledScalingActionListResponse struct {
+	Actions []*ScheduledScalingAction
+	QueryMeta
+}
+
 // ScalingPolicy specifies the scaling policy for a scaling target
 type ScalingPolicy struct {
 	// ID is a generated UUID used for looking up the scaling policy
@@ -5580,6 +6774,11 @@ type ScalingPolicy struct {
 	// Enabled indicates whether this policy has been enabled/disabled
 	Enabled bool
 
+	// Standalone indicates whether the policy was created with the scaling
+	// policy endpoints rather than from the scaling block of its job. These
+	// policies are kept when the job is updated.
+	Standalone bool
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -5598,7 +6797,9 @@ const (
 	ScalingTargetGroup     = "Group"
 	ScalingTargetTask      = "Task"
 
-	ScalingPolicyTypeHorizontal = "horizontal"
+	ScalingPolicyTypeHorizontal  = "horizontal"
+	ScalingPolicyTypeVerticalCPU = "vertical_cpu"
+	ScalingPolicyTypeVerticalMem = "vertical_mem"
 )
 
 func (p *ScalingPolicy) Canonicalize() {
@@ -5624,6 +6825,7 @@ func (p *ScalingPolicy) Copy() *ScalingPolicy {
 		Type:        p.Type,
 		Min:         p.Min,
 		Max:         p.Max,
+		Standalone:  p.Standalone,
 		CreateIndex: p.CreateIndex,
 		ModifyIndex: p.ModifyIndex,
 	}
@@ -5684,6 +6886,19 @@ func (p *ScalingPolicy) validateTargetHorizontal() (mErr multierror.Error) {
 	return
 }
 
+func (p *ScalingPolicy) validateTargetVertical() (mErr multierror.Error) {
+	if len(p.Target) == 0 {
+		return
+	}
+
+	// Nomad vertical policies target a task of a horizontal policy's group
+	mErr = p.validateTargetHorizontal()
+	if p.Target[ScalingTargetTask] == "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("missing target task"))
+	}
+	return
+}
+
 // Diff indicates whether the specification for a given scaling policy has changed
 func (p *ScalingPolicy) Diff(p2 *ScalingPolicy) bool {
 	copy := *p2
@@ -5716,6 +6931,7 @@ func (p *ScalingPolicy) Stub() *ScalingPolicyListStub {
 		Type:        p.Type,
 		Target:      make(map[string]string),
 		Enabled:     p.Enabled,
+		Standalone:  p.Standalone,
 		CreateIndex: p.CreateIndex,
 		ModifyIndex: p.ModifyIndex,
 	}
@@ -5733,6 +6949,9 @@ func (j *Job) GetScalingPolicies() []*ScalingPolicy {
 		if tg.Scaling != nil {
 			ret = append(ret, tg.Scaling)
 		}
+		for _, task := range tg.Tasks {
+			ret = append(ret, task.ScalingPolicies...)
+		}
 	}
 
 	ret = append(ret, j.GetEntScalingPolicies()...)
@@ -5745,6 +6964,7 @@ func (j *Job) GetScalingPolicies() []*ScalingPolicy {
 type ScalingPolicyListStub struct {
 	ID          string
 	Enabled     bool
+	Standalone  bool
 	Type        string
 	Target      map[string]string
 	CreateIndex uint64
@@ -5839,6 +7059,16 @@ type ReschedulePolicy struct {
 	// Unlimited allows infinite rescheduling attempts. Only allowed when delay is set
 	// between reschedule attempts.
 	Unlimited bool
+
+	// Jitter is the fraction of the delay, between 0 and 1, that may be
+	// randomly added to it to spread out the reschedules of allocations that
+	// failed at the same time.
+	Jitter float64
+
+	// MaxLifetime is the duration, from the creation of the first allocation
+	// of a reschedule chain, after which the allocation is no longer
+	// rescheduled, regardless of the attempts left in the interval.
+	MaxLifetime time.Duration
 }
 
 func (r *ReschedulePolicy) Copy() *ReschedulePolicy {
@@ -5876,6 +7106,13 @@ func (r *ReschedulePolicy) Validate() error {
 		}
 	}
 
+	if r.Jitter < 0 || r.Jitter > 1 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Jitter must be between 0 and 1 (got %v)", r.Jitter))
+	}
+	if r.MaxLifetime < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Max Lifetime cannot be negative (got %v)", r.MaxLifetime))
+	}
+
 	delayPreCheck := true
 	// Delay should be bigger than the default
 	if r.Delay.Nanoseconds() < ReschedulePolicyMinDelay.Nanoseconds() {
@@ -6150,6 +7387,20 @@ type TaskGroup struct {
 	// StopAfterClientDisconnect, if set, configures the client to stop the task group
 	// after this duration since the last known good heartbeat
 	StopAfterClientDisconnect *time.Duration
+
+	// Priority of the task group relative to the other groups of its job. When
+	// a group can't be placed, allocations of lower priority groups are stopped
+	// to make room for it. Zero inherits the job's priority.
+	Priority int
+}
+
+// EffectivePriority returns the priority of the task group, falling back to
+// the priority of its job if the group doesn't set one.
+func (tg *TaskGroup) EffectivePriority(j *Job) int {
+	if tg.Priority != 0 || j == nil {
+		return tg.Priority
+	}
+	return j.Priority
 }
 
 func (tg *TaskGroup) Copy() *TaskGroup {
@@ -6264,6 +7515,9 @@ func (tg *TaskGroup) Validate(j *Job) error {
 	if tg.Count < 0 {
 		mErr.Errors = append(mErr.Errors, errors.New("Task group count can't be negative"))
 	}
+	if tg.Priority != 0 && (tg.Priority < JobMinPriority || tg.Priority > j.Priority) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Task group priority must be between [%d, %d], the job's priority", JobMinPriority, j.Priority))
+	}
 	if len(tg.Tasks) == 0 {
 		// could be a lone consul gateway inserted by the connect mutator
 		mErr.Errors = append(mErr.Errors, errors.New("Missing tasks for task group"))
@@ -6444,6 +7698,13 @@ func (tg *TaskGroup) validateNetworks() error {
 	staticPortsIndex := make(map[string]map[int]string)
 
 	for _, net := range tg.Networks {
+		if err := net.validateBandwidth(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+		if err := net.validateFirewall(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+
 		for _, port := range append(net.ReservedPorts, net.DynamicPorts...) {
 			if other, ok := portLabels[port.Label]; ok {
 				mErr.Errors = append(mErr.Errors, fmt.Errorf("Port label %s already in use by %s", port.Label, other))
@@ -6451,25 +7712,30 @@ func (tg *TaskGroup) validateNetworks() error {
 				portLabels[port.Label] = "taskgroup network"
 			}
 
+			if err := port.validateHostNetworks(); err != nil {
+				mErr.Errors = append(mErr.Errors, err)
+			}
+
 			if port.Value != 0 {
-				hostNetwork := port.HostNetwork
-				if hostNetwork == "" {
-					hostNetwork = "default"
-				}
-				staticPorts, ok := staticPortsIndex[hostNetwork]
-				if !ok {
-					staticPorts = make(map[int]string)
-				}
-				// static port
-				if other, ok := staticPorts[port.Value]; ok {
-					err := fmt.Errorf("Static port %d already reserved by %s", port.Value, other)
-					mErr.Errors = append(mErr.Errors, err)
-				} else if port.Value > math.MaxUint16 {
-					err := fmt.Errorf("Port %s (%d) cannot be greater than %d", port.Label, port.Value, math.MaxUint16)
-					mErr.Errors = append(mErr.Errors, err)
-				} else {
-					staticPorts[port.Value] = fmt.Sprintf("taskgroup network:%s", port.Label)
-					staticPortsIndex[hostNetwork] = staticPorts
+				for _, hostNetwork := range port.HostNetworkNames() {
+					if hostNetwork == "" {
+						hostNetwork = "default"
+					}
+					staticPorts, ok := staticPortsIndex[hostNetwork]
+					if !ok {
+						staticPorts = make(map[int]string)
+					}
+					// static port
+					if other, ok := staticPorts[port.Value]; ok {
+						err := fmt.Errorf("Static port %d already reserved by %s", port.Value, other)
+						mErr.Errors = append(mErr.Errors, err)
+					} else if port.Value > math.MaxUint16 {
+						err := fmt.Errorf("Port %s (%d) cannot be greater than %d", port.Label, port.Value, math.MaxUint16)
+						mErr.Errors = append(mErr.Errors, err)
+					} else {
+						staticPorts[port.Value] = fmt.Sprintf("taskgroup network:%s", port.Label)
+						staticPortsIndex[hostNetwork] = staticPorts
+					}
 				}
 			}
 
@@ -6500,29 +7766,41 @@ func (tg *TaskGroup) validateNetworks() error {
 		}
 
 		for _, net := range task.Resources.Networks {
+			if err := net.validateBandwidth(); err != nil {
+				mErr.Errors = append(mErr.Errors, multierror.Prefix(err, fmt.Sprintf("Task %q:", task.Name)))
+			}
+			if net.Firewall != nil {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Task %q: Network firewall can only be set on the task group network", task.Name))
+			}
+
 			for _, port := range append(net.ReservedPorts, net.DynamicPorts...) {
 				if other, ok := portLabels[port.Label]; ok {
 					mErr.Errors = append(mErr.Errors, fmt.Errorf("Port label %s already in use by %s", port.Label, other))
 				}
 
+				if err := port.validateHostNetworks(); err != nil {
+					mErr.Errors = append(mErr.Errors, err)
+				}
+
 				if port.Value != 0 {
-					hostNetwork := port.HostNetwork
-					if hostNetwork == "" {
-						hostNetwork = "default"
-					}
-					staticPorts, ok := staticPortsIndex[hostNetwork]
-					if !ok {
-						staticPorts = make(map[int]string)
-					}
-					if other, ok := staticPorts[port.Value]; ok {
-						err := fmt.Errorf("Static port %d already reserved by %s", port.Value, other)
-						mErr.Errors = append(mErr.Errors, err)
-					} else if port.Value > math.MaxUint16 {
-						err := fmt.Errorf("Port %s (%d) cannot be greater than %d", port.Label, port.Value, math.MaxUint16)
-						mErr.Errors = append(mErr.Errors, err)
-					} else {
-						staticPorts[port.Value] = fmt.Sprintf("%s:%s", task.Name, port.Label)
-						staticPortsIndex[hostNetwork] = staticPorts
+					for _, hostNetwork := range port.HostNetworkNames() {
+						if hostNetwork == "" {
+							hostNetwork = "default"
+						}
+						staticPorts, ok := staticPortsIndex[hostNetwork]
+						if !ok {
+							staticPorts = make(map[int]string)
+						}
+						if other, ok := staticPorts[port.Value]; ok {
+							err := fmt.Errorf("Static port %d already reserved by %s", port.Value, other)
+							mErr.Errors = append(mErr.Errors, err)
+						} else if port.Value > math.MaxUint16 {
+							err := fmt.Errorf("Port %s (%d) cannot be greater than %d", port.Label, port.Value, math.MaxUint16)
+							mErr.Errors = append(mErr.Errors, err)
+						} else {
+							staticPorts[port.Value] = fmt.Sprintf("%s:%s", task.Name, port.Label)
+							staticPortsIndex[hostNetwork] = staticPorts
+						}
 					}
 				}
 			}
@@ -6545,6 +7823,9 @@ func (tg *TaskGroup) validateServices() error {
 			continue
 		}
 		for _, service := range task.Services {
+			if err := service.validateCheckTenancy(tg.Consul.GetNamespace()); err != nil {
+				multierror.Append(&mErr, err)
+			}
 			for _, check := range service.Checks {
 				if check.TaskName != "" {
 					mErr.Errors = append(mErr.Errors, fmt.Errorf("Check %s is invalid: only task group service checks can be assigned tasks", check.Name))
@@ -6564,6 +7845,9 @@ func (tg *TaskGroup) validateServices() error {
 		if service.AddressMode == AddressModeDriver {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("service %q cannot use address_mode=\"driver\", only services defined in a \"task\" block can use this mode", service.Name))
 		}
+		if err := service.validateCheckTenancy(tg.Consul.GetNamespace()); err != nil {
+			multierror.Append(&mErr, err)
+		}
 
 		for _, check := range service.Checks {
 			if check.TaskName != "" {
@@ -6592,7 +7876,7 @@ func (tg *TaskGroup) validateScriptChecksInGroupServices() error {
 	for _, service := range tg.Services {
 		if service.TaskName == "" {
 			for _, check := range service.Checks {
-				if check.Type == "script" && check.TaskName == "" {
+				if (check.Type == "script" || check.RequiresClientTLS()) && check.TaskName == "" {
 					mErr.Errors = append(mErr.Errors,
 						fmt.Errorf("Service [%s]->%s or Check %s must specify task parameter",
 							tg.Name, service.Name, check.Name,
@@ -6848,6 +8132,9 @@ type Task struct {
 	// have access to.
 	Vault *Vault
 
+	// Identities are the workload identities signed for the task.
+	Identities []*WorkloadIdentity
+
 	// Templates are the set of templates to be rendered for the task.
 	Templates []*Template
 
@@ -6870,6 +8157,10 @@ type Task struct {
 
 	Lifecycle *TaskLifecycleConfig
 
+	// RuntimeEnv opts the task into environment variables sizing common
+	// runtimes to its resources.
+	RuntimeEnv *RuntimeEnvConfig
+
 	// Meta is used to associate arbitrary metadata with this
 	// task. This is opaque to Nomad.
 	Meta map[string]string
@@ -6946,11 +8237,13 @@ func (t *Task) Copy() *Task {
 	nt.CSIPluginConfig = nt.CSIPluginConfig.Copy()
 
 	nt.Vault = nt.Vault.Copy()
+	nt.Identities = CopySliceWorkloadIdentities(nt.Identities)
 	nt.Resources = nt.Resources.Copy()
 	nt.LogConfig = nt.LogConfig.Copy()
 	nt.Meta = helper.CopyMapStringString(nt.Meta)
 	nt.DispatchPayload = nt.DispatchPayload.Copy()
 	nt.Lifecycle = nt.Lifecycle.Copy()
+	nt.RuntimeEnv = nt.RuntimeEnv.Copy()
 
 	if t.Artifacts != nil {
 		artifacts := make([]*TaskArtifact, 0, len(t.Artifacts))
@@ -7015,6 +8308,10 @@ func (t *Task) Canonicalize(job *Job, tg *TaskGroup) {
 		t.Vault.Canonicalize()
 	}
 
+	for _, identity := range t.Identities {
+		identity.Canonicalize()
+	}
+
 	for _, template := range t.Templates {
 		template.Canonicalize()
 	}
@@ -7114,6 +8411,21 @@ func (t *Task) Validate(ephemeralDisk *EphemeralDisk, jobType string, tgServices
 		}
 	}
 
+	identities := make(map[string]int, len(t.Identities))
+	for idx, identity := range t.Identities {
+		if err := identity.Validate(); err != nil {
+			outer := fmt.Errorf("Identity %d validation failed: %s", idx+1, err)
+			mErr.Errors = append(mErr.Errors, outer)
+		}
+
+		if other, ok := identities[identity.Name]; ok {
+			outer := fmt.Errorf("Identity %d has same name as %d", idx+1, other)
+			mErr.Errors = append(mErr.Errors, outer)
+		} else {
+			identities[identity.Name] = idx + 1
+		}
+	}
+
 	destinations := make(map[string]int, len(t.Templates))
 	for idx, tmpl := range t.Templates {
 		if err := tmpl.Validate(); err != nil {
@@ -7121,6 +8433,11 @@ func (t *Task) Validate(ephemeralDisk *EphemeralDisk, jobType string, tgServices
 			mErr.Errors = append(mErr.Errors, outer)
 		}
 
+		if tmpl.VaultCluster != "" && t.Vault.Cluster(tmpl.VaultCluster) == nil {
+			outer := fmt.Errorf("Template %d reads from Vault cluster %q, but the task doesn't request a token from it", idx+1, tmpl.VaultCluster)
+			mErr.Errors = append(mErr.Errors, outer)
+		}
+
 		if other, ok := destinations[tmpl.DestPath]; ok {
 			outer := fmt.Errorf("Template %d has same destination as %d", idx+1, other)
 			mErr.Errors = append(mErr.Errors, outer)
@@ -7144,6 +8461,18 @@ func (t *Task) Validate(ephemeralDisk *EphemeralDisk, jobType string, tgServices
 
 	}
 
+	// Validate the runtime env block if there
+	if err := t.RuntimeEnv.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Runtime env validation failed: %v", err))
+	}
+
+	// Validate the vertical scaling policies
+	for _, p := range t.ScalingPolicies {
+		if err := p.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Scaling policy %q invalid: %v", p.Type, err))
+		}
+	}
+
 	// Validation for TaskKind field which is used for Consul Connect integration
 	if t.Kind.IsConnectProxy() {
 		// This task is a Connect proxy so it should not have service stanzas
@@ -7166,6 +8495,9 @@ func (t *Task) Validate(ephemeralDisk *EphemeralDisk, jobType string, tgServices
 		if !MountPropagationModeIsValid(vm.PropagationMode) {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Volume Mount (%d) has an invalid propagation mode: \"%s\"", idx, vm.PropagationMode))
 		}
+		if !MountOnRecoverIsValid(vm.OnRecover) {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Volume Mount (%d) has an invalid on_recover action: \"%s\"", idx, vm.OnRecover))
+		}
 	}
 
 	// Validate CSI Plugin Config
@@ -7415,6 +8747,11 @@ func (k TaskKind) IsConnectMesh() bool {
 	return k.hasPrefix(ConnectMeshPrefix)
 }
 
+// IsConnectAPIGateway returns true if the TaskKind is connect-api-gateway.
+func (k TaskKind) IsConnectAPIGateway() bool {
+	return k.hasPrefix(ConnectAPIGatewayPrefix)
+}
+
 // IsAnyConnectGateway returns true if the TaskKind represents any one of the
 // supported connect gateway types.
 func (k TaskKind) IsAnyConnectGateway() bool {
@@ -7425,6 +8762,8 @@ func (k TaskKind) IsAnyConnectGateway() bool {
 		return true
 	case k.IsConnectMesh():
 		return true
+	case k.IsConnectAPIGateway():
+		return true
 	default:
 		return false
 	}
@@ -7450,6 +8789,10 @@ const (
 	// ConnectMeshPrefix is the prefix used for fields referencing a Consul Connect
 	// Mesh Gateway Proxy.
 	ConnectMeshPrefix = "connect-mesh"
+
+	// ConnectAPIGatewayPrefix is the prefix used for fields referencing a
+	// Consul API Gateway Proxy.
+	ConnectAPIGatewayPrefix = "connect-api-gateway"
 )
 
 // ValidateConnectProxyService checks that the service that is being
@@ -7496,14 +8839,65 @@ const (
 	// TemplateChangeModeRestart marks that the task should be restarted if the
 	// template is re-rendered
 	TemplateChangeModeRestart = "restart"
+
+	// TemplateChangeModeScript marks that a script should be executed inside
+	// the task if the template is re-rendered
+	TemplateChangeModeScript = "script"
 )
 
 var (
 	// TemplateChangeModeInvalidError is the error for when an invalid change
 	// mode is given
-	TemplateChangeModeInvalidError = errors.New("Invalid change mode. Must be one of the following: noop, signal, restart")
+	TemplateChangeModeInvalidError = errors.New("Invalid change mode. Must be one of the following: noop, signal, restart, script")
 )
 
+// ChangeScript is the script executed inside the task when a template with
+// change mode script is re-rendered.
+type ChangeScript struct {
+	// Command is the command to execute, with Args as its arguments
+	Command string
+	Args    []string
+
+	// Timeout is the time the script may run before it is killed
+	Timeout time.Duration
+
+	// FailOnError fails the task if the script fails or can't be executed
+	FailOnError bool
+
+	// PassChangedKeys appends the keys that changed when the template was
+	// re-rendered to the arguments of the script. The keys are the names of
+	// the changed variables of env templates and the destinations of other
+	// templates.
+	PassChangedKeys bool
+}
+
+// Copy returns a copy of this ChangeScript.
+func (cs *ChangeScript) Copy() *ChangeScript {
+	if cs == nil {
+		return nil
+	}
+	ncs := new(ChangeScript)
+	*ncs = *cs
+	ncs.Args = helper.CopySliceString(cs.Args)
+	return ncs
+}
+
+// Validate the script of a template.
+func (cs *ChangeScript) Validate() error {
+	if cs == nil {
+		return fmt.Errorf("Must specify change script when change mode is script")
+	}
+
+	var mErr multierror.Error
+	if cs.Command == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Must specify a change script command"))
+	}
+	if cs.Timeout < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Change script timeout can't be negative"))
+	}
+	return mErr.ErrorOrNil()
+}
+
 // Template represents a template configuration to be rendered for a given task
 type Template struct {
 	// SourcePath is the path to the template to be rendered
@@ -7523,6 +8917,10 @@ type Template struct {
 	// requires it.
 	ChangeSignal string
 
+	// ChangeScript is the script that should be executed if the change mode
+	// requires it.
+	ChangeScript *ChangeScript
+
 	// Splay is used to avoid coordinated restarts of processes by applying a
 	// random wait between 0 and the given splay value before signalling the
 	// application of a change
@@ -7554,6 +8952,11 @@ type Template struct {
 	// COMPAT(0.12) VaultGrace has been ignored by Vault since Vault v0.5.
 	VaultGrace time.Duration
 
+	// VaultCluster is the name of the Vault cluster the template reads
+	// secrets from, or empty for the default cluster. The task must request
+	// a token from the cluster.
+	VaultCluster string
+
 	// WaitConfig is used to override the global WaitConfig on a per-template basis
 	Wait *WaitConfig
 }
@@ -7578,6 +8981,8 @@ func (t *Template) Copy() *Template {
 		nt.Wait = t.Wait.Copy()
 	}
 
+	nt.ChangeScript = t.ChangeScript.Copy()
+
 	return nt
 }
 
@@ -7618,6 +9023,10 @@ func (t *Template) Validate() error {
 		if t.Envvars {
 			_ = multierror.Append(&mErr, fmt.Errorf("cannot use signals with env var templates"))
 		}
+	case TemplateChangeModeScript:
+		if err := t.ChangeScript.Validate(); err != nil {
+			_ = multierror.Append(&mErr, err)
+		}
 	default:
 		_ = multierror.Append(&mErr, TemplateChangeModeInvalidError)
 	}
@@ -7797,6 +9206,11 @@ type TaskState struct {
 	// Series of task events that transition the state of the task.
 	Events []*TaskEvent
 
+	// DriverStatus is the most recent message reported by the task driver
+	// while the task is pending, such as image pull progress. It is cleared
+	// once the task leaves the pending state.
+	DriverStatus string
+
 	// Experimental -  TaskHandle is based on drivers.TaskHandle and used
 	// by remote task drivers to migrate task handles between allocations.
 	TaskHandle *TaskHandle
@@ -7905,6 +9319,10 @@ const (
 	// exceeded the requested disk resources.
 	TaskDiskExceeded = "Disk Resources Exceeded"
 
+	// TaskNUMACrossNode indicates that the reserved cores of a task span
+	// several NUMA nodes, so its memory is bound to all of them.
+	TaskNUMACrossNode = "NUMA Cross-Node"
+
 	// TaskSiblingFailed indicates that a sibling task in the task group has
 	// failed.
 	TaskSiblingFailed = "Sibling Task Failed"
@@ -7920,6 +9338,10 @@ const (
 	// TaskMainDead indicates that the main tasks have dead
 	TaskMainDead = "Main Tasks Dead"
 
+	// TaskRunningPrestop indicates that the task is waiting for the prestop
+	// tasks of its group to finish before being killed.
+	TaskRunningPrestop = "Running Prestop Tasks"
+
 	// TaskHookFailed indicates that one of the hooks for a task failed.
 	TaskHookFailed = "Task hook failed"
 
@@ -7932,6 +9354,22 @@ const (
 
 	// TaskPluginHealthy indicates that a plugin managed by Nomad became healthy
 	TaskPluginHealthy = "Plugin became healthy"
+
+	// TaskVolumeUnavailable indicates that a volume mounted by the task
+	// became unavailable
+	TaskVolumeUnavailable = "Volume unavailable"
+
+	// TaskVolumeRecovered indicates that a volume mounted by the task
+	// recovered after being unavailable
+	TaskVolumeRecovered = "Volume recovered"
+
+	// TaskIdentityRotated indicates that a workload identity of the task was
+	// rotated
+	TaskIdentityRotated = "Identity rotated"
+
+	// TaskIdentityRotationFailed indicates that a workload identity of the
+	// task could not be rotated and will expire unless a retry succeeds
+	TaskIdentityRotationFailed = "Identity rotation failed"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
@@ -8143,6 +9581,8 @@ func (e *TaskEvent) PopulateEventDisplayMessage() {
 		desc = "Leader Task in Group dead"
 	case TaskMainDead:
 		desc = "Main tasks in the group died"
+	case TaskRunningPrestop:
+		desc = "Waiting for prestop tasks to finish before killing the task"
 	default:
 		desc = e.Message
 	}
@@ -8324,6 +9764,11 @@ func (e *TaskEvent) SetOOMKilled(oom bool) *TaskEvent {
 	return e
 }
 
+func (e *TaskEvent) SetNUMANodes(nodes string) *TaskEvent {
+	e.Details["numa_nodes"] = nodes
+	return e
+}
+
 // TaskArtifact is an artifact to download before running the task.
 type TaskArtifact struct {
 	// GetterSource is the source to download an artifact using go-getter
@@ -8493,8 +9938,61 @@ const (
 	ConstraintSetContainsAny    = "set_contains_any"
 	ConstraintAttributeIsSet    = "is_set"
 	ConstraintAttributeIsNotSet = "is_not_set"
+	ConstraintTimeWindow        = "time_window"
 )
 
+// TimeWindow is the set of times a time_window constraint allows placements
+// at, as a cron expression evaluated in a time zone with a granularity of a
+// minute.
+type TimeWindow struct {
+	expr     *cronexpr.Expression
+	location *time.Location
+}
+
+// ParseTimeWindow parses the value of a time_window constraint. The cron
+// expression may be prefixed with "CRON_TZ=<zone> " or "TZ=<zone> " to
+// evaluate it in a time zone other than UTC.
+func ParseTimeWindow(spec string) (*TimeWindow, error) {
+	location := time.UTC
+	spec = strings.TrimSpace(spec)
+	for _, prefix := range []string{"CRON_TZ=", "TZ="} {
+		if !strings.HasPrefix(spec, prefix) {
+			continue
+		}
+		fields := strings.SplitN(strings.TrimPrefix(spec, prefix), " ", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("missing cron expression after time zone")
+		}
+		var err error
+		if location, err = time.LoadLocation(fields[0]); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %v", fields[0], err)
+		}
+		spec = fields[1]
+		break
+	}
+
+	expr, err := cronexpr.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %v", spec, err)
+	}
+	return &TimeWindow{expr: expr, location: location}, nil
+}
+
+// Open returns whether the window is open at the given time.
+func (w *TimeWindow) Open(t time.Time) bool {
+	minute := t.In(w.location).Truncate(time.Minute)
+	return w.expr.Next(minute.Add(-time.Second)).Equal(minute)
+}
+
+// NextOpen returns the first time the window is open at or after the given
+// time, or the zero time if the window never opens.
+func (w *TimeWindow) NextOpen(t time.Time) time.Time {
+	if w.Open(t) {
+		return t
+	}
+	return w.expr.Next(t.In(w.location))
+}
+
 // A Constraint is used to restrict placement options.
 type Constraint struct {
 	LTarget string // Left-hand target
@@ -8544,6 +10042,13 @@ func (c *Constraint) Validate() error {
 	switch c.Operand {
 	case ConstraintDistinctHosts:
 		requireLtarget = false
+	case ConstraintTimeWindow:
+		requireLtarget = false
+		if w, err := ParseTimeWindow(c.RTarget); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Time window is invalid: %v", err))
+		} else if w.NextOpen(time.Now()).IsZero() {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Time window %q never opens", c.RTarget))
+		}
 	case ConstraintSetContainsAll, ConstraintSetContainsAny, ConstraintSetContains:
 		if c.RTarget == "" {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Set contains constraint requires an RTarget"))
@@ -8892,6 +10397,60 @@ type Vault struct {
 	// ChangeSignal is the signal sent to the task when a new token is
 	// retrieved. This is only valid when using the signal change mode.
 	ChangeSignal string
+
+	// Clusters is the set of named Vault clusters, configured on the agents,
+	// the task needs a token from in addition to the default cluster.
+	Clusters []*VaultCluster
+}
+
+// VaultCluster is the set of policies the task needs access to on a named
+// Vault cluster.
+type VaultCluster struct {
+	// Name is the name of the Vault cluster in the agent configuration
+	Name string
+
+	// Policies is the set of policies that the task needs access to
+	Policies []string
+
+	// Namespace is the vault namespace that should be used.
+	Namespace string
+
+	// Env marks whether the Vault Token should be exposed as an environment
+	// variable
+	Env bool
+}
+
+// Copy returns a copy of this Vault cluster block.
+func (c *VaultCluster) Copy() *VaultCluster {
+	if c == nil {
+		return nil
+	}
+
+	nc := new(VaultCluster)
+	*nc = *c
+	nc.Policies = helper.CopySliceString(c.Policies)
+	return nc
+}
+
+// validVaultClusterName matches the valid names of Vault clusters, which are
+// used in the names of the files and environment variables of their tokens.
+var validVaultClusterName = regexp.MustCompile("^[a-zA-Z0-9_-]+$")
+
+// Validate returns if the Vault cluster block is valid.
+func (c *VaultCluster) Validate() error {
+	var mErr multierror.Error
+	if !validVaultClusterName.MatchString(c.Name) {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid cluster name %q: must only contain alphanumeric, dash and underscore characters", c.Name))
+	}
+	if len(c.Policies) == 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Cluster %q policy list cannot be empty", c.Name))
+	}
+	for _, p := range c.Policies {
+		if p == "root" {
+			_ = multierror.Append(&mErr, fmt.Errorf("Cluster %q can not specify \"root\" policy", c.Name))
+		}
+	}
+	return mErr.ErrorOrNil()
 }
 
 func DefaultVaultBlock() *Vault {
@@ -8909,9 +10468,30 @@ func (v *Vault) Copy() *Vault {
 
 	nv := new(Vault)
 	*nv = *v
+	nv.Policies = helper.CopySliceString(v.Policies)
+	if v.Clusters != nil {
+		nv.Clusters = make([]*VaultCluster, len(v.Clusters))
+		for i, c := range v.Clusters {
+			nv.Clusters[i] = c.Copy()
+		}
+	}
 	return nv
 }
 
+// Cluster returns the block of the named Vault cluster, or nil if the task
+// doesn't need a token from it.
+func (v *Vault) Cluster(name string) *VaultCluster {
+	if v == nil {
+		return nil
+	}
+	for _, c := range v.Clusters {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
 func (v *Vault) Canonicalize() {
 	if v.ChangeSignal != "" {
 		v.ChangeSignal = strings.ToUpper(v.ChangeSignal)
@@ -8945,6 +10525,18 @@ func (v *Vault) Validate() error {
 		_ = multierror.Append(&mErr, fmt.Errorf("Unknown change mode %q", v.ChangeMode))
 	}
 
+	clusters := make(map[string]struct{}, len(v.Clusters))
+	for _, c := range v.Clusters {
+		if _, ok := clusters[c.Name]; ok {
+			_ = multierror.Append(&mErr, fmt.Errorf("Duplicate cluster %q", c.Name))
+			continue
+		}
+		clusters[c.Name] = struct{}{}
+		if err := c.Validate(); err != nil {
+			_ = multierror.Append(&mErr, err)
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -8972,6 +10564,7 @@ const (
 	DeploymentStatusDescriptionNewerJob              = "Cancelled due to newer version of job"
 	DeploymentStatusDescriptionFailedAllocations     = "Failed due to unhealthy allocations"
 	DeploymentStatusDescriptionProgressDeadline      = "Failed due to progress deadline"
+	DeploymentStatusDescriptionFailedSLO             = "Failed due to unhealthy SLO query"
 	DeploymentStatusDescriptionFailedByUser          = "Deployment marked as failed"
 
 	// used only in multiregion deployments
@@ -9041,6 +10634,10 @@ type Deployment struct {
 	// status.
 	StatusDescription string
 
+	// StatusReason is the reason given by the operator who set the status of
+	// the deployment, such as when failing it.
+	StatusReason string
+
 	// EvalPriority tracks the priority of the evaluation which lead to the
 	// creation of this Deployment object. Any additional evaluations created
 	// as a result of this deployment can therefore inherit this value, which
@@ -9135,7 +10732,7 @@ func (d *Deployment) RequiresPromotion() bool {
 		return false
 	}
 	for _, group := range d.TaskGroups {
-		if group.DesiredCanaries > 0 && !group.Promoted {
+		if group.DesiredCanaries > 0 && (!group.Promoted || group.PartiallyPromoted()) {
 			return true
 		}
 	}
@@ -9173,6 +10770,11 @@ type DeploymentState struct {
 	// copied from TaskGroup UpdateStrategy in scheduler.reconcile
 	AutoPromote bool
 
+	// BlueGreen marks that promotion stages the services of the allocations
+	// of the previous job version, copied from TaskGroup UpdateStrategy in
+	// scheduler.reconcile
+	BlueGreen bool
+
 	// ProgressDeadline is the deadline by which an allocation must transition
 	// to healthy before the deployment is considered failed. This value is set
 	// by the jobspec `update.progress_deadline` field.
@@ -9186,6 +10788,11 @@ type DeploymentState struct {
 	// Promoted marks whether the canaries have been promoted
 	Promoted bool
 
+	// PromotedPercent is the percentage of the group's allocations the
+	// deployment may roll out to after the canaries have been promoted. Zero
+	// or 100 means the canaries have been promoted fully.
+	PromotedPercent int
+
 	// PlacedCanaries is the set of placed canary allocations
 	PlacedCanaries []string
 
@@ -9211,6 +10818,7 @@ func (d *DeploymentState) GoString() string {
 	base += fmt.Sprintf("\n\tDesired Canaries: %d", d.DesiredCanaries)
 	base += fmt.Sprintf("\n\tPlaced Canaries: %#v", d.PlacedCanaries)
 	base += fmt.Sprintf("\n\tPromoted: %v", d.Promoted)
+	base += fmt.Sprintf("\n\tPromoted Percent: %d", d.PromotedPercent)
 	base += fmt.Sprintf("\n\tPlaced: %d", d.PlacedAllocs)
 	base += fmt.Sprintf("\n\tHealthy: %d", d.HealthyAllocs)
 	base += fmt.Sprintf("\n\tUnhealthy: %d", d.UnhealthyAllocs)
@@ -9226,6 +10834,23 @@ func (d *DeploymentState) Copy() *DeploymentState {
 	return c
 }
 
+// PartiallyPromoted returns whether the canaries have been promoted but the
+// deployment may only roll out to a percentage of the group's allocations.
+func (d *DeploymentState) PartiallyPromoted() bool {
+	return d.Promoted && d.PromotedPercent > 0 && d.PromotedPercent < 100
+}
+
+// PromotedTotal returns the number of allocations the deployment may roll out
+// to given the percentage the group has been promoted to. It is never less
+// than the number of canaries.
+func (d *DeploymentState) PromotedTotal() int {
+	if !d.PartiallyPromoted() {
+		return d.DesiredTotal
+	}
+	total := (d.DesiredTotal*d.PromotedPercent + 99) / 100
+	return helper.IntMax(total, d.DesiredCanaries)
+}
+
 // DeploymentStatusUpdate is used to update the status of a given deployment
 type DeploymentStatusUpdate struct {
 	// DeploymentID is the ID of the deployment to update
@@ -9236,11 +10861,19 @@ type DeploymentStatusUpdate struct {
 
 	// StatusDescription is the new status description of the deployment.
 	StatusDescription string
+
+	// StatusReason is the reason given by the operator for the new status.
+	StatusReason string
 }
 
 // RescheduleTracker encapsulates previous reschedule events
 type RescheduleTracker struct {
 	Events []*RescheduleEvent
+
+	// LifetimeStart is the time the first allocation of the reschedule chain
+	// was created, used to enforce the max lifetime of the reschedule policy.
+	// It is zero for allocations rescheduled before it was tracked.
+	LifetimeStart int64
 }
 
 func (rt *RescheduleTracker) Copy() *RescheduleTracker {
@@ -9484,6 +11117,11 @@ type Allocation struct {
 	// in order to place this allocation
 	PreemptedAllocations []string
 
+	// HostVolumeSources is the host volume chosen by the scheduler for each
+	// host volume of the task group with alternative sources, keyed by the
+	// name of the volume in the task group.
+	HostVolumeSources map[string]string
+
 	// PreemptedByAllocation tracks the alloc ID of the allocation that caused this allocation
 	// to stop running because it got preempted
 	PreemptedByAllocation string
@@ -9631,9 +11269,20 @@ func (a *Allocation) copyImpl(job bool) *Allocation {
 
 	na.RescheduleTracker = a.RescheduleTracker.Copy()
 	na.PreemptedAllocations = helper.CopySliceString(a.PreemptedAllocations)
+	na.HostVolumeSources = helper.CopyMapStringString(a.HostVolumeSources)
 	return na
 }
 
+// HostVolumeSource returns the host volume mounted for the host volume
+// request of the task group named name: the source chosen by the scheduler
+// if the request has alternative sources, and its source otherwise.
+func (a *Allocation) HostVolumeSource(name string, req *VolumeRequest) string {
+	if source, ok := a.HostVolumeSources[name]; ok {
+		return source
+	}
+	return req.Source
+}
+
 // TerminalStatus returns if the desired or actual status is terminal and
 // will no longer transition.
 func (a *Allocation) TerminalStatus() bool {
@@ -9690,6 +11339,9 @@ func (a *Allocation) RescheduleEligible(reschedulePolicy *ReschedulePolicy, fail
 	if !enabled {
 		return false
 	}
+	if a.rescheduleLifetimeExceeded(reschedulePolicy, failTime) {
+		return false
+	}
 	if reschedulePolicy.Unlimited {
 		return true
 	}
@@ -9721,6 +11373,43 @@ func (a *Allocation) rescheduleInfo(reschedulePolicy *ReschedulePolicy, failTime
 	return attempted, attempts
 }
 
+// RescheduleLifetimeStart returns the time the first allocation of the
+// reschedule chain of the allocation was created.
+func (a *Allocation) RescheduleLifetimeStart() time.Time {
+	if a.RescheduleTracker != nil && a.RescheduleTracker.LifetimeStart != 0 {
+		return time.Unix(0, a.RescheduleTracker.LifetimeStart).UTC()
+	}
+	return time.Unix(0, a.CreateTime).UTC()
+}
+
+// rescheduleLifetimeExceeded returns true if the max lifetime of the
+// reschedule policy has passed at the given time.
+func (a *Allocation) rescheduleLifetimeExceeded(reschedulePolicy *ReschedulePolicy, t time.Time) bool {
+	if reschedulePolicy.MaxLifetime <= 0 {
+		return false
+	}
+	return t.Sub(a.RescheduleLifetimeStart()) >= reschedulePolicy.MaxLifetime
+}
+
+// rescheduleJitter returns the jitter added to the given reschedule delay. It
+// is derived from the allocation ID and the number of previous attempts so
+// that it doesn't change when the next reschedule time is computed again.
+func (a *Allocation) rescheduleJitter(reschedulePolicy *ReschedulePolicy, delay time.Duration) time.Duration {
+	if reschedulePolicy.Jitter <= 0 || delay <= 0 {
+		return 0
+	}
+
+	attempt := 0
+	if a.RescheduleTracker != nil {
+		attempt = len(a.RescheduleTracker.Events)
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(fmt.Sprintf("%s-%d", a.ID, attempt)))
+	fraction := float64(h.Sum32()) / float64(math.MaxUint32)
+
+	return time.Duration(float64(delay) * reschedulePolicy.Jitter * fraction)
+}
+
 func (a *Allocation) RescheduleInfo() (int, int) {
 	return a.rescheduleInfo(a.ReschedulePolicy(), a.LastEventTime())
 }
@@ -9772,13 +11461,16 @@ func (a *Allocation) NextRescheduleTime() (time.Time, bool) {
 	}
 
 	nextDelay := a.NextDelay()
-	nextRescheduleTime := failTime.Add(nextDelay)
+	nextRescheduleTime := failTime.Add(nextDelay + a.rescheduleJitter(reschedulePolicy, nextDelay))
 	rescheduleEligible := reschedulePolicy.Unlimited || (reschedulePolicy.Attempts > 0 && a.RescheduleTracker == nil)
 	if reschedulePolicy.Attempts > 0 && a.RescheduleTracker != nil && a.RescheduleTracker.Events != nil {
 		// Check for eligibility based on the interval if max attempts is set
 		attempted, attempts := a.rescheduleInfo(reschedulePolicy, failTime)
 		rescheduleEligible = attempted < attempts && nextDelay < reschedulePolicy.Interval
 	}
+	if a.rescheduleLifetimeExceeded(reschedulePolicy, nextRescheduleTime) {
+		rescheduleEligible = false
+	}
 	return nextRescheduleTime, rescheduleEligible
 }
 
@@ -10010,6 +11702,27 @@ func (a *Allocation) LookupTask(name string) *Task {
 	return tg.LookupTask(name)
 }
 
+// BlueGreenTag returns the tag the allocation's services are registered with
+// as part of blue/green deployments, or an empty string if its task group
+// doesn't use them.
+func (a *Allocation) BlueGreenTag() string {
+	if a.DeploymentStatus != nil && a.DeploymentStatus.Staged {
+		return BlueGreenStagedTag
+	}
+	if a.Job == nil {
+		return ""
+	}
+
+	tg := a.Job.LookupTaskGroup(a.TaskGroup)
+	if tg == nil || tg.Update == nil || !tg.Update.BlueGreen {
+		return ""
+	}
+	if a.DeploymentStatus.IsCanary() {
+		return BlueGreenStagedTag
+	}
+	return BlueGreenLiveTag
+}
+
 // Stub returns a list stub for the allocation
 func (a *Allocation) Stub(fields *AllocStubFields) *AllocListStub {
 	s := &AllocListStub{
@@ -10180,6 +11893,10 @@ type AllocMetric struct {
 	// the highest normalized score
 	topScores *kheap.ScoreHeap
 
+	// tracer is notified of the per-node decisions when the evaluation is
+	// traced. It is never serialized.
+	tracer AllocMetricTracer
+
 	// AllocationTime is a measure of how long the allocation
 	// attempt took. This can affect performance and SLAs.
 	AllocationTime time.Duration
@@ -10205,15 +11922,25 @@ func (a *AllocMetric) Copy() *AllocMetric {
 	na.QuotaExhausted = helper.CopySliceString(na.QuotaExhausted)
 	na.Scores = helper.CopyMapStringFloat64(na.Scores)
 	na.ScoreMetaData = CopySliceNodeScoreMeta(na.ScoreMetaData)
+	na.tracer = nil
 	return na
 }
 
+// SetTracer sets the tracer notified of the per-node decisions recorded in
+// the metric.
+func (a *AllocMetric) SetTracer(tracer AllocMetricTracer) {
+	a.tracer = tracer
+}
+
 func (a *AllocMetric) EvaluateNode() {
 	a.NodesEvaluated += 1
 }
 
 func (a *AllocMetric) FilterNode(node *Node, constraint string) {
 	a.NodesFiltered += 1
+	if a.tracer != nil {
+		a.tracer.NodeFiltered(node, constraint)
+	}
 	if node != nil && node.NodeClass != "" {
 		if a.ClassFiltered == nil {
 			a.ClassFiltered = make(map[string]int)
@@ -10230,6 +11957,9 @@ func (a *AllocMetric) FilterNode(node *Node, constraint string) {
 
 func (a *AllocMetric) ExhaustedNode(node *Node, dimension string) {
 	a.NodesExhausted += 1
+	if a.tracer != nil {
+		a.tracer.NodeExhausted(node, dimension)
+	}
 	if node != nil && node.NodeClass != "" {
 		if a.ClassExhausted == nil {
 			a.ClassExhausted = make(map[string]int)
@@ -10292,6 +12022,9 @@ func (a *AllocMetric) ScoreNode(node *Node, name string, score float64) {
 	}
 	if name == NormScorerName {
 		a.nodeScoreMeta.NormScore = score
+		if a.tracer != nil {
+			a.tracer.NodeScored(node, a.nodeScoreMeta)
+		}
 		// Once we have the normalized score we can push to the heap
 		// that tracks top K by normalized score
 
@@ -10390,6 +12123,22 @@ type AllocDeploymentStatus struct {
 	// been promoted will have this field set to false.
 	Canary bool
 
+	// Staged marks an allocation of a previous job version whose services
+	// were moved to the BlueGreenStagedTag when a blue/green deployment
+	// replacing it was promoted.
+	Staged bool
+
+	// Manual marks whether the health was set through the deployment API
+	// rather than reported by the client.
+	Manual bool
+
+	// Reason is the reason given when the health was set manually.
+	Reason string
+
+	// SetBy is the accessor ID of the ACL token that set the health
+	// manually. It is empty if ACLs are disabled.
+	SetBy string
+
 	// ModifyIndex is the raft index in which the deployment status was last
 	// changed.
 	ModifyIndex uint64
@@ -10468,6 +12217,7 @@ const (
 	EvalTriggerQueuedAllocs      = "queued-allocs"
 	EvalTriggerPreemption        = "preemption"
 	EvalTriggerScaling           = "job-scaling"
+	EvalTriggerJobDependency     = "job-dependency"
 )
 
 const (
@@ -10572,6 +12322,11 @@ type Evaluation struct {
 	// supported delayed rescheduling of failed allocations
 	WaitUntil time.Time
 
+	// BlockedUntil is the time a blocked eval is unblocked at regardless of
+	// capacity changes. This is used to retry placements when the time
+	// windows of the job's constraints open.
+	BlockedUntil time.Time
+
 	// NextEval is the evaluation ID for the eval created to do a followup.
 	// This is used to support rolling upgrades and failed-follow-up evals, where
 	// we need a chain of evaluations.
@@ -10616,6 +12371,11 @@ type Evaluation struct {
 	// evaluation was processed. The map is keyed by Task Group names.
 	QueuedAllocations map[string]int
 
+	// Trace is the timeline of scheduler decisions recorded while processing
+	// the evaluation. It is only set when eval tracing is enabled in the
+	// scheduler configuration.
+	Trace *EvalTrace
+
 	// LeaderACL provides the ACL token to when issuing RPCs back to the
 	// leader. This will be a valid management token as long as the leader is
 	// active. This should not ever be exposed via the API.
@@ -10649,6 +12409,7 @@ type EvaluationStub struct {
 	Status            string
 	StatusDescription string
 	WaitUntil         time.Time
+	BlockedUntil      time.Time
 	NextEval          string
 	PreviousEval      string
 	BlockedEval       string
@@ -10724,6 +12485,7 @@ func (e *Evaluation) Stub() *EvaluationStub {
 		Status:            e.Status,
 		StatusDescription: e.StatusDescription,
 		WaitUntil:         e.WaitUntil,
+		BlockedUntil:      e.BlockedUntil,
 		NextEval:          e.NextEval,
 		PreviousEval:      e.PreviousEval,
 		BlockedEval:       e.BlockedEval,
@@ -10768,6 +12530,8 @@ func (e *Evaluation) Copy() *Evaluation {
 		ne.QueuedAllocations = queuedAllocations
 	}
 
+	ne.Trace = e.Trace.Copy()
+
 	return ne
 }
 
@@ -11169,6 +12933,86 @@ type PlanResult struct {
 	// AllocIndex is the Raft index in which the evictions and
 	// allocations took place. This is used for the write index.
 	AllocIndex uint64
+
+	// RejectedNodes maps the ID of each node whose updates and placements
+	// were rejected to the reason it was rejected.
+	RejectedNodes map[string]string
+}
+
+// JobPlanResults contains the most recent plan apply results of a job
+type JobPlanResults struct {
+	Namespace string
+	JobID     string
+
+	// PlanResults is sorted from newest to oldest and has at most
+	// JobTrackedPlanResults entries
+	PlanResults []*PlanApplyResult
+
+	// Raft index
+	ModifyIndex uint64
+}
+
+// PlanApplyResult records which nodes the plan applier accepted and rejected
+// when applying the plan of an evaluation, so that placement decisions can be
+// inspected after the fact.
+type PlanApplyResult struct {
+	// EvalID is the ID of the evaluation that submitted the plan
+	EvalID string
+
+	// AcceptedNodes are the IDs of the nodes whose updates and placements
+	// were committed
+	AcceptedNodes []string
+
+	// RejectedNodes are the nodes whose updates and placements were rejected
+	RejectedNodes []*PlanRejectedNode
+
+	// RefreshIndex is the index the scheduler was asked to refresh its state
+	// to because nodes were rejected
+	RefreshIndex uint64
+
+	CreateTime  int64
+	CreateIndex uint64
+}
+
+// PlanRejectedNode is a node rejected by the plan applier.
+type PlanRejectedNode struct {
+	NodeID string
+	Reason string
+}
+
+// NewPlanApplyResult returns the PlanApplyResult of applying the plan, with
+// the nodes sorted by ID.
+func NewPlanApplyResult(plan *Plan, result *PlanResult, now int64) *PlanApplyResult {
+	accepted := make(map[string]struct{}, len(result.NodeUpdate)+len(result.NodeAllocation))
+	for nodeID := range result.NodeUpdate {
+		accepted[nodeID] = struct{}{}
+	}
+	for nodeID := range result.NodeAllocation {
+		accepted[nodeID] = struct{}{}
+	}
+
+	applyResult := &PlanApplyResult{
+		EvalID:        plan.EvalID,
+		AcceptedNodes: make([]string, 0, len(accepted)),
+		RejectedNodes: make([]*PlanRejectedNode, 0, len(result.RejectedNodes)),
+		RefreshIndex:  result.RefreshIndex,
+		CreateTime:    now,
+	}
+	for nodeID := range accepted {
+		applyResult.AcceptedNodes = append(applyResult.AcceptedNodes, nodeID)
+	}
+	for nodeID, reason := range result.RejectedNodes {
+		applyResult.RejectedNodes = append(applyResult.RejectedNodes, &PlanRejectedNode{
+			NodeID: nodeID,
+			Reason: reason,
+		})
+	}
+
+	sort.Strings(applyResult.AcceptedNodes)
+	sort.Slice(applyResult.RejectedNodes, func(i, j int) bool {
+		return applyResult.RejectedNodes[i].NodeID < applyResult.RejectedNodes[j].NodeID
+	})
+	return applyResult
 }
 
 // IsNoOp checks if this plan result would do nothing
@@ -11199,6 +13043,70 @@ type PlanAnnotations struct {
 
 	// PreemptedAllocs is the set of allocations to be preempted to make the placement successful.
 	PreemptedAllocs []*AllocListStub
+
+	// PreemptingTaskGroups maps the IDs of the preempted allocations to the
+	// task group of the job whose placement preempts them.
+	PreemptingTaskGroups map[string]string
+
+	// PlacementScores attributes the score of the node selected for each
+	// placement to the built-in scorers and scoring plugins that ranked it.
+	PlacementScores []*PlacementScore
+}
+
+// PlacementScore is the score of the node selected for a placement, broken
+// down by scorer.
+type PlacementScore struct {
+	AllocName string
+	TaskGroup string
+	NodeID    string
+	NodeName  string
+
+	// FinalScore is the normalized score the node was ranked by.
+	FinalScore float64
+
+	// Scores are the scores of the node keyed by the name of the built-in
+	// scorer or scoring plugin that computed them. Scores of scoring plugins
+	// are recorded before they are weighted.
+	Scores map[string]float64
+}
+
+// AddPlacementScore annotates the plan with the score of the node selected
+// for the placement of the allocation. The per scorer scores are looked up in
+// the allocation's metrics.
+func (p *PlanAnnotations) AddPlacementScore(alloc *Allocation, finalScore float64) {
+	score := &PlacementScore{
+		AllocName:  alloc.Name,
+		TaskGroup:  alloc.TaskGroup,
+		NodeID:     alloc.NodeID,
+		NodeName:   alloc.NodeName,
+		FinalScore: finalScore,
+	}
+	if alloc.Metrics != nil {
+		for _, meta := range alloc.Metrics.ScoreMetaData {
+			if meta.NodeID == alloc.NodeID {
+				score.Scores = helper.CopyMapStringFloat64(meta.Scores)
+				break
+			}
+		}
+	}
+	p.PlacementScores = append(p.PlacementScores, score)
+}
+
+// AddPreemptedAlloc annotates the plan with an allocation preempted to place
+// an allocation of the given task group.
+func (p *PlanAnnotations) AddPreemptedAlloc(alloc *Allocation, taskGroup string) {
+	p.PreemptedAllocs = append(p.PreemptedAllocs, alloc.Stub(nil))
+
+	if p.PreemptingTaskGroups == nil {
+		p.PreemptingTaskGroups = make(map[string]string)
+	}
+	p.PreemptingTaskGroups[alloc.ID] = taskGroup
+
+	if p.DesiredTGUpdates != nil {
+		if desired := p.DesiredTGUpdates[taskGroup]; desired != nil {
+			desired.Preemptions += 1
+		}
+	}
 }
 
 // DesiredUpdates is the set of changes the scheduler would like to make given
@@ -11212,11 +13120,15 @@ type DesiredUpdates struct {
 	DestructiveUpdate uint64
 	Canary            uint64
 	Preemptions       uint64
+
+	// Displaced is the number of allocations of the task group stopped to
+	// make room for higher priority task groups of the job.
+	Displaced uint64
 }
 
 func (d *DesiredUpdates) GoString() string {
-	return fmt.Sprintf("(place %d) (inplace %d) (destructive %d) (stop %d) (migrate %d) (ignore %d) (canary %d)",
-		d.Place, d.InPlaceUpdate, d.DestructiveUpdate, d.Stop, d.Migrate, d.Ignore, d.Canary)
+	return fmt.Sprintf("(place %d) (inplace %d) (destructive %d) (stop %d) (migrate %d) (ignore %d) (canary %d) (displaced %d)",
+		d.Place, d.InPlaceUpdate, d.DestructiveUpdate, d.Stop, d.Migrate, d.Ignore, d.Canary, d.Displaced)
 }
 
 // msgpackHandle is a shared handle for encoding/decoding of structs
@@ -11487,6 +13399,40 @@ type ACLToken struct {
 	CreateTime  time.Time // Time of creation
 	CreateIndex uint64
 	ModifyIndex uint64
+
+	// ExpiringPolicies are policies granted to the token for a bounded
+	// amount of time. Once a policy's expiration time has passed it no longer
+	// contributes to the ACL the token resolves to.
+	ExpiringPolicies []*ACLTokenExpiringPolicy
+}
+
+// ACLTokenExpiringPolicy links a policy to a token until its expiration time.
+type ACLTokenExpiringPolicy struct {
+	// Name is the name of the linked policy.
+	Name string
+
+	// ExpirationTime is the time at which the link stops granting the policy.
+	ExpirationTime time.Time
+
+	// ExpirationTTL is a convenience for setting ExpirationTime when the
+	// token is upserted. It is converted into ExpirationTime by the servers
+	// and never stored.
+	ExpirationTTL time.Duration
+}
+
+// Copy returns a copy of the expiring policy link.
+func (e *ACLTokenExpiringPolicy) Copy() *ACLTokenExpiringPolicy {
+	if e == nil {
+		return nil
+	}
+	c := *e
+	return &c
+}
+
+// Expired returns whether the link no longer grants its policy at the given
+// time.
+func (e *ACLTokenExpiringPolicy) Expired(now time.Time) bool {
+	return !now.Before(e.ExpirationTime)
 }
 
 // GetID implements the IDGetter interface, required for pagination.
@@ -11515,9 +13461,33 @@ func (a *ACLToken) Copy() *ACLToken {
 	c.Hash = make([]byte, len(a.Hash))
 	copy(c.Hash, a.Hash)
 
+	if a.ExpiringPolicies != nil {
+		c.ExpiringPolicies = make([]*ACLTokenExpiringPolicy, len(a.ExpiringPolicies))
+		for i, p := range a.ExpiringPolicies {
+			c.ExpiringPolicies[i] = p.Copy()
+		}
+	}
+
 	return c
 }
 
+// ActivePolicies returns the names of the policies granted by the token at
+// the given time: its policies plus any expiring policy that has not yet
+// expired.
+func (a *ACLToken) ActivePolicies(now time.Time) []string {
+	if len(a.ExpiringPolicies) == 0 {
+		return a.Policies
+	}
+	policies := make([]string, 0, len(a.Policies)+len(a.ExpiringPolicies))
+	policies = append(policies, a.Policies...)
+	for _, p := range a.ExpiringPolicies {
+		if !p.Expired(now) {
+			policies = append(policies, p.Name)
+		}
+	}
+	return policies
+}
+
 var (
 	// AnonymousACLToken is used no SecretID is provided, and the
 	// request is made anonymously.
@@ -11540,6 +13510,8 @@ type ACLTokenListStub struct {
 	CreateTime  time.Time
 	CreateIndex uint64
 	ModifyIndex uint64
+
+	ExpiringPolicies []*ACLTokenExpiringPolicy
 }
 
 // SetHash is used to compute and set the hash of the ACL token
@@ -11556,6 +13528,10 @@ func (a *ACLToken) SetHash() []byte {
 	for _, policyName := range a.Policies {
 		_, _ = hash.Write([]byte(policyName))
 	}
+	for _, p := range a.ExpiringPolicies {
+		_, _ = hash.Write([]byte(p.Name))
+		_, _ = hash.Write([]byte(p.ExpirationTime.UTC().Format(time.RFC3339Nano)))
+	}
 	if a.Global {
 		_, _ = hash.Write([]byte("global"))
 	} else {
@@ -11581,6 +13557,8 @@ func (a *ACLToken) Stub() *ACLTokenListStub {
 		CreateTime:  a.CreateTime,
 		CreateIndex: a.CreateIndex,
 		ModifyIndex: a.ModifyIndex,
+
+		ExpiringPolicies: a.ExpiringPolicies,
 	}
 }
 
@@ -11592,27 +13570,41 @@ func (a *ACLToken) Validate() error {
 	}
 	switch a.Type {
 	case ACLClientToken:
-		if len(a.Policies) == 0 {
+		if len(a.Policies) == 0 && len(a.ExpiringPolicies) == 0 {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("client token missing policies"))
 		}
 	case ACLManagementToken:
-		if len(a.Policies) != 0 {
+		if len(a.Policies) != 0 || len(a.ExpiringPolicies) != 0 {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("management token cannot be associated with policies"))
 		}
 	default:
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("token type must be client or management"))
 	}
+	for i, p := range a.ExpiringPolicies {
+		switch {
+		case p == nil || p.Name == "":
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("expiring policy %d missing name", i))
+		case p.ExpirationTTL < 0:
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("expiring policy %q has a negative TTL", p.Name))
+		case p.ExpirationTTL == 0 && p.ExpirationTime.IsZero():
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("expiring policy %q missing expiration time or TTL", p.Name))
+		case p.ExpirationTTL != 0 && !p.ExpirationTime.IsZero():
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("expiring policy %q cannot set both an expiration time and a TTL", p.Name))
+		}
+	}
 	return mErr.ErrorOrNil()
 }
 
-// PolicySubset checks if a given set of policies is a subset of the token
+// PolicySubset checks if a given set of policies is a subset of the policies
+// currently granted to the token
 func (a *ACLToken) PolicySubset(policies []string) bool {
 	// Hot-path the management tokens, superset of all policies.
 	if a.Type == ACLManagementToken {
 		return true
 	}
-	associatedPolicies := make(map[string]struct{}, len(a.Policies))
-	for _, policy := range a.Policies {
+	active := a.ActivePolicies(time.Now())
+	associatedPolicies := make(map[string]struct{}, len(active))
+	for _, policy := range active {
 		associatedPolicies[policy] = struct{}{}
 	}
 	for _, policy := range policies {
@@ -11671,6 +13663,31 @@ type ResolveACLTokenResponse struct {
 	QueryMeta
 }
 
+// ACLCheckRequest is used to check whether a token is allowed to perform an
+// operation on a resource, without performing it. The namespace of namespace
+// operations is the one of the request.
+type ACLCheckRequest struct {
+	// SecretID is the token to check. If empty, the token of the request is
+	// checked.
+	SecretID string
+
+	// Resource is the resource to check, named after its policy stanza, such
+	// as "namespace" or "node".
+	Resource string
+
+	// Operation is the namespace capability for the namespace resource, or
+	// the policy level for the other resources.
+	Operation string
+
+	QueryOptions
+}
+
+// ACLCheckResponse is used to return whether the operation is allowed
+type ACLCheckResponse struct {
+	Allowed bool
+	QueryMeta
+}
+
 // ACLTokenDeleteRequest is used to delete a set of tokens
 type ACLTokenDeleteRequest struct {
 	AccessorIDs []string
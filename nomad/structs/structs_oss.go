@@ -26,6 +26,9 @@ func (p *ScalingPolicy) validateType() multierror.Error {
 	case ScalingPolicyTypeHorizontal:
 		targetErr := p.validateTargetHorizontal()
 		mErr.Errors = append(mErr.Errors, targetErr.Errors...)
+	case ScalingPolicyTypeVerticalCPU, ScalingPolicyTypeVerticalMem:
+		targetErr := p.validateTargetVertical()
+		mErr.Errors = append(mErr.Errors, targetErr.Errors...)
 	default:
 		mErr.Errors = append(mErr.Errors, fmt.Errorf(`scaling policy type "%s" is not valid`, p.Type))
 	}
@@ -146,6 +146,46 @@ func TestJob_ValidateScaling(t *testing.T) {
 	)
 }
 
+func TestJob_CopyWithID(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.Name = job.ID
+	job.TaskGroups[0].Scaling = &ScalingPolicy{
+		ID:      "group",
+		Enabled: true,
+		Max:     10,
+	}
+	job.TaskGroups[0].Scaling.TargetTaskGroup(job, job.TaskGroups[0])
+	task := job.TaskGroups[0].Tasks[0]
+	task.ScalingPolicies = []*ScalingPolicy{
+		(&ScalingPolicy{ID: "task", Type: ScalingPolicyTypeVerticalCPU}).TargetTask(job, job.TaskGroups[0], task),
+	}
+	oldID, oldNamespace := job.ID, job.Namespace
+
+	moved := job.CopyWithID("prod", "moved")
+	require.Equal(t, "moved", moved.ID)
+	require.Equal(t, "moved", moved.Name)
+	require.Equal(t, "prod", moved.Namespace)
+
+	for _, p := range moved.GetScalingPolicies() {
+		require.Equal(t, "prod", p.Target[ScalingTargetNamespace])
+		require.Equal(t, "moved", p.Target[ScalingTargetJob])
+	}
+
+	// The original job is untouched
+	require.Equal(t, oldID, job.ID)
+	require.Equal(t, oldNamespace, job.Namespace)
+	for _, p := range job.GetScalingPolicies() {
+		require.Equal(t, oldNamespace, p.Target[ScalingTargetNamespace])
+		require.Equal(t, oldID, p.Target[ScalingTargetJob])
+	}
+
+	// Names that differ from the ID are kept
+	job.Name = "custom"
+	require.Equal(t, "custom", job.CopyWithID("prod", "moved").Name)
+}
+
 func TestJob_ValidateNullChar(t *testing.T) {
 	ci.Parallel(t)
 
@@ -172,6 +212,176 @@ func TestJob_ValidateNullChar(t *testing.T) {
 	assert.Error(job.Validate(), "null character in task name should not validate")
 }
 
+func TestJob_ValidateDependsOn(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.DependsOn = []string{"db", "cache"}
+	require.NoError(t, job.Validate())
+
+	job.DependsOn = []string{"", job.ID, "db", "db"}
+	requireErrors(t, job.Validate(),
+		"dependency must be non-empty",
+		"can't depend on itself",
+		`"db" is listed more than once`,
+	)
+
+	job.Type = JobTypeSystem
+	job.DependsOn = []string{"db"}
+	requireErrors(t, job.Validate(), "Job dependencies can only be used")
+}
+
+func TestJob_ReadyAsDependency(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.CreateIndex = 10
+	job.Version = 2
+	job.Status = JobStatusRunning
+
+	current := &Deployment{
+		JobCreateIndex: 10,
+		JobVersion:     2,
+		Status:         DeploymentStatusRunning,
+	}
+	successful := current.Copy()
+	successful.Status = DeploymentStatusSuccessful
+
+	withUpdate := func(j *Job) {
+		j.TaskGroups[0].Update = &UpdateStrategy{MaxParallel: 1}
+	}
+	deadBatch := func(j *Job) {
+		j.Type = JobTypeBatch
+		j.Status = JobStatusDead
+	}
+	alloc := func(clientStatus, next string, createIndex uint64) *Allocation {
+		return &Allocation{
+			Job:            &Job{CreateIndex: createIndex},
+			ClientStatus:   clientStatus,
+			NextAllocation: next,
+		}
+	}
+
+	cases := []struct {
+		name   string
+		job    func(*Job)
+		latest *Deployment
+		allocs []*Allocation
+		ready  bool
+	}{
+		{
+			name:  "running without update strategy",
+			ready: true,
+		},
+		{
+			name:  "pending",
+			job:   func(j *Job) { j.Status = JobStatusPending },
+			ready: false,
+		},
+		{
+			name:  "stopped",
+			job:   func(j *Job) { j.Stop = true },
+			ready: false,
+		},
+		{
+			name:  "update strategy without deployment",
+			job:   withUpdate,
+			ready: false,
+		},
+		{
+			name:   "deployment running",
+			job:    withUpdate,
+			latest: current,
+			ready:  false,
+		},
+		{
+			name:   "deployment successful",
+			job:    withUpdate,
+			latest: successful,
+			ready:  true,
+		},
+		{
+			name: "deployment of previous version",
+			job:  withUpdate,
+			latest: func() *Deployment {
+				d := successful.Copy()
+				d.JobVersion = 1
+				return d
+			}(),
+			ready: false,
+		},
+		{
+			name: "deployment of previous job",
+			job:  withUpdate,
+			latest: func() *Deployment {
+				d := successful.Copy()
+				d.JobCreateIndex = 5
+				return d
+			}(),
+			ready: false,
+		},
+		{
+			name:  "running batch",
+			job:   func(j *Job) { j.Type = JobTypeBatch },
+			ready: true,
+		},
+		{
+			name: "dead batch completed",
+			job:  deadBatch,
+			allocs: []*Allocation{
+				alloc(AllocClientStatusComplete, "", 10),
+				alloc(AllocClientStatusFailed, "replacement", 10),
+				alloc(AllocClientStatusFailed, "", 5),
+			},
+			ready: true,
+		},
+		{
+			name: "dead sysbatch completed",
+			job: func(j *Job) {
+				j.Type = JobTypeSysBatch
+				j.Status = JobStatusDead
+			},
+			allocs: []*Allocation{alloc(AllocClientStatusComplete, "", 10)},
+			ready:  true,
+		},
+		{
+			name: "dead batch failed",
+			job:  deadBatch,
+			allocs: []*Allocation{
+				alloc(AllocClientStatusComplete, "", 10),
+				alloc(AllocClientStatusFailed, "", 10),
+			},
+			ready: false,
+		},
+		{
+			name:  "dead batch without allocs",
+			job:   deadBatch,
+			ready: false,
+		},
+		{
+			name: "dead service",
+			job:  func(j *Job) { j.Status = JobStatusDead },
+			allocs: []*Allocation{
+				alloc(AllocClientStatusComplete, "", 10),
+			},
+			ready: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			j := job.Copy()
+			if tc.job != nil {
+				tc.job(j)
+			}
+			require.Equal(t, tc.ready, j.ReadyAsDependency(tc.latest, tc.allocs))
+		})
+	}
+
+	var missing *Job
+	require.False(t, missing.ReadyAsDependency(nil, nil))
+}
+
 func TestJob_Warnings(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1114,7 +1324,7 @@ func TestTaskGroup_Validate(t *testing.T) {
 	tg = &TaskGroup{
 		Networks: []*NetworkResource{
 			{
-				DynamicPorts: []Port{{"http", 0, 80, ""}},
+				DynamicPorts: []Port{{Label: "http", Value: 0, To: 80}},
 			},
 		},
 		Tasks: []*Task{
@@ -1122,7 +1332,7 @@ func TestTaskGroup_Validate(t *testing.T) {
 				Resources: &Resources{
 					Networks: []*NetworkResource{
 						{
-							DynamicPorts: []Port{{"http", 0, 80, ""}},
+							DynamicPorts: []Port{{Label: "http", Value: 0, To: 80}},
 						},
 					},
 				},
@@ -1189,6 +1399,33 @@ func TestTaskGroup_Validate(t *testing.T) {
 	require.Contains(t, err.Error(), `CSI volumes must have an attachment mode`)
 	require.Contains(t, err.Error(), `CSI volumes must have an access mode`)
 
+	tg = &TaskGroup{
+		Volumes: map[string]*VolumeRequest{
+			"foo": {
+				Type:               "host",
+				Source:             "fast-ssd",
+				AlternativeSources: []string{"bulk", "", "fast-ssd"},
+			},
+			"bar": {
+				Type:               "csi",
+				Source:             "bar",
+				AlternativeSources: []string{"baz"},
+				AccessMode:         CSIVolumeAccessModeMultiNodeMultiWriter,
+				AttachmentMode:     CSIVolumeAttachmentModeFilesystem,
+			},
+		},
+		Tasks: []*Task{
+			{
+				Name:      "task-a",
+				Resources: &Resources{},
+			},
+		},
+	}
+	err = tg.Validate(&Job{})
+	require.Contains(t, err.Error(), `volume has an empty alternative source`)
+	require.Contains(t, err.Error(), `volume source "fast-ssd" is listed more than once`)
+	require.Contains(t, err.Error(), `only host volumes can have alternative sources`)
+
 	tg = &TaskGroup{
 		Volumes: map[string]*VolumeRequest{
 			"foo": {
@@ -1252,6 +1489,115 @@ func TestTaskGroup_Validate(t *testing.T) {
 
 }
 
+func TestTaskGroup_Validate_Priority(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name     string
+		priority int
+		err      string
+	}{
+		{name: "unset", priority: 0},
+		{name: "min", priority: JobMinPriority},
+		{name: "job priority", priority: 50},
+		{name: "above job priority", priority: 51, err: "Task group priority must be between [1, 50]"},
+		{name: "negative", priority: -1, err: "Task group priority must be between [1, 50]"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			j := testJob()
+			j.TaskGroups[0].Priority = tc.priority
+			err := j.TaskGroups[0].Validate(j)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestTaskGroup_Validate_CheckTenancy(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name             string
+		serviceNamespace string
+		checkNamespace   string
+		servicePartition string
+		checkPartition   string
+		err              string
+	}{
+		{name: "unset"},
+		{name: "service namespace", serviceNamespace: "team-a", checkNamespace: "team-a"},
+		{
+			name:             "other namespace",
+			serviceNamespace: "team-a",
+			checkNamespace:   "team-b",
+			err:              `Check check invalid: namespace "team-b" must match the namespace "team-a" of service web`,
+		},
+		{
+			name:           "inherited namespace",
+			checkNamespace: "team-b",
+			err:            `Check check invalid: namespace "team-b" must match the namespace "" of service web`,
+		},
+		{name: "service partition", servicePartition: "tenant-a", checkPartition: "tenant-a"},
+		{name: "inherited partition", servicePartition: "tenant-a"},
+		{
+			name:             "other partition",
+			servicePartition: "tenant-a",
+			checkPartition:   "tenant-b",
+			err:              `Check check invalid: partition "tenant-b" must match the partition "tenant-a" of service web`,
+		},
+		{
+			name:           "agent partition",
+			checkPartition: "tenant-b",
+			err:            `Check check invalid: partition "tenant-b" must match the partition "" of service web`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			j := testJob()
+			j.TaskGroups[0].Services[0] = &Service{
+				Name:      "web",
+				PortLabel: "http",
+				Namespace: tc.serviceNamespace,
+				Partition: tc.servicePartition,
+				Checks: []*ServiceCheck{{
+					Name:      "check",
+					Type:      ServiceCheckTCP,
+					Interval:  10 * time.Second,
+					Timeout:   2 * time.Second,
+					Namespace: tc.checkNamespace,
+					Partition: tc.checkPartition,
+				}},
+			}
+			err := j.TaskGroups[0].Validate(j)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestTaskGroup_EffectivePriority(t *testing.T) {
+	ci.Parallel(t)
+
+	j := testJob()
+	tg := j.TaskGroups[0]
+	require.Equal(t, j.Priority, tg.EffectivePriority(j))
+
+	tg.Priority = 10
+	require.Equal(t, 10, tg.EffectivePriority(j))
+	require.Equal(t, 10, tg.EffectivePriority(nil))
+}
+
 func TestTaskGroupNetwork_Validate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1404,6 +1750,184 @@ func TestTaskGroupNetwork_Validate(t *testing.T) {
 				},
 			},
 		},
+		{
+			TG: &TaskGroup{
+				Name: "group-static-port-multiple-host_networks",
+				Networks: Networks{
+					&NetworkResource{
+						ReservedPorts: []Port{
+							{
+								Label:        "http",
+								Value:        80,
+								HostNetworks: []string{"net1", "net2"},
+							},
+							{
+								Label:       "net2_http",
+								Value:       80,
+								HostNetwork: "net2",
+							},
+						},
+					},
+				},
+			},
+			ErrContains: "Static port 80 already reserved by taskgroup network:http",
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-port-host_network-and-host_networks",
+				Networks: Networks{
+					&NetworkResource{
+						DynamicPorts: []Port{
+							{
+								Label:        "http",
+								HostNetwork:  "net1",
+								HostNetworks: []string{"net1", "net2"},
+							},
+						},
+					},
+				},
+			},
+			ErrContains: `Port "http" can't set both host_network and host_networks`,
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-port-duplicate-host_networks",
+				Networks: Networks{
+					&NetworkResource{
+						DynamicPorts: []Port{
+							{
+								Label:        "http",
+								HostNetworks: []string{"net1", "net1"},
+							},
+						},
+					},
+				},
+			},
+			ErrContains: `Port "http" lists host network "net1" more than once`,
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-bandwidth-ok",
+				Networks: Networks{
+					&NetworkResource{
+						Mode:      "bridge",
+						Bandwidth: 100,
+					},
+				},
+			},
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-bandwidth-cni-ok",
+				Networks: Networks{
+					&NetworkResource{
+						Mode:      "cni/mynet",
+						Bandwidth: 100,
+					},
+				},
+			},
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-bandwidth-negative",
+				Networks: Networks{
+					&NetworkResource{
+						Mode:      "bridge",
+						Bandwidth: -1,
+					},
+				},
+			},
+			ErrContains: "Network bandwidth (-1) can't be negative",
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-bandwidth-host-mode",
+				Networks: Networks{
+					&NetworkResource{
+						Mode:      "host",
+						Bandwidth: 100,
+					},
+				},
+			},
+			ErrContains: `Network bandwidth can't be enforced in "host" network mode`,
+		},
+		{
+			TG: &TaskGroup{
+				Name: "task-bandwidth-host-mode",
+				Tasks: []*Task{
+					{
+						Name: "task1",
+						Resources: &Resources{
+							Networks: Networks{
+								&NetworkResource{
+									Bandwidth: 100,
+								},
+							},
+						},
+					},
+				},
+			},
+			ErrContains: `Task "task1": Network bandwidth can't be enforced in "host" network mode`,
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-firewall-ok",
+				Networks: Networks{
+					&NetworkResource{
+						Mode: "bridge",
+						Firewall: &NetworkFirewall{
+							AllowMetadata: true,
+							AllowCIDRs:    []string{"10.0.0.0/8"},
+						},
+					},
+				},
+			},
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-firewall-invalid-cidr",
+				Networks: Networks{
+					&NetworkResource{
+						Mode: "bridge",
+						Firewall: &NetworkFirewall{
+							AllowCIDRs: []string{"10.0.0.0"},
+						},
+					},
+				},
+			},
+			ErrContains: `Invalid firewall allowed CIDR "10.0.0.0"`,
+		},
+		{
+			TG: &TaskGroup{
+				Name: "group-firewall-host-mode",
+				Networks: Networks{
+					&NetworkResource{
+						Mode:     "host",
+						Firewall: &NetworkFirewall{},
+					},
+				},
+			},
+			ErrContains: `Network firewall can't be enforced in "host" network mode`,
+		},
+		{
+			TG: &TaskGroup{
+				Name: "task-firewall",
+				Tasks: []*Task{
+					{
+						Name: "task1",
+						Resources: &Resources{
+							Networks: Networks{
+								&NetworkResource{
+									Mode:     "bridge",
+									Firewall: &NetworkFirewall{},
+								},
+							},
+						},
+					},
+				},
+			},
+			ErrContains: `Task "task1": Network firewall can only be set on the task group network`,
+		},
 		{
 			TG: &TaskGroup{
 				Name: "mixing-group-task-ports",
@@ -1562,6 +2086,38 @@ func TestTask_Validate(t *testing.T) {
 	)
 }
 
+func TestTask_Validate_VolumeMounts(t *testing.T) {
+	ci.Parallel(t)
+
+	task := &Task{
+		Name:   "web",
+		Driver: "docker",
+		Resources: &Resources{
+			CPU:      100,
+			MemoryMB: 100,
+		},
+		LogConfig: DefaultLogConfig(),
+		VolumeMounts: []*VolumeMount{
+			{Volume: "a", OnRecover: VolumeMountOnRecoverRestartTask},
+			{Volume: "b", OnRecover: VolumeMountOnRecoverSignal},
+			{Volume: "c", OnRecover: VolumeMountOnRecoverNoop},
+			{Volume: "d"},
+		},
+	}
+	ephemeralDisk := DefaultEphemeralDisk()
+	require.NoError(t, task.Validate(ephemeralDisk, JobTypeService, nil, nil))
+
+	task.VolumeMounts = append(task.VolumeMounts,
+		&VolumeMount{Volume: "e", PropagationMode: "bad"},
+		&VolumeMount{Volume: "f", OnRecover: "reboot"},
+	)
+	err := task.Validate(ephemeralDisk, JobTypeService, nil, nil)
+	requireErrors(t, err,
+		`Volume Mount (4) has an invalid propagation mode: "bad"`,
+		`Volume Mount (5) has an invalid on_recover action: "reboot"`,
+	)
+}
+
 func TestTask_Validate_Resources(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1642,6 +2198,67 @@ func TestTask_Validate_Resources(t *testing.T) {
 			},
 			err: "MemoryMaxMB value (10) should be larger than MemoryMB value (200",
 		},
+		{
+			name: "disk io limits",
+			res: &Resources{
+				CPU:             100,
+				MemoryMB:        200,
+				DiskIOPS:        500,
+				DiskBandwidthMB: 50,
+			},
+		},
+		{
+			name: "negative disk iops",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				DiskIOPS: -1,
+			},
+			err: "DiskIOPS value (-1) can't be negative",
+		},
+		{
+			name: "negative disk bandwidth",
+			res: &Resources{
+				CPU:             100,
+				MemoryMB:        200,
+				DiskBandwidthMB: -1,
+			},
+			err: "DiskBandwidthMB value (-1) can't be negative",
+		},
+		{
+			name: "numa affinity",
+			res: &Resources{
+				Cores:    2,
+				MemoryMB: 200,
+				NUMA:     &NUMA{Affinity: NUMAAffinityRequire},
+			},
+		},
+		{
+			name: "numa affinity without cores",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				NUMA:     &NUMA{Affinity: NUMAAffinityPrefer},
+			},
+			err: "NUMA affinity requires reserving 'cores'",
+		},
+		{
+			name: "no numa affinity without cores",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				NUMA:     &NUMA{Affinity: NUMAAffinityNone},
+			},
+		},
+		{
+			name: "invalid numa affinity",
+			res: &Resources{
+				Cores:    2,
+				MemoryMB: 200,
+				NUMA:     &NUMA{Affinity: "always"},
+			},
+			err: `NUMA affinity must be one of "none", "prefer" or "require"; got "always"`,
+		},
 	}
 
 	for i := range cases {
@@ -1682,6 +2299,10 @@ func TestNetworkResource_Copy(t *testing.T) {
 					Searches: []string{"example.com"},
 					Options:  []string{"ndot:2"},
 				},
+				Firewall: &NetworkFirewall{
+					AllowClientAPI: true,
+					AllowCIDRs:     []string{"10.0.0.0/8"},
+				},
 				ReservedPorts: []Port{
 					{
 						Label:       "foo",
@@ -1696,6 +2317,11 @@ func TestNetworkResource_Copy(t *testing.T) {
 						To:          1414,
 						HostNetwork: "public",
 					},
+					{
+						Label:        "baz",
+						To:           1515,
+						HostNetworks: []string{"private", "public"},
+					},
 				},
 			},
 			name: "fully populated input check",
@@ -1715,6 +2341,10 @@ func TestNetworkResource_Copy(t *testing.T) {
 			// original
 			output.DNS.Servers[1] = "foo"
 			assert.NotEqual(t, tc.inputNetworkResource, output, tc.name)
+
+			output = tc.inputNetworkResource.Copy()
+			output.DynamicPorts[1].HostNetworks[0] = "foo"
+			assert.NotEqual(t, tc.inputNetworkResource, output, tc.name)
 		})
 	}
 }
@@ -2519,6 +3149,30 @@ func TestTask_Validate_Template(t *testing.T) {
 	if expected := "cannot use signals"; !strings.Contains(err.Error(), expected) {
 		t.Errorf("expected to find %q but found %v", expected, err)
 	}
+
+	// Templates can only read from Vault clusters the task requests a
+	// token from
+	task.Templates = []*Template{
+		{
+			SourcePath:   "foo",
+			DestPath:     "local/foo",
+			ChangeMode:   "noop",
+			VaultCluster: "other",
+		},
+	}
+
+	err = task.Validate(ephemeralDisk, JobTypeService, nil, nil)
+	if expected := `reads from Vault cluster "other"`; err == nil || !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected to find %q but found %v", expected, err)
+	}
+
+	task.Vault = &Vault{
+		Clusters: []*VaultCluster{{Name: "other", Policies: []string{"foo"}}},
+	}
+	err = task.Validate(ephemeralDisk, JobTypeService, nil, nil)
+	if err != nil && strings.Contains(err.Error(), "reads from Vault cluster") {
+		t.Errorf("unexpected error: %v", err)
+	}
 }
 
 func TestTemplate_Validate(t *testing.T) {
@@ -2565,6 +3219,38 @@ func TestTemplate_Validate(t *testing.T) {
 				"specify signal value",
 			},
 		},
+		{
+			Tmpl: &Template{
+				ChangeMode: "script",
+			},
+			Fail: true,
+			ContainsErrs: []string{
+				"specify change script",
+			},
+		},
+		{
+			Tmpl: &Template{
+				ChangeMode:   "script",
+				ChangeScript: &ChangeScript{Timeout: -1},
+			},
+			Fail: true,
+			ContainsErrs: []string{
+				"specify a change script command",
+				"timeout can't be negative",
+			},
+		},
+		{
+			Tmpl: &Template{
+				SourcePath: "foo",
+				DestPath:   "local/foo",
+				ChangeMode: "script",
+				ChangeScript: &ChangeScript{
+					Command: "/bin/reload",
+				},
+				Envvars: true,
+			},
+			Fail: false,
+		},
 		{
 			Tmpl: &Template{
 				SourcePath: "foo",
@@ -2777,6 +3463,26 @@ func TestConstraint_Validate(t *testing.T) {
 		require.Error(t, err, "requires an RTarget")
 	}
 
+	// Perform time_window validation
+	c.Operand = ConstraintTimeWindow
+	c.RTarget = "CRON_TZ=Europe/Paris 0 9-17 * * MON-FRI"
+	require.NoError(t, c.Validate())
+
+	c.RTarget = "CRON_TZ=Nowhere/Special 0 9-17 * * *"
+	err = c.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid time zone")
+
+	c.RTarget = "0 9-17 * *"
+	err = c.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid cron expression")
+
+	c.RTarget = "0 0 1 1 * 2000"
+	err = c.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "never opens")
+
 	// Perform LTarget validation
 	c.Operand = ConstraintRegex
 	c.RTarget = "foo"
@@ -2790,6 +3496,37 @@ func TestConstraint_Validate(t *testing.T) {
 	require.Error(t, err, "Unknown constraint type")
 }
 
+func TestTimeWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	w, err := ParseTimeWindow("CRON_TZ=America/New_York * 9-16 * * MON-FRI")
+	require.NoError(t, err)
+
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Monday 8:59 in New York is before the window
+	before := time.Date(2022, time.June, 6, 8, 59, 30, 0, ny)
+	require.False(t, w.Open(before))
+	require.True(t, w.NextOpen(before).Equal(time.Date(2022, time.June, 6, 9, 0, 0, 0, ny)))
+
+	// Monday 16:59 in New York is within the window
+	during := time.Date(2022, time.June, 6, 16, 59, 59, 0, ny)
+	require.True(t, w.Open(during))
+	require.True(t, w.NextOpen(during).Equal(during))
+
+	// The window opens again on Tuesday after closing on Monday
+	after := time.Date(2022, time.June, 6, 17, 0, 0, 0, ny)
+	require.False(t, w.Open(after))
+	require.True(t, w.NextOpen(after).Equal(time.Date(2022, time.June, 7, 9, 0, 0, 0, ny)))
+
+	// The time zone defaults to UTC
+	w, err = ParseTimeWindow("* 9 * * *")
+	require.NoError(t, err)
+	require.True(t, w.Open(time.Date(2022, time.June, 6, 9, 30, 0, 0, time.UTC)))
+	require.False(t, w.Open(time.Date(2022, time.June, 6, 9, 30, 0, 0, ny)))
+}
+
 func TestAffinity_Validate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2902,6 +3639,22 @@ func TestUpdateStrategy_Validate(t *testing.T) {
 		"Minimum healthy time must be less than healthy deadline",
 		"Healthy deadline must be less than progress deadline",
 	)
+
+	u = DefaultUpdateStrategy.Copy()
+	u.SLOQuery = "up"
+	u.SLOInterval = -1
+	err = u.Validate()
+	requireErrors(t, err,
+		"SLO query requires a Canary count greater than zero",
+		"SLO interval may not be less than zero",
+	)
+
+	u = DefaultUpdateStrategy.Copy()
+	u.BlueGreen = true
+	err = u.Validate()
+	requireErrors(t, err,
+		"Blue/green deployments require a Canary count greater than zero",
+	)
 }
 
 func TestResource_NetIndex(t *testing.T) {
@@ -2936,7 +3689,7 @@ func TestResource_Add(t *testing.T) {
 			{
 				CIDR:          "10.0.0.0/8",
 				MBits:         100,
-				ReservedPorts: []Port{{"ssh", 22, 0, ""}},
+				ReservedPorts: []Port{{Label: "ssh", Value: 22}},
 			},
 		},
 	}
@@ -2948,7 +3701,7 @@ func TestResource_Add(t *testing.T) {
 			{
 				IP:            "10.0.0.1",
 				MBits:         50,
-				ReservedPorts: []Port{{"web", 80, 0, ""}},
+				ReservedPorts: []Port{{Label: "web", Value: 80}},
 			},
 		},
 	}
@@ -2963,7 +3716,7 @@ func TestResource_Add(t *testing.T) {
 			{
 				CIDR:          "10.0.0.0/8",
 				MBits:         150,
-				ReservedPorts: []Port{{"ssh", 22, 0, ""}, {"web", 80, 0, ""}},
+				ReservedPorts: []Port{{Label: "ssh", Value: 22}, {Label: "web", Value: 80}},
 			},
 		},
 	}
@@ -2981,7 +3734,7 @@ func TestResource_Add_Network(t *testing.T) {
 		Networks: []*NetworkResource{
 			{
 				MBits:        50,
-				DynamicPorts: []Port{{"http", 0, 80, ""}, {"https", 0, 443, ""}},
+				DynamicPorts: []Port{{Label: "http", Value: 0, To: 80}, {Label: "https", Value: 0, To: 443}},
 			},
 		},
 	}
@@ -2989,7 +3742,7 @@ func TestResource_Add_Network(t *testing.T) {
 		Networks: []*NetworkResource{
 			{
 				MBits:        25,
-				DynamicPorts: []Port{{"admin", 0, 8080, ""}},
+				DynamicPorts: []Port{{Label: "admin", Value: 0, To: 8080}},
 			},
 		},
 	}
@@ -3001,7 +3754,7 @@ func TestResource_Add_Network(t *testing.T) {
 		Networks: []*NetworkResource{
 			{
 				MBits:        75,
-				DynamicPorts: []Port{{"http", 0, 80, ""}, {"https", 0, 443, ""}, {"admin", 0, 8080, ""}},
+				DynamicPorts: []Port{{Label: "http", Value: 0, To: 80}, {Label: "https", Value: 0, To: 443}, {Label: "admin", Value: 0, To: 8080}},
 			},
 		},
 	}
@@ -3028,7 +3781,7 @@ func TestComparableResources_Subtract(t *testing.T) {
 				{
 					CIDR:          "10.0.0.0/8",
 					MBits:         100,
-					ReservedPorts: []Port{{"ssh", 22, 0, ""}},
+					ReservedPorts: []Port{{Label: "ssh", Value: 22}},
 				},
 			},
 		},
@@ -3051,7 +3804,7 @@ func TestComparableResources_Subtract(t *testing.T) {
 				{
 					CIDR:          "10.0.0.0/8",
 					MBits:         20,
-					ReservedPorts: []Port{{"ssh", 22, 0, ""}},
+					ReservedPorts: []Port{{Label: "ssh", Value: 22}},
 				},
 			},
 		},
@@ -3075,7 +3828,7 @@ func TestComparableResources_Subtract(t *testing.T) {
 				{
 					CIDR:          "10.0.0.0/8",
 					MBits:         100,
-					ReservedPorts: []Port{{"ssh", 22, 0, ""}},
+					ReservedPorts: []Port{{Label: "ssh", Value: 22}},
 				},
 			},
 		},
@@ -3692,20 +4445,51 @@ func TestTaskLifecycleConfig_Validate(t *testing.T) {
 		err  error
 	}{
 		{
-			name: "prestart completed",
+			name: "prestart completed",
+			tlc: &TaskLifecycleConfig{
+				Hook:    "prestart",
+				Sidecar: false,
+			},
+			err: nil,
+		},
+		{
+			name: "prestart running",
+			tlc: &TaskLifecycleConfig{
+				Hook:    "prestart",
+				Sidecar: true,
+			},
+			err: nil,
+		},
+		{
+			name: "prestop",
+			tlc: &TaskLifecycleConfig{
+				Hook: "prestop",
+			},
+			err: nil,
+		},
+		{
+			name: "prestop sidecar",
+			tlc: &TaskLifecycleConfig{
+				Hook:    "prestop",
+				Sidecar: true,
+			},
+			err: fmt.Errorf("prestop tasks can't be sidecars"),
+		},
+		{
+			name: "poststop on failure",
 			tlc: &TaskLifecycleConfig{
-				Hook:    "prestart",
-				Sidecar: false,
+				Hook:      "poststop",
+				OnFailure: true,
 			},
 			err: nil,
 		},
 		{
-			name: "prestart running",
+			name: "prestart on failure",
 			tlc: &TaskLifecycleConfig{
-				Hook:    "prestart",
-				Sidecar: true,
+				Hook:      "prestart",
+				OnFailure: true,
 			},
-			err: nil,
+			err: fmt.Errorf("on_failure can only be set for poststop tasks"),
 		},
 		{
 			name: "no hook",
@@ -3848,6 +4632,19 @@ func TestReschedulePolicy_Validate(t *testing.T) {
 				fmt.Errorf("Invalid delay function %q, must be one of %q", "blah", RescheduleDelayFunctions),
 			},
 		},
+		{
+			desc: "Invalid jitter and max lifetime",
+			ReschedulePolicy: &ReschedulePolicy{
+				Delay:         30 * time.Second,
+				DelayFunction: "constant",
+				Unlimited:     true,
+				Jitter:        1.5,
+				MaxLifetime:   -1 * time.Hour},
+			errors: []error{
+				fmt.Errorf("Jitter must be between 0 and 1 (got %v)", 1.5),
+				fmt.Errorf("Max Lifetime cannot be negative (got %v)", -1*time.Hour),
+			},
+		},
 		{
 			desc: "Invalid delay ceiling",
 			ReschedulePolicy: &ReschedulePolicy{
@@ -4374,6 +5171,65 @@ func TestEvaluation_MsgPackTags(t *testing.T) {
 	assert.Equal(t, msgPackTags.Tag, reflect.StructTag(`codec:",omitempty"`))
 }
 
+func TestEvalTrace_Add(t *testing.T) {
+	ci.Parallel(t)
+
+	trace := new(EvalTrace)
+	for i := 0; i < MaxEvalTraceEvents+5; i++ {
+		trace.Add(&EvalTraceEvent{Type: EvalTraceNodeFiltered, NodeID: fmt.Sprintf("node-%d", i)})
+	}
+	require.Len(t, trace.Events, MaxEvalTraceEvents)
+	require.True(t, trace.Truncated)
+	require.NotZero(t, trace.Events[0].Time)
+	require.Equal(t, "node-0", trace.Events[0].NodeID)
+
+	// Copies don't share events with the original trace
+	eval := &Evaluation{Trace: trace}
+	c := eval.Copy()
+	require.Equal(t, trace, c.Trace)
+	c.Trace.Events[0].NodeID = "changed"
+	require.Equal(t, "node-0", trace.Events[0].NodeID)
+}
+
+func TestAllocMetric_Tracer(t *testing.T) {
+	ci.Parallel(t)
+
+	tracer := &recordingAllocMetricTracer{}
+	metric := new(AllocMetric)
+	metric.SetTracer(tracer)
+
+	node := &Node{ID: "node-1"}
+	metric.FilterNode(node, "${attr.kernel.name} = linux")
+	metric.ExhaustedNode(node, "memory")
+	metric.ScoreNode(node, "binpack", 0.5)
+	metric.ScoreNode(node, NormScorerName, 0.5)
+
+	require.Equal(t, []string{
+		"filtered node-1 ${attr.kernel.name} = linux",
+		"exhausted node-1 memory",
+		"scored node-1 0.5 map[binpack:0.5]",
+	}, tracer.calls)
+
+	// The tracer isn't kept by copies
+	require.Nil(t, metric.Copy().tracer)
+}
+
+type recordingAllocMetricTracer struct {
+	calls []string
+}
+
+func (r *recordingAllocMetricTracer) NodeFiltered(node *Node, constraint string) {
+	r.calls = append(r.calls, fmt.Sprintf("filtered %s %s", node.ID, constraint))
+}
+
+func (r *recordingAllocMetricTracer) NodeExhausted(node *Node, dimension string) {
+	r.calls = append(r.calls, fmt.Sprintf("exhausted %s %s", node.ID, dimension))
+}
+
+func (r *recordingAllocMetricTracer) NodeScored(node *Node, meta *NodeScoreMeta) {
+	r.calls = append(r.calls, fmt.Sprintf("scored %s %v %v", node.ID, meta.NormScore, meta.Scores))
+}
+
 func TestAllocation_Terminated(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4415,6 +5271,71 @@ func TestAllocation_Terminated(t *testing.T) {
 	}
 }
 
+func TestAllocation_BlueGreenTag(t *testing.T) {
+	ci.Parallel(t)
+
+	blueGreenJob := &Job{
+		TaskGroups: []*TaskGroup{{
+			Name:   "web",
+			Update: &UpdateStrategy{Canary: 2, BlueGreen: true},
+		}},
+	}
+	job := &Job{
+		TaskGroups: []*TaskGroup{{
+			Name:   "web",
+			Update: &UpdateStrategy{Canary: 2},
+		}},
+	}
+
+	cases := []struct {
+		name     string
+		job      *Job
+		status   *AllocDeploymentStatus
+		expected string
+	}{
+		{
+			name:     "not blue/green",
+			job:      job,
+			status:   &AllocDeploymentStatus{Canary: true},
+			expected: "",
+		},
+		{
+			name:     "live",
+			job:      blueGreenJob,
+			expected: BlueGreenLiveTag,
+		},
+		{
+			name:     "canary",
+			job:      blueGreenJob,
+			status:   &AllocDeploymentStatus{Canary: true},
+			expected: BlueGreenStagedTag,
+		},
+		{
+			name:     "staged",
+			job:      blueGreenJob,
+			status:   &AllocDeploymentStatus{Staged: true},
+			expected: BlueGreenStagedTag,
+		},
+		{
+			name:     "staged previous version",
+			job:      job,
+			status:   &AllocDeploymentStatus{Staged: true},
+			expected: BlueGreenStagedTag,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			alloc := &Allocation{
+				Job:              tc.job,
+				TaskGroup:        "web",
+				DeploymentStatus: tc.status,
+			}
+			require.Equal(t, tc.expected, alloc.BlueGreenTag())
+		})
+	}
+}
+
 func TestAllocation_ShouldReschedule(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4542,7 +5463,7 @@ func TestAllocation_ShouldReschedule(t *testing.T) {
 		alloc := Allocation{}
 		alloc.DesiredStatus = state.DesiredStatus
 		alloc.ClientStatus = state.ClientStatus
-		alloc.RescheduleTracker = &RescheduleTracker{state.RescheduleTrackers}
+		alloc.RescheduleTracker = &RescheduleTracker{Events: state.RescheduleTrackers}
 
 		t.Run(state.Desc, func(t *testing.T) {
 			if got := alloc.ShouldReschedule(state.ReschedulePolicy, state.FailTime); got != state.ShouldReschedule {
@@ -5084,6 +6005,67 @@ func TestAllocation_NextDelay(t *testing.T) {
 			expectedRescheduleTime:     now.Add(-5 * time.Second).Add(5 * time.Second),
 			expectedRescheduleEligible: true,
 		},
+		{
+			desc: "within max lifetime",
+			reschedulePolicy: &ReschedulePolicy{
+				DelayFunction: "constant",
+				Delay:         5 * time.Second,
+				Unlimited:     true,
+				MaxLifetime:   1 * time.Hour,
+			},
+			alloc: &Allocation{
+				ClientStatus: AllocClientStatusFailed,
+				CreateTime:   now.Add(-10 * time.Minute).UnixNano(),
+				TaskStates: map[string]*TaskState{"foo": {State: "dead",
+					StartedAt:  now.Add(-10 * time.Minute),
+					FinishedAt: now.Add(-2 * time.Second)}},
+			},
+			expectedRescheduleTime:     now.Add(-2 * time.Second).Add(5 * time.Second),
+			expectedRescheduleEligible: true,
+		},
+		{
+			desc: "max lifetime exceeded",
+			reschedulePolicy: &ReschedulePolicy{
+				DelayFunction: "constant",
+				Delay:         5 * time.Second,
+				Unlimited:     true,
+				MaxLifetime:   1 * time.Hour,
+			},
+			alloc: &Allocation{
+				ClientStatus: AllocClientStatusFailed,
+				CreateTime:   now.Add(-2 * time.Hour).UnixNano(),
+				TaskStates: map[string]*TaskState{"foo": {State: "dead",
+					StartedAt:  now.Add(-2 * time.Hour),
+					FinishedAt: now.Add(-2 * time.Second)}},
+			},
+			expectedRescheduleTime:     now.Add(-2 * time.Second).Add(5 * time.Second),
+			expectedRescheduleEligible: false,
+		},
+		{
+			desc: "max lifetime exceeded since the start of the reschedule chain",
+			reschedulePolicy: &ReschedulePolicy{
+				DelayFunction: "constant",
+				Delay:         5 * time.Second,
+				Interval:      10 * time.Minute,
+				Attempts:      2,
+				MaxLifetime:   1 * time.Hour,
+			},
+			alloc: &Allocation{
+				ClientStatus: AllocClientStatusFailed,
+				CreateTime:   now.Add(-1 * time.Minute).UnixNano(),
+				TaskStates: map[string]*TaskState{"foo": {State: "dead",
+					StartedAt:  now.Add(-1 * time.Minute),
+					FinishedAt: now.Add(-2 * time.Second)}},
+				RescheduleTracker: &RescheduleTracker{
+					Events: []*RescheduleEvent{{
+						RescheduleTime: now.Add(-1 * time.Minute).UTC().UnixNano(),
+						Delay:          5 * time.Second,
+					}},
+					LifetimeStart: now.Add(-2 * time.Hour).UnixNano(),
+				}},
+			expectedRescheduleTime:     now.Add(-2 * time.Second).Add(5 * time.Second),
+			expectedRescheduleEligible: false,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -5102,6 +6084,65 @@ func TestAllocation_NextDelay(t *testing.T) {
 
 }
 
+func TestAllocation_NextRescheduleTime_Jitter(t *testing.T) {
+	ci.Parallel(t)
+
+	j := testJob()
+	j.TaskGroups[0].ReschedulePolicy = &ReschedulePolicy{
+		DelayFunction: "constant",
+		Delay:         10 * time.Second,
+		Unlimited:     true,
+		Jitter:        0.5,
+	}
+
+	failTime := time.Now().UTC()
+	var times []time.Time
+	for i := 0; i < 10; i++ {
+		alloc := &Allocation{
+			ID:           uuid.Generate(),
+			Job:          j,
+			TaskGroup:    j.TaskGroups[0].Name,
+			ClientStatus: AllocClientStatusFailed,
+			TaskStates: map[string]*TaskState{"foo": {State: "dead",
+				FinishedAt: failTime}},
+		}
+
+		rescheduleTime, eligible := alloc.NextRescheduleTime()
+		require.True(t, eligible)
+		require.False(t, rescheduleTime.Before(failTime.Add(10*time.Second)))
+		require.False(t, rescheduleTime.After(failTime.Add(15*time.Second)))
+
+		// The jitter of an allocation doesn't change between calls
+		again, _ := alloc.NextRescheduleTime()
+		require.Equal(t, rescheduleTime, again)
+
+		// The delay recorded in the reschedule tracker doesn't include it
+		require.Equal(t, 10*time.Second, alloc.NextDelay())
+
+		times = append(times, rescheduleTime)
+	}
+
+	// The reschedules of the allocations are spread out
+	require.NotEqual(t, times[0], times[1])
+}
+
+func TestAllocation_RescheduleEligible_MaxLifetime(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	policy := &ReschedulePolicy{
+		Attempts:    3,
+		Interval:    time.Hour,
+		MaxLifetime: 30 * time.Minute,
+	}
+	alloc := &Allocation{CreateTime: now.Add(-10 * time.Minute).UnixNano()}
+	require.True(t, alloc.RescheduleEligible(policy, now))
+	require.False(t, alloc.RescheduleEligible(policy, now.Add(20*time.Minute)))
+
+	policy.MaxLifetime = 0
+	require.True(t, alloc.RescheduleEligible(policy, now.Add(20*time.Minute)))
+}
+
 func TestAllocation_WaitClientStop(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5285,6 +6326,94 @@ func TestVault_Validate(t *testing.T) {
 	}
 }
 
+func TestWorkloadIdentity_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	w := &WorkloadIdentity{
+		Name:     "aws",
+		Audience: []string{"sts.amazonaws.com"},
+		TTL:      time.Hour,
+		File:     true,
+	}
+	require.NoError(t, w.Validate())
+
+	w.Name = "aws/prod"
+	w.Audience = []string{" "}
+	w.TTL = time.Second
+	w.File = false
+	err := w.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Invalid name")
+	require.Contains(t, err.Error(), "Audience must not be empty")
+	require.Contains(t, err.Error(), "TTL must be at least")
+	require.Contains(t, err.Error(), "delivered as an environment variable, a file or both")
+
+	// Identities of a task must have unique names
+	task := &Task{
+		Name:      "web",
+		Driver:    "docker",
+		Resources: DefaultResources(),
+		LogConfig: DefaultLogConfig(),
+		Identities: []*WorkloadIdentity{
+			{Name: "aws", Audience: []string{"a"}, TTL: time.Hour, File: true},
+			{Name: "aws", Audience: []string{"b"}, TTL: time.Hour, Env: true},
+		},
+	}
+	err = task.Validate(&EphemeralDisk{SizeMB: 200}, JobTypeService, nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Identity 2 has same name as 1")
+}
+
+func TestWorkloadIdentitySubject(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := &Allocation{Namespace: "default", JobID: "web", TaskGroup: "api"}
+	require.Equal(t, "global:default:web:api:server:aws",
+		WorkloadIdentitySubject("global", alloc, "server", "aws"))
+
+	// Separators within the components are escaped, so workloads whose
+	// names only differ in where a ":" is don't share a subject
+	a := &Allocation{Namespace: "default", JobID: "web:api", TaskGroup: "server"}
+	b := &Allocation{Namespace: "default", JobID: "web", TaskGroup: "api:server"}
+	require.Equal(t, "global:default:web%3Aapi:server:task:aws",
+		WorkloadIdentitySubject("global", a, "task", "aws"))
+	require.NotEqual(t,
+		WorkloadIdentitySubject("global", a, "task", "aws"),
+		WorkloadIdentitySubject("global", b, "task", "aws"))
+
+	// The escape character is escaped too
+	c := &Allocation{Namespace: "default", JobID: "web%3Aapi", TaskGroup: "server"}
+	require.Equal(t, "global:default:web%253Aapi:server:task:aws",
+		WorkloadIdentitySubject("global", c, "task", "aws"))
+}
+
+func TestVault_Validate_Clusters(t *testing.T) {
+	ci.Parallel(t)
+
+	v := &Vault{
+		Policies:   []string{"foo"},
+		ChangeMode: VaultChangeModeRestart,
+		Clusters: []*VaultCluster{
+			{Name: "team", Policies: []string{"bar"}},
+			{Name: "team", Policies: []string{"bar"}},
+			{Name: "bad name", Policies: []string{"root"}},
+			{Name: "empty"},
+		},
+	}
+
+	err := v.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Duplicate cluster "team"`)
+	require.Contains(t, err.Error(), `Invalid cluster name "bad name"`)
+	require.Contains(t, err.Error(), `Cluster "bad name" can not specify "root" policy`)
+	require.Contains(t, err.Error(), `Cluster "empty" policy list cannot be empty`)
+
+	v.Clusters = v.Clusters[:1]
+	require.NoError(t, v.Validate())
+	require.Equal(t, v.Clusters[0], v.Cluster("team"))
+	require.Nil(t, v.Cluster("other"))
+}
+
 func TestParameterizedJobConfig_Validate(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5381,6 +6510,19 @@ func TestDispatchPayloadConfig_Validate(t *testing.T) {
 	if err := d.Validate(); err == nil {
 		t.Fatalf("bad: %v", err)
 	}
+
+	// stdin
+	d.File = ""
+	d.Stdin = true
+	if err := d.Validate(); err != nil {
+		t.Fatalf("bad: %v", err)
+	}
+
+	// file and stdin
+	d.File = "foo"
+	if err := d.Validate(); err == nil {
+		t.Fatalf("expected file and stdin to be mutually exclusive")
+	}
 }
 
 func TestScalingPolicy_Canonicalize(t *testing.T) {
@@ -5535,6 +6677,33 @@ func TestScalingPolicy_Validate(t *testing.T) {
 			},
 			expectedErr: "missing target group",
 		},
+		{
+			name: "full vertical policy",
+			input: &ScalingPolicy{
+				Type:    ScalingPolicyTypeVerticalCPU,
+				Min:     100,
+				Max:     1000,
+				Enabled: true,
+				Target: map[string]string{
+					ScalingTargetNamespace: "my-namespace",
+					ScalingTargetJob:       "my-job",
+					ScalingTargetGroup:     "my-task-group",
+					ScalingTargetTask:      "my-task",
+				},
+			},
+		},
+		{
+			name: "vertical missing task",
+			input: &ScalingPolicy{
+				Type: ScalingPolicyTypeVerticalMem,
+				Target: map[string]string{
+					ScalingTargetNamespace: "my-namespace",
+					ScalingTargetJob:       "my-job",
+					ScalingTargetGroup:     "my-group",
+				},
+			},
+			expectedErr: "missing target task",
+		},
 	}
 
 	for _, c := range cases {
@@ -5619,6 +6788,114 @@ func TestACLTokenValidate(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestACLTokenValidate_ExpiringPolicies(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	cases := []struct {
+		name     string
+		token    *ACLToken
+		expected string
+	}{
+		{
+			name: "only expiring policies",
+			token: &ACLToken{
+				Type: ACLClientToken,
+				ExpiringPolicies: []*ACLTokenExpiringPolicy{
+					{Name: "operator", ExpirationTTL: 8 * time.Hour},
+				},
+			},
+		},
+		{
+			name: "management",
+			token: &ACLToken{
+				Type: ACLManagementToken,
+				ExpiringPolicies: []*ACLTokenExpiringPolicy{
+					{Name: "operator", ExpirationTTL: 8 * time.Hour},
+				},
+			},
+			expected: "associated with policies",
+		},
+		{
+			name: "missing name",
+			token: &ACLToken{
+				Type:     ACLClientToken,
+				Policies: []string{"foo"},
+				ExpiringPolicies: []*ACLTokenExpiringPolicy{
+					{ExpirationTTL: 8 * time.Hour},
+				},
+			},
+			expected: "expiring policy 0 missing name",
+		},
+		{
+			name: "missing expiration",
+			token: &ACLToken{
+				Type:             ACLClientToken,
+				ExpiringPolicies: []*ACLTokenExpiringPolicy{{Name: "operator"}},
+			},
+			expected: "missing expiration time or TTL",
+		},
+		{
+			name: "negative ttl",
+			token: &ACLToken{
+				Type: ACLClientToken,
+				ExpiringPolicies: []*ACLTokenExpiringPolicy{
+					{Name: "operator", ExpirationTTL: -time.Hour},
+				},
+			},
+			expected: "negative TTL",
+		},
+		{
+			name: "time and ttl",
+			token: &ACLToken{
+				Type: ACLClientToken,
+				ExpiringPolicies: []*ACLTokenExpiringPolicy{
+					{Name: "operator", ExpirationTime: now, ExpirationTTL: time.Hour},
+				},
+			},
+			expected: "cannot set both",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.token.Validate()
+			if tc.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expected)
+			}
+		})
+	}
+}
+
+func TestACLTokenActivePolicies(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now()
+	tk := &ACLToken{
+		Type:     ACLClientToken,
+		Policies: []string{"foo"},
+	}
+	require.Equal(t, []string{"foo"}, tk.ActivePolicies(now))
+
+	tk.ExpiringPolicies = []*ACLTokenExpiringPolicy{
+		{Name: "operator", ExpirationTime: now.Add(8 * time.Hour)},
+		{Name: "expired", ExpirationTime: now.Add(-time.Minute)},
+	}
+	require.Equal(t, []string{"foo", "operator"}, tk.ActivePolicies(now))
+	require.Equal(t, []string{"foo"}, tk.ActivePolicies(now.Add(8*time.Hour)))
+
+	require.True(t, tk.PolicySubset([]string{"foo", "operator"}))
+	require.False(t, tk.PolicySubset([]string{"expired"}))
+
+	// The hash covers the expiration time of the links
+	hash := tk.SetHash()
+	tk.ExpiringPolicies[0].ExpirationTime = now.Add(9 * time.Hour)
+	require.NotEqual(t, hash, tk.SetHash())
+}
+
 func TestACLTokenPolicySubset(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5757,12 +7034,12 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{Label: "web", Value: 80}},
 				},
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{Label: "web", Value: 80}},
 				},
 			},
 			true,
@@ -5773,12 +7050,12 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:            "10.0.0.0",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{Label: "web", Value: 80}},
 				},
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{Label: "web", Value: 80}},
 				},
 			},
 			false,
@@ -5789,12 +7066,12 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:            "10.0.0.1",
 					MBits:         40,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{Label: "web", Value: 80}},
 				},
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{Label: "web", Value: 80}},
 				},
 			},
 			false,
@@ -5805,12 +7082,12 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{Label: "web", Value: 80}},
 				},
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}, {"web", 80, 0, ""}},
+					ReservedPorts: []Port{{Label: "web", Value: 80}, {Label: "web", Value: 80}},
 				},
 			},
 			false,
@@ -5821,7 +7098,7 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{Label: "web", Value: 80}},
 				},
 				{
 					IP:            "10.0.0.1",
@@ -5837,12 +7114,12 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{Label: "web", Value: 80}},
 				},
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"notweb", 80, 0, ""}},
+					ReservedPorts: []Port{{Label: "notweb", Value: 80}},
 				},
 			},
 			false,
@@ -5853,12 +7130,12 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:           "10.0.0.1",
 					MBits:        50,
-					DynamicPorts: []Port{{"web", 80, 0, ""}},
+					DynamicPorts: []Port{{Label: "web", Value: 80}},
 				},
 				{
 					IP:           "10.0.0.1",
 					MBits:        50,
-					DynamicPorts: []Port{{"web", 80, 0, ""}, {"web", 80, 0, ""}},
+					DynamicPorts: []Port{{Label: "web", Value: 80}, {Label: "web", Value: 80}},
 				},
 			},
 			false,
@@ -5869,7 +7146,7 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:           "10.0.0.1",
 					MBits:        50,
-					DynamicPorts: []Port{{"web", 80, 0, ""}},
+					DynamicPorts: []Port{{Label: "web", Value: 80}},
 				},
 				{
 					IP:           "10.0.0.1",
@@ -5885,12 +7162,12 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:           "10.0.0.1",
 					MBits:        50,
-					DynamicPorts: []Port{{"web", 80, 0, ""}},
+					DynamicPorts: []Port{{Label: "web", Value: 80}},
 				},
 				{
 					IP:           "10.0.0.1",
 					MBits:        50,
-					DynamicPorts: []Port{{"notweb", 80, 0, ""}},
+					DynamicPorts: []Port{{Label: "notweb", Value: 80}},
 				},
 			},
 			false,
@@ -6382,7 +7659,7 @@ func TestAllocatedResources_Canonicalize(t *testing.T) {
 						Networks: Networks{
 							{
 								IP:           "127.0.0.1",
-								DynamicPorts: []Port{{"admin", 8080, 0, "default"}},
+								DynamicPorts: []Port{{Label: "admin", Value: 8080, HostNetwork: "default"}},
 							},
 						},
 					},
@@ -6394,7 +7671,7 @@ func TestAllocatedResources_Canonicalize(t *testing.T) {
 						Networks: Networks{
 							{
 								IP:           "127.0.0.1",
-								DynamicPorts: []Port{{"admin", 8080, 0, "default"}},
+								DynamicPorts: []Port{{Label: "admin", Value: 8080, HostNetwork: "default"}},
 							},
 						},
 					},
@@ -6418,7 +7695,7 @@ func TestAllocatedResources_Canonicalize(t *testing.T) {
 						Networks: Networks{
 							{
 								IP:           "127.0.0.1",
-								DynamicPorts: []Port{{"admin", 8080, 0, "default"}},
+								DynamicPorts: []Port{{Label: "admin", Value: 8080, HostNetwork: "default"}},
 							},
 						},
 					},
@@ -6440,7 +7717,7 @@ func TestAllocatedResources_Canonicalize(t *testing.T) {
 						Networks: Networks{
 							{
 								IP:           "127.0.0.1",
-								DynamicPorts: []Port{{"admin", 8080, 0, "default"}},
+								DynamicPorts: []Port{{Label: "admin", Value: 8080, HostNetwork: "default"}},
 							},
 						},
 					},
@@ -6663,3 +7940,40 @@ func requireErrors(t *testing.T, err error, expected ...string) {
 
 	require.Equal(t, expected, found)
 }
+
+func TestVolumeRequest_HostVolumeSource(t *testing.T) {
+	ci.Parallel(t)
+
+	node := &Node{
+		HostVolumes: map[string]*ClientHostVolumeConfig{
+			"fast-ssd": {ReadOnly: true},
+			"bulk":     {},
+		},
+	}
+
+	req := &VolumeRequest{
+		Type:               VolumeTypeHost,
+		Source:             "fast-ssd",
+		AlternativeSources: []string{"bulk"},
+		ReadOnly:           true,
+	}
+	source, ok := req.HostVolumeSource(node)
+	require.True(t, ok)
+	require.Equal(t, "fast-ssd", source)
+
+	// A read-only source is skipped for a read-write request
+	req.ReadOnly = false
+	source, ok = req.HostVolumeSource(node)
+	require.True(t, ok)
+	require.Equal(t, "bulk", source)
+
+	req.AlternativeSources = []string{"archive"}
+	_, ok = req.HostVolumeSource(node)
+	require.False(t, ok)
+
+	// The allocation mounts the source chosen by the scheduler
+	alloc := &Allocation{HostVolumeSources: map[string]string{"data": "bulk"}}
+	require.Equal(t, "bulk", alloc.HostVolumeSource("data", req))
+	require.Equal(t, "fast-ssd", alloc.HostVolumeSource("logs", req))
+	require.Equal(t, alloc.HostVolumeSources, alloc.Copy().HostVolumeSources)
+}
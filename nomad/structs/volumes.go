@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
 )
 
 const (
@@ -16,6 +17,21 @@ const (
 	VolumeMountPropagationBidirectional = "bidirectional"
 )
 
+const (
+	VolumeMountOnRecoverRestartTask = "restart_task"
+	VolumeMountOnRecoverSignal      = "signal"
+	VolumeMountOnRecoverNoop        = "noop"
+)
+
+func MountOnRecoverIsValid(onRecover string) bool {
+	switch onRecover {
+	case "", VolumeMountOnRecoverRestartTask, VolumeMountOnRecoverSignal, VolumeMountOnRecoverNoop:
+		return true
+	default:
+		return false
+	}
+}
+
 func MountPropagationModeIsValid(propagationMode string) bool {
 	switch propagationMode {
 	case "", VolumeMountPropagationPrivate, VolumeMountPropagationHostToTask, VolumeMountPropagationBidirectional:
@@ -100,6 +116,11 @@ type VolumeRequest struct {
 	AttachmentMode CSIVolumeAttachmentMode
 	MountOptions   *CSIMountOptions
 	PerAlloc       bool
+
+	// AlternativeSources are the host volumes used in order when the node
+	// has no host volume Source that can be mounted. The source chosen is
+	// recorded in the HostVolumeSources of the allocation.
+	AlternativeSources []string
 }
 
 func (v *VolumeRequest) Validate(canaries int) error {
@@ -150,9 +171,52 @@ func (v *VolumeRequest) Validate(canaries int) error {
 	if v.Source == "" {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("volume has an empty source"))
 	}
+
+	if len(v.AlternativeSources) > 0 {
+		if v.Type != VolumeTypeHost {
+			mErr.Errors = append(mErr.Errors,
+				fmt.Errorf("only host volumes can have alternative sources"))
+		}
+		seen := map[string]struct{}{v.Source: {}}
+		for _, source := range v.AlternativeSources {
+			if source == "" {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("volume has an empty alternative source"))
+				continue
+			}
+			if _, ok := seen[source]; ok {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("volume source %q is listed more than once", source))
+			}
+			seen[source] = struct{}{}
+		}
+	}
 	return mErr.ErrorOrNil()
 }
 
+// Sources returns the source of the volume followed by its alternative
+// sources, in order of preference.
+func (v *VolumeRequest) Sources() []string {
+	return append([]string{v.Source}, v.AlternativeSources...)
+}
+
+// HostVolumeSource returns the first of the sources of the host volume
+// request that the node has and that can be mounted as requested, and false
+// if the node has none.
+func (v *VolumeRequest) HostVolumeSource(n *Node) (string, bool) {
+	for _, source := range v.Sources() {
+		nodeVolume, ok := n.HostVolumes[source]
+		if !ok {
+			continue
+		}
+
+		// A read-only host volume can't be mounted read-write
+		if nodeVolume.ReadOnly && !v.ReadOnly {
+			continue
+		}
+		return source, true
+	}
+	return "", false
+}
+
 func (v *VolumeRequest) Copy() *VolumeRequest {
 	if v == nil {
 		return nil
@@ -163,6 +227,7 @@ func (v *VolumeRequest) Copy() *VolumeRequest {
 	if v.MountOptions != nil {
 		nv.MountOptions = v.MountOptions.Copy()
 	}
+	nv.AlternativeSources = helper.CopySliceString(v.AlternativeSources)
 
 	return nv
 }
@@ -187,6 +252,11 @@ type VolumeMount struct {
 	Destination     string
 	ReadOnly        bool
 	PropagationMode string
+
+	// OnRecover is the action taken by the client when the volume becomes
+	// unavailable and later recovers. One of "restart_task", "signal" or
+	// "noop"; empty is treated as "noop".
+	OnRecover string
 }
 
 func (v *VolumeMount) Copy() *VolumeMount {
@@ -0,0 +1,334 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// WorkloadIdentityMinTTL is the shortest lifetime a workload identity
+	// may be signed for. Shorter lifetimes would have tokens rotated faster
+	// than they can reasonably be picked up by tasks.
+	WorkloadIdentityMinTTL = time.Minute
+
+	// WorkloadIdentityDefaultTTL is the lifetime of workload identities that
+	// do not configure one.
+	WorkloadIdentityDefaultTTL = time.Hour
+
+	// WorkloadIdentityDefaultRotationInterval is the interval the keys
+	// signing workload identities are rotated at, unless configured
+	// otherwise.
+	WorkloadIdentityDefaultRotationInterval = 30 * 24 * time.Hour
+
+	// WorkloadIdentityMinRotationInterval is the shortest interval the keys
+	// signing workload identities may be rotated at.
+	WorkloadIdentityMinRotationInterval = time.Hour
+)
+
+const (
+	// WorkloadIdentityKeyStateNext is the state of the key that is published
+	// ahead of signing workload identities, such that third parties learn
+	// about the key before the first identity signed by it.
+	WorkloadIdentityKeyStateNext = "next"
+
+	// WorkloadIdentityKeyStateActive is the state of the key signing
+	// workload identities.
+	WorkloadIdentityKeyStateActive = "active"
+
+	// WorkloadIdentityKeyStateRetired is the state of a key that no longer
+	// signs workload identities, but is still published until the identities
+	// it signed expired.
+	WorkloadIdentityKeyStateRetired = "retired"
+)
+
+// validWorkloadIdentityName matches the valid names of workload identities,
+// which are used in the names of the environment variables and files the
+// identities are delivered as.
+var validWorkloadIdentityName = regexp.MustCompile("^[a-zA-Z0-9_-]{1,128}$")
+
+// WorkloadIdentity is a named JSON Web Token a task receives to prove its
+// identity to third parties, such as the OIDC federation of cloud providers.
+type WorkloadIdentity struct {
+	// Name of the identity, unique within the task.
+	Name string
+
+	// Audience is the set of audiences the identity is signed for.
+	Audience []string
+
+	// TTL is the lifetime of the signed identity. The identity is rotated
+	// before it expires.
+	TTL time.Duration
+
+	// Env delivers the identity in the environment of the task.
+	Env bool
+
+	// File delivers the identity in the secrets directory of the task.
+	File bool
+}
+
+func (w *WorkloadIdentity) Copy() *WorkloadIdentity {
+	if w == nil {
+		return nil
+	}
+	nw := new(WorkloadIdentity)
+	*nw = *w
+	nw.Audience = helper.CopySliceString(w.Audience)
+	return nw
+}
+
+func (w *WorkloadIdentity) Canonicalize() {
+	if w.TTL == 0 {
+		w.TTL = WorkloadIdentityDefaultTTL
+	}
+}
+
+func (w *WorkloadIdentity) Validate() error {
+	if w == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	if !validWorkloadIdentityName.MatchString(w.Name) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid name %q, must match %s", w.Name, validWorkloadIdentityName))
+	}
+	if len(w.Audience) == 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("At least one audience must be specified"))
+	}
+	for _, aud := range w.Audience {
+		if strings.TrimSpace(aud) == "" {
+			mErr.Errors = append(mErr.Errors, errors.New("Audience must not be empty"))
+			break
+		}
+	}
+	if w.TTL < WorkloadIdentityMinTTL {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("TTL must be at least %v", WorkloadIdentityMinTTL))
+	}
+	if !w.Env && !w.File {
+		mErr.Errors = append(mErr.Errors, errors.New("Identity must be delivered as an environment variable, a file or both"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// EnvVar returns the name of the environment variable the identity is
+// delivered as.
+func (w *WorkloadIdentity) EnvVar() string {
+	return "NOMAD_TOKEN_" + w.Name
+}
+
+// FileName returns the name of the file in the secrets directory the identity
+// is delivered as.
+func (w *WorkloadIdentity) FileName() string {
+	return "nomad_" + w.Name + ".jwt"
+}
+
+// CopySliceWorkloadIdentities copies a slice of workload identities.
+func CopySliceWorkloadIdentities(s []*WorkloadIdentity) []*WorkloadIdentity {
+	if s == nil {
+		return nil
+	}
+	c := make([]*WorkloadIdentity, len(s))
+	for i, w := range s {
+		c[i] = w.Copy()
+	}
+	return c
+}
+
+// IdentityClaims are the claims of a signed workload identity, in addition to
+// the registered JWT claims.
+type IdentityClaims struct {
+	Namespace    string `json:"nomad_namespace"`
+	JobID        string `json:"nomad_job_id"`
+	AllocationID string `json:"nomad_allocation_id"`
+	Task         string `json:"nomad_task"`
+}
+
+// workloadIdentitySubjectEscaper escapes the separator of the components of
+// workload identity subjects, and the escape character itself.
+var workloadIdentitySubjectEscaper = strings.NewReplacer("%", "%25", ":", "%3A")
+
+// WorkloadIdentitySubject returns the subject of the given identity of a
+// task, which allows third parties to scope trust to a single identity. The
+// components are joined with ":", which is percent-encoded within them so
+// that different workloads never share a subject.
+func WorkloadIdentitySubject(region string, alloc *Allocation, task string, identity string) string {
+	parts := []string{region, alloc.Namespace, alloc.JobID, alloc.TaskGroup, task, identity}
+	for i, part := range parts {
+		parts[i] = workloadIdentitySubjectEscaper.Replace(part)
+	}
+	return strings.Join(parts, ":")
+}
+
+// SignedWorkloadIdentity is a workload identity signed by the servers.
+type SignedWorkloadIdentity struct {
+	// Name of the identity of the task.
+	Name string
+
+	// JWT is the signed token.
+	JWT string
+
+	// Expiration is the time the token expires at.
+	Expiration time.Time
+}
+
+// SignIdentitiesRequest is used to request signed workload identities from
+// the Nomad Server for a task in the given allocation.
+type SignIdentitiesRequest struct {
+	NodeID     string
+	SecretID   string
+	AllocID    string
+	Task       string
+	Identities []string
+	QueryOptions
+}
+
+func (r *SignIdentitiesRequest) Validate() error {
+	switch {
+	case r.NodeID == "":
+		return errors.New("missing node ID")
+	case r.SecretID == "":
+		return errors.New("missing node SecretID")
+	case r.AllocID == "":
+		return errors.New("missing allocation ID")
+	case r.Task == "":
+		return errors.New("missing task name")
+	case len(r.Identities) == 0:
+		return errors.New("no identities specified")
+	default:
+		return nil
+	}
+}
+
+type SignIdentitiesResponse struct {
+	// Identities maps from identity name to the signed identity.
+	Identities map[string]*SignedWorkloadIdentity
+
+	// Error stores any error that occurred. Errors are stored here so we can
+	// communicate whether it is retryable
+	Error *RecoverableError
+
+	QueryMeta
+}
+
+// WorkloadIdentityConfig is used in servers to configure the signing of
+// workload identities.
+type WorkloadIdentityConfig struct {
+	// Issuer is the URL third parties discover the keys verifying workload
+	// identities at, and the issuer claim of the identities.
+	Issuer string
+
+	// SigningKeyFile is the path to the PEM encoded RSA or ECDSA private key
+	// workload identities are signed with. It must be the same on all
+	// servers. If empty, the leader generates and rotates the keys.
+	SigningKeyFile string
+
+	// RotationInterval is the interval the leader rotates the keys it
+	// generates at. Retired keys are published for another interval.
+	RotationInterval time.Duration
+}
+
+// WorkloadIdentityKey is a key generated by the leader to sign workload
+// identities.
+type WorkloadIdentityKey struct {
+	// KeyID is the ID of the key in the key set published by the servers,
+	// and the key ID in the header of the identities signed by it.
+	KeyID string
+
+	// Algorithm is the JSON Web Signature algorithm of the key.
+	Algorithm string
+
+	// Key is the PKCS #8, ASN.1 DER encoded private key.
+	Key []byte
+
+	// State is the state of the key in its rotation.
+	State string
+
+	// CreateTime is the time the key was generated and first published at.
+	CreateTime time.Time
+
+	// ActivateTime is the time the key started signing identities at.
+	ActivateTime time.Time
+
+	// RetireTime is the time the key stopped signing identities at.
+	RetireTime time.Time
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+func (k *WorkloadIdentityKey) Copy() *WorkloadIdentityKey {
+	if k == nil {
+		return nil
+	}
+	nk := new(WorkloadIdentityKey)
+	*nk = *k
+	nk.Key = make([]byte, len(k.Key))
+	copy(nk.Key, k.Key)
+	return nk
+}
+
+// Stub returns the key without its private key material.
+func (k *WorkloadIdentityKey) Stub() *WorkloadIdentityKeyStub {
+	return &WorkloadIdentityKeyStub{
+		KeyID:        k.KeyID,
+		Algorithm:    k.Algorithm,
+		State:        k.State,
+		CreateTime:   k.CreateTime,
+		ActivateTime: k.ActivateTime,
+		RetireTime:   k.RetireTime,
+		CreateIndex:  k.CreateIndex,
+		ModifyIndex:  k.ModifyIndex,
+	}
+}
+
+// WorkloadIdentityKeyStub is the public information of a key signing
+// workload identities.
+type WorkloadIdentityKeyStub struct {
+	KeyID        string
+	Algorithm    string
+	State        string
+	CreateTime   time.Time
+	ActivateTime time.Time
+	RetireTime   time.Time
+	CreateIndex  uint64
+	ModifyIndex  uint64
+}
+
+// WorkloadIdentityKeysUpsertRequest is used to apply a rotation of the keys
+// signing workload identities.
+type WorkloadIdentityKeysUpsertRequest struct {
+	// Keys are the keys to insert or update.
+	Keys []*WorkloadIdentityKey
+
+	// DeleteKeyIDs are the IDs of the keys to delete.
+	DeleteKeyIDs []string
+
+	WriteRequest
+}
+
+// WorkloadIdentityKeyRotateRequest is used to rotate the keys signing
+// workload identities ahead of the rotation interval.
+type WorkloadIdentityKeyRotateRequest struct {
+	WriteRequest
+}
+
+// WorkloadIdentityKeyRotateResponse is the response of a rotation of the keys
+// signing workload identities.
+type WorkloadIdentityKeyRotateResponse struct {
+	// KeyID is the ID of the key signing identities after the rotation.
+	KeyID string
+
+	WriteMeta
+}
+
+// WorkloadIdentityKeyListResponse is used to list the keys signing workload
+// identities.
+type WorkloadIdentityKeyListResponse struct {
+	Keys []*WorkloadIdentityKeyStub
+	QueryMeta
+}
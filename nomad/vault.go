@@ -243,6 +243,10 @@ type vaultClient struct {
 	// setConfigLock serializes access to the SetConfig method
 	setConfigLock sync.Mutex
 
+	// cluster is the name of the Vault cluster the client creates tokens on,
+	// or empty for the default cluster
+	cluster string
+
 	// consts as struct fields for overriding in tests
 	maxRevokeBatchSize int
 	revocationIntv     time.Duration
@@ -987,15 +991,25 @@ func (v *vaultClient) CreateToken(ctx context.Context, a *structs.Allocation, ta
 		return nil, fmt.Errorf("Task does not require Vault policies")
 	}
 
+	// Use the policies of the task on the cluster of the client
+	policiesForTask, taskNamespace := taskVault.Policies, taskVault.Namespace
+	if v.cluster != "" {
+		cluster := taskVault.Cluster(v.cluster)
+		if cluster == nil {
+			return nil, fmt.Errorf("Task does not require Vault cluster %q policies", v.cluster)
+		}
+		policiesForTask, taskNamespace = cluster.Policies, cluster.Namespace
+	}
+
 	// Set namespace for task
 	namespaceForTask := v.config.Namespace
-	if taskVault.Namespace != "" {
-		namespaceForTask = taskVault.Namespace
+	if taskNamespace != "" {
+		namespaceForTask = taskNamespace
 	}
 
 	// Build the creation request
 	req := &vapi.TokenCreateRequest{
-		Policies: taskVault.Policies,
+		Policies: policiesForTask,
 		Metadata: map[string]string{
 			"AllocationID": a.ID,
 			"JobID":        a.JobID,
@@ -1371,6 +1385,72 @@ func (s *Server) purgeVaultAccessors(accessors []*structs.VaultAccessor) error {
 	return err
 }
 
+// vaultClusterClient returns the client of the named Vault cluster, or of the
+// default cluster if the name is empty.
+func (s *Server) vaultClusterClient(cluster string) (VaultClient, error) {
+	if cluster == "" {
+		return s.vault, nil
+	}
+	v, ok := s.vaultClusters[cluster]
+	if !ok {
+		return nil, fmt.Errorf("Vault cluster %q is not configured", cluster)
+	}
+	return v, nil
+}
+
+// setVaultActive activates or de-activates the clients of all the Vault
+// clusters.
+func (s *Server) setVaultActive(active bool) {
+	s.vault.SetActive(active)
+	for _, v := range s.vaultClusters {
+		v.SetActive(active)
+	}
+}
+
+// vaultAccessorsByCluster groups the accessors by the Vault cluster their
+// token was created on.
+func vaultAccessorsByCluster(accessors []*structs.VaultAccessor) map[string][]*structs.VaultAccessor {
+	byCluster := make(map[string][]*structs.VaultAccessor)
+	for _, va := range accessors {
+		byCluster[va.Cluster] = append(byCluster[va.Cluster], va)
+	}
+	return byCluster
+}
+
+// revokeVaultAccessors revokes the tokens of the accessors on the Vault
+// clusters they were created on.
+func (s *Server) revokeVaultAccessors(ctx context.Context, accessors []*structs.VaultAccessor, committed bool) error {
+	var mErr multierror.Error
+	for cluster, clusterAccessors := range vaultAccessorsByCluster(accessors) {
+		v, err := s.vaultClusterClient(cluster)
+		if err != nil {
+			_ = multierror.Append(&mErr, err)
+			continue
+		}
+		if err := v.RevokeTokens(ctx, clusterAccessors, committed); err != nil {
+			_ = multierror.Append(&mErr, err)
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// markVaultAccessorsForRevocation marks the tokens of the accessors for
+// revocation on the Vault clusters they were created on.
+func (s *Server) markVaultAccessorsForRevocation(accessors []*structs.VaultAccessor) error {
+	var mErr multierror.Error
+	for cluster, clusterAccessors := range vaultAccessorsByCluster(accessors) {
+		v, err := s.vaultClusterClient(cluster)
+		if err != nil {
+			_ = multierror.Append(&mErr, err)
+			continue
+		}
+		if err := v.MarkForRevocation(clusterAccessors); err != nil {
+			_ = multierror.Append(&mErr, err)
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
 // wrapNilError is a helper that returns a wrapped function that returns a nil
 // error
 func wrapNilError(f func()) func() error {
@@ -0,0 +1,176 @@
+package nomad
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+	log "github.com/hashicorp/go-hclog"
+)
+
+const (
+	// webhookSignatureHeader is the header carrying the HMAC-SHA256 signature
+	// of the request body.
+	webhookSignatureHeader = "X-Nomad-Signature"
+
+	// webhookQueueSize is the number of payloads that may be waiting for
+	// delivery before new payloads are dropped.
+	webhookQueueSize = 256
+
+	// webhookAttempts is the number of times delivery of a payload is
+	// attempted before it is dropped.
+	webhookAttempts = 3
+
+	// webhookRetryBackoff is the base backoff between delivery attempts. It
+	// grows linearly with each attempt.
+	webhookRetryBackoff = time.Second
+
+	// webhookTimeout is the timeout of a single delivery attempt.
+	webhookTimeout = 10 * time.Second
+)
+
+// webhookMessage is a payload queued for delivery, with the key/value pairs
+// identifying it in logs.
+type webhookMessage struct {
+	payload interface{}
+	logArgs []interface{}
+}
+
+// webhook delivers JSON payloads to an operator defined endpoint. Payloads
+// are queued and delivered in order on a single goroutine so that a slow
+// endpoint never blocks the RPCs producing them.
+type webhook struct {
+	// kind describes the payloads in errors and logs, such as "scaling
+	// event".
+	kind   string
+	url    string
+	secret string
+	client *http.Client
+	logger log.Logger
+
+	queueCh chan *webhookMessage
+}
+
+// newWebhook returns a webhook POSTing payloads of the given kind to url,
+// signed with secret. caFile is an optional CA certificate used to verify the
+// endpoint.
+func newWebhook(kind, url, secret, caFile string, logger log.Logger) (*webhook, error) {
+	transport := cleanhttp.DefaultPooledTransport()
+	if caFile != "" {
+		data, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s webhook CA file: %v", kind, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("failed to parse any valid certificates in %s webhook CA file: %s", kind, caFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	return &webhook{
+		kind:   kind,
+		url:    url,
+		secret: secret,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   webhookTimeout,
+		},
+		logger:  logger,
+		queueCh: make(chan *webhookMessage, webhookQueueSize),
+	}, nil
+}
+
+// run delivers queued payloads until ctx is done.
+func (w *webhook) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-w.queueCh:
+			w.deliver(ctx, msg)
+		}
+	}
+}
+
+// enqueue queues a payload for delivery. It never blocks; payloads are
+// dropped if the queue is full.
+func (w *webhook) enqueue(payload interface{}, logArgs ...interface{}) {
+	select {
+	case w.queueCh <- &webhookMessage{payload: payload, logArgs: logArgs}:
+	default:
+		w.logger.Warn(fmt.Sprintf("webhook queue full, dropping %s", w.kind), logArgs...)
+	}
+}
+
+// deliver POSTs the payload to the webhook, retrying failed attempts.
+func (w *webhook) deliver(ctx context.Context, msg *webhookMessage) {
+	body, err := json.Marshal(msg.payload)
+	if err != nil {
+		w.logger.Error(fmt.Sprintf("failed to encode %s", w.kind), "error", err)
+		return
+	}
+
+	logger := w.logger.With(msg.logArgs...)
+
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		err = w.post(ctx, body)
+		if err == nil {
+			logger.Trace(fmt.Sprintf("delivered %s", w.kind))
+			return
+		}
+
+		if attempt == webhookAttempts {
+			break
+		}
+
+		logger.Debug(fmt.Sprintf("failed to deliver %s, retrying", w.kind), "error", err, "attempt", attempt)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Duration(attempt) * webhookRetryBackoff):
+		}
+	}
+
+	logger.Warn(fmt.Sprintf("failed to deliver %s", w.kind), "error", err)
+}
+
+// post sends a single signed request to the webhook.
+func (w *webhook) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookSignatureHeader, "sha256="+webhookSignature(w.secret, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// webhookSignature returns the hex encoded HMAC-SHA256 of body.
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -111,6 +111,10 @@ type Worker struct {
 	// first invoked. It is used to mark the SnapshotIndex of evaluations
 	// Created, Updated or Reblocked.
 	snapshotIndex uint64
+
+	// feasibilityCache memoizes the feasibility of nodes across the
+	// evaluations processed by the worker.
+	feasibilityCache *scheduler.FeasibilityCache
 }
 
 // NewWorker starts a new scheduler worker associated with the given server
@@ -128,6 +132,7 @@ func newWorker(ctx context.Context, srv *Server, args SchedulerWorkerPoolArgs) *
 		start:             time.Now(),
 		status:            WorkerStarting,
 		enabledSchedulers: make([]string, len(args.EnabledSchedulers)),
+		feasibilityCache:  scheduler.NewFeasibilityCache(),
 	}
 	copy(w.enabledSchedulers, args.EnabledSchedulers)
 
@@ -573,13 +578,23 @@ func (w *Worker) invokeScheduler(snap *state.StateSnapshot, eval *structs.Evalua
 	}
 
 	// Process the evaluation
+	hits, misses := w.feasibilityCache.Stats()
 	err = sched.Process(eval)
+	w.emitFeasibilityCacheStats(hits, misses)
 	if err != nil {
 		return fmt.Errorf("failed to process evaluation: %v", err)
 	}
 	return nil
 }
 
+// emitFeasibilityCacheStats emits the feasibility cache hits and misses since
+// the given counts.
+func (w *Worker) emitFeasibilityCacheStats(prevHits, prevMisses uint64) {
+	hits, misses := w.feasibilityCache.Stats()
+	metrics.IncrCounter([]string{"nomad", "worker", "feasibility_cache", "hit"}, float32(hits-prevHits))
+	metrics.IncrCounter([]string{"nomad", "worker", "feasibility_cache", "miss"}, float32(misses-prevMisses))
+}
+
 // SubmitPlan is used to submit a plan for consideration. This allows
 // the worker to act as the planner for the scheduler.
 func (w *Worker) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, scheduler.State, error) {
@@ -795,6 +810,25 @@ SUBMIT:
 	return nil
 }
 
+// NodeScorer returns the external node scorer configured on the server. This
+// allows the worker to act as the planner for the scheduler.
+func (w *Worker) NodeScorer() scheduler.NodeScorer {
+	return w.srv.nodeScorer
+}
+
+// ScoringPlugins returns the scoring plugins enabled on the server. This
+// allows the worker to act as the planner for the scheduler.
+func (w *Worker) ScoringPlugins() []*scheduler.WeightedScoringPlugin {
+	return w.srv.scoringPlugins
+}
+
+// FeasibilityCache returns the feasibility cache of the worker, which is
+// shared by the evaluations it processes. This allows the worker to act as the
+// planner for the scheduler.
+func (w *Worker) FeasibilityCache() *scheduler.FeasibilityCache {
+	return w.feasibilityCache
+}
+
 // shouldResubmit checks if a given error should be swallowed and the plan
 // resubmitted after a backoff. Usually these are transient errors that
 // the cluster should heal from quickly.
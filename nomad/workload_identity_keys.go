@@ -0,0 +1,182 @@
+package nomad
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// identityKeyRetryInterval is how long the leader waits before retrying
+	// after failing to rotate the keys signing workload identities.
+	identityKeyRetryInterval = 5 * time.Second
+)
+
+var (
+	// errIdentityNotConfigured is returned when workload identities are not
+	// configured on the servers.
+	errIdentityNotConfigured = errors.New("workload identities are not configured")
+
+	// errIdentityKeysNotManaged is returned when the keys signing workload
+	// identities are read from a signing key file rather than generated by
+	// the servers.
+	errIdentityKeysNotManaged = errors.New("workload identities are signed with a static signing key file")
+)
+
+// identityKeyPlan is the change of the keys signing workload identities the
+// leader applies to rotate them.
+type identityKeyPlan struct {
+	// Upsert are the generated keys and the keys that changed state.
+	Upsert []*structs.WorkloadIdentityKey
+
+	// Delete are the IDs of the retired keys that are no longer published.
+	Delete []string
+
+	// ActiveKeyID is the ID of the key signing identities after the plan is
+	// applied.
+	ActiveKeyID string
+
+	// NextRotation is the time the keys need to be rotated or retired keys
+	// need to be deleted at.
+	NextRotation time.Time
+}
+
+// empty returns whether the plan doesn't change any keys.
+func (p *identityKeyPlan) empty() bool {
+	return len(p.Upsert) == 0 && len(p.Delete) == 0
+}
+
+// planIdentityKeyRotation plans the rotation of the given keys at now. The
+// active key is rotated once it signed identities for the rotation interval,
+// or immediately if forced: the next key, which was published ahead, becomes
+// active, the active key is retired, and a new next key is generated. Retired
+// keys are deleted once they were retired for the rotation interval.
+func planIdentityKeyRotation(keys []*structs.WorkloadIdentityKey, interval time.Duration, force bool, now time.Time) (*identityKeyPlan, error) {
+	plan := new(identityKeyPlan)
+
+	var active, next *structs.WorkloadIdentityKey
+	for _, key := range keys {
+		switch key.State {
+		case structs.WorkloadIdentityKeyStateActive:
+			active = key
+		case structs.WorkloadIdentityKeyStateNext:
+			next = key
+		case structs.WorkloadIdentityKeyStateRetired:
+			expire := key.RetireTime.Add(interval)
+			if !expire.After(now) {
+				plan.Delete = append(plan.Delete, key.KeyID)
+			} else {
+				plan.updateNextRotation(expire)
+			}
+		}
+	}
+
+	rotate := force || active == nil || !active.ActivateTime.Add(interval).After(now)
+	if rotate {
+		if active != nil {
+			retired := active.Copy()
+			retired.State = structs.WorkloadIdentityKeyStateRetired
+			retired.RetireTime = now
+			plan.Upsert = append(plan.Upsert, retired)
+			plan.updateNextRotation(now.Add(interval))
+		}
+
+		// Promote the next key, unless there is none yet because the keys
+		// are bootstrapped
+		if next == nil {
+			var err error
+			if next, err = generateIdentityKey(now); err != nil {
+				return nil, err
+			}
+		} else {
+			next = next.Copy()
+		}
+		next.State = structs.WorkloadIdentityKeyStateActive
+		next.ActivateTime = now
+		plan.Upsert = append(plan.Upsert, next)
+		active, next = next, nil
+	}
+	plan.ActiveKeyID = active.KeyID
+	plan.updateNextRotation(active.ActivateTime.Add(interval))
+
+	if next == nil {
+		generated, err := generateIdentityKey(now)
+		if err != nil {
+			return nil, err
+		}
+		plan.Upsert = append(plan.Upsert, generated)
+	}
+
+	return plan, nil
+}
+
+// updateNextRotation moves the next rotation of the plan to t if it is
+// earlier.
+func (p *identityKeyPlan) updateNextRotation(t time.Time) {
+	if p.NextRotation.IsZero() || t.Before(p.NextRotation) {
+		p.NextRotation = t
+	}
+}
+
+// rotateWorkloadIdentityKeys applies the rotation of the keys signing
+// workload identities that is due, or rotates the active key immediately if
+// forced. It returns the plan and the Raft index it was applied at, which is
+// zero if nothing changed.
+func (s *Server) rotateWorkloadIdentityKeys(force bool) (*identityKeyPlan, uint64, error) {
+	signer := s.identitySigner
+	signer.rotateLock.Lock()
+	defer signer.rotateLock.Unlock()
+
+	keys, err := signer.stateKeys()
+	if err != nil {
+		return nil, 0, err
+	}
+	plan, err := planIdentityKeyRotation(keys, signer.rotationInterval, force, time.Now())
+	if err != nil {
+		return nil, 0, err
+	}
+	if plan.empty() {
+		return plan, 0, nil
+	}
+
+	req := &structs.WorkloadIdentityKeysUpsertRequest{
+		Keys:         plan.Upsert,
+		DeleteKeyIDs: plan.Delete,
+		WriteRequest: structs.WriteRequest{Region: s.config.Region},
+	}
+	resp, index, err := s.raftApply(structs.WorkloadIdentityKeysUpsertRequestType, req)
+	if err != nil {
+		return nil, 0, err
+	} else if respErr, ok := resp.(error); ok {
+		return nil, 0, respErr
+	}
+
+	s.logger.Info("rotated workload identity keys", "active_key_id", plan.ActiveKeyID,
+		"upserted", len(plan.Upsert), "deleted", len(plan.Delete))
+	return plan, index, nil
+}
+
+// manageWorkloadIdentityKeys is a long lived function that generates the
+// keys signing workload identities, rotates them at the rotation interval
+// and deletes retired keys once the identities they signed expired, while we
+// are leader.
+func (s *Server) manageWorkloadIdentityKeys(stopCh chan struct{}) {
+	for {
+		wait := identityKeyRetryInterval
+		plan, _, err := s.rotateWorkloadIdentityKeys(false)
+		if err != nil {
+			s.logger.Error("failed to rotate workload identity keys", "error", err)
+		} else {
+			wait = time.Until(plan.NextRotation)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-stopCh:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanIdentityKeyRotation(t *testing.T) {
+	ci.Parallel(t)
+
+	interval := 24 * time.Hour
+	now := time.Now()
+
+	// Bootstrapping generates the active and the next key
+	plan, err := planIdentityKeyRotation(nil, interval, false, now)
+	require.NoError(t, err)
+	require.Empty(t, plan.Delete)
+	require.Len(t, plan.Upsert, 2)
+	active, next := plan.Upsert[0], plan.Upsert[1]
+	require.Equal(t, structs.WorkloadIdentityKeyStateActive, active.State)
+	require.Equal(t, now, active.ActivateTime)
+	require.Equal(t, structs.WorkloadIdentityKeyStateNext, next.State)
+	require.Equal(t, active.KeyID, plan.ActiveKeyID)
+	require.Equal(t, now.Add(interval), plan.NextRotation)
+
+	// Nothing changes before the rotation is due
+	keys := []*structs.WorkloadIdentityKey{active, next}
+	plan, err = planIdentityKeyRotation(keys, interval, false, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.True(t, plan.empty())
+	require.Equal(t, active.KeyID, plan.ActiveKeyID)
+	require.Equal(t, now.Add(interval), plan.NextRotation)
+
+	// Once due, the next key becomes active and the active key is retired
+	rotateTime := now.Add(interval)
+	plan, err = planIdentityKeyRotation(keys, interval, false, rotateTime)
+	require.NoError(t, err)
+	require.Empty(t, plan.Delete)
+	require.Len(t, plan.Upsert, 3)
+	retired, promoted, generated := plan.Upsert[0], plan.Upsert[1], plan.Upsert[2]
+	require.Equal(t, active.KeyID, retired.KeyID)
+	require.Equal(t, structs.WorkloadIdentityKeyStateRetired, retired.State)
+	require.Equal(t, rotateTime, retired.RetireTime)
+	require.Equal(t, next.KeyID, promoted.KeyID)
+	require.Equal(t, structs.WorkloadIdentityKeyStateActive, promoted.State)
+	require.Equal(t, rotateTime, promoted.ActivateTime)
+	require.Equal(t, structs.WorkloadIdentityKeyStateNext, generated.State)
+	require.Equal(t, next.KeyID, plan.ActiveKeyID)
+	require.Equal(t, rotateTime.Add(interval), plan.NextRotation)
+
+	// The planned keys are copies
+	require.Equal(t, structs.WorkloadIdentityKeyStateActive, active.State)
+	require.Equal(t, structs.WorkloadIdentityKeyStateNext, next.State)
+
+	// Retired keys are deleted once retired for the rotation interval
+	keys = []*structs.WorkloadIdentityKey{retired, promoted, generated}
+	plan, err = planIdentityKeyRotation(keys, interval, false, rotateTime.Add(interval-time.Minute))
+	require.NoError(t, err)
+	require.True(t, plan.empty())
+
+	plan, err = planIdentityKeyRotation(keys, interval, false, rotateTime.Add(interval))
+	require.NoError(t, err)
+	require.Equal(t, []string{retired.KeyID}, plan.Delete)
+	require.Len(t, plan.Upsert, 3)
+	require.Equal(t, generated.KeyID, plan.ActiveKeyID)
+
+	// Forced rotations are not delayed by the interval
+	plan, err = planIdentityKeyRotation(keys, interval, true, rotateTime.Add(time.Minute))
+	require.NoError(t, err)
+	require.Empty(t, plan.Delete)
+	require.Len(t, plan.Upsert, 3)
+	require.Equal(t, generated.KeyID, plan.ActiveKeyID)
+	require.Equal(t, rotateTime.Add(interval), plan.NextRotation)
+}
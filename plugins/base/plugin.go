@@ -20,6 +20,9 @@ const (
 
 	// PluginTypeDevice implements the device plugin interface
 	PluginTypeDevice = "device"
+
+	// PluginTypeTaskHook implements the task hook plugin interface
+	PluginTypeTaskHook = "task_hook"
 )
 
 var (
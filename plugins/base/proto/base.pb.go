@@ -29,21 +29,24 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type PluginType int32
 
 const (
-	PluginType_UNKNOWN PluginType = 0
-	PluginType_DRIVER  PluginType = 2
-	PluginType_DEVICE  PluginType = 3
+	PluginType_UNKNOWN   PluginType = 0
+	PluginType_DRIVER    PluginType = 2
+	PluginType_DEVICE    PluginType = 3
+	PluginType_TASK_HOOK PluginType = 4
 )
 
 var PluginType_name = map[int32]string{
 	0: "UNKNOWN",
 	2: "DRIVER",
 	3: "DEVICE",
+	4: "TASK_HOOK",
 }
 
 var PluginType_value = map[string]int32{
-	"UNKNOWN": 0,
-	"DRIVER":  2,
-	"DEVICE":  3,
+	"UNKNOWN":   0,
+	"DRIVER":    2,
+	"DEVICE":    3,
+	"TASK_HOOK": 4,
 }
 
 func (x PluginType) String() string {
@@ -434,40 +437,41 @@ func init() {
 }
 
 var fileDescriptor_19edef855873449e = []byte{
-	// 521 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x51, 0x6f, 0x12, 0x41,
-	0x10, 0xee, 0x01, 0xd2, 0x30, 0x40, 0x73, 0x2c, 0x9a, 0x10, 0x12, 0x13, 0x72, 0xb1, 0x09, 0x31,
-	0xcd, 0x12, 0x51, 0xd4, 0x47, 0x85, 0xf2, 0x40, 0x4c, 0xb1, 0x59, 0x14, 0x8d, 0x31, 0xb9, 0x6c,
-	0x8f, 0x2d, 0x77, 0x11, 0xf6, 0xd6, 0xdb, 0x6b, 0x63, 0x4d, 0x7c, 0xf2, 0xd9, 0x5f, 0xe4, 0xa3,
-	0x7f, 0xcc, 0xdc, 0xee, 0x5e, 0x39, 0x5a, 0x4d, 0x8f, 0xa7, 0x1b, 0x66, 0xbe, 0xef, 0x9b, 0x99,
-	0x8f, 0x59, 0x78, 0x28, 0x56, 0x17, 0xcb, 0x80, 0xcb, 0xde, 0x19, 0x95, 0xac, 0x27, 0xa2, 0x30,
-	0x0e, 0x55, 0x88, 0x55, 0x88, 0x1c, 0x9f, 0x4a, 0x3f, 0xf0, 0xc2, 0x48, 0x60, 0x1e, 0xae, 0xe9,
-	0x02, 0x1b, 0x38, 0xde, 0x60, 0xda, 0x87, 0xa9, 0x84, 0xf4, 0x69, 0xc4, 0x16, 0x3d, 0xdf, 0x5b,
-	0x49, 0xc1, 0xbc, 0xe4, 0xeb, 0x26, 0x81, 0x86, 0x39, 0x4d, 0x68, 0x9c, 0x2a, 0xe0, 0x84, 0x9f,
-	0x87, 0x84, 0x7d, 0xbd, 0x60, 0x32, 0x76, 0xfe, 0x58, 0x80, 0xb2, 0x59, 0x29, 0x42, 0x2e, 0x19,
-	0x1a, 0x42, 0x29, 0xbe, 0x12, 0xac, 0x65, 0x75, 0xac, 0xee, 0x41, 0x1f, 0xe3, 0xbb, 0xa7, 0xc0,
-	0x5a, 0xe5, 0xdd, 0x95, 0x60, 0x44, 0x71, 0x11, 0x86, 0xa6, 0x86, 0xb9, 0x54, 0x04, 0xee, 0x25,
-	0x8b, 0x64, 0x10, 0x72, 0xd9, 0x2a, 0x74, 0x8a, 0xdd, 0x0a, 0x69, 0xe8, 0xd2, 0x6b, 0x11, 0xcc,
-	0x4d, 0x01, 0x1d, 0xc2, 0x81, 0xc1, 0x1b, 0x6c, 0xab, 0xd8, 0xb1, 0xba, 0x15, 0x52, 0xd7, 0x59,
-	0x83, 0x43, 0x08, 0x4a, 0x9c, 0xae, 0x59, 0xab, 0xa4, 0x8a, 0x2a, 0x76, 0x1e, 0x40, 0x73, 0x14,
-	0xf2, 0xf3, 0x60, 0x39, 0xf3, 0x7c, 0xb6, 0xa6, 0xe9, 0x72, 0x1f, 0xe1, 0xfe, 0x76, 0xda, 0x6c,
-	0xf7, 0x0a, 0x4a, 0x89, 0x2f, 0x6a, 0xbb, 0x6a, 0xff, 0xe8, 0xbf, 0xdb, 0x69, 0x3f, 0xb1, 0xf1,
-	0x13, 0xcf, 0x04, 0xf3, 0x88, 0x62, 0x3a, 0xbf, 0x2d, 0xb0, 0x67, 0x2c, 0xd6, 0xea, 0xa6, 0x5d,
-	0xb2, 0xc0, 0x5a, 0x2e, 0x05, 0xf5, 0xbe, 0xb8, 0x9e, 0x2a, 0xa8, 0x06, 0x35, 0x52, 0x37, 0x59,
-	0x8d, 0x46, 0x04, 0x6a, 0xaa, 0x4d, 0x0a, 0x2a, 0xa8, 0x29, 0x7a, 0x79, 0x3c, 0x9e, 0x26, 0x05,
-	0xd3, 0xb4, 0xca, 0x37, 0x3f, 0xd0, 0x11, 0xa0, 0xdb, 0x5e, 0x1b, 0xff, 0xec, 0x9b, 0x56, 0x3b,
-	0x9f, 0xa1, 0x9a, 0x51, 0x42, 0x27, 0x50, 0x5e, 0x44, 0xc1, 0x25, 0x8b, 0x8c, 0x21, 0x83, 0xdc,
-	0xa3, 0x1c, 0x2b, 0x9a, 0x19, 0xc8, 0x88, 0x38, 0x2e, 0x34, 0x6e, 0x15, 0xd1, 0x23, 0xa8, 0x8f,
-	0x56, 0x01, 0xe3, 0xf1, 0x09, 0xfd, 0x76, 0x1a, 0x46, 0xb1, 0x6a, 0x55, 0x27, 0xdb, 0xc9, 0x0c,
-	0x2a, 0xe0, 0x0a, 0x55, 0xd8, 0x42, 0xe9, 0x64, 0x72, 0xc8, 0x19, 0xef, 0xf5, 0x7f, 0xfa, 0xf8,
-	0x09, 0xc0, 0xe6, 0x02, 0x51, 0x15, 0xf6, 0xdf, 0x4f, 0xdf, 0x4c, 0xdf, 0x7e, 0x98, 0xda, 0x7b,
-	0x08, 0xa0, 0x7c, 0x4c, 0x26, 0xf3, 0x31, 0xb1, 0x0b, 0x2a, 0x1e, 0xcf, 0x27, 0xa3, 0xb1, 0x5d,
-	0xec, 0xff, 0x2a, 0x02, 0x0c, 0xa9, 0x64, 0x9a, 0x87, 0x7e, 0xa4, 0x0a, 0xc9, 0x4b, 0x40, 0x83,
-	0xfc, 0x37, 0x9f, 0x79, 0x4f, 0xed, 0xe7, 0xbb, 0xd2, 0xf4, 0xf8, 0xce, 0x1e, 0xfa, 0x69, 0x41,
-	0x2d, 0x7b, 0xad, 0xe8, 0x45, 0x1e, 0xa9, 0x7f, 0x9c, 0x7d, 0xfb, 0xe5, 0xee, 0xc4, 0xeb, 0x29,
-	0xbe, 0x43, 0xe5, 0xda, 0x5b, 0xf4, 0x2c, 0x8f, 0xd0, 0xcd, 0x67, 0xd0, 0x1e, 0xec, 0xc8, 0x4a,
-	0x7b, 0x0f, 0xf7, 0x3f, 0xdd, 0x53, 0xc5, 0xb3, 0xb2, 0xfa, 0x3c, 0xfd, 0x1b, 0x00, 0x00, 0xff,
-	0xff, 0xdc, 0x0f, 0xf5, 0x51, 0x1c, 0x05, 0x00, 0x00,
+	// 530 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0x51, 0x6b, 0x1a, 0x41,
+	0x10, 0xce, 0xa9, 0x35, 0x38, 0x6a, 0x38, 0xc7, 0x16, 0x44, 0x28, 0xc8, 0xd1, 0x80, 0x94, 0x70,
+	0x82, 0xad, 0x6d, 0x1f, 0x13, 0x8d, 0x50, 0x91, 0x68, 0x38, 0x53, 0x5b, 0x4a, 0xe1, 0xd8, 0x9c,
+	0x1b, 0x3d, 0xaa, 0x7b, 0xdb, 0xdb, 0x4b, 0x68, 0x0a, 0x7d, 0xea, 0x73, 0x7f, 0x51, 0x1f, 0xfb,
+	0xc7, 0xca, 0xed, 0xae, 0xf1, 0x4c, 0x5a, 0x7a, 0x3e, 0xdd, 0x38, 0xdf, 0x37, 0xdf, 0xcc, 0x7c,
+	0xee, 0xc0, 0x53, 0xbe, 0xbc, 0x9e, 0xfb, 0x4c, 0xb4, 0x2e, 0x89, 0xa0, 0x2d, 0x1e, 0x06, 0x51,
+	0x20, 0x43, 0x5b, 0x86, 0x68, 0x2d, 0x88, 0x58, 0xf8, 0x5e, 0x10, 0x72, 0x9b, 0x05, 0x2b, 0x32,
+	0xb3, 0x35, 0xdd, 0xde, 0x70, 0xea, 0x87, 0x6b, 0x09, 0xb1, 0x20, 0x21, 0x9d, 0xb5, 0x16, 0xde,
+	0x52, 0x70, 0xea, 0xc5, 0x5f, 0x37, 0x0e, 0x14, 0xcd, 0xaa, 0x42, 0xe5, 0x5c, 0x12, 0x07, 0xec,
+	0x2a, 0x70, 0xe8, 0x97, 0x6b, 0x2a, 0x22, 0xeb, 0xb7, 0x01, 0x98, 0xcc, 0x0a, 0x1e, 0x30, 0x41,
+	0xb1, 0x0b, 0xb9, 0xe8, 0x96, 0xd3, 0x9a, 0xd1, 0x30, 0x9a, 0x07, 0x6d, 0xdb, 0xfe, 0xff, 0x14,
+	0xb6, 0x52, 0xb9, 0xb8, 0xe5, 0xd4, 0x91, 0xb5, 0x68, 0x43, 0x55, 0xd1, 0x5c, 0xc2, 0x7d, 0xf7,
+	0x86, 0x86, 0xc2, 0x0f, 0x98, 0xa8, 0x65, 0x1a, 0xd9, 0x66, 0xc1, 0xa9, 0x28, 0xe8, 0x84, 0xfb,
+	0x53, 0x0d, 0xe0, 0x21, 0x1c, 0x68, 0xbe, 0xe6, 0xd6, 0xb2, 0x0d, 0xa3, 0x59, 0x70, 0xca, 0x2a,
+	0xab, 0x79, 0x88, 0x90, 0x63, 0x64, 0x45, 0x6b, 0x39, 0x09, 0xca, 0xd8, 0x7a, 0x02, 0xd5, 0x5e,
+	0xc0, 0xae, 0xfc, 0xf9, 0xc4, 0x5b, 0xd0, 0x15, 0x59, 0x2f, 0xf7, 0x01, 0x1e, 0x6f, 0xa7, 0xf5,
+	0x76, 0xc7, 0x90, 0x8b, 0x7d, 0x91, 0xdb, 0x15, 0xdb, 0x47, 0xff, 0xdc, 0x4e, 0xf9, 0x69, 0x6b,
+	0x3f, 0xed, 0x09, 0xa7, 0x9e, 0x23, 0x2b, 0xad, 0x5f, 0x06, 0x98, 0x13, 0x1a, 0x29, 0x75, 0xdd,
+	0x2e, 0x5e, 0x60, 0x25, 0xe6, 0x9c, 0x78, 0x9f, 0x5d, 0x4f, 0x02, 0xb2, 0x41, 0xc9, 0x29, 0xeb,
+	0xac, 0x62, 0xa3, 0x03, 0x25, 0xd9, 0x66, 0x4d, 0xca, 0xc8, 0x29, 0x5a, 0x69, 0x3c, 0x1e, 0xc5,
+	0x80, 0x6e, 0x5a, 0x64, 0x9b, 0x1f, 0x78, 0x04, 0xf8, 0xd0, 0x6b, 0xed, 0x9f, 0x79, 0xdf, 0x6a,
+	0xeb, 0x13, 0x14, 0x13, 0x4a, 0x78, 0x06, 0xf9, 0x59, 0xe8, 0xdf, 0xd0, 0x50, 0x1b, 0xd2, 0x49,
+	0x3d, 0xca, 0xa9, 0x2c, 0xd3, 0x03, 0x69, 0x11, 0xcb, 0x85, 0xca, 0x03, 0x10, 0x9f, 0x41, 0xb9,
+	0xb7, 0xf4, 0x29, 0x8b, 0xce, 0xc8, 0xd7, 0xf3, 0x20, 0x8c, 0x64, 0xab, 0xb2, 0xb3, 0x9d, 0x4c,
+	0xb0, 0x7c, 0x26, 0x59, 0x99, 0x2d, 0x96, 0x4a, 0xc6, 0x0f, 0x39, 0xe1, 0xbd, 0xfa, 0x4f, 0x9f,
+	0x1f, 0x03, 0x6c, 0x5e, 0x20, 0x16, 0x61, 0xff, 0xdd, 0x68, 0x38, 0x1a, 0xbf, 0x1f, 0x99, 0x7b,
+	0x08, 0x90, 0x3f, 0x75, 0x06, 0xd3, 0xbe, 0x63, 0x66, 0x64, 0xdc, 0x9f, 0x0e, 0x7a, 0x7d, 0x33,
+	0x8b, 0x65, 0x28, 0x5c, 0x9c, 0x4c, 0x86, 0xee, 0xdb, 0xf1, 0x78, 0x68, 0xe6, 0xda, 0x3f, 0xb3,
+	0x00, 0x5d, 0x22, 0xa8, 0x92, 0xc1, 0xef, 0x00, 0x9b, 0xc3, 0xc0, 0x4e, 0xfa, 0x13, 0x48, 0x9c,
+	0x57, 0xfd, 0xd5, 0xae, 0x65, 0x6a, 0x1b, 0x6b, 0x0f, 0x7f, 0x18, 0x50, 0x4a, 0x3e, 0x5e, 0x7c,
+	0x9d, 0x46, 0xea, 0x2f, 0x57, 0x50, 0x7f, 0xb3, 0x7b, 0xe1, 0xdd, 0x14, 0xdf, 0xa0, 0x70, 0x67,
+	0x35, 0xbe, 0x4c, 0x23, 0x74, 0xff, 0x2a, 0xea, 0x9d, 0x1d, 0xab, 0xd6, 0xbd, 0xbb, 0xfb, 0x1f,
+	0x1f, 0x49, 0xf0, 0x32, 0x2f, 0x3f, 0x2f, 0xfe, 0x0c, 0x00, 0xfd, 0x5b, 0x02, 0xac, 0x2b, 0x05,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
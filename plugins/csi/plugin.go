@@ -755,16 +755,18 @@ func (r *ControllerDeleteSnapshotRequest) Validate() error {
 }
 
 type ControllerListSnapshotsRequest struct {
-	MaxEntries    int32
-	StartingToken string
-	Secrets       structs.CSISecrets
+	MaxEntries     int32
+	StartingToken  string
+	SourceVolumeID string // optional, storage provider's ID for volume
+	Secrets        structs.CSISecrets
 }
 
 func (r *ControllerListSnapshotsRequest) ToCSIRepresentation() *csipbv1.ListSnapshotsRequest {
 	return &csipbv1.ListSnapshotsRequest{
-		MaxEntries:    r.MaxEntries,
-		StartingToken: r.StartingToken,
-		Secrets:       r.Secrets,
+		MaxEntries:     r.MaxEntries,
+		StartingToken:  r.StartingToken,
+		SourceVolumeId: r.SourceVolumeID,
+		Secrets:        r.Secrets,
 	}
 }
 
@@ -10,6 +10,8 @@ import (
 	"github.com/hashicorp/nomad/helper/pluginutils/grpcutils"
 	"github.com/hashicorp/nomad/plugins/base"
 	"github.com/hashicorp/nomad/plugins/device/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // devicePluginClient implements the client side of a remote device plugin, using
@@ -94,6 +96,26 @@ func (d *devicePluginClient) Reserve(deviceIDs []string) (*ContainerReservation,
 	return out, nil
 }
 
+// Reset is used to reset devices once the task they were reserved for has
+// exited. ErrResetNotSupported is returned if the plugin doesn't support
+// resetting devices, including plugins built before the Reset RPC existed.
+func (d *devicePluginClient) Reset(deviceIDs []string) error {
+	// Build the request
+	req := &proto.ResetRequest{
+		DeviceIds: deviceIDs,
+	}
+
+	// Make the request
+	if _, err := d.client.Reset(d.doneCtx, req); err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return ErrResetNotSupported
+		}
+		return grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	return nil
+}
+
 // Stats is used to retrieve device statistics from the device plugin. An error
 // may be immediately returned if the stats call could not be made or as part of
 // the streaming response. If the context is cancelled, the error will be
@@ -57,14 +57,19 @@ var (
 			hclspec.NewAttr("unhealthy_perm", "string", false),
 			hclspec.NewLiteral("\"-rwxrwxrwx\""),
 		),
+		"truncate_on_reset": hclspec.NewDefault(
+			hclspec.NewAttr("truncate_on_reset", "bool", false),
+			hclspec.NewLiteral("false"),
+		),
 	})
 )
 
 // Config contains configuration information for the plugin.
 type Config struct {
-	Dir           string `codec:"dir"`
-	ListPeriod    string `codec:"list_period"`
-	UnhealthyPerm string `codec:"unhealthy_perm"`
+	Dir             string `codec:"dir"`
+	ListPeriod      string `codec:"list_period"`
+	UnhealthyPerm   string `codec:"unhealthy_perm"`
+	TruncateOnReset bool   `codec:"truncate_on_reset"`
 }
 
 // FsDevice is an example device plugin. The device plugin exposes files as
@@ -80,6 +85,10 @@ type FsDevice struct {
 	// unhealthyPerm is the permissions on a file we consider unhealthy
 	unhealthyPerm string
 
+	// truncateOnReset is whether files are truncated when the task they were
+	// reserved for exits
+	truncateOnReset bool
+
 	// listPeriod is how often we should list the device directory to detect new
 	// devices
 	listPeriod time.Duration
@@ -117,6 +126,7 @@ func (d *FsDevice) SetConfig(c *base.Config) error {
 	// Save the device directory and the unhealthy permissions
 	d.deviceDir = config.Dir
 	d.unhealthyPerm = config.UnhealthyPerm
+	d.truncateOnReset = config.TruncateOnReset
 
 	// Convert the poll period
 	period, err := time.ParseDuration(config.ListPeriod)
@@ -279,6 +289,30 @@ func (d *FsDevice) Reserve(deviceIDs []string) (*device.ContainerReservation, er
 	return resp, nil
 }
 
+// Reset truncates the given devices if configured to, so the content written
+// by a task isn't visible to the next one.
+func (d *FsDevice) Reset(deviceIDs []string) error {
+	if !d.truncateOnReset {
+		return nil
+	}
+
+	d.deviceLock.RLock()
+	defer d.deviceLock.RUnlock()
+
+	for _, id := range deviceIDs {
+		// Check if the device is known
+		if _, ok := d.devices[id]; !ok {
+			return status.Newf(codes.InvalidArgument, "unknown device %q", id).Err()
+		}
+
+		if err := os.Truncate(filepath.Join(d.deviceDir, id), 0); err != nil {
+			return status.Newf(codes.Internal, "failed to truncate device %q: %v", id, err).Err()
+		}
+	}
+
+	return nil
+}
+
 // Stats streams statistics for the detected devices.
 func (d *FsDevice) Stats(ctx context.Context, interval time.Duration) (<-chan *device.StatsResponse, error) {
 	outCh := make(chan *device.StatsResponse)
@@ -18,6 +18,10 @@ const (
 var (
 	// ErrPluginDisabled indicates that the device plugin is disabled
 	ErrPluginDisabled = fmt.Errorf("device is not enabled")
+
+	// ErrResetNotSupported indicates that the device plugin doesn't support
+	// resetting devices
+	ErrResetNotSupported = fmt.Errorf("device plugin does not support resetting devices")
 )
 
 // DevicePlugin is the interface for a plugin that can expose detected devices
@@ -37,6 +41,16 @@ type DevicePlugin interface {
 	Stats(ctx context.Context, interval time.Duration) (<-chan *StatsResponse, error)
 }
 
+// DeviceResetter marks that a device plugin can reset devices once the task
+// they were reserved for has exited, for example to scrub the memory of a GPU
+// before it is reserved for another allocation. Plugins which make resetting
+// configurable should return nil from Reset when it is disabled. If Reset
+// returns an error the client marks the devices as unhealthy.
+type DeviceResetter interface {
+	// Reset is used to reset a set of devices.
+	Reset(deviceIDs []string) error
+}
+
 // FingerprintResponse includes a set of detected devices or an error in the
 // process of fingerprinting.
 type FingerprintResponse struct {
@@ -68,7 +82,7 @@ type DeviceGroup struct {
 	// Vendor is the vendor providing the device (nvidia, intel, etc).
 	Vendor string
 
-	// Type is the type of the device (gpu, fpga, etc).
+	// Type is the type of the device (gpu, mig, fpga, etc).
 	Type string
 
 	// Name is the devices model name.
@@ -112,6 +126,12 @@ func (d *DeviceGroup) Validate() error {
 		}
 	}
 
+	if d.Type == DeviceTypeMIG {
+		if err := d.validateMIG(); err != nil {
+			_ = multierror.Append(&mErr, err)
+		}
+	}
+
 	return mErr.ErrorOrNil()
 
 }
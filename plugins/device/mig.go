@@ -0,0 +1,94 @@
+package device
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/nomad/plugins/shared/structs"
+)
+
+const (
+	// DeviceTypeMIG is a canonical device type for a GPU MIG (Multi-Instance
+	// GPU) slice, a partition of a GPU with its own compute and memory
+	// resources. MIG slices are scheduled as devices of their own, separate
+	// from the whole GPUs, and are grouped by profile.
+	DeviceTypeMIG = "mig"
+
+	// MIGProfileAttr is the attribute holding the profile of the MIG slices
+	// of a device group, such as "1g.5gb".
+	MIGProfileAttr = "mig_profile"
+
+	// MIGComputeSlicesAttr is the attribute holding the number of GPU compute
+	// slices of the MIG slices of a device group.
+	MIGComputeSlicesAttr = "compute_slices"
+
+	// MIGMemoryAttr is the attribute holding the memory of the MIG slices of
+	// a device group.
+	MIGMemoryAttr = "memory"
+
+	// MIGParentModelAttr is the attribute holding the model of the GPUs the
+	// MIG slices of a device group are partitions of.
+	MIGParentModelAttr = "parent_model"
+)
+
+// migProfileRe matches MIG profile names, such as "1g.5gb", "1c.2g.10gb" for
+// compute instances sharing a GPU instance, or "1g.10gb+me" for profiles with
+// extensions.
+var migProfileRe = regexp.MustCompile(`^(?:(\d+)c\.)?(\d+)g\.(\d+)gb(?:\+[a-z0-9]+)*$`)
+
+// ParseMIGProfile returns the number of compute slices of the MIG profile. A
+// compute instance profile, such as "1c.2g.10gb", has the compute slices of
+// its compute instance rather than of its GPU instance.
+func ParseMIGProfile(profile string) (int, error) {
+	m := migProfileRe.FindStringSubmatch(profile)
+	if m == nil {
+		return 0, fmt.Errorf("invalid MIG profile %q", profile)
+	}
+
+	slices := m[2]
+	if m[1] != "" {
+		slices = m[1]
+	}
+	return strconv.Atoi(slices)
+}
+
+// NewMIGDeviceGroup returns the device group of the MIG slices with the given
+// profile on the GPUs of the given model. memoryMiB is the memory of each
+// slice, as reported by the GPU rather than the nominal memory of the
+// profile name.
+func NewMIGDeviceGroup(vendor, gpuModel, profile string, memoryMiB int64, devices []*Device) (*DeviceGroup, error) {
+	slices, err := ParseMIGProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeviceGroup{
+		Vendor:  vendor,
+		Type:    DeviceTypeMIG,
+		Name:    profile,
+		Devices: devices,
+		Attributes: map[string]*structs.Attribute{
+			MIGProfileAttr:       structs.NewStringAttribute(profile),
+			MIGComputeSlicesAttr: structs.NewIntAttribute(int64(slices), ""),
+			MIGMemoryAttr:        structs.NewIntAttribute(memoryMiB, structs.UnitMiB),
+			MIGParentModelAttr:   structs.NewStringAttribute(gpuModel),
+		},
+	}, nil
+}
+
+// validateMIG validates the attributes of a group of MIG slices.
+func (d *DeviceGroup) validateMIG() error {
+	attr, ok := d.Attributes[MIGProfileAttr]
+	if !ok {
+		return fmt.Errorf("MIG device group must have the %q attribute", MIGProfileAttr)
+	}
+	profile, ok := attr.GetString()
+	if !ok {
+		return fmt.Errorf("MIG device group attribute %q must be a string", MIGProfileAttr)
+	}
+	if _, err := ParseMIGProfile(profile); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package device
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/shared/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMIGProfile(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		Profile string
+		Slices  int
+		Err     bool
+	}{
+		{Profile: "1g.5gb", Slices: 1},
+		{Profile: "7g.80gb", Slices: 7},
+		{Profile: "1c.3g.20gb", Slices: 1},
+		{Profile: "1g.10gb+me", Slices: 1},
+		{Profile: "", Err: true},
+		{Profile: "1g", Err: true},
+		{Profile: "5gb", Err: true},
+		{Profile: "g.5gb", Err: true},
+		{Profile: "1g.5gb.extra", Err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Profile, func(t *testing.T) {
+			slices, err := ParseMIGProfile(c.Profile)
+			if c.Err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.Slices, slices)
+		})
+	}
+}
+
+func TestNewMIGDeviceGroup(t *testing.T) {
+	ci.Parallel(t)
+
+	devices := []*Device{{ID: "MIG-1", Healthy: true}}
+	group, err := NewMIGDeviceGroup("nvidia", "A100", "3g.20gb", 19968, devices)
+	require.NoError(t, err)
+	require.NoError(t, group.Validate())
+
+	require.Equal(t, "nvidia", group.Vendor)
+	require.Equal(t, DeviceTypeMIG, group.Type)
+	require.Equal(t, "3g.20gb", group.Name)
+	require.Equal(t, devices, group.Devices)
+	require.Equal(t, map[string]*structs.Attribute{
+		MIGProfileAttr:       structs.NewStringAttribute("3g.20gb"),
+		MIGComputeSlicesAttr: structs.NewIntAttribute(3, ""),
+		MIGMemoryAttr:        structs.NewIntAttribute(19968, structs.UnitMiB),
+		MIGParentModelAttr:   structs.NewStringAttribute("A100"),
+	}, group.Attributes)
+
+	_, err = NewMIGDeviceGroup("nvidia", "A100", "invalid", 19968, devices)
+	require.Error(t, err)
+}
+
+func TestDeviceGroup_Validate_MIG(t *testing.T) {
+	ci.Parallel(t)
+
+	group := &DeviceGroup{
+		Vendor:  "nvidia",
+		Type:    DeviceTypeMIG,
+		Name:    "1g.5gb",
+		Devices: []*Device{{ID: "MIG-1", Healthy: true}},
+	}
+	err := group.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), MIGProfileAttr)
+
+	group.Attributes = map[string]*structs.Attribute{
+		MIGProfileAttr: structs.NewIntAttribute(1, ""),
+	}
+	err = group.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must be a string")
+
+	group.Attributes[MIGProfileAttr] = structs.NewStringAttribute("1g")
+	err = group.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid MIG profile")
+
+	group.Attributes[MIGProfileAttr] = structs.NewStringAttribute("1g.5gb")
+	require.NoError(t, group.Validate())
+}
@@ -10,6 +10,7 @@ import (
 type FingerprintFn func(context.Context) (<-chan *FingerprintResponse, error)
 type ReserveFn func([]string) (*ContainerReservation, error)
 type StatsFn func(context.Context, time.Duration) (<-chan *StatsResponse, error)
+type ResetFn func([]string) error
 
 // MockDevicePlugin is used for testing.
 // Each function can be set as a closure to make assertions about how data
@@ -19,6 +20,10 @@ type MockDevicePlugin struct {
 	FingerprintF FingerprintFn
 	ReserveF     ReserveFn
 	StatsF       StatsFn
+
+	// ResetF is optional; if unset the plugin doesn't support resetting
+	// devices.
+	ResetF ResetFn
 }
 
 func (p *MockDevicePlugin) Fingerprint(ctx context.Context) (<-chan *FingerprintResponse, error) {
@@ -33,6 +38,13 @@ func (p *MockDevicePlugin) Stats(ctx context.Context, interval time.Duration) (<
 	return p.StatsF(ctx, interval)
 }
 
+func (p *MockDevicePlugin) Reset(devices []string) error {
+	if p.ResetF == nil {
+		return ErrResetNotSupported
+	}
+	return p.ResetF(devices)
+}
+
 // Below are static implementations of the device functions
 
 // StaticFingerprinter fingerprints the passed devices just once
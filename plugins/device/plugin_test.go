@@ -2,6 +2,7 @@ package device
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -464,6 +465,43 @@ func TestDevicePlugin_Reserve(t *testing.T) {
 	require.EqualValues(reservation, containerRes)
 }
 
+func TestDevicePlugin_Reset(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	var received []string
+	resetErr := errors.New("scrubbing failed")
+	mock := &MockDevicePlugin{
+		ResetF: func(devices []string) error {
+			received = devices
+			return resetErr
+		},
+	}
+
+	client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		base.PluginTypeBase:   &base.PluginBase{Impl: mock},
+		base.PluginTypeDevice: &PluginDevice{Impl: mock},
+	})
+	defer server.Stop()
+	defer client.Close()
+
+	raw, err := client.Dispense(base.PluginTypeDevice)
+	require.NoError(err)
+
+	impl, ok := raw.(DeviceResetter)
+	require.True(ok)
+
+	req := []string{"a", "b"}
+	err = impl.Reset(req)
+	require.Error(err)
+	require.Contains(err.Error(), resetErr.Error())
+	require.EqualValues(req, received)
+
+	// Plugins that don't support resetting devices return a sentinel error
+	mock.ResetF = nil
+	require.Equal(ErrResetNotSupported, impl.Reset(req))
+}
+
 func TestDevicePlugin_Stats(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -7,12 +7,12 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
-	duration "github.com/golang/protobuf/ptypes/duration"
-	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	proto1 "github.com/hashicorp/nomad/plugins/shared/structs/proto"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	math "math"
 )
 
@@ -380,6 +380,80 @@ func (m *ReserveResponse) GetContainerRes() *ContainerReservation {
 	return nil
 }
 
+// ResetRequest is used to ask the device driver to reset devices once
+// the task they were reserved for has exited.
+type ResetRequest struct {
+	// device_ids are the devices to reset.
+	DeviceIds            []string `protobuf:"bytes,1,rep,name=device_ids,json=deviceIds,proto3" json:"device_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResetRequest) Reset()         { *m = ResetRequest{} }
+func (m *ResetRequest) String() string { return proto.CompactTextString(m) }
+func (*ResetRequest) ProtoMessage()    {}
+func (*ResetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5edb0c35c07fa415, []int{7}
+}
+
+func (m *ResetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResetRequest.Unmarshal(m, b)
+}
+func (m *ResetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResetRequest.Marshal(b, m, deterministic)
+}
+func (m *ResetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResetRequest.Merge(m, src)
+}
+func (m *ResetRequest) XXX_Size() int {
+	return xxx_messageInfo_ResetRequest.Size(m)
+}
+func (m *ResetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResetRequest proto.InternalMessageInfo
+
+func (m *ResetRequest) GetDeviceIds() []string {
+	if m != nil {
+		return m.DeviceIds
+	}
+	return nil
+}
+
+// ResetResponse is returned once the devices have been reset.
+type ResetResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResetResponse) Reset()         { *m = ResetResponse{} }
+func (m *ResetResponse) String() string { return proto.CompactTextString(m) }
+func (*ResetResponse) ProtoMessage()    {}
+func (*ResetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5edb0c35c07fa415, []int{8}
+}
+
+func (m *ResetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResetResponse.Unmarshal(m, b)
+}
+func (m *ResetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResetResponse.Marshal(b, m, deterministic)
+}
+func (m *ResetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResetResponse.Merge(m, src)
+}
+func (m *ResetResponse) XXX_Size() int {
+	return xxx_messageInfo_ResetResponse.Size(m)
+}
+func (m *ResetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResetResponse proto.InternalMessageInfo
+
 // ContainerReservation returns how to mount the device into a
 // container that shares the host OS.
 type ContainerReservation struct {
@@ -398,7 +472,7 @@ func (m *ContainerReservation) Reset()         { *m = ContainerReservation{} }
 func (m *ContainerReservation) String() string { return proto.CompactTextString(m) }
 func (*ContainerReservation) ProtoMessage()    {}
 func (*ContainerReservation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5edb0c35c07fa415, []int{7}
+	return fileDescriptor_5edb0c35c07fa415, []int{9}
 }
 
 func (m *ContainerReservation) XXX_Unmarshal(b []byte) error {
@@ -458,7 +532,7 @@ func (m *Mount) Reset()         { *m = Mount{} }
 func (m *Mount) String() string { return proto.CompactTextString(m) }
 func (*Mount) ProtoMessage()    {}
 func (*Mount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5edb0c35c07fa415, []int{8}
+	return fileDescriptor_5edb0c35c07fa415, []int{10}
 }
 
 func (m *Mount) XXX_Unmarshal(b []byte) error {
@@ -520,7 +594,7 @@ func (m *DeviceSpec) Reset()         { *m = DeviceSpec{} }
 func (m *DeviceSpec) String() string { return proto.CompactTextString(m) }
 func (*DeviceSpec) ProtoMessage()    {}
 func (*DeviceSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5edb0c35c07fa415, []int{9}
+	return fileDescriptor_5edb0c35c07fa415, []int{11}
 }
 
 func (m *DeviceSpec) XXX_Unmarshal(b []byte) error {
@@ -565,17 +639,17 @@ func (m *DeviceSpec) GetPermissions() string {
 // StatsRequest is used to parameterize the retrieval of statistics.
 type StatsRequest struct {
 	// collection_interval is the duration in which to collect statistics.
-	CollectionInterval   *duration.Duration `protobuf:"bytes,1,opt,name=collection_interval,json=collectionInterval,proto3" json:"collection_interval,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	CollectionInterval   *durationpb.Duration `protobuf:"bytes,1,opt,name=collection_interval,json=collectionInterval,proto3" json:"collection_interval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *StatsRequest) Reset()         { *m = StatsRequest{} }
 func (m *StatsRequest) String() string { return proto.CompactTextString(m) }
 func (*StatsRequest) ProtoMessage()    {}
 func (*StatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5edb0c35c07fa415, []int{10}
+	return fileDescriptor_5edb0c35c07fa415, []int{12}
 }
 
 func (m *StatsRequest) XXX_Unmarshal(b []byte) error {
@@ -596,7 +670,7 @@ func (m *StatsRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StatsRequest proto.InternalMessageInfo
 
-func (m *StatsRequest) GetCollectionInterval() *duration.Duration {
+func (m *StatsRequest) GetCollectionInterval() *durationpb.Duration {
 	if m != nil {
 		return m.CollectionInterval
 	}
@@ -616,7 +690,7 @@ func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
 func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
 func (*StatsResponse) ProtoMessage()    {}
 func (*StatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5edb0c35c07fa415, []int{11}
+	return fileDescriptor_5edb0c35c07fa415, []int{13}
 }
 
 func (m *StatsResponse) XXX_Unmarshal(b []byte) error {
@@ -661,7 +735,7 @@ func (m *DeviceGroupStats) Reset()         { *m = DeviceGroupStats{} }
 func (m *DeviceGroupStats) String() string { return proto.CompactTextString(m) }
 func (*DeviceGroupStats) ProtoMessage()    {}
 func (*DeviceGroupStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5edb0c35c07fa415, []int{12}
+	return fileDescriptor_5edb0c35c07fa415, []int{14}
 }
 
 func (m *DeviceGroupStats) XXX_Unmarshal(b []byte) error {
@@ -718,17 +792,17 @@ type DeviceStats struct {
 	// stats contains the verbose statistics for the device.
 	Stats *proto1.StatObject `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
 	// timestamp is the time the statistics were collected.
-	Timestamp            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Timestamp            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
 func (m *DeviceStats) Reset()         { *m = DeviceStats{} }
 func (m *DeviceStats) String() string { return proto.CompactTextString(m) }
 func (*DeviceStats) ProtoMessage()    {}
 func (*DeviceStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5edb0c35c07fa415, []int{13}
+	return fileDescriptor_5edb0c35c07fa415, []int{15}
 }
 
 func (m *DeviceStats) XXX_Unmarshal(b []byte) error {
@@ -763,7 +837,7 @@ func (m *DeviceStats) GetStats() *proto1.StatObject {
 	return nil
 }
 
-func (m *DeviceStats) GetTimestamp() *timestamp.Timestamp {
+func (m *DeviceStats) GetTimestamp() *timestamppb.Timestamp {
 	if m != nil {
 		return m.Timestamp
 	}
@@ -779,6 +853,8 @@ func init() {
 	proto.RegisterType((*DeviceLocality)(nil), "hashicorp.nomad.plugins.device.DeviceLocality")
 	proto.RegisterType((*ReserveRequest)(nil), "hashicorp.nomad.plugins.device.ReserveRequest")
 	proto.RegisterType((*ReserveResponse)(nil), "hashicorp.nomad.plugins.device.ReserveResponse")
+	proto.RegisterType((*ResetRequest)(nil), "hashicorp.nomad.plugins.device.ResetRequest")
+	proto.RegisterType((*ResetResponse)(nil), "hashicorp.nomad.plugins.device.ResetResponse")
 	proto.RegisterType((*ContainerReservation)(nil), "hashicorp.nomad.plugins.device.ContainerReservation")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.device.ContainerReservation.EnvsEntry")
 	proto.RegisterType((*Mount)(nil), "hashicorp.nomad.plugins.device.Mount")
@@ -795,68 +871,69 @@ func init() {
 }
 
 var fileDescriptor_5edb0c35c07fa415 = []byte{
-	// 965 bytes of a gzipped FileDescriptorProto
+	// 992 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xef, 0x8e, 0xdb, 0x44,
-	0x10, 0x27, 0xc9, 0xe5, 0x92, 0x4c, 0xee, 0xae, 0x65, 0x7b, 0x42, 0xc6, 0x40, 0x7b, 0x58, 0x42,
-	0x3a, 0x41, 0xeb, 0x94, 0x14, 0x89, 0x0a, 0x04, 0x52, 0xdb, 0x94, 0x5e, 0xf8, 0xd3, 0xab, 0xb6,
-	0x15, 0x52, 0x8b, 0x84, 0xb5, 0x67, 0x2f, 0xf1, 0xb6, 0xf6, 0xda, 0xec, 0xae, 0x53, 0x99, 0x4f,
-	0x3c, 0x0e, 0x5f, 0x78, 0x01, 0x1e, 0x86, 0x0f, 0x3c, 0x09, 0xf2, 0xee, 0x3a, 0xf1, 0xfd, 0xe9,
-	0x25, 0x81, 0x4f, 0xde, 0x9d, 0x99, 0xdf, 0xcc, 0xec, 0xcc, 0x6f, 0x67, 0x0d, 0x1f, 0xe6, 0x49,
-	0x31, 0x63, 0x5c, 0x8e, 0x22, 0x3a, 0x67, 0x21, 0x1d, 0xe5, 0x22, 0x53, 0x99, 0xdd, 0xf8, 0x7a,
-	0x83, 0xae, 0xc7, 0x44, 0xc6, 0x2c, 0xcc, 0x44, 0xee, 0xf3, 0x2c, 0x25, 0x91, 0x6f, 0x21, 0xbe,
-	0xb1, 0x72, 0x6f, 0xcc, 0xb2, 0x6c, 0x96, 0x58, 0xe8, 0x49, 0xf1, 0xcb, 0x48, 0xb1, 0x94, 0x4a,
-	0x45, 0xd2, 0xdc, 0x38, 0x70, 0xaf, 0x9f, 0x35, 0x88, 0x0a, 0x41, 0x14, 0xcb, 0xb8, 0xd5, 0xdf,
-	0xac, 0x73, 0x90, 0x31, 0x11, 0x34, 0x1a, 0x49, 0x25, 0x8a, 0x50, 0x49, 0x9b, 0x0b, 0x51, 0x4a,
-	0xb0, 0x93, 0x42, 0xd9, 0x74, 0xdc, 0xc3, 0x4b, 0xad, 0xa5, 0x22, 0x4a, 0x1a, 0x4b, 0x6f, 0x1f,
-	0xd0, 0x37, 0x8c, 0xcf, 0xa8, 0xc8, 0x05, 0xe3, 0x0a, 0xd3, 0x5f, 0x0b, 0x2a, 0x95, 0x47, 0xe1,
-	0xda, 0x29, 0xa9, 0xcc, 0x33, 0x2e, 0x29, 0x7a, 0x0c, 0x3b, 0xe6, 0x3c, 0xc1, 0x4c, 0x64, 0x45,
-	0xee, 0xb4, 0x0e, 0x3a, 0x87, 0xc3, 0xf1, 0x27, 0xfe, 0xe5, 0x87, 0xf7, 0x27, 0xfa, 0xf3, 0xa8,
-	0x82, 0xe0, 0x61, 0xb4, 0xdc, 0x78, 0xbf, 0x77, 0x60, 0xd8, 0x50, 0xa2, 0x77, 0x60, 0x7b, 0x4e,
-	0x79, 0x94, 0x09, 0xa7, 0x75, 0xd0, 0x3a, 0x1c, 0x60, 0xbb, 0x43, 0x37, 0xc0, 0xc2, 0x02, 0x55,
-	0xe6, 0xd4, 0x69, 0x6b, 0x25, 0x18, 0xd1, 0xb3, 0x32, 0xa7, 0x0d, 0x03, 0x4e, 0x52, 0xea, 0x74,
-	0x9a, 0x06, 0x8f, 0x49, 0x4a, 0xd1, 0x11, 0xf4, 0xcc, 0x4e, 0x3a, 0x5b, 0x3a, 0x69, 0x7f, 0x75,
-	0xd2, 0x8a, 0x86, 0x8a, 0x46, 0x26, 0x3f, 0x5c, 0xc3, 0xd1, 0x4f, 0x00, 0x8b, 0x6a, 0x4b, 0xa7,
-	0xab, 0x9d, 0x7d, 0xb9, 0x41, 0x05, 0xfc, 0x7b, 0x0b, 0xf4, 0x43, 0xae, 0x44, 0x89, 0x1b, 0xee,
-	0xdc, 0x1c, 0xae, 0x9c, 0x51, 0xa3, 0xab, 0xd0, 0x79, 0x45, 0x4b, 0x5b, 0x90, 0x6a, 0x89, 0x1e,
-	0x41, 0x77, 0x4e, 0x92, 0xc2, 0xd4, 0x61, 0x38, 0xfe, 0xf4, 0x8d, 0xc1, 0x4d, 0xf3, 0x7d, 0xdb,
-	0xfc, 0x65, 0x60, 0x6c, 0xf0, 0x5f, 0xb4, 0xef, 0xb6, 0xbc, 0xbf, 0x5a, 0xb0, 0x77, 0xfa, 0xa8,
-	0x68, 0x0f, 0xda, 0xd3, 0x89, 0x0d, 0xd8, 0x9e, 0x4e, 0x90, 0x03, 0xbd, 0x98, 0x92, 0x44, 0xc5,
-	0xa5, 0x8e, 0xd8, 0xc7, 0xf5, 0x16, 0xdd, 0x02, 0x64, 0x96, 0x41, 0x44, 0x65, 0x28, 0x58, 0x5e,
-	0x11, 0xd6, 0x56, 0xff, 0x6d, 0xa3, 0x99, 0x2c, 0x15, 0xe8, 0x18, 0x86, 0xf1, 0xeb, 0x20, 0xc9,
-	0x42, 0x92, 0x30, 0x55, 0x3a, 0x5b, 0x3a, 0x7d, 0x7f, 0xbd, 0xda, 0x7d, 0x6f, 0x51, 0x18, 0xe2,
-	0xd7, 0xf5, 0xda, 0xf3, 0xab, 0xdc, 0x9b, 0x5a, 0xf4, 0x3e, 0x40, 0x1e, 0xb2, 0xe0, 0xa4, 0x90,
-	0x01, 0x8b, 0xec, 0x19, 0xfa, 0x79, 0xc8, 0xee, 0x17, 0x72, 0x1a, 0x79, 0x23, 0xd8, 0xc3, 0x54,
-	0x52, 0x31, 0xa7, 0x96, 0xe8, 0xe8, 0x03, 0xb0, 0x2c, 0x09, 0x58, 0x24, 0x35, 0x9f, 0x07, 0x78,
-	0x60, 0x24, 0xd3, 0x48, 0x7a, 0x09, 0x5c, 0x59, 0x00, 0xec, 0x1d, 0x78, 0x0e, 0xbb, 0x61, 0xc6,
-	0x15, 0x61, 0x9c, 0x8a, 0x40, 0x50, 0xa9, 0x83, 0x0c, 0xc7, 0x9f, 0xad, 0x3a, 0xc6, 0x83, 0x1a,
-	0x64, 0x1c, 0xea, 0xbb, 0x8d, 0x77, 0xc2, 0x86, 0xd4, 0xfb, 0xa3, 0x0d, 0xfb, 0x17, 0x99, 0x21,
-	0x0c, 0x5b, 0x94, 0xcf, 0xa5, 0xbd, 0x6f, 0x5f, 0xff, 0x97, 0x50, 0xfe, 0x43, 0x3e, 0xb7, 0x84,
-	0xd3, 0xbe, 0xd0, 0x57, 0xb0, 0x9d, 0x66, 0x05, 0x57, 0xd2, 0x69, 0x6b, 0xaf, 0x1f, 0xad, 0xf2,
-	0xfa, 0x43, 0x65, 0x8d, 0x2d, 0x08, 0x4d, 0x96, 0x17, 0xaa, 0xa3, 0xf1, 0x1f, 0xaf, 0xd7, 0xc7,
-	0xa7, 0x39, 0x0d, 0x17, 0x97, 0xc9, 0xfd, 0x1c, 0x06, 0x8b, 0xbc, 0x2e, 0x60, 0xfa, 0x7e, 0x93,
-	0xe9, 0x83, 0x26, 0x6d, 0x7f, 0x86, 0xae, 0xce, 0x07, 0xbd, 0x07, 0x03, 0x45, 0xe4, 0xab, 0x20,
-	0x27, 0x2a, 0xae, 0xfb, 0x5d, 0x09, 0x9e, 0x10, 0x15, 0x57, 0xca, 0x38, 0x93, 0xca, 0x28, 0x8d,
-	0x8f, 0x7e, 0x25, 0xa8, 0x95, 0x82, 0x92, 0x28, 0xc8, 0x78, 0x52, 0x6a, 0xce, 0xf6, 0x71, 0xbf,
-	0x12, 0x1c, 0xf3, 0xa4, 0xf4, 0x62, 0x80, 0x65, 0xbe, 0xff, 0x23, 0xc8, 0x01, 0x0c, 0x73, 0x2a,
-	0x52, 0x26, 0x25, 0xcb, 0xb8, 0xb4, 0x57, 0xa3, 0x29, 0xf2, 0x5e, 0xc0, 0xce, 0xd3, 0x6a, 0x1e,
-	0xd7, 0x8c, 0xfc, 0x16, 0xae, 0x85, 0x59, 0x92, 0xd0, 0xb0, 0xea, 0x5a, 0xc0, 0xb8, 0xaa, 0x3a,
-	0x98, 0x58, 0x96, 0xbd, 0xeb, 0x9b, 0x67, 0xc2, 0xaf, 0x9f, 0x09, 0x7f, 0x62, 0x9f, 0x09, 0x8c,
-	0x96, 0xa8, 0xa9, 0x05, 0x79, 0xcf, 0x61, 0xd7, 0xfa, 0xb6, 0xe4, 0x3d, 0x82, 0x6d, 0x3d, 0xb9,
-	0x6b, 0x2a, 0xdd, 0xde, 0x60, 0x70, 0x19, 0x4f, 0x16, 0xef, 0xfd, 0xd9, 0x86, 0xab, 0x67, 0x95,
-	0x6f, 0x9c, 0xdf, 0x08, 0xb6, 0x1a, 0x83, 0x5b, 0xaf, 0x2b, 0x59, 0x63, 0x56, 0xeb, 0x35, 0x7a,
-	0x09, 0x7b, 0x8c, 0x4b, 0x45, 0x78, 0x48, 0x03, 0xfd, 0x48, 0xd9, 0x61, 0xfd, 0x60, 0xd3, 0x34,
-	0xfd, 0xa9, 0x75, 0xa3, 0x77, 0x86, 0xf6, 0xbb, 0xac, 0x29, 0x73, 0x53, 0x40, 0xe7, 0x8d, 0x2e,
-	0xe0, 0xe0, 0xbd, 0xd3, 0xd3, 0x76, 0xcd, 0xc7, 0xce, 0x14, 0xab, 0x41, 0xd8, 0xbf, 0x5b, 0xf5,
-	0x53, 0x67, 0x4a, 0xf5, 0x1d, 0xf4, 0x64, 0x91, 0xa6, 0x44, 0x94, 0xb6, 0xb5, 0x6b, 0x8f, 0xf1,
-	0x0a, 0xff, 0x63, 0xe5, 0x17, 0xd7, 0x1e, 0xd0, 0x11, 0x74, 0x4d, 0xb9, 0x4c, 0x8e, 0xe3, 0x4d,
-	0x5c, 0x1d, 0x9f, 0xbc, 0xa4, 0xa1, 0xc2, 0xc6, 0x01, 0xba, 0x0b, 0x83, 0xc5, 0x9f, 0x89, 0x6e,
-	0xcd, 0x70, 0xec, 0x9e, 0xe3, 0xdc, 0xb3, 0xda, 0x02, 0x2f, 0x8d, 0xc7, 0xff, 0xb4, 0x61, 0xc7,
-	0x1c, 0xf0, 0x89, 0x0e, 0x86, 0x7e, 0x83, 0x61, 0xe3, 0x1f, 0x02, 0x8d, 0x57, 0x15, 0xee, 0xfc,
-	0x6f, 0x88, 0x7b, 0x67, 0x23, 0x8c, 0xe1, 0xb8, 0xf7, 0xd6, 0xed, 0x16, 0x4a, 0xa0, 0x67, 0xe7,
-	0x36, 0x5a, 0xf9, 0xbe, 0x9c, 0x7e, 0x11, 0xdc, 0xd1, 0xda, 0xf6, 0x75, 0x3c, 0x14, 0x43, 0xd7,
-	0x34, 0xf5, 0xe6, 0x2a, 0x6c, 0xf3, 0xa6, 0xbb, 0xb7, 0xd6, 0xb4, 0x5e, 0x9e, 0xeb, 0x7e, 0xef,
-	0x45, 0xd7, 0x74, 0x61, 0x5b, 0x7f, 0xee, 0xfc, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x11, 0xd4, 0x56,
-	0x04, 0x9b, 0x0a, 0x00, 0x00,
+	0x10, 0x27, 0xc9, 0xe5, 0x92, 0x8c, 0x73, 0xb9, 0xb2, 0x3d, 0x21, 0x63, 0xa0, 0x3d, 0x2c, 0x21,
+	0x9d, 0xa0, 0xe7, 0x94, 0x14, 0x89, 0x0a, 0x04, 0x52, 0xdb, 0x94, 0x5e, 0xf8, 0xd3, 0xab, 0xdc,
+	0x0a, 0xa9, 0x45, 0xc2, 0xda, 0xb3, 0xb7, 0xf1, 0xb6, 0xf6, 0xda, 0xec, 0xae, 0x53, 0x85, 0x4f,
+	0x3c, 0x0e, 0x5f, 0x78, 0x01, 0x1e, 0x81, 0x87, 0xe0, 0x59, 0x90, 0x77, 0xd7, 0x89, 0x73, 0x77,
+	0x6d, 0x12, 0xfa, 0xc9, 0xbb, 0x33, 0xf3, 0x9b, 0x99, 0xdd, 0xfd, 0xcd, 0x8c, 0xe1, 0xe3, 0x3c,
+	0x29, 0xa6, 0x94, 0x89, 0x61, 0x44, 0x66, 0x34, 0x24, 0xc3, 0x9c, 0x67, 0x32, 0x33, 0x1b, 0x4f,
+	0x6d, 0xd0, 0xb5, 0x18, 0x8b, 0x98, 0x86, 0x19, 0xcf, 0x3d, 0x96, 0xa5, 0x38, 0xf2, 0x0c, 0xc4,
+	0xd3, 0x56, 0xce, 0xf5, 0x69, 0x96, 0x4d, 0x13, 0x03, 0x3d, 0x2b, 0x9e, 0x0f, 0x25, 0x4d, 0x89,
+	0x90, 0x38, 0xcd, 0xb5, 0x03, 0xe7, 0xda, 0x79, 0x83, 0xa8, 0xe0, 0x58, 0xd2, 0x8c, 0x19, 0xfd,
+	0x8d, 0x2a, 0x07, 0x11, 0x63, 0x4e, 0xa2, 0xa1, 0x90, 0xbc, 0x08, 0xa5, 0x30, 0xb9, 0x60, 0x29,
+	0x39, 0x3d, 0x2b, 0xa4, 0x49, 0xc7, 0x39, 0x7a, 0xa3, 0xb5, 0x90, 0x58, 0x0a, 0x6d, 0xe9, 0x1e,
+	0x00, 0xfa, 0x8e, 0xb2, 0x29, 0xe1, 0x39, 0xa7, 0x4c, 0xfa, 0xe4, 0xb7, 0x82, 0x08, 0xe9, 0x12,
+	0xb8, 0xba, 0x22, 0x15, 0x79, 0xc6, 0x04, 0x41, 0x0f, 0xa1, 0xaf, 0xcf, 0x13, 0x4c, 0x79, 0x56,
+	0xe4, 0x76, 0xe3, 0xb0, 0x75, 0x64, 0x8d, 0x3e, 0xf3, 0xde, 0x7c, 0x78, 0x6f, 0xac, 0x3e, 0x0f,
+	0x4a, 0x88, 0x6f, 0x45, 0xcb, 0x8d, 0xfb, 0x47, 0x0b, 0xac, 0x9a, 0x12, 0xbd, 0x07, 0xbb, 0x33,
+	0xc2, 0xa2, 0x8c, 0xdb, 0x8d, 0xc3, 0xc6, 0x51, 0xcf, 0x37, 0x3b, 0x74, 0x1d, 0x0c, 0x2c, 0x90,
+	0xf3, 0x9c, 0xd8, 0x4d, 0xa5, 0x04, 0x2d, 0x7a, 0x32, 0xcf, 0x49, 0xcd, 0x80, 0xe1, 0x94, 0xd8,
+	0xad, 0xba, 0xc1, 0x43, 0x9c, 0x12, 0x74, 0x02, 0x1d, 0xbd, 0x13, 0xf6, 0x8e, 0x4a, 0xda, 0x5b,
+	0x9f, 0xb4, 0x24, 0xa1, 0x24, 0x91, 0xce, 0xcf, 0xaf, 0xe0, 0xe8, 0x17, 0x80, 0xc5, 0x6d, 0x0b,
+	0xbb, 0xad, 0x9c, 0x7d, 0xbd, 0xc5, 0x0d, 0x78, 0x77, 0x16, 0xe8, 0xfb, 0x4c, 0xf2, 0xb9, 0x5f,
+	0x73, 0xe7, 0xe4, 0xb0, 0x7f, 0x4e, 0x8d, 0xae, 0x40, 0xeb, 0x25, 0x99, 0x9b, 0x0b, 0x29, 0x97,
+	0xe8, 0x01, 0xb4, 0x67, 0x38, 0x29, 0xf4, 0x3d, 0x58, 0xa3, 0xcf, 0x5f, 0x1b, 0x5c, 0x3f, 0xbe,
+	0x67, 0x1e, 0x7f, 0x19, 0xd8, 0xd7, 0xf8, 0xaf, 0x9a, 0xb7, 0x1b, 0xee, 0xdf, 0x0d, 0x18, 0xac,
+	0x1e, 0x15, 0x0d, 0xa0, 0x39, 0x19, 0x9b, 0x80, 0xcd, 0xc9, 0x18, 0xd9, 0xd0, 0x89, 0x09, 0x4e,
+	0x64, 0x3c, 0x57, 0x11, 0xbb, 0x7e, 0xb5, 0x45, 0xc7, 0x80, 0xf4, 0x32, 0x88, 0x88, 0x08, 0x39,
+	0xcd, 0x4b, 0xc2, 0x9a, 0xdb, 0x7f, 0x57, 0x6b, 0xc6, 0x4b, 0x05, 0x3a, 0x05, 0x2b, 0x7e, 0x15,
+	0x24, 0x59, 0x88, 0x13, 0x2a, 0xe7, 0xf6, 0xce, 0x61, 0x63, 0xb3, 0x87, 0x28, 0x3f, 0x3f, 0x1a,
+	0x94, 0x0f, 0xf1, 0xab, 0x6a, 0xed, 0x7a, 0x30, 0x58, 0xd5, 0xa2, 0x0f, 0x01, 0xf2, 0x90, 0x06,
+	0x67, 0x85, 0x08, 0x68, 0x64, 0xce, 0xd0, 0xcd, 0x43, 0x7a, 0xb7, 0x10, 0x93, 0xc8, 0x1d, 0xc2,
+	0xc0, 0x27, 0x82, 0xf0, 0x19, 0x31, 0x44, 0x47, 0x1f, 0x81, 0x61, 0x49, 0x40, 0x23, 0xa1, 0xf8,
+	0xdc, 0xf3, 0x7b, 0x5a, 0x32, 0x89, 0x84, 0x9b, 0xc0, 0xfe, 0x02, 0x60, 0x6a, 0xe0, 0x29, 0xec,
+	0x85, 0x19, 0x93, 0x98, 0x32, 0xc2, 0x03, 0x4e, 0x84, 0x0a, 0x62, 0x8d, 0xbe, 0x58, 0x77, 0x8c,
+	0x7b, 0x15, 0x48, 0x3b, 0x54, 0xb5, 0xed, 0xf7, 0xc3, 0x9a, 0xd4, 0x3d, 0x86, 0x7e, 0xa9, 0x94,
+	0x1b, 0x26, 0xb7, 0x0f, 0x7b, 0xc6, 0x5c, 0xa7, 0xe6, 0xfe, 0xd9, 0x84, 0x83, 0xcb, 0xc2, 0x20,
+	0x1f, 0x76, 0x08, 0x9b, 0x09, 0x53, 0xaf, 0xdf, 0xfe, 0x9f, 0x54, 0xbd, 0xfb, 0x6c, 0x66, 0x08,
+	0xab, 0x7c, 0xa1, 0x6f, 0x60, 0x37, 0xcd, 0x0a, 0x26, 0x85, 0xdd, 0x54, 0x5e, 0x3f, 0x59, 0xe7,
+	0xf5, 0xa7, 0xd2, 0xda, 0x37, 0x20, 0x34, 0x5e, 0x16, 0x64, 0x4b, 0xe1, 0x3f, 0xdd, 0x8c, 0x07,
+	0x8f, 0x73, 0x12, 0x2e, 0x8a, 0xd1, 0xf9, 0x12, 0x7a, 0x8b, 0xbc, 0x2e, 0xa9, 0x94, 0x83, 0x7a,
+	0xa5, 0xf4, 0xea, 0xb4, 0xff, 0x15, 0xda, 0x2a, 0x1f, 0xf4, 0x01, 0xf4, 0x24, 0x16, 0x2f, 0x83,
+	0x1c, 0xcb, 0xb8, 0xe2, 0x4b, 0x29, 0x78, 0x84, 0x65, 0x5c, 0x2a, 0xe3, 0x4c, 0x48, 0xad, 0xd4,
+	0x3e, 0xba, 0xa5, 0xa0, 0x52, 0x72, 0x82, 0xa3, 0x20, 0x63, 0xc9, 0x5c, 0x71, 0xbe, 0xeb, 0x77,
+	0x4b, 0xc1, 0x29, 0x4b, 0xe6, 0x6e, 0x0c, 0xb0, 0xcc, 0xf7, 0x2d, 0x82, 0x1c, 0x82, 0x95, 0x13,
+	0x9e, 0x52, 0x21, 0x68, 0xc6, 0x84, 0x29, 0xad, 0xba, 0xc8, 0x7d, 0x06, 0xfd, 0xc7, 0x65, 0x3f,
+	0xaf, 0x48, 0xf3, 0x3d, 0x5c, 0x0d, 0xb3, 0x24, 0x21, 0x61, 0xf9, 0x6a, 0x01, 0x65, 0xb2, 0x7c,
+	0xc1, 0xc4, 0xb0, 0xf4, 0x7d, 0x4f, 0x8f, 0x19, 0xaf, 0x1a, 0x33, 0xde, 0xd8, 0x8c, 0x19, 0x1f,
+	0x2d, 0x51, 0x13, 0x03, 0x72, 0x9f, 0xc2, 0x9e, 0xf1, 0x6d, 0xc8, 0x7f, 0x02, 0xbb, 0xaa, 0xf3,
+	0x57, 0x54, 0xba, 0xb9, 0x45, 0xe3, 0xd3, 0x9e, 0x0c, 0xde, 0xfd, 0xab, 0x09, 0x57, 0xce, 0x2b,
+	0x5f, 0xdb, 0xff, 0x11, 0xec, 0xd4, 0x1a, 0xbf, 0x5a, 0x97, 0xb2, 0x5a, 0xaf, 0x57, 0x6b, 0xf4,
+	0x02, 0x06, 0x94, 0x09, 0x89, 0x59, 0x48, 0x02, 0x35, 0xe4, 0x4c, 0xb3, 0xbf, 0xb7, 0x6d, 0x9a,
+	0xde, 0xc4, 0xb8, 0x51, 0x3b, 0x4d, 0xfb, 0x3d, 0x5a, 0x97, 0x39, 0x29, 0xa0, 0x8b, 0x46, 0x97,
+	0x70, 0xf0, 0xce, 0x6a, 0xb7, 0xde, 0x70, 0x58, 0xea, 0xcb, 0xaa, 0x11, 0xf6, 0xdf, 0x06, 0x58,
+	0x35, 0x15, 0xfa, 0x01, 0x3a, 0xa2, 0x48, 0x53, 0xcc, 0xe7, 0x76, 0x63, 0xbb, 0x31, 0x50, 0xe2,
+	0x7f, 0x2e, 0xfd, 0xfa, 0x95, 0x07, 0x74, 0x02, 0x6d, 0x7d, 0x5d, 0x3a, 0xc7, 0xd1, 0x36, 0xae,
+	0x4e, 0xcf, 0x5e, 0x90, 0x50, 0xfa, 0xda, 0x01, 0xba, 0x0d, 0xbd, 0xc5, 0x9f, 0x8d, 0x7a, 0x1a,
+	0x6b, 0xe4, 0x5c, 0xe0, 0xdc, 0x93, 0xca, 0xc2, 0x5f, 0x1a, 0x8f, 0xfe, 0x69, 0x41, 0x5f, 0x1f,
+	0xf0, 0x91, 0x0a, 0x86, 0x7e, 0x07, 0xab, 0xf6, 0x0f, 0x82, 0x46, 0xeb, 0x2e, 0xee, 0xe2, 0x6f,
+	0x8c, 0x73, 0x6b, 0x2b, 0x8c, 0xe9, 0xa2, 0xef, 0xdc, 0x6c, 0xa0, 0x04, 0x3a, 0xa6, 0xef, 0xa3,
+	0xb5, 0xf3, 0x69, 0x75, 0xa2, 0x38, 0xc3, 0x8d, 0xed, 0xab, 0x78, 0x28, 0x86, 0xb6, 0x7e, 0xd4,
+	0x1b, 0xeb, 0xb0, 0xf5, 0x4a, 0x77, 0x8e, 0x37, 0xb4, 0xae, 0x9d, 0xeb, 0x39, 0xb4, 0xcb, 0xf0,
+	0x72, 0x7d, 0xa4, 0xfa, 0x20, 0x72, 0x8e, 0x37, 0xb4, 0xae, 0x22, 0xdd, 0xed, 0x3c, 0x6b, 0xeb,
+	0xd7, 0xde, 0x55, 0x9f, 0x5b, 0xff, 0x0d, 0x00, 0x36, 0xbf, 0x2f, 0xb6, 0x43, 0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -882,6 +959,11 @@ type DevicePluginClient interface {
 	Reserve(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*ReserveResponse, error)
 	// Stats returns a stream of device statistics.
 	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (DevicePlugin_StatsClient, error)
+	// Reset is called by the client once the task the devices were
+	// reserved for has exited, so the plugin can reset or scrub them
+	// before they are reserved for another allocation. Plugins that
+	// don't support resetting devices return an Unimplemented error.
+	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*ResetResponse, error)
 }
 
 type devicePluginClient struct {
@@ -965,6 +1047,15 @@ func (x *devicePluginStatsClient) Recv() (*StatsResponse, error) {
 	return m, nil
 }
 
+func (c *devicePluginClient) Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*ResetResponse, error) {
+	out := new(ResetResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.device.DevicePlugin/Reset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DevicePluginServer is the server API for DevicePlugin service.
 type DevicePluginServer interface {
 	// Fingerprint allows the device plugin to return a set of
@@ -978,6 +1069,11 @@ type DevicePluginServer interface {
 	Reserve(context.Context, *ReserveRequest) (*ReserveResponse, error)
 	// Stats returns a stream of device statistics.
 	Stats(*StatsRequest, DevicePlugin_StatsServer) error
+	// Reset is called by the client once the task the devices were
+	// reserved for has exited, so the plugin can reset or scrub them
+	// before they are reserved for another allocation. Plugins that
+	// don't support resetting devices return an Unimplemented error.
+	Reset(context.Context, *ResetRequest) (*ResetResponse, error)
 }
 
 // UnimplementedDevicePluginServer can be embedded to have forward compatible implementations.
@@ -993,6 +1089,9 @@ func (*UnimplementedDevicePluginServer) Reserve(ctx context.Context, req *Reserv
 func (*UnimplementedDevicePluginServer) Stats(req *StatsRequest, srv DevicePlugin_StatsServer) error {
 	return status.Errorf(codes.Unimplemented, "method Stats not implemented")
 }
+func (*UnimplementedDevicePluginServer) Reset(ctx context.Context, req *ResetRequest) (*ResetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
+}
 
 func RegisterDevicePluginServer(s *grpc.Server, srv DevicePluginServer) {
 	s.RegisterService(&_DevicePlugin_serviceDesc, srv)
@@ -1058,6 +1157,24 @@ func (x *devicePluginStatsServer) Send(m *StatsResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _DevicePlugin_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DevicePluginServer).Reset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.device.DevicePlugin/Reset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DevicePluginServer).Reset(ctx, req.(*ResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DevicePlugin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.plugins.device.DevicePlugin",
 	HandlerType: (*DevicePluginServer)(nil),
@@ -1066,6 +1183,10 @@ var _DevicePlugin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Reserve",
 			Handler:    _DevicePlugin_Reserve_Handler,
 		},
+		{
+			MethodName: "Reset",
+			Handler:    _DevicePlugin_Reset_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
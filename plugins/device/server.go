@@ -8,6 +8,8 @@ import (
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/plugins/device/proto"
 	context "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // devicePluginServer wraps a device plugin and exposes it via gRPC.
@@ -117,3 +119,18 @@ func (d *devicePluginServer) Stats(req *proto.StatsRequest, stream proto.DeviceP
 		}
 	}
 }
+
+func (d *devicePluginServer) Reset(ctx context.Context, req *proto.ResetRequest) (*proto.ResetResponse, error) {
+	impl, ok := d.impl.(DeviceResetter)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, ErrResetNotSupported.Error())
+	}
+
+	if err := impl.Reset(req.GetDeviceIds()); err == ErrResetNotSupported {
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &proto.ResetResponse{}, nil
+}
@@ -74,6 +74,7 @@ func (d *driverPluginClient) Capabilities() (*Capabilities, error) {
 
 		caps.MountConfigs = MountConfigSupport(resp.Capabilities.MountConfigs)
 		caps.RemoteTasks = resp.Capabilities.RemoteTasks
+		caps.Stdin = resp.Capabilities.Stdin
 	}
 
 	return caps, nil
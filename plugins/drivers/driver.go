@@ -171,6 +171,11 @@ type Capabilities struct {
 	// adjust behavior such as propogating task handles between allocations
 	// to avoid downtime when a client is lost.
 	RemoteTasks bool
+
+	// Stdin indicates this driver connects the stdin of tasks to the fifo at
+	// TaskConfig.StdinPath, such that tasks can read their dispatch payload
+	// from stdin.
+	Stdin bool
 }
 
 func (c *Capabilities) HasNetIsolationMode(m NetIsolationMode) bool {
@@ -281,6 +286,7 @@ type TaskConfig struct {
 	rawDriverConfig  []byte
 	StdoutPath       string
 	StderrPath       string
+	StdinPath        string
 	AllocID          string
 	NetworkIsolation *NetworkIsolationSpec
 	DNS              *DNSConfig
@@ -407,6 +413,12 @@ type LinuxResources struct {
 	// specific options are deprecated in favor of exposes CPUPeriod and
 	// CPUQuota at the task resource stanza.
 	PercentTicks float64
+
+	// DiskIOPS and DiskBandwidthBytes limit the read and write operations
+	// and bytes per second of the task on the disk holding its allocation
+	// directory.
+	DiskIOPS           int64
+	DiskBandwidthBytes int64
 }
 
 func (r *LinuxResources) Copy() *LinuxResources {
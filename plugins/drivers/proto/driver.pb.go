@@ -1848,7 +1848,10 @@ type DriverCapabilities struct {
 	MountConfigs DriverCapabilities_MountConfigs `protobuf:"varint,6,opt,name=mount_configs,json=mountConfigs,proto3,enum=hashicorp.nomad.plugins.drivers.proto.DriverCapabilities_MountConfigs" json:"mount_configs,omitempty"`
 	// remote_tasks indicates whether the driver executes tasks remotely such
 	// on cloud runtimes like AWS ECS.
-	RemoteTasks          bool     `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	RemoteTasks bool `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	// stdin indicates whether the driver connects the stdin of tasks to the
+	// fifo at TaskConfig.stdin_path.
+	Stdin                bool     `protobuf:"varint,8,opt,name=stdin,proto3" json:"stdin,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1928,6 +1931,13 @@ func (m *DriverCapabilities) GetRemoteTasks() bool {
 	return false
 }
 
+func (m *DriverCapabilities) GetStdin() bool {
+	if m != nil {
+		return m.Stdin
+	}
+	return false
+}
+
 type NetworkIsolationSpec struct {
 	Mode                 NetworkIsolationSpec_NetworkIsolationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode" json:"mode,omitempty"`
 	Path                 string                                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -2133,10 +2143,13 @@ type TaskConfig struct {
 	// to use for the task. *Only supported on Linux
 	NetworkIsolationSpec *NetworkIsolationSpec `protobuf:"bytes,16,opt,name=network_isolation_spec,json=networkIsolationSpec,proto3" json:"network_isolation_spec,omitempty"`
 	// DNSConfig is the configuration for task DNS resolvers and other options
-	Dns                  *DNSConfig `protobuf:"bytes,17,opt,name=dns,proto3" json:"dns,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Dns *DNSConfig `protobuf:"bytes,17,opt,name=dns,proto3" json:"dns,omitempty"`
+	// StdinPath is the path to the fifo the task's stdin is read from, if the
+	// task reads its dispatch payload from stdin
+	StdinPath            string   `protobuf:"bytes,18,opt,name=stdin_path,json=stdinPath,proto3" json:"stdin_path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TaskConfig) Reset()         { *m = TaskConfig{} }
@@ -2283,6 +2296,13 @@ func (m *TaskConfig) GetDns() *DNSConfig {
 	return nil
 }
 
+func (m *TaskConfig) GetStdinPath() string {
+	if m != nil {
+		return m.StdinPath
+	}
+	return ""
+}
+
 type Resources struct {
 	// AllocatedResources are the resources set for the task
 	AllocatedResources *AllocatedTaskResources `protobuf:"bytes,1,opt,name=allocated_resources,json=allocatedResources,proto3" json:"allocated_resources,omitempty"`
@@ -2690,7 +2710,13 @@ type LinuxResources struct {
 	CpusetCgroup string `protobuf:"bytes,9,opt,name=cpuset_cgroup,json=cpusetCgroup,proto3" json:"cpuset_cgroup,omitempty"`
 	// PercentTicks is a compatibility option for docker and should not be used
 	// buf:lint:ignore FIELD_LOWER_SNAKE_CASE
-	PercentTicks         float64  `protobuf:"fixed64,8,opt,name=PercentTicks,proto3" json:"PercentTicks,omitempty"`
+	PercentTicks float64 `protobuf:"fixed64,8,opt,name=PercentTicks,proto3" json:"PercentTicks,omitempty"`
+	// DiskIops limits the read and write operations per second of the task on
+	// the disk holding its allocation directory. Default: 0 (not specified)
+	DiskIops int64 `protobuf:"varint,10,opt,name=disk_iops,json=diskIops,proto3" json:"disk_iops,omitempty"`
+	// DiskBandwidthBytes limits the read and write bytes per second of the
+	// task on the disk holding its allocation directory. Default: 0 (not specified)
+	DiskBandwidthBytes   int64    `protobuf:"varint,11,opt,name=disk_bandwidth_bytes,json=diskBandwidthBytes,proto3" json:"disk_bandwidth_bytes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2777,6 +2803,20 @@ func (m *LinuxResources) GetPercentTicks() float64 {
 	return 0
 }
 
+func (m *LinuxResources) GetDiskIops() int64 {
+	if m != nil {
+		return m.DiskIops
+	}
+	return 0
+}
+
+func (m *LinuxResources) GetDiskBandwidthBytes() int64 {
+	if m != nil {
+		return m.DiskBandwidthBytes
+	}
+	return 0
+}
+
 type Mount struct {
 	// TaskPath is the file path within the task directory to mount to
 	TaskPath string `protobuf:"bytes,1,opt,name=task_path,json=taskPath,proto3" json:"task_path,omitempty"`
@@ -3682,243 +3722,246 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3776 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xcd, 0x6f, 0x1b, 0x49,
-	0x76, 0x57, 0xb3, 0xf9, 0xf9, 0x48, 0x51, 0xad, 0xb2, 0x6c, 0xd3, 0x9c, 0x24, 0xe3, 0xed, 0x60,
-	0x02, 0x61, 0x77, 0x86, 0x9e, 0xd5, 0x22, 0xe3, 0xf1, 0xac, 0x67, 0x3d, 0x1c, 0x8a, 0xb6, 0x34,
-	0x96, 0x28, 0xa5, 0x48, 0xc1, 0xeb, 0x38, 0x3b, 0x9d, 0x16, 0xbb, 0x4c, 0xb5, 0xc5, 0xfe, 0x98,
-	0xae, 0xa6, 0x2c, 0x6d, 0x10, 0x24, 0xd8, 0x00, 0xc1, 0x06, 0x48, 0x90, 0x5c, 0x26, 0x7b, 0xc9,
-	0x69, 0x81, 0x9c, 0xf2, 0x0f, 0x04, 0x1b, 0xec, 0x29, 0x87, 0xfc, 0x13, 0xb9, 0xe4, 0x96, 0x63,
-	0x72, 0xca, 0x35, 0xa8, 0x8f, 0x6e, 0x76, 0x93, 0xf4, 0xb8, 0x49, 0xf9, 0xc4, 0x7e, 0xf5, 0xf1,
-	0xab, 0xc7, 0xf7, 0x5e, 0xbd, 0x7a, 0xf5, 0xea, 0x81, 0xee, 0x8f, 0x27, 0x23, 0xdb, 0xa5, 0xf7,
-	0xac, 0xc0, 0xbe, 0x20, 0x01, 0xbd, 0xe7, 0x07, 0x5e, 0xe8, 0x49, 0xaa, 0xc5, 0x09, 0xf4, 0xc1,
-	0x99, 0x49, 0xcf, 0xec, 0xa1, 0x17, 0xf8, 0x2d, 0xd7, 0x73, 0x4c, 0xab, 0x25, 0xe7, 0xb4, 0xe4,
-	0x1c, 0x31, 0xac, 0xf9, 0x7b, 0x23, 0xcf, 0x1b, 0x8d, 0x89, 0x40, 0x38, 0x9d, 0xbc, 0xbc, 0x67,
-	0x4d, 0x02, 0x33, 0xb4, 0x3d, 0x57, 0xf6, 0xbf, 0x3f, 0xdb, 0x1f, 0xda, 0x0e, 0xa1, 0xa1, 0xe9,
-	0xf8, 0x72, 0xc0, 0x07, 0x11, 0x2f, 0xf4, 0xcc, 0x0c, 0x88, 0x75, 0xef, 0x6c, 0x38, 0xa6, 0x3e,
-	0x19, 0xb2, 0x5f, 0x83, 0x7d, 0xc8, 0x61, 0x1f, 0xce, 0x0c, 0xa3, 0x61, 0x30, 0x19, 0x86, 0x11,
-	0xe7, 0x66, 0x18, 0x06, 0xf6, 0xe9, 0x24, 0x24, 0x62, 0xb4, 0x7e, 0x07, 0x6e, 0x0f, 0x4c, 0x7a,
-	0xde, 0xf1, 0xdc, 0x97, 0xf6, 0xa8, 0x3f, 0x3c, 0x23, 0x8e, 0x89, 0xc9, 0x37, 0x13, 0x42, 0x43,
-	0xfd, 0x4f, 0xa0, 0x31, 0xdf, 0x45, 0x7d, 0xcf, 0xa5, 0x04, 0x7d, 0x01, 0x79, 0xb6, 0x64, 0x43,
-	0xb9, 0xab, 0x6c, 0x57, 0x77, 0x3e, 0x6c, 0xbd, 0x49, 0x04, 0x82, 0x87, 0x96, 0x64, 0xb5, 0xd5,
-	0xf7, 0xc9, 0x10, 0xf3, 0x99, 0xfa, 0x4d, 0xb8, 0xd1, 0x31, 0x7d, 0xf3, 0xd4, 0x1e, 0xdb, 0xa1,
-	0x4d, 0x68, 0xb4, 0xe8, 0x04, 0xb6, 0xd2, 0xcd, 0x72, 0xc1, 0x9f, 0x41, 0x6d, 0x98, 0x68, 0x97,
-	0x0b, 0x3f, 0x68, 0x65, 0x92, 0x7d, 0x6b, 0x97, 0x53, 0x29, 0xe0, 0x14, 0x9c, 0xbe, 0x05, 0xe8,
-	0xb1, 0xed, 0x8e, 0x48, 0xe0, 0x07, 0xb6, 0x1b, 0x46, 0xcc, 0xfc, 0x56, 0x85, 0x1b, 0xa9, 0x66,
-	0xc9, 0xcc, 0x2b, 0x80, 0x58, 0x8e, 0x8c, 0x15, 0x75, 0xbb, 0xba, 0xf3, 0x55, 0x46, 0x56, 0x16,
-	0xe0, 0xb5, 0xda, 0x31, 0x58, 0xd7, 0x0d, 0x83, 0x2b, 0x9c, 0x40, 0x47, 0x5f, 0x43, 0xf1, 0x8c,
-	0x98, 0xe3, 0xf0, 0xac, 0x91, 0xbb, 0xab, 0x6c, 0xd7, 0x77, 0x1e, 0x5f, 0x63, 0x9d, 0x3d, 0x0e,
-	0xd4, 0x0f, 0xcd, 0x90, 0x60, 0x89, 0x8a, 0x3e, 0x02, 0x24, 0xbe, 0x0c, 0x8b, 0xd0, 0x61, 0x60,
-	0xfb, 0xcc, 0x24, 0x1b, 0xea, 0x5d, 0x65, 0xbb, 0x82, 0x37, 0x45, 0xcf, 0xee, 0xb4, 0xa3, 0xe9,
-	0xc3, 0xc6, 0x0c, 0xb7, 0x48, 0x03, 0xf5, 0x9c, 0x5c, 0x71, 0x8d, 0x54, 0x30, 0xfb, 0x44, 0x4f,
-	0xa0, 0x70, 0x61, 0x8e, 0x27, 0x84, 0xb3, 0x5c, 0xdd, 0xf9, 0xe1, 0xdb, 0xcc, 0x43, 0x9a, 0xe8,
-	0x54, 0x0e, 0x58, 0xcc, 0xff, 0x2c, 0xf7, 0xa9, 0xa2, 0x3f, 0x80, 0x6a, 0x82, 0x6f, 0x54, 0x07,
-	0x38, 0xe9, 0xed, 0x76, 0x07, 0xdd, 0xce, 0xa0, 0xbb, 0xab, 0xad, 0xa1, 0x75, 0xa8, 0x9c, 0xf4,
-	0xf6, 0xba, 0xed, 0x83, 0xc1, 0xde, 0x73, 0x4d, 0x41, 0x55, 0x28, 0x45, 0x44, 0x4e, 0xbf, 0x04,
-	0x84, 0xc9, 0xd0, 0xbb, 0x20, 0x01, 0x33, 0x64, 0xa9, 0x55, 0x74, 0x1b, 0x4a, 0xa1, 0x49, 0xcf,
-	0x0d, 0xdb, 0x92, 0x3c, 0x17, 0x19, 0xb9, 0x6f, 0xa1, 0x7d, 0x28, 0x9e, 0x99, 0xae, 0x35, 0x7e,
-	0x3b, 0xdf, 0x69, 0x51, 0x33, 0xf0, 0x3d, 0x3e, 0x11, 0x4b, 0x00, 0x66, 0xdd, 0xa9, 0x95, 0x85,
-	0x02, 0xf4, 0xe7, 0xa0, 0xf5, 0x43, 0x33, 0x08, 0x93, 0xec, 0x74, 0x21, 0xcf, 0xd6, 0x97, 0x16,
-	0xbd, 0xcc, 0x9a, 0x62, 0x67, 0x62, 0x3e, 0x5d, 0xff, 0xdf, 0x1c, 0x6c, 0x26, 0xb0, 0xa5, 0xa5,
-	0x3e, 0x83, 0x62, 0x40, 0xe8, 0x64, 0x1c, 0x72, 0xf8, 0xfa, 0xce, 0xa3, 0x8c, 0xf0, 0x73, 0x48,
-	0x2d, 0xcc, 0x61, 0xb0, 0x84, 0x43, 0xdb, 0xa0, 0x89, 0x19, 0x06, 0x09, 0x02, 0x2f, 0x30, 0x1c,
-	0x3a, 0xe2, 0x52, 0xab, 0xe0, 0xba, 0x68, 0xef, 0xb2, 0xe6, 0x43, 0x3a, 0x4a, 0x48, 0x55, 0xbd,
-	0xa6, 0x54, 0x91, 0x09, 0x9a, 0x4b, 0xc2, 0xd7, 0x5e, 0x70, 0x6e, 0x30, 0xd1, 0x06, 0xb6, 0x45,
-	0x1a, 0x79, 0x0e, 0xfa, 0x49, 0x46, 0xd0, 0x9e, 0x98, 0x7e, 0x24, 0x67, 0xe3, 0x0d, 0x37, 0xdd,
-	0xa0, 0xff, 0x00, 0x8a, 0xe2, 0x9f, 0x32, 0x4b, 0xea, 0x9f, 0x74, 0x3a, 0xdd, 0x7e, 0x5f, 0x5b,
-	0x43, 0x15, 0x28, 0xe0, 0xee, 0x00, 0x33, 0x0b, 0xab, 0x40, 0xe1, 0x71, 0x7b, 0xd0, 0x3e, 0xd0,
-	0x72, 0xfa, 0xf7, 0x61, 0xe3, 0x99, 0x69, 0x87, 0x59, 0x8c, 0x4b, 0xf7, 0x40, 0x9b, 0x8e, 0x95,
-	0xda, 0xd9, 0x4f, 0x69, 0x27, 0xbb, 0x68, 0xba, 0x97, 0x76, 0x38, 0xa3, 0x0f, 0x0d, 0x54, 0x12,
-	0x04, 0x52, 0x05, 0xec, 0x53, 0x7f, 0x0d, 0x1b, 0xfd, 0xd0, 0xf3, 0x33, 0x59, 0xfe, 0x8f, 0xa0,
-	0xc4, 0x4e, 0x1b, 0x6f, 0x12, 0x4a, 0xd3, 0xbf, 0xd3, 0x12, 0xa7, 0x51, 0x2b, 0x3a, 0x8d, 0x5a,
-	0xbb, 0xf2, 0xb4, 0xc2, 0xd1, 0x48, 0x74, 0x0b, 0x8a, 0xd4, 0x1e, 0xb9, 0xe6, 0x58, 0x7a, 0x0b,
-	0x49, 0xe9, 0x88, 0x19, 0x79, 0xb4, 0xb0, 0x34, 0xfc, 0x0e, 0xa0, 0x5d, 0x42, 0xc3, 0xc0, 0xbb,
-	0xca, 0xc4, 0xcf, 0x16, 0x14, 0x5e, 0x7a, 0xc1, 0x50, 0x6c, 0xc4, 0x32, 0x16, 0x04, 0xdb, 0x54,
-	0x29, 0x10, 0x89, 0xfd, 0x11, 0xa0, 0x7d, 0x97, 0x9d, 0x29, 0xd9, 0x14, 0xf1, 0x0f, 0x39, 0xb8,
-	0x91, 0x1a, 0x2f, 0x95, 0xb1, 0xfa, 0x3e, 0x64, 0x8e, 0x69, 0x42, 0xc5, 0x3e, 0x44, 0x47, 0x50,
-	0x14, 0x23, 0xa4, 0x24, 0xef, 0x2f, 0x01, 0x24, 0x8e, 0x29, 0x09, 0x27, 0x61, 0x16, 0x1a, 0xbd,
-	0xfa, 0x6e, 0x8d, 0xfe, 0x35, 0x68, 0xd1, 0xff, 0xa0, 0x6f, 0xd5, 0xcd, 0x57, 0x70, 0x63, 0xe8,
-	0x8d, 0xc7, 0x64, 0xc8, 0xac, 0xc1, 0xb0, 0xdd, 0x90, 0x04, 0x17, 0xe6, 0xf8, 0xed, 0x76, 0x83,
-	0xa6, 0xb3, 0xf6, 0xe5, 0x24, 0xfd, 0x05, 0x6c, 0x26, 0x16, 0x96, 0x8a, 0x78, 0x0c, 0x05, 0xca,
-	0x1a, 0xa4, 0x26, 0x3e, 0x5e, 0x52, 0x13, 0x14, 0x8b, 0xe9, 0xfa, 0x0d, 0x01, 0xde, 0xbd, 0x20,
-	0x6e, 0xfc, 0xb7, 0xf4, 0x5d, 0xd8, 0xec, 0x73, 0x33, 0xcd, 0x64, 0x87, 0x53, 0x13, 0xcf, 0xa5,
-	0x4c, 0x7c, 0x0b, 0x50, 0x12, 0x45, 0x1a, 0xe2, 0x15, 0x6c, 0x74, 0x2f, 0xc9, 0x30, 0x13, 0x72,
-	0x03, 0x4a, 0x43, 0xcf, 0x71, 0x4c, 0xd7, 0x6a, 0xe4, 0xee, 0xaa, 0xdb, 0x15, 0x1c, 0x91, 0xc9,
-	0xbd, 0xa8, 0x66, 0xdd, 0x8b, 0xfa, 0xdf, 0x29, 0xa0, 0x4d, 0xd7, 0x96, 0x82, 0x64, 0xdc, 0x87,
-	0x16, 0x03, 0x62, 0x6b, 0xd7, 0xb0, 0xa4, 0x64, 0x7b, 0xe4, 0x2e, 0x44, 0x3b, 0x09, 0x82, 0x84,
-	0x3b, 0x52, 0xaf, 0xe9, 0x8e, 0xf4, 0x3d, 0xf8, 0x9d, 0x88, 0x9d, 0x7e, 0x18, 0x10, 0xd3, 0xb1,
-	0xdd, 0xd1, 0xfe, 0xd1, 0x91, 0x4f, 0x04, 0xe3, 0x08, 0x41, 0xde, 0x32, 0x43, 0x53, 0x32, 0xc6,
-	0xbf, 0xd9, 0xa6, 0x1f, 0x8e, 0x3d, 0x1a, 0x6f, 0x7a, 0x4e, 0xe8, 0xff, 0xa1, 0x42, 0x63, 0x0e,
-	0x2a, 0x12, 0xef, 0x0b, 0x28, 0x50, 0x12, 0x4e, 0x7c, 0x69, 0x2a, 0xdd, 0xcc, 0x0c, 0x2f, 0xc6,
-	0x6b, 0xf5, 0x19, 0x18, 0x16, 0x98, 0x68, 0x04, 0xe5, 0x30, 0xbc, 0x32, 0xa8, 0xfd, 0xf3, 0x28,
-	0x20, 0x38, 0xb8, 0x2e, 0xfe, 0x80, 0x04, 0x8e, 0xed, 0x9a, 0xe3, 0xbe, 0xfd, 0x73, 0x82, 0x4b,
-	0x61, 0x78, 0xc5, 0x3e, 0xd0, 0x73, 0x66, 0xf0, 0x96, 0xed, 0x4a, 0xb1, 0x77, 0x56, 0x5d, 0x25,
-	0x21, 0x60, 0x2c, 0x10, 0x9b, 0x07, 0x50, 0xe0, 0xff, 0x69, 0x15, 0x43, 0xd4, 0x40, 0x0d, 0xc3,
-	0x2b, 0xce, 0x54, 0x19, 0xb3, 0xcf, 0xe6, 0x43, 0xa8, 0x25, 0xff, 0x01, 0x33, 0xa4, 0x33, 0x62,
-	0x8f, 0xce, 0x84, 0x81, 0x15, 0xb0, 0xa4, 0x98, 0x26, 0x5f, 0xdb, 0x96, 0x0c, 0x59, 0x0b, 0x58,
-	0x10, 0xfa, 0xbf, 0xe6, 0xe0, 0xce, 0x02, 0xc9, 0x48, 0x63, 0x7d, 0x91, 0x32, 0xd6, 0x77, 0x24,
-	0x85, 0xc8, 0xe2, 0x5f, 0xa4, 0x2c, 0xfe, 0x1d, 0x82, 0xb3, 0x6d, 0x73, 0x0b, 0x8a, 0xe4, 0xd2,
-	0x0e, 0x89, 0x25, 0x45, 0x25, 0xa9, 0xc4, 0x76, 0xca, 0x5f, 0x77, 0x3b, 0x1d, 0xc2, 0x56, 0x27,
-	0x20, 0x66, 0x48, 0xa4, 0x2b, 0x8f, 0xec, 0xff, 0x0e, 0x94, 0xcd, 0xf1, 0xd8, 0x1b, 0x4e, 0xd5,
-	0x5a, 0xe2, 0xf4, 0xbe, 0x85, 0x9a, 0x50, 0x3e, 0xf3, 0x68, 0xe8, 0x9a, 0x0e, 0x91, 0xce, 0x2b,
-	0xa6, 0xf5, 0x6f, 0x15, 0xb8, 0x39, 0x83, 0x27, 0xb5, 0x70, 0x0a, 0x75, 0x9b, 0x7a, 0x63, 0xfe,
-	0x07, 0x8d, 0xc4, 0x0d, 0xef, 0xc7, 0xcb, 0x1d, 0x35, 0xfb, 0x11, 0x06, 0xbf, 0xf0, 0xad, 0xdb,
-	0x49, 0x92, 0x5b, 0x1c, 0x5f, 0xdc, 0x92, 0x3b, 0x3d, 0x22, 0xf5, 0x7f, 0x54, 0xe0, 0xa6, 0x3c,
-	0xe1, 0xb3, 0xff, 0xd1, 0x79, 0x96, 0x73, 0xef, 0x9a, 0x65, 0xbd, 0x01, 0xb7, 0x66, 0xf9, 0x92,
-	0x3e, 0xff, 0xff, 0xf2, 0x80, 0xe6, 0x6f, 0x97, 0xe8, 0x7b, 0x50, 0xa3, 0xc4, 0xb5, 0x0c, 0x71,
-	0x5e, 0x88, 0xa3, 0xac, 0x8c, 0xab, 0xac, 0x4d, 0x1c, 0x1c, 0x94, 0xb9, 0x40, 0x72, 0x29, 0xb9,
-	0x2d, 0x63, 0xfe, 0x8d, 0xce, 0xa0, 0xf6, 0x92, 0x1a, 0xf1, 0xda, 0xdc, 0xa0, 0xea, 0x99, 0xdd,
-	0xda, 0x3c, 0x1f, 0xad, 0xc7, 0xfd, 0xf8, 0x7f, 0xe1, 0xea, 0x4b, 0x1a, 0x13, 0xe8, 0x97, 0x0a,
-	0xdc, 0x8e, 0xc2, 0x8a, 0xa9, 0xf8, 0x1c, 0xcf, 0x22, 0xb4, 0x91, 0xbf, 0xab, 0x6e, 0xd7, 0x77,
-	0x8e, 0xaf, 0x21, 0xbf, 0xb9, 0xc6, 0x43, 0xcf, 0x22, 0xf8, 0xa6, 0xbb, 0xa0, 0x95, 0xa2, 0x16,
-	0xdc, 0x70, 0x26, 0x34, 0x34, 0x84, 0x15, 0x18, 0x72, 0x50, 0xa3, 0xc0, 0xe5, 0xb2, 0xc9, 0xba,
-	0x52, 0xb6, 0x8a, 0xce, 0x61, 0xdd, 0xf1, 0x26, 0x6e, 0x68, 0x0c, 0xf9, 0xfd, 0x87, 0x36, 0x8a,
-	0x4b, 0x5d, 0x8c, 0x17, 0x48, 0xe9, 0x90, 0xc1, 0x89, 0xdb, 0x14, 0xc5, 0x35, 0x27, 0x41, 0x31,
-	0x45, 0x06, 0xc4, 0xf1, 0x42, 0x62, 0x30, 0x7f, 0x49, 0x1b, 0x25, 0xa1, 0x48, 0xd1, 0xc6, 0x5c,
-	0x03, 0xd5, 0x5b, 0x50, 0x4d, 0x88, 0x19, 0x95, 0x21, 0xdf, 0x3b, 0xea, 0x75, 0xb5, 0x35, 0x04,
-	0x50, 0xec, 0xec, 0xe1, 0xa3, 0xa3, 0x81, 0xb8, 0x35, 0xec, 0x1f, 0xb6, 0x9f, 0x74, 0xb5, 0x9c,
-	0xde, 0x85, 0x5a, 0x72, 0x41, 0x84, 0xa0, 0x7e, 0xd2, 0x7b, 0xda, 0x3b, 0x7a, 0xd6, 0x33, 0x0e,
-	0x8f, 0x4e, 0x7a, 0x03, 0x76, 0xdf, 0xa8, 0x03, 0xb4, 0x7b, 0xcf, 0xa7, 0xf4, 0x3a, 0x54, 0x7a,
-	0x47, 0x11, 0xa9, 0x34, 0x73, 0x9a, 0xa2, 0xff, 0xbb, 0x0a, 0x5b, 0x8b, 0x64, 0x8f, 0x2c, 0xc8,
-	0x33, 0x3d, 0xca, 0x1b, 0xdf, 0xbb, 0x57, 0x23, 0x47, 0x67, 0xe6, 0xeb, 0x9b, 0xd2, 0xc5, 0x57,
-	0x30, 0xff, 0x46, 0x06, 0x14, 0xc7, 0xe6, 0x29, 0x19, 0xd3, 0x86, 0xca, 0x73, 0x22, 0x4f, 0xae,
-	0xb3, 0xf6, 0x01, 0x47, 0x12, 0x09, 0x11, 0x09, 0x8b, 0x06, 0x50, 0x65, 0x4e, 0x8c, 0x0a, 0xd1,
-	0x49, 0xbf, 0xba, 0x93, 0x71, 0x95, 0xbd, 0xe9, 0x4c, 0x9c, 0x84, 0x69, 0x3e, 0x80, 0x6a, 0x62,
-	0xb1, 0x05, 0xf9, 0x8c, 0xad, 0x64, 0x3e, 0xa3, 0x92, 0x4c, 0x4e, 0x3c, 0x9a, 0xd7, 0x01, 0x93,
-	0x11, 0x33, 0x82, 0xbd, 0xa3, 0xfe, 0x40, 0xdc, 0x1c, 0x9f, 0xe0, 0xa3, 0x93, 0x63, 0x4d, 0x61,
-	0x8d, 0x83, 0x76, 0xff, 0xa9, 0x96, 0x8b, 0x6d, 0x44, 0xd5, 0x3b, 0x50, 0x4d, 0xf0, 0x95, 0xf2,
-	0xda, 0x4a, 0xda, 0x6b, 0x33, 0xbf, 0x69, 0x5a, 0x56, 0x40, 0x28, 0x95, 0x7c, 0x44, 0xa4, 0xfe,
-	0x02, 0x2a, 0xbb, 0xbd, 0xbe, 0x84, 0x68, 0x40, 0x89, 0x92, 0x80, 0xfd, 0x6f, 0x9e, 0x99, 0xaa,
-	0xe0, 0x88, 0x64, 0xe0, 0x94, 0x98, 0xc1, 0xf0, 0x8c, 0x50, 0x79, 0xd6, 0xc7, 0x34, 0x9b, 0xe5,
-	0xf1, 0x0c, 0x8f, 0xd0, 0x5d, 0x05, 0x47, 0xa4, 0xfe, 0x3f, 0x25, 0x80, 0x69, 0xb6, 0x01, 0xd5,
-	0x21, 0x17, 0xfb, 0xe0, 0x9c, 0x6d, 0x31, 0x3b, 0x48, 0x9c, 0x31, 0xfc, 0x1b, 0xed, 0xc0, 0x4d,
-	0x87, 0x8e, 0x7c, 0x73, 0x78, 0x6e, 0xc8, 0x24, 0x81, 0xd8, 0xaa, 0xdc, 0x9f, 0xd5, 0xf0, 0x0d,
-	0xd9, 0x29, 0x77, 0xa2, 0xc0, 0x3d, 0x00, 0x95, 0xb8, 0x17, 0xdc, 0xf7, 0x54, 0x77, 0x3e, 0x5b,
-	0x3a, 0x0b, 0xd2, 0xea, 0xba, 0x17, 0xc2, 0x56, 0x18, 0x0c, 0x32, 0x00, 0x2c, 0x72, 0x61, 0x0f,
-	0x89, 0xc1, 0x40, 0x0b, 0x1c, 0xf4, 0x8b, 0xe5, 0x41, 0x77, 0x39, 0x46, 0x0c, 0x5d, 0xb1, 0x22,
-	0x1a, 0xf5, 0xa0, 0x12, 0x10, 0xea, 0x4d, 0x82, 0x21, 0x11, 0x0e, 0x28, 0xfb, 0x45, 0x05, 0x47,
-	0xf3, 0xf0, 0x14, 0x02, 0xed, 0x42, 0x91, 0xfb, 0x1d, 0xe6, 0x61, 0xd4, 0xef, 0x4c, 0xa9, 0xa6,
-	0xc1, 0xb8, 0x27, 0xc1, 0x72, 0x2e, 0x7a, 0x02, 0x25, 0xc1, 0x22, 0x6d, 0x94, 0x39, 0xcc, 0x47,
-	0x59, 0x9d, 0x22, 0x9f, 0x85, 0xa3, 0xd9, 0x4c, 0xab, 0x13, 0x4a, 0x82, 0x46, 0x45, 0x68, 0x95,
-	0x7d, 0xa3, 0xf7, 0xa0, 0x22, 0xce, 0x60, 0xcb, 0x0e, 0x1a, 0x20, 0x8c, 0x93, 0x37, 0xec, 0xda,
-	0x01, 0x7a, 0x1f, 0xaa, 0x22, 0xd6, 0x32, 0xb8, 0x57, 0xa8, 0xf2, 0x6e, 0x10, 0x4d, 0xc7, 0xcc,
-	0x37, 0x88, 0x01, 0x24, 0x08, 0xc4, 0x80, 0x5a, 0x3c, 0x80, 0x04, 0x01, 0x1f, 0xf0, 0x07, 0xb0,
-	0xc1, 0x23, 0xd4, 0x51, 0xe0, 0x4d, 0x7c, 0x83, 0xdb, 0xd4, 0x3a, 0x1f, 0xb4, 0xce, 0x9a, 0x9f,
-	0xb0, 0xd6, 0x1e, 0x33, 0xae, 0x3b, 0x50, 0x7e, 0xe5, 0x9d, 0x8a, 0x01, 0x75, 0xb1, 0x0f, 0x5e,
-	0x79, 0xa7, 0x51, 0x57, 0x1c, 0x25, 0x6c, 0xa4, 0xa3, 0x84, 0x6f, 0xe0, 0xd6, 0xfc, 0x71, 0xc7,
-	0xa3, 0x05, 0xed, 0xfa, 0xd1, 0xc2, 0x96, 0xbb, 0xc8, 0x0f, 0x7f, 0x09, 0xaa, 0xe5, 0xd2, 0xc6,
-	0xe6, 0x52, 0xc6, 0x11, 0xef, 0x63, 0xcc, 0x26, 0x37, 0x3f, 0x81, 0x72, 0x64, 0x7d, 0xcb, 0xf8,
-	0xa5, 0xe6, 0x43, 0xa8, 0xa7, 0x6d, 0x77, 0x29, 0xaf, 0xf6, 0xcf, 0x39, 0xa8, 0xc4, 0x56, 0x8a,
-	0x5c, 0xb8, 0xc1, 0xa5, 0xc8, 0x42, 0x34, 0x63, 0x6a, 0xf4, 0x22, 0x30, 0xfc, 0x3c, 0xe3, 0xff,
-	0x6a, 0x47, 0x08, 0xf2, 0x86, 0x2a, 0x77, 0x00, 0x8a, 0x91, 0xa7, 0xeb, 0x7d, 0x0d, 0x1b, 0x63,
-	0xdb, 0x9d, 0x5c, 0x26, 0xd6, 0x12, 0x11, 0xdd, 0x1f, 0x66, 0x5c, 0xeb, 0x80, 0xcd, 0x9e, 0xae,
-	0x51, 0x1f, 0xa7, 0x68, 0xb4, 0x07, 0x05, 0xdf, 0x0b, 0xc2, 0xe8, 0x90, 0xca, 0x7a, 0x7c, 0x1c,
-	0x7b, 0x41, 0x78, 0x68, 0xfa, 0x3e, 0xbb, 0xb4, 0x08, 0x00, 0xfd, 0xdb, 0x1c, 0xdc, 0x5a, 0xfc,
-	0xc7, 0x50, 0x0f, 0xd4, 0xa1, 0x3f, 0x91, 0x42, 0x7a, 0xb8, 0xac, 0x90, 0x3a, 0xfe, 0x64, 0xca,
-	0x3f, 0x03, 0x42, 0xcf, 0xa0, 0xe8, 0x10, 0xc7, 0x0b, 0xae, 0xa4, 0x2c, 0x1e, 0x2d, 0x0b, 0x79,
-	0xc8, 0x67, 0x4f, 0x51, 0x25, 0x1c, 0xc2, 0x50, 0x96, 0xd6, 0x4b, 0xa5, 0x9f, 0x5c, 0x32, 0xad,
-	0x14, 0x41, 0xe2, 0x18, 0x47, 0xff, 0x04, 0x6e, 0x2e, 0xfc, 0x2b, 0xe8, 0x77, 0x01, 0x86, 0xfe,
-	0xc4, 0xe0, 0x69, 0x7f, 0x61, 0x41, 0x2a, 0xae, 0x0c, 0xfd, 0x49, 0x9f, 0x37, 0xe8, 0x2f, 0xa0,
-	0xf1, 0x26, 0x7e, 0x99, 0xf7, 0x11, 0x1c, 0x1b, 0xce, 0x29, 0x97, 0x81, 0x8a, 0xcb, 0xa2, 0xe1,
-	0xf0, 0x14, 0xe9, 0xb0, 0x1e, 0x75, 0x9a, 0x97, 0x6c, 0x80, 0xca, 0x07, 0x54, 0xe5, 0x00, 0xf3,
-	0xf2, 0xf0, 0x54, 0xff, 0x55, 0x0e, 0x36, 0x66, 0x58, 0x66, 0x57, 0x37, 0xe1, 0xf1, 0xa2, 0x4b,
-	0xb1, 0xa0, 0x98, 0xfb, 0x1b, 0xda, 0x56, 0x94, 0x4e, 0xe5, 0xdf, 0xfc, 0xe0, 0xf3, 0x65, 0xaa,
-	0x33, 0x67, 0xfb, 0x6c, 0xfb, 0x38, 0xa7, 0x76, 0x48, 0x79, 0x14, 0x52, 0xc0, 0x82, 0x40, 0xcf,
-	0xa1, 0x1e, 0x10, 0x7e, 0xe0, 0x5a, 0x86, 0xb0, 0xb2, 0xc2, 0x52, 0x56, 0x26, 0x39, 0x64, 0xc6,
-	0x86, 0xd7, 0x23, 0x24, 0x46, 0x51, 0xf4, 0x0c, 0xd6, 0xad, 0x2b, 0xd7, 0x74, 0xec, 0xa1, 0x44,
-	0x2e, 0xae, 0x8c, 0x5c, 0x93, 0x40, 0x1c, 0x58, 0x7f, 0x00, 0xd5, 0x44, 0x27, 0xfb, 0x63, 0x3c,
-	0xdc, 0x92, 0x32, 0x11, 0x44, 0xda, 0x5b, 0x14, 0xa4, 0xb7, 0xd0, 0x4f, 0xa1, 0x9a, 0xd8, 0x17,
-	0xcb, 0x4c, 0x65, 0xf2, 0x0c, 0x3d, 0x2e, 0xcf, 0x02, 0xce, 0x85, 0x1e, 0xba, 0x0d, 0x25, 0x16,
-	0xea, 0x18, 0xb6, 0xcf, 0x25, 0x5a, 0xc1, 0x45, 0x46, 0xee, 0xfb, 0xfa, 0x6f, 0x72, 0x50, 0x4f,
-	0x6f, 0xe9, 0xc8, 0x8e, 0x7c, 0x12, 0xd8, 0x9e, 0x95, 0xb0, 0xa3, 0x63, 0xde, 0xc0, 0x6c, 0x85,
-	0x75, 0x7f, 0x33, 0xf1, 0x42, 0x33, 0xb2, 0x95, 0xa1, 0x3f, 0xf9, 0x23, 0x46, 0xcf, 0xd8, 0xa0,
-	0x3a, 0x63, 0x83, 0xe8, 0x43, 0x40, 0xd2, 0x94, 0xc6, 0xb6, 0x63, 0x87, 0xc6, 0xe9, 0x55, 0x48,
-	0x84, 0x8e, 0x55, 0xac, 0x89, 0x9e, 0x03, 0xd6, 0xf1, 0x25, 0x6b, 0x67, 0x86, 0xe7, 0x79, 0x8e,
-	0x41, 0x87, 0x5e, 0x40, 0x0c, 0xd3, 0x7a, 0xc5, 0x6f, 0x2d, 0x2a, 0xae, 0x7a, 0x9e, 0xd3, 0x67,
-	0x6d, 0x6d, 0xeb, 0x15, 0x3b, 0xf9, 0x86, 0xfe, 0x84, 0x92, 0xd0, 0x60, 0x3f, 0x3c, 0x58, 0xa8,
-	0x60, 0x10, 0x4d, 0x1d, 0x7f, 0x42, 0xd1, 0xef, 0xc3, 0x7a, 0x34, 0x80, 0x1f, 0x7e, 0xf2, 0xd4,
-	0xad, 0xc9, 0x21, 0xbc, 0x0d, 0xe9, 0x50, 0x3b, 0x26, 0xc1, 0x90, 0xb8, 0xe1, 0xc0, 0x1e, 0x9e,
-	0xb3, 0xf3, 0x5d, 0xd9, 0x56, 0x70, 0xaa, 0xed, 0xab, 0x7c, 0xb9, 0xa4, 0x95, 0x71, 0xb4, 0x9a,
-	0x43, 0x1c, 0xaa, 0xff, 0x0c, 0x0a, 0x3c, 0x44, 0x60, 0x32, 0xe1, 0xc7, 0x2b, 0x3f, 0x7d, 0x65,
-	0x68, 0xc9, 0x1a, 0xf8, 0xd9, 0xfb, 0x1e, 0x54, 0xb8, 0xec, 0x13, 0x11, 0x3d, 0x8f, 0x3b, 0x79,
-	0x67, 0x13, 0xca, 0x01, 0x31, 0x2d, 0xcf, 0x1d, 0x47, 0xc9, 0xa0, 0x98, 0xd6, 0xbf, 0x81, 0xa2,
-	0x38, 0x67, 0xae, 0x81, 0xff, 0x11, 0x20, 0xf1, 0xbf, 0x99, 0x3e, 0x1d, 0x9b, 0x52, 0x19, 0x85,
-	0xf2, 0x17, 0x48, 0xd1, 0x73, 0x3c, 0xed, 0xd0, 0xff, 0x53, 0x11, 0xf1, 0xa8, 0x78, 0x1b, 0x62,
-	0x81, 0x2b, 0x33, 0x72, 0x76, 0x5b, 0x16, 0x49, 0xa8, 0x88, 0x44, 0xfb, 0x50, 0x94, 0x61, 0x67,
-	0x6e, 0xd5, 0xa7, 0x35, 0x09, 0x10, 0xa5, 0xa4, 0x89, 0xbc, 0x90, 0x2f, 0x9b, 0x92, 0x26, 0x22,
-	0x25, 0x4d, 0xd8, 0x6d, 0x52, 0x06, 0xc4, 0x02, 0x2e, 0xcf, 0xe3, 0xe1, 0xaa, 0x15, 0xe7, 0xfd,
-	0x89, 0xfe, 0xdf, 0x4a, 0xec, 0xa6, 0xa2, 0xfc, 0x3c, 0xfa, 0x1a, 0xca, 0x6c, 0xc7, 0x1b, 0x8e,
-	0xe9, 0xcb, 0xd7, 0xe6, 0xce, 0x6a, 0xa9, 0xff, 0xe8, 0x10, 0x13, 0xe1, 0x6c, 0xc9, 0x17, 0x14,
-	0x73, 0x77, 0xec, 0x2a, 0x11, 0xb9, 0x3b, 0xf6, 0x8d, 0x3e, 0x80, 0xba, 0x39, 0x09, 0x3d, 0xc3,
-	0xb4, 0x2e, 0x48, 0x10, 0xda, 0x94, 0x48, 0xdd, 0xaf, 0xb3, 0xd6, 0x76, 0xd4, 0xd8, 0xfc, 0x0c,
-	0x6a, 0x49, 0xcc, 0xb7, 0x85, 0x19, 0x85, 0x64, 0x98, 0xf1, 0xa7, 0x00, 0xd3, 0x5c, 0x17, 0xb3,
-	0x11, 0x72, 0x69, 0xb3, 0x5b, 0xbd, 0xbc, 0xbb, 0x16, 0x70, 0x99, 0x35, 0x74, 0xd8, 0x7d, 0x2a,
-	0x9d, 0x88, 0x2f, 0x44, 0x89, 0x78, 0xb6, 0x99, 0xd9, 0xfe, 0x3b, 0xb7, 0xc7, 0xe3, 0x38, 0xff,
-	0x56, 0xf1, 0x3c, 0xe7, 0x29, 0x6f, 0xd0, 0x7f, 0x9b, 0x13, 0xb6, 0x22, 0x9e, 0x54, 0x32, 0xdd,
-	0x5d, 0xde, 0x95, 0xaa, 0x1f, 0x00, 0xd0, 0xd0, 0x0c, 0x58, 0xcc, 0x64, 0x46, 0x19, 0xc0, 0xe6,
-	0x5c, 0x26, 0x7f, 0x10, 0xd5, 0x78, 0xe0, 0x8a, 0x1c, 0xdd, 0x0e, 0xd1, 0xe7, 0x50, 0x1b, 0x7a,
-	0x8e, 0x3f, 0x26, 0x72, 0x72, 0xe1, 0xad, 0x93, 0xab, 0xf1, 0xf8, 0x76, 0x98, 0xc8, 0x3b, 0x16,
-	0xaf, 0x9b, 0x77, 0xfc, 0x8d, 0x22, 0x5e, 0x86, 0x92, 0x0f, 0x53, 0x68, 0xb4, 0xa0, 0xfa, 0xe1,
-	0xc9, 0x8a, 0xaf, 0x5c, 0xdf, 0x55, 0xfa, 0xd0, 0xfc, 0x3c, 0x4b, 0xad, 0xc1, 0x9b, 0xa3, 0xd8,
-	0x7f, 0x53, 0xa1, 0x12, 0x3f, 0x0a, 0xcd, 0xe9, 0xfe, 0x53, 0xa8, 0xc4, 0x05, 0x36, 0xd2, 0x41,
-	0x7c, 0xa7, 0x7a, 0xe2, 0xc1, 0xe8, 0x25, 0x20, 0x73, 0x34, 0x8a, 0xa3, 0x53, 0x63, 0x42, 0xcd,
-	0x51, 0xf4, 0x24, 0xf7, 0xe9, 0x12, 0x72, 0x88, 0x8e, 0xb3, 0x13, 0x36, 0x1f, 0x6b, 0xe6, 0x68,
-	0x94, 0x6a, 0x41, 0x7f, 0x06, 0x37, 0xd3, 0x6b, 0x18, 0xa7, 0x57, 0x86, 0x6f, 0x5b, 0xf2, 0x8e,
-	0xbc, 0xb7, 0xec, 0xbb, 0x58, 0x2b, 0x05, 0xff, 0xe5, 0xd5, 0xb1, 0x6d, 0x09, 0x99, 0xa3, 0x60,
-	0xae, 0xa3, 0xf9, 0x17, 0x70, 0xfb, 0x0d, 0xc3, 0x17, 0xe8, 0xa0, 0x97, 0xae, 0xf7, 0x58, 0x5d,
-	0x08, 0x09, 0xed, 0xfd, 0x5a, 0x11, 0xcf, 0x77, 0x69, 0x99, 0xb4, 0x93, 0x61, 0xf5, 0xbd, 0x8c,
-	0xeb, 0x74, 0x8e, 0x4f, 0x04, 0x3c, 0x8f, 0xa4, 0xbf, 0x9a, 0x89, 0xa4, 0xb3, 0xc6, 0x4f, 0x22,
-	0x20, 0x15, 0x40, 0x12, 0x41, 0xff, 0x17, 0x15, 0xca, 0x11, 0x3a, 0xbf, 0xe1, 0x5e, 0xd1, 0x90,
-	0x38, 0x46, 0x9c, 0x7e, 0x53, 0x30, 0x88, 0x26, 0x9e, 0x14, 0x7a, 0x0f, 0x2a, 0xec, 0x22, 0x2d,
-	0xba, 0x73, 0xbc, 0xbb, 0xcc, 0x1a, 0x78, 0xe7, 0xfb, 0x50, 0x0d, 0xbd, 0xd0, 0x1c, 0x1b, 0x21,
-	0x3f, 0xde, 0x55, 0x31, 0x9b, 0x37, 0xf1, 0xc3, 0x1d, 0xfd, 0x00, 0x36, 0xc3, 0xb3, 0xc0, 0x0b,
-	0xc3, 0x31, 0x0b, 0x2d, 0x79, 0xa0, 0x23, 0xe2, 0x92, 0x3c, 0xd6, 0xe2, 0x0e, 0x11, 0x00, 0x51,
-	0xe6, 0xbd, 0xa7, 0x83, 0x99, 0xe9, 0x72, 0x27, 0x92, 0xc7, 0xeb, 0x71, 0x2b, 0x33, 0x6d, 0x76,
-	0x78, 0xfa, 0x22, 0x80, 0xe0, 0xbe, 0x42, 0xc1, 0x11, 0x89, 0x0c, 0xd8, 0x70, 0x88, 0x49, 0x27,
-	0x01, 0xb1, 0x8c, 0x97, 0x36, 0x19, 0x5b, 0x22, 0x31, 0x51, 0xcf, 0x7c, 0x3b, 0x88, 0xc4, 0xd2,
-	0x7a, 0xcc, 0x67, 0xe3, 0x7a, 0x04, 0x27, 0x68, 0x16, 0x39, 0x88, 0x2f, 0xb4, 0x01, 0xd5, 0xfe,
-	0xf3, 0xfe, 0xa0, 0x7b, 0x68, 0x1c, 0x1e, 0xed, 0x76, 0x65, 0x49, 0x4f, 0xbf, 0x8b, 0x05, 0xa9,
-	0xb0, 0xfe, 0xc1, 0xd1, 0xa0, 0x7d, 0x60, 0x0c, 0xf6, 0x3b, 0x4f, 0xfb, 0x5a, 0x0e, 0xdd, 0x84,
-	0xcd, 0xc1, 0x1e, 0x3e, 0x1a, 0x0c, 0x0e, 0xba, 0xbb, 0xc6, 0x71, 0x17, 0xef, 0x1f, 0xed, 0xf6,
-	0x35, 0x15, 0x21, 0xa8, 0x4f, 0x9b, 0x07, 0xfb, 0x87, 0x5d, 0x2d, 0x8f, 0xaa, 0x50, 0x3a, 0xee,
-	0xe2, 0x4e, 0xb7, 0x37, 0xd0, 0x0a, 0xfa, 0xaf, 0x54, 0xa8, 0x26, 0xb4, 0xc8, 0x0c, 0x39, 0xa0,
-	0xe2, 0x1a, 0x92, 0xc7, 0xec, 0x93, 0x3f, 0x41, 0x9a, 0xc3, 0x33, 0xa1, 0x9d, 0x3c, 0x16, 0x04,
-	0xbf, 0x7a, 0x98, 0x97, 0x89, 0x7d, 0x9e, 0xc7, 0x65, 0xc7, 0xbc, 0x14, 0x20, 0xdf, 0x83, 0xda,
-	0x39, 0x09, 0x5c, 0x32, 0x96, 0xfd, 0x42, 0x23, 0x55, 0xd1, 0x26, 0x86, 0x6c, 0x83, 0x26, 0x87,
-	0x4c, 0x61, 0x84, 0x3a, 0xea, 0xa2, 0xfd, 0x30, 0x02, 0xdb, 0x82, 0x82, 0xe8, 0x2e, 0x89, 0xf5,
-	0x39, 0xc1, 0x8e, 0x29, 0xfa, 0xda, 0xf4, 0x79, 0xc8, 0x97, 0xc7, 0xfc, 0x1b, 0x9d, 0xce, 0xeb,
-	0xa7, 0xc8, 0xf5, 0xf3, 0x60, 0x79, 0x73, 0x7e, 0x93, 0x8a, 0xce, 0x62, 0x15, 0x95, 0x40, 0xc5,
-	0x51, 0x1d, 0x4c, 0xa7, 0xdd, 0xd9, 0x63, 0x6a, 0x59, 0x87, 0xca, 0x61, 0xfb, 0xa7, 0xc6, 0x49,
-	0x9f, 0x67, 0xb5, 0x91, 0x06, 0xb5, 0xa7, 0x5d, 0xdc, 0xeb, 0x1e, 0xc8, 0x16, 0x15, 0x6d, 0x81,
-	0x26, 0x5b, 0xa6, 0xe3, 0xf2, 0x0c, 0x41, 0x7c, 0x16, 0x50, 0x19, 0xf2, 0xfd, 0x67, 0xed, 0x63,
-	0xad, 0xa8, 0xff, 0x57, 0x0e, 0x36, 0xc4, 0xb1, 0x10, 0xbf, 0xd8, 0xbf, 0xf9, 0xc5, 0x32, 0x99,
-	0xe5, 0xc9, 0xa5, 0xb3, 0x3c, 0x51, 0x10, 0xca, 0x4f, 0x75, 0x75, 0x1a, 0x84, 0xf2, 0xec, 0x50,
-	0xca, 0xe3, 0xe7, 0x97, 0xf1, 0xf8, 0x0d, 0x28, 0x39, 0x84, 0xc6, 0x7a, 0xab, 0xe0, 0x88, 0x44,
-	0x36, 0x54, 0x4d, 0xd7, 0xf5, 0x42, 0x53, 0xa4, 0x4e, 0x8b, 0x4b, 0x1d, 0x86, 0x33, 0xff, 0xb8,
-	0xd5, 0x9e, 0x22, 0x09, 0xc7, 0x9c, 0xc4, 0x6e, 0xfe, 0x04, 0xb4, 0xd9, 0x01, 0xcb, 0x1c, 0x87,
-	0xdf, 0xff, 0xe1, 0xf4, 0x34, 0x24, 0x6c, 0x5f, 0xc8, 0x37, 0x07, 0x6d, 0x8d, 0x11, 0xf8, 0xa4,
-	0xd7, 0xdb, 0xef, 0x3d, 0xd1, 0x14, 0x04, 0x50, 0xec, 0xfe, 0x74, 0x7f, 0xd0, 0xdd, 0xd5, 0x72,
-	0x3b, 0xbf, 0xde, 0x84, 0xa2, 0x60, 0x12, 0x7d, 0x2b, 0x23, 0x81, 0x64, 0x35, 0x28, 0xfa, 0xc9,
-	0xd2, 0x11, 0x75, 0xaa, 0xc2, 0xb4, 0xf9, 0x68, 0xe5, 0xf9, 0xf2, 0xf5, 0x6d, 0x0d, 0xfd, 0x8d,
-	0x02, 0xb5, 0xd4, 0xcb, 0x5b, 0xd6, 0xd4, 0xf1, 0x82, 0xe2, 0xd3, 0xe6, 0x8f, 0x57, 0x9a, 0x1b,
-	0xf3, 0xf2, 0x4b, 0x05, 0xaa, 0x89, 0xb2, 0x4b, 0xf4, 0x60, 0x95, 0x52, 0x4d, 0xc1, 0xc9, 0x67,
-	0xab, 0x57, 0x79, 0xea, 0x6b, 0x1f, 0x2b, 0xe8, 0xaf, 0x15, 0xa8, 0x26, 0x0a, 0x10, 0x33, 0xb3,
-	0x32, 0x5f, 0x2e, 0x99, 0x99, 0x95, 0x45, 0xf5, 0x8e, 0x6b, 0xe8, 0x2f, 0x15, 0xa8, 0xc4, 0xc5,
-	0x84, 0xe8, 0xfe, 0xf2, 0xe5, 0x87, 0x82, 0x89, 0x4f, 0x57, 0xad, 0x5b, 0xd4, 0xd7, 0xd0, 0x9f,
-	0x43, 0x39, 0xaa, 0xbc, 0x43, 0x59, 0x4f, 0xaf, 0x99, 0xb2, 0xbe, 0xe6, 0xfd, 0xa5, 0xe7, 0x25,
-	0x97, 0x8f, 0xca, 0xe1, 0x32, 0x2f, 0x3f, 0x53, 0xb8, 0xd7, 0xbc, 0xbf, 0xf4, 0xbc, 0x78, 0x79,
-	0x66, 0x09, 0x89, 0xaa, 0xb9, 0xcc, 0x96, 0x30, 0x5f, 0xae, 0x97, 0xd9, 0x12, 0x16, 0x15, 0xe9,
-	0x09, 0x46, 0x12, 0x75, 0x77, 0x99, 0x19, 0x99, 0xaf, 0xed, 0xcb, 0xcc, 0xc8, 0x82, 0x32, 0x3f,
-	0x7d, 0x0d, 0xfd, 0x42, 0x49, 0xde, 0x0b, 0xee, 0x2f, 0x5d, 0x5e, 0xb6, 0xa4, 0x49, 0xce, 0x15,
-	0xb8, 0xf1, 0x0d, 0xfa, 0x0b, 0x99, 0xc5, 0x10, 0xd5, 0x69, 0x68, 0x19, 0xb0, 0x54, 0x41, 0x5b,
-	0xf3, 0x93, 0xd5, 0x0e, 0x1b, 0xce, 0xc4, 0x5f, 0x29, 0x00, 0xd3, 0x3a, 0xb6, 0xcc, 0x4c, 0xcc,
-	0x15, 0xd0, 0x35, 0x1f, 0xac, 0x30, 0x33, 0xb9, 0x41, 0xa2, 0x3a, 0x9b, 0xcc, 0x1b, 0x64, 0xa6,
-	0xce, 0x2e, 0xf3, 0x06, 0x99, 0xad, 0x91, 0xd3, 0xd7, 0xd0, 0x3f, 0x29, 0xb0, 0x39, 0x57, 0xe7,
-	0x83, 0x1e, 0x5d, 0xb3, 0xd4, 0xab, 0xf9, 0xc5, 0xea, 0x00, 0x11, 0x6b, 0xdb, 0xca, 0xc7, 0x0a,
-	0xfa, 0x5b, 0x05, 0xd6, 0xd3, 0xf5, 0x0f, 0x99, 0x4f, 0xa9, 0x05, 0x15, 0x43, 0xcd, 0x87, 0xab,
-	0x4d, 0x8e, 0xa5, 0xf5, 0xf7, 0x0a, 0xd4, 0xd3, 0xa5, 0x30, 0xe8, 0xe1, 0x72, 0x6e, 0x61, 0x86,
-	0xa1, 0xcf, 0x57, 0x9c, 0x1d, 0x71, 0xf4, 0x65, 0xe9, 0x8f, 0x0b, 0x22, 0x7a, 0x2b, 0xf2, 0x9f,
-	0x1f, 0xfd, 0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x76, 0x8b, 0xb6, 0x52, 0xb4, 0x33, 0x00, 0x00,
+	// 3822 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x51, 0x6f, 0x1b, 0x49,
+	0x72, 0xf6, 0x70, 0x48, 0x8a, 0x2c, 0x4a, 0x14, 0xd5, 0x96, 0xbd, 0x34, 0x37, 0xc9, 0xfa, 0x26,
+	0xd8, 0x40, 0xb8, 0xdb, 0xa5, 0xf7, 0x74, 0xc8, 0x7a, 0xed, 0xf3, 0x9e, 0x97, 0xa6, 0x68, 0x4b,
+	0x6b, 0x89, 0x52, 0x9a, 0x14, 0x7c, 0x8e, 0x73, 0x3b, 0x19, 0x71, 0xda, 0xd4, 0x58, 0xe4, 0xcc,
+	0x78, 0x7a, 0x28, 0x4b, 0x17, 0x04, 0x09, 0x2e, 0x40, 0x70, 0x07, 0xe4, 0x70, 0x79, 0xd9, 0xdc,
+	0x4b, 0x9e, 0x0e, 0xc8, 0x53, 0xfe, 0x40, 0x90, 0xe0, 0x9e, 0xf2, 0x90, 0x3f, 0x91, 0x97, 0xbc,
+	0xe5, 0x35, 0xff, 0x20, 0xa8, 0xee, 0x9e, 0xe1, 0x8c, 0x28, 0xaf, 0x87, 0x94, 0x9f, 0xc8, 0xaa,
+	0xee, 0xfe, 0xba, 0xa6, 0xba, 0xba, 0xba, 0xba, 0xba, 0xc0, 0xf0, 0x47, 0x93, 0xa1, 0xe3, 0xf2,
+	0x3b, 0x76, 0xe0, 0x9c, 0xb2, 0x80, 0xdf, 0xf1, 0x03, 0x2f, 0xf4, 0x14, 0xd5, 0x14, 0x04, 0xf9,
+	0xf8, 0xd8, 0xe2, 0xc7, 0xce, 0xc0, 0x0b, 0xfc, 0xa6, 0xeb, 0x8d, 0x2d, 0xbb, 0xa9, 0xc6, 0x34,
+	0xd5, 0x18, 0xd9, 0xad, 0xf1, 0x47, 0x43, 0xcf, 0x1b, 0x8e, 0x98, 0x44, 0x38, 0x9a, 0xbc, 0xbc,
+	0x63, 0x4f, 0x02, 0x2b, 0x74, 0x3c, 0x57, 0xb5, 0x7f, 0x74, 0xb1, 0x3d, 0x74, 0xc6, 0x8c, 0x87,
+	0xd6, 0xd8, 0x57, 0x1d, 0x3e, 0x8e, 0x64, 0xe1, 0xc7, 0x56, 0xc0, 0xec, 0x3b, 0xc7, 0x83, 0x11,
+	0xf7, 0xd9, 0x00, 0x7f, 0x4d, 0xfc, 0xa3, 0xba, 0x7d, 0x72, 0xa1, 0x1b, 0x0f, 0x83, 0xc9, 0x20,
+	0x8c, 0x24, 0xb7, 0xc2, 0x30, 0x70, 0x8e, 0x26, 0x21, 0x93, 0xbd, 0x8d, 0x5b, 0xf0, 0x41, 0xdf,
+	0xe2, 0x27, 0x6d, 0xcf, 0x7d, 0xe9, 0x0c, 0x7b, 0x83, 0x63, 0x36, 0xb6, 0x28, 0x7b, 0x3d, 0x61,
+	0x3c, 0x34, 0xfe, 0x02, 0xea, 0xb3, 0x4d, 0xdc, 0xf7, 0x5c, 0xce, 0xc8, 0x57, 0x90, 0xc7, 0x29,
+	0xeb, 0xda, 0x6d, 0x6d, 0xa3, 0xb2, 0xf9, 0x49, 0xf3, 0x6d, 0x2a, 0x90, 0x32, 0x34, 0x95, 0xa8,
+	0xcd, 0x9e, 0xcf, 0x06, 0x54, 0x8c, 0x34, 0x6e, 0xc0, 0xf5, 0xb6, 0xe5, 0x5b, 0x47, 0xce, 0xc8,
+	0x09, 0x1d, 0xc6, 0xa3, 0x49, 0x27, 0xb0, 0x9e, 0x66, 0xab, 0x09, 0x7f, 0x06, 0xcb, 0x83, 0x04,
+	0x5f, 0x4d, 0x7c, 0xaf, 0x99, 0x49, 0xf7, 0xcd, 0x2d, 0x41, 0xa5, 0x80, 0x53, 0x70, 0xc6, 0x3a,
+	0x90, 0xc7, 0x8e, 0x3b, 0x64, 0x81, 0x1f, 0x38, 0x6e, 0x18, 0x09, 0xf3, 0x7b, 0x1d, 0xae, 0xa7,
+	0xd8, 0x4a, 0x98, 0x57, 0x00, 0xb1, 0x1e, 0x51, 0x14, 0x7d, 0xa3, 0xb2, 0xf9, 0x75, 0x46, 0x51,
+	0x2e, 0xc1, 0x6b, 0xb6, 0x62, 0xb0, 0x8e, 0x1b, 0x06, 0xe7, 0x34, 0x81, 0x4e, 0xbe, 0x81, 0xe2,
+	0x31, 0xb3, 0x46, 0xe1, 0x71, 0x3d, 0x77, 0x5b, 0xdb, 0xa8, 0x6e, 0x3e, 0xbe, 0xc2, 0x3c, 0xdb,
+	0x02, 0xa8, 0x17, 0x5a, 0x21, 0xa3, 0x0a, 0x95, 0x7c, 0x0a, 0x44, 0xfe, 0x33, 0x6d, 0xc6, 0x07,
+	0x81, 0xe3, 0xa3, 0x49, 0xd6, 0xf5, 0xdb, 0xda, 0x46, 0x99, 0xae, 0xc9, 0x96, 0xad, 0x69, 0x43,
+	0xc3, 0x87, 0xd5, 0x0b, 0xd2, 0x92, 0x1a, 0xe8, 0x27, 0xec, 0x5c, 0xac, 0x48, 0x99, 0xe2, 0x5f,
+	0xf2, 0x04, 0x0a, 0xa7, 0xd6, 0x68, 0xc2, 0x84, 0xc8, 0x95, 0xcd, 0x1f, 0xbe, 0xcb, 0x3c, 0x94,
+	0x89, 0x4e, 0xf5, 0x40, 0xe5, 0xf8, 0xfb, 0xb9, 0x2f, 0x34, 0xe3, 0x1e, 0x54, 0x12, 0x72, 0x93,
+	0x2a, 0xc0, 0x61, 0x77, 0xab, 0xd3, 0xef, 0xb4, 0xfb, 0x9d, 0xad, 0xda, 0x35, 0xb2, 0x02, 0xe5,
+	0xc3, 0xee, 0x76, 0xa7, 0xb5, 0xdb, 0xdf, 0x7e, 0x5e, 0xd3, 0x48, 0x05, 0x96, 0x22, 0x22, 0x67,
+	0x9c, 0x01, 0xa1, 0x6c, 0xe0, 0x9d, 0xb2, 0x00, 0x0d, 0x59, 0xad, 0x2a, 0xf9, 0x00, 0x96, 0x42,
+	0x8b, 0x9f, 0x98, 0x8e, 0xad, 0x64, 0x2e, 0x22, 0xb9, 0x63, 0x93, 0x1d, 0x28, 0x1e, 0x5b, 0xae,
+	0x3d, 0x7a, 0xb7, 0xdc, 0x69, 0x55, 0x23, 0xf8, 0xb6, 0x18, 0x48, 0x15, 0x00, 0x5a, 0x77, 0x6a,
+	0x66, 0xb9, 0x00, 0xc6, 0x73, 0xa8, 0xf5, 0x42, 0x2b, 0x08, 0x93, 0xe2, 0x74, 0x20, 0x8f, 0xf3,
+	0xd7, 0xb5, 0xb9, 0xe7, 0x94, 0x3b, 0x93, 0x8a, 0xe1, 0xc6, 0xff, 0xe5, 0x60, 0x2d, 0x81, 0xad,
+	0x2c, 0xf5, 0x19, 0x14, 0x03, 0xc6, 0x27, 0xa3, 0x50, 0xc0, 0x57, 0x37, 0x1f, 0x66, 0x84, 0x9f,
+	0x41, 0x6a, 0x52, 0x01, 0x43, 0x15, 0x1c, 0xd9, 0x80, 0x9a, 0x1c, 0x61, 0xb2, 0x20, 0xf0, 0x02,
+	0x73, 0xcc, 0x87, 0x42, 0x6b, 0x65, 0x5a, 0x95, 0xfc, 0x0e, 0xb2, 0xf7, 0xf8, 0x30, 0xa1, 0x55,
+	0xfd, 0x8a, 0x5a, 0x25, 0x16, 0xd4, 0x5c, 0x16, 0xbe, 0xf1, 0x82, 0x13, 0x13, 0x55, 0x1b, 0x38,
+	0x36, 0xab, 0xe7, 0x05, 0xe8, 0xe7, 0x19, 0x41, 0xbb, 0x72, 0xf8, 0xbe, 0x1a, 0x4d, 0x57, 0xdd,
+	0x34, 0xc3, 0xf8, 0x01, 0x14, 0xe5, 0x97, 0xa2, 0x25, 0xf5, 0x0e, 0xdb, 0xed, 0x4e, 0xaf, 0x57,
+	0xbb, 0x46, 0xca, 0x50, 0xa0, 0x9d, 0x3e, 0x45, 0x0b, 0x2b, 0x43, 0xe1, 0x71, 0xab, 0xdf, 0xda,
+	0xad, 0xe5, 0x8c, 0xef, 0xc3, 0xea, 0x33, 0xcb, 0x09, 0xb3, 0x18, 0x97, 0xe1, 0x41, 0x6d, 0xda,
+	0x57, 0xad, 0xce, 0x4e, 0x6a, 0x75, 0xb2, 0xab, 0xa6, 0x73, 0xe6, 0x84, 0x17, 0xd6, 0xa3, 0x06,
+	0x3a, 0x0b, 0x02, 0xb5, 0x04, 0xf8, 0xd7, 0x78, 0x03, 0xab, 0xbd, 0xd0, 0xf3, 0x33, 0x59, 0xfe,
+	0x8f, 0x60, 0x09, 0x4f, 0x1b, 0x6f, 0x12, 0x2a, 0xd3, 0xbf, 0xd5, 0x94, 0xa7, 0x51, 0x33, 0x3a,
+	0x8d, 0x9a, 0x5b, 0xea, 0xb4, 0xa2, 0x51, 0x4f, 0x72, 0x13, 0x8a, 0xdc, 0x19, 0xba, 0xd6, 0x48,
+	0x79, 0x0b, 0x45, 0x19, 0x04, 0x6a, 0xd3, 0x89, 0x95, 0xe1, 0xb7, 0x81, 0x6c, 0x31, 0x1e, 0x06,
+	0xde, 0x79, 0x26, 0x79, 0xd6, 0xa1, 0xf0, 0xd2, 0x0b, 0x06, 0x72, 0x23, 0x96, 0xa8, 0x24, 0x70,
+	0x53, 0xa5, 0x40, 0x14, 0xf6, 0xa7, 0x40, 0x76, 0x5c, 0x3c, 0x53, 0xb2, 0x2d, 0xc4, 0x3f, 0xe6,
+	0xe0, 0x7a, 0xaa, 0xbf, 0x5a, 0x8c, 0xc5, 0xf7, 0x21, 0x3a, 0xa6, 0x09, 0x97, 0xfb, 0x90, 0xec,
+	0x43, 0x51, 0xf6, 0x50, 0x9a, 0xbc, 0x3b, 0x07, 0x90, 0x3c, 0xa6, 0x14, 0x9c, 0x82, 0xb9, 0xd4,
+	0xe8, 0xf5, 0xf7, 0x6b, 0xf4, 0x6f, 0xa0, 0x16, 0x7d, 0x07, 0x7f, 0xe7, 0xda, 0x7c, 0x0d, 0xd7,
+	0x07, 0xde, 0x68, 0xc4, 0x06, 0x68, 0x0d, 0xa6, 0xe3, 0x86, 0x2c, 0x38, 0xb5, 0x46, 0xef, 0xb6,
+	0x1b, 0x32, 0x1d, 0xb5, 0xa3, 0x06, 0x19, 0x2f, 0x60, 0x2d, 0x31, 0xb1, 0x5a, 0x88, 0xc7, 0x50,
+	0xe0, 0xc8, 0x50, 0x2b, 0xf1, 0xd9, 0x9c, 0x2b, 0xc1, 0xa9, 0x1c, 0x6e, 0x5c, 0x97, 0xe0, 0x9d,
+	0x53, 0xe6, 0xc6, 0x9f, 0x65, 0x6c, 0xc1, 0x5a, 0x4f, 0x98, 0x69, 0x26, 0x3b, 0x9c, 0x9a, 0x78,
+	0x2e, 0x65, 0xe2, 0xeb, 0x40, 0x92, 0x28, 0xca, 0x10, 0xcf, 0x61, 0xb5, 0x73, 0xc6, 0x06, 0x99,
+	0x90, 0xeb, 0xb0, 0x34, 0xf0, 0xc6, 0x63, 0xcb, 0xb5, 0xeb, 0xb9, 0xdb, 0xfa, 0x46, 0x99, 0x46,
+	0x64, 0x72, 0x2f, 0xea, 0x59, 0xf7, 0xa2, 0xf1, 0x6b, 0x0d, 0x6a, 0xd3, 0xb9, 0x95, 0x22, 0x51,
+	0xfa, 0xd0, 0x46, 0x20, 0x9c, 0x7b, 0x99, 0x2a, 0x4a, 0xf1, 0x23, 0x77, 0x21, 0xf9, 0x2c, 0x08,
+	0x12, 0xee, 0x48, 0xbf, 0xa2, 0x3b, 0x32, 0xb6, 0xe1, 0x0f, 0x22, 0x71, 0x7a, 0x61, 0xc0, 0xac,
+	0xb1, 0xe3, 0x0e, 0x77, 0xf6, 0xf7, 0x7d, 0x26, 0x05, 0x27, 0x04, 0xf2, 0xb6, 0x15, 0x5a, 0x4a,
+	0x30, 0xf1, 0x1f, 0x37, 0xfd, 0x60, 0xe4, 0xf1, 0x78, 0xd3, 0x0b, 0xc2, 0xf8, 0x2f, 0x1d, 0xea,
+	0x33, 0x50, 0x91, 0x7a, 0x5f, 0x40, 0x81, 0xb3, 0x70, 0xe2, 0x2b, 0x53, 0xe9, 0x64, 0x16, 0xf8,
+	0x72, 0xbc, 0x66, 0x0f, 0xc1, 0xa8, 0xc4, 0x24, 0x43, 0x28, 0x85, 0xe1, 0xb9, 0xc9, 0x9d, 0x9f,
+	0x47, 0x01, 0xc1, 0xee, 0x55, 0xf1, 0xfb, 0x2c, 0x18, 0x3b, 0xae, 0x35, 0xea, 0x39, 0x3f, 0x67,
+	0x74, 0x29, 0x0c, 0xcf, 0xf1, 0x0f, 0x79, 0x8e, 0x06, 0x6f, 0x3b, 0xae, 0x52, 0x7b, 0x7b, 0xd1,
+	0x59, 0x12, 0x0a, 0xa6, 0x12, 0xb1, 0xb1, 0x0b, 0x05, 0xf1, 0x4d, 0x8b, 0x18, 0x62, 0x0d, 0xf4,
+	0x30, 0x3c, 0x17, 0x42, 0x95, 0x28, 0xfe, 0x6d, 0x3c, 0x80, 0xe5, 0xe4, 0x17, 0xa0, 0x21, 0x1d,
+	0x33, 0x67, 0x78, 0x2c, 0x0d, 0xac, 0x40, 0x15, 0x85, 0x2b, 0xf9, 0xc6, 0xb1, 0x55, 0xc8, 0x5a,
+	0xa0, 0x92, 0x30, 0xfe, 0x2d, 0x07, 0xb7, 0x2e, 0xd1, 0x8c, 0x32, 0xd6, 0x17, 0x29, 0x63, 0x7d,
+	0x4f, 0x5a, 0x88, 0x2c, 0xfe, 0x45, 0xca, 0xe2, 0xdf, 0x23, 0x38, 0x6e, 0x9b, 0x9b, 0x50, 0x64,
+	0x67, 0x4e, 0xc8, 0x6c, 0xa5, 0x2a, 0x45, 0x25, 0xb6, 0x53, 0xfe, 0xaa, 0xdb, 0x69, 0x0f, 0xd6,
+	0xdb, 0x01, 0xb3, 0x42, 0xa6, 0x5c, 0x79, 0x64, 0xff, 0xb7, 0xa0, 0x64, 0x8d, 0x46, 0xde, 0x60,
+	0xba, 0xac, 0x4b, 0x82, 0xde, 0xb1, 0x49, 0x03, 0x4a, 0xc7, 0x1e, 0x0f, 0x5d, 0x6b, 0xcc, 0x94,
+	0xf3, 0x8a, 0x69, 0xe3, 0x5b, 0x0d, 0x6e, 0x5c, 0xc0, 0x53, 0xab, 0x70, 0x04, 0x55, 0x87, 0x7b,
+	0x23, 0xf1, 0x81, 0x66, 0xe2, 0x86, 0xf7, 0xe3, 0xf9, 0x8e, 0x9a, 0x9d, 0x08, 0x43, 0x5c, 0xf8,
+	0x56, 0x9c, 0x24, 0x29, 0x2c, 0x4e, 0x4c, 0x6e, 0xab, 0x9d, 0x1e, 0x91, 0xc6, 0x3f, 0x69, 0x70,
+	0x43, 0x9d, 0xf0, 0xd9, 0x3f, 0x74, 0x56, 0xe4, 0xdc, 0xfb, 0x16, 0xd9, 0xa8, 0xc3, 0xcd, 0x8b,
+	0x72, 0x29, 0x9f, 0xff, 0xeb, 0x02, 0x90, 0xd9, 0xdb, 0x25, 0xf9, 0x1e, 0x2c, 0x73, 0xe6, 0xda,
+	0xa6, 0x3c, 0x2f, 0xe4, 0x51, 0x56, 0xa2, 0x15, 0xe4, 0xc9, 0x83, 0x83, 0xa3, 0x0b, 0x64, 0x67,
+	0x4a, 0xda, 0x12, 0x15, 0xff, 0xc9, 0x31, 0x2c, 0xbf, 0xe4, 0x66, 0x3c, 0xb7, 0x30, 0xa8, 0x6a,
+	0x66, 0xb7, 0x36, 0x2b, 0x47, 0xf3, 0x71, 0x2f, 0xfe, 0x2e, 0x5a, 0x79, 0xc9, 0x63, 0x82, 0xfc,
+	0x52, 0x83, 0x0f, 0xa2, 0xb0, 0x62, 0xaa, 0xbe, 0xb1, 0x67, 0x33, 0x5e, 0xcf, 0xdf, 0xd6, 0x37,
+	0xaa, 0x9b, 0x07, 0x57, 0xd0, 0xdf, 0x0c, 0x73, 0xcf, 0xb3, 0x19, 0xbd, 0xe1, 0x5e, 0xc2, 0xe5,
+	0xa4, 0x09, 0xd7, 0xc7, 0x13, 0x1e, 0x9a, 0xd2, 0x0a, 0x4c, 0xd5, 0xa9, 0x5e, 0x10, 0x7a, 0x59,
+	0xc3, 0xa6, 0x94, 0xad, 0x92, 0x13, 0x58, 0x19, 0x7b, 0x13, 0x37, 0x34, 0x07, 0xe2, 0xfe, 0xc3,
+	0xeb, 0xc5, 0xb9, 0x2e, 0xc6, 0x97, 0x68, 0x69, 0x0f, 0xe1, 0xe4, 0x6d, 0x8a, 0xd3, 0xe5, 0x71,
+	0x82, 0xc2, 0x85, 0x0c, 0xd8, 0xd8, 0x0b, 0x99, 0x89, 0xfe, 0x92, 0xd7, 0x97, 0xe4, 0x42, 0x4a,
+	0x1e, 0xba, 0x06, 0x8e, 0xde, 0x4e, 0xba, 0xef, 0x92, 0x3c, 0xb7, 0x04, 0x61, 0x34, 0xa1, 0x92,
+	0x50, 0x3e, 0x29, 0x41, 0xbe, 0xbb, 0xdf, 0xed, 0xd4, 0xae, 0x11, 0x80, 0x62, 0x7b, 0x9b, 0xee,
+	0xef, 0xf7, 0xe5, 0x5d, 0x62, 0x67, 0xaf, 0xf5, 0xa4, 0x53, 0xcb, 0x19, 0x1d, 0x58, 0x4e, 0x8a,
+	0x41, 0x08, 0x54, 0x0f, 0xbb, 0x4f, 0xbb, 0xfb, 0xcf, 0xba, 0xe6, 0xde, 0xfe, 0x61, 0xb7, 0x8f,
+	0xb7, 0x90, 0x2a, 0x40, 0xab, 0xfb, 0x7c, 0x4a, 0xaf, 0x40, 0xb9, 0xbb, 0x1f, 0x91, 0x5a, 0x23,
+	0x57, 0xd3, 0x8c, 0xff, 0xd4, 0x61, 0xfd, 0xb2, 0x15, 0x21, 0x36, 0xe4, 0x71, 0x75, 0xd5, 0x3d,
+	0xf0, 0xfd, 0x2f, 0xae, 0x40, 0x47, 0xa3, 0xf6, 0x2d, 0xe5, 0xf8, 0xcb, 0x54, 0xfc, 0x27, 0x26,
+	0x14, 0x47, 0xd6, 0x11, 0x1b, 0xf1, 0xba, 0x2e, 0x32, 0x25, 0x4f, 0xae, 0x32, 0xf7, 0xae, 0x40,
+	0x92, 0x69, 0x12, 0x05, 0x4b, 0xfa, 0x50, 0x41, 0xd7, 0xc6, 0xa5, 0xea, 0x94, 0xb7, 0xdd, 0xcc,
+	0x38, 0xcb, 0xf6, 0x74, 0x24, 0x4d, 0xc2, 0x34, 0xee, 0x41, 0x25, 0x31, 0xd9, 0x25, 0x59, 0x8e,
+	0xf5, 0x64, 0x96, 0xa3, 0x9c, 0x4c, 0x59, 0x3c, 0x84, 0xf5, 0xcb, 0x74, 0x84, 0x46, 0xb0, 0xbd,
+	0xdf, 0xeb, 0xcb, 0xfb, 0xe4, 0x13, 0xba, 0x7f, 0x78, 0x50, 0xd3, 0x90, 0xd9, 0x6f, 0xf5, 0x9e,
+	0xd6, 0x72, 0xb1, 0x8d, 0xe8, 0x46, 0x1b, 0x2a, 0x09, 0xb9, 0x52, 0xbe, 0x5c, 0x4b, 0xfb, 0x72,
+	0xf4, 0xa6, 0x96, 0x6d, 0x07, 0x8c, 0x73, 0x25, 0x47, 0x44, 0x1a, 0x2f, 0xa0, 0xbc, 0xd5, 0xed,
+	0x29, 0x88, 0x3a, 0x2c, 0x71, 0x16, 0xe0, 0x77, 0x8b, 0x7c, 0x55, 0x99, 0x46, 0x24, 0x82, 0x73,
+	0x66, 0x05, 0x83, 0x63, 0xc6, 0x55, 0x04, 0x10, 0xd3, 0x38, 0xca, 0x13, 0x79, 0x1f, 0xb9, 0x76,
+	0x65, 0x1a, 0x91, 0xc6, 0x6f, 0x4a, 0x00, 0xd3, 0x1c, 0x04, 0xa9, 0x42, 0x2e, 0xf6, 0xcc, 0x39,
+	0xc7, 0x46, 0x3b, 0x48, 0x9c, 0x3c, 0xe2, 0x3f, 0xd9, 0x84, 0x1b, 0x63, 0x3e, 0xf4, 0xad, 0xc1,
+	0x89, 0xa9, 0x52, 0x07, 0x72, 0x03, 0x0b, 0x2f, 0xb7, 0x4c, 0xaf, 0xab, 0x46, 0xb5, 0x3f, 0x25,
+	0xee, 0x2e, 0xe8, 0xcc, 0x3d, 0x15, 0x1e, 0xa9, 0xb2, 0x79, 0x7f, 0xee, 0xdc, 0x48, 0xb3, 0xe3,
+	0x9e, 0x4a, 0x5b, 0x41, 0x18, 0x62, 0x02, 0xd8, 0xec, 0xd4, 0x19, 0x30, 0x13, 0x41, 0x0b, 0x02,
+	0xf4, 0xab, 0xf9, 0x41, 0xb7, 0x04, 0x46, 0x0c, 0x5d, 0xb6, 0x23, 0x9a, 0x74, 0xa1, 0x1c, 0x30,
+	0xee, 0x4d, 0x82, 0x01, 0x93, 0x6e, 0x29, 0xfb, 0xf5, 0x85, 0x46, 0xe3, 0xe8, 0x14, 0x82, 0x6c,
+	0x41, 0x51, 0x78, 0x23, 0xf4, 0x3b, 0xfa, 0x77, 0x26, 0x5a, 0xd3, 0x60, 0xc2, 0x93, 0x50, 0x35,
+	0x96, 0x3c, 0x81, 0x25, 0x29, 0x22, 0xaf, 0x97, 0x04, 0xcc, 0xa7, 0x59, 0x5d, 0xa5, 0x18, 0x45,
+	0xa3, 0xd1, 0xb8, 0xaa, 0x13, 0xce, 0x82, 0x7a, 0x59, 0xae, 0x2a, 0xfe, 0x27, 0x1f, 0x42, 0x59,
+	0x9e, 0xcc, 0xb6, 0x13, 0xd4, 0x41, 0x1a, 0xa7, 0x60, 0x6c, 0x39, 0x01, 0xf9, 0x08, 0x2a, 0x32,
+	0x02, 0x33, 0x85, 0x57, 0xa8, 0x88, 0x66, 0x90, 0xac, 0x03, 0xf4, 0x0d, 0xb2, 0x03, 0x0b, 0x02,
+	0xd9, 0x61, 0x39, 0xee, 0xc0, 0x82, 0x40, 0x74, 0xf8, 0x13, 0x58, 0x15, 0x71, 0xeb, 0x30, 0xf0,
+	0x26, 0xbe, 0x29, 0x6c, 0x6a, 0x45, 0x74, 0x5a, 0x41, 0xf6, 0x13, 0xe4, 0x76, 0xd1, 0xb8, 0x6e,
+	0x41, 0xe9, 0x95, 0x77, 0x24, 0x3b, 0x54, 0xe5, 0x3e, 0x78, 0xe5, 0x1d, 0x45, 0x4d, 0x71, 0xec,
+	0xb0, 0x9a, 0x8e, 0x1d, 0x5e, 0xc3, 0xcd, 0xd9, 0x43, 0x50, 0xc4, 0x10, 0xb5, 0xab, 0xc7, 0x10,
+	0xeb, 0xee, 0x25, 0x5c, 0xf2, 0x08, 0x74, 0xdb, 0xe5, 0xf5, 0xb5, 0xb9, 0x8c, 0x23, 0xde, 0xc7,
+	0x14, 0x07, 0x93, 0x3f, 0x04, 0x10, 0x87, 0x8c, 0x54, 0x1a, 0x11, 0xdf, 0x54, 0x16, 0x1c, 0xd4,
+	0x59, 0xe3, 0x73, 0x28, 0x45, 0xc6, 0x39, 0x8f, 0xdb, 0x6a, 0x3c, 0x80, 0x6a, 0xda, 0xb4, 0xe7,
+	0x72, 0x7a, 0xff, 0x92, 0x83, 0x72, 0x6c, 0xc4, 0xc4, 0x85, 0xeb, 0x42, 0xc9, 0x56, 0xc8, 0x6c,
+	0x73, 0xba, 0x27, 0x64, 0x34, 0xf9, 0x65, 0xc6, 0xcf, 0x6e, 0x45, 0x08, 0xea, 0x5a, 0xab, 0x36,
+	0x08, 0x89, 0x91, 0xa7, 0xf3, 0x7d, 0x03, 0xab, 0x23, 0xc7, 0x9d, 0x9c, 0x25, 0xe6, 0x92, 0x61,
+	0xe0, 0x9f, 0x66, 0x9c, 0x6b, 0x17, 0x47, 0x4f, 0xe7, 0xa8, 0x8e, 0x52, 0x34, 0xd9, 0x86, 0x82,
+	0xef, 0x05, 0x61, 0x74, 0x86, 0x65, 0x3d, 0x5d, 0x0e, 0xbc, 0x20, 0xdc, 0xb3, 0x7c, 0x1f, 0x6f,
+	0x3a, 0x12, 0xc0, 0xf8, 0x36, 0x07, 0x37, 0x2f, 0xff, 0x30, 0xd2, 0x05, 0x7d, 0xe0, 0x4f, 0x94,
+	0x92, 0x1e, 0xcc, 0xab, 0xa4, 0xb6, 0x3f, 0x99, 0xca, 0x8f, 0x40, 0x98, 0xfd, 0x1d, 0xb3, 0xb1,
+	0x17, 0x9c, 0x2b, 0x5d, 0x3c, 0x9c, 0x17, 0x72, 0x4f, 0x8c, 0x9e, 0xa2, 0x2a, 0x38, 0x42, 0xa1,
+	0xa4, 0x8c, 0x9b, 0x2b, 0x37, 0x3a, 0x67, 0x2e, 0x2a, 0x82, 0xa4, 0x31, 0x8e, 0xf1, 0x39, 0xdc,
+	0xb8, 0xf4, 0x53, 0xd0, 0xda, 0x07, 0xfe, 0xc4, 0x14, 0x6f, 0x05, 0xd2, 0x82, 0x74, 0x5a, 0x1e,
+	0xf8, 0x93, 0x9e, 0x60, 0x18, 0x2f, 0xa0, 0xfe, 0x36, 0x79, 0xd1, 0x39, 0x49, 0x89, 0xcd, 0xf1,
+	0x91, 0xd0, 0x81, 0x4e, 0x4b, 0x92, 0xb1, 0x77, 0x44, 0x0c, 0x58, 0x89, 0x1a, 0xad, 0x33, 0xec,
+	0xa0, 0x8b, 0x0e, 0x15, 0xd5, 0xc1, 0x3a, 0xdb, 0x3b, 0x32, 0x7e, 0x9b, 0x83, 0xd5, 0x0b, 0x22,
+	0xe3, 0x7d, 0x4f, 0x3a, 0xc4, 0xe8, 0x26, 0x2d, 0x29, 0xf4, 0x8e, 0x03, 0xc7, 0x8e, 0x72, 0xb0,
+	0xe2, 0xbf, 0x38, 0x17, 0x7d, 0x95, 0x1f, 0xcd, 0x39, 0x3e, 0x6e, 0x9f, 0xf1, 0x91, 0x13, 0x72,
+	0x11, 0xa4, 0x14, 0xa8, 0x24, 0xc8, 0x73, 0xa8, 0x06, 0x4c, 0x9c, 0xc7, 0xb6, 0x29, 0xad, 0xac,
+	0x30, 0x97, 0x95, 0x29, 0x09, 0xd1, 0xd8, 0xe8, 0x4a, 0x84, 0x84, 0x14, 0x27, 0xcf, 0x60, 0xc5,
+	0x3e, 0x77, 0xad, 0xb1, 0x33, 0x50, 0xc8, 0xc5, 0x85, 0x91, 0x97, 0x15, 0x90, 0x00, 0xc6, 0x67,
+	0x99, 0x44, 0x23, 0x7e, 0x98, 0x88, 0xc6, 0x94, 0x4e, 0x24, 0x91, 0xf6, 0x16, 0x05, 0xe5, 0x2d,
+	0x8c, 0x23, 0xa8, 0x24, 0xf6, 0xc5, 0x3c, 0x43, 0x51, 0x9f, 0xa1, 0x27, 0xf4, 0x59, 0xa0, 0xb9,
+	0xd0, 0xc3, 0xb4, 0x06, 0x46, 0x42, 0xa6, 0xe3, 0x0b, 0x8d, 0x96, 0x69, 0x11, 0xc9, 0x1d, 0xdf,
+	0xf8, 0x95, 0x0e, 0xd5, 0xf4, 0x96, 0x8e, 0xec, 0xc8, 0x67, 0x81, 0xe3, 0xd9, 0x09, 0x3b, 0x3a,
+	0x10, 0x0c, 0xb4, 0x15, 0x6c, 0x7e, 0x3d, 0xf1, 0x42, 0x2b, 0xb2, 0x95, 0x81, 0x3f, 0xf9, 0x33,
+	0xa4, 0x2f, 0xd8, 0xa0, 0x7e, 0xc1, 0x06, 0xc9, 0x27, 0x40, 0x94, 0x29, 0x8d, 0x9c, 0xb1, 0x13,
+	0x9a, 0x47, 0xe7, 0x21, 0x93, 0x6b, 0xac, 0xd3, 0x9a, 0x6c, 0xd9, 0xc5, 0x86, 0x47, 0xc8, 0x47,
+	0xc3, 0xf3, 0xbc, 0xb1, 0xc9, 0x07, 0x5e, 0xc0, 0x4c, 0xcb, 0x7e, 0x25, 0xae, 0x3a, 0x3a, 0xad,
+	0x78, 0xde, 0xb8, 0x87, 0xbc, 0x96, 0xfd, 0x0a, 0x0f, 0xc6, 0x81, 0x3f, 0xe1, 0x2c, 0x34, 0xf1,
+	0x47, 0xc4, 0x12, 0x65, 0x0a, 0x92, 0xd5, 0xf6, 0x27, 0x9c, 0xfc, 0x31, 0xac, 0x44, 0x1d, 0xc4,
+	0xd9, 0xa8, 0x0e, 0xe5, 0x65, 0xd5, 0x45, 0xf0, 0x88, 0x01, 0xcb, 0x07, 0x2c, 0x18, 0x30, 0x37,
+	0xec, 0x3b, 0x83, 0x13, 0x2e, 0x6e, 0x28, 0x1a, 0x4d, 0xf1, 0xf0, 0xbb, 0x6d, 0x07, 0x33, 0x43,
+	0x9e, 0xcf, 0xc5, 0x01, 0xae, 0xd3, 0x12, 0x32, 0x76, 0x3c, 0x9f, 0x93, 0xcf, 0x60, 0x5d, 0x34,
+	0x1e, 0x59, 0xae, 0x2d, 0xb2, 0x38, 0xea, 0xd3, 0x2a, 0xa2, 0x1f, 0xc1, 0xb6, 0x47, 0x51, 0x93,
+	0xf8, 0xb8, 0xaf, 0xf3, 0xa5, 0xa5, 0x5a, 0x89, 0x46, 0xc2, 0x8f, 0xd9, 0x98, 0x1b, 0x3f, 0x83,
+	0x82, 0x08, 0x48, 0x70, 0x2a, 0x71, 0x98, 0x8b, 0x63, 0x4b, 0x05, 0xb2, 0xc8, 0x10, 0x27, 0xfd,
+	0x87, 0x50, 0x16, 0x4b, 0x99, 0xb8, 0x3f, 0x88, 0x28, 0x57, 0x34, 0x36, 0xa0, 0x14, 0x30, 0xcb,
+	0xf6, 0xdc, 0x51, 0x94, 0x90, 0x8a, 0x69, 0xe3, 0x35, 0x14, 0xe5, 0xb1, 0x75, 0x05, 0xfc, 0x4f,
+	0x81, 0x48, 0x35, 0xa2, 0x79, 0x8c, 0x1d, 0xce, 0x55, 0xcc, 0x2b, 0x5e, 0x41, 0x65, 0xcb, 0xc1,
+	0xb4, 0xc1, 0xf8, 0x6f, 0x0d, 0x60, 0xfa, 0x3e, 0x85, 0x61, 0x32, 0xee, 0x19, 0xbc, 0xb1, 0xcb,
+	0x44, 0x58, 0x44, 0x62, 0x0e, 0x48, 0x05, 0xb9, 0xb9, 0x45, 0x9f, 0xf7, 0x14, 0x40, 0x94, 0x16,
+	0x67, 0x2a, 0x29, 0x30, 0x6f, 0x5a, 0x9c, 0xc9, 0xb4, 0x38, 0xc3, 0x1b, 0xad, 0x0a, 0xbf, 0x25,
+	0x5c, 0x5e, 0x44, 0xdf, 0x15, 0x3b, 0x7e, 0x7b, 0x60, 0xc6, 0xff, 0x6a, 0xb1, 0xd7, 0x8b, 0xde,
+	0x08, 0xc8, 0x37, 0x50, 0x42, 0x07, 0x62, 0x8e, 0x2d, 0x5f, 0xbd, 0x78, 0xb7, 0x17, 0x7b, 0x7e,
+	0x88, 0xce, 0x44, 0x19, 0x3c, 0x2f, 0xf9, 0x92, 0x42, 0xef, 0x89, 0x17, 0x97, 0xc8, 0x7b, 0xe2,
+	0x7f, 0xf2, 0x31, 0x54, 0xad, 0x49, 0xe8, 0x99, 0x96, 0x7d, 0xca, 0x82, 0xd0, 0xe1, 0x4c, 0xad,
+	0xfd, 0x0a, 0x72, 0x5b, 0x11, 0xb3, 0x71, 0x1f, 0x96, 0x93, 0x98, 0xef, 0x8a, 0x5a, 0x0a, 0xc9,
+	0xa8, 0xe5, 0x2f, 0x01, 0xa6, 0xf9, 0x36, 0xb4, 0x11, 0x4c, 0xde, 0x99, 0x83, 0xe8, 0xa6, 0x5c,
+	0xa0, 0x25, 0x64, 0xb4, 0xf1, 0xf6, 0x96, 0x7e, 0x0c, 0x28, 0x44, 0x8f, 0x01, 0xe8, 0x1b, 0x70,
+	0x3b, 0x9f, 0x38, 0xa3, 0x51, 0x9c, 0x03, 0x2c, 0x7b, 0xde, 0xf8, 0xa9, 0x60, 0x18, 0xbf, 0xcf,
+	0x49, 0x5b, 0x91, 0xcf, 0x3a, 0x99, 0x6e, 0x4a, 0xef, 0x6b, 0xa9, 0xef, 0x61, 0x9c, 0x68, 0x05,
+	0x18, 0x82, 0x59, 0x51, 0x16, 0xb2, 0x31, 0xf3, 0x9a, 0xd0, 0x8f, 0xea, 0x4c, 0x68, 0x59, 0xf5,
+	0x6e, 0x85, 0xe4, 0x4b, 0x58, 0x1e, 0x78, 0x63, 0x7f, 0xc4, 0xd4, 0xe0, 0xc2, 0x3b, 0x07, 0x57,
+	0xe2, 0xfe, 0xad, 0x30, 0x91, 0xfb, 0x2c, 0x5e, 0x35, 0xf7, 0xf9, 0xef, 0x9a, 0x7c, 0x9d, 0x4a,
+	0x3e, 0x8e, 0x91, 0xe1, 0x25, 0x15, 0x18, 0x4f, 0x16, 0x7c, 0x69, 0xfb, 0xae, 0xf2, 0x8b, 0xc6,
+	0x97, 0x59, 0xea, 0x1d, 0xde, 0x1e, 0x14, 0xff, 0x87, 0x0e, 0xe5, 0x68, 0x59, 0x66, 0xd7, 0xfe,
+	0x0b, 0x28, 0xc7, 0x45, 0x3e, 0xf5, 0xdc, 0x3b, 0x35, 0x3c, 0xed, 0x4c, 0x5e, 0x02, 0xb1, 0x86,
+	0xc3, 0x38, 0xd8, 0x35, 0x27, 0xdc, 0x1a, 0x46, 0xcf, 0x82, 0x5f, 0xcc, 0xa1, 0x87, 0xe8, 0x74,
+	0x3c, 0xc4, 0xf1, 0xb4, 0x66, 0x0d, 0x87, 0x29, 0x0e, 0xf9, 0x2b, 0xb8, 0x91, 0x9e, 0xc3, 0x3c,
+	0x3a, 0x37, 0x7d, 0xc7, 0x56, 0x37, 0xf2, 0xed, 0x79, 0xdf, 0xe6, 0x9a, 0x29, 0xf8, 0x47, 0xe7,
+	0x07, 0x8e, 0x2d, 0x75, 0x4e, 0x82, 0x99, 0x86, 0xc6, 0xdf, 0xc0, 0x07, 0x6f, 0xe9, 0x7e, 0xc9,
+	0x1a, 0x74, 0xd3, 0x35, 0x27, 0x8b, 0x2b, 0x21, 0xb1, 0x7a, 0xbf, 0xd3, 0x60, 0x6d, 0xa6, 0x03,
+	0x69, 0x25, 0xa3, 0xf4, 0x3b, 0x19, 0xe7, 0x69, 0x1f, 0x1c, 0x4a, 0x78, 0x1c, 0x4b, 0xbe, 0xbe,
+	0x10, 0x98, 0x67, 0x0d, 0xc7, 0x64, 0x7c, 0x2b, 0x81, 0x14, 0x82, 0xf1, 0xaf, 0x3a, 0x94, 0x22,
+	0x74, 0x71, 0x9f, 0x3e, 0xe7, 0x21, 0x1b, 0x9b, 0x71, 0xb2, 0x4f, 0xa3, 0x20, 0x59, 0x22, 0x05,
+	0xf5, 0x21, 0x94, 0xf1, 0xda, 0x2e, 0x9b, 0x73, 0xa2, 0xb9, 0x84, 0x0c, 0xd1, 0xf8, 0x11, 0x54,
+	0x42, 0x2f, 0xb4, 0x46, 0x66, 0x28, 0xa2, 0x05, 0x5d, 0x8e, 0x16, 0x2c, 0x19, 0x2b, 0xfc, 0x00,
+	0xd6, 0xc2, 0xe3, 0xc0, 0x0b, 0xc3, 0x11, 0x46, 0xaa, 0x22, 0x6e, 0x92, 0x61, 0x4e, 0x9e, 0xd6,
+	0xe2, 0x06, 0x19, 0x4f, 0x71, 0xf4, 0xde, 0xd3, 0xce, 0x68, 0xba, 0xc2, 0x89, 0xe4, 0xe9, 0x4a,
+	0xcc, 0x45, 0xd3, 0xc6, 0xc3, 0xd3, 0x97, 0xf1, 0x88, 0xf0, 0x15, 0x1a, 0x8d, 0x48, 0x62, 0xc2,
+	0xea, 0x98, 0x59, 0x7c, 0x12, 0x30, 0xdb, 0x7c, 0xe9, 0xb0, 0x91, 0x2d, 0xd3, 0x20, 0xd5, 0xcc,
+	0x97, 0x8d, 0x48, 0x2d, 0xcd, 0xc7, 0x62, 0x34, 0xad, 0x46, 0x70, 0x92, 0xc6, 0xc8, 0x41, 0xfe,
+	0x23, 0xab, 0x50, 0xe9, 0x3d, 0xef, 0xf5, 0x3b, 0x7b, 0xe6, 0xde, 0xfe, 0x56, 0x47, 0x95, 0x15,
+	0xf5, 0x3a, 0x54, 0x92, 0x1a, 0xb6, 0xf7, 0xf7, 0xfb, 0xad, 0x5d, 0xb3, 0xbf, 0xd3, 0x7e, 0xda,
+	0xab, 0xe5, 0xc8, 0x0d, 0x58, 0xeb, 0x6f, 0xd3, 0xfd, 0x7e, 0x7f, 0xb7, 0xb3, 0x65, 0x1e, 0x74,
+	0xe8, 0xce, 0xfe, 0x56, 0xaf, 0xa6, 0x63, 0xd6, 0x76, 0xca, 0xee, 0xef, 0xec, 0x75, 0x6a, 0x79,
+	0x2c, 0x24, 0x39, 0xe8, 0xd0, 0x76, 0xa7, 0xdb, 0xaf, 0x15, 0x8c, 0xdf, 0xea, 0x50, 0x49, 0xac,
+	0x22, 0x1a, 0x72, 0xc0, 0xe5, 0xad, 0x26, 0x4f, 0xf1, 0xaf, 0x78, 0x06, 0xb5, 0x06, 0xc7, 0x72,
+	0x75, 0xf2, 0x54, 0x12, 0xe2, 0x26, 0x63, 0x9d, 0x25, 0xf6, 0x79, 0x9e, 0x96, 0xc6, 0xd6, 0x99,
+	0x04, 0xf9, 0x1e, 0x2c, 0x9f, 0xb0, 0xc0, 0x65, 0x23, 0xd5, 0x2e, 0x57, 0xa4, 0x22, 0x79, 0xb2,
+	0xcb, 0x06, 0xd4, 0x54, 0x97, 0x29, 0x8c, 0x5c, 0x8e, 0xaa, 0xe4, 0xef, 0x45, 0x60, 0xeb, 0x50,
+	0x90, 0xcd, 0x4b, 0x72, 0x7e, 0x41, 0xe0, 0x31, 0xc5, 0xdf, 0x58, 0xbe, 0x88, 0x20, 0xf3, 0x54,
+	0xfc, 0x27, 0x47, 0xb3, 0xeb, 0x53, 0x14, 0xeb, 0x73, 0x6f, 0x7e, 0x73, 0x7e, 0xdb, 0x12, 0x1d,
+	0xc7, 0x4b, 0xb4, 0x04, 0x3a, 0x8d, 0x6a, 0x71, 0xda, 0xad, 0xf6, 0x36, 0x2e, 0xcb, 0x0a, 0x94,
+	0xf7, 0x5a, 0x3f, 0x35, 0x0f, 0x7b, 0x22, 0x87, 0x4e, 0x6a, 0xb0, 0xfc, 0xb4, 0x43, 0xbb, 0x9d,
+	0x5d, 0xc5, 0xd1, 0xc9, 0x3a, 0xd4, 0x14, 0x67, 0xda, 0x2f, 0x8f, 0x08, 0xf2, 0x6f, 0x01, 0x73,
+	0xae, 0xbd, 0x67, 0xad, 0x83, 0x5a, 0xd1, 0xf8, 0x9f, 0x1c, 0xac, 0xca, 0x63, 0x21, 0xae, 0x1a,
+	0x78, 0xfb, 0xab, 0x69, 0x32, 0xa7, 0x94, 0x4b, 0xe7, 0x94, 0xa2, 0x20, 0x54, 0x9c, 0xea, 0xfa,
+	0x34, 0x08, 0x15, 0xb9, 0xa8, 0x94, 0xc7, 0xcf, 0xcf, 0xe3, 0xf1, 0xeb, 0xb0, 0x34, 0x66, 0x3c,
+	0x5e, 0xb7, 0x32, 0x8d, 0x48, 0xe2, 0x40, 0xc5, 0x72, 0x5d, 0x2f, 0xb4, 0x64, 0xa2, 0xb6, 0x38,
+	0xd7, 0x61, 0x78, 0xe1, 0x8b, 0x9b, 0xad, 0x29, 0x92, 0x74, 0xcc, 0x49, 0xec, 0xc6, 0x4f, 0xa0,
+	0x76, 0xb1, 0xc3, 0x3c, 0xc7, 0xe1, 0xf7, 0x7f, 0x38, 0x3d, 0x0d, 0x19, 0xee, 0x0b, 0xf5, 0xc2,
+	0x51, 0xbb, 0x86, 0x04, 0x3d, 0xec, 0x76, 0x77, 0xba, 0x4f, 0x6a, 0x1a, 0x3e, 0x91, 0x74, 0x7e,
+	0xba, 0x83, 0xf5, 0x7d, 0xb9, 0xcd, 0xdf, 0xad, 0x41, 0x51, 0x0a, 0x49, 0xbe, 0x55, 0x91, 0x40,
+	0xb2, 0x22, 0x95, 0xfc, 0x64, 0xee, 0x88, 0x3a, 0x55, 0xe5, 0xda, 0x78, 0xb8, 0xf0, 0x78, 0xf5,
+	0x02, 0x78, 0x8d, 0xfc, 0x4a, 0x83, 0xe5, 0xd4, 0xeb, 0x5f, 0xd6, 0x44, 0xf5, 0x25, 0x05, 0xb0,
+	0x8d, 0x1f, 0x2f, 0x34, 0x36, 0x96, 0xe5, 0x97, 0x1a, 0x54, 0x12, 0xa5, 0x9f, 0xe4, 0xde, 0x22,
+	0xe5, 0xa2, 0x52, 0x92, 0xfb, 0x8b, 0x57, 0x9a, 0x1a, 0xd7, 0x3e, 0xd3, 0xc8, 0xdf, 0x6b, 0x50,
+	0x49, 0x14, 0x41, 0x66, 0x16, 0x65, 0xb6, 0x64, 0xb3, 0x71, 0x7f, 0x91, 0xa1, 0xb1, 0x4e, 0xfe,
+	0x56, 0x83, 0x72, 0x5c, 0xd0, 0x48, 0xee, 0xce, 0x5f, 0x02, 0x29, 0x85, 0xf8, 0x62, 0xd1, 0xda,
+	0x49, 0xe3, 0x1a, 0xf9, 0x6b, 0x28, 0x45, 0xd5, 0x7f, 0x24, 0xeb, 0xe9, 0x75, 0xa1, 0xb4, 0xb0,
+	0x71, 0x77, 0xee, 0x71, 0xc9, 0xe9, 0xa3, 0x92, 0xbc, 0xcc, 0xd3, 0x5f, 0x28, 0x1e, 0x6c, 0xdc,
+	0x9d, 0x7b, 0x5c, 0x3c, 0x3d, 0x5a, 0x42, 0xa2, 0x72, 0x2f, 0xb3, 0x25, 0xcc, 0x96, 0x0c, 0x36,
+	0xee, 0x2f, 0x32, 0x34, 0x25, 0x48, 0xa2, 0xf6, 0x2f, 0xb3, 0x20, 0xb3, 0xf5, 0x85, 0x8d, 0xfb,
+	0x8b, 0x0c, 0x8d, 0x05, 0xf9, 0x85, 0x96, 0xbc, 0x17, 0xdc, 0x9d, 0xbb, 0xc4, 0x6d, 0x4e, 0x93,
+	0x9c, 0x29, 0xb2, 0x13, 0x1b, 0xf4, 0x17, 0x2a, 0x8b, 0x21, 0x2b, 0xe4, 0xc8, 0x3c, 0x60, 0xa9,
+	0xa2, 0xba, 0xc6, 0xe7, 0x8b, 0x1d, 0x36, 0x42, 0x88, 0xbf, 0xd3, 0x00, 0xa6, 0xb5, 0x74, 0x99,
+	0x85, 0x98, 0x29, 0xe2, 0x6b, 0xdc, 0x5b, 0x60, 0x64, 0x72, 0x83, 0x44, 0xb5, 0x3e, 0x99, 0x37,
+	0xc8, 0x85, 0x5a, 0xbf, 0xc6, 0xdd, 0xb9, 0xc7, 0xc5, 0xd3, 0xff, 0xb3, 0x06, 0x6b, 0x33, 0xb5,
+	0x46, 0xe4, 0xe1, 0x15, 0xcb, 0xcd, 0x1a, 0x5f, 0x2d, 0x0e, 0x10, 0x89, 0xb6, 0xa1, 0x7d, 0xa6,
+	0x91, 0x7f, 0xd0, 0x60, 0x25, 0x5d, 0x83, 0x91, 0xf9, 0x94, 0xba, 0xa4, 0x6a, 0xa9, 0xf1, 0x60,
+	0xb1, 0xc1, 0xb1, 0xb6, 0x7e, 0xa3, 0x41, 0x55, 0xed, 0xef, 0x48, 0x9e, 0x07, 0xf3, 0xb9, 0x85,
+	0x0b, 0x02, 0x7d, 0xb9, 0xe0, 0xe8, 0x48, 0xa2, 0x47, 0x4b, 0x7f, 0x5e, 0x90, 0xd1, 0x5b, 0x51,
+	0xfc, 0xfc, 0xe8, 0xff, 0x07, 0x00, 0x92, 0xad, 0xd3, 0x69, 0x38, 0x34, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
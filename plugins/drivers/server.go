@@ -45,6 +45,7 @@ func (b *driverPluginServer) Capabilities(ctx context.Context, req *proto.Capabi
 			MustCreateNetwork:     caps.MustInitiateNetwork,
 			NetworkIsolationModes: []proto.NetworkIsolationSpec_NetworkIsolationMode{},
 			RemoteTasks:           caps.RemoteTasks,
+			Stdin:                 caps.Stdin,
 		},
 	}
 
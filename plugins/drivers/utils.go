@@ -63,6 +63,7 @@ func taskConfigFromProto(pb *proto.TaskConfig) *TaskConfig {
 		AllocDir:         pb.AllocDir,
 		StdoutPath:       pb.StdoutPath,
 		StderrPath:       pb.StderrPath,
+		StdinPath:        pb.StdinPath,
 		AllocID:          pb.AllocId,
 		NetworkIsolation: NetworkIsolationSpecFromProto(pb.NetworkIsolationSpec),
 		DNS:              dnsConfigFromProto(pb.Dns),
@@ -88,6 +89,7 @@ func taskConfigToProto(cfg *TaskConfig) *proto.TaskConfig {
 		MsgpackDriverConfig:  cfg.rawDriverConfig,
 		StdoutPath:           cfg.StdoutPath,
 		StderrPath:           cfg.StderrPath,
+		StdinPath:            cfg.StdinPath,
 		AllocId:              cfg.AllocID,
 		NetworkIsolationSpec: NetworkIsolationSpecToProto(cfg.NetworkIsolation),
 		Dns:                  dnsConfigToProto(cfg.DNS),
@@ -137,14 +139,16 @@ func ResourcesFromProto(pb *proto.Resources) *Resources {
 
 	if pb.LinuxResources != nil {
 		r.LinuxResources = &LinuxResources{
-			CPUPeriod:        pb.LinuxResources.CpuPeriod,
-			CPUQuota:         pb.LinuxResources.CpuQuota,
-			CPUShares:        pb.LinuxResources.CpuShares,
-			MemoryLimitBytes: pb.LinuxResources.MemoryLimitBytes,
-			OOMScoreAdj:      pb.LinuxResources.OomScoreAdj,
-			CpusetCpus:       pb.LinuxResources.CpusetCpus,
-			CpusetCgroupPath: pb.LinuxResources.CpusetCgroup,
-			PercentTicks:     pb.LinuxResources.PercentTicks,
+			CPUPeriod:          pb.LinuxResources.CpuPeriod,
+			CPUQuota:           pb.LinuxResources.CpuQuota,
+			CPUShares:          pb.LinuxResources.CpuShares,
+			MemoryLimitBytes:   pb.LinuxResources.MemoryLimitBytes,
+			OOMScoreAdj:        pb.LinuxResources.OomScoreAdj,
+			CpusetCpus:         pb.LinuxResources.CpusetCpus,
+			CpusetCgroupPath:   pb.LinuxResources.CpusetCgroup,
+			PercentTicks:       pb.LinuxResources.PercentTicks,
+			DiskIOPS:           pb.LinuxResources.DiskIops,
+			DiskBandwidthBytes: pb.LinuxResources.DiskBandwidthBytes,
 		}
 	}
 
@@ -207,14 +211,16 @@ func ResourcesToProto(r *Resources) *proto.Resources {
 
 	if r.LinuxResources != nil {
 		pb.LinuxResources = &proto.LinuxResources{
-			CpuPeriod:        r.LinuxResources.CPUPeriod,
-			CpuQuota:         r.LinuxResources.CPUQuota,
-			CpuShares:        r.LinuxResources.CPUShares,
-			MemoryLimitBytes: r.LinuxResources.MemoryLimitBytes,
-			OomScoreAdj:      r.LinuxResources.OOMScoreAdj,
-			CpusetCpus:       r.LinuxResources.CpusetCpus,
-			CpusetCgroup:     r.LinuxResources.CpusetCgroupPath,
-			PercentTicks:     r.LinuxResources.PercentTicks,
+			CpuPeriod:          r.LinuxResources.CPUPeriod,
+			CpuQuota:           r.LinuxResources.CPUQuota,
+			CpuShares:          r.LinuxResources.CPUShares,
+			MemoryLimitBytes:   r.LinuxResources.MemoryLimitBytes,
+			OomScoreAdj:        r.LinuxResources.OOMScoreAdj,
+			CpusetCpus:         r.LinuxResources.CpusetCpus,
+			CpusetCgroup:       r.LinuxResources.CpusetCgroupPath,
+			PercentTicks:       r.LinuxResources.PercentTicks,
+			DiskIops:           r.LinuxResources.DiskIOPS,
+			DiskBandwidthBytes: r.LinuxResources.DiskBandwidthBytes,
 		}
 	}
 
@@ -54,9 +54,11 @@ func TestTaskConfigRoundTrip(t *testing.T) {
 				},
 			},
 			LinuxResources: &LinuxResources{
-				MemoryLimitBytes: 300 * 1024 * 1024,
-				CPUShares:        100,
-				PercentTicks:     float64(100) / float64(3200),
+				MemoryLimitBytes:   300 * 1024 * 1024,
+				CPUShares:          100,
+				PercentTicks:       float64(100) / float64(3200),
+				DiskIOPS:           500,
+				DiskBandwidthBytes: 50 * 1024 * 1024,
 			},
 			Ports: &structs.AllocatedPorts{
 				{
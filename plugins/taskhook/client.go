@@ -0,0 +1,57 @@
+package taskhook
+
+import (
+	"context"
+
+	"github.com/LK4D4/joincontext"
+	"github.com/hashicorp/nomad/helper/pluginutils/grpcutils"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/taskhook/proto"
+)
+
+// taskHookPluginClient implements the client side of a remote task hook
+// plugin, using gRPC to communicate to the remote plugin.
+type taskHookPluginClient struct {
+	// basePluginClient is embedded to give access to the base plugin methods.
+	*base.BasePluginClient
+
+	client proto.TaskHookPluginClient
+
+	// doneCtx is closed when the plugin exits
+	doneCtx context.Context
+}
+
+func (t *taskHookPluginClient) Prestart(ctx context.Context, task *TaskContext) (*PrestartResponse, error) {
+	// Join the passed context and the shutdown context
+	joinedCtx, _ := joincontext.Join(ctx, t.doneCtx)
+
+	req := &proto.PrestartRequest{Task: convertTaskContext(task)}
+	resp, err := t.client.Prestart(joinedCtx, req)
+	if err != nil {
+		return nil, grpcutils.HandleReqCtxGrpcErr(err, ctx, t.doneCtx)
+	}
+
+	return &PrestartResponse{Env: resp.GetEnv()}, nil
+}
+
+func (t *taskHookPluginClient) Poststart(ctx context.Context, task *TaskContext) error {
+	// Join the passed context and the shutdown context
+	joinedCtx, _ := joincontext.Join(ctx, t.doneCtx)
+
+	req := &proto.PoststartRequest{Task: convertTaskContext(task)}
+	if _, err := t.client.Poststart(joinedCtx, req); err != nil {
+		return grpcutils.HandleReqCtxGrpcErr(err, ctx, t.doneCtx)
+	}
+	return nil
+}
+
+func (t *taskHookPluginClient) Prestop(ctx context.Context, task *TaskContext) error {
+	// Join the passed context and the shutdown context
+	joinedCtx, _ := joincontext.Join(ctx, t.doneCtx)
+
+	req := &proto.PrestopRequest{Task: convertTaskContext(task)}
+	if _, err := t.client.Prestop(joinedCtx, req); err != nil {
+		return grpcutils.HandleReqCtxGrpcErr(err, ctx, t.doneCtx)
+	}
+	return nil
+}
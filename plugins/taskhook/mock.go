@@ -0,0 +1,42 @@
+package taskhook
+
+import (
+	"context"
+
+	"github.com/hashicorp/nomad/plugins/base"
+)
+
+type PrestartFn func(context.Context, *TaskContext) (*PrestartResponse, error)
+type PoststartFn func(context.Context, *TaskContext) error
+type PrestopFn func(context.Context, *TaskContext) error
+
+// MockTaskHookPlugin is used for testing.
+// Each function can be set as a closure to make assertions about how data
+// is passed through the base plugin layer. Unset functions do nothing.
+type MockTaskHookPlugin struct {
+	*base.MockPlugin
+	PrestartF  PrestartFn
+	PoststartF PoststartFn
+	PrestopF   PrestopFn
+}
+
+func (p *MockTaskHookPlugin) Prestart(ctx context.Context, task *TaskContext) (*PrestartResponse, error) {
+	if p.PrestartF == nil {
+		return &PrestartResponse{}, nil
+	}
+	return p.PrestartF(ctx, task)
+}
+
+func (p *MockTaskHookPlugin) Poststart(ctx context.Context, task *TaskContext) error {
+	if p.PoststartF == nil {
+		return nil
+	}
+	return p.PoststartF(ctx, task)
+}
+
+func (p *MockTaskHookPlugin) Prestop(ctx context.Context, task *TaskContext) error {
+	if p.PrestopF == nil {
+		return nil
+	}
+	return p.PrestopF(ctx, task)
+}
@@ -0,0 +1,51 @@
+package taskhook
+
+import (
+	"context"
+
+	log "github.com/hashicorp/go-hclog"
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/plugins/base"
+	bproto "github.com/hashicorp/nomad/plugins/base/proto"
+	"github.com/hashicorp/nomad/plugins/taskhook/proto"
+	"google.golang.org/grpc"
+)
+
+// PluginTaskHook wraps a TaskHookPlugin and implements go-plugins GRPCPlugin
+// interface to expose the interface over gRPC.
+type PluginTaskHook struct {
+	plugin.NetRPCUnsupportedPlugin
+	Impl TaskHookPlugin
+}
+
+func (p *PluginTaskHook) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	proto.RegisterTaskHookPluginServer(s, &taskHookPluginServer{
+		impl:   p.Impl,
+		broker: broker,
+	})
+	return nil
+}
+
+func (p *PluginTaskHook) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &taskHookPluginClient{
+		doneCtx: ctx,
+		client:  proto.NewTaskHookPluginClient(c),
+		BasePluginClient: &base.BasePluginClient{
+			Client:  bproto.NewBasePluginClient(c),
+			DoneCtx: ctx,
+		},
+	}, nil
+}
+
+// Serve is used to serve a task hook plugin
+func Serve(hook TaskHookPlugin, logger log.Logger) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: base.Handshake,
+		Plugins: map[string]plugin.Plugin{
+			base.PluginTypeBase:     &base.PluginBase{Impl: hook},
+			base.PluginTypeTaskHook: &PluginTaskHook{Impl: hook},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+		Logger:     logger,
+	})
+}
@@ -0,0 +1,98 @@
+package taskhook
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/stretchr/testify/require"
+)
+
+func testTaskHookPlugin(t *testing.T, mock *MockTaskHookPlugin) TaskHookPlugin {
+	client, server := plugin.TestPluginGRPCConn(t, map[string]plugin.Plugin{
+		base.PluginTypeBase:     &base.PluginBase{Impl: mock},
+		base.PluginTypeTaskHook: &PluginTaskHook{Impl: mock},
+	})
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+
+	raw, err := client.Dispense(base.PluginTypeTaskHook)
+	require.NoError(t, err)
+
+	impl, ok := raw.(TaskHookPlugin)
+	require.True(t, ok, "bad: %#v", raw)
+	return impl
+}
+
+func TestTaskHookPlugin_PluginInfo(t *testing.T) {
+	ci.Parallel(t)
+
+	info := &base.PluginInfoResponse{
+		Type:              base.PluginTypeTaskHook,
+		PluginApiVersions: []string{ApiVersion010},
+		PluginVersion:     "v0.1.0",
+		Name:              "mock_task_hook",
+	}
+	impl := testTaskHookPlugin(t, &MockTaskHookPlugin{
+		MockPlugin: &base.MockPlugin{
+			PluginInfoF: base.StaticInfo(info),
+		},
+	})
+
+	resp, err := impl.PluginInfo()
+	require.NoError(t, err)
+	require.Equal(t, info, resp)
+}
+
+func TestTaskHookPlugin_Hooks(t *testing.T) {
+	ci.Parallel(t)
+
+	task := &TaskContext{
+		AllocID:   "a5bc1cd8-2aa6-4a35-8a50-ab2b5b1e7d52",
+		Namespace: "default",
+		JobID:     "example",
+		TaskGroup: "cache",
+		TaskName:  "redis",
+		Driver:    "docker",
+		AllocDir:  "/var/lib/nomad/alloc/a5bc1cd8-2aa6-4a35-8a50-ab2b5b1e7d52",
+		TaskDir:   "/var/lib/nomad/alloc/a5bc1cd8-2aa6-4a35-8a50-ab2b5b1e7d52/redis",
+		Env:       map[string]string{"NOMAD_TASK_NAME": "redis"},
+	}
+
+	var called []string
+	impl := testTaskHookPlugin(t, &MockTaskHookPlugin{
+		MockPlugin: &base.MockPlugin{},
+		PrestartF: func(_ context.Context, received *TaskContext) (*PrestartResponse, error) {
+			require.Equal(t, task, received)
+			called = append(called, "prestart")
+			return &PrestartResponse{Env: map[string]string{"SITE_SLOT": "7"}}, nil
+		},
+		PoststartF: func(_ context.Context, received *TaskContext) error {
+			require.Equal(t, task, received)
+			called = append(called, "poststart")
+			return nil
+		},
+		PrestopF: func(_ context.Context, received *TaskContext) error {
+			require.Equal(t, task, received)
+			called = append(called, "prestop")
+			return fmt.Errorf("bookkeeping unavailable")
+		},
+	})
+
+	resp, err := impl.Prestart(context.Background(), task)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"SITE_SLOT": "7"}, resp.Env)
+
+	require.NoError(t, impl.Poststart(context.Background(), task))
+
+	err = impl.Prestop(context.Background(), task)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bookkeeping unavailable")
+
+	require.Equal(t, []string{"prestart", "poststart", "prestop"}, called)
+}
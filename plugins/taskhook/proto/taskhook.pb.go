@@ -0,0 +1,577 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: plugins/taskhook/proto/taskhook.proto
+
+package proto
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// TaskContext describes the task a hook is called for.
+type TaskContext struct {
+	// alloc_id is the ID of the allocation of the task.
+	AllocId string `protobuf:"bytes,1,opt,name=alloc_id,json=allocId,proto3" json:"alloc_id,omitempty"`
+	// namespace is the namespace of the job of the task.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// job_id is the ID of the job of the task.
+	JobId string `protobuf:"bytes,3,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// task_group is the name of the group of the task.
+	TaskGroup string `protobuf:"bytes,4,opt,name=task_group,json=taskGroup,proto3" json:"task_group,omitempty"`
+	// task_name is the name of the task.
+	TaskName string `protobuf:"bytes,5,opt,name=task_name,json=taskName,proto3" json:"task_name,omitempty"`
+	// driver is the name of the driver the task is run with.
+	Driver string `protobuf:"bytes,6,opt,name=driver,proto3" json:"driver,omitempty"`
+	// alloc_dir is the path to the allocation directory on the host.
+	AllocDir string `protobuf:"bytes,7,opt,name=alloc_dir,json=allocDir,proto3" json:"alloc_dir,omitempty"`
+	// task_dir is the path to the task directory on the host.
+	TaskDir string `protobuf:"bytes,8,opt,name=task_dir,json=taskDir,proto3" json:"task_dir,omitempty"`
+	// env is the environment of the task.
+	Env                  map[string]string `protobuf:"bytes,9,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TaskContext) Reset()         { *m = TaskContext{} }
+func (m *TaskContext) String() string { return proto.CompactTextString(m) }
+func (*TaskContext) ProtoMessage()    {}
+func (*TaskContext) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa72face1a2826ad, []int{0}
+}
+
+func (m *TaskContext) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskContext.Unmarshal(m, b)
+}
+func (m *TaskContext) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskContext.Marshal(b, m, deterministic)
+}
+func (m *TaskContext) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskContext.Merge(m, src)
+}
+func (m *TaskContext) XXX_Size() int {
+	return xxx_messageInfo_TaskContext.Size(m)
+}
+func (m *TaskContext) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskContext.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskContext proto.InternalMessageInfo
+
+func (m *TaskContext) GetAllocId() string {
+	if m != nil {
+		return m.AllocId
+	}
+	return ""
+}
+
+func (m *TaskContext) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *TaskContext) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *TaskContext) GetTaskGroup() string {
+	if m != nil {
+		return m.TaskGroup
+	}
+	return ""
+}
+
+func (m *TaskContext) GetTaskName() string {
+	if m != nil {
+		return m.TaskName
+	}
+	return ""
+}
+
+func (m *TaskContext) GetDriver() string {
+	if m != nil {
+		return m.Driver
+	}
+	return ""
+}
+
+func (m *TaskContext) GetAllocDir() string {
+	if m != nil {
+		return m.AllocDir
+	}
+	return ""
+}
+
+func (m *TaskContext) GetTaskDir() string {
+	if m != nil {
+		return m.TaskDir
+	}
+	return ""
+}
+
+func (m *TaskContext) GetEnv() map[string]string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+// PrestartRequest is used to call the prestart hook of a task.
+type PrestartRequest struct {
+	// task is the task that is about to be started.
+	Task                 *TaskContext `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PrestartRequest) Reset()         { *m = PrestartRequest{} }
+func (m *PrestartRequest) String() string { return proto.CompactTextString(m) }
+func (*PrestartRequest) ProtoMessage()    {}
+func (*PrestartRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa72face1a2826ad, []int{1}
+}
+
+func (m *PrestartRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrestartRequest.Unmarshal(m, b)
+}
+func (m *PrestartRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrestartRequest.Marshal(b, m, deterministic)
+}
+func (m *PrestartRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrestartRequest.Merge(m, src)
+}
+func (m *PrestartRequest) XXX_Size() int {
+	return xxx_messageInfo_PrestartRequest.Size(m)
+}
+func (m *PrestartRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrestartRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrestartRequest proto.InternalMessageInfo
+
+func (m *PrestartRequest) GetTask() *TaskContext {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+// PrestartResponse returns the result of the prestart hook.
+type PrestartResponse struct {
+	// env is the set of environment variables to set for the task.
+	Env                  map[string]string `protobuf:"bytes,1,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PrestartResponse) Reset()         { *m = PrestartResponse{} }
+func (m *PrestartResponse) String() string { return proto.CompactTextString(m) }
+func (*PrestartResponse) ProtoMessage()    {}
+func (*PrestartResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa72face1a2826ad, []int{2}
+}
+
+func (m *PrestartResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrestartResponse.Unmarshal(m, b)
+}
+func (m *PrestartResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrestartResponse.Marshal(b, m, deterministic)
+}
+func (m *PrestartResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrestartResponse.Merge(m, src)
+}
+func (m *PrestartResponse) XXX_Size() int {
+	return xxx_messageInfo_PrestartResponse.Size(m)
+}
+func (m *PrestartResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrestartResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrestartResponse proto.InternalMessageInfo
+
+func (m *PrestartResponse) GetEnv() map[string]string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+// PoststartRequest is used to call the poststart hook of a task.
+type PoststartRequest struct {
+	// task is the task that has been started.
+	Task                 *TaskContext `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PoststartRequest) Reset()         { *m = PoststartRequest{} }
+func (m *PoststartRequest) String() string { return proto.CompactTextString(m) }
+func (*PoststartRequest) ProtoMessage()    {}
+func (*PoststartRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa72face1a2826ad, []int{3}
+}
+
+func (m *PoststartRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PoststartRequest.Unmarshal(m, b)
+}
+func (m *PoststartRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PoststartRequest.Marshal(b, m, deterministic)
+}
+func (m *PoststartRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoststartRequest.Merge(m, src)
+}
+func (m *PoststartRequest) XXX_Size() int {
+	return xxx_messageInfo_PoststartRequest.Size(m)
+}
+func (m *PoststartRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoststartRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoststartRequest proto.InternalMessageInfo
+
+func (m *PoststartRequest) GetTask() *TaskContext {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+// PoststartResponse is the response of the poststart hook.
+type PoststartResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PoststartResponse) Reset()         { *m = PoststartResponse{} }
+func (m *PoststartResponse) String() string { return proto.CompactTextString(m) }
+func (*PoststartResponse) ProtoMessage()    {}
+func (*PoststartResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa72face1a2826ad, []int{4}
+}
+
+func (m *PoststartResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PoststartResponse.Unmarshal(m, b)
+}
+func (m *PoststartResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PoststartResponse.Marshal(b, m, deterministic)
+}
+func (m *PoststartResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoststartResponse.Merge(m, src)
+}
+func (m *PoststartResponse) XXX_Size() int {
+	return xxx_messageInfo_PoststartResponse.Size(m)
+}
+func (m *PoststartResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoststartResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoststartResponse proto.InternalMessageInfo
+
+// PrestopRequest is used to call the prestop hook of a task.
+type PrestopRequest struct {
+	// task is the task that is about to be killed.
+	Task                 *TaskContext `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PrestopRequest) Reset()         { *m = PrestopRequest{} }
+func (m *PrestopRequest) String() string { return proto.CompactTextString(m) }
+func (*PrestopRequest) ProtoMessage()    {}
+func (*PrestopRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa72face1a2826ad, []int{5}
+}
+
+func (m *PrestopRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrestopRequest.Unmarshal(m, b)
+}
+func (m *PrestopRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrestopRequest.Marshal(b, m, deterministic)
+}
+func (m *PrestopRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrestopRequest.Merge(m, src)
+}
+func (m *PrestopRequest) XXX_Size() int {
+	return xxx_messageInfo_PrestopRequest.Size(m)
+}
+func (m *PrestopRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrestopRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrestopRequest proto.InternalMessageInfo
+
+func (m *PrestopRequest) GetTask() *TaskContext {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+// PrestopResponse is the response of the prestop hook.
+type PrestopResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrestopResponse) Reset()         { *m = PrestopResponse{} }
+func (m *PrestopResponse) String() string { return proto.CompactTextString(m) }
+func (*PrestopResponse) ProtoMessage()    {}
+func (*PrestopResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_aa72face1a2826ad, []int{6}
+}
+
+func (m *PrestopResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrestopResponse.Unmarshal(m, b)
+}
+func (m *PrestopResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrestopResponse.Marshal(b, m, deterministic)
+}
+func (m *PrestopResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrestopResponse.Merge(m, src)
+}
+func (m *PrestopResponse) XXX_Size() int {
+	return xxx_messageInfo_PrestopResponse.Size(m)
+}
+func (m *PrestopResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrestopResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrestopResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*TaskContext)(nil), "hashicorp.nomad.plugins.taskhook.TaskContext")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.taskhook.TaskContext.EnvEntry")
+	proto.RegisterType((*PrestartRequest)(nil), "hashicorp.nomad.plugins.taskhook.PrestartRequest")
+	proto.RegisterType((*PrestartResponse)(nil), "hashicorp.nomad.plugins.taskhook.PrestartResponse")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.taskhook.PrestartResponse.EnvEntry")
+	proto.RegisterType((*PoststartRequest)(nil), "hashicorp.nomad.plugins.taskhook.PoststartRequest")
+	proto.RegisterType((*PoststartResponse)(nil), "hashicorp.nomad.plugins.taskhook.PoststartResponse")
+	proto.RegisterType((*PrestopRequest)(nil), "hashicorp.nomad.plugins.taskhook.PrestopRequest")
+	proto.RegisterType((*PrestopResponse)(nil), "hashicorp.nomad.plugins.taskhook.PrestopResponse")
+}
+
+func init() {
+	proto.RegisterFile("plugins/taskhook/proto/taskhook.proto", fileDescriptor_aa72face1a2826ad)
+}
+
+var fileDescriptor_aa72face1a2826ad = []byte{
+	// 459 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4d, 0x6b, 0xdb, 0x40,
+	0x10, 0xad, 0xec, 0xd8, 0x96, 0xc7, 0x90, 0x38, 0xd3, 0x0f, 0x54, 0xb7, 0x05, 0x23, 0x28, 0xe4,
+	0x52, 0xa5, 0x71, 0x20, 0x94, 0xf6, 0xd4, 0x8f, 0xd0, 0xe4, 0xd0, 0x62, 0xdc, 0xf4, 0xd2, 0x8b,
+	0x59, 0x5b, 0x4b, 0xac, 0xc8, 0xd6, 0xa8, 0xbb, 0x6b, 0xd1, 0xfc, 0x94, 0xfe, 0xc6, 0x1e, 0xfa,
+	0x17, 0xc2, 0x8e, 0x3e, 0x12, 0x72, 0x89, 0x05, 0x3e, 0xd9, 0x6f, 0x66, 0xdf, 0x9b, 0x79, 0x6f,
+	0x40, 0xf0, 0x3a, 0x5d, 0xae, 0x2f, 0xa3, 0x44, 0x1f, 0x1a, 0xa1, 0xe3, 0x05, 0x51, 0x7c, 0x98,
+	0x2a, 0x32, 0x54, 0xc1, 0x80, 0x21, 0x0e, 0x17, 0x42, 0x2f, 0xa2, 0x39, 0xa9, 0x34, 0x48, 0x68,
+	0x25, 0xc2, 0xa0, 0xa0, 0x05, 0xe5, 0x3b, 0xff, 0x5f, 0x03, 0x7a, 0x17, 0x42, 0xc7, 0x9f, 0x29,
+	0x31, 0xf2, 0x8f, 0xc1, 0xe7, 0xe0, 0x8a, 0xe5, 0x92, 0xe6, 0xd3, 0x28, 0xf4, 0x9c, 0xa1, 0x73,
+	0xd0, 0x9d, 0x74, 0x18, 0x9f, 0x87, 0xf8, 0x12, 0xba, 0x89, 0x58, 0x49, 0x9d, 0x8a, 0xb9, 0xf4,
+	0x1a, 0xdc, 0xbb, 0x2d, 0xe0, 0x53, 0x68, 0x5f, 0xd1, 0xcc, 0xd2, 0x9a, 0xdc, 0x6a, 0x5d, 0xd1,
+	0xec, 0x3c, 0xc4, 0x57, 0x00, 0x76, 0xd6, 0xf4, 0x52, 0xd1, 0x3a, 0xf5, 0x76, 0x72, 0x96, 0xad,
+	0x7c, 0xb5, 0x05, 0x7c, 0x01, 0x0c, 0xa6, 0x56, 0xc7, 0x6b, 0x71, 0xd7, 0xb5, 0x85, 0xef, 0x62,
+	0x25, 0xf1, 0x19, 0xb4, 0x43, 0x15, 0x65, 0x52, 0x79, 0x6d, 0xee, 0x14, 0xc8, 0x92, 0xf2, 0x1d,
+	0xc3, 0x48, 0x79, 0x9d, 0x9c, 0xc4, 0x85, 0x2f, 0x91, 0xb2, 0x06, 0x58, 0xd1, 0xf6, 0xdc, 0xdc,
+	0x80, 0xc5, 0xb6, 0x75, 0x06, 0x4d, 0x99, 0x64, 0x5e, 0x77, 0xd8, 0x3c, 0xe8, 0x8d, 0x4e, 0x82,
+	0x87, 0xb2, 0x09, 0xee, 0xe4, 0x12, 0x9c, 0x26, 0xd9, 0x69, 0x62, 0xd4, 0xf5, 0xc4, 0x4a, 0x0c,
+	0x4e, 0xc0, 0x2d, 0x0b, 0xd8, 0x87, 0x66, 0x2c, 0xaf, 0x8b, 0xb0, 0xec, 0x5f, 0x7c, 0x02, 0xad,
+	0x4c, 0x2c, 0xd7, 0x65, 0x48, 0x39, 0x78, 0xdf, 0x78, 0xe7, 0xf8, 0x17, 0xb0, 0x37, 0x56, 0x52,
+	0x1b, 0xa1, 0xcc, 0x44, 0xfe, 0x5e, 0x4b, 0x6d, 0xf0, 0x23, 0xec, 0xd8, 0x81, 0xcc, 0xef, 0x8d,
+	0xde, 0xd4, 0xda, 0x6a, 0xc2, 0x54, 0xff, 0xaf, 0x03, 0xfd, 0x5b, 0x59, 0x9d, 0x52, 0xa2, 0x25,
+	0x7e, 0xcb, 0xcd, 0x3a, 0x6c, 0xf6, 0xc3, 0xc3, 0xb2, 0xf7, 0x05, 0xb6, 0xe4, 0xf8, 0x27, 0xf4,
+	0xc7, 0xa4, 0xcd, 0xb6, 0x2d, 0x3f, 0x86, 0xfd, 0x3b, 0xb2, 0xf9, 0xc6, 0xfe, 0x0f, 0xd8, 0x65,
+	0x17, 0x94, 0x6e, 0x71, 0xd2, 0x3e, 0xec, 0x55, 0xa2, 0xf9, 0x9c, 0xd1, 0xff, 0x06, 0xec, 0xda,
+	0x87, 0x67, 0x44, 0xf1, 0x98, 0x15, 0x50, 0x83, 0x5b, 0x06, 0x88, 0x47, 0x75, 0xc2, 0xe6, 0x3d,
+	0x07, 0xa3, 0xfa, 0xf7, 0xf1, 0x1f, 0x61, 0x06, 0xdd, 0x2a, 0x04, 0xdc, 0x44, 0xe2, 0xde, 0x21,
+	0x06, 0xc7, 0xb5, 0x38, 0xd5, 0xdc, 0x14, 0x3a, 0x45, 0x24, 0xf8, 0x76, 0xc3, 0xc5, 0xab, 0x93,
+	0x0c, 0x8e, 0x6a, 0x30, 0xca, 0x89, 0x9f, 0x3a, 0xbf, 0x5a, 0xfc, 0x41, 0x9b, 0xb5, 0xf9, 0xe7,
+	0xf8, 0x66, 0x00, 0x5d, 0x8b, 0x66, 0x0f, 0x00, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// TaskHookPluginClient is the client API for TaskHookPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TaskHookPluginClient interface {
+	// Prestart is called before a task is started, including after every
+	// restart of the task. The returned environment variables are set for the
+	// task. If the call fails the task fails to start.
+	Prestart(ctx context.Context, in *PrestartRequest, opts ...grpc.CallOption) (*PrestartResponse, error)
+	// Poststart is called after a task has started.
+	Poststart(ctx context.Context, in *PoststartRequest, opts ...grpc.CallOption) (*PoststartResponse, error)
+	// Prestop is called before a task is killed or restarted.
+	Prestop(ctx context.Context, in *PrestopRequest, opts ...grpc.CallOption) (*PrestopResponse, error)
+}
+
+type taskHookPluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTaskHookPluginClient(cc grpc.ClientConnInterface) TaskHookPluginClient {
+	return &taskHookPluginClient{cc}
+}
+
+func (c *taskHookPluginClient) Prestart(ctx context.Context, in *PrestartRequest, opts ...grpc.CallOption) (*PrestartResponse, error) {
+	out := new(PrestartResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.taskhook.TaskHookPlugin/Prestart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskHookPluginClient) Poststart(ctx context.Context, in *PoststartRequest, opts ...grpc.CallOption) (*PoststartResponse, error) {
+	out := new(PoststartResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.taskhook.TaskHookPlugin/Poststart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskHookPluginClient) Prestop(ctx context.Context, in *PrestopRequest, opts ...grpc.CallOption) (*PrestopResponse, error) {
+	out := new(PrestopResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.taskhook.TaskHookPlugin/Prestop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TaskHookPluginServer is the server API for TaskHookPlugin service.
+type TaskHookPluginServer interface {
+	// Prestart is called before a task is started, including after every
+	// restart of the task. The returned environment variables are set for the
+	// task. If the call fails the task fails to start.
+	Prestart(context.Context, *PrestartRequest) (*PrestartResponse, error)
+	// Poststart is called after a task has started.
+	Poststart(context.Context, *PoststartRequest) (*PoststartResponse, error)
+	// Prestop is called before a task is killed or restarted.
+	Prestop(context.Context, *PrestopRequest) (*PrestopResponse, error)
+}
+
+// UnimplementedTaskHookPluginServer can be embedded to have forward compatible implementations.
+type UnimplementedTaskHookPluginServer struct {
+}
+
+func (*UnimplementedTaskHookPluginServer) Prestart(ctx context.Context, req *PrestartRequest) (*PrestartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Prestart not implemented")
+}
+func (*UnimplementedTaskHookPluginServer) Poststart(ctx context.Context, req *PoststartRequest) (*PoststartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Poststart not implemented")
+}
+func (*UnimplementedTaskHookPluginServer) Prestop(ctx context.Context, req *PrestopRequest) (*PrestopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Prestop not implemented")
+}
+
+func RegisterTaskHookPluginServer(s *grpc.Server, srv TaskHookPluginServer) {
+	s.RegisterService(&_TaskHookPlugin_serviceDesc, srv)
+}
+
+func _TaskHookPlugin_Prestart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrestartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskHookPluginServer).Prestart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.taskhook.TaskHookPlugin/Prestart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskHookPluginServer).Prestart(ctx, req.(*PrestartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskHookPlugin_Poststart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PoststartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskHookPluginServer).Poststart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.taskhook.TaskHookPlugin/Poststart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskHookPluginServer).Poststart(ctx, req.(*PoststartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskHookPlugin_Prestop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrestopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskHookPluginServer).Prestop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.taskhook.TaskHookPlugin/Prestop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskHookPluginServer).Prestop(ctx, req.(*PrestopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TaskHookPlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "hashicorp.nomad.plugins.taskhook.TaskHookPlugin",
+	HandlerType: (*TaskHookPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Prestart",
+			Handler:    _TaskHookPlugin_Prestart_Handler,
+		},
+		{
+			MethodName: "Poststart",
+			Handler:    _TaskHookPlugin_Poststart_Handler,
+		},
+		{
+			MethodName: "Prestop",
+			Handler:    _TaskHookPlugin_Prestop_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "plugins/taskhook/proto/taskhook.proto",
+}
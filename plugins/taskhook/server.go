@@ -0,0 +1,40 @@
+package taskhook
+
+import (
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/nomad/plugins/taskhook/proto"
+	context "golang.org/x/net/context"
+)
+
+// taskHookPluginServer wraps a task hook plugin and exposes it via gRPC.
+type taskHookPluginServer struct {
+	broker *plugin.GRPCBroker
+	impl   TaskHookPlugin
+}
+
+func (t *taskHookPluginServer) Prestart(ctx context.Context, req *proto.PrestartRequest) (*proto.PrestartResponse, error) {
+	resp, err := t.impl.Prestart(ctx, convertProtoTaskContext(req.GetTask()))
+	if err != nil {
+		return nil, err
+	}
+
+	presp := &proto.PrestartResponse{}
+	if resp != nil {
+		presp.Env = resp.Env
+	}
+	return presp, nil
+}
+
+func (t *taskHookPluginServer) Poststart(ctx context.Context, req *proto.PoststartRequest) (*proto.PoststartResponse, error) {
+	if err := t.impl.Poststart(ctx, convertProtoTaskContext(req.GetTask())); err != nil {
+		return nil, err
+	}
+	return &proto.PoststartResponse{}, nil
+}
+
+func (t *taskHookPluginServer) Prestop(ctx context.Context, req *proto.PrestopRequest) (*proto.PrestopResponse, error) {
+	if err := t.impl.Prestop(ctx, convertProtoTaskContext(req.GetTask())); err != nil {
+		return nil, err
+	}
+	return &proto.PrestopResponse{}, nil
+}
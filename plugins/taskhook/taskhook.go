@@ -0,0 +1,60 @@
+package taskhook
+
+import (
+	"context"
+
+	"github.com/hashicorp/nomad/plugins/base"
+)
+
+// TaskHookPlugin is the interface for a plugin that is called around the
+// lifecycle of every task on the client, to let operators integrate custom
+// host bookkeeping without modifying the client.
+type TaskHookPlugin interface {
+	base.BasePlugin
+
+	// Prestart is called before a task is started, including after every
+	// restart of the task. The task fails to start if an error is returned.
+	Prestart(ctx context.Context, task *TaskContext) (*PrestartResponse, error)
+
+	// Poststart is called after a task has started.
+	Poststart(ctx context.Context, task *TaskContext) error
+
+	// Prestop is called before a task is killed or restarted.
+	Prestop(ctx context.Context, task *TaskContext) error
+}
+
+// TaskContext describes the task a hook is called for.
+type TaskContext struct {
+	// AllocID is the ID of the allocation of the task.
+	AllocID string
+
+	// Namespace is the namespace of the job of the task.
+	Namespace string
+
+	// JobID is the ID of the job of the task.
+	JobID string
+
+	// TaskGroup is the name of the group of the task.
+	TaskGroup string
+
+	// TaskName is the name of the task.
+	TaskName string
+
+	// Driver is the name of the driver the task is run with.
+	Driver string
+
+	// AllocDir is the path to the allocation directory on the host.
+	AllocDir string
+
+	// TaskDir is the path to the task directory on the host.
+	TaskDir string
+
+	// Env is the environment of the task.
+	Env map[string]string
+}
+
+// PrestartResponse is the result of the prestart hook of a task.
+type PrestartResponse struct {
+	// Env is the set of environment variables to set for the task.
+	Env map[string]string
+}
@@ -0,0 +1,42 @@
+package taskhook
+
+import "github.com/hashicorp/nomad/plugins/taskhook/proto"
+
+// convertTaskContext converts a task context to its protobuf representation.
+func convertTaskContext(in *TaskContext) *proto.TaskContext {
+	if in == nil {
+		return nil
+	}
+
+	return &proto.TaskContext{
+		AllocId:   in.AllocID,
+		Namespace: in.Namespace,
+		JobId:     in.JobID,
+		TaskGroup: in.TaskGroup,
+		TaskName:  in.TaskName,
+		Driver:    in.Driver,
+		AllocDir:  in.AllocDir,
+		TaskDir:   in.TaskDir,
+		Env:       in.Env,
+	}
+}
+
+// convertProtoTaskContext converts a protobuf task context to its struct
+// representation.
+func convertProtoTaskContext(in *proto.TaskContext) *TaskContext {
+	if in == nil {
+		return nil
+	}
+
+	return &TaskContext{
+		AllocID:   in.AllocId,
+		Namespace: in.Namespace,
+		JobID:     in.JobId,
+		TaskGroup: in.TaskGroup,
+		TaskName:  in.TaskName,
+		Driver:    in.Driver,
+		AllocDir:  in.AllocDir,
+		TaskDir:   in.TaskDir,
+		Env:       in.Env,
+	}
+}
@@ -0,0 +1,6 @@
+package taskhook
+
+const (
+	// ApiVersion010 is the initial API version for the task hook plugins
+	ApiVersion010 = "v0.1.0"
+)
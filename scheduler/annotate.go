@@ -22,6 +22,7 @@ const (
 	UpdateTypeCanary            = "canary"
 	UpdateTypeInplaceUpdate     = "in-place update"
 	UpdateTypeDestructiveUpdate = "create/destroy update"
+	UpdateTypeDisplaced         = "displaced"
 )
 
 // Annotate takes the diff between the old and new version of a Job, the
@@ -81,6 +82,9 @@ func annotateTaskGroup(diff *structs.TaskGroupDiff, annotations *structs.PlanAnn
 			if tg.DestructiveUpdate != 0 {
 				diff.Updates[UpdateTypeDestructiveUpdate] = tg.DestructiveUpdate
 			}
+			if tg.Displaced != 0 {
+				diff.Updates[UpdateTypeDisplaced] = tg.Displaced
+			}
 		}
 	}
 
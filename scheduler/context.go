@@ -43,6 +43,10 @@ type Context interface {
 	// eval.
 	Eligibility() *EvalEligibility
 
+	// FeasibilityCache returns the cache of the feasibility of nodes against
+	// sets of constraints.
+	FeasibilityCache() *FeasibilityCache
+
 	// SendEvent provides best-effort delivery of scheduling and placement
 	// events.
 	SendEvent(event interface{})
@@ -132,6 +136,9 @@ type EvalContext struct {
 	logger      log.Logger
 	metrics     *structs.AllocMetric
 	eligibility *EvalEligibility
+	tracer      *evalTracer
+
+	feasibilityCache *FeasibilityCache
 }
 
 // NewEvalContext constructs a new EvalContext
@@ -168,6 +175,25 @@ func (e *EvalContext) SetState(s State) {
 
 func (e *EvalContext) Reset() {
 	e.metrics = new(structs.AllocMetric)
+	if e.tracer != nil {
+		e.metrics.SetTracer(e.tracer)
+	}
+}
+
+// EnableTracing makes the context record the scheduler decisions in the trace
+// of the evaluation.
+func (e *EvalContext) EnableTracing() {
+	e.tracer = newEvalTracer()
+	e.metrics.SetTracer(e.tracer)
+}
+
+// Tracer returns the tracer of the evaluation, which is nil if tracing is
+// disabled.
+func (e *EvalContext) Tracer() *evalTracer {
+	if e == nil {
+		return nil
+	}
+	return e.tracer
 }
 
 func (e *EvalContext) ProposedAllocs(nodeID string) ([]*structs.Allocation, error) {
@@ -217,6 +243,24 @@ func (e *EvalContext) Eligibility() *EvalEligibility {
 	return e.eligibility
 }
 
+// FeasibilityCache returns the feasibility cache of the context. A cache
+// scoped to the evaluation is created unless one was shared with
+// SetFeasibilityCache.
+func (e *EvalContext) FeasibilityCache() *FeasibilityCache {
+	if e.feasibilityCache == nil {
+		e.feasibilityCache = NewFeasibilityCache()
+	}
+	return e.feasibilityCache
+}
+
+// SetFeasibilityCache sets the feasibility cache used by the context, so that
+// it can be shared across evaluations. A nil cache is ignored.
+func (e *EvalContext) SetFeasibilityCache(cache *FeasibilityCache) {
+	if cache != nil {
+		e.feasibilityCache = cache
+	}
+}
+
 func (e *EvalContext) SendEvent(event interface{}) {
 	if e == nil || e.eventsCh == nil {
 		return
@@ -41,6 +41,7 @@ func (d *deviceAllocator) AssignDevice(ask *structs.RequestedDevice) (out *struc
 	// Hold the current best offer
 	var offer *structs.AllocatedDeviceResource
 	var offerScore float64
+	var offerDevice *structs.NodeDeviceResource
 	var matchedWeights float64
 
 	// Determine the devices that are feasible based on availability and
@@ -91,13 +92,18 @@ func (d *deviceAllocator) AssignDevice(ask *structs.RequestedDevice) (out *struc
 			choiceScore /= totalWeight
 		}
 
-		// Only use the device if it is a higher score than we have already seen
-		if offer != nil && choiceScore < offerScore {
+		// Only use the device if it is a higher score than we have already
+		// seen. Among devices with the same score, smaller MIG slices are
+		// preferred so the larger ones stay available to the requests
+		// needing them.
+		if offer != nil && (choiceScore < offerScore ||
+			choiceScore == offerScore && !smallerMIGSlice(devInst.Device, offerDevice)) {
 			continue
 		}
 
 		// Set the new highest score
 		offerScore = choiceScore
+		offerDevice = devInst.Device
 
 		// Set the new sum of matching affinity weights
 		matchedWeights = sumMatchedWeights
@@ -129,3 +135,28 @@ func (d *deviceAllocator) AssignDevice(ask *structs.RequestedDevice) (out *struc
 
 	return offer, matchedWeights, nil
 }
+
+// smallerMIGSlice returns whether the device group a holds smaller MIG slices
+// than the device group b, comparing their compute slices and then their
+// memory.
+func smallerMIGSlice(a, b *structs.NodeDeviceResource) bool {
+	if a.Type != structs.DeviceTypeMIG || b.Type != structs.DeviceTypeMIG {
+		return false
+	}
+
+	for _, attr := range []string{structs.DeviceAttrMIGComputeSlices, structs.DeviceAttrMIGMemory} {
+		aVal, aOk := a.Attributes[attr]
+		bVal, bOk := b.Attributes[attr]
+		if !aOk || !bOk {
+			return false
+		}
+		cmp, ok := aVal.Compare(bVal)
+		if !ok {
+			return false
+		}
+		if cmp != 0 {
+			return cmp < 0
+		}
+	}
+	return false
+}
@@ -367,3 +367,129 @@ func TestDeviceAllocator_Allocate_Affinities(t *testing.T) {
 		})
 	}
 }
+
+// migNode returns a node containing an nvidia gpu and two groups of MIG
+// slices of another nvidia gpu.
+func migNode() *structs.Node {
+	n := mock.NvidiaNode()
+	for _, mig := range []struct {
+		profile string
+		slices  int64
+		memory  int64
+	}{
+		{"1g.5gb", 1, 4864},
+		{"3g.20gb", 3, 19968},
+	} {
+		n.NodeResources.Devices = append(n.NodeResources.Devices, &structs.NodeDeviceResource{
+			Type:   structs.DeviceTypeMIG,
+			Vendor: "nvidia",
+			Name:   mig.profile,
+			Attributes: map[string]*psstructs.Attribute{
+				"mig_profile":    psstructs.NewStringAttribute(mig.profile),
+				"compute_slices": psstructs.NewIntAttribute(mig.slices, ""),
+				"memory":         psstructs.NewIntAttribute(mig.memory, psstructs.UnitMiB),
+				"parent_model":   psstructs.NewStringAttribute("A100"),
+			},
+			Instances: []*structs.NodeDevice{
+				{
+					ID:      uuid.Generate(),
+					Healthy: true,
+				},
+			},
+		})
+	}
+	return n
+}
+
+// Test that MIG slices are only assigned to requests for MIG devices, and that
+// the smallest slice fitting the request is preferred.
+func TestDeviceAllocator_Allocate_MIG(t *testing.T) {
+	ci.Parallel(t)
+
+	n := migNode()
+	gpu := n.NodeResources.Devices[0]
+	small := n.NodeResources.Devices[1]
+	large := n.NodeResources.Devices[2]
+
+	cases := []struct {
+		Name           string
+		Constraints    []*structs.Constraint
+		Affinities     []*structs.Affinity
+		ExpectedDevice *structs.NodeDeviceResource
+		NoPlacement    bool
+	}{
+		{
+			Name:           "nvidia/gpu",
+			ExpectedDevice: gpu,
+		},
+		{
+			Name:           "nvidia/mig",
+			ExpectedDevice: small,
+		},
+		{
+			Name:           "nvidia/mig/3g.20gb",
+			ExpectedDevice: large,
+		},
+		{
+			Name: "nvidia/mig",
+			Constraints: []*structs.Constraint{
+				{
+					LTarget: "${device.attr.memory}",
+					Operand: ">=",
+					RTarget: "10 GiB",
+				},
+			},
+			ExpectedDevice: large,
+		},
+		{
+			Name: "nvidia/mig",
+			Constraints: []*structs.Constraint{
+				{
+					LTarget: "${device.attr.compute_slices}",
+					Operand: ">",
+					RTarget: "4",
+				},
+			},
+			NoPlacement: true,
+		},
+		{
+			Name: "nvidia/mig",
+			Affinities: []*structs.Affinity{
+				{
+					LTarget: "${device.attr.mig_profile}",
+					Operand: "=",
+					RTarget: "3g.20gb",
+					Weight:  50,
+				},
+			},
+			ExpectedDevice: large,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			require := require.New(t)
+			_, ctx := testContext(t)
+			d := newDeviceAllocator(ctx, n)
+			require.NotNil(d)
+
+			// Build the request
+			ask := deviceRequest(c.Name, 1, c.Constraints, c.Affinities)
+
+			// Repeat the assignment as the devices are iterated in a random
+			// order
+			for i := 0; i < 10; i++ {
+				out, _, err := d.AssignDevice(ask)
+				if c.NoPlacement {
+					require.Nil(out)
+					require.Error(err)
+					return
+				}
+				require.NoError(err)
+				require.NotNil(out)
+				require.Len(out.DeviceIDs, 1)
+				require.Contains(collectInstanceIDs(c.ExpectedDevice), out.DeviceIDs[0])
+			}
+		})
+	}
+}
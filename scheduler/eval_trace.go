@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// evalTracer records the decisions of the scheduler in the trace of the
+// evaluation being processed. All of its methods are safe to call on a nil
+// tracer, which records nothing.
+type evalTracer struct {
+	trace *structs.EvalTrace
+
+	// taskGroup is the task group being placed
+	taskGroup string
+}
+
+func newEvalTracer() *evalTracer {
+	return &evalTracer{trace: new(structs.EvalTrace)}
+}
+
+// SetTaskGroup sets the task group attached to the events recorded until the
+// next call.
+func (t *evalTracer) SetTaskGroup(name string) {
+	if t == nil {
+		return
+	}
+	t.taskGroup = name
+}
+
+func (t *evalTracer) NodeFiltered(node *structs.Node, constraint string) {
+	t.addNodeEvent(structs.EvalTraceNodeFiltered, node, constraint)
+}
+
+func (t *evalTracer) NodeExhausted(node *structs.Node, dimension string) {
+	t.addNodeEvent(structs.EvalTraceNodeExhausted, node, dimension)
+}
+
+func (t *evalTracer) NodeScored(node *structs.Node, meta *structs.NodeScoreMeta) {
+	if t == nil {
+		return
+	}
+	event := t.nodeEvent(structs.EvalTraceNodeScored, node, "")
+	event.Score = meta.NormScore
+	event.Scores = helper.CopyMapStringFloat64(meta.Scores)
+	t.trace.Add(event)
+}
+
+// Placed records the node selected for an allocation.
+func (t *evalTracer) Placed(option *RankedNode) {
+	if t == nil {
+		return
+	}
+	event := t.nodeEvent(structs.EvalTracePlaced, option.Node, "")
+	event.Score = option.FinalScore
+	t.trace.Add(event)
+}
+
+// PlacementFailed records that no node could be selected for an allocation,
+// summarizing the metric of the failed selection.
+func (t *evalTracer) PlacementFailed(metric *structs.AllocMetric) {
+	if t == nil {
+		return
+	}
+	t.trace.Add(&structs.EvalTraceEvent{
+		Type:      structs.EvalTracePlacementFailed,
+		TaskGroup: t.taskGroup,
+		Reason:    placementFailureReason(metric),
+	})
+}
+
+// Trace returns the recorded trace.
+func (t *evalTracer) Trace() *structs.EvalTrace {
+	if t == nil {
+		return nil
+	}
+	return t.trace
+}
+
+func (t *evalTracer) addNodeEvent(eventType string, node *structs.Node, reason string) {
+	if t == nil {
+		return
+	}
+	t.trace.Add(t.nodeEvent(eventType, node, reason))
+}
+
+func (t *evalTracer) nodeEvent(eventType string, node *structs.Node, reason string) *structs.EvalTraceEvent {
+	event := &structs.EvalTraceEvent{
+		Type:      eventType,
+		TaskGroup: t.taskGroup,
+		Reason:    reason,
+	}
+	if node != nil {
+		event.NodeID = node.ID
+		event.NodeName = node.Name
+	}
+	return event
+}
+
+// placementFailureReason returns a one line summary of why a selection failed.
+func placementFailureReason(metric *structs.AllocMetric) string {
+	if metric == nil {
+		return ""
+	}
+
+	parts := []string{fmt.Sprintf("%d/%d nodes evaluated", metric.NodesEvaluated, metricNodesAvailable(metric))}
+	if metric.NodesFiltered > 0 {
+		parts = append(parts, fmt.Sprintf("%d filtered", metric.NodesFiltered))
+	}
+	if metric.NodesExhausted > 0 {
+		parts = append(parts, fmt.Sprintf("%d exhausted", metric.NodesExhausted))
+	}
+	if len(metric.DimensionExhausted) > 0 {
+		dims := make([]string, 0, len(metric.DimensionExhausted))
+		for dim := range metric.DimensionExhausted {
+			dims = append(dims, dim)
+		}
+		sort.Strings(dims)
+		parts = append(parts, "exhausted dimensions: "+strings.Join(dims, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// metricNodesAvailable returns the number of nodes available across
+// datacenters.
+func metricNodesAvailable(metric *structs.AllocMetric) int {
+	total := 0
+	for _, n := range metric.NodesAvailable {
+		total += n
+	}
+	return total
+}
@@ -0,0 +1,147 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// traceEventsByType groups the events of the trace by type.
+func traceEventsByType(trace *structs.EvalTrace) map[string][]*structs.EvalTraceEvent {
+	byType := make(map[string][]*structs.EvalTraceEvent)
+	for _, e := range trace.Events {
+		byType[e.Type] = append(byType[e.Type], e)
+	}
+	return byType
+}
+
+func TestServiceSched_EvalTrace(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name    string
+		enabled bool
+		cpu     int
+	}{
+		{name: "disabled", enabled: false, cpu: 500},
+		{name: "placed", enabled: true, cpu: 500},
+		{name: "exhausted", enabled: true, cpu: 1000000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHarness(t)
+			require.NoError(t, h.State.SchedulerSetConfig(h.NextIndex(), &structs.SchedulerConfiguration{
+				EvalTracingEnabled: tc.enabled,
+			}))
+
+			// Two nodes match the constraint of the job, one doesn't
+			var other *structs.Node
+			for i, class := range []string{"traced", "traced", "other"} {
+				node := mock.Node()
+				node.Name = fmt.Sprintf("%s-%d", class, i)
+				node.NodeClass = class
+				require.NoError(t, node.ComputeClass())
+				require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+				if class == "other" {
+					other = node
+				}
+			}
+
+			job := mock.Job()
+			job.Constraints = append(job.Constraints, &structs.Constraint{
+				LTarget: "${node.class}",
+				RTarget: "traced",
+				Operand: "=",
+			})
+			job.TaskGroups[0].Count = 2
+			job.TaskGroups[0].Tasks[0].Resources.CPU = tc.cpu
+			require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+			eval := &structs.Evaluation{
+				Namespace:   structs.DefaultNamespace,
+				ID:          uuid.Generate(),
+				Priority:    job.Priority,
+				TriggeredBy: structs.EvalTriggerJobRegister,
+				JobID:       job.ID,
+				Status:      structs.EvalStatusPending,
+			}
+			require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+			require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+			require.Len(t, h.Evals, 1)
+			trace := h.Evals[0].Trace
+			if !tc.enabled {
+				require.Nil(t, trace)
+				return
+			}
+			require.NotNil(t, trace)
+			require.False(t, trace.Truncated)
+
+			byType := traceEventsByType(trace)
+			filtered := byType[structs.EvalTraceNodeFiltered]
+			require.NotEmpty(t, filtered)
+			require.Equal(t, other.ID, filtered[0].NodeID)
+			require.Equal(t, other.Name, filtered[0].NodeName)
+			require.Equal(t, "${node.class} = traced", filtered[0].Reason)
+			for _, e := range trace.Events {
+				require.Equal(t, "web", e.TaskGroup)
+				require.NotZero(t, e.Time)
+			}
+
+			if tc.name == "placed" {
+				require.NotEmpty(t, byType[structs.EvalTraceNodeScored])
+				require.Contains(t, byType[structs.EvalTraceNodeScored][0].Scores, "binpack")
+				require.Len(t, byType[structs.EvalTracePlaced], 2)
+				require.Empty(t, byType[structs.EvalTracePlacementFailed])
+				return
+			}
+
+			require.NotEmpty(t, byType[structs.EvalTraceNodeExhausted])
+			require.Equal(t, "cpu", byType[structs.EvalTraceNodeExhausted][0].Reason)
+			require.Empty(t, byType[structs.EvalTracePlaced])
+			failed := byType[structs.EvalTracePlacementFailed]
+			require.Len(t, failed, 1)
+			require.Contains(t, failed[0].Reason, "exhausted dimensions: cpu")
+		})
+	}
+}
+
+func TestSystemSched_EvalTrace(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+	require.NoError(t, h.State.SchedulerSetConfig(h.NextIndex(), &structs.SchedulerConfiguration{
+		EvalTracingEnabled: true,
+	}))
+
+	nodes := createNodes(t, h, 3)
+
+	job := mock.SystemJob()
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	require.NoError(t, h.Process(NewSystemScheduler, eval))
+
+	require.Len(t, h.Evals, 1)
+	require.NotNil(t, h.Evals[0].Trace)
+
+	placed := traceEventsByType(h.Evals[0].Trace)[structs.EvalTracePlaced]
+	require.Len(t, placed, len(nodes))
+	for _, e := range placed {
+		require.Equal(t, "web", e.TaskGroup)
+	}
+}
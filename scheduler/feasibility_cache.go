@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"sync"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/hashstructure"
+)
+
+const (
+	// feasibilityCacheMaxEntries is the number of results a FeasibilityCache
+	// holds before it is cleared, bounding the memory used by long lived
+	// caches.
+	feasibilityCacheMaxEntries = 1 << 16
+)
+
+// feasibilityCacheKey identifies the result of checking a set of constraints
+// against a version of a node. The computed class is part of the key so nodes
+// modified outside of the state store aren't matched with stale results.
+type feasibilityCacheKey struct {
+	constraints   uint64
+	nodeID        string
+	nodeIndex     uint64
+	computedClass string
+}
+
+func newFeasibilityCacheKey(constraints uint64, node *structs.Node) feasibilityCacheKey {
+	return feasibilityCacheKey{
+		constraints:   constraints,
+		nodeID:        node.ID,
+		nodeIndex:     node.ModifyIndex,
+		computedClass: node.ComputedClass,
+	}
+}
+
+// FeasibilityCache memoizes whether nodes satisfy sets of constraints. Results
+// are keyed by the hash of the constraint set and by the node's modify index,
+// so they stay valid as long as the node isn't updated and the cache can be
+// shared by all the placements of an evaluation as well as across the
+// evaluations processed by the same worker.
+type FeasibilityCache struct {
+	l sync.Mutex
+
+	// results maps to the constraint that filtered the node out, or to the
+	// empty string if the node satisfies all the constraints.
+	results map[feasibilityCacheKey]string
+
+	hits   uint64
+	misses uint64
+}
+
+// NewFeasibilityCache returns an empty FeasibilityCache.
+func NewFeasibilityCache() *FeasibilityCache {
+	return &FeasibilityCache{
+		results: make(map[feasibilityCacheKey]string),
+	}
+}
+
+// Get returns the constraint of the set that filtered the node out, which is
+// empty if the node is feasible, and whether a result was cached.
+func (c *FeasibilityCache) Get(constraints uint64, node *structs.Node) (string, bool) {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	failed, ok := c.results[newFeasibilityCacheKey(constraints, node)]
+	if ok {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	return failed, ok
+}
+
+// Set stores the result of checking the set of constraints against the node.
+func (c *FeasibilityCache) Set(constraints uint64, node *structs.Node, failed string) {
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	if len(c.results) >= feasibilityCacheMaxEntries {
+		c.results = make(map[feasibilityCacheKey]string)
+	}
+	c.results[newFeasibilityCacheKey(constraints, node)] = failed
+}
+
+// Stats returns the number of cache hits and misses since the cache was
+// created.
+func (c *FeasibilityCache) Stats() (hits, misses uint64) {
+	c.l.Lock()
+	defer c.l.Unlock()
+	return c.hits, c.misses
+}
+
+// hashConstraints returns the key of a set of constraints in a
+// FeasibilityCache. The set can't be cached if an error is returned.
+func hashConstraints(constraints []*structs.Constraint) (uint64, error) {
+	return hashstructure.Hash(constraints, nil)
+}
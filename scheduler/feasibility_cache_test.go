@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeasibilityCache(t *testing.T) {
+	ci.Parallel(t)
+
+	cache := NewFeasibilityCache()
+	node := mock.Node()
+	node.ModifyIndex = 10
+
+	_, ok := cache.Get(1, node)
+	require.False(t, ok)
+
+	cache.Set(1, node, "failed constraint")
+	cache.Set(2, node, "")
+
+	failed, ok := cache.Get(1, node)
+	require.True(t, ok)
+	require.Equal(t, "failed constraint", failed)
+
+	failed, ok = cache.Get(2, node)
+	require.True(t, ok)
+	require.Empty(t, failed)
+
+	// Results aren't used once the node is updated
+	node.ModifyIndex = 11
+	_, ok = cache.Get(1, node)
+	require.False(t, ok)
+
+	hits, misses := cache.Stats()
+	require.Equal(t, uint64(2), hits)
+	require.Equal(t, uint64(2), misses)
+}
+
+func TestConstraintChecker_FeasibilityCache(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := testContext(t)
+	linux := mock.Node()
+	linux.ModifyIndex = 10
+	windows := mock.Node()
+	windows.Attributes["kernel.name"] = "windows"
+	windows.ModifyIndex = 10
+
+	constraints := []*structs.Constraint{
+		{
+			Operand: "=",
+			LTarget: "${attr.kernel.name}",
+			RTarget: "linux",
+		},
+	}
+	checker := NewConstraintChecker(ctx, constraints)
+
+	// The first checks populate the cache
+	require.True(t, checker.Feasible(linux))
+	require.False(t, checker.Feasible(windows))
+	hits, misses := ctx.FeasibilityCache().Stats()
+	require.Zero(t, hits)
+	require.Equal(t, uint64(2), misses)
+
+	// The following checks, including from other checkers with the same
+	// constraints, use the cached results and record filtered nodes
+	ctx.Reset()
+	other := NewConstraintChecker(ctx, []*structs.Constraint{constraints[0].Copy()})
+	require.True(t, other.Feasible(linux))
+	require.False(t, other.Feasible(windows))
+	hits, _ = ctx.FeasibilityCache().Stats()
+	require.Equal(t, uint64(2), hits)
+	require.Equal(t, 1, ctx.Metrics().NodesFiltered)
+	require.Equal(t, 1, ctx.Metrics().ConstraintFiltered[constraints[0].String()])
+}
+
+func TestServiceSched_FeasibilityCacheSharedAcrossEvals(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+	h.Cache = NewFeasibilityCache()
+
+	for i := 0; i < 5; i++ {
+		node := mock.Node()
+		require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	for i := 0; i < 2; i++ {
+		job := mock.Job()
+		job.TaskGroups[0].Count = 2
+		require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+		eval := &structs.Evaluation{
+			Namespace:   structs.DefaultNamespace,
+			ID:          uuid.Generate(),
+			Priority:    job.Priority,
+			TriggeredBy: structs.EvalTriggerJobRegister,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+		}
+		require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+		require.NoError(t, h.Process(NewServiceScheduler, eval))
+	}
+
+	// The second job has the same constraints as the first one, so its
+	// evaluation is served from the results cached by the first one
+	hits, _ := h.Cache.Stats()
+	require.NotZero(t, hits)
+	require.Len(t, h.Plans, 2)
+}
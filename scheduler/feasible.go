@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	memdb "github.com/hashicorp/go-memdb"
 	version "github.com/hashicorp/go-version"
@@ -138,6 +139,10 @@ type HostVolumeChecker struct {
 	// volumes is a map[HostVolumeName][]RequestedVolume. The requested volumes are
 	// a slice because a single task group may request the same volume multiple times.
 	volumes map[string][]*structs.VolumeRequest
+
+	// alternatives are the requested volumes with alternative sources, which
+	// are satisfied by any of their sources.
+	alternatives []*structs.VolumeRequest
 }
 
 // NewHostVolumeChecker creates a HostVolumeChecker from a set of volumes
@@ -150,6 +155,7 @@ func NewHostVolumeChecker(ctx Context) *HostVolumeChecker {
 // SetVolumes takes the volumes required by a task group and updates the checker.
 func (h *HostVolumeChecker) SetVolumes(volumes map[string]*structs.VolumeRequest) {
 	lookupMap := make(map[string][]*structs.VolumeRequest)
+	var alternatives []*structs.VolumeRequest
 	// Convert the map from map[DesiredName]Request to map[Source][]Request to improve
 	// lookup performance. Also filter non-host volumes.
 	for _, req := range volumes {
@@ -157,9 +163,15 @@ func (h *HostVolumeChecker) SetVolumes(volumes map[string]*structs.VolumeRequest
 			continue
 		}
 
+		if len(req.AlternativeSources) > 0 {
+			alternatives = append(alternatives, req)
+			continue
+		}
+
 		lookupMap[req.Source] = append(lookupMap[req.Source], req)
 	}
 	h.volumes = lookupMap
+	h.alternatives = alternatives
 }
 
 func (h *HostVolumeChecker) Feasible(candidate *structs.Node) bool {
@@ -175,6 +187,14 @@ func (h *HostVolumeChecker) hasVolumes(n *structs.Node) bool {
 	rLen := len(h.volumes)
 	hLen := len(n.HostVolumes)
 
+	// The first source of the node that can be mounted is used for the
+	// requests with alternative sources
+	for _, req := range h.alternatives {
+		if _, ok := req.HostVolumeSource(n); !ok {
+			return false
+		}
+	}
+
 	// Fast path: Requested no volumes. No need to check further.
 	if rLen == 0 {
 		return true
@@ -409,10 +429,13 @@ func (c *NetworkChecker) Feasible(option *structs.Node) bool {
 
 func (c *NetworkChecker) hasHostNetworks(option *structs.Node) bool {
 	for _, port := range c.ports {
-		if port.HostNetwork != "" {
-			hostNetworkValue, hostNetworkOk := resolveTarget(port.HostNetwork, option)
+		for _, hostNetwork := range port.HostNetworkNames() {
+			if hostNetwork == "" {
+				continue
+			}
+			hostNetworkValue, hostNetworkOk := resolveTarget(hostNetwork, option)
 			if !hostNetworkOk {
-				c.ctx.Metrics().FilterNode(option, fmt.Sprintf("invalid host network %q template for port %q", port.HostNetwork, port.Label))
+				c.ctx.Metrics().FilterNode(option, fmt.Sprintf("invalid host network %q template for port %q", hostNetwork, port.Label))
 				return false
 			}
 			found := false
@@ -730,28 +753,75 @@ func (iter *DistinctPropertyIterator) Reset() {
 type ConstraintChecker struct {
 	ctx         Context
 	constraints []*structs.Constraint
+
+	// hash is the key of the constraints in the feasibility cache, which is
+	// only used if cacheable is set.
+	hash      uint64
+	cacheable bool
 }
 
 // NewConstraintChecker creates a ConstraintChecker for a set of constraints
 func NewConstraintChecker(ctx Context, constraints []*structs.Constraint) *ConstraintChecker {
-	return &ConstraintChecker{
-		ctx:         ctx,
-		constraints: constraints,
+	c := &ConstraintChecker{
+		ctx: ctx,
 	}
+	c.SetConstraints(constraints)
+	return c
 }
 
 func (c *ConstraintChecker) SetConstraints(constraints []*structs.Constraint) {
 	c.constraints = constraints
+	c.cacheable = false
+	if len(constraints) == 0 {
+		return
+	}
+
+	// Whether a time window is open depends on the time of the check rather
+	// than on the node, so the results can't be cached
+	for _, constraint := range constraints {
+		if constraint.Operand == structs.ConstraintTimeWindow {
+			return
+		}
+	}
+
+	hash, err := hashConstraints(constraints)
+	if err != nil {
+		c.ctx.Logger().Warn("failed to hash constraints, feasibility results won't be cached", "error", err)
+		return
+	}
+	c.hash = hash
+	c.cacheable = true
 }
 
 func (c *ConstraintChecker) Feasible(option *structs.Node) bool {
+	var cache *FeasibilityCache
+	if c.cacheable {
+		cache = c.ctx.FeasibilityCache()
+		if failed, ok := cache.Get(c.hash, option); ok {
+			if failed != "" {
+				c.ctx.Metrics().FilterNode(option, failed)
+				return false
+			}
+			return true
+		}
+	}
+
 	// Use this node if possible
+	failed := ""
 	for _, constraint := range c.constraints {
 		if !c.meetsConstraint(constraint, option) {
-			c.ctx.Metrics().FilterNode(option, constraint.String())
-			return false
+			failed = constraint.String()
+			break
 		}
 	}
+
+	if cache != nil {
+		cache.Set(c.hash, option, failed)
+	}
+	if failed != "" {
+		c.ctx.Metrics().FilterNode(option, failed)
+		return false
+	}
 	return true
 }
 
@@ -835,6 +905,8 @@ func checkConstraint(ctx Context, operand string, lVal, rVal interface{}, lFound
 		return lFound && rFound && checkSetContainsAll(ctx, lVal, rVal)
 	case structs.ConstraintSetContainsAny:
 		return lFound && rFound && checkSetContainsAny(lVal, rVal)
+	case structs.ConstraintTimeWindow:
+		return rFound && checkTimeWindow(rVal, timeNow())
 	default:
 		return false
 	}
@@ -947,6 +1019,59 @@ func checkAttributeVersionMatch(ctx Context, parse verConstraintParser, lVal, rV
 	return constraints.Check(vers)
 }
 
+// timeNow returns the time time windows are checked at. It is replaced in tests.
+var timeNow = time.Now
+
+// checkTimeWindow is used to check that the time window on the right hand
+// side is open at the given time
+func checkTimeWindow(rVal interface{}, now time.Time) bool {
+	spec, ok := rVal.(string)
+	if !ok {
+		return false
+	}
+
+	w, err := structs.ParseTimeWindow(spec)
+	if err != nil {
+		return false
+	}
+	return w.Open(now)
+}
+
+// nextTimeWindow returns the earliest time a closed time window of the
+// constraints of the job, or of the given task groups, opens at. It returns the
+// zero time if none of the time windows is closed.
+func nextTimeWindow(job *structs.Job, taskGroups map[string]*structs.AllocMetric, now time.Time) time.Time {
+	if job == nil {
+		return time.Time{}
+	}
+
+	constraints := append([]*structs.Constraint{}, job.Constraints...)
+	for _, tg := range job.TaskGroups {
+		if _, ok := taskGroups[tg.Name]; !ok {
+			continue
+		}
+		constraints = append(constraints, tg.Constraints...)
+		for _, task := range tg.Tasks {
+			constraints = append(constraints, task.Constraints...)
+		}
+	}
+
+	var next time.Time
+	for _, c := range constraints {
+		if c.Operand != structs.ConstraintTimeWindow {
+			continue
+		}
+		w, err := structs.ParseTimeWindow(c.RTarget)
+		if err != nil || w.Open(now) {
+			continue
+		}
+		if open := w.NextOpen(now); !open.IsZero() && (next.IsZero() || open.Before(next)) {
+			next = open
+		}
+	}
+	return next
+}
+
 // checkRegexpMatch is used to compare a value on the
 // left hand side with a regexp on the right hand side
 func checkRegexpMatch(ctx Context, lVal, rVal interface{}) bool {
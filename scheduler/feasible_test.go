@@ -173,6 +173,43 @@ func TestHostVolumeChecker(t *testing.T) {
 	}
 }
 
+func TestHostVolumeChecker_AlternativeSources(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := testContext(t)
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+	}
+	nodes[0].HostVolumes = map[string]*structs.ClientHostVolumeConfig{
+		"fast-ssd": {},
+	}
+	nodes[1].HostVolumes = map[string]*structs.ClientHostVolumeConfig{
+		"bulk": {},
+	}
+	nodes[2].HostVolumes = map[string]*structs.ClientHostVolumeConfig{
+		"fast-ssd": {ReadOnly: true},
+	}
+
+	volumes := map[string]*structs.VolumeRequest{
+		"data": {
+			Type:               "host",
+			Source:             "fast-ssd",
+			AlternativeSources: []string{"bulk"},
+		},
+	}
+
+	checker := NewHostVolumeChecker(ctx)
+	checker.SetVolumes(volumes)
+	require.True(t, checker.Feasible(nodes[0]))
+	require.True(t, checker.Feasible(nodes[1]))
+	require.False(t, checker.Feasible(nodes[2]), "read-only source can't be mounted read-write")
+
+	require.Equal(t, map[string]string{"data": "fast-ssd"}, hostVolumeSources(&structs.TaskGroup{Volumes: volumes}, nodes[0]))
+	require.Equal(t, map[string]string{"data": "bulk"}, hostVolumeSources(&structs.TaskGroup{Volumes: volumes}, nodes[1]))
+}
+
 func TestHostVolumeChecker_ReadOnly(t *testing.T) {
 	ci.Parallel(t)
 
@@ -632,6 +669,32 @@ func TestNetworkChecker(t *testing.T) {
 			},
 			results: []bool{false, false, false},
 		},
+		{
+			network: &structs.NetworkResource{
+				Mode: "bridge",
+				DynamicPorts: []structs.Port{
+					{
+						Label:        "metrics",
+						To:           9090,
+						HostNetworks: []string{"${meta.public_network}", "private"},
+					},
+				},
+			},
+			results: []bool{true, true, false},
+		},
+		{
+			network: &structs.NetworkResource{
+				Mode: "bridge",
+				DynamicPorts: []structs.Port{
+					{
+						Label:        "metrics",
+						To:           9090,
+						HostNetworks: []string{"public", "${meta.wrong_network}"},
+					},
+				},
+			},
+			results: []bool{false, false, false},
+		},
 		{
 			network: &structs.NetworkResource{Mode: "cni/mynet"},
 			results: []bool{false, false, true},
@@ -1171,6 +1234,59 @@ func TestCheckLexicalOrder(t *testing.T) {
 	}
 }
 
+func TestCheckTimeWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Date(2022, time.June, 6, 23, 30, 0, 0, time.UTC)
+	cases := []struct {
+		rVal   interface{}
+		result bool
+	}{
+		{rVal: "* 22-23,0-5 * * *", result: true},
+		{rVal: "* 9-17 * * *", result: false},
+		{rVal: "CRON_TZ=Europe/Paris * 0-5 * * *", result: true},
+		{rVal: "CRON_TZ=Nowhere/Special * * * * *", result: false},
+		{rVal: "not a cron expression", result: false},
+		{rVal: 1, result: false},
+	}
+	for _, tc := range cases {
+		if res := checkTimeWindow(tc.rVal, now); res != tc.result {
+			t.Fatalf("TC: %#v, Result: %v", tc, res)
+		}
+	}
+}
+
+func TestNextTimeWindow(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Date(2022, time.June, 6, 8, 30, 0, 0, time.UTC)
+
+	job := mock.Job()
+	require.True(t, nextTimeWindow(job, nil, now).IsZero())
+
+	// An open window does not delay the job
+	job.Constraints = append(job.Constraints, &structs.Constraint{
+		Operand: structs.ConstraintTimeWindow,
+		RTarget: "* 8 * * *",
+	})
+	require.True(t, nextTimeWindow(job, nil, now).IsZero())
+
+	// Closed windows of task groups that were not placed are considered
+	job.TaskGroups[0].Tasks[0].Constraints = append(job.TaskGroups[0].Tasks[0].Constraints, &structs.Constraint{
+		Operand: structs.ConstraintTimeWindow,
+		RTarget: "* 12 * * *",
+	})
+	job.TaskGroups[0].Constraints = append(job.TaskGroups[0].Constraints, &structs.Constraint{
+		Operand: structs.ConstraintTimeWindow,
+		RTarget: "* 10 * * *",
+	})
+	require.True(t, nextTimeWindow(job, nil, now).IsZero())
+
+	failed := map[string]*structs.AllocMetric{job.TaskGroups[0].Name: {}}
+	next := nextTimeWindow(job, failed, now)
+	require.Equal(t, time.Date(2022, time.June, 6, 10, 0, 0, 0, time.UTC), next.UTC())
+}
+
 func TestCheckVersionConstraint(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	log "github.com/hashicorp/go-hclog"
@@ -43,6 +44,10 @@ const (
 	// allocRescheduled is the status used when an allocation failed and was rescheduled
 	allocRescheduled = "alloc was rescheduled because it failed"
 
+	// allocDisplaced is the status used when an allocation is stopped to make
+	// room for a higher priority task group of its job
+	allocDisplaced = "alloc displaced by a higher priority task group"
+
 	// blockedEvalMaxPlanDesc is the description used for blocked evals that are
 	// a result of hitting the max number of plan attempts
 	blockedEvalMaxPlanDesc = "created due to placement conflicts"
@@ -55,6 +60,10 @@ const (
 	// up evals for delayed rescheduling
 	reschedulingFollowupEvalDesc = "created for delayed rescheduling"
 
+	// waitingOnDependenciesDesc is the description used for evals that didn't
+	// place the job because its dependencies aren't ready
+	waitingOnDependenciesDesc = "waiting on job dependencies"
+
 	// maxPastRescheduleEvents is the maximum number of past reschedule event
 	// that we track when unlimited rescheduling is enabled
 	maxPastRescheduleEvents = 5
@@ -148,13 +157,26 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		structs.EvalTriggerPeriodicJob, structs.EvalTriggerMaxPlans,
 		structs.EvalTriggerDeploymentWatcher, structs.EvalTriggerRetryFailedAlloc,
 		structs.EvalTriggerFailedFollowUp, structs.EvalTriggerPreemption,
-		structs.EvalTriggerScaling:
+		structs.EvalTriggerScaling, structs.EvalTriggerJobDependency:
 	default:
 		desc := fmt.Sprintf("scheduler cannot handle '%s' evaluation reason",
 			eval.TriggeredBy)
 		return setStatus(s.logger, s.planner, s.eval, nil, s.blocked,
 			s.failedTGAllocs, structs.EvalStatusFailed, desc, s.queuedAllocs,
-			s.deployment.GetID())
+			s.deployment.GetID(), s.ctx.Tracer().Trace())
+	}
+
+	// Hold the initial placement of the job until its dependencies are ready.
+	// The leader creates a new evaluation once they are.
+	pending, err := JobDependenciesPending(s.state, eval.Namespace, eval.JobID)
+	if err != nil {
+		return err
+	}
+	if len(pending) != 0 {
+		desc := fmt.Sprintf("%s: %s", waitingOnDependenciesDesc, strings.Join(pending, ", "))
+		return setStatus(s.logger, s.planner, s.eval, nil, s.blocked,
+			s.failedTGAllocs, structs.EvalStatusComplete, desc, s.queuedAllocs,
+			s.deployment.GetID(), s.ctx.Tracer().Trace())
 	}
 
 	// Retry up to the maxScheduleAttempts and reset if progress is made.
@@ -173,7 +195,7 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 			}
 			if err := setStatus(s.logger, s.planner, s.eval, nil, s.blocked,
 				s.failedTGAllocs, statusErr.EvalStatus, err.Error(),
-				s.queuedAllocs, s.deployment.GetID()); err != nil {
+				s.queuedAllocs, s.deployment.GetID(), s.ctx.Tracer().Trace()); err != nil {
 				mErr.Errors = append(mErr.Errors, err)
 			}
 			return mErr.ErrorOrNil()
@@ -189,13 +211,17 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		newEval.EscapedComputedClass = e.HasEscaped()
 		newEval.ClassEligibility = e.GetClasses()
 		newEval.QuotaLimitReached = e.QuotaLimitReached()
+		newEval.BlockedUntil = nextTimeWindow(s.job, s.failedTGAllocs, timeNow().UTC())
+		if trace := s.ctx.Tracer().Trace(); trace != nil {
+			newEval.Trace = trace
+		}
 		return s.planner.ReblockEval(newEval)
 	}
 
 	// Update the status to complete
 	return setStatus(s.logger, s.planner, s.eval, nil, s.blocked,
 		s.failedTGAllocs, structs.EvalStatusComplete, "", s.queuedAllocs,
-		s.deployment.GetID())
+		s.deployment.GetID(), s.ctx.Tracer().Trace())
 }
 
 // createBlockedEval creates a blocked eval and submits it to the planner. If
@@ -211,6 +237,7 @@ func (s *GenericScheduler) createBlockedEval(planFailure bool) error {
 	}
 
 	s.blocked = s.eval.CreateBlockedEval(classEligibility, escaped, e.QuotaLimitReached(), s.failedTGAllocs)
+	s.blocked.BlockedUntil = nextTimeWindow(s.job, s.failedTGAllocs, timeNow().UTC())
 	if planFailure {
 		s.blocked.TriggeredBy = structs.EvalTriggerMaxPlans
 		s.blocked.StatusDescription = blockedEvalMaxPlanDesc
@@ -256,9 +283,15 @@ func (s *GenericScheduler) process() (bool, error) {
 
 	// Create an evaluation context
 	s.ctx = NewEvalContext(s.eventsCh, s.state, s.plan, s.logger)
+	s.ctx.SetFeasibilityCache(s.planner.FeasibilityCache())
+	if evalTracingEnabled(s.state) {
+		s.ctx.EnableTracing()
+	}
 
 	// Construct the placement stack
 	s.stack = NewGenericStack(s.batch, s.ctx)
+	s.stack.SetNodeScorer(s.planner.NodeScorer())
+	s.stack.SetScoringPlugins(s.planner.ScoringPlugins())
 	if !s.job.Stopped() {
 		s.stack.SetJob(s.job)
 	}
@@ -431,9 +464,167 @@ func (s *GenericScheduler) computeJobAllocs() error {
 		s.queuedAllocs[p.placeTaskGroup.Name] += 1
 		destructive = append(destructive, p)
 	}
+
+	// Place higher priority task groups first, so that they can displace the
+	// allocations of lower priority groups if the job can't be fully placed
+	s.sortPlacementsByPriority(destructive)
+	s.sortPlacementsByPriority(place)
+
 	return s.computePlacements(destructive, place)
 }
 
+// sortPlacementsByPriority sorts placements by the priority of their task
+// group, highest first. The sort is stable so placements for groups with the
+// same priority keep the order computed by the reconciler.
+func (s *GenericScheduler) sortPlacementsByPriority(place []placementResult) {
+	sort.SliceStable(place, func(i, j int) bool {
+		return place[i].TaskGroup().EffectivePriority(s.job) >
+			place[j].TaskGroup().EffectivePriority(s.job)
+	})
+}
+
+// displaceForPlacement tries to place the task group by stopping running
+// allocations of lower priority task groups of the job. Nodes are tried in
+// turn, starting with the ones running the lowest priority allocations, and
+// only as many allocations as needed are stopped on a single node. If the
+// task group can't be placed the stops are backed out and nil is returned.
+// Allocations in replacing already have a replacement placed by the eval and
+// are never displaced.
+func (s *GenericScheduler) displaceForPlacement(tg *structs.TaskGroup, nodes []*structs.Node, allocName string, replacing map[string]struct{}) (*RankedNode, []*structs.Allocation, error) {
+	priority := tg.EffectivePriority(s.job)
+
+	allocs, err := s.state.AllocsByJob(nil, s.job.Namespace, s.job.ID, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get allocs for job '%s': %v", s.job.ID, err)
+	}
+
+	// Group the running allocations of lower priority groups by node, lowest
+	// priority first
+	stopping := make(map[string]struct{})
+	for _, updates := range s.plan.NodeUpdate {
+		for _, alloc := range updates {
+			stopping[alloc.ID] = struct{}{}
+		}
+	}
+	byNode := make(map[string][]*structs.Allocation)
+	for _, alloc := range allocs {
+		if alloc.TerminalStatus() {
+			continue
+		}
+		if _, ok := stopping[alloc.ID]; ok {
+			continue
+		}
+		if _, ok := replacing[alloc.ID]; ok {
+			continue
+		}
+		allocTG := s.job.LookupTaskGroup(alloc.TaskGroup)
+		if allocTG == nil || allocTG.EffectivePriority(s.job) >= priority {
+			continue
+		}
+		byNode[alloc.NodeID] = append(byNode[alloc.NodeID], alloc)
+	}
+	if len(byNode) == 0 {
+		return nil, nil, nil
+	}
+
+	lowest := func(allocs []*structs.Allocation) int {
+		return s.job.LookupTaskGroup(allocs[0].TaskGroup).EffectivePriority(s.job)
+	}
+	var candidates []*structs.Node
+	for _, node := range nodes {
+		allocs, ok := byNode[node.ID]
+		if !ok {
+			continue
+		}
+		sort.SliceStable(allocs, func(i, j int) bool {
+			return s.job.LookupTaskGroup(allocs[i].TaskGroup).EffectivePriority(s.job) <
+				s.job.LookupTaskGroup(allocs[j].TaskGroup).EffectivePriority(s.job)
+		})
+		candidates = append(candidates, node)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return lowest(byNode[candidates[i].ID]) < lowest(byNode[candidates[j].ID])
+	})
+
+	// Only consider the node the allocations are stopped on, and restore the
+	// placement nodes once done
+	defer s.stack.SetNodes(nodes)
+
+	for _, node := range candidates {
+		s.stack.SetNodes([]*structs.Node{node})
+
+		// Allocations being updated in place are dropped from the plan
+		// when they are stopped
+		planned, hasPlanned := s.plan.NodeAllocation[node.ID]
+
+		var stopped []*structs.Allocation
+		for _, alloc := range byNode[node.ID] {
+			s.plan.AppendStoppedAlloc(alloc, allocDisplaced, "", "")
+			if hasPlanned {
+				s.plan.NodeAllocation[node.ID] = structs.RemoveAllocs(
+					s.plan.NodeAllocation[node.ID], []*structs.Allocation{alloc})
+			}
+			stopped = append(stopped, alloc)
+
+			if option := s.stack.Select(tg, &SelectOptions{AllocName: allocName}); option != nil {
+				return option, stopped, nil
+			}
+		}
+
+		// Back out the stops in the reverse order they were appended in
+		for i := len(stopped) - 1; i >= 0; i-- {
+			s.plan.PopUpdate(stopped[i])
+		}
+		if hasPlanned {
+			s.plan.NodeAllocation[node.ID] = planned
+		}
+	}
+	return nil, nil, nil
+}
+
+// canDisplace returns whether a placement that failed may displace the
+// allocations of lower priority task groups. Only placements that failed
+// because the nodes ran out of resources, or that migrate an allocation off
+// a draining node, may: stopping allocations doesn't help placements that
+// failed for other reasons, such as constraints.
+func canDisplace(missing placementResult, metric *structs.AllocMetric) bool {
+	if metric.NodesExhausted > 0 {
+		return true
+	}
+	prev := missing.PreviousAllocation()
+	return prev != nil && prev.DesiredTransition.ShouldMigrate()
+}
+
+// handleDisplaced queues the allocations stopped to place a higher priority
+// task group again. There is no room left for them, so their task groups are
+// tracked as failed so that a blocked evaluation places them once capacity
+// frees up.
+func (s *GenericScheduler) handleDisplaced(displaced []*structs.Allocation, metric *structs.AllocMetric) {
+	for _, alloc := range displaced {
+		s.queuedAllocs[alloc.TaskGroup] += 1
+
+		if s.failedTGAllocs == nil {
+			s.failedTGAllocs = make(map[string]*structs.AllocMetric)
+		}
+		failed, ok := s.failedTGAllocs[alloc.TaskGroup]
+		if ok {
+			failed.CoalescedFailures += 1
+		} else {
+			failed = metric.Copy()
+			s.failedTGAllocs[alloc.TaskGroup] = failed
+		}
+		if tg := s.job.LookupTaskGroup(alloc.TaskGroup); tg != nil {
+			failed.ExhaustResources(tg)
+		}
+
+		if s.eval.AnnotatePlan && s.plan.Annotations != nil && s.plan.Annotations.DesiredTGUpdates != nil {
+			if desired, ok := s.plan.Annotations.DesiredTGUpdates[alloc.TaskGroup]; ok {
+				desired.Displaced += 1
+			}
+		}
+	}
+}
+
 // downgradedJobForPlacement returns the job appropriate for non-canary placement replacement
 func (s *GenericScheduler) downgradedJobForPlacement(p placementResult) (string, *structs.Job, error) {
 	ns, jobID := s.job.Namespace, s.job.ID
@@ -491,6 +682,16 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 	// Capture current time to use as the start time for any rescheduled allocations
 	now := time.Now()
 
+	// Track the allocations being replaced so they aren't displaced
+	replacing := make(map[string]struct{})
+	for _, results := range [][]placementResult{destructive, place} {
+		for _, missing := range results {
+			if prev := missing.PreviousAllocation(); prev != nil {
+				replacing[prev.ID] = struct{}{}
+			}
+		}
+	}
+
 	// Have to handle destructive changes first as we need to discount their
 	// resources. To understand this imagine the resources were reduced and the
 	// count was scaled up.
@@ -553,13 +754,31 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 			// Compute penalty nodes for rescheduled allocs
 			selectOptions := getSelectOptions(prevAllocation, preferredNode)
 			selectOptions.AllocName = missing.Name()
+			s.ctx.Tracer().SetTaskGroup(tg.Name)
 			option := s.selectNextOption(tg, selectOptions)
 
+			// If the task group can't be placed for lack of resources, make
+			// room for it by displacing allocations of lower priority groups
+			// of the job
+			metrics := s.ctx.Metrics()
+			if option == nil && canDisplace(missing, metrics) {
+				var displaced []*structs.Allocation
+				option, displaced, err = s.displaceForPlacement(tg, nodes, missing.Name(), replacing)
+				if err != nil {
+					return err
+				}
+				if option != nil {
+					metrics.NodesAvailable = byDC
+					s.handleDisplaced(displaced, metrics)
+					metrics = s.ctx.Metrics()
+				}
+			}
+
 			// Store the available nodes by datacenter
-			s.ctx.Metrics().NodesAvailable = byDC
+			metrics.NodesAvailable = byDC
 
 			// Compute top K scoring node metadata
-			s.ctx.Metrics().PopulateScoreMetaData()
+			metrics.PopulateScoreMetaData()
 
 			// Restore stack job now that placement is done, to use plan job version
 			if downgradedJob != nil {
@@ -588,7 +807,7 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 					Name:               missing.Name(),
 					JobID:              s.job.ID,
 					TaskGroup:          tg.Name,
-					Metrics:            s.ctx.Metrics(),
+					Metrics:            metrics,
 					NodeID:             option.Node.ID,
 					NodeName:           option.Node.Name,
 					DeploymentID:       deploymentID,
@@ -602,6 +821,7 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 						DiskMB:   tg.EphemeralDisk.SizeMB,
 						Networks: resources.Shared.Networks,
 					},
+					HostVolumeSources: hostVolumeSources(tg, option.Node),
 				}
 
 				// If the new allocation is replacing an older allocation then we
@@ -626,6 +846,10 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 				}
 
 				s.handlePreemptions(option, alloc, missing)
+				s.ctx.Tracer().Placed(option)
+				if s.eval.AnnotatePlan && s.plan.Annotations != nil {
+					s.plan.Annotations.AddPlacementScore(alloc, option.FinalScore)
+				}
 
 				// Track the placement
 				s.plan.AppendAlloc(alloc, downgradedJob)
@@ -637,10 +861,11 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 				}
 
 				// Update metrics with the resources requested by the task group.
-				s.ctx.Metrics().ExhaustResources(tg)
+				metrics.ExhaustResources(tg)
 
 				// Track the fact that we didn't find a placement
-				s.failedTGAllocs[tg.Name] = s.ctx.Metrics()
+				s.failedTGAllocs[tg.Name] = metrics
+				s.ctx.Tracer().PlacementFailed(metrics)
 
 				// If we weren't able to find a replacement for the allocation, back
 				// out the fact that we asked to stop the allocation.
@@ -753,7 +978,10 @@ func updateRescheduleTracker(alloc *structs.Allocation, prev *structs.Allocation
 	nextDelay := prev.NextDelay()
 	rescheduleEvent := structs.NewRescheduleEvent(now.UnixNano(), prev.ID, prev.NodeID, nextDelay)
 	rescheduleEvents = append(rescheduleEvents, rescheduleEvent)
-	alloc.RescheduleTracker = &structs.RescheduleTracker{Events: rescheduleEvents}
+	alloc.RescheduleTracker = &structs.RescheduleTracker{
+		Events:        rescheduleEvents,
+		LifetimeStart: prev.RescheduleLifetimeStart().UnixNano(),
+	}
 }
 
 // findPreferredNode finds the preferred node for an allocation
@@ -808,11 +1036,7 @@ func (s *GenericScheduler) handlePreemptions(option *RankedNode, alloc *structs.
 		preemptedAllocIDs = append(preemptedAllocIDs, stop.ID)
 
 		if s.eval.AnnotatePlan && s.plan.Annotations != nil {
-			s.plan.Annotations.PreemptedAllocs = append(s.plan.Annotations.PreemptedAllocs, stop.Stub(nil))
-			if s.plan.Annotations.DesiredTGUpdates != nil {
-				desired := s.plan.Annotations.DesiredTGUpdates[missing.TaskGroup().Name]
-				desired.Preemptions += 1
-			}
+			s.plan.Annotations.AddPreemptedAlloc(stop, missing.TaskGroup().Name)
 		}
 	}
 
@@ -315,6 +315,160 @@ func TestServiceSched_JobRegister_StickyAllocs(t *testing.T) {
 	}
 }
 
+func TestServiceSched_JobRegister_NodeScorer(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create two identical nodes, only distinguished by the external score
+	var nodes []*structs.Node
+	for i := 0; i < 2; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+		require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+	h.Scorer = testNodeScorer{
+		nodes[0].ID: -1,
+		nodes[1].ID: 1,
+	}
+
+	// Create a job with a single allocation
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	// Create a mock evaluation to register the job
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Ensure the allocation was placed on the preferred node
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+	require.Len(t, plan.NodeAllocation, 1)
+	require.Len(t, plan.NodeAllocation[nodes[1].ID], 1)
+}
+
+func TestServiceSched_JobRegister_ScoringPlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create two identical nodes, only distinguished by the plugin scores
+	var nodes []*structs.Node
+	for i := 0; i < 2; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+		require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	// The heavier plugin outweighs the lighter plugin's preference
+	h.Plugins = []*WeightedScoringPlugin{
+		{
+			Name:   "cost",
+			Weight: 1,
+			Plugin: testNodeScorer{nodes[0].ID: 1, nodes[1].ID: -1},
+		},
+		{
+			Name:   "power",
+			Weight: 3,
+			Plugin: testNodeScorer{nodes[0].ID: -1, nodes[1].ID: 1},
+		},
+	}
+
+	// Create a job with a single allocation
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	// Create a mock evaluation to plan the job
+	eval := &structs.Evaluation{
+		Namespace:    structs.DefaultNamespace,
+		ID:           uuid.Generate(),
+		Priority:     job.Priority,
+		TriggeredBy:  structs.EvalTriggerJobRegister,
+		JobID:        job.ID,
+		Status:       structs.EvalStatusPending,
+		AnnotatePlan: true,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Ensure the allocation was placed on the node preferred by the heavier
+	// plugin
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+	require.Len(t, plan.NodeAllocation, 1)
+	require.Len(t, plan.NodeAllocation[nodes[1].ID], 1)
+
+	// Ensure the plan attributes the score to each plugin
+	require.NotNil(t, plan.Annotations)
+	require.Len(t, plan.Annotations.PlacementScores, 1)
+	score := plan.Annotations.PlacementScores[0]
+	require.Equal(t, nodes[1].ID, score.NodeID)
+	require.Equal(t, "web", score.TaskGroup)
+	require.Equal(t, -1.0, score.Scores["cost"])
+	require.Equal(t, 1.0, score.Scores["power"])
+	require.Contains(t, score.Scores, "binpack")
+	require.Greater(t, score.FinalScore, 0.0)
+}
+
+func TestServiceSched_JobRegister_HostVolumeAlternatives(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create a node with the fallback volume only
+	node := mock.Node()
+	node.HostVolumes = map[string]*structs.ClientHostVolumeConfig{
+		"bulk": {Name: "bulk", Path: "/srv/bulk"},
+	}
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Create a job preferring another volume
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].Volumes = map[string]*structs.VolumeRequest{
+		"data": {
+			Name:               "data",
+			Type:               structs.VolumeTypeHost,
+			Source:             "fast-ssd",
+			AlternativeSources: []string{"bulk"},
+		},
+	}
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// The allocations record the fallback volume
+	require.Len(t, h.Plans, 1)
+	allocs := h.Plans[0].NodeAllocation[node.ID]
+	require.Len(t, allocs, 2)
+	for _, alloc := range allocs {
+		require.Equal(t, map[string]string{"data": "bulk"}, alloc.HostVolumeSources)
+	}
+}
+
 func TestServiceSched_JobRegister_DiskConstraints(t *testing.T) {
 	ci.Parallel(t)
 
@@ -991,6 +1145,201 @@ func TestServiceSched_JobRegister_Annotate(t *testing.T) {
 	}
 }
 
+func TestServiceSched_JobRegister_TaskGroupPriority(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create a single node with room for 7 allocations
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Create a job with a low priority group whose allocations fill the node
+	job := mock.Job()
+	low := job.TaskGroups[0]
+	low.Name = "low"
+	low.Count = 7
+	low.Priority = 10
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < low.Count; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = structs.AllocName(job.ID, low.Name, uint(i))
+		alloc.TaskGroup = low.Name
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		alloc.AllocatedResources.Tasks["web"].Networks = nil
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	// Update the job to add a high priority group
+	job2 := job.Copy()
+	high := job2.TaskGroups[0].Copy()
+	high.Name = "high"
+	high.Count = 3
+	high.Priority = job2.Priority
+	job2.TaskGroups = append(job2.TaskGroups, high)
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job2))
+
+	// Create a mock evaluation to register the job
+	eval := &structs.Evaluation{
+		Namespace:    structs.DefaultNamespace,
+		ID:           uuid.Generate(),
+		Priority:     job2.Priority,
+		TriggeredBy:  structs.EvalTriggerJobRegister,
+		JobID:        job2.ID,
+		AnnotatePlan: true,
+		Status:       structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+
+	// Ensure the high priority group was fully placed
+	placed := map[string]int{}
+	for _, allocList := range plan.NodeAllocation {
+		for _, alloc := range allocList {
+			placed[alloc.TaskGroup]++
+		}
+	}
+	require.Equal(t, 3, placed["high"])
+
+	// Ensure low priority allocations were stopped to make room
+	var stopped []*structs.Allocation
+	for _, allocList := range plan.NodeUpdate {
+		stopped = append(stopped, allocList...)
+	}
+	require.Len(t, stopped, 3)
+	for _, alloc := range stopped {
+		require.Equal(t, "low", alloc.TaskGroup)
+		require.Equal(t, allocDisplaced, alloc.DesiredDescription)
+	}
+
+	// Ensure the displaced allocations are annotated and queued
+	require.NotNil(t, plan.Annotations)
+	desiredTGs := plan.Annotations.DesiredTGUpdates
+	require.Equal(t, uint64(0), desiredTGs["high"].Displaced)
+	require.Equal(t, uint64(3), desiredTGs["low"].Displaced)
+
+	require.Len(t, h.Evals, 1)
+	require.Contains(t, h.Evals[0].FailedTGAllocs, "low")
+	require.NotContains(t, h.Evals[0].FailedTGAllocs, "high")
+	require.Equal(t, 3, h.Evals[0].QueuedAllocations["low"])
+	require.Equal(t, 0, h.Evals[0].QueuedAllocations["high"])
+	require.Len(t, h.CreateEvals, 1)
+	require.Equal(t, structs.EvalStatusBlocked, h.CreateEvals[0].Status)
+
+	// Ensure the failure metrics of the displaced group report the resources
+	// of its own allocations
+	failed := h.Evals[0].FailedTGAllocs["low"]
+	require.Equal(t, 2, failed.CoalescedFailures)
+	require.NotZero(t, failed.NodesExhausted)
+	require.Equal(t, 3*low.Tasks[0].Resources.CPU, failed.ResourcesExhausted["web"].CPU)
+
+	// Ensure the placed allocations have the metrics of their placement
+	out, err := h.State.AllocsByJob(nil, job2.Namespace, job2.ID, false)
+	require.NoError(t, err)
+	for _, alloc := range out {
+		if alloc.TaskGroup != "high" {
+			continue
+		}
+		require.NotNil(t, alloc.Metrics)
+		require.Equal(t, map[string]int{"dc1": 1}, alloc.Metrics.NodesAvailable)
+		require.Zero(t, alloc.Metrics.NodesExhausted)
+	}
+}
+
+// TestServiceSched_JobRegister_TaskGroupPriority_NoDisplace asserts that a
+// higher priority task group that can't be placed for reasons other than
+// resources doesn't displace the allocations of lower priority groups, even
+// if displacing them would make room for it.
+func TestServiceSched_JobRegister_TaskGroupPriority_NoDisplace(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create a single node
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Create a job with distinct hosts, with a low priority group already
+	// running on the node and a high priority group yet to be placed
+	job := mock.Job()
+	job.Constraints = append(job.Constraints, &structs.Constraint{Operand: structs.ConstraintDistinctHosts})
+	low := job.TaskGroups[0]
+	low.Name = "low"
+	low.Count = 7
+	low.Priority = 10
+	high := low.Copy()
+	high.Name = "high"
+	high.Count = 3
+	high.Priority = job.Priority
+	job.TaskGroups = append(job.TaskGroups, high)
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < low.Count; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = structs.AllocName(job.ID, low.Name, uint(i))
+		alloc.TaskGroup = low.Name
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		alloc.AllocatedResources.Tasks["web"].Networks = nil
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+	// Create a mock evaluation to register the job
+	eval := &structs.Evaluation{
+		Namespace:    structs.DefaultNamespace,
+		ID:           uuid.Generate(),
+		Priority:     job.Priority,
+		TriggeredBy:  structs.EvalTriggerJobRegister,
+		JobID:        job.ID,
+		AnnotatePlan: true,
+		Status:       structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Ensure no allocation was stopped or placed
+	for _, plan := range h.Plans {
+		for _, allocList := range plan.NodeUpdate {
+			require.Empty(t, allocList)
+		}
+		for _, allocList := range plan.NodeAllocation {
+			require.Empty(t, allocList)
+		}
+		if plan.Annotations != nil {
+			desiredTGs := plan.Annotations.DesiredTGUpdates
+			require.Equal(t, uint64(0), desiredTGs["low"].Displaced)
+			require.Equal(t, uint64(3), desiredTGs["high"].Place)
+		}
+	}
+
+	// Ensure the failed placements are reported for the high priority group
+	// only, with the metrics of the constraint that filtered the node
+	require.Len(t, h.Evals, 1)
+	require.NotContains(t, h.Evals[0].FailedTGAllocs, "low")
+	failed := h.Evals[0].FailedTGAllocs["high"]
+	require.NotNil(t, failed)
+	require.Equal(t, 2, failed.CoalescedFailures)
+	require.Equal(t, 1, failed.NodesFiltered)
+	require.Zero(t, failed.NodesExhausted)
+	require.Equal(t, 3, h.Evals[0].QueuedAllocations["high"])
+	require.Equal(t, 0, h.Evals[0].QueuedAllocations["low"])
+}
+
 func TestServiceSched_JobRegister_CountZero(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2420,7 +2769,7 @@ func TestServiceSched_JobModify_InPlace(t *testing.T) {
 		require.Equal(t, alloc.AllocatedResources.Shared.Networks, asr.Networks)
 
 		for _, resources := range alloc.AllocatedResources.Tasks {
-			if resources.Networks[0].ReservedPorts[0] != rp {
+			if !reflect.DeepEqual(resources.Networks[0].ReservedPorts[0], rp) {
 				t.Fatalf("bad: %#v", alloc)
 			}
 			if len(resources.Devices) == 0 || reflect.DeepEqual(resources.Devices[0], adr) {
@@ -4273,6 +4622,173 @@ func TestBatchSched_Run_CompleteAlloc(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestBatchSched_JobDependencies(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create a node
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Create the dependency, which isn't running yet
+	dep := mock.Job()
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), dep))
+
+	// Create a job that depends on it
+	job := mock.Job()
+	job.Type = structs.JobTypeBatch
+	job.TaskGroups[0].Count = 1
+	job.DependsOn = []string{dep.ID}
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	// Create a mock evaluation to register the job
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// The job is held by its dependency
+	require.NoError(t, h.Process(NewBatchScheduler, eval))
+	require.Empty(t, h.Plans)
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+	require.Contains(t, h.Evals[0].StatusDescription, waitingOnDependenciesDesc)
+	require.Contains(t, h.Evals[0].StatusDescription, dep.ID)
+
+	// The job stays pending while it is held
+	ws := memdb.NewWatchSet()
+	out, err := h.State.JobByID(ws, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.JobStatusPending, out.Status)
+
+	// Start the dependency, which has no deployment
+	alloc := mock.Alloc()
+	alloc.Job = dep
+	alloc.JobID = dep.ID
+	alloc.NodeID = node.ID
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{alloc}))
+
+	// Once the dependency is ready the job is placed
+	eval = &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobDependency,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	require.NoError(t, h.Process(NewBatchScheduler, eval))
+	require.Len(t, h.Plans, 1)
+
+	placed, err := h.State.AllocsByJob(ws, job.Namespace, job.ID, false)
+	require.NoError(t, err)
+	require.Len(t, placed, 1)
+}
+
+func TestBatchSched_Run_TimeWindowClosed(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create a node
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Create a job that may only be placed in a window far in the future
+	job := mock.Job()
+	job.Type = structs.JobTypeBatch
+	job.TaskGroups[0].Count = 1
+	job.Constraints = append(job.Constraints, &structs.Constraint{
+		Operand: structs.ConstraintTimeWindow,
+		RTarget: "0 0 1 1 * 2099",
+	})
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	// Create a mock evaluation to register the job
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewBatchScheduler, eval))
+
+	// Ensure nothing was placed
+	require.Empty(t, h.Plans)
+
+	// Ensure a blocked eval was created that unblocks when the window opens
+	require.Len(t, h.CreateEvals, 1)
+	blocked := h.CreateEvals[0]
+	require.Equal(t, structs.EvalStatusBlocked, blocked.Status)
+	require.Equal(t, time.Date(2099, time.January, 1, 0, 0, 0, 0, time.UTC), blocked.BlockedUntil.UTC())
+}
+
+func TestBatchSched_Run_TimeWindowOpens(t *testing.T) {
+	// Not parallel because the clock of time windows is replaced
+	now := time.Date(2022, time.June, 6, 8, 30, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	h := NewHarness(t)
+	h.Cache = NewFeasibilityCache()
+
+	// Create a node
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Create a job that may only be placed from 10:00
+	job := mock.Job()
+	job.Type = structs.JobTypeBatch
+	job.TaskGroups[0].Count = 1
+	job.Constraints = append(job.Constraints, &structs.Constraint{
+		Operand: structs.ConstraintTimeWindow,
+		RTarget: "* 10 * * *",
+	})
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation while the window is closed
+	require.NoError(t, h.Process(NewBatchScheduler, eval))
+	require.Empty(t, h.Plans)
+	require.Len(t, h.CreateEvals, 1)
+	blocked := h.CreateEvals[0]
+	require.Equal(t, time.Date(2022, time.June, 6, 10, 0, 0, 0, time.UTC), blocked.BlockedUntil.UTC())
+
+	// Process the blocked evaluation with the same cache once the window
+	// opened, and ensure the node isn't still considered infeasible
+	now = blocked.BlockedUntil
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{blocked}))
+	require.NoError(t, h.Process(NewBatchScheduler, blocked))
+	require.Len(t, h.Plans, 1)
+
+	var placed []*structs.Allocation
+	for _, allocs := range h.Plans[0].NodeAllocation {
+		placed = append(placed, allocs...)
+	}
+	require.Len(t, placed, 1)
+}
+
 func TestBatchSched_Run_FailedAlloc(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5631,6 +6147,27 @@ func Test_updateRescheduleTracker(t *testing.T) {
 
 }
 
+func Test_updateRescheduleTracker_LifetimeStart(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now().UTC()
+	created := now.Add(-time.Hour)
+
+	// The first reschedule starts the lifetime at the creation of the
+	// failed allocation
+	first := mock.Alloc()
+	first.CreateTime = created.UnixNano()
+	second := mock.Alloc()
+	second.CreateTime = now.UnixNano()
+	updateRescheduleTracker(second, first, now)
+	require.Equal(t, created.UnixNano(), second.RescheduleTracker.LifetimeStart)
+
+	// Later reschedules keep it
+	third := mock.Alloc()
+	updateRescheduleTracker(third, second, now.Add(time.Minute))
+	require.Equal(t, created.UnixNano(), third.RescheduleTracker.LifetimeStart)
+}
+
 func TestServiceSched_Preemption(t *testing.T) {
 	ci.Parallel(t)
 
@@ -5751,12 +6288,13 @@ func TestServiceSched_Preemption(t *testing.T) {
 
 	// Create a mock evaluation to register the job
 	eval := &structs.Evaluation{
-		Namespace:   structs.DefaultNamespace,
-		ID:          uuid.Generate(),
-		Priority:    job3.Priority,
-		TriggeredBy: structs.EvalTriggerJobRegister,
-		JobID:       job3.ID,
-		Status:      structs.EvalStatusPending,
+		Namespace:    structs.DefaultNamespace,
+		ID:           uuid.Generate(),
+		Priority:     job3.Priority,
+		TriggeredBy:  structs.EvalTriggerJobRegister,
+		JobID:        job3.ID,
+		Status:       structs.EvalStatusPending,
+		AnnotatePlan: true,
 	}
 
 	require.NoError(h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
@@ -5768,6 +6306,18 @@ func TestServiceSched_Preemption(t *testing.T) {
 	// New plan should be the third one in the harness
 	plan := h.Plans[2]
 
+	// Ensure the plan is annotated with the preempted allocs and the task
+	// group preempting them
+	require.NotNil(plan.Annotations)
+	tgName := job3.TaskGroups[0].Name
+	require.Len(plan.Annotations.PreemptedAllocs, len(expectedPreemptedAllocs))
+	for _, stub := range plan.Annotations.PreemptedAllocs {
+		require.Contains(expectedPreemptedAllocs, stub.ID)
+		require.Equal(node.ID, stub.NodeID)
+		require.Equal(tgName, plan.Annotations.PreemptingTaskGroups[stub.ID])
+	}
+	require.Equal(uint64(len(expectedPreemptedAllocs)), plan.Annotations.DesiredTGUpdates[tgName].Preemptions)
+
 	// Ensure the eval has no spawned blocked eval
 	require.Equal(0, len(h.CreateEvals))
 
@@ -33,6 +33,11 @@ type RankedNode struct {
 	// PreemptedAllocs is used by the BinpackIterator to identify allocs
 	// that should be preempted in order to make the placement
 	PreemptedAllocs []*structs.Allocation
+
+	// extraWeight is the weight of the scores in Scores beyond one score per
+	// entry. Weighted scoring plugins append their weighted score along with
+	// their extra weight so the scores are averaged by total weight.
+	extraWeight float64
 }
 
 func (r *RankedNode) GoString() string {
@@ -281,6 +286,10 @@ OUTER:
 						continue OUTER
 					}
 				}
+				if !iter.resolveHostNetworks(option.Node, &ask.DynamicPorts[i]) {
+					netIdx.Release()
+					continue OUTER
+				}
 			}
 			for i, port := range ask.ReservedPorts {
 				if port.HostNetwork != "" {
@@ -292,6 +301,10 @@ OUTER:
 						continue OUTER
 					}
 				}
+				if !iter.resolveHostNetworks(option.Node, &ask.ReservedPorts[i]) {
+					netIdx.Release()
+					continue OUTER
+				}
 			}
 			offer, err := netIdx.AssignPorts(ask)
 			if err != nil {
@@ -550,6 +563,21 @@ OUTER:
 	}
 }
 
+// resolveHostNetworks interpolates the host networks of a port mapped on
+// several host networks with the node's attributes. It returns false if one of
+// them is an invalid template.
+func (iter *BinPackIterator) resolveHostNetworks(node *structs.Node, port *structs.Port) bool {
+	for i, hostNetwork := range port.HostNetworks {
+		hostNetworkValue, hostNetworkOk := resolveTarget(hostNetwork, node)
+		if !hostNetworkOk {
+			iter.ctx.Logger().Named("binpack").Error(fmt.Sprintf("Invalid template for %s host network in port %s", hostNetwork, port.Label))
+			return false
+		}
+		port.HostNetworks[i] = hostNetworkValue.(string)
+	}
+	return true
+}
+
 func (iter *BinPackIterator) Reset() {
 	iter.source.Reset()
 }
@@ -758,6 +786,131 @@ func matchesAffinity(ctx Context, affinity *structs.Affinity, option *structs.No
 	return checkAffinity(ctx, affinity.Operand, lVal, rVal, lOk, rOk)
 }
 
+// ExternalScoreIterator is used to apply the score contributed by an external
+// NodeScorer. Once the scorer fails to score a node, for example because it
+// timed out, it isn't called again for the rest of the evaluation so a
+// failing scorer doesn't stall the worker for its timeout on every candidate
+// node. Nodes that aren't scored are ranked using the other scores only.
+type ExternalScoreIterator struct {
+	ctx    Context
+	source RankIterator
+	scorer NodeScorer
+	job    *structs.Job
+	tg     *structs.TaskGroup
+
+	// failed is set once the scorer has failed in this evaluation
+	failed bool
+}
+
+// NewExternalScoreIterator is used to create an ExternalScoreIterator that
+// applies the score of the given scorer, which may be nil.
+func NewExternalScoreIterator(ctx Context, source RankIterator, scorer NodeScorer) *ExternalScoreIterator {
+	return &ExternalScoreIterator{
+		ctx:    ctx,
+		source: source,
+		scorer: scorer,
+	}
+}
+
+func (iter *ExternalScoreIterator) SetScorer(scorer NodeScorer) {
+	iter.scorer = scorer
+	iter.failed = false
+}
+
+func (iter *ExternalScoreIterator) SetJob(job *structs.Job) {
+	iter.job = job
+}
+
+func (iter *ExternalScoreIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+}
+
+func (iter *ExternalScoreIterator) Reset() {
+	iter.source.Reset()
+}
+
+func (iter *ExternalScoreIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil || iter.scorer == nil || iter.failed {
+		return option
+	}
+
+	score, err := iter.scorer.ScoreNode(iter.job, iter.tg, option.Node)
+	if err != nil {
+		iter.ctx.Logger().Named("external_score").Warn("failed to score node, skipping external scores for the evaluation",
+			"node_id", option.Node.ID, "error", err)
+		iter.failed = true
+		return option
+	}
+
+	// Clamp the score to the range of the other scores
+	score = math.Max(-1, math.Min(1, score))
+	option.Scores = append(option.Scores, score)
+	iter.ctx.Metrics().ScoreNode(option.Node, "external", score)
+	return option
+}
+
+// ScoringPluginIterator is used to apply the scores of the scoring plugins
+// enabled on the server. Each plugin's score counts as weight times a
+// built-in score when the scores are averaged. Nodes a plugin fails to score
+// are ranked without that plugin's score.
+type ScoringPluginIterator struct {
+	ctx     Context
+	source  RankIterator
+	plugins []*WeightedScoringPlugin
+	job     *structs.Job
+	tg      *structs.TaskGroup
+}
+
+// NewScoringPluginIterator is used to create a ScoringPluginIterator that
+// applies the scores of the given plugins.
+func NewScoringPluginIterator(ctx Context, source RankIterator, plugins []*WeightedScoringPlugin) *ScoringPluginIterator {
+	return &ScoringPluginIterator{
+		ctx:     ctx,
+		source:  source,
+		plugins: plugins,
+	}
+}
+
+func (iter *ScoringPluginIterator) SetPlugins(plugins []*WeightedScoringPlugin) {
+	iter.plugins = plugins
+}
+
+func (iter *ScoringPluginIterator) SetJob(job *structs.Job) {
+	iter.job = job
+}
+
+func (iter *ScoringPluginIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+}
+
+func (iter *ScoringPluginIterator) Reset() {
+	iter.source.Reset()
+}
+
+func (iter *ScoringPluginIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil {
+		return option
+	}
+
+	for _, p := range iter.plugins {
+		score, err := p.Plugin.ScoreNode(iter.job, iter.tg, option.Node)
+		if err != nil {
+			iter.ctx.Logger().Named("scoring_plugin").Debug("failed to score node",
+				"plugin", p.Name, "node_id", option.Node.ID, "error", err)
+			continue
+		}
+
+		// Clamp the score to the range of the other scores
+		score = math.Max(-1, math.Min(1, score))
+		option.Scores = append(option.Scores, score*p.Weight)
+		option.extraWeight += p.Weight - 1
+		iter.ctx.Metrics().ScoreNode(option.Node, p.Name, score)
+	}
+	return option
+}
+
 // ScoreNormalizationIterator is used to combine scores from various prior
 // iterators and combine them into one final score. The current implementation
 // averages the scores together.
@@ -783,12 +936,12 @@ func (iter *ScoreNormalizationIterator) Next() *RankedNode {
 	if option == nil || len(option.Scores) == 0 {
 		return option
 	}
-	numScorers := len(option.Scores)
+	numScorers := float64(len(option.Scores)) + option.extraWeight
 	sum := 0.0
 	for _, score := range option.Scores {
 		sum += score
 	}
-	option.FinalScore = sum / float64(numScorers)
+	option.FinalScore = sum / numScorers
 	//TODO(preetha): Turn map in allocmetrics into a heap of topK scores
 	iter.ctx.Metrics().ScoreNode(option.Node, "normalized-score", option.FinalScore)
 	return option
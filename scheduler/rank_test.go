@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"fmt"
 	"sort"
 	"testing"
 
@@ -2095,3 +2096,109 @@ func TestNodeAffinityIterator(t *testing.T) {
 	}
 
 }
+
+// testNodeScorer scores nodes from a map, failing for unknown nodes.
+type testNodeScorer map[string]float64
+
+func (s testNodeScorer) ScoreNode(_ *structs.Job, _ *structs.TaskGroup, node *structs.Node) (float64, error) {
+	score, ok := s[node.ID]
+	if !ok {
+		return 0, fmt.Errorf("timed out")
+	}
+	return score, nil
+}
+
+func TestExternalScoreIterator(t *testing.T) {
+	_, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+		{Node: mock.Node()},
+	}
+	static := NewStaticRankIterator(ctx, nodes)
+
+	job := mock.Job()
+	scorer := testNodeScorer{
+		nodes[0].Node.ID: 0.5,
+		nodes[1].Node.ID: 3,
+	}
+
+	iter := NewExternalScoreIterator(ctx, static, scorer)
+	iter.SetJob(job)
+	iter.SetTaskGroup(job.TaskGroups[0])
+
+	out := collectRanked(iter)
+	require.Len(t, out, 3)
+
+	// Scores are applied and clamped, failed nodes are not scored
+	require.Equal(t, []float64{0.5}, out[0].Scores)
+	require.Equal(t, []float64{1}, out[1].Scores)
+	require.Empty(t, out[2].Scores)
+
+	// The scorer isn't called again after it fails
+	static.Reset()
+	for _, node := range nodes {
+		node.Scores = nil
+	}
+	out = collectRanked(iter)
+	require.Len(t, out, 3)
+	for _, option := range out {
+		require.Empty(t, option.Scores)
+	}
+
+	// Nodes are not scored without a scorer
+	static.Reset()
+	for _, node := range nodes {
+		node.Scores = nil
+	}
+	iter.SetScorer(nil)
+	out = collectRanked(iter)
+	require.Len(t, out, 3)
+	for _, option := range out {
+		require.Empty(t, option.Scores)
+	}
+}
+
+func TestScoringPluginIterator(t *testing.T) {
+	_, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{Node: mock.Node(), Scores: []float64{0.5}},
+		{Node: mock.Node(), Scores: []float64{0.5}},
+		{Node: mock.Node(), Scores: []float64{0.5}},
+	}
+	static := NewStaticRankIterator(ctx, nodes)
+
+	job := mock.Job()
+	plugins := []*WeightedScoringPlugin{
+		{
+			Name:   "cost",
+			Weight: 3,
+			Plugin: testNodeScorer{
+				nodes[0].Node.ID: 1,
+				nodes[1].Node.ID: -2,
+			},
+		},
+	}
+
+	iter := NewScoringPluginIterator(ctx, static, plugins)
+	iter.SetJob(job)
+	iter.SetTaskGroup(job.TaskGroups[0])
+	norm := NewScoreNormalizationIterator(ctx, iter)
+
+	out := collectRanked(norm)
+	require.Len(t, out, 3)
+
+	// Plugin scores count as weight times a built-in score, failed nodes
+	// are ranked by the built-in scores only
+	require.Equal(t, []float64{0.5, 3}, out[0].Scores)
+	require.Equal(t, (0.5+3)/4, out[0].FinalScore)
+	require.Equal(t, []float64{0.5, -3}, out[1].Scores)
+	require.Equal(t, (0.5-3)/4, out[1].FinalScore)
+	require.Equal(t, []float64{0.5}, out[2].Scores)
+	require.Equal(t, 0.5, out[2].FinalScore)
+
+	// Unweighted plugin scores are recorded under the plugin name
+	ctx.Metrics().PopulateScoreMetaData()
+	require.Equal(t, nodes[0].Node.ID, ctx.Metrics().ScoreMetaData[0].NodeID)
+	require.Equal(t, 1.0, ctx.Metrics().ScoreMetaData[0].Scores["cost"])
+}
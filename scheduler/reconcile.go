@@ -484,6 +484,7 @@ func (a *allocReconciler) initializeDeploymentState(group string, tg *structs.Ta
 		if !tg.Update.IsEmpty() {
 			dstate.AutoRevert = tg.Update.AutoRevert
 			dstate.AutoPromote = tg.Update.AutoPromote
+			dstate.BlueGreen = tg.Update.BlueGreen
 			dstate.ProgressDeadline = tg.Update.ProgressDeadline
 		}
 	}
@@ -625,6 +626,12 @@ func (a *allocReconciler) computeUnderProvisionedBy(group *structs.TaskGroup, un
 		}
 	}
 
+	// If the canaries have been partially promoted, only roll out to the
+	// promoted percentage of the group.
+	if dstate, ok := a.deployment.TaskGroups[group.Name]; ok && dstate.PartiallyPromoted() {
+		underProvisionedBy = helper.IntMin(underProvisionedBy, dstate.PromotedTotal()-len(partOf))
+	}
+
 	// The limit can be less than zero in the case that the job was changed such
 	// that it required destructive changes and the count was scaled up.
 	if underProvisionedBy < 0 {
@@ -3794,6 +3794,83 @@ func TestReconciler_PromoteCanaries_Unblock(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(0, 1), stopResultsToNames(r.stop))
 }
 
+// Tests the reconciler only rolls out to the promoted percentage of the group
+// when the canaries are partially promoted
+func TestReconciler_PromoteCanaries_Partial(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	job.TaskGroups[0].Update = canaryUpdate
+
+	// Create an existing deployment that has placed some canaries and mark them
+	// promoted to 30% of the group
+	d := structs.NewDeployment(job, 50)
+	s := &structs.DeploymentState{
+		Promoted:        true,
+		PromotedPercent: 30,
+		DesiredTotal:    10,
+		DesiredCanaries: 2,
+		PlacedAllocs:    2,
+	}
+	d.TaskGroups[job.TaskGroups[0].Name] = s
+
+	// Create 10 allocations from the old job
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, alloc)
+	}
+
+	// Create the canaries
+	handled := make(map[string]allocUpdateType)
+	for i := 0; i < 2; i++ {
+		// Create one canary
+		canary := mock.Alloc()
+		canary.Job = job
+		canary.JobID = job.ID
+		canary.NodeID = uuid.Generate()
+		canary.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		canary.TaskGroup = job.TaskGroups[0].Name
+		s.PlacedCanaries = append(s.PlacedCanaries, canary.ID)
+		canary.DeploymentID = d.ID
+		canary.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy: helper.BoolToPtr(true),
+		}
+		allocs = append(allocs, canary)
+		handled[canary.ID] = allocUpdateFnIgnore
+	}
+
+	mockUpdateFn := allocUpdateFnMock(handled, allocUpdateFnDestructive)
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job,
+		d, allocs, nil, "", 50)
+	r := reconciler.Compute()
+
+	// Assert the correct results: only one of the old allocations is replaced
+	// so that three of the ten allocations run the new version
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		destructive:       1,
+		stop:              2,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Stop:              2,
+				DestructiveUpdate: 1,
+				Ignore:            9,
+			},
+		},
+	})
+
+	assertNoCanariesStopped(t, d, r.stop)
+	assertNamesHaveIndexes(t, intRange(2, 2), destructiveResultsToNames(r.destructiveUpdate))
+	assertNamesHaveIndexes(t, intRange(0, 1), stopResultsToNames(r.stop))
+}
+
 // Tests the reconciler handles canary promotion when the canary count equals
 // the total correctly
 func TestReconciler_PromoteCanaries_CanariesEqualCount(t *testing.T) {
@@ -132,4 +132,26 @@ type Planner interface {
 	// evaluation must exist in a blocked state prior to this being called such
 	// that on leader changes, the evaluation will be reblocked properly.
 	ReblockEval(*structs.Evaluation) error
+
+	// NodeScorer returns the external scorer that contributes a score to the
+	// ranking of candidate nodes, or nil if none is configured.
+	NodeScorer() NodeScorer
+
+	// ScoringPlugins returns the scoring plugins enabled on the server along
+	// with their weights.
+	ScoringPlugins() []*WeightedScoringPlugin
+
+	// FeasibilityCache returns the cache of node feasibility results shared
+	// across the evaluations processed by the planner, or nil if results
+	// should only be cached for the duration of each evaluation.
+	FeasibilityCache() *FeasibilityCache
+}
+
+// NodeScorer is implemented by external sources of node scores, such as a
+// cost or power aware placement service.
+type NodeScorer interface {
+	// ScoreNode returns the score of placing the task group of the job on
+	// the node, between -1 and 1. Nodes are not scored by the NodeScorer if
+	// it returns an error.
+	ScoreNode(job *structs.Job, tg *structs.TaskGroup, node *structs.Node) (float64, error)
 }
@@ -90,7 +90,7 @@ func (s *SystemScheduler) Process(eval *structs.Evaluation) (err error) {
 	if !s.canHandle(eval.TriggeredBy) {
 		desc := fmt.Sprintf("scheduler cannot handle '%s' evaluation reason", eval.TriggeredBy)
 		return setStatus(s.logger, s.planner, s.eval, s.nextEval, nil, s.failedTGAllocs, structs.EvalStatusFailed, desc,
-			s.queuedAllocs, "")
+			s.queuedAllocs, "", s.ctx.Tracer().Trace())
 	}
 
 	limit := maxSystemScheduleAttempts
@@ -103,14 +103,14 @@ func (s *SystemScheduler) Process(eval *structs.Evaluation) (err error) {
 	if err := retryMax(limit, s.process, progress); err != nil {
 		if statusErr, ok := err.(*SetStatusError); ok {
 			return setStatus(s.logger, s.planner, s.eval, s.nextEval, nil, s.failedTGAllocs, statusErr.EvalStatus, err.Error(),
-				s.queuedAllocs, "")
+				s.queuedAllocs, "", s.ctx.Tracer().Trace())
 		}
 		return err
 	}
 
 	// Update the status to complete
 	return setStatus(s.logger, s.planner, s.eval, s.nextEval, nil, s.failedTGAllocs, structs.EvalStatusComplete, "",
-		s.queuedAllocs, "")
+		s.queuedAllocs, "", s.ctx.Tracer().Trace())
 }
 
 // process is wrapped in retryMax to iteratively run the handler until we have no
@@ -146,6 +146,10 @@ func (s *SystemScheduler) process() (bool, error) {
 
 	// Create an evaluation context
 	s.ctx = NewEvalContext(s.eventsCh, s.state, s.plan, s.logger)
+	s.ctx.SetFeasibilityCache(s.planner.FeasibilityCache())
+	if evalTracingEnabled(s.state) {
+		s.ctx.EnableTracing()
+	}
 
 	// Construct the placement stack
 	s.stack = NewSystemStack(s.sysbatch, s.ctx)
@@ -338,6 +342,7 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 		s.stack.SetNodes(nodes)
 
 		// Attempt to match the task group
+		s.ctx.Tracer().SetTaskGroup(tgName)
 		option := s.stack.Select(missing.TaskGroup, &SelectOptions{AllocName: missing.Name})
 
 		if option == nil {
@@ -398,6 +403,7 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 
 			// Actual failure to start this task on this candidate node, report it individually
 			s.failedTGAllocs[tgName] = s.ctx.Metrics()
+			s.ctx.Tracer().PlacementFailed(s.ctx.Metrics())
 			s.addBlocked(node)
 
 			continue
@@ -444,6 +450,7 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 				DiskMB:   missing.TaskGroup.EphemeralDisk.SizeMB,
 				Networks: resources.Shared.Networks,
 			},
+			HostVolumeSources: hostVolumeSources(missing.TaskGroup, option.Node),
 		}
 
 		// If the new allocation is replacing an older allocation then we record the
@@ -460,16 +467,13 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 
 				preemptedAllocIDs = append(preemptedAllocIDs, stop.ID)
 				if s.eval.AnnotatePlan && s.plan.Annotations != nil {
-					s.plan.Annotations.PreemptedAllocs = append(s.plan.Annotations.PreemptedAllocs, stop.Stub(nil))
-					if s.plan.Annotations.DesiredTGUpdates != nil {
-						desired := s.plan.Annotations.DesiredTGUpdates[tgName]
-						desired.Preemptions += 1
-					}
+					s.plan.Annotations.AddPreemptedAlloc(stop, tgName)
 				}
 			}
 			alloc.PreemptedAllocations = preemptedAllocIDs
 		}
 
+		s.ctx.Tracer().Placed(option)
 		s.plan.AppendAlloc(alloc, nil)
 	}
 
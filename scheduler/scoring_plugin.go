@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+var (
+	// scoringPlugins is the registry of scoring plugins compiled into the
+	// binary, keyed by plugin name.
+	scoringPlugins     = make(map[string]NodeScorer)
+	scoringPluginsLock sync.RWMutex
+)
+
+// builtinScorerNames are the names the built-in scorers record their scores
+// under in the allocation metrics. Scoring plugins can't reuse them.
+var builtinScorerNames = map[string]struct{}{
+	"allocation-spread":       {},
+	"binpack":                 {},
+	"devices":                 {},
+	"external":                {},
+	"job-anti-affinity":       {},
+	"node-affinity":           {},
+	"node-reschedule-penalty": {},
+	"preemption":              {},
+	structs.NormScorerName:    {},
+}
+
+// RegisterScoringPlugin makes a scoring plugin available under the given name.
+// It is intended to be called from the init function of the package
+// implementing the plugin, which is compiled into a custom Nomad build.
+// Registered plugins only contribute to the ranking of nodes once enabled in
+// the server's configuration.
+//
+// RegisterScoringPlugin panics if the plugin is nil or the name is empty,
+// reserved by a built-in scorer or already registered.
+func RegisterScoringPlugin(name string, plugin NodeScorer) {
+	scoringPluginsLock.Lock()
+	defer scoringPluginsLock.Unlock()
+
+	if plugin == nil {
+		panic("scheduler: scoring plugin is nil")
+	}
+	if name == "" {
+		panic("scheduler: scoring plugin name is empty")
+	}
+	if _, ok := builtinScorerNames[name]; ok {
+		panic(fmt.Sprintf("scheduler: scoring plugin name %q is reserved", name))
+	}
+	if _, ok := scoringPlugins[name]; ok {
+		panic(fmt.Sprintf("scheduler: scoring plugin %q registered twice", name))
+	}
+	scoringPlugins[name] = plugin
+}
+
+// LookupScoringPlugin returns the scoring plugin registered under the given
+// name.
+func LookupScoringPlugin(name string) (NodeScorer, bool) {
+	scoringPluginsLock.RLock()
+	defer scoringPluginsLock.RUnlock()
+
+	plugin, ok := scoringPlugins[name]
+	return plugin, ok
+}
+
+// ScoringPluginNames returns the sorted names of the registered scoring
+// plugins.
+func ScoringPluginNames() []string {
+	scoringPluginsLock.RLock()
+	defer scoringPluginsLock.RUnlock()
+
+	names := make([]string, 0, len(scoringPlugins))
+	for name := range scoringPlugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WeightedScoringPlugin is a registered scoring plugin enabled on the server.
+type WeightedScoringPlugin struct {
+	// Name is the name the plugin was registered under. Scores of the plugin
+	// are recorded under it in the allocation metrics.
+	Name string
+
+	// Weight is the weight of the plugin's score relative to each of the
+	// built-in scores when computing the final score of a node.
+	Weight float64
+
+	Plugin NodeScorer
+}
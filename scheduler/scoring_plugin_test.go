@@ -0,0 +1,36 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterScoringPlugin(t *testing.T) {
+	ci.Parallel(t)
+
+	plugin := testNodeScorer{}
+	RegisterScoringPlugin("test-register", plugin)
+
+	got, ok := LookupScoringPlugin("test-register")
+	require.True(t, ok)
+	require.Equal(t, plugin, got)
+	require.Contains(t, ScoringPluginNames(), "test-register")
+
+	_, ok = LookupScoringPlugin("test-unknown")
+	require.False(t, ok)
+
+	require.PanicsWithValue(t, `scheduler: scoring plugin "test-register" registered twice`, func() {
+		RegisterScoringPlugin("test-register", plugin)
+	})
+	require.PanicsWithValue(t, `scheduler: scoring plugin name "binpack" is reserved`, func() {
+		RegisterScoringPlugin("binpack", plugin)
+	})
+	require.PanicsWithValue(t, "scheduler: scoring plugin name is empty", func() {
+		RegisterScoringPlugin("", plugin)
+	})
+	require.PanicsWithValue(t, "scheduler: scoring plugin is nil", func() {
+		RegisterScoringPlugin("test-nil", nil)
+	})
+}
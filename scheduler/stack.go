@@ -65,6 +65,8 @@ type GenericStack struct {
 	maxScore                   *MaxScoreIterator
 	nodeAffinity               *NodeAffinityIterator
 	spread                     *SpreadIterator
+	externalScore              *ExternalScoreIterator
+	scoringPlugins             *ScoringPluginIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
 
@@ -91,6 +93,18 @@ func (s *GenericStack) SetNodes(baseNodes []*structs.Node) {
 	s.limit.SetLimit(limit)
 }
 
+// SetNodeScorer sets the external scorer that contributes a score to the
+// ranking of nodes, which may be nil.
+func (s *GenericStack) SetNodeScorer(scorer NodeScorer) {
+	s.externalScore.SetScorer(scorer)
+}
+
+// SetScoringPlugins sets the weighted scoring plugins that contribute scores
+// to the ranking of nodes.
+func (s *GenericStack) SetScoringPlugins(plugins []*WeightedScoringPlugin) {
+	s.scoringPlugins.SetPlugins(plugins)
+}
+
 func (s *GenericStack) SetJob(job *structs.Job) {
 	if s.jobVersion != nil && *s.jobVersion == job.Version {
 		return
@@ -106,6 +120,8 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 	s.jobAntiAff.SetJob(job)
 	s.nodeAffinity.SetJob(job)
 	s.spread.SetJob(job)
+	s.externalScore.SetJob(job)
+	s.scoringPlugins.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
@@ -162,6 +178,8 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 	}
 	s.nodeAffinity.SetTaskGroup(tg)
 	s.spread.SetTaskGroup(tg)
+	s.externalScore.SetTaskGroup(tg)
+	s.scoringPlugins.SetTaskGroup(tg)
 
 	if s.nodeAffinity.hasAffinities() || s.spread.hasSpreads() {
 		// scoring spread across all nodes has quadratic behavior, so
@@ -424,8 +442,15 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Apply scores based on spread stanza
 	s.spread = NewSpreadIterator(ctx, s.nodeAffinity)
 
+	// Apply scores from the external node scorer, which is set later
+	s.externalScore = NewExternalScoreIterator(ctx, s.spread, nil)
+
+	// Apply scores from the scoring plugins enabled on the server, which are
+	// set later
+	s.scoringPlugins = NewScoringPluginIterator(ctx, s.externalScore, nil)
+
 	// Add the preemption options scoring iterator
-	preemptionScorer := NewPreemptionScoringIterator(ctx, s.spread)
+	preemptionScorer := NewPreemptionScoringIterator(ctx, s.scoringPlugins)
 
 	// Normalizes scores by averaging them across various scorers
 	s.scoreNorm = NewScoreNormalizationIterator(ctx, preemptionScorer)
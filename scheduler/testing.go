@@ -37,6 +37,18 @@ func (r *RejectPlan) ReblockEval(*structs.Evaluation) error {
 	return nil
 }
 
+func (r *RejectPlan) NodeScorer() NodeScorer {
+	return nil
+}
+
+func (r *RejectPlan) ScoringPlugins() []*WeightedScoringPlugin {
+	return nil
+}
+
+func (r *RejectPlan) FeasibilityCache() *FeasibilityCache {
+	return nil
+}
+
 // Harness is a lightweight testing harness for schedulers. It manages a state
 // store copy and provides the planner interface. It can be extended for various
 // testing uses or for invoking the scheduler without side effects.
@@ -47,6 +59,15 @@ type Harness struct {
 	Planner  Planner
 	planLock sync.Mutex
 
+	// Scorer is the external node scorer returned by NodeScorer
+	Scorer NodeScorer
+
+	// Plugins are the weighted scoring plugins returned by ScoringPlugins
+	Plugins []*WeightedScoringPlugin
+
+	// Cache is the feasibility cache returned by FeasibilityCache
+	Cache *FeasibilityCache
+
 	Plans        []*structs.Plan
 	Evals        []*structs.Evaluation
 	CreateEvals  []*structs.Evaluation
@@ -243,6 +264,18 @@ func (h *Harness) ReblockEval(eval *structs.Evaluation) error {
 	return nil
 }
 
+func (h *Harness) NodeScorer() NodeScorer {
+	return h.Scorer
+}
+
+func (h *Harness) ScoringPlugins() []*WeightedScoringPlugin {
+	return h.Plugins
+}
+
+func (h *Harness) FeasibilityCache() *FeasibilityCache {
+	return h.Cache
+}
+
 // NextIndex returns the next index
 func (h *Harness) NextIndex() uint64 {
 	h.nextIndexLock.Lock()
@@ -439,6 +439,12 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) bool {
 		return true
 	}
 
+	// Check the alternative sources of the host volumes, since the source
+	// chosen for an allocation is only chosen when it is placed
+	if volumeAlternativesUpdated(a, b) {
+		return true
+	}
+
 	// Check connect service(s) updated
 	if connectServiceUpdated(a.Services, b.Services) {
 		return true
@@ -462,6 +468,9 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) bool {
 		if !reflect.DeepEqual(at.Env, bt.Env) {
 			return true
 		}
+		if !reflect.DeepEqual(at.RuntimeEnv, bt.RuntimeEnv) {
+			return true
+		}
 		if !reflect.DeepEqual(at.Artifacts, bt.Artifacts) {
 			return true
 		}
@@ -493,6 +502,12 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) bool {
 			return true
 		} else if ar.MemoryMaxMB != br.MemoryMaxMB {
 			return true
+		} else if ar.DiskIOPS != br.DiskIOPS {
+			return true
+		} else if ar.DiskBandwidthMB != br.DiskBandwidthMB {
+			return true
+		} else if !ar.NUMA.Equals(br.NUMA) {
+			return true
 		} else if !ar.Devices.Equals(&br.Devices) {
 			return true
 		}
@@ -512,6 +527,45 @@ func consulNamespaceUpdated(tgA, tgB *structs.TaskGroup) bool {
 	return tgA.Consul.GetNamespace() != tgB.Consul.GetNamespace()
 }
 
+// volumeAlternativesUpdated returns true if the sources of a host volume with
+// alternative sources have changed, since the source used by an allocation is
+// chosen when it is placed.
+func volumeAlternativesUpdated(tgA, tgB *structs.TaskGroup) bool {
+	for name, volA := range tgA.Volumes {
+		volB, ok := tgB.Volumes[name]
+		if !ok || len(volA.AlternativeSources)+len(volB.AlternativeSources) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(volA.Sources(), volB.Sources()) {
+			return true
+		}
+	}
+	for name, volB := range tgB.Volumes {
+		if _, ok := tgA.Volumes[name]; !ok && len(volB.AlternativeSources) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hostVolumeSources returns the source chosen on the node for each host volume
+// of the task group with alternative sources, or nil if it has none.
+func hostVolumeSources(tg *structs.TaskGroup, node *structs.Node) map[string]string {
+	var sources map[string]string
+	for name, req := range tg.Volumes {
+		if req.Type != structs.VolumeTypeHost || len(req.AlternativeSources) == 0 {
+			continue
+		}
+		if source, ok := req.HostVolumeSource(node); ok {
+			if sources == nil {
+				sources = make(map[string]string)
+			}
+			sources[name] = source
+		}
+	}
+	return sources
+}
+
 // connectServiceUpdated returns true if any services with a connect stanza have
 // been changed in such a way that requires a destructive update.
 //
@@ -600,6 +654,10 @@ func networkUpdated(netA, netB []*structs.NetworkResource) bool {
 			return true
 		}
 
+		if an.Bandwidth != bn.Bandwidth {
+			return true
+		}
+
 		if an.Hostname != bn.Hostname {
 			return true
 		}
@@ -608,6 +666,10 @@ func networkUpdated(netA, netB []*structs.NetworkResource) bool {
 			return true
 		}
 
+		if !reflect.DeepEqual(an.Firewall, bn.Firewall) {
+			return true
+		}
+
 		aPorts, bPorts := networkPortMap(an), networkPortMap(bn)
 		if !reflect.DeepEqual(aPorts, bPorts) {
 			return true
@@ -622,20 +684,24 @@ func networkUpdated(netA, netB []*structs.NetworkResource) bool {
 func networkPortMap(n *structs.NetworkResource) structs.AllocatedPorts {
 	var m structs.AllocatedPorts
 	for _, p := range n.ReservedPorts {
-		m = append(m, structs.AllocatedPortMapping{
-			Label:  p.Label,
-			Value:  p.Value,
-			To:     p.To,
-			HostIP: p.HostNetwork,
-		})
+		for _, hostNetwork := range p.HostNetworkNames() {
+			m = append(m, structs.AllocatedPortMapping{
+				Label:  p.Label,
+				Value:  p.Value,
+				To:     p.To,
+				HostIP: hostNetwork,
+			})
+		}
 	}
 	for _, p := range n.DynamicPorts {
-		m = append(m, structs.AllocatedPortMapping{
-			Label:  p.Label,
-			Value:  -1,
-			To:     p.To,
-			HostIP: p.HostNetwork,
-		})
+		for _, hostNetwork := range p.HostNetworkNames() {
+			m = append(m, structs.AllocatedPortMapping{
+				Label:  p.Label,
+				Value:  -1,
+				To:     p.To,
+				HostIP: hostNetwork,
+			})
+		}
 	}
 	return m
 }
@@ -699,7 +765,7 @@ func spreadsUpdated(jobA, jobB *structs.Job, taskGroup string) bool {
 func setStatus(logger log.Logger, planner Planner,
 	eval, nextEval, spawnedBlocked *structs.Evaluation,
 	tgMetrics map[string]*structs.AllocMetric, status, desc string,
-	queuedAllocs map[string]int, deploymentID string) error {
+	queuedAllocs map[string]int, deploymentID string, trace *structs.EvalTrace) error {
 
 	logger.Debug("setting eval status", "status", status)
 	newEval := eval.Copy()
@@ -716,10 +782,20 @@ func setStatus(logger log.Logger, planner Planner,
 	if queuedAllocs != nil {
 		newEval.QueuedAllocations = queuedAllocs
 	}
+	if trace != nil {
+		newEval.Trace = trace
+	}
 
 	return planner.UpdateEval(newEval)
 }
 
+// evalTracingEnabled returns whether the scheduler configuration enables eval
+// tracing.
+func evalTracingEnabled(state State) bool {
+	_, schedConfig, _ := state.SchedulerConfig()
+	return schedConfig != nil && schedConfig.EvalTracingEnabled
+}
+
 // inplaceUpdate attempts to update allocations in-place where possible. It
 // returns the allocs that couldn't be done inplace and then those that could.
 func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
@@ -1137,3 +1213,51 @@ func genericAllocUpdateFn(ctx Context, stack Stack, evalID string) allocUpdateTy
 		return false, false, newAlloc
 	}
 }
+
+// JobDependenciesPending returns the IDs of the dependencies of the job that
+// aren't ready yet, if the job is held by its dependencies. Only the initial
+// placement of a job is held, so jobs that have allocations aren't held.
+func JobDependenciesPending(state State, namespace, jobID string) ([]string, error) {
+	ws := memdb.NewWatchSet()
+	job, err := state.JobByID(ws, namespace, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %q: %v", jobID, err)
+	}
+	if job.Stopped() || len(job.DependsOn) == 0 {
+		return nil, nil
+	}
+
+	allocs, err := state.AllocsByJob(ws, namespace, jobID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allocs for job %q: %v", jobID, err)
+	}
+	if len(allocs) != 0 {
+		return nil, nil
+	}
+
+	var pending []string
+	for _, depID := range job.DependsOn {
+		dep, err := state.JobByID(ws, namespace, depID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job %q: %v", depID, err)
+		}
+		var latest *structs.Deployment
+		var depAllocs []*structs.Allocation
+		if dep != nil {
+			latest, err = state.LatestDeploymentByJobID(ws, namespace, depID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get job deployment %q: %v", depID, err)
+			}
+			if dep.Status == structs.JobStatusDead {
+				depAllocs, err = state.AllocsByJob(ws, namespace, depID, true)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get allocs for job %q: %v", depID, err)
+				}
+			}
+		}
+		if !dep.ReadyAsDependency(latest, depAllocs) {
+			pending = append(pending, depID)
+		}
+	}
+	return pending, nil
+}
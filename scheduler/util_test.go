@@ -811,6 +811,25 @@ func TestTasksUpdated(t *testing.T) {
 	// Compare changed Template wait configs
 	j23.TaskGroups[0].Tasks[0].Templates[0].Wait.Max = helper.TimeToPtr(10 * time.Second)
 	require.True(t, tasksUpdated(j22, j23, name))
+
+	// Change NUMA affinity
+	j24 := mock.Job()
+	j24.TaskGroups[0].Tasks[0].Resources.NUMA = &structs.NUMA{Affinity: structs.NUMAAffinityPrefer}
+	require.True(t, tasksUpdated(j1, j24, name))
+	j25 := mock.Job()
+	j25.TaskGroups[0].Tasks[0].Resources.NUMA = &structs.NUMA{Affinity: structs.NUMAAffinityPrefer}
+	require.False(t, tasksUpdated(j24, j25, name))
+
+	// Change the alternative sources of a host volume
+	j26 := mock.Job()
+	j26.TaskGroups[0].Volumes = map[string]*structs.VolumeRequest{
+		"data": {Type: structs.VolumeTypeHost, Source: "fast-ssd", AlternativeSources: []string{"bulk"}},
+	}
+	require.True(t, tasksUpdated(j1, j26, name))
+	j27 := j26.Copy()
+	require.False(t, tasksUpdated(j26, j27, name))
+	j27.TaskGroups[0].Volumes["data"].AlternativeSources = []string{"archive"}
+	require.True(t, tasksUpdated(j26, j27, name))
 }
 
 func TestTasksUpdated_connectServiceUpdated(t *testing.T) {
@@ -950,6 +969,16 @@ func TestNetworkUpdated(t *testing.T) {
 			},
 			updated: true,
 		},
+		{
+			name: "bandwidth updated",
+			a: []*structs.NetworkResource{
+				{Mode: "bridge", Bandwidth: 100},
+			},
+			b: []*structs.NetworkResource{
+				{Mode: "bridge", Bandwidth: 200},
+			},
+			updated: true,
+		},
 	}
 
 	for i := range cases {
@@ -1005,7 +1034,7 @@ func TestSetStatus(t *testing.T) {
 	eval := mock.Eval()
 	status := "a"
 	desc := "b"
-	require.NoError(t, setStatus(logger, h, eval, nil, nil, nil, status, desc, nil, ""))
+	require.NoError(t, setStatus(logger, h, eval, nil, nil, nil, status, desc, nil, "", nil))
 	require.Equal(t, 1, len(h.Evals), "setStatus() didn't update plan: %v", h.Evals)
 
 	newEval := h.Evals[0]
@@ -1015,7 +1044,7 @@ func TestSetStatus(t *testing.T) {
 	// Test next evals
 	h = NewHarness(t)
 	next := mock.Eval()
-	require.NoError(t, setStatus(logger, h, eval, next, nil, nil, status, desc, nil, ""))
+	require.NoError(t, setStatus(logger, h, eval, next, nil, nil, status, desc, nil, "", nil))
 	require.Equal(t, 1, len(h.Evals), "setStatus() didn't update plan: %v", h.Evals)
 
 	newEval = h.Evals[0]
@@ -1024,7 +1053,7 @@ func TestSetStatus(t *testing.T) {
 	// Test blocked evals
 	h = NewHarness(t)
 	blocked := mock.Eval()
-	require.NoError(t, setStatus(logger, h, eval, nil, blocked, nil, status, desc, nil, ""))
+	require.NoError(t, setStatus(logger, h, eval, nil, blocked, nil, status, desc, nil, "", nil))
 	require.Equal(t, 1, len(h.Evals), "setStatus() didn't update plan: %v", h.Evals)
 
 	newEval = h.Evals[0]
@@ -1033,7 +1062,7 @@ func TestSetStatus(t *testing.T) {
 	// Test metrics
 	h = NewHarness(t)
 	metrics := map[string]*structs.AllocMetric{"foo": nil}
-	require.NoError(t, setStatus(logger, h, eval, nil, nil, metrics, status, desc, nil, ""))
+	require.NoError(t, setStatus(logger, h, eval, nil, nil, metrics, status, desc, nil, "", nil))
 	require.Equal(t, 1, len(h.Evals), "setStatus() didn't update plan: %v", h.Evals)
 
 	newEval = h.Evals[0]
@@ -1044,7 +1073,7 @@ func TestSetStatus(t *testing.T) {
 	h = NewHarness(t)
 	queuedAllocs := map[string]int{"web": 1}
 
-	require.NoError(t, setStatus(logger, h, eval, nil, nil, metrics, status, desc, queuedAllocs, ""))
+	require.NoError(t, setStatus(logger, h, eval, nil, nil, metrics, status, desc, queuedAllocs, "", nil))
 	require.Equal(t, 1, len(h.Evals), "setStatus() didn't update plan: %v", h.Evals)
 
 	newEval = h.Evals[0]
@@ -1052,7 +1081,7 @@ func TestSetStatus(t *testing.T) {
 
 	h = NewHarness(t)
 	dID := uuid.Generate()
-	require.NoError(t, setStatus(logger, h, eval, nil, nil, metrics, status, desc, queuedAllocs, dID))
+	require.NoError(t, setStatus(logger, h, eval, nil, nil, metrics, status, desc, queuedAllocs, dID, nil))
 	require.Equal(t, 1, len(h.Evals), "setStatus() didn't update plan: %v", h.Evals)
 
 	newEval = h.Evals[0]
@@ -1632,3 +1661,53 @@ func TestUtil_connectSidecarServiceUpdated(t *testing.T) {
 		require.False(t, connectSidecarServiceUpdated(a, b))
 	})
 }
+
+func TestUtil_JobDependenciesPending(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+
+	// A batch dependency whose allocations completed is dead
+	batch := mock.BatchJob()
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, batch))
+	batchAlloc := mock.Alloc()
+	batchAlloc.Job = batch
+	batchAlloc.JobID = batch.ID
+	batchAlloc.DesiredStatus = structs.AllocDesiredStatusStop
+	batchAlloc.ClientStatus = structs.AllocClientStatusComplete
+	require.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{batchAlloc}))
+
+	// A running service dependency with an update strategy, whose current
+	// version has no deployment yet
+	service := mock.Job()
+	service.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1002, service))
+	serviceAlloc := mock.Alloc()
+	serviceAlloc.Job = service
+	serviceAlloc.JobID = service.ID
+	serviceAlloc.ClientStatus = structs.AllocClientStatusRunning
+	require.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1003, []*structs.Allocation{serviceAlloc}))
+
+	job := mock.BatchJob()
+	job.DependsOn = []string{batch.ID, service.ID}
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1004, job))
+
+	out, err := store.JobByID(nil, batch.Namespace, batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.JobStatusDead, out.Status)
+
+	pending, err := JobDependenciesPending(store, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{service.ID}, pending)
+
+	// The service dependency is ready once its deployment is successful
+	service, err = store.JobByID(nil, service.Namespace, service.ID)
+	require.NoError(t, err)
+	d := structs.NewDeployment(service, 50)
+	d.Status = structs.DeploymentStatusSuccessful
+	require.NoError(t, store.UpsertDeployment(1005, d))
+
+	pending, err = JobDependenciesPending(store, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
@@ -9,6 +9,18 @@ apt-get install -y software-properties-common
 # Add i386 architecture (for libraries)
 dpkg --add-architecture i386
 
+# Add ARM architectures (for libseccomp), whose packages are only served by
+# the Ubuntu ports archive
+if [ "$(dpkg --print-architecture)" = "amd64" ]; then
+	dpkg --add-architecture armhf
+	dpkg --add-architecture arm64
+	sed -i -e 's/^deb \(http\|mirror\)/deb [arch=amd64,i386] \1/' /etc/apt/sources.list
+	. /etc/os-release
+	for suite in "${VERSION_CODENAME}" "${VERSION_CODENAME}-updates" "${VERSION_CODENAME}-security"; do
+		echo "deb [arch=armhf,arm64] http://ports.ubuntu.com/ubuntu-ports ${suite} main universe"
+	done > /etc/apt/sources.list.d/ubuntu-ports.list
+fi
+
 # Update with i386, Go and Docker
 apt-get update
 
@@ -35,6 +47,17 @@ apt-get install -y \
 	gcc-arm-linux-gnueabihf \
 	gcc-multilib-arm-linux-gnueabihf
 
+# Install libseccomp, which release builds link to apply seccomp profiles to
+# exec tasks
+apt-get install -y \
+	libseccomp-dev \
+	libseccomp-dev:i386
+if [ "$(dpkg --print-architecture)" = "amd64" ]; then
+	apt-get install -y \
+		libseccomp-dev:armhf \
+		libseccomp-dev:arm64
+fi
+
 # Install Windows build utilities
 apt-get install -y \
 	binutils-mingw-w64 \
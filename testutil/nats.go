@@ -0,0 +1,131 @@
+package testutil
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	testing "github.com/mitchellh/go-testing-interface"
+)
+
+// TestNATSServer is a test helper. It implements the subset of the NATS
+// protocol a client needs to connect and publish messages, and records the
+// connections and messages it receives.
+type TestNATSServer struct {
+	ln  net.Listener
+	tls *tls.Config
+
+	l        sync.Mutex
+	connects []string
+	messages map[string][][]byte
+	denied   map[string]bool
+
+	Addr string
+}
+
+// NewTestNATSServer starts a NATS server listening on a random local port.
+// Connections are upgraded to TLS when tlsConf is set. Call Stop to shut the
+// server down.
+func NewTestNATSServer(t testing.T, tlsConf *tls.Config) *TestNATSServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start NATS server: %v", err)
+	}
+
+	s := &TestNATSServer{
+		ln:       ln,
+		tls:      tlsConf,
+		messages: make(map[string][][]byte),
+		denied:   make(map[string]bool),
+		Addr:     ln.Addr().String(),
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *TestNATSServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	if s.tls != nil {
+		fmt.Fprint(conn, "INFO {\"server_id\":\"test\",\"max_payload\":1048576,\"tls_required\":true}\r\n")
+		conn = tls.Server(conn, s.tls)
+	} else {
+		fmt.Fprint(conn, "INFO {\"server_id\":\"test\",\"max_payload\":1048576}\r\n")
+	}
+
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "CONNECT":
+			s.l.Lock()
+			s.connects = append(s.connects, strings.TrimSpace(strings.TrimPrefix(line, "CONNECT")))
+			s.l.Unlock()
+		case "PUB":
+			var size int
+			fmt.Sscanf(fields[len(fields)-1], "%d", &size)
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+
+			s.l.Lock()
+			denied := s.denied[fields[1]]
+			if !denied {
+				s.messages[fields[1]] = append(s.messages[fields[1]], payload[:size])
+			}
+			s.l.Unlock()
+
+			if denied {
+				fmt.Fprintf(conn, "-ERR 'Permissions Violation for Publish to \"%s\"'\r\n", fields[1])
+			}
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		}
+	}
+}
+
+// Deny rejects publishing to the subject with a permissions violation.
+func (s *TestNATSServer) Deny(subject string) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.denied[subject] = true
+}
+
+// Connects returns the options of the CONNECT messages sent by the clients.
+func (s *TestNATSServer) Connects() []string {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return append([]string(nil), s.connects...)
+}
+
+// Messages returns the payloads of the messages published to the subject.
+func (s *TestNATSServer) Messages(subject string) [][]byte {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return append([][]byte(nil), s.messages[subject]...)
+}
+
+// Stop stops the server from accepting new connections.
+func (s *TestNATSServer) Stop() {
+	s.ln.Close()
+}